@@ -0,0 +1,72 @@
+// Governing: SPEC-0001 REQ "CLI Entrypoint", REQ "Data Consistency Check", ADR-0004
+package main
+
+import (
+	"fmt"
+
+	"github.com/joestump/joe-links/internal/config"
+	"github.com/joestump/joe-links/internal/db"
+	"github.com/joestump/joe-links/internal/store"
+	"github.com/spf13/cobra"
+)
+
+// fsckMaxListed caps the problem lines printed per check.
+const fsckMaxListed = 20
+
+func newFsckCmd() *cobra.Command {
+	var repair bool
+	cmd := &cobra.Command{
+		Use:   "fsck",
+		Short: "Check link data for consistency problems",
+		Long: `Scan the database for orphaned link_owners, link_tags, and link_shares rows,
+links without a primary owner, duplicate tag slugs, and invalid visibility
+values. With --repair, fixable problems are corrected in a single transaction.
+Exits non-zero when problems remain.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.Load()
+			if err != nil {
+				return err
+			}
+
+			database, err := db.New(cfg.DB.Driver, cfg.DB.DSN)
+			if err != nil {
+				return err
+			}
+			defer func() { _ = database.Close() }()
+
+			report, err := store.NewFsck(database).Run(cmd.Context(), repair)
+			if err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			for _, c := range report.Checks {
+				status := "ok"
+				if len(c.Problems) > 0 {
+					status = fmt.Sprintf("%d found", len(c.Problems))
+					if repair {
+						status += fmt.Sprintf(", %d repaired", c.Repaired)
+					}
+				}
+				fmt.Fprintf(out, "%-28s %s\n", c.Name, status)
+				for i, p := range c.Problems {
+					if i == fsckMaxListed {
+						fmt.Fprintf(out, "    ... and %d more\n", len(c.Problems)-fsckMaxListed)
+						break
+					}
+					fmt.Fprintf(out, "    %s\n", p)
+				}
+			}
+
+			if n := report.Unrepaired(); n > 0 {
+				if !repair {
+					return fmt.Errorf("%d problem(s) found; re-run with --repair to fix", n)
+				}
+				return fmt.Errorf("%d problem(s) could not be repaired automatically", n)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&repair, "repair", false, "repair problems in a single transaction")
+	return cmd
+}
@@ -0,0 +1,58 @@
+// Governing: SPEC-0005 REQ "gRPC Links Service"
+package main
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/joestump/joe-links/internal/config"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// newGRPCServer builds the server for the gRPC LinksService. With a
+// certificate and key it serves TLS; with a client CA as well, clients may
+// present a certificate signed by it instead of an API token (mTLS).
+// Certificates stay optional so token-authenticated callers keep working.
+// Governing: SPEC-0005 REQ "gRPC Links Service"
+func newGRPCServer(cfg *config.Config) (*grpc.Server, error) {
+	if cfg.GRPC.TLSCert == "" {
+		log.Printf("gRPC LinksService listening without TLS on %s; API tokens travel in clear text, so keep it on a private network", cfg.GRPC.Addr)
+		return grpc.NewServer(grpc.Creds(insecure.NewCredentials())), nil
+	}
+	cert, err := tls.LoadX509KeyPair(cfg.GRPC.TLSCert, cfg.GRPC.TLSKey)
+	if err != nil {
+		return nil, fmt.Errorf("JOE_GRPC_TLS_CERT: %w", err)
+	}
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+	if cfg.GRPC.ClientCA != "" {
+		pem, err := os.ReadFile(cfg.GRPC.ClientCA)
+		if err != nil {
+			return nil, fmt.Errorf("JOE_GRPC_CLIENT_CA: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("JOE_GRPC_CLIENT_CA: no certificates found in %s", cfg.GRPC.ClientCA)
+		}
+		tlsConfig.ClientCAs = pool
+		tlsConfig.ClientAuth = tls.VerifyClientCertIfGiven
+	}
+	log.Printf("gRPC LinksService listening on %s (TLS, client certificates: %t)", cfg.GRPC.Addr, cfg.GRPC.ClientCA != "")
+	return grpc.NewServer(grpc.Creds(credentials.NewTLS(tlsConfig))), nil
+}
+
+// grpcRegistrar returns s as a grpc.ServiceRegistrar, or a nil interface
+// when s is nil so handler.Deps sees the service as disabled.
+func grpcRegistrar(s *grpc.Server) grpc.ServiceRegistrar {
+	if s == nil {
+		return nil
+	}
+	return s
+}
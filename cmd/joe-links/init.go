@@ -0,0 +1,137 @@
+// Governing: SPEC-0001 REQ "CLI Entrypoint", REQ "First-Run Setup", ADR-0004
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/joestump/joe-links/internal/auth"
+	"github.com/joestump/joe-links/internal/config"
+	"github.com/joestump/joe-links/internal/db"
+	"github.com/joestump/joe-links/internal/ids"
+	"github.com/joestump/joe-links/internal/setup"
+	"github.com/joestump/joe-links/internal/store"
+	"github.com/spf13/cobra"
+)
+
+func newInitCmd() *cobra.Command {
+	var (
+		adminEmail   string
+		keywords     []string
+		demoLinks    bool
+		skipIDPCheck bool
+	)
+	cmd := &cobra.Command{
+		Use:   "init",
+		Short: "Run first-run setup without the web wizard",
+		Long: `Apply the steps of the /setup wizard from the command line: migrate the
+database, choose the admin account, check that the identity provider is
+reachable, add keywords, and create demo links. Setup is then marked complete,
+so the wizard is not served. Every step is idempotent and may be re-run.
+
+Demo links are owned by the admin, so they can only be created after the
+admin has signed in once.`,
+		Example: `  joe-links init --admin-email admin@example.com \
+    --keyword 'jira=https://jira.example.com/browse/{slug}' --demo-links`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.Load()
+			if err != nil {
+				return err
+			}
+			// Governing: SPEC-0002 REQ "ID Generation Strategy"
+			if err := ids.SetStrategy(cfg.IDStrategy); err != nil {
+				return fmt.Errorf("JOE_ID_STRATEGY: %w", err)
+			}
+
+			database, err := db.New(cfg.DB.Driver, cfg.DB.DSN)
+			if err != nil {
+				return err
+			}
+			defer func() { _ = database.Close() }()
+
+			if err := db.Migrate(database, cfg.DB.Driver); err != nil {
+				return err
+			}
+
+			ctx := cmd.Context()
+			out := cmd.OutOrStdout()
+			users := store.NewUserStore(database)
+			svc := setup.New(store.NewSettingsStore(database), users, store.NewLinkStore(database, store.NewOwnershipStore(database), store.NewTagStore(database)), store.NewKeywordStore(database))
+
+			if adminEmail != "" {
+				if err := svc.SetAdminEmail(ctx, adminEmail); err != nil {
+					return err
+				}
+				if cfg.AdminEmail != "" && cfg.AdminEmail != adminEmail {
+					fmt.Fprintf(out, "warning: JOE_ADMIN_EMAIL (%s) takes precedence over --admin-email\n", cfg.AdminEmail)
+				}
+			}
+			admin := auth.EffectiveAdminEmail(ctx, cfg.AdminEmail, svc.AdminEmail)
+			hasAdmin, err := svc.HasAdmin(ctx)
+			if err != nil {
+				return err
+			}
+			switch {
+			case admin != "":
+				fmt.Fprintf(out, "%-20s %s\n", "admin", admin)
+			case hasAdmin:
+				fmt.Fprintf(out, "%-20s %s\n", "admin", "already signed in")
+			case len(cfg.AdminGroups) > 0:
+				fmt.Fprintf(out, "%-20s members of %s\n", "admin", strings.Join(cfg.AdminGroups, ", "))
+			default:
+				return errors.New("no admin account: pass --admin-email or set JOE_ADMIN_EMAIL or JOE_OIDC_ADMIN_GROUPS")
+			}
+
+			if !skipIDPCheck {
+				if err := setup.CheckIdentityProvider(ctx, cfg); err != nil {
+					return fmt.Errorf("identity provider check failed (use --skip-idp-check to continue anyway): %w", err)
+				}
+				fmt.Fprintf(out, "%-20s %s\n", "identity provider", "ok")
+			}
+
+			for _, kw := range keywords {
+				name, tmpl, ok := strings.Cut(kw, "=")
+				if !ok {
+					return fmt.Errorf("--keyword %q: want NAME=URL_TEMPLATE", kw)
+				}
+				created, err := svc.AddKeyword(ctx, name, tmpl, "")
+				if err != nil {
+					return fmt.Errorf("--keyword %q: %w", kw, err)
+				}
+				status := "added"
+				if !created {
+					status = "already exists"
+				}
+				fmt.Fprintf(out, "%-20s %s %s\n", "keyword", name, status)
+			}
+
+			if demoLinks {
+				owner, err := users.GetByEmail(ctx, admin)
+				switch {
+				case admin == "" || errors.Is(err, store.ErrNotFound):
+					fmt.Fprintf(out, "%-20s skipped: sign in as the admin once, then re-run with --demo-links\n", "demo links")
+				case err != nil:
+					return err
+				default:
+					n, err := svc.AddDemoLinks(ctx, owner.ID)
+					if err != nil {
+						return err
+					}
+					fmt.Fprintf(out, "%-20s %d created\n", "demo links", n)
+				}
+			}
+
+			if err := svc.Complete(ctx); err != nil {
+				return err
+			}
+			fmt.Fprintln(out, "setup complete")
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&adminEmail, "admin-email", "", "email of the account that becomes admin at sign-in")
+	cmd.Flags().StringArrayVar(&keywords, "keyword", nil, "keyword to add, as NAME=URL_TEMPLATE with a {slug} placeholder (repeatable)")
+	cmd.Flags().BoolVar(&demoLinks, "demo-links", false, "create demo links owned by the admin")
+	cmd.Flags().BoolVar(&skipIDPCheck, "skip-idp-check", false, "do not fetch the OIDC discovery document or SAML metadata")
+	return cmd
+}
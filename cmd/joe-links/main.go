@@ -19,6 +19,9 @@ func main() {
 
 	rootCmd.AddCommand(newServeCmd())
 	rootCmd.AddCommand(newMigrateCmd())
+	rootCmd.AddCommand(newFsckCmd())
+	rootCmd.AddCommand(newInitCmd())
+	rootCmd.AddCommand(newSecretsCmd())
 
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintln(os.Stderr, err)
@@ -1,7 +1,8 @@
-// Governing: SPEC-0001 REQ "CLI Entrypoint", ADR-0004
+// Governing: SPEC-0001 REQ "CLI Entrypoint", REQ "Migration Pre-Flight Check", ADR-0004
 package main
 
 import (
+	"fmt"
 	"log"
 
 	"github.com/joestump/joe-links/internal/config"
@@ -10,7 +11,9 @@ import (
 )
 
 func newMigrateCmd() *cobra.Command {
-	return &cobra.Command{
+	var opts db.MigrateOptions
+	var check bool
+	cmd := &cobra.Command{
 		Use:   "migrate",
 		Short: "Run database migrations",
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -25,7 +28,24 @@ func newMigrateCmd() *cobra.Command {
 			}
 			defer func() { _ = database.Close() }()
 
-			if err := db.Migrate(database, cfg.DB.Driver); err != nil {
+			if check {
+				report, err := db.Preflight(cmd.Context(), database, cfg.DB.Driver, opts)
+				if err != nil {
+					return err
+				}
+				out := cmd.OutOrStdout()
+				fmt.Fprintf(out, "current version: %d\n", report.Current)
+				fmt.Fprintf(out, "pending migrations: %d\n", len(report.Pending))
+				for _, p := range report.Pending {
+					fmt.Fprintf(out, "    %s\n", p)
+				}
+				for _, w := range report.Warnings {
+					fmt.Fprintf(out, "warning: %s\n", w)
+				}
+				return nil
+			}
+
+			if err := db.MigrateWithOptions(cmd.Context(), database, cfg.DB.Driver, opts); err != nil {
 				return err
 			}
 
@@ -33,4 +53,8 @@ func newMigrateCmd() *cobra.Command {
 			return nil
 		},
 	}
+	cmd.Flags().BoolVar(&check, "check", false, "run the pre-flight check only and report pending migrations")
+	cmd.Flags().BoolVar(&opts.Online, "online", false, "build indexes without blocking writes (CREATE INDEX CONCURRENTLY on PostgreSQL)")
+	cmd.Flags().Int64Var(&opts.LargeTableRows, "large-table-rows", db.DefaultLargeTableRows, "estimated row count above which locking statements are flagged")
+	return cmd
 }
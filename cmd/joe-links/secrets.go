@@ -0,0 +1,188 @@
+// Governing: SPEC-0001 REQ "CLI Entrypoint", REQ "Encrypted Secrets", ADR-0004
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/joestump/joe-links/internal/config"
+	"github.com/joestump/joe-links/internal/db"
+	"github.com/joestump/joe-links/internal/secrets"
+	"github.com/joestump/joe-links/internal/store"
+	"github.com/spf13/cobra"
+)
+
+// newSettingsStore returns a SettingsStore that encrypts secrets with
+// JOE_ENCRYPTION_KEY, when it is set.
+func newSettingsStore(cfg *config.Config, database *sqlx.DB) (*store.SettingsStore, error) {
+	ss := store.NewSettingsStore(database)
+	if cfg.EncryptionKey == "" {
+		return ss, nil
+	}
+	box, err := secrets.New(cfg.EncryptionKey)
+	if err != nil {
+		return nil, fmt.Errorf("JOE_ENCRYPTION_KEY: %w", err)
+	}
+	return ss.WithSecrets(box), nil
+}
+
+// loadStoredSecrets fills credentials left unset in the environment from the
+// encrypted copies in the settings table. Environment variables win.
+func loadStoredSecrets(ctx context.Context, cfg *config.Config, ss *store.SettingsStore) error {
+	for _, s := range []struct {
+		name string
+		dst  *string
+	}{
+		{store.SettingSMTPPassword, &cfg.Mail.Password},
+		{store.SettingLLMAPIKey, &cfg.LLM.APIKey},
+	} {
+		if *s.dst != "" {
+			continue
+		}
+		v, err := ss.GetSecret(ctx, s.name)
+		switch {
+		case errors.Is(err, store.ErrNotFound):
+			continue
+		case errors.Is(err, secrets.ErrNoKey):
+			return fmt.Errorf("setting %s is stored encrypted; set JOE_ENCRYPTION_KEY to use it", s.name)
+		case errors.Is(err, secrets.ErrInvalid):
+			return fmt.Errorf("setting %s cannot be decrypted with JOE_ENCRYPTION_KEY; restore the key it was stored with or store it again", s.name)
+		case err != nil:
+			return fmt.Errorf("setting %s: %w", s.name, err)
+		}
+		*s.dst = v
+	}
+	return nil
+}
+
+func newSecretsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "secrets",
+		Short: "Manage credentials stored encrypted in the database",
+		Long: `Store credentials in the settings table, encrypted with JOE_ENCRYPTION_KEY,
+instead of passing them to the server as environment variables. A stored
+credential is only used when its environment variable is unset.
+
+Settings:
+  ` + store.SettingSMTPPassword + `   SMTP password (JOE_MAIL_PASSWORD)
+  ` + store.SettingLLMAPIKey + `          LLM provider API key (JOE_LLM_API_KEY)`,
+	}
+	cmd.AddCommand(newSecretsSetCmd(), newSecretsUnsetCmd(), newSecretsListCmd())
+	return cmd
+}
+
+func newSecretsSetCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     "set NAME",
+		Short:   "Encrypt and store a credential read from standard input",
+		Example: `  printf %s "$SMTP_PASSWORD" | joe-links secrets set mail.smtp_password`,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := checkSecretName(args[0]); err != nil {
+				return err
+			}
+			value, err := io.ReadAll(io.LimitReader(cmd.InOrStdin(), 64<<10))
+			if err != nil {
+				return err
+			}
+			v := strings.TrimRight(string(value), "\r\n")
+			if v == "" {
+				return errors.New("no value on standard input")
+			}
+			return withSettingsStore(cmd, func(ss *store.SettingsStore) error {
+				if err := ss.SetSecret(cmd.Context(), args[0], v); err != nil {
+					if errors.Is(err, secrets.ErrNoKey) {
+						return errors.New("JOE_ENCRYPTION_KEY is required to store secrets")
+					}
+					return err
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "%s stored\n", args[0])
+				return nil
+			})
+		},
+	}
+}
+
+func newSecretsUnsetCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "unset NAME",
+		Short: "Remove a stored credential",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := checkSecretName(args[0]); err != nil {
+				return err
+			}
+			return withSettingsStore(cmd, func(ss *store.SettingsStore) error {
+				if err := ss.Delete(cmd.Context(), args[0]); err != nil {
+					return err
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "%s removed\n", args[0])
+				return nil
+			})
+		},
+	}
+}
+
+func newSecretsListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "Show which credentials are stored, without their values",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return withSettingsStore(cmd, func(ss *store.SettingsStore) error {
+				out := cmd.OutOrStdout()
+				for _, name := range store.SecretSettings {
+					status := "stored"
+					_, err := ss.GetSecret(cmd.Context(), name)
+					switch {
+					case errors.Is(err, store.ErrNotFound):
+						status = "unset"
+					case errors.Is(err, secrets.ErrNoKey):
+						status = "stored (JOE_ENCRYPTION_KEY not set)"
+					case errors.Is(err, secrets.ErrInvalid):
+						status = "stored (cannot be decrypted with JOE_ENCRYPTION_KEY)"
+					case err != nil:
+						return err
+					}
+					fmt.Fprintf(out, "%-20s %s\n", name, status)
+				}
+				return nil
+			})
+		},
+	}
+}
+
+// checkSecretName rejects names that are not in store.SecretSettings.
+func checkSecretName(name string) error {
+	if !slices.Contains(store.SecretSettings, name) {
+		return fmt.Errorf("unknown secret %q (want one of %s)", name, strings.Join(store.SecretSettings, ", "))
+	}
+	return nil
+}
+
+// withSettingsStore opens the configured database, migrated, and calls fn
+// with its settings store.
+func withSettingsStore(cmd *cobra.Command, fn func(*store.SettingsStore) error) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	database, err := db.New(cfg.DB.Driver, cfg.DB.DSN)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = database.Close() }()
+	if err := db.Migrate(database, cfg.DB.Driver); err != nil {
+		return err
+	}
+	ss, err := newSettingsStore(cfg, database)
+	if err != nil {
+		return err
+	}
+	return fn(ss)
+}
@@ -5,21 +5,41 @@ package main
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
+	"github.com/joestump/joe-links/internal/api"
 	"github.com/joestump/joe-links/internal/auth"
+	authsaml "github.com/joestump/joe-links/internal/auth/saml"
+	"github.com/joestump/joe-links/internal/botfilter"
+	"github.com/joestump/joe-links/internal/campaign"
 	"github.com/joestump/joe-links/internal/config"
 	"github.com/joestump/joe-links/internal/db"
+	"github.com/joestump/joe-links/internal/demo"
+	"github.com/joestump/joe-links/internal/events"
+	"github.com/joestump/joe-links/internal/geoip"
 	"github.com/joestump/joe-links/internal/handler"
+	"github.com/joestump/joe-links/internal/ids"
+	"github.com/joestump/joe-links/internal/linkhealth"
 	"github.com/joestump/joe-links/internal/llm"
+	"github.com/joestump/joe-links/internal/mailer"
 	"github.com/joestump/joe-links/internal/metrics"
+	"github.com/joestump/joe-links/internal/netpolicy"
+	"github.com/joestump/joe-links/internal/setup"
+	"github.com/joestump/joe-links/internal/shareurl"
+	"github.com/joestump/joe-links/internal/status"
 	"github.com/joestump/joe-links/internal/store"
+	"github.com/joestump/joe-links/internal/telemetry"
+	"github.com/joestump/joe-links/internal/tracing"
 	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
 )
 
 func newServeCmd() *cobra.Command {
@@ -31,8 +51,23 @@ func newServeCmd() *cobra.Command {
 			if err != nil {
 				return err
 			}
+			// Governing: SPEC-0002 REQ "ID Generation Strategy"
+			if err := ids.SetStrategy(cfg.IDStrategy); err != nil {
+				return fmt.Errorf("JOE_ID_STRATEGY: %w", err)
+			}
+			// Governing: SPEC-0002 REQ "UTM Parameters"
+			if err := store.ValidateUTMParams(cfg.Resolver.UTMDefaults); err != nil {
+				return fmt.Errorf("invalid JOE_RESOLVER_UTM_DEFAULTS: %w", err)
+			}
 
-			database, err := db.New(cfg.DB.Driver, cfg.DB.DSN)
+			// Governing: SPEC-0001 REQ "Database Pool and Read Replica"
+			poolOpts := db.Options{
+				MaxOpenConns:     cfg.DB.MaxOpenConns,
+				MaxIdleConns:     cfg.DB.MaxIdleConns,
+				ConnMaxLifetime:  cfg.DB.ConnMaxLifetime,
+				StatementTimeout: cfg.DB.StatementTimeout,
+			}
+			database, err := db.Open(cfg.DB.Driver, cfg.DB.DSN, poolOpts)
 			if err != nil {
 				return err
 			}
@@ -48,26 +83,208 @@ func newServeCmd() *cobra.Command {
 			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 			defer stop()
 
-			oidcProvider, err := auth.NewProvider(ctx, cfg)
+			// Governing: SPEC-0001 REQ "Distributed Tracing"
+			shutdownTracing, err := tracing.Setup(ctx, tracing.Config{
+				Endpoint:    cfg.Tracing.Endpoint,
+				Insecure:    cfg.Tracing.Insecure,
+				ServiceName: cfg.Tracing.ServiceName,
+				SampleRatio: cfg.Tracing.SampleRatio,
+			})
 			if err != nil {
 				return err
 			}
+			defer func() {
+				flushCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+				defer cancel()
+				if err := shutdownTracing(flushCtx); err != nil {
+					log.Printf("tracing shutdown: %v", err)
+				}
+			}()
+			if cfg.Tracing.Endpoint != "" {
+				log.Printf("OpenTelemetry tracing enabled (endpoint: %s, sample ratio: %g)", cfg.Tracing.Endpoint, cfg.Tracing.SampleRatio)
+			}
 
 			userStore := store.NewUserStore(database)
 			ownershipStore := store.NewOwnershipStore(database)
 			tagStore := store.NewTagStore(database)
 			linkStore := store.NewLinkStore(database, ownershipStore, tagStore)
+			if cfg.DB.ReplicaDSN != "" {
+				replica, err := db.Open(cfg.DB.Driver, cfg.DB.ReplicaDSN, poolOpts)
+				if err != nil {
+					return fmt.Errorf("open read replica: %w", err)
+				}
+				defer func() { _ = replica.Close() }()
+				linkStore.SetReplica(replica)
+				log.Printf("read replica enabled for slug lookups and public listings")
+			}
+			// Governing: SPEC-0004 REQ "Live Dashboard Updates"
+			broker := events.NewBroker()
+			linkStore.SetEvents(broker)
+			// Governing: SPEC-0011 REQ "Public Link Moderation"
+			if cfg.Moderation.Enabled {
+				linkStore.SetModeration(true)
+				log.Printf("public link moderation enabled")
+			}
 			tokenStore := auth.NewSQLTokenStore(database)
 			keywordStore := store.NewKeywordStore(database)
+			reservedSlugStore := store.NewReservedSlugStore(database)
+			// Governing: SPEC-0002 REQ "Reserved Slugs" — links from before a slug was reserved
+			if shadowed, err := reservedSlugStore.Shadowed(ctx); err != nil {
+				log.Printf("warning: checking for links shadowed by reserved slugs: %v", err)
+			} else if len(shadowed) > 0 {
+				log.Printf("warning: links or aliases %s use slugs now reserved by application routes and no longer resolve; rename them (listed under /admin/reserved-slugs)", strings.Join(shadowed, ", "))
+			}
+			domainRuleStore := store.NewDomainRuleStore(database)
+			teamStore := store.NewTeamStore(database)
+			savedSearchStore := store.NewSavedSearchStore(database)
+			preferenceStore := store.NewPreferenceStore(database)
+			policyStore := store.NewPolicyStore(database, linkStore)
+
+			// Governing: SPEC-0001 REQ "Multi-Tenancy"
+			var tenantStore *store.TenantStore
+			if cfg.Tenancy.Enabled {
+				tenantStore = store.NewTenantStore(database)
+				log.Printf("multi-tenancy enabled; links are namespaced by hostname")
+			}
+
+			// Governing: SPEC-0001 REQ "Demo Mode" — wipe and seed before anything reads the data
+			settingsStore, err := newSettingsStore(cfg, database)
+			if err != nil {
+				return err
+			}
+			var sandbox *demo.Sandbox
+			if cfg.Demo.Mode {
+				sandbox = demo.New(database, userStore, linkStore, keywordStore, teamStore, store.NewClickStore(database), settingsStore)
+				if err := sandbox.Reset(ctx); err != nil {
+					return fmt.Errorf("demo reset: %w", err)
+				}
+				go sandbox.Run(ctx, cfg.Demo.ResetInterval)
+				log.Printf("demo mode enabled; all data is reset every %s", cfg.Demo.ResetInterval)
+			}
+
+			// Governing: SPEC-0016 REQ "Campaign Sources"
+			campaigns, err := campaign.LoadSigner(ctx, settingsStore)
+			if err != nil {
+				return fmt.Errorf("campaign key: %w", err)
+			}
+
+			// Governing: SPEC-0010 REQ "Signed Share URLs"
+			shareURLs, err := shareurl.LoadSigner(ctx, settingsStore)
+			if err != nil {
+				return fmt.Errorf("share URL key: %w", err)
+			}
+
+			// Governing: SPEC-0010 REQ "Strict Visibility" — on unless the instance predates it
+			strictVisibility := true
+			if cfg.Visibility.Strict != nil {
+				strictVisibility = *cfg.Visibility.Strict
+			} else if _, err := settingsStore.Get(ctx, store.SettingVisibilityLenient); err == nil {
+				strictVisibility = false
+			} else if !errors.Is(err, store.ErrNotFound) {
+				return fmt.Errorf("visibility setting: %w", err)
+			}
+			if !strictVisibility {
+				log.Printf("strict visibility off: links with unknown visibility values resolve as public; set JOE_VISIBILITY_STRICT=true to deny them")
+			}
+
+			// Governing: SPEC-0001 REQ "Encrypted Secrets"
+			if err := loadStoredSecrets(ctx, cfg, settingsStore); err != nil {
+				return err
+			}
+
+			// Governing: SPEC-0001 REQ "First-Run Setup"
+			setupService := setup.New(settingsStore, userStore, linkStore, keywordStore)
+			setupPending, err := setupService.Pending(ctx)
+			if err != nil {
+				return err
+			}
+			var setupWizard *setup.Service
+			if setupPending {
+				setupWizard = setupService
+				log.Printf("first-run setup pending; open /setup to finish configuring this server")
+			}
+
+			// Governing: SPEC-0016 REQ "Bot Filtering"
+			var botFilter *botfilter.Detector
+			if cfg.Bots.Detect {
+				botFilter = botfilter.New(cfg.Bots.UserAgents...)
+				if cfg.Bots.IPList != "" {
+					if err := botFilter.LoadIPListFile(cfg.Bots.IPList); err != nil {
+						return fmt.Errorf("JOE_BOTS_IP_LIST: %w", err)
+					}
+				}
+			}
+
+			// Governing: SPEC-0016 REQ "GeoIP Country Breakdown"
+			var geo *geoip.Reader
+			if cfg.GeoIP.Database != "" {
+				geo, err = geoip.Open(cfg.GeoIP.Database)
+				if err != nil {
+					return fmt.Errorf("JOE_GEOIP_DATABASE: %w", err)
+				}
+				log.Printf("GeoIP country lookup enabled (%s)", cfg.GeoIP.Database)
+			}
 
 			// Governing: SPEC-0016 REQ "Click Recording", ADR-0016
 			clickCh := make(chan store.ClickEvent, 256)
 			clickStore := store.NewClickStore(database)
-			go runClickWriter(ctx, clickCh, clickStore)
+			clickStore.SetEvents(broker)
+			metrics.SetClickQueue(func() int { return len(clickCh) }, cap(clickCh))
+			clickWriterDone := make(chan struct{})
+			// Governing: SPEC-0016 REQ "Durable Click Spool"
+			if cfg.Clicks.SpoolPath != "" {
+				spool, err := store.OpenClickSpool(cfg.Clicks.SpoolPath)
+				if err != nil {
+					return err
+				}
+				log.Printf("click spool enabled (%s)", cfg.Clicks.SpoolPath)
+				go func() {
+					defer close(clickWriterDone)
+					runSpooledClickWriter(clickCh, spool, clickStore, geo, clickSpoolDrainInterval)
+				}()
+			} else {
+				go func() {
+					defer close(clickWriterDone)
+					runClickWriter(ctx, clickCh, clickStore, geo)
+				}()
+			}
 
 			// Governing: SPEC-0016 REQ "Prometheus Metrics Endpoint", ADR-0016
 			go runGaugeUpdater(ctx, linkStore, userStore)
 
+			// Governing: SPEC-0011 REQ "Link Lifecycle Policies"
+			go runPolicySweeper(ctx, policyStore)
+
+			// Governing: SPEC-0009 REQ "Slug Filter Fast Path"
+			if cfg.Resolver.SlugFilterRefresh > 0 {
+				if err := linkStore.EnableSlugFilter(ctx); err != nil {
+					return fmt.Errorf("build slug filter: %w", err)
+				}
+				go runSlugFilterRefresher(ctx, linkStore, cfg.Resolver.SlugFilterRefresh)
+			}
+
+			// Governing: SPEC-0011 REQ "Instance Telemetry"
+			telemetryStore := store.NewTelemetryStore(database)
+			telemetryEndpoint := ""
+			if cfg.Telemetry.Share {
+				telemetryEndpoint = cfg.Telemetry.Endpoint
+				go telemetry.NewReporter(telemetryStore, cfg.DB.Driver, telemetryEndpoint).Run(ctx)
+				log.Printf("telemetry sharing enabled (endpoint: %s)", telemetryEndpoint)
+			}
+
+			// Governing: SPEC-0001 REQ "Link Health Checks"
+			healthStore := store.NewHealthStore(database)
+			if cfg.Health.CheckInterval > 0 {
+				checker := linkhealth.NewChecker(linkStore, healthStore, cfg.Health.CheckInterval, cfg.Health.CheckTimeout)
+				go checker.Run(ctx)
+				log.Printf("link health checks enabled (every %s)", cfg.Health.CheckInterval)
+			}
+
+			// Governing: SPEC-0006 REQ "API Usage Tracking"
+			usageStore := store.NewUsageStore(database)
+			usageRecorder := api.NewUsageRecorder(usageStore)
+			go usageRecorder.Run(ctx, time.Minute)
+
 			// Governing: SPEC-0017 REQ "LLM Provider Configuration", ADR-0017
 			suggester, err := llm.New(cfg)
 			if err != nil {
@@ -77,23 +294,189 @@ func newServeCmd() *cobra.Command {
 				log.Printf("LLM suggestions enabled (provider: %s)", cfg.LLM.Provider)
 			}
 
-			authHandlers := auth.NewHandlers(oidcProvider, sessionManager, userStore, cfg.AdminEmail, cfg.AdminGroups, cfg.GroupsClaim, !cfg.InsecureCookies)
+			// Governing: SPEC-0016 REQ "Status Page"
+			jobs := []status.Job{
+				{Name: metrics.JobClickWriter, Interval: clickFlushInterval},
+				{Name: metrics.JobGaugeUpdater, Interval: gaugeUpdateInterval},
+				{Name: metrics.JobUsageFlush, Interval: time.Minute},
+				{Name: metrics.JobPolicySweep, Interval: policySweepInterval},
+			}
+			if cfg.Clicks.SpoolPath != "" {
+				jobs[0].Interval = clickSpoolDrainInterval
+			}
+			if cfg.Health.CheckInterval > 0 {
+				jobs = append(jobs, status.Job{Name: metrics.JobLinkHealth, Interval: cfg.Health.CheckInterval})
+			}
+			if cfg.Telemetry.Share {
+				jobs = append(jobs, status.Job{Name: metrics.JobTelemetry, Interval: telemetry.Interval})
+			}
+			if cfg.Demo.Mode {
+				jobs = append(jobs, status.Job{Name: metrics.JobDemoReset, Interval: cfg.Demo.ResetInterval})
+			}
+			statusChecker := status.NewChecker(database, jobs...)
+
+			// Governing: SPEC-0001 REQ "Email Notifications"
+			var notifier *mailer.Notifier
+			if cfg.Mail.SMTPHost != "" {
+				notifier = mailer.NewNotifier(mailer.NewSMTPSender(mailer.Config{
+					Host:     cfg.Mail.SMTPHost,
+					Port:     cfg.Mail.SMTPPort,
+					Username: cfg.Mail.Username,
+					Password: cfg.Mail.Password,
+					From:     cfg.Mail.From,
+				}), cfg.Mail.BaseURL)
+				defer notifier.Wait()
+				log.Printf("email notifications enabled (SMTP: %s:%d)", cfg.Mail.SMTPHost, cfg.Mail.SMTPPort)
+			}
+
 			authMiddleware := auth.NewMiddleware(sessionManager, userStore)
 
+			// Governing: SPEC-0001 REQ "OIDC-Only Authentication", REQ "SAML Authentication"
+			var (
+				authHandlers     *auth.Handlers
+				samlHandlers     *authsaml.Handlers
+				sessionRefresher *auth.SessionRefresher
+			)
+			switch {
+			case cfg.Demo.Mode:
+				// Governing: SPEC-0001 REQ "Demo Mode" — visitors are signed in automatically
+			case cfg.AuthProvider == "saml":
+				sp, err := authsaml.NewServiceProvider(ctx, cfg)
+				if err != nil {
+					return err
+				}
+				samlHandlers = authsaml.NewHandlers(sp, sessionManager, userStore, authsaml.Attributes{
+					Email:  cfg.SAML.EmailAttribute,
+					Name:   cfg.SAML.NameAttribute,
+					Groups: cfg.SAML.GroupsAttribute,
+				}, cfg.AdminEmail, cfg.AdminGroups, !cfg.InsecureCookies)
+				samlHandlers.SetSetupAdmin(setupService.AdminEmail)
+				log.Printf("SAML authentication enabled (SP entity ID: %s)", sp.EntityID)
+			default:
+				oidcProvider, err := auth.NewProvider(ctx, cfg)
+				if err != nil {
+					return err
+				}
+				authHandlers = auth.NewHandlers(oidcProvider, sessionManager, userStore, cfg.AdminEmail, cfg.AdminGroups, cfg.GroupsClaim, !cfg.InsecureCookies)
+				authHandlers.SetSetupAdmin(setupService.AdminEmail)
+				authHandlers.SetVerifiedEmailLinking(cfg.OIDC.LinkVerifiedEmail)
+
+				// Governing: SPEC-0001 REQ "Refresh-Token Session Extension"
+				if cfg.SessionRefreshTokens {
+					sessionRefresher, err = auth.NewSessionRefresher(oidcProvider, sessionManager, cfg.SessionEncryptionKey, cfg.SessionLifetime, cfg.SessionMaxLifetime)
+					if err != nil {
+						return err
+					}
+					authHandlers.SetSessionRefresher(sessionRefresher)
+					log.Printf("refresh-token sessions enabled (max lifetime: %s)", cfg.SessionMaxLifetime)
+				}
+			}
+
+			// Governing: SPEC-0001 REQ "WebAuthn Step-Up"
+			var (
+				webAuthn      *auth.WebAuthn
+				webAuthnStore *store.WebAuthnStore
+			)
+			if cfg.WebAuthn.StepUp {
+				webAuthnStore = store.NewWebAuthnStore(database)
+				webAuthn = auth.NewWebAuthn(sessionManager, webAuthnStore, cfg.WebAuthn.RPID, cfg.WebAuthn.Origin)
+				log.Printf("passkey step-up enabled for destructive admin actions")
+			}
+
+			// Governing: SPEC-0001 REQ "Admin Network Allowlist"
+			adminNetworks, err := netpolicy.Parse(cfg.Admin.Networks)
+			if err != nil {
+				return fmt.Errorf("JOE_ADMIN_NETWORKS: %w", err)
+			}
+			adminAPINetworks, err := netpolicy.Parse(cfg.Admin.APINetworks)
+			if err != nil {
+				return fmt.Errorf("JOE_ADMIN_API_NETWORKS: %w", err)
+			}
+			if adminNetworks != nil || adminAPINetworks != nil {
+				log.Printf("admin routes restricted to %v (API: %v)", cfg.Admin.Networks, cfg.Admin.APINetworks)
+			}
+			trustedProxies, err := netpolicy.Parse(cfg.Proxy.Trusted)
+			if err != nil {
+				return fmt.Errorf("JOE_TRUSTED_PROXIES: %w", err)
+			}
+
+			// Governing: SPEC-0001 REQ "Security Headers"
+			securityHeaders := handler.SecurityHeaders{
+				CSPMode:               cfg.Security.CSPMode,
+				CSPPolicy:             cfg.Security.CSPPolicy,
+				HSTSMaxAge:            cfg.Security.HSTSMaxAge,
+				HSTSIncludeSubdomains: cfg.Security.HSTSIncludeSubdomains,
+			}
+
+			// Governing: SPEC-0006 REQ "Token Brute-Force Lockout"
+			var tokenLockout *auth.TokenLockout
+			if cfg.APILockout.Failures > 0 {
+				tokenLockout = auth.NewTokenLockout(cfg.APILockout.Failures, cfg.APILockout.Window, cfg.APILockout.Duration)
+			}
+
+			// Governing: SPEC-0005 REQ "gRPC Links Service"
+			var grpcServer *grpc.Server
+			if cfg.GRPC.Addr != "" {
+				if grpcServer, err = newGRPCServer(cfg); err != nil {
+					return err
+				}
+			}
+
 			router := handler.NewRouter(handler.Deps{
-				SessionManager: sessionManager,
-				AuthHandlers:   authHandlers,
-				AuthMiddleware: authMiddleware,
-				LinkStore:      linkStore,
-				OwnershipStore: ownershipStore,
-				TagStore:       tagStore,
-				UserStore:      userStore,
-				TokenStore:     tokenStore,
-				KeywordStore:   keywordStore,
-				ClickStore:     clickStore,
-				ClickCh:        clickCh,
-				Suggester:      suggester,
-				ShortKeyword:   cfg.ShortKeyword,
+				SessionManager:    sessionManager,
+				SessionRefresher:  sessionRefresher,
+				SessionTracker:    auth.NewSessionTracker(sessionManager, store.NewSessionStore(database)),
+				WebAuthn:          webAuthn,
+				WebAuthnStore:     webAuthnStore,
+				AdminNetworks:     adminNetworks,
+				AdminAPINetworks:  adminAPINetworks,
+				TrustedProxies:    trustedProxies,
+				SecurityHeaders:   securityHeaders,
+				TokenLockout:      tokenLockout,
+				AuthHandlers:      authHandlers,
+				SAMLHandlers:      samlHandlers,
+				AuthMiddleware:    authMiddleware,
+				LinkStore:         linkStore,
+				OwnershipStore:    ownershipStore,
+				TagStore:          tagStore,
+				UserStore:         userStore,
+				TokenStore:        tokenStore,
+				KeywordStore:      keywordStore,
+				ReservedSlugStore: reservedSlugStore,
+				DomainRuleStore:   domainRuleStore,
+				TeamStore:         teamStore,
+				SavedSearchStore:  savedSearchStore,
+				PreferenceStore:   preferenceStore,
+				PolicyStore:       policyStore,
+				ModerationEnabled: cfg.Moderation.Enabled,
+				TelemetryStore:    telemetryStore,
+				DBDriver:          cfg.DB.Driver,
+				TelemetryEndpoint: telemetryEndpoint,
+				ClickStore:        clickStore,
+				HealthStore:       healthStore,
+				ClickCh:           clickCh,
+				AnalyticsMode:     cfg.Analytics.Mode,
+				Campaigns:         campaigns,
+				ShareURLs:         shareURLs,
+				StrictVisibility:  strictVisibility,
+				GRPCServer:        grpcRegistrar(grpcServer),
+				RobotsIndexSlugs:  cfg.Robots.IndexSlugs,
+				Events:            broker,
+				UsageStore:        usageStore,
+				UsageRecorder:     usageRecorder,
+				Suggester:         suggester,
+				ShortKeyword:      cfg.ShortKeyword,
+				ResolverDebug:     cfg.Resolver.Debug,
+				UTMDefaults:       cfg.Resolver.UTMDefaults,
+				BotFilter:         botFilter,
+				Setup:             setupWizard,
+				Demo:              sandbox,
+				TenantStore:       tenantStore,
+				IdempotencyStore:  store.NewIdempotencyStore(database),
+				SettingsStore:     settingsStore,
+				AdminEmail:        cfg.AdminEmail,
+				StatusChecker:     statusChecker,
+				Notifier:          notifier,
 			})
 
 			srv := &http.Server{
@@ -101,10 +484,25 @@ func newServeCmd() *cobra.Command {
 				Handler: router,
 			}
 
+			if grpcServer != nil {
+				lis, err := net.Listen("tcp", cfg.GRPC.Addr)
+				if err != nil {
+					return fmt.Errorf("JOE_GRPC_ADDR: %w", err)
+				}
+				go func() {
+					if err := grpcServer.Serve(lis); err != nil {
+						log.Printf("gRPC server: %v", err)
+					}
+				}()
+			}
+
 			go func() {
 				<-ctx.Done()
 				shutdownCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 				defer cancel()
+				if grpcServer != nil {
+					grpcServer.GracefulStop()
+				}
 				close(clickCh) // signal writer to drain
 				_ = srv.Shutdown(shutdownCtx)
 			}()
@@ -113,38 +511,151 @@ func newServeCmd() *cobra.Command {
 			if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
 				return err
 			}
+			// Let the click writer finish draining before the process exits.
+			select {
+			case <-clickWriterDone:
+			case <-time.After(30 * time.Second):
+				log.Printf("click writer did not finish draining in time")
+			}
 			return nil
 		},
 	}
 }
 
-// runClickWriter reads click events from the channel and persists them.
-// It drains all remaining events when the channel is closed, then returns.
-// Governing: SPEC-0016 REQ "Click Recording", ADR-0016
-func runClickWriter(_ context.Context, ch <-chan store.ClickEvent, cs *store.ClickStore) {
-	for e := range ch {
-		if err := cs.RecordClick(context.Background(), e); err != nil {
-			log.Printf("click write error: %v", err)
-			metrics.ClicksRecordErrorsTotal.Inc()
-		} else {
-			metrics.ClicksRecordedTotal.Inc()
+// clickFlushInterval bounds how long a click waits in a partial batch.
+const clickFlushInterval = 500 * time.Millisecond
+
+// runClickWriter reads click events from the channel and persists them in
+// batches of up to store.ClickBatchSize, flushing at least every
+// clickFlushInterval. It drains all remaining events when the channel is
+// closed, then returns.
+// Governing: SPEC-0016 REQ "Click Recording", REQ "Batched Click Inserts", ADR-0016
+func runClickWriter(_ context.Context, ch <-chan store.ClickEvent, cs *store.ClickStore, geo *geoip.Reader) {
+	batch := make([]store.ClickEvent, 0, store.ClickBatchSize)
+	flush := func() {
+		if len(batch) == 0 {
+			metrics.MarkJobSuccess(metrics.JobClickWriter)
+			return
+		}
+		if _, err := recordClicks(context.Background(), cs, batch); err == nil {
+			metrics.MarkJobSuccess(metrics.JobClickWriter)
+		}
+		batch = batch[:0]
+	}
+	ticker := time.NewTicker(clickFlushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case e, ok := <-ch:
+			if !ok {
+				flush()
+				return
+			}
+			batch = append(batch, geolocate(geo, e))
+			if len(batch) == store.ClickBatchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		}
+	}
+}
+
+// recordClicks writes a batch of click events and updates the click metrics.
+func recordClicks(ctx context.Context, cs *store.ClickStore, batch []store.ClickEvent) (int, error) {
+	n, err := cs.RecordClicks(ctx, batch)
+	metrics.ClicksRecordedTotal.Add(float64(n))
+	if err != nil {
+		log.Printf("click write error: %v", err)
+		metrics.ClicksRecordErrorsTotal.Add(float64(len(batch) - n))
+	}
+	return n, err
+}
+
+// geolocate resolves e's country from its raw IP, which it then drops so the
+// IP is neither spooled nor stored.
+// Governing: SPEC-0016 REQ "GeoIP Country Breakdown"
+func geolocate(geo *geoip.Reader, e store.ClickEvent) store.ClickEvent {
+	if e.Country == "" {
+		e.Country = geo.Country(e.IP)
+	}
+	e.IP = ""
+	return e
+}
+
+// clickSpoolDrainInterval is how often spooled clicks are written to the database.
+const clickSpoolDrainInterval = 2 * time.Second
+
+// runSpooledClickWriter appends click events from the channel to the spool
+// and periodically drains the spool into the database. Events that cannot be
+// written (database down, shutdown) stay in the spool and are delivered after
+// a restart. It returns once the channel is closed and a final drain ran.
+// Governing: SPEC-0016 REQ "Durable Click Spool", ADR-0016
+func runSpooledClickWriter(ch <-chan store.ClickEvent, spool *store.ClickSpool, cs *store.ClickStore, geo *geoip.Reader, interval time.Duration) {
+	record := func(ctx context.Context, batch []store.ClickEvent) (int, error) {
+		return recordClicks(ctx, cs, batch)
+	}
+	drain := func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if _, err := spool.Drain(ctx, record); err != nil {
+			log.Printf("click spool drain: %v", err)
+			return
+		}
+		metrics.MarkJobSuccess(metrics.JobClickWriter)
+	}
+	defer func() {
+		if err := spool.Close(); err != nil {
+			log.Printf("click spool close: %v", err)
+		}
+	}()
+
+	drain() // deliver clicks spooled by a previous run
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case e, ok := <-ch:
+			if !ok {
+				drain()
+				return
+			}
+			// Batch whatever else is already queued into one fsync.
+			batch := []store.ClickEvent{geolocate(geo, e)}
+			for len(batch) < cap(ch) && len(ch) > 0 {
+				batch = append(batch, geolocate(geo, <-ch))
+			}
+			if err := spool.Append(batch...); err != nil {
+				log.Printf("click spool append: %v", err)
+				_, _ = record(context.Background(), batch)
+			}
+		case <-ticker.C:
+			drain()
 		}
 	}
 }
 
+// gaugeUpdateInterval is how often runGaugeUpdater refreshes the gauges.
+const gaugeUpdateInterval = 60 * time.Second
+
 // runGaugeUpdater periodically updates the links_total and users_total gauges.
 // Governing: SPEC-0016 REQ "Prometheus Metrics Endpoint", ADR-0016
 func runGaugeUpdater(ctx context.Context, ls *store.LinkStore, us *store.UserStore) {
 	update := func() {
-		if n, err := ls.CountAll(ctx); err == nil {
-			metrics.LinksTotal.Set(float64(n))
+		links, err := ls.CountAll(ctx)
+		if err != nil {
+			return
 		}
-		if n, err := us.CountAll(ctx); err == nil {
-			metrics.UsersTotal.Set(float64(n))
+		users, err := us.CountAll(ctx)
+		if err != nil {
+			return
 		}
+		metrics.LinksTotal.Set(float64(links))
+		metrics.UsersTotal.Set(float64(users))
+		metrics.MarkJobSuccess(metrics.JobGaugeUpdater)
 	}
 	update() // initial population
-	ticker := time.NewTicker(60 * time.Second)
+	ticker := time.NewTicker(gaugeUpdateInterval)
 	defer ticker.Stop()
 	for {
 		select {
@@ -155,3 +666,55 @@ func runGaugeUpdater(ctx context.Context, ls *store.LinkStore, us *store.UserSto
 		}
 	}
 }
+
+// runSlugFilterRefresher rebuilds the slug filter every interval, dropping
+// deleted slugs and picking up links created by other instances.
+// Governing: SPEC-0009 REQ "Slug Filter Fast Path"
+func runSlugFilterRefresher(ctx context.Context, ls *store.LinkStore, interval time.Duration) {
+	metrics.MarkJobSuccess(metrics.JobSlugFilter)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := ls.RefreshSlugFilter(ctx); err != nil {
+				log.Printf("slug filter refresh: %v", err)
+				continue
+			}
+			metrics.MarkJobSuccess(metrics.JobSlugFilter)
+		}
+	}
+}
+
+// policySweepInterval is how often runPolicySweeper re-checks every link.
+const policySweepInterval = 24 * time.Hour
+
+// runPolicySweeper evaluates every link against the link policies at startup
+// and then nightly, recording violations and expiring links.
+// Governing: SPEC-0011 REQ "Link Lifecycle Policies"
+func runPolicySweeper(ctx context.Context, ps *store.PolicyStore) {
+	sweep := func() {
+		result, err := ps.Sweep(ctx, time.Now())
+		if err != nil {
+			log.Printf("policy sweep: %v", err)
+			return
+		}
+		if result.Violations > 0 || result.Expired > 0 {
+			log.Printf("policy sweep: %d links checked, %d violations, %d expired", result.Checked, result.Violations, result.Expired)
+		}
+		metrics.MarkJobSuccess(metrics.JobPolicySweep)
+	}
+	sweep()
+	ticker := time.NewTicker(policySweepInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			sweep()
+		}
+	}
+}
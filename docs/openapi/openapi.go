@@ -0,0 +1,14 @@
+// Governing: SPEC-0007 REQ "OpenAPI 3.1 Document"
+//
+// Package openapi embeds the OpenAPI 3.1 document generated from the swag
+// annotations. Regenerate it with "make swagger".
+package openapi
+
+import _ "embed"
+
+//go:generate go run ../../internal/openapi/gen -in ../swagger/swagger.json -out openapi.json
+
+// Spec is the OpenAPI 3.1 JSON document for the REST API.
+//
+//go:embed openapi.json
+var Spec []byte
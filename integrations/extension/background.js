@@ -163,6 +163,66 @@ async function refreshKeywords() {
   }
 }
 
+// 32-bit FNV-1a and FNV-1 of a string's UTF-8 bytes, matching the server's
+// slug Bloom filter (internal/bloom).
+// Governing: SPEC-0008 REQ "Offline Slug Lookup"
+function slugHashes(key) {
+  let h1 = 0x811c9dc5;
+  let h2 = 0x811c9dc5;
+  for (const b of new TextEncoder().encode(key)) {
+    h1 = Math.imul(h1 ^ b, 16777619) >>> 0;
+    h2 = (Math.imul(h2, 16777619) ^ b) >>> 0;
+  }
+  return [h1, (h2 | 1) >>> 0];
+}
+
+// Reports whether slug may exist. False means it certainly does not; with no
+// usable filter the answer is always true so the server decides.
+function slugMayExist(filter, slug) {
+  if (!filter || filter.hash !== 'fnv1a32+fnv1-32' || !filter.m) return true;
+  const [h1, h2] = slugHashes(slug.toLowerCase());
+  for (let i = 0; i < filter.k; i++) {
+    const bit = ((h1 + Math.imul(i, h2)) >>> 0) % filter.m;
+    if ((filter.bytes.charCodeAt(bit >> 3) & (1 << (bit & 7))) === 0) return false;
+  }
+  return true;
+}
+
+// Fetch the slug Bloom filter, sending the stored ETag so an unchanged filter
+// costs a 304. Needs an API key; without one the filter is cleared.
+// Governing: SPEC-0008 REQ "Offline Slug Lookup"
+async function refreshSlugFilter() {
+  const { baseURL, apiKey, slugFilter } = await chrome.storage.local.get({
+    baseURL: DEFAULTS.baseURL, apiKey: '', slugFilter: null,
+  });
+  if (!apiKey) {
+    await chrome.storage.local.remove('slugFilter');
+    return;
+  }
+  const headers = { Authorization: `Bearer ${apiKey}` };
+  if (slugFilter?.etag && slugFilter.baseURL === baseURL) headers['If-None-Match'] = slugFilter.etag;
+  try {
+    const res = await fetch(`${baseURL}/api/v1/links/bloom`, {
+      signal: AbortSignal.timeout(5000),
+      headers,
+    });
+    if (res.status === 304 || !res.ok) return;
+    const data = await res.json();
+    await chrome.storage.local.set({
+      slugFilter: {
+        baseURL,
+        etag: res.headers.get('ETag') || '',
+        hash: data.hash,
+        m: data.m,
+        k: data.k,
+        bytes: atob(data.bits || ''),
+      },
+    });
+  } catch {
+    // Server unreachable — keep the existing filter.
+  }
+}
+
 // Governing: SPEC-0008 REQ "Keyword Host Discovery", REQ "On-Install Setup"
 chrome.runtime.onInstalled.addListener(async (details) => {
   if (details.reason === 'install') {
@@ -175,6 +235,7 @@ chrome.runtime.onInstalled.addListener(async (details) => {
   await refreshKeywords();
   await updateRedirectRules();
   await setActionIcon();
+  await refreshSlugFilter();
   chrome.alarms.create('keyword-refresh', { periodInMinutes: 5 });
 });
 
@@ -182,19 +243,21 @@ chrome.runtime.onStartup.addListener(async () => {
   await refreshKeywords();
   await updateRedirectRules();
   await setActionIcon();
+  await refreshSlugFilter();
 });
 
 chrome.alarms.onAlarm.addListener(async (alarm) => {
   if (alarm.name === 'keyword-refresh') {
     await refreshKeywords();
     await updateRedirectRules();
+    await refreshSlugFilter();
   }
 });
 
 // Allow the options page to trigger a keyword refresh after a base URL change.
 chrome.runtime.onMessage.addListener((message, _sender, sendResponse) => {
   if (message?.type === 'refresh-keywords') {
-    refreshKeywords().then(() => updateRedirectRules()).then(() => sendResponse({}));
+    refreshKeywords().then(() => updateRedirectRules()).then(() => refreshSlugFilter()).then(() => sendResponse({}));
     return true; // keep channel open for async response
   }
 });
@@ -209,9 +272,10 @@ chrome.webNavigation.onBeforeNavigate.addListener(async (details) => {
   try { url = new URL(details.url); } catch { return; }
 
   // Combine storage reads into a single call for efficiency.
-  const { baseURL, keywords } = await chrome.storage.local.get({
+  const { baseURL, keywords, slugFilter } = await chrome.storage.local.get({
     baseURL: DEFAULTS.baseURL,
     keywords: DEFAULTS.keywords,
+    slugFilter: null,
   });
   const kws = Array.isArray(keywords) ? keywords : DEFAULTS.keywords;
   const serverHost = new URL(baseURL).hostname;
@@ -222,10 +286,17 @@ chrome.webNavigation.onBeforeNavigate.addListener(async (details) => {
   // If the keyword matches the server hostname or its short alias, route directly to
   // baseURL/slug — avoids a double-prefix (e.g. /go/slack on a go.stump.rocks server).
   // Otherwise use path-based keyword routing: baseURL/keyword/slug.
+  // A slug the Bloom filter rules out goes straight to the create page.
+  // Governing: SPEC-0008 REQ "Offline Slug Lookup"
   function redirectFor(keyword, slug) {
-    return (keyword === serverHost || keyword === serverKeyword)
-      ? `${baseURL}/${slug}`
-      : `${baseURL}/${keyword}/${slug}`;
+    if (keyword !== serverHost && keyword !== serverKeyword) {
+      return `${baseURL}/${keyword}/${slug}`;
+    }
+    const first = slug.split(/[/?#]/)[0];
+    if (first && slugFilter?.baseURL === baseURL && !slugMayExist(slugFilter, first)) {
+      return `${baseURL}/dashboard/links/new?slug=${encodeURIComponent(first.toLowerCase())}`;
+    }
+    return `${baseURL}/${slug}`;
   }
 
   // Case 1: Search engine interception.
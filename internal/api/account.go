@@ -0,0 +1,289 @@
+// Governing: SPEC-0005 REQ "Personal Data Export", REQ "Self-Service Account Deletion"
+package api
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/joestump/joe-links/internal/auth"
+	"github.com/joestump/joe-links/internal/store"
+)
+
+// accountAPIHandler serves the caller's data export and account deletion.
+type accountAPIHandler struct {
+	users     *store.UserStore
+	links     *store.LinkStore
+	ownership *store.OwnershipStore
+	tokens    auth.TokenStore
+	clicks    *store.ClickStore
+}
+
+// registerAccountRoutes registers /me/export and DELETE /me on r.
+func registerAccountRoutes(r chi.Router, us *store.UserStore, ls *store.LinkStore, os *store.OwnershipStore, ts auth.TokenStore, cs *store.ClickStore) {
+	h := &accountAPIHandler{users: us, links: ls, ownership: os, tokens: ts, clicks: cs}
+	r.Get("/me/export", h.Export)
+	r.Delete("/me", h.Delete)
+}
+
+// ExportResponse is everything joe-links stores about the caller.
+type ExportResponse struct {
+	ExportedAt time.Time        `json:"exported_at"`
+	User       UserResponse     `json:"user"`
+	Links      []*LinkResponse  `json:"links"`
+	Tokens     []*TokenResponse `json:"tokens"`
+	Shares     []ExportShare    `json:"shares"`
+	Clicks     []ExportClick    `json:"clicks"`
+}
+
+// ExportShare is a share the caller received or granted.
+type ExportShare struct {
+	LinkID    string    `json:"link_id"`
+	Slug      string    `json:"slug"`
+	UserID    string    `json:"user_id"`
+	SharedBy  string    `json:"shared_by"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ExportClick is a click the caller made while signed in.
+type ExportClick struct {
+	LinkID    string    `json:"link_id"`
+	Slug      string    `json:"slug"`
+	ClickedAt time.Time `json:"clicked_at"`
+	Referrer  string    `json:"referrer"`
+	UserAgent string    `json:"user_agent"`
+	Country   string    `json:"country"`
+	Source    string    `json:"source"`
+}
+
+// DeleteAccountRequest chooses what happens to the caller's links.
+type DeleteAccountRequest struct {
+	// LinkAction is "delete" or "transfer"; required when the caller is the
+	// primary owner of any link.
+	LinkAction string `json:"link_action" example:"transfer"`
+	// TransferTo is the email of the user who becomes primary owner when
+	// LinkAction is "transfer".
+	TransferTo string `json:"transfer_to,omitempty" example:"teammate@example.com"`
+}
+
+// Export returns the caller's profile, links, token metadata, shares, and
+// clicks as one JSON document, or as a ZIP of one JSON file per section
+// with ?format=zip.
+// GET /api/v1/me/export
+//
+// @Summary      Export my data
+// @Description  Returns everything stored about the caller: profile, links they own, API token metadata (never the secrets), shares they received or granted, and clicks they made while signed in. Use format=zip for a ZIP of one JSON file per section.
+// @Tags         Users
+// @Produce      json
+// @Produce      application/zip
+// @Param        format  query     string  false  "json (default) or zip"
+// @Success      200     {object}  ExportResponse
+// @Failure      400     {object}  ErrorResponse
+// @Failure      401     {object}  ErrorResponse
+// @Failure      500     {object}  ErrorResponse
+// @Security     BearerToken
+// @Router       /me/export [get]
+func (h *accountAPIHandler) Export(w http.ResponseWriter, r *http.Request) {
+	user := auth.UserFromContext(r.Context())
+	if user == nil {
+		writeError(w, http.StatusUnauthorized, "unauthorized", "UNAUTHORIZED")
+		return
+	}
+	format := r.URL.Query().Get("format")
+	if format != "" && format != "json" && format != "zip" {
+		writeError(w, http.StatusBadRequest, "format must be json or zip", "BAD_REQUEST")
+		return
+	}
+
+	export, err := h.collect(r, user)
+	if err != nil {
+		log.Printf("export for user %s: %v", user.ID, err)
+		writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
+		return
+	}
+
+	name := "joe-links-export-" + export.ExportedAt.Format("20060102")
+	if format != "zip" {
+		w.Header().Set("Content-Disposition", `attachment; filename="`+name+`.json"`)
+		writeJSON(w, http.StatusOK, export)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", `attachment; filename="`+name+`.zip"`)
+	zw := zip.NewWriter(w)
+	for _, f := range []struct {
+		name string
+		v    any
+	}{
+		{"user.json", export.User},
+		{"links.json", export.Links},
+		{"tokens.json", export.Tokens},
+		{"shares.json", export.Shares},
+		{"clicks.json", export.Clicks},
+	} {
+		fw, err := zw.CreateHeader(&zip.FileHeader{Name: name + "/" + f.name, Method: zip.Deflate, Modified: export.ExportedAt})
+		if err != nil {
+			log.Printf("export zip for user %s: %v", user.ID, err)
+			return
+		}
+		enc := json.NewEncoder(fw)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(f.v); err != nil {
+			log.Printf("export zip for user %s: %v", user.ID, err)
+			return
+		}
+	}
+	if err := zw.Close(); err != nil {
+		log.Printf("export zip for user %s: %v", user.ID, err)
+	}
+}
+
+// collect gathers the export for user.
+func (h *accountAPIHandler) collect(r *http.Request, user *store.User) (*ExportResponse, error) {
+	ctx := r.Context()
+	export := &ExportResponse{
+		ExportedAt: time.Now().UTC(),
+		User: UserResponse{
+			ID:          user.ID,
+			Email:       user.Email,
+			DisplayName: user.DisplayName,
+			Role:        user.Role,
+			CreatedAt:   user.CreatedAt,
+		},
+		Links:  []*LinkResponse{},
+		Tokens: []*TokenResponse{},
+		Shares: []ExportShare{},
+		Clicks: []ExportClick{},
+	}
+
+	links, err := h.links.ListByOwner(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	lh := &linksAPIHandler{links: h.links, ownership: h.ownership}
+	for _, l := range links {
+		lr, err := lh.toLinkResponse(ctx, l)
+		if err != nil {
+			return nil, err
+		}
+		export.Links = append(export.Links, lr)
+	}
+
+	tokens, err := h.tokens.ListByUser(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, rec := range tokens {
+		item := &TokenResponse{
+			ID:        rec.ID,
+			Name:      rec.Name,
+			Scopes:    rec.ScopeList(),
+			CreatedAt: rec.CreatedAt,
+		}
+		if rec.LastUsedAt.Valid {
+			t := rec.LastUsedAt.Time
+			item.LastUsedAt = &t
+		}
+		if rec.ExpiresAt.Valid {
+			t := rec.ExpiresAt.Time
+			item.ExpiresAt = &t
+		}
+		export.Tokens = append(export.Tokens, item)
+	}
+
+	shares, err := h.users.ListSharesInvolving(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range shares {
+		export.Shares = append(export.Shares, ExportShare(s))
+	}
+
+	clicks, err := h.clicks.ListClicksByUser(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range clicks {
+		export.Clicks = append(export.Clicks, ExportClick(c))
+	}
+	return export, nil
+}
+
+// Delete deletes the caller's account. Links they are primary owner of are
+// deleted or transferred to another user, as the body chooses.
+// DELETE /api/v1/me
+//
+// @Summary      Delete my account
+// @Description  Deletes the caller's account, tokens, saved searches, co-ownerships, and received shares, and anonymizes their clicks. Links they are primary owner of are deleted (link_action=delete) or handed to another user (link_action=transfer with transfer_to). The only admin cannot delete their account.
+// @Tags         Users
+// @Accept       json
+// @Param        body  body  DeleteAccountRequest  false  "Link disposition"
+// @Success      204   "No Content"
+// @Failure      400   {object}  ErrorResponse
+// @Failure      401   {object}  ErrorResponse
+// @Failure      409   {object}  ErrorResponse
+// @Failure      500   {object}  ErrorResponse
+// @Security     BearerToken
+// @Router       /me [delete]
+func (h *accountAPIHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	user := auth.UserFromContext(r.Context())
+	if user == nil {
+		writeError(w, http.StatusUnauthorized, "unauthorized", "UNAUTHORIZED")
+		return
+	}
+
+	var req DeleteAccountRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid request body", "BAD_REQUEST")
+			return
+		}
+	}
+
+	linkCount, err := h.users.CountPrimaryLinks(r.Context(), user.ID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
+		return
+	}
+	var transferTo string
+	switch {
+	case linkCount == 0:
+		req.LinkAction = store.AccountLinksDelete
+	case req.LinkAction == store.AccountLinksDelete:
+	case req.LinkAction == store.AccountLinksTransfer:
+		if req.TransferTo == "" {
+			writeError(w, http.StatusBadRequest, "transfer_to is required", "BAD_REQUEST")
+			return
+		}
+		recipient, err := h.users.GetByEmail(r.Context(), req.TransferTo)
+		if errors.Is(err, store.ErrNotFound) || (err == nil && recipient.ID == user.ID) {
+			writeError(w, http.StatusBadRequest, "transfer_to must be the email of another user", "BAD_REQUEST")
+			return
+		}
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
+			return
+		}
+		transferTo = recipient.ID
+	default:
+		writeError(w, http.StatusBadRequest, "link_action must be delete or transfer", "BAD_REQUEST")
+		return
+	}
+
+	err = h.users.DeleteAccount(r.Context(), user.ID, req.LinkAction, transferTo)
+	if errors.Is(err, store.ErrLastAdmin) {
+		writeError(w, http.StatusConflict, "the only admin cannot delete their account", "LAST_ADMIN")
+		return
+	}
+	if err != nil {
+		log.Printf("delete account %s: %v", user.ID, err)
+		writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
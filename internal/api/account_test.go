@@ -0,0 +1,165 @@
+// Governing: SPEC-0005 REQ "Personal Data Export", REQ "Self-Service Account Deletion"
+package api_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/joestump/joe-links/internal/store"
+)
+
+func TestExport(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+	alice := seedUser(t, env, "alice@example.com", "user")
+	bob := seedUser(t, env, "bob@example.com", "user")
+	token := seedToken(t, env, alice.ID)
+
+	wiki, err := env.LinkStore.Create(ctx, "wiki", "https://wiki.example.com", alice.ID, "", "", "private")
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if _, err := env.LinkStore.Create(ctx, "bobs", "https://bob.example.com", bob.ID, "", "", "public"); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if err := env.LinkStore.AddShare(ctx, wiki.ID, bob.ID, alice.ID); err != nil {
+		t.Fatalf("AddShare: %v", err)
+	}
+	if err := env.ClickStore.RecordClick(ctx, store.ClickEvent{LinkID: wiki.ID, UserID: alice.ID, UserAgent: "Firefox"}); err != nil {
+		t.Fatalf("RecordClick: %v", err)
+	}
+
+	req := httptest.NewRequest("GET", "/me/export", nil)
+	authRequest(req, token)
+	rec := httptest.NewRecorder()
+	env.Router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d; body: %s", rec.Code, rec.Body.String())
+	}
+	if body := rec.Body.String(); strings.Contains(body, "token_hash") {
+		t.Error("export leaks token hashes")
+	}
+	var export struct {
+		User   struct{ Email string }  `json:"user"`
+		Links  []struct{ Slug string } `json:"links"`
+		Tokens []struct{ Name string } `json:"tokens"`
+		Shares []struct {
+			Slug   string `json:"slug"`
+			UserID string `json:"user_id"`
+		} `json:"shares"`
+		Clicks []struct{ Slug string } `json:"clicks"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&export); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if export.User.Email != "alice@example.com" {
+		t.Errorf("user email = %q", export.User.Email)
+	}
+	if len(export.Links) != 1 || export.Links[0].Slug != "wiki" {
+		t.Errorf("links = %+v, want only wiki", export.Links)
+	}
+	if len(export.Tokens) != 1 {
+		t.Errorf("tokens = %+v, want 1", export.Tokens)
+	}
+	if len(export.Shares) != 1 || export.Shares[0].UserID != bob.ID {
+		t.Errorf("shares = %+v, want wiki shared with bob", export.Shares)
+	}
+	if len(export.Clicks) != 1 || export.Clicks[0].Slug != "wiki" {
+		t.Errorf("clicks = %+v, want one on wiki", export.Clicks)
+	}
+
+	req = httptest.NewRequest("GET", "/me/export?format=zip", nil)
+	authRequest(req, token)
+	rec = httptest.NewRecorder()
+	env.Router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK || rec.Header().Get("Content-Type") != "application/zip" {
+		t.Fatalf("zip export: status %d, content type %q", rec.Code, rec.Header().Get("Content-Type"))
+	}
+	zr, err := zip.NewReader(bytes.NewReader(rec.Body.Bytes()), int64(rec.Body.Len()))
+	if err != nil {
+		t.Fatalf("read zip: %v", err)
+	}
+	var names []string
+	for _, f := range zr.File {
+		names = append(names, f.Name[strings.LastIndex(f.Name, "/")+1:])
+	}
+	sort.Strings(names)
+	if got := strings.Join(names, ","); got != "clicks.json,links.json,shares.json,tokens.json,user.json" {
+		t.Errorf("zip files = %s", got)
+	}
+}
+
+func TestDeleteAccount(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+	alice := seedUser(t, env, "alice@example.com", "user")
+	bob := seedUser(t, env, "bob@example.com", "user")
+	token := seedToken(t, env, alice.ID)
+
+	wiki, err := env.LinkStore.Create(ctx, "wiki", "https://wiki.example.com", alice.ID, "", "", "public")
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if err := env.ClickStore.RecordClick(ctx, store.ClickEvent{LinkID: wiki.ID, UserID: alice.ID}); err != nil {
+		t.Fatalf("RecordClick: %v", err)
+	}
+	do := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("DELETE", "/me", strings.NewReader(body))
+		authRequest(req, token)
+		rec := httptest.NewRecorder()
+		env.Router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	if rec := do(""); rec.Code != http.StatusBadRequest {
+		t.Errorf("without link_action: status = %d, want 400", rec.Code)
+	}
+	if rec := do(`{"link_action":"transfer","transfer_to":"nobody@example.com"}`); rec.Code != http.StatusBadRequest {
+		t.Errorf("transfer to unknown user: status = %d, want 400", rec.Code)
+	}
+	if rec := do(`{"link_action":"transfer","transfer_to":"bob@example.com"}`); rec.Code != http.StatusNoContent {
+		t.Fatalf("delete: status = %d; body: %s", rec.Code, rec.Body.String())
+	}
+
+	if _, err := env.UserStore.GetByID(ctx, alice.ID); err == nil {
+		t.Error("alice still exists after delete")
+	}
+	owners, err := env.OwnershipStore.ListOwnerUsers(wiki.ID)
+	if err != nil || len(owners) != 1 || owners[0].ID != bob.ID || !owners[0].IsPrimary {
+		t.Errorf("wiki owners = %+v, %v; want bob as primary", owners, err)
+	}
+	tokens, _ := env.TokenStore.ListByUser(ctx, alice.ID)
+	if len(tokens) != 0 {
+		t.Errorf("alice still has %d tokens", len(tokens))
+	}
+	recent, err := env.ClickStore.ListRecentClicks(ctx, wiki.ID, 10)
+	if err != nil || len(recent) != 1 || recent[0].UserID != "" {
+		t.Errorf("clicks = %+v, %v; want one anonymous click", recent, err)
+	}
+
+	// The token died with the account.
+	if rec := do(""); rec.Code != http.StatusUnauthorized {
+		t.Errorf("after delete: status = %d, want 401", rec.Code)
+	}
+}
+
+func TestDeleteAccount_LastAdmin(t *testing.T) {
+	env := newTestEnv(t)
+	admin := seedUser(t, env, "admin@example.com", "admin")
+	token := seedToken(t, env, admin.ID)
+
+	req := httptest.NewRequest("DELETE", "/me", nil)
+	authRequest(req, token)
+	rec := httptest.NewRecorder()
+	env.Router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("status = %d, want 409; body: %s", rec.Code, rec.Body.String())
+	}
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/go-chi/chi/v5"
 	"github.com/joestump/joe-links/internal/auth"
+	"github.com/joestump/joe-links/internal/netpolicy"
 	"github.com/joestump/joe-links/internal/store"
 )
 
@@ -18,20 +19,94 @@ type adminAPIHandler struct {
 	users     *store.UserStore
 	links     *store.LinkStore
 	ownership *store.OwnershipStore
+	reserved  *store.ReservedSlugStore
+	teams     *store.TeamStore
+	tags      *store.TagStore
+	clicks    *store.ClickStore
+	policies  *store.PolicyStore
+	domains   *store.DomainRuleStore
+	tenants   *store.TenantStore
 }
 
 // registerAdminRoutes registers admin routes inside a chi Group with role-check middleware.
 // Governing: SPEC-0005 REQ "Admin Endpoints" — chi Group MUST enforce role = admin.
-func registerAdminRoutes(r chi.Router, users *store.UserStore, links *store.LinkStore, ownership *store.OwnershipStore) {
-	h := &adminAPIHandler{users: users, links: links, ownership: ownership}
+func registerAdminRoutes(r chi.Router, users *store.UserStore, links *store.LinkStore, ownership *store.OwnershipStore, reserved *store.ReservedSlugStore, teams *store.TeamStore, tags *store.TagStore, clicks *store.ClickStore, policies *store.PolicyStore, domains *store.DomainRuleStore, tenants *store.TenantStore, networks *netpolicy.Allowlist, webauthn *auth.WebAuthn) {
+	h := &adminAPIHandler{users: users, links: links, ownership: ownership, reserved: reserved, teams: teams, tags: tags, clicks: clicks, policies: policies, domains: domains, tenants: tenants}
 
 	r.Route("/admin", func(admin chi.Router) {
+		// Governing: SPEC-0001 REQ "Admin Network Allowlist"
+		admin.Use(networks.Middleware(denyAdminNetwork))
 		// Governing: SPEC-0005 REQ "Admin Endpoints" — non-admin returns 403 Forbidden.
 		admin.Use(requireAdmin)
+		// Governing: SPEC-0006 REQ "Token Scopes"
+		admin.Use(auth.RequireScope(auth.ScopeAdmin))
+		// Governing: SPEC-0001 REQ "WebAuthn Step-Up" — every admin change
+		// made with a browser session needs a fresh passkey confirmation
+		admin.Use(requireSessionStepUp(webauthn, isUnsafe))
 
 		admin.Get("/users", h.ListUsers)
 		admin.Put("/users/{id}/role", h.UpdateRole)
 		admin.Get("/links", h.ListLinks)
+
+		// Governing: SPEC-0011 REQ "Public Link Moderation"
+		admin.Get("/moderation", h.ListPendingLinks)
+		admin.Post("/moderation/{id}/approve", h.ApproveLink)
+		admin.Post("/moderation/{id}/reject", h.RejectLink)
+
+		// Governing: SPEC-0005 REQ "Reserved Slugs API"
+		if reserved != nil {
+			admin.Get("/reserved-slugs", h.ListReservedSlugs)
+			admin.Post("/reserved-slugs", h.AddReservedSlug)
+			admin.Delete("/reserved-slugs/{slug}", h.RemoveReservedSlug)
+		}
+
+		// Governing: SPEC-0005 REQ "Teams API"
+		if teams != nil {
+			admin.Get("/teams", h.ListTeams)
+			admin.Post("/teams", h.CreateTeam)
+			admin.Delete("/teams/{slug}", h.DeleteTeam)
+			// Governing: SPEC-0010 REQ "Team Shares and Ownership"
+			admin.Get("/teams/{slug}/members", h.ListTeamMembers)
+			admin.Post("/teams/{slug}/members", h.AddTeamMember)
+			admin.Delete("/teams/{slug}/members/{uid}", h.RemoveTeamMember)
+		}
+
+		// Governing: SPEC-0005 REQ "Tag Administration API"
+		admin.Get("/tags", h.ListTags)
+		admin.Put("/tags/{slug}", h.RenameTag)
+		admin.Post("/tags/{slug}/merge", h.MergeTag)
+		admin.Delete("/tags/{slug}", h.DeleteTag)
+
+		// Governing: SPEC-0016 REQ "Referrer Exclusion"
+		if clicks != nil {
+			admin.Get("/referrers", h.ListExcludedReferrers)
+			admin.Post("/referrers", h.ExcludeReferrer)
+			admin.Delete("/referrers/{domain}", h.RemoveExcludedReferrer)
+		}
+
+		// Governing: SPEC-0011 REQ "Link Lifecycle Policies"
+		if policies != nil {
+			admin.Get("/policies", h.ListPolicies)
+			admin.Post("/policies", h.CreatePolicy)
+			admin.Delete("/policies/{id}", h.DeletePolicy)
+		}
+
+		// Governing: SPEC-0002 REQ "Destination Domain Rules"
+		if domains != nil {
+			admin.Get("/domain-rules", h.ListDomainRules)
+			admin.Post("/domain-rules", h.AddDomainRule)
+			admin.Delete("/domain-rules/{domain}", h.RemoveDomainRule)
+		}
+
+		// Governing: SPEC-0001 REQ "Multi-Tenancy"
+		if tenants != nil {
+			admin.Group(func(admin chi.Router) {
+				admin.Use(requireDefaultTenant)
+				admin.Get("/tenants", h.ListTenants)
+				admin.Post("/tenants", h.CreateTenant)
+				admin.Delete("/tenants/{id}", h.DeleteTenant)
+			})
+		}
 	})
 }
 
@@ -52,6 +127,13 @@ func requireAdmin(next http.Handler) http.Handler {
 	})
 }
 
+// denyAdminNetwork rejects admin requests from clients outside the admin
+// network allowlist.
+// Governing: SPEC-0001 REQ "Admin Network Allowlist"
+func denyAdminNetwork(w http.ResponseWriter, r *http.Request) {
+	writeError(w, http.StatusForbidden, "admin endpoints are not available from your network", "NETWORK_NOT_ALLOWED")
+}
+
 // ListUsers returns all users in the system.
 // GET /api/v1/admin/users
 // Governing: SPEC-0005 REQ "Admin Endpoints"
@@ -164,43 +246,12 @@ func (h *adminAPIHandler) ListLinks(w http.ResponseWriter, r *http.Request) {
 
 	resp := &LinkListResponse{Links: make([]*LinkResponse, 0, len(links))}
 	for _, l := range links {
-		// Build owner list for each link.
-		owners, err := h.ownership.ListOwnerUsers(l.ID)
+		lr, err := buildLinkResponse(r.Context(), h.links, h.ownership, l)
 		if err != nil {
 			writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
 			return
 		}
-		ownerResponses := make([]OwnerResponse, 0, len(owners))
-		for _, o := range owners {
-			ownerResponses = append(ownerResponses, OwnerResponse{
-				ID:        o.ID,
-				Email:     o.Email,
-				IsPrimary: o.IsPrimary,
-			})
-		}
-
-		// Build tag list for each link.
-		tags, err := h.links.ListTags(r.Context(), l.ID)
-		if err != nil {
-			writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
-			return
-		}
-		tagNames := make([]string, 0, len(tags))
-		for _, t := range tags {
-			tagNames = append(tagNames, t.Name)
-		}
-
-		resp.Links = append(resp.Links, &LinkResponse{
-			ID:          l.ID,
-			Slug:        l.Slug,
-			URL:         l.URL,
-			Title:       l.Title,
-			Description: l.Description,
-			Tags:        tagNames,
-			Owners:      ownerResponses,
-			CreatedAt:   l.CreatedAt,
-			UpdatedAt:   l.UpdatedAt,
-		})
+		resp.Links = append(resp.Links, lr)
 	}
 
 	writeJSON(w, http.StatusOK, resp)
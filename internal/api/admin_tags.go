@@ -0,0 +1,152 @@
+// Governing: SPEC-0005 REQ "Tag Administration API", SPEC-0004 REQ "Tag Administration"
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/joestump/joe-links/internal/store"
+)
+
+// ListTags returns every tag, including unused ones, with its link count.
+// GET /api/v1/admin/tags
+// Governing: SPEC-0005 REQ "Tag Administration API"
+//
+// @Summary      List all tags (admin)
+// @Description  Returns every tag with its link count, including tags no link uses. Requires admin role.
+// @Tags         Admin
+// @Produce      json
+// @Success      200  {array}   TagResponse
+// @Failure      401  {object}  ErrorResponse
+// @Failure      403  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Security     BearerToken
+// @Router       /admin/tags [get]
+func (h *adminAPIHandler) ListTags(w http.ResponseWriter, r *http.Request) {
+	tags, err := h.tags.ListAllWithCounts(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
+		return
+	}
+	resp := make([]*TagResponse, 0, len(tags))
+	for _, t := range tags {
+		resp = append(resp, &TagResponse{Slug: t.Slug, Name: t.Name, LinkCount: t.Count})
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// RenameTag renames a tag; its slug follows the new name.
+// PUT /api/v1/admin/tags/{slug}
+// Governing: SPEC-0005 REQ "Tag Administration API"
+//
+// @Summary      Rename a tag (admin)
+// @Description  Renames a tag and re-derives its slug. Fails with 409 if another tag already has that slug; merge instead. Requires admin role.
+// @Tags         Admin
+// @Accept       json
+// @Produce      json
+// @Param        slug  path      string            true  "Tag slug"
+// @Param        body  body      RenameTagRequest  true  "New name"
+// @Success      200   {object}  TagResponse
+// @Failure      400   {object}  ErrorResponse
+// @Failure      401   {object}  ErrorResponse
+// @Failure      403   {object}  ErrorResponse
+// @Failure      404   {object}  ErrorResponse
+// @Failure      409   {object}  ErrorResponse
+// @Failure      500   {object}  ErrorResponse
+// @Security     BearerToken
+// @Router       /admin/tags/{slug} [put]
+func (h *adminAPIHandler) RenameTag(w http.ResponseWriter, r *http.Request) {
+	var req RenameTagRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body", "BAD_REQUEST")
+		return
+	}
+	tag, err := h.tags.Rename(r.Context(), chi.URLParam(r, "slug"), req.Name)
+	if err != nil {
+		writeTagError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, h.tagResponse(r, tag))
+}
+
+// MergeTag moves every link from one tag onto another and deletes the first.
+// POST /api/v1/admin/tags/{slug}/merge
+// Governing: SPEC-0005 REQ "Tag Administration API"
+//
+// @Summary      Merge a tag into another (admin)
+// @Description  Re-tags every link carrying {slug} with the "into" tag, then deletes {slug}, in one transaction. Requires admin role.
+// @Tags         Admin
+// @Accept       json
+// @Produce      json
+// @Param        slug  path      string           true  "Tag slug to merge away"
+// @Param        body  body      MergeTagRequest  true  "Surviving tag"
+// @Success      200   {object}  TagResponse
+// @Failure      400   {object}  ErrorResponse
+// @Failure      401   {object}  ErrorResponse
+// @Failure      403   {object}  ErrorResponse
+// @Failure      404   {object}  ErrorResponse
+// @Failure      500   {object}  ErrorResponse
+// @Security     BearerToken
+// @Router       /admin/tags/{slug}/merge [post]
+func (h *adminAPIHandler) MergeTag(w http.ResponseWriter, r *http.Request) {
+	var req MergeTagRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body", "BAD_REQUEST")
+		return
+	}
+	tag, err := h.tags.Merge(r.Context(), chi.URLParam(r, "slug"), req.Into)
+	if err != nil {
+		writeTagError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, h.tagResponse(r, tag))
+}
+
+// DeleteTag removes a tag from every link and deletes it.
+// DELETE /api/v1/admin/tags/{slug}
+// Governing: SPEC-0005 REQ "Tag Administration API"
+//
+// @Summary      Delete a tag (admin)
+// @Description  Removes the tag from every link and deletes it. Requires admin role.
+// @Tags         Admin
+// @Produce      json
+// @Param        slug  path  string  true  "Tag slug"
+// @Success      204   "No Content"
+// @Failure      401   {object}  ErrorResponse
+// @Failure      403   {object}  ErrorResponse
+// @Failure      404   {object}  ErrorResponse
+// @Failure      500   {object}  ErrorResponse
+// @Security     BearerToken
+// @Router       /admin/tags/{slug} [delete]
+func (h *adminAPIHandler) DeleteTag(w http.ResponseWriter, r *http.Request) {
+	if err := h.tags.Delete(r.Context(), chi.URLParam(r, "slug")); err != nil {
+		writeTagError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// tagResponse returns tag with its current link count.
+func (h *adminAPIHandler) tagResponse(r *http.Request, tag *store.Tag) *TagResponse {
+	resp := &TagResponse{Slug: tag.Slug, Name: tag.Name}
+	if counted, err := h.tags.GetWithCount(r.Context(), tag.Slug); err == nil {
+		resp.LinkCount = counted.Count
+	}
+	return resp
+}
+
+// writeTagError maps tag store errors to API error responses.
+func writeTagError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, store.ErrNotFound):
+		writeError(w, http.StatusNotFound, "tag not found", "NOT_FOUND")
+	case errors.Is(err, store.ErrInvalidTagName), errors.Is(err, store.ErrMergeIntoSelf):
+		writeError(w, http.StatusBadRequest, err.Error(), "BAD_REQUEST")
+	case errors.Is(err, store.ErrSlugTaken):
+		writeError(w, http.StatusConflict, "another tag already has that slug; merge into it instead", "SLUG_CONFLICT")
+	default:
+		writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
+	}
+}
@@ -0,0 +1,99 @@
+// Governing: SPEC-0005 REQ "Tag Administration API"
+package api_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/joestump/joe-links/internal/api"
+)
+
+func TestAdminTags(t *testing.T) {
+	env := newTestEnv(t)
+	admin := seedUser(t, env, "admin@example.com", "admin")
+	user := seedUser(t, env, "user@example.com", "user")
+	adminToken := seedToken(t, env, admin.ID)
+	userToken := seedToken(t, env, user.ID)
+
+	do := func(token, method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		authRequest(req, token)
+		rec := httptest.NewRecorder()
+		env.Router.ServeHTTP(rec, req)
+		return rec
+	}
+	counts := func() map[string]int {
+		t.Helper()
+		rec := do(adminToken, "GET", "/admin/tags", "")
+		if rec.Code != http.StatusOK {
+			t.Fatalf("list tags: status = %d; body: %s", rec.Code, rec.Body.String())
+		}
+		var tags []api.TagResponse
+		if err := json.NewDecoder(rec.Body).Decode(&tags); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		m := map[string]int{}
+		for _, tag := range tags {
+			m[tag.Slug] = tag.LinkCount
+		}
+		return m
+	}
+
+	for _, body := range []string{
+		`{"slug":"a","url":"https://a.example.com","tags":["kubernetes","k8s"]}`,
+		`{"slug":"b","url":"https://b.example.com","tags":["k8s"]}`,
+		`{"slug":"c","url":"https://c.example.com","tags":["unused"]}`,
+	} {
+		if rec := do(userToken, "POST", "/links", body); rec.Code != http.StatusCreated {
+			t.Fatalf("create link: status = %d; body: %s", rec.Code, rec.Body.String())
+		}
+	}
+
+	if rec := do(userToken, "GET", "/admin/tags", ""); rec.Code != http.StatusForbidden {
+		t.Errorf("non-admin list: status = %d, want 403", rec.Code)
+	}
+	if got := counts(); got["k8s"] != 2 || got["kubernetes"] != 1 {
+		t.Errorf("counts = %v", got)
+	}
+
+	// Renaming onto an existing slug conflicts; a fresh name re-derives the slug.
+	if rec := do(adminToken, "PUT", "/admin/tags/unused", `{"name":"K8s"}`); rec.Code != http.StatusConflict {
+		t.Errorf("rename onto existing: status = %d, want 409", rec.Code)
+	}
+	rec := do(adminToken, "PUT", "/admin/tags/unused", `{"name":"Legacy Stuff"}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("rename: status = %d; body: %s", rec.Code, rec.Body.String())
+	}
+	var renamed api.TagResponse
+	if err := json.NewDecoder(rec.Body).Decode(&renamed); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if renamed.Slug != "legacy-stuff" || renamed.Name != "Legacy Stuff" || renamed.LinkCount != 1 {
+		t.Errorf("renamed = %+v", renamed)
+	}
+
+	// Merging folds k8s into kubernetes without duplicating link a.
+	if rec := do(adminToken, "POST", "/admin/tags/k8s/merge", `{"into":"k8s"}`); rec.Code != http.StatusBadRequest {
+		t.Errorf("merge into self: status = %d, want 400", rec.Code)
+	}
+	if rec := do(adminToken, "POST", "/admin/tags/k8s/merge", `{"into":"kubernetes"}`); rec.Code != http.StatusOK {
+		t.Fatalf("merge: status = %d; body: %s", rec.Code, rec.Body.String())
+	}
+	if got := counts(); got["kubernetes"] != 2 || got["k8s"] != 0 {
+		t.Errorf("counts after merge = %v", got)
+	}
+	if _, ok := counts()["k8s"]; ok {
+		t.Error("merged tag still listed")
+	}
+
+	if rec := do(adminToken, "DELETE", "/admin/tags/legacy-stuff", ""); rec.Code != http.StatusNoContent {
+		t.Fatalf("delete: status = %d", rec.Code)
+	}
+	if rec := do(adminToken, "DELETE", "/admin/tags/legacy-stuff", ""); rec.Code != http.StatusNotFound {
+		t.Errorf("delete again: status = %d, want 404", rec.Code)
+	}
+}
@@ -2,12 +2,19 @@ package api_test
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/alexedwards/scs/v2"
 	"github.com/joestump/joe-links/internal/api"
+	"github.com/joestump/joe-links/internal/auth"
+	"github.com/joestump/joe-links/internal/netpolicy"
+	"github.com/joestump/joe-links/internal/store"
 )
 
 func TestAdmin_ListUsers_Forbidden_NonAdmin(t *testing.T) {
@@ -174,3 +181,109 @@ func TestAdmin_Unauthenticated(t *testing.T) {
 		})
 	}
 }
+
+// Governing: SPEC-0001 REQ "Admin Network Allowlist"
+func TestAdmin_NetworkAllowlist(t *testing.T) {
+	env := newTestEnv(t)
+	admin := seedUser(t, env, "admin@example.com", "admin")
+	token := seedToken(t, env, admin.ID)
+
+	nets, err := netpolicy.Parse([]string{"10.0.0.0/8"})
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	deps := env.Deps
+	deps.AdminNetworks = nets
+	router := api.NewAPIRouter(deps)
+
+	for addr, want := range map[string]int{
+		"10.4.0.7:4242":     http.StatusOK,
+		"198.51.100.1:4242": http.StatusForbidden,
+	} {
+		req := httptest.NewRequest("GET", "/admin/users", nil)
+		req.RemoteAddr = addr
+		authRequest(req, token)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		if rec.Code != want {
+			t.Errorf("%s: status = %d, want %d; body: %s", addr, rec.Code, want, rec.Body.String())
+		}
+	}
+
+	// Non-admin routes stay reachable from anywhere.
+	req := httptest.NewRequest("GET", "/links", nil)
+	req.RemoteAddr = "198.51.100.1:4242"
+	authRequest(req, token)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Errorf("GET /links: status = %d, want %d", rec.Code, http.StatusOK)
+	}
+}
+
+// Governing: SPEC-0001 REQ "WebAuthn Step-Up"
+func TestAdmin_SessionStepUp(t *testing.T) {
+	env := newTestEnv(t)
+	admin := seedUser(t, env, "admin@example.com", "admin")
+	target := seedUser(t, env, "target@example.com", "user")
+	token := seedToken(t, env, admin.ID)
+
+	sm := scs.New()
+	deps := env.Deps
+	deps.BearerMiddleware = auth.NewBearerTokenMiddleware(env.TokenStore, env.UserStore).WithSessions(sm)
+	deps.WebAuthn = auth.NewWebAuthn(sm, store.NewWebAuthnStore(nil), "", "")
+	router := sm.LoadAndSave(api.NewAPIRouter(deps))
+
+	session := func(steppedUp bool) *http.Cookie {
+		ctx, err := sm.Load(context.Background(), "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		sm.Put(ctx, auth.SessionUserIDKey, admin.ID)
+		if steppedUp {
+			sm.Put(ctx, auth.SessionStepUpKey, time.Now().UTC())
+		}
+		tok, _, err := sm.Commit(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &http.Cookie{Name: sm.Cookie.Name, Value: tok}
+	}
+	serve := func(method, path string, cookie *http.Cookie, bearer string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, bytes.NewBufferString(`{"role":"admin"}`))
+		req.Header.Set("Content-Type", "application/json")
+		if cookie != nil {
+			req.AddCookie(cookie)
+			req.Header.Set(auth.SessionAuthHeader, "1")
+		}
+		if bearer != "" {
+			authRequest(req, bearer)
+		}
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	plain := session(false)
+	if rec := serve("GET", "/admin/users", plain, ""); rec.Code != http.StatusOK {
+		t.Errorf("session read: status = %d, want 200", rec.Code)
+	}
+	rec := serve("PUT", "/admin/users/"+target.ID+"/role", plain, "")
+	if rec.Code != http.StatusForbidden || !strings.Contains(rec.Body.String(), "STEP_UP_REQUIRED") {
+		t.Errorf("session role change: status = %d, body %s; want 403 STEP_UP_REQUIRED", rec.Code, rec.Body.String())
+	}
+	link, err := env.LinkStore.Create(context.Background(), "theirs", "https://example.com", target.ID, "", "", "public")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rec := serve("DELETE", "/links/"+link.ID, plain, ""); rec.Code != http.StatusForbidden {
+		t.Errorf("session link delete: status = %d, want 403", rec.Code)
+	}
+
+	if rec := serve("PUT", "/admin/users/"+target.ID+"/role", session(true), ""); rec.Code != http.StatusOK {
+		t.Errorf("stepped-up role change: status = %d, want 200; body %s", rec.Code, rec.Body.String())
+	}
+	if rec := serve("DELETE", "/links/"+link.ID, nil, token); rec.Code != http.StatusNoContent {
+		t.Errorf("token link delete: status = %d, want 204", rec.Code)
+	}
+}
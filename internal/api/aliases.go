@@ -0,0 +1,188 @@
+// Governing: SPEC-0005 REQ "Link Aliases API", SPEC-0002 REQ "Link Aliases"
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/joestump/joe-links/internal/auth"
+	"github.com/joestump/joe-links/internal/store"
+)
+
+// aliasesAPIHandler provides REST handlers for link alias management.
+type aliasesAPIHandler struct {
+	links     *store.LinkStore
+	ownership *store.OwnershipStore
+}
+
+// registerAliasRoutes registers alias management routes on r.
+// Governing: SPEC-0005 REQ "Link Aliases API"
+func registerAliasRoutes(r chi.Router, links *store.LinkStore, ownership *store.OwnershipStore) {
+	h := &aliasesAPIHandler{links: links, ownership: ownership}
+	r.Get("/links/{id}/aliases", h.List)
+	r.Post("/links/{id}/aliases", h.Add)
+	r.Delete("/links/{id}/aliases/{slug}", h.Remove)
+}
+
+// ownedLink loads the link named by the {id} URL parameter and checks that the
+// caller owns it or is an admin. It writes the error response and returns nil
+// when the request cannot proceed.
+func (h *aliasesAPIHandler) ownedLink(w http.ResponseWriter, r *http.Request) *store.Link {
+	user := auth.UserFromContext(r.Context())
+	if user == nil {
+		writeError(w, http.StatusUnauthorized, "unauthorized", "UNAUTHORIZED")
+		return nil
+	}
+	link, err := h.links.GetByID(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			writeError(w, http.StatusNotFound, "not found", "NOT_FOUND")
+			return nil
+		}
+		writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
+		return nil
+	}
+	allowed, err := store.IsOwnerOrAdmin(h.ownership, link.ID, user.ID, user.Role)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
+		return nil
+	}
+	if !allowed {
+		writeError(w, http.StatusForbidden, "forbidden", "FORBIDDEN")
+		return nil
+	}
+	return link
+}
+
+// List returns a link's aliases.
+// GET /api/v1/links/{id}/aliases
+// Governing: SPEC-0005 REQ "Link Aliases API"
+//
+// @Summary      List link aliases
+// @Description  Returns the additional slugs that resolve to a link. Only owners and admins may access.
+// @Tags         Aliases
+// @Produce      json
+// @Param        id   path      string  true  "Link ID"
+// @Success      200  {array}   AliasResponse
+// @Failure      401  {object}  ErrorResponse
+// @Failure      403  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Security     BearerToken
+// @Router       /links/{id}/aliases [get]
+func (h *aliasesAPIHandler) List(w http.ResponseWriter, r *http.Request) {
+	link := h.ownedLink(w, r)
+	if link == nil {
+		return
+	}
+	aliases, err := h.links.ListAliases(r.Context(), link.ID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
+		return
+	}
+	resp := make([]AliasResponse, 0, len(aliases))
+	for _, a := range aliases {
+		resp = append(resp, aliasResponse(a))
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// Add makes a link reachable under an additional slug.
+// POST /api/v1/links/{id}/aliases
+// Governing: SPEC-0005 REQ "Link Aliases API"
+//
+// @Summary      Add an alias
+// @Description  Adds a slug that resolves to the link. The slug follows the same format rules as link slugs and must not be used by any link or alias. Only owners and admins may add aliases.
+// @Tags         Aliases
+// @Accept       json
+// @Produce      json
+// @Param        id    path      string           true  "Link ID"
+// @Param        body  body      AddAliasRequest  true  "Alias to add"
+// @Success      201   {object}  AliasResponse
+// @Failure      400   {object}  ErrorResponse
+// @Failure      401   {object}  ErrorResponse
+// @Failure      403   {object}  ErrorResponse
+// @Failure      404   {object}  ErrorResponse
+// @Failure      409   {object}  ErrorResponse
+// @Failure      500   {object}  ErrorResponse
+// @Security     BearerToken
+// @Router       /links/{id}/aliases [post]
+func (h *aliasesAPIHandler) Add(w http.ResponseWriter, r *http.Request) {
+	link := h.ownedLink(w, r)
+	if link == nil {
+		return
+	}
+
+	var req AddAliasRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body", "BAD_REQUEST")
+		return
+	}
+	if req.Slug == "" {
+		writeRequiredField(w, "slug", "BAD_REQUEST")
+		return
+	}
+	if err := store.ValidateSlugFormat(req.Slug); err != nil {
+		if errors.Is(err, store.ErrSlugReserved) {
+			writeFieldError(w, http.StatusBadRequest, "slug", err.Error(), "INVALID_SLUG")
+			return
+		}
+		writeFieldError(w, http.StatusBadRequest, "slug", "slug must match [a-z0-9][a-z0-9-]*[a-z0-9]", "INVALID_SLUG")
+		return
+	}
+
+	alias, err := h.links.AddAlias(r.Context(), link.ID, req.Slug)
+	if err != nil {
+		// Governing: SPEC-0002 REQ "Reserved Slugs"
+		if errors.Is(err, store.ErrSlugReserved) {
+			writeFieldError(w, http.StatusBadRequest, "slug", err.Error(), "INVALID_SLUG")
+			return
+		}
+		if errors.Is(err, store.ErrSlugTaken) {
+			writeError(w, http.StatusConflict, "slug already exists", "SLUG_CONFLICT")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
+		return
+	}
+	writeJSON(w, http.StatusCreated, aliasResponse(alias))
+}
+
+// Remove deletes one of a link's aliases.
+// DELETE /api/v1/links/{id}/aliases/{slug}
+// Governing: SPEC-0005 REQ "Link Aliases API"
+//
+// @Summary      Remove an alias
+// @Description  Removes an alias slug from a link. Only owners and admins may remove aliases.
+// @Tags         Aliases
+// @Produce      json
+// @Param        id    path      string  true  "Link ID"
+// @Param        slug  path      string  true  "Alias slug"
+// @Success      204   "No Content"
+// @Failure      401   {object}  ErrorResponse
+// @Failure      403   {object}  ErrorResponse
+// @Failure      404   {object}  ErrorResponse
+// @Failure      500   {object}  ErrorResponse
+// @Security     BearerToken
+// @Router       /links/{id}/aliases/{slug} [delete]
+func (h *aliasesAPIHandler) Remove(w http.ResponseWriter, r *http.Request) {
+	link := h.ownedLink(w, r)
+	if link == nil {
+		return
+	}
+	if err := h.links.RemoveAlias(r.Context(), link.ID, chi.URLParam(r, "slug")); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			writeError(w, http.StatusNotFound, "alias not found", "NOT_FOUND")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func aliasResponse(a *store.Alias) AliasResponse {
+	return AliasResponse{Slug: a.Slug, LinkID: a.LinkID, CreatedAt: a.CreatedAt}
+}
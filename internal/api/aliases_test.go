@@ -0,0 +1,95 @@
+// Governing: SPEC-0005 REQ "Link Aliases API"
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/joestump/joe-links/internal/api"
+)
+
+func TestAliases_AddListRemove(t *testing.T) {
+	env := newTestEnv(t)
+	user := seedUser(t, env, "alias-owner@example.com", "user")
+	token := seedToken(t, env, user.ID)
+	ctx := context.Background()
+
+	link, err := env.LinkStore.Create(ctx, "vacation", "https://hr.example.com/pto", user.ID, "", "", "")
+	if err != nil {
+		t.Fatalf("create link: %v", err)
+	}
+	if _, err := env.LinkStore.Create(ctx, "payroll", "https://hr.example.com/pay", user.ID, "", "", ""); err != nil {
+		t.Fatalf("create link: %v", err)
+	}
+
+	post := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", "/links/"+link.ID+"/aliases", strings.NewReader(body))
+		authRequest(req, token)
+		rec := httptest.NewRecorder()
+		env.Router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := post(`{"slug":"pto"}`)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("add: status = %d; body: %s", rec.Code, rec.Body.String())
+	}
+	var created api.AliasResponse
+	if err := json.NewDecoder(rec.Body).Decode(&created); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if created.Slug != "pto" || created.LinkID != link.ID {
+		t.Errorf("created = %+v", created)
+	}
+
+	if rec := post(`{"slug":"payroll"}`); rec.Code != http.StatusConflict {
+		t.Errorf("conflict: status = %d, want %d", rec.Code, http.StatusConflict)
+	}
+	if rec := post(`{"slug":"Not Valid"}`); rec.Code != http.StatusBadRequest {
+		t.Errorf("invalid: status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+
+	req := httptest.NewRequest("GET", "/links/"+link.ID+"/aliases", nil)
+	authRequest(req, token)
+	rec = httptest.NewRecorder()
+	env.Router.ServeHTTP(rec, req)
+	var list []api.AliasResponse
+	if err := json.NewDecoder(rec.Body).Decode(&list); err != nil {
+		t.Fatalf("decode list: %v", err)
+	}
+	if len(list) != 1 || list[0].Slug != "pto" {
+		t.Errorf("list = %+v", list)
+	}
+
+	req = httptest.NewRequest("DELETE", "/links/"+link.ID+"/aliases/pto", nil)
+	authRequest(req, token)
+	rec = httptest.NewRecorder()
+	env.Router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNoContent {
+		t.Errorf("remove: status = %d, want %d", rec.Code, http.StatusNoContent)
+	}
+}
+
+func TestAliases_NonOwner_Forbidden(t *testing.T) {
+	env := newTestEnv(t)
+	owner := seedUser(t, env, "alias-owner2@example.com", "user")
+	other := seedUser(t, env, "alias-other@example.com", "user")
+	otherToken := seedToken(t, env, other.ID)
+
+	link, err := env.LinkStore.Create(context.Background(), "alias-forbidden", "https://example.com", owner.ID, "", "", "")
+	if err != nil {
+		t.Fatalf("create link: %v", err)
+	}
+
+	req := httptest.NewRequest("POST", "/links/"+link.ID+"/aliases", strings.NewReader(`{"slug":"mine-now"}`))
+	authRequest(req, otherToken)
+	rec := httptest.NewRecorder()
+	env.Router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusForbidden)
+	}
+}
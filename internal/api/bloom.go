@@ -0,0 +1,75 @@
+// Governing: SPEC-0008 REQ "Offline Slug Lookup"
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+
+	"github.com/joestump/joe-links/internal/auth"
+	"github.com/joestump/joe-links/internal/bloom"
+	"github.com/joestump/joe-links/internal/store"
+)
+
+// bloomHashName identifies the hashing scheme SlugBloomResponse describes,
+// so an extension can refuse a filter it does not know how to read.
+const bloomHashName = "fnv1a32+fnv1-32"
+
+// slugBloomAPIHandler serves the slug Bloom filter.
+type slugBloomAPIHandler struct {
+	links *store.LinkStore
+}
+
+// Get returns a Bloom filter of every slug and alias the caller can know
+// exists. The ETag changes only when that set does, so the extension polls
+// with If-None-Match and downloads the filter again only after a change.
+// GET /api/v1/links/bloom
+//
+// @Summary      Slug Bloom filter
+// @Description  Returns a Bloom filter (about 1% false positives) of the link slugs and aliases the caller can know exist,
+// @Description  so the browser extension can tell that go/x does not exist without a round trip and open the create page at once.
+// @Description  Secure links are included only for their owners, users they are shared with, and admins.
+// @Description  Send the returned ETag as If-None-Match to get 304 until a slug is added or removed.
+// @Tags         Links
+// @Produce      json
+// @Param        If-None-Match  header    string  false  "ETag of the filter the caller already has"
+// @Success      200            {object}  SlugBloomResponse
+// @Success      304            "Not Modified"
+// @Failure      401            {object}  ErrorResponse
+// @Failure      500            {object}  ErrorResponse
+// @Security     BearerToken
+// @Router       /links/bloom [get]
+func (h *slugBloomAPIHandler) Get(w http.ResponseWriter, r *http.Request) {
+	user := auth.UserFromContext(r.Context())
+	if user == nil {
+		writeError(w, http.StatusUnauthorized, "unauthorized", "UNAUTHORIZED")
+		return
+	}
+	slugs, err := h.links.ListKnownSlugs(r.Context(), user.ID, user.IsAdmin())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
+		return
+	}
+
+	sum := sha256.Sum256([]byte(bloomHashName + "\n" + strings.Join(slugs, "\n")))
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", defaultCacheControl)
+	if etagMatches(r, etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	f := bloom.New(len(slugs))
+	for _, s := range slugs {
+		f.Add(s)
+	}
+	writeJSON(w, http.StatusOK, SlugBloomResponse{
+		Hash:  bloomHashName,
+		Count: len(slugs),
+		M:     f.M,
+		K:     f.K,
+		Bits:  f.Bits,
+	})
+}
@@ -0,0 +1,70 @@
+// Governing: SPEC-0008 REQ "Offline Slug Lookup"
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/joestump/joe-links/internal/api"
+	"github.com/joestump/joe-links/internal/bloom"
+)
+
+func TestSlugBloom(t *testing.T) {
+	env := newTestEnv(t)
+	alice := seedUser(t, env, "alice@example.com", "user")
+	bob := seedUser(t, env, "bob@example.com", "user")
+	aliceToken := seedToken(t, env, alice.ID)
+	ctx := context.Background()
+
+	wiki, err := env.LinkStore.Create(ctx, "wiki", "https://wiki.example.com", bob.ID, "", "", "private")
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if _, err := env.LinkStore.AddAlias(ctx, wiki.ID, "docs"); err != nil {
+		t.Fatalf("alias: %v", err)
+	}
+	if _, err := env.LinkStore.Create(ctx, "vault", "https://vault.example.com", bob.ID, "", "", "secure"); err != nil {
+		t.Fatalf("create secure: %v", err)
+	}
+
+	get := func(etag string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", "/links/bloom", nil)
+		authRequest(req, aliceToken)
+		if etag != "" {
+			req.Header.Set("If-None-Match", etag)
+		}
+		rec := httptest.NewRecorder()
+		env.Router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := get("")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d; body: %s", rec.Code, rec.Body.String())
+	}
+	var resp api.SlugBloomResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if resp.Count != 2 {
+		t.Errorf("count = %d, want 2 (bob's secure link hidden from alice)", resp.Count)
+	}
+	f := &bloom.Filter{M: resp.M, K: resp.K, Bits: resp.Bits}
+	if !f.Test("wiki") || !f.Test("docs") {
+		t.Error("filter is missing wiki or its alias docs")
+	}
+
+	etag := rec.Header().Get("ETag")
+	if rec := get(etag); rec.Code != http.StatusNotModified {
+		t.Errorf("unchanged slugs: status = %d, want 304", rec.Code)
+	}
+	if _, err := env.LinkStore.Create(ctx, "jira", "https://jira.example.com", alice.ID, "", "", "public"); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if rec := get(etag); rec.Code != http.StatusOK {
+		t.Errorf("after a new link: status = %d, want 200", rec.Code)
+	}
+}
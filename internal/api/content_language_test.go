@@ -0,0 +1,46 @@
+// Governing: SPEC-0004 REQ "Internationalization"
+package api_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestErrors_TranslatedForAcceptLanguage(t *testing.T) {
+	env := newTestEnv(t)
+	user := seedUser(t, env, "i18n@example.com", "user")
+	token := seedToken(t, env, user.ID)
+
+	for _, tc := range []struct {
+		acceptLanguage, wantLang, wantError string
+	}{
+		{"", "en", "not found"},
+		{"de-DE,de;q=0.9", "de", "nicht gefunden"},
+		{"fr", "en", "not found"},
+	} {
+		req := httptest.NewRequest("GET", "/links/does-not-exist", nil)
+		authRequest(req, token)
+		req.Header.Set("Accept-Language", tc.acceptLanguage)
+		rec := httptest.NewRecorder()
+		env.Router.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusNotFound {
+			t.Fatalf("%q: status = %d, want 404", tc.acceptLanguage, rec.Code)
+		}
+		if got := rec.Header().Get("Content-Language"); got != tc.wantLang {
+			t.Errorf("%q: Content-Language = %q, want %q", tc.acceptLanguage, got, tc.wantLang)
+		}
+		var body struct {
+			Error string `json:"error"`
+			Code  string `json:"code"`
+		}
+		if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		if body.Error != tc.wantError || body.Code != "NOT_FOUND" {
+			t.Errorf("%q: error = %q (%s), want %q", tc.acceptLanguage, body.Error, body.Code, tc.wantError)
+		}
+	}
+}
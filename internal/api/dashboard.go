@@ -0,0 +1,114 @@
+// Governing: SPEC-0005 REQ "Dashboard Endpoint"
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/joestump/joe-links/internal/auth"
+	"github.com/joestump/joe-links/internal/store"
+)
+
+// dashboardAPIHandler serves the aggregate view dashboard-like clients would
+// otherwise assemble from one request per link.
+// Governing: SPEC-0005 REQ "Dashboard Endpoint"
+type dashboardAPIHandler struct {
+	links     *store.LinkStore
+	ownership *store.OwnershipStore
+	users     *store.UserStore
+	tags      *store.TagStore
+	clicks    *store.ClickStore
+}
+
+// Get returns the caller, the links they can see with their shares and click
+// stats, and every tag.
+// GET /api/v1/dashboard
+// Governing: SPEC-0005 REQ "Dashboard Endpoint"
+//
+// @Summary      Get the dashboard
+// @Description  Returns the caller, the links GET /links would return for the same q, and every tag, in one response.
+// @Description  Each link's owners, shares, and click stats are null unless the caller owns the link or is an admin.
+// @Description  referrers=all and bots=include widen the stats as they do for GET /links/{id}/stats.
+// @Tags         Links
+// @Accept       json
+// @Produce      json
+// @Param        q          query     string  false  "Search text and key:value filters"
+// @Param        referrers  query     string  false  "all counts clicks from excluded referrers"
+// @Param        bots       query     string  false  "include counts clicks flagged as bots"
+// @Success      200        {object}  DashboardResponse
+// @Failure      400        {object}  ErrorResponse
+// @Failure      401        {object}  ErrorResponse
+// @Failure      500        {object}  ErrorResponse
+// @Security     BearerToken
+// @Router       /dashboard [get]
+func (h *dashboardAPIHandler) Get(w http.ResponseWriter, r *http.Request) {
+	user := auth.UserFromContext(r.Context())
+	if user == nil {
+		writeError(w, http.StatusUnauthorized, "unauthorized", "UNAUTHORIZED")
+		return
+	}
+
+	links, err := listVisibleLinks(r.Context(), h.links, user, r.URL.Query().Get("q"), "")
+	if errors.Is(err, store.ErrInvalidFilter) {
+		writeError(w, http.StatusBadRequest, err.Error(), "INVALID_FILTER")
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
+		return
+	}
+
+	// Governing: SPEC-0016 REQ "Referrer Exclusion", REQ "Bot Filtering"
+	clicks := clicksFor(r, h.clicks)
+	resp := &DashboardResponse{
+		Me: &UserResponse{
+			ID:          user.ID,
+			Email:       user.Email,
+			DisplayName: user.DisplayName,
+			Role:        user.Role,
+			CreatedAt:   user.CreatedAt,
+		},
+		Links: make([]*DashboardLinkResponse, 0, len(links)),
+	}
+	for _, l := range links {
+		lr, err := buildLinkResponse(r.Context(), h.links, h.ownership, l)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
+			return
+		}
+		entry := &DashboardLinkResponse{Link: lr}
+
+		// Owners, shares, and stats follow GET /links/{id}/owners,
+		// /shares, and /stats: owners and admins only.
+		manage, err := store.IsOwnerOrAdmin(h.ownership, l.ID, user.ID, user.Role)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
+			return
+		}
+		if !manage {
+			lr.Owners = nil
+		} else {
+			if entry.Shares, err = buildShareResponses(r.Context(), h.links, h.users, l.ID); err != nil {
+				writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
+				return
+			}
+			if entry.Stats, err = buildStatsResponse(r.Context(), clicks, l.ID); err != nil {
+				writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
+				return
+			}
+		}
+		resp.Links = append(resp.Links, entry)
+	}
+
+	tags, err := h.tags.ListWithCounts(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
+		return
+	}
+	resp.Tags = make([]*TagResponse, 0, len(tags))
+	for _, t := range tags {
+		resp.Tags = append(resp.Tags, &TagResponse{Slug: t.Slug, Name: t.Name, LinkCount: t.Count})
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
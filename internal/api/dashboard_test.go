@@ -0,0 +1,150 @@
+// Governing: SPEC-0005 REQ "Dashboard Endpoint"
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/joestump/joe-links/internal/store"
+)
+
+type dashboardResult struct {
+	Me struct {
+		Email string `json:"email"`
+	} `json:"me"`
+	Links []struct {
+		Link struct {
+			Slug   string          `json:"slug"`
+			Tags   []string        `json:"tags"`
+			Owners json.RawMessage `json:"owners"`
+		} `json:"link"`
+		Shares json.RawMessage `json:"shares"`
+		Stats  json.RawMessage `json:"stats"`
+	} `json:"links"`
+	Tags []struct {
+		Slug      string `json:"slug"`
+		LinkCount int    `json:"link_count"`
+	} `json:"tags"`
+}
+
+func getDashboard(t *testing.T, env *testEnv, token, target string) (int, dashboardResult) {
+	t.Helper()
+	req := httptest.NewRequest("GET", target, nil)
+	authRequest(req, token)
+	rec := httptest.NewRecorder()
+	env.Router.ServeHTTP(rec, req)
+	var res dashboardResult
+	if rec.Code == http.StatusOK {
+		if err := json.Unmarshal(rec.Body.Bytes(), &res); err != nil {
+			t.Fatalf("decode %s: %v", rec.Body.String(), err)
+		}
+	}
+	return rec.Code, res
+}
+
+func TestDashboard_OneRoundTrip(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+	alice := seedUser(t, env, "alice@example.com", "user")
+	bob := seedUser(t, env, "bob@example.com", "user")
+	token := seedToken(t, env, alice.ID)
+	link, err := env.LinkStore.Create(ctx, "docs", "https://docs.example.com", alice.ID, "Docs", "", "public")
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if err := env.LinkStore.SetTags(ctx, link.ID, []string{"wiki"}); err != nil {
+		t.Fatalf("tags: %v", err)
+	}
+	if err := env.LinkStore.AddShare(ctx, link.ID, bob.ID, alice.ID); err != nil {
+		t.Fatalf("share: %v", err)
+	}
+	for _, bot := range []bool{false, true} {
+		if err := env.ClickStore.RecordClick(ctx, store.ClickEvent{LinkID: link.ID, Bot: bot}); err != nil {
+			t.Fatalf("click: %v", err)
+		}
+	}
+
+	code, res := getDashboard(t, env, token, "/dashboard")
+	if code != http.StatusOK {
+		t.Fatalf("status = %d", code)
+	}
+	if res.Me.Email != "alice@example.com" {
+		t.Errorf("me = %+v", res.Me)
+	}
+	if len(res.Links) != 1 {
+		t.Fatalf("links = %+v, want one", res.Links)
+	}
+	got := res.Links[0]
+	if got.Link.Slug != "docs" || len(got.Link.Tags) != 1 || got.Link.Tags[0] != "wiki" {
+		t.Errorf("link = %+v", got.Link)
+	}
+	var owners []struct {
+		Email string `json:"email"`
+	}
+	if err := json.Unmarshal(got.Link.Owners, &owners); err != nil || len(owners) != 1 || owners[0].Email != "alice@example.com" {
+		t.Errorf("owners = %s", got.Link.Owners)
+	}
+	var shares []struct {
+		Email string `json:"email"`
+	}
+	if err := json.Unmarshal(got.Shares, &shares); err != nil || len(shares) != 1 || shares[0].Email != "bob@example.com" {
+		t.Errorf("shares = %s", got.Shares)
+	}
+	var stats struct {
+		Total int64 `json:"total"`
+	}
+	if err := json.Unmarshal(got.Stats, &stats); err != nil || stats.Total != 1 {
+		t.Errorf("stats = %s, want a total of 1 without the bot click", got.Stats)
+	}
+	if len(res.Tags) != 1 || res.Tags[0].Slug != "wiki" || res.Tags[0].LinkCount != 1 {
+		t.Errorf("tags = %+v", res.Tags)
+	}
+
+	// Stats are read through the same filters as GET /links/{id}/stats.
+	_, res = getDashboard(t, env, token, "/dashboard?bots=include")
+	if err := json.Unmarshal(res.Links[0].Stats, &stats); err != nil || stats.Total != 2 {
+		t.Errorf("bots=include: stats = %s, want a total of 2", res.Links[0].Stats)
+	}
+
+	if code, _ := getDashboard(t, env, token, "/dashboard?q=visibility:hidden"); code != http.StatusBadRequest {
+		t.Errorf("invalid filter: status = %d, want 400", code)
+	}
+}
+
+func TestDashboard_SharedLinkHidesOwnersSharesAndStats(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+	alice := seedUser(t, env, "alice@example.com", "user")
+	bob := seedUser(t, env, "bob@example.com", "user")
+	link, err := env.LinkStore.Create(ctx, "plan", "https://example.com/plan", alice.ID, "", "", "private")
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if err := env.LinkStore.AddShare(ctx, link.ID, bob.ID, alice.ID); err != nil {
+		t.Fatalf("share: %v", err)
+	}
+
+	code, res := getDashboard(t, env, seedToken(t, env, bob.ID), "/dashboard")
+	if code != http.StatusOK {
+		t.Fatalf("status = %d", code)
+	}
+	if len(res.Links) != 1 || res.Links[0].Link.Slug != "plan" {
+		t.Fatalf("links = %+v, want the shared link", res.Links)
+	}
+	got := res.Links[0]
+	if string(got.Link.Owners) != "null" || string(got.Shares) != "null" || string(got.Stats) != "null" {
+		t.Errorf("owners = %s, shares = %s, stats = %s; want all null", got.Link.Owners, got.Shares, got.Stats)
+	}
+
+	// The REST owners endpoint refuses the same caller.
+	req := httptest.NewRequest("GET", "/links/"+link.ID+"/owners", nil)
+	authRequest(req, seedToken(t, env, bob.ID))
+	rec := httptest.NewRecorder()
+	env.Router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("GET /links/{id}/owners: status = %d, want 403", rec.Code)
+	}
+}
@@ -0,0 +1,19 @@
+// Governing: SPEC-0001 REQ "Demo Mode"
+package api
+
+import (
+	"net/http"
+
+	"github.com/joestump/joe-links/internal/demo"
+)
+
+// demoGuard rejects destructive admin actions on a demo instance with 403.
+func demoGuard(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if demo.IsDestructive(r) {
+			writeError(w, http.StatusForbidden, "disabled on the demo instance", "DEMO_MODE")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
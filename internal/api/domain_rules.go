@@ -0,0 +1,116 @@
+// Governing: SPEC-0002 REQ "Destination Domain Rules"
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/joestump/joe-links/internal/store"
+)
+
+// ListDomainRules returns the destination domain rules.
+// GET /api/v1/admin/domain-rules
+//
+// @Summary      List domain rules (admin)
+// @Description  Returns the allow and deny rules for link destinations in domain order. Requires admin role.
+// @Tags         Admin
+// @Produce      json
+// @Success      200  {array}   DomainRuleResponse
+// @Failure      401  {object}  ErrorResponse
+// @Failure      403  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Security     BearerToken
+// @Router       /admin/domain-rules [get]
+func (h *adminAPIHandler) ListDomainRules(w http.ResponseWriter, r *http.Request) {
+	rules, err := h.domains.List(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
+		return
+	}
+	resp := make([]DomainRuleResponse, 0, len(rules))
+	for _, dr := range rules {
+		resp = append(resp, domainRuleResponse(dr))
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// AddDomainRule allows or denies link destinations on a domain.
+// POST /api/v1/admin/domain-rules
+//
+// @Summary      Add a domain rule (admin)
+// @Description  Allows or denies link destinations on a domain and its subdomains; the most specific rule wins.
+// @Description  Once any allow rule exists, destinations no rule allows are refused. Links already saved are only
+// @Description  checked when their destination changes. Requires admin role.
+// @Tags         Admin
+// @Accept       json
+// @Produce      json
+// @Param        body  body      AddDomainRuleRequest  true  "Rule to add"
+// @Success      201   {object}  DomainRuleResponse
+// @Failure      400   {object}  ErrorResponse
+// @Failure      401   {object}  ErrorResponse
+// @Failure      403   {object}  ErrorResponse
+// @Failure      409   {object}  ErrorResponse
+// @Failure      500   {object}  ErrorResponse
+// @Security     BearerToken
+// @Router       /admin/domain-rules [post]
+func (h *adminAPIHandler) AddDomainRule(w http.ResponseWriter, r *http.Request) {
+	var req AddDomainRuleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body", "BAD_REQUEST")
+		return
+	}
+
+	dr, err := h.domains.Add(r.Context(), req.Domain, req.Action, req.Note)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrInvalidDomain):
+			writeError(w, http.StatusBadRequest, "domain must be a host name or a URL", "INVALID_DOMAIN")
+		case errors.Is(err, store.ErrInvalidDomainAction):
+			writeError(w, http.StatusBadRequest, err.Error(), "BAD_REQUEST")
+		case errors.Is(err, store.ErrDomainRuleExists):
+			writeError(w, http.StatusConflict, err.Error(), "DOMAIN_RULE_CONFLICT")
+		default:
+			writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
+		}
+		return
+	}
+	writeJSON(w, http.StatusCreated, domainRuleResponse(dr))
+}
+
+// RemoveDomainRule deletes a domain rule.
+// DELETE /api/v1/admin/domain-rules/{domain}
+//
+// @Summary      Remove a domain rule (admin)
+// @Description  Deletes the rule for a domain. Removing the last allow rule lifts the allowlist. Requires admin role.
+// @Tags         Admin
+// @Produce      json
+// @Param        domain  path  string  true  "Rule domain"
+// @Success      204     "No Content"
+// @Failure      401     {object}  ErrorResponse
+// @Failure      403     {object}  ErrorResponse
+// @Failure      404     {object}  ErrorResponse
+// @Failure      500     {object}  ErrorResponse
+// @Security     BearerToken
+// @Router       /admin/domain-rules/{domain} [delete]
+func (h *adminAPIHandler) RemoveDomainRule(w http.ResponseWriter, r *http.Request) {
+	if err := h.domains.Remove(r.Context(), chi.URLParam(r, "domain")); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			writeError(w, http.StatusNotFound, "domain rule not found", "NOT_FOUND")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func domainRuleResponse(dr *store.DomainRule) DomainRuleResponse {
+	return DomainRuleResponse{
+		Domain:    dr.Domain,
+		Action:    dr.Action,
+		Note:      dr.Note,
+		CreatedAt: dr.CreatedAt,
+	}
+}
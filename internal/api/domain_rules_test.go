@@ -0,0 +1,76 @@
+// Governing: SPEC-0002 REQ "Destination Domain Rules"
+package api_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/joestump/joe-links/internal/api"
+)
+
+func TestDomainRules(t *testing.T) {
+	env := newTestEnv(t)
+	admin := seedUser(t, env, "admin@example.com", "admin")
+	user := seedUser(t, env, "user@example.com", "user")
+	adminToken := seedToken(t, env, admin.ID)
+	userToken := seedToken(t, env, user.ID)
+
+	do := func(token, method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		authRequest(req, token)
+		rec := httptest.NewRecorder()
+		env.Router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	if rec := do(userToken, "POST", "/admin/domain-rules", `{"domain":"bit.ly","action":"deny"}`); rec.Code != http.StatusForbidden {
+		t.Errorf("non-admin add: status = %d, want 403", rec.Code)
+	}
+	if rec := do(adminToken, "POST", "/admin/domain-rules", `{"domain":"bit.ly","action":"block"}`); rec.Code != http.StatusBadRequest {
+		t.Errorf("bad action: status = %d, want 400", rec.Code)
+	}
+	rec := do(adminToken, "POST", "/admin/domain-rules", `{"domain":"https://bit.ly/abc","action":"deny","note":"URL shorteners"}`)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("add: status = %d; body: %s", rec.Code, rec.Body.String())
+	}
+	var rule api.DomainRuleResponse
+	if err := json.NewDecoder(rec.Body).Decode(&rule); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if rule.Domain != "bit.ly" {
+		t.Errorf("domain = %q, want bit.ly", rule.Domain)
+	}
+	if rec := do(adminToken, "POST", "/admin/domain-rules", `{"domain":"bit.ly","action":"allow"}`); rec.Code != http.StatusConflict {
+		t.Errorf("duplicate: status = %d, want 409", rec.Code)
+	}
+
+	rec = do(userToken, "POST", "/links", `{"slug":"short","url":"https://www.bit.ly/x"}`)
+	if rec.Code != http.StatusBadRequest || !strings.Contains(rec.Body.String(), "DOMAIN_NOT_ALLOWED") || !strings.Contains(rec.Body.String(), "URL shorteners") {
+		t.Fatalf("denied create: status = %d, body %s; want 400 DOMAIN_NOT_ALLOWED naming the rule", rec.Code, rec.Body.String())
+	}
+
+	rec = do(userToken, "POST", "/links", `{"slug":"wiki","url":"https://wiki.example.com"}`)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("allowed create: status = %d; body: %s", rec.Code, rec.Body.String())
+	}
+	var link api.LinkResponse
+	if err := json.NewDecoder(rec.Body).Decode(&link); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	rec = do(userToken, "PUT", "/links/"+link.ID, `{"url":"https://bit.ly/wiki"}`)
+	if rec.Code != http.StatusBadRequest || !strings.Contains(rec.Body.String(), "DOMAIN_NOT_ALLOWED") {
+		t.Errorf("denied update: status = %d, body %s; want 400 DOMAIN_NOT_ALLOWED", rec.Code, rec.Body.String())
+	}
+
+	if rec := do(adminToken, "DELETE", "/admin/domain-rules/bit.ly", ""); rec.Code != http.StatusNoContent {
+		t.Errorf("remove: status = %d, want 204", rec.Code)
+	}
+	if rec := do(userToken, "POST", "/links", `{"slug":"short","url":"https://bit.ly/x"}`); rec.Code != http.StatusCreated {
+		t.Errorf("after removing the rule: status = %d, want 201", rec.Code)
+	}
+}
@@ -5,11 +5,14 @@ import (
 	"encoding/json"
 	"net/http"
 	"strings"
+
+	"github.com/joestump/joe-links/internal/i18n"
 )
 
 type errorBody struct {
-	Error string `json:"error"`
-	Code  string `json:"code"`
+	Error  string       `json:"error"`
+	Code   string       `json:"code"`
+	Errors []FieldError `json:"errors,omitempty"`
 }
 
 // writeError writes a JSON error response with the given HTTP status code.
@@ -17,7 +20,42 @@ type errorBody struct {
 func writeError(w http.ResponseWriter, status int, message, code string) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(status)
-	_ = json.NewEncoder(w).Encode(errorBody{Error: message, Code: code})
+	_ = json.NewEncoder(w).Encode(errorBody{Error: localize(w, message), Code: code})
+}
+
+// localize translates message into the response's Content-Language, which
+// the contentLanguage middleware sets from Accept-Language.
+// Governing: SPEC-0004 REQ "Internationalization"
+func localize(w http.ResponseWriter, message string, args ...any) string {
+	return i18n.T(w.Header().Get("Content-Language"), message, args...)
+}
+
+// writeFieldError writes a JSON error response attributed to a single request
+// field. The top-level error and code mirror the field error so clients that
+// only read those keep working.
+// Governing: SPEC-0005 REQ "Standard Error Response Format"
+func writeFieldError(w http.ResponseWriter, status int, field, message, code string) {
+	message = localize(w, message)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(errorBody{
+		Error:  message,
+		Code:   code,
+		Errors: []FieldError{{Field: field, Code: code, Message: message}},
+	})
+}
+
+// writeRequiredField writes a 400 response for a missing required field. The
+// field error carries the REQUIRED code; code is the legacy top-level code.
+func writeRequiredField(w http.ResponseWriter, field, code string) {
+	message := localize(w, "%s is required", field)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadRequest)
+	_ = json.NewEncoder(w).Encode(errorBody{
+		Error:  message,
+		Code:   code,
+		Errors: []FieldError{{Field: field, Code: "REQUIRED", Message: message}},
+	})
 }
 
 // isDBLockError reports whether err is a database locking/busy error.
@@ -0,0 +1,124 @@
+// Governing: SPEC-0005 REQ "Conditional Requests"
+package api
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// defaultCacheControl lets clients keep API responses but makes them
+// revalidate every time; responses are per-user, so shared caches may not.
+const defaultCacheControl = "private, no-cache"
+
+// conditionalGET adds an ETag to successful GET responses and answers
+// requests whose If-None-Match already names it with 304 Not Modified, so
+// polling clients such as the browser extension skip the body when nothing
+// changed. Handlers that set their own ETag (computed more cheaply than by
+// rendering the body) are passed through untouched, as are responses marked
+// no-store.
+func conditionalGET(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			next.ServeHTTP(w, r)
+			return
+		}
+		bw := &bufferedWriter{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(bw, r)
+
+		h := w.Header()
+		if bw.status != http.StatusOK || h.Get("ETag") != "" || strings.Contains(h.Get("Cache-Control"), "no-store") {
+			bw.flush()
+			return
+		}
+		sum := sha256.Sum256(bw.body.Bytes())
+		etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+		h.Set("ETag", etag)
+		if h.Get("Cache-Control") == "" {
+			h.Set("Cache-Control", defaultCacheControl)
+		}
+		if etagMatches(r, etag) {
+			h.Del("Content-Length")
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		bw.flush()
+	})
+}
+
+// representationETag returns the strong ETag conditionalGET would give v when
+// written with writeJSON, so write responses and preconditions agree with GET.
+// Governing: SPEC-0005 REQ "Conditional Requests"
+func representationETag(v any) string {
+	var buf bytes.Buffer
+	_ = json.NewEncoder(&buf).Encode(v)
+	sum := sha256.Sum256(buf.Bytes())
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// checkIfMatch enforces the request's If-Match header against etag, the
+// current representation of the resource about to be changed, writing 412
+// Precondition Failed and returning false when none of the listed tags
+// match. Strong comparison is used, as RFC 9110 requires for If-Match.
+// Governing: SPEC-0005 REQ "Conditional Requests"
+func checkIfMatch(w http.ResponseWriter, r *http.Request, etag string) bool {
+	header := r.Header.Get("If-Match")
+	if header == "" || strings.TrimSpace(header) == "*" {
+		return true
+	}
+	for _, tag := range strings.Split(header, ",") {
+		if strings.TrimSpace(tag) == etag {
+			return true
+		}
+	}
+	writeError(w, http.StatusPreconditionFailed, "the resource has changed since it was read", "PRECONDITION_FAILED")
+	return false
+}
+
+// etagMatches reports whether the request's If-None-Match header lists etag.
+// Weak comparison is used, as RFC 9110 requires for If-None-Match.
+func etagMatches(r *http.Request, etag string) bool {
+	header := r.Header.Get("If-None-Match")
+	if header == "" {
+		return false
+	}
+	if strings.TrimSpace(header) == "*" {
+		return true
+	}
+	want := strings.TrimPrefix(etag, "W/")
+	for _, tag := range strings.Split(header, ",") {
+		if strings.TrimPrefix(strings.TrimSpace(tag), "W/") == want {
+			return true
+		}
+	}
+	return false
+}
+
+// bufferedWriter holds a response back until the handler returns.
+type bufferedWriter struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+	body        bytes.Buffer
+}
+
+func (bw *bufferedWriter) WriteHeader(status int) {
+	if !bw.wroteHeader {
+		bw.status = status
+		bw.wroteHeader = true
+	}
+}
+
+func (bw *bufferedWriter) Write(b []byte) (int, error) {
+	bw.wroteHeader = true
+	return bw.body.Write(b)
+}
+
+// flush sends the buffered status and body to the client.
+func (bw *bufferedWriter) flush() {
+	bw.ResponseWriter.WriteHeader(bw.status)
+	_, _ = bw.ResponseWriter.Write(bw.body.Bytes())
+}
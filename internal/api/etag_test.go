@@ -0,0 +1,65 @@
+// Governing: SPEC-0005 REQ "Conditional Requests"
+package api_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestConditionalGET(t *testing.T) {
+	env := newTestEnv(t)
+	user := seedUser(t, env, "etag@example.com", "user")
+	token := seedToken(t, env, user.ID)
+	ctx := context.Background()
+
+	link, err := env.LinkStore.Create(ctx, "wiki", "https://wiki.example.com", user.ID, "", "", "public")
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	do := func(method, path, etag, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		authRequest(req, token)
+		if etag != "" {
+			req.Header.Set("If-None-Match", etag)
+		}
+		rec := httptest.NewRecorder()
+		env.Router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	for _, path := range []string{"/links", "/links/" + link.ID, "/links/" + link.ID + "/stats"} {
+		rec := do("GET", path, "", "")
+		if rec.Code != http.StatusOK {
+			t.Fatalf("GET %s: status = %d; body: %s", path, rec.Code, rec.Body.String())
+		}
+		etag := rec.Header().Get("ETag")
+		if etag == "" {
+			t.Fatalf("GET %s: missing ETag", path)
+		}
+		if cc := rec.Header().Get("Cache-Control"); cc != "private, no-cache" {
+			t.Errorf("GET %s: Cache-Control = %q, want private, no-cache", path, cc)
+		}
+		rec = do("GET", path, `"stale", W/`+etag, "")
+		if rec.Code != http.StatusNotModified || rec.Body.Len() != 0 {
+			t.Errorf("GET %s with matching If-None-Match: status = %d, body %d bytes; want 304 and no body", path, rec.Code, rec.Body.Len())
+		}
+	}
+
+	// Changing the link changes the representation and so the ETag.
+	etag := do("GET", "/links/"+link.ID, "", "").Header().Get("ETag")
+	if rec := do("PUT", "/links/"+link.ID, "", `{"url": "https://wiki.example.com/v2"}`); rec.Code != http.StatusOK {
+		t.Fatalf("update: status = %d; body: %s", rec.Code, rec.Body.String())
+	}
+	if rec := do("GET", "/links/"+link.ID, etag, ""); rec.Code != http.StatusOK {
+		t.Errorf("GET after update: status = %d, want 200", rec.Code)
+	}
+
+	// Errors are never cached.
+	if rec := do("GET", "/links/missing", "", ""); rec.Code != http.StatusNotFound || rec.Header().Get("ETag") != "" {
+		t.Errorf("GET missing link: status = %d, ETag %q; want 404 without ETag", rec.Code, rec.Header().Get("ETag"))
+	}
+}
@@ -0,0 +1,394 @@
+// Governing: SPEC-0005 REQ "gRPC Links Service"
+package api
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/joestump/joe-links/internal/api/linkspb"
+	"github.com/joestump/joe-links/internal/auth"
+	"github.com/joestump/joe-links/internal/store"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// RegisterGRPCServer registers the LinksService on s. Callers authenticate
+// with an API token sent as "authorization: Bearer <token>" metadata, or with
+// a client certificate verified by the server's TLS config, which acts as the
+// user named by the certificate's email address (or common name). Token
+// scopes apply as on /api/v1: reads need links:read, CreateLink links:write.
+// Governing: SPEC-0005 REQ "gRPC Links Service"
+func RegisterGRPCServer(s grpc.ServiceRegistrar, deps Deps) {
+	linksH := &linksAPIHandler{links: deps.LinkStore, ownership: deps.OwnershipStore, users: deps.UserStore, teams: deps.TeamStore, policies: deps.PolicyStore, notify: deps.Notifier, idempotency: deps.IdempotencyStore}
+	linkspb.RegisterLinksServiceServer(s, &grpcLinksServer{
+		bearer:    deps.BearerMiddleware,
+		links:     deps.LinkStore,
+		ownership: deps.OwnershipStore,
+		users:     deps.UserStore,
+		tenants:   deps.TenantStore,
+		tester:    deps.ResolveTester,
+		usage:     deps.UsageRecorder,
+		create:    linksH.Create,
+	})
+}
+
+// grpcLinksServer implements linkspb.LinksServiceServer. Reads go straight to
+// the stores, since Resolve in particular is called at high volume; CreateLink
+// runs the REST create handler so validation, policies, and idempotency keys
+// behave identically on both transports.
+type grpcLinksServer struct {
+	linkspb.UnimplementedLinksServiceServer
+
+	bearer    *auth.BearerTokenMiddleware
+	links     *store.LinkStore
+	ownership *store.OwnershipStore
+	users     *store.UserStore
+	tenants   *store.TenantStore // nil unless multi-tenancy is enabled
+	tester    ResolveTester      // nil disables Resolve
+	usage     *UsageRecorder     // nil disables usage recording
+	create    http.HandlerFunc
+}
+
+var (
+	errGRPCUnauthenticated = status.Error(codes.Unauthenticated, "unauthorized")
+	errGRPCInternal        = status.Error(codes.Internal, "internal error")
+)
+
+// authenticate identifies the caller, scopes ctx to the tenant serving the
+// call's :authority when multi-tenancy is enabled, checks that the caller
+// holds scope, and records the call against the caller's token.
+// Governing: SPEC-0006 REQ "Bearer Token Middleware", REQ "Token Scopes", REQ "API Usage Tracking"
+func (s *grpcLinksServer) authenticate(ctx context.Context, method, scope string) (context.Context, *store.User, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if s.tenants != nil {
+		var host string
+		if v := md.Get(":authority"); len(v) > 0 {
+			host = v[0]
+		}
+		tenantID, err := s.tenants.Resolve(ctx, host)
+		if err != nil {
+			return nil, nil, status.Error(codes.Unavailable, "tenant unavailable")
+		}
+		ctx = store.WithTenant(ctx, tenantID)
+	}
+
+	if v := md.Get("authorization"); len(v) > 0 {
+		token, ok := strings.CutPrefix(v[0], "Bearer ")
+		if !ok {
+			return nil, nil, errGRPCUnauthenticated
+		}
+		// Governing: SPEC-0006 REQ "Token Brute-Force Lockout"
+		authed, err := s.bearer.AuthenticateTokenFrom(ctx, peerIP(ctx), token)
+		if errors.Is(err, auth.ErrLockedOut) {
+			return nil, nil, status.Error(codes.ResourceExhausted, "too many failed authentication attempts")
+		}
+		if err != nil {
+			return nil, nil, errGRPCUnauthenticated
+		}
+		ctx = authed
+	} else if email := peerCertEmail(ctx); email != "" {
+		// Governing: SPEC-0005 REQ "gRPC Links Service" — mTLS callers act as a user
+		user, err := s.users.GetByEmail(ctx, email)
+		if err != nil {
+			return nil, nil, errGRPCUnauthenticated
+		}
+		ctx = context.WithValue(ctx, auth.UserContextKey, user)
+	} else {
+		return nil, nil, errGRPCUnauthenticated
+	}
+
+	if !auth.HasScope(ctx, scope) {
+		return nil, nil, status.Errorf(codes.PermissionDenied, "token lacks the %s scope", scope)
+	}
+	if s.usage != nil {
+		s.usage.recordCall(ctx, "gRPC "+method)
+	}
+	return ctx, auth.UserFromContext(ctx), nil
+}
+
+// peerIP returns the caller's IP address, or "" when it is unknown.
+func peerIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
+
+// peerCertEmail returns the email address of the client certificate the TLS
+// handshake verified, falling back to its common name, or "" when the call
+// carried no verified certificate.
+func peerCertEmail(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return ""
+	}
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(info.State.VerifiedChains) == 0 || len(info.State.VerifiedChains[0]) == 0 {
+		return ""
+	}
+	return certEmail(info.State.VerifiedChains[0][0])
+}
+
+func certEmail(cert *x509.Certificate) string {
+	if len(cert.EmailAddresses) > 0 {
+		return cert.EmailAddresses[0]
+	}
+	if strings.Contains(cert.Subject.CommonName, "@") {
+		return cert.Subject.CommonName
+	}
+	return ""
+}
+
+// Resolve returns where a short-link path sends the caller.
+// Governing: SPEC-0005 REQ "Resolve Endpoint"
+func (s *grpcLinksServer) Resolve(ctx context.Context, req *linkspb.ResolveRequest) (*linkspb.ResolveResponse, error) {
+	ctx, user, err := s.authenticate(ctx, linkspb.LinksService_Resolve_FullMethodName, auth.ScopeLinksRead)
+	if err != nil {
+		return nil, err
+	}
+	if s.tester == nil {
+		return nil, status.Error(codes.Unimplemented, "resolve is not available")
+	}
+	path := strings.TrimPrefix(strings.TrimSpace(req.GetPath()), "/")
+	if path == "" {
+		return nil, status.Error(codes.InvalidArgument, "path is required")
+	}
+
+	res := s.tester.TestResolve(ctx, path, req.GetHost(), user)
+	switch res.Outcome {
+	case ResolveOutcomeNotFound:
+		return nil, status.Error(codes.NotFound, "no link or keyword matches path")
+	case ResolveOutcomeForbidden, ResolveOutcomeLoginRequired:
+		return nil, status.Error(codes.PermissionDenied, "you do not have access to this link")
+	}
+	resp := &linkspb.ResolveResponse{
+		Path:    res.Path,
+		Outcome: res.Outcome,
+		Target:  res.Target,
+		Keyword: res.Keyword,
+	}
+	if res.Link != nil {
+		resp.LinkId, resp.Slug = res.Link.ID, res.Link.Slug
+	}
+	for _, v := range res.Variables {
+		resp.Variables = append(resp.Variables, &linkspb.ResolveVariable{
+			Name:        v.Name,
+			Placeholder: v.Placeholder,
+			Value:       v.Value,
+			Default:     v.Default,
+			FromQuery:   v.FromQuery,
+		})
+	}
+	return resp, nil
+}
+
+// GetLink returns a link the caller may read, by ID or slug.
+// Governing: SPEC-0005 REQ "Link Resource"
+func (s *grpcLinksServer) GetLink(ctx context.Context, req *linkspb.GetLinkRequest) (*linkspb.Link, error) {
+	ctx, user, err := s.authenticate(ctx, linkspb.LinksService_GetLink_FullMethodName, auth.ScopeLinksRead)
+	if err != nil {
+		return nil, err
+	}
+	var link *store.Link
+	switch {
+	case req.GetId() != "" && req.GetSlug() != "":
+		return nil, status.Error(codes.InvalidArgument, "set only one of id and slug")
+	case req.GetId() != "":
+		link, err = s.links.GetByID(ctx, req.GetId())
+	case req.GetSlug() != "":
+		link, err = s.links.GetBySlug(ctx, req.GetSlug())
+	default:
+		return nil, status.Error(codes.InvalidArgument, "id or slug is required")
+	}
+	if errors.Is(err, store.ErrNotFound) {
+		return nil, status.Error(codes.NotFound, "link not found")
+	}
+	if err != nil {
+		return nil, errGRPCInternal
+	}
+	ok, err := canReadLink(ctx, s.links, s.ownership, user, link)
+	if err != nil {
+		return nil, errGRPCInternal
+	}
+	if !ok {
+		return nil, status.Error(codes.PermissionDenied, "forbidden")
+	}
+	lr, err := buildLinkResponse(ctx, s.links, s.ownership, link)
+	if err != nil {
+		return nil, errGRPCInternal
+	}
+	return toProtoLink(lr), nil
+}
+
+// ListLinks returns the links the caller may list, optionally filtered.
+// Governing: SPEC-0005 REQ "Links Collection"
+func (s *grpcLinksServer) ListLinks(ctx context.Context, req *linkspb.ListLinksRequest) (*linkspb.ListLinksResponse, error) {
+	ctx, user, err := s.authenticate(ctx, linkspb.LinksService_ListLinks_FullMethodName, auth.ScopeLinksRead)
+	if err != nil {
+		return nil, err
+	}
+	links, err := listVisibleLinks(ctx, s.links, user, req.GetQ(), req.GetUrl())
+	if errors.Is(err, store.ErrInvalidFilter) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		return nil, errGRPCInternal
+	}
+	resp := &linkspb.ListLinksResponse{Links: make([]*linkspb.Link, 0, len(links))}
+	for _, l := range links {
+		lr, err := buildLinkResponse(ctx, s.links, s.ownership, l)
+		if err != nil {
+			return nil, errGRPCInternal
+		}
+		resp.Links = append(resp.Links, toProtoLink(lr))
+	}
+	return resp, nil
+}
+
+// CreateLink creates a link with the caller as primary owner by running the
+// POST /api/v1/links handler in-process and translating its response.
+// Governing: SPEC-0005 REQ "Links Collection", REQ "Idempotent Link Creation"
+func (s *grpcLinksServer) CreateLink(ctx context.Context, req *linkspb.CreateLinkRequest) (*linkspb.Link, error) {
+	ctx, _, err := s.authenticate(ctx, linkspb.LinksService_CreateLink_FullMethodName, auth.ScopeLinksWrite)
+	if err != nil {
+		return nil, err
+	}
+	body, err := json.Marshal(CreateLinkRequest{
+		Slug:                req.GetSlug(),
+		URL:                 req.GetUrl(),
+		Title:               req.GetTitle(),
+		Description:         req.GetDescription(),
+		Visibility:          req.GetVisibility(),
+		Tags:                req.GetTags(),
+		VariableConstraints: req.GetVariableConstraints(),
+		RedirectType:        int(req.GetRedirectType()),
+		UTMParams:           req.GetUtmParams(),
+		PassQuery:           req.GetPassQuery(),
+		Team:                req.GetTeam(),
+	})
+	if err != nil {
+		return nil, errGRPCInternal
+	}
+	r, err := http.NewRequestWithContext(ctx, http.MethodPost, "/api/v1/links", bytes.NewReader(body))
+	if err != nil {
+		return nil, errGRPCInternal
+	}
+	r.Header.Set("Content-Type", "application/json")
+	if key := req.GetIdempotencyKey(); key != "" {
+		r.Header.Set("Idempotency-Key", key)
+	}
+	rec := &responseBuffer{header: http.Header{}, status: http.StatusOK}
+	s.create(rec, r)
+
+	if rec.status != http.StatusCreated {
+		return nil, grpcStatusFromHTTP(rec.status, rec.body.Bytes())
+	}
+	var lr LinkResponse
+	if err := json.Unmarshal(rec.body.Bytes(), &lr); err != nil {
+		return nil, errGRPCInternal
+	}
+	return toProtoLink(&lr), nil
+}
+
+// grpcStatusFromHTTP converts a REST error response into a gRPC status,
+// carrying the API error code as ErrorInfo and any field errors as
+// BadRequest field violations.
+// Governing: SPEC-0005 REQ "Standard Error Response Format"
+func grpcStatusFromHTTP(httpStatus int, body []byte) error {
+	var eb errorBody
+	if err := json.Unmarshal(body, &eb); err != nil || eb.Error == "" {
+		return errGRPCInternal
+	}
+	code := codes.Internal
+	switch httpStatus {
+	case http.StatusBadRequest:
+		code = codes.InvalidArgument
+	case http.StatusUnauthorized:
+		code = codes.Unauthenticated
+	case http.StatusForbidden:
+		code = codes.PermissionDenied
+	case http.StatusNotFound:
+		code = codes.NotFound
+	case http.StatusConflict:
+		code = codes.AlreadyExists
+	case http.StatusUnprocessableEntity:
+		code = codes.FailedPrecondition
+	case http.StatusServiceUnavailable:
+		code = codes.Unavailable
+	}
+	st := status.New(code, eb.Error)
+	details := []protoadapt.MessageV1{&errdetails.ErrorInfo{Reason: eb.Code, Domain: "joe-links"}}
+	if len(eb.Errors) > 0 {
+		br := &errdetails.BadRequest{}
+		for _, fe := range eb.Errors {
+			br.FieldViolations = append(br.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       fe.Field,
+				Description: fe.Message,
+				Reason:      fe.Code,
+			})
+		}
+		details = append(details, br)
+	}
+	if withDetails, err := st.WithDetails(details...); err == nil {
+		st = withDetails
+	}
+	return st.Err()
+}
+
+// toProtoLink converts the REST link representation to its protobuf message.
+func toProtoLink(lr *LinkResponse) *linkspb.Link {
+	l := &linkspb.Link{
+		Id:                  lr.ID,
+		Slug:                lr.Slug,
+		Url:                 lr.URL,
+		Title:               lr.Title,
+		Description:         lr.Description,
+		Visibility:          lr.Visibility,
+		Tags:                lr.Tags,
+		VariableConstraints: lr.VariableConstraints,
+		RedirectType:        int32(lr.RedirectType),
+		UtmParams:           lr.UTMParams,
+		PassQuery:           lr.PassQuery,
+		PendingReview:       lr.PendingReview,
+		CreatedBy:           lr.CreatedBy,
+		CreatedAt:           timestamppb.New(lr.CreatedAt),
+		UpdatedAt:           timestamppb.New(lr.UpdatedAt),
+	}
+	if lr.Team != nil {
+		l.Team = lr.Team.Slug
+	}
+	for _, o := range lr.Owners {
+		l.Owners = append(l.Owners, &linkspb.Owner{Id: o.ID, Email: o.Email, IsPrimary: o.IsPrimary})
+	}
+	return l
+}
+
+// responseBuffer is a minimal http.ResponseWriter that keeps the response in
+// memory, for running REST handlers on behalf of gRPC calls.
+type responseBuffer struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (b *responseBuffer) Header() http.Header         { return b.header }
+func (b *responseBuffer) Write(p []byte) (int, error) { return b.body.Write(p) }
+func (b *responseBuffer) WriteHeader(status int)      { b.status = status }
@@ -0,0 +1,269 @@
+package api_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/joestump/joe-links/internal/api"
+	"github.com/joestump/joe-links/internal/api/linkspb"
+	"github.com/joestump/joe-links/internal/auth"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// newGRPCClient serves the LinksService for env over an in-memory listener
+// and returns a client connected to it.
+func newGRPCClient(t *testing.T, env *testEnv, serverOpts []grpc.ServerOption, dialOpts ...grpc.DialOption) linkspb.LinksServiceClient {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer(serverOpts...)
+	api.RegisterGRPCServer(srv, env.Deps)
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	if len(dialOpts) == 0 {
+		dialOpts = []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+	}
+	dialOpts = append(dialOpts, grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+		return lis.DialContext(ctx)
+	}))
+	conn, err := grpc.NewClient("passthrough:///bufnet", dialOpts...)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	return linkspb.NewLinksServiceClient(conn)
+}
+
+func withToken(token string) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+token)
+}
+
+// Governing: SPEC-0005 REQ "gRPC Links Service"
+func TestGRPC_CreateGetList(t *testing.T) {
+	env := newTestEnv(t)
+	alice := seedUser(t, env, "alice@example.com", "user")
+	client := newGRPCClient(t, env, nil)
+	ctx := withToken(seedToken(t, env, alice.ID))
+
+	created, err := client.CreateLink(ctx, &linkspb.CreateLinkRequest{
+		Slug:         "grpc-docs",
+		Url:          "https://example.com/docs",
+		Title:        "Docs",
+		Tags:         []string{"docs"},
+		RedirectType: 301,
+	})
+	if err != nil {
+		t.Fatalf("CreateLink: %v", err)
+	}
+	if created.GetVisibility() != "public" || created.GetRedirectType() != 301 || len(created.GetOwners()) != 1 || !created.GetOwners()[0].GetIsPrimary() {
+		t.Errorf("created = %v", created)
+	}
+
+	got, err := client.GetLink(ctx, &linkspb.GetLinkRequest{Slug: "grpc-docs"})
+	if err != nil {
+		t.Fatalf("GetLink: %v", err)
+	}
+	if got.GetId() != created.GetId() || got.GetTags()[0] != "docs" || !got.GetCreatedAt().AsTime().Equal(created.GetCreatedAt().AsTime()) {
+		t.Errorf("got = %v, want %v", got, created)
+	}
+
+	list, err := client.ListLinks(ctx, &linkspb.ListLinksRequest{Q: "docs"})
+	if err != nil {
+		t.Fatalf("ListLinks: %v", err)
+	}
+	if len(list.GetLinks()) != 1 || list.GetLinks()[0].GetSlug() != "grpc-docs" {
+		t.Errorf("links = %v", list.GetLinks())
+	}
+
+	// Another user cannot read the link's details.
+	bob := seedUser(t, env, "bob@example.com", "user")
+	bobCtx := withToken(seedToken(t, env, bob.ID))
+	if _, err := client.GetLink(bobCtx, &linkspb.GetLinkRequest{Id: created.GetId()}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("GetLink as bob: %v, want PermissionDenied", err)
+	}
+	if _, err := client.GetLink(ctx, &linkspb.GetLinkRequest{Slug: "missing"}); status.Code(err) != codes.NotFound {
+		t.Errorf("GetLink missing: %v, want NotFound", err)
+	}
+}
+
+// Governing: SPEC-0005 REQ "gRPC Links Service", REQ "Standard Error Response Format"
+func TestGRPC_CreateLinkErrors(t *testing.T) {
+	env := newTestEnv(t)
+	alice := seedUser(t, env, "alice@example.com", "user")
+	client := newGRPCClient(t, env, nil)
+	ctx := withToken(seedToken(t, env, alice.ID))
+
+	_, err := client.CreateLink(ctx, &linkspb.CreateLinkRequest{Slug: "Bad Slug", Url: "https://example.com"})
+	st := status.Convert(err)
+	if st.Code() != codes.InvalidArgument {
+		t.Fatalf("bad slug: %v, want InvalidArgument", err)
+	}
+	var violation *errdetails.BadRequest_FieldViolation
+	for _, d := range st.Details() {
+		if br, ok := d.(*errdetails.BadRequest); ok && len(br.GetFieldViolations()) == 1 {
+			violation = br.GetFieldViolations()[0]
+		}
+	}
+	if violation == nil || violation.GetField() != "slug" || violation.GetReason() != "INVALID_SLUG" {
+		t.Errorf("field violation = %v", violation)
+	}
+
+	req := &linkspb.CreateLinkRequest{Slug: "taken", Url: "https://example.com", IdempotencyKey: "k1"}
+	first, err := client.CreateLink(ctx, req)
+	if err != nil {
+		t.Fatalf("CreateLink: %v", err)
+	}
+	// A retry with the same idempotency key returns the same link.
+	again, err := client.CreateLink(ctx, req)
+	if err != nil || again.GetId() != first.GetId() {
+		t.Errorf("retry = %v, %v; want link %s", again, err, first.GetId())
+	}
+	req.IdempotencyKey = ""
+	if _, err := client.CreateLink(ctx, req); status.Code(err) != codes.AlreadyExists {
+		t.Errorf("duplicate slug: %v, want AlreadyExists", err)
+	}
+}
+
+// Governing: SPEC-0005 REQ "gRPC Links Service", SPEC-0006 REQ "Token Scopes"
+func TestGRPC_AuthAndScopes(t *testing.T) {
+	env := newTestEnv(t)
+	alice := seedUser(t, env, "alice@example.com", "user")
+	client := newGRPCClient(t, env, nil)
+
+	if _, err := client.ListLinks(context.Background(), &linkspb.ListLinksRequest{}); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("no token: %v, want Unauthenticated", err)
+	}
+	if _, err := client.ListLinks(withToken("bogus"), &linkspb.ListLinksRequest{}); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("bad token: %v, want Unauthenticated", err)
+	}
+
+	readOnly := withToken(seedScopedToken(t, env, alice.ID, auth.ScopeLinksRead))
+	if _, err := client.ListLinks(readOnly, &linkspb.ListLinksRequest{}); err != nil {
+		t.Errorf("ListLinks with links:read: %v", err)
+	}
+	if _, err := client.CreateLink(readOnly, &linkspb.CreateLinkRequest{Slug: "nope", Url: "https://example.com"}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("CreateLink with links:read: %v, want PermissionDenied", err)
+	}
+}
+
+// Governing: SPEC-0005 REQ "gRPC Links Service", REQ "Resolve Endpoint"
+func TestGRPC_Resolve(t *testing.T) {
+	env := newTestEnv(t)
+	alice := seedUser(t, env, "alice@example.com", "user")
+	client := newGRPCClient(t, env, nil)
+	ctx := withToken(seedToken(t, env, alice.ID))
+
+	if _, err := client.Resolve(ctx, &linkspb.ResolveRequest{Path: "missing"}); status.Code(err) != codes.NotFound {
+		t.Errorf("missing: %v, want NotFound", err)
+	}
+
+	env.ResolveTester.resp = &api.ResolveTestResponse{
+		Path:      "gh/joestump",
+		Outcome:   api.ResolveOutcomeRedirect,
+		Status:    http.StatusFound,
+		Target:    "https://github.com/joestump",
+		Link:      &api.ResolvedLinkResponse{ID: "l1", Slug: "gh"},
+		Variables: []api.ResolveVariable{{Name: "user", Placeholder: "$user", Value: "joestump"}},
+	}
+	res, err := client.Resolve(ctx, &linkspb.ResolveRequest{Path: "/gh/joestump"})
+	if err != nil {
+		t.Fatalf("Resolve: %v", err)
+	}
+	if res.GetTarget() != "https://github.com/joestump" || res.GetSlug() != "gh" || res.GetVariables()[0].GetValue() != "joestump" {
+		t.Errorf("res = %v", res)
+	}
+	if env.ResolveTester.path != "gh/joestump" || env.ResolveTester.user.ID != alice.ID {
+		t.Errorf("resolved %q as %v", env.ResolveTester.path, env.ResolveTester.user)
+	}
+}
+
+// Governing: SPEC-0005 REQ "gRPC Links Service" — mTLS callers act as the user named by their certificate
+func TestGRPC_ClientCertificate(t *testing.T) {
+	env := newTestEnv(t)
+	alice := seedUser(t, env, "alice@example.com", "user")
+	if _, err := env.LinkStore.Create(context.Background(), "mine", "https://example.com", alice.ID, "", "", "private"); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	ca, caKey := newTestCert(t, nil, nil, func(c *x509.Certificate) {
+		c.IsCA, c.BasicConstraintsValid = true, true
+		c.KeyUsage = x509.KeyUsageCertSign
+	})
+	serverCert, serverKey := newTestCert(t, ca, caKey, func(c *x509.Certificate) {
+		c.DNSNames = []string{"links.internal"}
+		c.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}
+	})
+	clientCert, clientKey := newTestCert(t, ca, caKey, func(c *x509.Certificate) {
+		c.EmailAddresses = []string{"alice@example.com"}
+		c.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}
+	})
+	pool := x509.NewCertPool()
+	pool.AddCert(ca)
+
+	serverTLS := credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{{Certificate: [][]byte{serverCert.Raw}, PrivateKey: serverKey}},
+		ClientCAs:    pool,
+		ClientAuth:   tls.VerifyClientCertIfGiven,
+	})
+	clientTLS := credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{{Certificate: [][]byte{clientCert.Raw}, PrivateKey: clientKey}},
+		RootCAs:      pool,
+		ServerName:   "links.internal",
+	})
+	client := newGRPCClient(t, env, []grpc.ServerOption{grpc.Creds(serverTLS)}, grpc.WithTransportCredentials(clientTLS))
+
+	list, err := client.ListLinks(context.Background(), &linkspb.ListLinksRequest{})
+	if err != nil {
+		t.Fatalf("ListLinks: %v", err)
+	}
+	if len(list.GetLinks()) != 1 || list.GetLinks()[0].GetSlug() != "mine" {
+		t.Errorf("links = %v, want alice's link", list.GetLinks())
+	}
+}
+
+// newTestCert issues a certificate signed by parent, or self-signed when
+// parent is nil, after letting configure adjust the template.
+func newTestCert(t *testing.T, parent *x509.Certificate, parentKey *ecdsa.PrivateKey, configure func(*x509.Certificate)) (*x509.Certificate, *ecdsa.PrivateKey) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: "joe-links test"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+	configure(tmpl)
+	if parent == nil {
+		parent, parentKey = tmpl, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, parent, &key.PublicKey, parentKey)
+	if err != nil {
+		t.Fatalf("create certificate: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("parse certificate: %v", err)
+	}
+	return cert, key
+}
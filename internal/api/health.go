@@ -0,0 +1,79 @@
+// Governing: SPEC-0001 REQ "Link Health Checks", ADR-0008
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/joestump/joe-links/internal/auth"
+	"github.com/joestump/joe-links/internal/store"
+)
+
+// healthAPIHandler provides the link health endpoint.
+type healthAPIHandler struct {
+	links  *store.LinkStore
+	health *store.HealthStore
+	owns   *store.OwnershipStore
+}
+
+// Get returns the latest health check of a link's target URL.
+// GET /api/v1/links/{id}/health
+// Governing: SPEC-0001 REQ "Link Health Checks"
+//
+// @Summary      Get link health
+// @Description  Returns the status code, latency, and time of the latest background check of the link's URL. Owners and admins only. Templated links are never checked.
+// @Tags         Links
+// @Produce      json
+// @Param        id   path      string  true  "Link ID"
+// @Success      200  {object}  LinkHealthResponse
+// @Failure      401  {object}  ErrorResponse
+// @Failure      403  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Security     BearerToken
+// @Router       /links/{id}/health [get]
+func (h *healthAPIHandler) Get(w http.ResponseWriter, r *http.Request) {
+	user := auth.UserFromContext(r.Context())
+	if user == nil {
+		writeError(w, http.StatusUnauthorized, "unauthorized", "UNAUTHORIZED")
+		return
+	}
+
+	link, err := h.links.GetByID(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			writeError(w, http.StatusNotFound, "not found", "NOT_FOUND")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
+		return
+	}
+
+	allowed, err := store.IsOwnerOrAdmin(h.owns, link.ID, user.ID, user.Role)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
+		return
+	}
+	if !allowed {
+		writeError(w, http.StatusForbidden, "forbidden", "FORBIDDEN")
+		return
+	}
+
+	resp := LinkHealthResponse{LinkID: link.ID}
+	lh, err := h.health.Get(r.Context(), link.ID)
+	switch {
+	case errors.Is(err, store.ErrNotFound):
+		// Never checked.
+	case err != nil:
+		writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
+		return
+	default:
+		resp.Checked = true
+		resp.Broken = lh.Broken()
+		resp.StatusCode = lh.StatusCode
+		resp.LatencyMS = lh.LatencyMS
+		resp.Error = lh.Error
+		resp.CheckedAt = &lh.CheckedAt
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
@@ -0,0 +1,107 @@
+// Governing: SPEC-0001 REQ "Link Health Checks"
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/joestump/joe-links/internal/api"
+	"github.com/joestump/joe-links/internal/store"
+)
+
+func TestLinkHealth_Owner_OK(t *testing.T) {
+	env := newTestEnv(t)
+	user := seedUser(t, env, "health-owner@example.com", "user")
+	token := seedToken(t, env, user.ID)
+	ctx := context.Background()
+
+	link, err := env.LinkStore.Create(ctx, "health-link", "https://example.com/gone", user.ID, "", "", "")
+	if err != nil {
+		t.Fatalf("create link: %v", err)
+	}
+	if err := env.HealthStore.Record(ctx, store.LinkHealth{LinkID: link.ID, StatusCode: 404, LatencyMS: 42}); err != nil {
+		t.Fatalf("record health: %v", err)
+	}
+
+	req := httptest.NewRequest("GET", "/links/"+link.ID+"/health", nil)
+	authRequest(req, token)
+	rec := httptest.NewRecorder()
+	env.Router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d; body: %s", rec.Code, http.StatusOK, rec.Body.String())
+	}
+	var resp api.LinkHealthResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if !resp.Checked || !resp.Broken || resp.StatusCode != 404 || resp.LatencyMS != 42 || resp.CheckedAt == nil {
+		t.Errorf("unexpected response: %+v", resp)
+	}
+}
+
+func TestLinkHealth_NeverChecked(t *testing.T) {
+	env := newTestEnv(t)
+	user := seedUser(t, env, "health-unchecked@example.com", "user")
+	token := seedToken(t, env, user.ID)
+
+	link, err := env.LinkStore.Create(context.Background(), "health-unchecked", "https://example.com", user.ID, "", "", "")
+	if err != nil {
+		t.Fatalf("create link: %v", err)
+	}
+
+	req := httptest.NewRequest("GET", "/links/"+link.ID+"/health", nil)
+	authRequest(req, token)
+	rec := httptest.NewRecorder()
+	env.Router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	var resp api.LinkHealthResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if resp.Checked || resp.Broken || resp.CheckedAt != nil {
+		t.Errorf("expected unchecked response, got %+v", resp)
+	}
+}
+
+func TestLinkHealth_NonOwner_Forbidden(t *testing.T) {
+	env := newTestEnv(t)
+	owner := seedUser(t, env, "health-owner2@example.com", "user")
+	other := seedUser(t, env, "health-other@example.com", "user")
+	otherToken := seedToken(t, env, other.ID)
+
+	link, err := env.LinkStore.Create(context.Background(), "health-forbidden", "https://example.com", owner.ID, "", "", "")
+	if err != nil {
+		t.Fatalf("create link: %v", err)
+	}
+
+	req := httptest.NewRequest("GET", "/links/"+link.ID+"/health", nil)
+	authRequest(req, otherToken)
+	rec := httptest.NewRecorder()
+	env.Router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusForbidden)
+	}
+}
+
+func TestLinkHealth_NotFound(t *testing.T) {
+	env := newTestEnv(t)
+	user := seedUser(t, env, "health-nf@example.com", "user")
+	token := seedToken(t, env, user.ID)
+
+	req := httptest.NewRequest("GET", "/links/nonexistent-id/health", nil)
+	authRequest(req, token)
+	rec := httptest.NewRecorder()
+	env.Router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+}
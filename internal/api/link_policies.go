@@ -0,0 +1,186 @@
+// Governing: SPEC-0011 REQ "Link Lifecycle Policies"
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/joestump/joe-links/internal/auth"
+	"github.com/joestump/joe-links/internal/store"
+)
+
+// ListPolicies returns every link policy.
+// GET /api/v1/admin/policies
+//
+// @Summary      List link policies (admin)
+// @Description  Returns the link lifecycle policies ordered by name. Requires admin role.
+// @Tags         Admin
+// @Produce      json
+// @Success      200  {array}   LinkPolicyResponse
+// @Failure      401  {object}  ErrorResponse
+// @Failure      403  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Security     BearerToken
+// @Router       /admin/policies [get]
+func (h *adminAPIHandler) ListPolicies(w http.ResponseWriter, r *http.Request) {
+	policies, err := h.policies.List(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
+		return
+	}
+	resp := make([]LinkPolicyResponse, 0, len(policies))
+	for _, p := range policies {
+		resp = append(resp, linkPolicyResponse(p))
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// CreatePolicy adds a link policy.
+// POST /api/v1/admin/policies
+//
+// @Summary      Create a link policy (admin)
+// @Description  Adds a rule for the links scope selects. Blocking content rules refuse to save links that break them;
+// @Description  blocking max_age rules delete links past the limit in the nightly sweep. Requires admin role.
+// @Tags         Admin
+// @Accept       json
+// @Produce      json
+// @Param        body  body      CreateLinkPolicyRequest  true  "Policy to create"
+// @Success      201   {object}  LinkPolicyResponse
+// @Failure      400   {object}  ErrorResponse
+// @Failure      401   {object}  ErrorResponse
+// @Failure      403   {object}  ErrorResponse
+// @Failure      409   {object}  ErrorResponse
+// @Failure      500   {object}  ErrorResponse
+// @Security     BearerToken
+// @Router       /admin/policies [post]
+func (h *adminAPIHandler) CreatePolicy(w http.ResponseWriter, r *http.Request) {
+	var req CreateLinkPolicyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body", "BAD_REQUEST")
+		return
+	}
+
+	p, err := h.policies.Create(r.Context(), req.Name, req.Scope, req.Rule, req.Days, req.Enforcement)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrPolicyNameRequired):
+			writeError(w, http.StatusBadRequest, "name is required", "BAD_REQUEST")
+		case errors.Is(err, store.ErrInvalidPolicy):
+			writeError(w, http.StatusBadRequest, err.Error(), "INVALID_POLICY")
+		case errors.Is(err, store.ErrInvalidFilter):
+			writeError(w, http.StatusBadRequest, err.Error(), "INVALID_FILTER")
+		case errors.Is(err, store.ErrPolicyNameTaken):
+			writeError(w, http.StatusConflict, "a policy with that name already exists", "POLICY_CONFLICT")
+		default:
+			writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
+		}
+		return
+	}
+	writeJSON(w, http.StatusCreated, linkPolicyResponse(p))
+}
+
+// DeletePolicy removes a link policy and its recorded violations.
+// DELETE /api/v1/admin/policies/{id}
+//
+// @Summary      Delete a link policy (admin)
+// @Description  Removes a link policy and clears its violations. Requires admin role.
+// @Tags         Admin
+// @Produce      json
+// @Param        id   path  string  true  "Policy ID"
+// @Success      204  "No Content"
+// @Failure      401  {object}  ErrorResponse
+// @Failure      403  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Security     BearerToken
+// @Router       /admin/policies/{id} [delete]
+func (h *adminAPIHandler) DeletePolicy(w http.ResponseWriter, r *http.Request) {
+	if err := h.policies.Delete(r.Context(), chi.URLParam(r, "id")); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			writeError(w, http.StatusNotFound, "policy not found", "NOT_FOUND")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// policyViolationsAPIHandler lists policy violations on the caller's links.
+type policyViolationsAPIHandler struct {
+	policies *store.PolicyStore
+}
+
+// List returns the policy violations recorded on links the caller owns.
+// GET /api/v1/policy-violations
+//
+// @Summary      List policy violations
+// @Description  Returns the link policies the caller's links currently break, ordered by slug.
+// @Description  Violations are recorded when a link is saved and by the nightly policy sweep.
+// @Tags         Links
+// @Produce      json
+// @Success      200  {array}   PolicyViolationResponse
+// @Failure      401  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Security     BearerToken
+// @Router       /policy-violations [get]
+func (h *policyViolationsAPIHandler) List(w http.ResponseWriter, r *http.Request) {
+	user := auth.UserFromContext(r.Context())
+	if user == nil {
+		writeError(w, http.StatusUnauthorized, "unauthorized", "UNAUTHORIZED")
+		return
+	}
+	vs, err := h.policies.ListViolationsByOwner(r.Context(), user.ID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
+		return
+	}
+	resp := make([]PolicyViolationResponse, 0, len(vs))
+	for _, v := range vs {
+		resp = append(resp, PolicyViolationResponse{
+			LinkID:      v.LinkID,
+			Slug:        v.Slug,
+			PolicyID:    v.PolicyID,
+			PolicyName:  v.PolicyName,
+			Enforcement: v.Enforcement,
+			Message:     v.Message,
+			DetectedAt:  v.DetectedAt,
+		})
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// checkLinkPolicies evaluates a link about to be saved against the link
+// policies. It writes a 400 POLICY_VIOLATION and returns false when a blocking
+// policy refuses it; otherwise it returns the violations to record once the
+// link is saved. A nil store skips the check.
+func checkLinkPolicies(w http.ResponseWriter, r *http.Request, policies *store.PolicyStore, subj store.PolicySubject) ([]*store.PolicyViolation, bool) {
+	if policies == nil {
+		return nil, true
+	}
+	warnings, err := policies.CheckLink(r.Context(), subj)
+	if errors.Is(err, store.ErrPolicyViolation) {
+		writeError(w, http.StatusBadRequest, err.Error(), "POLICY_VIOLATION")
+		return nil, false
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
+		return nil, false
+	}
+	return warnings, true
+}
+
+func linkPolicyResponse(p *store.LinkPolicy) LinkPolicyResponse {
+	return LinkPolicyResponse{
+		ID:          p.ID,
+		Name:        p.Name,
+		Scope:       p.Scope,
+		Rule:        p.Rule,
+		Days:        p.Days,
+		Enforcement: p.Enforcement,
+		Summary:     p.Summary(),
+		CreatedAt:   p.CreatedAt,
+	}
+}
@@ -0,0 +1,76 @@
+// Governing: SPEC-0011 REQ "Link Lifecycle Policies"
+package api_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/joestump/joe-links/internal/api"
+)
+
+func TestLinkPolicies(t *testing.T) {
+	env := newTestEnv(t)
+	admin := seedUser(t, env, "admin@example.com", "admin")
+	user := seedUser(t, env, "user@example.com", "user")
+	adminToken := seedToken(t, env, admin.ID)
+	userToken := seedToken(t, env, user.ID)
+
+	do := func(token, method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		authRequest(req, token)
+		rec := httptest.NewRecorder()
+		env.Router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	if rec := do(userToken, "POST", "/admin/policies", `{"name":"x","rule":"require_title"}`); rec.Code != http.StatusForbidden {
+		t.Errorf("non-admin create: status = %d, want 403", rec.Code)
+	}
+	if rec := do(adminToken, "POST", "/admin/policies", `{"name":"x","rule":"max_age"}`); rec.Code != http.StatusBadRequest {
+		t.Errorf("max_age without days: status = %d, want 400", rec.Code)
+	}
+	rec := do(adminToken, "POST", "/admin/policies", `{"name":"Secure links are documented","scope":"visibility:secure","rule":"require_description","enforcement":"block"}`)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("create block policy: status = %d; body: %s", rec.Code, rec.Body.String())
+	}
+	var blocking api.LinkPolicyResponse
+	if err := json.NewDecoder(rec.Body).Decode(&blocking); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if rec := do(adminToken, "POST", "/admin/policies", `{"name":"Titles","rule":"require_title"}`); rec.Code != http.StatusCreated {
+		t.Fatalf("create warn policy: status = %d; body: %s", rec.Code, rec.Body.String())
+	}
+
+	// A blocking policy refuses the link.
+	rec = do(userToken, "POST", "/links", `{"slug":"vault","url":"https://vault.example.com","title":"Vault","visibility":"secure"}`)
+	if rec.Code != http.StatusBadRequest || !bytes.Contains(rec.Body.Bytes(), []byte("POLICY_VIOLATION")) {
+		t.Fatalf("secure without description: status = %d, body %s; want 400 POLICY_VIOLATION", rec.Code, rec.Body.String())
+	}
+
+	// A warning policy lets it through and records a violation for the owner.
+	if rec := do(userToken, "POST", "/links", `{"slug":"wiki","url":"https://wiki.example.com"}`); rec.Code != http.StatusCreated {
+		t.Fatalf("untitled link: status = %d; body: %s", rec.Code, rec.Body.String())
+	}
+	rec = do(userToken, "GET", "/policy-violations", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("list violations: status = %d", rec.Code)
+	}
+	var vs []api.PolicyViolationResponse
+	if err := json.NewDecoder(rec.Body).Decode(&vs); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(vs) != 1 || vs[0].Slug != "wiki" || vs[0].PolicyName != "Titles" {
+		t.Fatalf("violations = %+v, want wiki breaking Titles", vs)
+	}
+
+	if rec := do(adminToken, "DELETE", "/admin/policies/"+blocking.ID, ""); rec.Code != http.StatusNoContent {
+		t.Errorf("delete: status = %d, want 204", rec.Code)
+	}
+	if rec := do(userToken, "POST", "/links", `{"slug":"vault","url":"https://vault.example.com","title":"Vault","visibility":"secure"}`); rec.Code != http.StatusCreated {
+		t.Errorf("after deleting the policy: status = %d, want 201", rec.Code)
+	}
+}
@@ -5,13 +5,17 @@ package api
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
+	"io"
 	"log"
 	"net/http"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/joestump/joe-links/internal/auth"
+	"github.com/joestump/joe-links/internal/mailer"
 	"github.com/joestump/joe-links/internal/store"
 )
 
@@ -21,16 +25,24 @@ type linksAPIHandler struct {
 	links     *store.LinkStore
 	ownership *store.OwnershipStore
 	users     *store.UserStore
+	teams     *store.TeamStore
+	policies  *store.PolicyStore // Governing: SPEC-0011 REQ "Link Lifecycle Policies"; nil skips policy checks
+	notify    *mailer.Notifier   // nil disables co-owner emails
+
+	// idempotency remembers Idempotency-Key headers; nil ignores them.
+	// Governing: SPEC-0005 REQ "Idempotent Link Creation"
+	idempotency *store.IdempotencyStore
 }
 
 // registerLinkRoutes registers link and co-owner routes on r.
 // Governing: SPEC-0005 REQ "Links Collection", REQ "Link Resource", REQ "Co-Owner Management"
-func registerLinkRoutes(r chi.Router, links *store.LinkStore, ownership *store.OwnershipStore, users *store.UserStore) {
-	h := &linksAPIHandler{links: links, ownership: ownership, users: users}
+func registerLinkRoutes(r chi.Router, links *store.LinkStore, ownership *store.OwnershipStore, users *store.UserStore, teams *store.TeamStore, policies *store.PolicyStore, notify *mailer.Notifier, idempotency *store.IdempotencyStore) {
+	h := &linksAPIHandler{links: links, ownership: ownership, users: users, teams: teams, policies: policies, notify: notify, idempotency: idempotency}
 	r.Get("/links", h.List)
 	r.Post("/links", h.Create)
 	r.Get("/links/{id}", h.Get)
 	r.Put("/links/{id}", h.Update)
+	r.Patch("/links/{id}", h.Patch) // Governing: SPEC-0005 REQ "Link Resource"
 	r.Delete("/links/{id}", h.Delete)
 	r.Get("/links/{id}/owners", h.ListOwners)
 	r.Post("/links/{id}/owners", h.AddOwner)
@@ -42,13 +54,21 @@ func registerLinkRoutes(r chi.Router, links *store.LinkStore, ownership *store.O
 // Governing: SPEC-0005 REQ "Links Collection"
 //
 // @Summary      List links
-// @Description  Returns links owned by the caller. Admins see all links.
+// @Description  Returns links owned by or shared with the caller. Admins see all links.
+// @Description  q searches titles, descriptions, slugs, URLs, and tags, and accepts
+// @Description  owner:, tag:, team:, and visibility: filters (e.g. "owner:alice tag:infra").
 // @Tags         Links
 // @Accept       json
 // @Produce      json
-// @Success      200  {object}  LinkListResponse
-// @Failure      401  {object}  ErrorResponse
-// @Failure      500  {object}  ErrorResponse
+// @Param        q              query     string  false  "Search text and key:value filters"
+// @Param        url            query     string  false  "Exact destination URL"
+// @Param        slug           query     string  false  "Exact slug; returns at most one link"
+// @Param        If-None-Match  header    string  false  "ETag of a list the caller already has"
+// @Success      200            {object}  LinkListResponse
+// @Success      304            "Not Modified"
+// @Failure      400            {object}  ErrorResponse
+// @Failure      401            {object}  ErrorResponse
+// @Failure      500            {object}  ErrorResponse
 // @Security     BearerToken
 // @Router       /links [get]
 func (h *linksAPIHandler) List(w http.ResponseWriter, r *http.Request) {
@@ -62,12 +82,25 @@ func (h *linksAPIHandler) List(w http.ResponseWriter, r *http.Request) {
 	var err error
 
 	// Governing: SPEC-0010 REQ "REST API Visibility Field" — non-admin sees owned + shared
-	if urlFilter := r.URL.Query().Get("url"); urlFilter != "" {
-		links, err = h.links.ListByURL(r.Context(), urlFilter, user.ID, user.Role == "admin")
-	} else if user.Role == "admin" {
-		links, err = h.links.ListAll(r.Context())
+	if slug := r.URL.Query().Get("slug"); slug != "" {
+		// Governing: SPEC-0005 REQ "Links Collection" — exact lookup for declarative clients
+		link, lookupErr := h.links.GetBySlug(r.Context(), slug)
+		switch {
+		case errors.Is(lookupErr, store.ErrNotFound):
+		case lookupErr != nil:
+			err = lookupErr
+		default:
+			var ok bool
+			if ok, err = h.canRead(r.Context(), user, link); ok {
+				links = []*store.Link{link}
+			}
+		}
 	} else {
-		links, err = h.links.ListByOwnerOrShared(r.Context(), user.ID)
+		links, err = listVisibleLinks(r.Context(), h.links, user, r.URL.Query().Get("q"), r.URL.Query().Get("url"))
+		if errors.Is(err, store.ErrInvalidFilter) {
+			writeError(w, http.StatusBadRequest, err.Error(), "INVALID_FILTER")
+			return
+		}
 	}
 	if err != nil {
 		writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
@@ -87,21 +120,50 @@ func (h *linksAPIHandler) List(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusOK, resp)
 }
 
+// listVisibleLinks returns the links user may list: every link for admins,
+// otherwise those they own or that are shared with them, narrowed to an
+// exact destination URL or a search query when either is set.
+// Governing: SPEC-0005 REQ "Links Collection", SPEC-0002 REQ "Structured Search Filters"
+func listVisibleLinks(ctx context.Context, links *store.LinkStore, user *store.User, q, urlFilter string) ([]*store.Link, error) {
+	admin := user.Role == "admin"
+	switch {
+	case urlFilter != "":
+		return links.ListByURL(ctx, urlFilter, user.ID, admin)
+	case q != "" && admin:
+		return links.SearchAll(ctx, q)
+	case q != "":
+		return links.SearchByOwnerOrShared(ctx, user.ID, q)
+	case admin:
+		return links.ListAll(ctx)
+	default:
+		return links.ListByOwnerOrShared(ctx, user.ID)
+	}
+}
+
 // Create creates a new link with the authenticated user as primary owner.
 // POST /api/v1/links
 // Governing: SPEC-0005 REQ "Links Collection"
 //
 // @Summary      Create a link
 // @Description  Creates a new short link. The caller becomes the primary owner.
+// @Description  Links that break a blocking link policy are refused with POLICY_VIOLATION.
+// @Description  Retrying with the same Idempotency-Key and body within 24 hours returns the link the
+// @Description  first request created; the same key with a different body is refused with IDEMPOTENCY_KEY_REUSED.
+// @Description  With upsert=true an existing link with the slug that the caller may edit is updated
+// @Description  from the body as PUT /links/{id} would, and returned with 200.
 // @Tags         Links
 // @Accept       json
 // @Produce      json
-// @Param        body  body      CreateLinkRequest  true  "Link to create"
-// @Success      201   {object}  LinkResponse
-// @Failure      400   {object}  ErrorResponse
-// @Failure      401   {object}  ErrorResponse
-// @Failure      409   {object}  ErrorResponse
-// @Failure      500   {object}  ErrorResponse
+// @Param        body             body      CreateLinkRequest  true   "Link to create"
+// @Param        Idempotency-Key  header    string             false  "Client-chosen key that makes retries safe"
+// @Param        upsert           query     bool               false  "Update the link if the slug already exists"
+// @Success      200              {object}  LinkResponse  "existing link updated (upsert)"
+// @Success      201              {object}  LinkResponse
+// @Failure      400              {object}  ErrorResponse
+// @Failure      401              {object}  ErrorResponse
+// @Failure      409              {object}  ErrorResponse
+// @Failure      422              {object}  ErrorResponse  "Idempotency-Key reused with a different body"
+// @Failure      500              {object}  ErrorResponse
 // @Security     BearerToken
 // @Router       /links [post]
 func (h *linksAPIHandler) Create(w http.ResponseWriter, r *http.Request) {
@@ -111,18 +173,50 @@ func (h *linksAPIHandler) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body", "BAD_REQUEST")
+		return
+	}
 	var req CreateLinkRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+	if err := json.Unmarshal(body, &req); err != nil {
 		writeError(w, http.StatusBadRequest, "invalid request body", "BAD_REQUEST")
 		return
 	}
 
+	// Governing: SPEC-0005 REQ "Idempotent Link Creation"
+	idempotencyKey := r.Header.Get("Idempotency-Key")
+	sum := sha256.Sum256(body)
+	requestHash := hex.EncodeToString(sum[:])
+	if idempotencyKey != "" && h.idempotency != nil {
+		linkID, err := h.idempotency.Lookup(r.Context(), user.ID, idempotencyKey, requestHash)
+		if errors.Is(err, store.ErrIdempotencyKeyReused) {
+			writeError(w, http.StatusUnprocessableEntity, err.Error(), "IDEMPOTENCY_KEY_REUSED")
+			return
+		}
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
+			return
+		}
+		// A link deleted since is created again.
+		if link, err := h.links.GetByID(r.Context(), linkID); linkID != "" && err == nil {
+			lr, err := h.toLinkResponse(r.Context(), link)
+			if err != nil {
+				writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
+				return
+			}
+			w.Header().Set("Idempotent-Replayed", "true")
+			writeJSON(w, http.StatusCreated, lr)
+			return
+		}
+	}
+
 	if req.Slug == "" {
-		writeError(w, http.StatusBadRequest, "slug is required", "BAD_REQUEST")
+		writeRequiredField(w, "slug", "BAD_REQUEST")
 		return
 	}
 	if req.URL == "" {
-		writeError(w, http.StatusBadRequest, "url is required", "BAD_REQUEST")
+		writeRequiredField(w, "url", "BAD_REQUEST")
 		return
 	}
 
@@ -130,16 +224,75 @@ func (h *linksAPIHandler) Create(w http.ResponseWriter, r *http.Request) {
 	// Governing: SPEC-0005 REQ "Links Collection" — slug format [a-z0-9][a-z0-9\-]*[a-z0-9]
 	if err := store.ValidateSlugFormat(req.Slug); err != nil {
 		if errors.Is(err, store.ErrSlugReserved) {
-			writeError(w, http.StatusBadRequest, err.Error(), "INVALID_SLUG")
+			writeFieldError(w, http.StatusBadRequest, "slug", err.Error(), "INVALID_SLUG")
 			return
 		}
-		writeError(w, http.StatusBadRequest, "slug must match [a-z0-9][a-z0-9-]*[a-z0-9]", "INVALID_SLUG")
+		writeFieldError(w, http.StatusBadRequest, "slug", "slug must match [a-z0-9][a-z0-9-]*[a-z0-9]", "INVALID_SLUG")
 		return
 	}
 
+	// Governing: SPEC-0005 REQ "Idempotent Link Creation" — converge on an existing slug
+	if r.URL.Query().Get("upsert") == "true" {
+		existing, err := h.links.GetBySlug(r.Context(), req.Slug)
+		switch {
+		case err == nil:
+			// The same check as PUT /links/{id}, so team co-owners may converge too.
+			allowed, err := h.canEdit(user, existing)
+			if err != nil {
+				writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
+				return
+			}
+			if !allowed {
+				writeError(w, http.StatusConflict, "slug already exists", "SLUG_CONFLICT")
+				return
+			}
+			updated := h.update(w, r, existing, UpdateLinkRequest{
+				URL:                 req.URL,
+				Title:               req.Title,
+				Description:         req.Description,
+				Visibility:          req.Visibility,
+				Tags:                req.Tags,
+				VariableConstraints: req.VariableConstraints,
+				RedirectType:        req.RedirectType,
+				UTMParams:           req.UTMParams,
+				PassQuery:           &req.PassQuery,
+				Team:                &req.Team,
+			})
+			if updated {
+				h.saveIdempotencyKey(r, user, idempotencyKey, requestHash, existing.ID)
+			}
+			return
+		case !errors.Is(err, store.ErrNotFound):
+			writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
+			return
+		}
+	}
+
 	// Governing: SPEC-0009 REQ "Variable Placeholder Syntax", ADR-0013
 	if err := store.ValidateURLVariables(req.URL); err != nil {
-		writeError(w, http.StatusBadRequest, err.Error(), "INVALID_URL")
+		writeFieldError(w, http.StatusBadRequest, "url", err.Error(), "INVALID_URL")
+		return
+	}
+	// Governing: SPEC-0009 REQ "Variable Constraints", ADR-0013
+	if err := store.ValidateVariableConstraints(req.URL, req.VariableConstraints); err != nil {
+		writeFieldError(w, http.StatusBadRequest, "variable_constraints", err.Error(), "INVALID_CONSTRAINT")
+		return
+	}
+	// Governing: SPEC-0002 REQ "Redirect Type"
+	if req.RedirectType != 0 {
+		if err := store.ValidateRedirectType(req.RedirectType); err != nil {
+			writeFieldError(w, http.StatusBadRequest, "redirect_type", err.Error(), "INVALID_REDIRECT_TYPE")
+			return
+		}
+	}
+	// Governing: SPEC-0002 REQ "UTM Parameters"
+	if err := store.ValidateUTMParams(req.UTMParams); err != nil {
+		writeFieldError(w, http.StatusBadRequest, "utm_params", err.Error(), "INVALID_UTM_PARAMS")
+		return
+	}
+	// Governing: SPEC-0002 REQ "Team Ownership"
+	teamID, ok := h.resolveTeam(w, r, req.Team)
+	if !ok {
 		return
 	}
 
@@ -149,16 +302,39 @@ func (h *linksAPIHandler) Create(w http.ResponseWriter, r *http.Request) {
 		visibility = "public"
 	}
 	if err := store.ValidateVisibility(visibility); err != nil {
-		writeError(w, http.StatusBadRequest, err.Error(), "INVALID_VISIBILITY")
+		writeFieldError(w, http.StatusBadRequest, "visibility", err.Error(), "INVALID_VISIBILITY")
+		return
+	}
+
+	// Governing: SPEC-0011 REQ "Link Lifecycle Policies"
+	warnings, ok := checkLinkPolicies(w, r, h.policies, store.PolicySubject{
+		Title:       req.Title,
+		Description: req.Description,
+		Visibility:  visibility,
+		TeamID:      teamID,
+		Tags:        req.Tags,
+		OwnerIDs:    []string{user.ID},
+	})
+	if !ok {
 		return
 	}
 
 	link, err := h.links.Create(r.Context(), req.Slug, req.URL, user.ID, req.Title, req.Description, visibility)
 	if err != nil {
+		// Governing: SPEC-0002 REQ "Reserved Slugs"
+		if errors.Is(err, store.ErrSlugReserved) {
+			writeFieldError(w, http.StatusBadRequest, "slug", err.Error(), "INVALID_SLUG")
+			return
+		}
 		if errors.Is(err, store.ErrSlugTaken) {
 			writeError(w, http.StatusConflict, "slug already exists", "SLUG_CONFLICT")
 			return
 		}
+		// Governing: SPEC-0002 REQ "Destination Domain Rules"
+		if errors.Is(err, store.ErrDomainNotAllowed) {
+			writeFieldError(w, http.StatusBadRequest, "url", err.Error(), "DOMAIN_NOT_ALLOWED")
+			return
+		}
 		log.Printf("api: create link %q: %v", req.Slug, err)
 		if isDBLockError(err) {
 			writeError(w, http.StatusServiceUnavailable, "server is busy, please retry", "DB_BUSY")
@@ -168,6 +344,43 @@ func (h *linksAPIHandler) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if len(req.VariableConstraints) > 0 {
+		if err := h.links.SetVariableConstraints(r.Context(), link.ID, req.VariableConstraints); err != nil {
+			writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
+			return
+		}
+	}
+	if req.RedirectType != 0 && req.RedirectType != link.RedirectStatus() {
+		if err := h.links.SetRedirectType(r.Context(), link.ID, req.RedirectType); err != nil {
+			writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
+			return
+		}
+	}
+	if len(req.UTMParams) > 0 {
+		if err := h.links.SetUTMParams(r.Context(), link.ID, req.UTMParams); err != nil {
+			writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
+			return
+		}
+	}
+	if req.PassQuery {
+		if err := h.links.SetPassQuery(r.Context(), link.ID, true); err != nil {
+			writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
+			return
+		}
+	}
+	if teamID != "" {
+		if err := h.links.SetTeam(r.Context(), link.ID, teamID); err != nil {
+			writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
+			return
+		}
+	}
+	if len(req.VariableConstraints) > 0 || req.RedirectType != 0 || len(req.UTMParams) > 0 || req.PassQuery || teamID != "" {
+		if link, err = h.links.GetByID(r.Context(), link.ID); err != nil {
+			writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
+			return
+		}
+	}
+
 	// Set tags if provided.
 	if len(req.Tags) > 0 {
 		if err := h.links.SetTags(r.Context(), link.ID, req.Tags); err != nil {
@@ -175,6 +388,13 @@ func (h *linksAPIHandler) Create(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 	}
+	if h.policies != nil {
+		if err := h.policies.RecordViolations(r.Context(), link.ID, warnings); err != nil {
+			writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
+			return
+		}
+	}
+	h.saveIdempotencyKey(r, user, idempotencyKey, requestHash, link.ID)
 
 	lr, err := h.toLinkResponse(r.Context(), link)
 	if err != nil {
@@ -182,6 +402,7 @@ func (h *linksAPIHandler) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	w.Header().Set("ETag", representationETag(lr)) // Governing: SPEC-0005 REQ "Conditional Requests"
 	writeJSON(w, http.StatusCreated, lr)
 }
 
@@ -194,12 +415,14 @@ func (h *linksAPIHandler) Create(w http.ResponseWriter, r *http.Request) {
 // @Tags         Links
 // @Accept       json
 // @Produce      json
-// @Param        id   path      string  true  "Link ID"
-// @Success      200  {object}  LinkResponse
-// @Failure      401  {object}  ErrorResponse
-// @Failure      403  {object}  ErrorResponse
-// @Failure      404  {object}  ErrorResponse
-// @Failure      500  {object}  ErrorResponse
+// @Param        id             path      string  true   "Link ID"
+// @Param        If-None-Match  header    string  false  "ETag of the link the caller already has"
+// @Success      200            {object}  LinkResponse
+// @Success      304            "Not Modified"
+// @Failure      401            {object}  ErrorResponse
+// @Failure      403            {object}  ErrorResponse
+// @Failure      404            {object}  ErrorResponse
+// @Failure      500            {object}  ErrorResponse
 // @Security     BearerToken
 // @Router       /links/{id} [get]
 func (h *linksAPIHandler) Get(w http.ResponseWriter, r *http.Request) {
@@ -220,24 +443,14 @@ func (h *linksAPIHandler) Get(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Governing: SPEC-0010 REQ "REST API Visibility Field" — owners, shared users, and admins may access
-	if user.Role != "admin" {
-		isOwner, err := h.ownership.IsOwner(link.ID, user.ID)
-		if err != nil {
-			writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
-			return
-		}
-		if !isOwner {
-			hasShare, err := h.links.HasShare(r.Context(), link.ID, user.ID)
-			if err != nil {
-				writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
-				return
-			}
-			if !hasShare {
-				writeError(w, http.StatusForbidden, "forbidden", "FORBIDDEN")
-				return
-			}
-		}
+	ok, err := h.canRead(r.Context(), user, link)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
+		return
+	}
+	if !ok {
+		writeError(w, http.StatusForbidden, "forbidden", "FORBIDDEN")
+		return
 	}
 
 	lr, err := h.toLinkResponse(r.Context(), link)
@@ -249,101 +462,324 @@ func (h *linksAPIHandler) Get(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusOK, lr)
 }
 
+// saveIdempotencyKey remembers that key, sent with a body hashing to
+// requestHash, produced linkID. Failures are logged: the link exists either
+// way, and only a retry's replay is lost.
+// Governing: SPEC-0005 REQ "Idempotent Link Creation"
+func (h *linksAPIHandler) saveIdempotencyKey(r *http.Request, user *store.User, key, requestHash, linkID string) {
+	if key == "" || h.idempotency == nil {
+		return
+	}
+	if err := h.idempotency.Save(r.Context(), user.ID, key, requestHash, linkID); err != nil {
+		log.Printf("api: save idempotency key for link %s: %v", linkID, err)
+	}
+}
+
+// canEdit reports whether user may change link: admins and owners, including
+// members of a team that co-owns it.
+// Governing: SPEC-0010 REQ "Team Shares and Ownership"
+func (h *linksAPIHandler) canEdit(user *store.User, link *store.Link) (bool, error) {
+	return store.IsOwnerOrAdmin(h.ownership, link.ID, user.ID, user.Role)
+}
+
+// canRead reports whether user may read link through the API.
+// Governing: SPEC-0010 REQ "REST API Visibility Field" — owners, shared users, and admins may access
+func (h *linksAPIHandler) canRead(ctx context.Context, user *store.User, link *store.Link) (bool, error) {
+	return canReadLink(ctx, h.links, h.ownership, user, link)
+}
+
+// canReadLink reports whether user may read link: admins, owners, and users
+// the link is shared with.
+func canReadLink(ctx context.Context, links *store.LinkStore, ownership *store.OwnershipStore, user *store.User, link *store.Link) (bool, error) {
+	if user.Role == "admin" {
+		return true, nil
+	}
+	isOwner, err := ownership.IsOwner(link.ID, user.ID)
+	if err != nil || isOwner {
+		return isOwner, err
+	}
+	return links.HasShare(ctx, link.ID, user.ID)
+}
+
 // Update modifies a link's url, title, description, and tags. Slug is immutable and ignored.
 // PUT /api/v1/links/{id}
 // Governing: SPEC-0005 REQ "Link Resource" — slug field MUST be ignored (immutable)
 //
 // @Summary      Update a link
 // @Description  Updates url, title, description, and tags. Slug is immutable and ignored.
+// @Description  PUT replaces the link: title, description, tags, variable_constraints, and utm_params left out of
+// @Description  the body are cleared, while visibility, redirect_type, pass_query, and team are kept. Use PATCH to change single fields.
+// @Description  Changes that break a blocking link policy are refused with POLICY_VIOLATION.
+// @Description  Send the link's ETag as If-Match to refuse the change with 412 if the link changed since it was read.
 // @Tags         Links
 // @Accept       json
 // @Produce      json
-// @Param        id    path      string             true  "Link ID"
-// @Param        body  body      UpdateLinkRequest  true  "Fields to update"
-// @Success      200   {object}  LinkResponse
-// @Failure      400   {object}  ErrorResponse
-// @Failure      401   {object}  ErrorResponse
-// @Failure      403   {object}  ErrorResponse
-// @Failure      404   {object}  ErrorResponse
-// @Failure      500   {object}  ErrorResponse
+// @Param        id        path      string             true   "Link ID"
+// @Param        If-Match  header    string             false  "ETag of the link the change is based on"
+// @Param        body      body      UpdateLinkRequest  true   "Fields to update"
+// @Success      200       {object}  LinkResponse
+// @Failure      400       {object}  ErrorResponse
+// @Failure      401       {object}  ErrorResponse
+// @Failure      403       {object}  ErrorResponse
+// @Failure      404       {object}  ErrorResponse
+// @Failure      412       {object}  ErrorResponse
+// @Failure      500       {object}  ErrorResponse
 // @Security     BearerToken
 // @Router       /links/{id} [put]
 func (h *linksAPIHandler) Update(w http.ResponseWriter, r *http.Request) {
+	link, _, ok := h.editableLink(w, r)
+	if !ok {
+		return
+	}
+
+	var req UpdateLinkRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body", "BAD_REQUEST")
+		return
+	}
+	h.update(w, r, link, req)
+}
+
+// Patch changes only the fields present in the body and keeps the rest.
+// PATCH /api/v1/links/{id}
+// Governing: SPEC-0005 REQ "Link Resource"
+//
+// @Summary      Partially update a link
+// @Description  Changes only the fields present in the body; absent fields keep their values and null clears
+// @Description  a field (visibility, redirect_type, and pass_query return to their defaults). Slug is immutable.
+// @Description  Send the link's ETag as If-Match to refuse the change with 412 if the link changed since it was read.
+// @Tags         Links
+// @Accept       json
+// @Produce      json
+// @Param        id        path      string            true   "Link ID"
+// @Param        If-Match  header    string            false  "ETag of the link the change is based on"
+// @Param        body      body      PatchLinkRequest  true   "Fields to change"
+// @Success      200       {object}  LinkResponse
+// @Failure      400       {object}  ErrorResponse
+// @Failure      401       {object}  ErrorResponse
+// @Failure      403       {object}  ErrorResponse
+// @Failure      404       {object}  ErrorResponse
+// @Failure      412       {object}  ErrorResponse
+// @Failure      500       {object}  ErrorResponse
+// @Security     BearerToken
+// @Router       /links/{id} [patch]
+func (h *linksAPIHandler) Patch(w http.ResponseWriter, r *http.Request) {
+	link, current, ok := h.editableLink(w, r)
+	if !ok {
+		return
+	}
+
+	var req PatchLinkRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body", "BAD_REQUEST")
+		return
+	}
+
+	if req.URL.Null {
+		writeFieldError(w, http.StatusBadRequest, "url", "url cannot be null", "BAD_REQUEST")
+		return
+	}
+
+	// Resolve the body against the current link so update, which replaces
+	// the whole resource like PUT, leaves absent fields as they are.
+	full := UpdateLinkRequest{
+		URL:                 req.URL.apply(current.URL, ""),
+		Title:               req.Title.apply(current.Title, ""),
+		Description:         req.Description.apply(current.Description, ""),
+		Visibility:          req.Visibility.apply("", "public"), // "" keeps the current visibility
+		Tags:                req.Tags.apply(current.Tags, nil),
+		VariableConstraints: req.VariableConstraints.apply(current.VariableConstraints, nil),
+		RedirectType:        req.RedirectType.apply(0, store.DefaultRedirectType), // 0 keeps the current type
+		UTMParams:           req.UTMParams.apply(current.UTMParams, nil),
+	}
+	if req.PassQuery.Set {
+		passQuery := req.PassQuery.apply(current.PassQuery, false)
+		full.PassQuery = &passQuery
+	}
+	if req.Team.Set {
+		team := req.Team.apply("", "")
+		full.Team = &team
+	}
+	h.update(w, r, link, full)
+}
+
+// editableLink loads the link named by the {id} URL parameter for a change
+// by the caller, who must own it or be an admin, and enforces If-Match
+// against its current representation, which it also returns. On failure it
+// has already written the response and returns false.
+// Governing: SPEC-0005 REQ "Link Resource", REQ "Conditional Requests"
+func (h *linksAPIHandler) editableLink(w http.ResponseWriter, r *http.Request) (*store.Link, *LinkResponse, bool) {
 	user := auth.UserFromContext(r.Context())
 	if user == nil {
 		writeError(w, http.StatusUnauthorized, "unauthorized", "UNAUTHORIZED")
-		return
+		return nil, nil, false
 	}
 
-	linkID := chi.URLParam(r, "id")
-	link, err := h.links.GetByID(r.Context(), linkID)
+	link, err := h.links.GetByID(r.Context(), chi.URLParam(r, "id"))
 	if err != nil {
 		if errors.Is(err, store.ErrNotFound) {
 			writeError(w, http.StatusNotFound, "not found", "NOT_FOUND")
-			return
+			return nil, nil, false
 		}
 		writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
-		return
+		return nil, nil, false
 	}
 
-	if user.Role != "admin" {
-		isOwner, err := h.ownership.IsOwner(link.ID, user.ID)
-		if err != nil {
-			writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
-			return
-		}
-		if !isOwner {
-			writeError(w, http.StatusForbidden, "forbidden", "FORBIDDEN")
-			return
-		}
+	allowed, err := h.canEdit(user, link)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
+		return nil, nil, false
+	}
+	if !allowed {
+		writeError(w, http.StatusForbidden, "forbidden", "FORBIDDEN")
+		return nil, nil, false
 	}
 
-	var req UpdateLinkRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeError(w, http.StatusBadRequest, "invalid request body", "BAD_REQUEST")
-		return
+	current, err := h.toLinkResponse(r.Context(), link)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
+		return nil, nil, false
+	}
+	if !checkIfMatch(w, r, representationETag(current)) {
+		return nil, nil, false
 	}
+	return link, current, true
+}
 
+// update validates req, applies it to link, and writes the updated link,
+// reporting whether it succeeded. The caller has already checked that the
+// user may edit link.
+func (h *linksAPIHandler) update(w http.ResponseWriter, r *http.Request, link *store.Link, req UpdateLinkRequest) bool {
 	if req.URL == "" {
-		writeError(w, http.StatusBadRequest, "url is required", "BAD_REQUEST")
-		return
+		writeRequiredField(w, "url", "BAD_REQUEST")
+		return false
 	}
 
 	// Governing: SPEC-0009 REQ "Variable Placeholder Syntax", ADR-0013
 	if err := store.ValidateURLVariables(req.URL); err != nil {
-		writeError(w, http.StatusBadRequest, err.Error(), "INVALID_URL")
-		return
+		writeFieldError(w, http.StatusBadRequest, "url", err.Error(), "INVALID_URL")
+		return false
+	}
+	// Governing: SPEC-0009 REQ "Variable Constraints", ADR-0013
+	if err := store.ValidateVariableConstraints(req.URL, req.VariableConstraints); err != nil {
+		writeFieldError(w, http.StatusBadRequest, "variable_constraints", err.Error(), "INVALID_CONSTRAINT")
+		return false
+	}
+	// Governing: SPEC-0002 REQ "Redirect Type"
+	if req.RedirectType != 0 {
+		if err := store.ValidateRedirectType(req.RedirectType); err != nil {
+			writeFieldError(w, http.StatusBadRequest, "redirect_type", err.Error(), "INVALID_REDIRECT_TYPE")
+			return false
+		}
+	}
+	// Governing: SPEC-0002 REQ "UTM Parameters"
+	if err := store.ValidateUTMParams(req.UTMParams); err != nil {
+		writeFieldError(w, http.StatusBadRequest, "utm_params", err.Error(), "INVALID_UTM_PARAMS")
+		return false
+	}
+	// Governing: SPEC-0002 REQ "Team Ownership"
+	var teamID string
+	if req.Team != nil {
+		var ok bool
+		if teamID, ok = h.resolveTeam(w, r, *req.Team); !ok {
+			return false
+		}
 	}
 
 	// Governing: SPEC-0010 REQ "REST API Visibility Field"
 	visibility := link.Visibility
 	if req.Visibility != "" {
 		if err := store.ValidateVisibility(req.Visibility); err != nil {
-			writeError(w, http.StatusBadRequest, err.Error(), "INVALID_VISIBILITY")
-			return
+			writeFieldError(w, http.StatusBadRequest, "visibility", err.Error(), "INVALID_VISIBILITY")
+			return false
 		}
 		visibility = req.Visibility
 	}
 
+	// Governing: SPEC-0011 REQ "Link Lifecycle Policies"
+	policyTeamID := link.TeamID
+	if req.Team != nil {
+		policyTeamID = teamID
+	}
+	owners, err := h.ownership.ListOwners(link.ID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
+		return false
+	}
+	warnings, ok := checkLinkPolicies(w, r, h.policies, store.PolicySubject{
+		LinkID:      link.ID,
+		Title:       req.Title,
+		Description: req.Description,
+		Visibility:  visibility,
+		TeamID:      policyTeamID,
+		Tags:        req.Tags,
+		OwnerIDs:    owners,
+		CreatedAt:   link.CreatedAt,
+	})
+	if !ok {
+		return false
+	}
+
+	// PUT replaces constraints and UTM parameters along with the rest of the resource.
+	if err := h.links.SetVariableConstraints(r.Context(), link.ID, req.VariableConstraints); err != nil {
+		writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
+		return false
+	}
+	if err := h.links.SetUTMParams(r.Context(), link.ID, req.UTMParams); err != nil {
+		writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
+		return false
+	}
+	if req.PassQuery != nil {
+		if err := h.links.SetPassQuery(r.Context(), link.ID, *req.PassQuery); err != nil {
+			writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
+			return false
+		}
+	}
+	if req.Team != nil {
+		if err := h.links.SetTeam(r.Context(), link.ID, teamID); err != nil {
+			writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
+			return false
+		}
+	}
+	if req.RedirectType != 0 {
+		if err := h.links.SetRedirectType(r.Context(), link.ID, req.RedirectType); err != nil {
+			writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
+			return false
+		}
+	}
 	updated, err := h.links.Update(r.Context(), link.ID, req.URL, req.Title, req.Description, visibility)
 	if err != nil {
+		// Governing: SPEC-0002 REQ "Destination Domain Rules"
+		if errors.Is(err, store.ErrDomainNotAllowed) {
+			writeFieldError(w, http.StatusBadRequest, "url", err.Error(), "DOMAIN_NOT_ALLOWED")
+			return false
+		}
 		writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
-		return
+		return false
 	}
 
 	// Update tags.
 	if err := h.links.SetTags(r.Context(), link.ID, req.Tags); err != nil {
 		writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
-		return
+		return false
+	}
+	if h.policies != nil {
+		if err := h.policies.RecordViolations(r.Context(), link.ID, warnings); err != nil {
+			writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
+			return false
+		}
 	}
 
 	lr, err := h.toLinkResponse(r.Context(), updated)
 	if err != nil {
 		writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
-		return
+		return false
 	}
 
+	// Governing: SPEC-0005 REQ "Conditional Requests" — the ETag a following GET would return
+	w.Header().Set("ETag", representationETag(lr))
 	writeJSON(w, http.StatusOK, lr)
+	return true
 }
 
 // Delete removes a link. Owners and admins only.
@@ -352,47 +788,26 @@ func (h *linksAPIHandler) Update(w http.ResponseWriter, r *http.Request) {
 //
 // @Summary      Delete a link
 // @Description  Deletes a link by ID. Only owners and admins may delete.
+// @Description  Send the link's ETag as If-Match to refuse the delete with 412 if the link changed since it was read.
 // @Tags         Links
 // @Accept       json
 // @Produce      json
-// @Param        id   path      string  true  "Link ID"
-// @Success      204  "No Content"
-// @Failure      401  {object}  ErrorResponse
-// @Failure      403  {object}  ErrorResponse
-// @Failure      404  {object}  ErrorResponse
-// @Failure      500  {object}  ErrorResponse
+// @Param        id        path      string  true   "Link ID"
+// @Param        If-Match  header    string  false  "ETag of the link the delete is based on"
+// @Success      204       "No Content"
+// @Failure      401       {object}  ErrorResponse
+// @Failure      403       {object}  ErrorResponse
+// @Failure      404       {object}  ErrorResponse
+// @Failure      412       {object}  ErrorResponse
+// @Failure      500       {object}  ErrorResponse
 // @Security     BearerToken
 // @Router       /links/{id} [delete]
 func (h *linksAPIHandler) Delete(w http.ResponseWriter, r *http.Request) {
-	user := auth.UserFromContext(r.Context())
-	if user == nil {
-		writeError(w, http.StatusUnauthorized, "unauthorized", "UNAUTHORIZED")
+	link, _, ok := h.editableLink(w, r)
+	if !ok {
 		return
 	}
 
-	linkID := chi.URLParam(r, "id")
-	link, err := h.links.GetByID(r.Context(), linkID)
-	if err != nil {
-		if errors.Is(err, store.ErrNotFound) {
-			writeError(w, http.StatusNotFound, "not found", "NOT_FOUND")
-			return
-		}
-		writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
-		return
-	}
-
-	if user.Role != "admin" {
-		isOwner, err := h.ownership.IsOwner(link.ID, user.ID)
-		if err != nil {
-			writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
-			return
-		}
-		if !isOwner {
-			writeError(w, http.StatusForbidden, "forbidden", "FORBIDDEN")
-			return
-		}
-	}
-
 	if err := h.links.Delete(r.Context(), link.ID); err != nil {
 		writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
 		return
@@ -522,7 +937,7 @@ func (h *linksAPIHandler) AddOwner(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	if req.Email == "" {
-		writeError(w, http.StatusBadRequest, "email is required", "BAD_REQUEST")
+		writeRequiredField(w, "email", "BAD_REQUEST")
 		return
 	}
 
@@ -544,6 +959,10 @@ func (h *linksAPIHandler) AddOwner(w http.ResponseWriter, r *http.Request) {
 		writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
 		return
 	}
+	// Governing: SPEC-0001 REQ "Email Notifications"
+	if h.notify != nil {
+		h.notify.CoOwnerAdded(link, targetUser, user)
+	}
 
 	writeJSON(w, http.StatusCreated, OwnerResponse{
 		ID:        targetUser.ID,
@@ -618,9 +1037,39 @@ func (h *linksAPIHandler) RemoveOwner(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// resolveTeam returns the ID of the team with the given slug, or "" for an
+// empty slug. It writes a 400 INVALID_TEAM and returns false when no such team
+// exists.
+// Governing: SPEC-0002 REQ "Team Ownership"
+func (h *linksAPIHandler) resolveTeam(w http.ResponseWriter, r *http.Request, slug string) (string, bool) {
+	if slug == "" {
+		return "", true
+	}
+	if h.teams == nil {
+		writeFieldError(w, http.StatusBadRequest, "team", "teams are not enabled", "INVALID_TEAM")
+		return "", false
+	}
+	team, err := h.teams.GetBySlug(r.Context(), slug)
+	if errors.Is(err, store.ErrNotFound) {
+		writeFieldError(w, http.StatusBadRequest, "team", "team not found", "INVALID_TEAM")
+		return "", false
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
+		return "", false
+	}
+	return team.ID, true
+}
+
 // toLinkResponse converts a store.Link to an API LinkResponse, including owners and tags.
 func (h *linksAPIHandler) toLinkResponse(ctx context.Context, link *store.Link) (*LinkResponse, error) {
-	owners, err := h.ownership.ListOwnerUsers(link.ID)
+	return buildLinkResponse(ctx, h.links, h.ownership, link)
+}
+
+// buildLinkResponse converts a store.Link to the full LinkResponse. Every
+// endpoint that returns links uses it so they all return the same resource.
+func buildLinkResponse(ctx context.Context, links *store.LinkStore, ownership *store.OwnershipStore, link *store.Link) (*LinkResponse, error) {
+	owners, err := ownership.ListOwnerUsers(link.ID)
 	if err != nil {
 		return nil, err
 	}
@@ -634,7 +1083,7 @@ func (h *linksAPIHandler) toLinkResponse(ctx context.Context, link *store.Link)
 		})
 	}
 
-	tags, err := h.links.ListTags(ctx, link.ID)
+	tags, err := links.ListTags(ctx, link.ID)
 	if err != nil {
 		return nil, err
 	}
@@ -643,6 +1092,14 @@ func (h *linksAPIHandler) toLinkResponse(ctx context.Context, link *store.Link)
 		tagNames = append(tagNames, t.Name)
 	}
 
+	// Governing: SPEC-0002 REQ "Team Ownership"
+	var team *TeamResponse
+	if t, err := links.GetTeam(ctx, link.TeamID); err != nil {
+		return nil, err
+	} else if t != nil {
+		team = teamResponse(t)
+	}
+
 	return &LinkResponse{
 		ID:          link.ID,
 		Slug:        link.Slug,
@@ -654,5 +1111,13 @@ func (h *linksAPIHandler) toLinkResponse(ctx context.Context, link *store.Link)
 		Owners:      ownerResponses,
 		CreatedAt:   link.CreatedAt,
 		UpdatedAt:   link.UpdatedAt,
+
+		VariableConstraints: link.Constraints(),
+		RedirectType:        link.RedirectStatus(),
+		UTMParams:           link.UTM(),
+		PassQuery:           link.PassQuery,
+		Team:                team,
+		PendingReview:       link.PendingReview,
+		CreatedBy:           link.CreatedBy,
 	}, nil
 }
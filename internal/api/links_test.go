@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/joestump/joe-links/internal/api"
@@ -40,6 +41,49 @@ func TestLinks_List_OK(t *testing.T) {
 	}
 }
 
+// Governing: SPEC-0002 REQ "Structured Search Filters"
+func TestLinks_List_Search(t *testing.T) {
+	env := newTestEnv(t)
+	user := seedUser(t, env, "alice@example.com", "user")
+	token := seedToken(t, env, user.ID)
+	ctx := context.Background()
+
+	for _, slug := range []string{"runbook", "roadmap"} {
+		l, err := env.LinkStore.Create(ctx, slug, "https://example.com/"+slug, user.ID, "", "", "private")
+		if err != nil {
+			t.Fatalf("create link: %v", err)
+		}
+		if slug == "runbook" {
+			if err := env.LinkStore.SetTags(ctx, l.ID, []string{"ops"}); err != nil {
+				t.Fatalf("set tags: %v", err)
+			}
+		}
+	}
+
+	list := func(q string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", "/links?q="+q, nil)
+		authRequest(req, token)
+		rec := httptest.NewRecorder()
+		env.Router.ServeHTTP(rec, req)
+		return rec
+	}
+	rec := list("tag:ops+owner:alice")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d; body: %s", rec.Code, rec.Body.String())
+	}
+	var resp api.LinkListResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(resp.Links) != 1 || resp.Links[0].Slug != "runbook" {
+		t.Errorf("links = %+v, want only runbook", resp.Links)
+	}
+
+	if rec := list("visibility:hidden"); rec.Code != http.StatusBadRequest || !strings.Contains(rec.Body.String(), "INVALID_FILTER") {
+		t.Errorf("invalid filter: status = %d; body: %s", rec.Code, rec.Body.String())
+	}
+}
+
 func TestLinks_List_Unauthenticated(t *testing.T) {
 	env := newTestEnv(t)
 	req := httptest.NewRequest("GET", "/links", nil)
@@ -105,6 +149,152 @@ func TestLinks_Create_DuplicateSlug(t *testing.T) {
 	}
 }
 
+// Governing: SPEC-0005 REQ "Idempotent Link Creation"
+func TestLinks_Create_IdempotencyKey(t *testing.T) {
+	env := newTestEnv(t)
+	user := seedUser(t, env, "alice@example.com", "user")
+	token := seedToken(t, env, user.ID)
+
+	post := func(key, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", "/links", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Idempotency-Key", key)
+		rec := httptest.NewRecorder()
+		env.Router.ServeHTTP(rec, authRequest(req, token))
+		return rec
+	}
+
+	body := `{"slug":"retried","url":"https://example.com"}`
+	first := post("k1", body)
+	if first.Code != http.StatusCreated {
+		t.Fatalf("first: status = %d; body: %s", first.Code, first.Body.String())
+	}
+	retry := post("k1", body)
+	if retry.Code != http.StatusCreated || retry.Header().Get("Idempotent-Replayed") != "true" {
+		t.Fatalf("retry: status = %d, replayed = %q; body: %s", retry.Code, retry.Header().Get("Idempotent-Replayed"), retry.Body.String())
+	}
+	var a, b api.LinkResponse
+	_ = json.NewDecoder(first.Body).Decode(&a)
+	_ = json.NewDecoder(retry.Body).Decode(&b)
+	if a.ID == "" || a.ID != b.ID {
+		t.Errorf("retry returned link %q, want %q", b.ID, a.ID)
+	}
+
+	if rec := post("k1", `{"slug":"other","url":"https://example.com"}`); rec.Code != http.StatusUnprocessableEntity {
+		t.Errorf("reused key: status = %d, want 422", rec.Code)
+	}
+	if rec := post("k2", body); rec.Code != http.StatusConflict {
+		t.Errorf("new key, same slug: status = %d, want 409", rec.Code)
+	}
+}
+
+// Governing: SPEC-0005 REQ "Idempotent Link Creation"
+func TestLinks_Create_Upsert(t *testing.T) {
+	env := newTestEnv(t)
+	alice := seedUser(t, env, "alice@example.com", "user")
+	bob := seedUser(t, env, "bob@example.com", "user")
+	aliceToken := seedToken(t, env, alice.ID)
+	bobToken := seedToken(t, env, bob.ID)
+
+	post := func(token, key, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", "/links?upsert=true", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		if key != "" {
+			req.Header.Set("Idempotency-Key", key)
+		}
+		rec := httptest.NewRecorder()
+		env.Router.ServeHTTP(rec, authRequest(req, token))
+		return rec
+	}
+
+	if rec := post(aliceToken, "", `{"slug":"docs","url":"https://a.example.com"}`); rec.Code != http.StatusCreated {
+		t.Fatalf("create: status = %d; body: %s", rec.Code, rec.Body.String())
+	}
+	rec := post(aliceToken, "", `{"slug":"docs","url":"https://b.example.com","title":"Docs"}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("converge: status = %d; body: %s", rec.Code, rec.Body.String())
+	}
+	var resp api.LinkResponse
+	_ = json.NewDecoder(rec.Body).Decode(&resp)
+	if resp.URL != "https://b.example.com" || resp.Title != "Docs" {
+		t.Errorf("converged link = %q %q, want updated url and title", resp.URL, resp.Title)
+	}
+	if rec := post(bobToken, "", `{"slug":"docs","url":"https://evil.example.com"}`); rec.Code != http.StatusConflict {
+		t.Errorf("non-owner upsert: status = %d, want 409", rec.Code)
+	}
+
+	// Members of a co-owning team may converge like PUT lets them edit, and
+	// the Idempotency-Key of a converging request is remembered too.
+	ctx := context.Background()
+	team, err := env.Teams.Create(ctx, "docs-team", "Docs", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := env.Teams.AddMember(ctx, team.ID, bob.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := env.OwnershipStore.AddTeamOwner(resp.ID, team.ID); err != nil {
+		t.Fatal(err)
+	}
+	body := `{"slug":"docs","url":"https://c.example.com","title":"Docs"}`
+	if rec := post(bobToken, "k1", body); rec.Code != http.StatusOK {
+		t.Fatalf("team co-owner upsert: status = %d; body: %s", rec.Code, rec.Body.String())
+	}
+	retry := post(bobToken, "k1", body)
+	if retry.Code != http.StatusCreated || retry.Header().Get("Idempotent-Replayed") != "true" {
+		t.Errorf("retried upsert: status = %d, replayed = %q", retry.Code, retry.Header().Get("Idempotent-Replayed"))
+	}
+}
+
+// Governing: SPEC-0005 REQ "Standard Error Response Format"
+func TestLinks_Create_FieldErrors(t *testing.T) {
+	env := newTestEnv(t)
+	user := seedUser(t, env, "alice@example.com", "user")
+	token := seedToken(t, env, user.ID)
+
+	cases := []struct {
+		body, field, code string
+	}{
+		{`{"url":"https://example.com"}`, "slug", "REQUIRED"},
+		{`{"slug":"Bad Slug","url":"https://example.com"}`, "slug", "INVALID_SLUG"},
+		{`{"slug":"ok-slug","url":"https://example.com","redirect_type":303}`, "redirect_type", "INVALID_REDIRECT_TYPE"},
+	}
+	for _, tc := range cases {
+		req := httptest.NewRequest("POST", "/links", bytes.NewBufferString(tc.body))
+		req.Header.Set("Content-Type", "application/json")
+		authRequest(req, token)
+		rec := httptest.NewRecorder()
+		env.Router.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("body %s: status = %d, want 400", tc.body, rec.Code)
+		}
+		var resp api.ErrorResponse
+		if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		if resp.Error == "" || len(resp.Errors) != 1 {
+			t.Fatalf("body %s: response = %+v, want error and one field error", tc.body, resp)
+		}
+		if fe := resp.Errors[0]; fe.Field != tc.field || fe.Code != tc.code || fe.Message == "" {
+			t.Errorf("body %s: field error = %+v, want field %q code %q", tc.body, fe, tc.field, tc.code)
+		}
+	}
+
+	// Errors that are not about a field carry no errors array.
+	if _, err := env.LinkStore.Create(context.Background(), "taken", "https://a.com", user.ID, "", "", ""); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	req := httptest.NewRequest("POST", "/links", bytes.NewBufferString(`{"slug":"taken","url":"https://b.com"}`))
+	req.Header.Set("Content-Type", "application/json")
+	authRequest(req, token)
+	rec := httptest.NewRecorder()
+	env.Router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusConflict || strings.Contains(rec.Body.String(), `"errors"`) {
+		t.Errorf("conflict: status = %d, body %s", rec.Code, rec.Body.String())
+	}
+}
+
 func TestLinks_Create_Unauthenticated(t *testing.T) {
 	env := newTestEnv(t)
 	body := `{"slug":"no-auth","url":"https://example.com"}`
@@ -325,6 +515,53 @@ func TestLinks_Create_DuplicateVariable_Rejected(t *testing.T) {
 	}
 }
 
+// Governing: SPEC-0009 REQ "Variable Constraints", ADR-0013
+func TestLinks_Create_VariableConstraints(t *testing.T) {
+	env := newTestEnv(t)
+	user := seedUser(t, env, "alice@example.com", "user")
+	token := seedToken(t, env, user.ID)
+
+	body := `{"slug":"ticket","url":"https://jira.example.com/browse/$key","variable_constraints":{"key":"[A-Z]+-[0-9]+"}}`
+	req := httptest.NewRequest("POST", "/links", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	authRequest(req, token)
+	rec := httptest.NewRecorder()
+	env.Router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("status = %d, want %d; body: %s", rec.Code, http.StatusCreated, rec.Body.String())
+	}
+	var resp api.LinkResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if resp.VariableConstraints["key"] != "[A-Z]+-[0-9]+" {
+		t.Errorf("variable_constraints = %v", resp.VariableConstraints)
+	}
+}
+
+// Governing: SPEC-0009 REQ "Variable Constraints", ADR-0013
+func TestLinks_Create_VariableConstraints_Invalid(t *testing.T) {
+	env := newTestEnv(t)
+	user := seedUser(t, env, "alice@example.com", "user")
+	token := seedToken(t, env, user.ID)
+
+	for _, body := range []string{
+		`{"slug":"bad-re","url":"https://example.com/$id","variable_constraints":{"id":"[0-9"}}`,
+		`{"slug":"bad-var","url":"https://example.com/$id","variable_constraints":{"other":"[0-9]+"}}`,
+	} {
+		req := httptest.NewRequest("POST", "/links", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		authRequest(req, token)
+		rec := httptest.NewRecorder()
+		env.Router.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusBadRequest || !strings.Contains(rec.Body.String(), "INVALID_CONSTRAINT") {
+			t.Errorf("body %s: status = %d, response %s", body, rec.Code, rec.Body.String())
+		}
+	}
+}
+
 // Governing: SPEC-0009 REQ "API Representation", ADR-0013
 func TestLinks_Get_VariableURL_Passthrough(t *testing.T) {
 	env := newTestEnv(t)
@@ -353,3 +590,305 @@ func TestLinks_Get_VariableURL_Passthrough(t *testing.T) {
 		t.Errorf("url = %q, want %q — API must return template as-is", resp.URL, "https://example.com/$query/$page")
 	}
 }
+
+// Governing: SPEC-0002 REQ "Redirect Type"
+func TestLinks_RedirectType(t *testing.T) {
+	env := newTestEnv(t)
+	user := seedUser(t, env, "alice@example.com", "user")
+	token := seedToken(t, env, user.ID)
+
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		authRequest(req, token)
+		rec := httptest.NewRecorder()
+		env.Router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := do("POST", "/links", `{"slug":"handbook","url":"https://example.com/handbook","redirect_type":301}`)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("status = %d; body: %s", rec.Code, rec.Body.String())
+	}
+	var created api.LinkResponse
+	if err := json.NewDecoder(rec.Body).Decode(&created); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if created.RedirectType != 301 {
+		t.Errorf("redirect_type = %d, want 301", created.RedirectType)
+	}
+
+	// Omitting redirect_type on PUT keeps the current value.
+	rec = do("PUT", "/links/"+created.ID, `{"url":"https://example.com/handbook/v2"}`)
+	var updated api.LinkResponse
+	if err := json.NewDecoder(rec.Body).Decode(&updated); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if updated.RedirectType != 301 {
+		t.Errorf("after PUT without redirect_type: redirect_type = %d, want 301", updated.RedirectType)
+	}
+
+	rec = do("POST", "/links", `{"slug":"plain","url":"https://example.com"}`)
+	var plain api.LinkResponse
+	if err := json.NewDecoder(rec.Body).Decode(&plain); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if plain.RedirectType != 302 {
+		t.Errorf("default redirect_type = %d, want 302", plain.RedirectType)
+	}
+
+	rec = do("POST", "/links", `{"slug":"see-other","url":"https://example.com","redirect_type":303}`)
+	if rec.Code != http.StatusBadRequest || !strings.Contains(rec.Body.String(), "INVALID_REDIRECT_TYPE") {
+		t.Errorf("invalid redirect_type: status = %d; body: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestLinks_UTMParams(t *testing.T) {
+	env := newTestEnv(t)
+	user := seedUser(t, env, "alice@example.com", "user")
+	token := seedToken(t, env, user.ID)
+
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		authRequest(req, token)
+		rec := httptest.NewRecorder()
+		env.Router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := do("POST", "/links", `{"slug":"launch","url":"https://example.com/launch","utm_params":{"utm_campaign":"q3"}}`)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("status = %d; body: %s", rec.Code, rec.Body.String())
+	}
+	var created api.LinkResponse
+	if err := json.NewDecoder(rec.Body).Decode(&created); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if created.UTMParams["utm_campaign"] != "q3" {
+		t.Errorf("utm_params = %v, want utm_campaign=q3", created.UTMParams)
+	}
+
+	// PUT replaces the parameters; omitting them clears them.
+	rec = do("PUT", "/links/"+created.ID, `{"url":"https://example.com/launch"}`)
+	var updated api.LinkResponse
+	if err := json.NewDecoder(rec.Body).Decode(&updated); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(updated.UTMParams) != 0 {
+		t.Errorf("after PUT without utm_params: utm_params = %v, want none", updated.UTMParams)
+	}
+
+	rec = do("POST", "/links", `{"slug":"tracked","url":"https://example.com","utm_params":{"ref":"golinks"}}`)
+	if rec.Code != http.StatusBadRequest || !strings.Contains(rec.Body.String(), "INVALID_UTM_PARAMS") {
+		t.Errorf("invalid utm_params: status = %d; body: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestLinks_PassQuery(t *testing.T) {
+	env := newTestEnv(t)
+	user := seedUser(t, env, "alice@example.com", "user")
+	token := seedToken(t, env, user.ID)
+
+	do := func(method, path, body string) api.LinkResponse {
+		t.Helper()
+		req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		authRequest(req, token)
+		rec := httptest.NewRecorder()
+		env.Router.ServeHTTP(rec, req)
+		var resp api.LinkResponse
+		if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+			t.Fatalf("%s %s: decode: %v", method, path, err)
+		}
+		return resp
+	}
+
+	created := do("POST", "/links", `{"slug":"jira","url":"https://jira.example.com","pass_query":true}`)
+	if !created.PassQuery {
+		t.Fatal("pass_query = false after create, want true")
+	}
+	// Omitting pass_query on PUT keeps the current setting; false turns it off.
+	if updated := do("PUT", "/links/"+created.ID, `{"url":"https://jira.example.com/issues"}`); !updated.PassQuery {
+		t.Error("pass_query = false after PUT without it, want true")
+	}
+	if updated := do("PUT", "/links/"+created.ID, `{"url":"https://jira.example.com/issues","pass_query":false}`); updated.PassQuery {
+		t.Error("pass_query = true after PUT false, want false")
+	}
+}
+
+// Governing: SPEC-0005 REQ "Links Collection"
+func TestLinks_List_BySlug(t *testing.T) {
+	env := newTestEnv(t)
+	alice := seedUser(t, env, "alice@example.com", "user")
+	bob := seedUser(t, env, "bob@example.com", "user")
+	if _, err := env.LinkStore.Create(context.Background(), "docs", "https://docs.example.com", alice.ID, "", "", ""); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if _, err := env.LinkStore.Create(context.Background(), "docs-old", "https://old.example.com", alice.ID, "", "", ""); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	for _, tt := range []struct {
+		token string
+		slug  string
+		want  int
+	}{
+		{seedToken(t, env, alice.ID), "docs", 1},
+		{seedToken(t, env, alice.ID), "missing", 0},
+		{seedToken(t, env, bob.ID), "docs", 0},
+	} {
+		rec := httptest.NewRecorder()
+		env.Router.ServeHTTP(rec, authRequest(httptest.NewRequest("GET", "/links?slug="+tt.slug, nil), tt.token))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("slug %s: status = %d", tt.slug, rec.Code)
+		}
+		var resp api.LinkListResponse
+		_ = json.NewDecoder(rec.Body).Decode(&resp)
+		if len(resp.Links) != tt.want || (tt.want == 1 && resp.Links[0].Slug != tt.slug) {
+			t.Errorf("slug %s: got %d links, want %d", tt.slug, len(resp.Links), tt.want)
+		}
+	}
+}
+
+// Governing: SPEC-0005 REQ "Link Resource"
+func TestLinks_Patch_KeepsAbsentFields(t *testing.T) {
+	env := newTestEnv(t)
+	user := seedUser(t, env, "alice@example.com", "user")
+	token := seedToken(t, env, user.ID)
+	link, err := env.LinkStore.Create(context.Background(), "patch-me", "https://example.com", user.ID, "Old", "Keep me", "")
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if err := env.LinkStore.SetTags(context.Background(), link.ID, []string{"infra"}); err != nil {
+		t.Fatalf("tags: %v", err)
+	}
+
+	req := httptest.NewRequest("PATCH", "/links/"+link.ID, strings.NewReader(`{"title":"New"}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	env.Router.ServeHTTP(rec, authRequest(req, token))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d; body: %s", rec.Code, rec.Body.String())
+	}
+	var resp api.LinkResponse
+	_ = json.NewDecoder(rec.Body).Decode(&resp)
+	if resp.Title != "New" || resp.URL != "https://example.com" || resp.Description != "Keep me" || len(resp.Tags) != 1 {
+		t.Errorf("patched link = %+v, want only the title changed", resp)
+	}
+}
+
+// Governing: SPEC-0005 REQ "Link Resource"
+func TestLinks_Patch_NullClears(t *testing.T) {
+	env := newTestEnv(t)
+	user := seedUser(t, env, "alice@example.com", "user")
+	token := seedToken(t, env, user.ID)
+	link, err := env.LinkStore.Create(context.Background(), "clear-me", "https://example.com", user.ID, "Title", "Description", "private")
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if err := env.LinkStore.SetTags(context.Background(), link.ID, []string{"infra"}); err != nil {
+		t.Fatalf("tags: %v", err)
+	}
+
+	patch := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("PATCH", "/links/"+link.ID, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		env.Router.ServeHTTP(rec, authRequest(req, token))
+		return rec
+	}
+
+	rec := patch(`{"description":null,"tags":null,"visibility":null}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d; body: %s", rec.Code, rec.Body.String())
+	}
+	var resp api.LinkResponse
+	_ = json.NewDecoder(rec.Body).Decode(&resp)
+	if resp.Description != "" || len(resp.Tags) != 0 || resp.Visibility != "public" {
+		t.Errorf("description = %q, tags = %v, visibility = %q; want cleared and default", resp.Description, resp.Tags, resp.Visibility)
+	}
+	if resp.Title != "Title" {
+		t.Errorf("title = %q, want the absent field kept", resp.Title)
+	}
+	if rec := patch(`{"url":null}`); rec.Code != http.StatusBadRequest {
+		t.Errorf("null url: status = %d, want 400", rec.Code)
+	}
+}
+
+// PUT replaces the whole resource: optional fields left out of the body are
+// cleared, unlike PATCH.
+// Governing: SPEC-0005 REQ "Link Resource"
+func TestLinks_Update_ClearsOmittedFields(t *testing.T) {
+	env := newTestEnv(t)
+	user := seedUser(t, env, "alice@example.com", "user")
+	token := seedToken(t, env, user.ID)
+	link, err := env.LinkStore.Create(context.Background(), "put-me", "https://example.com", user.ID, "Title", "Description", "")
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if err := env.LinkStore.SetTags(context.Background(), link.ID, []string{"infra"}); err != nil {
+		t.Fatalf("tags: %v", err)
+	}
+
+	req := httptest.NewRequest("PUT", "/links/"+link.ID, strings.NewReader(`{"url":"https://example.org"}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	env.Router.ServeHTTP(rec, authRequest(req, token))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d; body: %s", rec.Code, rec.Body.String())
+	}
+	var resp api.LinkResponse
+	_ = json.NewDecoder(rec.Body).Decode(&resp)
+	if resp.URL != "https://example.org" || resp.Title != "" || resp.Description != "" || len(resp.Tags) != 0 {
+		t.Errorf("PUT result = %+v, want omitted title, description, and tags cleared", resp)
+	}
+}
+
+// Governing: SPEC-0005 REQ "Conditional Requests"
+func TestLinks_IfMatch(t *testing.T) {
+	env := newTestEnv(t)
+	user := seedUser(t, env, "alice@example.com", "user")
+	token := seedToken(t, env, user.ID)
+	link, err := env.LinkStore.Create(context.Background(), "guarded", "https://example.com", user.ID, "", "", "")
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	get := httptest.NewRecorder()
+	env.Router.ServeHTTP(get, authRequest(httptest.NewRequest("GET", "/links/"+link.ID, nil), token))
+	etag := get.Header().Get("ETag")
+	if etag == "" || strings.HasPrefix(etag, "W/") {
+		t.Fatalf("GET ETag = %q, want a strong ETag", etag)
+	}
+
+	patch := func(ifMatch, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("PATCH", "/links/"+link.ID, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("If-Match", ifMatch)
+		rec := httptest.NewRecorder()
+		env.Router.ServeHTTP(rec, authRequest(req, token))
+		return rec
+	}
+	first := patch(etag, `{"title":"One"}`)
+	if first.Code != http.StatusOK {
+		t.Fatalf("matching If-Match: status = %d; body: %s", first.Code, first.Body.String())
+	}
+	if stale := patch(etag, `{"title":"Two"}`); stale.Code != http.StatusPreconditionFailed {
+		t.Errorf("stale If-Match: status = %d, want 412", stale.Code)
+	}
+
+	get = httptest.NewRecorder()
+	env.Router.ServeHTTP(get, authRequest(httptest.NewRequest("GET", "/links/"+link.ID, nil), token))
+	if got := get.Header().Get("ETag"); got != first.Header().Get("ETag") {
+		t.Errorf("PATCH returned ETag %q, GET returns %q", first.Header().Get("ETag"), got)
+	}
+
+	del := httptest.NewRequest("DELETE", "/links/"+link.ID, nil)
+	del.Header.Set("If-Match", etag)
+	rec := httptest.NewRecorder()
+	env.Router.ServeHTTP(rec, authRequest(del, token))
+	if rec.Code != http.StatusPreconditionFailed {
+		t.Errorf("stale DELETE: status = %d, want 412", rec.Code)
+	}
+}
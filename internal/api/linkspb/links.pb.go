@@ -0,0 +1,943 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.8
+// 	protoc        (unknown)
+// source: internal/api/linkspb/links.proto
+
+package linkspb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ResolveRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Short-link path, optionally with a query string, e.g. "github/joestump".
+	Path string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	// Request host, for keyword host routing.
+	Host          string `protobuf:"bytes,2,opt,name=host,proto3" json:"host,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResolveRequest) Reset() {
+	*x = ResolveRequest{}
+	mi := &file_internal_api_linkspb_links_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolveRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveRequest) ProtoMessage() {}
+
+func (x *ResolveRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_api_linkspb_links_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolveRequest.ProtoReflect.Descriptor instead.
+func (*ResolveRequest) Descriptor() ([]byte, []int) {
+	return file_internal_api_linkspb_links_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ResolveRequest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *ResolveRequest) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+type ResolveResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Path  string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	// "redirect", "keyword", or "help".
+	Outcome       string             `protobuf:"bytes,2,opt,name=outcome,proto3" json:"outcome,omitempty"`
+	Target        string             `protobuf:"bytes,3,opt,name=target,proto3" json:"target,omitempty"`
+	LinkId        string             `protobuf:"bytes,4,opt,name=link_id,json=linkId,proto3" json:"link_id,omitempty"`
+	Slug          string             `protobuf:"bytes,5,opt,name=slug,proto3" json:"slug,omitempty"`
+	Keyword       string             `protobuf:"bytes,6,opt,name=keyword,proto3" json:"keyword,omitempty"`
+	Variables     []*ResolveVariable `protobuf:"bytes,7,rep,name=variables,proto3" json:"variables,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResolveResponse) Reset() {
+	*x = ResolveResponse{}
+	mi := &file_internal_api_linkspb_links_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolveResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveResponse) ProtoMessage() {}
+
+func (x *ResolveResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_api_linkspb_links_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolveResponse.ProtoReflect.Descriptor instead.
+func (*ResolveResponse) Descriptor() ([]byte, []int) {
+	return file_internal_api_linkspb_links_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ResolveResponse) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *ResolveResponse) GetOutcome() string {
+	if x != nil {
+		return x.Outcome
+	}
+	return ""
+}
+
+func (x *ResolveResponse) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *ResolveResponse) GetLinkId() string {
+	if x != nil {
+		return x.LinkId
+	}
+	return ""
+}
+
+func (x *ResolveResponse) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+func (x *ResolveResponse) GetKeyword() string {
+	if x != nil {
+		return x.Keyword
+	}
+	return ""
+}
+
+func (x *ResolveResponse) GetVariables() []*ResolveVariable {
+	if x != nil {
+		return x.Variables
+	}
+	return nil
+}
+
+type ResolveVariable struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Placeholder   string                 `protobuf:"bytes,2,opt,name=placeholder,proto3" json:"placeholder,omitempty"`
+	Value         string                 `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	Default       bool                   `protobuf:"varint,4,opt,name=default,proto3" json:"default,omitempty"`
+	FromQuery     bool                   `protobuf:"varint,5,opt,name=from_query,json=fromQuery,proto3" json:"from_query,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResolveVariable) Reset() {
+	*x = ResolveVariable{}
+	mi := &file_internal_api_linkspb_links_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolveVariable) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveVariable) ProtoMessage() {}
+
+func (x *ResolveVariable) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_api_linkspb_links_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolveVariable.ProtoReflect.Descriptor instead.
+func (*ResolveVariable) Descriptor() ([]byte, []int) {
+	return file_internal_api_linkspb_links_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ResolveVariable) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ResolveVariable) GetPlaceholder() string {
+	if x != nil {
+		return x.Placeholder
+	}
+	return ""
+}
+
+func (x *ResolveVariable) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *ResolveVariable) GetDefault() bool {
+	if x != nil {
+		return x.Default
+	}
+	return false
+}
+
+func (x *ResolveVariable) GetFromQuery() bool {
+	if x != nil {
+		return x.FromQuery
+	}
+	return false
+}
+
+type GetLinkRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Exactly one of id or slug.
+	Id            string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Slug          string `protobuf:"bytes,2,opt,name=slug,proto3" json:"slug,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLinkRequest) Reset() {
+	*x = GetLinkRequest{}
+	mi := &file_internal_api_linkspb_links_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLinkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLinkRequest) ProtoMessage() {}
+
+func (x *GetLinkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_api_linkspb_links_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLinkRequest.ProtoReflect.Descriptor instead.
+func (*GetLinkRequest) Descriptor() ([]byte, []int) {
+	return file_internal_api_linkspb_links_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetLinkRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GetLinkRequest) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+type ListLinksRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Search text and key:value filters, as for GET /api/v1/links?q=.
+	Q string `protobuf:"bytes,1,opt,name=q,proto3" json:"q,omitempty"`
+	// Exact destination URL.
+	Url           string `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLinksRequest) Reset() {
+	*x = ListLinksRequest{}
+	mi := &file_internal_api_linkspb_links_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLinksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLinksRequest) ProtoMessage() {}
+
+func (x *ListLinksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_api_linkspb_links_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLinksRequest.ProtoReflect.Descriptor instead.
+func (*ListLinksRequest) Descriptor() ([]byte, []int) {
+	return file_internal_api_linkspb_links_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListLinksRequest) GetQ() string {
+	if x != nil {
+		return x.Q
+	}
+	return ""
+}
+
+func (x *ListLinksRequest) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+type ListLinksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Links         []*Link                `protobuf:"bytes,1,rep,name=links,proto3" json:"links,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLinksResponse) Reset() {
+	*x = ListLinksResponse{}
+	mi := &file_internal_api_linkspb_links_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLinksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLinksResponse) ProtoMessage() {}
+
+func (x *ListLinksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_api_linkspb_links_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLinksResponse.ProtoReflect.Descriptor instead.
+func (*ListLinksResponse) Descriptor() ([]byte, []int) {
+	return file_internal_api_linkspb_links_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListLinksResponse) GetLinks() []*Link {
+	if x != nil {
+		return x.Links
+	}
+	return nil
+}
+
+type CreateLinkRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Slug        string                 `protobuf:"bytes,1,opt,name=slug,proto3" json:"slug,omitempty"`
+	Url         string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	Title       string                 `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	Description string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	// "public" (default), "private", or "secure".
+	Visibility          string            `protobuf:"bytes,5,opt,name=visibility,proto3" json:"visibility,omitempty"`
+	Tags                []string          `protobuf:"bytes,6,rep,name=tags,proto3" json:"tags,omitempty"`
+	VariableConstraints map[string]string `protobuf:"bytes,7,rep,name=variable_constraints,json=variableConstraints,proto3" json:"variable_constraints,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// 301, 302, 307, or 308; 0 means the default of 302.
+	RedirectType int32             `protobuf:"varint,8,opt,name=redirect_type,json=redirectType,proto3" json:"redirect_type,omitempty"`
+	UtmParams    map[string]string `protobuf:"bytes,9,rep,name=utm_params,json=utmParams,proto3" json:"utm_params,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	PassQuery    bool              `protobuf:"varint,10,opt,name=pass_query,json=passQuery,proto3" json:"pass_query,omitempty"`
+	// Slug of the owning team.
+	Team string `protobuf:"bytes,11,opt,name=team,proto3" json:"team,omitempty"`
+	// Makes retries safe, as the Idempotency-Key header does for REST.
+	IdempotencyKey string `protobuf:"bytes,12,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CreateLinkRequest) Reset() {
+	*x = CreateLinkRequest{}
+	mi := &file_internal_api_linkspb_links_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateLinkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateLinkRequest) ProtoMessage() {}
+
+func (x *CreateLinkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_api_linkspb_links_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateLinkRequest.ProtoReflect.Descriptor instead.
+func (*CreateLinkRequest) Descriptor() ([]byte, []int) {
+	return file_internal_api_linkspb_links_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CreateLinkRequest) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+func (x *CreateLinkRequest) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *CreateLinkRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *CreateLinkRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateLinkRequest) GetVisibility() string {
+	if x != nil {
+		return x.Visibility
+	}
+	return ""
+}
+
+func (x *CreateLinkRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *CreateLinkRequest) GetVariableConstraints() map[string]string {
+	if x != nil {
+		return x.VariableConstraints
+	}
+	return nil
+}
+
+func (x *CreateLinkRequest) GetRedirectType() int32 {
+	if x != nil {
+		return x.RedirectType
+	}
+	return 0
+}
+
+func (x *CreateLinkRequest) GetUtmParams() map[string]string {
+	if x != nil {
+		return x.UtmParams
+	}
+	return nil
+}
+
+func (x *CreateLinkRequest) GetPassQuery() bool {
+	if x != nil {
+		return x.PassQuery
+	}
+	return false
+}
+
+func (x *CreateLinkRequest) GetTeam() string {
+	if x != nil {
+		return x.Team
+	}
+	return ""
+}
+
+func (x *CreateLinkRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+type Link struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Id                  string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Slug                string                 `protobuf:"bytes,2,opt,name=slug,proto3" json:"slug,omitempty"`
+	Url                 string                 `protobuf:"bytes,3,opt,name=url,proto3" json:"url,omitempty"`
+	Title               string                 `protobuf:"bytes,4,opt,name=title,proto3" json:"title,omitempty"`
+	Description         string                 `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
+	Visibility          string                 `protobuf:"bytes,6,opt,name=visibility,proto3" json:"visibility,omitempty"`
+	Tags                []string               `protobuf:"bytes,7,rep,name=tags,proto3" json:"tags,omitempty"`
+	Owners              []*Owner               `protobuf:"bytes,8,rep,name=owners,proto3" json:"owners,omitempty"`
+	VariableConstraints map[string]string      `protobuf:"bytes,9,rep,name=variable_constraints,json=variableConstraints,proto3" json:"variable_constraints,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	RedirectType        int32                  `protobuf:"varint,10,opt,name=redirect_type,json=redirectType,proto3" json:"redirect_type,omitempty"`
+	UtmParams           map[string]string      `protobuf:"bytes,11,rep,name=utm_params,json=utmParams,proto3" json:"utm_params,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	PassQuery           bool                   `protobuf:"varint,12,opt,name=pass_query,json=passQuery,proto3" json:"pass_query,omitempty"`
+	// Slug of the owning team; empty when unset.
+	Team          string                 `protobuf:"bytes,13,opt,name=team,proto3" json:"team,omitempty"`
+	PendingReview bool                   `protobuf:"varint,14,opt,name=pending_review,json=pendingReview,proto3" json:"pending_review,omitempty"`
+	CreatedBy     string                 `protobuf:"bytes,15,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,16,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,17,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Link) Reset() {
+	*x = Link{}
+	mi := &file_internal_api_linkspb_links_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Link) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Link) ProtoMessage() {}
+
+func (x *Link) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_api_linkspb_links_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Link.ProtoReflect.Descriptor instead.
+func (*Link) Descriptor() ([]byte, []int) {
+	return file_internal_api_linkspb_links_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Link) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Link) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+func (x *Link) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *Link) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Link) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Link) GetVisibility() string {
+	if x != nil {
+		return x.Visibility
+	}
+	return ""
+}
+
+func (x *Link) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *Link) GetOwners() []*Owner {
+	if x != nil {
+		return x.Owners
+	}
+	return nil
+}
+
+func (x *Link) GetVariableConstraints() map[string]string {
+	if x != nil {
+		return x.VariableConstraints
+	}
+	return nil
+}
+
+func (x *Link) GetRedirectType() int32 {
+	if x != nil {
+		return x.RedirectType
+	}
+	return 0
+}
+
+func (x *Link) GetUtmParams() map[string]string {
+	if x != nil {
+		return x.UtmParams
+	}
+	return nil
+}
+
+func (x *Link) GetPassQuery() bool {
+	if x != nil {
+		return x.PassQuery
+	}
+	return false
+}
+
+func (x *Link) GetTeam() string {
+	if x != nil {
+		return x.Team
+	}
+	return ""
+}
+
+func (x *Link) GetPendingReview() bool {
+	if x != nil {
+		return x.PendingReview
+	}
+	return false
+}
+
+func (x *Link) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *Link) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Link) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type Owner struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Email         string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	IsPrimary     bool                   `protobuf:"varint,3,opt,name=is_primary,json=isPrimary,proto3" json:"is_primary,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Owner) Reset() {
+	*x = Owner{}
+	mi := &file_internal_api_linkspb_links_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Owner) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Owner) ProtoMessage() {}
+
+func (x *Owner) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_api_linkspb_links_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Owner.ProtoReflect.Descriptor instead.
+func (*Owner) Descriptor() ([]byte, []int) {
+	return file_internal_api_linkspb_links_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Owner) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Owner) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *Owner) GetIsPrimary() bool {
+	if x != nil {
+		return x.IsPrimary
+	}
+	return false
+}
+
+var File_internal_api_linkspb_links_proto protoreflect.FileDescriptor
+
+const file_internal_api_linkspb_links_proto_rawDesc = "" +
+	"\n" +
+	" internal/api/linkspb/links.proto\x12\vjoelinks.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"8\n" +
+	"\x0eResolveRequest\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12\x12\n" +
+	"\x04host\x18\x02 \x01(\tR\x04host\"\xda\x01\n" +
+	"\x0fResolveResponse\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12\x18\n" +
+	"\aoutcome\x18\x02 \x01(\tR\aoutcome\x12\x16\n" +
+	"\x06target\x18\x03 \x01(\tR\x06target\x12\x17\n" +
+	"\alink_id\x18\x04 \x01(\tR\x06linkId\x12\x12\n" +
+	"\x04slug\x18\x05 \x01(\tR\x04slug\x12\x18\n" +
+	"\akeyword\x18\x06 \x01(\tR\akeyword\x12:\n" +
+	"\tvariables\x18\a \x03(\v2\x1c.joelinks.v1.ResolveVariableR\tvariables\"\x96\x01\n" +
+	"\x0fResolveVariable\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
+	"\vplaceholder\x18\x02 \x01(\tR\vplaceholder\x12\x14\n" +
+	"\x05value\x18\x03 \x01(\tR\x05value\x12\x18\n" +
+	"\adefault\x18\x04 \x01(\bR\adefault\x12\x1d\n" +
+	"\n" +
+	"from_query\x18\x05 \x01(\bR\tfromQuery\"4\n" +
+	"\x0eGetLinkRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04slug\x18\x02 \x01(\tR\x04slug\"2\n" +
+	"\x10ListLinksRequest\x12\f\n" +
+	"\x01q\x18\x01 \x01(\tR\x01q\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\"<\n" +
+	"\x11ListLinksResponse\x12'\n" +
+	"\x05links\x18\x01 \x03(\v2\x11.joelinks.v1.LinkR\x05links\"\xe6\x04\n" +
+	"\x11CreateLinkRequest\x12\x12\n" +
+	"\x04slug\x18\x01 \x01(\tR\x04slug\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\x12\x14\n" +
+	"\x05title\x18\x03 \x01(\tR\x05title\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x12\x1e\n" +
+	"\n" +
+	"visibility\x18\x05 \x01(\tR\n" +
+	"visibility\x12\x12\n" +
+	"\x04tags\x18\x06 \x03(\tR\x04tags\x12j\n" +
+	"\x14variable_constraints\x18\a \x03(\v27.joelinks.v1.CreateLinkRequest.VariableConstraintsEntryR\x13variableConstraints\x12#\n" +
+	"\rredirect_type\x18\b \x01(\x05R\fredirectType\x12L\n" +
+	"\n" +
+	"utm_params\x18\t \x03(\v2-.joelinks.v1.CreateLinkRequest.UtmParamsEntryR\tutmParams\x12\x1d\n" +
+	"\n" +
+	"pass_query\x18\n" +
+	" \x01(\bR\tpassQuery\x12\x12\n" +
+	"\x04team\x18\v \x01(\tR\x04team\x12'\n" +
+	"\x0fidempotency_key\x18\f \x01(\tR\x0eidempotencyKey\x1aF\n" +
+	"\x18VariableConstraintsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a<\n" +
+	"\x0eUtmParamsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x8e\x06\n" +
+	"\x04Link\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04slug\x18\x02 \x01(\tR\x04slug\x12\x10\n" +
+	"\x03url\x18\x03 \x01(\tR\x03url\x12\x14\n" +
+	"\x05title\x18\x04 \x01(\tR\x05title\x12 \n" +
+	"\vdescription\x18\x05 \x01(\tR\vdescription\x12\x1e\n" +
+	"\n" +
+	"visibility\x18\x06 \x01(\tR\n" +
+	"visibility\x12\x12\n" +
+	"\x04tags\x18\a \x03(\tR\x04tags\x12*\n" +
+	"\x06owners\x18\b \x03(\v2\x12.joelinks.v1.OwnerR\x06owners\x12]\n" +
+	"\x14variable_constraints\x18\t \x03(\v2*.joelinks.v1.Link.VariableConstraintsEntryR\x13variableConstraints\x12#\n" +
+	"\rredirect_type\x18\n" +
+	" \x01(\x05R\fredirectType\x12?\n" +
+	"\n" +
+	"utm_params\x18\v \x03(\v2 .joelinks.v1.Link.UtmParamsEntryR\tutmParams\x12\x1d\n" +
+	"\n" +
+	"pass_query\x18\f \x01(\bR\tpassQuery\x12\x12\n" +
+	"\x04team\x18\r \x01(\tR\x04team\x12%\n" +
+	"\x0epending_review\x18\x0e \x01(\bR\rpendingReview\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\x0f \x01(\tR\tcreatedBy\x129\n" +
+	"\n" +
+	"created_at\x18\x10 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x11 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x1aF\n" +
+	"\x18VariableConstraintsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a<\n" +
+	"\x0eUtmParamsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"L\n" +
+	"\x05Owner\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05email\x18\x02 \x01(\tR\x05email\x12\x1d\n" +
+	"\n" +
+	"is_primary\x18\x03 \x01(\bR\tisPrimary2\x9c\x02\n" +
+	"\fLinksService\x12D\n" +
+	"\aResolve\x12\x1b.joelinks.v1.ResolveRequest\x1a\x1c.joelinks.v1.ResolveResponse\x129\n" +
+	"\aGetLink\x12\x1b.joelinks.v1.GetLinkRequest\x1a\x11.joelinks.v1.Link\x12J\n" +
+	"\tListLinks\x12\x1d.joelinks.v1.ListLinksRequest\x1a\x1e.joelinks.v1.ListLinksResponse\x12?\n" +
+	"\n" +
+	"CreateLink\x12\x1e.joelinks.v1.CreateLinkRequest\x1a\x11.joelinks.v1.LinkB4Z2github.com/joestump/joe-links/internal/api/linkspbb\x06proto3"
+
+var (
+	file_internal_api_linkspb_links_proto_rawDescOnce sync.Once
+	file_internal_api_linkspb_links_proto_rawDescData []byte
+)
+
+func file_internal_api_linkspb_links_proto_rawDescGZIP() []byte {
+	file_internal_api_linkspb_links_proto_rawDescOnce.Do(func() {
+		file_internal_api_linkspb_links_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_internal_api_linkspb_links_proto_rawDesc), len(file_internal_api_linkspb_links_proto_rawDesc)))
+	})
+	return file_internal_api_linkspb_links_proto_rawDescData
+}
+
+var file_internal_api_linkspb_links_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_internal_api_linkspb_links_proto_goTypes = []any{
+	(*ResolveRequest)(nil),        // 0: joelinks.v1.ResolveRequest
+	(*ResolveResponse)(nil),       // 1: joelinks.v1.ResolveResponse
+	(*ResolveVariable)(nil),       // 2: joelinks.v1.ResolveVariable
+	(*GetLinkRequest)(nil),        // 3: joelinks.v1.GetLinkRequest
+	(*ListLinksRequest)(nil),      // 4: joelinks.v1.ListLinksRequest
+	(*ListLinksResponse)(nil),     // 5: joelinks.v1.ListLinksResponse
+	(*CreateLinkRequest)(nil),     // 6: joelinks.v1.CreateLinkRequest
+	(*Link)(nil),                  // 7: joelinks.v1.Link
+	(*Owner)(nil),                 // 8: joelinks.v1.Owner
+	nil,                           // 9: joelinks.v1.CreateLinkRequest.VariableConstraintsEntry
+	nil,                           // 10: joelinks.v1.CreateLinkRequest.UtmParamsEntry
+	nil,                           // 11: joelinks.v1.Link.VariableConstraintsEntry
+	nil,                           // 12: joelinks.v1.Link.UtmParamsEntry
+	(*timestamppb.Timestamp)(nil), // 13: google.protobuf.Timestamp
+}
+var file_internal_api_linkspb_links_proto_depIdxs = []int32{
+	2,  // 0: joelinks.v1.ResolveResponse.variables:type_name -> joelinks.v1.ResolveVariable
+	7,  // 1: joelinks.v1.ListLinksResponse.links:type_name -> joelinks.v1.Link
+	9,  // 2: joelinks.v1.CreateLinkRequest.variable_constraints:type_name -> joelinks.v1.CreateLinkRequest.VariableConstraintsEntry
+	10, // 3: joelinks.v1.CreateLinkRequest.utm_params:type_name -> joelinks.v1.CreateLinkRequest.UtmParamsEntry
+	8,  // 4: joelinks.v1.Link.owners:type_name -> joelinks.v1.Owner
+	11, // 5: joelinks.v1.Link.variable_constraints:type_name -> joelinks.v1.Link.VariableConstraintsEntry
+	12, // 6: joelinks.v1.Link.utm_params:type_name -> joelinks.v1.Link.UtmParamsEntry
+	13, // 7: joelinks.v1.Link.created_at:type_name -> google.protobuf.Timestamp
+	13, // 8: joelinks.v1.Link.updated_at:type_name -> google.protobuf.Timestamp
+	0,  // 9: joelinks.v1.LinksService.Resolve:input_type -> joelinks.v1.ResolveRequest
+	3,  // 10: joelinks.v1.LinksService.GetLink:input_type -> joelinks.v1.GetLinkRequest
+	4,  // 11: joelinks.v1.LinksService.ListLinks:input_type -> joelinks.v1.ListLinksRequest
+	6,  // 12: joelinks.v1.LinksService.CreateLink:input_type -> joelinks.v1.CreateLinkRequest
+	1,  // 13: joelinks.v1.LinksService.Resolve:output_type -> joelinks.v1.ResolveResponse
+	7,  // 14: joelinks.v1.LinksService.GetLink:output_type -> joelinks.v1.Link
+	5,  // 15: joelinks.v1.LinksService.ListLinks:output_type -> joelinks.v1.ListLinksResponse
+	7,  // 16: joelinks.v1.LinksService.CreateLink:output_type -> joelinks.v1.Link
+	13, // [13:17] is the sub-list for method output_type
+	9,  // [9:13] is the sub-list for method input_type
+	9,  // [9:9] is the sub-list for extension type_name
+	9,  // [9:9] is the sub-list for extension extendee
+	0,  // [0:9] is the sub-list for field type_name
+}
+
+func init() { file_internal_api_linkspb_links_proto_init() }
+func file_internal_api_linkspb_links_proto_init() {
+	if File_internal_api_linkspb_links_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_internal_api_linkspb_links_proto_rawDesc), len(file_internal_api_linkspb_links_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   13,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_internal_api_linkspb_links_proto_goTypes,
+		DependencyIndexes: file_internal_api_linkspb_links_proto_depIdxs,
+		MessageInfos:      file_internal_api_linkspb_links_proto_msgTypes,
+	}.Build()
+	File_internal_api_linkspb_links_proto = out.File
+	file_internal_api_linkspb_links_proto_goTypes = nil
+	file_internal_api_linkspb_links_proto_depIdxs = nil
+}
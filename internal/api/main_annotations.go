@@ -3,6 +3,7 @@
 // @title           joe-links API
 // @version         1.0
 // @description     Self-hosted go-links service. Authenticate with a Personal Access Token.
+// @description     In the Swagger UI, logged-in users can use "Create test token" or call the API with their browser session.
 // @BasePath        /api/v1
 // @securityDefinitions.apikey BearerToken
 // @in              header
@@ -0,0 +1,94 @@
+// Governing: SPEC-0011 REQ "Public Link Moderation"
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/joestump/joe-links/internal/store"
+)
+
+// ListPendingLinks returns the public links awaiting review.
+// GET /api/v1/admin/moderation
+//
+// @Summary      List links awaiting review (admin)
+// @Description  Returns public links held for approval by JOE_MODERATION_ENABLED, oldest first.
+// @Description  They resolve but are not listed publicly until approved. Requires admin role.
+// @Tags         Admin
+// @Produce      json
+// @Success      200  {object}  LinkListResponse
+// @Failure      401  {object}  ErrorResponse
+// @Failure      403  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Security     BearerToken
+// @Router       /admin/moderation [get]
+func (h *adminAPIHandler) ListPendingLinks(w http.ResponseWriter, r *http.Request) {
+	pending, err := h.links.ListPendingReview(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
+		return
+	}
+	lh := &linksAPIHandler{links: h.links, ownership: h.ownership}
+	resp := &LinkListResponse{Links: make([]*LinkResponse, 0, len(pending))}
+	for _, l := range pending {
+		lr, err := lh.toLinkResponse(r.Context(), &l.Link)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
+			return
+		}
+		resp.Links = append(resp.Links, lr)
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// ApproveLink lists a pending link publicly.
+// POST /api/v1/admin/moderation/{id}/approve
+//
+// @Summary      Approve a link (admin)
+// @Description  Lists a link awaiting review in the public link browser, profiles, and feeds. Requires admin role.
+// @Tags         Admin
+// @Produce      json
+// @Param        id   path  string  true  "Link ID"
+// @Success      204  "No Content"
+// @Failure      401  {object}  ErrorResponse
+// @Failure      403  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Security     BearerToken
+// @Router       /admin/moderation/{id}/approve [post]
+func (h *adminAPIHandler) ApproveLink(w http.ResponseWriter, r *http.Request) {
+	h.review(w, r, h.links.ApproveLink)
+}
+
+// RejectLink makes a pending link private.
+// POST /api/v1/admin/moderation/{id}/reject
+//
+// @Summary      Reject a link (admin)
+// @Description  Makes a link awaiting review private; it keeps resolving for its owners. Requires admin role.
+// @Tags         Admin
+// @Produce      json
+// @Param        id   path  string  true  "Link ID"
+// @Success      204  "No Content"
+// @Failure      401  {object}  ErrorResponse
+// @Failure      403  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Security     BearerToken
+// @Router       /admin/moderation/{id}/reject [post]
+func (h *adminAPIHandler) RejectLink(w http.ResponseWriter, r *http.Request) {
+	h.review(w, r, h.links.RejectLink)
+}
+
+func (h *adminAPIHandler) review(w http.ResponseWriter, r *http.Request, apply func(context.Context, string) error) {
+	if err := apply(r.Context(), chi.URLParam(r, "id")); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			writeError(w, http.StatusNotFound, "link is not awaiting review", "NOT_FOUND")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
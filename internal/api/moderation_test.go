@@ -0,0 +1,64 @@
+// Governing: SPEC-0011 REQ "Public Link Moderation"
+package api_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/joestump/joe-links/internal/api"
+)
+
+func TestModerationQueue(t *testing.T) {
+	env := newTestEnv(t)
+	env.LinkStore.SetModeration(true)
+	admin := seedUser(t, env, "admin@example.com", "admin")
+	user := seedUser(t, env, "user@example.com", "user")
+	adminToken := seedToken(t, env, admin.ID)
+	userToken := seedToken(t, env, user.ID)
+
+	do := func(token, method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		authRequest(req, token)
+		rec := httptest.NewRecorder()
+		env.Router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := do(userToken, "POST", "/links", `{"slug":"wiki","url":"https://wiki.example.com","visibility":"public"}`)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("create: status = %d; body: %s", rec.Code, rec.Body.String())
+	}
+	var created api.LinkResponse
+	if err := json.NewDecoder(rec.Body).Decode(&created); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if !created.PendingReview {
+		t.Fatal("new public link should be pending review")
+	}
+
+	if rec := do(userToken, "GET", "/admin/moderation", ""); rec.Code != http.StatusForbidden {
+		t.Errorf("non-admin list: status = %d, want 403", rec.Code)
+	}
+	rec = do(adminToken, "GET", "/admin/moderation", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("list: status = %d", rec.Code)
+	}
+	var list api.LinkListResponse
+	if err := json.NewDecoder(rec.Body).Decode(&list); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(list.Links) != 1 || list.Links[0].Slug != "wiki" {
+		t.Fatalf("queue = %+v, want wiki", list.Links)
+	}
+
+	if rec := do(adminToken, "POST", "/admin/moderation/"+created.ID+"/approve", ""); rec.Code != http.StatusNoContent {
+		t.Fatalf("approve: status = %d, want 204", rec.Code)
+	}
+	if rec := do(adminToken, "POST", "/admin/moderation/"+created.ID+"/reject", ""); rec.Code != http.StatusNotFound {
+		t.Errorf("reject approved link: status = %d, want 404", rec.Code)
+	}
+}
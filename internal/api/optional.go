@@ -0,0 +1,37 @@
+// Governing: SPEC-0005 REQ "Link Resource"
+package api
+
+import "encoding/json"
+
+// Optional is a request field that tells an absent value apart from an
+// explicit null, for PATCH bodies where absent means "unchanged" and null
+// means "clear".
+type Optional[T any] struct {
+	Set   bool // the field was present, possibly as null
+	Null  bool // the field was null
+	Value T
+}
+
+// UnmarshalJSON records that the field was present. encoding/json calls it
+// for null too, since Optional is not a pointer.
+func (o *Optional[T]) UnmarshalJSON(b []byte) error {
+	o.Set = true
+	if string(b) == "null" {
+		o.Null = true
+		return nil
+	}
+	return json.Unmarshal(b, &o.Value)
+}
+
+// apply returns current when o is absent, cleared when it is null, and its
+// value otherwise.
+func (o Optional[T]) apply(current, cleared T) T {
+	switch {
+	case !o.Set:
+		return current
+	case o.Null:
+		return cleared
+	default:
+		return o.Value
+	}
+}
@@ -0,0 +1,96 @@
+// Governing: SPEC-0005 REQ "Link Preview Endpoint", SPEC-0010 REQ "Secure Link Resolution"
+package api
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/joestump/joe-links/internal/auth"
+	"github.com/joestump/joe-links/internal/store"
+)
+
+// previewAPIHandler serves hover-card previews of short links.
+type previewAPIHandler struct {
+	links *store.LinkStore
+	owns  *store.OwnershipStore
+}
+
+// Preview returns the title, description, and destination domain of the link
+// a slug (or alias) names, for hover cards in the browser extension and
+// unfurlers.
+// GET /api/v1/links/by-slug/{slug}/preview
+// Governing: SPEC-0005 REQ "Link Preview Endpoint"
+//
+// @Summary      Preview a short link
+// @Description  Returns the title, description, and destination domain of the link a slug or alias names. Public and private links are previewable by any caller; secure links only by owners, users they are shared with, and admins, and otherwise return 404 so their existence is not revealed.
+// @Tags         Links
+// @Produce      json
+// @Param        slug  path      string  true  "Link slug or alias"
+// @Success      200   {object}  LinkPreviewResponse
+// @Failure      401   {object}  ErrorResponse
+// @Failure      404   {object}  ErrorResponse
+// @Failure      500   {object}  ErrorResponse
+// @Security     BearerToken
+// @Router       /links/by-slug/{slug}/preview [get]
+func (h *previewAPIHandler) Preview(w http.ResponseWriter, r *http.Request) {
+	user := auth.UserFromContext(r.Context())
+	if user == nil {
+		writeError(w, http.StatusUnauthorized, "unauthorized", "UNAUTHORIZED")
+		return
+	}
+
+	slug := chi.URLParam(r, "slug")
+	link, matched, err := h.links.GetByPathPrefix(r.Context(), slug)
+	if err == nil && matched != slug {
+		err = store.ErrNotFound
+	}
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			writeError(w, http.StatusNotFound, "not found", "NOT_FOUND")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
+		return
+	}
+
+	// Governing: SPEC-0010 REQ "Secure Link Resolution" — a secure link is
+	// only previewable by those who could follow it; others see 404.
+	if link.Visibility == "secure" {
+		allowed, err := store.IsOwnerOrAdmin(h.owns, link.ID, user.ID, user.Role)
+		if err == nil && !allowed {
+			allowed, err = h.links.HasShare(r.Context(), link.ID, user.ID)
+		}
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
+			return
+		}
+		if !allowed {
+			writeError(w, http.StatusNotFound, "not found", "NOT_FOUND")
+			return
+		}
+	}
+
+	resp := LinkPreviewResponse{
+		Slug:        link.Slug,
+		Title:       link.Title,
+		Description: link.Description,
+		Domain:      destinationDomain(link.URL),
+		Templated:   store.VarPlaceholderRe.MatchString(link.URL),
+	}
+	// Hover cards fire repeatedly; let the caller cache briefly, but never
+	// in shared caches since secure links vary by user.
+	w.Header().Set("Cache-Control", "private, max-age=300")
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// destinationDomain returns the host name of target, or "" when target is not
+// an absolute URL (e.g. its host is itself a template variable).
+func destinationDomain(target string) string {
+	u, err := url.Parse(target)
+	if err != nil {
+		return ""
+	}
+	return u.Hostname()
+}
@@ -0,0 +1,71 @@
+// Governing: SPEC-0005 REQ "Link Preview Endpoint"
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/joestump/joe-links/internal/api"
+)
+
+func TestPreview(t *testing.T) {
+	env := newTestEnv(t)
+	owner := seedUser(t, env, "owner@example.com", "user")
+	other := seedUser(t, env, "other@example.com", "user")
+	friend := seedUser(t, env, "friend@example.com", "user")
+	ctx := context.Background()
+
+	wiki, err := env.LinkStore.Create(ctx, "wiki", "https://wiki.example.com/home", owner.ID, "Team Wiki", "Everything we know", "public")
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if _, err := env.LinkStore.AddAlias(ctx, wiki.ID, "kb"); err != nil {
+		t.Fatalf("add alias: %v", err)
+	}
+	secret, err := env.LinkStore.Create(ctx, "payroll", "https://pay.example.com", owner.ID, "Payroll", "", "secure")
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if err := env.LinkStore.AddShare(ctx, secret.ID, friend.ID, owner.ID); err != nil {
+		t.Fatalf("share: %v", err)
+	}
+
+	preview := func(userID, slug string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", "/links/by-slug/"+slug+"/preview", nil)
+		authRequest(req, seedToken(t, env, userID))
+		rec := httptest.NewRecorder()
+		env.Router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := preview(other.ID, "kb")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("alias preview: status = %d; body: %s", rec.Code, rec.Body.String())
+	}
+	var resp api.LinkPreviewResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if resp.Slug != "wiki" || resp.Title != "Team Wiki" || resp.Domain != "wiki.example.com" || resp.Templated {
+		t.Errorf("preview = %+v", resp)
+	}
+
+	for _, tc := range []struct {
+		name   string
+		userID string
+		slug   string
+		want   int
+	}{
+		{"secure, not shared", other.ID, "payroll", http.StatusNotFound},
+		{"secure, shared", friend.ID, "payroll", http.StatusOK},
+		{"secure, owner", owner.ID, "payroll", http.StatusOK},
+		{"unknown slug", owner.ID, "nope", http.StatusNotFound},
+	} {
+		if rec := preview(tc.userID, tc.slug); rec.Code != tc.want {
+			t.Errorf("%s: status = %d, want %d", tc.name, rec.Code, tc.want)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+// Governing: SPEC-0016 REQ "Referrer Exclusion"
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/joestump/joe-links/internal/store"
+)
+
+// ListExcludedReferrers returns the referrer domains excluded from click stats.
+// GET /api/v1/admin/referrers
+//
+// @Summary      List excluded referrers (admin)
+// @Description  Returns the referrer domains whose clicks are not recorded or counted in stats. Requires admin role.
+// @Tags         Admin
+// @Produce      json
+// @Success      200  {array}   ExcludedReferrerResponse
+// @Failure      401  {object}  ErrorResponse
+// @Failure      403  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Security     BearerToken
+// @Router       /admin/referrers [get]
+func (h *adminAPIHandler) ListExcludedReferrers(w http.ResponseWriter, r *http.Request) {
+	list, err := h.clicks.ListExcludedReferrers(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
+		return
+	}
+	resp := make([]ExcludedReferrerResponse, 0, len(list))
+	for _, er := range list {
+		resp = append(resp, excludedReferrerResponse(er))
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// ExcludeReferrer stops counting clicks referred by a domain.
+// POST /api/v1/admin/referrers
+//
+// @Summary      Exclude a referrer (admin)
+// @Description  Clicks referred by the domain or its subdomains are no longer recorded, and clicks recorded earlier are hidden from stats. Requires admin role.
+// @Tags         Admin
+// @Accept       json
+// @Produce      json
+// @Param        body  body      ExcludeReferrerRequest  true  "Domain to exclude"
+// @Success      201   {object}  ExcludedReferrerResponse
+// @Failure      400   {object}  ErrorResponse
+// @Failure      401   {object}  ErrorResponse
+// @Failure      403   {object}  ErrorResponse
+// @Failure      409   {object}  ErrorResponse
+// @Failure      500   {object}  ErrorResponse
+// @Security     BearerToken
+// @Router       /admin/referrers [post]
+func (h *adminAPIHandler) ExcludeReferrer(w http.ResponseWriter, r *http.Request) {
+	var req ExcludeReferrerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body", "BAD_REQUEST")
+		return
+	}
+
+	er, err := h.clicks.ExcludeReferrer(r.Context(), req.Domain, strings.TrimSpace(req.Reason))
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrInvalidDomain):
+			writeError(w, http.StatusBadRequest, "domain must be a host name or a URL", "INVALID_DOMAIN")
+		case errors.Is(err, store.ErrReferrerExcluded):
+			writeError(w, http.StatusConflict, "domain is already excluded", "DOMAIN_CONFLICT")
+		default:
+			writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
+		}
+		return
+	}
+	writeJSON(w, http.StatusCreated, excludedReferrerResponse(er))
+}
+
+// RemoveExcludedReferrer counts a referrer domain's clicks again.
+// DELETE /api/v1/admin/referrers/{domain}
+//
+// @Summary      Remove a referrer exclusion (admin)
+// @Description  Counts the domain's clicks again, including those recorded before it was excluded. Clicks dropped while it was excluded are not recovered. Requires admin role.
+// @Tags         Admin
+// @Produce      json
+// @Param        domain  path  string  true  "Excluded domain"
+// @Success      204     "No Content"
+// @Failure      401     {object}  ErrorResponse
+// @Failure      403     {object}  ErrorResponse
+// @Failure      404     {object}  ErrorResponse
+// @Failure      500     {object}  ErrorResponse
+// @Security     BearerToken
+// @Router       /admin/referrers/{domain} [delete]
+func (h *adminAPIHandler) RemoveExcludedReferrer(w http.ResponseWriter, r *http.Request) {
+	if err := h.clicks.RemoveExcludedReferrer(r.Context(), chi.URLParam(r, "domain")); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			writeError(w, http.StatusNotFound, "excluded referrer not found", "NOT_FOUND")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func excludedReferrerResponse(er *store.ExcludedReferrer) ExcludedReferrerResponse {
+	return ExcludedReferrerResponse{Domain: er.Domain, Reason: er.Reason, CreatedAt: er.CreatedAt}
+}
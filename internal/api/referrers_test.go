@@ -0,0 +1,92 @@
+// Governing: SPEC-0016 REQ "Referrer Exclusion"
+package api_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/joestump/joe-links/internal/api"
+	"github.com/joestump/joe-links/internal/store"
+)
+
+func TestAdminReferrers(t *testing.T) {
+	env := newTestEnv(t)
+	admin := seedUser(t, env, "admin@example.com", "admin")
+	user := seedUser(t, env, "user@example.com", "user")
+	adminToken := seedToken(t, env, admin.ID)
+	userToken := seedToken(t, env, user.ID)
+	ctx := context.Background()
+
+	do := func(token, method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		authRequest(req, token)
+		rec := httptest.NewRecorder()
+		env.Router.ServeHTTP(rec, req)
+		return rec
+	}
+	total := func(path string) int64 {
+		t.Helper()
+		rec := do(userToken, "GET", path, "")
+		if rec.Code != http.StatusOK {
+			t.Fatalf("GET %s: status = %d; body: %s", path, rec.Code, rec.Body.String())
+		}
+		var resp struct {
+			Total int64 `json:"total"`
+		}
+		if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		return resp.Total
+	}
+
+	link, err := env.LinkStore.Create(ctx, "uptime", "https://example.com", user.ID, "", "", "")
+	if err != nil {
+		t.Fatalf("create link: %v", err)
+	}
+	for _, ref := range []string{"https://ping.uptime.example.com/", "https://wiki.example.com/"} {
+		if err := env.ClickStore.RecordClick(ctx, store.ClickEvent{LinkID: link.ID, IPHash: "h", Referrer: ref}); err != nil {
+			t.Fatalf("record click: %v", err)
+		}
+	}
+
+	if rec := do(userToken, "POST", "/admin/referrers", `{"domain":"uptime.example.com"}`); rec.Code != http.StatusForbidden {
+		t.Errorf("non-admin exclude: status = %d, want 403", rec.Code)
+	}
+	if rec := do(adminToken, "POST", "/admin/referrers", `{"domain":"not a domain"}`); rec.Code != http.StatusBadRequest {
+		t.Errorf("invalid domain: status = %d, want 400", rec.Code)
+	}
+	rec := do(adminToken, "POST", "/admin/referrers", `{"domain":"https://uptime.example.com/ping","reason":"monitoring"}`)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("exclude: status = %d; body: %s", rec.Code, rec.Body.String())
+	}
+	var excluded api.ExcludedReferrerResponse
+	if err := json.NewDecoder(rec.Body).Decode(&excluded); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if excluded.Domain != "uptime.example.com" {
+		t.Errorf("domain = %q, want host parsed from the URL", excluded.Domain)
+	}
+	if rec := do(adminToken, "POST", "/admin/referrers", `{"domain":"uptime.example.com"}`); rec.Code != http.StatusConflict {
+		t.Errorf("duplicate: status = %d, want 409", rec.Code)
+	}
+
+	// Stats hide the earlier click unless asked to include excluded referrers.
+	if got := total("/links/" + link.ID + "/stats"); got != 1 {
+		t.Errorf("filtered total = %d, want 1", got)
+	}
+	if got := total("/links/" + link.ID + "/stats?referrers=all"); got != 2 {
+		t.Errorf("unfiltered total = %d, want 2", got)
+	}
+
+	if rec := do(adminToken, "DELETE", "/admin/referrers/uptime.example.com", ""); rec.Code != http.StatusNoContent {
+		t.Fatalf("remove: status = %d", rec.Code)
+	}
+	if rec := do(adminToken, "DELETE", "/admin/referrers/uptime.example.com", ""); rec.Code != http.StatusNotFound {
+		t.Errorf("remove again: status = %d, want 404", rec.Code)
+	}
+}
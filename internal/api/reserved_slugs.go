@@ -0,0 +1,119 @@
+// Governing: SPEC-0005 REQ "Reserved Slugs API", SPEC-0002 REQ "Reserved Slugs"
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/joestump/joe-links/internal/store"
+)
+
+// ListReservedSlugs returns the built-in and admin-managed reserved slugs.
+// GET /api/v1/admin/reserved-slugs
+// Governing: SPEC-0005 REQ "Reserved Slugs API"
+//
+// @Summary      List reserved slugs (admin)
+// @Description  Returns the slugs no link or alias may use: built-in route prefixes first, then admin-managed entries. Requires admin role.
+// @Tags         Admin
+// @Produce      json
+// @Success      200  {array}   ReservedSlugResponse
+// @Failure      401  {object}  ErrorResponse
+// @Failure      403  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Security     BearerToken
+// @Router       /admin/reserved-slugs [get]
+func (h *adminAPIHandler) ListReservedSlugs(w http.ResponseWriter, r *http.Request) {
+	list, err := h.reserved.List(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
+		return
+	}
+	resp := make([]ReservedSlugResponse, 0, len(list))
+	for _, rs := range list {
+		resp = append(resp, reservedSlugResponse(rs))
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// AddReservedSlug reserves a slug so that no new link or alias may use it.
+// POST /api/v1/admin/reserved-slugs
+// Governing: SPEC-0005 REQ "Reserved Slugs API"
+//
+// @Summary      Reserve a slug (admin)
+// @Description  Blocks a slug for new links and aliases. Links already using it keep working. Requires admin role.
+// @Tags         Admin
+// @Accept       json
+// @Produce      json
+// @Param        body  body      AddReservedSlugRequest  true  "Slug to reserve"
+// @Success      201   {object}  ReservedSlugResponse
+// @Failure      400   {object}  ErrorResponse
+// @Failure      401   {object}  ErrorResponse
+// @Failure      403   {object}  ErrorResponse
+// @Failure      409   {object}  ErrorResponse
+// @Failure      500   {object}  ErrorResponse
+// @Security     BearerToken
+// @Router       /admin/reserved-slugs [post]
+func (h *adminAPIHandler) AddReservedSlug(w http.ResponseWriter, r *http.Request) {
+	var req AddReservedSlugRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body", "BAD_REQUEST")
+		return
+	}
+	if req.Slug == "" {
+		writeError(w, http.StatusBadRequest, "slug is required", "BAD_REQUEST")
+		return
+	}
+
+	rs, err := h.reserved.Add(r.Context(), req.Slug, strings.TrimSpace(req.Reason))
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrSlugInvalid):
+			writeError(w, http.StatusBadRequest, "slug must match [a-z0-9][a-z0-9-]*[a-z0-9]", "INVALID_SLUG")
+		case errors.Is(err, store.ErrSlugTaken):
+			writeError(w, http.StatusConflict, "slug is already reserved", "SLUG_CONFLICT")
+		default:
+			writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
+		}
+		return
+	}
+	writeJSON(w, http.StatusCreated, reservedSlugResponse(rs))
+}
+
+// RemoveReservedSlug releases an admin-managed reserved slug.
+// DELETE /api/v1/admin/reserved-slugs/{slug}
+// Governing: SPEC-0005 REQ "Reserved Slugs API"
+//
+// @Summary      Release a reserved slug (admin)
+// @Description  Removes an admin-managed reserved slug. Built-in slugs cannot be removed and return 404. Requires admin role.
+// @Tags         Admin
+// @Produce      json
+// @Param        slug  path  string  true  "Reserved slug"
+// @Success      204   "No Content"
+// @Failure      401   {object}  ErrorResponse
+// @Failure      403   {object}  ErrorResponse
+// @Failure      404   {object}  ErrorResponse
+// @Failure      500   {object}  ErrorResponse
+// @Security     BearerToken
+// @Router       /admin/reserved-slugs/{slug} [delete]
+func (h *adminAPIHandler) RemoveReservedSlug(w http.ResponseWriter, r *http.Request) {
+	if err := h.reserved.Remove(r.Context(), chi.URLParam(r, "slug")); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			writeError(w, http.StatusNotFound, "reserved slug not found", "NOT_FOUND")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func reservedSlugResponse(rs *store.ReservedSlug) ReservedSlugResponse {
+	resp := ReservedSlugResponse{Slug: rs.Slug, Reason: rs.Reason, BuiltIn: rs.BuiltIn}
+	if !rs.BuiltIn {
+		resp.CreatedAt = &rs.CreatedAt
+	}
+	return resp
+}
@@ -0,0 +1,85 @@
+// Governing: SPEC-0005 REQ "Reserved Slugs API"
+package api_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/joestump/joe-links/internal/api"
+)
+
+func TestReservedSlugs_AdminCRUD(t *testing.T) {
+	env := newTestEnv(t)
+	admin := seedUser(t, env, "admin@example.com", "admin")
+	token := seedToken(t, env, admin.ID)
+
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		authRequest(req, token)
+		rec := httptest.NewRecorder()
+		env.Router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := do("POST", "/admin/reserved-slugs", `{"slug":"legal","reason":"Legal team"}`)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("add: status = %d; body: %s", rec.Code, rec.Body.String())
+	}
+	if rec := do("POST", "/admin/reserved-slugs", `{"slug":"legal"}`); rec.Code != http.StatusConflict {
+		t.Errorf("duplicate: status = %d, want %d", rec.Code, http.StatusConflict)
+	}
+	if rec := do("POST", "/admin/reserved-slugs", `{"slug":"Not Valid"}`); rec.Code != http.StatusBadRequest {
+		t.Errorf("invalid: status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+
+	// New links may not use the reserved slug.
+	rec = do("POST", "/links", `{"slug":"legal","url":"https://example.com"}`)
+	if rec.Code != http.StatusBadRequest || !strings.Contains(rec.Body.String(), "INVALID_SLUG") {
+		t.Errorf("create reserved link: status = %d; body: %s", rec.Code, rec.Body.String())
+	}
+
+	rec = do("GET", "/admin/reserved-slugs", "")
+	var list []api.ReservedSlugResponse
+	if err := json.NewDecoder(rec.Body).Decode(&list); err != nil {
+		t.Fatalf("decode list: %v", err)
+	}
+	var found, builtIn bool
+	for _, rs := range list {
+		if rs.Slug == "legal" && !rs.BuiltIn && rs.Reason == "Legal team" && rs.CreatedAt != nil {
+			found = true
+		}
+		if rs.Slug == "admin" && rs.BuiltIn {
+			builtIn = true
+		}
+	}
+	if !found || !builtIn {
+		t.Errorf("list = %+v", list)
+	}
+
+	if rec := do("DELETE", "/admin/reserved-slugs/admin", ""); rec.Code != http.StatusNotFound {
+		t.Errorf("remove built-in: status = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+	if rec := do("DELETE", "/admin/reserved-slugs/legal", ""); rec.Code != http.StatusNoContent {
+		t.Errorf("remove: status = %d, want %d", rec.Code, http.StatusNoContent)
+	}
+	if rec := do("POST", "/links", `{"slug":"legal","url":"https://example.com"}`); rec.Code != http.StatusCreated {
+		t.Errorf("create after release: status = %d; body: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestReservedSlugs_NonAdmin_Forbidden(t *testing.T) {
+	env := newTestEnv(t)
+	user := seedUser(t, env, "alice@example.com", "user")
+	token := seedToken(t, env, user.ID)
+
+	req := httptest.NewRequest("POST", "/admin/reserved-slugs", strings.NewReader(`{"slug":"legal"}`))
+	authRequest(req, token)
+	rec := httptest.NewRecorder()
+	env.Router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusForbidden)
+	}
+}
@@ -0,0 +1,183 @@
+// Governing: SPEC-0005 REQ "Resolve Test Endpoint", REQ "Resolve Endpoint", ADR-0008
+package api
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/joestump/joe-links/internal/auth"
+	"github.com/joestump/joe-links/internal/store"
+)
+
+// Resolve test outcomes reported in ResolveTestResponse.Outcome.
+const (
+	ResolveOutcomeRedirect      = "redirect"       // 302 to the link target
+	ResolveOutcomeKeyword       = "keyword"        // 302 via a keyword template
+	ResolveOutcomeHelp          = "help"           // templated link help page
+	ResolveOutcomeLoginRequired = "login_required" // 302 to login (secure link, anonymous)
+	ResolveOutcomeForbidden     = "forbidden"      // 403 (secure link, no grant)
+	ResolveOutcomeNotFound      = "not_found"      // 404
+)
+
+// ResolveTester reports how the short-link resolver handles a path for a
+// user (nil = anonymous) without redirecting or recording a click. The web
+// resolver implements it, so the answer always follows the live rules.
+type ResolveTester interface {
+	TestResolve(ctx context.Context, path, host string, user *store.User) *ResolveTestResponse
+}
+
+// resolveAPIHandler provides the resolve and resolve test endpoints.
+type resolveAPIHandler struct {
+	tester ResolveTester
+	users  *store.UserStore
+}
+
+// registerResolveRoutes registers resolve routes on r.
+// Governing: SPEC-0005 REQ "Resolve Test Endpoint"
+func registerResolveRoutes(r chi.Router, tester ResolveTester, us *store.UserStore) {
+	h := &resolveAPIHandler{tester: tester, users: us}
+	r.Get("/resolve", h.Resolve) // Governing: SPEC-0005 REQ "Resolve Endpoint"
+	r.Post("/resolve/test", h.Test)
+}
+
+// Resolve returns where a short-link path sends the caller, for bots and
+// tools that want the target without following a redirect.
+// GET /api/v1/resolve?path=github/joestump
+// Governing: SPEC-0005 REQ "Resolve Endpoint"
+//
+// @Summary      Resolve a path
+// @Description  Resolves a short-link path (optionally with a query string) for the caller exactly as the redirect would, returning the target URL, the matched link or keyword, and the values bound to path variables, without recording a click.
+// @Tags         Resolve
+// @Produce      json
+// @Param        path  query     string  true   "Short-link path, e.g. github/joestump"
+// @Param        host  query     string  false  "Request host, for keyword host routing"
+// @Success      200   {object}  ResolveResponse
+// @Failure      400   {object}  ErrorResponse
+// @Failure      401   {object}  ErrorResponse
+// @Failure      403   {object}  ErrorResponse  "secure link the caller cannot follow"
+// @Failure      404   {object}  ErrorResponse
+// @Security     BearerToken
+// @Router       /resolve [get]
+func (h *resolveAPIHandler) Resolve(w http.ResponseWriter, r *http.Request) {
+	caller := auth.UserFromContext(r.Context())
+	if caller == nil {
+		writeError(w, http.StatusUnauthorized, "unauthorized", "UNAUTHORIZED")
+		return
+	}
+	path := strings.TrimPrefix(strings.TrimSpace(r.URL.Query().Get("path")), "/")
+	if path == "" {
+		writeError(w, http.StatusBadRequest, "path is required", "BAD_REQUEST")
+		return
+	}
+
+	res := h.tester.TestResolve(r.Context(), path, r.URL.Query().Get("host"), caller)
+	switch res.Outcome {
+	case ResolveOutcomeNotFound:
+		writeError(w, http.StatusNotFound, "no link or keyword matches path", "NOT_FOUND")
+		return
+	case ResolveOutcomeForbidden, ResolveOutcomeLoginRequired:
+		writeError(w, http.StatusForbidden, "you do not have access to this link", "FORBIDDEN")
+		return
+	}
+	resp := ResolveResponse{
+		Path:      res.Path,
+		Outcome:   res.Outcome,
+		Target:    res.Target,
+		Keyword:   res.Keyword,
+		Variables: res.Variables,
+	}
+	if res.Link != nil {
+		resp.LinkID, resp.Slug = res.Link.ID, res.Link.Slug
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// Test explains how a short-link path resolves for the caller, or for another
+// user when an admin sets as_user.
+// POST /api/v1/resolve/test
+// Governing: SPEC-0005 REQ "Resolve Test Endpoint"
+//
+// @Summary      Test-resolve a path
+// @Description  Reports the link a path matches, the variable values it binds, the visibility decision, and the resulting redirect, without following it or recording a click. Admins can resolve as another user (as_user: ID or email) or anonymously.
+// @Tags         Resolve
+// @Accept       json
+// @Produce      json
+// @Param        body  body      ResolveTestRequest   true  "Path to resolve"
+// @Success      200   {object}  ResolveTestResponse
+// @Failure      400   {object}  ErrorResponse
+// @Failure      401   {object}  ErrorResponse
+// @Failure      403   {object}  ErrorResponse  "as_user requires admin"
+// @Failure      404   {object}  ErrorResponse  "as_user not found"
+// @Security     BearerToken
+// @Router       /resolve/test [post]
+func (h *resolveAPIHandler) Test(w http.ResponseWriter, r *http.Request) {
+	caller := auth.UserFromContext(r.Context())
+	if caller == nil {
+		writeError(w, http.StatusUnauthorized, "unauthorized", "UNAUTHORIZED")
+		return
+	}
+
+	var req ResolveTestRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body", "BAD_REQUEST")
+		return
+	}
+	req.Path = strings.TrimPrefix(strings.TrimSpace(req.Path), "/")
+	if req.Path == "" {
+		writeError(w, http.StatusBadRequest, "path is required", "BAD_REQUEST")
+		return
+	}
+
+	user := caller
+	switch {
+	case req.AsUser != "" && req.Anonymous:
+		writeError(w, http.StatusBadRequest, "as_user and anonymous are mutually exclusive", "BAD_REQUEST")
+		return
+	case req.AsUser != "":
+		if !caller.IsAdmin() {
+			writeError(w, http.StatusForbidden, "as_user requires admin", "FORBIDDEN")
+			return
+		}
+		u, err := h.lookupUser(r.Context(), req.AsUser)
+		if errors.Is(err, store.ErrNotFound) {
+			writeError(w, http.StatusNotFound, "user not found", "NOT_FOUND")
+			return
+		}
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
+			return
+		}
+		user = u
+	case req.Anonymous:
+		user = nil
+	}
+
+	resp := h.tester.TestResolve(r.Context(), req.Path, req.Host, user)
+	if user != nil {
+		resp.AsUser = &UserResponse{
+			ID:          user.ID,
+			Email:       user.Email,
+			DisplayName: user.DisplayName,
+			Role:        user.Role,
+			CreatedAt:   user.CreatedAt,
+		}
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// lookupUser finds a user by ID, falling back to email.
+func (h *resolveAPIHandler) lookupUser(ctx context.Context, idOrEmail string) (*store.User, error) {
+	if strings.Contains(idOrEmail, "@") {
+		return h.users.GetByEmail(ctx, idOrEmail)
+	}
+	u, err := h.users.GetByID(ctx, idOrEmail)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, store.ErrNotFound
+	}
+	return u, err
+}
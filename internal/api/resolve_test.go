@@ -0,0 +1,163 @@
+// Governing: SPEC-0005 REQ "Resolve Test Endpoint"
+package api_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/joestump/joe-links/internal/api"
+)
+
+func postResolveTest(t *testing.T, env *testEnv, token, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := authRequest(httptest.NewRequest(http.MethodPost, "/resolve/test", strings.NewReader(body)), token)
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	env.Router.ServeHTTP(w, req)
+	return w
+}
+
+func TestResolveTest_AsCaller(t *testing.T) {
+	env := newTestEnv(t)
+	user := seedUser(t, env, "caller@example.com", "user")
+	token := seedToken(t, env, user.ID)
+
+	w := postResolveTest(t, env, token, `{"path":"/jira/PROJ-1"}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", w.Code, w.Body.String())
+	}
+	if env.ResolveTester.path != "jira/PROJ-1" {
+		t.Errorf("path = %q, want leading slash trimmed", env.ResolveTester.path)
+	}
+	if env.ResolveTester.user == nil || env.ResolveTester.user.ID != user.ID {
+		t.Errorf("expected to resolve as the caller")
+	}
+	var resp api.ResolveTestResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if resp.AsUser == nil || resp.AsUser.Email != "caller@example.com" {
+		t.Errorf("as_user = %+v", resp.AsUser)
+	}
+}
+
+func TestResolveTest_AsUserRequiresAdmin(t *testing.T) {
+	env := newTestEnv(t)
+	user := seedUser(t, env, "user@example.com", "user")
+	seedUser(t, env, "other@example.com", "user")
+	token := seedToken(t, env, user.ID)
+
+	w := postResolveTest(t, env, token, `{"path":"x","as_user":"other@example.com"}`)
+	if w.Code != http.StatusForbidden {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusForbidden)
+	}
+}
+
+func TestResolveTest_AdminAsUser(t *testing.T) {
+	env := newTestEnv(t)
+	admin := seedUser(t, env, "admin@example.com", "admin")
+	other := seedUser(t, env, "other@example.com", "user")
+	token := seedToken(t, env, admin.ID)
+
+	for _, asUser := range []string{other.Email, other.ID} {
+		w := postResolveTest(t, env, token, `{"path":"x","as_user":"`+asUser+`"}`)
+		if w.Code != http.StatusOK {
+			t.Fatalf("as_user %s: status = %d", asUser, w.Code)
+		}
+		if env.ResolveTester.user == nil || env.ResolveTester.user.ID != other.ID {
+			t.Errorf("as_user %s: expected to resolve as other user", asUser)
+		}
+	}
+
+	w := postResolveTest(t, env, token, `{"path":"x","as_user":"nobody@example.com"}`)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("unknown as_user: status = %d, want %d", w.Code, http.StatusNotFound)
+	}
+}
+
+func TestResolveTest_Anonymous(t *testing.T) {
+	env := newTestEnv(t)
+	user := seedUser(t, env, "anon@example.com", "user")
+	token := seedToken(t, env, user.ID)
+
+	w := postResolveTest(t, env, token, `{"path":"x","anonymous":true}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d", w.Code)
+	}
+	if env.ResolveTester.user != nil {
+		t.Errorf("expected anonymous resolution")
+	}
+	if !strings.Contains(w.Body.String(), `"as_user":null`) {
+		t.Errorf("expected as_user null, got %s", w.Body.String())
+	}
+}
+
+func TestResolveTest_PathRequired(t *testing.T) {
+	env := newTestEnv(t)
+	user := seedUser(t, env, "empty@example.com", "user")
+	token := seedToken(t, env, user.ID)
+
+	w := postResolveTest(t, env, token, `{"path":"  "}`)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+}
+
+// Governing: SPEC-0005 REQ "Resolve Endpoint"
+func TestResolve_ReturnsTarget(t *testing.T) {
+	env := newTestEnv(t)
+	user := seedUser(t, env, "caller@example.com", "user")
+	token := seedToken(t, env, user.ID)
+	env.ResolveTester.resp = &api.ResolveTestResponse{
+		Path:      "github/joestump",
+		Outcome:   api.ResolveOutcomeRedirect,
+		Status:    http.StatusFound,
+		Target:    "https://github.com/joestump",
+		Link:      &api.ResolvedLinkResponse{ID: "link-1", Slug: "github", Visibility: "public"},
+		Variables: []api.ResolveVariable{{Name: "user", Placeholder: "$user", Value: "joestump"}},
+		Trace:     []string{},
+	}
+
+	w := httptest.NewRecorder()
+	env.Router.ServeHTTP(w, authRequest(httptest.NewRequest(http.MethodGet, "/resolve?path=%2Fgithub%2Fjoestump", nil), token))
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", w.Code, w.Body.String())
+	}
+	if env.ResolveTester.path != "github/joestump" || env.ResolveTester.user == nil || env.ResolveTester.user.ID != user.ID {
+		t.Errorf("resolved %q as %v, want github/joestump as the caller", env.ResolveTester.path, env.ResolveTester.user)
+	}
+	var resp api.ResolveResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if resp.Target != "https://github.com/joestump" || resp.LinkID != "link-1" || len(resp.Variables) != 1 {
+		t.Errorf("resp = %+v", resp)
+	}
+}
+
+// Governing: SPEC-0005 REQ "Resolve Endpoint"
+func TestResolve_Errors(t *testing.T) {
+	env := newTestEnv(t)
+	user := seedUser(t, env, "caller@example.com", "user")
+	token := seedToken(t, env, user.ID)
+
+	for _, tt := range []struct {
+		url     string
+		outcome string
+		want    int
+	}{
+		{"/resolve", "", http.StatusBadRequest},
+		{"/resolve?path=missing", api.ResolveOutcomeNotFound, http.StatusNotFound},
+		{"/resolve?path=vault", api.ResolveOutcomeForbidden, http.StatusForbidden},
+	} {
+		env.ResolveTester.resp = &api.ResolveTestResponse{Outcome: tt.outcome, Trace: []string{}}
+		w := httptest.NewRecorder()
+		env.Router.ServeHTTP(w, authRequest(httptest.NewRequest(http.MethodGet, tt.url, nil), token))
+		if w.Code != tt.want {
+			t.Errorf("GET %s: status = %d, want %d", tt.url, w.Code, tt.want)
+		}
+	}
+}
@@ -6,21 +6,43 @@ import (
 
 	"github.com/go-chi/chi/v5"
 	"github.com/joestump/joe-links/internal/auth"
+	"github.com/joestump/joe-links/internal/i18n"
 	"github.com/joestump/joe-links/internal/llm"
+	"github.com/joestump/joe-links/internal/mailer"
+	"github.com/joestump/joe-links/internal/netpolicy"
+	"github.com/joestump/joe-links/internal/shareurl"
+	"github.com/joestump/joe-links/internal/status"
 	"github.com/joestump/joe-links/internal/store"
 )
 
 // Deps holds dependencies for the API router.
 type Deps struct {
-	BearerMiddleware *auth.BearerTokenMiddleware
-	TokenStore       auth.TokenStore
-	LinkStore        *store.LinkStore
-	OwnershipStore   *store.OwnershipStore
-	TagStore         *store.TagStore
-	UserStore        *store.UserStore
-	KeywordStore     *store.KeywordStore
-	ClickStore       *store.ClickStore
-	Suggester        llm.Suggester // nil when LLM is not configured
+	BearerMiddleware  *auth.BearerTokenMiddleware
+	TokenStore        auth.TokenStore
+	LinkStore         *store.LinkStore
+	OwnershipStore    *store.OwnershipStore
+	TagStore          *store.TagStore
+	UserStore         *store.UserStore
+	KeywordStore      *store.KeywordStore
+	ClickStore        *store.ClickStore
+	HealthStore       *store.HealthStore       // nil disables GET /links/{id}/health
+	ReservedSlugStore *store.ReservedSlugStore // nil disables /admin/reserved-slugs
+	TeamStore         *store.TeamStore         // nil disables /admin/teams and link team assignment
+	SavedSearchStore  *store.SavedSearchStore  // nil disables /searches
+	PolicyStore       *store.PolicyStore       // nil disables /admin/policies and link policy checks
+	DomainRuleStore   *store.DomainRuleStore   // nil disables /admin/domain-rules (rules still apply)
+	UsageStore        *store.UsageStore
+	UsageRecorder     *UsageRecorder          // nil disables per-token usage recording
+	Suggester         llm.Suggester           // nil when LLM is not configured
+	ResolveTester     ResolveTester           // nil disables GET /resolve and POST /resolve/test
+	StatusChecker     *status.Checker         // nil disables GET /status
+	Notifier          *mailer.Notifier        // nil disables co-owner and share emails
+	ShareURLs         *shareurl.Signer        // nil disables POST /links/{id}/share-url
+	DemoMode          bool                    // Governing: SPEC-0001 REQ "Demo Mode"; rejects destructive admin actions
+	TenantStore       *store.TenantStore      // Governing: SPEC-0001 REQ "Multi-Tenancy"; nil disables /admin/tenants
+	IdempotencyStore  *store.IdempotencyStore // Governing: SPEC-0005 REQ "Idempotent Link Creation"; nil ignores Idempotency-Key
+	AdminNetworks     *netpolicy.Allowlist    // Governing: SPEC-0001 REQ "Admin Network Allowlist"; nil allows every client on /admin
+	WebAuthn          *auth.WebAuthn          // Governing: SPEC-0001 REQ "WebAuthn Step-Up"; nil skips step-up on session requests
 }
 
 // NewAPIRouter creates and returns a chi router for /api/v1.
@@ -32,11 +54,23 @@ func NewAPIRouter(deps Deps) http.Handler {
 	// Enforce JSON content type on all API responses.
 	// Governing: SPEC-0005 REQ "API Router Mounting"
 	r.Use(jsonContentType)
+	// Governing: SPEC-0004 REQ "Internationalization"
+	r.Use(contentLanguage)
+	// Governing: SPEC-0005 REQ "Conditional Requests"
+	r.Use(conditionalGET)
+	if deps.DemoMode {
+		r.Use(demoGuard)
+	}
 
 	// Public routes (no auth required).
 	// Governing: SPEC-0008 REQ "Keyword Host Discovery", ADR-0011
 	r.Group(func(r chi.Router) {
 		registerKeywordRoutes(r, deps.KeywordStore)
+
+		// Governing: SPEC-0016 REQ "Status Page"
+		if deps.StatusChecker != nil {
+			registerStatusRoutes(r, deps.StatusChecker)
+		}
 	})
 
 	// Authenticated routes — bearer token required.
@@ -44,6 +78,22 @@ func NewAPIRouter(deps Deps) http.Handler {
 	r.Group(func(r chi.Router) {
 		r.Use(deps.BearerMiddleware.Authenticate)
 
+		// Per-token, per-endpoint usage counting.
+		// Governing: SPEC-0006 REQ "API Usage Tracking"
+		if deps.UsageRecorder != nil {
+			r.Use(deps.UsageRecorder.Middleware)
+		}
+
+		// Token scopes: safe methods need links:read, everything else links:write.
+		// Admin routes additionally require the admin scope.
+		// Governing: SPEC-0006 REQ "Token Scopes"
+		r.Use(auth.RequireMethodScope(auth.ScopeLinksRead, auth.ScopeLinksWrite))
+
+		// An admin's session can delete other users' data, so deletes
+		// through the Swagger UI need the same passkey confirmation as the
+		// web UI. Governing: SPEC-0001 REQ "WebAuthn Step-Up"
+		r.Use(requireSessionStepUp(deps.WebAuthn, isDelete))
+
 		// Keyword templates (auth required for full template data).
 		registerKeywordTemplateRoutes(r, deps.KeywordStore)
 
@@ -53,11 +103,25 @@ func NewAPIRouter(deps Deps) http.Handler {
 
 		// Tag routes.
 		// Governing: SPEC-0005 REQ "Tags"
-		registerTagRoutes(r, deps.TagStore, deps.LinkStore)
+		registerTagRoutes(r, deps.TagStore, deps.LinkStore, deps.OwnershipStore)
 
 		// User profile routes.
 		// Governing: SPEC-0005 REQ "User Profile"
-		registerUserRoutes(r)
+		registerUserRoutes(r, deps.UserStore, deps.LinkStore)
+		if deps.UsageStore != nil {
+			usageH := &usageAPIHandler{usage: deps.UsageStore}
+			r.Get("/me/usage", usageH.MyUsage)
+		}
+
+		// Personal data export and self-service account deletion.
+		// Governing: SPEC-0005 REQ "Personal Data Export", REQ "Self-Service Account Deletion"
+		registerAccountRoutes(r, deps.UserStore, deps.LinkStore, deps.OwnershipStore, deps.TokenStore, deps.ClickStore)
+
+		// Saved search routes.
+		// Governing: SPEC-0005 REQ "Saved Searches API"
+		if deps.SavedSearchStore != nil {
+			registerSavedSearchRoutes(r, deps.SavedSearchStore)
+		}
 
 		// LLM-powered link metadata suggestions.
 		// Governing: SPEC-0017 REQ "Suggest API Endpoint", ADR-0017
@@ -66,11 +130,47 @@ func NewAPIRouter(deps Deps) http.Handler {
 
 		// Link and co-owner management routes.
 		// Governing: SPEC-0005 REQ "Links Collection", REQ "Link Resource", REQ "Co-Owner Management"
-		registerLinkRoutes(r, deps.LinkStore, deps.OwnershipStore, deps.UserStore)
+		registerLinkRoutes(r, deps.LinkStore, deps.OwnershipStore, deps.UserStore, deps.TeamStore, deps.PolicyStore, deps.Notifier, deps.IdempotencyStore)
+
+		// Policy violations on the caller's links.
+		// Governing: SPEC-0011 REQ "Link Lifecycle Policies"
+		if deps.PolicyStore != nil {
+			violationsH := &policyViolationsAPIHandler{policies: deps.PolicyStore}
+			r.Get("/policy-violations", violationsH.List)
+		}
 
 		// Link share management routes.
 		// Governing: SPEC-0010 REQ "Link Share Management API Endpoints"
-		registerShareRoutes(r, deps.LinkStore, deps.OwnershipStore, deps.UserStore)
+		registerShareRoutes(r, deps.LinkStore, deps.OwnershipStore, deps.UserStore, deps.Notifier)
+		// Governing: SPEC-0010 REQ "Team Shares and Ownership"
+		if deps.TeamStore != nil {
+			registerTeamGrantRoutes(r, deps.LinkStore, deps.OwnershipStore, deps.TeamStore)
+		}
+		// Governing: SPEC-0010 REQ "Signed Share URLs"
+		if deps.ShareURLs != nil {
+			shareURLH := &shareURLAPIHandler{links: deps.LinkStore, ownership: deps.OwnershipStore, signer: deps.ShareURLs}
+			r.Post("/links/{id}/share-url", shareURLH.Create)
+		}
+
+		// Hover-card previews by slug.
+		// Governing: SPEC-0005 REQ "Link Preview Endpoint"
+		previewH := &previewAPIHandler{links: deps.LinkStore, owns: deps.OwnershipStore}
+		r.Get("/links/by-slug/{slug}/preview", previewH.Preview)
+
+		// Slug Bloom filter for the browser extension's offline 404 fallback.
+		// Governing: SPEC-0008 REQ "Offline Slug Lookup"
+		bloomH := &slugBloomAPIHandler{links: deps.LinkStore}
+		r.Get("/links/bloom", bloomH.Get)
+
+		// Link alias management routes.
+		// Governing: SPEC-0005 REQ "Link Aliases API"
+		registerAliasRoutes(r, deps.LinkStore, deps.OwnershipStore)
+
+		// Resolver debugging.
+		// Governing: SPEC-0005 REQ "Resolve Test Endpoint"
+		if deps.ResolveTester != nil {
+			registerResolveRoutes(r, deps.ResolveTester, deps.UserStore)
+		}
 
 		// Link analytics routes (stats + click events).
 		// Governing: SPEC-0016 REQ "REST API Stats Endpoint", REQ "REST API Clicks Endpoint", ADR-0016
@@ -78,14 +178,37 @@ func NewAPIRouter(deps Deps) http.Handler {
 		r.Get("/links/{id}/stats", statsH.GetStats)
 		r.Get("/links/{id}/clicks", statsH.ListClicks)
 
+		// Links with their shares and stats, plus the caller and tags, in one
+		// round trip. Governing: SPEC-0005 REQ "Dashboard Endpoint"
+		dashboardH := &dashboardAPIHandler{links: deps.LinkStore, ownership: deps.OwnershipStore, users: deps.UserStore, tags: deps.TagStore, clicks: deps.ClickStore}
+		r.Get("/dashboard", dashboardH.Get)
+
+		// Link target health.
+		// Governing: SPEC-0001 REQ "Link Health Checks"
+		if deps.HealthStore != nil {
+			healthH := &healthAPIHandler{links: deps.LinkStore, health: deps.HealthStore, owns: deps.OwnershipStore}
+			r.Get("/links/{id}/health", healthH.Get)
+		}
+
 		// Admin-only routes behind role-check middleware group.
 		// Governing: SPEC-0005 REQ "Admin Endpoints", ADR-0008
-		registerAdminRoutes(r, deps.UserStore, deps.LinkStore, deps.OwnershipStore)
+		registerAdminRoutes(r, deps.UserStore, deps.LinkStore, deps.OwnershipStore, deps.ReservedSlugStore, deps.TeamStore, deps.TagStore, deps.ClickStore, deps.PolicyStore, deps.DomainRuleStore, deps.TenantStore, deps.AdminNetworks, deps.WebAuthn)
 	})
 
 	return r
 }
 
+// contentLanguage sets Content-Language to the best supported match for the
+// request's Accept-Language, which error responses are then written in.
+// Governing: SPEC-0004 REQ "Internationalization"
+func contentLanguage(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Accept-Language")
+		w.Header().Set("Content-Language", i18n.Match(r.Header.Get("Accept-Language")))
+		next.ServeHTTP(w, r)
+	})
+}
+
 // jsonContentType middleware sets Content-Type: application/json on all responses.
 func jsonContentType(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
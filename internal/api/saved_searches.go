@@ -0,0 +1,178 @@
+// Governing: SPEC-0005 REQ "Saved Searches API", SPEC-0004 REQ "Saved Searches"
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/joestump/joe-links/internal/auth"
+	"github.com/joestump/joe-links/internal/store"
+)
+
+// savedSearchesAPIHandler provides REST handlers for the caller's saved searches.
+type savedSearchesAPIHandler struct {
+	searches *store.SavedSearchStore
+}
+
+// registerSavedSearchRoutes registers saved search routes on r.
+// Governing: SPEC-0005 REQ "Saved Searches API"
+func registerSavedSearchRoutes(r chi.Router, searches *store.SavedSearchStore) {
+	h := &savedSearchesAPIHandler{searches: searches}
+	r.Get("/searches", h.List)
+	r.Post("/searches", h.Create)
+	r.Get("/searches/{id}", h.Get)
+	r.Delete("/searches/{id}", h.Delete)
+}
+
+// List returns the caller's saved searches ordered by name.
+// GET /api/v1/searches
+//
+// @Summary      List saved searches
+// @Description  Returns the authenticated user's saved searches, ordered by name.
+// @Tags         Saved Searches
+// @Produce      json
+// @Success      200  {array}   SavedSearchResponse
+// @Failure      401  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Security     BearerToken
+// @Router       /searches [get]
+func (h *savedSearchesAPIHandler) List(w http.ResponseWriter, r *http.Request) {
+	user := auth.UserFromContext(r.Context())
+	if user == nil {
+		writeError(w, http.StatusUnauthorized, "unauthorized", "UNAUTHORIZED")
+		return
+	}
+	searches, err := h.searches.ListByUser(r.Context(), user.ID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
+		return
+	}
+	resp := make([]*SavedSearchResponse, 0, len(searches))
+	for _, ss := range searches {
+		resp = append(resp, savedSearchResponse(ss))
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// Create saves a named search for the caller.
+// POST /api/v1/searches
+//
+// @Summary      Save a search
+// @Description  Saves a link search query and/or tag filter under a name unique to the caller.
+// @Description  The saved search appears in the caller's dashboard sidebar.
+// @Tags         Saved Searches
+// @Accept       json
+// @Produce      json
+// @Param        body  body      CreateSavedSearchRequest  true  "Search to save"
+// @Success      201   {object}  SavedSearchResponse
+// @Failure      400   {object}  ErrorResponse
+// @Failure      401   {object}  ErrorResponse
+// @Failure      409   {object}  ErrorResponse
+// @Failure      500   {object}  ErrorResponse
+// @Security     BearerToken
+// @Router       /searches [post]
+func (h *savedSearchesAPIHandler) Create(w http.ResponseWriter, r *http.Request) {
+	user := auth.UserFromContext(r.Context())
+	if user == nil {
+		writeError(w, http.StatusUnauthorized, "unauthorized", "UNAUTHORIZED")
+		return
+	}
+	var req CreateSavedSearchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body", "BAD_REQUEST")
+		return
+	}
+
+	ss, err := h.searches.Create(r.Context(), user.ID, strings.TrimSpace(req.Name), strings.TrimSpace(req.Query), strings.TrimSpace(req.Tag))
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrSavedSearchNameRequired), errors.Is(err, store.ErrSavedSearchEmpty):
+			writeError(w, http.StatusBadRequest, err.Error(), "BAD_REQUEST")
+		case errors.Is(err, store.ErrInvalidFilter):
+			writeError(w, http.StatusBadRequest, err.Error(), "INVALID_FILTER")
+		case errors.Is(err, store.ErrSavedSearchNameTaken):
+			writeError(w, http.StatusConflict, "a saved search with that name already exists", "NAME_CONFLICT")
+		default:
+			writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
+		}
+		return
+	}
+	writeJSON(w, http.StatusCreated, savedSearchResponse(ss))
+}
+
+// Get returns one of the caller's saved searches.
+// GET /api/v1/searches/{id}
+//
+// @Summary      Get a saved search
+// @Description  Returns one of the authenticated user's saved searches. Run it with GET /links?q={filter}.
+// @Tags         Saved Searches
+// @Produce      json
+// @Param        id   path      string  true  "Saved search ID"
+// @Success      200  {object}  SavedSearchResponse
+// @Failure      401  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Security     BearerToken
+// @Router       /searches/{id} [get]
+func (h *savedSearchesAPIHandler) Get(w http.ResponseWriter, r *http.Request) {
+	user := auth.UserFromContext(r.Context())
+	if user == nil {
+		writeError(w, http.StatusUnauthorized, "unauthorized", "UNAUTHORIZED")
+		return
+	}
+	ss, err := h.searches.Get(r.Context(), user.ID, chi.URLParam(r, "id"))
+	if errors.Is(err, store.ErrNotFound) {
+		writeError(w, http.StatusNotFound, "not found", "NOT_FOUND")
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
+		return
+	}
+	writeJSON(w, http.StatusOK, savedSearchResponse(ss))
+}
+
+// Delete removes one of the caller's saved searches.
+// DELETE /api/v1/searches/{id}
+//
+// @Summary      Delete a saved search
+// @Description  Removes one of the authenticated user's saved searches.
+// @Tags         Saved Searches
+// @Param        id   path      string  true  "Saved search ID"
+// @Success      204  "No Content"
+// @Failure      401  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Security     BearerToken
+// @Router       /searches/{id} [delete]
+func (h *savedSearchesAPIHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	user := auth.UserFromContext(r.Context())
+	if user == nil {
+		writeError(w, http.StatusUnauthorized, "unauthorized", "UNAUTHORIZED")
+		return
+	}
+	err := h.searches.Delete(r.Context(), user.ID, chi.URLParam(r, "id"))
+	if errors.Is(err, store.ErrNotFound) {
+		writeError(w, http.StatusNotFound, "not found", "NOT_FOUND")
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func savedSearchResponse(ss *store.SavedSearch) *SavedSearchResponse {
+	return &SavedSearchResponse{
+		ID:        ss.ID,
+		Name:      ss.Name,
+		Query:     ss.Query,
+		Tag:       ss.Tag,
+		Filter:    ss.FilterQuery(),
+		CreatedAt: ss.CreatedAt,
+	}
+}
@@ -0,0 +1,81 @@
+// Governing: SPEC-0005 REQ "Saved Searches API"
+package api_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/joestump/joe-links/internal/api"
+)
+
+func TestSavedSearches(t *testing.T) {
+	env := newTestEnv(t)
+	alice := seedUser(t, env, "alice@example.com", "user")
+	bob := seedUser(t, env, "bob@example.com", "user")
+	aliceToken := seedToken(t, env, alice.ID)
+	bobToken := seedToken(t, env, bob.ID)
+
+	do := func(token, method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		authRequest(req, token)
+		rec := httptest.NewRecorder()
+		env.Router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := do(aliceToken, "POST", "/searches", `{"name":"Deploys","query":"deploy","tag":"On Call"}`)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("create: status = %d; body: %s", rec.Code, rec.Body.String())
+	}
+	var saved api.SavedSearchResponse
+	if err := json.NewDecoder(rec.Body).Decode(&saved); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if saved.Tag != "on-call" || saved.Filter != "deploy tag:on-call" {
+		t.Errorf("saved = %+v, want tag slug folded into filter", saved)
+	}
+
+	for _, tc := range []struct {
+		body string
+		want int
+	}{
+		{`{"name":"Deploys","query":"other"}`, http.StatusConflict},
+		{`{"name":"","query":"deploy"}`, http.StatusBadRequest},
+		{`{"name":"Empty"}`, http.StatusBadRequest},
+		{`{"name":"Bad","query":"visibility:everyone"}`, http.StatusBadRequest},
+	} {
+		if rec := do(aliceToken, "POST", "/searches", tc.body); rec.Code != tc.want {
+			t.Errorf("create %s: status = %d, want %d", tc.body, rec.Code, tc.want)
+		}
+	}
+	// Names are unique per user, not globally.
+	if rec := do(bobToken, "POST", "/searches", `{"name":"Deploys","query":"deploy"}`); rec.Code != http.StatusCreated {
+		t.Errorf("other user, same name: status = %d, want 201", rec.Code)
+	}
+
+	rec = do(aliceToken, "GET", "/searches", "")
+	var list []api.SavedSearchResponse
+	if err := json.NewDecoder(rec.Body).Decode(&list); err != nil {
+		t.Fatalf("decode list: %v", err)
+	}
+	if len(list) != 1 || list[0].ID != saved.ID {
+		t.Errorf("list = %+v, want only alice's search", list)
+	}
+
+	if rec := do(bobToken, "GET", "/searches/"+saved.ID, ""); rec.Code != http.StatusNotFound {
+		t.Errorf("other user's get: status = %d, want 404", rec.Code)
+	}
+	if rec := do(bobToken, "DELETE", "/searches/"+saved.ID, ""); rec.Code != http.StatusNotFound {
+		t.Errorf("other user's delete: status = %d, want 404", rec.Code)
+	}
+	if rec := do(aliceToken, "DELETE", "/searches/"+saved.ID, ""); rec.Code != http.StatusNoContent {
+		t.Fatalf("delete: status = %d", rec.Code)
+	}
+	if rec := do(aliceToken, "GET", "/searches/"+saved.ID, ""); rec.Code != http.StatusNotFound {
+		t.Errorf("get after delete: status = %d, want 404", rec.Code)
+	}
+}
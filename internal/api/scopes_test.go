@@ -0,0 +1,100 @@
+// Governing: SPEC-0006 REQ "Token Scopes"
+package api_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/joestump/joe-links/internal/api"
+	"github.com/joestump/joe-links/internal/auth"
+)
+
+func TestScopes_ReadOnlyTokenCannotWrite(t *testing.T) {
+	env := newTestEnv(t)
+	user := seedUser(t, env, "ci@example.com", "user")
+	token := seedScopedToken(t, env, user.ID, auth.ScopeLinksRead)
+
+	rec := httptest.NewRecorder()
+	env.Router.ServeHTTP(rec, authRequest(httptest.NewRequest("GET", "/links", nil), token))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET /links status = %d, want 200; body: %s", rec.Code, rec.Body.String())
+	}
+
+	body := bytes.NewBufferString(`{"slug":"ci-link","url":"https://example.com"}`)
+	rec = httptest.NewRecorder()
+	env.Router.ServeHTTP(rec, authRequest(httptest.NewRequest("POST", "/links", body), token))
+	if rec.Code != http.StatusForbidden {
+		t.Fatalf("POST /links status = %d, want 403; body: %s", rec.Code, rec.Body.String())
+	}
+	var errResp api.ErrorResponse
+	if err := json.NewDecoder(rec.Body).Decode(&errResp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if errResp.Code != "INSUFFICIENT_SCOPE" {
+		t.Errorf("code = %q, want INSUFFICIENT_SCOPE", errResp.Code)
+	}
+}
+
+func TestScopes_AdminRoutesRequireAdminScope(t *testing.T) {
+	env := newTestEnv(t)
+	admin := seedUser(t, env, "scoped-admin@example.com", "admin")
+	token := seedScopedToken(t, env, admin.ID, auth.ScopeLinksRead, auth.ScopeLinksWrite)
+
+	rec := httptest.NewRecorder()
+	env.Router.ServeHTTP(rec, authRequest(httptest.NewRequest("GET", "/admin/users", nil), token))
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("status = %d, want 403", rec.Code)
+	}
+
+	full := seedToken(t, env, admin.ID)
+	rec = httptest.NewRecorder()
+	env.Router.ServeHTTP(rec, authRequest(httptest.NewRequest("GET", "/admin/users", nil), full))
+	if rec.Code != http.StatusOK {
+		t.Errorf("full-scope status = %d, want 200", rec.Code)
+	}
+}
+
+func TestScopes_CreateTokenCannotEscalate(t *testing.T) {
+	env := newTestEnv(t)
+	user := seedUser(t, env, "escalate@example.com", "user")
+	token := seedScopedToken(t, env, user.ID, auth.ScopeLinksRead, auth.ScopeLinksWrite)
+
+	body := bytes.NewBufferString(`{"name":"sneaky","scopes":["links:read","admin"]}`)
+	rec := httptest.NewRecorder()
+	env.Router.ServeHTTP(rec, authRequest(httptest.NewRequest("POST", "/tokens", body), token))
+	if rec.Code != http.StatusForbidden {
+		t.Fatalf("status = %d, want 403; body: %s", rec.Code, rec.Body.String())
+	}
+
+	// Omitting scopes inherits the caller's scopes.
+	body = bytes.NewBufferString(`{"name":"inherit"}`)
+	rec = httptest.NewRecorder()
+	env.Router.ServeHTTP(rec, authRequest(httptest.NewRequest("POST", "/tokens", body), token))
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("status = %d, want 201; body: %s", rec.Code, rec.Body.String())
+	}
+	var created api.TokenCreatedResponse
+	if err := json.NewDecoder(rec.Body).Decode(&created); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if !slices.Equal(created.Scopes, []string{auth.ScopeLinksRead, auth.ScopeLinksWrite}) {
+		t.Errorf("scopes = %v, want [links:read links:write]", created.Scopes)
+	}
+}
+
+func TestScopes_UnknownScopeRejected(t *testing.T) {
+	env := newTestEnv(t)
+	user := seedUser(t, env, "unknown-scope@example.com", "user")
+	token := seedToken(t, env, user.ID)
+
+	body := bytes.NewBufferString(`{"name":"bad","scopes":["links:delete"]}`)
+	rec := httptest.NewRecorder()
+	env.Router.ServeHTTP(rec, authRequest(httptest.NewRequest("POST", "/tokens", body), token))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want 400", rec.Code)
+	}
+}
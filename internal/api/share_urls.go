@@ -0,0 +1,107 @@
+// Governing: SPEC-0010 REQ "Signed Share URLs"
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/joestump/joe-links/internal/auth"
+	"github.com/joestump/joe-links/internal/shareurl"
+	"github.com/joestump/joe-links/internal/store"
+)
+
+// shareURLAPIHandler mints signed share URLs for secure links.
+type shareURLAPIHandler struct {
+	links     *store.LinkStore
+	ownership *store.OwnershipStore
+	signer    *shareurl.Signer
+}
+
+// Create mints an expiring URL that opens a secure link without a share record.
+// POST /api/v1/links/{id}/share-url
+// Governing: SPEC-0010 REQ "Signed Share URLs"
+//
+// @Summary      Create a signed share URL
+// @Description  Returns a /s/{slug} URL that opens a secure link for anyone holding it until it expires. Only owners and admins may create one.
+// @Tags         Shares
+// @Accept       json
+// @Produce      json
+// @Param        id    path      string                 true   "Link ID"
+// @Param        body  body      CreateShareURLRequest  false  "URL lifetime"
+// @Success      201   {object}  ShareURLResponse
+// @Failure      400   {object}  ErrorResponse
+// @Failure      401   {object}  ErrorResponse
+// @Failure      403   {object}  ErrorResponse
+// @Failure      404   {object}  ErrorResponse
+// @Failure      500   {object}  ErrorResponse
+// @Security     BearerToken
+// @Router       /links/{id}/share-url [post]
+func (h *shareURLAPIHandler) Create(w http.ResponseWriter, r *http.Request) {
+	user := auth.UserFromContext(r.Context())
+	if user == nil {
+		writeError(w, http.StatusUnauthorized, "unauthorized", "UNAUTHORIZED")
+		return
+	}
+
+	link, err := h.links.GetByID(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			writeError(w, http.StatusNotFound, "not found", "NOT_FOUND")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
+		return
+	}
+
+	if user.Role != "admin" {
+		isOwner, err := h.ownership.IsOwner(link.ID, user.ID)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
+			return
+		}
+		if !isOwner {
+			writeError(w, http.StatusForbidden, "forbidden", "FORBIDDEN")
+			return
+		}
+	}
+	if link.Visibility != "secure" {
+		writeError(w, http.StatusBadRequest, "share URLs are only needed for secure links", "BAD_REQUEST")
+		return
+	}
+
+	var req CreateShareURLRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		writeError(w, http.StatusBadRequest, "invalid request body", "BAD_REQUEST")
+		return
+	}
+	ttl := shareurl.DefaultTTL
+	if req.ExpiresIn != 0 {
+		ttl = time.Duration(req.ExpiresIn) * time.Second
+	}
+	query, expires, err := h.signer.Sign(link.ID, ttl, time.Now())
+	if err != nil {
+		writeFieldError(w, http.StatusBadRequest, "expires_in", "expires_in must be between 1 second and 30 days", "BAD_REQUEST")
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, ShareURLResponse{
+		URL:       requestBaseURL(r) + shareurl.Prefix + link.Slug + "?" + query.Encode(),
+		ExpiresAt: expires.UTC(),
+	})
+}
+
+// requestBaseURL returns the scheme and host the request was made to,
+// honoring X-Forwarded-Proto from a TLS-terminating proxy.
+func requestBaseURL(r *http.Request) string {
+	scheme := "https"
+	if proto := r.Header.Get("X-Forwarded-Proto"); proto != "" {
+		scheme = proto
+	} else if r.TLS == nil {
+		scheme = "http"
+	}
+	return scheme + "://" + r.Host
+}
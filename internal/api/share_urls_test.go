@@ -0,0 +1,72 @@
+// Governing: SPEC-0010 REQ "Signed Share URLs"
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/joestump/joe-links/internal/api"
+)
+
+func TestCreateShareURL(t *testing.T) {
+	env := newTestEnv(t)
+	owner := seedUser(t, env, "share-url-owner@example.com", "user")
+	other := seedUser(t, env, "share-url-other@example.com", "user")
+	ctx := context.Background()
+
+	secure, err := env.LinkStore.Create(ctx, "payroll", "https://hr.example.com/payroll", owner.ID, "", "", "secure")
+	if err != nil {
+		t.Fatalf("create link: %v", err)
+	}
+	public, err := env.LinkStore.Create(ctx, "wiki", "https://wiki.example.com", owner.ID, "", "", "public")
+	if err != nil {
+		t.Fatalf("create link: %v", err)
+	}
+
+	post := func(token, linkID, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", "http://go.example.com/links/"+linkID+"/share-url", strings.NewReader(body))
+		authRequest(req, token)
+		rec := httptest.NewRecorder()
+		env.Router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := post(seedToken(t, env, owner.ID), secure.ID, `{"expires_in": 3600}`)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("status = %d, want %d; body: %s", rec.Code, http.StatusCreated, rec.Body.String())
+	}
+	var resp api.ShareURLResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	u, err := url.Parse(resp.URL)
+	if err != nil || u.Host != "go.example.com" || u.Path != "/s/payroll" {
+		t.Fatalf("url = %q, want http://go.example.com/s/payroll?...", resp.URL)
+	}
+	if !env.ShareURLs.Verify(secure.ID, u.Query(), time.Now()) {
+		t.Errorf("url %q does not verify", resp.URL)
+	}
+	if d := time.Until(resp.ExpiresAt); d < 59*time.Minute || d > time.Hour {
+		t.Errorf("expires_at = %v, want about an hour from now", resp.ExpiresAt)
+	}
+
+	for _, tt := range []struct {
+		name, token, linkID, body string
+		want                      int
+	}{
+		{"default lifetime", seedToken(t, env, owner.ID), secure.ID, "", http.StatusCreated},
+		{"too long", seedToken(t, env, owner.ID), secure.ID, `{"expires_in": 31536000}`, http.StatusBadRequest},
+		{"public link", seedToken(t, env, owner.ID), public.ID, "", http.StatusBadRequest},
+		{"not an owner", seedToken(t, env, other.ID), secure.ID, "", http.StatusForbidden},
+	} {
+		if rec := post(tt.token, tt.linkID, tt.body); rec.Code != tt.want {
+			t.Errorf("%s: status = %d, want %d; body: %s", tt.name, rec.Code, tt.want, rec.Body.String())
+		}
+	}
+}
@@ -2,12 +2,14 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"net/http"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/joestump/joe-links/internal/auth"
+	"github.com/joestump/joe-links/internal/mailer"
 	"github.com/joestump/joe-links/internal/store"
 )
 
@@ -17,12 +19,13 @@ type sharesAPIHandler struct {
 	links     *store.LinkStore
 	ownership *store.OwnershipStore
 	users     *store.UserStore
+	notify    *mailer.Notifier // nil disables share emails
 }
 
 // registerShareRoutes registers share management routes on r.
 // Governing: SPEC-0010 REQ "Link Share Management API Endpoints"
-func registerShareRoutes(r chi.Router, links *store.LinkStore, ownership *store.OwnershipStore, users *store.UserStore) {
-	h := &sharesAPIHandler{links: links, ownership: ownership, users: users}
+func registerShareRoutes(r chi.Router, links *store.LinkStore, ownership *store.OwnershipStore, users *store.UserStore, notify *mailer.Notifier) {
+	h := &sharesAPIHandler{links: links, ownership: ownership, users: users, notify: notify}
 	r.Get("/links/{id}/shares", h.List)
 	r.Post("/links/{id}/shares", h.Add)
 	r.Delete("/links/{id}/shares/{uid}", h.Remove)
@@ -75,18 +78,26 @@ func (h *sharesAPIHandler) List(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	shares, err := h.links.ListShares(r.Context(), link.ID)
+	resp, err := buildShareResponses(r.Context(), h.links, h.users, link.ID)
 	if err != nil {
 		writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
 		return
 	}
 
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// buildShareResponses lists the users a link is shared with.
+func buildShareResponses(ctx context.Context, links *store.LinkStore, users *store.UserStore, linkID string) ([]ShareResponse, error) {
+	shares, err := links.ListShares(ctx, linkID)
+	if err != nil {
+		return nil, err
+	}
 	resp := make([]ShareResponse, 0, len(shares))
 	for _, s := range shares {
-		u, err := h.users.GetByID(r.Context(), s.UserID)
+		u, err := users.GetByID(ctx, s.UserID)
 		if err != nil {
-			writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
-			return
+			return nil, err
 		}
 		resp = append(resp, ShareResponse{
 			LinkID:      s.LinkID,
@@ -97,8 +108,7 @@ func (h *sharesAPIHandler) List(w http.ResponseWriter, r *http.Request) {
 			CreatedAt:   s.CreatedAt,
 		})
 	}
-
-	writeJSON(w, http.StatusOK, resp)
+	return resp, nil
 }
 
 // Add shares a link with a user by email.
@@ -157,7 +167,7 @@ func (h *sharesAPIHandler) Add(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	if req.Email == "" {
-		writeError(w, http.StatusBadRequest, "email is required", "BAD_REQUEST")
+		writeRequiredField(w, "email", "BAD_REQUEST")
 		return
 	}
 
@@ -172,7 +182,7 @@ func (h *sharesAPIHandler) Add(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Check if already shared.
-	hasShare, err := h.links.HasShare(r.Context(), link.ID, targetUser.ID)
+	hasShare, err := h.links.HasDirectShare(r.Context(), link.ID, targetUser.ID)
 	if err != nil {
 		writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
 		return
@@ -186,6 +196,10 @@ func (h *sharesAPIHandler) Add(w http.ResponseWriter, r *http.Request) {
 		writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
 		return
 	}
+	// Governing: SPEC-0001 REQ "Email Notifications"
+	if h.notify != nil {
+		h.notify.ShareGranted(link, targetUser, user)
+	}
 
 	// Fetch the created share record for the response.
 	shares, err := h.links.ListShares(r.Context(), link.ID)
@@ -262,7 +276,7 @@ func (h *sharesAPIHandler) Remove(w http.ResponseWriter, r *http.Request) {
 	shareUID := chi.URLParam(r, "uid")
 
 	// Verify the share exists before deleting.
-	hasShare, err := h.links.HasShare(r.Context(), link.ID, shareUID)
+	hasShare, err := h.links.HasDirectShare(r.Context(), link.ID, shareUID)
 	if err != nil {
 		writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
 		return
@@ -2,6 +2,7 @@
 package api
 
 import (
+	"context"
 	"errors"
 	"net/http"
 	"strconv"
@@ -24,17 +25,44 @@ func newStatsAPIHandler(ls *store.LinkStore, cs *store.ClickStore, os *store.Own
 
 // statsResponse is the JSON shape for GET /api/v1/links/{id}/stats.
 type statsResponse struct {
-	LinkID  string `json:"link_id"`
-	Total   int64  `json:"total"`
-	Last7d  int64  `json:"last_7d"`
-	Last30d int64  `json:"last_30d"`
+	LinkID    string                 `json:"link_id"`
+	Total     int64                  `json:"total"`
+	Last7d    int64                  `json:"last_7d"`
+	Last30d   int64                  `json:"last_30d"`
+	Countries []countryCountResponse `json:"countries"`
+	Sources   []sourceCountResponse  `json:"sources"`
 }
 
+// countryCountResponse is the number of clicks from one country. Country is
+// an ISO 3166-1 alpha-2 code, or "" for clicks of unknown country.
+// Governing: SPEC-0016 REQ "GeoIP Country Breakdown"
+type countryCountResponse struct {
+	Country string `json:"country"`
+	Count   int64  `json:"count"`
+}
+
+// statsCountryLimit is how many countries GetStats returns.
+const statsCountryLimit = 50
+
+// sourceCountResponse is the number of clicks from one campaign source, or
+// "" for clicks that carried none.
+// Governing: SPEC-0016 REQ "Campaign Sources"
+type sourceCountResponse struct {
+	Source string `json:"source"`
+	Count  int64  `json:"count"`
+}
+
+// statsSourceLimit is how many campaign sources GetStats returns.
+const statsSourceLimit = 50
+
 // clickResponse is one entry in the clicks list.
 type clickResponse struct {
 	ClickedAt time.Time     `json:"clicked_at"`
 	Referrer  *string       `json:"referrer"`
 	User      *clickUserRef `json:"user"`
+	Bot       bool          `json:"bot"`
+	Country   string        `json:"country"`
+	Source    string        `json:"source"`
 }
 
 type clickUserRef struct {
@@ -81,18 +109,47 @@ func (h *statsAPIHandler) GetStats(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	stats, err := h.clicks.GetClickStats(r.Context(), link.ID)
+	resp, err := buildStatsResponse(r.Context(), clicksFor(r, h.clicks), link.ID)
 	if err != nil {
 		writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
 		return
 	}
 
-	writeJSON(w, http.StatusOK, statsResponse{
-		LinkID:  link.ID,
-		Total:   stats.Total,
-		Last7d:  stats.Last7d,
-		Last30d: stats.Last30d,
-	})
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// buildStatsResponse aggregates a link's click totals, top countries, and top
+// campaign sources.
+func buildStatsResponse(ctx context.Context, clicks *store.ClickStore, linkID string) (*statsResponse, error) {
+	stats, err := clicks.GetClickStats(ctx, linkID)
+	if err != nil {
+		return nil, err
+	}
+	// Governing: SPEC-0016 REQ "GeoIP Country Breakdown"
+	countries, err := clicks.TopCountries(ctx, linkID, statsCountryLimit)
+	if err != nil {
+		return nil, err
+	}
+	// Governing: SPEC-0016 REQ "Campaign Sources"
+	sources, err := clicks.TopSources(ctx, linkID, statsSourceLimit)
+	if err != nil {
+		return nil, err
+	}
+	resp := &statsResponse{
+		LinkID:    linkID,
+		Total:     stats.Total,
+		Last7d:    stats.Last7d,
+		Last30d:   stats.Last30d,
+		Countries: make([]countryCountResponse, 0, len(countries)),
+		Sources:   make([]sourceCountResponse, 0, len(sources)),
+	}
+	for _, c := range countries {
+		resp.Countries = append(resp.Countries, countryCountResponse{Country: c.Country, Count: c.Count})
+	}
+	for _, s := range sources {
+		resp.Sources = append(resp.Sources, sourceCountResponse{Source: s.Source, Count: s.Count})
+	}
+	return resp, nil
 }
 
 // ListClicks returns paginated click events for a link.
@@ -151,7 +208,7 @@ func (h *statsAPIHandler) ListClicks(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Fetch limit+1 to detect next page.
-	rows, err := h.clicks.ListRecentClicksBefore(r.Context(), link.ID, before, limit+1)
+	rows, err := clicksFor(r, h.clicks).ListRecentClicksBefore(r.Context(), link.ID, before, limit+1)
 	if err != nil {
 		writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
 		return
@@ -168,6 +225,9 @@ func (h *statsAPIHandler) ListClicks(w http.ResponseWriter, r *http.Request) {
 	for _, rc := range rows {
 		cr := clickResponse{
 			ClickedAt: rc.ClickedAt,
+			Bot:       rc.Bot,
+			Country:   rc.Country,
+			Source:    rc.Source,
 		}
 		if rc.Referrer != "" {
 			ref := rc.Referrer
@@ -187,3 +247,17 @@ func (h *statsAPIHandler) ListClicks(w http.ResponseWriter, r *http.Request) {
 		NextCursor: nextCursor,
 	})
 }
+
+// clicksFor returns cs, or a view of it that counts clicks from excluded
+// referrers when the request asks for ?referrers=all and clicks flagged as
+// bots when it asks for ?bots=include.
+// Governing: SPEC-0016 REQ "Referrer Exclusion", REQ "Bot Filtering"
+func clicksFor(r *http.Request, cs *store.ClickStore) *store.ClickStore {
+	if r.URL.Query().Get("referrers") == "all" {
+		cs = cs.IncludingExcluded()
+	}
+	if r.URL.Query().Get("bots") == "include" {
+		cs = cs.IncludingBots()
+	}
+	return cs
+}
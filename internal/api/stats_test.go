@@ -27,7 +27,7 @@ func TestStats_Owner_OK(t *testing.T) {
 
 	// Record a click.
 	err = env.ClickStore.RecordClick(ctx, store.ClickEvent{
-		LinkID: link.ID, UserID: user.ID, IPHash: "h1", UserAgent: "Test/1", Referrer: "https://ref.com",
+		LinkID: link.ID, UserID: user.ID, IPHash: "h1", UserAgent: "Test/1", Referrer: "https://ref.com", Country: "NL",
 	})
 	if err != nil {
 		t.Fatalf("record click: %v", err)
@@ -47,10 +47,18 @@ func TestStats_Owner_OK(t *testing.T) {
 		Total   int64  `json:"total"`
 		Last7d  int64  `json:"last_7d"`
 		Last30d int64  `json:"last_30d"`
+		// Governing: SPEC-0016 REQ "GeoIP Country Breakdown"
+		Countries []struct {
+			Country string `json:"country"`
+			Count   int64  `json:"count"`
+		} `json:"countries"`
 	}
 	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
 		t.Fatalf("decode: %v", err)
 	}
+	if len(resp.Countries) != 1 || resp.Countries[0].Country != "NL" || resp.Countries[0].Count != 1 {
+		t.Errorf("countries = %+v, want [{NL 1}]", resp.Countries)
+	}
 	if resp.LinkID != link.ID {
 		t.Errorf("link_id = %q, want %q", resp.LinkID, link.ID)
 	}
@@ -0,0 +1,68 @@
+// Governing: SPEC-0016 REQ "Status Page"
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/joestump/joe-links/internal/status"
+)
+
+// statusAPIHandler serves the public server status summary.
+type statusAPIHandler struct {
+	checker *status.Checker
+}
+
+// registerStatusRoutes registers the public /status endpoint.
+// No auth required, so ops dashboards can poll it without a token.
+func registerStatusRoutes(r chi.Router, checker *status.Checker) {
+	h := &statusAPIHandler{checker: checker}
+	r.Get("/status", h.Get)
+}
+
+// Get returns the server status summary.
+// GET /api/v1/status
+//
+// @Summary      Server status
+// @Description  Summarizes server health: database latency, click queue depth, and background job staleness.
+// @Description  Returns 200 when the status is ok or degraded and 503 when the database is down. No authentication required.
+// @Tags         Status
+// @Produce      json
+// @Success      200  {object}  StatusResponse
+// @Failure      503  {object}  StatusResponse
+// @Router       /status [get]
+func (h *statusAPIHandler) Get(w http.ResponseWriter, r *http.Request) {
+	report := h.checker.Check(r.Context())
+	resp := StatusResponse{
+		Status:        report.Status,
+		Version:       report.Version,
+		CheckedAt:     report.CheckedAt,
+		UptimeSeconds: int64(report.Uptime / time.Second),
+		Database: DatabaseStatusResponse{
+			Status:    report.Database.Status,
+			LatencyMS: float64(report.Database.Latency.Microseconds()) / 1000,
+		},
+		ClickQueue: QueueStatusResponse{
+			Status:   report.Queue.Status,
+			Depth:    report.Queue.Depth,
+			Capacity: report.Queue.Capacity,
+		},
+		Jobs: make([]JobStatusResponse, 0, len(report.Jobs)),
+	}
+	for _, j := range report.Jobs {
+		js := JobStatusResponse{Name: j.Name, Status: j.Status, IntervalSeconds: int64(j.Interval / time.Second)}
+		if !j.LastSuccess.IsZero() {
+			last := j.LastSuccess
+			js.LastSuccess = &last
+		}
+		resp.Jobs = append(resp.Jobs, js)
+	}
+
+	code := http.StatusOK
+	if report.Status == status.Down {
+		code = http.StatusServiceUnavailable
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	writeJSON(w, code, resp)
+}
@@ -0,0 +1,33 @@
+package api_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/joestump/joe-links/internal/api"
+)
+
+// Governing: SPEC-0016 REQ "Status Page"
+func TestStatus_Public(t *testing.T) {
+	env := newTestEnv(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/status", nil)
+	rr := httptest.NewRecorder()
+	env.Router.ServeHTTP(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200 without a token, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	var resp api.StatusResponse
+	if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if resp.Status != "ok" || resp.Database.Status != "ok" {
+		t.Errorf("status = %q, database %q; want ok", resp.Status, resp.Database.Status)
+	}
+	if len(resp.Jobs) != 1 || resp.Jobs[0].Name != "test_job" || resp.Jobs[0].IntervalSeconds != 60 || resp.Jobs[0].LastSuccess != nil {
+		t.Errorf("jobs = %+v; want test_job, never run, every 60s", resp.Jobs)
+	}
+}
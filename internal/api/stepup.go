@@ -0,0 +1,41 @@
+// Governing: SPEC-0001 REQ "WebAuthn Step-Up", SPEC-0007 REQ "Swagger UI Session Try-It"
+package api
+
+import (
+	"net/http"
+
+	"github.com/joestump/joe-links/internal/auth"
+)
+
+// requireSessionStepUp holds requests an admin makes with their browser
+// session (the Swagger UI fallback) to the web UI's passkey rule: when
+// destructive reports true, the session must have confirmed a passkey within
+// auth.StepUpWindow. Token-authenticated requests and non-admins pass
+// through. A nil wa disables the check.
+func requireSessionStepUp(wa *auth.WebAuthn, destructive func(*http.Request) bool) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if wa == nil {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			user := auth.UserFromContext(r.Context())
+			if auth.TokenIDFromContext(r.Context()) != "" || user == nil || user.Role != "admin" || !destructive(r) || wa.SteppedUp(r.Context()) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			writeError(w, http.StatusForbidden, "confirm with a passkey at /dashboard/step-up, then retry", "STEP_UP_REQUIRED")
+		})
+	}
+}
+
+// isDelete reports whether r is a DELETE request.
+func isDelete(r *http.Request) bool { return r.Method == http.MethodDelete }
+
+// isUnsafe reports whether r may change state.
+func isUnsafe(r *http.Request) bool {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,315 @@
+// Governing: SPEC-0010 REQ "Team Shares and Ownership"
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/joestump/joe-links/internal/auth"
+	"github.com/joestump/joe-links/internal/store"
+)
+
+// teamGrantsAPIHandler manages link co-ownership and shares granted to teams.
+type teamGrantsAPIHandler struct {
+	links     *store.LinkStore
+	ownership *store.OwnershipStore
+	teams     *store.TeamStore
+}
+
+// registerTeamGrantRoutes registers team co-owner and team share routes on r.
+func registerTeamGrantRoutes(r chi.Router, links *store.LinkStore, ownership *store.OwnershipStore, teams *store.TeamStore) {
+	h := &teamGrantsAPIHandler{links: links, ownership: ownership, teams: teams}
+	r.Get("/links/{id}/team-owners", h.ListOwners)
+	r.Post("/links/{id}/team-owners", h.AddOwner)
+	r.Delete("/links/{id}/team-owners/{team}", h.RemoveOwner)
+	r.Get("/links/{id}/team-shares", h.ListShares)
+	r.Post("/links/{id}/team-shares", h.AddShare)
+	r.Delete("/links/{id}/team-shares/{team}", h.RemoveShare)
+}
+
+// ListOwners returns the teams that co-own a link.
+// GET /api/v1/links/{id}/team-owners
+//
+// @Summary      List team co-owners
+// @Description  Returns the teams whose members co-own a link. Only owners and admins may access.
+// @Tags         Owners
+// @Produce      json
+// @Param        id   path      string  true  "Link ID"
+// @Success      200  {array}   TeamResponse
+// @Failure      401  {object}  ErrorResponse
+// @Failure      403  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Security     BearerToken
+// @Router       /links/{id}/team-owners [get]
+func (h *teamGrantsAPIHandler) ListOwners(w http.ResponseWriter, r *http.Request) {
+	link, ok := h.authorize(w, r)
+	if !ok {
+		return
+	}
+	teams, err := h.ownership.ListTeamOwners(link.ID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
+		return
+	}
+	writeTeams(w, http.StatusOK, teams)
+}
+
+// AddOwner makes every member of a team a co-owner of a link.
+// POST /api/v1/links/{id}/team-owners
+//
+// @Summary      Add a team co-owner
+// @Description  Makes every member of a team a co-owner of a link. Only owners and admins may add co-owners.
+// @Tags         Owners
+// @Accept       json
+// @Produce      json
+// @Param        id    path      string            true  "Link ID"
+// @Param        body  body      TeamGrantRequest  true  "Team to add"
+// @Success      201   {array}   TeamResponse
+// @Failure      400   {object}  ErrorResponse
+// @Failure      401   {object}  ErrorResponse
+// @Failure      403   {object}  ErrorResponse
+// @Failure      404   {object}  ErrorResponse
+// @Failure      409   {object}  ErrorResponse
+// @Failure      500   {object}  ErrorResponse
+// @Security     BearerToken
+// @Router       /links/{id}/team-owners [post]
+func (h *teamGrantsAPIHandler) AddOwner(w http.ResponseWriter, r *http.Request) {
+	link, ok := h.authorize(w, r)
+	if !ok {
+		return
+	}
+	team, ok := h.requestedTeam(w, r)
+	if !ok {
+		return
+	}
+	if err := h.ownership.AddTeamOwner(link.ID, team.ID); err != nil {
+		if errors.Is(err, store.ErrAlreadyOwner) {
+			writeError(w, http.StatusConflict, "team is already a co-owner", "DUPLICATE_OWNER")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
+		return
+	}
+	teams, err := h.ownership.ListTeamOwners(link.ID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
+		return
+	}
+	writeTeams(w, http.StatusCreated, teams)
+}
+
+// RemoveOwner revokes a team's co-ownership of a link.
+// DELETE /api/v1/links/{id}/team-owners/{team}
+//
+// @Summary      Remove a team co-owner
+// @Description  Revokes a team's co-ownership of a link. Only owners and admins may remove co-owners.
+// @Tags         Owners
+// @Param        id    path  string  true  "Link ID"
+// @Param        team  path  string  true  "Team slug"
+// @Success      204   "No Content"
+// @Failure      401   {object}  ErrorResponse
+// @Failure      403   {object}  ErrorResponse
+// @Failure      404   {object}  ErrorResponse
+// @Failure      500   {object}  ErrorResponse
+// @Security     BearerToken
+// @Router       /links/{id}/team-owners/{team} [delete]
+func (h *teamGrantsAPIHandler) RemoveOwner(w http.ResponseWriter, r *http.Request) {
+	link, ok := h.authorize(w, r)
+	if !ok {
+		return
+	}
+	team, ok := h.pathTeam(w, r)
+	if !ok {
+		return
+	}
+	if err := h.ownership.RemoveTeamOwner(link.ID, team.ID); err != nil {
+		if errors.Is(err, store.ErrNotOwner) {
+			writeError(w, http.StatusNotFound, "team is not a co-owner", "NOT_FOUND")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListShares returns the teams a link is shared with.
+// GET /api/v1/links/{id}/team-shares
+//
+// @Summary      List team shares
+// @Description  Returns the teams whose members have been shared access to a link. Only owners and admins may access.
+// @Tags         Shares
+// @Produce      json
+// @Param        id   path      string  true  "Link ID"
+// @Success      200  {array}   TeamResponse
+// @Failure      401  {object}  ErrorResponse
+// @Failure      403  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Security     BearerToken
+// @Router       /links/{id}/team-shares [get]
+func (h *teamGrantsAPIHandler) ListShares(w http.ResponseWriter, r *http.Request) {
+	link, ok := h.authorize(w, r)
+	if !ok {
+		return
+	}
+	teams, err := h.links.ListTeamShares(r.Context(), link.ID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
+		return
+	}
+	writeTeams(w, http.StatusOK, teams)
+}
+
+// AddShare shares a link with every member of a team.
+// POST /api/v1/links/{id}/team-shares
+//
+// @Summary      Add a team share
+// @Description  Shares a secure link with every member of a team. Only owners and admins may share.
+// @Tags         Shares
+// @Accept       json
+// @Produce      json
+// @Param        id    path      string            true  "Link ID"
+// @Param        body  body      TeamGrantRequest  true  "Team to share with"
+// @Success      201   {array}   TeamResponse
+// @Failure      400   {object}  ErrorResponse
+// @Failure      401   {object}  ErrorResponse
+// @Failure      403   {object}  ErrorResponse
+// @Failure      404   {object}  ErrorResponse
+// @Failure      409   {object}  ErrorResponse
+// @Failure      500   {object}  ErrorResponse
+// @Security     BearerToken
+// @Router       /links/{id}/team-shares [post]
+func (h *teamGrantsAPIHandler) AddShare(w http.ResponseWriter, r *http.Request) {
+	link, ok := h.authorize(w, r)
+	if !ok {
+		return
+	}
+	team, ok := h.requestedTeam(w, r)
+	if !ok {
+		return
+	}
+	user := auth.UserFromContext(r.Context())
+	if err := h.links.AddTeamShare(r.Context(), link.ID, team.ID, user.ID); err != nil {
+		if errors.Is(err, store.ErrAlreadyShared) {
+			writeError(w, http.StatusConflict, "link is already shared with this team", "DUPLICATE_SHARE")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
+		return
+	}
+	teams, err := h.links.ListTeamShares(r.Context(), link.ID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
+		return
+	}
+	writeTeams(w, http.StatusCreated, teams)
+}
+
+// RemoveShare revokes a team share.
+// DELETE /api/v1/links/{id}/team-shares/{team}
+//
+// @Summary      Remove a team share
+// @Description  Revokes a team's shared access to a link. Only owners and admins may remove shares.
+// @Tags         Shares
+// @Param        id    path  string  true  "Link ID"
+// @Param        team  path  string  true  "Team slug"
+// @Success      204   "No Content"
+// @Failure      401   {object}  ErrorResponse
+// @Failure      403   {object}  ErrorResponse
+// @Failure      404   {object}  ErrorResponse
+// @Failure      500   {object}  ErrorResponse
+// @Security     BearerToken
+// @Router       /links/{id}/team-shares/{team} [delete]
+func (h *teamGrantsAPIHandler) RemoveShare(w http.ResponseWriter, r *http.Request) {
+	link, ok := h.authorize(w, r)
+	if !ok {
+		return
+	}
+	team, ok := h.pathTeam(w, r)
+	if !ok {
+		return
+	}
+	if err := h.links.RemoveTeamShare(r.Context(), link.ID, team.ID); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			writeError(w, http.StatusNotFound, "share not found", "NOT_FOUND")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// authorize loads the link named by {id} and checks that the caller owns it
+// or is an admin, writing an error response and returning false otherwise.
+func (h *teamGrantsAPIHandler) authorize(w http.ResponseWriter, r *http.Request) (*store.Link, bool) {
+	user := auth.UserFromContext(r.Context())
+	if user == nil {
+		writeError(w, http.StatusUnauthorized, "unauthorized", "UNAUTHORIZED")
+		return nil, false
+	}
+	link, err := h.links.GetByID(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			writeError(w, http.StatusNotFound, "not found", "NOT_FOUND")
+			return nil, false
+		}
+		writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
+		return nil, false
+	}
+	allowed, err := store.IsOwnerOrAdmin(h.ownership, link.ID, user.ID, user.Role)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
+		return nil, false
+	}
+	if !allowed {
+		writeError(w, http.StatusForbidden, "forbidden", "FORBIDDEN")
+		return nil, false
+	}
+	return link, true
+}
+
+// requestedTeam decodes a TeamGrantRequest and loads the team it names.
+func (h *teamGrantsAPIHandler) requestedTeam(w http.ResponseWriter, r *http.Request) (*store.Team, bool) {
+	var req TeamGrantRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body", "BAD_REQUEST")
+		return nil, false
+	}
+	if req.Team == "" {
+		writeRequiredField(w, "team", "BAD_REQUEST")
+		return nil, false
+	}
+	return h.lookupTeam(w, r, req.Team)
+}
+
+// pathTeam loads the team named by the {team} URL parameter.
+func (h *teamGrantsAPIHandler) pathTeam(w http.ResponseWriter, r *http.Request) (*store.Team, bool) {
+	return h.lookupTeam(w, r, chi.URLParam(r, "team"))
+}
+
+func (h *teamGrantsAPIHandler) lookupTeam(w http.ResponseWriter, r *http.Request, slug string) (*store.Team, bool) {
+	team, err := h.teams.GetBySlug(r.Context(), slug)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			writeError(w, http.StatusNotFound, "team not found", "NOT_FOUND")
+			return nil, false
+		}
+		writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
+		return nil, false
+	}
+	return team, true
+}
+
+// writeTeams writes teams as a JSON array of TeamResponse.
+func writeTeams(w http.ResponseWriter, status int, teams []*store.Team) {
+	resp := make([]*TeamResponse, 0, len(teams))
+	for _, t := range teams {
+		resp = append(resp, teamResponse(t))
+	}
+	writeJSON(w, status, resp)
+}
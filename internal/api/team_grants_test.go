@@ -0,0 +1,87 @@
+// Governing: SPEC-0010 REQ "Team Shares and Ownership"
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/joestump/joe-links/internal/api"
+)
+
+func TestTeamGrants(t *testing.T) {
+	env := newTestEnv(t)
+	admin := seedUser(t, env, "team-admin@example.com", "admin")
+	owner := seedUser(t, env, "team-owner@example.com", "user")
+	member := seedUser(t, env, "team-member@example.com", "user")
+	ctx := context.Background()
+
+	link, err := env.LinkStore.Create(ctx, "payroll", "https://hr.example.com/payroll", owner.ID, "", "", "secure")
+	if err != nil {
+		t.Fatalf("create link: %v", err)
+	}
+	if _, err := env.Teams.Create(ctx, "finance", "Finance", ""); err != nil {
+		t.Fatalf("create team: %v", err)
+	}
+
+	do := func(method, path, token, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		authRequest(req, token)
+		rec := httptest.NewRecorder()
+		env.Router.ServeHTTP(rec, req)
+		return rec
+	}
+	adminToken := seedToken(t, env, admin.ID)
+	ownerToken := seedToken(t, env, owner.ID)
+	memberToken := seedToken(t, env, member.ID)
+
+	rec := do("POST", "/admin/teams/finance/members", adminToken, `{"email": "team-member@example.com"}`)
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("add member: status = %d; body: %s", rec.Code, rec.Body.String())
+	}
+	if rec := do("POST", "/admin/teams/finance/members", adminToken, `{"email": "team-member@example.com"}`); rec.Code != http.StatusConflict {
+		t.Errorf("duplicate member: status = %d, want %d", rec.Code, http.StatusConflict)
+	}
+
+	if rec := do("POST", "/links/"+link.ID+"/team-shares", memberToken, `{"team": "finance"}`); rec.Code != http.StatusForbidden {
+		t.Errorf("share as non-owner: status = %d, want %d", rec.Code, http.StatusForbidden)
+	}
+	rec = do("POST", "/links/"+link.ID+"/team-shares", ownerToken, `{"team": "finance"}`)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("share: status = %d; body: %s", rec.Code, rec.Body.String())
+	}
+	var teams []api.TeamResponse
+	if err := json.NewDecoder(rec.Body).Decode(&teams); err != nil || len(teams) != 1 || teams[0].Slug != "finance" {
+		t.Fatalf("share response = %+v, %v; want [finance]", teams, err)
+	}
+	if ok, _ := env.LinkStore.HasShare(ctx, link.ID, member.ID); !ok {
+		t.Error("expected team member to have shared access")
+	}
+
+	if rec := do("POST", "/links/"+link.ID+"/team-owners", ownerToken, `{"team": "nope"}`); rec.Code != http.StatusNotFound {
+		t.Errorf("unknown team: status = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+	if rec := do("POST", "/links/"+link.ID+"/team-owners", ownerToken, `{"team": "finance"}`); rec.Code != http.StatusCreated {
+		t.Fatalf("add team owner: status = %d; body: %s", rec.Code, rec.Body.String())
+	}
+	// Members of a co-owning team manage the link like any other owner.
+	if rec := do("GET", "/links/"+link.ID+"/team-shares", memberToken, ""); rec.Code != http.StatusOK {
+		t.Errorf("list shares as team co-owner: status = %d, want %d", rec.Code, http.StatusOK)
+	}
+
+	if rec := do("DELETE", "/admin/teams/finance/members/"+member.ID, adminToken, ""); rec.Code != http.StatusNoContent {
+		t.Fatalf("remove member: status = %d; body: %s", rec.Code, rec.Body.String())
+	}
+	if rec := do("GET", "/links/"+link.ID+"/team-shares", memberToken, ""); rec.Code != http.StatusForbidden {
+		t.Errorf("list shares after leaving: status = %d, want %d", rec.Code, http.StatusForbidden)
+	}
+	if rec := do("DELETE", "/links/"+link.ID+"/team-shares/finance", ownerToken, ""); rec.Code != http.StatusNoContent {
+		t.Errorf("remove share: status = %d, want %d", rec.Code, http.StatusNoContent)
+	}
+	if rec := do("DELETE", "/links/"+link.ID+"/team-shares/finance", ownerToken, ""); rec.Code != http.StatusNotFound {
+		t.Errorf("remove missing share: status = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+}
@@ -0,0 +1,247 @@
+// Governing: SPEC-0005 REQ "Teams API", SPEC-0002 REQ "Team Ownership"
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/joestump/joe-links/internal/store"
+)
+
+// ListTeams returns every team.
+// GET /api/v1/admin/teams
+// Governing: SPEC-0005 REQ "Teams API"
+//
+// @Summary      List teams (admin)
+// @Description  Returns the teams that can own links, ordered by name. Requires admin role.
+// @Tags         Admin
+// @Produce      json
+// @Success      200  {array}   TeamResponse
+// @Failure      401  {object}  ErrorResponse
+// @Failure      403  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Security     BearerToken
+// @Router       /admin/teams [get]
+func (h *adminAPIHandler) ListTeams(w http.ResponseWriter, r *http.Request) {
+	teams, err := h.teams.List(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
+		return
+	}
+	resp := make([]*TeamResponse, 0, len(teams))
+	for _, t := range teams {
+		resp = append(resp, teamResponse(t))
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// CreateTeam adds a team that links can name as their owner.
+// POST /api/v1/admin/teams
+// Governing: SPEC-0005 REQ "Teams API"
+//
+// @Summary      Create a team (admin)
+// @Description  Adds a team that links can name as their owning team. Requires admin role.
+// @Tags         Admin
+// @Accept       json
+// @Produce      json
+// @Param        body  body      CreateTeamRequest  true  "Team to create"
+// @Success      201   {object}  TeamResponse
+// @Failure      400   {object}  ErrorResponse
+// @Failure      401   {object}  ErrorResponse
+// @Failure      403   {object}  ErrorResponse
+// @Failure      409   {object}  ErrorResponse
+// @Failure      500   {object}  ErrorResponse
+// @Security     BearerToken
+// @Router       /admin/teams [post]
+func (h *adminAPIHandler) CreateTeam(w http.ResponseWriter, r *http.Request) {
+	var req CreateTeamRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body", "BAD_REQUEST")
+		return
+	}
+
+	team, err := h.teams.Create(r.Context(), req.Slug, strings.TrimSpace(req.Name), strings.TrimSpace(req.Contact))
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrSlugInvalid):
+			writeError(w, http.StatusBadRequest, "slug must match [a-z0-9][a-z0-9-]*[a-z0-9]", "INVALID_SLUG")
+		case errors.Is(err, store.ErrTeamNameRequired):
+			writeError(w, http.StatusBadRequest, err.Error(), "BAD_REQUEST")
+		case errors.Is(err, store.ErrSlugTaken):
+			writeError(w, http.StatusConflict, "a team with that slug already exists", "SLUG_CONFLICT")
+		default:
+			writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
+		}
+		return
+	}
+	writeJSON(w, http.StatusCreated, teamResponse(team))
+}
+
+// DeleteTeam removes a team; links it owned fall back to their primary owner.
+// DELETE /api/v1/admin/teams/{slug}
+// Governing: SPEC-0005 REQ "Teams API"
+//
+// @Summary      Delete a team (admin)
+// @Description  Removes a team and clears it from the links it owned. Requires admin role.
+// @Tags         Admin
+// @Produce      json
+// @Param        slug  path  string  true  "Team slug"
+// @Success      204   "No Content"
+// @Failure      401   {object}  ErrorResponse
+// @Failure      403   {object}  ErrorResponse
+// @Failure      404   {object}  ErrorResponse
+// @Failure      500   {object}  ErrorResponse
+// @Security     BearerToken
+// @Router       /admin/teams/{slug} [delete]
+func (h *adminAPIHandler) DeleteTeam(w http.ResponseWriter, r *http.Request) {
+	if err := h.teams.Delete(r.Context(), chi.URLParam(r, "slug")); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			writeError(w, http.StatusNotFound, "team not found", "NOT_FOUND")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListTeamMembers returns the members of a team.
+// GET /api/v1/admin/teams/{slug}/members
+// Governing: SPEC-0010 REQ "Team Shares and Ownership"
+//
+// @Summary      List team members (admin)
+// @Description  Returns the users in a team, who hold every share and co-ownership granted to it. Requires admin role.
+// @Tags         Admin
+// @Produce      json
+// @Param        slug  path      string  true  "Team slug"
+// @Success      200   {array}   UserResponse
+// @Failure      401   {object}  ErrorResponse
+// @Failure      403   {object}  ErrorResponse
+// @Failure      404   {object}  ErrorResponse
+// @Failure      500   {object}  ErrorResponse
+// @Security     BearerToken
+// @Router       /admin/teams/{slug}/members [get]
+func (h *adminAPIHandler) ListTeamMembers(w http.ResponseWriter, r *http.Request) {
+	team, ok := h.team(w, r)
+	if !ok {
+		return
+	}
+	members, err := h.teams.ListTeamMembers(r.Context(), team.ID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
+		return
+	}
+	resp := make([]*UserResponse, 0, len(members))
+	for _, u := range members {
+		resp = append(resp, &UserResponse{ID: u.ID, Email: u.Email, DisplayName: u.DisplayName, Role: u.Role, CreatedAt: u.CreatedAt})
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// AddTeamMember adds a user to a team by email.
+// POST /api/v1/admin/teams/{slug}/members
+// Governing: SPEC-0010 REQ "Team Shares and Ownership"
+//
+// @Summary      Add a team member (admin)
+// @Description  Adds a user to a team by email address. Requires admin role.
+// @Tags         Admin
+// @Accept       json
+// @Produce      json
+// @Param        slug  path      string                true  "Team slug"
+// @Param        body  body      AddTeamMemberRequest  true  "User to add"
+// @Success      204   "No Content"
+// @Failure      400   {object}  ErrorResponse
+// @Failure      401   {object}  ErrorResponse
+// @Failure      403   {object}  ErrorResponse
+// @Failure      404   {object}  ErrorResponse
+// @Failure      409   {object}  ErrorResponse
+// @Failure      500   {object}  ErrorResponse
+// @Security     BearerToken
+// @Router       /admin/teams/{slug}/members [post]
+func (h *adminAPIHandler) AddTeamMember(w http.ResponseWriter, r *http.Request) {
+	team, ok := h.team(w, r)
+	if !ok {
+		return
+	}
+	var req AddTeamMemberRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body", "BAD_REQUEST")
+		return
+	}
+	if req.Email == "" {
+		writeError(w, http.StatusBadRequest, "email is required", "BAD_REQUEST")
+		return
+	}
+	user, err := h.users.GetByEmail(r.Context(), req.Email)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			writeError(w, http.StatusNotFound, "user not found", "NOT_FOUND")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
+		return
+	}
+	if err := h.teams.AddMember(r.Context(), team.ID, user.ID); err != nil {
+		if errors.Is(err, store.ErrAlreadyMember) {
+			writeError(w, http.StatusConflict, "user is already a member of this team", "DUPLICATE_MEMBER")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RemoveTeamMember removes a user from a team.
+// DELETE /api/v1/admin/teams/{slug}/members/{uid}
+// Governing: SPEC-0010 REQ "Team Shares and Ownership"
+//
+// @Summary      Remove a team member (admin)
+// @Description  Removes a user from a team; they lose the shares and co-ownership granted to it. Requires admin role.
+// @Tags         Admin
+// @Param        slug  path      string  true  "Team slug"
+// @Param        uid   path      string  true  "User ID"
+// @Success      204   "No Content"
+// @Failure      401   {object}  ErrorResponse
+// @Failure      403   {object}  ErrorResponse
+// @Failure      404   {object}  ErrorResponse
+// @Failure      500   {object}  ErrorResponse
+// @Security     BearerToken
+// @Router       /admin/teams/{slug}/members/{uid} [delete]
+func (h *adminAPIHandler) RemoveTeamMember(w http.ResponseWriter, r *http.Request) {
+	team, ok := h.team(w, r)
+	if !ok {
+		return
+	}
+	if err := h.teams.RemoveMember(r.Context(), team.ID, chi.URLParam(r, "uid")); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			writeError(w, http.StatusNotFound, "member not found", "NOT_FOUND")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// team loads the team named by the {slug} URL parameter, writing a 404 or
+// 500 and returning false when it cannot.
+func (h *adminAPIHandler) team(w http.ResponseWriter, r *http.Request) (*store.Team, bool) {
+	team, err := h.teams.GetBySlug(r.Context(), chi.URLParam(r, "slug"))
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			writeError(w, http.StatusNotFound, "team not found", "NOT_FOUND")
+			return nil, false
+		}
+		writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
+		return nil, false
+	}
+	return team, true
+}
+
+func teamResponse(t *store.Team) *TeamResponse {
+	return &TeamResponse{Slug: t.Slug, Name: t.Name, Contact: t.Contact}
+}
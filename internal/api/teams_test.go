@@ -0,0 +1,69 @@
+// Governing: SPEC-0005 REQ "Teams API", SPEC-0002 REQ "Team Ownership"
+package api_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/joestump/joe-links/internal/api"
+)
+
+func TestTeams(t *testing.T) {
+	env := newTestEnv(t)
+	admin := seedUser(t, env, "admin@example.com", "admin")
+	user := seedUser(t, env, "user@example.com", "user")
+	adminToken := seedToken(t, env, admin.ID)
+	userToken := seedToken(t, env, user.ID)
+
+	do := func(token, method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		authRequest(req, token)
+		rec := httptest.NewRecorder()
+		env.Router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	if rec := do(userToken, "POST", "/admin/teams", `{"slug":"sre","name":"SRE"}`); rec.Code != http.StatusForbidden {
+		t.Errorf("non-admin create: status = %d, want 403", rec.Code)
+	}
+	rec := do(adminToken, "POST", "/admin/teams", `{"slug":"sre","name":"SRE","contact":"#sre-oncall"}`)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("create team: status = %d; body: %s", rec.Code, rec.Body.String())
+	}
+	if rec := do(adminToken, "POST", "/admin/teams", `{"slug":"sre","name":"Again"}`); rec.Code != http.StatusConflict {
+		t.Errorf("duplicate team: status = %d, want 409", rec.Code)
+	}
+
+	// Any link owner can name a team; unknown teams are rejected.
+	rec = do(userToken, "POST", "/links", `{"slug":"runbooks","url":"https://runbooks.example.com","team":"sre"}`)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("create link: status = %d; body: %s", rec.Code, rec.Body.String())
+	}
+	var link api.LinkResponse
+	if err := json.NewDecoder(rec.Body).Decode(&link); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if link.Team == nil || link.Team.Slug != "sre" || link.Team.Contact != "#sre-oncall" {
+		t.Errorf("link team = %+v, want sre", link.Team)
+	}
+	if rec := do(userToken, "POST", "/links", `{"slug":"other","url":"https://example.com","team":"nope"}`); rec.Code != http.StatusBadRequest {
+		t.Errorf("unknown team: status = %d, want 400", rec.Code)
+	}
+
+	// Deleting the team clears it from the link.
+	if rec := do(adminToken, "DELETE", "/admin/teams/sre", ""); rec.Code != http.StatusNoContent {
+		t.Fatalf("delete team: status = %d", rec.Code)
+	}
+	rec = do(userToken, "GET", "/links/"+link.ID, "")
+	var after api.LinkResponse
+	if err := json.NewDecoder(rec.Body).Decode(&after); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if after.Team != nil {
+		t.Errorf("link team after delete = %+v, want none", after.Team)
+	}
+}
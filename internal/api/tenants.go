@@ -0,0 +1,127 @@
+// Governing: SPEC-0001 REQ "Multi-Tenancy"
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/joestump/joe-links/internal/store"
+)
+
+// requireDefaultTenant limits a route to requests served by the default
+// tenant, so admins of one tenant cannot create or remove others.
+func requireDefaultTenant(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if tenantID, _ := store.TenantFromContext(r.Context()); tenantID != "" {
+			writeError(w, http.StatusForbidden, "tenants are managed from the default hostname", "FORBIDDEN")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// ListTenants returns every tenant.
+// GET /api/v1/admin/tenants
+// Governing: SPEC-0001 REQ "Multi-Tenancy"
+//
+// @Summary      List tenants (admin)
+// @Description  Returns the hostnames served as separate link namespaces, ordered by hostname. Requires admin role on the default hostname.
+// @Tags         Admin
+// @Produce      json
+// @Success      200  {array}   TenantResponse
+// @Failure      401  {object}  ErrorResponse
+// @Failure      403  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Security     BearerToken
+// @Router       /admin/tenants [get]
+func (h *adminAPIHandler) ListTenants(w http.ResponseWriter, r *http.Request) {
+	tenants, err := h.tenants.List(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
+		return
+	}
+	resp := make([]*TenantResponse, 0, len(tenants))
+	for _, t := range tenants {
+		resp = append(resp, tenantResponse(t))
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// CreateTenant starts serving a separate link namespace on a hostname.
+// POST /api/v1/admin/tenants
+// Governing: SPEC-0001 REQ "Multi-Tenancy"
+//
+// @Summary      Create a tenant (admin)
+// @Description  Serves a separate namespace of links, keywords, and users on a hostname. Requires admin role on the default hostname.
+// @Tags         Admin
+// @Accept       json
+// @Produce      json
+// @Param        body  body      CreateTenantRequest  true  "Tenant to create"
+// @Success      201   {object}  TenantResponse
+// @Failure      400   {object}  ErrorResponse
+// @Failure      401   {object}  ErrorResponse
+// @Failure      403   {object}  ErrorResponse
+// @Failure      409   {object}  ErrorResponse
+// @Failure      500   {object}  ErrorResponse
+// @Security     BearerToken
+// @Router       /admin/tenants [post]
+func (h *adminAPIHandler) CreateTenant(w http.ResponseWriter, r *http.Request) {
+	var req CreateTenantRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body", "BAD_REQUEST")
+		return
+	}
+
+	tenant, err := h.tenants.Create(r.Context(), req.Hostname, strings.TrimSpace(req.Name))
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrTenantHostnameRequired):
+			writeError(w, http.StatusBadRequest, err.Error(), "BAD_REQUEST")
+		case errors.Is(err, store.ErrTenantHostnameTaken):
+			writeError(w, http.StatusConflict, err.Error(), "TENANT_CONFLICT")
+		default:
+			writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
+		}
+		return
+	}
+	writeJSON(w, http.StatusCreated, tenantResponse(tenant))
+}
+
+// DeleteTenant stops serving a tenant's hostname.
+// DELETE /api/v1/admin/tenants/{id}
+// Governing: SPEC-0001 REQ "Multi-Tenancy"
+//
+// @Summary      Delete a tenant (admin)
+// @Description  Removes a tenant that has no links or users left; its hostname falls back to the default tenant. Requires admin role on the default hostname.
+// @Tags         Admin
+// @Produce      json
+// @Param        id  path  string  true  "Tenant ID"
+// @Success      204  "No Content"
+// @Failure      401  {object}  ErrorResponse
+// @Failure      403  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      409  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Security     BearerToken
+// @Router       /admin/tenants/{id} [delete]
+func (h *adminAPIHandler) DeleteTenant(w http.ResponseWriter, r *http.Request) {
+	if err := h.tenants.Delete(r.Context(), chi.URLParam(r, "id")); err != nil {
+		switch {
+		case errors.Is(err, store.ErrNotFound):
+			writeError(w, http.StatusNotFound, "tenant not found", "NOT_FOUND")
+		case errors.Is(err, store.ErrTenantInUse):
+			writeError(w, http.StatusConflict, err.Error(), "TENANT_IN_USE")
+		default:
+			writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
+		}
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func tenantResponse(t *store.Tenant) *TenantResponse {
+	return &TenantResponse{ID: t.ID, Hostname: t.Hostname, Name: t.Name, CreatedAt: t.CreatedAt}
+}
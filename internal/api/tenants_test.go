@@ -0,0 +1,67 @@
+// Governing: SPEC-0001 REQ "Multi-Tenancy"
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/joestump/joe-links/internal/api"
+	"github.com/joestump/joe-links/internal/store"
+)
+
+func TestTenantsAPI(t *testing.T) {
+	env := newTestEnv(t)
+	admin := seedUser(t, env, "tenant-admin@example.com", "admin")
+	adminToken := seedToken(t, env, admin.ID)
+
+	do := func(ctx context.Context, method, path, token, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body)).WithContext(ctx)
+		authRequest(req, token)
+		rec := httptest.NewRecorder()
+		env.Router.ServeHTTP(rec, req)
+		return rec
+	}
+	def := store.WithTenant(context.Background(), "")
+
+	rec := do(def, "POST", "/admin/tenants", adminToken, `{"hostname": "go.acme.example", "name": "Acme"}`)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("create: status = %d; body: %s", rec.Code, rec.Body.String())
+	}
+	var acme api.TenantResponse
+	if err := json.NewDecoder(rec.Body).Decode(&acme); err != nil || acme.Hostname != "go.acme.example" || acme.Name != "Acme" {
+		t.Fatalf("create response = %+v, %v", acme, err)
+	}
+	if rec := do(def, "POST", "/admin/tenants", adminToken, `{"hostname": "GO.ACME.EXAMPLE"}`); rec.Code != http.StatusConflict {
+		t.Errorf("duplicate: status = %d, want %d", rec.Code, http.StatusConflict)
+	}
+	rec = do(def, "GET", "/admin/tenants", adminToken, "")
+	var list []api.TenantResponse
+	if err := json.NewDecoder(rec.Body).Decode(&list); err != nil || len(list) != 1 || list[0].ID != acme.ID {
+		t.Fatalf("list = %+v, %v; want [acme]", list, err)
+	}
+
+	// Credentials only work on their own tenant's hostname, and tenant admins
+	// cannot manage tenants.
+	acmeCtx := store.WithTenant(context.Background(), acme.ID)
+	if rec := do(acmeCtx, "GET", "/admin/tenants", adminToken, ""); rec.Code != http.StatusUnauthorized {
+		t.Errorf("default token on acme: status = %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+	acmeAdmin, err := env.UserStore.Upsert(acmeCtx, "test", "acme-admin", "admin@acme.example", "Acme Admin", "admin")
+	if err != nil {
+		t.Fatalf("seed acme admin: %v", err)
+	}
+	if rec := do(acmeCtx, "GET", "/admin/tenants", seedToken(t, env, acmeAdmin.ID), ""); rec.Code != http.StatusForbidden {
+		t.Errorf("acme admin: status = %d, want %d", rec.Code, http.StatusForbidden)
+	}
+
+	if rec := do(def, "DELETE", "/admin/tenants/"+acme.ID, adminToken, ""); rec.Code != http.StatusConflict {
+		t.Errorf("delete in use: status = %d, want %d", rec.Code, http.StatusConflict)
+	}
+	if rec := do(def, "DELETE", "/admin/tenants/missing", adminToken, ""); rec.Code != http.StatusNotFound {
+		t.Errorf("delete missing: status = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+}
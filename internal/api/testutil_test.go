@@ -4,9 +4,12 @@ import (
 	"context"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/joestump/joe-links/internal/api"
 	"github.com/joestump/joe-links/internal/auth"
+	"github.com/joestump/joe-links/internal/shareurl"
+	"github.com/joestump/joe-links/internal/status"
 	"github.com/joestump/joe-links/internal/store"
 	"github.com/joestump/joe-links/internal/testutil"
 )
@@ -14,12 +17,39 @@ import (
 // testEnv holds all stores and helpers needed for API integration tests.
 type testEnv struct {
 	Router         http.Handler
+	Deps           api.Deps // for serving other transports, e.g. gRPC
 	LinkStore      *store.LinkStore
 	TagStore       *store.TagStore
 	OwnershipStore *store.OwnershipStore
 	UserStore      *store.UserStore
 	TokenStore     *auth.SQLTokenStore
 	ClickStore     *store.ClickStore
+	HealthStore    *store.HealthStore
+	ReservedSlugs  *store.ReservedSlugStore
+	Teams          *store.TeamStore
+	UsageStore     *store.UsageStore
+	UsageRecorder  *api.UsageRecorder
+	ResolveTester  *fakeResolveTester
+	Policies       *store.PolicyStore
+	DomainRules    *store.DomainRuleStore
+	ShareURLs      *shareurl.Signer
+	Tenants        *store.TenantStore
+}
+
+// fakeResolveTester records the user it was asked to resolve as and answers
+// with resp, or not found when resp is nil.
+type fakeResolveTester struct {
+	user *store.User
+	path string
+	resp *api.ResolveTestResponse
+}
+
+func (f *fakeResolveTester) TestResolve(_ context.Context, path, _ string, user *store.User) *api.ResolveTestResponse {
+	f.user, f.path = user, path
+	if f.resp != nil {
+		return f.resp
+	}
+	return &api.ResolveTestResponse{Path: path, Outcome: api.ResolveOutcomeNotFound, Status: http.StatusNotFound, Trace: []string{}}
 }
 
 // newTestEnv creates an in-memory SQLite test database, runs migrations,
@@ -34,28 +64,63 @@ func newTestEnv(t *testing.T) *testEnv {
 	us := store.NewUserStore(db)
 	ts := auth.NewSQLTokenStore(db)
 	cs := store.NewClickStore(db)
+	hs := store.NewHealthStore(db)
+	rs := store.NewReservedSlugStore(db)
+	teams := store.NewTeamStore(db)
+	searches := store.NewSavedSearchStore(db)
+	policies := store.NewPolicyStore(db, ls)
+	domains := store.NewDomainRuleStore(db)
+	usage := store.NewUsageStore(db)
+	recorder := api.NewUsageRecorder(usage)
+	resolver := &fakeResolveTester{}
+	shareURLs := shareurl.NewSigner([]byte("test key"))
+	tenants := store.NewTenantStore(db)
 
 	bearerMW := auth.NewBearerTokenMiddleware(ts, us)
 
 	deps := api.Deps{
-		BearerMiddleware: bearerMW,
-		TokenStore:       ts,
-		LinkStore:        ls,
-		OwnershipStore:   owns,
-		TagStore:         tags,
-		UserStore:        us,
-		ClickStore:       cs,
+		BearerMiddleware:  bearerMW,
+		TokenStore:        ts,
+		LinkStore:         ls,
+		OwnershipStore:    owns,
+		TagStore:          tags,
+		UserStore:         us,
+		ClickStore:        cs,
+		HealthStore:       hs,
+		ReservedSlugStore: rs,
+		TeamStore:         teams,
+		SavedSearchStore:  searches,
+		PolicyStore:       policies,
+		DomainRuleStore:   domains,
+		UsageStore:        usage,
+		UsageRecorder:     recorder,
+		ResolveTester:     resolver,
+		ShareURLs:         shareURLs,
+		TenantStore:       tenants,
+		IdempotencyStore:  store.NewIdempotencyStore(db),
+		StatusChecker:     status.NewChecker(db, status.Job{Name: "test_job", Interval: time.Minute}),
 	}
 
 	router := api.NewAPIRouter(deps)
 	return &testEnv{
 		Router:         router,
+		Deps:           deps,
 		LinkStore:      ls,
 		TagStore:       tags,
 		OwnershipStore: owns,
 		UserStore:      us,
 		TokenStore:     ts,
 		ClickStore:     cs,
+		HealthStore:    hs,
+		ReservedSlugs:  rs,
+		Teams:          teams,
+		UsageStore:     usage,
+		UsageRecorder:  recorder,
+		ResolveTester:  resolver,
+		Policies:       policies,
+		DomainRules:    domains,
+		ShareURLs:      shareURLs,
+		Tenants:        tenants,
 	}
 }
 
@@ -83,13 +148,26 @@ func seedToken(t *testing.T, env *testEnv, userID string) string {
 	if err != nil {
 		t.Fatalf("generate token: %v", err)
 	}
-	_, err = env.TokenStore.Create(context.Background(), userID, "test-token", hash, nil)
+	_, err = env.TokenStore.Create(context.Background(), userID, "test-token", hash, nil, nil)
 	if err != nil {
 		t.Fatalf("create token: %v", err)
 	}
 	return plaintext
 }
 
+// seedScopedToken creates an API token limited to scopes and returns the plaintext Bearer value.
+func seedScopedToken(t *testing.T, env *testEnv, userID string, scopes ...string) string {
+	t.Helper()
+	plaintext, hash, err := auth.GenerateToken()
+	if err != nil {
+		t.Fatalf("generate token: %v", err)
+	}
+	if _, err := env.TokenStore.Create(context.Background(), userID, "scoped-token", hash, scopes, nil); err != nil {
+		t.Fatalf("create token: %v", err)
+	}
+	return plaintext
+}
+
 // authRequest adds a Bearer token to the request.
 func authRequest(r *http.Request, token string) *http.Request {
 	r.Header.Set("Authorization", "Bearer "+token)
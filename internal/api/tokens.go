@@ -4,6 +4,7 @@ package api
 import (
 	"encoding/json"
 	"net/http"
+	"slices"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/joestump/joe-links/internal/auth"
@@ -57,6 +58,7 @@ func (h *tokensAPIHandler) List(w http.ResponseWriter, r *http.Request) {
 		item := &TokenResponse{
 			ID:        rec.ID,
 			Name:      rec.Name,
+			Scopes:    rec.ScopeList(),
 			CreatedAt: rec.CreatedAt,
 		}
 		if rec.LastUsedAt.Valid {
@@ -79,6 +81,7 @@ func (h *tokensAPIHandler) List(w http.ResponseWriter, r *http.Request) {
 //
 // @Summary      Create a token
 // @Description  Generates a new API token. The plaintext token is returned only in this response.
+// @Description  Scopes (links:read, links:write, admin) default to all of the caller's scopes; a token cannot grant scopes its creator lacks.
 // @Tags         Tokens
 // @Accept       json
 // @Produce      json
@@ -86,6 +89,7 @@ func (h *tokensAPIHandler) List(w http.ResponseWriter, r *http.Request) {
 // @Success      201   {object}  TokenCreatedResponse
 // @Failure      400   {object}  ErrorResponse
 // @Failure      401   {object}  ErrorResponse
+// @Failure      403   {object}  ErrorResponse
 // @Failure      500   {object}  ErrorResponse
 // @Security     BearerToken
 // @Router       /tokens [post]
@@ -102,17 +106,38 @@ func (h *tokensAPIHandler) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	if req.Name == "" {
-		writeError(w, http.StatusBadRequest, "name is required", "bad_request")
+		writeRequiredField(w, "name", "bad_request")
 		return
 	}
 
+	// A token may only mint tokens with a subset of its own scopes.
+	// Governing: SPEC-0006 REQ "Token Scopes"
+	requested := req.Scopes
+	callerScopes, scoped := auth.ScopesFromContext(r.Context())
+	if len(requested) == 0 && scoped {
+		requested = callerScopes
+	}
+	scopes, err := auth.ParseScopes(requested)
+	if err != nil {
+		writeFieldError(w, http.StatusBadRequest, "scopes", err.Error(), "bad_request")
+		return
+	}
+	if scoped {
+		for _, s := range scopes {
+			if !slices.Contains(callerScopes, s) {
+				writeError(w, http.StatusForbidden, "cannot grant scope "+s+" not held by the current token", "INSUFFICIENT_SCOPE")
+				return
+			}
+		}
+	}
+
 	plaintext, hash, err := auth.GenerateToken()
 	if err != nil {
 		writeError(w, http.StatusInternalServerError, "token generation failed", "internal_error")
 		return
 	}
 
-	rec, err := h.tokens.Create(r.Context(), user.ID, req.Name, hash, req.ExpiresAt)
+	rec, err := h.tokens.Create(r.Context(), user.ID, req.Name, hash, scopes, req.ExpiresAt)
 	if err != nil {
 		writeError(w, http.StatusInternalServerError, "token creation failed", "internal_error")
 		return
@@ -121,6 +146,7 @@ func (h *tokensAPIHandler) Create(w http.ResponseWriter, r *http.Request) {
 	item := &TokenResponse{
 		ID:        rec.ID,
 		Name:      rec.Name,
+		Scopes:    rec.ScopeList(),
 		CreatedAt: rec.CreatedAt,
 	}
 	if rec.ExpiresAt.Valid {
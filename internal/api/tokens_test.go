@@ -19,7 +19,7 @@ func TestTokens_List_OK(t *testing.T) {
 
 	// Create an additional token for the user so there are at least 2.
 	_, hash2, _ := auth.GenerateToken()
-	_, err := env.TokenStore.Create(context.Background(), user.ID, "second-token", hash2, nil)
+	_, err := env.TokenStore.Create(context.Background(), user.ID, "second-token", hash2, nil, nil)
 	if err != nil {
 		t.Fatalf("create second token: %v", err)
 	}
@@ -97,7 +97,14 @@ func TestTokens_Create_MissingName(t *testing.T) {
 	env.Router.ServeHTTP(rec, req)
 
 	if rec.Code != http.StatusBadRequest {
-		t.Errorf("status = %d, want %d; body: %s", rec.Code, http.StatusBadRequest, rec.Body.String())
+		t.Fatalf("status = %d, want %d; body: %s", rec.Code, http.StatusBadRequest, rec.Body.String())
+	}
+	var resp api.ErrorResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(resp.Errors) != 1 || resp.Errors[0].Field != "name" || resp.Errors[0].Code != "REQUIRED" {
+		t.Errorf("errors = %+v, want name REQUIRED", resp.Errors)
 	}
 }
 
@@ -108,7 +115,7 @@ func TestTokens_Revoke_NoContent(t *testing.T) {
 
 	// Create a token to revoke.
 	_, hash, _ := auth.GenerateToken()
-	rec2, err := env.TokenStore.Create(context.Background(), user.ID, "revoke-me", hash, nil)
+	rec2, err := env.TokenStore.Create(context.Background(), user.ID, "revoke-me", hash, nil, nil)
 	if err != nil {
 		t.Fatalf("create token: %v", err)
 	}
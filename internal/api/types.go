@@ -4,9 +4,18 @@ package api
 import "time"
 
 // ErrorResponse is the standard error shape.
+// Errors lists the request fields that failed validation, if any.
 type ErrorResponse struct {
-	Error string `json:"error"`
-	Code  string `json:"code"`
+	Error  string       `json:"error"`
+	Code   string       `json:"code"`
+	Errors []FieldError `json:"errors,omitempty"`
+}
+
+// FieldError identifies a single request field that failed validation.
+type FieldError struct {
+	Field   string `json:"field" example:"slug"`
+	Code    string `json:"code" example:"INVALID_SLUG"`
+	Message string `json:"message" example:"slug must match [a-z0-9][a-z0-9-]*[a-z0-9]"`
 }
 
 // OwnerResponse represents a link owner.
@@ -24,18 +33,48 @@ type LinkResponse struct {
 	URL         string          `json:"url"`
 	Title       string          `json:"title"`
 	Description string          `json:"description"`
-	Visibility  string          `json:"visibility"`
+	Visibility  string          `json:"visibility" enums:"public,private,secure"`
 	Tags        []string        `json:"tags"`
 	Owners      []OwnerResponse `json:"owners"`
 	CreatedAt   time.Time       `json:"created_at"`
 	UpdatedAt   time.Time       `json:"updated_at"`
+
+	// VariableConstraints maps variable names to the regex their segments must match.
+	// Governing: SPEC-0009 REQ "Variable Constraints"
+	VariableConstraints map[string]string `json:"variable_constraints,omitempty"`
+
+	// RedirectType is the HTTP status the link redirects with (301, 302, 307, or 308).
+	// Governing: SPEC-0002 REQ "Redirect Type"
+	RedirectType int `json:"redirect_type"`
+
+	// UTMParams are the utm_* parameters appended to the target on redirect.
+	// Governing: SPEC-0002 REQ "UTM Parameters"
+	UTMParams map[string]string `json:"utm_params,omitempty"`
+
+	// PassQuery forwards the incoming query string to the target URL.
+	// Governing: SPEC-0009 REQ "Query-String Passthrough"
+	PassQuery bool `json:"pass_query"`
+
+	// Team is the owning team, shown in place of the primary owner; omitted when unset.
+	// Governing: SPEC-0002 REQ "Team Ownership"
+	Team *TeamResponse `json:"team,omitempty"`
+
+	// PendingReview is true while a public link awaits admin approval; it
+	// resolves but is not listed publicly.
+	// Governing: SPEC-0011 REQ "Public Link Moderation"
+	PendingReview bool `json:"pending_review"`
+
+	// CreatedBy is the ID of the user who created the link. Unlike the
+	// primary owner it never changes; empty for links whose creator is unknown.
+	// Governing: SPEC-0002 REQ "Link Creator Attribution"
+	CreatedBy string `json:"created_by"`
 }
 
 // LinkListResponse wraps a paginated list of links.
 // Governing: SPEC-0005 REQ "Pagination"
 type LinkListResponse struct {
 	Links      []*LinkResponse `json:"links"`
-	NextCursor *string         `json:"next_cursor"`
+	NextCursor *string         `json:"next_cursor" extensions:"x-nullable"`
 }
 
 // CreateLinkRequest is the body for POST /api/v1/links.
@@ -45,8 +84,28 @@ type CreateLinkRequest struct {
 	URL         string   `json:"url"`
 	Title       string   `json:"title,omitempty"`
 	Description string   `json:"description,omitempty"`
-	Visibility  string   `json:"visibility,omitempty"`
+	Visibility  string   `json:"visibility,omitempty" enums:"public,private,secure"`
 	Tags        []string `json:"tags,omitempty"`
+
+	// VariableConstraints maps variable names (without $) to a regex each
+	// substituted value must fully match. Governing: SPEC-0009 REQ "Variable Constraints"
+	VariableConstraints map[string]string `json:"variable_constraints,omitempty"`
+
+	// RedirectType is the HTTP status the link redirects with: 301, 302, 307,
+	// or 308. Defaults to 302. Governing: SPEC-0002 REQ "Redirect Type"
+	RedirectType int `json:"redirect_type,omitempty"`
+
+	// UTMParams maps utm_source, utm_medium, utm_campaign, utm_term, or
+	// utm_content to a value appended to the target on redirect.
+	// Governing: SPEC-0002 REQ "UTM Parameters"
+	UTMParams map[string]string `json:"utm_params,omitempty"`
+
+	// PassQuery forwards the incoming query string to the target URL.
+	// Governing: SPEC-0009 REQ "Query-String Passthrough"
+	PassQuery bool `json:"pass_query,omitempty"`
+
+	// Team is the slug of the owning team. Governing: SPEC-0002 REQ "Team Ownership"
+	Team string `json:"team,omitempty"`
 }
 
 // UpdateLinkRequest is the body for PUT /api/v1/links/{id}.
@@ -56,8 +115,46 @@ type UpdateLinkRequest struct {
 	URL         string   `json:"url"`
 	Title       string   `json:"title,omitempty"`
 	Description string   `json:"description,omitempty"`
-	Visibility  string   `json:"visibility,omitempty"`
+	Visibility  string   `json:"visibility,omitempty" enums:"public,private,secure"`
 	Tags        []string `json:"tags,omitempty"`
+
+	// VariableConstraints maps variable names (without $) to a regex each
+	// substituted value must fully match. Governing: SPEC-0009 REQ "Variable Constraints"
+	VariableConstraints map[string]string `json:"variable_constraints,omitempty"`
+
+	// RedirectType is the HTTP status the link redirects with: 301, 302, 307,
+	// or 308. Omitted keeps the current value. Governing: SPEC-0002 REQ "Redirect Type"
+	RedirectType int `json:"redirect_type,omitempty"`
+
+	// UTMParams maps utm_source, utm_medium, utm_campaign, utm_term, or
+	// utm_content to a value appended to the target on redirect.
+	// Governing: SPEC-0002 REQ "UTM Parameters"
+	UTMParams map[string]string `json:"utm_params,omitempty"`
+
+	// PassQuery forwards the incoming query string to the target URL.
+	// Omitted keeps the current setting. Governing: SPEC-0009 REQ "Query-String Passthrough"
+	PassQuery *bool `json:"pass_query,omitempty"`
+
+	// Team is the slug of the owning team. Omitted keeps the current team;
+	// "" clears it. Governing: SPEC-0002 REQ "Team Ownership"
+	Team *string `json:"team,omitempty"`
+}
+
+// PatchLinkRequest is the body for PATCH /api/v1/links/{id}. Absent fields
+// keep their current values; null clears a field or, for visibility,
+// redirect_type, and pass_query, restores its default. url cannot be null.
+// Governing: SPEC-0005 REQ "Link Resource"
+type PatchLinkRequest struct {
+	URL                 Optional[string]            `json:"url" swaggertype:"string"`
+	Title               Optional[string]            `json:"title" swaggertype:"string" extensions:"x-nullable"`
+	Description         Optional[string]            `json:"description" swaggertype:"string" extensions:"x-nullable"`
+	Visibility          Optional[string]            `json:"visibility" swaggertype:"string" enums:"public,private,secure" extensions:"x-nullable"`
+	Tags                Optional[[]string]          `json:"tags" swaggertype:"array,string" extensions:"x-nullable"`
+	VariableConstraints Optional[map[string]string] `json:"variable_constraints" swaggertype:"object,string" extensions:"x-nullable"`
+	RedirectType        Optional[int]               `json:"redirect_type" swaggertype:"integer" enums:"301,302,307,308" extensions:"x-nullable"`
+	UTMParams           Optional[map[string]string] `json:"utm_params" swaggertype:"object,string" extensions:"x-nullable"`
+	PassQuery           Optional[bool]              `json:"pass_query" swaggertype:"boolean" extensions:"x-nullable"`
+	Team                Optional[string]            `json:"team" swaggertype:"string" extensions:"x-nullable"` // null or "" clears the team
 }
 
 // AddOwnerRequest is the body for POST /api/v1/links/{id}/owners.
@@ -72,6 +169,118 @@ type AddShareRequest struct {
 	Email string `json:"email"`
 }
 
+// CreateShareURLRequest is the optional body for POST /api/v1/links/{id}/share-url.
+// Governing: SPEC-0010 REQ "Signed Share URLs"
+type CreateShareURLRequest struct {
+	// ExpiresIn is the URL's lifetime in seconds; 0 means 24 hours, and the
+	// most allowed is 30 days.
+	ExpiresIn int64 `json:"expires_in,omitempty" example:"3600"`
+}
+
+// ShareURLResponse is a signed, expiring URL that opens a secure link.
+// Governing: SPEC-0010 REQ "Signed Share URLs"
+type ShareURLResponse struct {
+	URL       string    `json:"url" example:"https://go.example.com/s/payroll?exp=1735689600&sig=3q2-7wQy1ZlTn9xTqHZ0Xw"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// LinkPreviewResponse is the hover-card summary of a short link.
+// Governing: SPEC-0005 REQ "Link Preview Endpoint"
+type LinkPreviewResponse struct {
+	Slug        string `json:"slug"` // primary slug, even when previewed by alias
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Domain      string `json:"domain"`    // host name of the destination, e.g. "jira.example.com"
+	Templated   bool   `json:"templated"` // destination contains $variables
+}
+
+// AddAliasRequest is the body for POST /api/v1/links/{id}/aliases.
+// Governing: SPEC-0005 REQ "Link Aliases API"
+type AddAliasRequest struct {
+	Slug string `json:"slug"`
+}
+
+// AliasResponse is an additional slug that resolves to a link.
+// Governing: SPEC-0005 REQ "Link Aliases API"
+type AliasResponse struct {
+	Slug      string    `json:"slug"`
+	LinkID    string    `json:"link_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// AddReservedSlugRequest is the body for POST /api/v1/admin/reserved-slugs.
+// Governing: SPEC-0005 REQ "Reserved Slugs API"
+type AddReservedSlugRequest struct {
+	Slug   string `json:"slug"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// ReservedSlugResponse is a slug that no link or alias may use.
+// Governing: SPEC-0005 REQ "Reserved Slugs API"
+type ReservedSlugResponse struct {
+	Slug      string     `json:"slug"`
+	Reason    string     `json:"reason"`
+	BuiltIn   bool       `json:"built_in"`
+	CreatedAt *time.Time `json:"created_at,omitempty"` // unset for built-in slugs
+}
+
+// RenameTagRequest is the body for PUT /api/v1/admin/tags/{slug}.
+// Governing: SPEC-0005 REQ "Tag Administration API"
+type RenameTagRequest struct {
+	Name string `json:"name"`
+}
+
+// MergeTagRequest is the body for POST /api/v1/admin/tags/{slug}/merge.
+// Governing: SPEC-0005 REQ "Tag Administration API"
+type MergeTagRequest struct {
+	Into string `json:"into"` // slug of the tag that survives
+}
+
+// CreateTeamRequest is the body for POST /api/v1/admin/teams.
+// Governing: SPEC-0005 REQ "Teams API"
+type CreateTeamRequest struct {
+	Slug    string `json:"slug"`
+	Name    string `json:"name"`
+	Contact string `json:"contact,omitempty"` // email, chat channel, or URL
+}
+
+// TeamResponse is a team that can own links.
+// Governing: SPEC-0005 REQ "Teams API", SPEC-0002 REQ "Team Ownership"
+type TeamResponse struct {
+	Slug    string `json:"slug"`
+	Name    string `json:"name"`
+	Contact string `json:"contact"`
+}
+
+// CreateTenantRequest is the body for POST /api/v1/admin/tenants.
+// Governing: SPEC-0001 REQ "Multi-Tenancy"
+type CreateTenantRequest struct {
+	Hostname string `json:"hostname"`       // e.g. go.subsidiary.example
+	Name     string `json:"name,omitempty"` // defaults to the hostname
+}
+
+// TenantResponse is a link namespace served on its own hostname.
+// Governing: SPEC-0001 REQ "Multi-Tenancy"
+type TenantResponse struct {
+	ID        string    `json:"id"`
+	Hostname  string    `json:"hostname"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// AddTeamMemberRequest is the body for POST /api/v1/admin/teams/{slug}/members.
+// Governing: SPEC-0010 REQ "Team Shares and Ownership"
+type AddTeamMemberRequest struct {
+	Email string `json:"email"`
+}
+
+// TeamGrantRequest is the body for POST /api/v1/links/{id}/team-owners and
+// POST /api/v1/links/{id}/team-shares.
+// Governing: SPEC-0010 REQ "Team Shares and Ownership"
+type TeamGrantRequest struct {
+	Team string `json:"team"` // team slug
+}
+
 // ShareResponse represents a share record in API responses.
 // Governing: SPEC-0010 REQ "Link Share Management API Endpoints"
 type ShareResponse struct {
@@ -83,6 +292,24 @@ type ShareResponse struct {
 	CreatedAt   time.Time `json:"created_at"`
 }
 
+// DashboardResponse is the body for GET /api/v1/dashboard: the caller, the
+// links they can see, and every tag, in one response.
+// Governing: SPEC-0005 REQ "Dashboard Endpoint"
+type DashboardResponse struct {
+	Me    *UserResponse            `json:"me"`
+	Links []*DashboardLinkResponse `json:"links"`
+	Tags  []*TagResponse           `json:"tags"`
+}
+
+// DashboardLinkResponse is one link on the dashboard. The link's owners,
+// shares, and stats are null unless the caller owns the link or is an admin.
+// Governing: SPEC-0005 REQ "Dashboard Endpoint"
+type DashboardLinkResponse struct {
+	Link   *LinkResponse   `json:"link"`
+	Shares []ShareResponse `json:"shares" extensions:"x-nullable"`
+	Stats  *statsResponse  `json:"stats" extensions:"x-nullable"`
+}
+
 // TagResponse represents a tag with its link count.
 // Governing: SPEC-0005 REQ "API Response Structures"
 type TagResponse struct {
@@ -95,7 +322,7 @@ type TagResponse struct {
 // Governing: SPEC-0005 REQ "Pagination"
 type TagListResponse struct {
 	Tags       []*TagResponse `json:"tags"`
-	NextCursor *string        `json:"next_cursor"`
+	NextCursor *string        `json:"next_cursor" extensions:"x-nullable"`
 }
 
 // UserResponse represents a user profile.
@@ -107,11 +334,23 @@ type UserResponse struct {
 	CreatedAt   time.Time `json:"created_at"`
 }
 
+// UserProfileResponse is a user's public profile. It deliberately omits the
+// email address and role.
+// Governing: SPEC-0005 REQ "Public User Profiles"
+type UserProfileResponse struct {
+	DisplayName     string         `json:"display_name"`
+	DisplayNameSlug string         `json:"display_name_slug"`
+	ProfileURL      string         `json:"profile_url"`
+	PublicLinkCount int            `json:"public_link_count"`
+	Tags            []*TagResponse `json:"tags"`
+	JoinedAt        time.Time      `json:"joined_at"`
+}
+
 // UserListResponse wraps a paginated list of users.
 // Governing: SPEC-0005 REQ "Pagination"
 type UserListResponse struct {
 	Users      []*UserResponse `json:"users"`
-	NextCursor *string         `json:"next_cursor"`
+	NextCursor *string         `json:"next_cursor" extensions:"x-nullable"`
 }
 
 // UpdateRoleRequest is the body for PUT /api/v1/admin/users/{id}/role.
@@ -124,6 +363,7 @@ type UpdateRoleRequest struct {
 type TokenResponse struct {
 	ID         string     `json:"id"`
 	Name       string     `json:"name"`
+	Scopes     []string   `json:"scopes" example:"links:read"`
 	CreatedAt  time.Time  `json:"created_at"`
 	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
 	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
@@ -141,7 +381,231 @@ type TokenListResponse struct {
 }
 
 // CreateTokenRequest is the body for POST /api/v1/tokens.
+// Scopes defaults to every scope the caller holds when omitted.
 type CreateTokenRequest struct {
 	Name      string     `json:"name"`
+	Scopes    []string   `json:"scopes,omitempty" example:"links:read"`
 	ExpiresAt *time.Time `json:"expires_at,omitempty"`
 }
+
+// UsageEntry is one daily request count for a token and endpoint.
+// Governing: SPEC-0006 REQ "API Usage Tracking"
+type UsageEntry struct {
+	TokenID   string `json:"token_id"`
+	TokenName string `json:"token_name"`
+	Endpoint  string `json:"endpoint"`
+	Day       string `json:"day"`
+	Requests  int64  `json:"requests"`
+}
+
+// UsageResponse is returned by GET /api/v1/me/usage.
+type UsageResponse struct {
+	Since string        `json:"since"`
+	Total int64         `json:"total"`
+	Usage []*UsageEntry `json:"usage"`
+}
+
+// ResolveTestRequest is the body for POST /api/v1/resolve/test.
+// Governing: SPEC-0005 REQ "Resolve Test Endpoint"
+type ResolveTestRequest struct {
+	Path      string `json:"path" example:"jira/PROJ-1?focus=2"`
+	Host      string `json:"host,omitempty"`      // request host, for keyword host routing
+	AsUser    string `json:"as_user,omitempty"`   // user ID or email to resolve as; admin only
+	Anonymous bool   `json:"anonymous,omitempty"` // resolve as a logged-out visitor
+}
+
+// ResolvedLinkResponse identifies the link a path matched.
+type ResolvedLinkResponse struct {
+	ID         string `json:"id"`
+	Slug       string `json:"slug"`
+	URL        string `json:"url,omitempty"` // omitted when access is denied
+	Visibility string `json:"visibility" enums:"public,private,secure"`
+}
+
+// ResolveVariable is a path variable and the value bound to it.
+type ResolveVariable struct {
+	Name        string `json:"name"`
+	Placeholder string `json:"placeholder"`
+	Value       string `json:"value"`
+	// Default is true when no path segment was supplied and Value is the
+	// placeholder's default.
+	Default bool `json:"default,omitempty"`
+	// FromQuery is true when Value came from the query parameter named
+	// after the variable rather than a path segment.
+	FromQuery bool `json:"from_query,omitempty"`
+}
+
+// ResolveResponse is where a path sends the caller, from GET /api/v1/resolve.
+// Target is empty for the help outcome, which renders a page instead.
+// Governing: SPEC-0005 REQ "Resolve Endpoint"
+type ResolveResponse struct {
+	Path      string            `json:"path" example:"github/joestump"`
+	Outcome   string            `json:"outcome" example:"redirect"` // redirect, keyword, or help
+	Target    string            `json:"target,omitempty" example:"https://github.com/joestump"`
+	LinkID    string            `json:"link_id,omitempty"`
+	Slug      string            `json:"slug,omitempty" example:"github"`
+	Keyword   string            `json:"keyword,omitempty"`
+	Variables []ResolveVariable `json:"variables,omitempty"`
+}
+
+// ResolveTestResponse explains how the resolver handles a path.
+// Governing: SPEC-0005 REQ "Resolve Test Endpoint"
+type ResolveTestResponse struct {
+	Path      string                `json:"path"`
+	AsUser    *UserResponse         `json:"as_user"` // null when resolved anonymously
+	Outcome   string                `json:"outcome" example:"redirect"`
+	Status    int                   `json:"status" example:"302"`
+	Target    string                `json:"target,omitempty"`
+	Link      *ResolvedLinkResponse `json:"link,omitempty"`
+	Keyword   string                `json:"keyword,omitempty"`
+	Variables []ResolveVariable     `json:"variables,omitempty"`
+	Trace     []string              `json:"trace"`
+}
+
+// LinkHealthResponse is the latest health check of a link's target URL.
+// Governing: SPEC-0001 REQ "Link Health Checks"
+type LinkHealthResponse struct {
+	LinkID     string     `json:"link_id"`
+	Checked    bool       `json:"checked"` // false until the first check of this link
+	Broken     bool       `json:"broken"`
+	StatusCode int        `json:"status_code,omitempty"`
+	LatencyMS  int64      `json:"latency_ms,omitempty"`
+	Error      string     `json:"error,omitempty"`
+	CheckedAt  *time.Time `json:"checked_at,omitempty"`
+}
+
+// CreateSavedSearchRequest is the body for POST /api/v1/searches.
+// Governing: SPEC-0005 REQ "Saved Searches API"
+type CreateSavedSearchRequest struct {
+	Name  string `json:"name"`
+	Query string `json:"query,omitempty"` // link search, e.g. "deploy owner:ada"
+	Tag   string `json:"tag,omitempty"`   // tag slug or name
+}
+
+// SavedSearchResponse is a saved dashboard search.
+// Governing: SPEC-0005 REQ "Saved Searches API"
+type SavedSearchResponse struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Query string `json:"query"`
+	Tag   string `json:"tag"`
+	// Filter is Query with Tag folded in; pass it as GET /links?q= to run the search.
+	Filter    string    `json:"filter"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ExcludeReferrerRequest is the body for POST /api/v1/admin/referrers.
+// Governing: SPEC-0016 REQ "Referrer Exclusion"
+type ExcludeReferrerRequest struct {
+	Domain string `json:"domain"` // host name, or a URL on it
+	Reason string `json:"reason,omitempty"`
+}
+
+// ExcludedReferrerResponse is a referrer domain whose clicks are not counted.
+// Governing: SPEC-0016 REQ "Referrer Exclusion"
+type ExcludedReferrerResponse struct {
+	Domain    string    `json:"domain"`
+	Reason    string    `json:"reason"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// AddDomainRuleRequest is the body for POST /api/v1/admin/domain-rules.
+// Governing: SPEC-0002 REQ "Destination Domain Rules"
+type AddDomainRuleRequest struct {
+	Domain string `json:"domain" example:"bit.ly"` // host name, or a URL on it
+	Action string `json:"action" example:"deny"`   // allow or deny
+	Note   string `json:"note,omitempty" example:"URL shorteners"`
+}
+
+// DomainRuleResponse allows or denies link destinations on a domain and its
+// subdomains.
+// Governing: SPEC-0002 REQ "Destination Domain Rules"
+type DomainRuleResponse struct {
+	Domain    string    `json:"domain"`
+	Action    string    `json:"action"`
+	Note      string    `json:"note"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// StatusResponse summarizes server health for ops dashboards.
+// Governing: SPEC-0016 REQ "Status Page"
+type StatusResponse struct {
+	Status        string                 `json:"status" example:"ok"` // ok, degraded, or down
+	Version       string                 `json:"version"`
+	CheckedAt     time.Time              `json:"checked_at"`
+	UptimeSeconds int64                  `json:"uptime_seconds"`
+	Database      DatabaseStatusResponse `json:"database"`
+	ClickQueue    QueueStatusResponse    `json:"click_queue"`
+	Jobs          []JobStatusResponse    `json:"jobs"`
+}
+
+// DatabaseStatusResponse reports database reachability and ping latency.
+type DatabaseStatusResponse struct {
+	Status    string  `json:"status"`
+	LatencyMS float64 `json:"latency_ms"`
+}
+
+// QueueStatusResponse reports the in-memory click queue's fill level.
+type QueueStatusResponse struct {
+	Status   string `json:"status"`
+	Depth    int    `json:"depth"`
+	Capacity int    `json:"capacity"`
+}
+
+// JobStatusResponse reports when a background job last succeeded.
+type JobStatusResponse struct {
+	Name            string     `json:"name"`
+	Status          string     `json:"status"`
+	IntervalSeconds int64      `json:"interval_seconds"`
+	LastSuccess     *time.Time `json:"last_success"` // null until the job first succeeds
+}
+
+// CreateLinkPolicyRequest is the body for POST /api/v1/admin/policies.
+// Governing: SPEC-0011 REQ "Link Lifecycle Policies"
+type CreateLinkPolicyRequest struct {
+	Name        string `json:"name"`
+	Scope       string `json:"scope,omitempty" example:"tag:temp"`    // owner:, tag:, team:, visibility: filters; empty for all links
+	Rule        string `json:"rule" example:"max_age"`                // require_title, require_description, require_team, or max_age
+	Days        int    `json:"days,omitempty" example:"30"`           // age limit, max_age only
+	Enforcement string `json:"enforcement,omitempty" example:"block"` // warn (default) or block
+}
+
+// LinkPolicyResponse is an admin-defined rule for the links its scope selects.
+// Governing: SPEC-0011 REQ "Link Lifecycle Policies"
+type LinkPolicyResponse struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Scope       string    `json:"scope"`
+	Rule        string    `json:"rule"`
+	Days        int       `json:"days,omitempty"`
+	Enforcement string    `json:"enforcement"`
+	Summary     string    `json:"summary" example:"Expire after 30 days"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// PolicyViolationResponse is a policy one of the caller's links breaks.
+// Governing: SPEC-0011 REQ "Link Lifecycle Policies"
+type PolicyViolationResponse struct {
+	LinkID      string    `json:"link_id"`
+	Slug        string    `json:"slug"`
+	PolicyID    string    `json:"policy_id"`
+	PolicyName  string    `json:"policy_name"`
+	Enforcement string    `json:"enforcement"`
+	Message     string    `json:"message" example:"a description is required"`
+	DetectedAt  time.Time `json:"detected_at"`
+}
+
+// SlugBloomResponse is a Bloom filter of the slugs the caller can know exist,
+// for the browser extension's offline 404 fallback. Bit i of the filter is bit
+// i%8 (least significant first) of byte i/8 of Bits. A key's K bit positions
+// are (h1 + i*h2) mod M for i in [0, K), with h1 + i*h2 wrapping at 32 bits;
+// h1 is the 32-bit FNV-1a hash of the slug and h2 its 32-bit FNV-1 hash with
+// the low bit set.
+// Governing: SPEC-0008 REQ "Offline Slug Lookup"
+type SlugBloomResponse struct {
+	Hash  string `json:"hash" example:"fnv1a32+fnv1-32"`
+	Count int    `json:"count" example:"412"`
+	M     uint32 `json:"m" example:"3952"`
+	K     uint32 `json:"k" example:"7"`
+	Bits  []byte `json:"bits" swaggertype:"string" format:"base64"`
+}
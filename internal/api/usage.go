@@ -0,0 +1,189 @@
+// Governing: SPEC-0006 REQ "API Usage Tracking", ADR-0009
+package api
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/joestump/joe-links/internal/auth"
+	"github.com/joestump/joe-links/internal/metrics"
+	"github.com/joestump/joe-links/internal/store"
+)
+
+// usageKey identifies one daily rollup bucket held in memory before flush.
+type usageKey struct {
+	tokenID  string
+	userID   string
+	endpoint string
+	day      string
+}
+
+// UsageRecorder counts token-authenticated API requests per endpoint in memory
+// and periodically flushes the daily rollups to the UsageStore, so recording
+// adds no database write to the request path.
+type UsageRecorder struct {
+	store *store.UsageStore
+
+	mu      sync.Mutex
+	pending map[usageKey]int64
+	now     func() time.Time
+}
+
+// NewUsageRecorder creates a UsageRecorder backed by us.
+func NewUsageRecorder(us *store.UsageStore) *UsageRecorder {
+	return &UsageRecorder{store: us, pending: make(map[usageKey]int64), now: time.Now}
+}
+
+// Middleware records the request after the handler runs, keyed by the matched
+// chi route pattern (e.g. "GET /api/v1/links/{id}") rather than the raw path
+// so per-resource URLs roll up together. Requests not authenticated by an API
+// token are not counted. Must be used after BearerTokenMiddleware.Authenticate.
+func (u *UsageRecorder) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r)
+
+		pattern := r.URL.Path
+		if rctx := chi.RouteContext(r.Context()); rctx != nil && rctx.RoutePattern() != "" {
+			pattern = rctx.RoutePattern()
+		}
+		u.recordCall(r.Context(), r.Method+" "+pattern)
+	})
+}
+
+// recordCall counts one call to endpoint by the API token that authenticated
+// ctx; calls authenticated any other way are not counted.
+func (u *UsageRecorder) recordCall(ctx context.Context, endpoint string) {
+	tokenID := auth.TokenIDFromContext(ctx)
+	user := auth.UserFromContext(ctx)
+	if tokenID == "" || user == nil {
+		return
+	}
+	u.record(usageKey{
+		tokenID:  tokenID,
+		userID:   user.ID,
+		endpoint: endpoint,
+		day:      u.now().UTC().Format(store.UsageDayFormat),
+	})
+}
+
+func (u *UsageRecorder) record(k usageKey) {
+	u.mu.Lock()
+	u.pending[k]++
+	u.mu.Unlock()
+}
+
+// Flush writes all pending counts to the store. On failure the counts are
+// merged back so they are retried on the next flush.
+func (u *UsageRecorder) Flush(ctx context.Context) error {
+	u.mu.Lock()
+	batch := u.pending
+	u.pending = make(map[usageKey]int64, len(batch))
+	u.mu.Unlock()
+
+	if len(batch) == 0 {
+		return nil
+	}
+	deltas := make([]store.UsageDelta, 0, len(batch))
+	for k, n := range batch {
+		deltas = append(deltas, store.UsageDelta{
+			TokenID: k.tokenID, UserID: k.userID, Endpoint: k.endpoint, Day: k.day, Count: n,
+		})
+	}
+	if err := u.store.Add(ctx, deltas); err != nil {
+		u.mu.Lock()
+		for k, n := range batch {
+			u.pending[k] += n
+		}
+		u.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// Run flushes pending counts every interval until ctx is cancelled, then
+// performs a final flush.
+func (u *UsageRecorder) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			if err := u.Flush(context.Background()); err != nil {
+				log.Printf("api usage flush error: %v", err)
+			}
+			return
+		case <-ticker.C:
+			if err := u.Flush(ctx); err != nil {
+				log.Printf("api usage flush error: %v", err)
+			} else {
+				metrics.MarkJobSuccess(metrics.JobUsageFlush)
+			}
+		}
+	}
+}
+
+// usageAPIHandler serves the caller's API usage report.
+type usageAPIHandler struct {
+	usage *store.UsageStore
+}
+
+// maxUsageDays bounds the reporting window for GET /me/usage.
+const maxUsageDays = 90
+
+// MyUsage returns the caller's daily API request counts per token and endpoint.
+// GET /api/v1/me/usage
+// Governing: SPEC-0006 REQ "API Usage Tracking"
+//
+// @Summary      Get my API usage
+// @Description  Returns daily request counts per token and endpoint for the authenticated user. Counts are flushed about once a minute.
+// @Tags         Users
+// @Produce      json
+// @Param        days  query     int  false  "Days of history including today (default 30, max 90)"
+// @Success      200   {object}  UsageResponse
+// @Failure      400   {object}  ErrorResponse
+// @Failure      401   {object}  ErrorResponse
+// @Failure      500   {object}  ErrorResponse
+// @Security     BearerToken
+// @Router       /me/usage [get]
+func (h *usageAPIHandler) MyUsage(w http.ResponseWriter, r *http.Request) {
+	user := auth.UserFromContext(r.Context())
+	if user == nil {
+		writeError(w, http.StatusUnauthorized, "unauthorized", "UNAUTHORIZED")
+		return
+	}
+
+	days := 30
+	if v := r.URL.Query().Get("days"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > maxUsageDays {
+			writeError(w, http.StatusBadRequest, "days must be between 1 and 90", "BAD_REQUEST")
+			return
+		}
+		days = n
+	}
+	since := time.Now().UTC().AddDate(0, 0, -(days - 1))
+
+	rows, err := h.usage.ListByUser(r.Context(), user.ID, since)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
+		return
+	}
+
+	resp := &UsageResponse{Since: since.Format(store.UsageDayFormat), Usage: make([]*UsageEntry, 0, len(rows))}
+	for _, row := range rows {
+		resp.Total += row.Count
+		resp.Usage = append(resp.Usage, &UsageEntry{
+			TokenID:   row.TokenID,
+			TokenName: row.TokenName,
+			Endpoint:  row.Endpoint,
+			Day:       row.Day,
+			Requests:  row.Count,
+		})
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
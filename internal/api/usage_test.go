@@ -0,0 +1,90 @@
+// Governing: SPEC-0006 REQ "API Usage Tracking"
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/joestump/joe-links/internal/api"
+)
+
+func TestMyUsage_CountsPerEndpoint(t *testing.T) {
+	env := newTestEnv(t)
+	user := seedUser(t, env, "usage@example.com", "user")
+	token := seedToken(t, env, user.ID)
+	ctx := context.Background()
+
+	link, err := env.LinkStore.Create(ctx, "usage-link", "https://example.com", user.ID, "Usage", "", "")
+	if err != nil {
+		t.Fatalf("create link: %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		req := authRequest(httptest.NewRequest("GET", "/links/"+link.ID, nil), token)
+		env.Router.ServeHTTP(httptest.NewRecorder(), req)
+	}
+	if err := env.UsageRecorder.Flush(ctx); err != nil {
+		t.Fatalf("flush: %v", err)
+	}
+
+	req := authRequest(httptest.NewRequest("GET", "/me/usage", nil), token)
+	rec := httptest.NewRecorder()
+	env.Router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200; body: %s", rec.Code, rec.Body.String())
+	}
+
+	var resp api.UsageResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(resp.Usage) != 1 {
+		t.Fatalf("usage entries = %d, want 1: %+v", len(resp.Usage), resp.Usage)
+	}
+	got := resp.Usage[0]
+	if got.Endpoint != "GET /links/{id}" || got.Requests != 3 || got.TokenName != "test-token" {
+		t.Errorf("entry = %+v, want GET /links/{id} x3 for test-token", got)
+	}
+	if resp.Total != 3 {
+		t.Errorf("total = %d, want 3", resp.Total)
+	}
+}
+
+func TestMyUsage_ScopedToCaller(t *testing.T) {
+	env := newTestEnv(t)
+	alice := seedUser(t, env, "usage-alice@example.com", "user")
+	bob := seedUser(t, env, "usage-bob@example.com", "user")
+	aliceToken := seedToken(t, env, alice.ID)
+	bobToken := seedToken(t, env, bob.ID)
+
+	env.Router.ServeHTTP(httptest.NewRecorder(), authRequest(httptest.NewRequest("GET", "/links", nil), aliceToken))
+	if err := env.UsageRecorder.Flush(context.Background()); err != nil {
+		t.Fatalf("flush: %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	env.Router.ServeHTTP(rec, authRequest(httptest.NewRequest("GET", "/me/usage", nil), bobToken))
+	var resp api.UsageResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if resp.Total != 0 || len(resp.Usage) != 0 {
+		t.Errorf("bob sees usage %+v, want none", resp)
+	}
+}
+
+func TestMyUsage_InvalidDays(t *testing.T) {
+	env := newTestEnv(t)
+	user := seedUser(t, env, "usage-days@example.com", "user")
+	token := seedToken(t, env, user.ID)
+
+	for _, days := range []string{"0", "91", "abc"} {
+		rec := httptest.NewRecorder()
+		env.Router.ServeHTTP(rec, authRequest(httptest.NewRequest("GET", "/me/usage?days="+days, nil), token))
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("days=%s: status = %d, want 400", days, rec.Code)
+		}
+	}
+}
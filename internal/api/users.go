@@ -1,4 +1,4 @@
-// Governing: SPEC-0005 REQ "User Profile"
+// Governing: SPEC-0005 REQ "User Profile", REQ "Public User Profiles"
 package api
 
 import (
@@ -6,17 +6,22 @@ import (
 
 	"github.com/go-chi/chi/v5"
 	"github.com/joestump/joe-links/internal/auth"
+	"github.com/joestump/joe-links/internal/store"
 )
 
 // usersAPIHandler provides REST handlers for user endpoints.
 // Governing: SPEC-0005 REQ "User Profile"
-type usersAPIHandler struct{}
+type usersAPIHandler struct {
+	users *store.UserStore
+	links *store.LinkStore
+}
 
 // registerUserRoutes registers user routes on r.
-// Governing: SPEC-0005 REQ "User Profile"
-func registerUserRoutes(r chi.Router) {
-	h := &usersAPIHandler{}
+// Governing: SPEC-0005 REQ "User Profile", REQ "Public User Profiles"
+func registerUserRoutes(r chi.Router, us *store.UserStore, ls *store.LinkStore) {
+	h := &usersAPIHandler{users: us, links: ls}
 	r.Get("/users/me", h.Me)
+	r.Get("/users/{slug}", h.Profile)
 }
 
 // Me returns the authenticated caller's profile.
@@ -47,3 +52,55 @@ func (h *usersAPIHandler) Me(w http.ResponseWriter, r *http.Request) {
 		CreatedAt:   user.CreatedAt,
 	})
 }
+
+// Profile returns a user's public profile: display name, join date, public
+// link count, and the tags on those links. {slug} is a display_name_slug or
+// an @username alias.
+// GET /api/v1/users/{slug}
+// Governing: SPEC-0005 REQ "Public User Profiles", SPEC-0012 REQ "Profile Username Alias"
+//
+// @Summary      Get a user's public profile
+// @Description  Returns the public profile shown at /u/{slug}. The slug may be a display name slug or an @username alias.
+// @Tags         Users
+// @Produce      json
+// @Param        slug  path      string  true  "Display name slug or @username"
+// @Success      200   {object}  UserProfileResponse
+// @Failure      401   {object}  ErrorResponse
+// @Failure      404   {object}  ErrorResponse
+// @Security     BearerToken
+// @Router       /users/{slug} [get]
+func (h *usersAPIHandler) Profile(w http.ResponseWriter, r *http.Request) {
+	user, err := h.users.GetByProfileRef(r.Context(), chi.URLParam(r, "slug"))
+	if err == store.ErrNotFound {
+		writeError(w, http.StatusNotFound, "user not found", "NOT_FOUND")
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
+		return
+	}
+
+	_, total, err := h.links.ListPublicByOwner(r.Context(), user.ID, 1, 1)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
+		return
+	}
+	tags, err := h.links.ListPublicTagsByOwner(r.Context(), user.ID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "internal error", "INTERNAL_ERROR")
+		return
+	}
+
+	resp := &UserProfileResponse{
+		DisplayName:     user.DisplayName,
+		DisplayNameSlug: user.DisplayNameSlug,
+		ProfileURL:      "/u/" + user.DisplayNameSlug,
+		PublicLinkCount: total,
+		Tags:            make([]*TagResponse, 0, len(tags)),
+		JoinedAt:        user.CreatedAt,
+	}
+	for _, t := range tags {
+		resp.Tags = append(resp.Tags, &TagResponse{Slug: t.Slug, Name: t.Name, LinkCount: t.Count})
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
@@ -0,0 +1,73 @@
+// Governing: SPEC-0005 REQ "Public User Profiles"
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/joestump/joe-links/internal/api"
+)
+
+func TestUserProfile(t *testing.T) {
+	env := newTestEnv(t)
+	owner := seedUser(t, env, "owner@example.com", "user")
+	caller := seedUser(t, env, "caller@example.com", "user")
+	token := seedToken(t, env, caller.ID)
+	ctx := context.Background()
+
+	wiki, err := env.LinkStore.Create(ctx, "wiki", "https://wiki.example.com", owner.ID, "", "", "public")
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if err := env.LinkStore.SetTags(ctx, wiki.ID, []string{"docs"}); err != nil {
+		t.Fatalf("set tags: %v", err)
+	}
+	payroll, err := env.LinkStore.Create(ctx, "payroll", "https://pay.example.com", owner.ID, "", "", "private")
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if err := env.LinkStore.SetTags(ctx, payroll.ID, []string{"hr"}); err != nil {
+		t.Fatalf("set tags: %v", err)
+	}
+
+	get := func(path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		authRequest(req, token)
+		rec := httptest.NewRecorder()
+		env.Router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	for _, ref := range []string{owner.DisplayNameSlug, "@owner"} {
+		rec := get("/users/" + ref)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("GET /users/%s: status = %d; body: %s", ref, rec.Code, rec.Body.String())
+		}
+		if strings.Contains(rec.Body.String(), "owner@example.com") {
+			t.Errorf("profile leaks email: %s", rec.Body.String())
+		}
+		var resp api.UserProfileResponse
+		if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		if resp.DisplayNameSlug != owner.DisplayNameSlug || resp.PublicLinkCount != 1 {
+			t.Errorf("GET /users/%s = %+v", ref, resp)
+		}
+		if len(resp.Tags) != 1 || resp.Tags[0].Name != "docs" || resp.Tags[0].LinkCount != 1 {
+			t.Errorf("tags = %+v, want only docs", resp.Tags)
+		}
+	}
+
+	// /users/me still returns the caller rather than a user slugged "me".
+	if rec := get("/users/me"); !strings.Contains(rec.Body.String(), "caller@example.com") {
+		t.Errorf("GET /users/me: %s", rec.Body.String())
+	}
+
+	if rec := get("/users/@nobody"); rec.Code != http.StatusNotFound {
+		t.Errorf("unknown user: status = %d, want 404", rec.Code)
+	}
+}
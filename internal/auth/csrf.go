@@ -0,0 +1,150 @@
+// Governing: SPEC-0001 REQ "CSRF Protection"
+package auth
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"strings"
+)
+
+const (
+	// CSRFCookie holds the browser's CSRF token. Forms echo it back in
+	// CSRFField and HTMX and fetch requests in CSRFHeader.
+	CSRFCookie = "__csrf"
+	CSRFHeader = "X-CSRF-Token"
+	CSRFField  = "csrf_token"
+
+	csrfTokenBytes = 32
+	csrfCookieAge  = 365 * 24 * 60 * 60
+	// csrfFieldLimit caps how much of a multipart body is read looking for
+	// the token field.
+	csrfFieldLimit = 4 << 10
+)
+
+type csrfContextKey struct{}
+
+// csrfState carries the request's token to CSRFToken, which issues the
+// cookie the first time a page asks for a token the browser does not have.
+type csrfState struct {
+	w      http.ResponseWriter
+	token  string
+	secure bool
+}
+
+// CSRFProtect rejects POST, PUT, PATCH, and DELETE requests whose token, in
+// the CSRFHeader header or the CSRFField form field, does not match the
+// browser's CSRFCookie (the double-submit pattern). deny answers rejected
+// requests. Paths starting with one of exempt skip the check; they must not
+// rely on cookies alone to authenticate, as the bearer-token API does not.
+// In a multipart form CSRFField must be the first field.
+func CSRFProtect(secure bool, deny http.HandlerFunc, exempt ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			st := &csrfState{w: w, secure: secure}
+			if c, err := r.Cookie(CSRFCookie); err == nil && validCSRFToken(c.Value) {
+				st.token = c.Value
+			}
+			r = r.WithContext(context.WithValue(r.Context(), csrfContextKey{}, st))
+
+			switch r.Method {
+			case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace:
+				next.ServeHTTP(w, r)
+				return
+			}
+			for _, p := range exempt {
+				if strings.HasPrefix(r.URL.Path, p) {
+					next.ServeHTTP(w, r)
+					return
+				}
+			}
+			sent := requestCSRFToken(r)
+			if st.token == "" || subtle.ConstantTimeCompare([]byte(sent), []byte(st.token)) != 1 {
+				deny(w, r)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// CSRFToken returns the token pages must send back with unsafe requests,
+// setting the CSRF cookie when the browser has none yet. It must be called
+// before the response is written. Outside CSRFProtect it returns "".
+func CSRFToken(r *http.Request) string {
+	st, _ := r.Context().Value(csrfContextKey{}).(*csrfState)
+	if st == nil {
+		return ""
+	}
+	if st.token == "" {
+		b := make([]byte, csrfTokenBytes)
+		if _, err := rand.Read(b); err != nil {
+			return ""
+		}
+		st.token = base64.RawURLEncoding.EncodeToString(b)
+		http.SetCookie(st.w, &http.Cookie{
+			Name:     CSRFCookie,
+			Value:    st.token,
+			Path:     "/",
+			MaxAge:   csrfCookieAge,
+			HttpOnly: true,
+			Secure:   st.secure,
+			SameSite: http.SameSiteLaxMode,
+		})
+	}
+	return st.token
+}
+
+// validCSRFToken reports whether s looks like a token CSRFToken issued.
+func validCSRFToken(s string) bool {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	return err == nil && len(b) == csrfTokenBytes
+}
+
+// requestCSRFToken returns the token r carries in its header or form body.
+func requestCSRFToken(r *http.Request) string {
+	if t := r.Header.Get(CSRFHeader); t != "" {
+		return t
+	}
+	ct, params, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	switch ct {
+	case "application/x-www-form-urlencoded":
+		return r.PostFormValue(CSRFField)
+	case "multipart/form-data":
+		return firstPartCSRFToken(r, params["boundary"])
+	}
+	return ""
+}
+
+// firstPartCSRFToken reads CSRFField from the first part of a multipart
+// body without parsing the rest, so handlers can still apply their own
+// upload limits. The bytes it reads are put back in front of r.Body.
+func firstPartCSRFToken(r *http.Request, boundary string) string {
+	if boundary == "" || r.Body == nil {
+		return ""
+	}
+	var seen bytes.Buffer
+	body := r.Body
+	defer func() {
+		r.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(&seen, body), body}
+	}()
+	mr := multipart.NewReader(io.TeeReader(io.LimitReader(body, csrfFieldLimit), &seen), boundary)
+	part, err := mr.NextPart()
+	if err != nil || part.FormName() != CSRFField {
+		return ""
+	}
+	v, err := io.ReadAll(io.LimitReader(part, 256))
+	if err != nil {
+		return ""
+	}
+	return string(v)
+}
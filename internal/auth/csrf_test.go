@@ -0,0 +1,105 @@
+package auth
+
+import (
+	"bytes"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+// Governing: SPEC-0001 REQ "CSRF Protection"
+func TestCSRFProtect(t *testing.T) {
+	var gotBody string
+	h := CSRFProtect(false, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}, "/api/")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			_, _ = io.WriteString(w, CSRFToken(r))
+			return
+		}
+		b, _ := io.ReadAll(r.Body)
+		gotBody = string(b)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	serve := func(req *http.Request) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+		return w
+	}
+
+	// A page asking for a token issues the cookie.
+	w := serve(httptest.NewRequest(http.MethodGet, "/dashboard", nil))
+	token := w.Body.String()
+	var cookie *http.Cookie
+	for _, c := range w.Result().Cookies() {
+		if c.Name == CSRFCookie {
+			cookie = c
+		}
+	}
+	if cookie == nil || cookie.Value != token || token == "" || !cookie.HttpOnly {
+		t.Fatalf("GET issued cookie %+v for token %q", cookie, token)
+	}
+
+	// The cookie is reused rather than reissued.
+	req := httptest.NewRequest(http.MethodGet, "/dashboard", nil)
+	req.AddCookie(cookie)
+	if w := serve(req); w.Body.String() != token || len(w.Result().Cookies()) != 0 {
+		t.Errorf("second GET: token %q, cookies %v", w.Body.String(), w.Result().Cookies())
+	}
+
+	post := func(body io.Reader, contentType string, withCookie bool) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/dashboard/links", body)
+		if contentType != "" {
+			req.Header.Set("Content-Type", contentType)
+		}
+		if withCookie {
+			req.AddCookie(cookie)
+		}
+		return req
+	}
+
+	form := url.Values{CSRFField: {token}, "slug": {"x"}}.Encode()
+	if w := serve(post(strings.NewReader(form), "application/x-www-form-urlencoded", true)); w.Code != http.StatusNoContent {
+		t.Errorf("form with token: status = %d", w.Code)
+	}
+	if w := serve(post(strings.NewReader(form), "application/x-www-form-urlencoded", false)); w.Code != http.StatusForbidden {
+		t.Errorf("form without cookie: status = %d", w.Code)
+	}
+	bad := url.Values{CSRFField: {"x" + token[1:]}}.Encode()
+	if w := serve(post(strings.NewReader(bad), "application/x-www-form-urlencoded", true)); w.Code != http.StatusForbidden {
+		t.Errorf("form with wrong token: status = %d", w.Code)
+	}
+
+	req = post(nil, "", true)
+	req.Method = http.MethodDelete
+	req.Header.Set(CSRFHeader, token)
+	if w := serve(req); w.Code != http.StatusNoContent {
+		t.Errorf("DELETE with header: status = %d", w.Code)
+	}
+	if w := serve(post(nil, "", true)); w.Code != http.StatusForbidden {
+		t.Errorf("POST without token: status = %d", w.Code)
+	}
+
+	// Multipart: the token is read from the first field and the body is
+	// passed on untouched.
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+	_ = mw.WriteField(CSRFField, token)
+	fw, _ := mw.CreateFormFile("logo", "logo.png")
+	_, _ = fw.Write(bytes.Repeat([]byte("png"), 4000))
+	_ = mw.Close()
+	raw := buf.String()
+	if w := serve(post(strings.NewReader(raw), mw.FormDataContentType(), true)); w.Code != http.StatusNoContent || gotBody != raw {
+		t.Errorf("multipart with token: status = %d, body intact = %v", w.Code, gotBody == raw)
+	}
+
+	// Exempt paths skip the check.
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/links", nil)
+	if w := serve(req); w.Code != http.StatusNoContent {
+		t.Errorf("exempt POST: status = %d", w.Code)
+	}
+}
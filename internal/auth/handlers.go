@@ -1,7 +1,9 @@
-// Governing: SPEC-0001 REQ "OIDC-Only Authentication", ADR-0003
+// Governing: SPEC-0001 REQ "OIDC-Only Authentication", REQ "RP-Initiated Logout", REQ "Refresh-Token Session Extension", REQ "Account Linking", ADR-0003
 package auth
 
 import (
+	"context"
+	"errors"
 	"log"
 	"net/http"
 	"time"
@@ -25,6 +27,9 @@ type Handlers struct {
 	adminGroups   []string // OIDC group names that grant the admin role
 	groupsClaim   string   // OIDC claim name for groups (default: "groups")
 	secureCookies bool
+	refresher     *SessionRefresher // nil unless refresh-token sessions are enabled
+	setupAdmin    AdminEmailFunc    // admin email chosen in the setup wizard; nil = none
+	linkByEmail   bool              // link new sign-ins to the account with the same verified email
 }
 
 // NewHandlers creates a new Handlers with the given dependencies.
@@ -44,6 +49,41 @@ func NewHandlers(p *Provider, sm *scs.SessionManager, us *store.UserStore, admin
 	}
 }
 
+// SetSessionRefresher enables refresh-token backed sessions: refresh tokens are
+// stored at login and revoked at logout.
+// Governing: SPEC-0001 REQ "Refresh-Token Session Extension"
+func (h *Handlers) SetSessionRefresher(rf *SessionRefresher) {
+	h.refresher = rf
+}
+
+// SetVerifiedEmailLinking makes a first sign-in whose ID token carries a
+// verified email join the existing account with that email instead of
+// creating a new one.
+// Governing: SPEC-0001 REQ "Account Linking"
+func (h *Handlers) SetVerifiedEmailLinking(enabled bool) {
+	h.linkByEmail = enabled
+}
+
+// AdminEmailFunc returns the admin email chosen in the first-run setup, or "".
+// Governing: SPEC-0001 REQ "First-Run Setup"
+type AdminEmailFunc func(ctx context.Context) string
+
+// EffectiveAdminEmail returns configured (JOE_ADMIN_EMAIL), or the email
+// chosen during setup when it is empty.
+func EffectiveAdminEmail(ctx context.Context, configured string, setup AdminEmailFunc) string {
+	if configured == "" && setup != nil {
+		return setup(ctx)
+	}
+	return configured
+}
+
+// SetSetupAdmin makes the admin email chosen during setup grant the admin
+// role when JOE_ADMIN_EMAIL is unset.
+// Governing: SPEC-0001 REQ "First-Run Setup"
+func (h *Handlers) SetSetupAdmin(fn AdminEmailFunc) {
+	h.setupAdmin = fn
+}
+
 // Login initiates the OIDC authorization code flow with PKCE.
 func (h *Handlers) Login(w http.ResponseWriter, r *http.Request) {
 	state, err := GenerateState()
@@ -88,11 +128,12 @@ func (h *Handlers) Callback(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Exchange code for tokens
-	idToken, err := h.provider.Exchange(r.Context(), r.URL.Query().Get("code"), verifierCookie.Value)
+	tokens, err := h.provider.Exchange(r.Context(), r.URL.Query().Get("code"), verifierCookie.Value)
 	if err != nil {
 		http.Error(w, "authentication failed", http.StatusUnauthorized)
 		return
 	}
+	idToken := tokens.IDToken
 
 	// Extract claims — groups claim is dynamic based on config.
 	var rawClaims map[string]interface{}
@@ -105,38 +146,38 @@ func (h *Handlers) Callback(w http.ResponseWriter, r *http.Request) {
 	subject, _ := rawClaims["sub"].(string)
 
 	// Determine role from adminEmail and OIDC group membership.
-	role := "user"
-	if h.adminEmail != "" && email == h.adminEmail {
-		role = "admin"
-	}
-	if role != "admin" && len(h.adminGroups) > 0 {
-		if groups := rawClaims[h.groupsClaim]; groups != nil {
-			var userGroups []string
-			switch v := groups.(type) {
-			case []interface{}:
-				for _, g := range v {
-					if s, ok := g.(string); ok {
-						userGroups = append(userGroups, s)
-					}
-				}
-			case []string:
-				userGroups = v
-			}
-			adminSet := make(map[string]struct{}, len(h.adminGroups))
-			for _, g := range h.adminGroups {
-				adminSet[g] = struct{}{}
-			}
-			for _, g := range userGroups {
-				if _, ok := adminSet[g]; ok {
-					role = "admin"
-					break
-				}
+	var userGroups []string
+	switch v := rawClaims[h.groupsClaim].(type) {
+	case []interface{}:
+		for _, g := range v {
+			if s, ok := g.(string); ok {
+				userGroups = append(userGroups, s)
 			}
 		}
+	case []string:
+		userGroups = v
+	}
+	role := ResolveRole(email, userGroups, EffectiveAdminEmail(r.Context(), h.adminEmail, h.setupAdmin), h.adminGroups)
+
+	// Governing: SPEC-0001 REQ "Account Linking"
+	err = h.linkSignIn(r, idToken.Issuer, subject, email, emailVerified(rawClaims))
+	switch {
+	case errors.Is(err, store.ErrIdentityLinked):
+		http.Error(w, "this sign-in is already linked to another account", http.StatusConflict)
+		return
+	case err != nil:
+		log.Printf("auth callback: link identity (issuer=%s subject=%s): %v", idToken.Issuer, subject, err)
+		http.Error(w, "user record error", http.StatusInternalServerError)
+		return
 	}
 
 	// Upsert user record — role is enforced on every login.
 	user, err := h.users.Upsert(r.Context(), idToken.Issuer, subject, email, name, role)
+	// Governing: SPEC-0001 REQ "Multi-Tenancy"
+	if errors.Is(err, store.ErrWrongTenant) {
+		http.Error(w, "this account belongs to another site; sign in there instead", http.StatusForbidden)
+		return
+	}
 	if err != nil {
 		log.Printf("auth callback: upsert user (issuer=%s subject=%s email=%s): %v", idToken.Issuer, subject, email, err)
 		http.Error(w, "user record error", http.StatusInternalServerError)
@@ -150,6 +191,14 @@ func (h *Handlers) Callback(w http.ResponseWriter, r *http.Request) {
 	}
 	h.sessions.Put(r.Context(), SessionUserIDKey, user.ID)
 	h.sessions.Put(r.Context(), SessionRoleKey, user.Role)
+	if h.provider.RPLogoutEnabled() {
+		h.sessions.Put(r.Context(), SessionIDTokenKey, tokens.RawIDToken)
+	}
+	if h.refresher != nil {
+		if err := h.refresher.Store(r.Context(), tokens.RefreshToken); err != nil {
+			log.Printf("auth callback: store refresh token: %v", err)
+		}
+	}
 
 	// Clear pre-auth cookies
 	clearCookie(w, cookieState)
@@ -166,15 +215,73 @@ func (h *Handlers) Callback(w http.ResponseWriter, r *http.Request) {
 	http.Redirect(w, r, redirect, http.StatusFound)
 }
 
-// Logout destroys the session and redirects to the login page.
+// Logout destroys the session and redirects to the login page. When
+// RP-initiated logout is enabled, the browser is instead sent to the provider's
+// end_session_endpoint so the IdP session ends too.
 func (h *Handlers) Logout(w http.ResponseWriter, r *http.Request) {
+	idTokenHint := h.sessions.GetString(r.Context(), SessionIDTokenKey)
+	if h.refresher != nil {
+		h.refresher.Revoke(r.Context())
+	}
 	if err := h.sessions.Destroy(r.Context()); err != nil {
 		http.Error(w, "logout error", http.StatusInternalServerError)
 		return
 	}
+	ClearSiteCache(w)
+	if endSession := h.provider.EndSessionURL(idTokenHint); endSession != "" {
+		http.Redirect(w, r, endSession, http.StatusFound)
+		return
+	}
 	http.Redirect(w, r, "/auth/login", http.StatusFound)
 }
 
+// linkSignIn attaches a new sign-in, when enabled, to the account with the
+// same verified email before it is upserted. Link codes are redeemed from
+// the signed-in account's settings instead, so the account's owner confirms.
+// Governing: SPEC-0001 REQ "Account Linking"
+func (h *Handlers) linkSignIn(r *http.Request, provider, subject, email string, verified bool) error {
+	ctx := r.Context()
+	if !h.linkByEmail || !verified || email == "" {
+		return nil
+	}
+	if _, err := h.users.GetByIdentity(ctx, provider, subject); !errors.Is(err, store.ErrNotFound) {
+		return nil // already known, or a lookup error Upsert will report
+	}
+	u, err := h.users.GetByEmail(ctx, email)
+	if err != nil {
+		return nil // no account to join
+	}
+	return h.users.LinkIdentity(ctx, u.ID, provider, subject, email)
+}
+
+// emailVerified reports the ID token's email_verified claim, which some
+// providers send as a string.
+func emailVerified(claims map[string]interface{}) bool {
+	switch v := claims["email_verified"].(type) {
+	case bool:
+		return v
+	case string:
+		return v == "true"
+	}
+	return false
+}
+
+// ResolveRole returns "admin" when email matches adminEmail or any of groups is
+// listed in adminGroups, and "user" otherwise. Shared by every identity provider.
+func ResolveRole(email string, groups []string, adminEmail string, adminGroups []string) string {
+	if adminEmail != "" && email == adminEmail {
+		return "admin"
+	}
+	for _, g := range groups {
+		for _, ag := range adminGroups {
+			if g == ag {
+				return "admin"
+			}
+		}
+	}
+	return "user"
+}
+
 func (h *Handlers) setPreAuthCookie(w http.ResponseWriter, name, value string) {
 	http.SetCookie(w, &http.Cookie{
 		Name:     name,
@@ -0,0 +1,29 @@
+package auth_test
+
+import (
+	"testing"
+
+	"github.com/joestump/joe-links/internal/auth"
+)
+
+func TestResolveRole(t *testing.T) {
+	tests := []struct {
+		name   string
+		email  string
+		groups []string
+		want   string
+	}{
+		{"admin email", "root@example.com", nil, "admin"},
+		{"admin group", "alice@example.com", []string{"eng", "joe-admins"}, "admin"},
+		{"plain user", "bob@example.com", []string{"eng"}, "user"},
+		{"no email", "", nil, "user"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := auth.ResolveRole(tt.email, tt.groups, "root@example.com", []string{"joe-admins"})
+			if got != tt.want {
+				t.Errorf("ResolveRole() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
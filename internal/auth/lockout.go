@@ -0,0 +1,111 @@
+// Governing: SPEC-0006 REQ "Token Brute-Force Lockout"
+package auth
+
+import (
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/joestump/joe-links/internal/metrics"
+)
+
+// ErrLockedOut is returned instead of checking a token when the client has
+// failed too many token checks recently.
+var ErrLockedOut = errors.New("too many failed authentication attempts")
+
+// maxLockoutClients bounds how many client addresses a TokenLockout tracks,
+// so a flood from many addresses cannot grow it without limit.
+const maxLockoutClients = 10000
+
+// TokenLockout counts failed API token checks per client address and locks
+// out an address that fails too often. It is in memory, so each server
+// process counts separately and a restart clears it. A nil *TokenLockout
+// never locks anyone out.
+type TokenLockout struct {
+	maxFailures int
+	window      time.Duration
+	duration    time.Duration
+	now         func() time.Time
+
+	mu      sync.Mutex
+	clients map[string]*lockoutClient
+}
+
+type lockoutClient struct {
+	failures    int
+	windowStart time.Time
+	lockedUntil time.Time
+}
+
+// NewTokenLockout locks out an address for duration once it has failed
+// maxFailures token checks within window.
+func NewTokenLockout(maxFailures int, window, duration time.Duration) *TokenLockout {
+	return &TokenLockout{
+		maxFailures: maxFailures,
+		window:      window,
+		duration:    duration,
+		now:         time.Now,
+		clients:     make(map[string]*lockoutClient),
+	}
+}
+
+// Locked reports whether ip is locked out, and for how much longer.
+func (l *TokenLockout) Locked(ip string) (time.Duration, bool) {
+	if l == nil {
+		return 0, false
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	c := l.clients[ip]
+	if c == nil {
+		return 0, false
+	}
+	if wait := c.lockedUntil.Sub(l.now()); wait > 0 {
+		return wait, true
+	}
+	return 0, false
+}
+
+// Fail records a failed token check from ip, locking it out when it reaches
+// the limit. Successful checks do not reset the count, so a client holding
+// one valid token cannot use it to keep guessing others.
+func (l *TokenLockout) Fail(ip string) {
+	if l == nil {
+		return
+	}
+	metrics.APIAuthFailuresTotal.Inc()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	c := l.clients[ip]
+	if c == nil {
+		if len(l.clients) >= maxLockoutClients {
+			l.prune(now)
+		}
+		if len(l.clients) >= maxLockoutClients {
+			return
+		}
+		c = &lockoutClient{windowStart: now}
+		l.clients[ip] = c
+	}
+	if now.Sub(c.windowStart) > l.window {
+		c.failures, c.windowStart = 0, now
+	}
+	c.failures++
+	if c.failures >= l.maxFailures && !c.lockedUntil.After(now) {
+		c.lockedUntil = now.Add(l.duration)
+		c.failures, c.windowStart = 0, now
+		metrics.APILockoutsTotal.Inc()
+		log.Printf("api auth: locked out %s for %s after %d failed token attempts within %s", ip, l.duration, l.maxFailures, l.window)
+	}
+}
+
+// prune forgets addresses whose window and lockout have both passed.
+func (l *TokenLockout) prune(now time.Time) {
+	for ip, c := range l.clients {
+		if now.Sub(c.windowStart) > l.window && !c.lockedUntil.After(now) {
+			delete(l.clients, ip)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package auth
+
+import (
+	"testing"
+	"time"
+)
+
+// Governing: SPEC-0006 REQ "Token Brute-Force Lockout"
+func TestTokenLockout(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	l := NewTokenLockout(3, time.Minute, 10*time.Minute)
+	l.now = func() time.Time { return now }
+
+	// Failures spread wider than the window never lock out.
+	for i := 0; i < 5; i++ {
+		l.Fail("192.0.2.1")
+		now = now.Add(40 * time.Second)
+		if i%2 == 1 {
+			now = now.Add(time.Minute)
+		}
+	}
+	if _, locked := l.Locked("192.0.2.1"); locked {
+		t.Fatal("locked out by failures outside the window")
+	}
+
+	for i := 0; i < 3; i++ {
+		l.Fail("192.0.2.2")
+	}
+	wait, locked := l.Locked("192.0.2.2")
+	if !locked || wait != 10*time.Minute {
+		t.Fatalf("Locked = %v, %v; want 10m, true", wait, locked)
+	}
+	now = now.Add(10*time.Minute + time.Second)
+	if _, locked := l.Locked("192.0.2.2"); locked {
+		t.Error("still locked out after the lockout lapsed")
+	}
+
+	var nilLockout *TokenLockout
+	nilLockout.Fail("192.0.2.3")
+	if _, locked := nilLockout.Locked("192.0.2.3"); locked {
+		t.Error("nil TokenLockout locked an address out")
+	}
+}
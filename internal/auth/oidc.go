@@ -1,4 +1,4 @@
-// Governing: SPEC-0001 REQ "OIDC-Only Authentication", ADR-0003
+// Governing: SPEC-0001 REQ "OIDC-Only Authentication", REQ "RP-Initiated Logout", REQ "Refresh-Token Session Extension", ADR-0003
 package auth
 
 import (
@@ -7,6 +7,9 @@ import (
 	"crypto/sha256"
 	"encoding/base64"
 	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
 
 	gooidc "github.com/coreos/go-oidc/v3/oidc"
 	"golang.org/x/oauth2"
@@ -18,6 +21,23 @@ import (
 type Provider struct {
 	verifier     *gooidc.IDTokenVerifier
 	oauth2Config oauth2.Config
+
+	// endSessionEndpoint is discovered only when RP-initiated logout is enabled;
+	// empty means logout stays local.
+	endSessionEndpoint    string
+	postLogoutRedirectURL string
+
+	// revocationEndpoint is the RFC 7009 endpoint used to revoke refresh
+	// tokens on logout; empty when unsupported or refresh tokens are disabled.
+	refreshTokens      bool
+	revocationEndpoint string
+}
+
+// Tokens is the result of a successful authorization code exchange.
+type Tokens struct {
+	IDToken      *gooidc.IDToken
+	RawIDToken   string // compact JWS form, used as id_token_hint
+	RefreshToken string // empty unless the provider issued one
 }
 
 // NewProvider performs OIDC discovery and returns a configured Provider.
@@ -27,33 +47,65 @@ func NewProvider(ctx context.Context, cfg *config.Config) (*Provider, error) {
 		return nil, fmt.Errorf("OIDC provider discovery failed for %s: %w", cfg.OIDC.Issuer, err)
 	}
 
+	scopes := []string{gooidc.ScopeOpenID, "profile", "email"}
+	if cfg.SessionRefreshTokens {
+		scopes = append(scopes, gooidc.ScopeOfflineAccess)
+	}
+
 	oauth2Cfg := oauth2.Config{
 		ClientID:     cfg.OIDC.ClientID,
 		ClientSecret: cfg.OIDC.ClientSecret,
 		RedirectURL:  cfg.OIDC.RedirectURL,
 		Endpoint:     provider.Endpoint(),
-		Scopes:       []string{gooidc.ScopeOpenID, "profile", "email"},
+		Scopes:       scopes,
 	}
 
 	verifier := provider.Verifier(&gooidc.Config{ClientID: cfg.OIDC.ClientID})
 
-	return &Provider{
-		verifier:     verifier,
-		oauth2Config: oauth2Cfg,
-	}, nil
+	p := &Provider{
+		verifier:      verifier,
+		oauth2Config:  oauth2Cfg,
+		refreshTokens: cfg.SessionRefreshTokens,
+	}
+
+	var claims struct {
+		EndSessionEndpoint string `json:"end_session_endpoint"`
+		RevocationEndpoint string `json:"revocation_endpoint"`
+	}
+	if err := provider.Claims(&claims); err != nil {
+		return nil, fmt.Errorf("OIDC discovery claims for %s: %w", cfg.OIDC.Issuer, err)
+	}
+
+	// Governing: SPEC-0001 REQ "RP-Initiated Logout"
+	if cfg.OIDC.RPLogout {
+		p.endSessionEndpoint = claims.EndSessionEndpoint
+		p.postLogoutRedirectURL = cfg.OIDC.PostLogoutRedirectURL
+	}
+
+	// Governing: SPEC-0001 REQ "Refresh-Token Session Extension"
+	if cfg.SessionRefreshTokens {
+		p.revocationEndpoint = claims.RevocationEndpoint
+	}
+
+	return p, nil
 }
 
 // AuthCodeURL generates the authorization URL with PKCE and state.
 func (p *Provider) AuthCodeURL(state, codeChallenge string) string {
+	accessType := oauth2.AccessTypeOnline
+	if p.refreshTokens {
+		accessType = oauth2.AccessTypeOffline
+	}
 	return p.oauth2Config.AuthCodeURL(state,
-		oauth2.AccessTypeOnline,
+		accessType,
 		oauth2.SetAuthURLParam("code_challenge", codeChallenge),
 		oauth2.SetAuthURLParam("code_challenge_method", "S256"),
 	)
 }
 
-// Exchange trades an authorization code for tokens and returns the verified ID token.
-func (p *Provider) Exchange(ctx context.Context, code, codeVerifier string) (*gooidc.IDToken, error) {
+// Exchange trades an authorization code for tokens and returns the verified ID
+// token along with its raw form and any refresh token.
+func (p *Provider) Exchange(ctx context.Context, code, codeVerifier string) (*Tokens, error) {
 	token, err := p.oauth2Config.Exchange(ctx, code,
 		oauth2.SetAuthURLParam("code_verifier", codeVerifier),
 	)
@@ -71,7 +123,89 @@ func (p *Provider) Exchange(ctx context.Context, code, codeVerifier string) (*go
 		return nil, fmt.Errorf("id_token verification: %w", err)
 	}
 
-	return idToken, nil
+	return &Tokens{IDToken: idToken, RawIDToken: rawIDToken, RefreshToken: token.RefreshToken}, nil
+}
+
+// Refresh redeems a refresh token at the provider's token endpoint. The
+// returned Tokens carry the (possibly rotated) refresh token; IDToken is set
+// only when the provider re-issues one.
+// Governing: SPEC-0001 REQ "Refresh-Token Session Extension"
+func (p *Provider) Refresh(ctx context.Context, refreshToken string) (*Tokens, error) {
+	token, err := p.oauth2Config.TokenSource(ctx, &oauth2.Token{RefreshToken: refreshToken}).Token()
+	if err != nil {
+		return nil, fmt.Errorf("token refresh: %w", err)
+	}
+
+	out := &Tokens{RefreshToken: token.RefreshToken}
+	if out.RefreshToken == "" {
+		out.RefreshToken = refreshToken // provider does not rotate
+	}
+	if rawIDToken, ok := token.Extra("id_token").(string); ok && rawIDToken != "" {
+		idToken, err := p.verifier.Verify(ctx, rawIDToken)
+		if err != nil {
+			return nil, fmt.Errorf("id_token verification: %w", err)
+		}
+		out.IDToken = idToken
+		out.RawIDToken = rawIDToken
+	}
+	return out, nil
+}
+
+// RevokeRefreshToken revokes a refresh token via the provider's RFC 7009
+// revocation endpoint. It is a no-op when the provider advertises none.
+// Governing: SPEC-0001 REQ "Refresh-Token Session Extension"
+func (p *Provider) RevokeRefreshToken(ctx context.Context, refreshToken string) error {
+	if p.revocationEndpoint == "" || refreshToken == "" {
+		return nil
+	}
+	form := url.Values{
+		"token":           {refreshToken},
+		"token_type_hint": {"refresh_token"},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.revocationEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(url.QueryEscape(p.oauth2Config.ClientID), url.QueryEscape(p.oauth2Config.ClientSecret))
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("token revocation: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("token revocation: unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// RPLogoutEnabled reports whether logout should be forwarded to the provider.
+func (p *Provider) RPLogoutEnabled() bool {
+	return p.endSessionEndpoint != ""
+}
+
+// EndSessionURL builds the provider's RP-initiated logout URL. It returns an
+// empty string when RP-initiated logout is disabled or unsupported.
+// Governing: SPEC-0001 REQ "RP-Initiated Logout"
+func (p *Provider) EndSessionURL(idTokenHint string) string {
+	if p.endSessionEndpoint == "" {
+		return ""
+	}
+	u, err := url.Parse(p.endSessionEndpoint)
+	if err != nil {
+		return ""
+	}
+	q := u.Query()
+	q.Set("client_id", p.oauth2Config.ClientID)
+	if idTokenHint != "" {
+		q.Set("id_token_hint", idTokenHint)
+	}
+	if p.postLogoutRedirectURL != "" {
+		q.Set("post_logout_redirect_uri", p.postLogoutRedirectURL)
+	}
+	u.RawQuery = q.Encode()
+	return u.String()
 }
 
 // GenerateState returns a cryptographically random state string.
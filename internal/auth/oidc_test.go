@@ -0,0 +1,44 @@
+package auth
+
+import (
+	"net/url"
+	"testing"
+
+	"golang.org/x/oauth2"
+)
+
+func TestEndSessionURL(t *testing.T) {
+	p := &Provider{
+		oauth2Config:          oauth2.Config{ClientID: "joe-links"},
+		endSessionEndpoint:    "https://idp.example.com/logout?tenant=acme",
+		postLogoutRedirectURL: "https://go.example.com/",
+	}
+
+	raw := p.EndSessionURL("raw.id.token")
+	u, err := url.Parse(raw)
+	if err != nil {
+		t.Fatalf("parse %q: %v", raw, err)
+	}
+	q := u.Query()
+	checks := map[string]string{
+		"tenant":                   "acme",
+		"client_id":                "joe-links",
+		"id_token_hint":            "raw.id.token",
+		"post_logout_redirect_uri": "https://go.example.com/",
+	}
+	for k, want := range checks {
+		if got := q.Get(k); got != want {
+			t.Errorf("%s = %q, want %q", k, got, want)
+		}
+	}
+}
+
+func TestEndSessionURL_Disabled(t *testing.T) {
+	p := &Provider{oauth2Config: oauth2.Config{ClientID: "joe-links"}}
+	if p.RPLogoutEnabled() {
+		t.Error("RPLogoutEnabled() = true, want false")
+	}
+	if got := p.EndSessionURL("raw.id.token"); got != "" {
+		t.Errorf("EndSessionURL() = %q, want empty", got)
+	}
+}
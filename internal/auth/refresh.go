@@ -0,0 +1,148 @@
+// Governing: SPEC-0001 REQ "Server-Side Sessions", REQ "Refresh-Token Session Extension", ADR-0003
+package auth
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/alexedwards/scs/v2"
+	"github.com/joestump/joe-links/internal/secrets"
+)
+
+const (
+	// SessionRefreshTokenKey holds the AES-GCM sealed OIDC refresh token.
+	SessionRefreshTokenKey = "refresh_token"
+	// SessionStartedKey records when the user originally authenticated; the
+	// session is never extended past SessionStartedKey + max lifetime.
+	SessionStartedKey = "session_started"
+)
+
+// newTokenSealer returns the box that encrypts refresh tokens before they
+// are written to the session store, so they are never persisted in plaintext.
+func newTokenSealer(secret string) (*secrets.Box, error) {
+	return secrets.New(secret)
+}
+
+// SessionRefresher silently extends sessions using stored OIDC refresh tokens.
+// When an authenticated session is within half its lifetime of expiring, the
+// refresh token is redeemed at the provider; on success the session deadline
+// is pushed out by another lifetime, capped at the configured maximum.
+type SessionRefresher struct {
+	provider    *Provider
+	sessions    *scs.SessionManager
+	sealer      *secrets.Box
+	lifetime    time.Duration
+	maxLifetime time.Duration
+	now         func() time.Time
+}
+
+// NewSessionRefresher creates a SessionRefresher. encryptionKey is used to seal
+// refresh tokens at rest; maxLifetime bounds how long a session may be
+// extended after the original login.
+func NewSessionRefresher(p *Provider, sm *scs.SessionManager, encryptionKey string, lifetime, maxLifetime time.Duration) (*SessionRefresher, error) {
+	if encryptionKey == "" {
+		return nil, fmt.Errorf("refresh tokens require an encryption key")
+	}
+	sealer, err := newTokenSealer(encryptionKey)
+	if err != nil {
+		return nil, err
+	}
+	return &SessionRefresher{
+		provider:    p,
+		sessions:    sm,
+		sealer:      sealer,
+		lifetime:    lifetime,
+		maxLifetime: maxLifetime,
+		now:         time.Now,
+	}, nil
+}
+
+// Store seals and saves the refresh token in the current session and records
+// the session start time. Called from the OIDC callback after login.
+func (rf *SessionRefresher) Store(ctx context.Context, refreshToken string) error {
+	rf.sessions.Put(ctx, SessionStartedKey, rf.now().UTC())
+	if refreshToken == "" {
+		return nil
+	}
+	sealed, err := rf.sealer.Seal(refreshToken)
+	if err != nil {
+		return err
+	}
+	rf.sessions.Put(ctx, SessionRefreshTokenKey, sealed)
+	return nil
+}
+
+// Revoke revokes the session's refresh token at the provider, if any. Errors
+// are logged rather than returned so logout always completes locally.
+func (rf *SessionRefresher) Revoke(ctx context.Context) {
+	sealed := rf.sessions.GetString(ctx, SessionRefreshTokenKey)
+	if sealed == "" {
+		return
+	}
+	refreshToken, err := rf.sealer.Open(sealed)
+	if err != nil {
+		return
+	}
+	if err := rf.provider.RevokeRefreshToken(ctx, refreshToken); err != nil {
+		log.Printf("logout: revoke refresh token: %v", err)
+	}
+}
+
+// Extend is middleware that refreshes the session when it nears expiry. It
+// must run inside SessionManager.LoadAndSave.
+func (rf *SessionRefresher) Extend(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rf.maybeExtend(r.Context())
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (rf *SessionRefresher) maybeExtend(ctx context.Context) {
+	sealed := rf.sessions.GetString(ctx, SessionRefreshTokenKey)
+	if sealed == "" || rf.sessions.GetString(ctx, SessionUserIDKey) == "" {
+		return
+	}
+
+	now := rf.now()
+	if rf.sessions.Deadline(ctx).Sub(now) > rf.lifetime/2 {
+		return
+	}
+
+	started := rf.sessions.GetTime(ctx, SessionStartedKey)
+	hardLimit := started.Add(rf.maxLifetime)
+	if started.IsZero() || !now.Before(hardLimit) {
+		// Past the maximum lifetime: let the session expire naturally.
+		rf.sessions.Remove(ctx, SessionRefreshTokenKey)
+		return
+	}
+
+	refreshToken, err := rf.sealer.Open(sealed)
+	if err != nil {
+		rf.sessions.Remove(ctx, SessionRefreshTokenKey)
+		return
+	}
+	tokens, err := rf.provider.Refresh(ctx, refreshToken)
+	if err != nil {
+		// The IdP session is gone or the token was revoked; stop trying and
+		// let the current session run out.
+		log.Printf("session refresh: %v", err)
+		rf.sessions.Remove(ctx, SessionRefreshTokenKey)
+		return
+	}
+
+	if resealed, err := rf.sealer.Seal(tokens.RefreshToken); err == nil {
+		rf.sessions.Put(ctx, SessionRefreshTokenKey, resealed)
+	}
+	if tokens.RawIDToken != "" && rf.provider.RPLogoutEnabled() {
+		rf.sessions.Put(ctx, SessionIDTokenKey, tokens.RawIDToken)
+	}
+
+	deadline := now.Add(rf.lifetime)
+	if deadline.After(hardLimit) {
+		deadline = hardLimit
+	}
+	rf.sessions.SetDeadline(ctx, deadline)
+}
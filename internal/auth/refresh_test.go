@@ -0,0 +1,123 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/alexedwards/scs/v2"
+	"golang.org/x/oauth2"
+)
+
+func TestTokenSealer_RoundTrip(t *testing.T) {
+	s, err := newTokenSealer("test-secret")
+	if err != nil {
+		t.Fatalf("newTokenSealer: %v", err)
+	}
+	sealed, err := s.Seal("refresh-abc")
+	if err != nil {
+		t.Fatalf("Seal: %v", err)
+	}
+	if sealed == "refresh-abc" {
+		t.Fatal("sealed token equals plaintext")
+	}
+	got, err := s.Open(sealed)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	if got != "refresh-abc" {
+		t.Errorf("Open = %q, want %q", got, "refresh-abc")
+	}
+
+	other, _ := newTokenSealer("other-secret")
+	if _, err := other.Open(sealed); err == nil {
+		t.Error("Open with wrong key succeeded, want error")
+	}
+}
+
+// newRefreshTestEnv returns a SessionRefresher backed by an in-memory session
+// store and a fake token endpoint that rotates refresh tokens.
+func newRefreshTestEnv(t *testing.T, lifetime, maxLifetime time.Duration) (*SessionRefresher, context.Context, *int) {
+	t.Helper()
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token":"at","token_type":"Bearer","refresh_token":"rotated","expires_in":3600}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	p := &Provider{oauth2Config: oauth2.Config{
+		ClientID: "joe-links",
+		Endpoint: oauth2.Endpoint{TokenURL: srv.URL},
+	}}
+	sm := scs.New()
+	sm.Lifetime = lifetime
+
+	rf, err := NewSessionRefresher(p, sm, "test-secret", lifetime, maxLifetime)
+	if err != nil {
+		t.Fatalf("NewSessionRefresher: %v", err)
+	}
+	ctx, err := sm.Load(context.Background(), "")
+	if err != nil {
+		t.Fatalf("load session: %v", err)
+	}
+	sm.Put(ctx, SessionUserIDKey, "user-1")
+	if err := rf.Store(ctx, "original"); err != nil {
+		t.Fatalf("Store: %v", err)
+	}
+	return rf, ctx, &calls
+}
+
+func TestSessionRefresher_ExtendsNearExpiry(t *testing.T) {
+	rf, ctx, calls := newRefreshTestEnv(t, 24*time.Hour, 90*24*time.Hour)
+	deadline := rf.sessions.Deadline(ctx)
+
+	// Early in the session: no refresh.
+	rf.maybeExtend(ctx)
+	if *calls != 0 {
+		t.Fatalf("token endpoint calls = %d, want 0", *calls)
+	}
+
+	// Within the last half of the lifetime: refresh and extend.
+	later := deadline.Add(-time.Hour)
+	rf.now = func() time.Time { return later }
+	rf.maybeExtend(ctx)
+	if *calls != 1 {
+		t.Fatalf("token endpoint calls = %d, want 1", *calls)
+	}
+	if got, want := rf.sessions.Deadline(ctx), later.Add(24*time.Hour); !got.Equal(want) {
+		t.Errorf("deadline = %v, want %v", got, want)
+	}
+	rt, err := rf.sealer.Open(rf.sessions.GetString(ctx, SessionRefreshTokenKey))
+	if err != nil || rt != "rotated" {
+		t.Errorf("stored refresh token = %q (err %v), want %q", rt, err, "rotated")
+	}
+}
+
+func TestSessionRefresher_CapsAtMaxLifetime(t *testing.T) {
+	rf, ctx, calls := newRefreshTestEnv(t, 24*time.Hour, 36*time.Hour)
+	started := rf.sessions.GetTime(ctx, SessionStartedKey)
+
+	later := started.Add(23 * time.Hour)
+	rf.now = func() time.Time { return later }
+	rf.maybeExtend(ctx)
+	if *calls != 1 {
+		t.Fatalf("token endpoint calls = %d, want 1", *calls)
+	}
+	if got, want := rf.sessions.Deadline(ctx), started.Add(36*time.Hour); !got.Equal(want) {
+		t.Errorf("deadline = %v, want capped at %v", got, want)
+	}
+
+	// Past the hard limit the refresh token is dropped and not redeemed.
+	rf.now = func() time.Time { return started.Add(37 * time.Hour) }
+	rf.maybeExtend(ctx)
+	if *calls != 1 {
+		t.Errorf("token endpoint calls = %d, want 1", *calls)
+	}
+	if rf.sessions.Exists(ctx, SessionRefreshTokenKey) {
+		t.Error("refresh token still in session after max lifetime")
+	}
+}
@@ -0,0 +1,298 @@
+// Governing: SPEC-0001 REQ "SAML Authentication", REQ "Local User Records", REQ "Server-Side Sessions", ADR-0003
+package saml
+
+import (
+	"context"
+	"crypto"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/alexedwards/scs/v2"
+	gosaml "github.com/crewjam/saml"
+	"github.com/crewjam/saml/samlsp"
+
+	"github.com/joestump/joe-links/internal/auth"
+	"github.com/joestump/joe-links/internal/config"
+	"github.com/joestump/joe-links/internal/store"
+)
+
+const (
+	cookieRequestID = "__saml_request"
+	cookieRedirect  = "__saml_redirect"
+
+	// Paths are relative to JOE_SAML_ROOT_URL.
+	MetadataPath = "/auth/saml/metadata"
+	ACSPath      = "/auth/saml/acs"
+)
+
+// Attributes names the assertion attributes that carry user profile data.
+type Attributes struct {
+	Email  string
+	Name   string
+	Groups string
+}
+
+// NewServiceProvider loads the SP key pair, fetches the IdP metadata, and
+// returns a configured SAML service provider.
+func NewServiceProvider(ctx context.Context, cfg *config.Config) (*gosaml.ServiceProvider, error) {
+	keyPair, err := tls.LoadX509KeyPair(cfg.SAML.CertFile, cfg.SAML.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("load SAML key pair: %w", err)
+	}
+	cert, err := x509.ParseCertificate(keyPair.Certificate[0])
+	if err != nil {
+		return nil, fmt.Errorf("parse SAML certificate: %w", err)
+	}
+	signer, ok := keyPair.PrivateKey.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("SAML private key does not support signing")
+	}
+
+	idpMetadataURL, err := url.Parse(cfg.SAML.IDPMetadataURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid JOE_SAML_IDP_METADATA_URL: %w", err)
+	}
+	idpMetadata, err := samlsp.FetchMetadata(ctx, http.DefaultClient, *idpMetadataURL)
+	if err != nil {
+		return nil, fmt.Errorf("SAML IdP metadata fetch failed for %s: %w", cfg.SAML.IDPMetadataURL, err)
+	}
+
+	root, err := url.Parse(cfg.SAML.RootURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid JOE_SAML_ROOT_URL: %w", err)
+	}
+	metadataURL := root.ResolveReference(&url.URL{Path: MetadataPath})
+	acsURL := root.ResolveReference(&url.URL{Path: ACSPath})
+
+	entityID := cfg.SAML.EntityID
+	if entityID == "" {
+		entityID = metadataURL.String()
+	}
+
+	return &gosaml.ServiceProvider{
+		EntityID:    entityID,
+		Key:         signer,
+		Certificate: cert,
+		MetadataURL: *metadataURL,
+		AcsURL:      *acsURL,
+		IDPMetadata: idpMetadata,
+	}, nil
+}
+
+// Handlers provides HTTP handlers for the SAML SP-initiated login flow. They
+// mirror auth.Handlers: users are upserted and sessions created exactly as
+// for OIDC logins.
+type Handlers struct {
+	sp            *gosaml.ServiceProvider
+	sessions      *scs.SessionManager
+	users         *store.UserStore
+	attrs         Attributes
+	adminEmail    string
+	adminGroups   []string
+	secureCookies bool
+	setupAdmin    auth.AdminEmailFunc // admin email chosen in the setup wizard; nil = none
+}
+
+// NewHandlers creates SAML Handlers. Set secureCookies=false for local HTTP development.
+func NewHandlers(sp *gosaml.ServiceProvider, sm *scs.SessionManager, us *store.UserStore, attrs Attributes, adminEmail string, adminGroups []string, secureCookies bool) *Handlers {
+	return &Handlers{
+		sp:            sp,
+		sessions:      sm,
+		users:         us,
+		attrs:         attrs,
+		adminEmail:    adminEmail,
+		adminGroups:   adminGroups,
+		secureCookies: secureCookies,
+	}
+}
+
+// SetSetupAdmin makes the admin email chosen during setup grant the admin
+// role when JOE_ADMIN_EMAIL is unset.
+// Governing: SPEC-0001 REQ "First-Run Setup"
+func (h *Handlers) SetSetupAdmin(fn auth.AdminEmailFunc) {
+	h.setupAdmin = fn
+}
+
+// Metadata serves the SP metadata document for registration with the IdP.
+// GET /auth/saml/metadata
+func (h *Handlers) Metadata(w http.ResponseWriter, r *http.Request) {
+	buf, err := xml.MarshalIndent(h.sp.Metadata(), "", "  ")
+	if err != nil {
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/samlmetadata+xml")
+	_, _ = w.Write(buf)
+}
+
+// Login starts SP-initiated SSO with the HTTP-Redirect binding. The AuthnRequest
+// ID is kept in a short-lived cookie so the response can be bound to this browser.
+// GET /auth/login
+func (h *Handlers) Login(w http.ResponseWriter, r *http.Request) {
+	req, err := h.sp.MakeAuthenticationRequest(
+		h.sp.GetSSOBindingLocation(gosaml.HTTPRedirectBinding),
+		gosaml.HTTPRedirectBinding,
+		gosaml.HTTPPostBinding,
+	)
+	if err != nil {
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+	redirectURL, err := req.Redirect("", h.sp)
+	if err != nil {
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	redirect := r.URL.Query().Get("redirect")
+	if redirect == "" {
+		redirect = "/dashboard"
+	}
+	h.setPreAuthCookie(w, cookieRequestID, req.ID)
+	h.setPreAuthCookie(w, cookieRedirect, redirect)
+
+	http.Redirect(w, r, redirectURL.String(), http.StatusFound)
+}
+
+// ACS is the assertion consumer service. It validates the IdP's POSTed
+// response (signature, audience, validity window, and InResponseTo), then
+// upserts the user and creates a session.
+// POST /auth/saml/acs
+func (h *Handlers) ACS(w http.ResponseWriter, r *http.Request) {
+	requestCookie, err := r.Cookie(cookieRequestID)
+	if err != nil || requestCookie.Value == "" {
+		http.Error(w, "missing SAML request", http.StatusBadRequest)
+		return
+	}
+
+	assertion, err := h.sp.ParseResponse(r, []string{requestCookie.Value})
+	if err != nil {
+		if ire, ok := err.(*gosaml.InvalidResponseError); ok {
+			log.Printf("saml acs: %v", ire.PrivateErr)
+		}
+		http.Error(w, "authentication failed", http.StatusUnauthorized)
+		return
+	}
+	if assertion.Subject == nil || assertion.Subject.NameID == nil || assertion.Subject.NameID.Value == "" {
+		http.Error(w, "invalid assertion", http.StatusUnauthorized)
+		return
+	}
+
+	subject := assertion.Subject.NameID.Value
+	issuer := assertion.Issuer.Value
+	email := firstAttribute(assertion, h.attrs.Email)
+	if email == "" && strings.Contains(subject, "@") {
+		email = subject // emailAddress NameID format
+	}
+	name := firstAttribute(assertion, h.attrs.Name)
+	role := auth.ResolveRole(email, allAttributes(assertion, h.attrs.Groups), auth.EffectiveAdminEmail(r.Context(), h.adminEmail, h.setupAdmin), h.adminGroups)
+
+	// Upsert user record — role is enforced on every login.
+	user, err := h.users.Upsert(r.Context(), issuer, subject, email, name, role)
+	// Governing: SPEC-0001 REQ "Multi-Tenancy"
+	if errors.Is(err, store.ErrWrongTenant) {
+		http.Error(w, "this account belongs to another site; sign in there instead", http.StatusForbidden)
+		return
+	}
+	if err != nil {
+		log.Printf("saml acs: upsert user (issuer=%s subject=%s email=%s): %v", issuer, subject, email, err)
+		http.Error(w, "user record error", http.StatusInternalServerError)
+		return
+	}
+
+	if err := h.sessions.RenewToken(r.Context()); err != nil {
+		http.Error(w, "session error", http.StatusInternalServerError)
+		return
+	}
+	h.sessions.Put(r.Context(), auth.SessionUserIDKey, user.ID)
+	h.sessions.Put(r.Context(), auth.SessionRoleKey, user.Role)
+
+	redirect := "/dashboard"
+	if c, err := r.Cookie(cookieRedirect); err == nil && strings.HasPrefix(c.Value, "/") && !strings.HasPrefix(c.Value, "//") {
+		redirect = c.Value
+	}
+	h.clearCookie(w, cookieRequestID)
+	h.clearCookie(w, cookieRedirect)
+
+	http.Redirect(w, r, redirect, http.StatusFound)
+}
+
+// Logout destroys the local session and redirects to the login page.
+// POST /auth/logout
+func (h *Handlers) Logout(w http.ResponseWriter, r *http.Request) {
+	if err := h.sessions.Destroy(r.Context()); err != nil {
+		http.Error(w, "logout error", http.StatusInternalServerError)
+		return
+	}
+	auth.ClearSiteCache(w)
+	http.Redirect(w, r, "/auth/login", http.StatusFound)
+}
+
+// setPreAuthCookie stores login state. The IdP POSTs back cross-site, so in
+// production the cookie must be SameSite=None (which browsers only accept
+// with Secure).
+func (h *Handlers) setPreAuthCookie(w http.ResponseWriter, name, value string) {
+	sameSite := http.SameSiteDefaultMode
+	if h.secureCookies {
+		sameSite = http.SameSiteNoneMode
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     "/",
+		MaxAge:   300, // 5 minutes
+		HttpOnly: true,
+		Secure:   h.secureCookies,
+		SameSite: sameSite,
+	})
+}
+
+func (h *Handlers) clearCookie(w http.ResponseWriter, name string) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     name,
+		Value:    "",
+		Path:     "/",
+		MaxAge:   -1,
+		Expires:  time.Unix(0, 0),
+		HttpOnly: true,
+		Secure:   h.secureCookies,
+	})
+}
+
+// firstAttribute returns the first value of the named attribute, matching
+// either its Name or FriendlyName.
+func firstAttribute(a *gosaml.Assertion, name string) string {
+	if vals := allAttributes(a, name); len(vals) > 0 {
+		return vals[0]
+	}
+	return ""
+}
+
+// allAttributes returns every value of the named attribute.
+func allAttributes(a *gosaml.Assertion, name string) []string {
+	if name == "" {
+		return nil
+	}
+	var out []string
+	for _, stmt := range a.AttributeStatements {
+		for _, attr := range stmt.Attributes {
+			if attr.Name != name && attr.FriendlyName != name {
+				continue
+			}
+			for _, v := range attr.Values {
+				if v.Value != "" {
+					out = append(out, v.Value)
+				}
+			}
+		}
+	}
+	return out
+}
@@ -0,0 +1,129 @@
+package saml
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	gosaml "github.com/crewjam/saml"
+)
+
+func TestAllAttributes(t *testing.T) {
+	a := &gosaml.Assertion{AttributeStatements: []gosaml.AttributeStatement{{
+		Attributes: []gosaml.Attribute{
+			{Name: "urn:oid:0.9.2342.19200300.100.1.3", FriendlyName: "email", Values: []gosaml.AttributeValue{{Value: "alice@example.com"}}},
+			{Name: "groups", Values: []gosaml.AttributeValue{{Value: "eng"}, {Value: "admins"}}},
+		},
+	}}}
+
+	if got := firstAttribute(a, "email"); got != "alice@example.com" {
+		t.Errorf("firstAttribute(email) = %q, want %q", got, "alice@example.com")
+	}
+	if got := allAttributes(a, "groups"); len(got) != 2 || got[1] != "admins" {
+		t.Errorf("allAttributes(groups) = %v, want [eng admins]", got)
+	}
+	if got := firstAttribute(a, "displayName"); got != "" {
+		t.Errorf("firstAttribute(displayName) = %q, want empty", got)
+	}
+}
+
+// newTestServiceProvider returns an SP with a throwaway key pair and an IdP
+// that only advertises a redirect-binding SSO endpoint.
+func newTestServiceProvider(t *testing.T) *gosaml.ServiceProvider {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "joe-links"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("create certificate: %v", err)
+	}
+	cert, _ := x509.ParseCertificate(der)
+
+	metadataURL, _ := url.Parse("https://go.example.com" + MetadataPath)
+	acsURL, _ := url.Parse("https://go.example.com" + ACSPath)
+	return &gosaml.ServiceProvider{
+		EntityID:    metadataURL.String(),
+		Key:         key,
+		Certificate: cert,
+		MetadataURL: *metadataURL,
+		AcsURL:      *acsURL,
+		IDPMetadata: &gosaml.EntityDescriptor{
+			EntityID: "https://idp.example.com",
+			IDPSSODescriptors: []gosaml.IDPSSODescriptor{{
+				SingleSignOnServices: []gosaml.Endpoint{{
+					Binding:  gosaml.HTTPRedirectBinding,
+					Location: "https://idp.example.com/sso",
+				}},
+			}},
+		},
+	}
+}
+
+func TestMetadata(t *testing.T) {
+	h := NewHandlers(newTestServiceProvider(t), nil, nil, Attributes{}, "", nil, true)
+
+	w := httptest.NewRecorder()
+	h.Metadata(w, httptest.NewRequest(http.MethodGet, MetadataPath, nil))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "application/samlmetadata+xml" {
+		t.Errorf("Content-Type = %q, want application/samlmetadata+xml", ct)
+	}
+	if body := w.Body.String(); !strings.Contains(body, "https://go.example.com"+ACSPath) {
+		t.Errorf("metadata does not advertise the ACS URL:\n%s", body)
+	}
+}
+
+func TestLogin_RedirectsToIdP(t *testing.T) {
+	h := NewHandlers(newTestServiceProvider(t), nil, nil, Attributes{}, "", nil, true)
+
+	w := httptest.NewRecorder()
+	h.Login(w, httptest.NewRequest(http.MethodGet, "/auth/login?redirect=/links", nil))
+
+	if w.Code != http.StatusFound {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusFound)
+	}
+	if loc := w.Header().Get("Location"); !strings.HasPrefix(loc, "https://idp.example.com/sso?SAMLRequest=") {
+		t.Errorf("Location = %q, want IdP SSO URL with SAMLRequest", loc)
+	}
+
+	cookies := map[string]*http.Cookie{}
+	for _, c := range w.Result().Cookies() {
+		cookies[c.Name] = c
+	}
+	if c := cookies[cookieRequestID]; c == nil || c.Value == "" || c.SameSite != http.SameSiteNoneMode {
+		t.Errorf("request ID cookie = %+v, want non-empty SameSite=None cookie", c)
+	}
+	if c := cookies[cookieRedirect]; c == nil || c.Value != "/links" {
+		t.Errorf("redirect cookie = %+v, want /links", c)
+	}
+}
+
+func TestACS_MissingRequestCookie(t *testing.T) {
+	h := NewHandlers(newTestServiceProvider(t), nil, nil, Attributes{}, "", nil, true)
+
+	w := httptest.NewRecorder()
+	h.ACS(w, httptest.NewRequest(http.MethodPost, ACSPath, strings.NewReader("SAMLResponse=x")))
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+}
@@ -0,0 +1,111 @@
+// Governing: SPEC-0006 REQ "Token Scopes", ADR-0009
+package auth
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// API token scopes. Scopes narrow what a token may do; they never grant more
+// than the owning user's role allows (admin routes still require role=admin).
+const (
+	ScopeLinksRead  = "links:read"  // all non-admin GET requests
+	ScopeLinksWrite = "links:write" // all non-admin mutating requests
+	ScopeAdmin      = "admin"       // /api/v1/admin/* endpoints
+)
+
+// AllScopes lists every valid scope in display order. Tokens created without
+// an explicit scope list receive all of them.
+var AllScopes = []string{ScopeLinksRead, ScopeLinksWrite, ScopeAdmin}
+
+// TokenScopesContextKey holds the scopes of the API token that authenticated the request.
+const TokenScopesContextKey contextKey = "token_scopes"
+
+// ParseScopes validates and normalizes a scope list, dropping duplicates and
+// returning scopes in AllScopes order. An empty list yields AllScopes.
+func ParseScopes(scopes []string) ([]string, error) {
+	if len(scopes) == 0 {
+		return slices.Clone(AllScopes), nil
+	}
+	for _, s := range scopes {
+		if !slices.Contains(AllScopes, s) {
+			return nil, fmt.Errorf("unknown scope %q", s)
+		}
+	}
+	out := make([]string, 0, len(AllScopes))
+	for _, s := range AllScopes {
+		if slices.Contains(scopes, s) {
+			out = append(out, s)
+		}
+	}
+	return out, nil
+}
+
+// ScopeList returns the token's scopes as stored (space-separated).
+func (t *TokenRecord) ScopeList() []string {
+	return strings.Fields(t.Scopes)
+}
+
+// HasScope reports whether the token carries scope.
+func (t *TokenRecord) HasScope(scope string) bool {
+	return slices.Contains(t.ScopeList(), scope)
+}
+
+// ScopesFromContext returns the authenticating token's scopes. ok is false
+// when the request was not authenticated by an API token (e.g. a Swagger UI
+// session), in which case no scope restrictions apply.
+func ScopesFromContext(ctx context.Context) (scopes []string, ok bool) {
+	scopes, ok = ctx.Value(TokenScopesContextKey).([]string)
+	return scopes, ok
+}
+
+// HasScope reports whether the request may act with scope. Requests not
+// authenticated by an API token are unrestricted.
+func HasScope(ctx context.Context, scope string) bool {
+	scopes, ok := ScopesFromContext(ctx)
+	return !ok || slices.Contains(scopes, scope)
+}
+
+// RequireScope returns middleware that rejects token-authenticated requests
+// lacking scope with 403 Forbidden. Must be used after BearerTokenMiddleware.Authenticate.
+func RequireScope(scope string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !HasScope(r.Context(), scope) {
+				writeInsufficientScope(w, scope)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// RequireMethodScope returns middleware that requires read for safe methods
+// (GET, HEAD, OPTIONS) and write for everything else.
+func RequireMethodScope(read, write string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			scope := write
+			switch r.Method {
+			case http.MethodGet, http.MethodHead, http.MethodOptions:
+				scope = read
+			}
+			if !HasScope(r.Context(), scope) {
+				writeInsufficientScope(w, scope)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// writeInsufficientScope writes a 403 JSON response naming the missing scope.
+func writeInsufficientScope(w http.ResponseWriter, scope string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("WWW-Authenticate", `Bearer error="insufficient_scope", scope="`+scope+`"`)
+	w.WriteHeader(http.StatusForbidden)
+	_, _ = fmt.Fprintf(w, `{"error":"token lacks the %s scope","code":"INSUFFICIENT_SCOPE"}`, scope)
+}
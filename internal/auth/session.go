@@ -2,6 +2,7 @@
 package auth
 
 import (
+	"encoding/gob"
 	"net/http"
 	"time"
 
@@ -15,8 +16,18 @@ import (
 const (
 	SessionUserIDKey = "user_id"
 	SessionRoleKey   = "role"
+	// SessionIDTokenKey holds the raw ID token, used only as id_token_hint for
+	// RP-initiated logout. It is never set when that feature is disabled.
+	SessionIDTokenKey = "id_token"
 )
 
+// Session values are gob-encoded as interfaces, so every non-basic type put
+// in a session must be registered; SessionStepUpKey and SessionStartedKey
+// hold times.
+func init() {
+	gob.Register(time.Time{})
+}
+
 // NewSessionManager creates an SCS session manager backed by the application DB.
 // The driver parameter selects the appropriate store: "mysql", "postgres", or
 // "sqlite3" (default). Set secureCookies=false for local HTTP development.
@@ -36,3 +47,13 @@ func NewSessionManager(db *sqlx.DB, driver string, lifetime time.Duration, secur
 	sm.Cookie.SameSite = http.SameSiteLaxMode
 	return sm
 }
+
+// ClearSiteCache tells the browser to drop what it cached for the site when
+// the user signs out. The dashboard service worker only sees sign-outs made
+// from pages in its /dashboard scope, and keeps its pages in the Cache API,
+// which "storage" covers; the session cookie is already gone, so cookies are
+// left alone.
+// Governing: SPEC-0004 REQ "Progressive Web App"
+func ClearSiteCache(w http.ResponseWriter) {
+	w.Header().Set("Clear-Site-Data", `"cache", "storage"`)
+}
@@ -0,0 +1,154 @@
+// Governing: SPEC-0001 REQ "Session Management", ADR-0003
+package auth
+
+import (
+	"context"
+	"log"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/alexedwards/scs/v2"
+	"github.com/joestump/joe-links/internal/store"
+)
+
+// SessionTracker records each signed-in session alongside the session
+// manager's store, so users can see where they are signed in and revoke
+// sessions other than the one they are using.
+type SessionTracker struct {
+	sessions *scs.SessionManager
+	store    *store.SessionStore
+	now      func() time.Time
+
+	mu     sync.Mutex
+	seen   map[string]trackedSession // by session token
+	pruned time.Time
+}
+
+// trackedSession is the last use of a session the tracker recorded.
+type trackedSession struct {
+	userID string
+	at     time.Time
+}
+
+// untrackedPaths are prefixes of assets and machine endpoints, which say
+// nothing about where a session is in use.
+var untrackedPaths = []string{"/static/", "/branding/", "/metrics", "/robots.txt", "/sitemap.xml", "/api/docs/", "/api/openapi.json", "/api/csp-report"}
+
+// NewSessionTracker creates a SessionTracker.
+func NewSessionTracker(sm *scs.SessionManager, ss *store.SessionStore) *SessionTracker {
+	return &SessionTracker{sessions: sm, store: ss, now: time.Now, seen: make(map[string]trackedSession)}
+}
+
+// Track is middleware that records the current session's last use. It must
+// run inside SessionManager.LoadAndSave. Sessions that are not signed in, or
+// not yet committed to the store, are ignored, as are requests for
+// untrackedPaths. A session is written at most once per
+// store.SessionTouchInterval; uses in between are skipped in memory.
+func (t *SessionTracker) Track(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for _, p := range untrackedPaths {
+			if strings.HasPrefix(r.URL.Path, p) {
+				next.ServeHTTP(w, r)
+				return
+			}
+		}
+		ctx := r.Context()
+		userID := t.sessions.GetString(ctx, SessionUserIDKey)
+		if token := t.sessions.Token(ctx); token != "" && userID != "" && t.due(token, userID) {
+			ip := r.RemoteAddr
+			if host, _, err := net.SplitHostPort(ip); err == nil {
+				ip = host
+			}
+			if err := t.store.Touch(ctx, token, userID, store.HashIP(ip), r.UserAgent(), t.now().UTC()); err != nil {
+				log.Printf("session tracker: %v", err)
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// due reports whether token's use by userID should be written, noting it
+// as written if so. Entries older than the touch interval are dropped once
+// per interval, so the map only holds recently active sessions.
+func (t *SessionTracker) due(token, userID string) bool {
+	now := t.now()
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if now.Sub(t.pruned) >= store.SessionTouchInterval {
+		for k, s := range t.seen {
+			if now.Sub(s.at) >= store.SessionTouchInterval {
+				delete(t.seen, k)
+			}
+		}
+		t.pruned = now
+	}
+	if s, ok := t.seen[token]; ok && s.userID == userID && now.Sub(s.at) < store.SessionTouchInterval {
+		return false
+	}
+	t.seen[token] = trackedSession{userID: userID, at: now}
+	return true
+}
+
+// CurrentToken returns the token of the request's session, for telling the
+// current session apart in List.
+func (t *SessionTracker) CurrentToken(ctx context.Context) string {
+	return t.sessions.Token(ctx)
+}
+
+// List returns userID's active sessions, most recently used first. Sessions
+// that have expired or been destroyed are forgotten along the way.
+func (t *SessionTracker) List(ctx context.Context, userID string) ([]*store.UserSession, error) {
+	all, err := t.store.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	active := all[:0]
+	for _, s := range all {
+		_, found, err := t.sessions.Store.Find(s.Token)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			if err := t.store.Delete(ctx, s.Token); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		active = append(active, s)
+	}
+	return active, nil
+}
+
+// Revoke signs out userID's session with the given ID. It returns
+// store.ErrNotFound if the user has no such session.
+func (t *SessionTracker) Revoke(ctx context.Context, userID, id string) error {
+	s, err := t.store.Get(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+	if err := t.sessions.Store.Delete(s.Token); err != nil {
+		return err
+	}
+	return t.store.Delete(ctx, s.Token)
+}
+
+// RevokeAll signs out every one of userID's sessions, including the current
+// one.
+func (t *SessionTracker) RevokeAll(ctx context.Context, userID string) error {
+	all, err := t.store.ListByUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	for _, s := range all {
+		if err := t.sessions.Store.Delete(s.Token); err != nil {
+			return err
+		}
+		if err := t.store.Delete(ctx, s.Token); err != nil {
+			return err
+		}
+	}
+	return t.sessions.Destroy(ctx)
+}
@@ -0,0 +1,36 @@
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/joestump/joe-links/internal/store"
+)
+
+// Governing: SPEC-0001 REQ "Session Management"
+func TestSessionTracker_Due(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	tr := NewSessionTracker(nil, nil)
+	tr.now = func() time.Time { return now }
+
+	if !tr.due("tok", "u1") {
+		t.Fatal("first use not written")
+	}
+	now = now.Add(store.SessionTouchInterval / 2)
+	if tr.due("tok", "u1") {
+		t.Error("use within the interval written again")
+	}
+	if !tr.due("tok", "u2") {
+		t.Error("use by another user not written")
+	}
+	if !tr.due("other", "u1") {
+		t.Error("another session's first use not written")
+	}
+	now = now.Add(store.SessionTouchInterval)
+	if !tr.due("tok", "u2") {
+		t.Error("use after the interval not written")
+	}
+	if _, ok := tr.seen["other"]; ok {
+		t.Error("stale session not pruned")
+	}
+}
@@ -1,21 +1,37 @@
 // Governing: SPEC-0006 REQ "Bearer Token Middleware", REQ "No Web UI Session on API Routes", ADR-0009
+// Governing: SPEC-0007 REQ "Swagger UI Session Try-It"
 package auth
 
 import (
 	"context"
+	"errors"
+	"net"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/alexedwards/scs/v2"
 	"github.com/joestump/joe-links/internal/store"
 )
 
+// SessionAuthHeader opts a browser request into session-cookie authentication
+// on API routes. Only the Swagger UI sets it; because it is a custom header,
+// cross-site pages cannot send it without a CORS preflight.
+const SessionAuthHeader = "X-Joe-Session-Auth"
+
+// TokenIDContextKey holds the ID of the API token that authenticated the request.
+const TokenIDContextKey contextKey = "token_id"
+
 // BearerTokenMiddleware authenticates API requests via Bearer token.
-// It explicitly rejects session cookies — only API tokens are accepted.
+// Session cookies are rejected unless the request carries SessionAuthHeader
+// and a session manager has been attached with WithSessions.
 // Governing: SPEC-0006 REQ "No Web UI Session on API Routes"
 type BearerTokenMiddleware struct {
-	tokens TokenStore
-	users  *store.UserStore
+	tokens   TokenStore
+	users    *store.UserStore
+	sessions *scs.SessionManager // nil disables the Swagger UI session fallback
+	lockout  *TokenLockout       // nil disables brute-force lockout
 }
 
 // NewBearerTokenMiddleware creates a new BearerTokenMiddleware.
@@ -23,6 +39,21 @@ func NewBearerTokenMiddleware(ts TokenStore, us *store.UserStore) *BearerTokenMi
 	return &BearerTokenMiddleware{tokens: ts, users: us}
 }
 
+// WithSessions enables session-cookie authentication for requests that carry
+// SessionAuthHeader, so Swagger UI "Try it out" works for logged-in users.
+// Governing: SPEC-0007 REQ "Swagger UI Session Try-It"
+func (m *BearerTokenMiddleware) WithSessions(sm *scs.SessionManager) *BearerTokenMiddleware {
+	m.sessions = sm
+	return m
+}
+
+// WithLockout locks out client addresses that fail too many token checks.
+// Governing: SPEC-0006 REQ "Token Brute-Force Lockout"
+func (m *BearerTokenMiddleware) WithLockout(l *TokenLockout) *BearerTokenMiddleware {
+	m.lockout = l
+	return m
+}
+
 // Authenticate is an http.Handler middleware that extracts and validates a Bearer token.
 // WHEN valid: injects the token owner's *store.User into context and fires an async last_used_at update.
 // WHEN invalid/missing/expired/revoked: returns 401 with {"error": "unauthorized"}.
@@ -31,57 +62,130 @@ func (m *BearerTokenMiddleware) Authenticate(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Extract Bearer token from Authorization header.
 		authHeader := r.Header.Get("Authorization")
-		if authHeader == "" || !strings.HasPrefix(authHeader, "Bearer ") {
-			writeUnauthorized(w)
+		if authHeader == "" && m.sessions != nil && r.Header.Get(SessionAuthHeader) != "" {
+			m.authenticateSession(w, r, next)
 			return
 		}
-		plaintext := strings.TrimPrefix(authHeader, "Bearer ")
-		if plaintext == "" {
+		if authHeader == "" {
 			writeUnauthorized(w)
 			return
 		}
-
-		// Hash the plaintext token and look it up.
-		hash := HashToken(plaintext)
-		rec, err := m.tokens.GetByHash(r.Context(), hash)
-		if err != nil {
-			writeUnauthorized(w)
-			return
+		// A malformed header is checked as an empty token, so it counts as a
+		// failure too. RemoteAddr only comes from forwarding headers when a
+		// trusted proxy sent them, so clients cannot rotate their own address.
+		plaintext, ok := strings.CutPrefix(authHeader, "Bearer ")
+		if !ok {
+			plaintext = ""
 		}
-
-		// Reject revoked tokens.
-		// Governing: SPEC-0006 REQ "Bearer Token Middleware" — revoked_at IS NULL
-		if rec.RevokedAt.Valid {
-			writeUnauthorized(w)
-			return
+		ip := r.RemoteAddr
+		if host, _, err := net.SplitHostPort(ip); err == nil {
+			ip = host
 		}
-
-		// Reject expired tokens.
-		// Governing: SPEC-0006 REQ "Bearer Token Middleware" — expires_at IS NULL OR expires_at > NOW()
-		if rec.ExpiresAt.Valid && rec.ExpiresAt.Time.Before(time.Now()) {
-			writeUnauthorized(w)
+		ctx, err := m.AuthenticateTokenFrom(r.Context(), ip, plaintext)
+		if errors.Is(err, ErrLockedOut) {
+			wait, _ := m.lockout.Locked(ip)
+			w.Header().Set("Retry-After", strconv.Itoa(int(wait.Seconds())+1))
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte(`{"error":"too many failed authentication attempts","code":"TOO_MANY_ATTEMPTS"}`))
 			return
 		}
-
-		// Load the user who owns the token.
-		user, err := m.users.GetByID(r.Context(), rec.UserID)
 		if err != nil {
 			writeUnauthorized(w)
 			return
 		}
-
-		// Update last_used_at asynchronously to avoid write overhead on every read.
-		// Governing: ADR-0009 (async last_used_at)
-		go func() {
-			_ = m.tokens.UpdateLastUsed(context.Background(), rec.ID)
-		}()
-
-		// Inject user into context using the same key as session-based auth.
-		ctx := context.WithValue(r.Context(), UserContextKey, user)
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
 
+// ErrInvalidToken is returned by AuthenticateToken for a missing, unknown,
+// revoked, or expired token, or one whose owner no longer exists.
+var ErrInvalidToken = errors.New("invalid API token")
+
+// AuthenticateToken validates a plaintext API token and returns ctx carrying
+// its owner, ID, and scopes under the same keys Authenticate uses, so other
+// transports (the gRPC service) authenticate exactly like the REST API.
+// Governing: SPEC-0006 REQ "Bearer Token Middleware"
+func (m *BearerTokenMiddleware) AuthenticateToken(ctx context.Context, plaintext string) (context.Context, error) {
+	if plaintext == "" {
+		return nil, ErrInvalidToken
+	}
+
+	// Hash the plaintext token and look it up.
+	rec, err := m.tokens.GetByHash(ctx, HashToken(plaintext))
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	// Reject revoked tokens.
+	// Governing: SPEC-0006 REQ "Bearer Token Middleware" — revoked_at IS NULL
+	if rec.RevokedAt.Valid {
+		return nil, ErrInvalidToken
+	}
+
+	// Reject expired tokens.
+	// Governing: SPEC-0006 REQ "Bearer Token Middleware" — expires_at IS NULL OR expires_at > NOW()
+	if rec.ExpiresAt.Valid && rec.ExpiresAt.Time.Before(time.Now()) {
+		return nil, ErrInvalidToken
+	}
+
+	// Load the user who owns the token.
+	user, err := m.users.GetByID(ctx, rec.UserID)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	// Update last_used_at asynchronously to avoid write overhead on every read.
+	// Governing: ADR-0009 (async last_used_at)
+	go func() {
+		_ = m.tokens.UpdateLastUsed(context.Background(), rec.ID)
+	}()
+
+	// Inject user into context using the same key as session-based auth.
+	ctx = context.WithValue(ctx, UserContextKey, user)
+	ctx = context.WithValue(ctx, TokenIDContextKey, rec.ID)
+	ctx = context.WithValue(ctx, TokenScopesContextKey, rec.ScopeList())
+	return ctx, nil
+}
+
+// AuthenticateTokenFrom is AuthenticateToken for a client at address ip:
+// it returns ErrLockedOut without checking the token while ip is locked out,
+// and counts a failed check against ip.
+// Governing: SPEC-0006 REQ "Token Brute-Force Lockout"
+func (m *BearerTokenMiddleware) AuthenticateTokenFrom(ctx context.Context, ip, plaintext string) (context.Context, error) {
+	if _, locked := m.lockout.Locked(ip); locked {
+		return nil, ErrLockedOut
+	}
+	ctx, err := m.AuthenticateToken(ctx, plaintext)
+	if err != nil {
+		m.lockout.Fail(ip)
+	}
+	return ctx, err
+}
+
+// TokenIDFromContext returns the authenticating API token's ID, or "" when the
+// request was authenticated some other way.
+func TokenIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(TokenIDContextKey).(string)
+	return id
+}
+
+// authenticateSession authenticates a Swagger UI request from its session cookie.
+func (m *BearerTokenMiddleware) authenticateSession(w http.ResponseWriter, r *http.Request, next http.Handler) {
+	userID := m.sessions.GetString(r.Context(), SessionUserIDKey)
+	if userID == "" {
+		writeUnauthorized(w)
+		return
+	}
+	user, err := m.users.GetByID(r.Context(), userID)
+	if err != nil {
+		writeUnauthorized(w)
+		return
+	}
+	ctx := context.WithValue(r.Context(), UserContextKey, user)
+	next.ServeHTTP(w, r.WithContext(ctx))
+}
+
 // writeUnauthorized writes a 401 JSON response.
 func writeUnauthorized(w http.ResponseWriter) {
 	w.Header().Set("Content-Type", "application/json")
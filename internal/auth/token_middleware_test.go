@@ -8,6 +8,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/alexedwards/scs/v2"
 	"github.com/jmoiron/sqlx"
 	"github.com/joestump/joe-links/internal/auth"
 	"github.com/joestump/joe-links/internal/store"
@@ -20,7 +21,7 @@ type mockTokenStore struct {
 	updateLastUsed func(ctx context.Context, id string) error
 }
 
-func (m *mockTokenStore) Create(ctx context.Context, userID, name, tokenHash string, expiresAt *time.Time) (*auth.TokenRecord, error) {
+func (m *mockTokenStore) Create(ctx context.Context, userID, name, tokenHash string, scopes []string, expiresAt *time.Time) (*auth.TokenRecord, error) {
 	return nil, nil
 }
 
@@ -239,3 +240,105 @@ func setupTestDBWithUser(t *testing.T, user *store.User) *sqlx.DB {
 	}
 	return db
 }
+
+// loggedIn wraps next so the request carries an SCS session for userID.
+func loggedIn(sm *scs.SessionManager, userID string, next http.Handler) http.Handler {
+	return sm.LoadAndSave(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sm.Put(r.Context(), auth.SessionUserIDKey, userID)
+		next.ServeHTTP(w, r)
+	}))
+}
+
+func TestBearerTokenMiddleware_SessionRequiresOptInHeader(t *testing.T) {
+	testUser := &store.User{ID: "user-1", Email: "test@example.com", Role: "user"}
+	us := store.NewUserStore(setupTestDBWithUser(t, testUser))
+	sm := scs.New()
+
+	mw := auth.NewBearerTokenMiddleware(&mockTokenStore{}, us).WithSessions(sm)
+	handler := loggedIn(sm, testUser.ID, mw.Authenticate(okHandler()))
+
+	req := httptest.NewRequest("GET", "/api/v1/links", nil)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("without %s: status = %d, want %d", auth.SessionAuthHeader, rec.Code, http.StatusUnauthorized)
+	}
+
+	req = httptest.NewRequest("GET", "/api/v1/links", nil)
+	req.Header.Set(auth.SessionAuthHeader, "1")
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Errorf("with %s: status = %d, want %d", auth.SessionAuthHeader, rec.Code, http.StatusOK)
+	}
+}
+
+func TestBearerTokenMiddleware_SessionFallbackDisabled(t *testing.T) {
+	testUser := &store.User{ID: "user-1", Email: "test@example.com", Role: "user"}
+	us := store.NewUserStore(setupTestDBWithUser(t, testUser))
+	sm := scs.New()
+
+	mw := auth.NewBearerTokenMiddleware(&mockTokenStore{}, us)
+	handler := loggedIn(sm, testUser.ID, mw.Authenticate(okHandler()))
+
+	req := httptest.NewRequest("GET", "/api/v1/links", nil)
+	req.Header.Set(auth.SessionAuthHeader, "1")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+}
+
+// Governing: SPEC-0006 REQ "Token Brute-Force Lockout"
+func TestBearerTokenMiddleware_Lockout(t *testing.T) {
+	checks := 0
+	ts := &mockTokenStore{
+		getByHash: func(ctx context.Context, h string) (*auth.TokenRecord, error) {
+			checks++
+			return nil, store.ErrNotFound
+		},
+	}
+	mw := auth.NewBearerTokenMiddleware(ts, store.NewUserStore(nil)).WithLockout(auth.NewTokenLockout(3, time.Minute, 15*time.Minute))
+	handler := mw.Authenticate(okHandler())
+
+	serve := func(addr string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", "/api/v1/links", nil)
+		req.RemoteAddr = addr
+		req.Header.Set("Authorization", "Bearer guessed-token")
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	for i := 0; i < 3; i++ {
+		if rec := serve("203.0.113.5:1000"); rec.Code != http.StatusUnauthorized {
+			t.Fatalf("attempt %d: status = %d, want %d", i+1, rec.Code, http.StatusUnauthorized)
+		}
+	}
+	rec := serve("203.0.113.5:1001")
+	if rec.Code != http.StatusTooManyRequests || rec.Header().Get("Retry-After") == "" {
+		t.Errorf("locked out: status = %d, Retry-After = %q", rec.Code, rec.Header().Get("Retry-After"))
+	}
+	if checks != 3 {
+		t.Errorf("token store consulted %d times, want 3", checks)
+	}
+	if rec := serve("198.51.100.9:1000"); rec.Code != http.StatusUnauthorized {
+		t.Errorf("other address: status = %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+
+	// Malformed headers count as failures too.
+	for _, header := range []string{"Basic Z3Vlc3M=", "bearer guessed-token", "Token guessed-token"} {
+		req := httptest.NewRequest("GET", "/api/v1/links", nil)
+		req.RemoteAddr = "192.0.2.7:1000"
+		req.Header.Set("Authorization", header)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		if rec.Code != http.StatusUnauthorized {
+			t.Errorf("%q: status = %d, want %d", header, rec.Code, http.StatusUnauthorized)
+		}
+	}
+	if rec := serve("192.0.2.7:1000"); rec.Code != http.StatusTooManyRequests {
+		t.Errorf("after malformed headers: status = %d, want %d", rec.Code, http.StatusTooManyRequests)
+	}
+}
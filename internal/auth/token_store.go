@@ -8,10 +8,12 @@ import (
 	"database/sql"
 	"encoding/hex"
 	"math/big"
+	"strings"
 	"time"
 
-	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
+	"github.com/joestump/joe-links/internal/ids"
+	"github.com/joestump/joe-links/internal/metrics"
 	"github.com/joestump/joe-links/internal/store"
 )
 
@@ -25,11 +27,12 @@ type TokenRecord struct {
 	ExpiresAt  sql.NullTime `db:"expires_at"`
 	CreatedAt  time.Time    `db:"created_at"`
 	RevokedAt  sql.NullTime `db:"revoked_at"`
+	Scopes     string       `db:"scopes"` // space-separated; see ScopeList
 }
 
 // TokenStore defines operations for API token management.
 type TokenStore interface {
-	Create(ctx context.Context, userID, name, tokenHash string, expiresAt *time.Time) (*TokenRecord, error)
+	Create(ctx context.Context, userID, name, tokenHash string, scopes []string, expiresAt *time.Time) (*TokenRecord, error)
 	GetByHash(ctx context.Context, hash string) (*TokenRecord, error)
 	ListByUser(ctx context.Context, userID string) ([]*TokenRecord, error)
 	Revoke(ctx context.Context, id, userID string) error
@@ -49,9 +52,14 @@ func NewSQLTokenStore(db *sqlx.DB) *SQLTokenStore {
 // q rebinds ? placeholders to the driver's native format ($1,$2,... for PostgreSQL).
 func (s *SQLTokenStore) q(query string) string { return s.db.Rebind(query) }
 
-// Create inserts a new API token record.
-func (s *SQLTokenStore) Create(ctx context.Context, userID, name, tokenHash string, expiresAt *time.Time) (*TokenRecord, error) {
-	id := uuid.New().String()
+// Create inserts a new API token record. A nil or empty scopes list grants AllScopes.
+// Governing: SPEC-0006 REQ "Token Scopes"
+func (s *SQLTokenStore) Create(ctx context.Context, userID, name, tokenHash string, scopes []string, expiresAt *time.Time) (*TokenRecord, error) {
+	scopes, err := ParseScopes(scopes)
+	if err != nil {
+		return nil, err
+	}
+	id := ids.New()
 	now := time.Now().UTC()
 
 	var exp sql.NullTime
@@ -59,10 +67,10 @@ func (s *SQLTokenStore) Create(ctx context.Context, userID, name, tokenHash stri
 		exp = sql.NullTime{Time: *expiresAt, Valid: true}
 	}
 
-	_, err := s.db.ExecContext(ctx, s.q(`
-		INSERT INTO api_tokens (id, user_id, name, token_hash, expires_at, created_at)
-		VALUES (?, ?, ?, ?, ?, ?)
-	`), id, userID, name, tokenHash, exp, now)
+	_, err = s.db.ExecContext(ctx, s.q(`
+		INSERT INTO api_tokens (id, user_id, name, token_hash, scopes, expires_at, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`), id, userID, name, tokenHash, strings.Join(scopes, " "), exp, now)
 	if err != nil {
 		return nil, err
 	}
@@ -77,6 +85,7 @@ func (s *SQLTokenStore) Create(ctx context.Context, userID, name, tokenHash stri
 
 // GetByHash returns the token record matching the given hash, or store.ErrNotFound.
 func (s *SQLTokenStore) GetByHash(ctx context.Context, hash string) (*TokenRecord, error) {
+	defer metrics.ObserveDBQuery("token_get_by_hash", time.Now())
 	var rec TokenRecord
 	err := s.db.GetContext(ctx, &rec, s.q(`SELECT * FROM api_tokens WHERE token_hash = ?`), hash)
 	if err == sql.ErrNoRows {
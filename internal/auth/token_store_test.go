@@ -56,7 +56,7 @@ func TestTokenStore_CreateAndGetByHash(t *testing.T) {
 		t.Fatalf("GenerateToken: %v", err)
 	}
 
-	rec, err := ts.Create(ctx, userID, "test-token", hash, nil)
+	rec, err := ts.Create(ctx, userID, "test-token", hash, nil, nil)
 	if err != nil {
 		t.Fatalf("Create: %v", err)
 	}
@@ -91,7 +91,7 @@ func TestTokenStore_Revoke(t *testing.T) {
 	ctx := context.Background()
 
 	_, hash, _ := auth.GenerateToken()
-	rec, err := ts.Create(ctx, userID, "revoke-me", hash, nil)
+	rec, err := ts.Create(ctx, userID, "revoke-me", hash, nil, nil)
 	if err != nil {
 		t.Fatalf("Create: %v", err)
 	}
@@ -126,7 +126,7 @@ func TestTokenStore_ExpiredToken(t *testing.T) {
 
 	_, hash, _ := auth.GenerateToken()
 	expired := time.Now().Add(-1 * time.Hour)
-	rec, err := ts.Create(ctx, userID, "expired-token", hash, &expired)
+	rec, err := ts.Create(ctx, userID, "expired-token", hash, nil, &expired)
 	if err != nil {
 		t.Fatalf("Create: %v", err)
 	}
@@ -152,13 +152,13 @@ func TestTokenStore_ListByUser(t *testing.T) {
 	ctx := context.Background()
 
 	_, hash1, _ := auth.GenerateToken()
-	_, err := ts.Create(ctx, userID, "token-1", hash1, nil)
+	_, err := ts.Create(ctx, userID, "token-1", hash1, nil, nil)
 	if err != nil {
 		t.Fatalf("Create token-1: %v", err)
 	}
 
 	_, hash2, _ := auth.GenerateToken()
-	_, err = ts.Create(ctx, userID, "token-2", hash2, nil)
+	_, err = ts.Create(ctx, userID, "token-2", hash2, nil, nil)
 	if err != nil {
 		t.Fatalf("Create token-2: %v", err)
 	}
@@ -177,7 +177,7 @@ func TestTokenStore_UpdateLastUsed(t *testing.T) {
 	ctx := context.Background()
 
 	_, hash, _ := auth.GenerateToken()
-	rec, err := ts.Create(ctx, userID, "track-usage", hash, nil)
+	rec, err := ts.Create(ctx, userID, "track-usage", hash, nil, nil)
 	if err != nil {
 		t.Fatalf("Create: %v", err)
 	}
@@ -0,0 +1,442 @@
+// Governing: SPEC-0001 REQ "WebAuthn Step-Up"
+package auth
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/alexedwards/scs/v2"
+	"github.com/joestump/joe-links/internal/store"
+)
+
+const (
+	// SessionWebAuthnChallengeKey holds the challenge of the ceremony in
+	// progress; it is removed when the ceremony finishes.
+	SessionWebAuthnChallengeKey = "webauthn_challenge"
+	// SessionStepUpKey records when the user last confirmed a passkey.
+	SessionStepUpKey = "step_up_at"
+
+	// StepUpWindow is how long a passkey confirmation allows sensitive
+	// actions before the user must confirm again.
+	StepUpWindow = 5 * time.Minute
+)
+
+// COSE algorithm identifiers accepted for passkeys.
+const (
+	algES256 = -7
+	algEdDSA = -8
+	algRS256 = -257
+)
+
+// Authenticator data flags.
+const (
+	flagUserPresent  = 0x01
+	flagUserVerified = 0x04
+	flagAttested     = 0x40
+)
+
+var (
+	// ErrNoPasskeys is returned when an assertion is requested from a user
+	// with no registered passkeys.
+	ErrNoPasskeys = errors.New("no passkeys registered")
+	// errWebAuthn wraps every reason a ceremony response is rejected.
+	errWebAuthn = errors.New("webauthn verification failed")
+)
+
+// WebAuthn runs passkey registration and assertion ceremonies and tracks
+// when the session last confirmed a passkey. Only attestation "none" is
+// supported: the browser reports the new credential's public key through
+// AuthenticatorAttestationResponse.getPublicKey(), so no CBOR is parsed.
+type WebAuthn struct {
+	sessions *scs.SessionManager
+	creds    *store.WebAuthnStore
+	rpID     string // "" uses the request's hostname
+	origin   string // "" uses the request's scheme and host
+	now      func() time.Time
+}
+
+// NewWebAuthn creates a WebAuthn. rpID and origin may be empty to follow the
+// host each request was made to.
+func NewWebAuthn(sm *scs.SessionManager, creds *store.WebAuthnStore, rpID, origin string) *WebAuthn {
+	return &WebAuthn{sessions: sm, creds: creds, rpID: rpID, origin: strings.TrimSuffix(origin, "/"), now: time.Now}
+}
+
+// CredentialDescriptor names a registered credential in ceremony options.
+type CredentialDescriptor struct {
+	Type string `json:"type"`
+	ID   string `json:"id"` // base64url
+}
+
+// CreationOptions are the publicKey options for navigator.credentials.create.
+// Binary fields are base64url; the page decodes them.
+type CreationOptions struct {
+	Challenge string `json:"challenge"`
+	RP        struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"rp"`
+	User struct {
+		ID          string `json:"id"`
+		Name        string `json:"name"`
+		DisplayName string `json:"displayName"`
+	} `json:"user"`
+	PubKeyCredParams []struct {
+		Type string `json:"type"`
+		Alg  int    `json:"alg"`
+	} `json:"pubKeyCredParams"`
+	Timeout                int                    `json:"timeout"`
+	ExcludeCredentials     []CredentialDescriptor `json:"excludeCredentials"`
+	AuthenticatorSelection struct {
+		ResidentKey      string `json:"residentKey"`
+		UserVerification string `json:"userVerification"`
+	} `json:"authenticatorSelection"`
+	Attestation string `json:"attestation"`
+}
+
+// RequestOptions are the publicKey options for navigator.credentials.get.
+type RequestOptions struct {
+	Challenge        string                 `json:"challenge"`
+	RPID             string                 `json:"rpId"`
+	Timeout          int                    `json:"timeout"`
+	AllowCredentials []CredentialDescriptor `json:"allowCredentials"`
+	UserVerification string                 `json:"userVerification"`
+}
+
+// RegistrationResponse is the page's encoding of a new PublicKeyCredential.
+type RegistrationResponse struct {
+	ID       string `json:"id"`
+	Response struct {
+		ClientDataJSON     string `json:"clientDataJSON"`
+		AuthenticatorData  string `json:"authenticatorData"`
+		PublicKey          string `json:"publicKey"`
+		PublicKeyAlgorithm int64  `json:"publicKeyAlgorithm"`
+	} `json:"response"`
+}
+
+// AssertionResponse is the page's encoding of an asserted PublicKeyCredential.
+type AssertionResponse struct {
+	ID       string `json:"id"`
+	Response struct {
+		ClientDataJSON    string `json:"clientDataJSON"`
+		AuthenticatorData string `json:"authenticatorData"`
+		Signature         string `json:"signature"`
+	} `json:"response"`
+}
+
+// BeginRegistration starts registering a passkey for user and returns the
+// options for the browser. rpName is shown by the authenticator.
+func (wa *WebAuthn) BeginRegistration(r *http.Request, user *store.User, rpName string) (*CreationOptions, error) {
+	challenge, err := wa.newChallenge(r.Context())
+	if err != nil {
+		return nil, err
+	}
+	existing, err := wa.creds.ListByUser(r.Context(), user.ID)
+	if err != nil {
+		return nil, err
+	}
+	opts := &CreationOptions{Challenge: challenge, Timeout: 60000, Attestation: "none"}
+	opts.RP.ID = wa.rpIDFor(r)
+	opts.RP.Name = rpName
+	opts.User.ID = b64.EncodeToString([]byte(user.ID))
+	opts.User.Name = user.Email
+	opts.User.DisplayName = user.DisplayName
+	for _, alg := range []int{algES256, algEdDSA, algRS256} {
+		opts.PubKeyCredParams = append(opts.PubKeyCredParams, struct {
+			Type string `json:"type"`
+			Alg  int    `json:"alg"`
+		}{"public-key", alg})
+	}
+	opts.ExcludeCredentials = descriptors(existing)
+	opts.AuthenticatorSelection.ResidentKey = "preferred"
+	opts.AuthenticatorSelection.UserVerification = "required"
+	return opts, nil
+}
+
+// FinishRegistration verifies the browser's response to BeginRegistration
+// and saves the passkey under name.
+func (wa *WebAuthn) FinishRegistration(r *http.Request, user *store.User, name string, resp RegistrationResponse) (*store.WebAuthnCredential, error) {
+	challenge := wa.sessions.PopString(r.Context(), SessionWebAuthnChallengeKey)
+	if err := wa.verifyClientData(r, resp.Response.ClientDataJSON, "webauthn.create", challenge); err != nil {
+		return nil, err
+	}
+	authData, err := decodeB64(resp.Response.AuthenticatorData)
+	if err != nil {
+		return nil, fmt.Errorf("%w: authenticator data: %v", errWebAuthn, err)
+	}
+	signCount, err := wa.verifyAuthData(r, authData)
+	if err != nil {
+		return nil, err
+	}
+	// The attested credential data follows the fixed 37-byte header:
+	// AAGUID (16), credential ID length (2), credential ID.
+	if authData[32]&flagAttested == 0 || len(authData) < 55 {
+		return nil, fmt.Errorf("%w: no attested credential", errWebAuthn)
+	}
+	idLen := int(binary.BigEndian.Uint16(authData[53:55]))
+	if len(authData) < 55+idLen {
+		return nil, fmt.Errorf("%w: truncated credential ID", errWebAuthn)
+	}
+	credID := b64.EncodeToString(authData[55 : 55+idLen])
+	if credID != strings.TrimRight(resp.ID, "=") {
+		return nil, fmt.Errorf("%w: credential ID mismatch", errWebAuthn)
+	}
+	der, err := decodeB64(resp.Response.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("%w: public key: %v", errWebAuthn, err)
+	}
+	pub, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		return nil, fmt.Errorf("%w: public key: %v", errWebAuthn, err)
+	}
+	if !algMatches(resp.Response.PublicKeyAlgorithm, pub) {
+		return nil, fmt.Errorf("%w: unsupported algorithm %d", errWebAuthn, resp.Response.PublicKeyAlgorithm)
+	}
+	cred := &store.WebAuthnCredential{
+		UserID:       user.ID,
+		CredentialID: credID,
+		PublicKey:    b64.EncodeToString(der),
+		Alg:          resp.Response.PublicKeyAlgorithm,
+		SignCount:    int64(signCount),
+		Name:         name,
+	}
+	if err := wa.creds.Create(r.Context(), cred); err != nil {
+		return nil, err
+	}
+	return cred, nil
+}
+
+// BeginAssertion starts a passkey confirmation for userID. It returns
+// ErrNoPasskeys if the user has none.
+func (wa *WebAuthn) BeginAssertion(r *http.Request, userID string) (*RequestOptions, error) {
+	existing, err := wa.creds.ListByUser(r.Context(), userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(existing) == 0 {
+		return nil, ErrNoPasskeys
+	}
+	challenge, err := wa.newChallenge(r.Context())
+	if err != nil {
+		return nil, err
+	}
+	return &RequestOptions{
+		Challenge:        challenge,
+		RPID:             wa.rpIDFor(r),
+		Timeout:          60000,
+		AllowCredentials: descriptors(existing),
+		UserVerification: "required",
+	}, nil
+}
+
+// FinishAssertion verifies the browser's response to BeginAssertion and, on
+// success, marks the session as stepped up for StepUpWindow.
+func (wa *WebAuthn) FinishAssertion(r *http.Request, userID string, resp AssertionResponse) error {
+	ctx := r.Context()
+	challenge := wa.sessions.PopString(ctx, SessionWebAuthnChallengeKey)
+	if err := wa.verifyClientData(r, resp.Response.ClientDataJSON, "webauthn.get", challenge); err != nil {
+		return err
+	}
+	cred, err := wa.creds.GetByCredentialID(ctx, userID, strings.TrimRight(resp.ID, "="))
+	if errors.Is(err, store.ErrNotFound) {
+		return fmt.Errorf("%w: unknown credential", errWebAuthn)
+	}
+	if err != nil {
+		return err
+	}
+	authData, err := decodeB64(resp.Response.AuthenticatorData)
+	if err != nil {
+		return fmt.Errorf("%w: authenticator data: %v", errWebAuthn, err)
+	}
+	signCount, err := wa.verifyAuthData(r, authData)
+	if err != nil {
+		return err
+	}
+	clientData, _ := decodeB64(resp.Response.ClientDataJSON)
+	sig, err := decodeB64(resp.Response.Signature)
+	if err != nil {
+		return fmt.Errorf("%w: signature: %v", errWebAuthn, err)
+	}
+	if err := verifySignature(cred, authData, clientData, sig); err != nil {
+		return err
+	}
+	// A counter that fails to advance suggests a cloned authenticator;
+	// authenticators that do not count always report zero.
+	if signCount != 0 || cred.SignCount != 0 {
+		if int64(signCount) <= cred.SignCount {
+			return fmt.Errorf("%w: signature counter did not advance", errWebAuthn)
+		}
+	}
+	if err := wa.creds.MarkUsed(ctx, cred.ID, int64(signCount)); err != nil {
+		return err
+	}
+	wa.sessions.Put(ctx, SessionStepUpKey, wa.now().UTC())
+	return nil
+}
+
+// SteppedUp reports whether the session confirmed a passkey within the last
+// StepUpWindow.
+func (wa *WebAuthn) SteppedUp(ctx context.Context) bool {
+	at := wa.sessions.GetTime(ctx, SessionStepUpKey)
+	return !at.IsZero() && wa.now().Sub(at) < StepUpWindow
+}
+
+// Enrolled reports whether userID has registered a passkey.
+func (wa *WebAuthn) Enrolled(ctx context.Context, userID string) (bool, error) {
+	n, err := wa.creds.CountByUser(ctx, userID)
+	return n > 0, err
+}
+
+// IsVerificationError reports whether err means the browser's response was
+// rejected, as opposed to a server failure.
+func IsVerificationError(err error) bool {
+	return errors.Is(err, errWebAuthn)
+}
+
+func (wa *WebAuthn) newChallenge(ctx context.Context) (string, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	challenge := b64.EncodeToString(raw)
+	wa.sessions.Put(ctx, SessionWebAuthnChallengeKey, challenge)
+	return challenge, nil
+}
+
+func (wa *WebAuthn) rpIDFor(r *http.Request) string {
+	if wa.rpID != "" {
+		return wa.rpID
+	}
+	host, _, err := net.SplitHostPort(r.Host)
+	if err != nil {
+		return r.Host
+	}
+	return host
+}
+
+func (wa *WebAuthn) originFor(r *http.Request) string {
+	if wa.origin != "" {
+		return wa.origin
+	}
+	scheme := "http"
+	if r.TLS != nil || r.Header.Get("X-Forwarded-Proto") == "https" {
+		scheme = "https"
+	}
+	return scheme + "://" + r.Host
+}
+
+// verifyClientData checks the ceremony type, challenge, and origin the
+// browser signed.
+func (wa *WebAuthn) verifyClientData(r *http.Request, encoded, ceremony, challenge string) error {
+	if challenge == "" {
+		return fmt.Errorf("%w: no ceremony in progress", errWebAuthn)
+	}
+	raw, err := decodeB64(encoded)
+	if err != nil {
+		return fmt.Errorf("%w: client data: %v", errWebAuthn, err)
+	}
+	var cd struct {
+		Type      string `json:"type"`
+		Challenge string `json:"challenge"`
+		Origin    string `json:"origin"`
+	}
+	if err := json.Unmarshal(raw, &cd); err != nil {
+		return fmt.Errorf("%w: client data: %v", errWebAuthn, err)
+	}
+	switch {
+	case cd.Type != ceremony:
+		return fmt.Errorf("%w: client data type %q", errWebAuthn, cd.Type)
+	case strings.TrimRight(cd.Challenge, "=") != challenge:
+		return fmt.Errorf("%w: challenge mismatch", errWebAuthn)
+	case cd.Origin != wa.originFor(r):
+		return fmt.Errorf("%w: origin %q", errWebAuthn, cd.Origin)
+	}
+	return nil
+}
+
+// verifyAuthData checks the RP ID hash and that the user was present and
+// verified, and returns the signature counter.
+func (wa *WebAuthn) verifyAuthData(r *http.Request, authData []byte) (uint32, error) {
+	if len(authData) < 37 {
+		return 0, fmt.Errorf("%w: authenticator data too short", errWebAuthn)
+	}
+	rpHash := sha256.Sum256([]byte(wa.rpIDFor(r)))
+	if !bytes.Equal(authData[:32], rpHash[:]) {
+		return 0, fmt.Errorf("%w: RP ID mismatch", errWebAuthn)
+	}
+	if flags := authData[32]; flags&flagUserPresent == 0 || flags&flagUserVerified == 0 {
+		return 0, fmt.Errorf("%w: user not verified", errWebAuthn)
+	}
+	return binary.BigEndian.Uint32(authData[33:37]), nil
+}
+
+// verifySignature checks sig over authData || SHA-256(clientData).
+func verifySignature(cred *store.WebAuthnCredential, authData, clientData, sig []byte) error {
+	der, err := decodeB64(cred.PublicKey)
+	if err != nil {
+		return err
+	}
+	pub, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		return err
+	}
+	cdHash := sha256.Sum256(clientData)
+	signed := append(append([]byte{}, authData...), cdHash[:]...)
+	digest := sha256.Sum256(signed)
+	ok := false
+	switch key := pub.(type) {
+	case *ecdsa.PublicKey:
+		ok = ecdsa.VerifyASN1(key, digest[:], sig)
+	case ed25519.PublicKey:
+		ok = ed25519.Verify(key, signed, sig)
+	case *rsa.PublicKey:
+		ok = rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], sig) == nil
+	}
+	if !ok {
+		return fmt.Errorf("%w: bad signature", errWebAuthn)
+	}
+	return nil
+}
+
+// algMatches reports whether the COSE algorithm fits the key type.
+func algMatches(alg int64, pub any) bool {
+	switch key := pub.(type) {
+	case *ecdsa.PublicKey:
+		return alg == algES256 && key.Curve.Params().Name == "P-256"
+	case ed25519.PublicKey:
+		return alg == algEdDSA
+	case *rsa.PublicKey:
+		return alg == algRS256
+	}
+	return false
+}
+
+func descriptors(creds []*store.WebAuthnCredential) []CredentialDescriptor {
+	out := make([]CredentialDescriptor, 0, len(creds))
+	for _, c := range creds {
+		out = append(out, CredentialDescriptor{Type: "public-key", ID: c.CredentialID})
+	}
+	return out
+}
+
+// b64 is the unpadded base64url encoding WebAuthn uses on the wire.
+var b64 = base64.RawURLEncoding
+
+func decodeB64(s string) ([]byte, error) {
+	return b64.DecodeString(strings.TrimRight(s, "="))
+}
@@ -0,0 +1,137 @@
+package auth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/binary"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/alexedwards/scs/v2"
+	"github.com/joestump/joe-links/internal/store"
+	"github.com/joestump/joe-links/internal/testutil"
+)
+
+// softAuthenticator stands in for a platform authenticator with an ES256 key.
+type softAuthenticator struct {
+	key     *ecdsa.PrivateKey
+	credID  []byte
+	counter uint32
+}
+
+func (a *softAuthenticator) authData(rpID string, attested bool) []byte {
+	rpHash := sha256.Sum256([]byte(rpID))
+	out := append([]byte{}, rpHash[:]...)
+	flags := byte(flagUserPresent | flagUserVerified)
+	if attested {
+		flags |= flagAttested
+	}
+	out = append(out, flags)
+	out = binary.BigEndian.AppendUint32(out, a.counter)
+	if attested {
+		out = append(out, make([]byte, 16)...) // AAGUID
+		out = binary.BigEndian.AppendUint16(out, uint16(len(a.credID)))
+		out = append(out, a.credID...)
+	}
+	return out
+}
+
+func clientData(typ, challenge, origin string) []byte {
+	b, _ := json.Marshal(map[string]string{"type": typ, "challenge": challenge, "origin": origin})
+	return b
+}
+
+// Governing: SPEC-0001 REQ "WebAuthn Step-Up"
+func TestWebAuthn_RegisterAndStepUp(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	user, err := store.NewUserStore(db).Upsert(context.Background(), "test", "sub1", "u@example.com", "User", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sm := scs.New()
+	wa := NewWebAuthn(sm, store.NewWebAuthnStore(db), "", "")
+	ctx, err := sm.Load(context.Background(), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	req := httptest.NewRequest("POST", "http://go.example.com/", nil).WithContext(ctx)
+	const origin = "http://go.example.com"
+
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	a := &softAuthenticator{key: key, credID: []byte("cred-1")}
+
+	// Registration.
+	opts, err := wa.BeginRegistration(req, user, "joe-links")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if opts.RP.ID != "go.example.com" {
+		t.Errorf("rp.id = %q", opts.RP.ID)
+	}
+	spki, _ := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	var reg RegistrationResponse
+	reg.ID = b64.EncodeToString(a.credID)
+	reg.Response.ClientDataJSON = b64.EncodeToString(clientData("webauthn.create", opts.Challenge, origin))
+	reg.Response.AuthenticatorData = b64.EncodeToString(a.authData("go.example.com", true))
+	reg.Response.PublicKey = b64.EncodeToString(spki)
+	reg.Response.PublicKeyAlgorithm = algES256
+	if _, err := wa.FinishRegistration(req, user, "Laptop", reg); err != nil {
+		t.Fatalf("FinishRegistration: %v", err)
+	}
+	if ok, _ := wa.Enrolled(ctx, user.ID); !ok {
+		t.Fatal("not enrolled after registration")
+	}
+
+	assert := func(challenge, origin string) AssertionResponse {
+		a.counter++
+		ad := a.authData("go.example.com", false)
+		cd := clientData("webauthn.get", challenge, origin)
+		cdHash := sha256.Sum256(cd)
+		digest := sha256.Sum256(append(append([]byte{}, ad...), cdHash[:]...))
+		sig, _ := ecdsa.SignASN1(rand.Reader, key, digest[:])
+		var resp AssertionResponse
+		resp.ID = b64.EncodeToString(a.credID)
+		resp.Response.ClientDataJSON = b64.EncodeToString(cd)
+		resp.Response.AuthenticatorData = b64.EncodeToString(ad)
+		resp.Response.Signature = b64.EncodeToString(sig)
+		return resp
+	}
+
+	if wa.SteppedUp(ctx) {
+		t.Fatal("stepped up before any assertion")
+	}
+
+	// A response from another origin is rejected.
+	ro, err := wa.BeginAssertion(req, user.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := wa.FinishAssertion(req, user.ID, assert(ro.Challenge, "https://evil.example")); !IsVerificationError(err) {
+		t.Errorf("foreign origin: err = %v", err)
+	}
+
+	ro, _ = wa.BeginAssertion(req, user.ID)
+	resp := assert(ro.Challenge, origin)
+	if err := wa.FinishAssertion(req, user.ID, resp); err != nil {
+		t.Fatalf("FinishAssertion: %v", err)
+	}
+	if !wa.SteppedUp(ctx) {
+		t.Error("not stepped up after assertion")
+	}
+	// The challenge is single-use.
+	if err := wa.FinishAssertion(req, user.ID, resp); !IsVerificationError(err) {
+		t.Errorf("replayed assertion: err = %v", err)
+	}
+
+	// The confirmation lapses after the window.
+	wa.now = func() time.Time { return time.Now().Add(StepUpWindow + time.Second) }
+	if wa.SteppedUp(ctx) {
+		t.Error("still stepped up after the window")
+	}
+}
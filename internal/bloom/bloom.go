@@ -0,0 +1,70 @@
+// Package bloom builds the Bloom filter of slugs the browser extension uses
+// to tell, without a round trip, that a go/ link does not exist. The hashing
+// is simple enough to reimplement in a few lines of JavaScript.
+// Governing: SPEC-0008 REQ "Offline Slug Lookup"
+package bloom
+
+import "math"
+
+// FalsePositiveRate is the target false positive rate New sizes filters for.
+const FalsePositiveRate = 0.01
+
+// Filter is a Bloom filter over strings. Bit i lives in Bits[i/8] at
+// position i%8, least significant bit first.
+type Filter struct {
+	M    uint32 // number of bits
+	K    uint32 // number of hash functions
+	Bits []byte
+}
+
+// New returns an empty filter sized to hold n keys at FalsePositiveRate.
+func New(n int) *Filter {
+	if n < 1 {
+		n = 1
+	}
+	m := uint32(math.Ceil(-float64(n) * math.Log(FalsePositiveRate) / (math.Ln2 * math.Ln2)))
+	// Round up to whole bytes so every bit of Bits is addressable.
+	m = (m + 7) &^ 7
+	k := uint32(math.Round(float64(m) / float64(n) * math.Ln2))
+	if k < 1 {
+		k = 1
+	}
+	return &Filter{M: m, K: k, Bits: make([]byte, m/8)}
+}
+
+// Add inserts key into the filter. Bit positions are h1 + i*h2 in uint32
+// arithmetic, wrapping, modulo M.
+func (f *Filter) Add(key string) {
+	h1, h2 := hashes(key)
+	for i := uint32(0); i < f.K; i++ {
+		bit := (h1 + i*h2) % f.M
+		f.Bits[bit/8] |= 1 << (bit % 8)
+	}
+}
+
+// Test reports whether key may be in the filter. False means it certainly
+// is not.
+func (f *Filter) Test(key string) bool {
+	h1, h2 := hashes(key)
+	for i := uint32(0); i < f.K; i++ {
+		bit := (h1 + i*h2) % f.M
+		if f.Bits[bit/8]&(1<<(bit%8)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// hashes returns the two 32-bit hashes combined by double hashing:
+// FNV-1a and FNV-1 of the key's bytes, the second forced odd.
+func hashes(key string) (uint32, uint32) {
+	const offset, prime = 2166136261, 16777619
+	h1, h2 := uint32(offset), uint32(offset)
+	for i := 0; i < len(key); i++ {
+		h1 ^= uint32(key[i])
+		h1 *= prime
+		h2 *= prime
+		h2 ^= uint32(key[i])
+	}
+	return h1, h2 | 1
+}
@@ -0,0 +1,36 @@
+package bloom
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestFilter(t *testing.T) {
+	f := New(1000)
+	for i := 0; i < 1000; i++ {
+		f.Add(fmt.Sprintf("slug-%d", i))
+	}
+	for i := 0; i < 1000; i++ {
+		if !f.Test(fmt.Sprintf("slug-%d", i)) {
+			t.Fatalf("slug-%d: false negative", i)
+		}
+	}
+	fp := 0
+	for i := 0; i < 10000; i++ {
+		if f.Test(fmt.Sprintf("missing-%d", i)) {
+			fp++
+		}
+	}
+	if rate := float64(fp) / 10000; rate > 3*FalsePositiveRate {
+		t.Errorf("false positive rate = %.3f, want about %.2f", rate, FalsePositiveRate)
+	}
+}
+
+func TestHashesKnownValues(t *testing.T) {
+	// FNV-1a and FNV-1 (32-bit) of "a"; the extension's JavaScript port
+	// must produce the same values.
+	h1, h2 := hashes("a")
+	if h1 != 0xe40c292c || h2 != 0x050c5d7e|1 {
+		t.Errorf("hashes(a) = %#x, %#x", h1, h2)
+	}
+}
@@ -0,0 +1,194 @@
+// Package bookmarks reads browser bookmark exports in the Netscape bookmark
+// file format, which Chrome, Firefox, Safari, and Edge all write, and derives
+// link slugs and tags from them.
+// Governing: SPEC-0004 REQ "Bookmark Import"
+package bookmarks
+
+import (
+	"errors"
+	"html"
+	"io"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// ErrNotBookmarkFile is returned by Parse for input without the Netscape
+// bookmark file doctype.
+var ErrNotBookmarkFile = errors.New("not a Netscape bookmark file")
+
+// MaxSlugLen is the longest slug ProposeSlug returns.
+const MaxSlugLen = 40
+
+// Bookmark is one bookmarked http or https URL.
+type Bookmark struct {
+	URL         string
+	Title       string
+	Description string
+	Folders     []string // enclosing folder names, outermost first
+	Tags        []string // from the TAGS attribute some browsers write
+}
+
+var (
+	doctypeRe = regexp.MustCompile(`(?i)<!DOCTYPE\s+NETSCAPE-Bookmark-file-1>`)
+	tagRe     = regexp.MustCompile(`(?s)<(/?)([A-Za-z0-9]+)([^>]*)>`)
+	attrRe    = regexp.MustCompile(`(?s)([A-Za-z_-]+)\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s>]+))`)
+	nonSlugRe = regexp.MustCompile(`[^a-z0-9]+`)
+)
+
+// rootFolders are the folders browsers create themselves; they say nothing
+// about a bookmark, so Tags leaves them out.
+var rootFolders = map[string]bool{
+	"bookmarks":         true,
+	"bookmarks bar":     true,
+	"bookmarks toolbar": true,
+	"bookmarks menu":    true,
+	"favorites":         true,
+	"favorites bar":     true,
+	"mobile bookmarks":  true,
+	"other bookmarks":   true,
+}
+
+// Parse reads a bookmark file and returns its http and https bookmarks in
+// file order. Other schemes, such as javascript: bookmarklets, are skipped.
+func Parse(r io.Reader) ([]Bookmark, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	doc := string(data)
+	if !doctypeRe.MatchString(doc) {
+		return nil, ErrNotBookmarkFile
+	}
+
+	var (
+		out     []Bookmark
+		folders []string
+		heading string // last folder heading, pushed by the <DL> that follows it
+		open    string // element whose text is being collected: "h3", "a", or "dd"
+		text    strings.Builder
+		current *Bookmark
+		last    = -1 // index in out of the bookmark a <DD> describes
+	)
+	flush := func() string {
+		s := strings.Join(strings.Fields(html.UnescapeString(text.String())), " ")
+		text.Reset()
+		return s
+	}
+	pos := 0
+	for _, m := range tagRe.FindAllStringSubmatchIndex(doc, -1) {
+		if open != "" {
+			text.WriteString(doc[pos:m[0]])
+		}
+		pos = m[1]
+		closing := m[3] > m[2]
+		name := strings.ToLower(doc[m[4]:m[5]])
+		attrs := doc[m[6]:m[7]]
+
+		// A description runs until the next tag.
+		if open == "dd" {
+			if d := flush(); d != "" && last >= 0 {
+				out[last].Description = d
+			}
+			open = ""
+		}
+		switch {
+		case name == "h3" && !closing:
+			open = "h3"
+			text.Reset()
+		case name == "h3" && closing && open == "h3":
+			heading = flush()
+			open = ""
+		case name == "dl" && !closing:
+			folders = append(folders, heading)
+			heading = ""
+		case name == "dl" && closing:
+			if len(folders) > 0 {
+				folders = folders[:len(folders)-1]
+			}
+		case name == "a" && !closing:
+			current = &Bookmark{URL: strings.TrimSpace(attr(attrs, "href"))}
+			for _, t := range strings.Split(attr(attrs, "tags"), ",") {
+				if t = strings.TrimSpace(t); t != "" {
+					current.Tags = append(current.Tags, t)
+				}
+			}
+			open = "a"
+			text.Reset()
+		case name == "a" && closing && current != nil:
+			current.Title = flush()
+			open = ""
+			last = -1
+			if u, err := url.Parse(current.URL); err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != "" {
+				for _, f := range folders {
+					if f != "" {
+						current.Folders = append(current.Folders, f)
+					}
+				}
+				out = append(out, *current)
+				last = len(out) - 1
+			}
+			current = nil
+		case name == "dd" && !closing:
+			open = "dd"
+			text.Reset()
+		}
+	}
+	return out, nil
+}
+
+// attr returns the unescaped value of the named attribute in attrs, or "".
+func attr(attrs, name string) string {
+	for _, m := range attrRe.FindAllStringSubmatch(attrs, -1) {
+		if strings.EqualFold(m[1], name) {
+			return html.UnescapeString(m[2] + m[3] + m[4])
+		}
+	}
+	return ""
+}
+
+// TagNames returns the bookmark's tags followed by its folder names, leaving
+// out the folders browsers create themselves and repeats.
+func (b Bookmark) TagNames() []string {
+	var names []string
+	seen := map[string]bool{}
+	for _, n := range append(append([]string{}, b.Tags...), b.Folders...) {
+		key := strings.ToLower(n)
+		if seen[key] || rootFolders[key] {
+			continue
+		}
+		seen[key] = true
+		names = append(names, n)
+	}
+	return names
+}
+
+// ProposeSlug derives a slug from the bookmark's title, or from its host and
+// first path segment when the title has no letters or digits. The result is
+// at most MaxSlugLen characters, cut at a hyphen where possible, and may be
+// empty.
+func ProposeSlug(b Bookmark) string {
+	s := slugify(b.Title)
+	if s == "" {
+		if u, err := url.Parse(b.URL); err == nil {
+			s = strings.TrimPrefix(u.Hostname(), "www.")
+			if seg, _, _ := strings.Cut(strings.Trim(u.Path, "/"), "/"); seg != "" {
+				s += "-" + seg
+			}
+			s = slugify(s)
+		}
+	}
+	if len(s) > MaxSlugLen {
+		s = s[:MaxSlugLen]
+		if i := strings.LastIndexByte(s, '-'); i > MaxSlugLen/2 {
+			s = s[:i]
+		}
+		s = strings.Trim(s, "-")
+	}
+	return s
+}
+
+// slugify lowercases s and joins its runs of letters and digits with hyphens.
+func slugify(s string) string {
+	return strings.Trim(nonSlugRe.ReplaceAllString(strings.ToLower(s), "-"), "-")
+}
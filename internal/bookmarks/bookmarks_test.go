@@ -0,0 +1,83 @@
+package bookmarks_test
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/joestump/joe-links/internal/bookmarks"
+)
+
+const export = `<!DOCTYPE NETSCAPE-Bookmark-file-1>
+<!-- This is an automatically generated file. -->
+<META HTTP-EQUIV="Content-Type" CONTENT="text/html; charset=UTF-8">
+<TITLE>Bookmarks</TITLE>
+<H1>Bookmarks</H1>
+<DL><p>
+    <DT><H3 ADD_DATE="1700000000" PERSONAL_TOOLBAR_FOLDER="true">Bookmarks bar</H3>
+    <DL><p>
+        <DT><A HREF="https://wiki.example.com/" ADD_DATE="1700000000">Team Wiki</A>
+        <DD>Everything &amp; anything
+        <DT><H3>Ops</H3>
+        <DL><p>
+            <DT><A HREF="https://grafana.example.com/d/abc" TAGS="dashboards,Ops">Grafana &mdash; API latency</A>
+            <DT><A HREF="javascript:alert(1)">Bookmarklet</A>
+        </DL><p>
+        <DT><A HREF='https://www.example.org/docs/start'>!!!</A>
+    </DL><p>
+    <DT><A HREF="https://top.example.com/">Top level</A>
+</DL><p>
+`
+
+func TestParse(t *testing.T) {
+	got, err := bookmarks.Parse(strings.NewReader(export))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	want := []bookmarks.Bookmark{
+		{URL: "https://wiki.example.com/", Title: "Team Wiki", Description: "Everything & anything", Folders: []string{"Bookmarks bar"}},
+		{URL: "https://grafana.example.com/d/abc", Title: "Grafana — API latency", Folders: []string{"Bookmarks bar", "Ops"}, Tags: []string{"dashboards", "Ops"}},
+		{URL: "https://www.example.org/docs/start", Title: "!!!", Folders: []string{"Bookmarks bar"}},
+		{URL: "https://top.example.com/", Title: "Top level"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Parse returned %d bookmarks, want %d: %+v", len(got), len(want), got)
+	}
+	for i := range want {
+		g, w := got[i], want[i]
+		if g.URL != w.URL || g.Title != w.Title || g.Description != w.Description ||
+			!slices.Equal(g.Folders, w.Folders) || !slices.Equal(g.Tags, w.Tags) {
+			t.Errorf("bookmark %d = %+v, want %+v", i, g, w)
+		}
+	}
+
+	if names := got[1].TagNames(); !slices.Equal(names, []string{"dashboards", "Ops"}) {
+		t.Errorf("TagNames = %v, want [dashboards Ops]", names)
+	}
+}
+
+func TestParseRejectsOtherHTML(t *testing.T) {
+	_, err := bookmarks.Parse(strings.NewReader(`<html><body><a href="https://example.com">x</a></body></html>`))
+	if !errors.Is(err, bookmarks.ErrNotBookmarkFile) {
+		t.Errorf("Parse error = %v, want ErrNotBookmarkFile", err)
+	}
+}
+
+func TestProposeSlug(t *testing.T) {
+	cases := []struct {
+		b    bookmarks.Bookmark
+		want string
+	}{
+		{bookmarks.Bookmark{Title: "Team Wiki"}, "team-wiki"},
+		{bookmarks.Bookmark{Title: "Grafana — API latency"}, "grafana-api-latency"},
+		{bookmarks.Bookmark{Title: "!!!", URL: "https://www.example.org/docs/start"}, "example-org-docs"},
+		{bookmarks.Bookmark{Title: "The quarterly planning spreadsheet for the platform team"}, "the-quarterly-planning-spreadsheet-for"},
+		{bookmarks.Bookmark{Title: "", URL: ""}, ""},
+	}
+	for _, tt := range cases {
+		if got := bookmarks.ProposeSlug(tt.b); got != tt.want {
+			t.Errorf("ProposeSlug(%q) = %q, want %q", tt.b.Title, got, tt.want)
+		}
+	}
+}
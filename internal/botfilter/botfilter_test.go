@@ -0,0 +1,59 @@
+package botfilter
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestIsBot_UserAgent(t *testing.T) {
+	d := New("InternalScanner")
+	cases := []struct {
+		ua  string
+		bot bool
+	}{
+		{"Mozilla/5.0 (Macintosh; Intel Mac OS X 14_5) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.5 Safari/605.1.15", false},
+		{"Mozilla/5.0 (compatible; Googlebot/2.1; +http://www.google.com/bot.html)", true},
+		{"Slackbot-LinkExpanding 1.0 (+https://api.slack.com/robots)", true},
+		{"facebookexternalhit/1.1", true},
+		{"curl/8.4.0", true},
+		{"Go-http-client/2.0", true},
+		{"internalscanner/3", true},
+		{"", true},
+	}
+	for _, c := range cases {
+		if got := d.IsBot(c.ua, "203.0.113.7"); got != c.bot {
+			t.Errorf("IsBot(%q) = %v, want %v", c.ua, got, c.bot)
+		}
+	}
+}
+
+func TestIsBot_IPList(t *testing.T) {
+	d := New()
+	list := "# scanners\n198.51.100.0/24\n\n203.0.113.9  # single host\n2001:db8::/32\n"
+	if err := d.LoadIPList(strings.NewReader(list)); err != nil {
+		t.Fatalf("LoadIPList: %v", err)
+	}
+	const browser = "Mozilla/5.0 (X11; Linux x86_64; rv:128.0) Gecko/20100101 Firefox/128.0"
+	for ip, bot := range map[string]bool{
+		"198.51.100.42": true,
+		"203.0.113.9":   true,
+		"203.0.113.10":  false,
+		"2001:db8::1":   true,
+		"not-an-ip":     false,
+	} {
+		if got := d.IsBot(browser, ip); got != bot {
+			t.Errorf("IsBot(browser, %s) = %v, want %v", ip, got, bot)
+		}
+	}
+
+	if err := d.LoadIPList(strings.NewReader("10.0.0.0/33\n")); err == nil {
+		t.Error("LoadIPList accepted an invalid CIDR")
+	}
+}
+
+func TestIsBot_Nil(t *testing.T) {
+	var d *Detector
+	if d.IsBot("", "198.51.100.42") {
+		t.Error("nil Detector flagged a request")
+	}
+}
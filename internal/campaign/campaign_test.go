@@ -0,0 +1,76 @@
+package campaign_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/joestump/joe-links/internal/campaign"
+	"github.com/joestump/joe-links/internal/store"
+	"github.com/joestump/joe-links/internal/testutil"
+)
+
+func TestSignVerify(t *testing.T) {
+	s := campaign.NewSigner([]byte("test key"))
+	now := time.Now()
+	tag, err := s.Sign("link-1", "email", now.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+	if !strings.HasPrefix(tag, "email~") {
+		t.Errorf("tag = %q, want email~ prefix", tag)
+	}
+
+	cases := []struct {
+		name   string
+		signer *campaign.Signer
+		linkID string
+		tag    string
+		now    time.Time
+		want   string
+	}{
+		{"valid", s, "link-1", tag, now, "email"},
+		{"expired", s, "link-1", tag, now.Add(2 * time.Hour), ""},
+		{"other link", s, "link-2", tag, now, ""},
+		{"other key", campaign.NewSigner([]byte("other key")), "link-1", tag, now, ""},
+		{"renamed source", s, "link-1", "slack" + strings.TrimPrefix(tag, "email"), now, ""},
+		{"plain source", s, "link-1", "email", now, ""},
+		{"nil signer", nil, "link-1", tag, now, ""},
+	}
+	for _, tt := range cases {
+		if got := tt.signer.Verify(tt.linkID, tt.tag, tt.now); got != tt.want {
+			t.Errorf("%s: Verify = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestSignRejectsInvalidSource(t *testing.T) {
+	s := campaign.NewSigner([]byte("test key"))
+	for _, src := range []string{"", "Email", "a~b", "-x", strings.Repeat("a", campaign.MaxSourceLen+1)} {
+		if _, err := s.Sign("link-1", src, time.Now()); !errors.Is(err, campaign.ErrInvalidSource) {
+			t.Errorf("Sign(%q) error = %v, want ErrInvalidSource", src, err)
+		}
+	}
+}
+
+func TestLoadSignerPersistsKey(t *testing.T) {
+	settings := store.NewSettingsStore(testutil.NewTestDB(t))
+	ctx := context.Background()
+	first, err := campaign.LoadSigner(ctx, settings)
+	if err != nil {
+		t.Fatalf("LoadSigner: %v", err)
+	}
+	tag, err := first.Sign("link-1", "email", time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+	second, err := campaign.LoadSigner(ctx, settings)
+	if err != nil {
+		t.Fatalf("LoadSigner again: %v", err)
+	}
+	if got := second.Verify("link-1", tag, time.Now()); got != "email" {
+		t.Errorf("tag from first signer verified as %q after reload, want email", got)
+	}
+}
@@ -1,15 +1,32 @@
-// Governing: SPEC-0001 REQ "CLI Entrypoint", "OIDC-Only Authentication", "Server-Side Sessions", ADR-0003, ADR-0004
+// Governing: SPEC-0001 REQ "CLI Entrypoint", "OIDC-Only Authentication", "Server-Side Sessions", "RP-Initiated Logout", "Refresh-Token Session Extension", ADR-0003, ADR-0004
 // Governing: SPEC-0017 REQ "LLM Provider Configuration", ADR-0017
+// Governing: SPEC-0001 REQ "SAML Authentication"
+// Governing: SPEC-0001 REQ "Email Notifications"
 package config
 
 import (
 	"fmt"
+	"net/url"
 	"strings"
 	"time"
 
 	"github.com/spf13/viper"
 )
 
+// Click analytics modes accepted by JOE_ANALYTICS_MODE.
+// Governing: SPEC-0016 REQ "Analytics Mode"
+const (
+	AnalyticsFull      = "full"      // record clicks with the signed-in user's ID
+	AnalyticsAnonymous = "anonymous" // record clicks but never the user's ID
+	AnalyticsOff       = "off"       // record no clicks at all
+)
+
+// DefaultTrustedProxies is JOE_TRUSTED_PROXIES when unset: loopback and the
+// private ranges reverse proxies and container networks usually sit on.
+// "none" trusts no peer.
+// Governing: SPEC-0001 REQ "Admin Network Allowlist"
+const DefaultTrustedProxies = "127.0.0.0/8,::1,10.0.0.0/8,172.16.0.0/12,192.168.0.0/16,fc00::/7"
+
 type Config struct {
 	HTTP struct {
 		Addr string
@@ -17,26 +34,175 @@ type Config struct {
 	DB struct {
 		Driver string
 		DSN    string
+
+		// Governing: SPEC-0001 REQ "Database Pool and Read Replica"
+		ReplicaDSN       string        // optional read replica for resolver lookups and public listings
+		MaxOpenConns     int           // 0 = unlimited
+		MaxIdleConns     int           // 0 = database/sql default (2)
+		ConnMaxLifetime  time.Duration // 0 = connections are reused forever
+		StatementTimeout time.Duration // 0 = none; PostgreSQL and MySQL (SELECTs only)
 	}
-	OIDC struct {
+	AuthProvider string // "oidc" (default) or "saml"
+	OIDC         struct {
 		Issuer       string
 		ClientID     string
 		ClientSecret string
 		RedirectURL  string
+		// RPLogout enables OIDC RP-initiated logout via the provider's end_session_endpoint.
+		RPLogout              bool
+		PostLogoutRedirectURL string // where the provider sends the browser after logout
+		// LinkVerifiedEmail joins a first sign-in with a verified email to the
+		// existing account with that email. Governing: SPEC-0001 REQ "Account Linking"
+		LinkVerifiedEmail bool
+	}
+	SAML struct {
+		IDPMetadataURL  string // IdP metadata document URL
+		RootURL         string // public base URL of this service, e.g. https://go.example.com
+		EntityID        string // SP entity ID (default: metadata URL)
+		CertFile        string // PEM certificate advertised in SP metadata
+		KeyFile         string // PEM private key used to sign requests and decrypt assertions
+		EmailAttribute  string // assertion attribute holding the email (default: "email")
+		NameAttribute   string // assertion attribute holding the display name (default: "displayName")
+		GroupsAttribute string // assertion attribute holding group names (default: "groups")
 	}
 	AdminEmail      string
 	AdminGroups     []string // OIDC group names that grant the admin role
 	GroupsClaim     string   // OIDC claim name containing the user's groups (default: "groups")
 	ShortKeyword    string   // override the short keyword prefix (default: first label of HTTP host)
+	IDStrategy      string   // Governing: SPEC-0002 REQ "ID Generation Strategy"; "uuid" (default), "uuidv7", or "short"
 	SessionLifetime time.Duration
-	InsecureCookies bool
-	LLM             struct {
+	// Refresh-token backed sessions: when enabled, the OIDC refresh token is
+	// stored (encrypted) and used to silently extend sessions up to SessionMaxLifetime.
+	SessionRefreshTokens bool
+	SessionMaxLifetime   time.Duration
+	SessionEncryptionKey string // defaults to EncryptionKey
+	EncryptionKey        string // Governing: SPEC-0001 REQ "Encrypted Secrets"; seals credentials kept in the settings table
+	InsecureCookies      bool
+	LLM                  struct {
 		Provider string // "anthropic", "openai", or "openai-compatible"; empty = disabled
 		APIKey   string
 		Model    string
 		BaseURL  string // override for openai-compatible providers
 		Prompt   string // custom prompt template text (overrides built-in default)
 	}
+	// Governing: SPEC-0001 REQ "Distributed Tracing"
+	Tracing struct {
+		Endpoint    string  // OTLP/HTTP collector host:port; empty disables tracing
+		Insecure    bool    // export over plain HTTP
+		ServiceName string  // service.name resource attribute (default: "joe-links")
+		SampleRatio float64 // fraction of new traces sampled (default: 1.0)
+	}
+	// Governing: SPEC-0016 REQ "Analytics Mode"
+	Analytics struct {
+		Mode string // AnalyticsFull (default), AnalyticsAnonymous, or AnalyticsOff
+	}
+	// Governing: SPEC-0010 REQ "Strict Visibility"
+	Visibility struct {
+		// Strict denies links whose visibility is not public, private, or
+		// secure instead of treating them as public. nil follows the instance
+		// default: on, except for instances that predate strict mode.
+		Strict *bool
+	}
+	// Governing: SPEC-0012 REQ "Sitemap and Robots"
+	Robots struct {
+		IndexSlugs bool // let crawlers follow slug redirects; by default robots.txt only admits the public directory
+	}
+	// Governing: SPEC-0016 REQ "Durable Click Spool"
+	Clicks struct {
+		SpoolPath string // append-only file buffering click events; empty = in-memory queue only
+	}
+	// Governing: SPEC-0016 REQ "Bot Filtering"
+	Bots struct {
+		Detect     bool     // flag clicks from crawlers and other automated clients (default: true)
+		UserAgents []string // extra user-agent substrings to treat as bots
+		IPList     string   // file of IPs and CIDR ranges to treat as bots; empty = none
+	}
+	// Governing: SPEC-0016 REQ "GeoIP Country Breakdown"
+	GeoIP struct {
+		Database string // MaxMind DB (e.g. GeoLite2-Country.mmdb) used to record click countries; empty = off
+	}
+	// Governing: SPEC-0009 REQ "Resolver Decision Tracing"
+	Resolver struct {
+		Debug bool // log each resolution's decisions; admins also get them in X-Joe-Trace
+
+		// UTMDefaults are appended to every link target unless the link or the
+		// target sets the same parameter; parsed from a query string such as
+		// "utm_source=golinks&utm_medium=internal".
+		// Governing: SPEC-0002 REQ "UTM Parameters"
+		UTMDefaults map[string]string
+
+		// SlugFilterRefresh is how often the in-process Bloom filter of slugs
+		// is rebuilt from the database; 0 disables the filter.
+		// Governing: SPEC-0009 REQ "Slug Filter Fast Path"
+		SlugFilterRefresh time.Duration
+	}
+	// Governing: SPEC-0001 REQ "Email Notifications"
+	Mail struct {
+		SMTPHost string // SMTP server host; empty disables notification emails
+		SMTPPort int    // SMTP server port (default: 587); STARTTLS is used when offered
+		Username string // SMTP auth user; empty sends without authentication
+		Password string
+		From     string // envelope and header sender address
+		BaseURL  string // public URL of this server, used in email links
+	}
+	// Governing: SPEC-0001 REQ "Link Health Checks"
+	Health struct {
+		CheckInterval time.Duration // time between sweeps of all link targets; 0 disables checks
+		CheckTimeout  time.Duration // per-request timeout (default: 10s)
+	}
+	// Governing: SPEC-0011 REQ "Public Link Moderation"
+	Moderation struct {
+		Enabled bool // hold new public links for admin approval before listing them
+	}
+	// Governing: SPEC-0011 REQ "Instance Telemetry"
+	Telemetry struct {
+		Share    bool   // opt in to sending anonymized instance stats upstream weekly
+		Endpoint string // URL the stats are POSTed to; required when Share is set
+	}
+	// Governing: SPEC-0001 REQ "Demo Mode"
+	Demo struct {
+		Mode          bool          // run as a public sandbox: auto-login, no destructive admin actions, periodic reset
+		ResetInterval time.Duration // time between wipes of the database (default: 1h)
+	}
+	// Governing: SPEC-0001 REQ "Multi-Tenancy"
+	Tenancy struct {
+		Enabled bool // serve a separate link namespace on each hostname in the tenants table
+	}
+	// Governing: SPEC-0001 REQ "WebAuthn Step-Up"
+	WebAuthn struct {
+		StepUp bool   // require a passkey confirmation before destructive admin actions
+		RPID   string // relying party ID (default: the request's hostname)
+		Origin string // expected origin, e.g. https://go.example.com (default: the request's scheme and host)
+	}
+	// Governing: SPEC-0001 REQ "Admin Network Allowlist"
+	Admin struct {
+		Networks    []string // CIDRs or addresses allowed to reach /admin; empty allows every client
+		APINetworks []string // CIDRs or addresses allowed to reach /api/v1/admin (default: Networks)
+	}
+	// Governing: SPEC-0001 REQ "Admin Network Allowlist"
+	Proxy struct {
+		Trusted []string // CIDRs or addresses of reverse proxies whose X-Forwarded-For / X-Real-IP headers are honored (default: DefaultTrustedProxies); empty trusts none
+	}
+	// Governing: SPEC-0001 REQ "Security Headers"
+	Security struct {
+		CSPMode               string        // "enforce" (default), "report-only", or "off"
+		CSPPolicy             string        // replaces the built-in Content-Security-Policy when set
+		HSTSMaxAge            time.Duration // Strict-Transport-Security max-age on HTTPS requests; 0 disables HSTS
+		HSTSIncludeSubdomains bool          // adds includeSubDomains to Strict-Transport-Security
+	}
+	// Governing: SPEC-0006 REQ "Token Brute-Force Lockout"
+	APILockout struct {
+		Failures int           // failed token checks from one address that trigger a lockout; 0 disables lockout
+		Window   time.Duration // period the failures are counted over
+		Duration time.Duration // how long the address is locked out
+	}
+	// Governing: SPEC-0005 REQ "gRPC Links Service"
+	GRPC struct {
+		Addr     string // listen address for the gRPC LinksService; empty disables it
+		TLSCert  string // PEM certificate; with TLSKey, serve gRPC over TLS
+		TLSKey   string // PEM private key for TLSCert
+		ClientCA string // PEM CA bundle; client certificates it signs authenticate as the user named by their email (mTLS)
+	}
 }
 
 // Load reads config from environment (JOE_ prefix) and optional joe-links.yaml.
@@ -51,16 +217,69 @@ func Load() (*Config, error) {
 	_ = v.ReadInConfig() // optional config file
 
 	v.SetDefault("http.addr", ":8080")
+	v.SetDefault("db.conn_max_lifetime", "0")
+	v.SetDefault("db.statement_timeout", "0")
+	v.SetDefault("auth.provider", "oidc")
+	v.SetDefault("saml.email_attribute", "email")
+	v.SetDefault("saml.name_attribute", "displayName")
+	v.SetDefault("saml.groups_attribute", "groups")
 	v.SetDefault("session.lifetime", "720h")
+	v.SetDefault("session.max_lifetime", "2160h")
+	v.SetDefault("tracing.service_name", "joe-links")
+	v.SetDefault("tracing.sample_ratio", 1.0)
+	v.SetDefault("health.check_interval", "0")
+	v.SetDefault("resolver.slug_filter_refresh", "1m")
+	v.SetDefault("health.check_timeout", "10s")
+	v.SetDefault("mail.smtp_port", 587)
+	v.SetDefault("analytics.mode", AnalyticsFull)
+	v.SetDefault("bots.detect", true)
+	v.SetDefault("demo.reset_interval", "1h")
+	v.SetDefault("csp.mode", "enforce")
+	v.SetDefault("hsts.max_age", "0")
+	v.SetDefault("api_lockout.failures", 20)
+	v.SetDefault("api_lockout.window", "10m")
+	v.SetDefault("api_lockout.duration", "15m")
+	v.SetDefault("trusted_proxies", DefaultTrustedProxies)
 
 	cfg := &Config{}
 	cfg.HTTP.Addr = v.GetString("http.addr")
 	cfg.DB.Driver = v.GetString("db.driver")
 	cfg.DB.DSN = v.GetString("db.dsn")
+	cfg.DB.ReplicaDSN = v.GetString("db.replica_dsn")
+	cfg.DB.MaxOpenConns = v.GetInt("db.max_open_conns")
+	cfg.DB.MaxIdleConns = v.GetInt("db.max_idle_conns")
+	if cfg.DB.MaxOpenConns < 0 || cfg.DB.MaxIdleConns < 0 {
+		return nil, fmt.Errorf("JOE_DB_MAX_OPEN_CONNS and JOE_DB_MAX_IDLE_CONNS must not be negative")
+	}
+	connLifetime, err := time.ParseDuration(v.GetString("db.conn_max_lifetime"))
+	if err != nil || connLifetime < 0 {
+		return nil, fmt.Errorf("invalid JOE_DB_CONN_MAX_LIFETIME: %q", v.GetString("db.conn_max_lifetime"))
+	}
+	cfg.DB.ConnMaxLifetime = connLifetime
+	statementTimeout, err := time.ParseDuration(v.GetString("db.statement_timeout"))
+	if err != nil || statementTimeout < 0 {
+		return nil, fmt.Errorf("invalid JOE_DB_STATEMENT_TIMEOUT: %q", v.GetString("db.statement_timeout"))
+	}
+	cfg.DB.StatementTimeout = statementTimeout
+	cfg.AuthProvider = strings.ToLower(v.GetString("auth.provider"))
 	cfg.OIDC.Issuer = v.GetString("oidc.issuer")
 	cfg.OIDC.ClientID = v.GetString("oidc.client_id")
 	cfg.OIDC.ClientSecret = v.GetString("oidc.client_secret")
 	cfg.OIDC.RedirectURL = v.GetString("oidc.redirect_url")
+	cfg.OIDC.RPLogout = v.GetBool("oidc.rp_logout")
+	cfg.OIDC.PostLogoutRedirectURL = v.GetString("oidc.post_logout_redirect_url")
+	cfg.OIDC.LinkVerifiedEmail = v.GetBool("oidc.link_verified_email")
+	cfg.WebAuthn.StepUp = v.GetBool("webauthn.step_up")
+	cfg.WebAuthn.RPID = v.GetString("webauthn.rp_id")
+	cfg.WebAuthn.Origin = v.GetString("webauthn.origin")
+	cfg.SAML.IDPMetadataURL = v.GetString("saml.idp_metadata_url")
+	cfg.SAML.RootURL = strings.TrimSuffix(v.GetString("saml.root_url"), "/")
+	cfg.SAML.EntityID = v.GetString("saml.entity_id")
+	cfg.SAML.CertFile = v.GetString("saml.cert_file")
+	cfg.SAML.KeyFile = v.GetString("saml.key_file")
+	cfg.SAML.EmailAttribute = v.GetString("saml.email_attribute")
+	cfg.SAML.NameAttribute = v.GetString("saml.name_attribute")
+	cfg.SAML.GroupsAttribute = v.GetString("saml.groups_attribute")
 	cfg.AdminEmail = v.GetString("admin_email")
 	cfg.InsecureCookies = v.GetBool("insecure_cookies")
 	if raw := v.GetString("oidc.admin_groups"); raw != "" {
@@ -70,11 +289,22 @@ func Load() (*Config, error) {
 			}
 		}
 	}
+	cfg.Admin.Networks = splitList(v.GetString("admin.networks"))
+	cfg.Admin.APINetworks = cfg.Admin.Networks
+	if v.IsSet("admin.api_networks") {
+		cfg.Admin.APINetworks = splitList(v.GetString("admin.api_networks"))
+	}
+	// Governing: SPEC-0001 REQ "Admin Network Allowlist"
+	cfg.Proxy.Trusted = splitList(v.GetString("trusted_proxies"))
+	if len(cfg.Proxy.Trusted) == 1 && strings.EqualFold(cfg.Proxy.Trusted[0], "none") {
+		cfg.Proxy.Trusted = nil
+	}
 	cfg.GroupsClaim = v.GetString("oidc.groups_claim")
 	if cfg.GroupsClaim == "" {
 		cfg.GroupsClaim = "groups"
 	}
 	cfg.ShortKeyword = v.GetString("short_keyword")
+	cfg.IDStrategy = v.GetString("id_strategy")
 
 	cfg.LLM.Provider = v.GetString("llm.provider")
 	cfg.LLM.APIKey = v.GetString("llm.api_key")
@@ -82,30 +312,216 @@ func Load() (*Config, error) {
 	cfg.LLM.BaseURL = v.GetString("llm.base_url")
 	cfg.LLM.Prompt = v.GetString("llm.prompt")
 
+	cfg.Tracing.Endpoint = v.GetString("tracing.endpoint")
+	cfg.Tracing.Insecure = v.GetBool("tracing.insecure")
+	cfg.Tracing.ServiceName = v.GetString("tracing.service_name")
+	cfg.Tracing.SampleRatio = v.GetFloat64("tracing.sample_ratio")
+	if cfg.Tracing.SampleRatio < 0 || cfg.Tracing.SampleRatio > 1 {
+		return nil, fmt.Errorf("JOE_TRACING_SAMPLE_RATIO must be between 0 and 1, got %v", cfg.Tracing.SampleRatio)
+	}
+
+	cfg.Analytics.Mode = strings.ToLower(v.GetString("analytics.mode"))
+	switch cfg.Analytics.Mode {
+	case AnalyticsFull, AnalyticsAnonymous, AnalyticsOff:
+	default:
+		return nil, fmt.Errorf("JOE_ANALYTICS_MODE must be full, anonymous, or off, got %q", cfg.Analytics.Mode)
+	}
+	if v.IsSet("visibility.strict") {
+		strict := v.GetBool("visibility.strict")
+		cfg.Visibility.Strict = &strict
+	}
+	cfg.Robots.IndexSlugs = v.GetBool("robots.index_slugs")
+	cfg.Clicks.SpoolPath = v.GetString("clicks.spool_path")
+	cfg.Bots.Detect = v.GetBool("bots.detect")
+	if raw := v.GetString("bots.user_agents"); raw != "" {
+		for _, ua := range strings.Split(raw, ",") {
+			if ua = strings.TrimSpace(ua); ua != "" {
+				cfg.Bots.UserAgents = append(cfg.Bots.UserAgents, ua)
+			}
+		}
+	}
+	cfg.Bots.IPList = v.GetString("bots.ip_list")
+	cfg.GeoIP.Database = v.GetString("geoip.database")
+	cfg.Tenancy.Enabled = v.GetBool("tenancy.enabled")
+	cfg.GRPC.Addr = v.GetString("grpc.addr")
+	cfg.GRPC.TLSCert = v.GetString("grpc.tls_cert")
+	cfg.GRPC.TLSKey = v.GetString("grpc.tls_key")
+	cfg.GRPC.ClientCA = v.GetString("grpc.client_ca")
+	if (cfg.GRPC.TLSCert == "") != (cfg.GRPC.TLSKey == "") {
+		return nil, fmt.Errorf("JOE_GRPC_TLS_CERT and JOE_GRPC_TLS_KEY must be set together")
+	}
+	if cfg.GRPC.ClientCA != "" && cfg.GRPC.TLSCert == "" {
+		return nil, fmt.Errorf("JOE_GRPC_CLIENT_CA requires JOE_GRPC_TLS_CERT and JOE_GRPC_TLS_KEY")
+	}
+	cfg.Resolver.Debug = v.GetBool("resolver.debug")
+	slugFilterRefresh, err := time.ParseDuration(v.GetString("resolver.slug_filter_refresh"))
+	if err != nil || slugFilterRefresh < 0 {
+		return nil, fmt.Errorf("invalid JOE_RESOLVER_SLUG_FILTER_REFRESH: %q", v.GetString("resolver.slug_filter_refresh"))
+	}
+	cfg.Resolver.SlugFilterRefresh = slugFilterRefresh
+	if raw := v.GetString("resolver.utm_defaults"); raw != "" {
+		q, err := url.ParseQuery(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid JOE_RESOLVER_UTM_DEFAULTS: %w", err)
+		}
+		cfg.Resolver.UTMDefaults = make(map[string]string, len(q))
+		for name := range q {
+			cfg.Resolver.UTMDefaults[name] = q.Get(name)
+		}
+	}
+
+	cfg.Security.CSPMode = strings.ToLower(v.GetString("csp.mode"))
+	switch cfg.Security.CSPMode {
+	case "enforce", "report-only", "off":
+	default:
+		return nil, fmt.Errorf("JOE_CSP_MODE must be enforce, report-only, or off, got %q", cfg.Security.CSPMode)
+	}
+	cfg.Security.CSPPolicy = v.GetString("csp.policy")
+	hstsMaxAge, err := time.ParseDuration(v.GetString("hsts.max_age"))
+	if err != nil || hstsMaxAge < 0 {
+		return nil, fmt.Errorf("invalid JOE_HSTS_MAX_AGE: %q", v.GetString("hsts.max_age"))
+	}
+	cfg.Security.HSTSMaxAge = hstsMaxAge
+	cfg.Security.HSTSIncludeSubdomains = v.GetBool("hsts.include_subdomains")
+
+	cfg.APILockout.Failures = v.GetInt("api_lockout.failures")
+	if cfg.APILockout.Failures < 0 {
+		return nil, fmt.Errorf("JOE_API_LOCKOUT_FAILURES must not be negative, got %d", cfg.APILockout.Failures)
+	}
+	lockoutWindow, err := time.ParseDuration(v.GetString("api_lockout.window"))
+	if err != nil || lockoutWindow <= 0 {
+		return nil, fmt.Errorf("invalid JOE_API_LOCKOUT_WINDOW: %q", v.GetString("api_lockout.window"))
+	}
+	cfg.APILockout.Window = lockoutWindow
+	lockoutDuration, err := time.ParseDuration(v.GetString("api_lockout.duration"))
+	if err != nil || lockoutDuration <= 0 {
+		return nil, fmt.Errorf("invalid JOE_API_LOCKOUT_DURATION: %q", v.GetString("api_lockout.duration"))
+	}
+	cfg.APILockout.Duration = lockoutDuration
+
+	checkInterval, err := time.ParseDuration(v.GetString("health.check_interval"))
+	if err != nil || checkInterval < 0 {
+		return nil, fmt.Errorf("invalid JOE_HEALTH_CHECK_INTERVAL: %q", v.GetString("health.check_interval"))
+	}
+	cfg.Health.CheckInterval = checkInterval
+	checkTimeout, err := time.ParseDuration(v.GetString("health.check_timeout"))
+	if err != nil || checkTimeout <= 0 {
+		return nil, fmt.Errorf("invalid JOE_HEALTH_CHECK_TIMEOUT: %q", v.GetString("health.check_timeout"))
+	}
+	cfg.Health.CheckTimeout = checkTimeout
+
+	cfg.Moderation.Enabled = v.GetBool("moderation.enabled")
+
+	cfg.Telemetry.Share = v.GetBool("telemetry.share")
+	cfg.Telemetry.Endpoint = v.GetString("telemetry.endpoint")
+	if cfg.Telemetry.Share && cfg.Telemetry.Endpoint == "" {
+		return nil, fmt.Errorf("JOE_TELEMETRY_SHARE requires JOE_TELEMETRY_ENDPOINT")
+	}
+
+	cfg.Demo.Mode = v.GetBool("demo.mode")
+	resetInterval, err := time.ParseDuration(v.GetString("demo.reset_interval"))
+	if err != nil || resetInterval <= 0 {
+		return nil, fmt.Errorf("invalid JOE_DEMO_RESET_INTERVAL: %q", v.GetString("demo.reset_interval"))
+	}
+	cfg.Demo.ResetInterval = resetInterval
+
+	cfg.Mail.SMTPHost = v.GetString("mail.smtp_host")
+	cfg.Mail.SMTPPort = v.GetInt("mail.smtp_port")
+	cfg.Mail.Username = v.GetString("mail.username")
+	cfg.Mail.Password = v.GetString("mail.password")
+	cfg.Mail.From = v.GetString("mail.from")
+	cfg.Mail.BaseURL = strings.TrimSuffix(v.GetString("mail.base_url"), "/")
+	if cfg.Mail.SMTPHost != "" && (cfg.Mail.From == "" || cfg.Mail.BaseURL == "") {
+		return nil, fmt.Errorf("JOE_MAIL_FROM and JOE_MAIL_BASE_URL are required when JOE_MAIL_SMTP_HOST is set")
+	}
+
 	lifetime, err := time.ParseDuration(v.GetString("session.lifetime"))
 	if err != nil {
 		return nil, fmt.Errorf("invalid JOE_SESSION_LIFETIME: %w", err)
 	}
 	cfg.SessionLifetime = lifetime
 
+	cfg.SessionRefreshTokens = v.GetBool("session.refresh_tokens")
+	cfg.EncryptionKey = v.GetString("encryption_key")
+	cfg.SessionEncryptionKey = v.GetString("session.encryption_key")
+	if cfg.SessionEncryptionKey == "" {
+		cfg.SessionEncryptionKey = cfg.EncryptionKey
+	}
+	maxLifetime, err := time.ParseDuration(v.GetString("session.max_lifetime"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid JOE_SESSION_MAX_LIFETIME: %w", err)
+	}
+	cfg.SessionMaxLifetime = maxLifetime
+	if cfg.SessionRefreshTokens && cfg.SessionEncryptionKey == "" {
+		return nil, fmt.Errorf("JOE_SESSION_ENCRYPTION_KEY or JOE_ENCRYPTION_KEY is required when JOE_SESSION_REFRESH_TOKENS is enabled")
+	}
+
 	if cfg.DB.Driver == "" {
 		return nil, fmt.Errorf("JOE_DB_DRIVER is required (sqlite3, mysql, postgres)")
 	}
 	if cfg.DB.DSN == "" {
 		return nil, fmt.Errorf("JOE_DB_DSN is required")
 	}
+	if cfg.Demo.Mode {
+		// Visitors are signed in as the demo user; no identity provider is used.
+		return cfg, nil
+	}
+	switch cfg.AuthProvider {
+	case "oidc":
+		if err := validateOIDC(cfg); err != nil {
+			return nil, err
+		}
+	case "saml":
+		if err := validateSAML(cfg); err != nil {
+			return nil, err
+		}
+		if cfg.SessionRefreshTokens {
+			return nil, fmt.Errorf("JOE_SESSION_REFRESH_TOKENS is only supported with JOE_AUTH_PROVIDER=oidc")
+		}
+	default:
+		return nil, fmt.Errorf("JOE_AUTH_PROVIDER must be oidc or saml, got %q", cfg.AuthProvider)
+	}
+
+	return cfg, nil
+}
+
+func validateOIDC(cfg *Config) error {
 	if cfg.OIDC.Issuer == "" {
-		return nil, fmt.Errorf("JOE_OIDC_ISSUER is required")
+		return fmt.Errorf("JOE_OIDC_ISSUER is required")
 	}
 	if cfg.OIDC.ClientID == "" {
-		return nil, fmt.Errorf("JOE_OIDC_CLIENT_ID is required")
+		return fmt.Errorf("JOE_OIDC_CLIENT_ID is required")
 	}
 	if cfg.OIDC.ClientSecret == "" {
-		return nil, fmt.Errorf("JOE_OIDC_CLIENT_SECRET is required")
+		return fmt.Errorf("JOE_OIDC_CLIENT_SECRET is required")
 	}
 	if cfg.OIDC.RedirectURL == "" {
-		return nil, fmt.Errorf("JOE_OIDC_REDIRECT_URL is required")
+		return fmt.Errorf("JOE_OIDC_REDIRECT_URL is required")
 	}
+	return nil
+}
 
-	return cfg, nil
+// Governing: SPEC-0001 REQ "SAML Authentication"
+func validateSAML(cfg *Config) error {
+	if cfg.SAML.IDPMetadataURL == "" {
+		return fmt.Errorf("JOE_SAML_IDP_METADATA_URL is required when JOE_AUTH_PROVIDER=saml")
+	}
+	if cfg.SAML.RootURL == "" {
+		return fmt.Errorf("JOE_SAML_ROOT_URL is required when JOE_AUTH_PROVIDER=saml")
+	}
+	if cfg.SAML.CertFile == "" || cfg.SAML.KeyFile == "" {
+		return fmt.Errorf("JOE_SAML_CERT_FILE and JOE_SAML_KEY_FILE are required when JOE_AUTH_PROVIDER=saml")
+	}
+	return nil
+}
+
+// splitList splits a comma-separated setting, dropping blank entries.
+func splitList(raw string) []string {
+	var out []string
+	for _, s := range strings.Split(raw, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			out = append(out, s)
+		}
+	}
+	return out
 }
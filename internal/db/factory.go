@@ -1,12 +1,22 @@
-// Governing: SPEC-0001 REQ "Pluggable Database Backend", ADR-0002
+// Governing: SPEC-0001 REQ "Pluggable Database Backend", REQ "Distributed Tracing", ADR-0002
 package db
 
 import (
+	"context"
+	"database/sql/driver"
 	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
 
-	_ "github.com/go-sql-driver/mysql"
+	"github.com/XSAM/otelsql"
+	"github.com/go-sql-driver/mysql"
 	"github.com/jmoiron/sqlx"
 	_ "github.com/lib/pq"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.34.0"
+	"go.opentelemetry.io/otel/trace"
 	_ "modernc.org/sqlite"
 )
 
@@ -16,7 +26,7 @@ func New(driver, dsn string) (*sqlx.DB, error) {
 	switch driver {
 	case "sqlite3":
 		// modernc/sqlite uses "sqlite" as the driver name (CGO-free)
-		db, err := sqlx.Open("sqlite", dsn)
+		db, err := open("sqlite", dsn, semconv.DBSystemNameSQLite)
 		if err != nil {
 			return nil, fmt.Errorf("open sqlite: %w", err)
 		}
@@ -26,13 +36,13 @@ func New(driver, dsn string) (*sqlx.DB, error) {
 		}
 		return db, nil
 	case "mysql":
-		db, err := sqlx.Open("mysql", dsn)
+		db, err := open("mysql", dsn, semconv.DBSystemNameMySQL)
 		if err != nil {
 			return nil, fmt.Errorf("open mysql: %w", err)
 		}
 		return db, nil
 	case "postgres":
-		db, err := sqlx.Open("postgres", dsn)
+		db, err := open("postgres", dsn, semconv.DBSystemNamePostgreSQL)
 		if err != nil {
 			return nil, fmt.Errorf("open postgres: %w", err)
 		}
@@ -41,3 +51,94 @@ func New(driver, dsn string) (*sqlx.DB, error) {
 		return nil, fmt.Errorf("unsupported DB driver %q: must be sqlite3, mysql, or postgres", driver)
 	}
 }
+
+// Options tunes the connection pool of a database opened with Open. Zero
+// values keep the database/sql defaults.
+// Governing: SPEC-0001 REQ "Database Pool and Read Replica"
+type Options struct {
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	// StatementTimeout aborts statements that run longer. PostgreSQL applies
+	// it to every statement, MySQL to SELECTs only; SQLite ignores it.
+	StatementTimeout time.Duration
+}
+
+// Open is New with a tuned connection pool and statement timeout.
+// Governing: SPEC-0001 REQ "Database Pool and Read Replica"
+func Open(driver, dsn string, opts Options) (*sqlx.DB, error) {
+	if opts.StatementTimeout > 0 {
+		var err error
+		if dsn, err = withStatementTimeout(driver, dsn, opts.StatementTimeout); err != nil {
+			return nil, err
+		}
+	}
+	db, err := New(driver, dsn)
+	if err != nil {
+		return nil, err
+	}
+	if opts.MaxOpenConns > 0 {
+		db.SetMaxOpenConns(opts.MaxOpenConns)
+	}
+	if opts.MaxIdleConns > 0 {
+		db.SetMaxIdleConns(opts.MaxIdleConns)
+	}
+	if opts.ConnMaxLifetime > 0 {
+		db.SetConnMaxLifetime(opts.ConnMaxLifetime)
+	}
+	return db, nil
+}
+
+// withStatementTimeout adds the driver's session timeout parameter to dsn:
+// statement_timeout for PostgreSQL, which lib/pq sends as a run-time
+// parameter, and max_execution_time for MySQL, which the driver sets as a
+// session variable. SQLite has no equivalent, so its DSN is returned as is.
+func withStatementTimeout(driver, dsn string, d time.Duration) (string, error) {
+	ms := strconv.FormatInt(d.Milliseconds(), 10)
+	switch driver {
+	case "postgres":
+		if strings.HasPrefix(dsn, "postgres://") || strings.HasPrefix(dsn, "postgresql://") {
+			u, err := url.Parse(dsn)
+			if err != nil {
+				return "", fmt.Errorf("parse postgres DSN: %w", err)
+			}
+			q := u.Query()
+			q.Set("statement_timeout", ms)
+			u.RawQuery = q.Encode()
+			return u.String(), nil
+		}
+		return strings.TrimSpace(dsn) + " statement_timeout=" + ms, nil
+	case "mysql":
+		cfg, err := mysql.ParseDSN(dsn)
+		if err != nil {
+			return "", fmt.Errorf("parse mysql DSN: %w", err)
+		}
+		if cfg.Params == nil {
+			cfg.Params = map[string]string{}
+		}
+		cfg.Params["max_execution_time"] = ms
+		return cfg.FormatDSN(), nil
+	default:
+		return dsn, nil
+	}
+}
+
+// open wraps the driver with OpenTelemetry instrumentation so every store
+// query becomes a child span of the request that issued it. Queries run
+// without a traced context (background jobs, migrations) create no spans.
+func open(driverName, dsn string, system attribute.KeyValue) (*sqlx.DB, error) {
+	sqlDB, err := otelsql.Open(driverName, dsn,
+		otelsql.WithAttributes(system),
+		otelsql.WithSpanOptions(otelsql.SpanOptions{
+			OmitConnResetSession: true,
+			OmitRows:             true,
+			SpanFilter: func(ctx context.Context, _ otelsql.Method, _ string, _ []driver.NamedValue) bool {
+				return trace.SpanContextFromContext(ctx).IsValid()
+			},
+		}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return sqlx.NewDb(sqlDB, driverName), nil
+}
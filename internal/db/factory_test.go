@@ -0,0 +1,38 @@
+package db
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWithStatementTimeout(t *testing.T) {
+	tests := []struct {
+		driver, dsn, want string
+	}{
+		{"postgres", "postgres://app:pw@db:5432/links?sslmode=disable", "postgres://app:pw@db:5432/links?sslmode=disable&statement_timeout=2500"},
+		{"postgres", "host=db dbname=links ", "host=db dbname=links statement_timeout=2500"},
+		{"mysql", "app:pw@tcp(db:3306)/links?parseTime=true", "app:pw@tcp(db:3306)/links?parseTime=true&max_execution_time=2500"},
+		{"sqlite3", "file:links.db", "file:links.db"},
+	}
+	for _, tt := range tests {
+		got, err := withStatementTimeout(tt.driver, tt.dsn, 2500*time.Millisecond)
+		if err != nil {
+			t.Errorf("withStatementTimeout(%q, %q): %v", tt.driver, tt.dsn, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("withStatementTimeout(%q, %q) = %q, want %q", tt.driver, tt.dsn, got, tt.want)
+		}
+	}
+}
+
+func TestOpenPool(t *testing.T) {
+	db, err := Open("sqlite3", ":memory:", Options{MaxOpenConns: 3, StatementTimeout: time.Second})
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer db.Close()
+	if got := db.Stats().MaxOpenConnections; got != 3 {
+		t.Errorf("MaxOpenConnections = %d, want 3", got)
+	}
+}
@@ -2,9 +2,11 @@
 package db
 
 import (
+	"context"
 	"embed"
 	"fmt"
 	"io/fs"
+	"log"
 
 	"github.com/jmoiron/sqlx"
 	"github.com/joestump/joe-links/internal/db/migrations"
@@ -17,11 +19,26 @@ var Migrations embed.FS
 // Migrate runs all pending goose migrations from the embedded migration files.
 // It must be called before the HTTP server starts accepting requests.
 func Migrate(db *sqlx.DB, driver string) error {
+	return MigrateWithOptions(context.Background(), db, driver, MigrateOptions{})
+}
+
+// MigrateWithOptions runs the pre-flight check, logs any locking warnings, and
+// applies all pending migrations, building indexes online when opts.Online is set.
+// Governing: SPEC-0001 REQ "Migration Pre-Flight Check"
+func MigrateWithOptions(ctx context.Context, db *sqlx.DB, driver string, opts MigrateOptions) error {
 	gooseDriver, err := gooseDialect(driver)
 	if err != nil {
 		return err
 	}
 
+	report, err := Preflight(ctx, db, driver, opts)
+	if err != nil {
+		return fmt.Errorf("migration pre-flight: %w", err)
+	}
+	for _, w := range report.Warnings {
+		log.Printf("migration pre-flight warning: %s", w)
+	}
+
 	if err := goose.SetDialect(gooseDriver); err != nil {
 		return fmt.Errorf("set goose dialect: %w", err)
 	}
@@ -34,8 +51,12 @@ func Migrate(db *sqlx.DB, driver string) error {
 		return fmt.Errorf("sub migrations fs: %w", err)
 	}
 
+	if opts.Online && gooseDriver == "postgres" {
+		sub = onlineFS{sub}
+	}
+
 	goose.SetBaseFS(sub)
-	if err := goose.Up(db.DB, "."); err != nil {
+	if err := goose.UpContext(ctx, db.DB, "."); err != nil {
 		return fmt.Errorf("run migrations: %w", err)
 	}
 	goose.SetBaseFS(nil)
@@ -0,0 +1,18 @@
+-- Governing: SPEC-0006 REQ "API Usage Tracking", ADR-0009
+-- +goose Up
+CREATE TABLE IF NOT EXISTS api_usage_daily (
+    id TEXT PRIMARY KEY,
+    token_id TEXT NOT NULL REFERENCES api_tokens(id) ON DELETE CASCADE,
+    user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    endpoint TEXT NOT NULL,
+    day TEXT NOT NULL,
+    request_count INTEGER NOT NULL DEFAULT 0,
+    UNIQUE (token_id, endpoint, day)
+);
+CREATE INDEX IF NOT EXISTS idx_api_usage_daily_user_day ON api_usage_daily(user_id, day);
+CREATE INDEX IF NOT EXISTS idx_api_usage_daily_day ON api_usage_daily(day);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_api_usage_daily_day;
+DROP INDEX IF EXISTS idx_api_usage_daily_user_day;
+DROP TABLE IF EXISTS api_usage_daily;
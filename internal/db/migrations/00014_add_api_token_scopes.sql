@@ -0,0 +1,7 @@
+-- Governing: SPEC-0006 REQ "Token Scopes", ADR-0009
+-- +goose Up
+-- Existing tokens keep full access.
+ALTER TABLE api_tokens ADD COLUMN scopes TEXT NOT NULL DEFAULT 'links:read links:write admin';
+
+-- +goose Down
+ALTER TABLE api_tokens DROP COLUMN scopes;
@@ -0,0 +1,7 @@
+-- Governing: SPEC-0009 REQ "Variable Constraints", ADR-0013
+-- +goose Up
+-- JSON object mapping variable names to regular expressions; empty means unconstrained.
+ALTER TABLE links ADD COLUMN variable_constraints TEXT NOT NULL DEFAULT '';
+
+-- +goose Down
+ALTER TABLE links DROP COLUMN variable_constraints;
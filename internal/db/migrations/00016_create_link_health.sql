@@ -0,0 +1,13 @@
+-- Governing: SPEC-0001 REQ "Link Health Checks"
+-- +goose Up
+-- Latest health check result per link; status_code is 0 when the request failed.
+CREATE TABLE IF NOT EXISTS link_health (
+    link_id TEXT NOT NULL PRIMARY KEY REFERENCES links(id) ON DELETE CASCADE,
+    status_code INTEGER NOT NULL DEFAULT 0,
+    latency_ms INTEGER NOT NULL DEFAULT 0,
+    error TEXT NOT NULL DEFAULT '',
+    checked_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS link_health;
@@ -0,0 +1,14 @@
+-- Governing: SPEC-0002 REQ "Link Aliases"
+-- +goose Up
+-- Additional slugs that resolve to a link. An alias slug must not collide
+-- with any link slug; the store checks links before inserting.
+CREATE TABLE IF NOT EXISTS link_aliases (
+    slug TEXT NOT NULL PRIMARY KEY,
+    link_id TEXT NOT NULL REFERENCES links(id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_link_aliases_link ON link_aliases(link_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_link_aliases_link;
+DROP TABLE IF EXISTS link_aliases;
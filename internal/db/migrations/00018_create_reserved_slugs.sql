@@ -0,0 +1,12 @@
+-- Governing: SPEC-0002 REQ "Reserved Slugs"
+-- +goose Up
+-- Admin-managed slugs that no link or alias may use, in addition to the
+-- built-in route prefixes compiled into the binary.
+CREATE TABLE IF NOT EXISTS reserved_slugs (
+    slug TEXT NOT NULL PRIMARY KEY,
+    reason TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS reserved_slugs;
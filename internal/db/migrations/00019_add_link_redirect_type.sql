@@ -0,0 +1,7 @@
+-- Governing: SPEC-0002 REQ "Redirect Type"
+-- +goose Up
+-- HTTP status used when the link redirects: 301, 302, 307, or 308.
+ALTER TABLE links ADD COLUMN redirect_type INTEGER NOT NULL DEFAULT 302;
+
+-- +goose Down
+ALTER TABLE links DROP COLUMN redirect_type;
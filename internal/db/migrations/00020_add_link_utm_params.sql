@@ -0,0 +1,7 @@
+-- Governing: SPEC-0002 REQ "UTM Parameters"
+-- +goose Up
+-- JSON object of utm_* parameters appended to the target at resolve time; '' for none.
+ALTER TABLE links ADD COLUMN utm_params TEXT NOT NULL DEFAULT '';
+
+-- +goose Down
+ALTER TABLE links DROP COLUMN utm_params;
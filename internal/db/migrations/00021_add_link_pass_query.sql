@@ -0,0 +1,7 @@
+-- Governing: SPEC-0009 REQ "Query-String Passthrough"
+-- +goose Up
+-- 1 forwards the incoming request's query string to the target URL.
+ALTER TABLE links ADD COLUMN pass_query INTEGER NOT NULL DEFAULT 0;
+
+-- +goose Down
+ALTER TABLE links DROP COLUMN pass_query;
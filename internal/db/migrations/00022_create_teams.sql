@@ -0,0 +1,16 @@
+-- Governing: SPEC-0002 REQ "Team Ownership"
+-- +goose Up
+-- Admin-managed teams. A link may name one owning team, shown in public views
+-- in place of its primary owner; '' means no team.
+CREATE TABLE IF NOT EXISTS teams (
+    id TEXT NOT NULL PRIMARY KEY,
+    slug TEXT NOT NULL UNIQUE,
+    name TEXT NOT NULL,
+    contact TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+ALTER TABLE links ADD COLUMN team_id TEXT NOT NULL DEFAULT '';
+
+-- +goose Down
+ALTER TABLE links DROP COLUMN team_id;
+DROP TABLE IF EXISTS teams;
@@ -0,0 +1,83 @@
+package migrations
+
+// Governing: SPEC-0002 REQ "Full-Text Link Search"
+// This Go migration creates the full-text search index for links. Each
+// database has its own engine: an FTS5 virtual table on SQLite, a tsvector
+// column with a GIN index on PostgreSQL, and an InnoDB FULLTEXT index on
+// MySQL. The store keeps the index current; this migration backfills it.
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upCreateLinkSearch, downCreateLinkSearch)
+}
+
+func upCreateLinkSearch(ctx context.Context, tx *sql.Tx) error {
+	var ddl []string
+	var backfill string
+	switch dialect {
+	case "postgres":
+		ddl = []string{
+			`CREATE TABLE IF NOT EXISTS link_search (
+    link_id  TEXT NOT NULL PRIMARY KEY,
+    document TSVECTOR NOT NULL
+)`,
+			`CREATE INDEX IF NOT EXISTS link_search_document_idx ON link_search USING GIN (document)`,
+		}
+		backfill = `INSERT INTO link_search (link_id, document)
+SELECT l.id,
+       setweight(to_tsvector('simple', l.slug), 'A') ||
+       setweight(to_tsvector('simple', l.title), 'A') ||
+       setweight(to_tsvector('simple', COALESCE((SELECT STRING_AGG(t.name, ' ') FROM link_tags lt JOIN tags t ON t.id = lt.tag_id WHERE lt.link_id = l.id), '')), 'B') ||
+       setweight(to_tsvector('simple', l.description), 'C') ||
+       setweight(to_tsvector('simple', l.url), 'D')
+FROM links l`
+	case "mysql":
+		ddl = []string{
+			`CREATE TABLE IF NOT EXISTS link_search (
+    link_id     VARCHAR(36) NOT NULL PRIMARY KEY,
+    slug        TEXT NOT NULL,
+    title       TEXT NOT NULL,
+    description TEXT NOT NULL,
+    url         TEXT NOT NULL,
+    tags        TEXT NOT NULL,
+    FULLTEXT INDEX link_search_fulltext (slug, title, description, url, tags)
+) ENGINE=InnoDB`,
+		}
+		backfill = `INSERT INTO link_search (link_id, slug, title, description, url, tags)
+SELECT l.id, l.slug, l.title, l.description, l.url,
+       COALESCE((SELECT GROUP_CONCAT(t.name SEPARATOR ' ') FROM link_tags lt JOIN tags t ON t.id = lt.tag_id WHERE lt.link_id = l.id), '')
+FROM links l`
+	default: // sqlite3
+		ddl = []string{
+			`CREATE VIRTUAL TABLE IF NOT EXISTS link_search USING fts5(
+    link_id UNINDEXED, slug, title, description, url, tags,
+    tokenize = 'unicode61'
+)`,
+		}
+		backfill = `INSERT INTO link_search (link_id, slug, title, description, url, tags)
+SELECT l.id, l.slug, l.title, l.description, l.url,
+       COALESCE((SELECT GROUP_CONCAT(t.name, ' ') FROM link_tags lt JOIN tags t ON t.id = lt.tag_id WHERE lt.link_id = l.id), '')
+FROM links l`
+	}
+	for _, stmt := range ddl {
+		if _, err := tx.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("create link_search: %w", err)
+		}
+	}
+	if _, err := tx.ExecContext(ctx, backfill); err != nil {
+		return fmt.Errorf("backfill link_search: %w", err)
+	}
+	return nil
+}
+
+func downCreateLinkSearch(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, `DROP TABLE IF EXISTS link_search`)
+	return err
+}
@@ -0,0 +1,16 @@
+-- Governing: SPEC-0004 REQ "Saved Searches"
+-- +goose Up
+-- Named dashboard views. query is a link search (free text plus key:value
+-- filters); tag, when set, narrows it to one tag slug.
+CREATE TABLE IF NOT EXISTS saved_searches (
+    id TEXT NOT NULL PRIMARY KEY,
+    user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    query TEXT NOT NULL DEFAULT '',
+    tag TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (user_id, name)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS saved_searches;
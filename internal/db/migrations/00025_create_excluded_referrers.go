@@ -0,0 +1,91 @@
+package migrations
+
+// Governing: SPEC-0016 REQ "Referrer Exclusion"
+// This Go migration adds link_clicks.referrer_host, backfilled by parsing the
+// stored referrer URLs (which no dialect can do portably in SQL), and creates
+// the admin-managed excluded_referrers table matched against it.
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upCreateExcludedReferrers, downCreateExcludedReferrers)
+}
+
+func upCreateExcludedReferrers(ctx context.Context, tx *sql.Tx) error {
+	ddl := []string{
+		`ALTER TABLE link_clicks ADD COLUMN referrer_host TEXT NOT NULL DEFAULT ''`,
+		`CREATE TABLE IF NOT EXISTS excluded_referrers (
+    domain     TEXT NOT NULL PRIMARY KEY,
+    reason     TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+)`,
+	}
+	update := `UPDATE link_clicks SET referrer_host = ? WHERE referrer = ?`
+	switch dialect {
+	case "postgres":
+		update = `UPDATE link_clicks SET referrer_host = $1 WHERE referrer = $2`
+	case "mysql":
+		// MySQL cannot default a TEXT column or use one as a primary key.
+		ddl = []string{
+			`ALTER TABLE link_clicks ADD COLUMN referrer_host VARCHAR(255) NOT NULL DEFAULT ''`,
+			`CREATE TABLE IF NOT EXISTS excluded_referrers (
+    domain     VARCHAR(255) NOT NULL PRIMARY KEY,
+    reason     VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+)`,
+		}
+	}
+	for _, stmt := range ddl {
+		if _, err := tx.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("create excluded_referrers: %w", err)
+		}
+	}
+
+	rows, err := tx.QueryContext(ctx, `SELECT DISTINCT referrer FROM link_clicks WHERE referrer IS NOT NULL AND referrer <> ''`)
+	if err != nil {
+		return fmt.Errorf("backfill referrer_host: %w", err)
+	}
+	var referrers []string
+	for rows.Next() {
+		var ref string
+		if err := rows.Scan(&ref); err != nil {
+			rows.Close()
+			return fmt.Errorf("backfill referrer_host: %w", err)
+		}
+		referrers = append(referrers, ref)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("backfill referrer_host: %w", err)
+	}
+	for _, ref := range referrers {
+		u, err := url.Parse(ref)
+		if err != nil || u.Hostname() == "" {
+			continue
+		}
+		if _, err := tx.ExecContext(ctx, update, strings.ToLower(u.Hostname()), ref); err != nil {
+			return fmt.Errorf("backfill referrer_host: %w", err)
+		}
+	}
+	return nil
+}
+
+func downCreateExcludedReferrers(ctx context.Context, tx *sql.Tx) error {
+	for _, stmt := range []string{
+		`DROP TABLE IF EXISTS excluded_referrers`,
+		`ALTER TABLE link_clicks DROP COLUMN referrer_host`,
+	} {
+		if _, err := tx.ExecContext(ctx, stmt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
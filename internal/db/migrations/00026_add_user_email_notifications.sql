@@ -0,0 +1,7 @@
+-- Governing: SPEC-0001 REQ "Email Notifications"
+-- +goose Up
+-- 1 sends the user co-owner and share notification emails; 0 opts out.
+ALTER TABLE users ADD COLUMN email_notifications INTEGER NOT NULL DEFAULT 1;
+
+-- +goose Down
+ALTER TABLE users DROP COLUMN email_notifications;
@@ -0,0 +1,31 @@
+-- Governing: SPEC-0011 REQ "Link Lifecycle Policies"
+-- +goose Up
+-- Admin-defined rules links must follow. scope is a link search filter
+-- (owner:, tag:, team:, visibility:) selecting the links a policy covers;
+-- rule is one of require_title, require_description, require_team, or
+-- max_age (days set). enforcement is warn or block.
+CREATE TABLE IF NOT EXISTS link_policies (
+    id TEXT NOT NULL PRIMARY KEY,
+    name TEXT NOT NULL UNIQUE,
+    scope TEXT NOT NULL DEFAULT '',
+    rule TEXT NOT NULL,
+    days INTEGER NOT NULL DEFAULT 0,
+    enforcement TEXT NOT NULL DEFAULT 'warn',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Outstanding violations, recorded when a link is saved and by the nightly
+-- policy sweep, and shown to the link's owners.
+CREATE TABLE IF NOT EXISTS link_policy_violations (
+    policy_id TEXT NOT NULL REFERENCES link_policies(id) ON DELETE CASCADE,
+    link_id TEXT NOT NULL REFERENCES links(id) ON DELETE CASCADE,
+    message TEXT NOT NULL,
+    detected_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (policy_id, link_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_link_policy_violations_link_id ON link_policy_violations(link_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS link_policy_violations;
+DROP TABLE IF EXISTS link_policies;
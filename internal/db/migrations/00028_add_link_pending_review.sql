@@ -0,0 +1,8 @@
+-- Governing: SPEC-0011 REQ "Public Link Moderation"
+-- +goose Up
+-- 1 while a public link waits for admin approval (JOE_MODERATION_ENABLED);
+-- such links resolve but are left out of public listings.
+ALTER TABLE links ADD COLUMN pending_review INTEGER NOT NULL DEFAULT 0;
+
+-- +goose Down
+ALTER TABLE links DROP COLUMN pending_review;
@@ -0,0 +1,14 @@
+-- Governing: SPEC-0002 REQ "Destination Domain Rules"
+-- +goose Up
+-- Admin-managed allow and deny rules for link destinations. Each rule
+-- matches its domain and all of its subdomains; the most specific rule
+-- wins. Once any allow rule exists, destinations no rule allows are refused.
+CREATE TABLE IF NOT EXISTS domain_rules (
+    domain TEXT NOT NULL PRIMARY KEY,
+    action TEXT NOT NULL,
+    note TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS domain_rules;
@@ -0,0 +1,9 @@
+-- Governing: SPEC-0016 REQ "Bot Filtering"
+-- +goose Up
+-- 1 when the click came from a crawler, link unfurler, or other automated
+-- client; stats leave such clicks out unless asked to include them. Clicks
+-- recorded before this migration count as human.
+ALTER TABLE link_clicks ADD COLUMN bot INTEGER NOT NULL DEFAULT 0;
+
+-- +goose Down
+ALTER TABLE link_clicks DROP COLUMN bot;
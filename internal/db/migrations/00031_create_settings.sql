@@ -0,0 +1,16 @@
+-- Governing: SPEC-0001 REQ "First-Run Setup"
+-- +goose Up
+-- Instance-wide settings changed at runtime rather than through JOE_*
+-- environment variables, such as the admin email chosen in the setup wizard.
+CREATE TABLE IF NOT EXISTS settings (
+    name TEXT NOT NULL PRIMARY KEY,
+    value TEXT NOT NULL DEFAULT '',
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Instances that already have users were set up before the wizard existed;
+-- mark them done so /setup never appears on an upgrade.
+INSERT INTO settings (name, value) SELECT 'setup.completed', 'migrated' FROM users LIMIT 1;
+
+-- +goose Down
+DROP TABLE IF EXISTS settings;
@@ -0,0 +1,8 @@
+-- Governing: SPEC-0016 REQ "GeoIP Country Breakdown"
+-- +goose Up
+-- ISO 3166-1 alpha-2 code of the country the click came from, resolved from
+-- the client IP when JOE_GEOIP_DATABASE is set; '' when unknown.
+ALTER TABLE link_clicks ADD COLUMN country TEXT NOT NULL DEFAULT '';
+
+-- +goose Down
+ALTER TABLE link_clicks DROP COLUMN country;
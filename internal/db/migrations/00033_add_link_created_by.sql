@@ -0,0 +1,13 @@
+-- Governing: SPEC-0002 REQ "Link Creator Attribution"
+-- +goose Up
+-- The user who created the link. Unlike the primary owner it never changes,
+-- so attribution survives ownership transfers and account deletions. Links
+-- created before this migration are attributed to their current primary owner.
+ALTER TABLE links ADD COLUMN created_by TEXT NOT NULL DEFAULT '';
+UPDATE links SET created_by = COALESCE(
+    (SELECT lo.user_id FROM link_owners lo WHERE lo.link_id = links.id AND lo.is_primary = 1),
+    ''
+);
+
+-- +goose Down
+ALTER TABLE links DROP COLUMN created_by;
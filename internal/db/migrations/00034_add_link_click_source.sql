@@ -0,0 +1,8 @@
+-- Governing: SPEC-0016 REQ "Campaign Sources"
+-- +goose Up
+-- Campaign source named by a valid signed ?src= tag on the short URL, such
+-- as 'email' for go/launch?src=email~...; '' when the click carried none.
+ALTER TABLE link_clicks ADD COLUMN source TEXT NOT NULL DEFAULT '';
+
+-- +goose Down
+ALTER TABLE link_clicks DROP COLUMN source;
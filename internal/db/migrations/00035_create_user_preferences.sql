@@ -0,0 +1,14 @@
+-- Governing: SPEC-0004 REQ "Sortable Link Lists"
+-- +goose Up
+-- Per-user UI preferences, such as the dashboard's sort order. name is a
+-- dotted key owned by the feature that reads it.
+CREATE TABLE IF NOT EXISTS user_preferences (
+    user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    value TEXT NOT NULL DEFAULT '',
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, name)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS user_preferences;
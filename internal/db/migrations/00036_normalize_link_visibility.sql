@@ -0,0 +1,17 @@
+-- Governing: SPEC-0010 REQ "Strict Visibility"
+-- +goose Up
+-- Legacy rows may carry visibility values the resolver does not know, which
+-- it used to treat as public. Fold case and whitespace variants into the
+-- known values, give empty values the column default, and make anything else
+-- secure, as joe-links fsck does, so bad data cannot widen access.
+UPDATE links SET visibility = LOWER(TRIM(visibility))
+WHERE LOWER(TRIM(visibility)) IN ('public', 'private', 'secure') AND visibility NOT IN ('public', 'private', 'secure');
+UPDATE links SET visibility = 'public' WHERE TRIM(visibility) = '';
+UPDATE links SET visibility = 'secure' WHERE visibility NOT IN ('public', 'private', 'secure');
+
+-- Instances that already have users predate strict visibility; mark them so
+-- strict mode stays opt-in there unless JOE_VISIBILITY_STRICT is set.
+INSERT INTO settings (name, value) SELECT 'visibility.lenient', 'migrated' FROM users LIMIT 1;
+
+-- +goose Down
+DELETE FROM settings WHERE name = 'visibility.lenient';
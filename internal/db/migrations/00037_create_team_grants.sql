@@ -0,0 +1,33 @@
+-- Governing: SPEC-0010 REQ "Team Shares and Ownership"
+-- +goose Up
+-- Team membership, and link ownership and shares granted to a whole team.
+-- A member of a team holds every grant made to it for as long as they stay
+-- a member.
+CREATE TABLE IF NOT EXISTS team_members (
+    team_id    TEXT NOT NULL REFERENCES teams(id) ON DELETE CASCADE,
+    user_id    TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (team_id, user_id)
+);
+CREATE INDEX idx_team_members_user ON team_members(user_id);
+
+CREATE TABLE IF NOT EXISTS link_team_owners (
+    link_id    TEXT NOT NULL REFERENCES links(id) ON DELETE CASCADE,
+    team_id    TEXT NOT NULL REFERENCES teams(id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (link_id, team_id)
+);
+
+CREATE TABLE IF NOT EXISTS link_team_shares (
+    link_id    TEXT NOT NULL REFERENCES links(id) ON DELETE CASCADE,
+    team_id    TEXT NOT NULL REFERENCES teams(id) ON DELETE CASCADE,
+    shared_by  TEXT NOT NULL REFERENCES users(id),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (link_id, team_id)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS link_team_shares;
+DROP TABLE IF EXISTS link_team_owners;
+DROP INDEX IF EXISTS idx_team_members_user;
+DROP TABLE IF EXISTS team_members;
@@ -0,0 +1,149 @@
+package migrations
+
+// Governing: SPEC-0001 REQ "Multi-Tenancy"
+// This Go migration adds the tenants table and a tenant_id column to links,
+// keywords, and users, and narrows slug and keyword uniqueness to a single
+// tenant. Keyword uniqueness was declared inline, so each database drops it
+// differently: PostgreSQL names the constraint keywords_keyword_key, MySQL
+// names the index after the column, and SQLite has to rebuild the table.
+// Existing rows join the default tenant, whose ID is the empty string.
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upCreateTenants, downCreateTenants)
+}
+
+func upCreateTenants(ctx context.Context, tx *sql.Tx) error {
+	for _, stmt := range tenantsUpStmts() {
+		if _, err := tx.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("create tenants: %w", err)
+		}
+	}
+	return nil
+}
+
+func downCreateTenants(ctx context.Context, tx *sql.Tx) error {
+	for _, stmt := range tenantsDownStmts() {
+		if _, err := tx.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("drop tenants: %w", err)
+		}
+	}
+	return nil
+}
+
+func tenantsUpStmts() []string {
+	switch dialect {
+	case "postgres":
+		return []string{
+			`CREATE TABLE IF NOT EXISTS tenants (
+    id         TEXT PRIMARY KEY,
+    hostname   TEXT NOT NULL UNIQUE,
+    name       TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+)`,
+			`ALTER TABLE links ADD COLUMN IF NOT EXISTS tenant_id TEXT NOT NULL DEFAULT ''`,
+			`ALTER TABLE keywords ADD COLUMN IF NOT EXISTS tenant_id TEXT NOT NULL DEFAULT ''`,
+			`ALTER TABLE users ADD COLUMN IF NOT EXISTS tenant_id TEXT NOT NULL DEFAULT ''`,
+			`DROP INDEX IF EXISTS idx_links_slug`,
+			`CREATE UNIQUE INDEX idx_links_tenant_slug ON links(tenant_id, slug)`,
+			`ALTER TABLE keywords DROP CONSTRAINT IF EXISTS keywords_keyword_key`,
+			`CREATE UNIQUE INDEX idx_keywords_tenant_keyword ON keywords(tenant_id, keyword)`,
+		}
+	case "mysql":
+		return []string{
+			`CREATE TABLE IF NOT EXISTS tenants (
+    id         VARCHAR(36) PRIMARY KEY,
+    hostname   VARCHAR(255) NOT NULL UNIQUE,
+    name       TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+)`,
+			`ALTER TABLE links ADD COLUMN tenant_id VARCHAR(36) NOT NULL DEFAULT ''`,
+			`ALTER TABLE keywords ADD COLUMN tenant_id VARCHAR(36) NOT NULL DEFAULT ''`,
+			`ALTER TABLE users ADD COLUMN tenant_id VARCHAR(36) NOT NULL DEFAULT ''`,
+			`DROP INDEX idx_links_slug ON links`,
+			`CREATE UNIQUE INDEX idx_links_tenant_slug ON links(tenant_id, slug(255))`,
+			`DROP INDEX keyword ON keywords`,
+			`CREATE UNIQUE INDEX idx_keywords_tenant_keyword ON keywords(tenant_id, keyword(255))`,
+		}
+	default: // sqlite3
+		return []string{
+			`CREATE TABLE IF NOT EXISTS tenants (
+    id         TEXT PRIMARY KEY,
+    hostname   TEXT NOT NULL UNIQUE,
+    name       TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+)`,
+			`ALTER TABLE links ADD COLUMN tenant_id TEXT NOT NULL DEFAULT ''`,
+			`ALTER TABLE users ADD COLUMN tenant_id TEXT NOT NULL DEFAULT ''`,
+			`DROP INDEX IF EXISTS idx_links_slug`,
+			`CREATE UNIQUE INDEX idx_links_tenant_slug ON links(tenant_id, slug)`,
+			`CREATE TABLE keywords_new (
+    id           TEXT PRIMARY KEY,
+    tenant_id    TEXT NOT NULL DEFAULT '',
+    keyword      TEXT NOT NULL,
+    url_template TEXT NOT NULL,
+    description  TEXT NOT NULL DEFAULT '',
+    created_at   TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+)`,
+			`INSERT INTO keywords_new (id, keyword, url_template, description, created_at)
+SELECT id, keyword, url_template, description, created_at FROM keywords`,
+			`DROP TABLE keywords`,
+			`ALTER TABLE keywords_new RENAME TO keywords`,
+			`CREATE UNIQUE INDEX idx_keywords_tenant_keyword ON keywords(tenant_id, keyword)`,
+		}
+	}
+}
+
+func tenantsDownStmts() []string {
+	switch dialect {
+	case "postgres":
+		return []string{
+			`DROP INDEX IF EXISTS idx_keywords_tenant_keyword`,
+			`ALTER TABLE keywords ADD CONSTRAINT keywords_keyword_key UNIQUE (keyword)`,
+			`DROP INDEX IF EXISTS idx_links_tenant_slug`,
+			`CREATE UNIQUE INDEX idx_links_slug ON links(slug)`,
+			`ALTER TABLE users DROP COLUMN tenant_id`,
+			`ALTER TABLE keywords DROP COLUMN tenant_id`,
+			`ALTER TABLE links DROP COLUMN tenant_id`,
+			`DROP TABLE IF EXISTS tenants`,
+		}
+	case "mysql":
+		return []string{
+			`DROP INDEX idx_keywords_tenant_keyword ON keywords`,
+			`CREATE UNIQUE INDEX keyword ON keywords(keyword(255))`,
+			`DROP INDEX idx_links_tenant_slug ON links`,
+			`CREATE UNIQUE INDEX idx_links_slug ON links(slug(255))`,
+			`ALTER TABLE users DROP COLUMN tenant_id`,
+			`ALTER TABLE keywords DROP COLUMN tenant_id`,
+			`ALTER TABLE links DROP COLUMN tenant_id`,
+			`DROP TABLE IF EXISTS tenants`,
+		}
+	default: // sqlite3
+		return []string{
+			`DROP INDEX IF EXISTS idx_keywords_tenant_keyword`,
+			`CREATE TABLE keywords_old (
+    id           TEXT PRIMARY KEY,
+    keyword      TEXT NOT NULL UNIQUE,
+    url_template TEXT NOT NULL,
+    description  TEXT NOT NULL DEFAULT '',
+    created_at   TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+)`,
+			`INSERT INTO keywords_old (id, keyword, url_template, description, created_at)
+SELECT id, keyword, url_template, description, created_at FROM keywords`,
+			`DROP TABLE keywords`,
+			`ALTER TABLE keywords_old RENAME TO keywords`,
+			`DROP INDEX IF EXISTS idx_links_tenant_slug`,
+			`CREATE UNIQUE INDEX idx_links_slug ON links(slug)`,
+			`ALTER TABLE users DROP COLUMN tenant_id`,
+			`ALTER TABLE links DROP COLUMN tenant_id`,
+			`DROP TABLE IF EXISTS tenants`,
+		}
+	}
+}
@@ -0,0 +1,13 @@
+-- Governing: SPEC-0016 REQ "Keyword Analytics"
+-- +goose Up
+-- Daily hit counts for keyword redirects, which have no link to record a
+-- click against. Only the count is kept: keyword hits carry no per-user data.
+CREATE TABLE IF NOT EXISTS keyword_clicks (
+    keyword_id TEXT NOT NULL REFERENCES keywords(id) ON DELETE CASCADE,
+    day        TEXT NOT NULL,
+    clicks     INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (keyword_id, day)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS keyword_clicks;
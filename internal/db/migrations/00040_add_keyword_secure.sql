@@ -0,0 +1,8 @@
+-- Governing: SPEC-0008 REQ "Secure Keywords"
+-- +goose Up
+-- 1 when the keyword only redirects signed-in users; anonymous visitors are
+-- sent to log in first. Existing keywords stay open to everyone.
+ALTER TABLE keywords ADD COLUMN secure INTEGER NOT NULL DEFAULT 0;
+
+-- +goose Down
+ALTER TABLE keywords DROP COLUMN secure;
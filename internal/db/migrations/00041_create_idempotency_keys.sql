@@ -0,0 +1,16 @@
+-- Governing: SPEC-0005 REQ "Idempotent Link Creation"
+-- +goose Up
+-- Idempotency-Key values sent with POST /api/v1/links, per user, with a hash
+-- of the request body and the link it created, so a retried request returns
+-- that link instead of a conflict. Keys are forgotten after a day.
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    user_id         TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    idempotency_key TEXT NOT NULL,
+    request_hash    TEXT NOT NULL,
+    link_id         TEXT NOT NULL REFERENCES links(id) ON DELETE CASCADE,
+    created_at      TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, idempotency_key)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS idempotency_keys;
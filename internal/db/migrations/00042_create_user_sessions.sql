@@ -0,0 +1,19 @@
+-- Governing: SPEC-0001 REQ "Session Management"
+-- +goose Up
+-- One row per signed-in browser session, alongside the sessions table the
+-- session manager owns. token is that table's key and never leaves the
+-- server; id names the session in URLs. Rows whose session has expired or
+-- been destroyed are pruned when the user lists their sessions.
+CREATE TABLE IF NOT EXISTS user_sessions (
+    id TEXT NOT NULL PRIMARY KEY,
+    token TEXT NOT NULL UNIQUE,
+    user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    ip_hash TEXT NOT NULL DEFAULT '',
+    user_agent TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_seen_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_user_sessions_user_id ON user_sessions(user_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS user_sessions;
@@ -0,0 +1,29 @@
+-- Governing: SPEC-0001 REQ "Account Linking"
+-- +goose Up
+-- Each (provider, subject) a user can sign in with. users.provider and
+-- users.subject remain the identity the account was created with; every
+-- existing account starts with that one identity, sharing the user's ID.
+CREATE TABLE IF NOT EXISTS user_identities (
+    id TEXT NOT NULL PRIMARY KEY,
+    user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    provider TEXT NOT NULL,
+    subject TEXT NOT NULL,
+    email TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(provider, subject)
+);
+CREATE INDEX IF NOT EXISTS idx_user_identities_user_id ON user_identities(user_id);
+INSERT INTO user_identities (id, user_id, provider, subject, email, created_at)
+    SELECT id, id, provider, subject, email, created_at FROM users;
+
+-- One-time codes a signed-in user creates to attach another sign-in to
+-- their account. Only a SHA-256 hash of the code is stored.
+CREATE TABLE IF NOT EXISTS account_link_codes (
+    code_hash TEXT NOT NULL PRIMARY KEY,
+    user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    expires_at TIMESTAMP NOT NULL
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS account_link_codes;
+DROP TABLE IF EXISTS user_identities;
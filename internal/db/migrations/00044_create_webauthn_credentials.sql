@@ -0,0 +1,20 @@
+-- Governing: SPEC-0001 REQ "WebAuthn Step-Up"
+-- +goose Up
+-- Passkeys users register to confirm sensitive actions. credential_id and
+-- public_key (a DER SubjectPublicKeyInfo) are base64url text so every
+-- driver stores them the same way; alg is the COSE algorithm identifier.
+CREATE TABLE IF NOT EXISTS webauthn_credentials (
+    id TEXT NOT NULL PRIMARY KEY,
+    user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    credential_id TEXT NOT NULL UNIQUE,
+    public_key TEXT NOT NULL,
+    alg INTEGER NOT NULL,
+    sign_count BIGINT NOT NULL DEFAULT 0,
+    name TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_used_at TIMESTAMP NULL
+);
+CREATE INDEX IF NOT EXISTS idx_webauthn_credentials_user_id ON webauthn_credentials(user_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS webauthn_credentials;
@@ -0,0 +1,306 @@
+// Governing: SPEC-0001 REQ "Database Schema Migrations", REQ "Migration Pre-Flight Check", ADR-0002
+package db
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pressly/goose/v3"
+)
+
+// DefaultLargeTableRows is the estimated row count above which pre-flight
+// warns that a pending migration may hold locks long enough to stall redirects.
+const DefaultLargeTableRows = 100_000
+
+// MigrateOptions tunes how pending migrations are checked and applied.
+type MigrateOptions struct {
+	// Online builds indexes without blocking writes where the driver supports
+	// it: on PostgreSQL, migrations consisting only of CREATE INDEX statements
+	// run outside a transaction with CREATE INDEX CONCURRENTLY. MySQL/InnoDB
+	// already builds secondary indexes in place without blocking writes.
+	Online bool
+	// LargeTableRows overrides DefaultLargeTableRows when > 0.
+	LargeTableRows int64
+}
+
+// PreflightWarning flags one pending migration statement that may lock a large table.
+type PreflightWarning struct {
+	Version int64
+	Source  string
+	Table   string
+	Rows    int64 // estimated
+	Message string
+}
+
+func (w PreflightWarning) String() string {
+	return fmt.Sprintf("%s: %s", w.Source, w.Message)
+}
+
+// PreflightReport describes the pending migrations and their locking risks.
+type PreflightReport struct {
+	Current  int64
+	Pending  []string // migration file names, in apply order
+	Warnings []PreflightWarning
+}
+
+var (
+	alterTableRE  = regexp.MustCompile(`(?is)^ALTER\s+TABLE\s+(?:IF\s+EXISTS\s+)?["` + "`" + `]?(\w+)`)
+	createIndexRE = regexp.MustCompile(`(?is)^CREATE\s+(UNIQUE\s+)?INDEX\s+(CONCURRENTLY\s+)?(?:IF\s+NOT\s+EXISTS\s+)?\S+\s+ON\s+["` + "`" + `]?(\w+)`)
+	updateRE      = regexp.MustCompile(`(?is)^UPDATE\s+["` + "`" + `]?(\w+)`)
+)
+
+// Preflight inspects pending migrations without applying them. For each
+// ALTER TABLE, CREATE INDEX, or backfill UPDATE against an existing table it
+// estimates the table's size on MySQL and PostgreSQL and warns when the
+// statement may block writes on a large table. Go migrations cannot be
+// inspected and are listed without warnings.
+func Preflight(ctx context.Context, db *sqlx.DB, driver string, opts MigrateOptions) (*PreflightReport, error) {
+	dialect, err := gooseDialect(driver)
+	if err != nil {
+		return nil, err
+	}
+	if err := goose.SetDialect(dialect); err != nil {
+		return nil, fmt.Errorf("set goose dialect: %w", err)
+	}
+	sub, err := fs.Sub(Migrations, "migrations")
+	if err != nil {
+		return nil, fmt.Errorf("sub migrations fs: %w", err)
+	}
+	goose.SetBaseFS(sub)
+	defer goose.SetBaseFS(nil)
+
+	current, err := goose.GetDBVersionContext(ctx, db.DB)
+	if err != nil {
+		return nil, fmt.Errorf("read migration version: %w", err)
+	}
+	all, err := goose.CollectMigrations(".", 0, goose.MaxVersion)
+	if err != nil {
+		return nil, fmt.Errorf("collect migrations: %w", err)
+	}
+
+	threshold := opts.LargeTableRows
+	if threshold <= 0 {
+		threshold = DefaultLargeTableRows
+	}
+	report := &PreflightReport{Current: current}
+	rowCache := map[string]int64{}
+	for _, m := range all {
+		if m.Version <= current {
+			continue
+		}
+		name := path.Base(m.Source)
+		report.Pending = append(report.Pending, name)
+		if !strings.HasSuffix(name, ".sql") {
+			continue
+		}
+		src, err := fs.ReadFile(sub, name)
+		if err != nil {
+			return nil, err
+		}
+		stmts := upStatements(string(src))
+		online := opts.Online && dialect == "postgres" && indexOnly(stmts)
+		for _, stmt := range stmts {
+			// SQLite serializes all writers anyway, so there is nothing to warn about.
+			table, kind := classifyStatement(stmt)
+			if table == "" || dialect == "sqlite3" {
+				continue
+			}
+			rows, ok := rowCache[table]
+			if !ok {
+				rows, err = estimateRows(ctx, db, dialect, table)
+				if err != nil {
+					return nil, fmt.Errorf("estimate rows for %s: %w", table, err)
+				}
+				rowCache[table] = rows
+			}
+			if rows < threshold {
+				continue
+			}
+			if msg := lockWarning(dialect, kind, table, rows, online, opts.Online); msg != "" {
+				report.Warnings = append(report.Warnings, PreflightWarning{
+					Version: m.Version, Source: name, Table: table, Rows: rows, Message: msg,
+				})
+			}
+		}
+	}
+	return report, nil
+}
+
+// upStatements returns the statements in a goose SQL file's Up section.
+func upStatements(src string) []string {
+	var up strings.Builder
+	inUp := false
+	for _, line := range strings.Split(src, "\n") {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(trimmed, "-- +goose Up"):
+			inUp = true
+			continue
+		case strings.HasPrefix(trimmed, "-- +goose Down"):
+			inUp = false
+			continue
+		case strings.HasPrefix(trimmed, "--"):
+			continue
+		}
+		if inUp {
+			up.WriteString(line)
+			up.WriteByte('\n')
+		}
+	}
+	var stmts []string
+	for _, s := range strings.Split(up.String(), ";") {
+		if s = strings.TrimSpace(s); s != "" {
+			stmts = append(stmts, s)
+		}
+	}
+	return stmts
+}
+
+// classifyStatement returns the table a statement locks and its kind
+// ("alter", "index", "index_concurrently", or "backfill"), or "" when the
+// statement does not touch an existing table in a lock-prone way.
+func classifyStatement(stmt string) (table, kind string) {
+	if m := alterTableRE.FindStringSubmatch(stmt); m != nil {
+		return m[1], "alter"
+	}
+	if m := createIndexRE.FindStringSubmatch(stmt); m != nil {
+		if m[2] != "" {
+			return m[3], "index_concurrently"
+		}
+		return m[3], "index"
+	}
+	if m := updateRE.FindStringSubmatch(stmt); m != nil {
+		return m[1], "backfill"
+	}
+	return "", ""
+}
+
+// indexOnly reports whether every statement is a plain CREATE INDEX, which
+// is the only shape that can safely be rewritten to run concurrently.
+func indexOnly(stmts []string) bool {
+	if len(stmts) == 0 {
+		return false
+	}
+	for _, s := range stmts {
+		if _, kind := classifyStatement(s); kind != "index" && kind != "index_concurrently" {
+			return false
+		}
+	}
+	return true
+}
+
+func lockWarning(dialect, kind, table string, rows int64, onlineApplied, onlineRequested bool) string {
+	switch kind {
+	case "index":
+		if dialect == "mysql" || onlineApplied {
+			return ""
+		}
+		if onlineRequested {
+			return fmt.Sprintf("CREATE INDEX on %s (~%d rows) blocks writes; --online cannot apply because the migration mixes index and other statements", table, rows)
+		}
+		return fmt.Sprintf("CREATE INDEX on %s (~%d rows) blocks writes until built; re-run with --online to build it CONCURRENTLY", table, rows)
+	case "alter":
+		if dialect == "postgres" {
+			return fmt.Sprintf("ALTER TABLE %s (~%d rows) takes an ACCESS EXCLUSIVE lock; defaults that are volatile or type changes rewrite the table", table, rows)
+		}
+		return fmt.Sprintf("ALTER TABLE %s (~%d rows) may copy the table and block writes unless InnoDB can apply it INSTANT or INPLACE", table, rows)
+	case "backfill":
+		return fmt.Sprintf("backfill UPDATE on %s (~%d rows) holds row locks until the migration commits", table, rows)
+	}
+	return ""
+}
+
+// estimateRows returns an approximate row count from catalog statistics
+// (cheap, no table scan). Tables that do not exist yet report zero.
+func estimateRows(ctx context.Context, db *sqlx.DB, dialect, table string) (int64, error) {
+	var n int64
+	var err error
+	switch dialect {
+	case "postgres":
+		err = db.GetContext(ctx, &n, `SELECT COALESCE(MAX(reltuples), 0)::bigint FROM pg_class WHERE relname = $1 AND relkind = 'r'`, table)
+	case "mysql":
+		err = db.GetContext(ctx, &n, `SELECT COALESCE(MAX(TABLE_ROWS), 0) FROM information_schema.TABLES WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ?`, table)
+	}
+	return n, err
+}
+
+// onlineFS rewrites index-only SQL migrations to build indexes concurrently
+// outside a transaction (PostgreSQL only). Other files pass through unchanged.
+type onlineFS struct {
+	fs.FS
+}
+
+var createIndexPrefixRE = regexp.MustCompile(`(?i)\bCREATE\s+(UNIQUE\s+)?INDEX\s+`)
+
+func (o onlineFS) ReadDir(name string) ([]fs.DirEntry, error) { return fs.ReadDir(o.FS, name) }
+
+func (o onlineFS) ReadFile(name string) ([]byte, error) {
+	b, err := fs.ReadFile(o.FS, name)
+	if err != nil || !strings.HasSuffix(name, ".sql") {
+		return b, err
+	}
+	return rewriteOnline(b), nil
+}
+
+func (o onlineFS) Open(name string) (fs.File, error) {
+	f, err := o.FS.Open(name)
+	if err != nil || !strings.HasSuffix(name, ".sql") {
+		return f, err
+	}
+	defer func() { _ = f.Close() }()
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	b, err := io.ReadAll(f)
+	if err != nil {
+		return nil, err
+	}
+	b = rewriteOnline(b)
+	return &memFile{Reader: bytes.NewReader(b), info: sizedInfo{FileInfo: info, size: int64(len(b))}}, nil
+}
+
+// rewriteOnline converts an index-only migration to CREATE INDEX CONCURRENTLY
+// and marks it NO TRANSACTION, since PostgreSQL forbids concurrent index
+// builds inside a transaction. Any other migration is returned unchanged.
+func rewriteOnline(src []byte) []byte {
+	s := string(src)
+	if strings.Contains(s, "+goose NO TRANSACTION") || strings.Contains(s, "+goose StatementBegin") ||
+		strings.Contains(strings.ToUpper(s), "CONCURRENTLY") || !indexOnly(upStatements(s)) {
+		return src
+	}
+	up, down, ok := strings.Cut(s, "-- +goose Down")
+	up = createIndexPrefixRE.ReplaceAllStringFunc(up, func(m string) string {
+		if strings.Contains(strings.ToUpper(m), "UNIQUE") {
+			return "CREATE UNIQUE INDEX CONCURRENTLY "
+		}
+		return "CREATE INDEX CONCURRENTLY "
+	})
+	out := "-- +goose NO TRANSACTION\n" + up
+	if ok {
+		out += "-- +goose Down" + down
+	}
+	return []byte(out)
+}
+
+type memFile struct {
+	*bytes.Reader
+	info fs.FileInfo
+}
+
+func (f *memFile) Stat() (fs.FileInfo, error) { return f.info, nil }
+func (f *memFile) Close() error               { return nil }
+
+type sizedInfo struct {
+	fs.FileInfo
+	size int64
+}
+
+func (i sizedInfo) Size() int64 { return i.size }
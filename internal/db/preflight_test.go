@@ -0,0 +1,124 @@
+package db
+
+import (
+	"context"
+	"io/fs"
+	"strings"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	_ "modernc.org/sqlite"
+)
+
+const indexOnlyMigration = `-- Governing: test
+-- +goose Up
+CREATE INDEX IF NOT EXISTS idx_link_clicks_ip ON link_clicks(ip_hash);
+CREATE UNIQUE INDEX idx_links_url ON links(url);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_link_clicks_ip;
+DROP INDEX IF EXISTS idx_links_url;
+`
+
+func TestClassifyStatement(t *testing.T) {
+	tests := []struct {
+		stmt, table, kind string
+	}{
+		{"ALTER TABLE links ADD COLUMN x TEXT", "links", "alter"},
+		{"CREATE INDEX IF NOT EXISTS idx ON link_clicks(link_id)", "link_clicks", "index"},
+		{"CREATE UNIQUE INDEX CONCURRENTLY idx ON users (email)", "users", "index_concurrently"},
+		{"UPDATE users SET display_name_slug = ''", "users", "backfill"},
+		{"CREATE TABLE IF NOT EXISTS t (id TEXT)", "", ""},
+	}
+	for _, tt := range tests {
+		table, kind := classifyStatement(tt.stmt)
+		if table != tt.table || kind != tt.kind {
+			t.Errorf("classifyStatement(%q) = (%q, %q), want (%q, %q)", tt.stmt, table, kind, tt.table, tt.kind)
+		}
+	}
+}
+
+func TestRewriteOnline_IndexOnly(t *testing.T) {
+	got := string(rewriteOnline([]byte(indexOnlyMigration)))
+	if !strings.HasPrefix(got, "-- +goose NO TRANSACTION\n") {
+		t.Errorf("missing NO TRANSACTION annotation:\n%s", got)
+	}
+	for _, want := range []string{
+		"CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_link_clicks_ip",
+		"CREATE UNIQUE INDEX CONCURRENTLY idx_links_url",
+		"DROP INDEX IF EXISTS idx_links_url",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("rewritten migration missing %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestRewriteOnline_MixedUnchanged(t *testing.T) {
+	src := "-- +goose Up\nALTER TABLE links ADD COLUMN x TEXT;\nCREATE INDEX idx_x ON links(x);\n-- +goose Down\n"
+	if got := string(rewriteOnline([]byte(src))); got != src {
+		t.Errorf("mixed migration rewritten:\n%s", got)
+	}
+}
+
+func TestOnlineFS_RewritesEmbeddedFiles(t *testing.T) {
+	sub, err := fs.Sub(Migrations, "migrations")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 00007 mixes CREATE TABLE with indexes, so it must pass through unchanged.
+	orig, _ := fs.ReadFile(sub, "00007_create_api_tokens.sql")
+	got, err := fs.ReadFile(onlineFS{sub}, "00007_create_api_tokens.sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != string(orig) {
+		t.Error("mixed migration rewritten by onlineFS")
+	}
+	entries, err := fs.ReadDir(onlineFS{sub}, ".")
+	if err != nil || len(entries) == 0 {
+		t.Fatalf("ReadDir = %d entries, err %v", len(entries), err)
+	}
+}
+
+func TestLockWarning(t *testing.T) {
+	if msg := lockWarning("postgres", "index", "links", 500_000, false, false); !strings.Contains(msg, "--online") {
+		t.Errorf("postgres index warning = %q, want --online hint", msg)
+	}
+	if msg := lockWarning("postgres", "index", "links", 500_000, true, true); msg != "" {
+		t.Errorf("online postgres index warning = %q, want none", msg)
+	}
+	if msg := lockWarning("mysql", "alter", "links", 500_000, false, false); !strings.Contains(msg, "ALTER TABLE links") {
+		t.Errorf("mysql alter warning = %q", msg)
+	}
+}
+
+func TestPreflight_SQLiteListsPending(t *testing.T) {
+	conn, err := sqlx.Open("sqlite", "file:"+t.Name()+"?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+
+	report, err := Preflight(context.Background(), conn, "sqlite3", MigrateOptions{})
+	if err != nil {
+		t.Fatalf("Preflight: %v", err)
+	}
+	if report.Current != 0 || len(report.Pending) == 0 || report.Pending[0] != "00001_create_users.sql" {
+		t.Errorf("report = %+v, want all migrations pending from 00001", report)
+	}
+	if len(report.Warnings) != 0 {
+		t.Errorf("sqlite warnings = %v, want none", report.Warnings)
+	}
+
+	if err := MigrateWithOptions(context.Background(), conn, "sqlite3", MigrateOptions{}); err != nil {
+		t.Fatalf("MigrateWithOptions: %v", err)
+	}
+	after, err := Preflight(context.Background(), conn, "sqlite3", MigrateOptions{})
+	if err != nil {
+		t.Fatalf("Preflight after migrate: %v", err)
+	}
+	if len(after.Pending) != 0 {
+		t.Errorf("pending after migrate = %v, want none", after.Pending)
+	}
+}
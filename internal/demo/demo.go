@@ -0,0 +1,230 @@
+// Package demo runs a public sandbox instance (JOE_DEMO_MODE): it seeds
+// sample data, signs every visitor in as a shared demo admin, and wipes and
+// reseeds the database on an interval.
+// Governing: SPEC-0001 REQ "Demo Mode"
+package demo
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/alexedwards/scs/v2"
+	"github.com/jmoiron/sqlx"
+	"github.com/joestump/joe-links/internal/auth"
+	"github.com/joestump/joe-links/internal/metrics"
+	"github.com/joestump/joe-links/internal/setup"
+	"github.com/joestump/joe-links/internal/store"
+)
+
+// DefaultResetInterval is how often the sandbox is reset unless
+// JOE_DEMO_RESET_INTERVAL says otherwise.
+const DefaultResetInterval = time.Hour
+
+// The shared account every visitor is signed in as.
+const (
+	UserProvider = "demo"
+	UserSubject  = "demo"
+	UserEmail    = "demo@example.com"
+	UserName     = "Demo User"
+)
+
+// sampleLink is a seeded link beyond setup.DemoLinks.
+type sampleLink struct {
+	slug, url, title, description, visibility string
+	tags                                      []string
+	team                                      bool // owned by the sample team
+	sam                                       bool // owned by the second sample user
+	clicks                                    int
+}
+
+var sampleLinks = []sampleLink{
+	{"standup", "https://meet.example.com/daily-standup", "Daily standup", "The team's video call.", "public", []string{"Meetings"}, true, false, 40},
+	{"oncall", "https://pagerduty.example.com/schedules/platform", "On-call schedule", "", "public", []string{"Ops"}, true, false, 25},
+	{"roadmap", "https://docs.example.com/roadmap", "Roadmap", "What we are building this quarter.", "public", []string{"Planning"}, false, true, 12},
+	{"payroll", "https://hr.example.com/payroll", "Payroll", "Only visible to its owners and people it is shared with.", "secure", []string{"HR"}, false, true, 0},
+	{"notes", "https://notes.example.com/demo", "My notes", "A private link only you can see.", "private", nil, false, false, 3},
+}
+
+// Sandbox seeds and resets the demo database.
+type Sandbox struct {
+	db       *sqlx.DB
+	users    *store.UserStore
+	links    *store.LinkStore
+	keywords *store.KeywordStore
+	teams    *store.TeamStore
+	clicks   *store.ClickStore
+	settings *store.SettingsStore
+
+	mu     sync.RWMutex
+	userID string // the demo user's ID; changes on every reset
+}
+
+// New creates a Sandbox.
+func New(db *sqlx.DB, us *store.UserStore, ls *store.LinkStore, ks *store.KeywordStore, ts *store.TeamStore, cs *store.ClickStore, ss *store.SettingsStore) *Sandbox {
+	return &Sandbox{db: db, users: us, links: ls, keywords: ks, teams: ts, clicks: cs, settings: ss}
+}
+
+// UserID returns the ID of the demo user, or "" before the first reset.
+func (s *Sandbox) UserID() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.userID
+}
+
+// Reset deletes all data and seeds the sample data again.
+func (s *Sandbox) Reset(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := store.DeleteAllData(ctx, s.db); err != nil {
+		return err
+	}
+	id, err := s.seed(ctx)
+	if err != nil {
+		return err
+	}
+	s.userID = id
+	return nil
+}
+
+// seed creates the sample data and returns the demo user's ID.
+func (s *Sandbox) seed(ctx context.Context) (string, error) {
+	if err := s.settings.Set(ctx, store.SettingSetupCompleted, time.Now().UTC().Format(time.RFC3339)); err != nil {
+		return "", err
+	}
+	demo, err := s.users.Upsert(ctx, UserProvider, UserSubject, UserEmail, UserName, "admin")
+	if err != nil {
+		return "", err
+	}
+	sam, err := s.users.Upsert(ctx, UserProvider, "sam", "sam@example.com", "Sam Sample", "user")
+	if err != nil {
+		return "", err
+	}
+	team, err := s.teams.Create(ctx, "platform", "Platform", "#platform")
+	if err != nil {
+		return "", err
+	}
+	if _, err := s.keywords.Create(ctx, "jira", "https://jira.example.com/browse/{slug}", "Jira issues"); err != nil {
+		return "", err
+	}
+
+	for _, d := range setup.DemoLinks {
+		if _, err := s.links.Create(ctx, d.Slug, d.URL, demo.ID, d.Title, d.Description, "public"); err != nil {
+			return "", err
+		}
+	}
+	now := time.Now().UTC()
+	var events []store.ClickEvent
+	for _, l := range sampleLinks {
+		owner := demo.ID
+		if l.sam {
+			owner = sam.ID
+		}
+		link, err := s.links.Create(ctx, l.slug, l.url, owner, l.title, l.description, l.visibility)
+		if err != nil {
+			return "", err
+		}
+		if len(l.tags) > 0 {
+			if err := s.links.SetTags(ctx, link.ID, l.tags); err != nil {
+				return "", err
+			}
+		}
+		if l.team {
+			if err := s.links.SetTeam(ctx, link.ID, team.ID); err != nil {
+				return "", err
+			}
+		}
+		// Spread clicks over the last month so the stats pages have shape.
+		for i := 0; i < l.clicks; i++ {
+			events = append(events, store.ClickEvent{
+				LinkID:    link.ID,
+				UserAgent: "Mozilla/5.0 (demo)",
+				ClickedAt: now.Add(-time.Duration(i*i%720+i) * time.Hour),
+			})
+		}
+	}
+	for len(events) > 0 {
+		n := min(len(events), store.ClickBatchSize)
+		if _, err := s.clicks.RecordClicks(ctx, events[:n]); err != nil {
+			return "", err
+		}
+		events = events[n:]
+	}
+	return demo.ID, nil
+}
+
+// Run resets the sandbox every interval until ctx is done. The caller
+// performs the first reset before serving.
+func (s *Sandbox) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.Reset(ctx); err != nil {
+				log.Printf("demo reset: %v", err)
+				continue
+			}
+			metrics.MarkJobSuccess(metrics.JobDemoReset)
+		}
+	}
+}
+
+// AutoLogin signs every request without a current demo session in as the
+// demo user. Sessions from before a reset point at a deleted user and are
+// replaced too. It must run inside sm.LoadAndSave.
+func (s *Sandbox) AutoLogin(sm *scs.SessionManager) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if id := s.UserID(); id != "" && sm.GetString(r.Context(), auth.SessionUserIDKey) != id {
+				sm.Put(r.Context(), auth.SessionUserIDKey, id)
+				sm.Put(r.Context(), auth.SessionRoleKey, "admin")
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// Login replaces /auth/login: visitors are already signed in, so it only
+// sends them on to ?redirect= (a local path) or the dashboard.
+func Login(w http.ResponseWriter, r *http.Request) {
+	target := r.URL.Query().Get("redirect")
+	if len(target) < 2 || target[0] != '/' || target[1] == '/' || target[1] == '\\' {
+		target = "/dashboard"
+	}
+	http.Redirect(w, r, target, http.StatusFound)
+}
+
+// Logout replaces /auth/logout. The next request signs the visitor in again,
+// so it simply returns to the landing page.
+func Logout(w http.ResponseWriter, r *http.Request) {
+	http.Redirect(w, r, "/", http.StatusSeeOther)
+}
+
+// IsDestructive reports whether r is an action that visitors of a shared
+// sandbox must not perform: deleting the shared account, or an admin action
+// that deletes anything, changes roles, or merges tags. It matches both the
+// web UI (/admin/...) and the API (/api/v1/...).
+func IsDestructive(r *http.Request) bool {
+	path := strings.TrimPrefix(r.URL.Path, "/api/v1")
+	if path == "/me" && r.Method == http.MethodDelete {
+		return true
+	}
+	if !strings.HasPrefix(path, "/admin/") {
+		return false
+	}
+	switch r.Method {
+	case http.MethodDelete:
+		return true
+	case http.MethodPut:
+		return strings.HasSuffix(path, "/role")
+	case http.MethodPost:
+		return strings.HasSuffix(path, "/merge")
+	}
+	return false
+}
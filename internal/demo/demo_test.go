@@ -0,0 +1,79 @@
+package demo_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/joestump/joe-links/internal/demo"
+	"github.com/joestump/joe-links/internal/store"
+	"github.com/joestump/joe-links/internal/testutil"
+)
+
+func TestResetSeedsAndWipes(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	ctx := context.Background()
+	us := store.NewUserStore(db)
+	ls := store.NewLinkStore(db, store.NewOwnershipStore(db), store.NewTagStore(db))
+	sb := demo.New(db, us, ls, store.NewKeywordStore(db), store.NewTeamStore(db), store.NewClickStore(db), store.NewSettingsStore(db))
+
+	if err := sb.Reset(ctx); err != nil {
+		t.Fatalf("Reset: %v", err)
+	}
+	first := sb.UserID()
+	u, err := us.GetByID(ctx, first)
+	if err != nil {
+		t.Fatalf("demo user: %v", err)
+	}
+	if u.Role != "admin" {
+		t.Errorf("demo user role = %q, want admin", u.Role)
+	}
+	seeded, err := ls.CountAll(ctx)
+	if err != nil || seeded == 0 {
+		t.Fatalf("CountAll after reset = %d, %v; want seeded links", seeded, err)
+	}
+
+	// A visitor's link disappears on the next reset.
+	if _, err := ls.Create(ctx, "visitor", "https://example.com", first, "", "", "public"); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if err := sb.Reset(ctx); err != nil {
+		t.Fatalf("second Reset: %v", err)
+	}
+	if _, err := ls.GetBySlug(ctx, "visitor"); err != store.ErrNotFound {
+		t.Errorf("visitor link after reset: err = %v, want ErrNotFound", err)
+	}
+	if n, _ := ls.CountAll(ctx); n != seeded {
+		t.Errorf("CountAll after second reset = %d, want %d", n, seeded)
+	}
+	if sb.UserID() == first {
+		t.Error("demo user ID unchanged after reset; stale sessions would survive")
+	}
+}
+
+func TestIsDestructive(t *testing.T) {
+	cases := []struct {
+		method, path string
+		want         bool
+	}{
+		{http.MethodDelete, "/admin/users/u1", true},
+		{http.MethodPut, "/admin/users/u1/role", true},
+		{http.MethodPost, "/admin/tags/go/merge", true},
+		{http.MethodDelete, "/api/v1/admin/teams/platform", true},
+		{http.MethodPut, "/api/v1/admin/users/u1/role", true},
+		{http.MethodDelete, "/api/v1/me", true},
+		{http.MethodGet, "/api/v1/me/export", false},
+		{http.MethodGet, "/admin/users", false},
+		{http.MethodPost, "/admin/keywords", false},
+		{http.MethodPut, "/admin/tags/go", false},
+		{http.MethodDelete, "/dashboard/links/l1", false},
+		{http.MethodDelete, "/api/v1/links/l1", false},
+	}
+	for _, c := range cases {
+		r := httptest.NewRequest(c.method, c.path, nil)
+		if got := demo.IsDestructive(r); got != c.want {
+			t.Errorf("IsDestructive(%s %s) = %v, want %v", c.method, c.path, got, c.want)
+		}
+	}
+}
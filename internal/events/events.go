@@ -0,0 +1,68 @@
+// Package events fans out link changes and clicks to open dashboards. It is an
+// in-process broker: each replica only sees the changes it made itself.
+// Governing: SPEC-0004 REQ "Live Dashboard Updates"
+package events
+
+import "sync"
+
+// Event kinds, used verbatim as the SSE event names.
+const (
+	LinkCreated = "link-created"
+	LinkUpdated = "link-updated"
+	LinkDeleted = "link-deleted"
+	Click       = "click"
+)
+
+// subscriberBuffer is how many events a subscriber may fall behind before
+// further events to it are dropped.
+const subscriberBuffer = 64
+
+// Event reports that something happened to a link.
+type Event struct {
+	Kind   string `json:"kind"`
+	LinkID string `json:"id"`
+}
+
+// Broker delivers published events to every current subscriber. A nil
+// *Broker is valid and discards everything published to it.
+type Broker struct {
+	mu   sync.Mutex
+	subs map[chan Event]struct{}
+}
+
+// NewBroker creates an empty Broker.
+func NewBroker() *Broker {
+	return &Broker{subs: make(map[chan Event]struct{})}
+}
+
+// Subscribe registers a new subscriber. The returned function unsubscribes
+// and closes the channel; callers must call it exactly once.
+func (b *Broker) Subscribe() (<-chan Event, func()) {
+	ch := make(chan Event, subscriberBuffer)
+	b.mu.Lock()
+	b.subs[ch] = struct{}{}
+	b.mu.Unlock()
+	return ch, func() {
+		b.mu.Lock()
+		delete(b.subs, ch)
+		b.mu.Unlock()
+		close(ch)
+	}
+}
+
+// Publish sends e to every subscriber without blocking. Subscribers whose
+// buffer is full miss the event; a dashboard only uses events as a cue to
+// refresh, so the next one catches it up.
+func (b *Broker) Publish(e Event) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for ch := range b.subs {
+		select {
+		case ch <- e:
+		default:
+		}
+	}
+}
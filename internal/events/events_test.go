@@ -0,0 +1,33 @@
+package events
+
+import "testing"
+
+func TestBroker(t *testing.T) {
+	var nilBroker *Broker
+	nilBroker.Publish(Event{Kind: LinkCreated, LinkID: "l1"}) // must not panic
+
+	b := NewBroker()
+	a, unsubA := b.Subscribe()
+	c, unsubC := b.Subscribe()
+	defer unsubC()
+
+	b.Publish(Event{Kind: LinkUpdated, LinkID: "l1"})
+	for _, ch := range []<-chan Event{a, c} {
+		if e := <-ch; e.Kind != LinkUpdated || e.LinkID != "l1" {
+			t.Errorf("event = %+v, want link-updated l1", e)
+		}
+	}
+
+	unsubA()
+	if _, ok := <-a; ok {
+		t.Error("expected the channel to be closed after unsubscribing")
+	}
+
+	// A subscriber that falls behind misses events instead of blocking Publish.
+	for i := 0; i < subscriberBuffer+10; i++ {
+		b.Publish(Event{Kind: Click, LinkID: "l1"})
+	}
+	if len(c) != subscriberBuffer {
+		t.Errorf("buffered = %d, want %d", len(c), subscriberBuffer)
+	}
+}
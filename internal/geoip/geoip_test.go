@@ -0,0 +1,123 @@
+package geoip_test
+
+import (
+	"bytes"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/joestump/joe-links/internal/geoip"
+)
+
+// encString, encUint, and encMap write MaxMind DB data section values.
+func encString(s string) []byte {
+	return append([]byte{2<<5 | byte(len(s))}, s...)
+}
+
+func encUint(typ byte, v uint32) []byte {
+	return []byte{typ<<5 | 4, byte(v >> 24), byte(v >> 16), byte(v >> 8), byte(v)}
+}
+
+func encMap(pairs ...[]byte) []byte {
+	out := []byte{7<<5 | byte(len(pairs)/2)}
+	for _, p := range pairs {
+		out = append(out, p...)
+	}
+	return out
+}
+
+// buildDB returns an IPv4, 24-bit-record database in which prefix maps to
+// record and every other address is missing.
+func buildDB(t *testing.T, prefix string, record []byte) []byte {
+	t.Helper()
+	_, network, err := net.ParseCIDR(prefix)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ones, _ := network.Mask.Size()
+	nodeCount := uint32(ones)
+	ip := network.IP.To4()
+
+	var tree []byte
+	put := func(v uint32) { tree = append(tree, byte(v>>16), byte(v>>8), byte(v)) }
+	for i := 0; i < ones; i++ {
+		next := uint32(i + 1)
+		if i == ones-1 {
+			next = nodeCount + 16 // data section offset 0
+		}
+		if ip[i/8]>>(7-uint(i%8))&1 == 0 {
+			put(next)
+			put(nodeCount)
+		} else {
+			put(nodeCount)
+			put(next)
+		}
+	}
+
+	var buf bytes.Buffer
+	buf.Write(tree)
+	buf.Write(make([]byte, 16))
+	buf.Write(record)
+	buf.WriteString("\xAB\xCD\xEFMaxMind.com")
+	buf.Write(encMap(
+		encString("node_count"), encUint(6, nodeCount),
+		encString("record_size"), encUint(5, 24),
+		encString("ip_version"), encUint(5, 4),
+		encString("database_type"), encString("Test"),
+	))
+	return buf.Bytes()
+}
+
+func TestCountry(t *testing.T) {
+	record := encMap(
+		encString("country"), encMap(encString("iso_code"), encString("gb")),
+	)
+	path := filepath.Join(t.TempDir(), "test.mmdb")
+	if err := os.WriteFile(path, buildDB(t, "81.2.69.0/24", record), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	r, err := geoip.Open(path)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+
+	cases := map[string]string{
+		"81.2.69.142": "GB",
+		"81.2.70.1":   "",
+		"10.0.0.1":    "",
+		"2001:db8::1": "",
+		"not-an-ip":   "",
+	}
+	for ip, want := range cases {
+		if got := r.Country(ip); got != want {
+			t.Errorf("Country(%q) = %q, want %q", ip, got, want)
+		}
+	}
+}
+
+func TestCountryRegisteredFallback(t *testing.T) {
+	record := encMap(
+		encString("registered_country"), encMap(encString("iso_code"), encString("DE")),
+	)
+	r, err := geoip.New(buildDB(t, "192.0.2.0/24", record))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if got := r.Country("192.0.2.7"); got != "DE" {
+		t.Errorf("Country = %q, want DE", got)
+	}
+}
+
+func TestNilReader(t *testing.T) {
+	var r *geoip.Reader
+	if got := r.Country("81.2.69.142"); got != "" {
+		t.Errorf("nil Reader Country = %q, want empty", got)
+	}
+}
+
+func TestNewRejectsNonMMDB(t *testing.T) {
+	if _, err := geoip.New([]byte("hello")); err == nil {
+		t.Error("New accepted a file without metadata")
+	}
+}
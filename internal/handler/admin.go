@@ -2,10 +2,15 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
+	"net/url"
+	"slices"
+	"strings"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/joestump/joe-links/internal/auth"
+	"github.com/joestump/joe-links/internal/config"
 	"github.com/joestump/joe-links/internal/store"
 )
 
@@ -14,11 +19,23 @@ type AdminHandler struct {
 	links    *store.LinkStore
 	users    *store.UserStore
 	keywords *store.KeywordStore
+	health   *store.HealthStore // Governing: SPEC-0001 REQ "Link Health Checks"; nil hides health flags
+	tags     *store.TagStore    // Governing: SPEC-0002 REQ "Structured Search Filters" — filter builder choices
+	teams    *store.TeamStore
+
+	// analyticsMode is shown on the dashboard's settings card.
+	// Governing: SPEC-0016 REQ "Analytics Mode"
+	analyticsMode string
+
+	// prefs saves each admin's links screen sort; nil keeps sorts for the
+	// request only.
+	// Governing: SPEC-0004 REQ "Sortable Link Lists"
+	prefs *store.PreferenceStore
 }
 
 // NewAdminHandler creates a new AdminHandler.
-func NewAdminHandler(ls *store.LinkStore, us *store.UserStore, ks *store.KeywordStore) *AdminHandler {
-	return &AdminHandler{links: ls, users: us, keywords: ks}
+func NewAdminHandler(ls *store.LinkStore, us *store.UserStore, ks *store.KeywordStore, hs *store.HealthStore, ts *store.TagStore, tms *store.TeamStore) *AdminHandler {
+	return &AdminHandler{links: ls, users: us, keywords: ks, health: hs, tags: ts, teams: tms}
 }
 
 // AdminDashboardPage is the template data for the admin overview.
@@ -27,6 +44,12 @@ type AdminDashboardPage struct {
 	UserCount    int
 	LinkCount    int
 	KeywordCount int
+	BrokenCount  int // Governing: SPEC-0001 REQ "Link Health Checks"
+	PendingCount int // Governing: SPEC-0011 REQ "Public Link Moderation"
+
+	// AnalyticsMode is JOE_ANALYTICS_MODE: "full", "anonymous", or "off".
+	// Governing: SPEC-0016 REQ "Analytics Mode"
+	AnalyticsMode string
 }
 
 // UserRowData wraps a user row with the current admin's ID for conditional rendering.
@@ -56,6 +79,21 @@ type AdminLinksPage struct {
 	ShowTags       bool   // show Tags column
 	ShowVisibility bool   // show Visibility column
 	ShowActions    bool   // show Edit/Delete action buttons
+	ShowContact    bool   // show Contact owner buttons
+
+	// Filter builder state. Filter is the effective query: Query plus the
+	// builder's selections as key:value filters.
+	// Governing: SPEC-0002 REQ "Structured Search Filters"
+	Filter     string
+	Owner      string
+	FilterTag  string
+	Team       string
+	Visibility string
+	AllTags    []*store.Tag
+	AllTeams   []*store.Team
+	Error      string
+	ExportURL  string    // Governing: SPEC-0011 REQ "Admin Links CSV Export" — carries the current filters
+	Sort       *ListSort // Governing: SPEC-0004 REQ "Sortable Link Lists"
 }
 
 // Dashboard renders the admin overview with summary stats.
@@ -71,6 +109,15 @@ func (h *AdminHandler) Dashboard(w http.ResponseWriter, r *http.Request) {
 		LinkCount:    len(allLinks),
 		KeywordCount: len(allKeywords),
 	}
+	data.AnalyticsMode = h.analyticsMode
+	if data.AnalyticsMode == "" {
+		data.AnalyticsMode = config.AnalyticsFull
+	}
+	if h.health != nil {
+		broken, _ := h.health.ListBroken(r.Context())
+		data.BrokenCount = len(broken)
+	}
+	data.PendingCount, _ = h.links.CountPendingReview(r.Context())
 	render(w, "admin/dashboard.html", data)
 }
 
@@ -96,17 +143,17 @@ func (h *AdminHandler) UpdateRole(w http.ResponseWriter, r *http.Request) {
 	currentUser := auth.UserFromContext(r.Context())
 	id := chi.URLParam(r, "id")
 	if err := r.ParseForm(); err != nil {
-		http.Error(w, "bad request", http.StatusBadRequest)
+		renderError(w, r, http.StatusBadRequest, "The request could not be understood.")
 		return
 	}
 	role := r.FormValue("role")
 	if role != "admin" && role != "user" {
-		http.Error(w, "invalid role", http.StatusBadRequest)
+		renderError(w, r, http.StatusBadRequest, "Invalid role.")
 		return
 	}
 	target, err := h.users.UpdateRole(r.Context(), id, role)
 	if err != nil {
-		http.Error(w, "update failed", http.StatusInternalServerError)
+		renderError(w, r, http.StatusInternalServerError, "Update failed.")
 		return
 	}
 	row := UserRowData{User: target, CurrentUserID: currentUser.ID}
@@ -120,8 +167,29 @@ func (h *AdminHandler) UpdateRole(w http.ResponseWriter, r *http.Request) {
 // Governing: SPEC-0014 REQ "Abstract Link Widget"
 func (h *AdminHandler) Links(w http.ResponseWriter, r *http.Request) {
 	user := auth.UserFromContext(r.Context())
-	q := r.URL.Query().Get("q")
-	allLinks, _ := h.links.ListAllAdmin(r.Context(), q)
+	params := r.URL.Query()
+	q := params.Get("q")
+	filter := adminLinksFilter(params)
+	var errMsg string
+	allLinks, err := h.links.ListAllAdmin(r.Context(), filter)
+	if errors.Is(err, store.ErrInvalidFilter) {
+		errMsg = filterErrorMessage(err)
+	}
+	// Governing: SPEC-0004 REQ "Sortable Link Lists"
+	sort := listSort(r, h.prefs, user.ID, store.PrefAdminLinksSort, "#admin-link-list")
+	if sort.Key != "" {
+		slices.SortStableFunc(allLinks, func(a, b *store.AdminLink) int {
+			return store.CompareLinks(&a.Link, &b.Link, sort.Key, sort.Desc)
+		})
+	}
+	// Governing: SPEC-0001 REQ "Link Health Checks" — flag broken links
+	if h.health != nil {
+		links := make([]*store.Link, len(allLinks))
+		for i, l := range allLinks {
+			links[i] = &l.Link
+		}
+		_ = h.health.Attach(r.Context(), links)
+	}
 
 	data := AdminLinksPage{
 		BasePage:       newBasePage(r, user),
@@ -132,11 +200,21 @@ func (h *AdminHandler) Links(w http.ResponseWriter, r *http.Request) {
 		ShowTags:       true,
 		ShowVisibility: true,
 		ShowActions:    true,
+		Filter:         filter,
+		Owner:          params.Get("owner"),
+		FilterTag:      params.Get("tag"),
+		Team:           params.Get("team"),
+		Visibility:     params.Get("visibility"),
+		Error:          errMsg,
+		ExportURL:      "/admin/links/export.csv?" + params.Encode(),
+		Sort:           sort,
 	}
 	if isHTMX(r) {
 		renderPageFragment(w, "admin/links.html", "admin_link_list", data)
 		return
 	}
+	data.AllTags, _ = h.tags.ListAll(r.Context())
+	data.AllTeams, _ = h.teams.List(r.Context())
 	render(w, "admin/links.html", data)
 }
 
@@ -146,7 +224,7 @@ func (h *AdminHandler) EditLinkRow(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 	link, err := h.links.GetAdminLink(r.Context(), id)
 	if err != nil {
-		http.NotFound(w, r)
+		renderError(w, r, http.StatusNotFound, "That item no longer exists.")
 		return
 	}
 	renderPageFragment(w, "admin/links.html", "admin_link_edit_row", link)
@@ -158,7 +236,7 @@ func (h *AdminHandler) EditLinkRow(w http.ResponseWriter, r *http.Request) {
 func (h *AdminHandler) UpdateLink(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 	if err := r.ParseForm(); err != nil {
-		http.Error(w, "bad request", http.StatusBadRequest)
+		renderError(w, r, http.StatusBadRequest, "The request could not be understood.")
 		return
 	}
 
@@ -170,26 +248,31 @@ func (h *AdminHandler) UpdateLink(w http.ResponseWriter, r *http.Request) {
 	// Preserve existing visibility for admin inline edits
 	existing, err := h.links.GetByID(r.Context(), id)
 	if err != nil {
-		http.Error(w, "not found", http.StatusNotFound)
+		renderError(w, r, http.StatusNotFound, "That item no longer exists.")
 		return
 	}
 	_, err = h.links.Update(r.Context(), id, url, title, description, existing.Visibility)
 	if err != nil {
-		http.Error(w, "update failed", http.StatusInternalServerError)
+		// Governing: SPEC-0002 REQ "Destination Domain Rules"
+		if errors.Is(err, store.ErrDomainNotAllowed) {
+			renderError(w, r, http.StatusBadRequest, domainRuleMessage(err))
+			return
+		}
+		renderError(w, r, http.StatusInternalServerError, "Update failed.")
 		return
 	}
 
 	// Governing: SPEC-0010 REQ "Admin Visibility Override" — admin can change visibility
 	if visibility == "public" || visibility == "private" || visibility == "secure" {
 		if err := h.links.UpdateVisibility(r.Context(), id, visibility); err != nil {
-			http.Error(w, "visibility update failed", http.StatusInternalServerError)
+			renderError(w, r, http.StatusInternalServerError, "Visibility update failed.")
 			return
 		}
 	}
 
 	link, err := h.links.GetAdminLink(r.Context(), id)
 	if err != nil {
-		http.Error(w, "fetch failed", http.StatusInternalServerError)
+		renderError(w, r, http.StatusInternalServerError, "Fetch failed.")
 		return
 	}
 	renderPageFragment(w, "admin/links.html", "admin_link_row", link)
@@ -200,7 +283,7 @@ func (h *AdminHandler) UpdateLink(w http.ResponseWriter, r *http.Request) {
 func (h *AdminHandler) DeleteLink(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 	if err := h.links.Delete(r.Context(), id); err != nil {
-		http.Error(w, "delete failed", http.StatusInternalServerError)
+		renderError(w, r, http.StatusInternalServerError, "Delete failed.")
 		return
 	}
 	w.Header().Set("Content-Type", "text/html")
@@ -215,7 +298,7 @@ func (h *AdminHandler) LinkRow(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 	link, err := h.links.GetAdminLink(r.Context(), id)
 	if err != nil {
-		http.NotFound(w, r)
+		renderError(w, r, http.StatusNotFound, "That item no longer exists.")
 		return
 	}
 	renderPageFragment(w, "admin/links.html", "admin_link_row", link)
@@ -227,7 +310,7 @@ func (h *AdminHandler) ConfirmDeleteLink(w http.ResponseWriter, r *http.Request)
 	id := chi.URLParam(r, "id")
 	link, err := h.links.GetByID(r.Context(), id)
 	if err != nil {
-		http.NotFound(w, r)
+		renderError(w, r, http.StatusNotFound, "That item no longer exists.")
 		return
 	}
 	data := ConfirmDeleteData{
@@ -256,19 +339,19 @@ func (h *AdminHandler) ConfirmDeleteUser(w http.ResponseWriter, r *http.Request)
 
 	// Guard: admin cannot delete themselves
 	if id == currentUser.ID {
-		http.Error(w, "cannot delete yourself", http.StatusBadRequest)
+		renderError(w, r, http.StatusBadRequest, "Cannot delete yourself.")
 		return
 	}
 
 	target, err := h.users.GetByID(r.Context(), id)
 	if err != nil {
-		http.NotFound(w, r)
+		renderError(w, r, http.StatusNotFound, "That item no longer exists.")
 		return
 	}
 
 	linkCount, err := h.users.CountPrimaryLinks(r.Context(), id)
 	if err != nil {
-		http.Error(w, "failed to count links", http.StatusInternalServerError)
+		renderError(w, r, http.StatusInternalServerError, "Failed to count links.")
 		return
 	}
 
@@ -290,12 +373,12 @@ func (h *AdminHandler) DeleteUser(w http.ResponseWriter, r *http.Request) {
 
 	// Guard: admin cannot delete themselves
 	if id == currentUser.ID {
-		http.Error(w, "cannot delete yourself", http.StatusBadRequest)
+		renderError(w, r, http.StatusBadRequest, "Cannot delete yourself.")
 		return
 	}
 
 	if err := r.ParseForm(); err != nil {
-		http.Error(w, "bad request", http.StatusBadRequest)
+		renderError(w, r, http.StatusBadRequest, "The request could not be understood.")
 		return
 	}
 
@@ -304,13 +387,13 @@ func (h *AdminHandler) DeleteUser(w http.ResponseWriter, r *http.Request) {
 	// Check how many links the target owns
 	linkCount, err := h.users.CountPrimaryLinks(r.Context(), id)
 	if err != nil {
-		http.Error(w, "failed to count links", http.StatusInternalServerError)
+		renderError(w, r, http.StatusInternalServerError, "Failed to count links.")
 		return
 	}
 
 	// Require link_action when user owns links
 	if linkCount > 0 && linkAction != "reassign" && linkAction != "delete" {
-		http.Error(w, "link_action required (reassign or delete)", http.StatusBadRequest)
+		renderError(w, r, http.StatusBadRequest, "Choose whether to reassign or delete the user's links.")
 		return
 	}
 
@@ -320,7 +403,7 @@ func (h *AdminHandler) DeleteUser(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := h.users.DeleteUserWithLinks(r.Context(), id, currentUser.ID, linkAction); err != nil {
-		http.Error(w, "delete failed", http.StatusInternalServerError)
+		renderError(w, r, http.StatusInternalServerError, "Delete failed.")
 		return
 	}
 
@@ -329,3 +412,33 @@ func (h *AdminHandler) DeleteUser(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
 	_, _ = w.Write([]byte(`<div id="toast-area" hx-swap-oob="innerHTML:#toast-area"><div class="alert alert-success"><span>User deleted.</span></div></div>`))
 }
+
+// adminLinksFilter returns the effective search query for the admin links
+// screen: the q parameter plus the filter builder's selections.
+func adminLinksFilter(params url.Values) string {
+	return composeFilter(params.Get("q"), map[string]string{
+		"owner":      params.Get("owner"),
+		"tag":        params.Get("tag"),
+		"team":       params.Get("team"),
+		"visibility": params.Get("visibility"),
+	})
+}
+
+// composeFilter appends the filter builder's selections to q as key:value
+// filters, quoting values that contain spaces, so the builder and typed
+// filters share one parser.
+// Governing: SPEC-0002 REQ "Structured Search Filters"
+func composeFilter(q string, fields map[string]string) string {
+	parts := []string{strings.TrimSpace(q)}
+	for _, key := range []string{"owner", "tag", "team", "visibility"} {
+		v := strings.TrimSpace(fields[key])
+		if v == "" {
+			continue
+		}
+		if strings.ContainsAny(v, " \t") {
+			v = `"` + strings.ReplaceAll(v, `"`, "") + `"`
+		}
+		parts = append(parts, key+":"+v)
+	}
+	return strings.TrimSpace(strings.Join(parts, " "))
+}
@@ -0,0 +1,72 @@
+package handler
+
+import (
+	"context"
+	"encoding/csv"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/joestump/joe-links/internal/auth"
+	"github.com/joestump/joe-links/internal/store"
+	"github.com/joestump/joe-links/internal/testutil"
+)
+
+// Governing: SPEC-0011 REQ "Admin Links CSV Export"
+func TestAdminExportLinks(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	owns := store.NewOwnershipStore(db)
+	ts := store.NewTagStore(db)
+	ls := store.NewLinkStore(db, owns, ts)
+	us := store.NewUserStore(db)
+	ctx := context.Background()
+
+	admin, err := us.Upsert(ctx, "test", "sub1", "admin@example.com", "Admin", "")
+	if err != nil {
+		t.Fatalf("seed user: %v", err)
+	}
+	ops, err := ls.Create(ctx, "ops", "https://ops.example.com", admin.ID, "=HYPERLINK(\"x\")", "Ops, SRE, and on-call", "public")
+	if err != nil {
+		t.Fatalf("seed link: %v", err)
+	}
+	if err := ls.SetTags(ctx, ops.ID, []string{"infra"}); err != nil {
+		t.Fatalf("seed tags: %v", err)
+	}
+	if _, err := ls.Create(ctx, "lunch", "https://lunch.example.com", admin.ID, "Lunch", "", "public"); err != nil {
+		t.Fatalf("seed link: %v", err)
+	}
+
+	h := NewAdminHandler(ls, us, nil, nil, ts, store.NewTeamStore(db))
+	export := func(query string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/admin/links/export.csv?"+query, nil)
+		req = req.WithContext(context.WithValue(req.Context(), auth.UserContextKey, admin))
+		w := httptest.NewRecorder()
+		h.ExportLinks(w, req)
+		return w
+	}
+
+	w := export("tag=infra")
+	if w.Code != http.StatusOK || w.Header().Get("Content-Type") != "text/csv; charset=utf-8" {
+		t.Fatalf("status = %d, Content-Type = %q", w.Code, w.Header().Get("Content-Type"))
+	}
+	rows, err := csv.NewReader(w.Body).ReadAll()
+	if err != nil {
+		t.Fatalf("parse CSV: %v", err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("rows = %d, want header + 1 filtered link", len(rows))
+	}
+	if rows[0][1] != "slug" || rows[1][1] != "ops" || rows[1][4] != "Ops, SRE, and on-call" || rows[1][7] != "infra" {
+		t.Errorf("rows = %q", rows)
+	}
+	if rows[1][3] != `'=HYPERLINK("x")` {
+		t.Errorf("title cell = %q, want formula neutralized", rows[1][3])
+	}
+
+	if all, _ := csv.NewReader(export("").Body).ReadAll(); len(all) != 3 {
+		t.Errorf("unfiltered export rows = %d, want 3", len(all))
+	}
+	if w := export("q=visibility:nope"); w.Code != http.StatusBadRequest {
+		t.Errorf("invalid filter: status = %d, want 400", w.Code)
+	}
+}
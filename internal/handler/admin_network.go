@@ -0,0 +1,10 @@
+package handler
+
+import "net/http"
+
+// denyAdminNetwork answers admin requests from clients outside the admin
+// network allowlist.
+// Governing: SPEC-0001 REQ "Admin Network Allowlist"
+func denyAdminNetwork(w http.ResponseWriter, r *http.Request) {
+	renderError(w, r, http.StatusForbidden, "Admin pages are not available from your network.")
+}
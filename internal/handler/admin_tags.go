@@ -0,0 +1,134 @@
+// Governing: SPEC-0004 REQ "Tag Administration"
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/joestump/joe-links/internal/auth"
+	"github.com/joestump/joe-links/internal/store"
+)
+
+// AdminTagsHandler serves the admin tag management screen.
+type AdminTagsHandler struct {
+	tags *store.TagStore
+}
+
+// NewAdminTagsHandler creates a new AdminTagsHandler.
+func NewAdminTagsHandler(ts *store.TagStore) *AdminTagsHandler {
+	return &AdminTagsHandler{tags: ts}
+}
+
+// AdminTagsPage is the template data for the tag list.
+type AdminTagsPage struct {
+	BasePage
+	Tags  []*store.TagWithCount
+	Error string
+}
+
+// Index renders every tag, including tags no link uses.
+// GET /admin/tags
+func (h *AdminTagsHandler) Index(w http.ResponseWriter, r *http.Request) {
+	h.renderList(w, r, auth.UserFromContext(r.Context()), "")
+}
+
+// Rename changes a tag's display name and slug from the inline form.
+// PUT /admin/tags/{slug}
+func (h *AdminTagsHandler) Rename(w http.ResponseWriter, r *http.Request) {
+	user := auth.UserFromContext(r.Context())
+	if err := r.ParseForm(); err != nil {
+		renderError(w, r, http.StatusBadRequest, "The request could not be understood.")
+		return
+	}
+
+	name := strings.TrimSpace(r.FormValue("name"))
+	if _, err := h.tags.Rename(r.Context(), chi.URLParam(r, "slug"), name); err != nil {
+		switch {
+		case errors.Is(err, store.ErrNotFound):
+			h.renderList(w, r, user, "That tag no longer exists.")
+		case errors.Is(err, store.ErrInvalidTagName):
+			h.renderList(w, r, user, "Tag names must contain a letter or digit.")
+		case errors.Is(err, store.ErrSlugTaken):
+			h.renderList(w, r, user, "A tag with that name already exists. Merge the tags instead.")
+		default:
+			h.renderList(w, r, user, "Failed to rename tag.")
+		}
+		return
+	}
+
+	h.renderList(w, r, user, "")
+}
+
+// Merge moves every link from one tag onto another and deletes the first.
+// POST /admin/tags/{slug}/merge
+func (h *AdminTagsHandler) Merge(w http.ResponseWriter, r *http.Request) {
+	user := auth.UserFromContext(r.Context())
+	if err := r.ParseForm(); err != nil {
+		renderError(w, r, http.StatusBadRequest, "The request could not be understood.")
+		return
+	}
+
+	into := strings.TrimSpace(r.FormValue("into"))
+	if into == "" {
+		h.renderList(w, r, user, "Choose a tag to merge into.")
+		return
+	}
+	if _, err := h.tags.Merge(r.Context(), chi.URLParam(r, "slug"), into); err != nil {
+		switch {
+		case errors.Is(err, store.ErrNotFound):
+			h.renderList(w, r, user, "That tag no longer exists.")
+		case errors.Is(err, store.ErrMergeIntoSelf):
+			h.renderList(w, r, user, "A tag cannot be merged into itself.")
+		default:
+			h.renderList(w, r, user, "Failed to merge tags.")
+		}
+		return
+	}
+
+	h.renderList(w, r, user, "")
+}
+
+// Delete removes a tag from every link and deletes it. Returns empty 200 so
+// HTMX swaps out the row.
+// DELETE /admin/tags/{slug}
+func (h *AdminTagsHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	if err := h.tags.Delete(r.Context(), chi.URLParam(r, "slug")); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			renderError(w, r, http.StatusNotFound, "That item no longer exists.")
+			return
+		}
+		renderError(w, r, http.StatusInternalServerError, "Delete failed.")
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// ConfirmDelete renders the delete confirmation modal for a tag.
+// GET /admin/tags/{slug}/confirm-delete
+// Governing: SPEC-0013 REQ "DaisyUI Delete Confirmation Modal"
+func (h *AdminTagsHandler) ConfirmDelete(w http.ResponseWriter, r *http.Request) {
+	slug := chi.URLParam(r, "slug")
+	data := ConfirmDeleteData{
+		Name:      "#" + slug,
+		DeleteURL: "/admin/tags/" + slug,
+		Target:    "#tag-" + slug,
+	}
+	renderFragment(w, "confirm_delete", data)
+}
+
+// renderList re-renders the tag_list partial (or full page for non-HTMX).
+func (h *AdminTagsHandler) renderList(w http.ResponseWriter, r *http.Request, user *store.User, errMsg string) {
+	tags, _ := h.tags.ListAllWithCounts(r.Context())
+	data := AdminTagsPage{
+		BasePage: newBasePage(r, user),
+		Tags:     tags,
+		Error:    errMsg,
+	}
+	if isHTMX(r) {
+		renderPageFragment(w, "admin/tags.html", "tag_list", data)
+		return
+	}
+	render(w, "admin/tags.html", data)
+}
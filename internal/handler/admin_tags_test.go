@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/joestump/joe-links/internal/auth"
+	"github.com/joestump/joe-links/internal/store"
+	"github.com/joestump/joe-links/internal/testutil"
+)
+
+// Governing: SPEC-0004 REQ "Tag Administration"
+func TestAdminTags_Merge(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	owns := store.NewOwnershipStore(db)
+	ts := store.NewTagStore(db)
+	ls := store.NewLinkStore(db, owns, ts)
+	us := store.NewUserStore(db)
+	ctx := context.Background()
+
+	admin, err := us.Upsert(ctx, "test", "sub1", "admin@example.com", "Admin", "")
+	if err != nil {
+		t.Fatalf("seed user: %v", err)
+	}
+	link, err := ls.Create(ctx, "cluster", "https://k8s.example.com", admin.ID, "", "", "public")
+	if err != nil {
+		t.Fatalf("seed link: %v", err)
+	}
+	if err := ls.SetTags(ctx, link.ID, []string{"k8s", "kubernetes"}); err != nil {
+		t.Fatalf("seed tags: %v", err)
+	}
+
+	h := NewAdminTagsHandler(ts)
+	r := chi.NewRouter()
+	r.Get("/admin/tags", h.Index)
+	r.Post("/admin/tags/{slug}/merge", h.Merge)
+	serve := func(req *http.Request) *httptest.ResponseRecorder {
+		req = req.WithContext(context.WithValue(req.Context(), auth.UserContextKey, admin))
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	w := serve(httptest.NewRequest(http.MethodGet, "/admin/tags", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("index: status = %d", w.Code)
+	}
+	if body := w.Body.String(); !strings.Contains(body, `id="tag-k8s"`) || !strings.Contains(body, `<option value="kubernetes">`) {
+		t.Error("index missing tag rows or merge targets")
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/tags/k8s/merge", strings.NewReader(url.Values{"into": {"kubernetes"}}.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("HX-Request", "true")
+	w = serve(req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("merge: status = %d", w.Code)
+	}
+	if body := w.Body.String(); strings.Contains(body, `id="tag-k8s"`) || !strings.Contains(body, `id="tag-kubernetes"`) {
+		t.Errorf("merge response = %s", body)
+	}
+}
@@ -0,0 +1,232 @@
+// Governing: SPEC-0004 REQ "Announcement Banner"
+package handler
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/joestump/joe-links/internal/auth"
+	"github.com/joestump/joe-links/internal/store"
+)
+
+const (
+	// announcementCookie holds the ID of the announcement the browser dismissed.
+	announcementCookie = "announcement_dismissed"
+	// announcementTimeLayout is the format of datetime-local form inputs.
+	announcementTimeLayout = "2006-01-02T15:04"
+	maxAnnouncementLen     = 500
+)
+
+// announcementCache holds the current announcement for the layout. The
+// router wires it to Deps.SettingsStore; unwired, no banner is shown.
+var announcementCache cachedSetting[*store.Announcement]
+
+// useAnnouncementSettings loads the announcement from ss, or none when ss is nil.
+func useAnnouncementSettings(ss *store.SettingsStore) {
+	if ss == nil {
+		announcementCache.reset(nil)
+		return
+	}
+	announcementCache.reset(ss.Announcement)
+}
+
+// activeAnnouncement returns the announcement to show on r: nil when none is
+// set, it is outside its start and end times, or this browser dismissed it.
+// Governing: SPEC-0004 REQ "Announcement Banner"
+func activeAnnouncement(r *http.Request) *store.Announcement {
+	a := announcementCache.get(r.Context())
+	if a == nil || !a.ActiveAt(time.Now()) {
+		return nil
+	}
+	if c, err := r.Cookie(announcementCookie); err == nil && c.Value == a.ID {
+		return nil
+	}
+	return a
+}
+
+// AnnouncementHandler serves the admin announcement form and dismissals.
+type AnnouncementHandler struct {
+	settings *store.SettingsStore
+}
+
+// NewAnnouncementHandler creates a new AnnouncementHandler.
+func NewAnnouncementHandler(ss *store.SettingsStore) *AnnouncementHandler {
+	return &AnnouncementHandler{settings: ss}
+}
+
+// AdminAnnouncementPage is the template data for the announcement form.
+type AdminAnnouncementPage struct {
+	BasePage
+	Current  *store.Announcement // the stored announcement, nil when none
+	Message  string
+	Level    string
+	StartsAt string // datetime-local, UTC
+	EndsAt   string
+	Flash    *Flash
+}
+
+// Index renders the announcement form.
+// GET /admin/announcement
+func (h *AnnouncementHandler) Index(w http.ResponseWriter, r *http.Request) {
+	current, err := h.settings.Announcement(r.Context())
+	if err != nil {
+		renderError(w, r, http.StatusInternalServerError, "Could not load the announcement.")
+		return
+	}
+	data := h.page(r, current, nil)
+	if current != nil {
+		data.Message, data.Level = current.Message, current.Level
+		data.StartsAt, data.EndsAt = formatAnnouncementTime(current.StartsAt), formatAnnouncementTime(current.EndsAt)
+	}
+	render(w, "admin/announcement.html", data)
+}
+
+// Save publishes the announcement, or clears it when the form's action is
+// "clear".
+// POST /admin/announcement
+func (h *AnnouncementHandler) Save(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		renderError(w, r, http.StatusBadRequest, "The request could not be understood.")
+		return
+	}
+	current, err := h.settings.Announcement(r.Context())
+	if err != nil {
+		renderError(w, r, http.StatusInternalServerError, "Could not load the announcement.")
+		return
+	}
+
+	if r.FormValue("action") == "clear" {
+		if err := h.settings.SetAnnouncement(r.Context(), nil); err != nil {
+			renderError(w, r, http.StatusInternalServerError, "Failed to clear the announcement.")
+			return
+		}
+		announcementCache.set(nil)
+		data := h.page(r, nil, &Flash{Type: "success", Message: "Announcement cleared."})
+		render(w, "admin/announcement.html", data)
+		return
+	}
+
+	data := h.page(r, current, nil)
+	data.Message = strings.TrimSpace(r.FormValue("message"))
+	data.Level = r.FormValue("level")
+	data.StartsAt = strings.TrimSpace(r.FormValue("starts_at"))
+	data.EndsAt = strings.TrimSpace(r.FormValue("ends_at"))
+	fail := func(msg string) {
+		data.Flash = &Flash{Type: "error", Message: msg}
+		renderWithStatus(w, http.StatusUnprocessableEntity, "admin/announcement.html", data)
+	}
+
+	a := &store.Announcement{Message: data.Message, Level: data.Level}
+	switch {
+	case a.Message == "":
+		fail("Message is required.")
+		return
+	case len([]rune(a.Message)) > maxAnnouncementLen:
+		fail("The message may be at most 500 characters.")
+		return
+	case a.Level != store.AnnouncementInfo && a.Level != store.AnnouncementWarning && a.Level != store.AnnouncementError:
+		fail("Choose info, warning, or error.")
+		return
+	}
+	if a.StartsAt, err = parseAnnouncementTime(data.StartsAt); err != nil {
+		fail("The start time is not a valid date and time.")
+		return
+	}
+	if a.EndsAt, err = parseAnnouncementTime(data.EndsAt); err != nil {
+		fail("The end time is not a valid date and time.")
+		return
+	}
+	if a.StartsAt != nil && a.EndsAt != nil && !a.EndsAt.After(*a.StartsAt) {
+		fail("The end time must be after the start time.")
+		return
+	}
+
+	// Keep the ID for edits that leave the message alone, so fixing the
+	// schedule does not bring the banner back for users who dismissed it.
+	if current != nil && current.Message == a.Message {
+		a.ID = current.ID
+	} else {
+		a.ID = newAnnouncementID()
+	}
+	if err := h.settings.SetAnnouncement(r.Context(), a); err != nil {
+		fail("Failed to save the announcement.")
+		return
+	}
+	announcementCache.set(a)
+	data.BasePage = newBasePage(r, auth.UserFromContext(r.Context()))
+	data.Current = a
+	data.Flash = &Flash{Type: "success", Message: "Announcement saved."}
+	render(w, "admin/announcement.html", data)
+}
+
+// Dismiss hides the announcement in this browser until a new one is
+// published. HTMX requests get an empty body, which swaps the banner out;
+// other requests are sent back to the page they came from.
+// POST /announcement/dismiss
+func (h *AnnouncementHandler) Dismiss(w http.ResponseWriter, r *http.Request) {
+	id := r.FormValue("id")
+	if id == "" {
+		renderError(w, r, http.StatusBadRequest, "The request could not be understood.")
+		return
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:     announcementCookie,
+		Value:    id,
+		Path:     "/",
+		MaxAge:   365 * 24 * 60 * 60, // 1 year
+		SameSite: http.SameSiteLaxMode,
+		HttpOnly: true,
+	})
+	if isHTMX(r) {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	http.Redirect(w, r, sameHostReferer(r), http.StatusSeeOther)
+}
+
+func (h *AnnouncementHandler) page(r *http.Request, current *store.Announcement, flash *Flash) AdminAnnouncementPage {
+	return AdminAnnouncementPage{
+		BasePage: newBasePage(r, auth.UserFromContext(r.Context())),
+		Current:  current,
+		Level:    store.AnnouncementInfo,
+		Flash:    flash,
+	}
+}
+
+// sameHostReferer returns the path of r's Referer when it points at this
+// host, or "/" otherwise, so dismissing never redirects off-site.
+func sameHostReferer(r *http.Request) string {
+	ref, err := url.Parse(r.Referer())
+	if err != nil || ref.Host != r.Host || !strings.HasPrefix(ref.Path, "/") {
+		return "/"
+	}
+	return (&url.URL{Path: ref.Path, RawQuery: ref.RawQuery}).String()
+}
+
+func parseAnnouncementTime(v string) (*time.Time, error) {
+	if v == "" {
+		return nil, nil
+	}
+	t, err := time.ParseInLocation(announcementTimeLayout, v, time.UTC)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func formatAnnouncementTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(announcementTimeLayout)
+}
+
+func newAnnouncementID() string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
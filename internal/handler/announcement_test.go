@@ -0,0 +1,115 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/joestump/joe-links/internal/auth"
+	"github.com/joestump/joe-links/internal/store"
+	"github.com/joestump/joe-links/internal/testutil"
+)
+
+// Governing: SPEC-0004 REQ "Announcement Banner"
+func TestAnnouncement_PublishAndDismiss(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	ss := store.NewSettingsStore(db)
+	admin, err := store.NewUserStore(db).Upsert(context.Background(), "test", "sub1", "admin@example.com", "Admin", "")
+	if err != nil {
+		t.Fatalf("seed user: %v", err)
+	}
+	useAnnouncementSettings(ss)
+	t.Cleanup(func() { useAnnouncementSettings(nil) })
+
+	h := NewAnnouncementHandler(ss)
+	r := chi.NewRouter()
+	r.Get("/admin/announcement", h.Index)
+	r.Post("/admin/announcement", h.Save)
+	r.Post("/announcement/dismiss", h.Dismiss)
+	serve := func(req *http.Request, cookies ...*http.Cookie) *httptest.ResponseRecorder {
+		req = req.WithContext(context.WithValue(req.Context(), auth.UserContextKey, admin))
+		for _, c := range cookies {
+			req.AddCookie(c)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+	post := func(path string, form url.Values) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		return serve(req)
+	}
+	const msg = "Maintenance on Saturday"
+
+	if w := post("/admin/announcement", url.Values{"message": {msg}, "level": {"warning"}}); w.Code != http.StatusOK {
+		t.Fatalf("publish: status = %d", w.Code)
+	}
+	w := serve(httptest.NewRequest(http.MethodGet, "/admin/announcement", nil))
+	if !strings.Contains(w.Body.String(), `id="announcement"`) || !strings.Contains(w.Body.String(), "alert-warning") {
+		t.Fatal("published banner not shown")
+	}
+	a, err := ss.Announcement(context.Background())
+	if err != nil || a == nil {
+		t.Fatalf("stored announcement: %v %v", a, err)
+	}
+
+	// Dismissing sets a cookie that hides this announcement only.
+	req := httptest.NewRequest(http.MethodPost, "/announcement/dismiss", strings.NewReader(url.Values{"id": {a.ID}}.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Referer", "http://evil.example/phish")
+	w = serve(req)
+	if w.Code != http.StatusSeeOther || w.Header().Get("Location") != "/" {
+		t.Errorf("dismiss: %d -> %q", w.Code, w.Header().Get("Location"))
+	}
+	cookie := w.Result().Cookies()[0]
+	w = serve(httptest.NewRequest(http.MethodGet, "/admin/announcement", nil), cookie)
+	if strings.Contains(w.Body.String(), `id="announcement"`) {
+		t.Error("dismissed banner still shown")
+	}
+
+	// Rescheduling keeps the ID; a new message is shown again.
+	if w := post("/admin/announcement", url.Values{"message": {msg}, "level": {"info"}}); w.Code != http.StatusOK {
+		t.Fatalf("edit: status = %d", w.Code)
+	}
+	if w := serve(httptest.NewRequest(http.MethodGet, "/admin/announcement", nil), cookie); strings.Contains(w.Body.String(), `id="announcement"`) {
+		t.Error("same message reappeared after dismissal")
+	}
+	if w := post("/admin/announcement", url.Values{"message": {"New policy"}, "level": {"info"}}); w.Code != http.StatusOK {
+		t.Fatalf("new message: status = %d", w.Code)
+	}
+	if w := serve(httptest.NewRequest(http.MethodGet, "/admin/announcement", nil), cookie); !strings.Contains(w.Body.String(), `id="announcement"`) {
+		t.Error("new message hidden by the old dismissal")
+	}
+
+	// Scheduled in the future: not shown yet.
+	future := time.Now().UTC().Add(time.Hour).Format(announcementTimeLayout)
+	if w := post("/admin/announcement", url.Values{"message": {"Later"}, "level": {"info"}, "starts_at": {future}}); w.Code != http.StatusOK {
+		t.Fatalf("schedule: status = %d", w.Code)
+	}
+	if w := serve(httptest.NewRequest(http.MethodGet, "/admin/announcement", nil)); strings.Contains(w.Body.String(), `id="announcement"`) {
+		t.Error("future announcement shown early")
+	}
+
+	for name, form := range map[string]url.Values{
+		"no message":    {"level": {"info"}},
+		"bad level":     {"message": {"x"}, "level": {"success"}},
+		"ends too soon": {"message": {"x"}, "level": {"info"}, "starts_at": {"2030-01-02T00:00"}, "ends_at": {"2030-01-01T00:00"}},
+	} {
+		if w := post("/admin/announcement", form); w.Code != http.StatusUnprocessableEntity {
+			t.Errorf("%s: status = %d, want 422", name, w.Code)
+		}
+	}
+
+	if w := post("/admin/announcement", url.Values{"action": {"clear"}}); w.Code != http.StatusOK {
+		t.Fatalf("clear: status = %d", w.Code)
+	}
+	if a, _ := ss.Announcement(context.Background()); a != nil {
+		t.Errorf("announcement not cleared: %+v", a)
+	}
+}
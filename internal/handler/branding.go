@@ -0,0 +1,300 @@
+// Governing: SPEC-0003 REQ "Instance Branding"
+package handler
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"html/template"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/joestump/joe-links/internal/auth"
+	"github.com/joestump/joe-links/internal/store"
+)
+
+// Limits on what the appearance form accepts.
+const (
+	maxLogoBytes       = 256 << 10
+	maxInstanceNameLen = 64
+	maxFooterLinks     = 10
+	maxFooterLabelLen  = 40
+)
+
+// logoTypes are the image types accepted as a logo.
+var logoTypes = map[string]bool{
+	"image/png":     true,
+	"image/jpeg":    true,
+	"image/gif":     true,
+	"image/webp":    true,
+	"image/svg+xml": true,
+}
+
+var hexColorRe = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// Brand is the branding as the layout uses it.
+type Brand struct {
+	store.Branding
+	LogoURL  string       // "" when no logo is set
+	ThemeCSS template.CSS // daisyUI color overrides, "" for the stock palette
+}
+
+// brandCache holds the instance branding for the layout. The router wires
+// it to Deps.SettingsStore; unwired, it keeps the stock look.
+var brandCache cachedSetting[Brand]
+
+// useBrandingSettings loads branding from ss, or the stock look when ss is nil.
+func useBrandingSettings(ss *store.SettingsStore) {
+	if ss == nil {
+		brandCache.reset(nil)
+		return
+	}
+	brandCache.reset(func(ctx context.Context) (Brand, error) {
+		b, err := ss.Branding(ctx)
+		return newBrand(b), err
+	})
+}
+
+// currentBrand returns the instance branding for the layout.
+// Governing: SPEC-0003 REQ "Instance Branding"
+func currentBrand(ctx context.Context) Brand { return brandCache.get(ctx) }
+
+// setCurrentBrand replaces the cached branding after an admin saves it.
+func setCurrentBrand(b store.Branding) { brandCache.set(newBrand(b)) }
+
+// newBrand derives the layout's view of b.
+func newBrand(b store.Branding) Brand {
+	brand := Brand{Branding: b}
+	if len(b.Logo) > 0 {
+		sum := sha256.Sum256(b.Logo)
+		brand.LogoURL = "/branding/logo?v=" + hex.EncodeToString(sum[:4])
+	}
+	if hexColorRe.MatchString(b.PrimaryColor) {
+		brand.ThemeCSS = primaryColorCSS(b.PrimaryColor)
+	}
+	return brand
+}
+
+// primaryColorCSS overrides daisyUI's primary color variables, which hold
+// OKLCH components, with color (#rrggbb). The content color is black or
+// white, whichever contrasts more with it.
+func primaryColorCSS(color string) template.CSS {
+	v, _ := strconv.ParseUint(color[1:], 16, 32)
+	r, g, b := float64(v>>16&0xff)/255, float64(v>>8&0xff)/255, float64(v&0xff)/255
+	l, c, h := oklch(r, g, b)
+
+	// WCAG relative luminance decides the content color.
+	lum := 0.2126*srgbToLinear(r) + 0.7152*srgbToLinear(g) + 0.0722*srgbToLinear(b)
+	content := "100% 0 0"
+	if (lum+0.05)/0.05 > 1.05/(lum+0.05) {
+		content = "0% 0 0"
+	}
+	return template.CSS(fmt.Sprintf("--p:%.4f%% %.6f %.4f;--pc:%s;", l*100, c, h, content))
+}
+
+// oklch converts sRGB components in [0,1] to OKLCH.
+func oklch(r, g, b float64) (l, c, h float64) {
+	r, g, b = srgbToLinear(r), srgbToLinear(g), srgbToLinear(b)
+	lm := math.Cbrt(0.4122214708*r + 0.5363325363*g + 0.0514459929*b)
+	mm := math.Cbrt(0.2119034982*r + 0.6806995451*g + 0.1073969566*b)
+	sm := math.Cbrt(0.0883024619*r + 0.2817188376*g + 0.6299787005*b)
+	l = 0.2104542553*lm + 0.7936177850*mm - 0.0040720468*sm
+	a := 1.9779984951*lm - 2.4285922050*mm + 0.4505937099*sm
+	bb := 0.0259040371*lm + 0.7827717662*mm - 0.8086757660*sm
+	c = math.Hypot(a, bb)
+	h = math.Atan2(bb, a) * 180 / math.Pi
+	if h < 0 {
+		h += 360
+	}
+	return l, c, h
+}
+
+func srgbToLinear(v float64) float64 {
+	if v <= 0.04045 {
+		return v / 12.92
+	}
+	return math.Pow((v+0.055)/1.055, 2.4)
+}
+
+// BrandingHandler serves the admin appearance settings and the logo.
+type BrandingHandler struct {
+	settings *store.SettingsStore
+}
+
+// NewBrandingHandler creates a new BrandingHandler.
+func NewBrandingHandler(ss *store.SettingsStore) *BrandingHandler {
+	return &BrandingHandler{settings: ss}
+}
+
+// AdminAppearancePage is the template data for the appearance settings.
+type AdminAppearancePage struct {
+	BasePage
+	Form        store.Branding
+	FooterLinks string // one "Label | URL" per line
+	Flash       *Flash
+}
+
+// Index renders the appearance form.
+// GET /admin/appearance
+func (h *BrandingHandler) Index(w http.ResponseWriter, r *http.Request) {
+	b, err := h.settings.Branding(r.Context())
+	if err != nil {
+		renderError(w, r, http.StatusInternalServerError, "Could not load the appearance settings.")
+		return
+	}
+	h.renderForm(w, r, http.StatusOK, b, formatFooterLinks(b.FooterLinks), nil)
+}
+
+// Save validates and stores the appearance form. The logo is kept unless a
+// new one is uploaded or "remove logo" is checked.
+// POST /admin/appearance
+func (h *BrandingHandler) Save(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxLogoBytes+64<<10)
+	if err := r.ParseMultipartForm(maxLogoBytes + 64<<10); err != nil {
+		if errors.Is(err, http.ErrNotMultipart) {
+			renderError(w, r, http.StatusBadRequest, "The request could not be understood.")
+			return
+		}
+		renderError(w, r, http.StatusRequestEntityTooLarge, fmt.Sprintf("The upload is too large; logos may be at most %d KB.", maxLogoBytes>>10))
+		return
+	}
+	current, err := h.settings.Branding(r.Context())
+	if err != nil {
+		renderError(w, r, http.StatusInternalServerError, "Could not load the appearance settings.")
+		return
+	}
+
+	form := store.Branding{
+		InstanceName: strings.TrimSpace(r.FormValue("instance_name")),
+		PrimaryColor: strings.ToLower(strings.TrimSpace(r.FormValue("primary_color"))),
+		Logo:         current.Logo,
+		LogoType:     current.LogoType,
+	}
+	footer := r.FormValue("footer_links")
+	fail := func(msg string) {
+		h.renderForm(w, r, http.StatusUnprocessableEntity, form, footer, &Flash{Type: "error", Message: msg})
+	}
+
+	if len([]rune(form.InstanceName)) > maxInstanceNameLen {
+		fail(fmt.Sprintf("The instance name may be at most %d characters.", maxInstanceNameLen))
+		return
+	}
+	if form.PrimaryColor != "" && !hexColorRe.MatchString(form.PrimaryColor) {
+		fail("The primary color must be a hex color such as #7c3aed.")
+		return
+	}
+	if form.FooterLinks, err = parseFooterLinks(footer); err != nil {
+		fail(err.Error())
+		return
+	}
+	if r.FormValue("remove_logo") != "" {
+		form.Logo, form.LogoType = nil, ""
+	}
+	if file, _, err := r.FormFile("logo"); err == nil {
+		defer file.Close()
+		logo, err := io.ReadAll(io.LimitReader(file, maxLogoBytes+1))
+		if err != nil {
+			fail("The logo could not be read.")
+			return
+		}
+		if len(logo) > maxLogoBytes {
+			fail(fmt.Sprintf("Logos may be at most %d KB.", maxLogoBytes>>10))
+			return
+		}
+		logoType := sniffLogoType(logo)
+		if !logoTypes[logoType] {
+			fail("The logo must be a PNG, JPEG, GIF, WebP, or SVG image.")
+			return
+		}
+		form.Logo, form.LogoType = logo, logoType
+	}
+
+	if err := h.settings.SetBranding(r.Context(), form); err != nil {
+		fail("Failed to save the appearance settings.")
+		return
+	}
+	setCurrentBrand(form)
+	h.renderForm(w, r, http.StatusOK, form, formatFooterLinks(form.FooterLinks), &Flash{Type: "success", Message: "Appearance saved."})
+}
+
+// Logo serves the uploaded logo. SVG logos are served under a CSP that keeps
+// any script in them from running when opened directly.
+// GET /branding/logo
+func (h *BrandingHandler) Logo(w http.ResponseWriter, r *http.Request) {
+	b := currentBrand(r.Context())
+	if len(b.Logo) == 0 {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", b.LogoType)
+	w.Header().Set("Content-Security-Policy", "default-src 'none'; style-src 'unsafe-inline'; sandbox")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Cache-Control", "public, max-age=86400")
+	_, _ = w.Write(b.Logo)
+}
+
+func (h *BrandingHandler) renderForm(w http.ResponseWriter, r *http.Request, status int, form store.Branding, footer string, flash *Flash) {
+	renderWithStatus(w, status, "admin/appearance.html", AdminAppearancePage{
+		BasePage:    newBasePage(r, auth.UserFromContext(r.Context())),
+		Form:        form,
+		FooterLinks: footer,
+		Flash:       flash,
+	})
+}
+
+// sniffLogoType returns the media type of an uploaded logo. SVG is text to
+// http.DetectContentType, so it is recognized by its root element.
+func sniffLogoType(logo []byte) string {
+	if t := http.DetectContentType(logo); t != "text/xml; charset=utf-8" && !strings.HasPrefix(t, "text/plain") {
+		return t
+	}
+	if bytes.Contains(bytes.ToLower(logo[:min(len(logo), 1024)]), []byte("<svg")) {
+		return "image/svg+xml"
+	}
+	return ""
+}
+
+// parseFooterLinks parses one "Label | URL" pair per line.
+func parseFooterLinks(text string) ([]store.FooterLink, error) {
+	var links []store.FooterLink
+	for i, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		label, target, ok := strings.Cut(line, "|")
+		label, target = strings.TrimSpace(label), strings.TrimSpace(target)
+		if !ok || label == "" || target == "" {
+			return nil, fmt.Errorf("Footer link on line %d must look like: Label | https://example.com", i+1)
+		}
+		if len([]rune(label)) > maxFooterLabelLen {
+			return nil, fmt.Errorf("Footer link labels may be at most %d characters (line %d).", maxFooterLabelLen, i+1)
+		}
+		u, err := url.Parse(target)
+		if err != nil || (u.Scheme != "https" && u.Scheme != "http" && u.Scheme != "mailto") || (u.Scheme != "mailto" && u.Host == "") {
+			return nil, fmt.Errorf("Footer link on line %d needs an http, https, or mailto URL.", i+1)
+		}
+		links = append(links, store.FooterLink{Label: label, URL: target})
+	}
+	if len(links) > maxFooterLinks {
+		return nil, fmt.Errorf("At most %d footer links are allowed.", maxFooterLinks)
+	}
+	return links, nil
+}
+
+// formatFooterLinks is the inverse of parseFooterLinks.
+func formatFooterLinks(links []store.FooterLink) string {
+	var sb strings.Builder
+	for _, l := range links {
+		sb.WriteString(l.Label + " | " + l.URL + "\n")
+	}
+	return sb.String()
+}
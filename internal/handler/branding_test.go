@@ -0,0 +1,134 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/joestump/joe-links/internal/auth"
+	"github.com/joestump/joe-links/internal/store"
+	"github.com/joestump/joe-links/internal/testutil"
+)
+
+// pngHeader is enough of a PNG for content sniffing.
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+// Governing: SPEC-0003 REQ "Instance Branding"
+func TestBranding_SaveAndRender(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	ss := store.NewSettingsStore(db)
+	admin, err := store.NewUserStore(db).Upsert(context.Background(), "test", "sub1", "admin@example.com", "Admin", "")
+	if err != nil {
+		t.Fatalf("seed user: %v", err)
+	}
+	useBrandingSettings(ss)
+	t.Cleanup(func() { useBrandingSettings(nil) })
+
+	h := NewBrandingHandler(ss)
+	r := chi.NewRouter()
+	r.Get("/admin/appearance", h.Index)
+	r.Post("/admin/appearance", h.Save)
+	r.Get("/branding/logo", h.Logo)
+	post := func(fields map[string]string, logo []byte) *httptest.ResponseRecorder {
+		var body bytes.Buffer
+		mw := multipart.NewWriter(&body)
+		for k, v := range fields {
+			_ = mw.WriteField(k, v)
+		}
+		if logo != nil {
+			fw, _ := mw.CreateFormFile("logo", "logo.png")
+			_, _ = fw.Write(logo)
+		}
+		_ = mw.Close()
+		req := httptest.NewRequest(http.MethodPost, "/admin/appearance", &body)
+		req.Header.Set("Content-Type", mw.FormDataContentType())
+		req = req.WithContext(context.WithValue(req.Context(), auth.UserContextKey, admin))
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	w := post(map[string]string{
+		"instance_name": "Acme Go",
+		"primary_color": "#7C3AED",
+		"footer_links":  "Privacy | https://acme.example/privacy\n\nHelp | mailto:it@acme.example\n",
+	}, pngHeader)
+	if w.Code != http.StatusOK {
+		t.Fatalf("save: status = %d", w.Code)
+	}
+	body := w.Body.String()
+	for _, want := range []string{
+		"<title>Appearance — Admin — Acme Go</title>",
+		"Appearance saved.",
+		`<img src="/branding/logo?v=`,
+		`href="https://acme.example/privacy"`,
+		"--p:",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("page missing %q", want)
+		}
+	}
+
+	stored, err := ss.Branding(context.Background())
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if stored.PrimaryColor != "#7c3aed" || len(stored.FooterLinks) != 2 || stored.LogoType != "image/png" {
+		t.Errorf("stored = %+v", stored)
+	}
+
+	// Saving without a new upload keeps the logo.
+	if w := post(map[string]string{"instance_name": "Acme"}, nil); w.Code != http.StatusOK {
+		t.Fatalf("resave: status = %d", w.Code)
+	}
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/branding/logo", nil))
+	if w.Code != http.StatusOK || w.Header().Get("Content-Type") != "image/png" || !bytes.Equal(w.Body.Bytes(), pngHeader) {
+		t.Errorf("logo: %d %q", w.Code, w.Header().Get("Content-Type"))
+	}
+
+	for name, tc := range map[string]struct {
+		fields map[string]string
+		logo   []byte
+	}{
+		"bad color":       {map[string]string{"primary_color": "purple"}, nil},
+		"bad footer link": {map[string]string{"footer_links": "Docs | javascript:alert(1)"}, nil},
+		"not an image":    {nil, []byte("just some text")},
+	} {
+		if w := post(tc.fields, tc.logo); w.Code != http.StatusUnprocessableEntity {
+			t.Errorf("%s: status = %d, want 422", name, w.Code)
+		}
+	}
+
+	if w := post(map[string]string{"remove_logo": "1"}, nil); w.Code != http.StatusOK {
+		t.Fatalf("remove logo: status = %d", w.Code)
+	}
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/branding/logo", nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("removed logo: status = %d, want 404", w.Code)
+	}
+}
+
+// Governing: SPEC-0003 REQ "Instance Branding"
+func TestPrimaryColorCSS(t *testing.T) {
+	for color, want := range map[string]string{
+		"#ffffff": "--p:100.0000% 0.000000",
+		"#000000": "--p:0.0000% 0.000000",
+	} {
+		if got := string(primaryColorCSS(color)); !strings.HasPrefix(got, want) {
+			t.Errorf("primaryColorCSS(%s) = %q, want prefix %q", color, got, want)
+		}
+	}
+	if got := string(primaryColorCSS("#ffff00")); !strings.HasSuffix(got, "--pc:0% 0 0;") {
+		t.Errorf("yellow should get black text: %q", got)
+	}
+	if got := string(primaryColorCSS("#1e3a8a")); !strings.HasSuffix(got, "--pc:100% 0 0;") {
+		t.Errorf("dark blue should get white text: %q", got)
+	}
+}
@@ -0,0 +1,80 @@
+// Governing: SPEC-0012 REQ "Contact Link Owner"
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/joestump/joe-links/internal/auth"
+	"github.com/joestump/joe-links/internal/store"
+)
+
+// ContactHandler sends signed-in users to their mail client to reach a
+// link's primary owner. The owner's address only ever appears in the
+// redirect, so link pages never expose it to anonymous visitors.
+// Governing: SPEC-0012 REQ "Contact Link Owner"
+type ContactHandler struct {
+	links *store.LinkStore
+	owns  *store.OwnershipStore
+}
+
+// NewContactHandler creates a new ContactHandler.
+func NewContactHandler(ls *store.LinkStore, os *store.OwnershipStore) *ContactHandler {
+	return &ContactHandler{links: ls, owns: os}
+}
+
+// Contact handles GET /links/{id}/contact. It redirects to a mailto: URL
+// addressed to the link's primary owner with a prefilled subject and body.
+// Secure links answer 404 unless the user could resolve them.
+// Governing: SPEC-0012 REQ "Contact Link Owner"
+func (h *ContactHandler) Contact(w http.ResponseWriter, r *http.Request) {
+	user := auth.UserFromContext(r.Context())
+	link, err := h.links.GetByID(r.Context(), chi.URLParam(r, "id"))
+	if err != nil || !h.canSee(r, link, user) {
+		renderError(w, r, http.StatusNotFound, "That link no longer exists.")
+		return
+	}
+
+	owners, err := h.owns.ListOwnerUsers(link.ID)
+	if err != nil {
+		renderError(w, r, http.StatusInternalServerError, "Could not load the link's owners.")
+		return
+	}
+	// ListOwnerUsers orders the primary owner first.
+	if len(owners) == 0 || owners[0].Email == "" {
+		renderError(w, r, http.StatusNotFound, "This link has no owner to contact.")
+		return
+	}
+
+	base := newBasePage(r, user)
+	http.Redirect(w, r, ownerMailto(owners[0].Email, owners[0].DisplayName, base.ShortKeyword, base.SiteURL, link.Slug, user.DisplayName), http.StatusFound)
+}
+
+// canSee reports whether user may learn who owns link: anyone signed in for
+// public and private links; owners, shared users, and admins for secure ones.
+func (h *ContactHandler) canSee(r *http.Request, link *store.Link, user *store.User) bool {
+	if link.Visibility != "secure" || user.IsAdmin() {
+		return true
+	}
+	if ok, err := h.owns.IsOwner(link.ID, user.ID); err == nil && ok {
+		return true
+	}
+	ok, err := h.links.HasShare(r.Context(), link.ID, user.ID)
+	return err == nil && ok
+}
+
+// ownerMailto builds the mailto: URL for a message about keyword/slug.
+// Subject and body are percent-encoded as RFC 6068 requires, with spaces
+// written %20 rather than "+".
+func ownerMailto(email, ownerName, keyword, siteURL, slug, senderName string) string {
+	escape := func(s string) string {
+		return strings.ReplaceAll(url.QueryEscape(s), "+", "%20")
+	}
+	short := keyword + "/" + slug
+	subject := "Question about " + short
+	body := fmt.Sprintf("Hi %s,\n\nI have a question about %s (%s/%s).\n\n%s", ownerName, short, siteURL, slug, senderName)
+	return "mailto:" + escape(email) + "?subject=" + escape(subject) + "&body=" + escape(body)
+}
@@ -0,0 +1,72 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/joestump/joe-links/internal/auth"
+	"github.com/joestump/joe-links/internal/store"
+	"github.com/joestump/joe-links/internal/testutil"
+)
+
+// Governing: SPEC-0012 REQ "Contact Link Owner"
+func TestContact(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	owns := store.NewOwnershipStore(db)
+	ls := store.NewLinkStore(db, owns, store.NewTagStore(db))
+	us := store.NewUserStore(db)
+	ctx := context.Background()
+
+	owner, err := us.Upsert(ctx, "test", "sub1", "owner@example.com", "Olive Owner", "")
+	if err != nil {
+		t.Fatalf("seed owner: %v", err)
+	}
+	visitor, err := us.Upsert(ctx, "test", "sub2", "visitor@example.com", "Val Visitor", "")
+	if err != nil {
+		t.Fatalf("seed visitor: %v", err)
+	}
+	public, err := ls.Create(ctx, "wiki", "https://wiki.example.com", owner.ID, "", "", "public")
+	if err != nil {
+		t.Fatalf("seed link: %v", err)
+	}
+	secure, err := ls.Create(ctx, "vault", "https://vault.example.com", owner.ID, "", "", "secure")
+	if err != nil {
+		t.Fatalf("seed link: %v", err)
+	}
+
+	r := chi.NewRouter()
+	r.Get("/links/{id}/contact", NewContactHandler(ls, owns).Contact)
+	contact := func(id string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "http://go.example.com/links/"+id+"/contact", nil)
+		req = req.WithContext(context.WithValue(req.Context(), auth.UserContextKey, visitor))
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	w := contact(public.ID)
+	if w.Code != http.StatusFound {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusFound)
+	}
+	loc := w.Header().Get("Location")
+	for _, want := range []string{"mailto:owner%40example.com?subject=Question%20about%20go%2Fwiki", "Hi%20Olive%20Owner", "Val%20Visitor"} {
+		if !strings.Contains(loc, want) {
+			t.Errorf("Location = %q, want it to contain %q", loc, want)
+		}
+	}
+
+	// A secure link the visitor cannot resolve does not reveal its owner.
+	if w := contact(secure.ID); w.Code != http.StatusNotFound {
+		t.Errorf("secure link: status = %d, want %d", w.Code, http.StatusNotFound)
+	}
+	if err := ls.AddShare(ctx, secure.ID, visitor.ID, owner.ID); err != nil {
+		t.Fatalf("AddShare: %v", err)
+	}
+	if w := contact(secure.ID); w.Code != http.StatusFound {
+		t.Errorf("shared secure link: status = %d, want %d", w.Code, http.StatusFound)
+	}
+}
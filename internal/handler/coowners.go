@@ -19,17 +19,17 @@ func (h *LinksHandler) AddOwner(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 	link, err := h.links.GetByID(r.Context(), id)
 	if err != nil {
-		http.NotFound(w, r)
+		renderError(w, r, http.StatusNotFound, "That item no longer exists.")
 		return
 	}
 	allowed, err := store.IsOwnerOrAdmin(h.owns, link.ID, user.ID, user.Role)
 	if err != nil || !allowed {
-		http.Error(w, "forbidden", http.StatusForbidden)
+		renderError(w, r, http.StatusForbidden, "You don't have permission to do that.")
 		return
 	}
 
 	if err := r.ParseForm(); err != nil {
-		http.Error(w, "bad request", http.StatusBadRequest)
+		renderError(w, r, http.StatusBadRequest, "The request could not be understood.")
 		return
 	}
 	email := r.FormValue("email")
@@ -52,8 +52,12 @@ func (h *LinksHandler) AddOwner(w http.ResponseWriter, r *http.Request) {
 		h.renderOwnersError(w, r, link, user, "Could not add co-owner.")
 		return
 	}
+	// Governing: SPEC-0001 REQ "Email Notifications"
+	if h.notify != nil {
+		h.notify.CoOwnerAdded(link, target, user)
+	}
 
-	h.renderOwnersFragment(w, link)
+	h.renderOwnersFragment(w, r, link)
 }
 
 // RemoveOwner handles DELETE /dashboard/links/{id}/owners/{uid}.
@@ -63,26 +67,26 @@ func (h *LinksHandler) RemoveOwner(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 	link, err := h.links.GetByID(r.Context(), id)
 	if err != nil {
-		http.NotFound(w, r)
+		renderError(w, r, http.StatusNotFound, "That item no longer exists.")
 		return
 	}
 	allowed, err := store.IsOwnerOrAdmin(h.owns, link.ID, user.ID, user.Role)
 	if err != nil || !allowed {
-		http.Error(w, "forbidden", http.StatusForbidden)
+		renderError(w, r, http.StatusForbidden, "You don't have permission to do that.")
 		return
 	}
 
 	uid := chi.URLParam(r, "uid")
 	if err := h.links.RemoveOwner(r.Context(), link.ID, uid); err != nil {
 		if errors.Is(err, store.ErrPrimaryOwnerImmutable) {
-			http.Error(w, "Cannot remove primary owner", http.StatusBadRequest)
+			renderError(w, r, http.StatusBadRequest, "Cannot remove primary owner.")
 			return
 		}
-		http.Error(w, "Could not remove co-owner", http.StatusInternalServerError)
+		renderError(w, r, http.StatusInternalServerError, "Could not remove co-owner.")
 		return
 	}
 
-	h.renderOwnersFragment(w, link)
+	h.renderOwnersFragment(w, r, link)
 }
 
 // Detail handles GET /dashboard/links/{id}.
@@ -92,35 +96,37 @@ func (h *LinksHandler) Detail(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 	link, err := h.links.GetByID(r.Context(), id)
 	if err != nil {
-		http.NotFound(w, r)
+		renderError(w, r, http.StatusNotFound, "That item no longer exists.")
 		return
 	}
 	allowed, err := store.IsOwnerOrAdmin(h.owns, link.ID, user.ID, user.Role)
 	if err != nil || !allowed {
-		http.Error(w, "forbidden", http.StatusForbidden)
+		renderError(w, r, http.StatusForbidden, "You don't have permission to do that.")
+		return
+	}
+
+	data := h.detailPage(r, user, link)
+	if isHTMX(r) {
+		renderPageFragment(w, "links/detail.html", "content", data)
 		return
 	}
+	render(w, "links/detail.html", data)
+}
 
+// detailPage builds the link detail page data for link.
+func (h *LinksHandler) detailPage(r *http.Request, user *store.User, link *store.Link) LinkDetailPage {
 	tags, _ := h.links.ListTags(r.Context(), link.ID)
 	owners, _ := h.owns.ListOwnerUsers(link.ID)
 
 	// Governing: SPEC-0010 REQ "Share Management Panel on Link Detail"
 	var shares []ShareUser
 	if link.Visibility == "secure" {
-		shareRecords, _ := h.links.ListShares(r.Context(), link.ID)
-		for _, sr := range shareRecords {
-			u, err := h.users.GetByID(r.Context(), sr.UserID)
-			if err != nil {
-				continue
-			}
-			shares = append(shares, ShareUser{
-				UserID:      u.ID,
-				DisplayName: u.DisplayName,
-				Email:       u.Email,
-			})
-		}
+		shares = h.loadShares(r, link)
 	}
 
+	// Governing: SPEC-0002 REQ "Link Aliases"
+	aliases, _ := h.links.ListAliases(r.Context(), link.ID)
+
 	data := LinkDetailPage{
 		BasePage: newBasePage(r, user),
 		User:     user,
@@ -128,12 +134,21 @@ func (h *LinksHandler) Detail(w http.ResponseWriter, r *http.Request) {
 		Tags:     tags,
 		Owners:   owners,
 		Shares:   shares,
+		Aliases:  aliases,
 	}
-	if isHTMX(r) {
-		renderPageFragment(w, "links/detail.html", "content", data)
-		return
+	// Governing: SPEC-0010 REQ "Team Shares and Ownership"
+	if h.teams != nil {
+		data.TeamOwners, _ = h.owns.ListTeamOwners(link.ID)
+		data.Teams, _ = h.teams.List(r.Context())
+		if link.Visibility == "secure" {
+			data.TeamShares, _ = h.links.ListTeamShares(r.Context(), link.ID)
+		}
 	}
-	render(w, "links/detail.html", data)
+	// Governing: SPEC-0011 REQ "Link Lifecycle Policies"
+	if h.policies != nil {
+		data.Violations, _ = h.policies.ListViolationsByLink(r.Context(), link.ID)
+	}
+	return data
 }
 
 // ValidateSlug handles GET /dashboard/links/validate-slug?slug=...
@@ -149,7 +164,13 @@ func (h *LinksHandler) ValidateSlug(w http.ResponseWriter, r *http.Request) {
 		_, _ = w.Write([]byte(`<span class="text-error text-xs">` + err.Error() + `</span>`))
 		return
 	}
-	if _, err := h.links.GetBySlug(r.Context(), slug); err == nil {
+	// Governing: SPEC-0002 REQ "Reserved Slugs"
+	if reserved, err := h.reserved.IsReserved(r.Context(), slug); err == nil && reserved {
+		_, _ = w.Write([]byte(`<span class="text-error text-xs">Slug is reserved</span>`))
+		return
+	}
+	// Governing: SPEC-0002 REQ "Link Aliases" — aliases share the slug namespace
+	if taken, err := h.links.SlugInUse(r.Context(), slug); err == nil && taken {
 		_, _ = w.Write([]byte(`<span class="text-error text-xs">Slug already taken</span>`))
 		return
 	}
@@ -157,23 +178,35 @@ func (h *LinksHandler) ValidateSlug(w http.ResponseWriter, r *http.Request) {
 }
 
 // renderOwnersFragment re-renders the owners list for HTMX swap.
-func (h *LinksHandler) renderOwnersFragment(w http.ResponseWriter, link *store.Link) {
-	owners, _ := h.owns.ListOwnerUsers(link.ID)
-	w.Header().Set("Content-Type", "text/html")
-	renderFragment(w, "owners_list", &ownersFragmentData{Link: link, Owners: owners})
+func (h *LinksHandler) renderOwnersFragment(w http.ResponseWriter, r *http.Request, link *store.Link) {
+	h.renderOwnersError(w, r, link, nil, "")
 }
 
-// renderOwnersError renders owners fragment with an error message.
+// renderOwnersError renders owners fragment with an error message. Without
+// HTMX it redirects back to the link, or re-renders its page with the error.
 func (h *LinksHandler) renderOwnersError(w http.ResponseWriter, r *http.Request, link *store.Link, user *store.User, errMsg string) {
+	// Governing: SPEC-0013 REQ "No-JavaScript Fallbacks"
+	if !isHTMX(r) {
+		h.renderDetailResult(w, r, link, errMsg, "")
+		return
+	}
 	owners, _ := h.owns.ListOwnerUsers(link.ID)
+	data := &ownersFragmentData{Link: link, Owners: owners, OwnersError: errMsg}
+	// Governing: SPEC-0010 REQ "Team Shares and Ownership"
+	if h.teams != nil {
+		data.TeamOwners, _ = h.owns.ListTeamOwners(link.ID)
+		data.Teams, _ = h.teams.List(r.Context())
+	}
 	w.Header().Set("Content-Type", "text/html")
-	renderFragment(w, "owners_list", &ownersFragmentData{Link: link, Owners: owners, Error: errMsg})
+	renderFragment(w, "owners_list", data)
 }
 
 type ownersFragmentData struct {
-	Link   *store.Link
-	Owners []*store.OwnerInfo
-	Error  string
+	Link        *store.Link
+	Owners      []*store.OwnerInfo
+	TeamOwners  []*store.Team // Governing: SPEC-0010 REQ "Team Shares and Ownership"
+	Teams       []*store.Team // every team, for the add-team form; empty hides it
+	OwnersError string
 }
 
 // AddShare handles POST /dashboard/links/{id}/shares.
@@ -184,18 +217,18 @@ func (h *LinksHandler) AddShare(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 	link, err := h.links.GetByID(r.Context(), id)
 	if err != nil {
-		http.NotFound(w, r)
+		renderError(w, r, http.StatusNotFound, "That item no longer exists.")
 		return
 	}
 	// Governing: SPEC-0010 REQ "Link Share Management Endpoints" — only owners, co-owners, and admins
 	allowed, err := store.IsOwnerOrAdmin(h.owns, link.ID, user.ID, user.Role)
 	if err != nil || !allowed {
-		http.Error(w, "forbidden", http.StatusForbidden)
+		renderError(w, r, http.StatusForbidden, "You don't have permission to do that.")
 		return
 	}
 
 	if err := r.ParseForm(); err != nil {
-		http.Error(w, "bad request", http.StatusBadRequest)
+		renderError(w, r, http.StatusBadRequest, "The request could not be understood.")
 		return
 	}
 	email := r.FormValue("email")
@@ -214,6 +247,10 @@ func (h *LinksHandler) AddShare(w http.ResponseWriter, r *http.Request) {
 		h.renderSharesError(w, r, link, "Could not add user. They may already have access.")
 		return
 	}
+	// Governing: SPEC-0001 REQ "Email Notifications"
+	if h.notify != nil {
+		h.notify.ShareGranted(link, target, user)
+	}
 
 	h.renderSharesFragment(w, r, link)
 }
@@ -225,19 +262,19 @@ func (h *LinksHandler) RemoveShare(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 	link, err := h.links.GetByID(r.Context(), id)
 	if err != nil {
-		http.NotFound(w, r)
+		renderError(w, r, http.StatusNotFound, "That item no longer exists.")
 		return
 	}
 	// Governing: SPEC-0010 REQ "Link Share Management Endpoints" — only owners, co-owners, and admins
 	allowed, err := store.IsOwnerOrAdmin(h.owns, link.ID, user.ID, user.Role)
 	if err != nil || !allowed {
-		http.Error(w, "forbidden", http.StatusForbidden)
+		renderError(w, r, http.StatusForbidden, "You don't have permission to do that.")
 		return
 	}
 
 	uid := chi.URLParam(r, "uid")
 	if err := h.links.RemoveShare(r.Context(), link.ID, uid); err != nil {
-		http.Error(w, "Could not remove user", http.StatusInternalServerError)
+		renderError(w, r, http.StatusInternalServerError, "Could not remove user.")
 		return
 	}
 
@@ -246,21 +283,34 @@ func (h *LinksHandler) RemoveShare(w http.ResponseWriter, r *http.Request) {
 
 // sharesFragmentData holds template data for the shares panel HTMX fragment.
 type sharesFragmentData struct {
-	Link   *store.Link
-	Shares []ShareUser
-	Error  string
+	Link        *store.Link
+	Shares      []ShareUser
+	TeamShares  []*store.Team // Governing: SPEC-0010 REQ "Team Shares and Ownership"
+	Teams       []*store.Team // every team, for the add-team form; empty hides it
+	SharesError string
 }
 
 // renderSharesFragment re-renders the shares panel for HTMX swap.
 func (h *LinksHandler) renderSharesFragment(w http.ResponseWriter, r *http.Request, link *store.Link) {
-	shares := h.loadShares(r, link)
-	renderFragment(w, "shares_panel", &sharesFragmentData{Link: link, Shares: shares})
+	h.renderSharesError(w, r, link, "")
 }
 
 // renderSharesError renders shares panel with an inline validation error.
+// Without HTMX it redirects back to the link, or re-renders its page with
+// the error.
 func (h *LinksHandler) renderSharesError(w http.ResponseWriter, r *http.Request, link *store.Link, errMsg string) {
-	shares := h.loadShares(r, link)
-	renderFragment(w, "shares_panel", &sharesFragmentData{Link: link, Shares: shares, Error: errMsg})
+	// Governing: SPEC-0013 REQ "No-JavaScript Fallbacks"
+	if !isHTMX(r) {
+		h.renderDetailResult(w, r, link, "", errMsg)
+		return
+	}
+	data := &sharesFragmentData{Link: link, Shares: h.loadShares(r, link), SharesError: errMsg}
+	// Governing: SPEC-0010 REQ "Team Shares and Ownership"
+	if h.teams != nil {
+		data.TeamShares, _ = h.links.ListTeamShares(r.Context(), link.ID)
+		data.Teams, _ = h.teams.List(r.Context())
+	}
+	renderFragment(w, "shares_panel", data)
 }
 
 // loadShares resolves share records to ShareUser display objects.
@@ -280,3 +330,17 @@ func (h *LinksHandler) loadShares(r *http.Request, link *store.Link) []ShareUser
 	}
 	return shares
 }
+
+// renderDetailResult answers a no-JavaScript owners or shares form: it
+// redirects back to the link detail page on success, and otherwise
+// re-renders that page with the error in its section.
+// Governing: SPEC-0013 REQ "No-JavaScript Fallbacks"
+func (h *LinksHandler) renderDetailResult(w http.ResponseWriter, r *http.Request, link *store.Link, ownersErr, sharesErr string) {
+	if ownersErr == "" && sharesErr == "" {
+		http.Redirect(w, r, "/dashboard/links/"+link.ID, http.StatusSeeOther)
+		return
+	}
+	data := h.detailPage(r, auth.UserFromContext(r.Context()), link)
+	data.OwnersError, data.SharesError = ownersErr, sharesErr
+	renderWithStatus(w, http.StatusUnprocessableEntity, "links/detail.html", data)
+}
@@ -0,0 +1,27 @@
+package handler
+
+import (
+	"html/template"
+	"net/http"
+
+	"github.com/joestump/joe-links/internal/auth"
+)
+
+// csrfField renders the hidden CSRF token input for a form that posts
+// without HTMX; templates call it as {{csrfField $}}. Pages carry the token
+// in BasePage. Fragment data without one renders nothing, which is fine
+// because HTMX sends the token in a header instead.
+// Governing: SPEC-0001 REQ "CSRF Protection"
+func csrfField(data any) template.HTML {
+	p, ok := data.(interface{ csrfToken() string })
+	if !ok || p.csrfToken() == "" {
+		return ""
+	}
+	return template.HTML(`<input type="hidden" name="` + auth.CSRFField + `" value="` + template.HTMLEscapeString(p.csrfToken()) + `">`)
+}
+
+// denyCSRF answers unsafe requests whose CSRF token is missing or wrong.
+// Governing: SPEC-0001 REQ "CSRF Protection"
+func denyCSRF(w http.ResponseWriter, r *http.Request) {
+	renderError(w, r, http.StatusForbidden, "This form has expired. Reload the page and try again.")
+}
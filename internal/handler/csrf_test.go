@@ -0,0 +1,27 @@
+package handler
+
+import (
+	"strings"
+	"testing"
+)
+
+// Governing: SPEC-0001 REQ "CSRF Protection"
+func TestCSRFField(t *testing.T) {
+	page := TokensPage{BasePage: BasePage{CSRFToken: "tok"}}
+	if got := string(csrfField(page)); !strings.Contains(got, `name="csrf_token"`) || !strings.Contains(got, `value="tok"`) {
+		t.Errorf("csrfField(page) = %q", got)
+	}
+	if got := csrfField(&ownersFragmentData{}); got != "" {
+		t.Errorf("csrfField(fragment) = %q, want empty", got)
+	}
+
+	set := templateSets["en"]
+	data := ConfirmDeletePage{BasePage: BasePage{CSRFToken: "tok"}, ConfirmDeleteData: ConfirmDeleteData{DeleteURL: "/dashboard/links/l1"}}
+	var b strings.Builder
+	if err := set.pageLayouts["confirm.html"].Execute(&b, data); err != nil {
+		t.Fatalf("render: %v", err)
+	}
+	if out := b.String(); !strings.Contains(out, `<input type="hidden" name="csrf_token" value="tok">`) || !strings.Contains(out, `hx-headers='{"X-CSRF-Token": "tok"}'`) {
+		t.Error("confirm page missing CSRF form field or HTMX header")
+	}
+}
@@ -5,7 +5,10 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
+	"slices"
+	"strings"
 
 	"github.com/joestump/joe-links/internal/auth"
 	"github.com/joestump/joe-links/internal/store"
@@ -22,12 +25,17 @@ type DashboardPage struct {
 	Query     string // current search query
 	Tag       string // current tag filter slug
 	Filter    string // "shared" for shared-with-me view
+	Saved     *store.SavedSearch // saved search being viewed, if any
 	Flash     *Flash
 	ShowTitle      bool // show Title column
 	ShowOwner      bool // show Owner(s) column
 	ShowTags       bool // show Tags column
 	ShowVisibility bool // show Visibility column
 	ShowActions    bool // show Edit/Delete action buttons
+	ShowContact    bool // show Contact owner buttons
+	Violations     []*store.PolicyViolation // Governing: SPEC-0011 REQ "Link Lifecycle Policies"
+	Sort           *ListSort // Governing: SPEC-0004 REQ "Sortable Link Lists"
+	LiveUpdates    bool      // Governing: SPEC-0004 REQ "Live Dashboard Updates"; subscribe to /dashboard/events
 }
 
 // DashboardHandler serves the authenticated link management dashboard.
@@ -35,30 +43,52 @@ type DashboardHandler struct {
 	links    *store.LinkStore
 	tags     *store.TagStore
 	keywords *store.KeywordStore
+	health   *store.HealthStore // Governing: SPEC-0001 REQ "Link Health Checks"; nil hides health flags
+	searches *store.SavedSearchStore // Governing: SPEC-0004 REQ "Saved Searches"
+	policies *store.PolicyStore // Governing: SPEC-0011 REQ "Link Lifecycle Policies"; nil hides violations
+	prefs    *store.PreferenceStore // Governing: SPEC-0004 REQ "Sortable Link Lists"; nil keeps sorts for the request only
+	live     bool // Governing: SPEC-0004 REQ "Live Dashboard Updates"; /dashboard/events is served
 }
 
 // NewDashboardHandler creates a new DashboardHandler.
 // Governing: SPEC-0004 REQ "User Dashboard"
-func NewDashboardHandler(ls *store.LinkStore, ts *store.TagStore, ks *store.KeywordStore) *DashboardHandler {
-	return &DashboardHandler{links: ls, tags: ts, keywords: ks}
+func NewDashboardHandler(ls *store.LinkStore, ts *store.TagStore, ks *store.KeywordStore, hs *store.HealthStore, ss *store.SavedSearchStore, ps *store.PolicyStore) *DashboardHandler {
+	return &DashboardHandler{links: ls, tags: ts, keywords: ks, health: hs, searches: ss, policies: ps}
 }
 
 // Show renders the dashboard with the user's links (or all links for admins).
-// Supports ?q= for search and ?tag= for tag filtering via HTMX.
+// Supports ?q= for search, ?tag= for tag filtering, and ?sort=&dir= for
+// ordering via HTMX.
 // Governing: SPEC-0004 REQ "User Dashboard"
 // Governing: SPEC-0001 REQ "HTMX Hypermedia Interactions"
 func (h *DashboardHandler) Show(w http.ResponseWriter, r *http.Request) {
 	user := auth.UserFromContext(r.Context())
-	query := r.URL.Query().Get("q")
-	tagSlug := r.URL.Query().Get("tag")
-	filter := r.URL.Query().Get("filter")
+	h.show(w, r, DashboardPage{
+		BasePage:    newBasePage(r, user),
+		User:        user,
+		Query:       r.URL.Query().Get("q"),
+		Tag:         r.URL.Query().Get("tag"),
+		Filter:      r.URL.Query().Get("filter"),
+		ShowActions: true,
+	})
+}
+
+// show loads the links data selects and renders the dashboard: the full page,
+// or for HTMX requests the link list plus the out-of-band save-search form.
+func (h *DashboardHandler) show(w http.ResponseWriter, r *http.Request, data DashboardPage) {
+	user := data.User
+	query, tagSlug := data.Query, data.Tag
+	// Governing: SPEC-0004 REQ "Saved Searches" — a saved view combines query and tag
+	if data.Saved != nil {
+		query, tagSlug = data.Saved.FilterQuery(), ""
+	}
 
 	var links []*store.Link
 	var err error
 
 	switch {
 	// Governing: SPEC-0010 REQ "Dashboard Visibility Filtering" — "Shared with me" filter
-	case filter == "shared":
+	case data.Filter == "shared":
 		links, err = h.links.ListSharedWithUser(r.Context(), user.ID)
 	case tagSlug != "":
 		// Tag filter takes precedence
@@ -82,28 +112,48 @@ func (h *DashboardHandler) Show(w http.ResponseWriter, r *http.Request) {
 			links, err = h.links.ListByOwner(r.Context(), user.ID)
 		}
 	}
+	// Governing: SPEC-0002 REQ "Structured Search Filters"
+	if errors.Is(err, store.ErrInvalidFilter) {
+		renderError(w, r, http.StatusBadRequest, filterErrorMessage(err))
+		return
+	}
 	if err != nil {
-		http.Error(w, "could not load links", http.StatusInternalServerError)
+		renderError(w, r, http.StatusInternalServerError, "Could not load links.")
 		return
 	}
 
-	// Load all tags for the tag filter chips
-	allTags, _ := h.tags.ListAll(r.Context())
+	// Governing: SPEC-0004 REQ "Sortable Link Lists"
+	data.Sort = listSort(r, h.prefs, user.ID, store.PrefDashboardSort, "#link-list")
+	_ = h.links.AttachClickCounts(r.Context(), links)
+	if data.Sort.Key != "" {
+		slices.SortStableFunc(links, func(a, b *store.Link) int {
+			return store.CompareLinks(a, b, data.Sort.Key, data.Sort.Desc)
+		})
+	}
 
-	data := DashboardPage{
-		BasePage:    newBasePage(r, user),
-		User:        user,
-		Links:       links,
-		Tags:        allTags,
-		Query:       query,
-		Tag:         tagSlug,
-		Filter:      filter,
-		ShowActions: true,
+	// Governing: SPEC-0001 REQ "Link Health Checks" — flag broken links
+	if h.health != nil {
+		_ = h.health.Attach(r.Context(), links)
 	}
+	data.Links = links
 
 	if isHTMX(r) {
-		renderFragment(w, "link_list", data)
+		renderFragment(w, "dashboard_results", data)
 		return
 	}
+
+	// Load all tags for the tag filter chips
+	data.Tags, _ = h.tags.ListAll(r.Context())
+	// Governing: SPEC-0011 REQ "Link Lifecycle Policies" — owners see what to fix
+	if h.policies != nil {
+		data.Violations, _ = h.policies.ListViolationsByOwner(r.Context(), user.ID)
+	}
+	data.LiveUpdates = h.live
 	render(w, "dashboard.html", data)
 }
+
+// filterErrorMessage turns a store.ErrInvalidFilter into a sentence for the UI.
+// Governing: SPEC-0002 REQ "Structured Search Filters"
+func filterErrorMessage(err error) string {
+	return "Invalid search: " + strings.TrimPrefix(err.Error(), store.ErrInvalidFilter.Error()+": ") + "."
+}
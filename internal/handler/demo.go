@@ -0,0 +1,19 @@
+// Governing: SPEC-0001 REQ "Demo Mode"
+package handler
+
+import (
+	"net/http"
+
+	"github.com/joestump/joe-links/internal/demo"
+)
+
+// demoGuard rejects destructive admin actions on a demo instance with 403.
+func demoGuard(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if demo.IsDestructive(r) {
+			renderError(w, r, http.StatusForbidden, "This action is disabled on the demo instance.")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
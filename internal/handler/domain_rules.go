@@ -0,0 +1,120 @@
+// Governing: SPEC-0002 REQ "Destination Domain Rules"
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/joestump/joe-links/internal/auth"
+	"github.com/joestump/joe-links/internal/store"
+)
+
+// DomainRulesHandler serves the admin destination domain rule screens.
+type DomainRulesHandler struct {
+	rules *store.DomainRuleStore
+}
+
+// NewDomainRulesHandler creates a new DomainRulesHandler.
+func NewDomainRulesHandler(ds *store.DomainRuleStore) *DomainRulesHandler {
+	return &DomainRulesHandler{rules: ds}
+}
+
+// AdminDomainRulesPage is the template data for the domain rule list.
+type AdminDomainRulesPage struct {
+	BasePage
+	Rules     []*store.DomainRule
+	Allowlist bool // some allow rule exists, so unlisted domains are refused
+	Error     string
+}
+
+// Index renders the domain rule list.
+// GET /admin/domains
+func (h *DomainRulesHandler) Index(w http.ResponseWriter, r *http.Request) {
+	h.renderList(w, r, auth.UserFromContext(r.Context()), "")
+}
+
+// Create adds a domain rule from the inline form.
+// POST /admin/domains
+func (h *DomainRulesHandler) Create(w http.ResponseWriter, r *http.Request) {
+	user := auth.UserFromContext(r.Context())
+	if err := r.ParseForm(); err != nil {
+		renderError(w, r, http.StatusBadRequest, "The request could not be understood.")
+		return
+	}
+
+	domain := strings.TrimSpace(r.FormValue("domain"))
+	if domain == "" {
+		h.renderList(w, r, user, "Domain is required.")
+		return
+	}
+
+	if _, err := h.rules.Add(r.Context(), domain, r.FormValue("action"), r.FormValue("note")); err != nil {
+		switch {
+		case errors.Is(err, store.ErrInvalidDomain):
+			h.renderList(w, r, user, "Enter a domain such as bit.ly, or a URL on it.")
+		case errors.Is(err, store.ErrInvalidDomainAction):
+			h.renderList(w, r, user, "Choose allow or deny.")
+		case errors.Is(err, store.ErrDomainRuleExists):
+			h.renderList(w, r, user, "That domain already has a rule.")
+		default:
+			h.renderList(w, r, user, "Failed to add rule.")
+		}
+		return
+	}
+
+	h.renderList(w, r, user, "")
+}
+
+// Delete removes a domain rule. Returns empty 200 so HTMX swaps out the row.
+// DELETE /admin/domains/{domain}
+func (h *DomainRulesHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	if err := h.rules.Remove(r.Context(), chi.URLParam(r, "domain")); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			renderError(w, r, http.StatusNotFound, "That item no longer exists.")
+			return
+		}
+		renderError(w, r, http.StatusInternalServerError, "Delete failed.")
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// ConfirmDelete renders the delete confirmation modal for a domain rule.
+// GET /admin/domains/{domain}/confirm-delete
+// Governing: SPEC-0013 REQ "DaisyUI Delete Confirmation Modal"
+func (h *DomainRulesHandler) ConfirmDelete(w http.ResponseWriter, r *http.Request) {
+	domain, err := store.NormalizeReferrerDomain(chi.URLParam(r, "domain"))
+	if err != nil {
+		renderError(w, r, http.StatusNotFound, "That item no longer exists.")
+		return
+	}
+	data := ConfirmDeleteData{
+		Name:      domain,
+		DeleteURL: "/admin/domains/" + domain,
+		// Domains contain dots, so match the row by attribute rather than id.
+		Target: `tr[data-domain="` + domain + `"]`,
+	}
+	renderFragment(w, "confirm_delete", data)
+}
+
+// renderList re-renders the domain_rule_list partial (or full page for non-HTMX).
+func (h *DomainRulesHandler) renderList(w http.ResponseWriter, r *http.Request, user *store.User, errMsg string) {
+	rules, _ := h.rules.List(r.Context())
+	data := AdminDomainRulesPage{
+		BasePage: newBasePage(r, user),
+		Rules:    rules,
+		Error:    errMsg,
+	}
+	for _, rule := range rules {
+		if rule.Action == store.DomainAllow {
+			data.Allowlist = true
+		}
+	}
+	if isHTMX(r) {
+		renderPageFragment(w, "admin/domains.html", "domain_rule_list", data)
+		return
+	}
+	render(w, "admin/domains.html", data)
+}
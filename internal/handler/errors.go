@@ -0,0 +1,77 @@
+// Governing: SPEC-0001 REQ "Error Pages"
+package handler
+
+import (
+	"log"
+	"net/http"
+	"runtime/debug"
+
+	"github.com/joestump/joe-links/internal/auth"
+	"github.com/joestump/joe-links/internal/store"
+)
+
+// errorPage is the template data for the generic error page and its HTMX toast.
+type errorPage struct {
+	BasePage
+	User    *store.User
+	Status  int
+	Title   string // e.g. "Bad Request"
+	Message string // shown to the user; never internal error details
+}
+
+// renderError answers r with a branded error. Full-page requests get the error
+// page (the 500 page for server errors); HTMX requests get an error toast that
+// is retargeted into #toast-area, so a failed swap never replaces page content
+// with an error body.
+// Governing: SPEC-0001 REQ "Error Pages"
+func renderError(w http.ResponseWriter, r *http.Request, status int, message string) {
+	user := auth.UserFromContext(r.Context())
+	base := newBasePage(r, user)
+	data := errorPage{
+		BasePage: base,
+		User:     user,
+		Status:   status,
+		Title:    base.T(http.StatusText(status)),
+		Message:  base.T(message),
+	}
+	if isHTMX(r) {
+		w.Header().Set("HX-Retarget", "#toast-area")
+		w.Header().Set("HX-Reswap", "innerHTML")
+		t, ok := templatesFor(data).fragments["error_toast"]
+		if !ok {
+			templateNotFound(w, "error_toast")
+			return
+		}
+		execute(w, status, "error_toast", t, data)
+		return
+	}
+	page := "error.html"
+	if status >= http.StatusInternalServerError {
+		page = "500.html"
+	}
+	renderWithStatus(w, status, page, data)
+}
+
+// Recoverer recovers from panics in later handlers, logs the panic with its
+// stack, and answers with the 500 error page (or toast, for HTMX requests).
+// It replaces chi's middleware.Recoverer, which answers with a bare status.
+// Governing: SPEC-0001 REQ "Error Pages"
+func Recoverer(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() {
+			rec := recover()
+			if rec == nil {
+				return
+			}
+			if rec == http.ErrAbortHandler {
+				// Deliberate abort; let net/http drop the connection quietly.
+				panic(rec)
+			}
+			log.Printf("panic serving %s %s: %v\n%s", r.Method, r.URL.Path, rec, debug.Stack())
+			if r.Header.Get("Connection") != "Upgrade" {
+				renderError(w, r, http.StatusInternalServerError, "Something went wrong.")
+			}
+		}()
+		next.ServeHTTP(w, r)
+	})
+}
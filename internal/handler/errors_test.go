@@ -0,0 +1,81 @@
+// Governing: SPEC-0001 REQ "Error Pages"
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRenderError_FullPage(t *testing.T) {
+	w := httptest.NewRecorder()
+	renderError(w, httptest.NewRequest(http.MethodGet, "/dashboard/links/x", nil), http.StatusForbidden, "Not yours.")
+
+	if w.Code != http.StatusForbidden {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusForbidden)
+	}
+	body := w.Body.String()
+	for _, want := range []string{"<html", "403", "Forbidden", "Not yours."} {
+		if !strings.Contains(body, want) {
+			t.Errorf("body missing %q", want)
+		}
+	}
+}
+
+func TestRenderError_ServerErrorUses500Page(t *testing.T) {
+	w := httptest.NewRecorder()
+	renderError(w, httptest.NewRequest(http.MethodGet, "/dashboard", nil), http.StatusInternalServerError, "Could not load links.")
+
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusInternalServerError)
+	}
+	if !strings.Contains(w.Body.String(), "Something went wrong") {
+		t.Errorf("expected the 500 page, got %q", w.Body.String())
+	}
+}
+
+func TestRenderError_HTMXToast(t *testing.T) {
+	r := httptest.NewRequest(http.MethodDelete, "/dashboard/links/x", nil)
+	r.Header.Set("HX-Request", "true")
+	w := httptest.NewRecorder()
+	renderError(w, r, http.StatusInternalServerError, "Delete failed.")
+
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusInternalServerError)
+	}
+	if got := w.Header().Get("HX-Retarget"); got != "#toast-area" {
+		t.Errorf("HX-Retarget = %q", got)
+	}
+	body := w.Body.String()
+	if strings.Contains(body, "<html") || !strings.Contains(body, "Delete failed.") {
+		t.Errorf("expected a toast fragment, got %q", body)
+	}
+}
+
+func TestRecoverer(t *testing.T) {
+	h := Recoverer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		panic("boom")
+	}))
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/dashboard", nil))
+
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusInternalServerError)
+	}
+	if body := w.Body.String(); !strings.Contains(body, "Something went wrong") || strings.Contains(body, "boom") {
+		t.Errorf("expected the 500 page without panic details, got %q", body)
+	}
+}
+
+func TestRecoverer_AbortHandlerPropagates(t *testing.T) {
+	h := Recoverer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		panic(http.ErrAbortHandler)
+	}))
+	defer func() {
+		if rec := recover(); rec != http.ErrAbortHandler {
+			t.Errorf("recovered %v, want http.ErrAbortHandler", rec)
+		}
+	}()
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+}
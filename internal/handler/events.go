@@ -0,0 +1,125 @@
+// Governing: SPEC-0004 REQ "Live Dashboard Updates"
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/joestump/joe-links/internal/auth"
+	"github.com/joestump/joe-links/internal/events"
+	"github.com/joestump/joe-links/internal/store"
+)
+
+// eventsHeartbeat is how often an idle stream sends a comment line so
+// proxies do not time it out.
+const eventsHeartbeat = 30 * time.Second
+
+// EventsHandler streams link changes and clicks to open dashboards as
+// server-sent events.
+type EventsHandler struct {
+	broker    *events.Broker
+	links     *store.LinkStore
+	ownership *store.OwnershipStore
+}
+
+// NewEventsHandler creates a new EventsHandler.
+func NewEventsHandler(b *events.Broker, ls *store.LinkStore, os *store.OwnershipStore) *EventsHandler {
+	return &EventsHandler{broker: b, links: ls, ownership: os}
+}
+
+// Stream sends an event each time a link on the user's dashboard is created,
+// updated, deleted, or clicked. Admins, whose dashboard lists every link,
+// receive every event.
+// GET /dashboard/events
+func (h *EventsHandler) Stream(w http.ResponseWriter, r *http.Request) {
+	user := auth.UserFromContext(r.Context())
+	ctx := r.Context()
+	visible, err := h.visibleLinks(ctx, user)
+	if err != nil {
+		http.Error(w, "could not load links", http.StatusInternalServerError)
+		return
+	}
+
+	ch, unsubscribe := h.broker.Subscribe()
+	defer unsubscribe()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no") // keep nginx from buffering the stream
+	rc := http.NewResponseController(w)
+	fmt.Fprint(w, "retry: 5000\n\n")
+	if err := rc.Flush(); err != nil {
+		return
+	}
+
+	heartbeat := time.NewTicker(eventsHeartbeat)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-heartbeat.C:
+			fmt.Fprint(w, ": ping\n\n")
+		case e := <-ch:
+			if !h.relevant(ctx, user, visible, e) {
+				continue
+			}
+			fmt.Fprintf(w, "event: %s\ndata: {\"id\":%q}\n\n", e.Kind, e.LinkID)
+		}
+		if err := rc.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+// visibleLinks returns the IDs of the links on user's dashboard, or nil for
+// admins, who see all links.
+func (h *EventsHandler) visibleLinks(ctx context.Context, user *store.User) (map[string]bool, error) {
+	if user.IsAdmin() {
+		return nil, nil
+	}
+	links, err := h.links.ListByOwnerOrShared(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	visible := make(map[string]bool, len(links))
+	for _, l := range links {
+		visible[l.ID] = true
+	}
+	return visible, nil
+}
+
+// relevant reports whether e concerns a link on user's dashboard, keeping
+// visible in step as links are created, shared, and deleted. A deleted link
+// can no longer be looked up, which is why the set is tracked at all.
+func (h *EventsHandler) relevant(ctx context.Context, user *store.User, visible map[string]bool, e events.Event) bool {
+	if visible == nil {
+		return true
+	}
+	switch e.Kind {
+	case events.LinkDeleted:
+		was := visible[e.LinkID]
+		delete(visible, e.LinkID)
+		return was
+	case events.Click:
+		return visible[e.LinkID]
+	}
+	owner, err := h.ownership.IsOwner(e.LinkID, user.ID)
+	if err != nil {
+		return false
+	}
+	shared := false
+	if !owner {
+		shared, _ = h.links.HasShare(ctx, e.LinkID, user.ID)
+	}
+	was := visible[e.LinkID]
+	if owner || shared {
+		visible[e.LinkID] = true
+		return true
+	}
+	// The link left the dashboard, e.g. its share was revoked.
+	delete(visible, e.LinkID)
+	return was
+}
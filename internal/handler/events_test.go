@@ -0,0 +1,100 @@
+package handler
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/joestump/joe-links/internal/auth"
+	"github.com/joestump/joe-links/internal/events"
+	"github.com/joestump/joe-links/internal/store"
+	"github.com/joestump/joe-links/internal/testutil"
+)
+
+// Governing: SPEC-0004 REQ "Live Dashboard Updates"
+func TestEventsStream(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	owns := store.NewOwnershipStore(db)
+	ls := store.NewLinkStore(db, owns, store.NewTagStore(db))
+	us := store.NewUserStore(db)
+	broker := events.NewBroker()
+	ls.SetEvents(broker)
+	ctx := context.Background()
+
+	user, err := us.Upsert(ctx, "test", "sub1", "user@example.com", "User", "")
+	if err != nil {
+		t.Fatalf("seed user: %v", err)
+	}
+	other, err := us.Upsert(ctx, "test", "sub2", "other@example.com", "Other", "")
+	if err != nil {
+		t.Fatalf("seed user: %v", err)
+	}
+	mine, err := ls.Create(ctx, "mine", "https://example.com/mine", user.ID, "", "", "public")
+	if err != nil {
+		t.Fatalf("seed link: %v", err)
+	}
+	theirs, err := ls.Create(ctx, "theirs", "https://example.com/theirs", other.ID, "", "", "public")
+	if err != nil {
+		t.Fatalf("seed link: %v", err)
+	}
+
+	h := NewEventsHandler(broker, ls, owns)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h.Stream(w, r.WithContext(context.WithValue(r.Context(), auth.UserContextKey, user)))
+	}))
+	defer srv.Close()
+
+	reqCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(reqCtx, http.MethodGet, srv.URL, nil)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("GET: %v", err)
+	}
+	defer resp.Body.Close()
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("Content-Type = %q, want text/event-stream", ct)
+	}
+	lines := bufio.NewScanner(resp.Body)
+	next := func() string {
+		t.Helper()
+		for lines.Scan() {
+			if line := lines.Text(); strings.HasPrefix(line, "event: ") {
+				lines.Scan()
+				return line + " " + lines.Text()
+			}
+		}
+		t.Fatalf("stream ended: %v", lines.Err())
+		return ""
+	}
+	// The retry line is flushed once the handler has subscribed.
+	if !lines.Scan() || lines.Text() != "retry: 5000" {
+		t.Fatalf("first line = %q, want retry", lines.Text())
+	}
+
+	broker.Publish(events.Event{Kind: events.Click, LinkID: theirs.ID})
+	broker.Publish(events.Event{Kind: events.Click, LinkID: mine.ID})
+	if got, want := next(), `event: click data: {"id":"`+mine.ID+`"}`; got != want {
+		t.Errorf("event = %q, want %q", got, want)
+	}
+
+	if err := ls.Delete(ctx, theirs.ID); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	created, err := ls.Create(ctx, "new", "https://example.com/new", user.ID, "", "", "public")
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if got, want := next(), `event: link-created data: {"id":"`+created.ID+`"}`; got != want {
+		t.Errorf("event = %q, want %q", got, want)
+	}
+	if err := ls.Delete(ctx, created.ID); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if got, want := next(), `event: link-deleted data: {"id":"`+created.ID+`"}`; got != want {
+		t.Errorf("event = %q, want %q", got, want)
+	}
+}
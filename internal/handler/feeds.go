@@ -0,0 +1,128 @@
+// Governing: SPEC-0012 REQ "Tag Feeds"
+package handler
+
+import (
+	"encoding/xml"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/joestump/joe-links/internal/store"
+)
+
+// feedSize caps the number of entries in a tag feed.
+const feedSize = 50
+
+// FeedsHandler serves Atom feeds of public links.
+type FeedsHandler struct {
+	tags  *store.TagStore
+	links *store.LinkStore
+}
+
+// NewFeedsHandler creates a new FeedsHandler.
+func NewFeedsHandler(ts *store.TagStore, ls *store.LinkStore) *FeedsHandler {
+	return &FeedsHandler{tags: ts, links: ls}
+}
+
+// atomFeed is an RFC 4287 feed document.
+type atomFeed struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	ID      string      `xml:"id"`
+	Title   string      `xml:"title"`
+	Updated string      `xml:"updated"`
+	Links   []atomLink  `xml:"link"`
+	Author  atomPerson  `xml:"author"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+	Href string `xml:"href,attr"`
+}
+
+type atomPerson struct {
+	Name string `xml:"name"`
+	URI  string `xml:"uri,omitempty"`
+}
+
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+type atomEntry struct {
+	ID         string         `xml:"id"`
+	Title      string         `xml:"title"`
+	Updated    string         `xml:"updated"`
+	Published  string         `xml:"published"`
+	Links      []atomLink     `xml:"link"`
+	Author     *atomPerson    `xml:"author,omitempty"`
+	Summary    string         `xml:"summary,omitempty"`
+	Categories []atomCategory `xml:"category"`
+}
+
+// Tag renders an Atom feed of the newest public links carrying a tag.
+// GET /links/tags/{slug}/feed.atom
+func (h *FeedsHandler) Tag(w http.ResponseWriter, r *http.Request) {
+	slug := chi.URLParam(r, "slug")
+	tag, err := h.tags.GetBySlug(r.Context(), slug)
+	if err != nil {
+		http.Error(w, "feed not found", http.StatusNotFound)
+		return
+	}
+	links, err := h.links.ListRecentPublicByTag(r.Context(), slug, feedSize)
+	if err != nil {
+		http.Error(w, "could not load feed", http.StatusInternalServerError)
+		return
+	}
+
+	base := newBasePage(r, nil)
+	self := base.SiteURL + "/links/tags/" + tag.Slug + "/feed.atom"
+	// An empty feed still needs a stable updated time; the tag's creation
+	// time is the last moment its contents could have changed.
+	updated := tag.CreatedAt
+	if len(links) > 0 {
+		updated = links[0].CreatedAt
+	}
+	feed := atomFeed{
+		ID:      self,
+		Title:   "#" + tag.Name + " — " + base.ShortKeyword + "/ links",
+		Updated: updated.UTC().Format(time.RFC3339),
+		Links: []atomLink{
+			{Rel: "self", Type: "application/atom+xml", Href: self},
+			{Rel: "alternate", Type: "text/html", Href: base.SiteURL + "/links"},
+		},
+		Author: atomPerson{Name: "Joe Links", URI: base.SiteURL},
+	}
+	for i := range links {
+		l := &links[i]
+		created := l.CreatedAt.UTC().Format(time.RFC3339)
+		entry := atomEntry{
+			ID:        "urn:uuid:" + l.ID,
+			Title:     base.ShortKeyword + "/" + l.Slug + " — " + l.DisplayTitle(),
+			Updated:   created,
+			Published: created,
+			Links:     []atomLink{{Rel: "alternate", Href: base.SiteURL + "/" + l.Slug}},
+			Summary:   l.Description,
+		}
+		if l.OwnerDisplayName != "" {
+			entry.Author = &atomPerson{Name: l.OwnerDisplayName}
+			if l.OwnerSlug != "" {
+				entry.Author.URI = base.SiteURL + "/u/" + l.OwnerSlug
+			}
+		}
+		for _, name := range l.Tags() {
+			entry.Categories = append(entry.Categories, atomCategory{Term: name})
+		}
+		feed.Entries = append(feed.Entries, entry)
+	}
+
+	out, err := xml.MarshalIndent(feed, "", "  ")
+	if err != nil {
+		http.Error(w, "could not render feed", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/atom+xml; charset=utf-8")
+	w.Write([]byte(xml.Header))
+	w.Write(out)
+}
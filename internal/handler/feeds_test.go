@@ -0,0 +1,74 @@
+package handler
+
+import (
+	"context"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/joestump/joe-links/internal/store"
+	"github.com/joestump/joe-links/internal/testutil"
+)
+
+// Governing: SPEC-0012 REQ "Tag Feeds"
+func TestTagFeed(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	owns := store.NewOwnershipStore(db)
+	ts := store.NewTagStore(db)
+	ls := store.NewLinkStore(db, owns, ts)
+	us := store.NewUserStore(db)
+	ctx := context.Background()
+
+	owner, err := us.Upsert(ctx, "test", "sub1", "owner@example.com", "Owner", "")
+	if err != nil {
+		t.Fatalf("seed user: %v", err)
+	}
+	for _, l := range []struct{ slug, visibility string }{
+		{"deploy", "public"},
+		{"secret-runbook", "private"},
+	} {
+		link, err := ls.Create(ctx, l.slug, "https://example.com/"+l.slug, owner.ID, "", "How to "+l.slug, l.visibility)
+		if err != nil {
+			t.Fatalf("seed link: %v", err)
+		}
+		if err := ls.SetTags(ctx, link.ID, []string{"Runbooks", "ops"}); err != nil {
+			t.Fatalf("seed tags: %v", err)
+		}
+	}
+
+	r := chi.NewRouter()
+	r.Get("/links/tags/{slug}/feed.atom", NewFeedsHandler(ts, ls).Tag)
+	get := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "http://go.example.com"+path, nil))
+		return w
+	}
+
+	w := get("/links/tags/runbooks/feed.atom")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", w.Code)
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "application/atom+xml; charset=utf-8" {
+		t.Errorf("Content-Type = %q", ct)
+	}
+	var feed atomFeed
+	if err := xml.Unmarshal(w.Body.Bytes(), &feed); err != nil {
+		t.Fatalf("parse feed: %v", err)
+	}
+	if len(feed.Entries) != 1 {
+		t.Fatalf("entries = %d, want only the public link", len(feed.Entries))
+	}
+	e := feed.Entries[0]
+	if e.Links[0].Href != "http://go.example.com/deploy" || e.Summary != "How to deploy" || len(e.Categories) != 2 {
+		t.Errorf("entry = %+v", e)
+	}
+	if e.Author == nil || e.Author.Name != "Owner" {
+		t.Errorf("author = %+v, want Owner", e.Author)
+	}
+
+	if w := get("/links/tags/nope/feed.atom"); w.Code != http.StatusNotFound {
+		t.Errorf("unknown tag: status = %d, want 404", w.Code)
+	}
+}
@@ -0,0 +1,199 @@
+// Governing: SPEC-0001 REQ "Account Linking"
+package handler
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/alexedwards/scs/v2"
+	"github.com/go-chi/chi/v5"
+	"github.com/joestump/joe-links/internal/auth"
+	"github.com/joestump/joe-links/internal/store"
+)
+
+// IdentitiesPage is the template data for the page listing the sign-ins
+// linked to the user's account.
+type IdentitiesPage struct {
+	BasePage
+	Identities []*store.Identity
+	// LinkCodes is false in demo mode, where everyone shares one account.
+	LinkCodes bool
+	// Code and ExpiresAt are set right after a link code is created.
+	Code      string
+	ExpiresAt time.Time
+	Flash     *Flash
+}
+
+// JoinAccountPage is the template data for the screen that confirms joining
+// the signed-in account to the account that created a link code.
+type JoinAccountPage struct {
+	BasePage
+	Code     string
+	Target   *store.User
+	Identity *store.Identity
+}
+
+// IdentitiesHandler lists, links, and unlinks the user's sign-ins.
+type IdentitiesHandler struct {
+	users     *store.UserStore
+	sessions  *scs.SessionManager
+	linkCodes bool
+}
+
+// NewIdentitiesHandler creates a new IdentitiesHandler. sm is used to move
+// the browser's session to the account a redeemed link code joins.
+func NewIdentitiesHandler(us *store.UserStore, sm *scs.SessionManager, linkCodes bool) *IdentitiesHandler {
+	return &IdentitiesHandler{users: us, sessions: sm, linkCodes: linkCodes}
+}
+
+// Index renders the user's sign-ins.
+// GET /dashboard/settings/identities
+func (h *IdentitiesHandler) Index(w http.ResponseWriter, r *http.Request) {
+	h.render(w, r, IdentitiesPage{})
+}
+
+// CreateCode creates a one-time link code for the user to enter while
+// signed in to their other account.
+// POST /dashboard/settings/identities/code
+func (h *IdentitiesHandler) CreateCode(w http.ResponseWriter, r *http.Request) {
+	if !h.linkCodes {
+		renderError(w, r, http.StatusNotFound, "This action is disabled on the demo instance.")
+		return
+	}
+	user := auth.UserFromContext(r.Context())
+	code, err := h.users.CreateLinkCode(r.Context(), user.ID)
+	if err != nil {
+		renderError(w, r, http.StatusInternalServerError, "Could not create a link code.")
+		return
+	}
+	h.render(w, r, IdentitiesPage{
+		Code:      code,
+		ExpiresAt: time.Now().Add(store.LinkCodeTTL),
+	})
+}
+
+// Join shows what redeeming a link code will do to the signed-in account,
+// which is deleted once its sign-in moves to the code's account. Nothing
+// changes until the user confirms.
+// POST /dashboard/settings/identities/join
+func (h *IdentitiesHandler) Join(w http.ResponseWriter, r *http.Request) {
+	code, target, identity, ok := h.joinRequest(w, r)
+	if !ok {
+		return
+	}
+	user := auth.UserFromContext(r.Context())
+	render(w, "settings/identities_join.html", JoinAccountPage{
+		BasePage: newBasePage(r, user),
+		Code:     code,
+		Target:   target,
+		Identity: identity,
+	})
+}
+
+// ConfirmJoin redeems a link code: the signed-in account's links move to
+// the code's account, its sign-in is linked there, and it is deleted. The
+// browser stays signed in, now to the code's account.
+// POST /dashboard/settings/identities/join/confirm
+func (h *IdentitiesHandler) ConfirmJoin(w http.ResponseWriter, r *http.Request) {
+	code, target, identity, ok := h.joinRequest(w, r)
+	if !ok {
+		return
+	}
+	user := auth.UserFromContext(r.Context())
+	// The code is only used up if the accounts are joined.
+	err := h.users.JoinByLinkCode(r.Context(), code, target.ID, identity.Provider, identity.Subject, identity.Email)
+	switch {
+	case errors.Is(err, store.ErrNotFound):
+		h.render(w, r, IdentitiesPage{Flash: &Flash{Type: "error", Message: "The link code is invalid or has expired."}})
+		return
+	case errors.Is(err, store.ErrIdentityLinked):
+		renderError(w, r, http.StatusConflict, "This account cannot be joined to that one.")
+		return
+	case err != nil:
+		log.Printf("identities: join %s to %s: %v", user.ID, target.ID, err)
+		renderError(w, r, http.StatusInternalServerError, "Could not join the accounts.")
+		return
+	}
+	if err := h.sessions.RenewToken(r.Context()); err != nil {
+		renderError(w, r, http.StatusInternalServerError, "Something went wrong.")
+		return
+	}
+	h.sessions.Put(r.Context(), auth.SessionUserIDKey, target.ID)
+	h.sessions.Put(r.Context(), auth.SessionRoleKey, target.Role)
+	http.Redirect(w, r, "/dashboard/settings/identities", http.StatusSeeOther)
+}
+
+// joinRequest validates a join form: the code must be live and belong to
+// another account, and the signed-in account must have a single sign-in,
+// which is the one that moves. It answers the request itself when ok is false.
+func (h *IdentitiesHandler) joinRequest(w http.ResponseWriter, r *http.Request) (code string, target *store.User, identity *store.Identity, ok bool) {
+	if !h.linkCodes {
+		renderError(w, r, http.StatusNotFound, "This action is disabled on the demo instance.")
+		return "", nil, nil, false
+	}
+	user := auth.UserFromContext(r.Context())
+	code = strings.TrimSpace(r.PostFormValue("code"))
+	targetID, err := h.users.LinkCodeUser(r.Context(), code)
+	if err == nil {
+		target, err = h.users.GetByID(r.Context(), targetID)
+	}
+	switch {
+	case code == "" || errors.Is(err, store.ErrNotFound):
+		h.render(w, r, IdentitiesPage{Flash: &Flash{Type: "error", Message: "The link code is invalid or has expired."}})
+		return "", nil, nil, false
+	case err != nil:
+		renderError(w, r, http.StatusInternalServerError, "Something went wrong.")
+		return "", nil, nil, false
+	case target.ID == user.ID:
+		h.render(w, r, IdentitiesPage{Flash: &Flash{Type: "error", Message: "Enter the code while signed in to your other account."}})
+		return "", nil, nil, false
+	}
+	identities, err := h.users.Identities(r.Context(), user.ID)
+	if err != nil {
+		renderError(w, r, http.StatusInternalServerError, "Could not load your sign-ins.")
+		return "", nil, nil, false
+	}
+	if len(identities) != 1 {
+		h.render(w, r, IdentitiesPage{Flash: &Flash{Type: "error", Message: "Only an account with a single sign-in can be joined to another. Unlink the others first."}})
+		return "", nil, nil, false
+	}
+	return code, target, identities[0], true
+}
+
+// Unlink removes one of the user's sign-ins. The last one cannot be removed.
+// POST /dashboard/settings/identities/{id}/delete
+func (h *IdentitiesHandler) Unlink(w http.ResponseWriter, r *http.Request) {
+	user := auth.UserFromContext(r.Context())
+	err := h.users.UnlinkIdentity(r.Context(), user.ID, chi.URLParam(r, "id"))
+	switch {
+	case errors.Is(err, store.ErrNotFound):
+		renderError(w, r, http.StatusNotFound, "Sign-in not found.")
+		return
+	case errors.Is(err, store.ErrLastIdentity):
+		renderError(w, r, http.StatusBadRequest, "You cannot unlink your only sign-in.")
+		return
+	case err != nil:
+		renderError(w, r, http.StatusInternalServerError, "Could not unlink the sign-in.")
+		return
+	}
+	h.render(w, r, IdentitiesPage{Flash: &Flash{Type: "success", Message: "Sign-in unlinked."}})
+}
+
+func (h *IdentitiesHandler) render(w http.ResponseWriter, r *http.Request, data IdentitiesPage) {
+	user := auth.UserFromContext(r.Context())
+	identities, err := h.users.Identities(r.Context(), user.ID)
+	if err != nil {
+		renderError(w, r, http.StatusInternalServerError, "Could not load your sign-ins.")
+		return
+	}
+	data.BasePage = newBasePage(r, user)
+	data.Identities = identities
+	data.LinkCodes = h.linkCodes
+	if data.Flash != nil {
+		data.Flash.Message = data.T(data.Flash.Message)
+	}
+	render(w, "settings/identities.html", data)
+}
@@ -0,0 +1,154 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/alexedwards/scs/v2"
+	"github.com/go-chi/chi/v5"
+	"github.com/joestump/joe-links/internal/auth"
+	"github.com/joestump/joe-links/internal/store"
+	"github.com/joestump/joe-links/internal/testutil"
+)
+
+// Governing: SPEC-0001 REQ "Account Linking"
+func TestIdentities_Page(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	us := store.NewUserStore(db)
+	ctx := context.Background()
+	user, err := us.Upsert(ctx, "https://okta", "sub1", "u@example.com", "User", "")
+	if err != nil {
+		t.Fatalf("seed user: %v", err)
+	}
+	if err := us.LinkIdentity(ctx, user.ID, "https://google", "g1", "u@gmail.com"); err != nil {
+		t.Fatal(err)
+	}
+
+	h := NewIdentitiesHandler(us, scs.New(), true)
+	r := chi.NewRouter()
+	r.Get("/dashboard/settings/identities", h.Index)
+	r.Post("/dashboard/settings/identities/code", h.CreateCode)
+	r.Post("/dashboard/settings/identities/{id}/delete", h.Unlink)
+	serve := func(method, path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		req = req.WithContext(context.WithValue(req.Context(), auth.UserContextKey, user))
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	w := serve(http.MethodGet, "/dashboard/settings/identities")
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), "https://google") || !strings.Contains(w.Body.String(), "Unlink") {
+		t.Fatalf("index: status = %d, sign-ins missing", w.Code)
+	}
+
+	w = serve(http.MethodPost, "/dashboard/settings/identities/code")
+	if w.Code != http.StatusOK || strings.Contains(w.Body.String(), "/auth/login?link=") {
+		t.Fatalf("create code: status = %d", w.Code)
+	}
+
+	ids, _ := us.Identities(ctx, user.ID)
+	if w := serve(http.MethodPost, "/dashboard/settings/identities/"+ids[1].ID+"/delete"); w.Code != http.StatusOK {
+		t.Errorf("unlink: status = %d", w.Code)
+	}
+	if w := serve(http.MethodPost, "/dashboard/settings/identities/"+ids[0].ID+"/delete"); w.Code != http.StatusBadRequest {
+		t.Errorf("unlink last: status = %d, want 400", w.Code)
+	}
+	if w := serve(http.MethodPost, "/dashboard/settings/identities/nope/delete"); w.Code != http.StatusNotFound {
+		t.Errorf("unlink unknown: status = %d, want 404", w.Code)
+	}
+
+	// Demo visitors share one account, so there is nothing to link.
+	h.linkCodes = false
+	if w := serve(http.MethodPost, "/dashboard/settings/identities/code"); w.Code != http.StatusNotFound {
+		t.Errorf("code in demo mode: status = %d, want 404", w.Code)
+	}
+}
+
+// Governing: SPEC-0001 REQ "Account Linking"
+func TestIdentities_Join(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	us := store.NewUserStore(db)
+	ctx := context.Background()
+	alice, err := us.Upsert(ctx, "https://okta", "a1", "alice@example.com", "Alice", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	bob, err := us.Upsert(ctx, "https://google", "g1", "alice@gmail.com", "Alice G", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	code, err := us.CreateLinkCode(ctx, alice.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(code) < 26 {
+		t.Errorf("link code %q is too short to resist guessing", code)
+	}
+
+	sm := scs.New()
+	h := NewIdentitiesHandler(us, sm, true)
+	r := chi.NewRouter()
+	r.Post("/dashboard/settings/identities/join", h.Join)
+	r.Post("/dashboard/settings/identities/join/confirm", h.ConfirmJoin)
+	r.Get("/whoami", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(sm.GetString(r.Context(), auth.SessionUserIDKey)))
+	})
+	handler := sm.LoadAndSave(r)
+	var cookie *http.Cookie
+	serve := func(as *store.User, path, code string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(url.Values{"code": {code}}.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		if cookie != nil {
+			req.AddCookie(cookie)
+		}
+		req = req.WithContext(context.WithValue(req.Context(), auth.UserContextKey, as))
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		for _, c := range w.Result().Cookies() {
+			if c.Name == sm.Cookie.Name {
+				cookie = c
+			}
+		}
+		return w
+	}
+
+	// The code's own account cannot redeem it, and a wrong code is refused.
+	if w := serve(alice, "/dashboard/settings/identities/join", code); !strings.Contains(w.Body.String(), "other account") {
+		t.Errorf("join own code: body lacks the explanation")
+	}
+	if w := serve(bob, "/dashboard/settings/identities/join", "WRONG"); !strings.Contains(w.Body.String(), "invalid or has expired") {
+		t.Errorf("join with a wrong code: body lacks the error")
+	}
+
+	// Entering the code only asks for confirmation.
+	w := serve(bob, "/dashboard/settings/identities/join", code)
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), "alice@example.com") || !strings.Contains(w.Body.String(), "join/confirm") {
+		t.Fatalf("join: status = %d, confirmation missing", w.Code)
+	}
+	if _, err := us.GetByID(ctx, bob.ID); err != nil {
+		t.Fatalf("account changed before confirmation: %v", err)
+	}
+
+	w = serve(bob, "/dashboard/settings/identities/join/confirm", code)
+	if w.Code != http.StatusSeeOther {
+		t.Fatalf("confirm: status = %d, want 303", w.Code)
+	}
+	if _, err := us.GetByID(ctx, bob.ID); err == nil {
+		t.Error("joined account not deleted")
+	}
+	if u, err := us.GetByIdentity(ctx, "https://google", "g1"); err != nil || u.ID != alice.ID {
+		t.Errorf("joined sign-in resolves to %v, %v; want alice", u, err)
+	}
+	req := httptest.NewRequest(http.MethodGet, "/whoami", nil)
+	req.AddCookie(cookie)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Body.String() != alice.ID {
+		t.Errorf("session user = %q, want alice", rec.Body.String())
+	}
+}
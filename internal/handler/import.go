@@ -0,0 +1,239 @@
+// Governing: SPEC-0004 REQ "Bookmark Import"
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/joestump/joe-links/internal/auth"
+	"github.com/joestump/joe-links/internal/bookmarks"
+	"github.com/joestump/joe-links/internal/store"
+)
+
+const (
+	// maxImportSize is the largest bookmark file accepted for import.
+	maxImportSize = 10 << 20
+	// maxImportRows is the most bookmarks one import may review and create.
+	maxImportRows = 1000
+)
+
+// ImportRow is one bookmark under review: the link it would become and, when
+// it cannot be created as shown, why.
+type ImportRow struct {
+	Slug        string
+	URL         string
+	Title       string
+	Description string
+	Tags        string // comma-separated tag names
+	Include     bool
+	Problem     string
+}
+
+// ImportPage is the template data for the bookmark import flow: the upload
+// form, the review of parsed bookmarks, and the result.
+type ImportPage struct {
+	BasePage
+	User       *store.User
+	Rows       []ImportRow
+	Visibility string
+	Created    int
+	Error      string
+}
+
+// Import renders the bookmark file upload form.
+// GET /dashboard/import
+func (h *LinksHandler) Import(w http.ResponseWriter, r *http.Request) {
+	user := auth.UserFromContext(r.Context())
+	render(w, "import.html", ImportPage{BasePage: newBasePage(r, user), User: user, Visibility: "private"})
+}
+
+// ImportReview parses an uploaded bookmark file and renders each bookmark as
+// a proposed link for the user to review. Folders become tags; slugs are
+// derived from titles, numbered when the file repeats one, and flagged when
+// an existing link already uses them.
+// POST /dashboard/import
+func (h *LinksHandler) ImportReview(w http.ResponseWriter, r *http.Request) {
+	user := auth.UserFromContext(r.Context())
+	data := ImportPage{BasePage: newBasePage(r, user), User: user, Visibility: "private"}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportSize)
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		data.Error = "Choose a bookmarks file of at most 10 MB."
+		renderWithStatus(w, http.StatusBadRequest, "import.html", data)
+		return
+	}
+	defer file.Close()
+	marks, err := bookmarks.Parse(file)
+	if errors.Is(err, bookmarks.ErrNotBookmarkFile) {
+		data.Error = "That is not a bookmarks file. Export your bookmarks as HTML from your browser and upload that file."
+		renderWithStatus(w, http.StatusBadRequest, "import.html", data)
+		return
+	}
+	if err != nil {
+		data.Error = "Could not read the bookmarks file."
+		renderWithStatus(w, http.StatusBadRequest, "import.html", data)
+		return
+	}
+	switch {
+	case len(marks) == 0:
+		data.Error = "The file has no http or https bookmarks."
+	case len(marks) > maxImportRows:
+		data.Error = fmt.Sprintf("The file has %d bookmarks; import at most %d at a time.", len(marks), maxImportRows)
+	}
+	if data.Error != "" {
+		renderWithStatus(w, http.StatusBadRequest, "import.html", data)
+		return
+	}
+
+	proposed := make(map[string]bool, len(marks))
+	for _, b := range marks {
+		row := ImportRow{
+			Slug:        uniqueSlug(bookmarks.ProposeSlug(b), proposed),
+			URL:         b.URL,
+			Title:       b.Title,
+			Description: b.Description,
+			Tags:        strings.Join(b.TagNames(), ", "),
+		}
+		proposed[row.Slug] = true
+		row.Problem = h.importProblem(r, row)
+		row.Include = row.Problem == ""
+		data.Rows = append(data.Rows, row)
+	}
+	render(w, "import.html", data)
+}
+
+// ImportCreate creates a link for each reviewed row the user kept. Rows that
+// fail are shown again with the reason so they can be fixed and resubmitted.
+// POST /dashboard/import/confirm
+func (h *LinksHandler) ImportCreate(w http.ResponseWriter, r *http.Request) {
+	user := auth.UserFromContext(r.Context())
+	if err := r.ParseForm(); err != nil {
+		renderError(w, r, http.StatusBadRequest, "The request could not be understood.")
+		return
+	}
+	data := ImportPage{BasePage: newBasePage(r, user), User: user, Visibility: r.FormValue("visibility")}
+	if err := store.ValidateVisibility(data.Visibility); err != nil {
+		renderError(w, r, http.StatusBadRequest, "Choose a visibility for the imported links.")
+		return
+	}
+
+	slugs, urls := r.Form["slug"], r.Form["url"]
+	titles, descriptions, tags := r.Form["title"], r.Form["description"], r.Form["tags"]
+	n := len(slugs)
+	if n > maxImportRows || len(urls) != n || len(titles) != n || len(descriptions) != n || len(tags) != n {
+		renderError(w, r, http.StatusBadRequest, "The request could not be understood.")
+		return
+	}
+	include := make(map[int]bool, n)
+	for _, v := range r.Form["include"] {
+		if i, err := strconv.Atoi(v); err == nil {
+			include[i] = true
+		}
+	}
+
+	for i := range n {
+		if !include[i] {
+			continue
+		}
+		row := ImportRow{
+			Slug:        strings.TrimSpace(slugs[i]),
+			URL:         strings.TrimSpace(urls[i]),
+			Title:       titles[i],
+			Description: descriptions[i],
+			Tags:        tags[i],
+			Include:     true,
+		}
+		if row.Problem = h.createImported(r, user, row, data.Visibility); row.Problem != "" {
+			data.Rows = append(data.Rows, row)
+			continue
+		}
+		data.Created++
+	}
+	render(w, "import.html", data)
+}
+
+// importProblem reports why row would not be created as proposed, or "".
+func (h *LinksHandler) importProblem(r *http.Request, row ImportRow) string {
+	if row.Slug == "" {
+		return "Choose a slug."
+	}
+	if err := store.ValidateSlugFormat(row.Slug); err != nil {
+		return slugErrorMessage(err)
+	}
+	existing, err := h.links.GetBySlug(r.Context(), row.Slug)
+	switch {
+	case err == nil && existing.URL == row.URL:
+		return "Already a link to this URL."
+	case err == nil:
+		return "Slug taken by another link."
+	}
+	return ""
+}
+
+// createImported creates the link for row with the same checks as the new
+// link form, returning why it failed or "".
+func (h *LinksHandler) createImported(r *http.Request, user *store.User, row ImportRow, visibility string) string {
+	if err := store.ValidateSlugFormat(row.Slug); err != nil {
+		return slugErrorMessage(err)
+	}
+	if err := store.ValidateURLVariables(row.URL); err != nil {
+		return err.Error()
+	}
+	tagNames := parseTagNames(row.Tags)
+	// Governing: SPEC-0011 REQ "Link Lifecycle Policies"
+	warnings, msg := h.checkPolicies(r, store.PolicySubject{
+		Title:       row.Title,
+		Description: row.Description,
+		Visibility:  visibility,
+		Tags:        tagNames,
+		OwnerIDs:    []string{user.ID},
+	})
+	if msg != "" {
+		return msg
+	}
+	link, err := h.links.Create(r.Context(), row.Slug, row.URL, user.ID, row.Title, row.Description, visibility)
+	switch {
+	// Governing: SPEC-0002 REQ "Reserved Slugs"
+	case errors.Is(err, store.ErrSlugReserved):
+		return "That slug is reserved."
+	// Governing: SPEC-0002 REQ "Destination Domain Rules"
+	case errors.Is(err, store.ErrDomainNotAllowed):
+		return domainRuleMessage(err)
+	case errors.Is(err, store.ErrSlugTaken):
+		return "Slug taken by another link."
+	case err != nil:
+		return "Could not create the link."
+	}
+	if len(tagNames) > 0 {
+		_ = h.links.SetTags(r.Context(), link.ID, tagNames)
+	}
+	if h.policies != nil {
+		_ = h.policies.RecordViolations(r.Context(), link.ID, warnings)
+	}
+	return ""
+}
+
+// slugErrorMessage turns a store.ValidateSlugFormat error into a sentence.
+func slugErrorMessage(err error) string {
+	if errors.Is(err, store.ErrSlugReserved) {
+		return "That slug is reserved."
+	}
+	return "Slugs use lowercase letters, digits, and hyphens."
+}
+
+// uniqueSlug returns slug, or slug with the lowest numeric suffix from -2 up
+// that is not in taken. An empty slug stays empty.
+func uniqueSlug(slug string, taken map[string]bool) string {
+	if slug == "" || !taken[slug] {
+		return slug
+	}
+	for i := 2; ; i++ {
+		if s := slug + "-" + strconv.Itoa(i); !taken[s] {
+			return s
+		}
+	}
+}
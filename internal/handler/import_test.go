@@ -0,0 +1,100 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/joestump/joe-links/internal/auth"
+	"github.com/joestump/joe-links/internal/store"
+	"github.com/joestump/joe-links/internal/testutil"
+)
+
+// Governing: SPEC-0004 REQ "Bookmark Import"
+func TestImportBookmarks(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	owns := store.NewOwnershipStore(db)
+	ts := store.NewTagStore(db)
+	ls := store.NewLinkStore(db, owns, ts)
+	us := store.NewUserStore(db)
+	ctx := context.Background()
+	user, err := us.Upsert(ctx, "test", "sub1", "user@example.com", "User", "")
+	if err != nil {
+		t.Fatalf("seed user: %v", err)
+	}
+	if _, err := ls.Create(ctx, "team-wiki", "https://old-wiki.example.com", user.ID, "", "", "public"); err != nil {
+		t.Fatalf("seed link: %v", err)
+	}
+
+	h := NewLinksHandler(ls, owns, us, nil, nil, nil, nil, nil)
+	r := chi.NewRouter()
+	r.Post("/dashboard/import", h.ImportReview)
+	r.Post("/dashboard/import/confirm", h.ImportCreate)
+	serve := func(req *http.Request) string {
+		req = req.WithContext(context.WithValue(req.Context(), auth.UserContextKey, user))
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("%s %s: status %d", req.Method, req.URL, w.Code)
+		}
+		return w.Body.String()
+	}
+
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	fw, _ := mw.CreateFormFile("file", "bookmarks.html")
+	_, _ = fw.Write([]byte(`<!DOCTYPE NETSCAPE-Bookmark-file-1>
+<DL><p>
+    <DT><H3>Ops</H3>
+    <DL><p>
+        <DT><A HREF="https://grafana.example.com/">Grafana</A>
+        <DT><A HREF="https://grafana.example.com/old">Grafana</A>
+    </DL><p>
+    <DT><A HREF="https://wiki.example.com/">Team Wiki</A>
+</DL><p>`))
+	_ = mw.Close()
+	req := httptest.NewRequest(http.MethodPost, "/dashboard/import", &body)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	review := serve(req)
+	for _, want := range []string{`value="grafana"`, `value="grafana-2"`, `value="Ops"`, "Slug taken by another link."} {
+		if !strings.Contains(review, want) {
+			t.Errorf("review page missing %q", want)
+		}
+	}
+
+	form := url.Values{
+		"visibility":  {"private"},
+		"slug":        {"grafana", "grafana-2", "team-wiki"},
+		"url":         {"https://grafana.example.com/", "https://grafana.example.com/old", "https://wiki.example.com/"},
+		"title":       {"Grafana", "Grafana", "Team Wiki"},
+		"description": {"", "", ""},
+		"tags":        {"Ops", "Ops", ""},
+		"include":     {"0", "2"},
+	}
+	req = httptest.NewRequest(http.MethodPost, "/dashboard/import/confirm", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	result := serve(req)
+	if !strings.Contains(result, "Imported 1 link.") || !strings.Contains(result, `value="team-wiki"`) {
+		t.Errorf("result should report one import and return the conflicting row:\n%s", result)
+	}
+
+	link, err := ls.GetBySlug(ctx, "grafana")
+	if err != nil {
+		t.Fatalf("imported link: %v", err)
+	}
+	if link.Visibility != "private" {
+		t.Errorf("visibility = %q, want private", link.Visibility)
+	}
+	if tags, _ := ls.ListTags(ctx, link.ID); len(tags) != 1 || tags[0].Name != "Ops" {
+		t.Errorf("tags = %v, want [Ops]", tags)
+	}
+	if _, err := ls.GetBySlug(ctx, "grafana-2"); err == nil {
+		t.Error("unchecked bookmark was imported")
+	}
+}
@@ -2,9 +2,11 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/joestump/joe-links/internal/auth"
@@ -30,12 +32,20 @@ type AdminKeywordsPage struct {
 	Error    string
 }
 
+// listKeywords returns every keyword with its recent hit count.
+// Governing: SPEC-0016 REQ "Keyword Analytics"
+func (h *KeywordsHandler) listKeywords(r *http.Request) []*store.Keyword {
+	keywords, _ := h.keywords.List(r.Context())
+	_ = h.keywords.AttachUsage(r.Context(), keywords, time.Now().Add(-store.KeywordUsageWindow))
+	return keywords
+}
+
 // Index renders the keyword management list.
 // GET /admin/keywords
 // Governing: SPEC-0008 REQ "Keyword Host Discovery", ADR-0011
 func (h *KeywordsHandler) Index(w http.ResponseWriter, r *http.Request) {
 	user := auth.UserFromContext(r.Context())
-	keywords, _ := h.keywords.List(r.Context())
+	keywords := h.listKeywords(r)
 	data := AdminKeywordsPage{
 		BasePage: newBasePage(r, user),
 		Keywords: keywords,
@@ -52,7 +62,7 @@ func (h *KeywordsHandler) Index(w http.ResponseWriter, r *http.Request) {
 func (h *KeywordsHandler) Create(w http.ResponseWriter, r *http.Request) {
 	user := auth.UserFromContext(r.Context())
 	if err := r.ParseForm(); err != nil {
-		http.Error(w, "bad request", http.StatusBadRequest)
+		renderError(w, r, http.StatusBadRequest, "The request could not be understood.")
 		return
 	}
 
@@ -82,11 +92,40 @@ func (h *KeywordsHandler) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if _, err := h.keywords.Create(r.Context(), keyword, urlTemplate, description); err != nil {
+	kw, err := h.keywords.Create(r.Context(), keyword, urlTemplate, description)
+	if err != nil {
 		h.renderList(w, r, user, "Failed to create keyword.")
 		return
 	}
+	// Governing: SPEC-0008 REQ "Secure Keywords"
+	if r.FormValue("secure") == "1" {
+		if err := h.keywords.SetSecure(r.Context(), kw.ID, true); err != nil {
+			h.renderList(w, r, user, "Keyword created, but it could not be made secure.")
+			return
+		}
+	}
+
+	h.renderList(w, r, user, "")
+}
 
+// SetSecure sets whether a keyword requires sign-in from the "secure" form
+// value ("1" or empty) and re-renders the list.
+// POST /admin/keywords/{id}/secure
+// Governing: SPEC-0008 REQ "Secure Keywords"
+func (h *KeywordsHandler) SetSecure(w http.ResponseWriter, r *http.Request) {
+	user := auth.UserFromContext(r.Context())
+	if err := r.ParseForm(); err != nil {
+		renderError(w, r, http.StatusBadRequest, "The request could not be understood.")
+		return
+	}
+	if err := h.keywords.SetSecure(r.Context(), chi.URLParam(r, "id"), r.FormValue("secure") == "1"); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			renderError(w, r, http.StatusNotFound, "That item no longer exists.")
+			return
+		}
+		h.renderList(w, r, user, "Failed to update keyword.")
+		return
+	}
 	h.renderList(w, r, user, "")
 }
 
@@ -97,7 +136,7 @@ func (h *KeywordsHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 
 	if err := h.keywords.Delete(r.Context(), id); err != nil {
-		http.Error(w, "delete failed", http.StatusInternalServerError)
+		renderError(w, r, http.StatusInternalServerError, "Delete failed.")
 		return
 	}
 
@@ -112,7 +151,7 @@ func (h *KeywordsHandler) ConfirmDelete(w http.ResponseWriter, r *http.Request)
 
 	kw, err := h.keywords.GetByID(r.Context(), id)
 	if err != nil {
-		http.NotFound(w, r)
+		renderError(w, r, http.StatusNotFound, "That item no longer exists.")
 		return
 	}
 
@@ -126,7 +165,7 @@ func (h *KeywordsHandler) ConfirmDelete(w http.ResponseWriter, r *http.Request)
 
 // renderList re-renders the keyword_list partial (or full page for non-HTMX).
 func (h *KeywordsHandler) renderList(w http.ResponseWriter, r *http.Request, user *store.User, errMsg string) {
-	keywords, _ := h.keywords.List(r.Context())
+	keywords := h.listKeywords(r)
 	data := AdminKeywordsPage{
 		BasePage: newBasePage(r, user),
 		Keywords: keywords,
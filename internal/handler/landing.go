@@ -2,19 +2,36 @@
 package handler
 
 import (
+	"log"
 	"net/http"
 
 	"github.com/joestump/joe-links/internal/auth"
+	"github.com/joestump/joe-links/internal/setup"
 )
 
 // LandingHandler serves the public landing page.
-type LandingHandler struct{}
+type LandingHandler struct {
+	// setup, when set, sends visitors to /setup until setup finishes.
+	// Governing: SPEC-0001 REQ "First-Run Setup"
+	setup *setup.Service
+}
 
 // NewLandingHandler creates a new LandingHandler.
 func NewLandingHandler() *LandingHandler { return &LandingHandler{} }
 
-// Index serves GET /. Authenticated users are redirected to /dashboard.
+// Index serves GET /. Authenticated users are redirected to /dashboard, and
+// everyone to /setup while first-run setup is pending.
 func (h *LandingHandler) Index(w http.ResponseWriter, r *http.Request) {
+	if h.setup != nil {
+		pending, err := h.setup.Pending(r.Context())
+		if err != nil {
+			log.Printf("landing: setup pending check: %v", err)
+		}
+		if pending {
+			http.Redirect(w, r, "/setup", http.StatusFound)
+			return
+		}
+	}
 	user := auth.UserFromContext(r.Context())
 	if user != nil {
 		http.Redirect(w, r, "/dashboard", http.StatusFound)
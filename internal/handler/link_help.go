@@ -0,0 +1,133 @@
+// Governing: SPEC-0009 REQ "Templated Link Help Page", ADR-0013
+package handler
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/joestump/joe-links/internal/auth"
+	"github.com/joestump/joe-links/internal/store"
+)
+
+// helpQueryParam requests the help view for a templated link, e.g. go/jira?help.
+const helpQueryParam = "help"
+
+// templateVariable describes one placeholder of a URL template for the help page.
+type templateVariable struct {
+	Name       string // without $, *, default or q:
+	Kind       string // "path", "rest", or "query"
+	Constraint string // regex the value must match, if any
+	Default    string // value used when the segment is left out, if any
+	Optional   bool   // path variable with a default
+}
+
+// Placeholder returns how the variable is written in the URL template.
+func (v templateVariable) Placeholder() string {
+	switch v.Kind {
+	case "rest":
+		return "$" + v.Name + "*"
+	case "query":
+		return queryPlaceholderPrefix + v.Name
+	}
+	if v.Optional {
+		return "$" + v.Name + ":" + v.Default
+	}
+	return "$" + v.Name
+}
+
+// linkHelpPage is the template data for the templated link help view.
+type linkHelpPage struct {
+	BasePage
+	User      *store.User
+	Link      *store.Link
+	Variables []templateVariable
+	Usage     string // e.g. "jira/<key>?focus=<comment>"
+}
+
+// describeVariables lists the distinct placeholders of tmpl in order of first
+// appearance, with their constraints.
+func describeVariables(tmpl string, constraints map[string]string) []templateVariable {
+	seen := make(map[string]bool)
+	var vars []templateVariable
+	for _, p := range varPlaceholderRe.FindAllString(tmpl, -1) {
+		if seen[p] {
+			continue
+		}
+		seen[p] = true
+		v := templateVariable{Kind: "path"}
+		switch {
+		case strings.HasPrefix(p, queryPlaceholderPrefix):
+			v.Name, v.Kind = strings.TrimPrefix(p, queryPlaceholderPrefix), "query"
+		case strings.HasSuffix(p, "*"):
+			v.Name, v.Kind = strings.TrimSuffix(p[1:], "*"), "rest"
+		default:
+			v.Name, v.Default, v.Optional = store.SplitPlaceholder(p)
+		}
+		if v.Kind != "query" {
+			v.Constraint = constraints[v.Name]
+		}
+		vars = append(vars, v)
+	}
+	return vars
+}
+
+// usageFor builds the path a user types to follow slug with vars, e.g.
+// "gh/<org>/<repo>/<rest>/…?ref=<ref>". Optional variables are bracketed, as
+// in "dash[/<env>]".
+func usageFor(slug string, vars []templateVariable) string {
+	var path strings.Builder
+	var query []string
+	path.WriteString(slug)
+	for _, v := range vars {
+		switch v.Kind {
+		case "path":
+			if v.Optional {
+				path.WriteString("[/<" + v.Name + ">]")
+				continue
+			}
+			path.WriteString("/<" + v.Name + ">")
+		case "rest":
+			path.WriteString("/<" + v.Name + ">/…")
+		case "query":
+			query = append(query, v.Name+"=<"+v.Name+">")
+		}
+	}
+	if len(query) > 0 {
+		path.WriteString("?" + strings.Join(query, "&"))
+	}
+	return path.String()
+}
+
+// wantsHelp reports whether r asks for the help view of link. Only templated
+// links have one, and a template that reads ?help itself keeps it.
+func wantsHelp(query url.Values, link *store.Link) bool {
+	if !query.Has(helpQueryParam) || !varPlaceholderRe.MatchString(link.URL) {
+		return false
+	}
+	for _, p := range varPlaceholderRe.FindAllString(link.URL, -1) {
+		if p == queryPlaceholderPrefix+helpQueryParam {
+			return false
+		}
+	}
+	return true
+}
+
+// renderHelp renders the help view describing link's variables.
+// Governing: SPEC-0009 REQ "Templated Link Help Page", ADR-0013
+func (h *ResolveHandler) renderHelp(w http.ResponseWriter, r *http.Request, link *store.Link) {
+	user := auth.UserFromContext(r.Context())
+	vars := describeVariables(link.URL, link.Constraints())
+	data := linkHelpPage{
+		BasePage:  newBasePage(r, user),
+		User:      user,
+		Link:      link,
+		Variables: vars,
+		Usage:     usageFor(link.Slug, vars),
+	}
+	if isHTMX(r) {
+		renderPageFragment(w, "links/help.html", "content", data)
+		return
+	}
+	render(w, "links/help.html", data)
+}
@@ -0,0 +1,118 @@
+// Governing: SPEC-0011 REQ "Link Lifecycle Policies"
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/joestump/joe-links/internal/auth"
+	"github.com/joestump/joe-links/internal/store"
+)
+
+// LinkPoliciesHandler serves the admin link policy screens.
+type LinkPoliciesHandler struct {
+	policies *store.PolicyStore
+}
+
+// NewLinkPoliciesHandler creates a new LinkPoliciesHandler.
+func NewLinkPoliciesHandler(ps *store.PolicyStore) *LinkPoliciesHandler {
+	return &LinkPoliciesHandler{policies: ps}
+}
+
+// AdminPoliciesPage is the template data for the link policy list.
+type AdminPoliciesPage struct {
+	BasePage
+	Policies []*store.LinkPolicy
+	Rules    []string
+	Error    string
+}
+
+// Index renders the link policy list.
+// GET /admin/policies
+func (h *LinkPoliciesHandler) Index(w http.ResponseWriter, r *http.Request) {
+	h.renderList(w, r, auth.UserFromContext(r.Context()), "")
+}
+
+// Create adds a policy from the rule builder form.
+// POST /admin/policies
+func (h *LinkPoliciesHandler) Create(w http.ResponseWriter, r *http.Request) {
+	user := auth.UserFromContext(r.Context())
+	if err := r.ParseForm(); err != nil {
+		renderError(w, r, http.StatusBadRequest, "The request could not be understood.")
+		return
+	}
+
+	days, _ := strconv.Atoi(strings.TrimSpace(r.FormValue("days")))
+	_, err := h.policies.Create(r.Context(), r.FormValue("name"), r.FormValue("scope"), r.FormValue("rule"), days, r.FormValue("enforcement"))
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrPolicyNameRequired):
+			h.renderList(w, r, user, "Name is required.")
+		case errors.Is(err, store.ErrPolicyNameTaken):
+			h.renderList(w, r, user, "A policy with that name already exists.")
+		case errors.Is(err, store.ErrInvalidPolicy):
+			h.renderList(w, r, user, "Invalid policy: "+strings.TrimPrefix(err.Error(), store.ErrInvalidPolicy.Error()+": ")+".")
+		case errors.Is(err, store.ErrInvalidFilter):
+			h.renderList(w, r, user, "Invalid scope: "+strings.TrimPrefix(err.Error(), store.ErrInvalidFilter.Error()+": ")+".")
+		default:
+			h.renderList(w, r, user, "Failed to create policy.")
+		}
+		return
+	}
+
+	h.renderList(w, r, user, "")
+}
+
+// Delete removes a policy. Returns empty 200 so HTMX swaps out the row.
+// DELETE /admin/policies/{id}
+func (h *LinkPoliciesHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	if err := h.policies.Delete(r.Context(), chi.URLParam(r, "id")); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			renderError(w, r, http.StatusNotFound, "That item no longer exists.")
+			return
+		}
+		renderError(w, r, http.StatusInternalServerError, "Delete failed.")
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// ConfirmDelete renders the delete confirmation modal for a policy.
+// GET /admin/policies/{id}/confirm-delete
+// Governing: SPEC-0013 REQ "DaisyUI Delete Confirmation Modal"
+func (h *LinkPoliciesHandler) ConfirmDelete(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	name := "this policy"
+	if policies, err := h.policies.List(r.Context()); err == nil {
+		for _, p := range policies {
+			if p.ID == id {
+				name = p.Name
+			}
+		}
+	}
+	data := ConfirmDeleteData{
+		Name:      name,
+		DeleteURL: "/admin/policies/" + id,
+		Target:    "#policy-" + id,
+	}
+	renderFragment(w, "confirm_delete", data)
+}
+
+// renderList re-renders the policy_list partial (or full page for non-HTMX).
+func (h *LinkPoliciesHandler) renderList(w http.ResponseWriter, r *http.Request, user *store.User, errMsg string) {
+	policies, _ := h.policies.List(r.Context())
+	data := AdminPoliciesPage{
+		BasePage: newBasePage(r, user),
+		Policies: policies,
+		Rules:    store.PolicyRules,
+		Error:    errMsg,
+	}
+	if isHTMX(r) {
+		renderPageFragment(w, "admin/policies.html", "policy_list", data)
+		return
+	}
+	render(w, "admin/policies.html", data)
+}
@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/joestump/joe-links/internal/auth"
+	"github.com/joestump/joe-links/internal/store"
+	"github.com/joestump/joe-links/internal/testutil"
+)
+
+// Governing: SPEC-0011 REQ "Link Lifecycle Policies"
+func TestLinkPolicies_BlockAndWarn(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	us := store.NewUserStore(db)
+	admin, err := us.Upsert(context.Background(), "test", "sub1", "admin@example.com", "Admin", "")
+	if err != nil {
+		t.Fatalf("seed user: %v", err)
+	}
+	owns := store.NewOwnershipStore(db)
+	ls := store.NewLinkStore(db, owns, store.NewTagStore(db))
+	ps := store.NewPolicyStore(db, ls)
+
+	policies := NewLinkPoliciesHandler(ps)
+	links := NewLinksHandler(ls, owns, us, nil, nil, nil, ps, nil)
+	r := chi.NewRouter()
+	r.Post("/admin/policies", policies.Create)
+	r.Post("/dashboard/links", links.Create)
+	post := func(path string, form url.Values) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.Header.Set("HX-Request", "true")
+		req = req.WithContext(context.WithValue(req.Context(), auth.UserContextKey, admin))
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	body := post("/admin/policies", url.Values{"name": {"Infra links are documented"}, "scope": {"tag:infra"}, "rule": {"require_description"}, "enforcement": {"block"}}).Body.String()
+	if !strings.Contains(body, "Infra links are documented") || !strings.Contains(body, "Require a description") {
+		t.Fatalf("policy list missing the new policy:\n%s", body)
+	}
+	if body := post("/admin/policies", url.Values{"name": {"Bad scope"}, "scope": {"runbook"}, "rule": {"require_title"}}).Body.String(); !strings.Contains(body, "Invalid scope") {
+		t.Errorf("free-text scope did not report an error:\n%s", body)
+	}
+	if _, err := ps.Create(context.Background(), "Titles", "", store.PolicyRequireTitle, 0, store.PolicyWarn); err != nil {
+		t.Fatalf("Create(warn): %v", err)
+	}
+
+	w := post("/dashboard/links", url.Values{"slug": {"grafana"}, "url": {"https://grafana.example.com"}, "title": {"Grafana"}, "tags": {"Infra"}})
+	if !strings.Contains(w.Body.String(), "Infra links are documented") {
+		t.Errorf("blocked link did not show the policy error:\n%s", w.Body.String())
+	}
+	if _, err := ls.GetBySlug(context.Background(), "grafana"); err == nil {
+		t.Error("blocked link was created")
+	}
+
+	if w := post("/dashboard/links", url.Values{"slug": {"wiki"}, "url": {"https://wiki.example.com"}}); w.Header().Get("HX-Trigger") != "linkCreated" {
+		t.Fatalf("warned link was not created: %d\n%s", w.Code, w.Body.String())
+	}
+	vs, err := ps.ListViolationsByOwner(context.Background(), admin.ID)
+	if err != nil {
+		t.Fatalf("ListViolationsByOwner: %v", err)
+	}
+	if len(vs) != 1 || vs[0].Slug != "wiki" {
+		t.Errorf("violations = %+v, want the untitled wiki link", vs)
+	}
+}
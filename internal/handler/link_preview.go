@@ -0,0 +1,75 @@
+// Governing: SPEC-0009 REQ "Link Form Preview", ADR-0013
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// linkPreview is the template data for the link_preview fragment.
+type linkPreview struct {
+	Sample string // what the user typed after go/<slug>/
+	Target string // resolved destination, when the sample fits the template
+	Error  string
+}
+
+// PreviewURL handles GET /dashboard/links/preview?url=...&constraints=...&sample=...
+// It resolves the URL template against sample values typed in the link form
+// with the resolver's own substitution, so owners see the exact destination
+// before saving. The sample is the path after the slug, optionally followed
+// by a query string for $q:param placeholders, e.g. "PROJ-1?focus=42".
+// Governing: SPEC-0009 REQ "Link Form Preview", ADR-0013
+func (h *LinksHandler) PreviewURL(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	tmpl := strings.TrimSpace(q.Get("url"))
+	sample := strings.TrimPrefix(strings.TrimSpace(q.Get("sample")), "/")
+	if sample == "" || !varPlaceholderRe.MatchString(tmpl) {
+		renderFragment(w, "link_preview", linkPreview{})
+		return
+	}
+
+	data := linkPreview{Sample: sample}
+	constraints, err := parseConstraints(q.Get("constraints"), tmpl)
+	if err != nil {
+		data.Error = err.Error()
+		renderFragment(w, "link_preview", data)
+		return
+	}
+	data.Target, err = previewTarget(tmpl, sample, constraints)
+	if err != nil {
+		data.Error = previewError(err)
+	}
+	renderFragment(w, "link_preview", data)
+}
+
+// previewTarget resolves tmpl the way the resolver would for a request to
+// go/<slug>/<sample>.
+func previewTarget(tmpl, sample string, constraints map[string]string) (string, error) {
+	path, rawQuery, _ := strings.Cut(sample, "?")
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return "", err
+	}
+	if !hasPathVariables(tmpl) {
+		return substituteQueryVariables(tmpl, query), nil
+	}
+	var segments []string
+	if path = strings.Trim(path, "/"); path != "" {
+		segments = strings.Split(path, "/")
+	}
+	return substituteVariables(tmpl, segments, query, constraints)
+}
+
+// previewError turns a substitution error into a message for the link form.
+func previewError(err error) string {
+	var mismatch *variableMismatch
+	switch {
+	case errors.As(err, &mismatch):
+		return "$" + mismatch.Name + " must match " + mismatch.Pattern
+	case errors.Is(err, errVariableArity):
+		return "Wrong number of values: give one path segment per variable"
+	}
+	return err.Error()
+}
@@ -5,38 +5,31 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/joestump/joe-links/internal/auth"
+	"github.com/joestump/joe-links/internal/mailer"
 	"github.com/joestump/joe-links/internal/store"
 )
 
-// Governing: SPEC-0001 REQ "Short Link Resolution" — reserved prefixes MUST NOT be valid slugs.
-// Governing: SPEC-0012 REQ "User Profile Route Priority" — "u" reserved for user profile pages.
-// Governing: SPEC-0012 REQ "Public Link Browser Route Priority" — "links" reserved for public link browser.
-var reservedPrefixes = []string{"auth", "static", "dashboard", "admin", "u", "links"}
-
-// isReservedSlug returns true if the slug matches or starts with a reserved prefix.
-func isReservedSlug(slug string) bool {
-	for _, prefix := range reservedPrefixes {
-		if slug == prefix || strings.HasPrefix(slug, prefix+"-") {
-			return true
-		}
-	}
-	return false
-}
-
 // LinkForm holds form input values for creating or editing a link.
 // Governing: SPEC-0010 REQ "Visibility Selector in Link Forms"
 type LinkForm struct {
-	Slug        string
-	URL         string
-	Title       string
-	Description string
-	Tags        string // comma-separated tag names
-	Visibility  string // public, private, or secure
+	Slug         string
+	URL          string
+	Title        string
+	Description  string
+	Tags         string // comma-separated tag names
+	Visibility   string // public, private, or secure
+	Constraints  string // one name=regex per line; Governing: SPEC-0009 REQ "Variable Constraints"
+	RedirectType int    // 301, 302, 307, or 308; Governing: SPEC-0002 REQ "Redirect Type"
+	TeamID       string // owning team, "" for none; Governing: SPEC-0002 REQ "Team Ownership"
+	UTM          string // one utm_name=value per line; Governing: SPEC-0002 REQ "UTM Parameters"
+	PassQuery    bool   // forward the request query; Governing: SPEC-0009 REQ "Query-String Passthrough"
 }
 
 // LinkFormPage is the template data for the new/edit link forms.
@@ -45,21 +38,39 @@ type LinkFormPage struct {
 	User    *store.User
 	Link    *store.Link
 	Form    LinkForm
+	Teams   []*store.Team // choices for the owning team; Governing: SPEC-0002 REQ "Team Ownership"
 	Error   string
 	Flash   *Flash
 }
 
+// editPage builds the edit form data for link, including the team choices.
+func (h *LinksHandler) editPage(r *http.Request, user *store.User, link *store.Link, form LinkForm, errMsg string) LinkFormPage {
+	data := LinkFormPage{BasePage: newBasePage(r, user), User: user, Link: link, Form: form, Error: errMsg}
+	if h.teams != nil {
+		data.Teams, _ = h.teams.List(r.Context())
+	}
+	return data
+}
+
 // LinkDetailPage is the template data for the link detail view.
 // Governing: SPEC-0004 REQ "Link Detail View"
 // Governing: SPEC-0010 REQ "Share Management Panel on Link Detail"
 type LinkDetailPage struct {
 	BasePage
-	User   *store.User
-	Link   *store.Link
-	Tags   []*store.Tag
-	Owners []*store.OwnerInfo
-	Shares []ShareUser
-	Error  string
+	User       *store.User
+	Link       *store.Link
+	Tags       []*store.Tag
+	Owners     []*store.OwnerInfo
+	Shares     []ShareUser
+	TeamOwners []*store.Team            // Governing: SPEC-0010 REQ "Team Shares and Ownership"
+	TeamShares []*store.Team            // Governing: SPEC-0010 REQ "Team Shares and Ownership"
+	Teams      []*store.Team            // every team, for the add-team forms
+	Aliases    []*store.Alias           // Governing: SPEC-0002 REQ "Link Aliases"
+	Violations []*store.PolicyViolation // Governing: SPEC-0011 REQ "Link Lifecycle Policies"
+	// OwnersError and SharesError show a failed no-JavaScript owners or
+	// shares form in its section. Governing: SPEC-0013 REQ "No-JavaScript Fallbacks"
+	OwnersError string
+	SharesError string
 }
 
 // ShareUser combines share record with user display info for templates.
@@ -78,17 +89,46 @@ type ConfirmDeleteData struct {
 	Target    string
 }
 
+// ConfirmDeletePage is the standalone delete confirmation page served when
+// the modal cannot be opened, e.g. with JavaScript disabled.
+// Governing: SPEC-0013 REQ "No-JavaScript Fallbacks"
+type ConfirmDeletePage struct {
+	BasePage
+	ConfirmDeleteData
+	CancelURL string // where Cancel returns to
+}
+
+// renderConfirmDelete renders the delete confirmation modal for HTMX
+// requests, and otherwise a full page whose form posts to
+// data.DeleteURL + "/delete".
+// Governing: SPEC-0013 REQ "No-JavaScript Fallbacks"
+func renderConfirmDelete(w http.ResponseWriter, r *http.Request, data ConfirmDeleteData, cancelURL string) {
+	if isHTMX(r) {
+		renderFragment(w, "confirm_delete", data)
+		return
+	}
+	render(w, "confirm.html", ConfirmDeletePage{
+		BasePage:          newBasePage(r, auth.UserFromContext(r.Context())),
+		ConfirmDeleteData: data,
+		CancelURL:         cancelURL,
+	})
+}
+
 // LinksHandler provides HTTP handlers for link CRUD operations.
 type LinksHandler struct {
 	links    *store.LinkStore
 	owns     *store.OwnershipStore
 	users    *store.UserStore
 	keywords *store.KeywordStore
+	reserved *store.ReservedSlugStore
+	teams    *store.TeamStore
+	policies *store.PolicyStore // Governing: SPEC-0011 REQ "Link Lifecycle Policies"; nil skips policy checks
+	notify   *mailer.Notifier   // Governing: SPEC-0001 REQ "Email Notifications"; nil disables emails
 }
 
 // NewLinksHandler creates a new LinksHandler.
-func NewLinksHandler(ls *store.LinkStore, os *store.OwnershipStore, us *store.UserStore, ks *store.KeywordStore) *LinksHandler {
-	return &LinksHandler{links: ls, owns: os, users: us, keywords: ks}
+func NewLinksHandler(ls *store.LinkStore, os *store.OwnershipStore, us *store.UserStore, ks *store.KeywordStore, rs *store.ReservedSlugStore, ts *store.TeamStore, ps *store.PolicyStore, n *mailer.Notifier) *LinksHandler {
+	return &LinksHandler{links: ls, owns: os, users: us, keywords: ks, reserved: rs, teams: ts, policies: ps, notify: n}
 }
 
 // New renders the create-link form.
@@ -110,7 +150,7 @@ func (h *LinksHandler) New(w http.ResponseWriter, r *http.Request) {
 func (h *LinksHandler) Create(w http.ResponseWriter, r *http.Request) {
 	user := auth.UserFromContext(r.Context())
 	if err := r.ParseForm(); err != nil {
-		http.Error(w, "bad request", http.StatusBadRequest)
+		renderError(w, r, http.StatusBadRequest, "The request could not be understood.")
 		return
 	}
 
@@ -127,6 +167,7 @@ func (h *LinksHandler) Create(w http.ResponseWriter, r *http.Request) {
 		Description: r.FormValue("description"),
 		Tags:        r.FormValue("tags"),
 		Visibility:  visibility,
+		Constraints: r.FormValue("constraints"),
 	}
 
 	// Governing: SPEC-0010 REQ "Visibility Selector in Link Forms" — validate visibility value
@@ -150,8 +191,10 @@ func (h *LinksHandler) Create(w http.ResponseWriter, r *http.Request) {
 		render(w, "new.html", data)
 		return
 	}
-	if isReservedSlug(form.Slug) {
-		data := LinkFormPage{BasePage: newBasePage(r, user), User: user, Form: form, Error: "That slug uses a reserved prefix (auth, static, dashboard, admin, links)."}
+
+	// Governing: SPEC-0009 REQ "Variable Placeholder Syntax", ADR-0013
+	if err := store.ValidateURLVariables(form.URL); err != nil {
+		data := LinkFormPage{BasePage: newBasePage(r, user), User: user, Form: form, Error: err.Error()}
 		if isHTMX(r) {
 			renderFragment(w, "new_link_modal", data)
 			return
@@ -160,8 +203,9 @@ func (h *LinksHandler) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Governing: SPEC-0009 REQ "Variable Placeholder Syntax", ADR-0013
-	if err := store.ValidateURLVariables(form.URL); err != nil {
+	// Governing: SPEC-0009 REQ "Variable Constraints", ADR-0013
+	constraints, err := parseConstraints(form.Constraints, form.URL)
+	if err != nil {
 		data := LinkFormPage{BasePage: newBasePage(r, user), User: user, Form: form, Error: err.Error()}
 		if isHTMX(r) {
 			renderFragment(w, "new_link_modal", data)
@@ -171,9 +215,36 @@ func (h *LinksHandler) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Governing: SPEC-0011 REQ "Link Lifecycle Policies"
+	warnings, msg := h.checkPolicies(r, store.PolicySubject{
+		Title:       form.Title,
+		Description: form.Description,
+		Visibility:  form.Visibility,
+		Tags:        parseTagNames(form.Tags),
+		OwnerIDs:    []string{user.ID},
+	})
+	if msg != "" {
+		data := LinkFormPage{BasePage: newBasePage(r, user), User: user, Form: form, Error: msg}
+		if isHTMX(r) {
+			renderFragment(w, "new_link_modal", data)
+			return
+		}
+		render(w, "new.html", data)
+		return
+	}
+
 	link, err := h.links.Create(r.Context(), form.Slug, form.URL, user.ID, form.Title, form.Description, form.Visibility)
 	if err != nil {
-		data := LinkFormPage{BasePage: newBasePage(r, user), User: user, Form: form, Error: "That slug is already taken. Choose a different one."}
+		msg := "That slug is already taken. Choose a different one."
+		// Governing: SPEC-0002 REQ "Reserved Slugs"
+		if errors.Is(err, store.ErrSlugReserved) {
+			msg = "That slug is reserved. Choose a different one."
+		}
+		// Governing: SPEC-0002 REQ "Destination Domain Rules"
+		if errors.Is(err, store.ErrDomainNotAllowed) {
+			msg = domainRuleMessage(err)
+		}
+		data := LinkFormPage{BasePage: newBasePage(r, user), User: user, Form: form, Error: msg}
 		if isHTMX(r) {
 			renderFragment(w, "new_link_modal", data)
 			return
@@ -182,6 +253,10 @@ func (h *LinksHandler) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if len(constraints) > 0 {
+		_ = h.links.SetVariableConstraints(r.Context(), link.ID, constraints)
+	}
+
 	// Set tags if provided
 	if form.Tags != "" {
 		tagNames := parseTagNames(form.Tags)
@@ -189,6 +264,9 @@ func (h *LinksHandler) Create(w http.ResponseWriter, r *http.Request) {
 			_ = h.links.SetTags(r.Context(), link.ID, tagNames)
 		}
 	}
+	if h.policies != nil {
+		_ = h.policies.RecordViolations(r.Context(), link.ID, warnings)
+	}
 
 	// Governing: SPEC-0013 REQ "Create/Edit Link Form as HTMX Modal" — close modal + trigger list refresh
 	if isHTMX(r) {
@@ -208,14 +286,14 @@ func (h *LinksHandler) Edit(w http.ResponseWriter, r *http.Request) {
 
 	link, err := h.links.GetByID(r.Context(), id)
 	if err != nil {
-		http.NotFound(w, r)
+		renderError(w, r, http.StatusNotFound, "That item no longer exists.")
 		return
 	}
 
 	// Governing: SPEC-0002 REQ "Authorization Based on Ownership"
 	allowed, err := store.IsOwnerOrAdmin(h.owns, link.ID, user.ID, user.Role)
 	if err != nil || !allowed {
-		http.Error(w, "forbidden", http.StatusForbidden)
+		renderError(w, r, http.StatusForbidden, "You don't have permission to do that.")
 		return
 	}
 
@@ -227,14 +305,19 @@ func (h *LinksHandler) Edit(w http.ResponseWriter, r *http.Request) {
 	}
 
 	form := LinkForm{
-		URL:         link.URL,
-		Title:       link.Title,
-		Description: link.Description,
-		Tags:        strings.Join(tagNames, ", "),
-		Visibility:  link.Visibility,
-	}
-
-	data := LinkFormPage{BasePage: newBasePage(r, user), User: user, Link: link, Form: form}
+		URL:          link.URL,
+		Title:        link.Title,
+		Description:  link.Description,
+		Tags:         strings.Join(tagNames, ", "),
+		Visibility:   link.Visibility,
+		Constraints:  store.FormatVariableConstraints(link.Constraints()),
+		RedirectType: link.RedirectStatus(),
+		UTM:          store.FormatUTMParams(link.UTM()),
+		PassQuery:    link.PassQuery,
+		TeamID:       link.TeamID,
+	}
+
+	data := h.editPage(r, user, link, form, "")
 	if isHTMX(r) {
 		renderFragment(w, "edit_link_modal", data)
 		return
@@ -250,19 +333,19 @@ func (h *LinksHandler) Update(w http.ResponseWriter, r *http.Request) {
 
 	link, err := h.links.GetByID(r.Context(), id)
 	if err != nil {
-		http.NotFound(w, r)
+		renderError(w, r, http.StatusNotFound, "That item no longer exists.")
 		return
 	}
 
 	// Governing: SPEC-0002 REQ "Authorization Based on Ownership"
 	allowed, err := store.IsOwnerOrAdmin(h.owns, link.ID, user.ID, user.Role)
 	if err != nil || !allowed {
-		http.Error(w, "forbidden", http.StatusForbidden)
+		renderError(w, r, http.StatusForbidden, "You don't have permission to do that.")
 		return
 	}
 
 	if err := r.ParseForm(); err != nil {
-		http.Error(w, "bad request", http.StatusBadRequest)
+		renderError(w, r, http.StatusBadRequest, "The request could not be understood.")
 		return
 	}
 
@@ -273,17 +356,62 @@ func (h *LinksHandler) Update(w http.ResponseWriter, r *http.Request) {
 		visibility = link.Visibility
 	}
 
+	// Governing: SPEC-0002 REQ "Redirect Type"
+	redirectType := link.RedirectStatus()
+	if v := r.FormValue("redirect_type"); v != "" {
+		redirectType, _ = strconv.Atoi(v)
+	}
+
 	form := LinkForm{
-		URL:         r.FormValue("url"),
-		Title:       r.FormValue("title"),
-		Description: r.FormValue("description"),
-		Tags:        r.FormValue("tags"),
-		Visibility:  visibility,
+		URL:          r.FormValue("url"),
+		Title:        r.FormValue("title"),
+		Description:  r.FormValue("description"),
+		Tags:         r.FormValue("tags"),
+		Visibility:   visibility,
+		Constraints:  r.FormValue("constraints"),
+		RedirectType: redirectType,
+		UTM:          r.FormValue("utm"),
+		PassQuery:    r.FormValue("pass_query") != "",
+		TeamID:       r.FormValue("team_id"),
 	}
 
 	// Governing: SPEC-0010 REQ "Visibility Selector in Link Forms" — validate visibility value
 	if err := store.ValidateVisibility(form.Visibility); err != nil {
-		data := LinkFormPage{BasePage: newBasePage(r, user), User: user, Link: link, Form: form, Error: err.Error()}
+		data := h.editPage(r, user, link, form, err.Error())
+		if isHTMX(r) {
+			renderFragment(w, "edit_link_modal", data)
+			return
+		}
+		render(w, "edit.html", data)
+		return
+	}
+
+	// Governing: SPEC-0002 REQ "Redirect Type"
+	if err := store.ValidateRedirectType(form.RedirectType); err != nil {
+		data := h.editPage(r, user, link, form, err.Error())
+		if isHTMX(r) {
+			renderFragment(w, "edit_link_modal", data)
+			return
+		}
+		render(w, "edit.html", data)
+		return
+	}
+
+	// Governing: SPEC-0002 REQ "Team Ownership"
+	if team, err := h.links.GetTeam(r.Context(), form.TeamID); err != nil || (form.TeamID != "" && team == nil) {
+		data := h.editPage(r, user, link, form, "Choose a team from the list.")
+		if isHTMX(r) {
+			renderFragment(w, "edit_link_modal", data)
+			return
+		}
+		render(w, "edit.html", data)
+		return
+	}
+
+	// Governing: SPEC-0002 REQ "UTM Parameters"
+	utm, err := store.ParseUTMParams(form.UTM)
+	if err != nil {
+		data := h.editPage(r, user, link, form, err.Error())
 		if isHTMX(r) {
 			renderFragment(w, "edit_link_modal", data)
 			return
@@ -294,7 +422,41 @@ func (h *LinksHandler) Update(w http.ResponseWriter, r *http.Request) {
 
 	// Governing: SPEC-0009 REQ "Variable Placeholder Syntax", ADR-0013
 	if err := store.ValidateURLVariables(form.URL); err != nil {
-		data := LinkFormPage{BasePage: newBasePage(r, user), User: user, Link: link, Form: form, Error: err.Error()}
+		data := h.editPage(r, user, link, form, err.Error())
+		if isHTMX(r) {
+			renderFragment(w, "edit_link_modal", data)
+			return
+		}
+		render(w, "edit.html", data)
+		return
+	}
+
+	// Governing: SPEC-0009 REQ "Variable Constraints", ADR-0013
+	constraints, err := parseConstraints(form.Constraints, form.URL)
+	if err != nil {
+		data := h.editPage(r, user, link, form, err.Error())
+		if isHTMX(r) {
+			renderFragment(w, "edit_link_modal", data)
+			return
+		}
+		render(w, "edit.html", data)
+		return
+	}
+
+	// Governing: SPEC-0011 REQ "Link Lifecycle Policies"
+	owners, _ := h.owns.ListOwners(link.ID)
+	warnings, msg := h.checkPolicies(r, store.PolicySubject{
+		LinkID:      link.ID,
+		Title:       form.Title,
+		Description: form.Description,
+		Visibility:  form.Visibility,
+		TeamID:      form.TeamID,
+		Tags:        parseTagNames(form.Tags),
+		OwnerIDs:    owners,
+		CreatedAt:   link.CreatedAt,
+	})
+	if msg != "" {
+		data := h.editPage(r, user, link, form, msg)
 		if isHTMX(r) {
 			renderFragment(w, "edit_link_modal", data)
 			return
@@ -305,7 +467,12 @@ func (h *LinksHandler) Update(w http.ResponseWriter, r *http.Request) {
 
 	_, err = h.links.Update(r.Context(), id, form.URL, form.Title, form.Description, form.Visibility)
 	if err != nil {
-		data := LinkFormPage{BasePage: newBasePage(r, user), User: user, Link: link, Form: form, Error: "Update failed."}
+		msg := "Update failed."
+		// Governing: SPEC-0002 REQ "Destination Domain Rules"
+		if errors.Is(err, store.ErrDomainNotAllowed) {
+			msg = domainRuleMessage(err)
+		}
+		data := h.editPage(r, user, link, form, msg)
 		// Governing: SPEC-0013 REQ "Create/Edit Link Form as HTMX Modal" — re-render inside modal on error
 		if isHTMX(r) {
 			renderFragment(w, "edit_link_modal", data)
@@ -315,9 +482,18 @@ func (h *LinksHandler) Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	_ = h.links.SetVariableConstraints(r.Context(), id, constraints)
+	_ = h.links.SetRedirectType(r.Context(), id, form.RedirectType)
+	_ = h.links.SetUTMParams(r.Context(), id, utm)
+	_ = h.links.SetPassQuery(r.Context(), id, form.PassQuery)
+	_ = h.links.SetTeam(r.Context(), id, form.TeamID)
+
 	// Update tags
 	tagNames := parseTagNames(form.Tags)
 	_ = h.links.SetTags(r.Context(), id, tagNames)
+	if h.policies != nil {
+		_ = h.policies.RecordViolations(r.Context(), id, warnings)
+	}
 
 	// Governing: SPEC-0013 REQ "Create/Edit Link Form as HTMX Modal" — close modal + trigger list refresh
 	if isHTMX(r) {
@@ -328,7 +504,44 @@ func (h *LinksHandler) Update(w http.ResponseWriter, r *http.Request) {
 	http.Redirect(w, r, "/dashboard/links/"+id, http.StatusSeeOther)
 }
 
-// Delete removes a link. Returns 200 with empty body for HTMX row removal.
+// checkPolicies evaluates a link about to be saved against the lifecycle
+// policies. It returns the violations to record once the link is saved, or a
+// form error when a policy enforced with block refuses it.
+// Governing: SPEC-0011 REQ "Link Lifecycle Policies"
+func (h *LinksHandler) checkPolicies(r *http.Request, subj store.PolicySubject) ([]*store.PolicyViolation, string) {
+	if h.policies == nil {
+		return nil, ""
+	}
+	warnings, err := h.policies.CheckLink(r.Context(), subj)
+	if errors.Is(err, store.ErrPolicyViolation) {
+		return nil, "This " + err.Error() + "."
+	}
+	if err != nil {
+		return nil, "Could not check link policies."
+	}
+	return warnings, ""
+}
+
+// domainRuleMessage turns a store.ErrDomainNotAllowed error, which names the
+// rule a destination breaks, into a form error.
+// Governing: SPEC-0002 REQ "Destination Domain Rules"
+func domainRuleMessage(err error) string {
+	msg := err.Error()
+	return strings.ToUpper(msg[:1]) + msg[1:] + "."
+}
+
+// parseConstraints parses the constraints form field and validates it against url.
+// Governing: SPEC-0009 REQ "Variable Constraints", ADR-0013
+func parseConstraints(text, url string) (map[string]string, error) {
+	constraints, err := store.ParseVariableConstraints(text)
+	if err != nil {
+		return nil, err
+	}
+	return constraints, store.ValidateVariableConstraints(url, constraints)
+}
+
+// Delete removes a link. Returns 200 with empty body for HTMX row removal,
+// and redirects to the dashboard for the no-JavaScript form.
 // Governing: SPEC-0004 REQ "Delete Link"
 func (h *LinksHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	user := auth.UserFromContext(r.Context())
@@ -336,19 +549,25 @@ func (h *LinksHandler) Delete(w http.ResponseWriter, r *http.Request) {
 
 	link, err := h.links.GetByID(r.Context(), id)
 	if err != nil {
-		http.NotFound(w, r)
+		renderError(w, r, http.StatusNotFound, "That item no longer exists.")
 		return
 	}
 
 	// Governing: SPEC-0002 REQ "Authorization Based on Ownership"
 	allowed, err := store.IsOwnerOrAdmin(h.owns, link.ID, user.ID, user.Role)
 	if err != nil || !allowed {
-		http.Error(w, "forbidden", http.StatusForbidden)
+		renderError(w, r, http.StatusForbidden, "You don't have permission to do that.")
 		return
 	}
 
 	if err := h.links.Delete(r.Context(), id); err != nil {
-		http.Error(w, "delete failed", http.StatusInternalServerError)
+		renderError(w, r, http.StatusInternalServerError, "Delete failed.")
+		return
+	}
+
+	// Governing: SPEC-0013 REQ "No-JavaScript Fallbacks"
+	if !isHTMX(r) {
+		http.Redirect(w, r, "/dashboard", http.StatusSeeOther)
 		return
 	}
 
@@ -358,7 +577,8 @@ func (h *LinksHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	_, _ = w.Write([]byte(`<div id="toast-area" hx-swap-oob="innerHTML:#toast-area"><div class="alert alert-success"><span>Link deleted.</span></div></div>`))
 }
 
-// ConfirmDelete renders the delete confirmation modal for a link.
+// ConfirmDelete renders the delete confirmation modal for a link, or the
+// standalone confirmation page for non-HTMX requests.
 // Governing: SPEC-0013 REQ "DaisyUI Delete Confirmation Modal"
 func (h *LinksHandler) ConfirmDelete(w http.ResponseWriter, r *http.Request) {
 	user := auth.UserFromContext(r.Context())
@@ -366,14 +586,14 @@ func (h *LinksHandler) ConfirmDelete(w http.ResponseWriter, r *http.Request) {
 
 	link, err := h.links.GetByID(r.Context(), id)
 	if err != nil {
-		http.NotFound(w, r)
+		renderError(w, r, http.StatusNotFound, "That item no longer exists.")
 		return
 	}
 
 	// Governing: SPEC-0002 REQ "Authorization Based on Ownership"
 	allowed, err := store.IsOwnerOrAdmin(h.owns, link.ID, user.ID, user.Role)
 	if err != nil || !allowed {
-		http.Error(w, "forbidden", http.StatusForbidden)
+		renderError(w, r, http.StatusForbidden, "You don't have permission to do that.")
 		return
 	}
 
@@ -382,7 +602,7 @@ func (h *LinksHandler) ConfirmDelete(w http.ResponseWriter, r *http.Request) {
 		DeleteURL: "/dashboard/links/" + id,
 		Target:    "#link-" + id,
 	}
-	renderFragment(w, "confirm_delete", data)
+	renderConfirmDelete(w, r, data, "/dashboard/links/"+id)
 }
 
 // parseTagNames splits a comma-separated string into trimmed, non-empty tag names.
@@ -0,0 +1,104 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/joestump/joe-links/internal/auth"
+	"github.com/joestump/joe-links/internal/store"
+	"github.com/joestump/joe-links/internal/testutil"
+)
+
+// Governing: SPEC-0013 REQ "No-JavaScript Fallbacks"
+func TestLinks_NoJavaScript(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	owns := store.NewOwnershipStore(db)
+	ls := store.NewLinkStore(db, owns, store.NewTagStore(db))
+	us := store.NewUserStore(db)
+	ctx := context.Background()
+
+	owner, err := us.Upsert(ctx, "test", "sub1", "owner@example.com", "Owner", "")
+	if err != nil {
+		t.Fatalf("seed owner: %v", err)
+	}
+	other, err := us.Upsert(ctx, "test", "sub2", "other@example.com", "Other", "")
+	if err != nil {
+		t.Fatalf("seed user: %v", err)
+	}
+	link, err := ls.Create(ctx, "roadmap", "https://example.com/roadmap", owner.ID, "", "", "secure")
+	if err != nil {
+		t.Fatalf("seed link: %v", err)
+	}
+
+	h := NewLinksHandler(ls, owns, us, nil, nil, nil, nil, nil)
+	r := chi.NewRouter()
+	r.Get("/dashboard/links/{id}/confirm-delete", h.ConfirmDelete)
+	r.Post("/dashboard/links/{id}/delete", h.Delete)
+	r.Post("/dashboard/links/{id}/owners", h.AddOwner)
+	r.Post("/dashboard/links/{id}/owners/{uid}/delete", h.RemoveOwner)
+	r.Post("/dashboard/links/{id}/shares", h.AddShare)
+	serve := func(method, path string, form url.Values, htmx bool) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		if htmx {
+			req.Header.Set("HX-Request", "true")
+		}
+		req = req.WithContext(context.WithValue(req.Context(), auth.UserContextKey, owner))
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+	base := "/dashboard/links/" + link.ID
+	wantRedirect := func(name string, w *httptest.ResponseRecorder, to string) {
+		t.Helper()
+		if w.Code != http.StatusSeeOther || w.Header().Get("Location") != to {
+			t.Errorf("%s: status = %d, Location = %q; want 303 to %s", name, w.Code, w.Header().Get("Location"), to)
+		}
+	}
+
+	// The delete control links to a standalone page that posts the delete;
+	// HTMX still gets the modal.
+	w := serve(http.MethodGet, base+"/confirm-delete", nil, false)
+	if body := w.Body.String(); w.Code != http.StatusOK || !strings.Contains(body, `action="`+base+`/delete"`) || !strings.Contains(body, "<html") {
+		t.Errorf("confirm page: status = %d, want a full page posting to %s/delete", w.Code, base)
+	}
+	if body := serve(http.MethodGet, base+"/confirm-delete", nil, true).Body.String(); !strings.Contains(body, "hx-delete") || strings.Contains(body, "<html") {
+		t.Error("HTMX confirm: want the modal fragment")
+	}
+
+	// Owner and share forms redirect back to the link.
+	wantRedirect("add owner", serve(http.MethodPost, base+"/owners", url.Values{"email": {other.Email}}, false), base)
+	if ids, _ := owns.ListOwners(link.ID); !slices.Contains(ids, other.ID) {
+		t.Error("add owner: co-owner not added")
+	}
+	wantRedirect("remove owner", serve(http.MethodPost, base+"/owners/"+other.ID+"/delete", nil, false), base)
+	if ids, _ := owns.ListOwners(link.ID); slices.Contains(ids, other.ID) {
+		t.Error("remove owner: co-owner not removed")
+	}
+	wantRedirect("add share", serve(http.MethodPost, base+"/shares", url.Values{"email": {other.Email}}, false), base)
+	if shares, _ := ls.ListShares(ctx, link.ID); len(shares) != 1 || shares[0].UserID != other.ID {
+		t.Errorf("add share: shares = %v, want %s", shares, other.ID)
+	}
+
+	// A failed form re-renders the detail page with the error.
+	w = serve(http.MethodPost, base+"/owners", url.Values{"email": {"nobody@example.com"}}, false)
+	if body := w.Body.String(); w.Code != http.StatusUnprocessableEntity || !strings.Contains(body, "No user found with that email.") || !strings.Contains(body, "<html") {
+		t.Errorf("bad owner: status = %d, want the detail page with the error", w.Code)
+	}
+	// HTMX still gets the fragment.
+	w = serve(http.MethodPost, base+"/owners", url.Values{"email": {"nobody@example.com"}}, true)
+	if body := w.Body.String(); w.Code != http.StatusOK || !strings.Contains(body, `id="owners-section"`) || strings.Contains(body, "<html") {
+		t.Errorf("HTMX bad owner: status = %d, want the owners fragment", w.Code)
+	}
+
+	wantRedirect("delete", serve(http.MethodPost, base+"/delete", nil, false), "/dashboard")
+	if _, err := ls.GetByID(ctx, link.ID); err == nil {
+		t.Error("delete: link still exists")
+	}
+}
@@ -0,0 +1,74 @@
+// Governing: SPEC-0011 REQ "Public Link Moderation"
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/joestump/joe-links/internal/auth"
+	"github.com/joestump/joe-links/internal/store"
+)
+
+// ModerationHandler serves the admin queue of public links awaiting review.
+type ModerationHandler struct {
+	links   *store.LinkStore
+	enabled bool
+}
+
+// NewModerationHandler creates a new ModerationHandler. enabled reports
+// whether JOE_MODERATION_ENABLED is set; the queue can still be worked
+// through after it is turned off.
+func NewModerationHandler(ls *store.LinkStore, enabled bool) *ModerationHandler {
+	return &ModerationHandler{links: ls, enabled: enabled}
+}
+
+// AdminModerationPage is the template data for the moderation queue.
+type AdminModerationPage struct {
+	BasePage
+	Links   []*store.AdminLink
+	Enabled bool
+}
+
+// Index renders the links awaiting review, oldest first.
+// GET /admin/moderation
+func (h *ModerationHandler) Index(w http.ResponseWriter, r *http.Request) {
+	user := auth.UserFromContext(r.Context())
+	links, err := h.links.ListPendingReview(r.Context())
+	if err != nil {
+		renderError(w, r, http.StatusInternalServerError, "Could not load the moderation queue.")
+		return
+	}
+	render(w, "admin/moderation.html", AdminModerationPage{
+		BasePage: newBasePage(r, user),
+		Links:    links,
+		Enabled:  h.enabled,
+	})
+}
+
+// Approve lists a pending link publicly. Returns the row removal and a toast.
+// POST /admin/moderation/{id}/approve
+func (h *ModerationHandler) Approve(w http.ResponseWriter, r *http.Request) {
+	h.review(w, r, h.links.ApproveLink, "Link approved.")
+}
+
+// Reject makes a pending link private. Returns the row removal and a toast.
+// POST /admin/moderation/{id}/reject
+func (h *ModerationHandler) Reject(w http.ResponseWriter, r *http.Request) {
+	h.review(w, r, h.links.RejectLink, "Link rejected and made private.")
+}
+
+func (h *ModerationHandler) review(w http.ResponseWriter, r *http.Request, apply func(context.Context, string) error, msg string) {
+	if err := apply(r.Context(), chi.URLParam(r, "id")); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			renderError(w, r, http.StatusNotFound, "That link is no longer awaiting review.")
+			return
+		}
+		renderError(w, r, http.StatusInternalServerError, "Review failed.")
+		return
+	}
+	w.Header().Set("Content-Type", "text/html")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte(`<div id="toast-area" hx-swap-oob="innerHTML:#toast-area"><div class="alert alert-success"><span>` + msg + `</span></div></div>`))
+}
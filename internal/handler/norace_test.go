@@ -0,0 +1,6 @@
+//go:build !race
+
+package handler
+
+// raceEnabled reports whether the race detector is on.
+const raceEnabled = false
@@ -0,0 +1,64 @@
+// Governing: SPEC-0001 REQ "Email Notifications"
+package handler
+
+import (
+	"net/http"
+
+	"github.com/joestump/joe-links/internal/auth"
+	"github.com/joestump/joe-links/internal/store"
+)
+
+// NotificationsPage is the template data for the notification settings page.
+type NotificationsPage struct {
+	BasePage
+	Enabled     bool // the user receives notification emails
+	MailEnabled bool // the server has SMTP configured
+	Flash       *Flash
+}
+
+// NotificationsHandler serves the user's notification email preference.
+type NotificationsHandler struct {
+	users       *store.UserStore
+	mailEnabled bool
+}
+
+// NewNotificationsHandler creates a new NotificationsHandler. mailEnabled
+// reports whether the server sends email at all.
+func NewNotificationsHandler(us *store.UserStore, mailEnabled bool) *NotificationsHandler {
+	return &NotificationsHandler{users: us, mailEnabled: mailEnabled}
+}
+
+// Show renders the notification settings page.
+// GET /dashboard/settings/notifications
+func (h *NotificationsHandler) Show(w http.ResponseWriter, r *http.Request) {
+	user := auth.UserFromContext(r.Context())
+	render(w, "notifications.html", NotificationsPage{
+		BasePage:    newBasePage(r, user),
+		Enabled:     user.EmailNotifications,
+		MailEnabled: h.mailEnabled,
+	})
+}
+
+// Update saves the preference from the "enabled" checkbox and re-renders the form.
+// PUT /dashboard/settings/notifications
+func (h *NotificationsHandler) Update(w http.ResponseWriter, r *http.Request) {
+	user := auth.UserFromContext(r.Context())
+	if err := r.ParseForm(); err != nil {
+		renderError(w, r, http.StatusBadRequest, "Invalid form data.")
+		return
+	}
+	enabled := r.FormValue("enabled") == "on"
+	if err := h.users.SetEmailNotifications(r.Context(), user.ID, enabled); err != nil {
+		renderError(w, r, http.StatusInternalServerError, "Could not save your preference.")
+		return
+	}
+	msg := "You will no longer receive notification emails."
+	if enabled {
+		msg = "Notification emails are on."
+	}
+	renderPageFragment(w, "settings/notifications.html", "notification_form", NotificationsPage{
+		Enabled:     enabled,
+		MailEnabled: h.mailEnabled,
+		Flash:       &Flash{Type: "success", Message: msg},
+	})
+}
@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/joestump/joe-links/internal/auth"
+	"github.com/joestump/joe-links/internal/store"
+	"github.com/joestump/joe-links/internal/testutil"
+)
+
+// Governing: SPEC-0001 REQ "Email Notifications"
+func TestNotifications_OptOut(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	us := store.NewUserStore(db)
+	ctx := context.Background()
+	user, err := us.Upsert(ctx, "test", "sub1", "user@example.com", "User", "")
+	if err != nil {
+		t.Fatalf("seed user: %v", err)
+	}
+	if !user.EmailNotifications {
+		t.Fatal("new users should receive notification emails")
+	}
+
+	h := NewNotificationsHandler(us, true)
+	put := func(form url.Values) string {
+		req := httptest.NewRequest(http.MethodPut, "/dashboard/settings/notifications", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req = req.WithContext(context.WithValue(req.Context(), auth.UserContextKey, user))
+		w := httptest.NewRecorder()
+		h.Update(w, req)
+		return w.Body.String()
+	}
+
+	// An unchecked checkbox submits no value.
+	if body := put(url.Values{}); !strings.Contains(body, "no longer receive") {
+		t.Errorf("opt-out response:\n%s", body)
+	}
+	if u, _ := us.GetByID(ctx, user.ID); u.EmailNotifications {
+		t.Error("preference not saved as off")
+	}
+	if body := put(url.Values{"enabled": {"on"}}); !strings.Contains(body, "checked") {
+		t.Errorf("opt-in response:\n%s", body)
+	}
+	if u, _ := us.GetByID(ctx, user.ID); !u.EmailNotifications {
+		t.Error("preference not saved as on")
+	}
+}
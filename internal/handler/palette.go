@@ -0,0 +1,73 @@
+// Governing: SPEC-0004 REQ "Command Palette"
+package handler
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/joestump/joe-links/internal/auth"
+	"github.com/joestump/joe-links/internal/store"
+)
+
+// PaletteAction is a command palette entry that navigates somewhere other
+// than a link's detail page.
+type PaletteAction struct {
+	Label string
+	URL   string
+}
+
+// PaletteResults is the template data for the command palette results list.
+type PaletteResults struct {
+	Query   string
+	Matches []*store.Link // links whose slug matches Query
+	Recent  []*store.Link // the user's recently edited links, shown before typing
+	Actions []PaletteAction
+}
+
+// paletteActions are the fixed palette commands, filtered by the query.
+var paletteActions = []PaletteAction{
+	{Label: "New link", URL: "/dashboard/links/new"},
+	{Label: "Browse tags", URL: "/dashboard/tags"},
+	{Label: "Browse public links", URL: "/links"},
+	{Label: "API tokens", URL: "/dashboard/settings/tokens"},
+}
+
+// Palette renders the Ctrl+K command palette results for ?q=: links whose
+// slug matches, or the user's recent links when q is empty, followed by
+// actions. It only runs indexed slug lookups so it can answer every keystroke.
+// GET /dashboard/palette
+func (h *DashboardHandler) Palette(w http.ResponseWriter, r *http.Request) {
+	user := auth.UserFromContext(r.Context())
+	q := strings.TrimSpace(r.URL.Query().Get("q"))
+	data := PaletteResults{Query: q}
+
+	var err error
+	switch {
+	case q == "":
+		data.Recent, err = h.links.ListRecentByOwner(r.Context(), user.ID, store.PaletteLimit)
+	case user.IsAdmin():
+		data.Matches, err = h.links.MatchSlugsAll(r.Context(), q, store.PaletteLimit)
+	default:
+		data.Matches, err = h.links.MatchSlugsByOwner(r.Context(), user.ID, q, store.PaletteLimit)
+	}
+	if err != nil {
+		renderError(w, r, http.StatusInternalServerError, "Could not load links.")
+		return
+	}
+
+	slug := strings.TrimPrefix(strings.ToLower(q), "go/")
+	if len(data.Matches) > 0 {
+		top := data.Matches[0]
+		data.Actions = append(data.Actions, PaletteAction{Label: "View stats for go/" + top.Slug, URL: "/dashboard/links/" + top.ID + "/stats"})
+	}
+	if slug != "" && store.ValidateSlugFormat(slug) == nil && (len(data.Matches) == 0 || data.Matches[0].Slug != slug) {
+		data.Actions = append(data.Actions, PaletteAction{Label: "Create go/" + slug, URL: "/dashboard/links/new?slug=" + url.QueryEscape(slug)})
+	}
+	for _, a := range paletteActions {
+		if q == "" || strings.Contains(strings.ToLower(a.Label), strings.ToLower(q)) {
+			data.Actions = append(data.Actions, a)
+		}
+	}
+	renderFragment(w, "palette_results", data)
+}
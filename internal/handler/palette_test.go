@@ -0,0 +1,74 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/joestump/joe-links/internal/auth"
+	"github.com/joestump/joe-links/internal/store"
+	"github.com/joestump/joe-links/internal/testutil"
+)
+
+// Governing: SPEC-0004 REQ "Command Palette"
+func TestDashboard_Palette(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	owns := store.NewOwnershipStore(db)
+	ts := store.NewTagStore(db)
+	ls := store.NewLinkStore(db, owns, ts)
+	us := store.NewUserStore(db)
+	ctx := context.Background()
+
+	user, err := us.Upsert(ctx, "test", "sub1", "user@example.com", "User", "")
+	if err != nil {
+		t.Fatalf("seed user: %v", err)
+	}
+	other, err := us.Upsert(ctx, "test", "sub2", "other@example.com", "Other", "")
+	if err != nil {
+		t.Fatalf("seed user: %v", err)
+	}
+	for _, slug := range []string{"deploy-web", "deploy", "redeploy"} {
+		if _, err := ls.Create(ctx, slug, "https://example.com/"+slug, user.ID, "", "", "public"); err != nil {
+			t.Fatalf("seed link: %v", err)
+		}
+	}
+	if _, err := ls.Create(ctx, "deploy-secret", "https://example.com/x", other.ID, "", "", "public"); err != nil {
+		t.Fatalf("seed link: %v", err)
+	}
+
+	h := NewDashboardHandler(ls, ts, nil, nil, nil, nil)
+	get := func(q string) string {
+		req := httptest.NewRequest(http.MethodGet, "/dashboard/palette?q="+q, nil)
+		req = req.WithContext(context.WithValue(req.Context(), auth.UserContextKey, user))
+		w := httptest.NewRecorder()
+		h.Palette(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("q=%q: status %d", q, w.Code)
+		}
+		return w.Body.String()
+	}
+
+	body := get("go/deploy")
+	first, second, third := strings.Index(body, "go/deploy<"), strings.Index(body, "go/deploy-web<"), strings.Index(body, "go/redeploy<")
+	if first < 0 || second < first || third < second {
+		t.Errorf("matches not ranked prefix-first, shortest first:\n%s", body)
+	}
+	if strings.Contains(body, "deploy-secret") {
+		t.Error("palette listed another user's link")
+	}
+	if !strings.Contains(body, "View stats for go/deploy") || strings.Contains(body, "Create go/deploy") {
+		t.Errorf("unexpected actions for an exact match:\n%s", body)
+	}
+
+	if body := get("wiki"); !strings.Contains(body, "Create go/wiki") || !strings.Contains(body, `href="/dashboard/links/new?slug=wiki"`) {
+		t.Errorf("no create action for an unused slug:\n%s", body)
+	}
+	if body := get(""); !strings.Contains(body, "Recent") || !strings.Contains(body, "New link") {
+		t.Errorf("empty query did not show recent links and actions:\n%s", body)
+	}
+	if body := get("tags"); !strings.Contains(body, "Browse tags") || strings.Contains(body, "New link") {
+		t.Errorf("actions not filtered by query:\n%s", body)
+	}
+}
@@ -0,0 +1,110 @@
+// Governing: SPEC-0001 REQ "WebAuthn Step-Up"
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/joestump/joe-links/internal/auth"
+	"github.com/joestump/joe-links/internal/store"
+)
+
+// PasskeysPage is the template data for the passkey settings page.
+type PasskeysPage struct {
+	BasePage
+	Passkeys []*store.WebAuthnCredential
+	Flash    *Flash
+}
+
+// PasskeysHandler lets users register and remove passkeys.
+type PasskeysHandler struct {
+	webauthn *auth.WebAuthn
+	creds    *store.WebAuthnStore
+}
+
+// NewPasskeysHandler creates a new PasskeysHandler.
+func NewPasskeysHandler(wa *auth.WebAuthn, creds *store.WebAuthnStore) *PasskeysHandler {
+	return &PasskeysHandler{webauthn: wa, creds: creds}
+}
+
+// Index lists the user's passkeys.
+// GET /dashboard/settings/passkeys
+func (h *PasskeysHandler) Index(w http.ResponseWriter, r *http.Request) {
+	h.render(w, r, nil)
+}
+
+// RegisterBegin returns the options for navigator.credentials.create.
+// POST /dashboard/settings/passkeys/register/begin
+func (h *PasskeysHandler) RegisterBegin(w http.ResponseWriter, r *http.Request) {
+	user := auth.UserFromContext(r.Context())
+	opts, err := h.webauthn.BeginRegistration(r, user, newBasePage(r, user).Brand.Name())
+	if err != nil {
+		log.Printf("passkey register begin: %v", err)
+		writeWebAuthnJSON(w, http.StatusInternalServerError, map[string]string{"error": "Something went wrong."})
+		return
+	}
+	writeWebAuthnJSON(w, http.StatusOK, map[string]any{"publicKey": opts})
+}
+
+// RegisterFinish verifies and saves a new passkey.
+// POST /dashboard/settings/passkeys/register/finish
+func (h *PasskeysHandler) RegisterFinish(w http.ResponseWriter, r *http.Request) {
+	user := auth.UserFromContext(r.Context())
+	var body struct {
+		Name       string                    `json:"name"`
+		Credential auth.RegistrationResponse `json:"credential"`
+	}
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 64<<10)).Decode(&body); err != nil {
+		writeWebAuthnJSON(w, http.StatusBadRequest, map[string]string{"error": "Invalid response."})
+		return
+	}
+	name := strings.TrimSpace(body.Name)
+	if name == "" {
+		name = "Passkey"
+	}
+	if _, err := h.webauthn.FinishRegistration(r, user, name, body.Credential); err != nil {
+		if auth.IsVerificationError(err) {
+			writeWebAuthnJSON(w, http.StatusBadRequest, map[string]string{"error": "The passkey could not be verified."})
+			return
+		}
+		log.Printf("passkey register finish: %v", err)
+		writeWebAuthnJSON(w, http.StatusInternalServerError, map[string]string{"error": "Something went wrong."})
+		return
+	}
+	writeWebAuthnJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// Delete removes one of the user's passkeys.
+// POST /dashboard/settings/passkeys/{id}/delete
+func (h *PasskeysHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	user := auth.UserFromContext(r.Context())
+	err := h.creds.Delete(r.Context(), user.ID, chi.URLParam(r, "id"))
+	if errors.Is(err, store.ErrNotFound) {
+		renderError(w, r, http.StatusNotFound, "Passkey not found.")
+		return
+	}
+	if err != nil {
+		renderError(w, r, http.StatusInternalServerError, "Could not remove the passkey.")
+		return
+	}
+	h.render(w, r, &Flash{Type: "success", Message: "Passkey removed."})
+}
+
+func (h *PasskeysHandler) render(w http.ResponseWriter, r *http.Request, flash *Flash) {
+	user := auth.UserFromContext(r.Context())
+	passkeys, err := h.creds.ListByUser(r.Context(), user.ID)
+	if err != nil {
+		renderError(w, r, http.StatusInternalServerError, "Could not load your passkeys.")
+		return
+	}
+	data := PasskeysPage{BasePage: newBasePage(r, user), Passkeys: passkeys}
+	if flash != nil {
+		flash.Message = data.T(flash.Message)
+		data.Flash = flash
+	}
+	render(w, "settings/passkeys.html", data)
+}
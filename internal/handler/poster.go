@@ -0,0 +1,60 @@
+// Governing: SPEC-0001 REQ "Link Poster"
+package handler
+
+import (
+	"html/template"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/joestump/joe-links/internal/auth"
+	"github.com/joestump/joe-links/internal/qr"
+	"github.com/joestump/joe-links/internal/store"
+)
+
+// LinkPosterPage is the template data for the printable link poster.
+// Governing: SPEC-0001 REQ "Link Poster"
+type LinkPosterPage struct {
+	BasePage
+	User     *store.User
+	Link     *store.Link
+	ShortURL string        // absolute URL the QR code encodes
+	QR       template.HTML // inline SVG generated by internal/qr
+}
+
+// Poster handles GET /dashboard/links/{id}/poster. It renders a one-page
+// poster with the short link, a QR code for it, and the link's title and
+// description, styled so the browser prints (or saves as PDF) only the poster.
+// Governing: SPEC-0001 REQ "Link Poster"
+func (h *LinksHandler) Poster(w http.ResponseWriter, r *http.Request) {
+	user := auth.UserFromContext(r.Context())
+	link, err := h.links.GetByID(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		renderError(w, r, http.StatusNotFound, "That item no longer exists.")
+		return
+	}
+	allowed, err := store.IsOwnerOrAdmin(h.owns, link.ID, user.ID, user.Role)
+	if err != nil || !allowed {
+		renderError(w, r, http.StatusForbidden, "You don't have permission to do that.")
+		return
+	}
+
+	base := newBasePage(r, user)
+	shortURL := base.SiteURL + "/" + link.Slug
+	code, err := qr.Encode(shortURL)
+	if err != nil {
+		renderError(w, r, http.StatusInternalServerError, "Could not generate the QR code.")
+		return
+	}
+	data := LinkPosterPage{
+		BasePage: base,
+		User:     user,
+		Link:     link,
+		ShortURL: shortURL,
+		QR:       template.HTML(code.SVG()), // generated markup, no user input
+	}
+	if isHTMX(r) {
+		renderPageFragment(w, "links/poster.html", "content", data)
+		return
+	}
+	render(w, "links/poster.html", data)
+}
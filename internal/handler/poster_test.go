@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/joestump/joe-links/internal/auth"
+	"github.com/joestump/joe-links/internal/store"
+	"github.com/joestump/joe-links/internal/testutil"
+)
+
+// Governing: SPEC-0001 REQ "Link Poster"
+func TestPoster(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	owns := store.NewOwnershipStore(db)
+	ls := store.NewLinkStore(db, owns, store.NewTagStore(db))
+	us := store.NewUserStore(db)
+	ctx := context.Background()
+
+	owner, err := us.Upsert(ctx, "test", "sub1", "owner@example.com", "Owner", "")
+	if err != nil {
+		t.Fatalf("seed owner: %v", err)
+	}
+	other, err := us.Upsert(ctx, "test", "sub2", "other@example.com", "Other", "")
+	if err != nil {
+		t.Fatalf("seed user: %v", err)
+	}
+	link, err := ls.Create(ctx, "offsite", "https://wiki.example.com/offsite", owner.ID, "Offsite agenda", "Schedule, rooms, and dinner plans", "public")
+	if err != nil {
+		t.Fatalf("seed link: %v", err)
+	}
+
+	r := chi.NewRouter()
+	r.Get("/dashboard/links/{id}/poster", NewLinksHandler(ls, owns, us, nil, nil, nil, nil, nil).Poster)
+	poster := func(user *store.User) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "http://go.example.com/dashboard/links/"+link.ID+"/poster", nil)
+		req = req.WithContext(context.WithValue(req.Context(), auth.UserContextKey, user))
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	w := poster(owner)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+	}
+	body := w.Body.String()
+	for _, want := range []string{"go/offsite", "http://go.example.com/offsite", "Offsite agenda", "Schedule, rooms, and dinner plans", `<svg xmlns="http://www.w3.org/2000/svg"`} {
+		if !strings.Contains(body, want) {
+			t.Errorf("poster missing %q", want)
+		}
+	}
+
+	if w := poster(other); w.Code != http.StatusForbidden {
+		t.Errorf("non-owner: status = %d, want %d", w.Code, http.StatusForbidden)
+	}
+}
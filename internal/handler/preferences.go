@@ -0,0 +1,117 @@
+// Governing: SPEC-0004 REQ "Internationalization"
+package handler
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/joestump/joe-links/internal/auth"
+	"github.com/joestump/joe-links/internal/i18n"
+	"github.com/joestump/joe-links/internal/store"
+)
+
+// uiPrefs is set at startup from Deps.PreferenceStore; nil ignores users'
+// saved language and theme and follows the browser alone.
+var uiPrefs *store.PreferenceStore
+
+// savedPrefs returns the signed-in user's saved preferences, or nil for
+// anonymous visitors and when they cannot be loaded.
+func savedPrefs(r *http.Request, user *store.User) map[string]string {
+	if user == nil || uiPrefs == nil {
+		return nil
+	}
+	prefs, err := uiPrefs.All(r.Context(), user.ID)
+	if err != nil {
+		log.Printf("preferences for %s: %v", user.ID, err)
+		return nil
+	}
+	return prefs
+}
+
+// requestLocale picks the locale for r: the user's saved language, else the
+// best match for the browser's Accept-Language, else English.
+// Governing: SPEC-0004 REQ "Internationalization"
+func requestLocale(r *http.Request, prefs map[string]string) string {
+	if lang := prefs[store.PrefLanguage]; i18n.Supported(lang) {
+		return lang
+	}
+	return i18n.Match(r.Header.Get("Accept-Language"))
+}
+
+// PreferencesPage is the template data for the user's preferences page.
+type PreferencesPage struct {
+	BasePage
+	Language string // saved language, "" to follow the browser
+	Locales  []i18n.Locale
+	// SavedTheme is the saved theme, "" to follow the OS preference.
+	// Governing: SPEC-0003 REQ "Theme Persistence via Cookie"
+	SavedTheme string
+	Flash      *Flash
+}
+
+// PreferencesHandler serves the signed-in user's UI preferences.
+type PreferencesHandler struct {
+	prefs *store.PreferenceStore
+}
+
+// NewPreferencesHandler creates a new PreferencesHandler.
+func NewPreferencesHandler(ps *store.PreferenceStore) *PreferencesHandler {
+	return &PreferencesHandler{prefs: ps}
+}
+
+// Show renders the preferences form.
+// GET /dashboard/settings/preferences
+func (h *PreferencesHandler) Show(w http.ResponseWriter, r *http.Request) {
+	h.render(w, r, nil)
+}
+
+// Update saves the preferences and re-renders the page, already in the
+// newly chosen language and theme.
+// POST /dashboard/settings/preferences
+func (h *PreferencesHandler) Update(w http.ResponseWriter, r *http.Request) {
+	user := auth.UserFromContext(r.Context())
+	if err := r.ParseForm(); err != nil {
+		renderError(w, r, http.StatusBadRequest, "Invalid form data.")
+		return
+	}
+	lang := r.FormValue("language")
+	if lang != "" && !i18n.Supported(lang) {
+		renderError(w, r, http.StatusBadRequest, "Unsupported language.")
+		return
+	}
+	theme := r.FormValue("theme")
+	if theme != "" && !validTheme(theme) {
+		renderError(w, r, http.StatusBadRequest, "Invalid theme.")
+		return
+	}
+	// Empty values follow the browser again; the cookie is cleared with the
+	// theme so the OS preference applies on this device too.
+	for name, value := range map[string]string{store.PrefLanguage: lang, store.PrefTheme: theme} {
+		if err := h.prefs.Set(r.Context(), user.ID, name, value); err != nil {
+			renderError(w, r, http.StatusInternalServerError, "Could not save your preference.")
+			return
+		}
+	}
+	setThemeCookie(w, theme)
+	h.render(w, r, &Flash{Type: "success", Message: "Preferences saved."})
+}
+
+func (h *PreferencesHandler) render(w http.ResponseWriter, r *http.Request, flash *Flash) {
+	user := auth.UserFromContext(r.Context())
+	prefs, err := h.prefs.All(r.Context(), user.ID)
+	if err != nil {
+		renderError(w, r, http.StatusInternalServerError, "Could not load your preferences.")
+		return
+	}
+	data := PreferencesPage{
+		BasePage:   newBasePage(r, user),
+		Language:   prefs[store.PrefLanguage],
+		Locales:    i18n.Locales(),
+		SavedTheme: prefs[store.PrefTheme],
+	}
+	if flash != nil {
+		flash.Message = data.T(flash.Message)
+		data.Flash = flash
+	}
+	render(w, "settings/preferences.html", data)
+}
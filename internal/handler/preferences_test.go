@@ -0,0 +1,150 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/joestump/joe-links/internal/auth"
+	"github.com/joestump/joe-links/internal/store"
+	"github.com/joestump/joe-links/internal/testutil"
+)
+
+// Governing: SPEC-0004 REQ "Internationalization"
+func TestPreferences_Language(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	ps := store.NewPreferenceStore(db)
+	user, err := store.NewUserStore(db).Upsert(context.Background(), "test", "sub1", "u@example.com", "User", "")
+	if err != nil {
+		t.Fatalf("seed user: %v", err)
+	}
+	uiPrefs = ps
+	t.Cleanup(func() { uiPrefs = nil })
+
+	h := NewPreferencesHandler(ps)
+	r := chi.NewRouter()
+	r.Get("/dashboard/settings/preferences", h.Show)
+	r.Post("/dashboard/settings/preferences", h.Update)
+	serve := func(req *http.Request, acceptLanguage string) *httptest.ResponseRecorder {
+		req = req.WithContext(context.WithValue(req.Context(), auth.UserContextKey, user))
+		req.Header.Set("Accept-Language", acceptLanguage)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+	get := func(acceptLanguage string) string {
+		return serve(httptest.NewRequest(http.MethodGet, "/dashboard/settings/preferences", nil), acceptLanguage).Body.String()
+	}
+	post := func(lang string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/dashboard/settings/preferences", strings.NewReader(url.Values{"language": {lang}}.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		return serve(req, "")
+	}
+
+	// With nothing saved the browser's language wins.
+	if body := get("en-US"); !strings.Contains(body, `<html lang="en"`) || !strings.Contains(body, "Sign out") {
+		t.Error("English page not rendered for en-US")
+	}
+	if body := get("de-DE,de;q=0.9"); !strings.Contains(body, `<html lang="de"`) || !strings.Contains(body, "Abmelden") {
+		t.Error("German page not rendered for de-DE")
+	}
+
+	// A saved language overrides Accept-Language, and the save itself
+	// renders in it.
+	w := post("de")
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), "Abmelden") {
+		t.Fatalf("save: status = %d, German = %v", w.Code, strings.Contains(w.Body.String(), "Abmelden"))
+	}
+	if body := get("en-US"); !strings.Contains(body, "Abmelden") {
+		t.Error("saved language ignored")
+	}
+
+	// Clearing it follows the browser again.
+	if w := post(""); w.Code != http.StatusOK {
+		t.Fatalf("clear: status = %d", w.Code)
+	}
+	if body := get("en-US"); !strings.Contains(body, "Sign out") {
+		t.Error("cleared language still applied")
+	}
+
+	if w := post("xx"); w.Code != http.StatusBadRequest {
+		t.Errorf("unsupported language: status = %d, want 400", w.Code)
+	}
+}
+
+// Governing: SPEC-0003 REQ "Theme Persistence via Cookie"
+func TestPreferences_Theme(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	ps := store.NewPreferenceStore(db)
+	user, err := store.NewUserStore(db).Upsert(context.Background(), "test", "sub1", "u@example.com", "User", "")
+	if err != nil {
+		t.Fatalf("seed user: %v", err)
+	}
+	uiPrefs = ps
+	t.Cleanup(func() { uiPrefs = nil })
+
+	theme := NewThemeHandler()
+	theme.prefs = ps
+	h := NewPreferencesHandler(ps)
+	r := chi.NewRouter()
+	r.Get("/dashboard/settings/preferences", h.Show)
+	r.Post("/dashboard/settings/preferences", h.Update)
+	r.Post("/dashboard/theme", theme.Toggle)
+	serve := func(req *http.Request, cookie string) *httptest.ResponseRecorder {
+		req = req.WithContext(context.WithValue(req.Context(), auth.UserContextKey, user))
+		if cookie != "" {
+			req.AddCookie(&http.Cookie{Name: "theme", Value: cookie})
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+	get := func(cookie string) string {
+		return serve(httptest.NewRequest(http.MethodGet, "/dashboard/settings/preferences", nil), cookie).Body.String()
+	}
+	post := func(path, theme string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(url.Values{"theme": {theme}}.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		return serve(req, "")
+	}
+
+	// With nothing saved the cookie wins.
+	if body := get("joe-dark"); !strings.Contains(body, `data-theme="joe-dark"`) {
+		t.Error("cookie theme not rendered")
+	}
+
+	// Toggling while signed in saves the theme, so a device without the
+	// cookie, or with a stale one, gets it too.
+	if w := post("/dashboard/theme", "joe-light"); w.Code != http.StatusOK {
+		t.Fatalf("toggle: status = %d", w.Code)
+	}
+	if got, err := ps.Get(context.Background(), user.ID, store.PrefTheme); err != nil || got != "joe-light" {
+		t.Fatalf("saved theme = %q, %v; want joe-light", got, err)
+	}
+	if body := get(""); !strings.Contains(body, `data-theme="joe-light"`) {
+		t.Error("saved theme not rendered without cookie")
+	}
+	if body := get("joe-dark"); !strings.Contains(body, `data-theme="joe-light"`) {
+		t.Error("stale cookie overrode saved theme")
+	}
+
+	// Choosing automatic on the preferences page clears both.
+	w := post("/dashboard/settings/preferences", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("clear: status = %d", w.Code)
+	}
+	if c := w.Result().Cookies(); len(c) != 1 || c[0].Name != "theme" || c[0].MaxAge >= 0 {
+		t.Errorf("clear: cookies = %v, want theme cookie deleted", c)
+	}
+	if body := get(""); strings.Contains(body, `data-theme="joe-`) {
+		t.Error("cleared theme still rendered")
+	}
+
+	if w := post("/dashboard/settings/preferences", "joe-neon"); w.Code != http.StatusBadRequest {
+		t.Errorf("invalid theme: status = %d, want 400", w.Code)
+	}
+}
@@ -1,9 +1,10 @@
-// Governing: SPEC-0012 REQ "User Profile Page (GET /u/{display_name_slug})"
+// Governing: SPEC-0012 REQ "User Profile Page (GET /u/{display_name_slug})", REQ "Profile Username Alias", REQ "Profile Statistics"
 package handler
 
 import (
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/joestump/joe-links/internal/auth"
@@ -18,6 +19,7 @@ type ProfilePage struct {
 	BasePage
 	ProfileUser *store.User
 	Links       []store.PublicLink
+	Tags        []*store.TagWithCount // Governing: SPEC-0012 REQ "Profile Statistics"
 	Page        int
 	TotalPages  int
 	TotalLinks  int
@@ -41,20 +43,14 @@ func NewProfileHandler(us *store.UserStore, ls *store.LinkStore) *ProfileHandler
 func (h *ProfileHandler) Show(w http.ResponseWriter, r *http.Request) {
 	slug := chi.URLParam(r, "displayNameSlug")
 
-	profileUser, err := h.users.GetByDisplayNameSlug(r.Context(), slug)
+	// Governing: SPEC-0012 REQ "Profile Username Alias" — /u/@name redirects to the canonical URL
+	profileUser, err := h.users.GetByProfileRef(r.Context(), slug)
 	if err != nil {
-		if err == store.ErrNotFound {
-			viewer := auth.UserFromContext(r.Context())
-			w.WriteHeader(http.StatusNotFound)
-			data := notFoundPage{BasePage: newBasePage(r, viewer), User: viewer, Slug: "u/" + slug}
-			if isHTMX(r) {
-				renderPageFragment(w, "404.html", "content", data)
-				return
-			}
-			render(w, "404.html", data)
-			return
-		}
-		http.Error(w, "internal error", http.StatusInternalServerError)
+		h.renderLookupError(w, r, "u/"+slug, err)
+		return
+	}
+	if strings.HasPrefix(slug, "@") {
+		http.Redirect(w, r, "/u/"+profileUser.DisplayNameSlug, http.StatusFound)
 		return
 	}
 
@@ -67,7 +63,14 @@ func (h *ProfileHandler) Show(w http.ResponseWriter, r *http.Request) {
 
 	links, total, err := h.links.ListPublicByOwner(r.Context(), profileUser.ID, page, profilePageSize)
 	if err != nil {
-		http.Error(w, "internal error", http.StatusInternalServerError)
+		renderError(w, r, http.StatusInternalServerError, "Something went wrong.")
+		return
+	}
+
+	// Governing: SPEC-0012 REQ "Profile Statistics"
+	tags, err := h.links.ListPublicTagsByOwner(r.Context(), profileUser.ID)
+	if err != nil {
+		renderError(w, r, http.StatusInternalServerError, "Something went wrong.")
 		return
 	}
 
@@ -81,6 +84,7 @@ func (h *ProfileHandler) Show(w http.ResponseWriter, r *http.Request) {
 		BasePage:    newBasePage(r, viewer),
 		ProfileUser: profileUser,
 		Links:       links,
+		Tags:        tags,
 		Page:        page,
 		TotalPages:  totalPages,
 		TotalLinks:  total,
@@ -94,3 +98,27 @@ func (h *ProfileHandler) Show(w http.ResponseWriter, r *http.Request) {
 	}
 	render(w, "profile.html", data)
 }
+
+// Alias redirects GET /@{username} to the user's profile page.
+// Governing: SPEC-0012 REQ "Profile Username Alias"
+func (h *ProfileHandler) Alias(w http.ResponseWriter, r *http.Request) {
+	username := chi.URLParam(r, "username")
+	profileUser, err := h.users.GetByProfileRef(r.Context(), "@"+username)
+	if err != nil {
+		h.renderLookupError(w, r, "@"+username, err)
+		return
+	}
+	http.Redirect(w, r, "/u/"+profileUser.DisplayNameSlug, http.StatusFound)
+}
+
+// renderLookupError renders the 404 page for an unknown profile, or a 500 for
+// any other lookup failure.
+func (h *ProfileHandler) renderLookupError(w http.ResponseWriter, r *http.Request, path string, err error) {
+	if err == store.ErrNotFound {
+		viewer := auth.UserFromContext(r.Context())
+		data := notFoundPage{BasePage: newBasePage(r, viewer), User: viewer, Slug: path}
+		renderPage(w, r, http.StatusNotFound, "404.html", data)
+		return
+	}
+	renderError(w, r, http.StatusInternalServerError, "Something went wrong.")
+}
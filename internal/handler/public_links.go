@@ -31,6 +31,8 @@ type PublicLinksPage struct {
 	ShowTags       bool
 	ShowVisibility bool
 	ShowActions    bool
+	ShowContact    bool
+	Sort           *ListSort // unused; present for link_list partial compatibility
 }
 
 // PublicLinksHandler serves the public link browser at GET /links.
@@ -65,7 +67,7 @@ func (h *PublicLinksHandler) Index(w http.ResponseWriter, r *http.Request) {
 
 	links, total, err := h.links.ListPublic(r.Context(), currentUserID, query, page, defaultPageSize)
 	if err != nil {
-		http.Error(w, "could not load links", http.StatusInternalServerError)
+		renderError(w, r, http.StatusInternalServerError, "Could not load links.")
 		return
 	}
 
@@ -88,6 +90,7 @@ func (h *PublicLinksHandler) Index(w http.ResponseWriter, r *http.Request) {
 		ShowOwner:      true,
 		ShowTags:       true,
 		ShowVisibility: true,
+		ShowContact:    true,
 	}
 
 	if isHTMX(r) {
@@ -0,0 +1,113 @@
+// Governing: SPEC-0016 REQ "Referrer Exclusion"
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/joestump/joe-links/internal/auth"
+	"github.com/joestump/joe-links/internal/store"
+)
+
+// ReferrerExclusionsHandler serves the admin excluded referrer screens.
+type ReferrerExclusionsHandler struct {
+	clicks *store.ClickStore
+}
+
+// NewReferrerExclusionsHandler creates a new ReferrerExclusionsHandler.
+func NewReferrerExclusionsHandler(cs *store.ClickStore) *ReferrerExclusionsHandler {
+	return &ReferrerExclusionsHandler{clicks: cs}
+}
+
+// AdminReferrersPage is the template data for the excluded referrer list.
+type AdminReferrersPage struct {
+	BasePage
+	Referrers []*store.ExcludedReferrer
+	Error     string
+}
+
+// Index renders the excluded referrer list.
+// GET /admin/referrers
+func (h *ReferrerExclusionsHandler) Index(w http.ResponseWriter, r *http.Request) {
+	h.renderList(w, r, auth.UserFromContext(r.Context()), "")
+}
+
+// Create excludes a referrer domain from the inline form.
+// POST /admin/referrers
+func (h *ReferrerExclusionsHandler) Create(w http.ResponseWriter, r *http.Request) {
+	user := auth.UserFromContext(r.Context())
+	if err := r.ParseForm(); err != nil {
+		renderError(w, r, http.StatusBadRequest, "The request could not be understood.")
+		return
+	}
+
+	domain := strings.TrimSpace(r.FormValue("domain"))
+	reason := strings.TrimSpace(r.FormValue("reason"))
+	if domain == "" {
+		h.renderList(w, r, user, "Domain is required.")
+		return
+	}
+
+	if _, err := h.clicks.ExcludeReferrer(r.Context(), domain, reason); err != nil {
+		switch {
+		case errors.Is(err, store.ErrInvalidDomain):
+			h.renderList(w, r, user, "Enter a domain such as status.example.com, or a URL on it.")
+		case errors.Is(err, store.ErrReferrerExcluded):
+			h.renderList(w, r, user, "That domain is already excluded.")
+		default:
+			h.renderList(w, r, user, "Failed to exclude referrer.")
+		}
+		return
+	}
+
+	h.renderList(w, r, user, "")
+}
+
+// Delete counts a referrer domain again. Returns empty 200 so HTMX swaps out the row.
+// DELETE /admin/referrers/{domain}
+func (h *ReferrerExclusionsHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	if err := h.clicks.RemoveExcludedReferrer(r.Context(), chi.URLParam(r, "domain")); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			renderError(w, r, http.StatusNotFound, "That item no longer exists.")
+			return
+		}
+		renderError(w, r, http.StatusInternalServerError, "Delete failed.")
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// ConfirmDelete renders the delete confirmation modal for an excluded referrer.
+// GET /admin/referrers/{domain}/confirm-delete
+// Governing: SPEC-0013 REQ "DaisyUI Delete Confirmation Modal"
+func (h *ReferrerExclusionsHandler) ConfirmDelete(w http.ResponseWriter, r *http.Request) {
+	domain, err := store.NormalizeReferrerDomain(chi.URLParam(r, "domain"))
+	if err != nil {
+		renderError(w, r, http.StatusNotFound, "That item no longer exists.")
+		return
+	}
+	data := ConfirmDeleteData{
+		Name:      domain,
+		DeleteURL: "/admin/referrers/" + domain,
+		// Domains contain dots, so match the row by attribute rather than id.
+		Target: `tr[data-referrer="` + domain + `"]`,
+	}
+	renderFragment(w, "confirm_delete", data)
+}
+
+// renderList re-renders the referrer_list partial (or full page for non-HTMX).
+func (h *ReferrerExclusionsHandler) renderList(w http.ResponseWriter, r *http.Request, user *store.User, errMsg string) {
+	referrers, _ := h.clicks.ListExcludedReferrers(r.Context())
+	data := AdminReferrersPage{
+		BasePage:  newBasePage(r, user),
+		Referrers: referrers,
+		Error:     errMsg,
+	}
+	if isHTMX(r) {
+		renderPageFragment(w, "admin/referrers.html", "referrer_list", data)
+		return
+	}
+	render(w, "admin/referrers.html", data)
+}
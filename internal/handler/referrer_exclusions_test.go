@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/joestump/joe-links/internal/auth"
+	"github.com/joestump/joe-links/internal/store"
+	"github.com/joestump/joe-links/internal/testutil"
+)
+
+// Governing: SPEC-0016 REQ "Referrer Exclusion"
+func TestReferrerExclusions_Create(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	admin, err := store.NewUserStore(db).Upsert(context.Background(), "test", "sub1", "admin@example.com", "Admin", "")
+	if err != nil {
+		t.Fatalf("seed user: %v", err)
+	}
+
+	h := NewReferrerExclusionsHandler(store.NewClickStore(db))
+	r := chi.NewRouter()
+	r.Post("/admin/referrers", h.Create)
+	r.Get("/admin/referrers/{domain}/confirm-delete", h.ConfirmDelete)
+	serve := func(req *http.Request) *httptest.ResponseRecorder {
+		req = req.WithContext(context.WithValue(req.Context(), auth.UserContextKey, admin))
+		req.Header.Set("HX-Request", "true")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+	post := func(domain string) string {
+		req := httptest.NewRequest(http.MethodPost, "/admin/referrers", strings.NewReader(url.Values{"domain": {domain}}.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		return serve(req).Body.String()
+	}
+
+	if body := post("https://Status.Example.com/health"); !strings.Contains(body, `data-referrer="status.example.com"`) {
+		t.Errorf("list missing the normalized domain:\n%s", body)
+	}
+	if body := post("status.example.com"); !strings.Contains(body, "already excluded") {
+		t.Error("duplicate domain did not report an error")
+	}
+	if body := post("bad domain"); !strings.Contains(body, "alert-error") {
+		t.Error("invalid domain did not report an error")
+	}
+
+	w := serve(httptest.NewRequest(http.MethodGet, "/admin/referrers/status.example.com/confirm-delete", nil))
+	if !strings.Contains(w.Body.String(), `hx-delete="/admin/referrers/status.example.com"`) {
+		t.Errorf("confirm modal missing delete URL:\n%s", w.Body.String())
+	}
+}
@@ -0,0 +1,110 @@
+// Governing: SPEC-0002 REQ "Reserved Slugs"
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/joestump/joe-links/internal/auth"
+	"github.com/joestump/joe-links/internal/store"
+)
+
+// ReservedSlugsHandler serves the admin reserved slug screens.
+type ReservedSlugsHandler struct {
+	reserved *store.ReservedSlugStore
+}
+
+// NewReservedSlugsHandler creates a new ReservedSlugsHandler.
+func NewReservedSlugsHandler(rs *store.ReservedSlugStore) *ReservedSlugsHandler {
+	return &ReservedSlugsHandler{reserved: rs}
+}
+
+// AdminReservedSlugsPage is the template data for the reserved slug list.
+type AdminReservedSlugsPage struct {
+	BasePage
+	Slugs    []*store.ReservedSlug
+	Shadowed []string // link and alias slugs that a built-in reservation shadows
+	Error    string
+}
+
+// Index renders the reserved slug list.
+// GET /admin/reserved-slugs
+func (h *ReservedSlugsHandler) Index(w http.ResponseWriter, r *http.Request) {
+	h.renderList(w, r, auth.UserFromContext(r.Context()), "")
+}
+
+// Create reserves a slug from the inline form.
+// POST /admin/reserved-slugs
+func (h *ReservedSlugsHandler) Create(w http.ResponseWriter, r *http.Request) {
+	user := auth.UserFromContext(r.Context())
+	if err := r.ParseForm(); err != nil {
+		renderError(w, r, http.StatusBadRequest, "The request could not be understood.")
+		return
+	}
+
+	slug := strings.ToLower(strings.TrimSpace(r.FormValue("slug")))
+	reason := strings.TrimSpace(r.FormValue("reason"))
+	if slug == "" {
+		h.renderList(w, r, user, "Slug is required.")
+		return
+	}
+
+	if _, err := h.reserved.Add(r.Context(), slug, reason); err != nil {
+		switch {
+		case errors.Is(err, store.ErrSlugInvalid):
+			h.renderList(w, r, user, "Slug must be lowercase letters, digits, and hyphens (e.g. legal, hr).")
+		case errors.Is(err, store.ErrSlugTaken):
+			h.renderList(w, r, user, "That slug is already reserved.")
+		default:
+			h.renderList(w, r, user, "Failed to reserve slug.")
+		}
+		return
+	}
+
+	h.renderList(w, r, user, "")
+}
+
+// Delete releases a reserved slug. Returns empty 200 so HTMX swaps out the row.
+// DELETE /admin/reserved-slugs/{slug}
+func (h *ReservedSlugsHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	if err := h.reserved.Remove(r.Context(), chi.URLParam(r, "slug")); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			renderError(w, r, http.StatusNotFound, "That item no longer exists.")
+			return
+		}
+		renderError(w, r, http.StatusInternalServerError, "Delete failed.")
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// ConfirmDelete renders the delete confirmation modal for a reserved slug.
+// GET /admin/reserved-slugs/{slug}/confirm-delete
+// Governing: SPEC-0013 REQ "DaisyUI Delete Confirmation Modal"
+func (h *ReservedSlugsHandler) ConfirmDelete(w http.ResponseWriter, r *http.Request) {
+	slug := chi.URLParam(r, "slug")
+	data := ConfirmDeleteData{
+		Name:      slug,
+		DeleteURL: "/admin/reserved-slugs/" + slug,
+		Target:    "#reserved-" + slug,
+	}
+	renderFragment(w, "confirm_delete", data)
+}
+
+// renderList re-renders the reserved_slug_list partial (or full page for non-HTMX).
+func (h *ReservedSlugsHandler) renderList(w http.ResponseWriter, r *http.Request, user *store.User, errMsg string) {
+	slugs, _ := h.reserved.List(r.Context())
+	data := AdminReservedSlugsPage{
+		BasePage: newBasePage(r, user),
+		Slugs:    slugs,
+		Error:    errMsg,
+	}
+	if isHTMX(r) {
+		renderPageFragment(w, "admin/reserved_slugs.html", "reserved_slug_list", data)
+		return
+	}
+	data.Shadowed, _ = h.reserved.Shadowed(r.Context())
+	render(w, "admin/reserved_slugs.html", data)
+}
@@ -7,6 +7,9 @@
 package handler
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"log"
 	"net"
 	"net/http"
@@ -16,13 +19,26 @@ import (
 	"time"
 
 	"github.com/joestump/joe-links/internal/auth"
+	"github.com/joestump/joe-links/internal/botfilter"
+	"github.com/joestump/joe-links/internal/campaign"
+	"github.com/joestump/joe-links/internal/config"
 	"github.com/joestump/joe-links/internal/metrics"
+	"github.com/joestump/joe-links/internal/shareurl"
 	"github.com/joestump/joe-links/internal/store"
+	"github.com/joestump/joe-links/internal/tracing"
+	"go.opentelemetry.io/otel/attribute"
 )
 
-// varPlaceholderRe matches $varname placeholders in URL templates.
-// Governing: SPEC-0009 REQ "Variable Substitution and Redirect", ADR-0013
-var varPlaceholderRe = regexp.MustCompile(`\$[a-z][a-z0-9_]*`)
+// varPlaceholderRe matches $varname placeholders in URL templates, including
+// a trailing rest-capture placeholder written $name*, optional placeholders
+// written $name:default, and query-string placeholders written $q:param. The
+// query form is listed first so "$q:term" is never read as the path variable
+// $q with a default.
+// Governing: SPEC-0009 REQ "Variable Substitution and Redirect", REQ "Rest-Capture Placeholder", REQ "Query-String Placeholder", REQ "Variable Defaults", ADR-0013
+var varPlaceholderRe = regexp.MustCompile(`\$q:[A-Za-z0-9_.\-]+|\$[a-z][a-z0-9_]*(?:\*|:[A-Za-z0-9_.~\-]+)?`)
+
+// queryPlaceholderPrefix marks a placeholder filled from the request's query string.
+const queryPlaceholderPrefix = "$q:"
 
 // ResolveHandler handles short link slug resolution and redirection.
 // Governing: SPEC-0016 REQ "Click Recording", ADR-0016
@@ -31,6 +47,39 @@ type ResolveHandler struct {
 	keywords   *store.KeywordStore
 	ownership  *store.OwnershipStore
 	clickCh    chan<- store.ClickEvent
+
+	// debug logs every resolution's decisions and returns them to admins in
+	// the X-Joe-Trace header.
+	// Governing: SPEC-0009 REQ "Resolver Decision Tracing"
+	debug bool
+
+	// utmDefaults are the system-wide UTM parameters appended to every link
+	// target; a link's own parameters take precedence per name.
+	// Governing: SPEC-0002 REQ "UTM Parameters"
+	utmDefaults map[string]string
+
+	// bots flags clicks from automated clients so stats can leave them out;
+	// nil flags nothing.
+	// Governing: SPEC-0016 REQ "Bot Filtering"
+	bots *botfilter.Detector
+
+	// analyticsMode is config.AnalyticsFull, AnalyticsAnonymous, or
+	// AnalyticsOff; empty behaves as full.
+	// Governing: SPEC-0016 REQ "Analytics Mode"
+	analyticsMode string
+
+	// campaigns verifies ?src= campaign tags; nil records no sources.
+	// Governing: SPEC-0016 REQ "Campaign Sources"
+	campaigns *campaign.Signer
+
+	// strictVisibility denies links whose visibility is not public,
+	// private, or secure instead of treating them as public.
+	// Governing: SPEC-0010 REQ "Strict Visibility"
+	strictVisibility bool
+
+	// shares verifies the signatures on /s/ share URLs; nil admits none.
+	// Governing: SPEC-0010 REQ "Signed Share URLs"
+	shares *shareurl.Signer
 }
 
 // NewResolveHandler creates a new ResolveHandler.
@@ -44,6 +93,14 @@ type notFoundPage struct {
 	User  *store.User
 	Slug  string
 	Flash *Flash
+
+	// LinkSlug and LinkID are set when Slug matched a templated link but its
+	// segments did not fit; Mismatch is also set when a segment failed a
+	// variable constraint.
+	// Governing: SPEC-0009 REQ "Variable Constraints", REQ "Templated Link Help Page", SPEC-0012 REQ "Contact Link Owner"
+	LinkSlug string
+	LinkID   string
+	Mismatch *variableMismatch
 }
 
 // Resolve looks up a slug and redirects to the target URL, or renders a 404 page.
@@ -53,6 +110,8 @@ func (h *ResolveHandler) Resolve(w http.ResponseWriter, r *http.Request) {
 	// Governing: SPEC-0016 REQ "Prometheus Metrics Endpoint", ADR-0016
 	start := time.Now()
 	defer func() { metrics.RedirectDuration.Observe(time.Since(start).Seconds()) }()
+	// Governing: SPEC-0009 REQ "Resolver Content Negotiation" — JSON and redirects share URLs
+	w.Header().Add("Vary", "Accept")
 
 	// Extract the full path after the leading "/".
 	// Governing: SPEC-0009 REQ "Multi-Segment Path Resolution", ADR-0013
@@ -63,176 +122,573 @@ func (h *ResolveHandler) Resolve(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Governing: SPEC-0009 REQ "Resolver Decision Tracing"
+	user := auth.UserFromContext(r.Context())
+	trace, w, logTrace := h.startTrace(w, user != nil && user.IsAdmin(), fullPath)
+	defer logTrace()
+
 	host := strings.SplitN(r.Host, ":", 2)[0]
 
-	// Path-based keyword routing: /{keyword}/{slug} on the main server.
-	// The browser extension redirects to {baseURL}/{keyword}/{slug} when the
-	// keyword hostname isn't the server itself (Firefox fallback).
-	// Governing: SPEC-0008 REQ "Search Interception and Redirect"
-	parts := strings.SplitN(fullPath, "/", 2)
-	if len(parts) == 2 && parts[1] != "" && parts[0] != host {
-		if kw, err := h.keywords.GetByKeyword(r.Context(), parts[0]); err == nil {
-			target := strings.ReplaceAll(kw.URLTemplate, "{slug}", parts[1])
-			metrics.RedirectsTotal.WithLabelValues("found").Inc()
-			http.Redirect(w, r, target, http.StatusFound)
+	if kw, target, ok := h.keywordTarget(r.Context(), host, fullPath, trace); ok {
+		// Governing: SPEC-0008 REQ "Secure Keywords"
+		decision, reason := keywordAccess(kw, user)
+		trace.add("keyword access: %s", reason)
+		if !h.enforceAccess(w, r, decision) {
 			return
 		}
-	}
-
-	// Governing: ADR-0011 — check if request host is a registered keyword.
-	kw, kwErr := h.keywords.GetByKeyword(r.Context(), host)
-	if kwErr == nil {
-		// Substitute {slug} in the URL template and redirect.
-		target := strings.ReplaceAll(kw.URLTemplate, "{slug}", fullPath)
+		trace.add("redirect to %s", target)
 		metrics.RedirectsTotal.WithLabelValues("found").Inc()
+		// Governing: SPEC-0009 REQ "Resolver Content Negotiation"
+		if wantsJSON(r) {
+			writeResolveJSON(w, http.StatusOK, resolveJSON{Target: target, Keyword: kw.Keyword, RedirectType: http.StatusFound})
+			return
+		}
 		http.Redirect(w, r, target, http.StatusFound)
+		h.recordClick(r, store.ClickEvent{KeywordID: kw.ID}) // Governing: SPEC-0016 REQ "Keyword Analytics"
 		return
 	}
-	// kwErr == store.ErrNotFound → fall through to normal slug resolution
 
-	// Step 1: Try exact slug match on the full path.
-	// Governing: SPEC-0009 REQ "Multi-Segment Path Resolution" — exact match wins
-	link, err := h.links.GetBySlug(r.Context(), fullPath)
-	if err == nil {
-		// Governing: SPEC-0010 REQ "Secure Link Resolution", REQ "Public Link Resolution", REQ "Private Link Resolution"
-		if !h.checkVisibility(w, r, link) {
-			return
-		}
-		metrics.RedirectsTotal.WithLabelValues("found").Inc()
-		h.redirect(w, r, link.ID, link.URL)
+	link, remaining, err := h.lookup(r.Context(), fullPath, trace)
+	if err != nil {
+		// No match found → 404.
+		trace.add("not found")
+		metrics.RedirectsTotal.WithLabelValues("not_found").Inc()
+		h.render404(w, r, fullPath)
 		return
 	}
 
-	// Step 2: Try progressively shorter prefixes for multi-segment paths.
-	// Governing: SPEC-0009 REQ "Multi-Segment Path Resolution", ADR-0013
-	segments := strings.Split(fullPath, "/")
-	if len(segments) > 1 {
-		for i := len(segments) - 1; i >= 1; i-- {
-			prefix := strings.Join(segments[:i], "/")
-			link, err := h.links.GetBySlug(r.Context(), prefix)
-			if err != nil {
-				continue
-			}
+	// Governing: SPEC-0010 REQ "Secure Link Resolution", REQ "Public Link Resolution", REQ "Private Link Resolution"
+	if !h.checkVisibility(w, r, link, trace) {
+		return
+	}
+	// Governing: SPEC-0009 REQ "Templated Link Help Page"
+	if wantsHelp(r.URL.Query(), link) {
+		trace.add("?help requested on a templated link")
+		h.renderHelp(w, r, link)
+		return
+	}
 
-			remaining := segments[i:]
+	target, _, err := resolveTarget(link, remaining, r.URL.Query())
+	if err != nil {
+		trace.add("variables: %v", err)
+		metrics.RedirectsTotal.WithLabelValues("not_found").Inc()
+		// Governing: SPEC-0009 REQ "Variable Constraints" — explain which segment was rejected
+		var mismatch *variableMismatch
+		if errors.As(err, &mismatch) {
+			h.renderNotFound(w, r, notFoundPage{Slug: fullPath, LinkSlug: link.Slug, LinkID: link.ID, Mismatch: mismatch})
+			return
+		}
+		h.renderNotFound(w, r, notFoundPage{Slug: fullPath, LinkSlug: link.Slug, LinkID: link.ID})
+		return
+	}
+	// Governing: SPEC-0002 REQ "UTM Parameters"
+	target = appendUTM(target, h.utmDefaults, link.UTM())
 
-			// Governing: SPEC-0010 REQ "Secure Link Resolution"
-			if !h.checkVisibility(w, r, link) {
-				return
-			}
+	trace.add("redirect to %s", target)
+	metrics.RedirectsTotal.WithLabelValues("found").Inc()
+	h.redirect(w, r, link, target)
+}
 
-			// Check if URL contains $varname placeholders.
-			// Governing: SPEC-0009 REQ "Variable Substitution and Redirect", ADR-0013
-			placeholders := varPlaceholderRe.FindAllString(link.URL, -1)
-			if len(placeholders) == 0 {
-				// Static link — redirect as-is.
-				metrics.RedirectsTotal.WithLabelValues("found").Inc()
-				h.redirect(w, r, link.ID, link.URL)
-				return
-			}
+// keywordTarget returns the keyword and redirect target when fullPath or host
+// names a keyword, either as /{keyword}/{slug} on the main server or via a
+// request whose host is the keyword itself. Each check is recorded in trace.
+// Governing: SPEC-0008 REQ "Search Interception and Redirect", ADR-0011
+func (h *ResolveHandler) keywordTarget(ctx context.Context, host, fullPath string, trace *resolveTrace) (kw *store.Keyword, target string, ok bool) {
+	// Path-based keyword routing: /{keyword}/{slug} on the main server.
+	// The browser extension redirects to {baseURL}/{keyword}/{slug} when the
+	// keyword hostname isn't the server itself (Firefox fallback).
+	// Governing: SPEC-0008 REQ "Search Interception and Redirect"
+	parts := strings.SplitN(fullPath, "/", 2)
+	if len(parts) == 2 && parts[1] != "" && parts[0] != host {
+		if kw, err := h.keywords.GetByKeyword(ctx, parts[0]); err == nil {
+			trace.add("path keyword %q: matched", kw.Keyword)
+			return kw, strings.ReplaceAll(kw.URLTemplate, "{slug}", parts[1]), true
+		}
+		trace.add("path keyword %q: not registered", parts[0])
+	}
 
-			// Deduplicate placeholders preserving order of first appearance.
-			seen := make(map[string]bool)
-			var unique []string
-			for _, p := range placeholders {
-				if !seen[p] {
-					seen[p] = true
-					unique = append(unique, p)
-				}
-			}
+	// Governing: ADR-0011 — check if request host is a registered keyword.
+	if host == "" {
+		return nil, "", false
+	}
+	kw, err := h.keywords.GetByKeyword(ctx, host)
+	if err != nil {
+		// store.ErrNotFound → fall through to normal slug resolution
+		trace.add("host %q: not a keyword", host)
+		return nil, "", false
+	}
+	trace.add("host keyword %q: matched", kw.Keyword)
+	// Substitute {slug} in the URL template.
+	return kw, strings.ReplaceAll(kw.URLTemplate, "{slug}", fullPath), true
+}
 
-			// Arity check: remaining segments must equal unique placeholder count.
-			if len(remaining) != len(unique) {
-				metrics.RedirectsTotal.WithLabelValues("not_found").Inc()
-				h.render404(w, r, fullPath)
-				return
-			}
+// lookup finds the link fullPath resolves to: an exact slug match wins,
+// otherwise the longest slug prefix of fullPath. remaining holds the path
+// segments after a prefix match and is nil for an exact match. Every
+// candidate is fetched in a single query; the slugs it rules out are still
+// recorded in trace, longest first.
+// Governing: SPEC-0009 REQ "Multi-Segment Path Resolution", ADR-0013
+func (h *ResolveHandler) lookup(ctx context.Context, fullPath string, trace *resolveTrace) (link *store.Link, remaining []string, err error) {
+	link, matched, err := h.links.GetByPathPrefix(ctx, fullPath)
+	if err != nil && !errors.Is(err, store.ErrNotFound) {
+		trace.add("slug lookup failed: %v", err)
+		return nil, nil, err
+	}
+	// Governing: SPEC-0002 REQ "Link Aliases"
+	via := ""
+	if link != nil && matched != link.Slug {
+		via = fmt.Sprintf(" (alias of %q)", link.Slug)
+	}
 
-			// Substitute positionally with url.PathEscape.
-			target := link.URL
-			for j, placeholder := range unique {
-				target = strings.ReplaceAll(target, placeholder, url.PathEscape(remaining[j]))
-			}
+	// Governing: SPEC-0009 REQ "Multi-Segment Path Resolution" — exact match wins
+	if link != nil && matched == fullPath {
+		trace.add("exact slug %q: matched%s", fullPath, via)
+		return link, nil, nil
+	}
+	trace.add("exact slug %q: no match", fullPath)
 
-			metrics.RedirectsTotal.WithLabelValues("found").Inc()
-			h.redirect(w, r, link.ID, target)
-			return
+	segments := strings.Split(fullPath, "/")
+	for i := min(len(segments)-1, store.MaxPrefixDepth); i >= 1; i-- {
+		prefix := strings.Join(segments[:i], "/")
+		if link != nil && matched == prefix {
+			trace.add("prefix %q: matched%s, remaining segments %q", prefix, via, segments[i:])
+			return link, segments[i:], nil
 		}
+		trace.add("prefix %q: no match", prefix)
 	}
+	return nil, nil, store.ErrNotFound
+}
 
-	// No match found → 404.
-	metrics.RedirectsTotal.WithLabelValues("not_found").Inc()
-	h.render404(w, r, fullPath)
+// resolveTarget builds the redirect target for link given the path segments
+// left after its slug and the request query. A static link redirects to the
+// URL as-is, apart from any $q:param placeholders and, for passthrough links,
+// the forwarded query. An exact match (no remaining segments) fills a
+// template whose path variables all have query values or defaults and
+// otherwise leaves its path placeholders as written. It also returns the values bound to path
+// variables.
+// Governing: SPEC-0009 REQ "Variable Substitution and Redirect", REQ "Query-String Passthrough", REQ "Variable Defaults", REQ "Named Query Variables", ADR-0013
+func resolveTarget(link *store.Link, remaining []string, query url.Values) (string, []boundVariable, error) {
+	var (
+		target string
+		bound  []boundVariable
+	)
+	if !hasPathVariables(link.URL) {
+		target = substituteQueryVariables(link.URL, query)
+	} else {
+		var err error
+		bound, err = bindVariables(link.URL, remaining, query, link.Constraints())
+		switch {
+		case err == nil:
+			target = expandVariables(link.URL, bound, query)
+		case remaining == nil && errors.Is(err, errVariableArity):
+			// An exact match that still lacks values uses the template as written.
+			target = substituteQueryVariables(link.URL, query)
+		default:
+			return "", nil, err
+		}
+	}
+	// Governing: SPEC-0009 REQ "Query-String Passthrough"
+	if link.PassQuery {
+		target = forwardQuery(target, link.URL, query, bound)
+	}
+	return target, bound, nil
 }
 
-// checkVisibility enforces visibility rules for a link.
-// Returns true if the request is allowed to proceed to redirect.
-// Returns false if it has already written a response (login redirect or 403).
+// accessDecision is the outcome of a visibility check.
+type accessDecision int
+
+const (
+	accessAllowed accessDecision = iota
+	accessLoginRequired
+	accessForbidden
+)
+
+// decideAccess applies the visibility rules for link to user (nil when
+// anonymous) and explains the decision.
 // Governing: SPEC-0010 REQ "Secure Link Resolution", REQ "Public Link Resolution", REQ "Private Link Resolution", REQ "Admin Visibility Override"
-func (h *ResolveHandler) checkVisibility(w http.ResponseWriter, r *http.Request, link *store.Link) bool {
+func (h *ResolveHandler) decideAccess(ctx context.Context, link *store.Link, user *store.User) (accessDecision, string) {
 	switch link.Visibility {
-	case "public", "private":
+	case "public":
 		// Governing: SPEC-0010 REQ "Public Link Resolution" — 302 for anyone
+		return accessAllowed, "public link"
+	case "private":
 		// Governing: SPEC-0010 REQ "Private Link Resolution" — 302 for anyone who knows the slug
-		return true
+		return accessAllowed, "private link, allowed for anyone who knows the slug"
 	case "secure":
-		user := auth.UserFromContext(r.Context())
+		// Governing: SPEC-0010 REQ "Signed Share URLs" — a valid signature stands in for a share record
+		if h.shares.Verify(link.ID, shareGrantFromContext(ctx), time.Now()) {
+			return accessAllowed, "secure link, allowed by signed share URL"
+		}
 		if user == nil {
 			// Governing: SPEC-0010 REQ "Secure Link Resolution" — redirect to login with return URL
-			returnURL := r.URL.RequestURI()
-			http.Redirect(w, r, "/auth/login?redirect="+url.QueryEscape(returnURL), http.StatusFound)
-			return false
+			return accessLoginRequired, "secure link requires login"
 		}
 		// Governing: SPEC-0010 REQ "Admin Visibility Override" — admins always authorized
 		if user.IsAdmin() {
-			return true
+			return accessAllowed, "secure link, allowed by admin override"
 		}
 		// Check if user is an owner/co-owner
 		isOwner, err := h.ownership.IsOwner(link.ID, user.ID)
 		if err == nil && isOwner {
-			return true
+			return accessAllowed, "secure link, user is an owner"
 		}
 		// Check link_shares
-		hasShare, err := h.links.HasShare(r.Context(), link.ID, user.ID)
+		hasShare, err := h.links.HasShare(ctx, link.ID, user.ID)
 		if err == nil && hasShare {
-			return true
+			return accessAllowed, "secure link, shared with user"
 		}
 		// Not authorized
+		return accessForbidden, "secure link, user is neither an owner nor shared with"
+	default:
+		// Governing: SPEC-0010 REQ "Strict Visibility" — unknown values never widen access
+		if h.strictVisibility {
+			return accessForbidden, fmt.Sprintf("unknown visibility %q denied by strict mode", link.Visibility)
+		}
+		return accessAllowed, fmt.Sprintf("unknown visibility %q treated as public", link.Visibility)
+	}
+}
+
+// keywordAccess decides whether user (nil when anonymous) may follow kw.
+// Secure keywords need a signed-in user, like secure links, but have no
+// owners or shares: any signed-in user is allowed.
+// Governing: SPEC-0008 REQ "Secure Keywords"
+func keywordAccess(kw *store.Keyword, user *store.User) (accessDecision, string) {
+	switch {
+	case !kw.Secure:
+		return accessAllowed, "open keyword"
+	case user == nil:
+		return accessLoginRequired, "secure keyword requires login"
+	default:
+		return accessAllowed, "secure keyword, user is signed in"
+	}
+}
+
+// checkVisibility enforces visibility rules for a link.
+// Returns true if the request is allowed to proceed to redirect.
+// Returns false if it has already written a response (login redirect or 403).
+// Governing: SPEC-0010 REQ "Secure Link Resolution", REQ "Public Link Resolution", REQ "Private Link Resolution", REQ "Admin Visibility Override", REQ "Strict Visibility"
+func (h *ResolveHandler) checkVisibility(w http.ResponseWriter, r *http.Request, link *store.Link, trace *resolveTrace) bool {
+	decision, reason := h.decideAccess(r.Context(), link, auth.UserFromContext(r.Context()))
+	trace.add("visibility: %s", reason)
+	// Governing: SPEC-0010 REQ "Strict Visibility" — count and log bad data
+	if store.ValidateVisibility(link.Visibility) != nil {
+		outcome := "allowed"
+		if decision == accessForbidden {
+			outcome = "denied"
+		}
+		metrics.UnknownVisibilityTotal.WithLabelValues(outcome).Inc()
+		log.Printf("resolve %s: %s", link.Slug, reason)
+	}
+	return h.enforceAccess(w, r, decision)
+}
+
+// enforceAccess acts on an access decision. Returns true if the request may
+// proceed to redirect, false if it has already written a login redirect or 403.
+// Governing: SPEC-0010 REQ "Secure Link Resolution", SPEC-0008 REQ "Secure Keywords"
+func (h *ResolveHandler) enforceAccess(w http.ResponseWriter, r *http.Request, decision accessDecision) bool {
+	switch decision {
+	case accessLoginRequired:
+		// Governing: SPEC-0009 REQ "Resolver Content Negotiation" — no login page for API clients
+		if wantsJSON(r) {
+			writeResolveJSON(w, http.StatusUnauthorized, resolveJSONError{Error: "sign in to follow this link", Code: "UNAUTHORIZED"})
+			return false
+		}
+		returnURL := r.URL.RequestURI()
+		http.Redirect(w, r, "/auth/login?redirect="+url.QueryEscape(returnURL), http.StatusFound)
+		return false
+	case accessForbidden:
 		h.render403(w, r)
 		return false
-	default:
-		// Unknown visibility — treat as public
-		return true
 	}
+	return true
+}
+
+// hasPathVariables reports whether tmpl contains any $name or $name* placeholder.
+func hasPathVariables(tmpl string) bool {
+	for _, p := range varPlaceholderRe.FindAllString(tmpl, -1) {
+		if !strings.HasPrefix(p, queryPlaceholderPrefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// substituteQueryVariables fills $q:param placeholders in tmpl from query and
+// leaves every other placeholder untouched. Values are query-escaped; a
+// parameter missing from the request becomes the empty string.
+// Governing: SPEC-0009 REQ "Query-String Placeholder", ADR-0013
+func substituteQueryVariables(tmpl string, query url.Values) string {
+	return varPlaceholderRe.ReplaceAllStringFunc(tmpl, func(p string) string {
+		if name, ok := strings.CutPrefix(p, queryPlaceholderPrefix); ok {
+			return url.QueryEscape(query.Get(name))
+		}
+		return p
+	})
+}
+
+// errVariableArity is returned by substituteVariables when the number of
+// remaining path segments does not fit the template's placeholders.
+var errVariableArity = errors.New("path segments do not match URL variables")
+
+// variableMismatch reports a segment that failed its variable's constraint.
+// Governing: SPEC-0009 REQ "Variable Constraints", ADR-0013
+type variableMismatch struct {
+	Name    string // variable name without $ or *
+	Value   string // unescaped segment value (joined with "/" for rest capture)
+	Pattern string
+}
+
+func (e *variableMismatch) Error() string {
+	return fmt.Sprintf("value %q for $%s does not match %s", e.Value, e.Name, e.Pattern)
+}
+
+// boundVariable is a path placeholder and the request value assigned to it.
+type boundVariable struct {
+	Placeholder string // as written in the template, e.g. "$ticket", "$path*" or "$env:prod"
+	Value       string // unescaped value; rest captures are joined with "/"
+	Default     bool   // Value came from the placeholder's default, not the request
+	FromQuery   bool   // Value came from the query parameter named after the variable
+	escaped     string
+}
+
+// Name returns the variable name without $, * or default.
+func (v boundVariable) Name() string {
+	name, _, _ := store.SplitPlaceholder(v.Placeholder)
+	return name
+}
+
+// bindVariables assigns the remaining path segments to the path placeholders
+// of tmpl, positionally by first appearance. A trailing $name* placeholder
+// captures every remaining segment (at least one). A placeholder left without
+// a segment takes the non-empty query parameter of the same name, so
+// go/jira?ticket=ABC-1 fills $ticket, or failing that its default. $q:param
+// placeholders do not count towards the segments. Returns errVariableArity
+// when the segments and query do not fit the path placeholders, or a
+// *variableMismatch when a request value fails its entry in constraints.
+// Defaults are checked against constraints when the link is saved.
+// Governing: SPEC-0009 REQ "Variable Substitution and Redirect", REQ "Rest-Capture Placeholder", REQ "Variable Constraints", REQ "Variable Defaults", REQ "Named Query Variables", ADR-0013
+func bindVariables(tmpl string, remaining []string, query url.Values, constraints map[string]string) ([]boundVariable, error) {
+	// Deduplicate path placeholders preserving order of first appearance.
+	seen := make(map[string]bool)
+	var unique []string
+	for _, p := range varPlaceholderRe.FindAllString(tmpl, -1) {
+		if strings.HasPrefix(p, queryPlaceholderPrefix) {
+			continue
+		}
+		if !seen[p] {
+			seen[p] = true
+			unique = append(unique, p)
+		}
+	}
+
+	rest := len(unique) > 0 && strings.HasSuffix(unique[len(unique)-1], "*")
+	// Arity check: remaining segments must not outnumber the placeholders
+	// unless the last placeholder captures the rest; placeholders left
+	// without a segment need a query value or a default (checked below).
+	if !rest && len(remaining) > len(unique) {
+		return nil, errVariableArity
+	}
+
+	bound := make([]boundVariable, len(unique))
+	for j, placeholder := range unique {
+		var (
+			raw       []string
+			fromQuery bool
+		)
+		name, def, hasDefault := store.SplitPlaceholder(placeholder)
+		switch {
+		case j < len(remaining) && rest && j == len(unique)-1:
+			raw = remaining[j:]
+		case j < len(remaining):
+			raw = []string{remaining[j]}
+		case query.Get(name) != "":
+			// Governing: SPEC-0009 REQ "Named Query Variables"
+			raw, fromQuery = []string{query.Get(name)}, true
+			if rest && j == len(unique)-1 {
+				raw = strings.Split(query.Get(name), "/")
+			}
+		case hasDefault:
+			bound[j] = boundVariable{Placeholder: placeholder, Value: def, Default: true, escaped: url.PathEscape(def)}
+			continue
+		default:
+			return nil, errVariableArity
+		}
+		v := boundVariable{Placeholder: placeholder, Value: strings.Join(raw, "/"), FromQuery: fromQuery}
+		if pattern, ok := constraints[v.Name()]; ok {
+			if re, err := store.CompileConstraint(pattern); err == nil && !re.MatchString(v.Value) {
+				return nil, &variableMismatch{Name: v.Name(), Value: v.Value, Pattern: pattern}
+			}
+		}
+		escaped := make([]string, len(raw))
+		for i, seg := range raw {
+			escaped[i] = url.PathEscape(seg)
+		}
+		v.escaped = strings.Join(escaped, "/")
+		bound[j] = v
+	}
+	return bound, nil
+}
+
+// expandVariables replaces the placeholders in tmpl with the path-escaped
+// bound values and the query-escaped $q:param values from query.
+// Governing: SPEC-0009 REQ "Variable Substitution and Redirect", REQ "Query-String Placeholder", ADR-0013
+func expandVariables(tmpl string, bound []boundVariable, query url.Values) string {
+	values := make(map[string]string, len(bound))
+	for _, v := range bound {
+		values[v.Placeholder] = v.escaped
+	}
+	// Single pass, so substituted values are never re-scanned for placeholders.
+	return varPlaceholderRe.ReplaceAllStringFunc(tmpl, func(p string) string {
+		if name, ok := strings.CutPrefix(p, queryPlaceholderPrefix); ok {
+			return url.QueryEscape(query.Get(name))
+		}
+		return values[p]
+	})
+}
+
+// substituteVariables fills the placeholders in tmpl from the remaining path
+// segments and query. See bindVariables for the matching rules and errors.
+// Governing: SPEC-0009 REQ "Variable Substitution and Redirect", REQ "Rest-Capture Placeholder", REQ "Query-String Placeholder", REQ "Variable Constraints", ADR-0013
+func substituteVariables(tmpl string, remaining []string, query url.Values, constraints map[string]string) (string, error) {
+	bound, err := bindVariables(tmpl, remaining, query, constraints)
+	if err != nil {
+		return "", err
+	}
+	return expandVariables(tmpl, bound, query), nil
+}
+
+// appendUTM adds UTM parameters to target's query string, taking each name
+// from params or, failing that, from defaults. Names the target already
+// carries are left alone so an explicit value in the URL always wins. Only
+// http(s) targets are changed; the fragment, if any, stays last.
+// Governing: SPEC-0002 REQ "UTM Parameters"
+func appendUTM(target string, defaults, params map[string]string) string {
+	if len(defaults) == 0 && len(params) == 0 {
+		return target
+	}
+	u, err := url.Parse(target)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return target
+	}
+	existing := u.Query()
+	add := url.Values{}
+	for _, name := range store.UTMParamNames {
+		value, ok := params[name]
+		if !ok {
+			value, ok = defaults[name]
+		}
+		if ok && !existing.Has(name) {
+			add.Set(name, value)
+		}
+	}
+	return appendQuery(target, add)
+}
+
+// forwardQuery appends the request's query parameters to target for links
+// with passthrough enabled. Parameters consumed by a $q:param placeholder in
+// tmpl or that filled a path variable in bound are not forwarded, and
+// parameters the target already carries win over
+// incoming ones of the same name. Forwarded parameters are appended sorted by
+// name, keeping every value of multi-valued ones.
+// Governing: SPEC-0009 REQ "Query-String Passthrough"
+func forwardQuery(target, tmpl string, query url.Values, bound []boundVariable) string {
+	if len(query) == 0 {
+		return target
+	}
+	u, err := url.Parse(target)
+	if err != nil {
+		return target
+	}
+	consumed := make(map[string]bool)
+	for _, p := range varPlaceholderRe.FindAllString(tmpl, -1) {
+		if name, ok := strings.CutPrefix(p, queryPlaceholderPrefix); ok {
+			consumed[name] = true
+		}
+	}
+	for _, v := range bound {
+		if v.FromQuery {
+			consumed[v.Name()] = true
+		}
+	}
+	// Governing: SPEC-0016 REQ "Campaign Sources" — the campaign tag is for
+	// analytics only; a target that wants it names it with $q:src.
+	consumed[campaign.Param] = true
+	existing := u.Query()
+	add := url.Values{}
+	for name, values := range query {
+		if !consumed[name] && !existing.Has(name) {
+			add[name] = values
+		}
+	}
+	return appendQuery(target, add)
+}
+
+// appendQuery appends add to target's query string without re-encoding the
+// parameters already there, keeping any fragment last.
+func appendQuery(target string, add url.Values) string {
+	if len(add) == 0 {
+		return target
+	}
+	base, fragment, hasFragment := strings.Cut(target, "#")
+	switch {
+	case !strings.Contains(base, "?"):
+		base += "?"
+	case !strings.HasSuffix(base, "?") && !strings.HasSuffix(base, "&"):
+		base += "&"
+	}
+	target = base + add.Encode()
+	if hasFragment {
+		target += "#" + fragment
+	}
+	return target
 }
 
 // render403 renders a 403 Forbidden page.
 // Governing: SPEC-0010 REQ "Secure Link Resolution"
 func (h *ResolveHandler) render403(w http.ResponseWriter, r *http.Request) {
-	user := auth.UserFromContext(r.Context())
-	w.WriteHeader(http.StatusForbidden)
-	data := notFoundPage{BasePage: newBasePage(r, user), User: user, Slug: ""}
-	if isHTMX(r) {
-		renderPageFragment(w, "403.html", "content", data)
+	if wantsJSON(r) {
+		writeResolveJSON(w, http.StatusForbidden, resolveJSONError{Error: "you do not have access to this link", Code: "FORBIDDEN"})
 		return
 	}
-	render(w, "403.html", data)
+	user := auth.UserFromContext(r.Context())
+	data := notFoundPage{BasePage: newBasePage(r, user), User: user, Slug: ""}
+	renderPage(w, r, http.StatusForbidden, "403.html", data)
 }
 
-// redirect issues a 302 redirect, handling HTMX requests with HX-Redirect header.
-// It also fires a non-blocking click event if the click channel is configured.
-// Governing: SPEC-0016 REQ "Click Recording", ADR-0016
-func (h *ResolveHandler) redirect(w http.ResponseWriter, r *http.Request, linkID, target string) {
+// redirect issues a redirect with the link's redirect type (302 unless the
+// owner chose otherwise), handling HTMX requests with HX-Redirect header.
+// It also records the click. Clients that asked for JSON get the target in
+// a 200 response instead, which is not counted as a click.
+// Governing: SPEC-0016 REQ "Click Recording", REQ "Extended Operational Metrics", ADR-0016
+// Governing: SPEC-0009 REQ "Resolver Content Negotiation"
+func (h *ResolveHandler) redirect(w http.ResponseWriter, r *http.Request, link *store.Link, target string) {
+	tracing.SetAttributes(r.Context(), attribute.String("joelinks.slug", link.Slug), attribute.String("joelinks.link_id", link.ID))
+	if wantsJSON(r) {
+		writeResolveJSON(w, http.StatusOK, resolveJSON{Target: target, LinkID: link.ID, RedirectType: link.RedirectStatus()})
+		return
+	}
+	metrics.SlugRedirects.Inc(link.Slug)
 	if isHTMX(r) {
 		w.Header().Set("HX-Redirect", target)
 		w.WriteHeader(http.StatusNoContent)
 	} else {
-		http.Redirect(w, r, target, http.StatusFound)
+		// Governing: SPEC-0002 REQ "Redirect Type"
+		http.Redirect(w, r, target, link.RedirectStatus())
 	}
+	h.recordClick(r, store.ClickEvent{
+		LinkID: link.ID,
+		Source: h.campaigns.Verify(link.ID, r.URL.Query().Get(campaign.Param), time.Now()), // Governing: SPEC-0016 REQ "Campaign Sources"
+	})
+}
 
-	if h.clickCh != nil {
+// recordClick fills in e from r and fires it as a non-blocking click event if
+// the click channel is configured. e names either a link or a keyword.
+// Governing: SPEC-0016 REQ "Click Recording", REQ "Keyword Analytics", ADR-0016
+func (h *ResolveHandler) recordClick(r *http.Request, e store.ClickEvent) {
+	// Governing: SPEC-0016 REQ "Analytics Mode"
+	if h.clickCh != nil && h.analyticsMode != config.AnalyticsOff {
 		var userID string
-		if u := auth.UserFromContext(r.Context()); u != nil {
+		if u := auth.UserFromContext(r.Context()); u != nil && h.analyticsMode != config.AnalyticsAnonymous {
 			userID = u.ID
 		}
 		ua := r.UserAgent()
@@ -251,22 +707,29 @@ func (h *ResolveHandler) redirect(w http.ResponseWriter, r *http.Request, linkID
 		if len(ref) > 2048 {
 			ref = ref[:2048]
 		}
+		e.UserID = userID
+		e.IPHash = store.HashIP(realIP(r))
+		e.UserAgent = ua
+		e.Referrer = ref
+		e.ClickedAt = time.Now().UTC()
+		e.Bot = h.bots.IsBot(r.UserAgent(), realIP(r))
+		e.IP = realIP(r) // Governing: SPEC-0016 REQ "GeoIP Country Breakdown" — the click writer resolves and drops it
 		select {
-		case h.clickCh <- store.ClickEvent{
-			LinkID:    linkID,
-			UserID:    userID,
-			IPHash:    store.HashIP(realIP(r)),
-			UserAgent: ua,
-			Referrer:  ref,
-		}:
+		case h.clickCh <- e:
 		default: // Governing: SPEC-0016 REQ "Click Recording"
-			log.Printf("analytics: click channel full, dropping event for link %s", linkID)
+			metrics.ClicksDroppedTotal.Inc()
+			if e.KeywordID != "" {
+				log.Printf("analytics: click channel full, dropping event for keyword %s", e.KeywordID)
+			} else {
+				log.Printf("analytics: click channel full, dropping event for link %s", e.LinkID)
+			}
 		}
 	}
 }
 
 // realIP extracts the client IP from r.RemoteAddr (port stripped).
-// Chi's middleware.RealIP already rewrites r.RemoteAddr from X-Real-IP / X-Forwarded-For.
+// netpolicy.RealIP already rewrites r.RemoteAddr from X-Forwarded-For / X-Real-IP
+// for requests from trusted proxies.
 func realIP(r *http.Request) string {
 	host, _, err := net.SplitHostPort(r.RemoteAddr)
 	if err != nil {
@@ -277,12 +740,22 @@ func realIP(r *http.Request) string {
 
 // render404 renders the 404 page for a missing slug.
 func (h *ResolveHandler) render404(w http.ResponseWriter, r *http.Request, slug string) {
-	user := auth.UserFromContext(r.Context())
-	w.WriteHeader(http.StatusNotFound)
-	data := notFoundPage{BasePage: newBasePage(r, user), User: user, Slug: slug}
-	if isHTMX(r) {
-		renderPageFragment(w, "404.html", "content", data)
+	h.renderNotFound(w, r, notFoundPage{Slug: slug})
+}
+
+// renderNotFound renders the 404 page with data, filling in the user and base page.
+func (h *ResolveHandler) renderNotFound(w http.ResponseWriter, r *http.Request, data notFoundPage) {
+	// Governing: SPEC-0009 REQ "Resolver Content Negotiation"
+	if wantsJSON(r) {
+		msg := "no link matches this path"
+		if data.Mismatch != nil {
+			msg = data.Mismatch.Error()
+		}
+		writeResolveJSON(w, http.StatusNotFound, resolveJSONError{Error: msg, Code: "NOT_FOUND"})
 		return
 	}
-	render(w, "404.html", data)
+	user := auth.UserFromContext(r.Context())
+	data.BasePage = newBasePage(r, user)
+	data.User = user
+	renderPage(w, r, http.StatusNotFound, "404.html", data)
 }
@@ -0,0 +1,99 @@
+// Governing: SPEC-0009 REQ "Multi-Segment Path Resolution", REQ "Variable Substitution and Redirect", ADR-0013
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// resolveBenchCase is one request shape on the resolver hot path.
+type resolveBenchCase struct {
+	name   string
+	path   string
+	host   string
+	status int
+
+	// Budgets for TestResolvePerformanceBudget. Time is deliberately loose
+	// (shared CI runners are noisy); allocations are stable and catch most
+	// accidental regressions.
+	maxNsPerOp     int64
+	maxAllocsPerOp int64
+}
+
+var resolveBenchCases = []resolveBenchCase{
+	{name: "ExactMatch", path: "/example", status: http.StatusFound, maxNsPerOp: 2_000_000, maxAllocsPerOp: 180},
+	{name: "DeepPrefix", path: "/gh/a/b/c/d/e/f/g/h/i/j/k", status: http.StatusFound, maxNsPerOp: 2_000_000, maxAllocsPerOp: 300},
+	{name: "Variables", path: "/jira/PROJ-123/comments?focus=42", status: http.StatusFound, maxNsPerOp: 2_000_000, maxAllocsPerOp: 340},
+	{name: "Keyword", path: "/kw/joestump", status: http.StatusFound, maxNsPerOp: 2_000_000, maxAllocsPerOp: 110},
+	{name: "Miss", path: "/no/such/slug", status: http.StatusNotFound, maxNsPerOp: 5_000_000, maxAllocsPerOp: 280},
+}
+
+// newResolveBenchRouter seeds the links and keyword used by resolveBenchCases
+// and returns a router serving the resolver.
+func newResolveBenchRouter(tb testing.TB) http.Handler {
+	tb.Helper()
+	env := newResolveTestEnv(tb)
+	env.seedLink(tb, "example", "https://example.com")
+	env.seedLink(tb, "gh", "https://github.com")
+	env.seedConstrainedLink(tb, "jira", "https://jira.example.com/browse/$key/$tab?focusedId=$q:focus",
+		map[string]string{"key": "[A-Z]+-[0-9]+"})
+	env.seedKeyword(tb, "kw", "https://search.example.com/?q={slug}", "")
+
+	r := chi.NewRouter()
+	r.Get("/{slug}*", env.rh.Resolve)
+	return r
+}
+
+// benchmarkResolve returns a benchmark function resolving c.path repeatedly.
+func benchmarkResolve(router http.Handler, c resolveBenchCase) func(*testing.B) {
+	return func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			req := httptest.NewRequest(http.MethodGet, c.path, nil)
+			if c.host != "" {
+				req.Host = c.host
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			if w.Code != c.status {
+				b.Fatalf("%s: status = %d, want %d", c.path, w.Code, c.status)
+			}
+		}
+	}
+}
+
+func BenchmarkResolve(b *testing.B) {
+	router := newResolveBenchRouter(b)
+	for _, c := range resolveBenchCases {
+		b.Run(c.name, benchmarkResolve(router, c))
+	}
+}
+
+// TestResolvePerformanceBudget fails when a resolver path exceeds its time or
+// allocation budget, so hot-path regressions surface in CI without having to
+// compare benchmark output by hand. Skipped with -short and under -race, whose
+// instrumentation distorts both measurements.
+func TestResolvePerformanceBudget(t *testing.T) {
+	if testing.Short() || raceEnabled {
+		t.Skip("performance budgets are not meaningful with -short or -race")
+	}
+	router := newResolveBenchRouter(t)
+	for _, c := range resolveBenchCases {
+		t.Run(c.name, func(t *testing.T) {
+			res := testing.Benchmark(benchmarkResolve(router, c))
+			if res.N == 0 {
+				t.Fatal("benchmark did not run; see the status check in benchmarkResolve")
+			}
+			if ns := res.NsPerOp(); ns > c.maxNsPerOp {
+				t.Errorf("%d ns/op exceeds budget of %d", ns, c.maxNsPerOp)
+			}
+			if allocs := res.AllocsPerOp(); allocs > c.maxAllocsPerOp {
+				t.Errorf("%d allocs/op exceeds budget of %d", allocs, c.maxAllocsPerOp)
+			}
+			t.Logf("%s: %d ns/op, %d allocs/op", c.path, res.NsPerOp(), res.AllocsPerOp())
+		})
+	}
+}
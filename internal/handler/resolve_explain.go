@@ -0,0 +1,93 @@
+// Governing: SPEC-0005 REQ "Resolve Test Endpoint"
+// Governing: SPEC-0009 REQ "Multi-Segment Path Resolution", REQ "Variable Substitution and Redirect", ADR-0013
+// Governing: SPEC-0010 REQ "Secure Link Resolution", ADR-0014
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/joestump/joe-links/internal/api"
+	"github.com/joestump/joe-links/internal/store"
+)
+
+// TestResolve reports how Resolve handles a request for rawPath (which may
+// carry a query string) on host by user (nil = anonymous), walking the same
+// steps without redirecting or recording a click. It implements
+// api.ResolveTester.
+// Governing: SPEC-0005 REQ "Resolve Test Endpoint"
+func (h *ResolveHandler) TestResolve(ctx context.Context, rawPath, host string, user *store.User) *api.ResolveTestResponse {
+	fullPath, rawQuery, _ := strings.Cut(strings.TrimPrefix(rawPath, "/"), "?")
+	resp := &api.ResolveTestResponse{Path: fullPath}
+	trace := &resolveTrace{steps: []string{}}
+	defer func() { resp.Trace = trace.steps }()
+	step := trace.add
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		step("query string %q is malformed and ignored: %v", rawQuery, err)
+	}
+
+	if kw, target, ok := h.keywordTarget(ctx, strings.SplitN(host, ":", 2)[0], fullPath, trace); ok {
+		resp.Keyword = kw.Keyword
+		// Governing: SPEC-0008 REQ "Secure Keywords"
+		decision, reason := keywordAccess(kw, user)
+		step("keyword access: %s", reason)
+		if decision == accessLoginRequired {
+			resp.Outcome, resp.Status = api.ResolveOutcomeLoginRequired, http.StatusFound
+			resp.Target = "/auth/login?redirect=" + url.QueryEscape("/"+rawPath)
+			return resp
+		}
+		resp.Outcome, resp.Status, resp.Target = api.ResolveOutcomeKeyword, http.StatusFound, target
+		return resp
+	}
+
+	link, remaining, err := h.lookup(ctx, fullPath, trace)
+	if err != nil {
+		resp.Outcome, resp.Status = api.ResolveOutcomeNotFound, http.StatusNotFound
+		return resp
+	}
+	resp.Link = &api.ResolvedLinkResponse{ID: link.ID, Slug: link.Slug, Visibility: link.Visibility}
+
+	decision, reason := h.decideAccess(ctx, link, user)
+	step("visibility: %s", reason)
+	switch decision {
+	case accessLoginRequired:
+		resp.Outcome, resp.Status = api.ResolveOutcomeLoginRequired, http.StatusFound
+		resp.Target = "/auth/login?redirect=" + url.QueryEscape("/"+rawPath)
+		return resp
+	case accessForbidden:
+		resp.Outcome, resp.Status = api.ResolveOutcomeForbidden, http.StatusForbidden
+		return resp
+	}
+	resp.Link.URL = link.URL
+
+	if wantsHelp(query, link) {
+		step("?help requested on a templated link")
+		resp.Outcome, resp.Status = api.ResolveOutcomeHelp, http.StatusOK
+		return resp
+	}
+
+	target, bound, err := resolveTarget(link, remaining, query)
+	var mismatch *variableMismatch
+	switch {
+	case errors.As(err, &mismatch):
+		step("variables: %v", mismatch)
+		resp.Outcome, resp.Status = api.ResolveOutcomeNotFound, http.StatusNotFound
+		return resp
+	case err != nil:
+		step("variables: %d remaining segment(s) do not fit the URL template", len(remaining))
+		resp.Outcome, resp.Status = api.ResolveOutcomeNotFound, http.StatusNotFound
+		return resp
+	}
+	for _, v := range bound {
+		resp.Variables = append(resp.Variables, api.ResolveVariable{Name: v.Name(), Placeholder: v.Placeholder, Value: v.Value, Default: v.Default, FromQuery: v.FromQuery})
+	}
+	if len(bound) > 0 {
+		step("bound %d path variable(s)", len(bound))
+	}
+	resp.Outcome, resp.Status, resp.Target = api.ResolveOutcomeRedirect, http.StatusFound, target
+	return resp
+}
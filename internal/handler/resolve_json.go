@@ -0,0 +1,61 @@
+// Governing: SPEC-0009 REQ "Resolver Content Negotiation"
+package handler
+
+import (
+	"encoding/json"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// resolveJSON is the resolver's answer to a client that asked for JSON: the
+// redirect it would have issued, as data.
+type resolveJSON struct {
+	Target       string `json:"target"`
+	LinkID       string `json:"link_id,omitempty"`
+	Keyword      string `json:"keyword,omitempty"`
+	RedirectType int    `json:"redirect_type"`
+}
+
+// resolveJSONError matches the REST API's error body, so clients parse both alike.
+type resolveJSONError struct {
+	Error string `json:"error"`
+	Code  string `json:"code"`
+}
+
+// wantsJSON reports whether r prefers application/json over text/html, so
+// API clients calling a short link directly can introspect it instead of
+// following the redirect. Browsers, which list text/html first, and clients
+// that send no Accept header or only */* keep getting redirects.
+// Governing: SPEC-0009 REQ "Resolver Content Negotiation"
+func wantsJSON(r *http.Request) bool {
+	jsonQ, htmlQ := -1.0, -1.0
+	for _, part := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		q := 1.0
+		if v, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(v, 64); err != nil {
+				continue
+			}
+		}
+		switch mediaType {
+		case "application/json":
+			jsonQ = max(jsonQ, q)
+		case "text/html":
+			htmlQ = max(htmlQ, q)
+		}
+	}
+	return jsonQ > 0 && jsonQ > htmlQ
+}
+
+// writeResolveJSON writes the resolver's JSON answer with status.
+func writeResolveJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
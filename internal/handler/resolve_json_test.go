@@ -0,0 +1,100 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/joestump/joe-links/internal/store"
+)
+
+// Governing: SPEC-0009 REQ "Resolver Content Negotiation"
+func TestWantsJSON(t *testing.T) {
+	for accept, want := range map[string]bool{
+		"":                                  false,
+		"*/*":                               false,
+		"application/json":                  true,
+		"application/json, text/plain, */*": true,
+		"text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8":        false,
+		"text/html;q=0.5, application/json":                                      true,
+		"application/json;q=0.5, text/html":                                      false,
+		"application/json;q=0":                                                   false,
+		"application/json;charset=utf-8":                                         true,
+		"text/html, application/json":                                            false,
+		"application/problem+json, application/json;q=0.9, text/html;q=0.1, */*": true,
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/x", nil)
+		req.Header.Set("Accept", accept)
+		if got := wantsJSON(req); got != want {
+			t.Errorf("wantsJSON(%q) = %v, want %v", accept, got, want)
+		}
+	}
+}
+
+// Governing: SPEC-0009 REQ "Resolver Content Negotiation"
+func TestResolve_JSON(t *testing.T) {
+	e := newResolveTestEnv(t)
+	link, err := e.ls.Create(context.Background(), "docs", "https://docs.example.com/$page", e.userID, "", "", "public")
+	if err != nil {
+		t.Fatalf("seed link: %v", err)
+	}
+	if _, err := e.ls.Create(context.Background(), "payroll", "https://payroll.example.com", e.userID, "", "", "secure"); err != nil {
+		t.Fatalf("seed link: %v", err)
+	}
+	clicks := make(chan store.ClickEvent, 1)
+	e.rh.clickCh = clicks
+
+	r := chi.NewRouter()
+	r.Get("/{slug}*", e.rh.Resolve)
+	get := func(path string) (*httptest.ResponseRecorder, map[string]any) {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("Accept", "application/json")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		var body map[string]any
+		if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+			t.Fatalf("%s: decode %q: %v", path, w.Body.String(), err)
+		}
+		return w, body
+	}
+
+	w, body := get("/docs/setup")
+	if w.Code != http.StatusOK || body["target"] != "https://docs.example.com/setup" || body["link_id"] != link.ID || body["redirect_type"] != float64(http.StatusFound) {
+		t.Errorf("resolve: %d %v", w.Code, body)
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Content-Type = %q", ct)
+	}
+	if vary := w.Header().Get("Vary"); vary != "Accept" {
+		t.Errorf("Vary = %q, want Accept", vary)
+	}
+	select {
+	case c := <-clicks:
+		t.Errorf("JSON lookup recorded a click: %+v", c)
+	default:
+	}
+
+	if w, body := get("/missing"); w.Code != http.StatusNotFound || body["code"] != "NOT_FOUND" {
+		t.Errorf("missing: %d %v", w.Code, body)
+	}
+	if w, body := get("/payroll"); w.Code != http.StatusUnauthorized || body["code"] != "UNAUTHORIZED" {
+		t.Errorf("secure, anonymous: %d %v", w.Code, body)
+	}
+
+	// Browsers still get the redirect, and the click is recorded.
+	req := httptest.NewRequest(http.MethodGet, "/docs/setup", nil)
+	req.Header.Set("Accept", "text/html,application/xhtml+xml,*/*;q=0.8")
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusFound || w.Header().Get("Vary") != "Accept" {
+		t.Errorf("browser: %d, Vary %q", w.Code, w.Header().Get("Vary"))
+	}
+	select {
+	case <-clicks:
+	default:
+		t.Error("browser redirect recorded no click")
+	}
+}
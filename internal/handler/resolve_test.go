@@ -4,9 +4,17 @@ import (
 	"context"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/joestump/joe-links/internal/auth"
+	"github.com/joestump/joe-links/internal/botfilter"
+	"github.com/joestump/joe-links/internal/campaign"
+	"github.com/joestump/joe-links/internal/config"
+	"github.com/joestump/joe-links/internal/shareurl"
 	"github.com/joestump/joe-links/internal/store"
 	"github.com/joestump/joe-links/internal/testutil"
 )
@@ -20,7 +28,7 @@ type resolveTestEnv struct {
 
 // newResolveTestEnv sets up a LinkStore, KeywordStore, and ResolveHandler backed
 // by an in-memory SQLite database with all migrations applied.
-func newResolveTestEnv(t *testing.T) *resolveTestEnv {
+func newResolveTestEnv(t testing.TB) *resolveTestEnv {
 	t.Helper()
 	db := testutil.NewTestDB(t)
 	owns := store.NewOwnershipStore(db)
@@ -39,7 +47,7 @@ func newResolveTestEnv(t *testing.T) *resolveTestEnv {
 }
 
 // seedLink creates a link with the given slug and URL.
-func (e *resolveTestEnv) seedLink(t *testing.T, slug, url string) {
+func (e *resolveTestEnv) seedLink(t testing.TB, slug, url string) {
 	t.Helper()
 	_, err := e.ls.Create(context.Background(), slug, url, e.userID, "", "", "")
 	if err != nil {
@@ -48,7 +56,7 @@ func (e *resolveTestEnv) seedLink(t *testing.T, slug, url string) {
 }
 
 // seedKeyword creates a keyword with the given keyword string, URL template, and description.
-func (e *resolveTestEnv) seedKeyword(t *testing.T, keyword, urlTemplate, description string) {
+func (e *resolveTestEnv) seedKeyword(t testing.TB, keyword, urlTemplate, description string) {
 	t.Helper()
 	_, err := e.ks.Create(context.Background(), keyword, urlTemplate, description)
 	if err != nil {
@@ -95,6 +103,324 @@ func TestResolve_StaticLink(t *testing.T) {
 	}
 }
 
+// Governing: SPEC-0002 REQ "Redirect Type"
+func TestResolve_RedirectType(t *testing.T) {
+	env := newResolveTestEnv(t)
+	link, err := env.ls.Create(context.Background(), "handbook", "https://example.com/handbook", env.userID, "", "", "")
+	if err != nil {
+		t.Fatalf("seed link: %v", err)
+	}
+	if err := env.ls.SetRedirectType(context.Background(), link.ID, http.StatusMovedPermanently); err != nil {
+		t.Fatalf("set redirect type: %v", err)
+	}
+
+	w := env.resolve(t, "/handbook")
+	if w.Code != http.StatusMovedPermanently {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusMovedPermanently)
+	}
+	if loc := w.Header().Get("Location"); loc != "https://example.com/handbook" {
+		t.Errorf("Location = %q", loc)
+	}
+}
+
+func TestResolve_UTMParams(t *testing.T) {
+	env := newResolveTestEnv(t)
+	env.rh.utmDefaults = map[string]string{"utm_source": "golinks", "utm_medium": "internal"}
+	link, err := env.ls.Create(context.Background(), "launch", "https://example.com/launch#pricing", env.userID, "", "", "")
+	if err != nil {
+		t.Fatalf("seed link: %v", err)
+	}
+	if err := env.ls.SetUTMParams(context.Background(), link.ID, map[string]string{"utm_medium": "slides", "utm_campaign": "q3"}); err != nil {
+		t.Fatalf("set utm params: %v", err)
+	}
+
+	w := env.resolve(t, "/launch")
+	want := "https://example.com/launch?utm_campaign=q3&utm_medium=slides&utm_source=golinks#pricing"
+	if loc := w.Header().Get("Location"); loc != want {
+		t.Errorf("Location = %q, want %q", loc, want)
+	}
+}
+
+func TestResolve_PassQuery(t *testing.T) {
+	env := newResolveTestEnv(t)
+	for slug, url := range map[string]string{
+		"jira":   "https://jira.example.com/issues?project=OPS#list",
+		"search": "https://example.com/search?q=$q:term",
+		"plain":  "https://example.com/plain",
+	} {
+		link, err := env.ls.Create(context.Background(), slug, url, env.userID, "", "", "")
+		if err != nil {
+			t.Fatalf("seed link %q: %v", slug, err)
+		}
+		if slug != "plain" {
+			if err := env.ls.SetPassQuery(context.Background(), link.ID, true); err != nil {
+				t.Fatalf("set pass query: %v", err)
+			}
+		}
+	}
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		// Incoming parameters are appended sorted by name; the target's own project wins.
+		{"/jira?z=1&project=WEB&filter=a&filter=b", "https://jira.example.com/issues?project=OPS&filter=a&filter=b&z=1#list"},
+		{"/jira", "https://jira.example.com/issues?project=OPS#list"},
+		// term is consumed by $q:term and not forwarded a second time.
+		{"/search?term=go&page=2", "https://example.com/search?q=go&page=2"},
+		{"/plain?filter=123", "https://example.com/plain"},
+	}
+	for _, tt := range tests {
+		w := env.resolve(t, tt.path)
+		if loc := w.Header().Get("Location"); loc != tt.want {
+			t.Errorf("GET %s: Location = %q, want %q", tt.path, loc, tt.want)
+		}
+	}
+}
+
+// Governing: SPEC-0016 REQ "Bot Filtering"
+func TestResolve_FlagsBotClicks(t *testing.T) {
+	e := newResolveTestEnv(t)
+	e.seedLink(t, "wiki", "https://wiki.example.com")
+	clicks := make(chan store.ClickEvent, 2)
+	e.rh.clickCh = clicks
+	e.rh.bots = botfilter.New()
+
+	r := chi.NewRouter()
+	r.Get("/{slug}*", e.rh.Resolve)
+	for _, ua := range []string{"Mozilla/5.0 (X11; Linux x86_64; rv:128.0) Gecko/20100101 Firefox/128.0", "Slackbot-LinkExpanding 1.0"} {
+		req := httptest.NewRequest(http.MethodGet, "/wiki", nil)
+		req.Header.Set("User-Agent", ua)
+		r.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	if human := <-clicks; human.Bot {
+		t.Error("browser click flagged as a bot")
+	}
+	if bot := <-clicks; !bot.Bot {
+		t.Error("Slackbot click not flagged as a bot")
+	}
+}
+
+// Governing: SPEC-0016 REQ "Keyword Analytics"
+func TestResolve_RecordsKeywordHits(t *testing.T) {
+	e := newResolveTestEnv(t)
+	e.seedKeyword(t, "jira", "https://jira.example.com/browse/{slug}", "")
+	clicks := make(chan store.ClickEvent, 1)
+	e.rh.clickCh = clicks
+
+	r := chi.NewRouter()
+	r.Get("/{slug}*", e.rh.Resolve)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/jira/PROJ-1", nil))
+	if w.Code != http.StatusFound {
+		t.Fatalf("status = %d, want 302", w.Code)
+	}
+
+	select {
+	case c := <-clicks:
+		kw, _ := e.ks.GetByKeyword(context.Background(), "jira")
+		if c.KeywordID != kw.ID || c.LinkID != "" {
+			t.Errorf("click = {KeywordID:%q LinkID:%q}, want keyword %q and no link", c.KeywordID, c.LinkID, kw.ID)
+		}
+	default:
+		t.Fatal("no click recorded for keyword redirect")
+	}
+}
+
+// Governing: SPEC-0008 REQ "Secure Keywords"
+func TestResolve_SecureKeyword(t *testing.T) {
+	e := newResolveTestEnv(t)
+	e.seedKeyword(t, "jira", "https://jira.example.com/browse/{slug}", "")
+	kw, _ := e.ks.GetByKeyword(context.Background(), "jira")
+	if err := e.ks.SetSecure(context.Background(), kw.ID, true); err != nil {
+		t.Fatalf("SetSecure: %v", err)
+	}
+
+	r := chi.NewRouter()
+	r.Get("/{slug}*", e.rh.Resolve)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/jira/PROJ-1", nil))
+	if w.Code != http.StatusFound || w.Header().Get("Location") != "/auth/login?redirect=%2Fjira%2FPROJ-1" {
+		t.Errorf("anonymous: %d to %q, want 302 to login", w.Code, w.Header().Get("Location"))
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/jira/PROJ-1", nil)
+	req = req.WithContext(context.WithValue(req.Context(), auth.UserContextKey, &store.User{ID: e.userID}))
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if loc := w.Header().Get("Location"); w.Code != http.StatusFound || loc != "https://jira.example.com/browse/PROJ-1" {
+		t.Errorf("signed in: %d to %q, want 302 to the issue", w.Code, loc)
+	}
+}
+
+// Governing: SPEC-0016 REQ "Analytics Mode"
+func TestResolve_AnalyticsMode(t *testing.T) {
+	for _, tt := range []struct {
+		mode       string
+		wantClick  bool
+		wantUserID bool
+	}{
+		{config.AnalyticsFull, true, true},
+		{config.AnalyticsAnonymous, true, false},
+		{config.AnalyticsOff, false, false},
+	} {
+		t.Run(tt.mode, func(t *testing.T) {
+			e := newResolveTestEnv(t)
+			e.seedLink(t, "wiki", "https://wiki.example.com")
+			clicks := make(chan store.ClickEvent, 1)
+			e.rh.clickCh = clicks
+			e.rh.analyticsMode = tt.mode
+
+			r := chi.NewRouter()
+			r.Get("/{slug}*", e.rh.Resolve)
+			req := httptest.NewRequest(http.MethodGet, "/wiki", nil)
+			req = req.WithContext(context.WithValue(req.Context(), auth.UserContextKey, &store.User{ID: e.userID}))
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+			if w.Code != http.StatusFound {
+				t.Fatalf("status = %d, want %d", w.Code, http.StatusFound)
+			}
+
+			select {
+			case c := <-clicks:
+				if !tt.wantClick {
+					t.Fatal("click recorded with analytics off")
+				}
+				if got := c.UserID != ""; got != tt.wantUserID {
+					t.Errorf("click UserID = %q, want user recorded: %v", c.UserID, tt.wantUserID)
+				}
+			default:
+				if tt.wantClick {
+					t.Fatal("no click recorded")
+				}
+			}
+		})
+	}
+}
+
+// Governing: SPEC-0010 REQ "Strict Visibility"
+func TestResolve_StrictVisibility(t *testing.T) {
+	for _, tt := range []struct {
+		name   string
+		strict bool
+		want   int
+	}{
+		{"lenient", false, http.StatusFound},
+		{"strict", true, http.StatusForbidden},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newResolveTestEnv(t)
+			link, err := e.ls.Create(context.Background(), "wiki", "https://wiki.example.com", e.userID, "", "", "")
+			if err != nil {
+				t.Fatalf("seed link: %v", err)
+			}
+			if err := e.ls.UpdateVisibility(context.Background(), link.ID, "internal"); err != nil {
+				t.Fatalf("UpdateVisibility: %v", err)
+			}
+			e.rh.strictVisibility = tt.strict
+			if w := e.resolve(t, "/wiki"); w.Code != tt.want {
+				t.Errorf("status = %d, want %d", w.Code, tt.want)
+			}
+		})
+	}
+}
+
+// Governing: SPEC-0016 REQ "Campaign Sources"
+func TestResolve_CampaignSource(t *testing.T) {
+	e := newResolveTestEnv(t)
+	link, err := e.ls.Create(context.Background(), "launch", "https://example.com/launch", e.userID, "", "", "")
+	if err != nil {
+		t.Fatalf("seed link: %v", err)
+	}
+	if err := e.ls.SetPassQuery(context.Background(), link.ID, true); err != nil {
+		t.Fatalf("set pass query: %v", err)
+	}
+	clicks := make(chan store.ClickEvent, 3)
+	e.rh.clickCh = clicks
+	e.rh.campaigns = campaign.NewSigner([]byte("test key"))
+	tag, err := e.rh.campaigns.Sign(link.ID, "email", time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+
+	for _, tt := range []struct {
+		query      string
+		wantSource string
+	}{
+		{"?src=" + tag + "&ref=1", "email"},
+		{"?src=email&ref=1", ""},
+		{"?ref=1", ""},
+	} {
+		w := e.resolve(t, "/launch"+tt.query)
+		if loc := w.Header().Get("Location"); loc != "https://example.com/launch?ref=1" {
+			t.Errorf("GET /launch%s: Location = %q, want src dropped", tt.query, loc)
+		}
+		if c := <-clicks; c.Source != tt.wantSource {
+			t.Errorf("GET /launch%s: Source = %q, want %q", tt.query, c.Source, tt.wantSource)
+		}
+	}
+}
+
+// Governing: SPEC-0010 REQ "Signed Share URLs"
+func TestResolve_SignedShareURL(t *testing.T) {
+	e := newResolveTestEnv(t)
+	link, err := e.ls.Create(context.Background(), "payroll", "https://hr.example.com/payroll", e.userID, "", "", "secure")
+	if err != nil {
+		t.Fatalf("seed link: %v", err)
+	}
+	e.rh.shares = shareurl.NewSigner([]byte("test key"))
+	query, _, err := e.rh.shares.Sign(link.ID, time.Hour, time.Now())
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+	expired, _, err := e.rh.shares.Sign(link.ID, time.Second, time.Now().Add(-time.Hour))
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+
+	r := chi.NewRouter()
+	r.Get(shareurl.Prefix+"*", e.rh.ResolveShared)
+	r.Get("/{slug}*", e.rh.Resolve)
+	for _, tt := range []struct {
+		name, path, wantLocation string
+	}{
+		{"signed", "/s/payroll?" + query.Encode(), "https://hr.example.com/payroll"},
+		{"expired", "/s/payroll?" + expired.Encode(), "/auth/login?redirect=%2Fpayroll"},
+		{"signature on plain path", "/payroll?" + query.Encode(), "/auth/login?redirect=" + url.QueryEscape("/payroll?"+query.Encode())},
+	} {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+		if w.Code != http.StatusFound || w.Header().Get("Location") != tt.wantLocation {
+			t.Errorf("%s: got %d %q, want 302 %q", tt.name, w.Code, w.Header().Get("Location"), tt.wantLocation)
+		}
+	}
+}
+
+func TestAppendUTM(t *testing.T) {
+	defaults := map[string]string{"utm_source": "golinks"}
+	tests := []struct {
+		target string
+		params map[string]string
+		want   string
+	}{
+		{"https://example.com", nil, "https://example.com?utm_source=golinks"},
+		{"https://example.com/?q=a b", nil, "https://example.com/?q=a b&utm_source=golinks"},
+		{"https://example.com/?utm_source=newsletter", map[string]string{"utm_term": "x y"}, "https://example.com/?utm_source=newsletter&utm_term=x+y"},
+		{"https://example.com/?", nil, "https://example.com/?utm_source=golinks"},
+		{"mailto:team@example.com", nil, "mailto:team@example.com"},
+	}
+	for _, tt := range tests {
+		if got := appendUTM(tt.target, defaults, tt.params); got != tt.want {
+			t.Errorf("appendUTM(%q, %v) = %q, want %q", tt.target, tt.params, got, tt.want)
+		}
+	}
+	if got := appendUTM("https://example.com", nil, nil); got != "https://example.com" {
+		t.Errorf("no parameters: got %q", got)
+	}
+}
+
 func TestResolve_ExactMatchPriority(t *testing.T) {
 	env := newResolveTestEnv(t)
 	env.seedLink(t, "github", "https://github.com/$username")
@@ -218,6 +544,286 @@ func TestResolve_DuplicatePlaceholder(t *testing.T) {
 	}
 }
 
+// Governing: SPEC-0009 REQ "Rest-Capture Placeholder"
+func TestResolve_RestCapture(t *testing.T) {
+	env := newResolveTestEnv(t)
+	env.seedLink(t, "gh", "https://github.com/$org/$repo/$rest*")
+
+	w := env.resolve(t, "/gh/joestump/joe-links/blob/main/read%20me.md")
+	if w.Code != http.StatusFound {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusFound)
+	}
+	want := "https://github.com/joestump/joe-links/blob/main/read%20me.md"
+	if loc := w.Header().Get("Location"); loc != want {
+		t.Errorf("Location = %q, want %q", loc, want)
+	}
+}
+
+func TestResolve_RestCapture_SingleSegment(t *testing.T) {
+	env := newResolveTestEnv(t)
+	env.seedLink(t, "gh", "https://github.com/$org/$repo/$rest*")
+
+	w := env.resolve(t, "/gh/joestump/joe-links/issues")
+	if loc := w.Header().Get("Location"); loc != "https://github.com/joestump/joe-links/issues" {
+		t.Errorf("Location = %q, want %q", loc, "https://github.com/joestump/joe-links/issues")
+	}
+}
+
+func TestResolve_RestCapture_TooFew(t *testing.T) {
+	env := newResolveTestEnv(t)
+	env.seedLink(t, "gh", "https://github.com/$org/$repo/$rest*")
+
+	w := env.resolve(t, "/gh/joestump/joe-links")
+	if w.Code != http.StatusNotFound {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusNotFound)
+	}
+}
+
+func TestResolve_PlaceholderPrefixCollision(t *testing.T) {
+	env := newResolveTestEnv(t)
+	env.seedLink(t, "pair", "https://example.com/$re/$rest")
+
+	w := env.resolve(t, "/pair/a/b")
+	if loc := w.Header().Get("Location"); loc != "https://example.com/a/b" {
+		t.Errorf("Location = %q, want %q", loc, "https://example.com/a/b")
+	}
+}
+
+func TestResolve_QueryPlaceholder(t *testing.T) {
+	env := newResolveTestEnv(t)
+	env.seedLink(t, "search", "https://example.com/search?q=$q:term")
+
+	w := env.resolve(t, "/search?term=foo+bar%26baz")
+	if w.Code != http.StatusFound {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusFound)
+	}
+	want := "https://example.com/search?q=foo+bar%26baz"
+	if loc := w.Header().Get("Location"); loc != want {
+		t.Errorf("Location = %q, want %q", loc, want)
+	}
+}
+
+func TestResolve_QueryPlaceholder_Missing(t *testing.T) {
+	env := newResolveTestEnv(t)
+	env.seedLink(t, "search", "https://example.com/search?q=$q:term")
+
+	w := env.resolve(t, "/search")
+	if loc := w.Header().Get("Location"); loc != "https://example.com/search?q=" {
+		t.Errorf("Location = %q, want %q", loc, "https://example.com/search?q=")
+	}
+}
+
+func TestResolve_QueryAndPathPlaceholders(t *testing.T) {
+	env := newResolveTestEnv(t)
+	env.seedLink(t, "jira", "https://jira.example.com/browse/$key?focus=$q:comment&q=$q")
+
+	w := env.resolve(t, "/jira/PROJ-1/x?comment=42")
+	if w.Code != http.StatusFound {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusFound)
+	}
+	want := "https://jira.example.com/browse/PROJ-1?focus=42&q=x"
+	if loc := w.Header().Get("Location"); loc != want {
+		t.Errorf("Location = %q, want %q", loc, want)
+	}
+}
+
+func TestResolve_QueryPlaceholder_NotRescanned(t *testing.T) {
+	env := newResolveTestEnv(t)
+	env.seedLink(t, "echo", "https://example.com/$v?x=$q:secret")
+
+	w := env.resolve(t, "/echo/$q:secret?secret=s")
+	want := "https://example.com/$q:secret?x=s"
+	if loc := w.Header().Get("Location"); loc != want {
+		t.Errorf("Location = %q, want %q", loc, want)
+	}
+}
+
+// seedConstrainedLink creates a link and sets its variable constraints.
+func (e *resolveTestEnv) seedConstrainedLink(t testing.TB, slug, url string, constraints map[string]string) {
+	t.Helper()
+	l, err := e.ls.Create(context.Background(), slug, url, e.userID, "", "", "")
+	if err != nil {
+		t.Fatalf("seed link %q: %v", slug, err)
+	}
+	if err := e.ls.SetVariableConstraints(context.Background(), l.ID, constraints); err != nil {
+		t.Fatalf("set constraints: %v", err)
+	}
+}
+
+func TestResolve_ConstraintMatch(t *testing.T) {
+	env := newResolveTestEnv(t)
+	env.seedConstrainedLink(t, "issue", "https://example.com/issues/$id", map[string]string{"id": "[0-9]+"})
+
+	w := env.resolve(t, "/issue/42")
+	if loc := w.Header().Get("Location"); loc != "https://example.com/issues/42" {
+		t.Errorf("Location = %q, want %q", loc, "https://example.com/issues/42")
+	}
+}
+
+func TestResolve_ConstraintMismatch(t *testing.T) {
+	env := newResolveTestEnv(t)
+	env.seedConstrainedLink(t, "issue", "https://example.com/issues/$id", map[string]string{"id": "[0-9]+"})
+
+	w := env.resolve(t, "/issue/42abc")
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusNotFound)
+	}
+	body := w.Body.String()
+	if !strings.Contains(body, "Invalid value for") || !strings.Contains(body, "42abc") {
+		t.Errorf("expected constraint explanation in body")
+	}
+}
+
+func TestResolve_ConstraintOnRestCapture(t *testing.T) {
+	env := newResolveTestEnv(t)
+	env.seedConstrainedLink(t, "docs", "https://example.com/$path*", map[string]string{"path": "[a-z]+(/[a-z]+)*"})
+
+	if w := env.resolve(t, "/docs/guide/install"); w.Header().Get("Location") != "https://example.com/guide/install" {
+		t.Errorf("Location = %q", w.Header().Get("Location"))
+	}
+	if w := env.resolve(t, "/docs/guide/v2"); w.Code != http.StatusNotFound {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusNotFound)
+	}
+}
+
+// Governing: SPEC-0009 REQ "Templated Link Help Page"
+func TestResolve_HelpPage(t *testing.T) {
+	env := newResolveTestEnv(t)
+	env.seedConstrainedLink(t, "gh", "https://github.com/$org/$repo/$rest*?ref=$q:ref", map[string]string{"org": "[a-z]+"})
+
+	for _, path := range []string{"/gh?help", "/gh/joestump?help"} {
+		w := env.resolve(t, path)
+		if w.Code != http.StatusOK {
+			t.Fatalf("%s: status = %d, want %d", path, w.Code, http.StatusOK)
+		}
+		body := w.Body.String()
+		for _, want := range []string{"gh/&lt;org&gt;/&lt;repo&gt;/&lt;rest&gt;/…?ref=&lt;ref&gt;", "$rest*", "[a-z]&#43;"} {
+			if !strings.Contains(body, want) {
+				t.Errorf("%s: body missing %q", path, want)
+			}
+		}
+	}
+}
+
+func TestResolve_HelpIgnoredForStaticLink(t *testing.T) {
+	env := newResolveTestEnv(t)
+	env.seedLink(t, "example", "https://example.com")
+
+	w := env.resolve(t, "/example?help")
+	if w.Code != http.StatusFound {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusFound)
+	}
+}
+
+func TestResolve_HelpKeptByQueryPlaceholder(t *testing.T) {
+	env := newResolveTestEnv(t)
+	env.seedLink(t, "man", "https://example.com/man?page=$q:help")
+
+	w := env.resolve(t, "/man?help=ls")
+	if loc := w.Header().Get("Location"); loc != "https://example.com/man?page=ls" {
+		t.Errorf("Location = %q, want %q", loc, "https://example.com/man?page=ls")
+	}
+}
+
+func TestResolve_ArityMismatch_LinksToHelp(t *testing.T) {
+	env := newResolveTestEnv(t)
+	env.seedLink(t, "pair", "https://example.com/$a/$b")
+
+	w := env.resolve(t, "/pair/one")
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusNotFound)
+	}
+	if !strings.Contains(w.Body.String(), `href="/pair?help"`) {
+		t.Errorf("expected link to help page")
+	}
+}
+
+// Governing: SPEC-0009 REQ "Variable Defaults"
+func TestResolve_VariableDefaults(t *testing.T) {
+	env := newResolveTestEnv(t)
+	env.seedLink(t, "dash", "https://dash.example.com/$env:prod")
+	env.seedLink(t, "repo", "https://github.com/$org/$repo:joe-links")
+
+	tests := []struct {
+		path, want string
+		code       int
+	}{
+		{"/dash", "https://dash.example.com/prod", http.StatusFound},
+		{"/dash/staging", "https://dash.example.com/staging", http.StatusFound},
+		{"/dash/staging/extra", "", http.StatusNotFound},
+		{"/repo/joestump", "https://github.com/joestump/joe-links", http.StatusFound},
+		{"/repo/joestump/other", "https://github.com/joestump/other", http.StatusFound},
+		// A required variable is still required on an exact match, so the
+		// template is used as written.
+		{"/repo", "https://github.com/$org/$repo:joe-links", http.StatusFound},
+	}
+	for _, tt := range tests {
+		w := env.resolve(t, tt.path)
+		if w.Code != tt.code {
+			t.Errorf("%s: status = %d, want %d", tt.path, w.Code, tt.code)
+			continue
+		}
+		if loc := w.Header().Get("Location"); tt.want != "" && loc != tt.want {
+			t.Errorf("%s: Location = %q, want %q", tt.path, loc, tt.want)
+		}
+	}
+
+	w := env.resolve(t, "/dash?help")
+	if body := w.Body.String(); !strings.Contains(body, "dash[/&lt;env&gt;]") || !strings.Contains(body, "$env:prod") {
+		t.Errorf("help page missing optional variable usage")
+	}
+
+	resp := env.rh.TestResolve(context.Background(), "/dash", "", nil)
+	if len(resp.Variables) != 1 || resp.Variables[0].Name != "env" || resp.Variables[0].Value != "prod" || !resp.Variables[0].Default {
+		t.Errorf("test resolve variables = %+v", resp.Variables)
+	}
+}
+
+// Governing: SPEC-0009 REQ "Named Query Variables"
+func TestResolve_NamedQueryVariables(t *testing.T) {
+	env := newResolveTestEnv(t)
+	env.seedLink(t, "jira", "https://jira.example.com/browse/$ticket")
+	env.seedLink(t, "gh", "https://github.com/$org/$repo:joe-links/$path*")
+	env.seedConstrainedLink(t, "pr", "https://github.com/joestump/joe-links/pull/$id", map[string]string{"id": "[0-9]+"})
+
+	tests := []struct {
+		path, want string
+		code       int
+	}{
+		{"/jira?ticket=ABC-123", "https://jira.example.com/browse/ABC-123", http.StatusFound},
+		// A path segment wins over the query parameter.
+		{"/jira/ABC-1?ticket=ABC-2", "https://jira.example.com/browse/ABC-1", http.StatusFound},
+		{"/jira?ticket=", "https://jira.example.com/browse/$ticket", http.StatusFound},
+		{"/gh/joestump?path=blob/main/README.md", "https://github.com/joestump/joe-links/blob/main/README.md", http.StatusFound},
+		{"/gh?org=joestump&repo=other&path=issues", "https://github.com/joestump/other/issues", http.StatusFound},
+		{"/pr?id=42", "https://github.com/joestump/joe-links/pull/42", http.StatusFound},
+		{"/pr?id=abc", "", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		w := env.resolve(t, tt.path)
+		if w.Code != tt.code {
+			t.Errorf("%s: status = %d, want %d", tt.path, w.Code, tt.code)
+			continue
+		}
+		if loc := w.Header().Get("Location"); tt.want != "" && loc != tt.want {
+			t.Errorf("%s: Location = %q, want %q", tt.path, loc, tt.want)
+		}
+	}
+
+	// Passthrough does not forward parameters that filled a variable.
+	link, err := env.ls.GetBySlug(context.Background(), "jira")
+	if err != nil {
+		t.Fatalf("GetBySlug: %v", err)
+	}
+	if err := env.ls.SetPassQuery(context.Background(), link.ID, true); err != nil {
+		t.Fatalf("SetPassQuery: %v", err)
+	}
+	w := env.resolve(t, "/jira?ticket=ABC-123&focus=1")
+	if loc := w.Header().Get("Location"); loc != "https://jira.example.com/browse/ABC-123?focus=1" {
+		t.Errorf("passthrough Location = %q", loc)
+	}
+}
+
 func TestResolve_PathKeywordRouting(t *testing.T) {
 	env := newResolveTestEnv(t)
 	env.seedKeyword(t, "gh", "https://github.com/{slug}", "GitHub shortcut")
@@ -286,3 +892,156 @@ func TestResolve_PathKeywordRouting_SkipWhenHostIsKeyword(t *testing.T) {
 		t.Errorf("Location = %q, want %q", loc, "https://go.example.com/go/slack")
 	}
 }
+
+// Governing: SPEC-0009 REQ "Link Form Preview"
+func TestPreviewURL(t *testing.T) {
+	h := &LinksHandler{}
+	tests := []struct {
+		name, tmpl, constraints, sample, want string
+	}{
+		{"positional", "https://jira.example.com/browse/$ticket", "", "PROJ-1", "https://jira.example.com/browse/PROJ-1"},
+		{"rest and query", "https://github.com/$org/$path*?q=$q:q", "", "joestump/a/b?q=x y", "https://github.com/joestump/a/b?q=x&#43;y"},
+		{"arity", "https://example.com/$a/$b", "", "one", "Wrong number of values"},
+		{"constraint", "https://example.com/$id", "id=[0-9]+", "abc", "$id must match [0-9]&#43;"},
+		{"bad constraint", "https://example.com/$id", "other=x", "1", "not in the URL template"},
+		{"static", "https://example.com/", "", "anything", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := url.Values{"url": {tt.tmpl}, "constraints": {tt.constraints}, "sample": {tt.sample}}
+			w := httptest.NewRecorder()
+			h.PreviewURL(w, httptest.NewRequest(http.MethodGet, "/dashboard/links/preview?"+q.Encode(), nil))
+			body := strings.TrimSpace(w.Body.String())
+			if tt.want == "" {
+				if body != "" {
+					t.Errorf("expected empty preview, got %q", body)
+				}
+				return
+			}
+			if !strings.Contains(body, tt.want) {
+				t.Errorf("body = %q, want it to contain %q", body, tt.want)
+			}
+		})
+	}
+}
+
+// Governing: SPEC-0005 REQ "Resolve Test Endpoint"
+func TestTestResolve_Variables(t *testing.T) {
+	env := newResolveTestEnv(t)
+	env.seedLink(t, "gh", "https://github.com/$org/$path*?tab=$q:tab")
+
+	resp := env.rh.TestResolve(context.Background(), "/gh/joestump/a/b?tab=code", "", nil)
+	if resp.Outcome != "redirect" || resp.Status != http.StatusFound {
+		t.Fatalf("outcome = %s %d, trace %q", resp.Outcome, resp.Status, resp.Trace)
+	}
+	if resp.Target != "https://github.com/joestump/a/b?tab=code" {
+		t.Errorf("target = %q", resp.Target)
+	}
+	if len(resp.Variables) != 2 || resp.Variables[0].Name != "org" || resp.Variables[1].Value != "a/b" {
+		t.Errorf("variables = %+v", resp.Variables)
+	}
+	if resp.Link == nil || resp.Link.Slug != "gh" {
+		t.Errorf("link = %+v", resp.Link)
+	}
+}
+
+func TestTestResolve_SecureLink(t *testing.T) {
+	env := newResolveTestEnv(t)
+	if _, err := env.ls.Create(context.Background(), "vault", "https://vault.example.com", env.userID, "", "", "secure"); err != nil {
+		t.Fatalf("seed link: %v", err)
+	}
+	ctx := context.Background()
+
+	anon := env.rh.TestResolve(ctx, "vault", "", nil)
+	if anon.Outcome != "login_required" || anon.Link.URL != "" {
+		t.Errorf("anonymous: outcome = %s, url = %q", anon.Outcome, anon.Link.URL)
+	}
+	stranger := env.rh.TestResolve(ctx, "vault", "", &store.User{ID: "stranger", Role: "user"})
+	if stranger.Outcome != "forbidden" || stranger.Status != http.StatusForbidden || stranger.Target != "" {
+		t.Errorf("stranger: outcome = %s %d, target = %q", stranger.Outcome, stranger.Status, stranger.Target)
+	}
+	owner := env.rh.TestResolve(ctx, "vault", "", &store.User{ID: env.userID, Role: "user"})
+	if owner.Outcome != "redirect" || owner.Target != "https://vault.example.com" {
+		t.Errorf("owner: outcome = %s, target = %q, trace %q", owner.Outcome, owner.Target, owner.Trace)
+	}
+}
+
+func TestTestResolve_NotFoundAndKeyword(t *testing.T) {
+	env := newResolveTestEnv(t)
+	env.seedKeyword(t, "gh", "https://github.com/{slug}", "")
+	ctx := context.Background()
+
+	if resp := env.rh.TestResolve(ctx, "missing/path", "", nil); resp.Outcome != "not_found" || resp.Link != nil {
+		t.Errorf("missing: outcome = %s", resp.Outcome)
+	}
+	resp := env.rh.TestResolve(ctx, "gh/joestump", "", nil)
+	if resp.Outcome != "keyword" || resp.Keyword != "gh" || resp.Target != "https://github.com/joestump" {
+		t.Errorf("keyword: %+v", resp)
+	}
+}
+
+func TestResolve_DebugTraceHeader(t *testing.T) {
+	env := newResolveTestEnv(t)
+	env.seedLink(t, "gh", "https://github.com/$org/$repo")
+	env.rh.debug = true
+
+	r := chi.NewRouter()
+	r.Get("/{slug}*", env.rh.Resolve)
+	serve := func(user *store.User) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/gh/joestump/joe-links", nil)
+		if user != nil {
+			req = req.WithContext(context.WithValue(req.Context(), auth.UserContextKey, user))
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	w := serve(&store.User{ID: "admin", Role: "admin"})
+	if w.Code != http.StatusFound {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusFound)
+	}
+	trace := w.Header().Get(traceHeader)
+	for _, want := range []string{
+		`exact slug "gh/joestump/joe-links": no match`,
+		`prefix "gh/joestump": no match`,
+		`prefix "gh": matched`,
+		"visibility: public link",
+		"redirect to https://github.com/joestump/joe-links",
+	} {
+		if !strings.Contains(trace, want) {
+			t.Errorf("trace %q missing %q", trace, want)
+		}
+	}
+
+	if w := serve(&store.User{ID: "someone", Role: "user"}); w.Header().Get(traceHeader) != "" {
+		t.Errorf("non-admin received trace header %q", w.Header().Get(traceHeader))
+	}
+	if w := serve(nil); w.Header().Get(traceHeader) != "" {
+		t.Errorf("anonymous request received trace header")
+	}
+
+	env.rh.debug = false
+	if w := serve(&store.User{ID: "admin", Role: "admin"}); w.Header().Get(traceHeader) != "" {
+		t.Errorf("trace header sent with debugging disabled")
+	}
+}
+
+func TestResolve_Alias(t *testing.T) {
+	env := newResolveTestEnv(t)
+	link, err := env.ls.Create(context.Background(), "jira", "https://jira.example.com/browse/$key", env.userID, "", "", "")
+	if err != nil {
+		t.Fatalf("seed link: %v", err)
+	}
+	if _, err := env.ls.AddAlias(context.Background(), link.ID, "ticket"); err != nil {
+		t.Fatalf("add alias: %v", err)
+	}
+
+	w := env.resolve(t, "/ticket/PROJ-7")
+	if w.Code != http.StatusFound {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusFound)
+	}
+	if loc := w.Header().Get("Location"); loc != "https://jira.example.com/browse/PROJ-7" {
+		t.Errorf("Location = %q", loc)
+	}
+}
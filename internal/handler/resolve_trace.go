@@ -0,0 +1,75 @@
+// Governing: SPEC-0009 REQ "Resolver Decision Tracing", ADR-0013
+package handler
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+)
+
+// traceHeader carries the resolver's decisions back to admins when resolver
+// debugging is enabled.
+const traceHeader = "X-Joe-Trace"
+
+// resolveTrace collects the decisions made while resolving one request. A nil
+// *resolveTrace discards them, so resolution pays nothing when debugging is off.
+type resolveTrace struct {
+	steps []string
+}
+
+// add records one step. It is a no-op on a nil trace.
+func (t *resolveTrace) add(format string, args ...any) {
+	if t == nil {
+		return
+	}
+	t.steps = append(t.steps, fmt.Sprintf(format, args...))
+}
+
+// String joins the steps on one line, suitable for a log entry or header.
+func (t *resolveTrace) String() string {
+	return strings.Join(t.steps, " | ")
+}
+
+// traceWriter adds the trace header to the response when it is first written,
+// so every exit path of Resolve reports the steps taken up to that point.
+type traceWriter struct {
+	http.ResponseWriter
+	trace   *resolveTrace
+	written bool
+}
+
+func (w *traceWriter) WriteHeader(status int) {
+	if !w.written {
+		w.written = true
+		w.Header().Set(traceHeader, w.trace.String())
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *traceWriter) Write(b []byte) (int, error) {
+	if !w.written {
+		w.WriteHeader(http.StatusOK)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer.
+func (w *traceWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// startTrace returns a trace for the resolution of fullPath when debugging is
+// enabled, and nil otherwise. The steps are logged once the request finishes
+// (via the returned func) and, for admins, sent in the X-Joe-Trace header.
+// Governing: SPEC-0009 REQ "Resolver Decision Tracing"
+func (h *ResolveHandler) startTrace(w http.ResponseWriter, admin bool, fullPath string) (*resolveTrace, http.ResponseWriter, func()) {
+	if !h.debug {
+		return nil, w, func() {}
+	}
+	trace := &resolveTrace{}
+	if admin {
+		w = &traceWriter{ResponseWriter: w, trace: trace}
+	}
+	return trace, w, func() { log.Printf("resolve trace %q: %s", fullPath, trace) }
+}
@@ -16,18 +16,39 @@ import (
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/joestump/joe-links/internal/api"
 	"github.com/joestump/joe-links/internal/auth"
+	authsaml "github.com/joestump/joe-links/internal/auth/saml"
+	"github.com/joestump/joe-links/internal/botfilter"
+	"github.com/joestump/joe-links/internal/campaign"
+	"github.com/joestump/joe-links/internal/demo"
+	"github.com/joestump/joe-links/internal/events"
 	"github.com/joestump/joe-links/internal/llm"
+	"github.com/joestump/joe-links/internal/mailer"
+	"github.com/joestump/joe-links/internal/netpolicy"
+	"github.com/joestump/joe-links/internal/setup"
+	"github.com/joestump/joe-links/internal/shareurl"
+	"github.com/joestump/joe-links/internal/status"
 	"github.com/joestump/joe-links/internal/store"
+	"github.com/joestump/joe-links/internal/tracing"
 	"github.com/joestump/joe-links/web"
 	_ "github.com/joestump/joe-links/docs/swagger"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
-	httpSwagger "github.com/swaggo/http-swagger/v2"
+	"google.golang.org/grpc"
 )
 
 // Deps holds all dependencies required to build the HTTP router.
 type Deps struct {
 	SessionManager *scs.SessionManager
+	SessionRefresher *auth.SessionRefresher // Governing: SPEC-0001 REQ "Refresh-Token Session Extension"; nil when disabled
+	SessionTracker   *auth.SessionTracker   // Governing: SPEC-0001 REQ "Session Management"; nil disables the sessions page
+	WebAuthn         *auth.WebAuthn         // Governing: SPEC-0001 REQ "WebAuthn Step-Up"; nil disables passkeys and step-up
+	WebAuthnStore    *store.WebAuthnStore
+	AdminNetworks    *netpolicy.Allowlist   // Governing: SPEC-0001 REQ "Admin Network Allowlist"; nil allows every client on /admin
+	AdminAPINetworks *netpolicy.Allowlist   // nil allows every client on /api/v1/admin
+	TrustedProxies   *netpolicy.Allowlist   // peers whose forwarding headers set the client address; nil trusts none
+	SecurityHeaders  SecurityHeaders        // Governing: SPEC-0001 REQ "Security Headers"; the zero value enforces DefaultCSP without HSTS
+	TokenLockout     *auth.TokenLockout     // Governing: SPEC-0006 REQ "Token Brute-Force Lockout"; nil disables lockout
 	AuthHandlers   *auth.Handlers
+	SAMLHandlers   *authsaml.Handlers // Governing: SPEC-0001 REQ "SAML Authentication"; set instead of AuthHandlers when JOE_AUTH_PROVIDER=saml
 	AuthMiddleware *auth.Middleware
 	LinkStore      *store.LinkStore
 	OwnershipStore *store.OwnershipStore
@@ -36,9 +57,40 @@ type Deps struct {
 	TokenStore     auth.TokenStore
 	KeywordStore   *store.KeywordStore
 	ClickStore     *store.ClickStore   // Governing: SPEC-0016 REQ "Click Recording", ADR-0016
+	HealthStore    *store.HealthStore  // Governing: SPEC-0001 REQ "Link Health Checks"
+	ReservedSlugStore *store.ReservedSlugStore // Governing: SPEC-0002 REQ "Reserved Slugs"
+	DomainRuleStore *store.DomainRuleStore // Governing: SPEC-0002 REQ "Destination Domain Rules"
+	TeamStore      *store.TeamStore        // Governing: SPEC-0002 REQ "Team Ownership"
+	SavedSearchStore *store.SavedSearchStore // Governing: SPEC-0004 REQ "Saved Searches"
+	PolicyStore    *store.PolicyStore      // Governing: SPEC-0011 REQ "Link Lifecycle Policies"
+	PreferenceStore *store.PreferenceStore // Governing: SPEC-0004 REQ "Sortable Link Lists"; nil keeps list sorts for the request only
+	ModerationEnabled bool                 // Governing: SPEC-0011 REQ "Public Link Moderation"; JOE_MODERATION_ENABLED
+	TelemetryStore *store.TelemetryStore   // Governing: SPEC-0011 REQ "Instance Telemetry"; nil disables /admin/telemetry
+	DBDriver       string                  // database driver name, reported on /admin/telemetry
+	TelemetryEndpoint string               // Governing: SPEC-0011 REQ "Instance Telemetry"; "" unless JOE_TELEMETRY_SHARE is on
 	ClickCh        chan<- store.ClickEvent // Governing: SPEC-0016 REQ "Click Recording", ADR-0016
+	UsageStore     *store.UsageStore      // Governing: SPEC-0006 REQ "API Usage Tracking"
+	UsageRecorder  *api.UsageRecorder     // Governing: SPEC-0006 REQ "API Usage Tracking"; nil disables recording
 	Suggester      llm.Suggester          // Governing: SPEC-0017 REQ "Suggest API Endpoint", ADR-0017; nil when LLM is not configured
 	ShortKeyword   string // optional override (e.g. "go"); defaults to first label of HTTP host
+	ResolverDebug  bool   // Governing: SPEC-0009 REQ "Resolver Decision Tracing"; log resolver decisions, X-Joe-Trace for admins
+	UTMDefaults    map[string]string // Governing: SPEC-0002 REQ "UTM Parameters"; appended to every link target
+	BotFilter      *botfilter.Detector // Governing: SPEC-0016 REQ "Bot Filtering"; flags bot clicks; nil flags none
+	AnalyticsMode  string              // Governing: SPEC-0016 REQ "Analytics Mode"; config.AnalyticsFull (default), AnalyticsAnonymous, or AnalyticsOff
+	Campaigns      *campaign.Signer    // Governing: SPEC-0016 REQ "Campaign Sources"; signs and verifies ?src= tags; nil records no sources
+	StrictVisibility bool              // Governing: SPEC-0010 REQ "Strict Visibility"; deny links with unknown visibility values
+	GRPCServer       grpc.ServiceRegistrar // Governing: SPEC-0005 REQ "gRPC Links Service"; nil unless JOE_GRPC_ADDR is set
+	ShareURLs      *shareurl.Signer    // Governing: SPEC-0010 REQ "Signed Share URLs"; signs and verifies /s/ share URLs; nil disables them
+	RobotsIndexSlugs bool              // Governing: SPEC-0012 REQ "Sitemap and Robots"; let robots.txt admit slug redirects
+	Events         *events.Broker      // Governing: SPEC-0004 REQ "Live Dashboard Updates"; nil disables /dashboard/events
+	StatusChecker  *status.Checker   // Governing: SPEC-0016 REQ "Status Page"; nil disables /status
+	Notifier       *mailer.Notifier  // Governing: SPEC-0001 REQ "Email Notifications"; nil disables emails
+	Setup          *setup.Service    // Governing: SPEC-0001 REQ "First-Run Setup"; nil unless setup was pending at startup
+	AdminEmail     string            // JOE_ADMIN_EMAIL, shown by the setup wizard
+	Demo           *demo.Sandbox     // Governing: SPEC-0001 REQ "Demo Mode"; nil unless JOE_DEMO_MODE
+	TenantStore    *store.TenantStore // Governing: SPEC-0001 REQ "Multi-Tenancy"; nil unless JOE_TENANCY_ENABLED
+	IdempotencyStore *store.IdempotencyStore // Governing: SPEC-0005 REQ "Idempotent Link Creation"
+	SettingsStore  *store.SettingsStore // Governing: SPEC-0003 REQ "Instance Branding"; nil keeps the stock look and disables /admin/appearance
 }
 
 // NewRouter assembles the full chi router with all middleware and routes.
@@ -48,14 +100,40 @@ func NewRouter(deps Deps) http.Handler {
 	if deps.ShortKeyword != "" {
 		configuredShortKeyword = deps.ShortKeyword
 	}
+	demoMode = deps.Demo != nil
+	useBrandingSettings(deps.SettingsStore)
+	useAnnouncementSettings(deps.SettingsStore)
+	uiPrefs = deps.PreferenceStore
+	passkeysEnabled = deps.WebAuthn != nil
 
 	r := chi.NewRouter()
 
 	// Standard middleware
+	r.Use(tracing.Middleware) // Governing: SPEC-0001 REQ "Distributed Tracing" — outermost so spans cover everything
 	r.Use(middleware.Logger)
-	r.Use(middleware.Recoverer)
-	r.Use(middleware.RealIP)
+	r.Use(deps.SecurityHeaders.Middleware) // Governing: SPEC-0001 REQ "Security Headers" — before Recoverer so error pages get them too
+	r.Use(Recoverer)                       // Governing: SPEC-0001 REQ "Error Pages" — branded 500 on panic
+	r.Use(netpolicy.RealIP(deps.TrustedProxies)) // Governing: SPEC-0001 REQ "Admin Network Allowlist" — only trusted proxies may name the client
+	// Governing: SPEC-0001 REQ "Multi-Tenancy" — before anything that reads the stores
+	if deps.TenantStore != nil {
+		r.Use(TenantMiddleware(deps.TenantStore))
+	}
 	r.Use(deps.SessionManager.LoadAndSave)
+	if deps.SessionRefresher != nil {
+		r.Use(deps.SessionRefresher.Extend)
+	}
+	// Governing: SPEC-0001 REQ "Demo Mode" — every visitor is the demo user
+	if deps.Demo != nil {
+		r.Use(deps.Demo.AutoLogin(deps.SessionManager))
+	}
+	// Governing: SPEC-0001 REQ "Session Management"
+	if deps.SessionTracker != nil {
+		r.Use(deps.SessionTracker.Track)
+	}
+	// Governing: SPEC-0001 REQ "CSRF Protection" — the API authenticates with
+	// bearer tokens or an explicit session header, never the cookie alone, and
+	// the SAML IdP posts its assertion from another site
+	r.Use(auth.CSRFProtect(deps.SessionManager.Cookie.Secure, denyCSRF, "/api/", authsaml.ACSPath))
 
 	// Static assets (embedded). Use fs.Sub so the file server sees
 	// css/app.css and js/htmx.min.js directly, not static/css/... paths.
@@ -63,46 +141,102 @@ func NewRouter(deps Deps) http.Handler {
 	if err != nil {
 		panic("failed to sub static FS: " + err.Error())
 	}
-	r.Handle("/static/*", http.StripPrefix("/static", http.FileServerFS(staticSub)))
+	r.Handle("/static/*", staticHandler(staticSub))
 
 	// Auth routes (no auth required)
-	r.Get("/auth/login", deps.AuthHandlers.Login)
-	r.Get("/auth/callback", deps.AuthHandlers.Callback)
-	r.Post("/auth/logout", deps.AuthHandlers.Logout)
+	if deps.Demo != nil {
+		// Governing: SPEC-0001 REQ "Demo Mode"
+		r.Get("/auth/login", demo.Login)
+		r.Post("/auth/logout", demo.Logout)
+	} else if deps.SAMLHandlers != nil {
+		// Governing: SPEC-0001 REQ "SAML Authentication"
+		r.Get("/auth/login", deps.SAMLHandlers.Login)
+		r.Get(authsaml.MetadataPath, deps.SAMLHandlers.Metadata)
+		r.Post(authsaml.ACSPath, deps.SAMLHandlers.ACS)
+		r.Post("/auth/logout", deps.SAMLHandlers.Logout)
+	} else {
+		r.Get("/auth/login", deps.AuthHandlers.Login)
+		r.Get("/auth/callback", deps.AuthHandlers.Callback)
+		r.Post("/auth/logout", deps.AuthHandlers.Logout)
+	}
 
 	// Theme toggle — no auth required, must precede auth group.
 	// Governing: SPEC-0003 REQ "HTMX Theme Endpoint"
+	// OptionalUser lets a signed-in user's choice be saved to their account.
 	themeHandler := NewThemeHandler()
-	r.Post("/dashboard/theme", themeHandler.Toggle)
+	themeHandler.prefs = deps.PreferenceStore
+	r.With(deps.AuthMiddleware.OptionalUser).Post("/dashboard/theme", themeHandler.Toggle)
 
 	// Landing page (unauthenticated; redirects authenticated to /dashboard)
 	// Uses OptionalUser so we can detect logged-in users without requiring auth.
 	// Governing: SPEC-0004 REQ "Landing Page"
 	landing := NewLandingHandler()
+	landing.setup = deps.Setup
 	r.With(deps.AuthMiddleware.OptionalUser).Get("/", landing.Index)
 
+	// First-run setup wizard, only on instances that had not finished setup
+	// when the server started; it 404s once finished.
+	// Governing: SPEC-0001 REQ "First-Run Setup"
+	if deps.Setup != nil {
+		setupWeb := NewSetupHandler(deps.Setup, deps.AdminEmail)
+		r.Route("/setup", func(r chi.Router) {
+			r.Use(deps.AuthMiddleware.OptionalUser, setupWeb.RequirePending)
+			r.Get("/", setupWeb.Index)
+			r.Post("/admin", setupWeb.SaveAdmin)
+			r.Post("/keyword", setupWeb.SaveKeyword)
+			r.Post("/demo", setupWeb.CreateDemoLinks)
+			r.Post("/finish", setupWeb.Finish)
+		})
+	}
+
 	// Authenticated routes
 	// Governing: SPEC-0004 REQ "Route Registration and Priority" — dashboard, link, and tag routes
-	dashboard := NewDashboardHandler(deps.LinkStore, deps.TagStore, deps.KeywordStore)
-	links := NewLinksHandler(deps.LinkStore, deps.OwnershipStore, deps.UserStore, deps.KeywordStore)
+	dashboard := NewDashboardHandler(deps.LinkStore, deps.TagStore, deps.KeywordStore, deps.HealthStore, deps.SavedSearchStore, deps.PolicyStore)
+	dashboard.prefs = deps.PreferenceStore
+	dashboard.live = deps.Events != nil
+	links := NewLinksHandler(deps.LinkStore, deps.OwnershipStore, deps.UserStore, deps.KeywordStore, deps.ReservedSlugStore, deps.TeamStore, deps.PolicyStore, deps.Notifier)
 	tags := NewTagsHandler(deps.TagStore, deps.LinkStore, deps.KeywordStore)
 	tokensWeb := NewTokensHandler(deps.TokenStore)
+	notifications := NewNotificationsHandler(deps.UserStore, deps.Notifier != nil)
 	// Governing: SPEC-0016 REQ "Link Stats Dashboard Page", ADR-0016
 	statsHandler := NewStatsHandler(deps.LinkStore, deps.ClickStore, deps.OwnershipStore)
+	statsHandler.campaigns = deps.Campaigns
 
 	r.Group(func(r chi.Router) {
 		r.Use(deps.AuthMiddleware.RequireAuth)
 
 		r.Get("/dashboard", dashboard.Show)
+		// Governing: SPEC-0004 REQ "Live Dashboard Updates"
+		if deps.Events != nil {
+			r.Get("/dashboard/events", NewEventsHandler(deps.Events, deps.LinkStore, deps.OwnershipStore).Stream)
+		}
+		// Governing: SPEC-0004 REQ "Saved Searches"
+		r.Get("/dashboard/searches", dashboard.SavedSearches)
+		r.Post("/dashboard/searches", dashboard.SaveSearch)
+		r.Get("/dashboard/searches/{id}", dashboard.ShowSavedSearch)
+		r.Get("/dashboard/searches/{id}/confirm-delete", dashboard.ConfirmDeleteSavedSearch)
+		r.Delete("/dashboard/searches/{id}", dashboard.DeleteSavedSearch)
+		r.Post("/dashboard/searches/{id}/delete", dashboard.DeleteSavedSearch) // Governing: SPEC-0013 REQ "No-JavaScript Fallbacks"
+		// Governing: SPEC-0004 REQ "Command Palette"
+		r.Get("/dashboard/palette", dashboard.Palette)
+		// Governing: SPEC-0004 REQ "Bookmark Import"
+		r.Get("/dashboard/import", links.Import)
+		r.Post("/dashboard/import", links.ImportReview)
+		r.Post("/dashboard/import/confirm", links.ImportCreate)
 
 		// NOTE: validate-slug MUST be before /{id} to avoid chi treating "validate-slug" as an id
 		r.Get("/dashboard/links/validate-slug", links.ValidateSlug)
 		r.Get("/dashboard/links/new", links.New)
+		// Governing: SPEC-0009 REQ "Link Form Preview"
+		r.Get("/dashboard/links/preview", links.PreviewURL)
 		r.Post("/dashboard/links", links.Create)
 		r.Get("/dashboard/links/{id}", links.Detail)
 		r.Get("/dashboard/links/{id}/edit", links.Edit)
 		// Governing: SPEC-0016 REQ "Link Stats Dashboard Page", ADR-0016
 		r.Get("/dashboard/links/{id}/stats", statsHandler.Show)
+		r.Get("/dashboard/links/{id}/stats/widget", statsHandler.Widget) // Governing: SPEC-0016 REQ "Link Detail Stats Widget"
+		// Governing: SPEC-0001 REQ "Link Poster"
+		r.Get("/dashboard/links/{id}/poster", links.Poster)
 		// Governing: SPEC-0013 REQ "DaisyUI Delete Confirmation Modal"
 		r.Get("/dashboard/links/{id}/confirm-delete", links.ConfirmDelete)
 		r.Put("/dashboard/links/{id}", links.Update)
@@ -113,6 +247,25 @@ func NewRouter(deps Deps) http.Handler {
 		// Governing: SPEC-0010 REQ "Link Share Management Endpoints"
 		r.Post("/dashboard/links/{id}/shares", links.AddShare)
 		r.Delete("/dashboard/links/{id}/shares/{uid}", links.RemoveShare)
+		// Governing: SPEC-0010 REQ "Team Shares and Ownership"
+		if deps.TeamStore != nil {
+			r.Post("/dashboard/links/{id}/team-owners", links.AddTeamOwner)
+			r.Delete("/dashboard/links/{id}/team-owners/{team}", links.RemoveTeamOwner)
+			r.Post("/dashboard/links/{id}/team-shares", links.AddTeamShare)
+			r.Delete("/dashboard/links/{id}/team-shares/{team}", links.RemoveTeamShare)
+		}
+
+		// HTML forms can only GET and POST, so each PUT and DELETE above has
+		// a POST twin for browsers without JavaScript.
+		// Governing: SPEC-0013 REQ "No-JavaScript Fallbacks"
+		r.Post("/dashboard/links/{id}", links.Update)
+		r.Post("/dashboard/links/{id}/delete", links.Delete)
+		r.Post("/dashboard/links/{id}/owners/{uid}/delete", links.RemoveOwner)
+		r.Post("/dashboard/links/{id}/shares/{uid}/delete", links.RemoveShare)
+		if deps.TeamStore != nil {
+			r.Post("/dashboard/links/{id}/team-owners/{team}/delete", links.RemoveTeamOwner)
+			r.Post("/dashboard/links/{id}/team-shares/{team}/delete", links.RemoveTeamShare)
+		}
 
 		r.Get("/dashboard/tags", tags.Index)
 		r.Get("/dashboard/tags/suggest", tags.Suggest)
@@ -121,85 +274,289 @@ func NewRouter(deps Deps) http.Handler {
 		// Governing: SPEC-0006 REQ "Token Management Web UI"
 		r.Get("/dashboard/settings/tokens", tokensWeb.Index)
 		r.Post("/dashboard/settings/tokens", tokensWeb.Create)
+		// Governing: SPEC-0007 REQ "Swagger UI Test Token"
+		r.Post("/dashboard/settings/tokens/docs", tokensWeb.CreateDocsToken)
 		// Governing: SPEC-0013 REQ "DaisyUI Delete Confirmation Modal"
 		r.Get("/dashboard/settings/tokens/{id}/confirm-revoke", tokensWeb.ConfirmRevoke)
 		r.Delete("/dashboard/settings/tokens/{id}", tokensWeb.Revoke)
+		r.Post("/dashboard/settings/tokens/{id}/delete", tokensWeb.Revoke) // Governing: SPEC-0013 REQ "No-JavaScript Fallbacks"
+
+		// Governing: SPEC-0001 REQ "Email Notifications"
+		r.Get("/dashboard/settings/notifications", notifications.Show)
+		r.Put("/dashboard/settings/notifications", notifications.Update)
+		// Governing: SPEC-0004 REQ "Internationalization"
+		if deps.PreferenceStore != nil {
+			preferences := NewPreferencesHandler(deps.PreferenceStore)
+			r.Get("/dashboard/settings/preferences", preferences.Show)
+			r.Post("/dashboard/settings/preferences", preferences.Update)
+		}
+		// Governing: SPEC-0001 REQ "Account Linking" — demo visitors share one account
+		identities := NewIdentitiesHandler(deps.UserStore, deps.SessionManager, deps.Demo == nil)
+		r.Get("/dashboard/settings/identities", identities.Index)
+		r.Post("/dashboard/settings/identities/code", identities.CreateCode)
+		r.Post("/dashboard/settings/identities/join", identities.Join)
+		r.Post("/dashboard/settings/identities/join/confirm", identities.ConfirmJoin)
+		r.Post("/dashboard/settings/identities/{id}/delete", identities.Unlink)
+		// Governing: SPEC-0001 REQ "Session Management"
+		if deps.SessionTracker != nil {
+			sessions := NewSessionsHandler(deps.SessionTracker)
+			r.Get("/dashboard/settings/sessions", sessions.Index)
+			r.Post("/dashboard/settings/sessions/delete", sessions.RevokeAll)
+			r.Post("/dashboard/settings/sessions/{id}/delete", sessions.Revoke)
+		}
+		// Governing: SPEC-0001 REQ "WebAuthn Step-Up" — once a user has a passkey,
+		// adding or removing one needs a fresh confirmation with it
+		if deps.WebAuthn != nil {
+			stepUpWeb := NewStepUpHandler(deps.WebAuthn)
+			r.Get("/dashboard/step-up", stepUpWeb.Show)
+			r.Post("/dashboard/step-up/begin", stepUpWeb.Begin)
+			r.Post("/dashboard/step-up/finish", stepUpWeb.Finish)
+			passkeys := NewPasskeysHandler(deps.WebAuthn, deps.WebAuthnStore)
+			r.Get("/dashboard/settings/passkeys", passkeys.Index)
+			r.Group(func(r chi.Router) {
+				r.Use(requireStepUpIfEnrolled(deps.WebAuthn))
+				r.Post("/dashboard/settings/passkeys/register/begin", passkeys.RegisterBegin)
+				r.Post("/dashboard/settings/passkeys/register/finish", passkeys.RegisterFinish)
+				r.Post("/dashboard/settings/passkeys/{id}/delete", passkeys.Delete)
+			})
+		}
 	})
 
 	// Admin routes (require admin role)
 	// Governing: SPEC-0004 REQ "Route Registration and Priority" — admin group with RequireAdmin
-	admin := NewAdminHandler(deps.LinkStore, deps.UserStore, deps.KeywordStore)
+	admin := NewAdminHandler(deps.LinkStore, deps.UserStore, deps.KeywordStore, deps.HealthStore, deps.TagStore, deps.TeamStore)
+	admin.analyticsMode = deps.AnalyticsMode
+	admin.prefs = deps.PreferenceStore
 	keywordsHandler := NewKeywordsHandler(deps.KeywordStore)
+	reservedHandler := NewReservedSlugsHandler(deps.ReservedSlugStore)
+	teamsHandler := NewTeamsHandler(deps.TeamStore)
+	teamsHandler.users = deps.UserStore
+	adminTagsHandler := NewAdminTagsHandler(deps.TagStore)
+	usageHandler := NewUsageHandler(deps.UsageStore)
+	referrersHandler := NewReferrerExclusionsHandler(deps.ClickStore)
+	domainsHandler := NewDomainRulesHandler(deps.DomainRuleStore)
+	policiesHandler := NewLinkPoliciesHandler(deps.PolicyStore)
+	moderationHandler := NewModerationHandler(deps.LinkStore, deps.ModerationEnabled)
+	// Governing: SPEC-0001 REQ "WebAuthn Step-Up" — guards destructive admin
+	// actions and every settings change (keywords, reserved slugs, policies,
+	// teams, tags, domain rules, referrers, appearance, announcement); a
+	// no-op when passkeys are disabled
+	stepUp := func(next http.Handler) http.Handler { return next }
+	if deps.WebAuthn != nil {
+		stepUp = requireStepUp(deps.WebAuthn)
+	}
 	r.Group(func(r chi.Router) {
+		// Governing: SPEC-0001 REQ "Admin Network Allowlist" — checked before
+		// sign-in so outside clients are never sent to the login page
+		r.Use(deps.AdminNetworks.Middleware(denyAdminNetwork))
 		r.Use(deps.AuthMiddleware.RequireAuth)
 		r.Use(deps.AuthMiddleware.RequireRole("admin"))
+		if deps.Demo != nil {
+			r.Use(demoGuard)
+		}
 		r.Get("/admin", admin.Dashboard)
 		r.Get("/admin/users", admin.Users)
 		// Governing: SPEC-0013 REQ "DaisyUI Delete Confirmation Modal"
 		// Governing: SPEC-0011 REQ "Admin User Deletion with Link Handling"
 		r.Get("/admin/users/{id}/confirm-delete", admin.ConfirmDeleteUser)
 		// Governing: SPEC-0011 REQ "Admin User Deletion Endpoint", ADR-0005
-		r.Delete("/admin/users/{id}", admin.DeleteUser)
-		r.Put("/admin/users/{id}/role", admin.UpdateRole)
+		r.With(stepUp).Delete("/admin/users/{id}", admin.DeleteUser)
+		r.With(stepUp).Put("/admin/users/{id}/role", admin.UpdateRole)
 		// Governing: SPEC-0011 REQ "Admin Links Screen", "Admin Inline Link Editing", "Admin Link Deletion"
 		r.Get("/admin/links", admin.Links)
+		// Governing: SPEC-0011 REQ "Admin Links CSV Export"
+		r.Get("/admin/links/export.csv", admin.ExportLinks)
 		r.Get("/admin/links/{id}/edit", admin.EditLinkRow)
 		r.Get("/admin/links/{id}/row", admin.LinkRow)
 		r.Put("/admin/links/{id}", admin.UpdateLink)
 		r.Get("/admin/links/{id}/confirm-delete", admin.ConfirmDeleteLink)
-		r.Delete("/admin/links/{id}", admin.DeleteLink)
+		r.With(stepUp).Delete("/admin/links/{id}", admin.DeleteLink)
+		// Governing: SPEC-0011 REQ "Public Link Moderation"
+		r.Get("/admin/moderation", moderationHandler.Index)
+		r.Post("/admin/moderation/{id}/approve", moderationHandler.Approve)
+		r.Post("/admin/moderation/{id}/reject", moderationHandler.Reject)
 
 		// Governing: SPEC-0008 REQ "Keyword Host Discovery", ADR-0011
 		r.Get("/admin/keywords", keywordsHandler.Index)
-		r.Post("/admin/keywords", keywordsHandler.Create)
+		r.With(stepUp).Post("/admin/keywords", keywordsHandler.Create)
+		r.With(stepUp).Post("/admin/keywords/{id}/secure", keywordsHandler.SetSecure) // Governing: SPEC-0008 REQ "Secure Keywords"
 		// Governing: SPEC-0013 REQ "DaisyUI Delete Confirmation Modal"
 		r.Get("/admin/keywords/{id}/confirm-delete", keywordsHandler.ConfirmDelete)
-		r.Delete("/admin/keywords/{id}", keywordsHandler.Delete)
+		r.With(stepUp).Delete("/admin/keywords/{id}", keywordsHandler.Delete)
+
+		// Governing: SPEC-0002 REQ "Reserved Slugs"
+		r.Get("/admin/reserved-slugs", reservedHandler.Index)
+		r.With(stepUp).Post("/admin/reserved-slugs", reservedHandler.Create)
+		r.Get("/admin/reserved-slugs/{slug}/confirm-delete", reservedHandler.ConfirmDelete)
+		r.With(stepUp).Delete("/admin/reserved-slugs/{slug}", reservedHandler.Delete)
+		// Governing: SPEC-0011 REQ "Link Lifecycle Policies"
+		r.Get("/admin/policies", policiesHandler.Index)
+		r.With(stepUp).Post("/admin/policies", policiesHandler.Create)
+		r.Get("/admin/policies/{id}/confirm-delete", policiesHandler.ConfirmDelete)
+		r.With(stepUp).Delete("/admin/policies/{id}", policiesHandler.Delete)
+		// Governing: SPEC-0002 REQ "Team Ownership"
+		r.Get("/admin/teams", teamsHandler.Index)
+		r.With(stepUp).Post("/admin/teams", teamsHandler.Create)
+		r.Get("/admin/teams/{slug}/confirm-delete", teamsHandler.ConfirmDelete)
+		r.With(stepUp).Delete("/admin/teams/{slug}", teamsHandler.Delete)
+		// Governing: SPEC-0010 REQ "Team Shares and Ownership"
+		r.With(stepUp).Post("/admin/teams/{slug}/members", teamsHandler.AddMember)
+		r.With(stepUp).Delete("/admin/teams/{slug}/members/{uid}", teamsHandler.RemoveMember)
+		// Governing: SPEC-0004 REQ "Tag Administration"
+		r.Get("/admin/tags", adminTagsHandler.Index)
+		r.With(stepUp).Put("/admin/tags/{slug}", adminTagsHandler.Rename)
+		r.With(stepUp).Post("/admin/tags/{slug}/merge", adminTagsHandler.Merge)
+		r.Get("/admin/tags/{slug}/confirm-delete", adminTagsHandler.ConfirmDelete)
+		r.With(stepUp).Delete("/admin/tags/{slug}", adminTagsHandler.Delete)
+
+		// Governing: SPEC-0002 REQ "Destination Domain Rules"
+		r.Get("/admin/domains", domainsHandler.Index)
+		r.With(stepUp).Post("/admin/domains", domainsHandler.Create)
+		r.Get("/admin/domains/{domain}/confirm-delete", domainsHandler.ConfirmDelete)
+		r.With(stepUp).Delete("/admin/domains/{domain}", domainsHandler.Delete)
+
+		// Governing: SPEC-0016 REQ "Referrer Exclusion"
+		r.Get("/admin/referrers", referrersHandler.Index)
+		r.With(stepUp).Post("/admin/referrers", referrersHandler.Create)
+		r.Get("/admin/referrers/{domain}/confirm-delete", referrersHandler.ConfirmDelete)
+		r.With(stepUp).Delete("/admin/referrers/{domain}", referrersHandler.Delete)
+
+		// Governing: SPEC-0006 REQ "API Usage Tracking"
+		r.Get("/admin/usage", usageHandler.Index)
+
+		// Governing: SPEC-0011 REQ "Instance Telemetry"
+		if deps.TelemetryStore != nil {
+			telemetryHandler := NewTelemetryHandler(deps.TelemetryStore, deps.DBDriver, deps.TelemetryEndpoint)
+			r.Get("/admin/telemetry", telemetryHandler.Index)
+		}
+
+		// Governing: SPEC-0003 REQ "Instance Branding"
+		if deps.SettingsStore != nil {
+			brandingHandler := NewBrandingHandler(deps.SettingsStore)
+			r.Get("/admin/appearance", brandingHandler.Index)
+			r.With(stepUp).Post("/admin/appearance", brandingHandler.Save)
+			// Governing: SPEC-0004 REQ "Announcement Banner"
+			announcementHandler := NewAnnouncementHandler(deps.SettingsStore)
+			r.Get("/admin/announcement", announcementHandler.Index)
+			r.With(stepUp).Post("/admin/announcement", announcementHandler.Save)
+		}
 	})
 
 	// Swagger UI — no auth required; MUST be before slug catch-all.
-	// Use BaseLayout to avoid SwaggerUIStandalonePreset store error in Swagger UI 5.x.
 	// Governing: SPEC-0007 REQ "Swagger UI Endpoint", REQ "Swagger UI Authorization"
-	r.Get("/api/docs/*", httpSwagger.Handler(httpSwagger.Layout(httpSwagger.BaseLayout)))
+	r.Get("/api/docs/*", newSwaggerHandler())
+	r.Get(openAPIPath, serveOpenAPI)
+
+	// Governing: SPEC-0001 REQ "Security Headers"
+	if deps.SecurityHeaders.CSPMode != "off" {
+		r.Post(CSPReportPath, NewCSPReportHandler().Report)
+	}
+
+	// Slug resolver, mounted as the catch-all below; also backs the API's resolve test.
+	// Governing: SPEC-0010 REQ "Secure Link Resolution" — resolver needs OwnershipStore for access checks
+	resolver := NewResolveHandler(deps.LinkStore, deps.KeywordStore, deps.OwnershipStore, deps.ClickCh)
+	resolver.debug = deps.ResolverDebug
+	resolver.utmDefaults = deps.UTMDefaults
+	resolver.bots = deps.BotFilter
+	resolver.analyticsMode = deps.AnalyticsMode
+	resolver.campaigns = deps.Campaigns
+	resolver.shares = deps.ShareURLs
+	resolver.strictVisibility = deps.StrictVisibility
 
 	// API sub-router at /api/v1 — must be before slug catch-all.
 	// Governing: SPEC-0005 REQ "API Router Mounting"
+	// Governing: SPEC-0007 REQ "Swagger UI Session Try-It" — session fallback for Swagger UI requests
 	tokenStore := deps.TokenStore
-	bearerMiddleware := auth.NewBearerTokenMiddleware(tokenStore, deps.UserStore)
-	apiRouter := api.NewAPIRouter(api.Deps{
-		BearerMiddleware: bearerMiddleware,
-		TokenStore:       tokenStore,
-		LinkStore:        deps.LinkStore,
-		OwnershipStore:   deps.OwnershipStore,
-		TagStore:         deps.TagStore,
-		UserStore:        deps.UserStore,
-		KeywordStore:     deps.KeywordStore,
-		ClickStore:       deps.ClickStore,
-		Suggester:        deps.Suggester,
-	})
-	r.Mount("/api/v1", apiRouter)
+	bearerMiddleware := auth.NewBearerTokenMiddleware(tokenStore, deps.UserStore).WithSessions(deps.SessionManager).WithLockout(deps.TokenLockout)
+	apiDeps := api.Deps{
+		BearerMiddleware:  bearerMiddleware,
+		TokenStore:        tokenStore,
+		LinkStore:         deps.LinkStore,
+		OwnershipStore:    deps.OwnershipStore,
+		TagStore:          deps.TagStore,
+		UserStore:         deps.UserStore,
+		KeywordStore:      deps.KeywordStore,
+		ClickStore:        deps.ClickStore,
+		HealthStore:       deps.HealthStore,
+		ReservedSlugStore: deps.ReservedSlugStore,
+		TeamStore:         deps.TeamStore,
+		SavedSearchStore:  deps.SavedSearchStore,
+		PolicyStore:       deps.PolicyStore,
+		DomainRuleStore:   deps.DomainRuleStore,
+		UsageStore:        deps.UsageStore,
+		UsageRecorder:     deps.UsageRecorder,
+		Suggester:         deps.Suggester,
+		ResolveTester:     resolver,
+		StatusChecker:     deps.StatusChecker,
+		Notifier:          deps.Notifier,
+		ShareURLs:         deps.ShareURLs,
+		DemoMode:          deps.Demo != nil,
+		TenantStore:       deps.TenantStore,
+		IdempotencyStore:  deps.IdempotencyStore,
+		AdminNetworks:     deps.AdminAPINetworks,
+		WebAuthn:          deps.WebAuthn,
+	}
+	r.Mount("/api/v1", api.NewAPIRouter(apiDeps))
+	// Governing: SPEC-0005 REQ "gRPC Links Service" — served on its own listener by the caller
+	if deps.GRPCServer != nil {
+		api.RegisterGRPCServer(deps.GRPCServer, apiDeps)
+	}
 
 	// User profile pages — no auth required, BEFORE slug catch-all.
 	// Governing: SPEC-0012 REQ "User Profile Page (GET /u/{display_name_slug})", REQ "User Profile Route Priority"
 	profileHandler := NewProfileHandler(deps.UserStore, deps.LinkStore)
 	r.With(deps.AuthMiddleware.OptionalUser).Get("/u/{displayNameSlug}", profileHandler.Show)
+	// Governing: SPEC-0012 REQ "Profile Username Alias" — slugs cannot contain "@", so this never shadows a link
+	r.With(deps.AuthMiddleware.OptionalUser).Get("/@{username}", profileHandler.Alias)
 
 	// Public link browser — no auth required; MUST be before slug catch-all.
 	// Governing: SPEC-0012 REQ "Public Link Browser Route Priority"
 	publicLinks := NewPublicLinksHandler(deps.LinkStore, deps.KeywordStore)
 	r.With(deps.AuthMiddleware.OptionalUser).Get("/links", publicLinks.Index)
+	// Governing: SPEC-0012 REQ "Tag Feeds" — public links only, so no auth
+	feeds := NewFeedsHandler(deps.TagStore, deps.LinkStore)
+	r.Get("/links/tags/{slug}/feed.atom", feeds.Tag)
+	// Governing: SPEC-0012 REQ "Contact Link Owner" — sign-in required so owner addresses stay private
+	contact := NewContactHandler(deps.LinkStore, deps.OwnershipStore)
+	r.With(deps.AuthMiddleware.RequireAuth).Get("/links/{id}/contact", contact.Contact)
+
+	// Sitemap and robots.txt — no auth required; slugs cannot contain ".", so these never shadow a link.
+	// Governing: SPEC-0012 REQ "Sitemap and Robots"
+	sitemap := NewSitemapHandler(deps.LinkStore, deps.RobotsIndexSlugs)
+	r.Get("/sitemap.xml", sitemap.Sitemap)
+	r.Get("/robots.txt", sitemap.Robots)
+
+	// Instance logo and announcement dismissal — no auth required, since
+	// signed-out pages show both.
+	// Governing: SPEC-0003 REQ "Instance Branding"
+	if deps.SettingsStore != nil {
+		r.Get("/branding/logo", NewBrandingHandler(deps.SettingsStore).Logo)
+		// Governing: SPEC-0004 REQ "Announcement Banner" — signed-out visitors can dismiss it too
+		r.Post("/announcement/dismiss", NewAnnouncementHandler(deps.SettingsStore).Dismiss)
+	}
 
 	// Prometheus metrics endpoint — no auth required; MUST be before slug catch-all.
 	// Governing: SPEC-0016 REQ "Prometheus Metrics Endpoint", ADR-0016
 	r.Get("/metrics", promhttp.Handler().ServeHTTP)
 
+	// Public status page — no auth required; MUST be before slug catch-all.
+	// Governing: SPEC-0016 REQ "Status Page"
+	if deps.StatusChecker != nil {
+		statusHandler := NewStatusHandler(deps.StatusChecker)
+		r.With(deps.AuthMiddleware.OptionalUser).Get("/status", statusHandler.Show)
+	}
+
+	// Signed share URLs for secure links; "s" is a reserved slug, so this never shadows a link.
+	// Governing: SPEC-0010 REQ "Signed Share URLs"
+	if deps.ShareURLs != nil {
+		r.With(deps.AuthMiddleware.OptionalUser).Get(shareurl.Prefix+"*", resolver.ResolveShared)
+	}
+
 	// Slug resolver -- catch-all, must be last.
 	// Resolver does not require auth (links are publicly accessible).
 	// Uses OptionalUser so the 404 page can offer "Create this link" when logged in.
 	// Governing: SPEC-0004 REQ "Route Registration and Priority" — catch-all AFTER named routes
 	// Governing: SPEC-0009 REQ "Multi-Segment Path Resolution", ADR-0013 — wildcard for multi-segment paths
-	// Governing: SPEC-0010 REQ "Secure Link Resolution" — resolver needs OwnershipStore for access checks
-	resolver := NewResolveHandler(deps.LinkStore, deps.KeywordStore, deps.OwnershipStore, deps.ClickCh)
 	r.With(deps.AuthMiddleware.OptionalUser).Get("/{slug}*", resolver.Resolve)
 
 	return r
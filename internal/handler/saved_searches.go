@@ -0,0 +1,147 @@
+// Governing: SPEC-0004 REQ "Saved Searches"
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/joestump/joe-links/internal/auth"
+	"github.com/joestump/joe-links/internal/store"
+)
+
+// SavedSearchNav is the template data for the sidebar list of saved searches.
+type SavedSearchNav struct {
+	Searches []*store.SavedSearch
+	Current  string // ID of the saved search the page shows, highlighted in the list
+}
+
+// SavedSearches renders the sidebar list of the user's saved searches. The
+// base layout loads it over HTMX and reloads it on savedSearchesChanged, after
+// the page's own nav highlighting has run, so the entry for the page being
+// viewed is highlighted here from the HX-Current-URL header.
+// GET /dashboard/searches
+func (h *DashboardHandler) SavedSearches(w http.ResponseWriter, r *http.Request) {
+	user := auth.UserFromContext(r.Context())
+	searches, err := h.searches.ListByUser(r.Context(), user.ID)
+	if err != nil {
+		renderError(w, r, http.StatusInternalServerError, "Could not load saved searches.")
+		return
+	}
+	data := SavedSearchNav{Searches: searches}
+	if u, err := url.Parse(r.Header.Get("HX-Current-URL")); err == nil {
+		data.Current, _ = strings.CutPrefix(u.Path, "/dashboard/searches/")
+	}
+	renderFragment(w, "saved_search_nav", data)
+}
+
+// ShowSavedSearch renders the dashboard filtered by a saved search.
+// GET /dashboard/searches/{id}
+func (h *DashboardHandler) ShowSavedSearch(w http.ResponseWriter, r *http.Request) {
+	user := auth.UserFromContext(r.Context())
+	saved, err := h.searches.Get(r.Context(), user.ID, chi.URLParam(r, "id"))
+	if errors.Is(err, store.ErrNotFound) {
+		renderError(w, r, http.StatusNotFound, "That saved search no longer exists.")
+		return
+	}
+	if err != nil {
+		renderError(w, r, http.StatusInternalServerError, "Could not load saved search.")
+		return
+	}
+	h.show(w, r, DashboardPage{
+		BasePage:    newBasePage(r, user),
+		User:        user,
+		Query:       saved.Query,
+		Tag:         saved.Tag,
+		Saved:       saved,
+		ShowActions: true,
+	})
+}
+
+// SaveSearch saves the dashboard's current query and tag as a named search,
+// then re-renders the save form and triggers a sidebar reload.
+// POST /dashboard/searches
+func (h *DashboardHandler) SaveSearch(w http.ResponseWriter, r *http.Request) {
+	user := auth.UserFromContext(r.Context())
+	if err := r.ParseForm(); err != nil {
+		renderError(w, r, http.StatusBadRequest, "Invalid form data.")
+		return
+	}
+	query := strings.TrimSpace(r.FormValue("q"))
+	tag := strings.TrimSpace(r.FormValue("tag"))
+	saved, err := h.searches.Create(r.Context(), user.ID, strings.TrimSpace(r.FormValue("name")), query, tag)
+	switch {
+	case errors.Is(err, store.ErrSavedSearchNameRequired):
+		renderError(w, r, http.StatusBadRequest, "Give the search a name.")
+		return
+	case errors.Is(err, store.ErrSavedSearchEmpty):
+		renderError(w, r, http.StatusBadRequest, "Enter a search or pick a tag before saving.")
+		return
+	case errors.Is(err, store.ErrInvalidFilter):
+		renderError(w, r, http.StatusBadRequest, filterErrorMessage(err))
+		return
+	case errors.Is(err, store.ErrSavedSearchNameTaken):
+		renderError(w, r, http.StatusConflict, "You already have a saved search with that name.")
+		return
+	case err != nil:
+		renderError(w, r, http.StatusInternalServerError, "Could not save search.")
+		return
+	}
+
+	if !isHTMX(r) {
+		http.Redirect(w, r, "/dashboard/searches/"+saved.ID, http.StatusSeeOther)
+		return
+	}
+	w.Header().Set("HX-Trigger", "savedSearchesChanged")
+	renderFragment(w, "saved_search_form", DashboardPage{
+		Query: query,
+		Tag:   tag,
+		Saved: saved,
+		Flash: &Flash{Type: "success", Message: "Saved as “" + saved.Name + "”."},
+	})
+}
+
+// ConfirmDeleteSavedSearch renders the delete confirmation modal for a saved search.
+// GET /dashboard/searches/{id}/confirm-delete
+// Governing: SPEC-0013 REQ "DaisyUI Delete Confirmation Modal"
+func (h *DashboardHandler) ConfirmDeleteSavedSearch(w http.ResponseWriter, r *http.Request) {
+	user := auth.UserFromContext(r.Context())
+	saved, err := h.searches.Get(r.Context(), user.ID, chi.URLParam(r, "id"))
+	if err != nil {
+		renderError(w, r, http.StatusNotFound, "That item no longer exists.")
+		return
+	}
+	renderConfirmDelete(w, r, ConfirmDeleteData{
+		Name:      saved.Name,
+		DeleteURL: "/dashboard/searches/" + saved.ID,
+		Target:    "#saved-search-" + saved.ID,
+	}, "/dashboard/searches/"+saved.ID)
+}
+
+// DeleteSavedSearch removes a saved search. Returns 200 with an empty body so
+// HTMX removes the sidebar entry; the no-JavaScript form is redirected to the
+// dashboard.
+// DELETE /dashboard/searches/{id}, POST /dashboard/searches/{id}/delete
+func (h *DashboardHandler) DeleteSavedSearch(w http.ResponseWriter, r *http.Request) {
+	user := auth.UserFromContext(r.Context())
+	err := h.searches.Delete(r.Context(), user.ID, chi.URLParam(r, "id"))
+	if errors.Is(err, store.ErrNotFound) {
+		renderError(w, r, http.StatusNotFound, "That item no longer exists.")
+		return
+	}
+	if err != nil {
+		renderError(w, r, http.StatusInternalServerError, "Delete failed.")
+		return
+	}
+
+	// Governing: SPEC-0013 REQ "No-JavaScript Fallbacks"
+	if !isHTMX(r) {
+		http.Redirect(w, r, "/dashboard", http.StatusSeeOther)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte(`<div id="toast-area" hx-swap-oob="innerHTML:#toast-area"><div class="alert alert-success"><span>Saved search deleted.</span></div></div>`))
+}
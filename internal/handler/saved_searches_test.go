@@ -0,0 +1,110 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/joestump/joe-links/internal/auth"
+	"github.com/joestump/joe-links/internal/store"
+	"github.com/joestump/joe-links/internal/testutil"
+)
+
+// Governing: SPEC-0004 REQ "Saved Searches"
+func TestDashboard_SavedSearches(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	owns := store.NewOwnershipStore(db)
+	ts := store.NewTagStore(db)
+	ls := store.NewLinkStore(db, owns, ts)
+	us := store.NewUserStore(db)
+	ctx := context.Background()
+
+	user, err := us.Upsert(ctx, "test", "sub1", "user@example.com", "User", "")
+	if err != nil {
+		t.Fatalf("seed user: %v", err)
+	}
+	for _, seed := range []struct{ slug, title, tag string }{
+		{"deploy-api", "Deploy the API", "oncall"},
+		{"deploy-web", "Deploy the web app", ""},
+		{"pager", "Pager rotation", "oncall"},
+	} {
+		link, err := ls.Create(ctx, seed.slug, "https://example.com/"+seed.slug, user.ID, seed.title, "", "public")
+		if err != nil {
+			t.Fatalf("seed link: %v", err)
+		}
+		if seed.tag != "" {
+			if err := ls.SetTags(ctx, link.ID, []string{seed.tag}); err != nil {
+				t.Fatalf("seed tags: %v", err)
+			}
+		}
+	}
+
+	h := NewDashboardHandler(ls, ts, nil, nil, store.NewSavedSearchStore(db), nil)
+	r := chi.NewRouter()
+	r.Get("/dashboard", h.Show)
+	r.Get("/dashboard/searches", h.SavedSearches)
+	r.Post("/dashboard/searches", h.SaveSearch)
+	r.Get("/dashboard/searches/{id}", h.ShowSavedSearch)
+	r.Delete("/dashboard/searches/{id}", h.DeleteSavedSearch)
+	serve := func(req *http.Request) *httptest.ResponseRecorder {
+		req = req.WithContext(context.WithValue(req.Context(), auth.UserContextKey, user))
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	// Filtering offers to save the search, out of band alongside the list.
+	req := httptest.NewRequest(http.MethodGet, "/dashboard?q=deploy", nil)
+	req.Header.Set("HX-Request", "true")
+	if body := serve(req).Body.String(); !strings.Contains(body, `hx-post="/dashboard/searches"`) {
+		t.Error("filtered dashboard does not offer to save the search")
+	}
+
+	form := url.Values{"name": {"On-call deploys"}, "q": {"deploy"}, "tag": {"oncall"}}
+	req = httptest.NewRequest(http.MethodPost, "/dashboard/searches", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("HX-Request", "true")
+	w := serve(req)
+	if w.Code != http.StatusOK || w.Header().Get("HX-Trigger") != "savedSearchesChanged" {
+		t.Fatalf("save: status = %d, HX-Trigger = %q", w.Code, w.Header().Get("HX-Trigger"))
+	}
+	saved, err := store.NewSavedSearchStore(db).ListByUser(ctx, user.ID)
+	if err != nil || len(saved) != 1 {
+		t.Fatalf("saved searches = %v, %v", saved, err)
+	}
+	id := saved[0].ID
+
+	// The saved view combines the query with the tag.
+	w = serve(httptest.NewRequest(http.MethodGet, "/dashboard/searches/"+id, nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("saved view: status = %d", w.Code)
+	}
+	body := w.Body.String()
+	if !strings.Contains(body, "On-call deploys") || !strings.Contains(body, "deploy-api") {
+		t.Error("saved view missing its name or matching link")
+	}
+	if strings.Contains(body, "deploy-web") || strings.Contains(body, "pager</a>") {
+		t.Error("saved view includes links outside the saved query and tag")
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/dashboard/searches", nil)
+	req.Header.Set("HX-Request", "true")
+	req.Header.Set("HX-Current-URL", "http://localhost/dashboard/searches/"+id)
+	body = serve(req).Body.String()
+	if !strings.Contains(body, `href="/dashboard/searches/`+id+`"`) || !strings.Contains(body, "bg-primary") {
+		t.Error("sidebar does not list and highlight the saved search")
+	}
+
+	req = httptest.NewRequest(http.MethodDelete, "/dashboard/searches/"+id, nil)
+	req.Header.Set("HX-Request", "true")
+	if w := serve(req); w.Code != http.StatusOK {
+		t.Fatalf("delete: status = %d", w.Code)
+	}
+	if w := serve(httptest.NewRequest(http.MethodGet, "/dashboard/searches/"+id, nil)); w.Code != http.StatusNotFound {
+		t.Errorf("deleted view: status = %d, want 404", w.Code)
+	}
+}
@@ -0,0 +1,209 @@
+// Governing: SPEC-0001 REQ "Security Headers"
+package handler
+
+import (
+	"encoding/json"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/joestump/joe-links/internal/metrics"
+)
+
+// DefaultCSP is the Content-Security-Policy sent unless one is configured.
+// HTMX evaluates hx-on handlers and templates use inline scripts, event
+// handlers, and styles, so those stay allowed; everything is same-origin,
+// and the app may not be framed.
+const DefaultCSP = "default-src 'self'; script-src 'self' 'unsafe-inline' 'unsafe-eval'; " +
+	"style-src 'self' 'unsafe-inline'; img-src 'self' data:; connect-src 'self'; " +
+	"object-src 'none'; base-uri 'self'; frame-ancestors 'none'"
+
+// CSPReportPath receives violation reports from browsers. It is under /api/
+// so it is exempt from CSRF checks, which browsers' reports cannot pass.
+const CSPReportPath = "/api/csp-report"
+
+// maxCSPReport caps the size of a violation report body.
+const maxCSPReport = 64 << 10
+
+// cspReportsPerMinute caps the reports one client address may send. A
+// browser sends a few per page at most, so only floods are turned away.
+const cspReportsPerMinute = 30
+
+// maxCSPReportClients bounds how many client addresses CSPReportHandler
+// tracks, so a flood from many addresses cannot grow it without limit.
+const maxCSPReportClients = 10000
+
+// SecurityHeaders configures the security headers sent with every response.
+type SecurityHeaders struct {
+	CSPMode               string        // "enforce", "report-only", or "off"
+	CSPPolicy             string        // "" sends DefaultCSP
+	HSTSMaxAge            time.Duration // 0 disables Strict-Transport-Security
+	HSTSIncludeSubdomains bool
+}
+
+// Middleware sets Content-Security-Policy (or its report-only variant),
+// X-Frame-Options, X-Content-Type-Options, and Referrer-Policy on every
+// response, and Strict-Transport-Security on HTTPS requests when enabled.
+// Handlers may still replace them, as the uploaded logo does with its own
+// sandboxing policy.
+func (c SecurityHeaders) Middleware(next http.Handler) http.Handler {
+	var cspHeader, csp string
+	switch c.CSPMode {
+	case "enforce", "":
+		cspHeader = "Content-Security-Policy"
+	case "report-only":
+		cspHeader = "Content-Security-Policy-Report-Only"
+	}
+	if cspHeader != "" {
+		csp = c.CSPPolicy
+		if csp == "" {
+			csp = DefaultCSP
+		}
+		csp = strings.TrimRight(strings.TrimSpace(csp), ";") + "; report-uri " + CSPReportPath
+	}
+	var hsts string
+	if c.HSTSMaxAge > 0 {
+		hsts = "max-age=" + strconv.FormatInt(int64(c.HSTSMaxAge/time.Second), 10)
+		if c.HSTSIncludeSubdomains {
+			hsts += "; includeSubDomains"
+		}
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h := w.Header()
+		if cspHeader != "" {
+			h.Set(cspHeader, csp)
+		}
+		h.Set("X-Frame-Options", "DENY")
+		h.Set("X-Content-Type-Options", "nosniff")
+		h.Set("Referrer-Policy", "strict-origin-when-cross-origin")
+		if hsts != "" && (r.TLS != nil || r.Header.Get("X-Forwarded-Proto") == "https") {
+			h.Set("Strict-Transport-Security", hsts)
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// cspViolation is a violation report, with the fields worth logging.
+type cspViolation struct {
+	document, blocked, directive string
+}
+
+// CSPReportHandler ingests Content-Security-Policy violation reports. The
+// endpoint takes unauthenticated posts, so each client address is limited
+// to cspReportsPerMinute reports; counts are kept in memory per process.
+type CSPReportHandler struct {
+	now func() time.Time
+
+	mu      sync.Mutex
+	clients map[string]*cspReportWindow
+}
+
+type cspReportWindow struct {
+	start time.Time
+	count int
+}
+
+// NewCSPReportHandler creates a CSPReportHandler.
+func NewCSPReportHandler() *CSPReportHandler {
+	return &CSPReportHandler{now: time.Now, clients: make(map[string]*cspReportWindow)}
+}
+
+// allow counts a report from ip and reports whether it is within the limit.
+// When the table is full, expired windows are dropped first, and addresses
+// that still do not fit are refused until some expire.
+func (h *CSPReportHandler) allow(ip string) bool {
+	now := h.now()
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	c := h.clients[ip]
+	if c == nil || now.Sub(c.start) >= time.Minute {
+		if c == nil && len(h.clients) >= maxCSPReportClients {
+			for k, old := range h.clients {
+				if now.Sub(old.start) >= time.Minute {
+					delete(h.clients, k)
+				}
+			}
+			if len(h.clients) >= maxCSPReportClients {
+				return false
+			}
+		}
+		c = &cspReportWindow{start: now}
+		h.clients[ip] = c
+	}
+	c.count++
+	return c.count <= cspReportsPerMinute
+}
+
+// Report logs and counts each violation in a report. Reports come as
+// application/csp-report (report-uri) or application/reports+json (the
+// Reporting API); both are answered with 204, and clients over the limit
+// with 429.
+// POST /api/csp-report
+func (h *CSPReportHandler) Report(w http.ResponseWriter, r *http.Request) {
+	ip := r.RemoteAddr
+	if host, _, err := net.SplitHostPort(ip); err == nil {
+		ip = host
+	}
+	if !h.allow(ip) {
+		w.WriteHeader(http.StatusTooManyRequests)
+		return
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxCSPReport))
+	if err != nil {
+		w.WriteHeader(http.StatusRequestEntityTooLarge)
+		return
+	}
+	var violations []cspViolation
+	var legacy struct {
+		Report *struct {
+			DocumentURI        string `json:"document-uri"`
+			BlockedURI         string `json:"blocked-uri"`
+			ViolatedDirective  string `json:"violated-directive"`
+			EffectiveDirective string `json:"effective-directive"`
+		} `json:"csp-report"`
+	}
+	var batch []struct {
+		Type string `json:"type"`
+		Body struct {
+			DocumentURL        string `json:"documentURL"`
+			BlockedURL         string `json:"blockedURL"`
+			EffectiveDirective string `json:"effectiveDirective"`
+		} `json:"body"`
+	}
+	switch {
+	case json.Unmarshal(body, &legacy) == nil && legacy.Report != nil:
+		rep := legacy.Report
+		directive := rep.EffectiveDirective
+		if directive == "" {
+			directive = rep.ViolatedDirective
+		}
+		violations = append(violations, cspViolation{rep.DocumentURI, rep.BlockedURI, directive})
+	case json.Unmarshal(body, &batch) == nil:
+		for _, rep := range batch {
+			if rep.Type == "csp-violation" {
+				violations = append(violations, cspViolation{rep.Body.DocumentURL, rep.Body.BlockedURL, rep.Body.EffectiveDirective})
+			}
+		}
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	for _, v := range violations {
+		metrics.CSPViolationsTotal.Inc()
+		log.Printf("csp: %q blocked %q on %q", truncate(v.directive, 64), truncate(v.blocked, 256), truncate(v.document, 256))
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// truncate shortens s to at most n bytes for logging.
+func truncate(s string, n int) string {
+	if len(s) > n {
+		return s[:n] + "…"
+	}
+	return s
+}
@@ -0,0 +1,96 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Governing: SPEC-0001 REQ "Security Headers"
+func TestSecurityHeaders(t *testing.T) {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	serve := func(c SecurityHeaders, req *http.Request) http.Header {
+		w := httptest.NewRecorder()
+		c.Middleware(ok).ServeHTTP(w, req)
+		return w.Header()
+	}
+
+	h := serve(SecurityHeaders{}, httptest.NewRequest(http.MethodGet, "/dashboard", nil))
+	if csp := h.Get("Content-Security-Policy"); !strings.HasPrefix(csp, DefaultCSP) || !strings.HasSuffix(csp, "; report-uri "+CSPReportPath) {
+		t.Errorf("Content-Security-Policy = %q", csp)
+	}
+	if h.Get("X-Frame-Options") != "DENY" || h.Get("Referrer-Policy") != "strict-origin-when-cross-origin" || h.Get("X-Content-Type-Options") != "nosniff" {
+		t.Errorf("headers = %v", h)
+	}
+	if h.Get("Strict-Transport-Security") != "" {
+		t.Error("HSTS sent although disabled")
+	}
+
+	h = serve(SecurityHeaders{CSPMode: "report-only", CSPPolicy: "default-src 'none';"}, httptest.NewRequest(http.MethodGet, "/", nil))
+	if h.Get("Content-Security-Policy") != "" || h.Get("Content-Security-Policy-Report-Only") != "default-src 'none'; report-uri "+CSPReportPath {
+		t.Errorf("report-only headers = %v", h)
+	}
+	if h = serve(SecurityHeaders{CSPMode: "off"}, httptest.NewRequest(http.MethodGet, "/", nil)); h.Get("Content-Security-Policy") != "" || h.Get("Content-Security-Policy-Report-Only") != "" {
+		t.Errorf("off headers = %v", h)
+	}
+
+	hsts := SecurityHeaders{HSTSMaxAge: 365 * 24 * time.Hour, HSTSIncludeSubdomains: true}
+	if h = serve(hsts, httptest.NewRequest(http.MethodGet, "http://go.example.com/", nil)); h.Get("Strict-Transport-Security") != "" {
+		t.Error("HSTS sent over plain HTTP")
+	}
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("X-Forwarded-Proto", "https")
+	if got := serve(hsts, req).Get("Strict-Transport-Security"); got != "max-age=31536000; includeSubDomains" {
+		t.Errorf("Strict-Transport-Security = %q", got)
+	}
+}
+
+// Governing: SPEC-0001 REQ "Security Headers"
+func TestCSPReport(t *testing.T) {
+	for name, c := range map[string]struct {
+		body string
+		want int
+	}{
+		"report-uri":    {`{"csp-report":{"document-uri":"https://go.example.com/dashboard","blocked-uri":"https://evil.example/x.js","effective-directive":"script-src-elem"}}`, http.StatusNoContent},
+		"reporting API": {`[{"type":"csp-violation","body":{"documentURL":"https://go.example.com/","blockedURL":"inline","effectiveDirective":"script-src-elem"}}]`, http.StatusNoContent},
+		"malformed":     {`not json`, http.StatusBadRequest},
+	} {
+		w := httptest.NewRecorder()
+		NewCSPReportHandler().Report(w, httptest.NewRequest(http.MethodPost, CSPReportPath, strings.NewReader(c.body)))
+		if w.Code != c.want {
+			t.Errorf("%s: status = %d, want %d", name, w.Code, c.want)
+		}
+	}
+}
+
+// Governing: SPEC-0001 REQ "Security Headers"
+func TestCSPReport_RateLimit(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	h := NewCSPReportHandler()
+	h.now = func() time.Time { return now }
+	post := func(addr string) int {
+		req := httptest.NewRequest(http.MethodPost, CSPReportPath, strings.NewReader(`[]`))
+		req.RemoteAddr = addr
+		w := httptest.NewRecorder()
+		h.Report(w, req)
+		return w.Code
+	}
+
+	for i := 0; i < cspReportsPerMinute; i++ {
+		if code := post("203.0.113.5:1000"); code != http.StatusNoContent {
+			t.Fatalf("report %d: status = %d, want 204", i+1, code)
+		}
+	}
+	if code := post("203.0.113.5:1001"); code != http.StatusTooManyRequests {
+		t.Errorf("over the limit: status = %d, want 429", code)
+	}
+	if code := post("198.51.100.9:1000"); code != http.StatusNoContent {
+		t.Errorf("other address: status = %d, want 204", code)
+	}
+	now = now.Add(time.Minute)
+	if code := post("203.0.113.5:1000"); code != http.StatusNoContent {
+		t.Errorf("next minute: status = %d, want 204", code)
+	}
+}
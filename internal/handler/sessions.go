@@ -0,0 +1,109 @@
+// Governing: SPEC-0001 REQ "Session Management"
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/joestump/joe-links/internal/auth"
+	"github.com/joestump/joe-links/internal/store"
+)
+
+// SessionView is one row of the sessions page.
+type SessionView struct {
+	*store.UserSession
+	Current bool // the session making this request
+}
+
+// ShortIPHash is enough of the IP hash to tell sessions apart at a glance.
+func (v SessionView) ShortIPHash() string {
+	if len(v.IPHash) > 12 {
+		return v.IPHash[:12]
+	}
+	return v.IPHash
+}
+
+// SessionsPage is the template data for the user's active sessions page.
+type SessionsPage struct {
+	BasePage
+	Sessions []SessionView
+	Flash    *Flash
+}
+
+// SessionsHandler lists and revokes the signed-in user's sessions.
+type SessionsHandler struct {
+	tracker *auth.SessionTracker
+}
+
+// NewSessionsHandler creates a new SessionsHandler.
+func NewSessionsHandler(t *auth.SessionTracker) *SessionsHandler {
+	return &SessionsHandler{tracker: t}
+}
+
+// Index renders the user's active sessions.
+// GET /dashboard/settings/sessions
+func (h *SessionsHandler) Index(w http.ResponseWriter, r *http.Request) {
+	h.render(w, r, nil)
+}
+
+// Revoke signs out one of the user's other sessions. The current session is
+// refused; signing out is how it ends.
+// POST /dashboard/settings/sessions/{id}/delete
+func (h *SessionsHandler) Revoke(w http.ResponseWriter, r *http.Request) {
+	user := auth.UserFromContext(r.Context())
+	id := chi.URLParam(r, "id")
+	sessions, err := h.tracker.List(r.Context(), user.ID)
+	if err != nil {
+		renderError(w, r, http.StatusInternalServerError, "Could not load your sessions.")
+		return
+	}
+	current := h.tracker.CurrentToken(r.Context())
+	for _, s := range sessions {
+		if s.ID == id && s.Token == current {
+			renderError(w, r, http.StatusBadRequest, "Sign out to end the session you are using.")
+			return
+		}
+	}
+	err = h.tracker.Revoke(r.Context(), user.ID, id)
+	if errors.Is(err, store.ErrNotFound) {
+		renderError(w, r, http.StatusNotFound, "Session not found.")
+		return
+	}
+	if err != nil {
+		renderError(w, r, http.StatusInternalServerError, "Could not revoke the session.")
+		return
+	}
+	h.render(w, r, &Flash{Type: "success", Message: "Session revoked."})
+}
+
+// RevokeAll signs the user out of every session, this one included.
+// POST /dashboard/settings/sessions/delete
+func (h *SessionsHandler) RevokeAll(w http.ResponseWriter, r *http.Request) {
+	user := auth.UserFromContext(r.Context())
+	if err := h.tracker.RevokeAll(r.Context(), user.ID); err != nil {
+		renderError(w, r, http.StatusInternalServerError, "Could not sign out your sessions.")
+		return
+	}
+	auth.ClearSiteCache(w)
+	http.Redirect(w, r, "/", http.StatusSeeOther)
+}
+
+func (h *SessionsHandler) render(w http.ResponseWriter, r *http.Request, flash *Flash) {
+	user := auth.UserFromContext(r.Context())
+	sessions, err := h.tracker.List(r.Context(), user.ID)
+	if err != nil {
+		renderError(w, r, http.StatusInternalServerError, "Could not load your sessions.")
+		return
+	}
+	current := h.tracker.CurrentToken(r.Context())
+	data := SessionsPage{BasePage: newBasePage(r, user)}
+	for _, s := range sessions {
+		data.Sessions = append(data.Sessions, SessionView{UserSession: s, Current: s.Token == current})
+	}
+	if flash != nil {
+		flash.Message = data.T(flash.Message)
+		data.Flash = flash
+	}
+	render(w, "settings/sessions.html", data)
+}
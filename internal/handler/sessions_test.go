@@ -0,0 +1,105 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/joestump/joe-links/internal/auth"
+	"github.com/joestump/joe-links/internal/store"
+	"github.com/joestump/joe-links/internal/testutil"
+)
+
+// Governing: SPEC-0001 REQ "Session Management"
+func TestSessions_ListAndRevoke(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	users := store.NewUserStore(db)
+	user, err := users.Upsert(context.Background(), "test", "sub1", "u@example.com", "User", "")
+	if err != nil {
+		t.Fatalf("seed user: %v", err)
+	}
+	sm := auth.NewSessionManager(db, "sqlite3", time.Hour, false)
+	ss := store.NewSessionStore(db)
+	tracker := auth.NewSessionTracker(sm, ss)
+
+	h := NewSessionsHandler(tracker)
+	r := chi.NewRouter()
+	r.Use(sm.LoadAndSave, tracker.Track)
+	r.Get("/login", func(w http.ResponseWriter, r *http.Request) {
+		_ = sm.RenewToken(r.Context())
+		sm.Put(r.Context(), auth.SessionUserIDKey, user.ID)
+	})
+	r.Group(func(r chi.Router) {
+		r.Use(auth.NewMiddleware(sm, users).RequireAuth)
+		r.Get("/dashboard/settings/sessions", h.Index)
+		r.Post("/dashboard/settings/sessions/delete", h.RevokeAll)
+		r.Post("/dashboard/settings/sessions/{id}/delete", h.Revoke)
+	})
+
+	serve := func(method, path string, cookie *http.Cookie, ua string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		if cookie != nil {
+			req.AddCookie(cookie)
+		}
+		req.Header.Set("User-Agent", ua)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+	login := func(ua string) *http.Cookie {
+		c := serve(http.MethodGet, "/login", nil, ua).Result().Cookies()
+		if len(c) == 0 {
+			t.Fatal("login set no cookie")
+		}
+		return c[0]
+	}
+	laptop, phone := login("Laptop"), login("Phone")
+
+	// Sessions are recorded on their first signed-in request.
+	serve(http.MethodGet, "/dashboard/settings/sessions", phone, "Phone")
+	w := serve(http.MethodGet, "/dashboard/settings/sessions", laptop, "Laptop")
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), "Phone") || !strings.Contains(w.Body.String(), "This session") {
+		t.Fatalf("index: status = %d, body lacks sessions", w.Code)
+	}
+	if strings.Contains(w.Body.String(), laptop.Value) {
+		t.Error("session token rendered")
+	}
+
+	list, err := ss.ListByUser(context.Background(), user.ID)
+	if err != nil || len(list) != 2 {
+		t.Fatalf("recorded sessions = %d, %v", len(list), err)
+	}
+	ids := map[string]string{}
+	for _, s := range list {
+		ids[s.UserAgent] = s.ID
+	}
+
+	if w := serve(http.MethodPost, "/dashboard/settings/sessions/"+ids["Laptop"]+"/delete", laptop, "Laptop"); w.Code != http.StatusBadRequest {
+		t.Errorf("revoke current: status = %d, want 400", w.Code)
+	}
+	if w := serve(http.MethodPost, "/dashboard/settings/sessions/nope/delete", laptop, "Laptop"); w.Code != http.StatusNotFound {
+		t.Errorf("revoke unknown: status = %d, want 404", w.Code)
+	}
+	if w := serve(http.MethodPost, "/dashboard/settings/sessions/"+ids["Phone"]+"/delete", laptop, "Laptop"); w.Code != http.StatusOK || strings.Contains(w.Body.String(), "Phone") {
+		t.Errorf("revoke phone: status = %d, still listed = %v", w.Code, strings.Contains(w.Body.String(), "Phone"))
+	}
+	if w := serve(http.MethodGet, "/dashboard/settings/sessions", phone, "Phone"); w.Code != http.StatusFound {
+		t.Errorf("revoked session: status = %d, want redirect to login", w.Code)
+	}
+
+	// Signing out everywhere ends the current session too.
+	other := login("Tablet")
+	serve(http.MethodGet, "/dashboard/settings/sessions", other, "Tablet")
+	if w := serve(http.MethodPost, "/dashboard/settings/sessions/delete", laptop, "Laptop"); w.Code != http.StatusSeeOther || w.Header().Get("Clear-Site-Data") == "" {
+		t.Fatalf("sign out everywhere: status = %d, Clear-Site-Data = %q", w.Code, w.Header().Get("Clear-Site-Data"))
+	}
+	for _, c := range []*http.Cookie{laptop, other} {
+		if w := serve(http.MethodGet, "/dashboard/settings/sessions", c, "x"); w.Code != http.StatusFound {
+			t.Errorf("after sign out everywhere: status = %d, want redirect", w.Code)
+		}
+	}
+}
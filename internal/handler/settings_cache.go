@@ -0,0 +1,55 @@
+// Governing: SPEC-0003 REQ "Instance Branding"
+package handler
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+)
+
+// settingsCacheMaxAge bounds how long a cached instance setting is served,
+// so other replicas pick up an admin's change within it.
+const settingsCacheMaxAge = 30 * time.Second
+
+// cachedSetting keeps the layout from reading the settings table on every
+// page render. load is nil until the router wires a SettingsStore in, and
+// get then returns the zero T.
+type cachedSetting[T any] struct {
+	mu       sync.Mutex
+	load     func(context.Context) (T, error)
+	value    T
+	loadedAt time.Time
+}
+
+// get returns the cached value, reloading it once it is older than
+// settingsCacheMaxAge. A failed reload keeps serving the last good value.
+func (c *cachedSetting[T]) get(ctx context.Context) T {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.load == nil || (!c.loadedAt.IsZero() && time.Since(c.loadedAt) < settingsCacheMaxAge) {
+		return c.value
+	}
+	v, err := c.load(ctx)
+	if err != nil {
+		log.Printf("load setting: %v", err)
+		return c.value
+	}
+	c.value, c.loadedAt = v, time.Now()
+	return v
+}
+
+// set replaces the cached value after an admin saves it.
+func (c *cachedSetting[T]) set(v T) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.value, c.loadedAt = v, time.Now()
+}
+
+// reset sets the loader and drops the cached value.
+func (c *cachedSetting[T]) reset(load func(context.Context) (T, error)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var zero T
+	c.load, c.value, c.loadedAt = load, zero, time.Time{}
+}
@@ -0,0 +1,169 @@
+// Governing: SPEC-0001 REQ "First-Run Setup"
+package handler
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/joestump/joe-links/internal/auth"
+	"github.com/joestump/joe-links/internal/setup"
+	"github.com/joestump/joe-links/internal/store"
+)
+
+// setupNotices are the confirmations shown after each step, keyed by the
+// ?saved= value the step redirects with.
+var setupNotices = map[string]string{
+	"admin":   "Admin email saved. Sign in with that account to continue.",
+	"keyword": "Keyword saved.",
+	"demo":    "Demo links created.",
+}
+
+// SetupHandler serves the first-run setup wizard at /setup.
+type SetupHandler struct {
+	setup      *setup.Service
+	adminEmail string // JOE_ADMIN_EMAIL, which takes precedence over the wizard's choice
+}
+
+// NewSetupHandler creates a new SetupHandler. adminEmail is JOE_ADMIN_EMAIL.
+func NewSetupHandler(s *setup.Service, adminEmail string) *SetupHandler {
+	return &SetupHandler{setup: s, adminEmail: adminEmail}
+}
+
+// SetupPage is the template data for the setup wizard.
+type SetupPage struct {
+	BasePage
+	User            *store.User
+	ConfiguredAdmin string // JOE_ADMIN_EMAIL, if set
+	AdminEmail      string // the email that is granted admin at sign-in
+	HasAdmin        bool   // some user already has the admin role
+	DemoLinks       []setup.DemoLink
+	Notice          string
+	Error           string
+}
+
+// RequirePending serves 404 for every /setup route once setup has finished.
+func (h *SetupHandler) RequirePending(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		pending, err := h.setup.Pending(r.Context())
+		if err != nil {
+			log.Printf("setup: pending check: %v", err)
+			renderError(w, r, http.StatusInternalServerError, "Something went wrong.")
+			return
+		}
+		if !pending {
+			renderError(w, r, http.StatusNotFound, "Setup is already complete.")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Index renders the wizard.
+// GET /setup
+func (h *SetupHandler) Index(w http.ResponseWriter, r *http.Request) {
+	h.render(w, r, http.StatusOK, setupNotices[r.URL.Query().Get("saved")], "")
+}
+
+// SaveAdmin chooses the account that becomes admin at sign-in. It is only
+// available until an admin exists and when JOE_ADMIN_EMAIL is unset.
+// POST /setup/admin
+func (h *SetupHandler) SaveAdmin(w http.ResponseWriter, r *http.Request) {
+	hasAdmin, err := h.setup.HasAdmin(r.Context())
+	if err != nil {
+		renderError(w, r, http.StatusInternalServerError, "Something went wrong.")
+		return
+	}
+	if hasAdmin || h.adminEmail != "" {
+		renderError(w, r, http.StatusForbidden, "The admin account is already chosen.")
+		return
+	}
+	if err := h.setup.SetAdminEmail(r.Context(), r.FormValue("email")); err != nil {
+		if errors.Is(err, setup.ErrInvalidEmail) {
+			h.render(w, r, http.StatusBadRequest, "", "Enter the email address your identity provider reports for the admin.")
+			return
+		}
+		renderError(w, r, http.StatusInternalServerError, "Could not save the admin email.")
+		return
+	}
+	http.Redirect(w, r, "/setup?saved=admin", http.StatusSeeOther)
+}
+
+// SaveKeyword creates a keyword. Admin only.
+// POST /setup/keyword
+func (h *SetupHandler) SaveKeyword(w http.ResponseWriter, r *http.Request) {
+	if h.requireAdmin(w, r) == nil {
+		return
+	}
+	_, err := h.setup.AddKeyword(r.Context(), r.FormValue("keyword"), r.FormValue("url_template"), r.FormValue("description"))
+	switch {
+	case errors.Is(err, setup.ErrInvalidKeyword):
+		h.render(w, r, http.StatusBadRequest, "", "Keyword must be lowercase letters, digits, and hyphens (e.g. jira, my-tool).")
+		return
+	case errors.Is(err, setup.ErrKeywordTemplate):
+		h.render(w, r, http.StatusBadRequest, "", "URL template must contain {slug} placeholder.")
+		return
+	case err != nil:
+		renderError(w, r, http.StatusInternalServerError, "Failed to create keyword.")
+		return
+	}
+	http.Redirect(w, r, "/setup?saved=keyword", http.StatusSeeOther)
+}
+
+// CreateDemoLinks creates the demo links, owned by the signed-in admin.
+// POST /setup/demo
+func (h *SetupHandler) CreateDemoLinks(w http.ResponseWriter, r *http.Request) {
+	user := h.requireAdmin(w, r)
+	if user == nil {
+		return
+	}
+	if _, err := h.setup.AddDemoLinks(r.Context(), user.ID); err != nil {
+		log.Printf("setup: %v", err)
+		h.render(w, r, http.StatusBadRequest, "", "Could not create the demo links: "+err.Error())
+		return
+	}
+	http.Redirect(w, r, "/setup?saved=demo", http.StatusSeeOther)
+}
+
+// Finish marks setup complete and sends the admin to the dashboard.
+// POST /setup/finish
+func (h *SetupHandler) Finish(w http.ResponseWriter, r *http.Request) {
+	if h.requireAdmin(w, r) == nil {
+		return
+	}
+	if err := h.setup.Complete(r.Context()); err != nil {
+		renderError(w, r, http.StatusInternalServerError, "Could not finish setup.")
+		return
+	}
+	http.Redirect(w, r, "/dashboard", http.StatusSeeOther)
+}
+
+// requireAdmin returns the signed-in admin, or renders 403 and returns nil.
+func (h *SetupHandler) requireAdmin(w http.ResponseWriter, r *http.Request) *store.User {
+	user := auth.UserFromContext(r.Context())
+	if user == nil || !user.IsAdmin() {
+		renderError(w, r, http.StatusForbidden, "Sign in as the admin to continue setup.")
+		return nil
+	}
+	return user
+}
+
+// render renders the wizard with its current state.
+func (h *SetupHandler) render(w http.ResponseWriter, r *http.Request, status int, notice, errMsg string) {
+	user := auth.UserFromContext(r.Context())
+	hasAdmin, err := h.setup.HasAdmin(r.Context())
+	if err != nil {
+		renderError(w, r, http.StatusInternalServerError, "Something went wrong.")
+		return
+	}
+	renderWithStatus(w, status, "setup.html", SetupPage{
+		BasePage:        newBasePage(r, user),
+		User:            user,
+		ConfiguredAdmin: h.adminEmail,
+		AdminEmail:      auth.EffectiveAdminEmail(r.Context(), h.adminEmail, h.setup.AdminEmail),
+		HasAdmin:        hasAdmin,
+		DemoLinks:       setup.DemoLinks,
+		Notice:          notice,
+		Error:           errMsg,
+	})
+}
@@ -0,0 +1,104 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/joestump/joe-links/internal/auth"
+	"github.com/joestump/joe-links/internal/setup"
+	"github.com/joestump/joe-links/internal/store"
+	"github.com/joestump/joe-links/internal/testutil"
+)
+
+// Governing: SPEC-0001 REQ "First-Run Setup"
+func TestSetupWizard(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	ctx := context.Background()
+	us := store.NewUserStore(db)
+	ls := store.NewLinkStore(db, store.NewOwnershipStore(db), store.NewTagStore(db))
+	svc := setup.New(store.NewSettingsStore(db), us, ls, store.NewKeywordStore(db))
+
+	h := NewSetupHandler(svc, "")
+	landing := NewLandingHandler()
+	landing.setup = svc
+	r := chi.NewRouter()
+	r.Get("/", landing.Index)
+	r.Route("/setup", func(r chi.Router) {
+		r.Use(h.RequirePending)
+		r.Get("/", h.Index)
+		r.Post("/admin", h.SaveAdmin)
+		r.Post("/demo", h.CreateDemoLinks)
+		r.Post("/finish", h.Finish)
+	})
+	do := func(method, path string, user *store.User, form url.Values) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		if user != nil {
+			req = req.WithContext(context.WithValue(req.Context(), auth.UserContextKey, user))
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	if w := do("GET", "/", nil, nil); w.Code != http.StatusFound || w.Header().Get("Location") != "/setup" {
+		t.Fatalf("landing while pending: %d %q, want redirect to /setup", w.Code, w.Header().Get("Location"))
+	}
+	if w := do("GET", "/setup", nil, nil); w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `action="/setup/admin"`) {
+		t.Fatalf("GET /setup: %d, want the admin form", w.Code)
+	}
+	if w := do("POST", "/setup/admin", nil, url.Values{"email": {"not an email"}}); w.Code != http.StatusBadRequest {
+		t.Errorf("invalid admin email: status = %d, want 400", w.Code)
+	}
+	if w := do("POST", "/setup/admin", nil, url.Values{"email": {"admin@example.com"}}); w.Code != http.StatusSeeOther {
+		t.Fatalf("save admin: status = %d, want 303", w.Code)
+	}
+	if got := svc.AdminEmail(ctx); got != "admin@example.com" {
+		t.Fatalf("AdminEmail = %q", got)
+	}
+
+	// The chosen email is granted admin at sign-in.
+	role := auth.ResolveRole("admin@example.com", nil, auth.EffectiveAdminEmail(ctx, "", svc.AdminEmail), nil)
+	admin, err := us.Upsert(ctx, "test", "sub1", "admin@example.com", "Admin", role)
+	if err != nil {
+		t.Fatalf("seed admin: %v", err)
+	}
+	if !admin.IsAdmin() {
+		t.Fatalf("role = %q, want admin", admin.Role)
+	}
+	user, err := us.Upsert(ctx, "test", "sub2", "user@example.com", "User", "user")
+	if err != nil {
+		t.Fatalf("seed user: %v", err)
+	}
+
+	if w := do("POST", "/setup/admin", nil, url.Values{"email": {"other@example.com"}}); w.Code != http.StatusForbidden {
+		t.Errorf("changing the admin after sign-in: status = %d, want 403", w.Code)
+	}
+	if w := do("POST", "/setup/demo", user, nil); w.Code != http.StatusForbidden {
+		t.Errorf("demo links as non-admin: status = %d, want 403", w.Code)
+	}
+	if w := do("POST", "/setup/demo", admin, nil); w.Code != http.StatusSeeOther {
+		t.Fatalf("demo links: status = %d, want 303", w.Code)
+	}
+	if _, err := ls.GetBySlug(ctx, setup.DemoLinks[0].Slug); err != nil {
+		t.Errorf("demo link not created: %v", err)
+	}
+	if w := do("GET", "/setup", admin, nil); !strings.Contains(w.Body.String(), "with the admin role") || !strings.Contains(w.Body.String(), `action="/setup/finish"`) {
+		t.Errorf("GET /setup as admin: %d, want the sign-in confirmation and finish button", w.Code)
+	}
+	if w := do("POST", "/setup/finish", admin, nil); w.Code != http.StatusSeeOther || w.Header().Get("Location") != "/dashboard" {
+		t.Fatalf("finish: %d %q", w.Code, w.Header().Get("Location"))
+	}
+
+	if w := do("GET", "/setup", admin, nil); w.Code != http.StatusNotFound {
+		t.Errorf("GET /setup after finishing: status = %d, want 404", w.Code)
+	}
+	if w := do("GET", "/", nil, nil); w.Code != http.StatusOK {
+		t.Errorf("landing after finishing: status = %d, want 200", w.Code)
+	}
+}
@@ -0,0 +1,41 @@
+// Governing: SPEC-0010 REQ "Signed Share URLs"
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/joestump/joe-links/internal/shareurl"
+)
+
+// shareGrantKey is the context key for the signature carried by a share URL.
+type shareGrantKey struct{}
+
+// shareGrantFromContext returns the expiry and signature parameters of the
+// share URL being resolved, or nil for ordinary requests.
+func shareGrantFromContext(ctx context.Context) url.Values {
+	grant, _ := ctx.Value(shareGrantKey{}).(url.Values)
+	return grant
+}
+
+// ResolveShared resolves a signed share URL, /s/{slug}?exp=...&sig=..., as
+// /{slug} with the signature set aside for the visibility check. The exp and
+// sig parameters are never forwarded to the target. An expired or tampered
+// URL falls back to the usual secure-link rules.
+func (h *ResolveHandler) ResolveShared(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	grant := url.Values{
+		shareurl.ExpParam: {query.Get(shareurl.ExpParam)},
+		shareurl.SigParam: {query.Get(shareurl.SigParam)},
+	}
+	query.Del(shareurl.ExpParam)
+	query.Del(shareurl.SigParam)
+
+	r = r.Clone(context.WithValue(r.Context(), shareGrantKey{}, grant))
+	r.URL.Path = "/" + strings.TrimPrefix(r.URL.Path, shareurl.Prefix)
+	r.URL.RawPath = ""
+	r.URL.RawQuery = query.Encode()
+	h.Resolve(w, r)
+}
@@ -0,0 +1,110 @@
+// Governing: SPEC-0012 REQ "Sitemap and Robots"
+package handler
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/joestump/joe-links/internal/store"
+)
+
+// SitemapHandler serves /sitemap.xml and /robots.txt so crawlers such as
+// intranet search appliances index the public directory but not redirects.
+type SitemapHandler struct {
+	links      *store.LinkStore
+	indexSlugs bool
+}
+
+// NewSitemapHandler creates a new SitemapHandler. When indexSlugs is false,
+// robots.txt disallows everything outside the public directory, including
+// every slug resolution path.
+func NewSitemapHandler(ls *store.LinkStore, indexSlugs bool) *SitemapHandler {
+	return &SitemapHandler{links: ls, indexSlugs: indexSlugs}
+}
+
+// sitemapURLSet is a sitemaps.org 0.9 urlset document.
+type sitemapURLSet struct {
+	XMLName xml.Name     `xml:"http://www.sitemaps.org/schemas/sitemap/0.9 urlset"`
+	URLs    []sitemapURL `xml:"url"`
+}
+
+type sitemapURL struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+// pageCount returns the number of pages needed to show total items.
+func pageCount(total, perPage int) int {
+	if total <= perPage {
+		return 1
+	}
+	return (total + perPage - 1) / perPage
+}
+
+// Sitemap lists every page of the public link browser and of each user
+// profile with public links.
+// GET /sitemap.xml
+func (h *SitemapHandler) Sitemap(w http.ResponseWriter, r *http.Request) {
+	newest, total, err := h.links.ListPublic(r.Context(), "", "", 1, 1)
+	if err != nil {
+		http.Error(w, "could not load sitemap", http.StatusInternalServerError)
+		return
+	}
+	profiles, err := h.links.ListPublicProfiles(r.Context())
+	if err != nil {
+		http.Error(w, "could not load sitemap", http.StatusInternalServerError)
+		return
+	}
+
+	base := newBasePage(r, nil).SiteURL
+	var set sitemapURLSet
+	lastMod := ""
+	if len(newest) > 0 {
+		lastMod = newest[0].CreatedAt.UTC().Format(time.RFC3339)
+	}
+	set.URLs = append(set.URLs, sitemapURL{Loc: base + "/links", LastMod: lastMod})
+	for p := 2; p <= pageCount(total, defaultPageSize); p++ {
+		set.URLs = append(set.URLs, sitemapURL{Loc: fmt.Sprintf("%s/links?page=%d", base, p)})
+	}
+	for _, prof := range profiles {
+		loc := base + "/u/" + prof.Slug
+		set.URLs = append(set.URLs, sitemapURL{Loc: loc})
+		for p := 2; p <= pageCount(prof.Links, profilePageSize); p++ {
+			set.URLs = append(set.URLs, sitemapURL{Loc: fmt.Sprintf("%s?page=%d", loc, p)})
+		}
+	}
+
+	out, err := xml.MarshalIndent(set, "", "  ")
+	if err != nil {
+		http.Error(w, "could not render sitemap", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+	w.Write([]byte(xml.Header))
+	w.Write(out)
+}
+
+// Robots serves robots.txt. Slugs live at the site root, so by default the
+// file disallows everything and allows only the public directory back in;
+// crawlers that honour Allow pick the longest matching rule.
+// GET /robots.txt
+func (h *SitemapHandler) Robots(w http.ResponseWriter, r *http.Request) {
+	var b strings.Builder
+	b.WriteString("User-agent: *\n")
+	if h.indexSlugs {
+		for _, p := range []string{"/admin", "/api/", "/auth/", "/dashboard"} {
+			b.WriteString("Disallow: " + p + "\n")
+		}
+	} else {
+		for _, p := range []string{"/links", "/u/", "/static/"} {
+			b.WriteString("Allow: " + p + "\n")
+		}
+		b.WriteString("Disallow: /\n")
+	}
+	b.WriteString("\nSitemap: " + newBasePage(r, nil).SiteURL + "/sitemap.xml\n")
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Write([]byte(b.String()))
+}
@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"context"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/joestump/joe-links/internal/store"
+	"github.com/joestump/joe-links/internal/testutil"
+)
+
+// Governing: SPEC-0012 REQ "Sitemap and Robots"
+func TestSitemapAndRobots(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	owns := store.NewOwnershipStore(db)
+	ls := store.NewLinkStore(db, owns, store.NewTagStore(db))
+	us := store.NewUserStore(db)
+	ctx := context.Background()
+
+	for _, u := range []struct{ sub, name, slug, visibility string }{
+		{"sub1", "Public Owner", "handbook", "public"},
+		{"sub2", "Private Owner", "secret", "private"},
+	} {
+		owner, err := us.Upsert(ctx, "test", u.sub, u.sub+"@example.com", u.name, "")
+		if err != nil {
+			t.Fatalf("seed user: %v", err)
+		}
+		if _, err := ls.Create(ctx, u.slug, "https://example.com/"+u.slug, owner.ID, "", "", u.visibility); err != nil {
+			t.Fatalf("seed link: %v", err)
+		}
+	}
+
+	get := func(h *SitemapHandler, path string) *httptest.ResponseRecorder {
+		r := chi.NewRouter()
+		r.Get("/sitemap.xml", h.Sitemap)
+		r.Get("/robots.txt", h.Robots)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "http://go.example.com"+path, nil))
+		return w
+	}
+
+	t.Run("sitemap", func(t *testing.T) {
+		w := get(NewSitemapHandler(ls, false), "/sitemap.xml")
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want 200", w.Code)
+		}
+		var set sitemapURLSet
+		if err := xml.Unmarshal(w.Body.Bytes(), &set); err != nil {
+			t.Fatalf("parse sitemap: %v", err)
+		}
+		var locs []string
+		for _, u := range set.URLs {
+			locs = append(locs, u.Loc)
+		}
+		want := []string{"http://go.example.com/links", "http://go.example.com/u/public-owner"}
+		if strings.Join(locs, " ") != strings.Join(want, " ") {
+			t.Errorf("locs = %v, want %v", locs, want)
+		}
+		if set.URLs[0].LastMod == "" {
+			t.Error("expected /links to carry a lastmod")
+		}
+	})
+
+	t.Run("robots default", func(t *testing.T) {
+		body := get(NewSitemapHandler(ls, false), "/robots.txt").Body.String()
+		for _, line := range []string{"Allow: /links", "Allow: /u/", "Disallow: /\n", "Sitemap: http://go.example.com/sitemap.xml"} {
+			if !strings.Contains(body, line) {
+				t.Errorf("robots.txt missing %q:\n%s", line, body)
+			}
+		}
+	})
+
+	t.Run("robots index slugs", func(t *testing.T) {
+		body := get(NewSitemapHandler(ls, true), "/robots.txt").Body.String()
+		if strings.Contains(body, "Disallow: /\n") {
+			t.Errorf("robots.txt should not disallow the root:\n%s", body)
+		}
+		if !strings.Contains(body, "Disallow: /dashboard") {
+			t.Errorf("robots.txt should still disallow the dashboard:\n%s", body)
+		}
+	})
+}
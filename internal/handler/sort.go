@@ -0,0 +1,98 @@
+// Governing: SPEC-0004 REQ "Sortable Link Lists"
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/joestump/joe-links/internal/store"
+)
+
+// ListSort is the order of a sortable link list and what its column headers
+// need to re-sort it with HTMX.
+type ListSort struct {
+	Key    string // store.LinkSort* key; "" keeps the store's order
+	Desc   bool
+	Base   string // current path and filters without sort parameters, ending in "?" or "&"
+	Target string // selector of the element the re-sorted list replaces
+}
+
+// HeaderURL returns the URL a column header links to: the same list sorted
+// by key, reversing the direction when the list is already sorted by it.
+func (s *ListSort) HeaderURL(key string) string {
+	desc := defaultSortDesc(key)
+	if key == s.Key {
+		desc = !s.Desc
+	}
+	return s.Base + "sort=" + key + "&dir=" + sortDir(desc)
+}
+
+// Indicator returns the arrow shown beside the header of the sorted column.
+func (s *ListSort) Indicator(key string) string {
+	switch {
+	case key != s.Key:
+		return ""
+	case s.Desc:
+		return " ▼"
+	default:
+		return " ▲"
+	}
+}
+
+// defaultSortDesc reports whether a first click on key's header sorts
+// descending: clicks and dates start with the largest, slugs with A.
+func defaultSortDesc(key string) bool {
+	return key != store.LinkSortSlug
+}
+
+// sortDir returns the ?dir= value for desc.
+func sortDir(desc bool) string {
+	if desc {
+		return "desc"
+	}
+	return "asc"
+}
+
+// listSort resolves the sort for a link list. A valid ?sort= (with optional
+// ?dir=asc|desc) applies and is saved as the user's pref preference; without
+// one the saved preference applies. prefs may be nil, in which case sorts
+// last only for the request.
+func listSort(r *http.Request, prefs *store.PreferenceStore, userID, pref, target string) *ListSort {
+	params := r.URL.Query()
+	s := &ListSort{Target: target}
+	if key := params.Get("sort"); store.ValidLinkSort(key) {
+		s.Key = key
+		s.Desc = defaultSortDesc(key)
+		if dir := params.Get("dir"); dir == "asc" || dir == "desc" {
+			s.Desc = dir == "desc"
+		}
+		if prefs != nil {
+			_ = prefs.Set(r.Context(), userID, pref, s.Key+":"+sortDir(s.Desc))
+		}
+	} else if prefs != nil {
+		s.Key, s.Desc = savedSort(r.Context(), prefs, userID, pref)
+	}
+
+	params.Del("sort")
+	params.Del("dir")
+	s.Base = r.URL.Path + "?"
+	if enc := params.Encode(); enc != "" {
+		s.Base += enc + "&"
+	}
+	return s
+}
+
+// savedSort returns the user's saved sort, or no sort if none is saved or
+// the saved value is no longer valid.
+func savedSort(ctx context.Context, prefs *store.PreferenceStore, userID, pref string) (string, bool) {
+	v, err := prefs.Get(ctx, userID, pref)
+	if err != nil {
+		return "", false
+	}
+	key, dir, _ := strings.Cut(v, ":")
+	if !store.ValidLinkSort(key) {
+		return "", false
+	}
+	return key, dir == "desc"
+}
@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/joestump/joe-links/internal/auth"
+	"github.com/joestump/joe-links/internal/store"
+	"github.com/joestump/joe-links/internal/testutil"
+)
+
+// Governing: SPEC-0004 REQ "Sortable Link Lists"
+func TestDashboard_Sort(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	ts := store.NewTagStore(db)
+	ls := store.NewLinkStore(db, store.NewOwnershipStore(db), ts)
+	ctx := context.Background()
+	user, err := store.NewUserStore(db).Upsert(ctx, "test", "sub1", "user@example.com", "User", "")
+	if err != nil {
+		t.Fatalf("seed user: %v", err)
+	}
+	var events []store.ClickEvent
+	for i, slug := range []string{"alpha", "bravo"} {
+		link, err := ls.Create(ctx, slug, "https://example.com/"+slug, user.ID, "", "", "public")
+		if err != nil {
+			t.Fatalf("seed link: %v", err)
+		}
+		for range i * 2 {
+			events = append(events, store.ClickEvent{LinkID: link.ID, ClickedAt: time.Now()})
+		}
+	}
+	if _, err := store.NewClickStore(db).RecordClicks(ctx, events); err != nil {
+		t.Fatalf("RecordClicks: %v", err)
+	}
+
+	h := NewDashboardHandler(ls, ts, nil, nil, store.NewSavedSearchStore(db), nil)
+	h.prefs = store.NewPreferenceStore(db)
+	r := chi.NewRouter()
+	r.Get("/dashboard", h.Show)
+	get := func(target string) string {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		req.Header.Set("HX-Request", "true")
+		req = req.WithContext(context.WithValue(req.Context(), auth.UserContextKey, user))
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("GET %s: status %d", target, w.Code)
+		}
+		return w.Body.String()
+	}
+	first := func(body string) string {
+		if strings.Index(body, ">alpha<") < strings.Index(body, ">bravo<") {
+			return "alpha"
+		}
+		return "bravo"
+	}
+
+	if got := first(get("/dashboard")); got != "alpha" {
+		t.Errorf("default order starts with %s, want alpha", got)
+	}
+	body := get("/dashboard?q=a&sort=clicks")
+	if got := first(body); got != "bravo" {
+		t.Errorf("sort=clicks starts with %s, want bravo", got)
+	}
+	if !strings.Contains(body, `hx-get="/dashboard?q=a&amp;sort=clicks&amp;dir=asc"`) {
+		t.Error("clicks header does not reverse the sort and keep the query")
+	}
+	// The sort is remembered for the next visit without parameters.
+	if got := first(get("/dashboard")); got != "bravo" {
+		t.Errorf("saved sort starts with %s, want bravo", got)
+	}
+	if got := first(get("/dashboard?sort=slug&dir=asc")); got != "alpha" {
+		t.Errorf("sort=slug starts with %s, want alpha", got)
+	}
+}
@@ -0,0 +1,26 @@
+// Governing: SPEC-0004 REQ "Progressive Web App"
+package handler
+
+import (
+	"io/fs"
+	"net/http"
+)
+
+// staticHandler serves the embedded static assets under /static/. The web
+// app manifest is sent with its registered media type, and the service
+// worker is allowed to control /dashboard although it lives under /static/.
+func staticHandler(fsys fs.FS) http.Handler {
+	files := http.StripPrefix("/static", http.FileServerFS(fsys))
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/static/sw.js":
+			w.Header().Set("Service-Worker-Allowed", "/dashboard")
+			// Browsers check for a new worker on each visit; a cached copy
+			// would hold an old one in place.
+			w.Header().Set("Cache-Control", "no-cache")
+		case "/static/manifest.webmanifest":
+			w.Header().Set("Content-Type", "application/manifest+json")
+		}
+		files.ServeHTTP(w, r)
+	})
+}
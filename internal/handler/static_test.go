@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"encoding/json"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/joestump/joe-links/web"
+)
+
+// Governing: SPEC-0004 REQ "Progressive Web App"
+func TestStaticHandler_PWA(t *testing.T) {
+	sub, err := fs.Sub(web.StaticFS, "static")
+	if err != nil {
+		t.Fatalf("sub static FS: %v", err)
+	}
+	h := staticHandler(sub)
+	get := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w
+	}
+
+	w := get("/static/manifest.webmanifest")
+	if w.Code != http.StatusOK || w.Header().Get("Content-Type") != "application/manifest+json" {
+		t.Fatalf("manifest: status = %d, Content-Type = %q", w.Code, w.Header().Get("Content-Type"))
+	}
+	var manifest struct {
+		StartURL string `json:"start_url"`
+		Display  string `json:"display"`
+		Icons    []struct {
+			Src string `json:"src"`
+		} `json:"icons"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &manifest); err != nil {
+		t.Fatalf("manifest: %v", err)
+	}
+	if manifest.StartURL != "/dashboard" || manifest.Display != "standalone" || len(manifest.Icons) == 0 {
+		t.Errorf("manifest = %+v", manifest)
+	}
+	for _, icon := range manifest.Icons {
+		if w := get(icon.Src); w.Code != http.StatusOK {
+			t.Errorf("icon %s: status = %d", icon.Src, w.Code)
+		}
+	}
+
+	w = get("/static/sw.js")
+	if w.Code != http.StatusOK || w.Header().Get("Service-Worker-Allowed") != "/dashboard" || w.Header().Get("Cache-Control") != "no-cache" {
+		t.Errorf("service worker: status = %d, headers = %v", w.Code, w.Header())
+	}
+
+	if w := get("/static/css/app.css"); w.Code != http.StatusOK || w.Header().Get("Service-Worker-Allowed") != "" {
+		t.Errorf("app.css: status = %d, Service-Worker-Allowed = %q", w.Code, w.Header().Get("Service-Worker-Allowed"))
+	}
+}
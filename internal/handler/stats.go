@@ -5,9 +5,13 @@ import (
 	"log"
 	"net/http"
 	"net/url"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/joestump/joe-links/internal/auth"
+	"github.com/joestump/joe-links/internal/campaign"
 	"github.com/joestump/joe-links/internal/store"
 )
 
@@ -19,13 +23,49 @@ type StatsPage struct {
 	Link         *store.Link
 	Stats        store.ClickStats
 	RecentClicks []store.RecentClick
+	AllReferrers bool // counting excluded referrers too (?referrers=all)
+	IncludeBots  bool // counting clicks flagged as bots too (?bots=include)
+	// Countries is the per-country breakdown; empty unless some click has a
+	// known country. Governing: SPEC-0016 REQ "GeoIP Country Breakdown"
+	Countries []store.CountryCount
+
+	// Sources is the per-campaign-source breakdown; empty unless some click
+	// carried a source. Campaign holds the campaign link form and its result;
+	// nil when campaign links are unavailable.
+	// Governing: SPEC-0016 REQ "Campaign Sources"
+	Sources  []store.SourceCount
+	Campaign *CampaignForm
+
+	// ReferrersToggleURL and BotsToggleURL flip one filter, keeping the other.
+	ReferrersToggleURL string
+	BotsToggleURL      string
 }
 
+// CampaignForm is the stats page's campaign link generator.
+// Governing: SPEC-0016 REQ "Campaign Sources"
+type CampaignForm struct {
+	Source  string
+	Days    int
+	URL     string // the generated link; empty until the form is submitted
+	Expires time.Time
+	Error   string
+}
+
+// campaignDays are the lifetimes the campaign link form offers.
+var campaignDays = []int{7, 30, 90, 365}
+
+// CampaignDays returns the lifetimes the campaign link form offers.
+func (CampaignForm) CampaignDays() []int { return campaignDays }
+
 // StatsHandler serves the per-link analytics page.
 type StatsHandler struct {
 	links  *store.LinkStore
 	clicks *store.ClickStore
 	owns   *store.OwnershipStore
+
+	// campaigns signs campaign source tags; nil hides the campaign link form.
+	// Governing: SPEC-0016 REQ "Campaign Sources"
+	campaigns *campaign.Signer
 }
 
 // NewStatsHandler creates a new StatsHandler.
@@ -42,40 +82,53 @@ func (h *StatsHandler) Show(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	id := chi.URLParam(r, "id")
-	link, err := h.links.GetByID(r.Context(), id)
+	link, ok := h.authorizedLink(w, r, user)
+	if !ok {
+		return
+	}
+
+	// Governing: SPEC-0016 REQ "Referrer Exclusion" — ?referrers=all counts excluded referrers
+	clicks := h.clicks
+	allReferrers := r.URL.Query().Get("referrers") == "all"
+	if allReferrers {
+		clicks = clicks.IncludingExcluded()
+	}
+	// Governing: SPEC-0016 REQ "Bot Filtering" — ?bots=include counts bot clicks
+	includeBots := r.URL.Query().Get("bots") == "include"
+	if includeBots {
+		clicks = clicks.IncludingBots()
+	}
+
+	stats, err := clicks.GetClickStats(r.Context(), link.ID)
 	if err != nil {
-		http.NotFound(w, r)
+		renderError(w, r, http.StatusInternalServerError, "Could not load stats.")
 		return
 	}
 
-	// Check ownership: user must be owner/co-owner or admin
-	// Governing: SPEC-0016 REQ "Link Stats Dashboard Page"
-	if !user.IsAdmin() {
-		isOwner, err := h.owns.IsOwner(link.ID, user.ID)
-		if err != nil {
-			log.Printf("stats: IsOwner check failed for link %s user %s: %v", link.ID, user.ID, err)
-			http.Error(w, "internal server error", http.StatusInternalServerError)
-			return
-		}
-		if !isOwner {
-			w.WriteHeader(http.StatusForbidden)
-			render(w, "403.html", newBasePage(r, user))
-			return
-		}
+	recent, err := clicks.ListRecentClicks(r.Context(), link.ID, 50)
+	if err != nil {
+		renderError(w, r, http.StatusInternalServerError, "Could not load recent clicks.")
+		return
 	}
 
-	stats, err := h.clicks.GetClickStats(r.Context(), link.ID)
+	countries, err := clicks.TopCountries(r.Context(), link.ID, statsCountryLimit)
 	if err != nil {
-		http.Error(w, "could not load stats", http.StatusInternalServerError)
+		renderError(w, r, http.StatusInternalServerError, "Could not load stats.")
 		return
 	}
+	if len(countries) == 1 && countries[0].Country == "" {
+		countries = nil // GeoIP is off, or every click is of unknown country
+	}
 
-	recent, err := h.clicks.ListRecentClicks(r.Context(), link.ID, 50)
+	// Governing: SPEC-0016 REQ "Campaign Sources"
+	sources, err := clicks.TopSources(r.Context(), link.ID, statsSourceLimit)
 	if err != nil {
-		http.Error(w, "could not load recent clicks", http.StatusInternalServerError)
+		renderError(w, r, http.StatusInternalServerError, "Could not load stats.")
 		return
 	}
+	if len(sources) == 1 && sources[0].Source == "" {
+		sources = nil // no click carried a campaign source
+	}
 
 	data := StatsPage{
 		BasePage:     newBasePage(r, user),
@@ -83,6 +136,17 @@ func (h *StatsHandler) Show(w http.ResponseWriter, r *http.Request) {
 		Link:         link,
 		Stats:        stats,
 		RecentClicks: recent,
+		AllReferrers: allReferrers,
+		IncludeBots:  includeBots,
+		Countries:    countries,
+		Sources:      sources,
+
+		ReferrersToggleURL: statsURL(link.ID, !allReferrers, includeBots),
+		BotsToggleURL:      statsURL(link.ID, allReferrers, !includeBots),
+	}
+
+	if h.campaigns != nil {
+		data.Campaign = h.campaignForm(r, link, data.SiteURL)
 	}
 
 	if isHTMX(r) {
@@ -91,3 +155,144 @@ func (h *StatsHandler) Show(w http.ResponseWriter, r *http.Request) {
 	}
 	render(w, "links/stats.html", data)
 }
+
+// StatsWidget is the template data for the compact stats card on the link
+// detail page.
+// Governing: SPEC-0016 REQ "Link Detail Stats Widget"
+type StatsWidget struct {
+	Link      *store.Link
+	Stats     store.ClickStats
+	Sparkline string // SVG polyline points for the daily clicks, oldest first
+	PeakDay   int64  // most clicks on one day in the sparkline
+}
+
+// widgetDays is how many days the widget's sparkline covers.
+const widgetDays = 30
+
+// Sparkline dimensions, in SVG user units.
+const (
+	sparklineWidth  = 120
+	sparklineHeight = 32
+)
+
+// Widget renders the compact stats card that the link detail page lazy-loads.
+// GET /dashboard/links/{id}/stats/widget
+// Governing: SPEC-0016 REQ "Link Detail Stats Widget"
+func (h *StatsHandler) Widget(w http.ResponseWriter, r *http.Request) {
+	user := auth.UserFromContext(r.Context())
+	if user == nil {
+		renderError(w, r, http.StatusUnauthorized, "Please sign in.")
+		return
+	}
+	link, ok := h.authorizedLink(w, r, user)
+	if !ok {
+		return
+	}
+	stats, err := h.clicks.GetClickStats(r.Context(), link.ID)
+	if err != nil {
+		renderError(w, r, http.StatusInternalServerError, "Could not load stats.")
+		return
+	}
+	daily, err := h.clicks.DailyClicks(r.Context(), link.ID, widgetDays)
+	if err != nil {
+		renderError(w, r, http.StatusInternalServerError, "Could not load stats.")
+		return
+	}
+	data := StatsWidget{Link: link, Stats: stats}
+	data.Sparkline, data.PeakDay = sparkline(daily)
+	renderPageFragment(w, "links/detail.html", "link_stats_widget", data)
+}
+
+// sparkline returns SVG polyline points plotting counts across a
+// sparklineWidth by sparklineHeight box, and the largest count.
+func sparkline(counts []int64) (string, int64) {
+	var peak int64
+	for _, c := range counts {
+		peak = max(peak, c)
+	}
+	if len(counts) < 2 {
+		return "", peak
+	}
+	points := make([]string, len(counts))
+	step := float64(sparklineWidth) / float64(len(counts)-1)
+	for i, c := range counts {
+		y := float64(sparklineHeight - 1)
+		if peak > 0 {
+			y -= float64(c) / float64(peak) * float64(sparklineHeight-2)
+		}
+		points[i] = strconv.FormatFloat(float64(i)*step, 'f', 1, 64) + "," + strconv.FormatFloat(y, 'f', 1, 64)
+	}
+	return strings.Join(points, " "), peak
+}
+
+// authorizedLink loads the {id} link and checks that user owns it or is an
+// admin, writing the error response and returning false otherwise.
+// Governing: SPEC-0016 REQ "Link Stats Dashboard Page"
+func (h *StatsHandler) authorizedLink(w http.ResponseWriter, r *http.Request, user *store.User) (*store.Link, bool) {
+	link, err := h.links.GetByID(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		renderError(w, r, http.StatusNotFound, "That item no longer exists.")
+		return nil, false
+	}
+	if user.IsAdmin() {
+		return link, true
+	}
+	isOwner, err := h.owns.IsOwner(link.ID, user.ID)
+	if err != nil {
+		log.Printf("stats: IsOwner check failed for link %s user %s: %v", link.ID, user.ID, err)
+		renderError(w, r, http.StatusInternalServerError, "Something went wrong.")
+		return nil, false
+	}
+	if !isOwner {
+		renderWithStatus(w, http.StatusForbidden, "403.html", newBasePage(r, user))
+		return nil, false
+	}
+	return link, true
+}
+
+// statsCountryLimit is how many countries the stats page lists.
+const statsCountryLimit = 20
+
+// statsSourceLimit is how many campaign sources the stats page lists.
+const statsSourceLimit = 20
+
+// campaignForm fills the campaign link form from the request's src and days
+// parameters, signing a link when src is given.
+// Governing: SPEC-0016 REQ "Campaign Sources"
+func (h *StatsHandler) campaignForm(r *http.Request, link *store.Link, siteURL string) *CampaignForm {
+	f := &CampaignForm{Source: r.URL.Query().Get(campaign.Param), Days: 30}
+	if d, err := strconv.Atoi(r.URL.Query().Get("days")); err == nil {
+		for _, allowed := range campaignDays {
+			if d == allowed {
+				f.Days = d
+			}
+		}
+	}
+	if f.Source == "" {
+		return f
+	}
+	f.Expires = time.Now().UTC().AddDate(0, 0, f.Days)
+	tag, err := h.campaigns.Sign(link.ID, f.Source, f.Expires)
+	if err != nil {
+		f.Error = "Use 1-32 lowercase letters, digits, dashes, or underscores."
+		return f
+	}
+	f.URL = siteURL + "/" + link.Slug + "?" + campaign.Param + "=" + tag
+	return f
+}
+
+// statsURL returns the stats page URL for a link with the given filters.
+func statsURL(linkID string, allReferrers, includeBots bool) string {
+	var params []string
+	if allReferrers {
+		params = append(params, "referrers=all")
+	}
+	if includeBots {
+		params = append(params, "bots=include")
+	}
+	u := "/dashboard/links/" + linkID + "/stats"
+	if len(params) > 0 {
+		u += "?" + strings.Join(params, "&")
+	}
+	return u
+}
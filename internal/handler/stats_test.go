@@ -0,0 +1,21 @@
+package handler
+
+import "testing"
+
+// Governing: SPEC-0016 REQ "Link Detail Stats Widget"
+func TestSparkline(t *testing.T) {
+	points, peak := sparkline([]int64{0, 4, 2})
+	if peak != 4 {
+		t.Errorf("peak = %d, want 4", peak)
+	}
+	if want := "0.0,31.0 60.0,1.0 120.0,16.0"; points != want {
+		t.Errorf("points = %q, want %q", points, want)
+	}
+
+	if points, _ := sparkline([]int64{0, 0}); points != "0.0,31.0 120.0,31.0" {
+		t.Errorf("no clicks: points = %q, want a flat line", points)
+	}
+	if points, _ := sparkline([]int64{3}); points != "" {
+		t.Errorf("one day: points = %q, want none", points)
+	}
+}
@@ -0,0 +1,43 @@
+// Governing: SPEC-0016 REQ "Status Page"
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/joestump/joe-links/internal/auth"
+	"github.com/joestump/joe-links/internal/status"
+)
+
+// StatusPage is the template data for the public status page.
+type StatusPage struct {
+	BasePage
+	Report status.Report
+}
+
+// StatusHandler serves the public server status page.
+type StatusHandler struct {
+	checker *status.Checker
+}
+
+// NewStatusHandler creates a new StatusHandler.
+func NewStatusHandler(checker *status.Checker) *StatusHandler {
+	return &StatusHandler{checker: checker}
+}
+
+// Show renders the status page, with 503 Service Unavailable when the
+// database is down so uptime monitors can watch it directly.
+// GET /status
+func (h *StatusHandler) Show(w http.ResponseWriter, r *http.Request) {
+	report := h.checker.Check(r.Context())
+	report.Database.Latency = report.Database.Latency.Round(10 * time.Microsecond)
+	code := http.StatusOK
+	if report.Status == status.Down {
+		code = http.StatusServiceUnavailable
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	renderWithStatus(w, code, "status.html", StatusPage{
+		BasePage: newBasePage(r, auth.UserFromContext(r.Context())),
+		Report:   report,
+	})
+}
@@ -0,0 +1,34 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/joestump/joe-links/internal/status"
+	"github.com/joestump/joe-links/internal/testutil"
+)
+
+// Governing: SPEC-0016 REQ "Status Page"
+func TestStatusPage(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	h := NewStatusHandler(status.NewChecker(db, status.Job{Name: "test_job", Interval: time.Minute}))
+
+	w := httptest.NewRecorder()
+	h.Show(w, httptest.NewRequest(http.MethodGet, "/status", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d; want 200", w.Code)
+	}
+	if body := w.Body.String(); !strings.Contains(body, "test_job") || !strings.Contains(body, "not run yet") {
+		t.Errorf("page missing the job row:\n%s", body)
+	}
+
+	_ = db.Close()
+	w = httptest.NewRecorder()
+	h.Show(w, httptest.NewRequest(http.MethodGet, "/status", nil))
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("status with the database down = %d; want 503", w.Code)
+	}
+}
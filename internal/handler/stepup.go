@@ -0,0 +1,158 @@
+// Governing: SPEC-0001 REQ "WebAuthn Step-Up"
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/joestump/joe-links/internal/auth"
+)
+
+// passkeysEnabled is set at startup when Deps.WebAuthn is configured.
+var passkeysEnabled bool
+
+// requireStepUp is middleware for sensitive actions: unless the session
+// confirmed a passkey within auth.StepUpWindow, the user is sent to the
+// step-up page and returned to the page they came from afterwards, where
+// they repeat the action. Must run after RequireAuth.
+func requireStepUp(wa *auth.WebAuthn) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if wa.SteppedUp(r.Context()) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			sendToStepUp(w, r)
+		})
+	}
+}
+
+// requireStepUpIfEnrolled is requireStepUp for users who have a passkey; the
+// rest pass through, so a first passkey can be registered.
+func requireStepUpIfEnrolled(wa *auth.WebAuthn) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			user := auth.UserFromContext(r.Context())
+			enrolled, err := wa.Enrolled(r.Context(), user.ID)
+			if err != nil {
+				renderError(w, r, http.StatusInternalServerError, "Could not load your passkeys.")
+				return
+			}
+			if !enrolled || wa.SteppedUp(r.Context()) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			sendToStepUp(w, r)
+		})
+	}
+}
+
+// sendToStepUp redirects to the step-up page, returning to the referring
+// page: a POST or DELETE cannot be replayed after a redirect.
+func sendToStepUp(w http.ResponseWriter, r *http.Request) {
+	back := r.URL.RequestURI()
+	if r.Method != http.MethodGet {
+		back = "/dashboard"
+		if ref, err := url.Parse(r.Referer()); err == nil && ref.Host == r.Host {
+			back = localPath(ref.RequestURI())
+		}
+	}
+	target := "/dashboard/step-up?" + url.Values{"redirect": {back}}.Encode()
+	if isHTMX(r) {
+		w.Header().Set("HX-Redirect", target)
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+	http.Redirect(w, r, target, http.StatusSeeOther)
+}
+
+// localPath returns p if it is a path on this site, else "/dashboard".
+func localPath(p string) string {
+	if !strings.HasPrefix(p, "/") || strings.HasPrefix(p, "//") || strings.HasPrefix(p, "/\\") {
+		return "/dashboard"
+	}
+	return p
+}
+
+// StepUpPage is the template data for the passkey confirmation page.
+type StepUpPage struct {
+	BasePage
+	Redirect string
+	Enrolled bool
+}
+
+// StepUpHandler confirms the signed-in user with one of their passkeys.
+type StepUpHandler struct {
+	webauthn *auth.WebAuthn
+}
+
+// NewStepUpHandler creates a new StepUpHandler.
+func NewStepUpHandler(wa *auth.WebAuthn) *StepUpHandler {
+	return &StepUpHandler{webauthn: wa}
+}
+
+// Show renders the confirmation page.
+// GET /dashboard/step-up?redirect=/admin/users
+func (h *StepUpHandler) Show(w http.ResponseWriter, r *http.Request) {
+	user := auth.UserFromContext(r.Context())
+	enrolled, err := h.webauthn.Enrolled(r.Context(), user.ID)
+	if err != nil {
+		renderError(w, r, http.StatusInternalServerError, "Could not load your passkeys.")
+		return
+	}
+	render(w, "step_up.html", StepUpPage{
+		BasePage: newBasePage(r, user),
+		Redirect: localPath(r.URL.Query().Get("redirect")),
+		Enrolled: enrolled,
+	})
+}
+
+// Begin returns the options for navigator.credentials.get.
+// POST /dashboard/step-up/begin
+func (h *StepUpHandler) Begin(w http.ResponseWriter, r *http.Request) {
+	user := auth.UserFromContext(r.Context())
+	opts, err := h.webauthn.BeginAssertion(r, user.ID)
+	if errors.Is(err, auth.ErrNoPasskeys) {
+		writeWebAuthnJSON(w, http.StatusConflict, map[string]string{"error": "Register a passkey first."})
+		return
+	}
+	if err != nil {
+		log.Printf("step-up begin: %v", err)
+		writeWebAuthnJSON(w, http.StatusInternalServerError, map[string]string{"error": "Something went wrong."})
+		return
+	}
+	writeWebAuthnJSON(w, http.StatusOK, map[string]any{"publicKey": opts})
+}
+
+// Finish verifies the passkey assertion.
+// POST /dashboard/step-up/finish
+func (h *StepUpHandler) Finish(w http.ResponseWriter, r *http.Request) {
+	user := auth.UserFromContext(r.Context())
+	var resp auth.AssertionResponse
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 64<<10)).Decode(&resp); err != nil {
+		writeWebAuthnJSON(w, http.StatusBadRequest, map[string]string{"error": "Invalid response."})
+		return
+	}
+	if err := h.webauthn.FinishAssertion(r, user.ID, resp); err != nil {
+		if auth.IsVerificationError(err) {
+			writeWebAuthnJSON(w, http.StatusBadRequest, map[string]string{"error": "The passkey could not be verified."})
+			return
+		}
+		log.Printf("step-up finish: %v", err)
+		writeWebAuthnJSON(w, http.StatusInternalServerError, map[string]string{"error": "Something went wrong."})
+		return
+	}
+	writeWebAuthnJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// writeWebAuthnJSON answers a passkey ceremony request.
+func writeWebAuthnJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
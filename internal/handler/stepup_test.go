@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/alexedwards/scs/v2"
+	"github.com/go-chi/chi/v5"
+	"github.com/joestump/joe-links/internal/auth"
+	"github.com/joestump/joe-links/internal/store"
+	"github.com/joestump/joe-links/internal/testutil"
+)
+
+// Governing: SPEC-0001 REQ "WebAuthn Step-Up"
+func TestRequireStepUp(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	user, err := store.NewUserStore(db).Upsert(context.Background(), "test", "sub1", "admin@example.com", "Admin", "admin")
+	if err != nil {
+		t.Fatalf("seed user: %v", err)
+	}
+	sm := scs.New()
+	wa := auth.NewWebAuthn(sm, store.NewWebAuthnStore(db), "", "")
+	stepUpWeb := NewStepUpHandler(wa)
+
+	r := chi.NewRouter()
+	r.Use(sm.LoadAndSave, func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), auth.UserContextKey, user)))
+		})
+	})
+	r.With(requireStepUp(wa)).Delete("/admin/users/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	r.Get("/dashboard/step-up", stepUpWeb.Show)
+
+	serve := func(req *http.Request) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	req := httptest.NewRequest(http.MethodDelete, "/admin/users/u2", nil)
+	req.Header.Set("Referer", "http://example.com/admin/users")
+	w := serve(req)
+	if w.Code != http.StatusSeeOther || w.Header().Get("Location") != "/dashboard/step-up?redirect=%2Fadmin%2Fusers" {
+		t.Errorf("without step-up: status = %d, Location = %q", w.Code, w.Header().Get("Location"))
+	}
+
+	req = httptest.NewRequest(http.MethodDelete, "/admin/users/u2", nil)
+	req.Header.Set("HX-Request", "true")
+	req.Header.Set("Referer", "https://evil.example/phish")
+	w = serve(req)
+	if w.Code != http.StatusForbidden || w.Header().Get("HX-Redirect") != "/dashboard/step-up?redirect=%2Fdashboard" {
+		t.Errorf("HTMX without step-up: status = %d, HX-Redirect = %q", w.Code, w.Header().Get("HX-Redirect"))
+	}
+
+	// Without a passkey the step-up page points at registration.
+	w = serve(httptest.NewRequest(http.MethodGet, "/dashboard/step-up?redirect=//evil.example", nil))
+	if body := w.Body.String(); w.Code != http.StatusOK || !strings.Contains(body, "/dashboard/settings/passkeys") || strings.Contains(body, "evil.example") {
+		t.Errorf("step-up page: status = %d", w.Code)
+	}
+}
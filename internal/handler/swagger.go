@@ -0,0 +1,88 @@
+// Governing: SPEC-0007 REQ "Swagger UI Endpoint", REQ "Swagger UI Authorization", REQ "Swagger UI Session Try-It", REQ "Swagger UI Test Token", REQ "OpenAPI 3.1 Document", ADR-0010
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/joestump/joe-links/docs/openapi"
+	"github.com/joestump/joe-links/internal/auth"
+	httpSwagger "github.com/swaggo/http-swagger/v2"
+)
+
+// openAPIPath is where the OpenAPI 3.1 document is served.
+const openAPIPath = "/api/openapi.json"
+
+// swaggerRequestInterceptor marks every "Try it out" request so the API accepts
+// the browser's session cookie when no Bearer token has been authorized.
+const swaggerRequestInterceptor = `(req) => {
+  req.headers['` + auth.SessionAuthHeader + `'] = '1';
+  req.credentials = 'same-origin';
+  return req;
+}`
+
+// swaggerTestTokenScript adds a "Create test token" button above the spec. It
+// mints a short-lived PAT for the logged-in user and pre-authorizes it. The
+// page defines csrfToken before it, since the CSRF cookie is HttpOnly.
+const swaggerTestTokenScript = `
+  const bar = document.createElement('div');
+  bar.style.cssText = 'max-width:1460px;margin:12px auto;padding:0 20px;font-family:sans-serif;display:flex;gap:12px;align-items:center';
+  const btn = document.createElement('button');
+  btn.textContent = 'Create test token';
+  btn.className = 'btn authorize';
+  const msg = document.createElement('span');
+  bar.append(btn, msg);
+  document.getElementById('swagger-ui').before(bar);
+  btn.addEventListener('click', async () => {
+    msg.textContent = '';
+    const res = await fetch('/dashboard/settings/tokens/docs', {
+      method: 'POST', credentials: 'same-origin', redirect: 'manual',
+      headers: {'Accept': 'application/json', '` + auth.CSRFHeader + `': csrfToken}
+    });
+    if (!res.ok) {
+      msg.innerHTML = 'Sign in at <a href="/auth/login?redirect=/api/docs/index.html">/auth/login</a> to create a test token.';
+      return;
+    }
+    const body = await res.json();
+    ui.preauthorizeApiKey('BearerToken', 'Bearer ' + body.token);
+    msg.textContent = 'Authorized with a test token that expires at ' + new Date(body.expires_at).toLocaleString() + '.';
+  });
+`
+
+// newSwaggerHandler returns the Swagger UI handler configured for interactive use.
+// The index page is built per request so the test token script can carry the
+// browser's CSRF token; the page also sets the CSRF cookie when it is missing.
+// Governing: SPEC-0001 REQ "CSRF Protection"
+func newSwaggerHandler() http.HandlerFunc {
+	assets := swaggerHandler("")
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/index.html") {
+			assets(w, r)
+			return
+		}
+		swaggerHandler(auth.CSRFToken(r))(w, r)
+	}
+}
+
+// swaggerHandler builds the Swagger UI handler with csrfToken embedded in the
+// test token script.
+// Use BaseLayout to avoid SwaggerUIStandalonePreset store error in Swagger UI 5.x.
+func swaggerHandler(csrfToken string) http.HandlerFunc {
+	return httpSwagger.Handler(
+		httpSwagger.URL(openAPIPath),
+		httpSwagger.Layout(httpSwagger.BaseLayout),
+		httpSwagger.PersistAuthorization(true),
+		httpSwagger.UIConfig(map[string]string{
+			"requestInterceptor": swaggerRequestInterceptor,
+		}),
+		httpSwagger.AfterScript("const csrfToken = "+strconv.Quote(csrfToken)+";"+swaggerTestTokenScript),
+	)
+}
+
+// serveOpenAPI writes the OpenAPI 3.1 document generated from the API annotations.
+// Governing: SPEC-0007 REQ "OpenAPI 3.1 Document"
+func serveOpenAPI(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(openapi.Spec)
+}
@@ -0,0 +1,72 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/joestump/joe-links/internal/auth"
+	"github.com/joestump/joe-links/internal/store"
+	"github.com/joestump/joe-links/internal/testutil"
+)
+
+// Governing: SPEC-0007 REQ "Swagger UI Test Token", SPEC-0001 REQ "CSRF Protection"
+func TestSwagger_TestTokenCSRF(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	us := store.NewUserStore(db)
+	user, err := us.Upsert(context.Background(), "https://okta", "sub1", "u@example.com", "User", "")
+	if err != nil {
+		t.Fatalf("seed user: %v", err)
+	}
+
+	tokens := NewTokensHandler(auth.NewSQLTokenStore(db))
+	r := chi.NewRouter()
+	r.Use(auth.CSRFProtect(false, denyCSRF, "/api/"))
+	r.Get("/api/docs/*", newSwaggerHandler())
+	r.With(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), auth.UserContextKey, user)))
+		})
+	}).Post("/dashboard/settings/tokens/docs", tokens.CreateDocsToken)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/docs/index.html", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("docs page: status = %d", w.Code)
+	}
+	var cookie *http.Cookie
+	for _, c := range w.Result().Cookies() {
+		if c.Name == auth.CSRFCookie {
+			cookie = c
+		}
+	}
+	if cookie == nil {
+		t.Fatal("docs page did not set the CSRF cookie")
+	}
+	body := w.Body.String()
+	if !strings.Contains(body, `const csrfToken = "`+cookie.Value+`";`) || !strings.Contains(body, `'`+auth.CSRFHeader+`': csrfToken`) {
+		t.Fatal("test token script does not send the CSRF token")
+	}
+
+	post := func(token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/dashboard/settings/tokens/docs", nil)
+		req.Header.Set("Accept", "application/json")
+		req.AddCookie(cookie)
+		if token != "" {
+			req.Header.Set(auth.CSRFHeader, token)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+	if w := post(""); w.Code != http.StatusForbidden {
+		t.Errorf("without CSRF header: status = %d, want 403", w.Code)
+	}
+	if w := post(cookie.Value); w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `"token"`) {
+		t.Errorf("with CSRF header: status = %d, body = %s", w.Code, w.Body.String())
+	}
+}
@@ -38,6 +38,8 @@ type TagDetailPage struct {
 	ShowTitle      bool
 	ShowOwner      bool
 	ShowTags       bool
+	ShowContact    bool
+	Sort           *ListSort // unused; present for link_list partial compatibility
 }
 
 // Index renders all tags with ≥1 link and their counts.
@@ -63,7 +65,7 @@ func (h *TagsHandler) Detail(w http.ResponseWriter, r *http.Request) {
 	slug := chi.URLParam(r, "slug")
 	tag, err := h.tags.GetBySlug(r.Context(), slug)
 	if err != nil {
-		http.NotFound(w, r)
+		renderError(w, r, http.StatusNotFound, "That item no longer exists.")
 		return
 	}
 	links, _ := h.links.ListByTag(r.Context(), slug)
@@ -0,0 +1,113 @@
+// Governing: SPEC-0010 REQ "Team Shares and Ownership"
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/joestump/joe-links/internal/auth"
+	"github.com/joestump/joe-links/internal/store"
+)
+
+// teamGrantTarget loads the link named by {id} and checks that the caller
+// may manage its owners and shares. It writes an error page and returns nil
+// when not.
+func (h *LinksHandler) teamGrantTarget(w http.ResponseWriter, r *http.Request) *store.Link {
+	user := auth.UserFromContext(r.Context())
+	link, err := h.links.GetByID(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		renderError(w, r, http.StatusNotFound, "That item no longer exists.")
+		return nil
+	}
+	allowed, err := store.IsOwnerOrAdmin(h.owns, link.ID, user.ID, user.Role)
+	if err != nil || !allowed {
+		renderError(w, r, http.StatusForbidden, "You don't have permission to do that.")
+		return nil
+	}
+	return link
+}
+
+// AddTeamOwner handles POST /dashboard/links/{id}/team-owners.
+// Accepts form field "team" (a team slug); every member becomes a co-owner.
+func (h *LinksHandler) AddTeamOwner(w http.ResponseWriter, r *http.Request) {
+	link := h.teamGrantTarget(w, r)
+	if link == nil {
+		return
+	}
+	user := auth.UserFromContext(r.Context())
+	team, err := h.teams.GetBySlug(r.Context(), r.FormValue("team"))
+	if err != nil {
+		h.renderOwnersError(w, r, link, user, "Choose a team.")
+		return
+	}
+	if err := h.owns.AddTeamOwner(link.ID, team.ID); err != nil {
+		if errors.Is(err, store.ErrAlreadyOwner) {
+			h.renderOwnersError(w, r, link, user, "That team is already a co-owner.")
+			return
+		}
+		h.renderOwnersError(w, r, link, user, "Could not add team.")
+		return
+	}
+	h.renderOwnersFragment(w, r, link)
+}
+
+// RemoveTeamOwner handles DELETE /dashboard/links/{id}/team-owners/{team}.
+func (h *LinksHandler) RemoveTeamOwner(w http.ResponseWriter, r *http.Request) {
+	link := h.teamGrantTarget(w, r)
+	if link == nil {
+		return
+	}
+	team, err := h.teams.GetBySlug(r.Context(), chi.URLParam(r, "team"))
+	if err != nil {
+		renderError(w, r, http.StatusNotFound, "That item no longer exists.")
+		return
+	}
+	if err := h.owns.RemoveTeamOwner(link.ID, team.ID); err != nil && !errors.Is(err, store.ErrNotOwner) {
+		renderError(w, r, http.StatusInternalServerError, "Could not remove team.")
+		return
+	}
+	h.renderOwnersFragment(w, r, link)
+}
+
+// AddTeamShare handles POST /dashboard/links/{id}/team-shares.
+// Accepts form field "team" (a team slug); every member gains access.
+func (h *LinksHandler) AddTeamShare(w http.ResponseWriter, r *http.Request) {
+	link := h.teamGrantTarget(w, r)
+	if link == nil {
+		return
+	}
+	user := auth.UserFromContext(r.Context())
+	team, err := h.teams.GetBySlug(r.Context(), r.FormValue("team"))
+	if err != nil {
+		h.renderSharesError(w, r, link, "Choose a team.")
+		return
+	}
+	if err := h.links.AddTeamShare(r.Context(), link.ID, team.ID, user.ID); err != nil {
+		if errors.Is(err, store.ErrAlreadyShared) {
+			h.renderSharesError(w, r, link, "The link is already shared with that team.")
+			return
+		}
+		h.renderSharesError(w, r, link, "Could not add team.")
+		return
+	}
+	h.renderSharesFragment(w, r, link)
+}
+
+// RemoveTeamShare handles DELETE /dashboard/links/{id}/team-shares/{team}.
+func (h *LinksHandler) RemoveTeamShare(w http.ResponseWriter, r *http.Request) {
+	link := h.teamGrantTarget(w, r)
+	if link == nil {
+		return
+	}
+	team, err := h.teams.GetBySlug(r.Context(), chi.URLParam(r, "team"))
+	if err != nil {
+		renderError(w, r, http.StatusNotFound, "That item no longer exists.")
+		return
+	}
+	if err := h.links.RemoveTeamShare(r.Context(), link.ID, team.ID); err != nil && !errors.Is(err, store.ErrNotFound) {
+		renderError(w, r, http.StatusInternalServerError, "Could not remove team.")
+		return
+	}
+	h.renderSharesFragment(w, r, link)
+}
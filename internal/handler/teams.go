@@ -0,0 +1,159 @@
+// Governing: SPEC-0002 REQ "Team Ownership"
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/joestump/joe-links/internal/auth"
+	"github.com/joestump/joe-links/internal/store"
+)
+
+// TeamsHandler serves the admin team screens.
+type TeamsHandler struct {
+	teams *store.TeamStore
+	users *store.UserStore // Governing: SPEC-0010 REQ "Team Shares and Ownership"; finds members by email
+}
+
+// NewTeamsHandler creates a new TeamsHandler.
+func NewTeamsHandler(ts *store.TeamStore) *TeamsHandler {
+	return &TeamsHandler{teams: ts}
+}
+
+// AdminTeamsPage is the template data for the team list.
+type AdminTeamsPage struct {
+	BasePage
+	Teams   []*store.Team
+	Members map[string][]*store.User // team ID to members, by display name
+	Error   string
+}
+
+// Index renders the team list.
+// GET /admin/teams
+func (h *TeamsHandler) Index(w http.ResponseWriter, r *http.Request) {
+	h.renderList(w, r, auth.UserFromContext(r.Context()), "")
+}
+
+// Create adds a team from the inline form.
+// POST /admin/teams
+func (h *TeamsHandler) Create(w http.ResponseWriter, r *http.Request) {
+	user := auth.UserFromContext(r.Context())
+	if err := r.ParseForm(); err != nil {
+		renderError(w, r, http.StatusBadRequest, "The request could not be understood.")
+		return
+	}
+
+	name := strings.TrimSpace(r.FormValue("name"))
+	slug := strings.ToLower(strings.TrimSpace(r.FormValue("slug")))
+	if slug == "" {
+		slug = store.DeriveTagSlug(name)
+	}
+	contact := strings.TrimSpace(r.FormValue("contact"))
+
+	if _, err := h.teams.Create(r.Context(), slug, name, contact); err != nil {
+		switch {
+		case errors.Is(err, store.ErrTeamNameRequired):
+			h.renderList(w, r, user, "Name is required.")
+		case errors.Is(err, store.ErrSlugInvalid):
+			h.renderList(w, r, user, "Slug must be lowercase letters, digits, and hyphens (e.g. platform, sre).")
+		case errors.Is(err, store.ErrSlugTaken):
+			h.renderList(w, r, user, "A team with that slug already exists.")
+		default:
+			h.renderList(w, r, user, "Failed to create team.")
+		}
+		return
+	}
+
+	h.renderList(w, r, user, "")
+}
+
+// Delete removes a team. Returns empty 200 so HTMX swaps out the row.
+// DELETE /admin/teams/{slug}
+func (h *TeamsHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	if err := h.teams.Delete(r.Context(), chi.URLParam(r, "slug")); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			renderError(w, r, http.StatusNotFound, "That item no longer exists.")
+			return
+		}
+		renderError(w, r, http.StatusInternalServerError, "Delete failed.")
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// AddMember adds a user to a team by email from the inline form.
+// POST /admin/teams/{slug}/members
+// Governing: SPEC-0010 REQ "Team Shares and Ownership"
+func (h *TeamsHandler) AddMember(w http.ResponseWriter, r *http.Request) {
+	user := auth.UserFromContext(r.Context())
+	team, err := h.teams.GetBySlug(r.Context(), chi.URLParam(r, "slug"))
+	if err != nil {
+		renderError(w, r, http.StatusNotFound, "That item no longer exists.")
+		return
+	}
+	member, err := h.users.GetByEmail(r.Context(), strings.TrimSpace(r.FormValue("email")))
+	if err != nil {
+		h.renderList(w, r, user, "No user found with that email.")
+		return
+	}
+	if err := h.teams.AddMember(r.Context(), team.ID, member.ID); err != nil {
+		if errors.Is(err, store.ErrAlreadyMember) {
+			h.renderList(w, r, user, member.DisplayName+" is already in "+team.Name+".")
+			return
+		}
+		h.renderList(w, r, user, "Failed to add member.")
+		return
+	}
+	h.renderList(w, r, user, "")
+}
+
+// RemoveMember removes a user from a team.
+// DELETE /admin/teams/{slug}/members/{uid}
+// Governing: SPEC-0010 REQ "Team Shares and Ownership"
+func (h *TeamsHandler) RemoveMember(w http.ResponseWriter, r *http.Request) {
+	team, err := h.teams.GetBySlug(r.Context(), chi.URLParam(r, "slug"))
+	if err != nil {
+		renderError(w, r, http.StatusNotFound, "That item no longer exists.")
+		return
+	}
+	if err := h.teams.RemoveMember(r.Context(), team.ID, chi.URLParam(r, "uid")); err != nil && !errors.Is(err, store.ErrNotFound) {
+		renderError(w, r, http.StatusInternalServerError, "Failed to remove member.")
+		return
+	}
+	h.renderList(w, r, auth.UserFromContext(r.Context()), "")
+}
+
+// ConfirmDelete renders the delete confirmation modal for a team.
+// GET /admin/teams/{slug}/confirm-delete
+// Governing: SPEC-0013 REQ "DaisyUI Delete Confirmation Modal"
+func (h *TeamsHandler) ConfirmDelete(w http.ResponseWriter, r *http.Request) {
+	slug := chi.URLParam(r, "slug")
+	data := ConfirmDeleteData{
+		Name:      slug,
+		DeleteURL: "/admin/teams/" + slug,
+		Target:    "#team-" + slug,
+	}
+	renderFragment(w, "confirm_delete", data)
+}
+
+// renderList re-renders the team_list partial (or full page for non-HTMX).
+func (h *TeamsHandler) renderList(w http.ResponseWriter, r *http.Request, user *store.User, errMsg string) {
+	teams, _ := h.teams.List(r.Context())
+	data := AdminTeamsPage{
+		BasePage: newBasePage(r, user),
+		Teams:    teams,
+		Members:  map[string][]*store.User{},
+		Error:    errMsg,
+	}
+	members, _ := h.teams.ListMembers(r.Context())
+	for _, m := range members {
+		data.Members[m.TeamID] = append(data.Members[m.TeamID], &m.User)
+	}
+	if isHTMX(r) {
+		renderPageFragment(w, "admin/teams.html", "team_list", data)
+		return
+	}
+	render(w, "admin/teams.html", data)
+}
@@ -0,0 +1,61 @@
+// Governing: SPEC-0011 REQ "Instance Telemetry"
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/joestump/joe-links/internal/auth"
+	"github.com/joestump/joe-links/internal/metrics"
+	"github.com/joestump/joe-links/internal/store"
+	"github.com/joestump/joe-links/internal/telemetry"
+)
+
+// TelemetryHandler serves the admin page of instance statistics.
+type TelemetryHandler struct {
+	stats    *store.TelemetryStore
+	driver   string
+	endpoint string // "" unless JOE_TELEMETRY_SHARE is on
+}
+
+// NewTelemetryHandler creates a new TelemetryHandler. endpoint is where the
+// report is shared, or "" when sharing is off.
+func NewTelemetryHandler(ts *store.TelemetryStore, driver, endpoint string) *TelemetryHandler {
+	return &TelemetryHandler{stats: ts, driver: driver, endpoint: endpoint}
+}
+
+// AdminTelemetryPage is the template data for the telemetry page.
+type AdminTelemetryPage struct {
+	BasePage
+	Report   *telemetry.Report
+	JSON     string // exactly what sharing sends
+	Endpoint string
+	LastSent time.Time
+}
+
+// Index renders the instance statistics and the report sharing would send.
+// GET /admin/telemetry
+func (h *TelemetryHandler) Index(w http.ResponseWriter, r *http.Request) {
+	user := auth.UserFromContext(r.Context())
+	report, err := telemetry.Build(r.Context(), h.stats, h.driver, time.Now())
+	if err != nil {
+		renderError(w, r, http.StatusInternalServerError, "Could not collect instance statistics.")
+		return
+	}
+	body, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		renderError(w, r, http.StatusInternalServerError, "Could not collect instance statistics.")
+		return
+	}
+	data := AdminTelemetryPage{
+		BasePage: newBasePage(r, user),
+		Report:   report,
+		JSON:     string(body),
+		Endpoint: h.endpoint,
+	}
+	if h.endpoint != "" {
+		data.LastSent, _ = metrics.JobLastSuccessAt(metrics.JobTelemetry)
+	}
+	render(w, "admin/telemetry.html", data)
+}
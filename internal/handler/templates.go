@@ -3,14 +3,22 @@
 package handler
 
 import (
+	"bytes"
 	"fmt"
+	"html"
 	"html/template"
 	"io/fs"
+	"log"
 	"net/http"
 	"path/filepath"
+	"regexp"
+	"strconv"
 	"strings"
+	"sync"
 
+	"github.com/joestump/joe-links/internal/auth"
 	"github.com/joestump/joe-links/internal/build"
+	"github.com/joestump/joe-links/internal/i18n"
 	"github.com/joestump/joe-links/internal/store"
 	"github.com/joestump/joe-links/web"
 )
@@ -28,8 +36,27 @@ type BasePage struct {
 	BuildVersion   string      // e.g. "v0.2.15" or "dev"
 	BuildCommit    string      // short commit SHA, e.g. "abc1234"
 	BuildBranch    string      // e.g. "main"
+	DemoMode       bool        // Governing: SPEC-0001 REQ "Demo Mode"; shows the sandbox banner
+	Brand          Brand       // Governing: SPEC-0003 REQ "Instance Branding"; name, logo, color, footer links
+	Announcement   *store.Announcement // Governing: SPEC-0004 REQ "Announcement Banner"; nil hides the banner
+	Locale         string      // Governing: SPEC-0004 REQ "Internationalization"; e.g. "en", "de"
+	Passkeys       bool        // Governing: SPEC-0001 REQ "WebAuthn Step-Up"; shows the passkey settings link
+	CSRFToken      string      // Governing: SPEC-0001 REQ "CSRF Protection"; sent back by forms and HTMX requests
 }
 
+// T translates msg into the page's locale, for text handlers put on the
+// page. Template text is translated with {{t "..."}} instead.
+// Governing: SPEC-0004 REQ "Internationalization"
+func (p BasePage) T(msg string) string {
+	return i18n.T(p.Locale, msg)
+}
+
+// locale picks the template set the page renders with.
+func (p BasePage) locale() string { return p.Locale }
+
+// csrfToken gives csrfField the page's CSRF token.
+func (p BasePage) csrfToken() string { return p.CSRFToken }
+
 // newBasePage constructs a BasePage from the current request, setting theme,
 // user, and admin-page state.
 // Governing: SPEC-0013 REQ "Collapsible Admin Sidebar Section"
@@ -52,8 +79,9 @@ func newBasePage(r *http.Request, user *store.User) BasePage {
 		}
 		shortKeyword = strings.SplitN(host, ".", 2)[0]
 	}
+	prefs := savedPrefs(r, user)
 	return BasePage{
-		Theme:        themeFromRequest(r),
+		Theme:        themeFromRequest(r, prefs),
 		User:         user,
 		IsAdminPage:  strings.HasPrefix(r.URL.Path, "/admin"),
 		SiteURL:      scheme + "://" + r.Host,
@@ -61,18 +89,28 @@ func newBasePage(r *http.Request, user *store.User) BasePage {
 		BuildVersion: build.Version,
 		BuildCommit:  commit,
 		BuildBranch:  build.Branch,
+		DemoMode:     demoMode,
+		Passkeys:     passkeysEnabled,
+		CSRFToken:    auth.CSRFToken(r),
+		Brand:        currentBrand(r.Context()),
+		Announcement: activeAnnouncement(r),
+		Locale:       requestLocale(r, prefs),
 	}
 }
 
-// themeFromRequest reads the "theme" cookie. Returns "" if absent or invalid,
-// so the server omits data-theme and lets the anti-flash inline script handle it.
+// themeFromRequest returns the user's saved theme, else the "theme" cookie.
+// Returns "" if neither is set or valid, so the server omits data-theme and
+// lets the anti-flash inline script handle it.
 // Governing: SPEC-0003 REQ "Theme Persistence via Cookie"
-func themeFromRequest(r *http.Request) string {
+func themeFromRequest(r *http.Request, prefs map[string]string) string {
+	if theme := prefs[store.PrefTheme]; validTheme(theme) {
+		return theme
+	}
 	c, err := r.Cookie("theme")
 	if err != nil {
 		return ""
 	}
-	if c.Value == "joe-light" || c.Value == "joe-dark" {
+	if validTheme(c.Value) {
 		return c.Value
 	}
 	return ""
@@ -82,22 +120,113 @@ func themeFromRequest(r *http.Request) string {
 // When empty, newBasePage derives the keyword from the HTTP Host header.
 var configuredShortKeyword string
 
+// demoMode is set at startup when Deps.Demo is non-nil.
+var demoMode bool
+
+// templateSet holds every compiled template for one locale.
+//
 // pageCache maps a render key (e.g. "dashboard.html", "tags/index.html") to a
 // compiled template set containing base.html + partials + that one page file.
 // Each page gets its own set so {{define "content"}} blocks don't collide.
-var (
-	pageCache    map[string]*template.Template
-	fragmentTmpl *template.Template
-)
+//
+// pageLayouts and fragments hold the "base" template of each page set and
+// every named partial, resolved once at startup so rendering skips the
+// per-call name lookup of ExecuteTemplate.
+type templateSet struct {
+	pageCache   map[string]*template.Template
+	pageLayouts map[string]*template.Template
+	fragments   map[string]*template.Template
+}
+
+// templateSets maps each supported locale to its templates. Templates mark
+// translatable text as {{t "Dashboard"}}; each locale's set is parsed with
+// those calls already replaced by the translation, so rendering a
+// translated page costs no more than rendering an English one.
+// Governing: SPEC-0004 REQ "Internationalization"
+var templateSets = map[string]*templateSet{}
+
+// templateFuncs are the functions every template may call.
+var templateFuncs = template.FuncMap{"csrfField": csrfField}
+
+// translateCallRe matches a {{t "..."}} call in template source.
+var translateCallRe = regexp.MustCompile(`\{\{t ("(?:[^"\\]|\\.)*")\}\}`)
+
+// translateTemplate replaces the {{t "..."}} calls in src with their
+// translation into locale, HTML-escaped.
+func translateTemplate(locale, src string) string {
+	return translateCallRe.ReplaceAllStringFunc(src, func(call string) string {
+		msg, err := strconv.Unquote(translateCallRe.FindStringSubmatch(call)[1])
+		if err != nil {
+			panic("template: bad t call " + call)
+		}
+		return html.EscapeString(i18n.T(locale, msg))
+	})
+}
+
+// parseLocalized parses files from web.TemplateFS as template.ParseFS does,
+// translated into locale first.
+func parseLocalized(locale string, files ...string) (*template.Template, error) {
+	t := template.New("").Funcs(templateFuncs)
+	for _, f := range files {
+		src, err := fs.ReadFile(web.TemplateFS, f)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := t.New(filepath.Base(f)).Parse(translateTemplate(locale, string(src))); err != nil {
+			return nil, fmt.Errorf("%s: %w", f, err)
+		}
+	}
+	return t, nil
+}
+
+// templatesFor returns the templates in data's locale, or the default
+// locale's when data carries none.
+func templatesFor(data any) *templateSet {
+	if p, ok := data.(interface{ locale() string }); ok {
+		if set, ok := templateSets[p.locale()]; ok {
+			return set
+		}
+	}
+	return templateSets[i18n.Default]
+}
+
+// maxPooledBuffer caps the capacity of buffers returned to bufPool, so one
+// unusually large page does not pin its buffer for the life of the process.
+const maxPooledBuffer = 1 << 20
+
+// bufPool recycles render buffers across requests.
+var bufPool = sync.Pool{New: func() any { return new(bytes.Buffer) }}
 
 func init() {
+	for _, l := range i18n.Locales() {
+		set, err := buildTemplateSet(l.Code)
+		if err != nil {
+			panic(err.Error())
+		}
+		templateSets[l.Code] = set
+	}
+}
+
+// buildTemplateSet parses every page and partial, translated into locale.
+func buildTemplateSet(locale string) (*templateSet, error) {
 	partials, err := fs.Glob(web.TemplateFS, "templates/partials/*.html")
 	if err != nil {
-		panic("glob partials: " + err.Error())
+		return nil, fmt.Errorf("glob partials: %w", err)
 	}
 
 	// Standalone set for global HTMX fragment rendering (partials only).
-	fragmentTmpl = template.Must(template.New("").ParseFS(web.TemplateFS, partials...))
+	fragmentTmpl, err := parseLocalized(locale, partials...)
+	if err != nil {
+		return nil, fmt.Errorf("parse partials: %w", err)
+	}
+	set := &templateSet{
+		pageCache:   make(map[string]*template.Template),
+		pageLayouts: make(map[string]*template.Template),
+		fragments:   make(map[string]*template.Template),
+	}
+	for _, t := range fragmentTmpl.Templates() {
+		set.fragments[t.Name()] = t
+	}
 
 	// Count how many page files share each basename to detect collisions.
 	baseCount := map[string]int{}
@@ -110,7 +239,6 @@ func init() {
 	})
 
 	// Build one template set per page file.
-	pageCache = make(map[string]*template.Template)
 	err = fs.WalkDir(web.TemplateFS, "templates/pages", func(p string, d fs.DirEntry, e error) error {
 		if e != nil || d.IsDir() || !strings.HasSuffix(p, ".html") {
 			return e
@@ -121,26 +249,29 @@ func init() {
 		files = append(files, partials...)
 		files = append(files, p)
 
-		t, err := template.New("").ParseFS(web.TemplateFS, files...)
+		t, err := parseLocalized(locale, files...)
 		if err != nil {
 			return fmt.Errorf("parse %s: %w", p, err)
 		}
 
 		// Primary key: path relative to "templates/pages/" (always unambiguous).
 		rel, _ := strings.CutPrefix(p, "templates/pages/")
-		pageCache[rel] = t
+		set.pageCache[rel] = t
+		set.pageLayouts[rel] = t.Lookup("base")
 
 		// Alias under bare basename when it is unique across all page files.
 		base := filepath.Base(p)
 		if baseCount[base] == 1 {
-			pageCache[base] = t
+			set.pageCache[base] = t
+			set.pageLayouts[base] = set.pageLayouts[rel]
 		}
 
 		return nil
 	})
 	if err != nil {
-		panic("build page cache: " + err.Error())
+		return nil, fmt.Errorf("build page cache (%s): %w", locale, err)
 	}
+	return set, nil
 }
 
 // Flash represents a one-time notification message shown to the user.
@@ -154,40 +285,109 @@ func isHTMX(r *http.Request) bool {
 	return r.Header.Get("HX-Request") == "true"
 }
 
+// execute runs t with data into a pooled buffer and sends it with status only
+// when execution succeeds. A template error is logged and answered with the
+// 500 error page instead, so the client never receives a truncated page
+// behind a success status. name identifies the template in the log.
+// Governing: SPEC-0001 REQ "Buffered Template Rendering"
+func execute(w http.ResponseWriter, status int, name string, t *template.Template, data any) {
+	buf := bufPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	defer func() {
+		if buf.Cap() <= maxPooledBuffer {
+			bufPool.Put(buf)
+		}
+	}()
+
+	if err := t.Execute(buf, data); err != nil {
+		log.Printf("render %s: %v", name, err)
+		renderServerError(w)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(status)
+	_, _ = buf.WriteTo(w)
+}
+
+// renderServerError sends the 500 error page, falling back to plain text
+// if the error page itself cannot be rendered. It carries no request data,
+// since it is rendered when request data failed to render.
+// Governing: SPEC-0001 REQ "Buffered Template Rendering"
+func renderServerError(w http.ResponseWriter) {
+	var buf bytes.Buffer
+	t := templateSets[i18n.Default].pageLayouts["500.html"]
+	if t == nil || t.Execute(&buf, errorPage{BasePage: BasePage{BuildVersion: build.Version}, Status: http.StatusInternalServerError}) != nil {
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(http.StatusInternalServerError)
+	_, _ = buf.WriteTo(w)
+}
+
+// templateNotFound reports a render key with no template. It indicates a
+// programming error, so it is logged like a failed render.
+func templateNotFound(w http.ResponseWriter, name string) {
+	log.Printf("render %s: template not found", name)
+	renderServerError(w)
+}
+
 // render executes a full-page template (base layout + named page).
 // tmpl is the render key, e.g. "dashboard.html" or "tags/index.html".
 func render(w http.ResponseWriter, tmpl string, data any) {
-	w.Header().Set("Content-Type", "text/html; charset=utf-8")
-	t, ok := pageCache[tmpl]
-	if !ok {
-		http.Error(w, "template not found: "+tmpl, http.StatusInternalServerError)
+	renderWithStatus(w, http.StatusOK, tmpl, data)
+}
+
+// renderWithStatus is render with a status other than 200 OK.
+func renderWithStatus(w http.ResponseWriter, status int, tmpl string, data any) {
+	t, ok := templatesFor(data).pageLayouts[tmpl]
+	if !ok || t == nil {
+		templateNotFound(w, tmpl)
 		return
 	}
-	if err := t.ExecuteTemplate(w, "base", data); err != nil {
-		http.Error(w, "template error: "+err.Error(), http.StatusInternalServerError)
+	execute(w, status, tmpl, t, data)
+}
+
+// renderPage renders page with status: only its "content" block for HTMX
+// requests, the full page otherwise.
+func renderPage(w http.ResponseWriter, r *http.Request, status int, page string, data any) {
+	if isHTMX(r) {
+		renderPageFragmentWithStatus(w, status, page, "content", data)
+		return
 	}
+	renderWithStatus(w, status, page, data)
 }
 
 // renderFragment executes a named template from the global partials set.
 // Use for standalone HTMX partials (link_list, token_list, owners_list, etc.).
 func renderFragment(w http.ResponseWriter, tmpl string, data any) {
-	w.Header().Set("Content-Type", "text/html; charset=utf-8")
-	if err := fragmentTmpl.ExecuteTemplate(w, tmpl, data); err != nil {
-		http.Error(w, "template error: "+err.Error(), http.StatusInternalServerError)
+	t, ok := templatesFor(data).fragments[tmpl]
+	if !ok {
+		templateNotFound(w, tmpl)
+		return
 	}
+	execute(w, http.StatusOK, tmpl, t, data)
 }
 
 // renderPageFragment executes a named template from a specific page's template set.
 // Use for HTMX partial renders that need a page-specific block (e.g. "content")
 // or a page-local named template (e.g. "user_row" in admin/users.html).
 func renderPageFragment(w http.ResponseWriter, page, tmpl string, data any) {
-	w.Header().Set("Content-Type", "text/html; charset=utf-8")
-	t, ok := pageCache[page]
+	renderPageFragmentWithStatus(w, http.StatusOK, page, tmpl, data)
+}
+
+// renderPageFragmentWithStatus is renderPageFragment with a status other than 200 OK.
+func renderPageFragmentWithStatus(w http.ResponseWriter, status int, page, tmpl string, data any) {
+	name := page + "#" + tmpl
+	set, ok := templatesFor(data).pageCache[page]
 	if !ok {
-		http.Error(w, "template not found: "+page, http.StatusInternalServerError)
+		templateNotFound(w, name)
 		return
 	}
-	if err := t.ExecuteTemplate(w, tmpl, data); err != nil {
-		http.Error(w, "template error: "+err.Error(), http.StatusInternalServerError)
+	t := set.Lookup(tmpl)
+	if t == nil {
+		templateNotFound(w, name)
+		return
 	}
+	execute(w, status, name, t, data)
 }
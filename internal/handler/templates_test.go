@@ -0,0 +1,111 @@
+package handler
+
+import (
+	"fmt"
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/joestump/joe-links/internal/store"
+)
+
+func TestExecute_ErrorRendersErrorPage(t *testing.T) {
+	tmpl := template.Must(template.New("page").Parse(`<p>partial</p>{{.Missing}}`))
+	w := httptest.NewRecorder()
+	execute(w, http.StatusOK, "page", tmpl, struct{}{})
+
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusInternalServerError)
+	}
+	body := w.Body.String()
+	if strings.Contains(body, "partial") {
+		t.Errorf("partial output leaked: %q", body)
+	}
+	if !strings.Contains(body, "Something went wrong") {
+		t.Errorf("expected the 500 error page, got %q", body)
+	}
+	if strings.Contains(body, "Missing") {
+		t.Errorf("template error details leaked to the client")
+	}
+}
+
+func TestRenderPage_Status(t *testing.T) {
+	data := notFoundPage{Slug: "missing"}
+	for _, htmx := range []bool{false, true} {
+		r := httptest.NewRequest(http.MethodGet, "/missing", nil)
+		if htmx {
+			r.Header.Set("HX-Request", "true")
+		}
+		w := httptest.NewRecorder()
+		renderPage(w, r, http.StatusNotFound, "404.html", data)
+
+		if w.Code != http.StatusNotFound {
+			t.Errorf("htmx=%v: status = %d, want %d", htmx, w.Code, http.StatusNotFound)
+		}
+		if full := strings.Contains(w.Body.String(), "<html"); full == htmx {
+			t.Errorf("htmx=%v: full page = %v", htmx, full)
+		}
+	}
+}
+
+func TestRender_UnknownTemplate(t *testing.T) {
+	for name, fn := range map[string]func(http.ResponseWriter){
+		"page":          func(w http.ResponseWriter) { render(w, "nope.html", nil) },
+		"fragment":      func(w http.ResponseWriter) { renderFragment(w, "nope", nil) },
+		"page fragment": func(w http.ResponseWriter) { renderPageFragment(w, "dashboard.html", "nope", nil) },
+	} {
+		w := httptest.NewRecorder()
+		fn(w)
+		if w.Code != http.StatusInternalServerError {
+			t.Errorf("%s: status = %d, want %d", name, w.Code, http.StatusInternalServerError)
+		}
+	}
+}
+
+// benchDashboardPage returns dashboard data with n links, the shape of a
+// typical signed-in dashboard render.
+func benchDashboardPage(n int) DashboardPage {
+	user := &store.User{ID: "u1", Email: "bench@example.com", DisplayName: "Bench", Role: "user"}
+	links := make([]*store.Link, n)
+	for i := range links {
+		links[i] = &store.Link{
+			ID:          fmt.Sprintf("link-%d", i),
+			Slug:        fmt.Sprintf("slug-%d", i),
+			URL:         fmt.Sprintf("https://example.com/%d", i),
+			Title:       fmt.Sprintf("Link %d", i),
+			Description: "A link used to benchmark dashboard rendering.",
+			Visibility:  "public",
+			CreatedAt:   time.Now(),
+			UpdatedAt:   time.Now(),
+		}
+	}
+	r := httptest.NewRequest(http.MethodGet, "/dashboard", nil)
+	return DashboardPage{BasePage: newBasePage(r, user), User: user, Links: links, ShowActions: true}
+}
+
+func BenchmarkRenderDashboard(b *testing.B) {
+	data := benchDashboardPage(50)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		w := httptest.NewRecorder()
+		render(w, "dashboard.html", data)
+		if w.Code != http.StatusOK {
+			b.Fatalf("status = %d: %s", w.Code, w.Body.String())
+		}
+	}
+}
+
+func BenchmarkRenderLinkListFragment(b *testing.B) {
+	data := benchDashboardPage(50)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		w := httptest.NewRecorder()
+		renderFragment(w, "link_list", data)
+		if w.Code != http.StatusOK {
+			b.Fatalf("status = %d: %s", w.Code, w.Body.String())
+		}
+	}
+}
@@ -0,0 +1,27 @@
+// Governing: SPEC-0001 REQ "Multi-Tenancy"
+package handler
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/joestump/joe-links/internal/store"
+)
+
+// TenantMiddleware scopes each request to the tenant serving its Host, so the
+// stores it reaches only see that tenant's links, keywords, and users.
+// Hostnames without a tenant are served by the default tenant.
+// Governing: SPEC-0001 REQ "Multi-Tenancy"
+func TenantMiddleware(tenants *store.TenantStore) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			tenantID, err := tenants.Resolve(r.Context(), r.Host)
+			if err != nil {
+				log.Printf("tenant: resolve %q: %v", r.Host, err)
+				renderError(w, r, http.StatusServiceUnavailable, "This site is temporarily unavailable.")
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(store.WithTenant(r.Context(), tenantID)))
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/joestump/joe-links/internal/store"
+	"github.com/joestump/joe-links/internal/testutil"
+)
+
+// Governing: SPEC-0001 REQ "Multi-Tenancy"
+func TestTenantMiddleware(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	tenants := store.NewTenantStore(db)
+	acme, err := tenants.Create(context.Background(), "go.acme.example", "Acme")
+	if err != nil {
+		t.Fatalf("create tenant: %v", err)
+	}
+
+	var got string
+	var scoped bool
+	h := TenantMiddleware(tenants)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, scoped = store.TenantFromContext(r.Context())
+	}))
+	for host, want := range map[string]string{
+		"GO.ACME.EXAMPLE:8443": acme.ID,
+		"go.example.com":       "",
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/wiki", nil)
+		req.Host = host
+		h.ServeHTTP(httptest.NewRecorder(), req)
+		if !scoped || got != want {
+			t.Errorf("Host %q: tenant = %q (scoped %v), want %q", host, got, scoped, want)
+		}
+	}
+}
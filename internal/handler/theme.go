@@ -3,11 +3,17 @@ package handler
 
 import (
 	"encoding/json"
+	"log"
 	"net/http"
+
+	"github.com/joestump/joe-links/internal/auth"
+	"github.com/joestump/joe-links/internal/store"
 )
 
 // ThemeHandler handles the theme toggle endpoint.
-type ThemeHandler struct{}
+type ThemeHandler struct {
+	prefs *store.PreferenceStore // nil keeps the choice in the cookie only
+}
 
 // NewThemeHandler creates a new ThemeHandler.
 func NewThemeHandler() *ThemeHandler {
@@ -16,28 +22,27 @@ func NewThemeHandler() *ThemeHandler {
 
 // Toggle handles POST /dashboard/theme.
 // No auth required — sets the theme cookie and returns HX-Trigger for client-side swap.
+// A signed-in user's choice is also saved to their preferences, so it
+// follows them to other devices.
 // Governing: SPEC-0003 REQ "HTMX Theme Endpoint"
 func (h *ThemeHandler) Toggle(w http.ResponseWriter, r *http.Request) {
 	if err := r.ParseForm(); err != nil {
-		http.Error(w, "bad request", http.StatusBadRequest)
+		renderError(w, r, http.StatusBadRequest, "The request could not be understood.")
 		return
 	}
 	theme := r.FormValue("theme")
-	if theme != "joe-light" && theme != "joe-dark" {
-		http.Error(w, "invalid theme", http.StatusBadRequest)
+	if !validTheme(theme) {
+		renderError(w, r, http.StatusBadRequest, "Invalid theme.")
 		return
 	}
 
-	// Persist via cookie (non-HttpOnly so the anti-flash script can read it).
-	// Governing: SPEC-0003 REQ "Theme Persistence via Cookie"
-	http.SetCookie(w, &http.Cookie{
-		Name:     "theme",
-		Value:    theme,
-		Path:     "/",
-		MaxAge:   365 * 24 * 60 * 60, // 1 year
-		SameSite: http.SameSiteLaxMode,
-		HttpOnly: false,
-	})
+	setThemeCookie(w, theme)
+	if user := auth.UserFromContext(r.Context()); user != nil && h.prefs != nil {
+		// The cookie already took effect, so a failed save is not an error.
+		if err := h.prefs.Set(r.Context(), user.ID, store.PrefTheme, theme); err != nil {
+			log.Printf("theme: save preference for %s: %v", user.ID, err)
+		}
+	}
 
 	// Return HX-Trigger for client-side data-theme swap.
 	// Governing: SPEC-0003 REQ "Theme Toggle Control"
@@ -47,3 +52,26 @@ func (h *ThemeHandler) Toggle(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("HX-Trigger", string(trigger))
 	w.WriteHeader(http.StatusOK)
 }
+
+// validTheme reports whether theme is one of the two themes.
+func validTheme(theme string) bool {
+	return theme == "joe-light" || theme == "joe-dark"
+}
+
+// setThemeCookie persists theme in the browser (non-HttpOnly so the
+// anti-flash script can read it), or clears the cookie when theme is "".
+// Governing: SPEC-0003 REQ "Theme Persistence via Cookie"
+func setThemeCookie(w http.ResponseWriter, theme string) {
+	maxAge := 365 * 24 * 60 * 60 // 1 year
+	if theme == "" {
+		maxAge = -1
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:     "theme",
+		Value:    theme,
+		Path:     "/",
+		MaxAge:   maxAge,
+		SameSite: http.SameSiteLaxMode,
+		HttpOnly: false,
+	})
+}
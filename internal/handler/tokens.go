@@ -2,7 +2,9 @@
 package handler
 
 import (
+	"encoding/json"
 	"net/http"
+	"slices"
 	"time"
 
 	"github.com/go-chi/chi/v5"
@@ -38,7 +40,7 @@ func (h *TokensHandler) Index(w http.ResponseWriter, r *http.Request) {
 
 	records, err := h.tokens.ListByUser(r.Context(), user.ID)
 	if err != nil {
-		http.Error(w, "could not load tokens", http.StatusInternalServerError)
+		renderError(w, r, http.StatusInternalServerError, "Could not load tokens.")
 		return
 	}
 
@@ -62,7 +64,7 @@ func (h *TokensHandler) Create(w http.ResponseWriter, r *http.Request) {
 	user := auth.UserFromContext(r.Context())
 
 	if err := r.ParseForm(); err != nil {
-		http.Error(w, "bad request", http.StatusBadRequest)
+		renderError(w, r, http.StatusBadRequest, "The request could not be understood.")
 		return
 	}
 
@@ -72,6 +74,21 @@ func (h *TokensHandler) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Governing: SPEC-0006 REQ "Token Scopes"
+	scopes := r.Form["scopes"]
+	if len(scopes) == 0 {
+		h.renderWithError(w, r, user, "Select at least one scope.")
+		return
+	}
+	if slices.Contains(scopes, auth.ScopeAdmin) && user.Role != "admin" {
+		h.renderWithError(w, r, user, "Only admins can create tokens with the admin scope.")
+		return
+	}
+	if _, err := auth.ParseScopes(scopes); err != nil {
+		h.renderWithError(w, r, user, "Invalid scope selection.")
+		return
+	}
+
 	var expiresAt *time.Time
 	if exp := r.FormValue("expires_in"); exp != "" {
 		d, err := time.ParseDuration(exp)
@@ -89,7 +106,7 @@ func (h *TokensHandler) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	_, err = h.tokens.Create(r.Context(), user.ID, name, hash, expiresAt)
+	_, err = h.tokens.Create(r.Context(), user.ID, name, hash, scopes, expiresAt)
 	if err != nil {
 		h.renderWithError(w, r, user, "Failed to create token.")
 		return
@@ -112,8 +129,37 @@ func (h *TokensHandler) Create(w http.ResponseWriter, r *http.Request) {
 	render(w, "tokens.html", data)
 }
 
+// docsTokenLifetime bounds test tokens minted from the Swagger UI.
+const docsTokenLifetime = time.Hour
+
+// CreateDocsToken mints a short-lived token for the Swagger UI "Create test
+// token" button and returns it as JSON so the page can pre-authorize it.
+// POST /dashboard/settings/tokens/docs
+// Governing: SPEC-0007 REQ "Swagger UI Test Token"
+func (h *TokensHandler) CreateDocsToken(w http.ResponseWriter, r *http.Request) {
+	user := auth.UserFromContext(r.Context())
+
+	plaintext, hash, err := auth.GenerateToken()
+	if err != nil {
+		renderError(w, r, http.StatusInternalServerError, "Failed to generate token.")
+		return
+	}
+	expiresAt := time.Now().Add(docsTokenLifetime)
+	if _, err := h.tokens.Create(r.Context(), user.ID, "API docs test token", hash, nil, &expiresAt); err != nil {
+		renderError(w, r, http.StatusInternalServerError, "Failed to create token.")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"token":      plaintext,
+		"expires_at": expiresAt.UTC(),
+	})
+}
+
 // Revoke soft-deletes a token owned by the current user.
-// DELETE /dashboard/settings/tokens/{id}
+// DELETE /dashboard/settings/tokens/{id}, POST /dashboard/settings/tokens/{id}/delete
 // Governing: SPEC-0006 REQ "Token Management Web UI" — revocation with confirmation.
 func (h *TokensHandler) Revoke(w http.ResponseWriter, r *http.Request) {
 	user := auth.UserFromContext(r.Context())
@@ -121,11 +167,11 @@ func (h *TokensHandler) Revoke(w http.ResponseWriter, r *http.Request) {
 
 	err := h.tokens.Revoke(r.Context(), tokenID, user.ID)
 	if err == store.ErrNotFound {
-		http.NotFound(w, r)
+		renderError(w, r, http.StatusNotFound, "That item no longer exists.")
 		return
 	}
 	if err != nil {
-		http.Error(w, "revoke failed", http.StatusInternalServerError)
+		renderError(w, r, http.StatusInternalServerError, "Revoke failed.")
 		return
 	}
 
@@ -162,7 +208,7 @@ func (h *TokensHandler) ConfirmRevoke(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 	if tokenName == "" {
-		http.NotFound(w, r)
+		renderError(w, r, http.StatusNotFound, "That item no longer exists.")
 		return
 	}
 
@@ -171,7 +217,7 @@ func (h *TokensHandler) ConfirmRevoke(w http.ResponseWriter, r *http.Request) {
 		DeleteURL: "/dashboard/settings/tokens/" + tokenID,
 		Target:    "#token-content",
 	}
-	renderFragment(w, "confirm_delete", data)
+	renderConfirmDelete(w, r, data, "/dashboard/settings/tokens")
 }
 
 func (h *TokensHandler) renderWithError(w http.ResponseWriter, r *http.Request, user *store.User, errMsg string) {
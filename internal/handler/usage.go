@@ -0,0 +1,47 @@
+// Governing: SPEC-0006 REQ "API Usage Tracking"
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/joestump/joe-links/internal/auth"
+	"github.com/joestump/joe-links/internal/store"
+)
+
+// usageWindowDays is the reporting window for the admin usage view.
+const usageWindowDays = 30
+
+// UsageHandler serves the admin API usage report.
+type UsageHandler struct {
+	usage *store.UsageStore
+}
+
+// NewUsageHandler creates a new UsageHandler.
+func NewUsageHandler(us *store.UsageStore) *UsageHandler {
+	return &UsageHandler{usage: us}
+}
+
+// AdminUsagePage is the template data for the API usage view.
+type AdminUsagePage struct {
+	BasePage
+	Days   int
+	Tokens []*store.TokenUsageSummary
+}
+
+// Index renders the busiest API tokens over the last 30 days.
+// GET /admin/usage
+func (h *UsageHandler) Index(w http.ResponseWriter, r *http.Request) {
+	user := auth.UserFromContext(r.Context())
+	since := time.Now().UTC().AddDate(0, 0, -(usageWindowDays - 1))
+	tokens, err := h.usage.ListTopTokens(r.Context(), since, 100)
+	if err != nil {
+		renderError(w, r, http.StatusInternalServerError, "Something went wrong.")
+		return
+	}
+	render(w, "admin/usage.html", AdminUsagePage{
+		BasePage: newBasePage(r, user),
+		Days:     usageWindowDays,
+		Tokens:   tokens,
+	})
+}
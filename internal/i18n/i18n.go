@@ -0,0 +1,114 @@
+// Package i18n translates user-facing strings. Catalogs are keyed by the
+// English text itself, so English needs no catalog and any string a locale
+// has not translated yet falls back to English rather than to a key.
+// Governing: SPEC-0004 REQ "Internationalization"
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// Default is the source locale every string is written in.
+const Default = "en"
+
+// Locale is a language the UI can be shown in.
+type Locale struct {
+	Code string // BCP 47 tag, e.g. "de"
+	Name string // the language's name in itself, e.g. "Deutsch"
+}
+
+// catalog is the JSON layout of locales/<code>.json.
+type catalog struct {
+	Name     string            `json:"name"`
+	Messages map[string]string `json:"messages"`
+}
+
+//go:embed locales/*.json
+var localeFS embed.FS
+
+var (
+	catalogs = map[string]catalog{Default: {Name: "English"}}
+	locales  []Locale
+	matcher  language.Matcher
+)
+
+func init() {
+	files, err := localeFS.ReadDir("locales")
+	if err != nil {
+		panic("i18n: " + err.Error())
+	}
+	for _, f := range files {
+		raw, err := localeFS.ReadFile("locales/" + f.Name())
+		if err != nil {
+			panic("i18n: " + err.Error())
+		}
+		var c catalog
+		if err := json.Unmarshal(raw, &c); err != nil {
+			panic(fmt.Sprintf("i18n: %s: %v", f.Name(), err))
+		}
+		catalogs[strings.TrimSuffix(f.Name(), path.Ext(f.Name()))] = c
+	}
+
+	// The default comes first so the matcher falls back to it.
+	codes := make([]string, 0, len(catalogs))
+	for code := range catalogs {
+		if code != Default {
+			codes = append(codes, code)
+		}
+	}
+	sort.Strings(codes)
+	codes = append([]string{Default}, codes...)
+	tags := make([]language.Tag, len(codes))
+	for i, code := range codes {
+		tags[i] = language.MustParse(code)
+		locales = append(locales, Locale{Code: code, Name: catalogs[code].Name})
+	}
+	matcher = language.NewMatcher(tags)
+}
+
+// Locales returns the supported locales, the default first.
+func Locales() []Locale {
+	return locales
+}
+
+// Supported reports whether code names a supported locale.
+func Supported(code string) bool {
+	_, ok := catalogs[code]
+	return ok
+}
+
+// Match returns the supported locale that best fits an Accept-Language
+// header, or Default when none does.
+func Match(acceptLanguage string) string {
+	if acceptLanguage == "" {
+		return Default
+	}
+	tags, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil || len(tags) == 0 {
+		return Default
+	}
+	_, i, conf := matcher.Match(tags...)
+	if conf == language.No {
+		return Default
+	}
+	return locales[i].Code
+}
+
+// T translates msg into locale, then formats it with args as fmt.Sprintf
+// does when any are given. Unknown locales and untranslated strings use msg.
+func T(locale, msg string, args ...any) string {
+	if tr, ok := catalogs[locale].Messages[msg]; ok {
+		msg = tr
+	}
+	if len(args) > 0 {
+		return fmt.Sprintf(msg, args...)
+	}
+	return msg
+}
@@ -0,0 +1,70 @@
+package i18n
+
+import (
+	"regexp"
+	"slices"
+	"testing"
+)
+
+func TestMatch(t *testing.T) {
+	for header, want := range map[string]string{
+		"":                        Default,
+		"de":                      "de",
+		"de-AT,de;q=0.9,en;q=0.8": "de",
+		"fr-FR,fr;q=0.9":          Default,
+		"fr,de;q=0.5":             "de",
+		"en-GB,de;q=0.5":          Default,
+		"not a header;;":          Default,
+	} {
+		if got := Match(header); got != want {
+			t.Errorf("Match(%q) = %q, want %q", header, got, want)
+		}
+	}
+}
+
+func TestT(t *testing.T) {
+	if got := T("de", "Dashboard"); got == "Dashboard" || got == "" {
+		t.Errorf("T(de, Dashboard) = %q, want a translation", got)
+	}
+	if got := T("de", "not in any catalog"); got != "not in any catalog" {
+		t.Errorf("untranslated string = %q", got)
+	}
+	if got := T("xx", "Dashboard"); got != "Dashboard" {
+		t.Errorf("unknown locale = %q", got)
+	}
+	if got := T("de", "%s is required", "url"); got != "url ist erforderlich" {
+		t.Errorf("formatted = %q", got)
+	}
+}
+
+var verbRe = regexp.MustCompile(`%[-+# 0]*[0-9]*(?:\.[0-9]+)?[a-zA-Z%]`)
+
+// Every translation must keep its message's format verbs, in order, or
+// formatting it would garble the output.
+func TestCatalogsKeepFormatVerbs(t *testing.T) {
+	for code, c := range catalogs {
+		for msg, tr := range c.Messages {
+			if tr == "" {
+				t.Errorf("%s: %q has an empty translation", code, msg)
+			}
+			if want, got := verbRe.FindAllString(msg, -1), verbRe.FindAllString(tr, -1); !slices.Equal(want, got) {
+				t.Errorf("%s: %q has verbs %v, translation has %v", code, msg, want, got)
+			}
+		}
+	}
+}
+
+func TestLocales(t *testing.T) {
+	ls := Locales()
+	if len(ls) < 2 || ls[0].Code != Default {
+		t.Fatalf("Locales() = %+v, want the default first", ls)
+	}
+	for _, l := range ls {
+		if !Supported(l.Code) || l.Name == "" {
+			t.Errorf("locale %+v", l)
+		}
+	}
+	if Supported("xx") {
+		t.Error("Supported(xx) = true")
+	}
+}
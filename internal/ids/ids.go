@@ -0,0 +1,83 @@
+// Package ids generates the primary keys of every stored row. The strategy
+// is chosen once at startup with JOE_ID_STRATEGY; IDs are opaque TEXT, so
+// rows created under an earlier strategy keep working unchanged.
+// Governing: SPEC-0002 REQ "ID Generation Strategy"
+package ids
+
+import (
+	"crypto/rand"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/google/uuid"
+)
+
+// Strategies accepted by SetStrategy.
+const (
+	UUID   = "uuid"   // random UUIDv4 (default)
+	UUIDv7 = "uuidv7" // time-ordered UUIDv7, so IDs sort by creation time
+	Short  = "short"  // 16 random lowercase alphanumerics
+)
+
+// MaxLen is the longest ID any strategy produces; ID columns must hold it.
+const MaxLen = 36
+
+// shortAlphabet is lowercase only: MySQL's default collations compare
+// case-insensitively, so mixed-case IDs could collide there. 16 characters
+// of base 36 carry about 82 bits of randomness.
+const (
+	shortAlphabet = "0123456789abcdefghijklmnopqrstuvwxyz"
+	shortLen      = 16
+)
+
+var generator atomic.Pointer[func() string]
+
+func init() {
+	f := newUUID
+	generator.Store(&f)
+}
+
+// New returns a new ID using the current strategy.
+func New() string {
+	return (*generator.Load())()
+}
+
+// SetStrategy selects how New generates IDs; "" means UUID.
+func SetStrategy(name string) error {
+	var f func() string
+	switch name {
+	case "", UUID:
+		f = newUUID
+	case UUIDv7:
+		f = newUUIDv7
+	case Short:
+		f = newShort
+	default:
+		return fmt.Errorf("unknown ID strategy %q (want %s, %s, or %s)", name, UUID, UUIDv7, Short)
+	}
+	generator.Store(&f)
+	return nil
+}
+
+func newUUID() string { return uuid.New().String() }
+
+func newUUIDv7() string { return uuid.Must(uuid.NewV7()).String() }
+
+func newShort() string {
+	// Rejection sampling keeps every character equally likely: bytes at or
+	// above the largest multiple of 36 are discarded.
+	const limit = 256 - 256%len(shortAlphabet)
+	id := make([]byte, 0, shortLen)
+	var buf [shortLen * 2]byte
+	for len(id) < shortLen {
+		if _, err := rand.Read(buf[:]); err != nil {
+			panic("ids: crypto/rand failed: " + err.Error())
+		}
+		for _, b := range buf {
+			if int(b) < limit && len(id) < shortLen {
+				id = append(id, shortAlphabet[int(b)%len(shortAlphabet)])
+			}
+		}
+	}
+	return string(id)
+}
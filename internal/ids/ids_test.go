@@ -0,0 +1,63 @@
+package ids_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/joestump/joe-links/internal/ids"
+)
+
+func TestStrategies(t *testing.T) {
+	t.Cleanup(func() { _ = ids.SetStrategy(ids.UUID) })
+
+	cases := []struct {
+		strategy string
+		length   int
+		charset  string
+	}{
+		{ids.UUID, 36, "0123456789abcdef-"},
+		{ids.UUIDv7, 36, "0123456789abcdef-"},
+		{ids.Short, 16, "0123456789abcdefghijklmnopqrstuvwxyz"},
+	}
+	for _, c := range cases {
+		if err := ids.SetStrategy(c.strategy); err != nil {
+			t.Fatalf("SetStrategy(%q): %v", c.strategy, err)
+		}
+		seen := make(map[string]bool)
+		for i := 0; i < 1000; i++ {
+			id := ids.New()
+			if len(id) != c.length || len(id) > ids.MaxLen {
+				t.Fatalf("%s: len(%q) = %d, want %d", c.strategy, id, len(id), c.length)
+			}
+			if strings.Trim(id, c.charset) != "" {
+				t.Fatalf("%s: %q has characters outside %q", c.strategy, id, c.charset)
+			}
+			if seen[id] {
+				t.Fatalf("%s: duplicate ID %q", c.strategy, id)
+			}
+			seen[id] = true
+		}
+	}
+}
+
+func TestUUIDv7Sorts(t *testing.T) {
+	t.Cleanup(func() { _ = ids.SetStrategy(ids.UUID) })
+	if err := ids.SetStrategy(ids.UUIDv7); err != nil {
+		t.Fatal(err)
+	}
+	first := ids.New()
+	time.Sleep(2 * time.Millisecond)
+	if second := ids.New(); second <= first {
+		t.Errorf("later UUIDv7 %q does not sort after %q", second, first)
+	}
+}
+
+func TestSetStrategyRejectsUnknown(t *testing.T) {
+	if err := ids.SetStrategy("snowflake"); err == nil {
+		t.Error("SetStrategy accepted an unknown strategy")
+	}
+	if err := ids.SetStrategy(""); err != nil {
+		t.Errorf(`SetStrategy("") = %v, want nil`, err)
+	}
+}
@@ -0,0 +1,131 @@
+// Governing: SPEC-0001 REQ "Link Health Checks"
+package linkhealth
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/joestump/joe-links/internal/build"
+	"github.com/joestump/joe-links/internal/metrics"
+	"github.com/joestump/joe-links/internal/store"
+)
+
+// pause spaces out requests within a sweep so a large link table does not
+// burst outbound traffic.
+const pause = 100 * time.Millisecond
+
+// Checker periodically requests every link's target URL and records the
+// status code and latency. Templated links are skipped: their URL is not a
+// reachable address until variables are substituted.
+type Checker struct {
+	links    *store.LinkStore
+	health   *store.HealthStore
+	client   *http.Client
+	interval time.Duration
+}
+
+// NewChecker creates a Checker that sweeps all links every interval, giving
+// each request up to timeout.
+func NewChecker(ls *store.LinkStore, hs *store.HealthStore, interval, timeout time.Duration) *Checker {
+	return &Checker{
+		links:    ls,
+		health:   hs,
+		client:   &http.Client{Timeout: timeout},
+		interval: interval,
+	}
+}
+
+// Run sweeps immediately and then every interval until ctx is cancelled.
+func (c *Checker) Run(ctx context.Context) {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+	for {
+		if err := c.CheckAll(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("link health: %v", err)
+		} else if err == nil {
+			metrics.MarkJobSuccess(metrics.JobLinkHealth)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// CheckAll checks every non-templated link once and records the results.
+func (c *Checker) CheckAll(ctx context.Context) error {
+	links, err := c.links.ListAll(ctx)
+	if err != nil {
+		return err
+	}
+	broken := 0
+	for i, link := range links {
+		if store.VarPlaceholderRe.MatchString(link.URL) {
+			continue
+		}
+		if i > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(pause):
+			}
+		}
+		h := c.Check(ctx, link)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if h.Broken() {
+			broken++
+		}
+		if err := c.health.Record(ctx, h); err != nil {
+			log.Printf("link health: record %s: %v", link.Slug, err)
+		}
+	}
+	metrics.LinksBroken.Set(float64(broken))
+	return nil
+}
+
+// Check requests link's URL with HEAD, falling back to GET for servers that
+// do not support HEAD, and returns the result. Redirects are followed.
+func (c *Checker) Check(ctx context.Context, link *store.Link) store.LinkHealth {
+	h := store.LinkHealth{LinkID: link.ID, CheckedAt: time.Now().UTC()}
+	start := time.Now()
+	status, err := c.request(ctx, http.MethodHead, link.URL)
+	if err == nil && (status == http.StatusMethodNotAllowed || status == http.StatusNotImplemented) {
+		status, err = c.request(ctx, http.MethodGet, link.URL)
+	}
+	h.LatencyMS = time.Since(start).Milliseconds()
+	if err != nil {
+		h.Error = err.Error()
+		return h
+	}
+	h.StatusCode = status
+	return h
+}
+
+// request returns the response status for method on target. Errors are
+// unwrapped from *url.Error so they do not repeat the method and URL.
+func (c *Checker) request(ctx context.Context, method, target string) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, method, target, nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("User-Agent", "joe-links-health-checker/"+build.Version)
+	resp, err := c.client.Do(req)
+	if err != nil {
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			return 0, urlErr.Err
+		}
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	return resp.StatusCode, nil
+}
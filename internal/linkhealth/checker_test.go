@@ -0,0 +1,102 @@
+// Governing: SPEC-0001 REQ "Link Health Checks"
+package linkhealth
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/joestump/joe-links/internal/store"
+	"github.com/joestump/joe-links/internal/testutil"
+)
+
+func newTestChecker(t *testing.T) (*Checker, *store.LinkStore, *store.HealthStore, string) {
+	t.Helper()
+	db := testutil.NewTestDB(t)
+	owns := store.NewOwnershipStore(db)
+	ls := store.NewLinkStore(db, owns, store.NewTagStore(db))
+	hs := store.NewHealthStore(db)
+	u, err := store.NewUserStore(db).Upsert(context.Background(), "test", "sub1", "health@example.com", "Health", "")
+	if err != nil {
+		t.Fatalf("seed user: %v", err)
+	}
+	return NewChecker(ls, hs, time.Hour, 5*time.Second), ls, hs, u.ID
+}
+
+func TestCheck_Status(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	c, _, _, _ := newTestChecker(t)
+
+	h := c.Check(context.Background(), &store.Link{ID: "a", URL: srv.URL + "/ok"})
+	if h.StatusCode != http.StatusOK || h.Broken() {
+		t.Errorf("ok: got %+v", h)
+	}
+	h = c.Check(context.Background(), &store.Link{ID: "b", URL: srv.URL + "/missing"})
+	if h.StatusCode != http.StatusNotFound || !h.Broken() {
+		t.Errorf("missing: got %+v", h)
+	}
+}
+
+func TestCheck_FallsBackToGET(t *testing.T) {
+	var methods []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.Method)
+		if r.Method == http.MethodHead {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}))
+	defer srv.Close()
+	c, _, _, _ := newTestChecker(t)
+
+	h := c.Check(context.Background(), &store.Link{ID: "a", URL: srv.URL})
+	if h.StatusCode != http.StatusOK {
+		t.Errorf("status = %d, want 200", h.StatusCode)
+	}
+	if len(methods) != 2 || methods[0] != http.MethodHead || methods[1] != http.MethodGet {
+		t.Errorf("methods = %v, want [HEAD GET]", methods)
+	}
+}
+
+func TestCheck_TransportError(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	srv.Close()
+	c, _, _, _ := newTestChecker(t)
+
+	h := c.Check(context.Background(), &store.Link{ID: "a", URL: srv.URL})
+	if h.Error == "" || h.StatusCode != 0 || !h.Broken() {
+		t.Errorf("expected transport error, got %+v", h)
+	}
+}
+
+func TestCheckAll_SkipsTemplatedLinks(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+	c, ls, hs, userID := newTestChecker(t)
+	ctx := context.Background()
+
+	static, err := ls.Create(ctx, "plain", srv.URL, userID, "", "", "")
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	templated, err := ls.Create(ctx, "templated", srv.URL+"/$id", userID, "", "", "")
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	if err := c.CheckAll(ctx); err != nil {
+		t.Fatalf("CheckAll: %v", err)
+	}
+	if h, err := hs.Get(ctx, static.ID); err != nil || h.StatusCode != http.StatusOK {
+		t.Errorf("static: got %+v, %v", h, err)
+	}
+	if _, err := hs.Get(ctx, templated.ID); err != store.ErrNotFound {
+		t.Errorf("templated: err = %v, want ErrNotFound", err)
+	}
+}
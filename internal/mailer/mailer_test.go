@@ -0,0 +1,73 @@
+package mailer
+
+import (
+	"context"
+	"net/mail"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/joestump/joe-links/internal/store"
+)
+
+// fakeSender records the messages it is asked to send.
+type fakeSender struct {
+	mu   sync.Mutex
+	sent []Message
+}
+
+func (f *fakeSender) Send(_ context.Context, m Message) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.sent = append(f.sent, m)
+	return nil
+}
+
+func TestNotifier(t *testing.T) {
+	link := &store.Link{ID: "link-1", Slug: "deploy", URL: "https://ci.example.com", Title: "Deploy"}
+	actor := &store.User{ID: "u1", DisplayName: "Ada", Email: "ada@example.com", EmailNotifications: true}
+	bob := &store.User{ID: "u2", DisplayName: "Bob", Email: "bob@example.com", EmailNotifications: true}
+	optedOut := &store.User{ID: "u3", DisplayName: "Cy", Email: "cy@example.com"}
+
+	f := &fakeSender{}
+	n := NewNotifier(f, "https://go.example.com/")
+	n.CoOwnerAdded(link, bob, actor)
+	n.ShareGranted(link, bob, actor)
+	n.ShareGranted(link, optedOut, actor)
+	n.CoOwnerAdded(link, actor, actor)
+	n.Wait()
+
+	if len(f.sent) != 2 {
+		t.Fatalf("sent %d messages; want 2 (opted-out and self notifications skipped)", len(f.sent))
+	}
+	bySubject := map[string]Message{}
+	for _, m := range f.sent {
+		bySubject[m.Subject] = m
+		if m.To != "bob@example.com" {
+			t.Errorf("message sent to %q", m.To)
+		}
+	}
+	co, ok := bySubject["You are now a co-owner of go/deploy"]
+	if !ok || !strings.Contains(co.Body, "https://go.example.com/dashboard/links/link-1") {
+		t.Errorf("co-owner message = %+v", co)
+	}
+	share, ok := bySubject["Ada shared go/deploy with you"]
+	if !ok || !strings.Contains(share.Body, "https://go.example.com/deploy") || !strings.HasPrefix(share.Body, "Hi Bob,") {
+		t.Errorf("share message = %+v", share)
+	}
+}
+
+func TestFormat_NoHeaderInjection(t *testing.T) {
+	from := &mail.Address{Address: "links@example.com"}
+	to := &mail.Address{Address: "bob@example.com"}
+	msg := string(format(from, to, Message{Subject: "hi\r\nBcc: eve@example.com", Body: "line one\nline two"}, time.Unix(0, 0)))
+
+	head, body, _ := strings.Cut(msg, "\r\n\r\n")
+	if strings.Contains(head, "\r\nBcc:") {
+		t.Errorf("subject injected a header:\n%s", head)
+	}
+	if body != "line one\r\nline two" {
+		t.Errorf("body = %q; want CRLF line endings", body)
+	}
+}
@@ -0,0 +1,94 @@
+// Governing: SPEC-0001 REQ "Email Notifications"
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"log"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/joestump/joe-links/internal/store"
+)
+
+//go:embed templates/*.txt
+var templateFS embed.FS
+
+// Templates, each defining "subject" and "body".
+var (
+	coOwnerAddedTmpl = template.Must(template.ParseFS(templateFS, "templates/coowner_added.txt"))
+	shareGrantedTmpl = template.Must(template.ParseFS(templateFS, "templates/share_granted.txt"))
+)
+
+// sendTimeout bounds one notification's delivery.
+const sendTimeout = 30 * time.Second
+
+// templateData is the data every notification template receives.
+type templateData struct {
+	Link      *store.Link
+	Recipient *store.User
+	Actor     *store.User
+	BaseURL   string
+}
+
+// Notifier emails users about changes to their access to links. Sends run
+// in the background so a slow SMTP server never delays the request; failures
+// are logged.
+type Notifier struct {
+	sender  Sender
+	baseURL string
+	wg      sync.WaitGroup
+}
+
+// NewNotifier creates a Notifier that sends through sender and links to
+// pages under baseURL (e.g. https://go.example.com).
+func NewNotifier(sender Sender, baseURL string) *Notifier {
+	return &Notifier{sender: sender, baseURL: strings.TrimSuffix(baseURL, "/")}
+}
+
+// CoOwnerAdded tells recipient that actor made them a co-owner of link.
+func (n *Notifier) CoOwnerAdded(link *store.Link, recipient, actor *store.User) {
+	n.notify(coOwnerAddedTmpl, link, recipient, actor)
+}
+
+// ShareGranted tells recipient that actor shared the secure link with them.
+func (n *Notifier) ShareGranted(link *store.Link, recipient, actor *store.User) {
+	n.notify(shareGrantedTmpl, link, recipient, actor)
+}
+
+// Wait blocks until notifications already started have been sent or failed.
+func (n *Notifier) Wait() {
+	n.wg.Wait()
+}
+
+// notify renders t and sends it to recipient, unless they opted out, have no
+// email address, or made the change themselves.
+func (n *Notifier) notify(t *template.Template, link *store.Link, recipient, actor *store.User) {
+	if !recipient.EmailNotifications || recipient.Email == "" || recipient.ID == actor.ID {
+		return
+	}
+	data := templateData{Link: link, Recipient: recipient, Actor: actor, BaseURL: n.baseURL}
+	var subject, body bytes.Buffer
+	if err := t.ExecuteTemplate(&subject, "subject", data); err != nil {
+		log.Printf("mailer: render %s: %v", t.Name(), err)
+		return
+	}
+	if err := t.ExecuteTemplate(&body, "body", data); err != nil {
+		log.Printf("mailer: render %s: %v", t.Name(), err)
+		return
+	}
+	msg := Message{To: recipient.Email, Subject: subject.String(), Body: strings.TrimLeft(body.String(), "\n")}
+
+	n.wg.Add(1)
+	go func() {
+		defer n.wg.Done()
+		ctx, cancel := context.WithTimeout(context.Background(), sendTimeout)
+		defer cancel()
+		if err := n.sender.Send(ctx, msg); err != nil {
+			log.Printf("mailer: send %s to user %s: %v", t.Name(), recipient.ID, err)
+		}
+	}()
+}
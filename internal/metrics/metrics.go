@@ -1,7 +1,12 @@
-// Governing: SPEC-0016 REQ "Prometheus Metrics Endpoint", ADR-0016
+// Governing: SPEC-0016 REQ "Prometheus Metrics Endpoint", REQ "Extended Operational Metrics", ADR-0016
+// Governing: SPEC-0016 REQ "Status Page"
 package metrics
 
 import (
+	"sync"
+	"sync/atomic"
+	"time"
+
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 )
@@ -37,4 +42,170 @@ var (
 		Name: "joelinks_users_total",
 		Help: "Total number of registered users in the database.",
 	})
+
+	LinksBroken = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "joelinks_links_broken",
+		Help: "Links whose target failed the last health check sweep.",
+	})
+
+	ClicksDroppedTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "joelinks_clicks_dropped_total",
+		Help: "Click events dropped because the click queue was full.",
+	})
+
+	// Governing: SPEC-0016 REQ "Referrer Exclusion"
+	ClicksExcludedTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "joelinks_clicks_excluded_total",
+		Help: "Click events not recorded because their referrer is excluded.",
+	})
+
+	// Governing: SPEC-0006 REQ "Token Brute-Force Lockout"
+	APIAuthFailuresTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "joelinks_api_auth_failures_total",
+		Help: "API requests rejected for presenting an unknown, revoked, or expired token.",
+	})
+	APILockoutsTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "joelinks_api_lockouts_total",
+		Help: "Client addresses locked out after repeated failed API token checks.",
+	})
+
+	// Governing: SPEC-0001 REQ "Security Headers"
+	CSPViolationsTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "joelinks_csp_violations_total",
+		Help: "Content-Security-Policy violation reports received from browsers.",
+	})
+
+	// UnknownVisibilityTotal counts resolutions of links whose visibility is
+	// not public, private, or secure, by whether strict mode denied them.
+	// Governing: SPEC-0010 REQ "Strict Visibility"
+	UnknownVisibilityTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "joelinks_unknown_visibility_total",
+		Help: "Resolutions of links with an unknown visibility value, by outcome (denied or allowed).",
+	}, []string{"outcome"})
+
+	// SlugRedirects counts successful redirects for the TopSlugs busiest slugs.
+	// It is the only slug-labeled metric; its cardinality is capped at TopSlugs.
+	SlugRedirects = NewTopNCounter(
+		"joelinks_slug_redirects_total",
+		"Successful redirects per slug, exported for the busiest slugs only (approximate).",
+		"slug", TopSlugs,
+	)
+
+	// DBQueryDuration records store-layer query latency. The op label is a
+	// fixed, code-defined operation name, never a query string or argument.
+	DBQueryDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "joelinks_db_query_duration_seconds",
+		Help:    "Latency of store-layer database queries by operation.",
+		Buckets: []float64{0.0005, 0.001, 0.0025, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1},
+	}, []string{"op"})
+
+	// SlugFilterChecksTotal counts slug lookups by what the in-process slug
+	// filter concluded: "negative" lookups skipped the database, "positive"
+	// ones found a link, and "false_positive" ones queried it for nothing.
+	// Governing: SPEC-0009 REQ "Slug Filter Fast Path"
+	SlugFilterChecksTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "joelinks_slug_filter_checks_total",
+		Help: "Slug lookups by slug filter outcome (negative, positive, false_positive).",
+	}, []string{"result"})
+
+	// ReplicaFallbacksTotal counts reads retried on the primary because the
+	// read replica failed or had not yet seen the row.
+	// Governing: SPEC-0001 REQ "Database Pool and Read Replica"
+	ReplicaFallbacksTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "joelinks_db_replica_fallbacks_total",
+		Help: "Replica reads retried on the primary database.",
+	})
+
+	ClickBatchSize = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "joelinks_click_batch_size",
+		Help:    "Click events per batched insert.",
+		Buckets: []float64{1, 5, 10, 25, 50, 100, 250, 500},
+	})
+
+	ClickQueueCapacity = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "joelinks_click_queue_capacity",
+		Help: "Capacity of the in-memory click event queue.",
+	})
+
+	// Governing: SPEC-0016 REQ "Status Page"
+	JobLastSuccess = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "joelinks_job_last_success_timestamp_seconds",
+		Help: "Unix time of each background job's last successful run.",
+	}, []string{"job"})
+
+	ClickQueueDepth = promauto.NewGaugeFunc(prometheus.GaugeOpts{
+		Name: "joelinks_click_queue_depth",
+		Help: "Click events waiting in the in-memory queue to be written.",
+	}, func() float64 {
+		if f := clickQueueLen.Load(); f != nil {
+			return float64((*f)())
+		}
+		return 0
+	})
+)
+
+// TopSlugs is the number of slugs exported by SlugRedirects.
+const TopSlugs = 25
+
+var (
+	clickQueueLen atomic.Pointer[func() int]
+	clickQueueCap atomic.Int64
+	jobRuns       sync.Map // job name -> time.Time of last success
 )
+
+func init() {
+	prometheus.MustRegister(SlugRedirects)
+}
+
+// SetClickQueue registers the click queue so its depth is sampled on every
+// scrape. length is typically a closure over len(ch).
+func SetClickQueue(length func() int, capacity int) {
+	clickQueueLen.Store(&length)
+	clickQueueCap.Store(int64(capacity))
+	ClickQueueCapacity.Set(float64(capacity))
+}
+
+// ClickQueue returns the click queue's current depth and capacity, both zero
+// when no queue is registered.
+func ClickQueue() (depth, capacity int) {
+	if f := clickQueueLen.Load(); f != nil {
+		depth = (*f)()
+	}
+	return depth, int(clickQueueCap.Load())
+}
+
+// Background job names passed to MarkJobSuccess.
+const (
+	JobClickWriter  = "click_writer"
+	JobGaugeUpdater = "gauge_updater"
+	JobLinkHealth   = "link_health"
+	JobUsageFlush   = "usage_flush"
+	JobPolicySweep  = "policy_sweep"
+	JobTelemetry    = "telemetry"
+	JobDemoReset    = "demo_reset"
+	JobSlugFilter   = "slug_filter"
+)
+
+// MarkJobSuccess records that the named background job just completed a run.
+// Governing: SPEC-0016 REQ "Status Page"
+func MarkJobSuccess(job string) {
+	now := time.Now()
+	jobRuns.Store(job, now)
+	JobLastSuccess.WithLabelValues(job).Set(float64(now.Unix()))
+}
+
+// JobLastSuccessAt returns when the named job last completed a run, and false
+// if it has not completed one since the process started.
+func JobLastSuccessAt(job string) (time.Time, bool) {
+	v, ok := jobRuns.Load(job)
+	if !ok {
+		return time.Time{}, false
+	}
+	return v.(time.Time), true
+}
+
+// ObserveDBQuery records the time elapsed since start under op. Call it as
+// defer metrics.ObserveDBQuery("link_get_by_slug", time.Now()).
+func ObserveDBQuery(op string, start time.Time) {
+	DBQueryDuration.WithLabelValues(op).Observe(time.Since(start).Seconds())
+}
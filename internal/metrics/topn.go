@@ -0,0 +1,100 @@
+// Governing: SPEC-0016 REQ "Extended Operational Metrics", ADR-0016
+package metrics
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// TopNCounter counts events per key with bounded memory and exports only the
+// N busiest keys as labeled series, so an unbounded key space (slugs) cannot
+// explode metric cardinality.
+//
+// Keys are tracked with the Space-Saving algorithm: at most capacity keys are
+// held, and when a new key arrives with the table full it replaces the least
+// counted key and inherits that count. Counts for heavy hitters are therefore
+// upper bounds that never decrease while the key stays tracked; keys that fall
+// out of the top N simply stop being exported.
+type TopNCounter struct {
+	desc     *prometheus.Desc
+	limit    int
+	capacity int
+
+	mu     sync.Mutex
+	counts map[string]uint64
+}
+
+// NewTopNCounter creates a TopNCounter exporting the limit busiest keys under
+// the given label. It tracks four times as many keys as it exports so that
+// rising keys can overtake incumbents.
+func NewTopNCounter(name, help, label string, limit int) *TopNCounter {
+	if limit < 1 {
+		limit = 1
+	}
+	return &TopNCounter{
+		desc:     prometheus.NewDesc(name, help, []string{label}, nil),
+		limit:    limit,
+		capacity: 4 * limit,
+		counts:   make(map[string]uint64, 4*limit),
+	}
+}
+
+// Inc adds one event for key.
+func (c *TopNCounter) Inc(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.counts[key]; ok || len(c.counts) < c.capacity {
+		c.counts[key]++
+		return
+	}
+	var minKey string
+	var minCount uint64
+	first := true
+	for k, n := range c.counts {
+		if first || n < minCount {
+			minKey, minCount, first = k, n, false
+		}
+	}
+	delete(c.counts, minKey)
+	c.counts[key] = minCount + 1
+}
+
+type topNEntry struct {
+	key   string
+	count uint64
+}
+
+// top returns the exported keys and their counts, busiest first.
+func (c *TopNCounter) top() []topNEntry {
+	c.mu.Lock()
+	entries := make([]topNEntry, 0, len(c.counts))
+	for k, n := range c.counts {
+		entries = append(entries, topNEntry{k, n})
+	}
+	c.mu.Unlock()
+
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].count != entries[j].count {
+			return entries[i].count > entries[j].count
+		}
+		return entries[i].key < entries[j].key
+	})
+	if len(entries) > c.limit {
+		entries = entries[:c.limit]
+	}
+	return entries
+}
+
+// Describe implements prometheus.Collector.
+func (c *TopNCounter) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+// Collect implements prometheus.Collector.
+func (c *TopNCounter) Collect(ch chan<- prometheus.Metric) {
+	for _, e := range c.top() {
+		ch <- prometheus.MustNewConstMetric(c.desc, prometheus.CounterValue, float64(e.count), e.key)
+	}
+}
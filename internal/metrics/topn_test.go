@@ -0,0 +1,73 @@
+// Governing: SPEC-0016 REQ "Extended Operational Metrics", ADR-0016
+package metrics
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestTopNCounter_ExportsOnlyBusiest(t *testing.T) {
+	c := NewTopNCounter("test_slug_total", "test", "slug", 2)
+	for i := 0; i < 5; i++ {
+		c.Inc("a")
+	}
+	for i := 0; i < 3; i++ {
+		c.Inc("b")
+	}
+	c.Inc("c")
+
+	top := c.top()
+	if len(top) != 2 {
+		t.Fatalf("expected 2 exported keys, got %d", len(top))
+	}
+	if top[0].key != "a" || top[0].count != 5 || top[1].key != "b" || top[1].count != 3 {
+		t.Errorf("unexpected top: %+v", top)
+	}
+
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(c)
+	if n := testutil.CollectAndCount(c); n != 2 {
+		t.Errorf("expected 2 series, got %d", n)
+	}
+	want := `
+# HELP test_slug_total test
+# TYPE test_slug_total counter
+test_slug_total{slug="a"} 5
+test_slug_total{slug="b"} 3
+`
+	if err := testutil.CollectAndCompare(c, strings.NewReader(want)); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestTopNCounter_BoundedMemory(t *testing.T) {
+	c := NewTopNCounter("test_bounded_total", "test", "slug", 3)
+	for i := 0; i < 1000; i++ {
+		c.Inc(fmt.Sprintf("slug-%d", i))
+	}
+	if len(c.counts) > c.capacity {
+		t.Fatalf("tracked %d keys, capacity %d", len(c.counts), c.capacity)
+	}
+	if n := len(c.top()); n != 3 {
+		t.Errorf("expected 3 exported keys, got %d", n)
+	}
+}
+
+func TestTopNCounter_HeavyHitterSurvivesChurn(t *testing.T) {
+	c := NewTopNCounter("test_churn_total", "test", "slug", 1)
+	for i := 0; i < 100; i++ {
+		c.Inc("hot")
+		c.Inc(fmt.Sprintf("cold-%d", i))
+	}
+	top := c.top()
+	if len(top) != 1 || top[0].key != "hot" {
+		t.Fatalf("expected hot to be the top key, got %+v", top)
+	}
+	if top[0].count < 100 {
+		t.Errorf("expected count >= 100, got %d", top[0].count)
+	}
+}
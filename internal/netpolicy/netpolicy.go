@@ -0,0 +1,151 @@
+// Package netpolicy limits routes to clients on listed networks, so admin
+// pages and endpoints can stay on a corporate VPN while the rest of the
+// service is public.
+// Governing: SPEC-0001 REQ "Admin Network Allowlist"
+package netpolicy
+
+import (
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// Allowlist holds the networks allowed through. A nil *Allowlist allows
+// every client.
+type Allowlist struct {
+	nets []*net.IPNet
+}
+
+// Parse builds an Allowlist from CIDR ranges and bare addresses. It returns
+// nil, allowing every client, when entries holds nothing but blanks.
+func Parse(entries []string) (*Allowlist, error) {
+	a := &Allowlist{}
+	for _, e := range entries {
+		e = strings.TrimSpace(e)
+		if e == "" {
+			continue
+		}
+		cidr := e
+		if !strings.Contains(cidr, "/") {
+			if ip := net.ParseIP(cidr); ip != nil && ip.To4() != nil {
+				cidr += "/32"
+			} else {
+				cidr += "/128"
+			}
+		}
+		_, ipnet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid IP or CIDR %q", e)
+		}
+		a.nets = append(a.nets, ipnet)
+	}
+	if len(a.nets) == 0 {
+		return nil, nil
+	}
+	return a, nil
+}
+
+// Allows reports whether the client address ip is on an allowed network.
+// Unparseable addresses are refused.
+func (a *Allowlist) Allows(ip string) bool {
+	if a == nil {
+		return true
+	}
+	addr := net.ParseIP(ip)
+	if addr == nil {
+		return false
+	}
+	for _, n := range a.nets {
+		if n.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// Middleware passes requests from allowed clients to the next handler and
+// hands the rest to deny. The client address is r.RemoteAddr, so it is only
+// as trustworthy as the proxy headers RealIP accepts before it.
+func (a *Allowlist) Middleware(deny http.HandlerFunc) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if a == nil {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !a.Allows(clientIP(r)) {
+				deny(w, r)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// clientIP returns r.RemoteAddr without its port.
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// RealIP sets r.RemoteAddr to the client address a trusted reverse proxy
+// forwarded in X-Forwarded-For or X-Real-IP. Forwarding headers from any
+// other peer are ignored, so clients cannot pick their own address. Unlike
+// Middleware, a nil proxies trusts no peer.
+//
+// X-Forwarded-For is read from the right, skipping the trusted proxies'
+// own entries, so addresses a client prepends are never used.
+//
+// The first request that carries forwarding headers from an untrusted peer
+// is logged: behind an unlisted proxy every client shares its address, so
+// lockouts, click records, and rate limits would lump them together.
+func RealIP(proxies *Allowlist) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		var warn sync.Once
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			peer := clientIP(r)
+			switch {
+			case proxies != nil && proxies.Allows(peer):
+				if ip := forwardedIP(r, proxies); ip != "" {
+					r.RemoteAddr = ip
+				}
+			case r.Header.Get("X-Forwarded-For") != "" || r.Header.Get("X-Real-IP") != "":
+				warn.Do(func() {
+					log.Printf("warning: ignoring X-Forwarded-For/X-Real-IP from untrusted peer %s; if it is a reverse proxy, add it to JOE_TRUSTED_PROXIES", peer)
+				})
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// forwardedIP returns the nearest untrusted address in X-Forwarded-For,
+// falling back to X-Real-IP, or "" when neither holds a valid address.
+func forwardedIP(r *http.Request, proxies *Allowlist) string {
+	if xff := r.Header.Values("X-Forwarded-For"); len(xff) > 0 {
+		hops := strings.Split(strings.Join(xff, ","), ",")
+		ip := ""
+		for i := len(hops) - 1; i >= 0; i-- {
+			addr := net.ParseIP(strings.TrimSpace(hops[i]))
+			if addr == nil {
+				break
+			}
+			ip = addr.String()
+			if !proxies.Allows(ip) {
+				break
+			}
+		}
+		if ip != "" {
+			return ip
+		}
+	}
+	if addr := net.ParseIP(strings.TrimSpace(r.Header.Get("X-Real-IP"))); addr != nil {
+		return addr.String()
+	}
+	return ""
+}
@@ -0,0 +1,137 @@
+package netpolicy
+
+import (
+	"bytes"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestAllows(t *testing.T) {
+	a, err := Parse([]string{" 10.8.0.0/16", "", "203.0.113.9", "2001:db8::/32 "})
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	for ip, want := range map[string]bool{
+		"10.8.4.2":     true,
+		"10.9.0.1":     false,
+		"203.0.113.9":  true,
+		"203.0.113.10": false,
+		"2001:db8::1":  true,
+		"2001:db9::1":  false,
+		"not-an-ip":    false,
+		"":             false,
+	} {
+		if got := a.Allows(ip); got != want {
+			t.Errorf("Allows(%q) = %v, want %v", ip, got, want)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	if a, err := Parse([]string{"", "  "}); err != nil || a != nil {
+		t.Errorf("Parse(blanks) = %v, %v; want nil, nil", a, err)
+	}
+	if !(*Allowlist)(nil).Allows("198.51.100.1") {
+		t.Error("nil Allowlist should allow every client")
+	}
+	if _, err := Parse([]string{"10.0.0.0/33"}); err == nil {
+		t.Error("Parse accepted an invalid CIDR")
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	a, err := Parse([]string{"10.0.0.0/8"})
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	h := a.Middleware(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	for addr, want := range map[string]int{
+		"10.1.2.3:51234":    http.StatusNoContent,
+		"192.168.1.5:51234": http.StatusForbidden,
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/admin", nil)
+		req.RemoteAddr = addr
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+		if w.Code != want {
+			t.Errorf("%s: status = %d, want %d", addr, w.Code, want)
+		}
+	}
+}
+
+func TestRealIP(t *testing.T) {
+	proxies, err := Parse([]string{"10.0.0.0/8"})
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	for _, tc := range []struct {
+		proxies *Allowlist
+		peer    string
+		xff     string
+		realIP  string
+		want    string
+	}{
+		{proxies, "10.0.0.1:443", "198.51.100.7", "", "198.51.100.7"},
+		{proxies, "10.0.0.1:443", "10.8.0.5, 198.51.100.7, 10.0.0.2", "", "198.51.100.7"},
+		{proxies, "10.0.0.1:443", "", "198.51.100.7", "198.51.100.7"},
+		{proxies, "10.0.0.1:443", "junk", "", "10.0.0.1:443"},
+		{proxies, "203.0.113.4:443", "10.8.0.5", "10.8.0.5", "203.0.113.4:443"},
+		{nil, "203.0.113.4:443", "10.8.0.5", "", "203.0.113.4:443"},
+	} {
+		var got string
+		h := RealIP(tc.proxies)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			got = r.RemoteAddr
+		}))
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.RemoteAddr = tc.peer
+		if tc.xff != "" {
+			req.Header.Set("X-Forwarded-For", tc.xff)
+		}
+		if tc.realIP != "" {
+			req.Header.Set("X-Real-IP", tc.realIP)
+		}
+		h.ServeHTTP(httptest.NewRecorder(), req)
+		if got != tc.want {
+			t.Errorf("peer %s, X-Forwarded-For %q: RemoteAddr = %q, want %q", tc.peer, tc.xff, got, tc.want)
+		}
+	}
+}
+
+func TestRealIP_WarnsOnceAboutUntrustedForwarding(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	t.Cleanup(func() { log.SetOutput(os.Stderr) })
+
+	proxies, err := Parse([]string{"10.0.0.0/8"})
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	h := RealIP(proxies)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	serve := func(peer, xff string) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.RemoteAddr = peer
+		if xff != "" {
+			req.Header.Set("X-Forwarded-For", xff)
+		}
+		h.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	serve("10.0.0.1:443", "198.51.100.7")
+	serve("203.0.113.4:443", "")
+	if buf.Len() != 0 {
+		t.Fatalf("logged without an untrusted forwarding header: %s", buf.String())
+	}
+	serve("203.0.113.4:443", "198.51.100.7")
+	serve("203.0.113.5:443", "198.51.100.8")
+	if n := strings.Count(buf.String(), "untrusted peer"); n != 1 || !strings.Contains(buf.String(), "203.0.113.4") {
+		t.Errorf("warnings = %d, log = %q; want one naming 203.0.113.4", n, buf.String())
+	}
+}
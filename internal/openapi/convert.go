@@ -0,0 +1,356 @@
+// Governing: SPEC-0007 REQ "OpenAPI 3.1 Document"
+//
+// Package openapi converts the Swagger 2.0 document generated by swag into an
+// OpenAPI 3.1 document, and validates JSON values against its schemas so tests
+// can check real handler responses against the published contract.
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Version is the OpenAPI version of documents produced by Convert.
+const Version = "3.1.0"
+
+const (
+	swaggerRefPrefix = "#/definitions/"
+	schemaRefPrefix  = "#/components/schemas/"
+)
+
+// Convert translates a Swagger 2.0 JSON document into OpenAPI 3.1. Definitions
+// move to components.schemas under their unqualified Go type names, body
+// parameters become request bodies, and the x-nullable extension becomes a
+// JSON Schema type union with "null". The output is indented JSON ending in a
+// newline, so regenerating an unchanged spec yields identical bytes.
+func Convert(swagger []byte) ([]byte, error) {
+	var doc map[string]any
+	if err := json.Unmarshal(swagger, &doc); err != nil {
+		return nil, fmt.Errorf("openapi: parse swagger document: %w", err)
+	}
+	if v, _ := doc["swagger"].(string); v != "2.0" {
+		return nil, fmt.Errorf("openapi: unsupported swagger version %q", v)
+	}
+
+	defs, _ := doc["definitions"].(map[string]any)
+	c := &converter{names: make(map[string]string, len(defs))}
+	if err := c.nameSchemas(defs); err != nil {
+		return nil, err
+	}
+
+	out := document{OpenAPI: Version, Info: doc["info"], Tags: doc["tags"]}
+	if base, _ := doc["basePath"].(string); base != "" {
+		out.Servers = []any{map[string]any{"url": base}}
+	}
+
+	components := map[string]any{}
+	schemas := map[string]any{}
+	for name, def := range defs {
+		schemas[c.names[name]] = c.schema(def)
+	}
+	if len(schemas) > 0 {
+		components["schemas"] = schemas
+	}
+	if sec, ok := doc["securityDefinitions"].(map[string]any); ok && len(sec) > 0 {
+		components["securitySchemes"] = sec
+	}
+	if len(components) > 0 {
+		out.Components = components
+	}
+
+	paths := map[string]any{}
+	if in, ok := doc["paths"].(map[string]any); ok {
+		for path, item := range in {
+			ops, _ := item.(map[string]any)
+			converted := map[string]any{}
+			for method, op := range ops {
+				operation, ok := op.(map[string]any)
+				if !ok {
+					return nil, fmt.Errorf("openapi: %s %s: unexpected operation", method, path)
+				}
+				converted[method] = c.operation(operation)
+			}
+			paths[path] = converted
+		}
+	}
+	out.Paths = paths
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "    ")
+	if err := enc.Encode(out); err != nil {
+		return nil, fmt.Errorf("openapi: encode document: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// document fixes the order of the top-level fields; everything below them is
+// written with sorted keys.
+type document struct {
+	OpenAPI    string         `json:"openapi"`
+	Info       any            `json:"info"`
+	Servers    []any          `json:"servers,omitempty"`
+	Tags       any            `json:"tags,omitempty"`
+	Paths      map[string]any `json:"paths"`
+	Components map[string]any `json:"components,omitempty"`
+}
+
+type converter struct {
+	// names maps Swagger definition names (e.g. "internal_api.LinkResponse")
+	// to component schema names (e.g. "LinkResponse").
+	names map[string]string
+}
+
+// nameSchemas drops the package qualifier swag adds to definition names,
+// keeping it only where two packages declare the same type name.
+func (c *converter) nameSchemas(defs map[string]any) error {
+	keys := make([]string, 0, len(defs))
+	for k := range defs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	short := map[string]int{}
+	for _, k := range keys {
+		short[shortName(k)]++
+	}
+	used := map[string]bool{}
+	for _, k := range keys {
+		name := shortName(k)
+		if short[name] > 1 {
+			name = strings.NewReplacer(".", "_", "/", "_").Replace(k)
+		}
+		if used[name] {
+			return fmt.Errorf("openapi: duplicate schema name %q", name)
+		}
+		used[name] = true
+		c.names[k] = name
+	}
+	return nil
+}
+
+func shortName(def string) string {
+	if i := strings.LastIndex(def, "."); i >= 0 {
+		return def[i+1:]
+	}
+	return def
+}
+
+func (c *converter) ref(ref string) string {
+	if name, ok := strings.CutPrefix(ref, swaggerRefPrefix); ok {
+		if short, ok := c.names[name]; ok {
+			return schemaRefPrefix + short
+		}
+		return schemaRefPrefix + name
+	}
+	return ref
+}
+
+// schema converts a Swagger schema object into a JSON Schema 2020-12 schema.
+func (c *converter) schema(v any) any {
+	in, ok := v.(map[string]any)
+	if !ok {
+		return v
+	}
+	out := make(map[string]any, len(in))
+	for k, val := range in {
+		switch k {
+		case "$ref":
+			out[k] = c.ref(val.(string))
+		case "items", "additionalProperties", "not":
+			out[k] = c.schema(val)
+		case "properties":
+			props := map[string]any{}
+			for name, p := range val.(map[string]any) {
+				props[name] = c.schema(p)
+			}
+			out[k] = props
+		case "allOf", "anyOf", "oneOf":
+			list := val.([]any)
+			conv := make([]any, len(list))
+			for i, s := range list {
+				conv[i] = c.schema(s)
+			}
+			out[k] = conv
+		case "example":
+			out["examples"] = []any{val}
+		case "x-nullable":
+			// Handled below once the rest of the schema is converted.
+		default:
+			out[k] = val
+		}
+	}
+	if nullable, _ := in["x-nullable"].(bool); nullable {
+		return makeNullable(out)
+	}
+	return out
+}
+
+// makeNullable widens a converted schema to also accept null.
+func makeNullable(s map[string]any) map[string]any {
+	if t, ok := s["type"].(string); ok {
+		s["type"] = []any{t, "null"}
+		if enum, ok := s["enum"].([]any); ok {
+			s["enum"] = append(enum, nil)
+		}
+		return s
+	}
+	// A $ref or composition cannot carry a type, so offer null as an alternative.
+	desc, hasDesc := s["description"]
+	delete(s, "description")
+	wrapped := map[string]any{"anyOf": []any{s, map[string]any{"type": "null"}}}
+	if hasDesc {
+		wrapped["description"] = desc
+	}
+	return wrapped
+}
+
+// parameterSchemaKeys are the Swagger parameter fields that describe the
+// value and so move into the parameter's schema in OpenAPI 3.
+var parameterSchemaKeys = []string{
+	"type", "format", "items", "enum", "default", "minimum", "maximum",
+	"minLength", "maxLength", "pattern", "minItems", "maxItems",
+}
+
+func (c *converter) operation(op map[string]any) map[string]any {
+	out := map[string]any{}
+	consumes := mediaTypes(op["consumes"])
+	produces := mediaTypes(op["produces"])
+
+	for k, v := range op {
+		switch k {
+		case "consumes", "produces", "parameters", "responses":
+		default:
+			out[k] = v
+		}
+	}
+
+	var params []any
+	form := map[string]any{}
+	var formRequired []any
+	for _, p := range asList(op["parameters"]) {
+		param := p.(map[string]any)
+		switch param["in"] {
+		case "body":
+			body := map[string]any{"content": content(consumes, c.schema(param["schema"]))}
+			if d, ok := param["description"]; ok {
+				body["description"] = d
+			}
+			if r, _ := param["required"].(bool); r {
+				body["required"] = true
+			}
+			out["requestBody"] = body
+		case "formData":
+			form[param["name"].(string)] = c.parameterSchema(param)
+			if r, _ := param["required"].(bool); r {
+				formRequired = append(formRequired, param["name"])
+			}
+		default:
+			conv := map[string]any{"schema": c.parameterSchema(param)}
+			for _, k := range []string{"name", "in", "description", "required"} {
+				if v, ok := param[k]; ok {
+					conv[k] = v
+				}
+			}
+			if param["in"] == "path" {
+				conv["required"] = true
+			}
+			params = append(params, conv)
+		}
+	}
+	if len(params) > 0 {
+		out["parameters"] = params
+	}
+	if len(form) > 0 {
+		schema := map[string]any{"type": "object", "properties": form}
+		if len(formRequired) > 0 {
+			schema["required"] = formRequired
+		}
+		media := consumes
+		if len(media) == 0 || media[0] == "application/json" {
+			media = []string{"application/x-www-form-urlencoded"}
+		}
+		out["requestBody"] = map[string]any{"content": content(media, schema)}
+	}
+
+	responses := map[string]any{}
+	for code, r := range asMap(op["responses"]) {
+		resp := r.(map[string]any)
+		conv := map[string]any{"description": resp["description"]}
+		if conv["description"] == nil {
+			conv["description"] = ""
+		}
+		if s, ok := resp["schema"]; ok {
+			conv["content"] = content(produces, c.schema(s))
+		}
+		if hs, ok := resp["headers"].(map[string]any); ok {
+			headers := map[string]any{}
+			for name, h := range hs {
+				hdr := h.(map[string]any)
+				header := map[string]any{"schema": c.parameterSchema(hdr)}
+				if d, ok := hdr["description"]; ok {
+					header["description"] = d
+				}
+				headers[name] = header
+			}
+			conv["headers"] = headers
+		}
+		responses[code] = conv
+	}
+	out["responses"] = responses
+	return out
+}
+
+// parameterSchema builds a schema from the value fields of a Swagger
+// parameter or header.
+func (c *converter) parameterSchema(param map[string]any) map[string]any {
+	schema := map[string]any{}
+	for _, k := range parameterSchemaKeys {
+		if v, ok := param[k]; ok {
+			schema[k] = v
+		}
+	}
+	if items, ok := schema["items"]; ok {
+		schema["items"] = c.schema(items)
+	}
+	if t, _ := schema["type"].(string); t == "file" {
+		schema["type"] = "string"
+		schema["contentMediaType"] = "application/octet-stream"
+	}
+	return schema
+}
+
+func content(media []string, schema any) map[string]any {
+	if len(media) == 0 {
+		media = []string{"application/json"}
+	}
+	out := make(map[string]any, len(media))
+	for _, m := range media {
+		out[m] = map[string]any{"schema": schema}
+	}
+	return out
+}
+
+func mediaTypes(v any) []string {
+	var out []string
+	for _, m := range asList(v) {
+		if s, ok := m.(string); ok {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+func asList(v any) []any {
+	l, _ := v.([]any)
+	return l
+}
+
+func asMap(v any) map[string]any {
+	m, _ := v.(map[string]any)
+	return m
+}
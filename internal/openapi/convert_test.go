@@ -0,0 +1,114 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"testing"
+
+	spec "github.com/joestump/joe-links/docs/openapi"
+)
+
+const swaggerFixture = `{
+  "swagger": "2.0",
+  "info": {"title": "t", "version": "1"},
+  "basePath": "/api/v1",
+  "paths": {
+    "/things/{id}": {
+      "put": {
+        "consumes": ["application/json"],
+        "produces": ["application/json"],
+        "parameters": [
+          {"name": "id", "in": "path", "required": true, "type": "string"},
+          {"name": "body", "in": "body", "required": true, "schema": {"$ref": "#/definitions/pkg.Thing"}}
+        ],
+        "responses": {
+          "200": {"description": "OK", "schema": {"$ref": "#/definitions/pkg.Thing"}},
+          "204": {"description": "No Content"}
+        }
+      }
+    }
+  },
+  "definitions": {
+    "pkg.Thing": {
+      "type": "object",
+      "properties": {
+        "name": {"type": "string", "example": "x"},
+        "kind": {"type": "string", "enum": ["a", "b"], "x-nullable": true},
+        "parent": {"allOf": [{"$ref": "#/definitions/pkg.Thing"}], "x-nullable": true}
+      }
+    }
+  }
+}`
+
+// Governing: SPEC-0007 REQ "OpenAPI 3.1 Document"
+func TestConvert(t *testing.T) {
+	out, err := Convert([]byte(swaggerFixture))
+	if err != nil {
+		t.Fatalf("Convert: %v", err)
+	}
+	var doc struct {
+		OpenAPI    string `json:"openapi"`
+		Servers    []map[string]string
+		Paths      map[string]map[string]map[string]any
+		Components struct {
+			Schemas map[string]map[string]any
+		}
+	}
+	if err := json.Unmarshal(out, &doc); err != nil {
+		t.Fatalf("output is not JSON: %v", err)
+	}
+	if doc.OpenAPI != Version || len(doc.Servers) != 1 || doc.Servers[0]["url"] != "/api/v1" {
+		t.Errorf("openapi = %q, servers = %v", doc.OpenAPI, doc.Servers)
+	}
+	op := doc.Paths["/things/{id}"]["put"]
+	body, _ := json.Marshal(op["requestBody"])
+	if !bytes.Contains(body, []byte(`"$ref":"#/components/schemas/Thing"`)) {
+		t.Errorf("requestBody = %s, want a Thing reference", body)
+	}
+	if params, _ := json.Marshal(op["parameters"]); bytes.Contains(params, []byte(`"in":"body"`)) {
+		t.Errorf("parameters still contain the body: %s", params)
+	}
+
+	d, err := Parse(out)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	valid := []byte(`{"name":"n","kind":null,"parent":{"name":"p","kind":"a"}}`)
+	if err := d.ValidateResponse("PUT", "/things/{id}", 200, valid); err != nil {
+		t.Errorf("valid body rejected: %v", err)
+	}
+	for _, bad := range []string{
+		`{"name":1}`,
+		`{"kind":"c"}`,
+		`{"parent":{"extra":true}}`,
+		`[]`,
+	} {
+		if err := d.ValidateResponse("PUT", "/things/{id}", 200, []byte(bad)); err == nil {
+			t.Errorf("body %s accepted, want an error", bad)
+		}
+	}
+	if err := d.ValidateResponse("PUT", "/things/{id}", 204, nil); err != nil {
+		t.Errorf("204: %v", err)
+	}
+	if err := d.ValidateResponse("PUT", "/things/{id}", 404, nil); err == nil {
+		t.Error("undocumented status accepted")
+	}
+}
+
+// TestEmbeddedSpecIsCurrent fails when the committed OpenAPI document is out
+// of date with the Swagger spec. Run "make swagger" to regenerate both.
+// Governing: SPEC-0007 REQ "Spec Freshness in CI"
+func TestEmbeddedSpecIsCurrent(t *testing.T) {
+	swagger, err := os.ReadFile("../../docs/swagger/swagger.json")
+	if err != nil {
+		t.Fatalf("read swagger.json: %v", err)
+	}
+	want, err := Convert(swagger)
+	if err != nil {
+		t.Fatalf("Convert: %v", err)
+	}
+	if !bytes.Equal(spec.Spec, want) {
+		t.Error("docs/openapi/openapi.json is stale; run 'make swagger'")
+	}
+}
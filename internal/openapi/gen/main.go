@@ -0,0 +1,31 @@
+// Governing: SPEC-0007 REQ "OpenAPI 3.1 Document"
+//
+// Command gen converts the swag-generated Swagger 2.0 spec into the OpenAPI
+// 3.1 document served at /api/openapi.json. Run it through "make swagger".
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+
+	"github.com/joestump/joe-links/internal/openapi"
+)
+
+func main() {
+	in := flag.String("in", "docs/swagger/swagger.json", "Swagger 2.0 JSON input")
+	out := flag.String("out", "docs/openapi/openapi.json", "OpenAPI 3.1 JSON output")
+	flag.Parse()
+
+	swagger, err := os.ReadFile(*in)
+	if err != nil {
+		log.Fatal(err)
+	}
+	doc, err := openapi.Convert(swagger)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(*out, doc, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,259 @@
+// Governing: SPEC-0007 REQ "API Contract Tests"
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Document is a parsed OpenAPI 3.1 document.
+type Document struct {
+	paths   map[string]any
+	schemas map[string]any
+}
+
+// Parse reads an OpenAPI 3.1 JSON document such as the output of Convert.
+func Parse(data []byte) (*Document, error) {
+	var doc map[string]any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("openapi: parse document: %w", err)
+	}
+	if v, _ := doc["openapi"].(string); !strings.HasPrefix(v, "3.1") {
+		return nil, fmt.Errorf("openapi: unsupported openapi version %q", v)
+	}
+	d := &Document{paths: asMap(doc["paths"])}
+	d.schemas = asMap(asMap(doc["components"])["schemas"])
+	return d, nil
+}
+
+// ValidateResponse checks a JSON response body against the schema the
+// document declares for the operation's status code. path is the templated
+// path as written in the document, e.g. "/links/{id}". Responses without
+// content, such as 204, must have an empty body.
+func (d *Document) ValidateResponse(method, path string, status int, body []byte) error {
+	item, ok := d.paths[path].(map[string]any)
+	if !ok {
+		return fmt.Errorf("openapi: path %s is not documented", path)
+	}
+	op, ok := item[strings.ToLower(method)].(map[string]any)
+	if !ok {
+		return fmt.Errorf("openapi: %s %s is not documented", method, path)
+	}
+	resp, ok := asMap(op["responses"])[strconv.Itoa(status)].(map[string]any)
+	if !ok {
+		return fmt.Errorf("openapi: %s %s does not document status %d", method, path, status)
+	}
+	media, ok := asMap(resp["content"])["application/json"].(map[string]any)
+	if !ok {
+		if len(bytes.TrimSpace(body)) > 0 {
+			return fmt.Errorf("openapi: %s %s %d documents no body but got %q", method, path, status, body)
+		}
+		return nil
+	}
+	var value any
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	if err := dec.Decode(&value); err != nil {
+		return fmt.Errorf("openapi: %s %s %d: decode body: %w", method, path, status, err)
+	}
+	if err := d.Validate(media["schema"], value); err != nil {
+		return fmt.Errorf("openapi: %s %s %d: %w", method, path, status, err)
+	}
+	return nil
+}
+
+// Validate checks a decoded JSON value against a schema. It supports the
+// subset of JSON Schema that Convert emits. Objects are closed: a property the
+// schema does not declare is an error, so a handler that starts returning an
+// undocumented field fails the contract until the annotations catch up.
+func (d *Document) Validate(schema, value any) error {
+	return d.validate(schema, value, "$")
+}
+
+func (d *Document) validate(schema, value any, at string) error {
+	s, ok := schema.(map[string]any)
+	if !ok {
+		if b, isBool := schema.(bool); isBool && !b {
+			return fmt.Errorf("%s: no value allowed", at)
+		}
+		return nil
+	}
+
+	if ref, ok := s["$ref"].(string); ok {
+		name, found := strings.CutPrefix(ref, schemaRefPrefix)
+		target, exists := d.schemas[name]
+		if !found || !exists {
+			return fmt.Errorf("%s: unresolved reference %s", at, ref)
+		}
+		if err := d.validate(target, value, at); err != nil {
+			return err
+		}
+	}
+	for _, sub := range asList(s["allOf"]) {
+		if err := d.validate(sub, value, at); err != nil {
+			return err
+		}
+	}
+	if anyOf := asList(s["anyOf"]); len(anyOf) > 0 {
+		var errs []string
+		for _, sub := range anyOf {
+			err := d.validate(sub, value, at)
+			if err == nil {
+				errs = nil
+				break
+			}
+			errs = append(errs, err.Error())
+		}
+		if errs != nil {
+			return fmt.Errorf("%s: matches no anyOf alternative (%s)", at, strings.Join(errs, "; "))
+		}
+	}
+	if oneOf := asList(s["oneOf"]); len(oneOf) > 0 {
+		matched := 0
+		for _, sub := range oneOf {
+			if d.validate(sub, value, at) == nil {
+				matched++
+			}
+		}
+		if matched != 1 {
+			return fmt.Errorf("%s: matches %d oneOf alternatives, want 1", at, matched)
+		}
+	}
+
+	if types := schemaTypes(s["type"]); len(types) > 0 {
+		t := jsonType(value)
+		if !typeAllowed(types, t) {
+			return fmt.Errorf("%s: got %s, want %s", at, t, strings.Join(types, " or "))
+		}
+	}
+	if enum, ok := s["enum"].([]any); ok && !inEnum(enum, value) {
+		return fmt.Errorf("%s: %v is not one of %v", at, value, enum)
+	}
+	if str, ok := value.(string); ok && s["format"] == "date-time" {
+		if _, err := time.Parse(time.RFC3339Nano, str); err != nil {
+			return fmt.Errorf("%s: %q is not a date-time", at, str)
+		}
+	}
+
+	switch v := value.(type) {
+	case map[string]any:
+		return d.validateObject(s, v, at)
+	case []any:
+		if items, ok := s["items"]; ok {
+			for i, item := range v {
+				if err := d.validate(items, item, fmt.Sprintf("%s[%d]", at, i)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (d *Document) validateObject(s map[string]any, obj map[string]any, at string) error {
+	for _, r := range asList(s["required"]) {
+		name, _ := r.(string)
+		if _, ok := obj[name]; !ok {
+			return fmt.Errorf("%s: missing required property %q", at, name)
+		}
+	}
+	props := asMap(s["properties"])
+	extra, hasExtra := s["additionalProperties"]
+	declared := props != nil || hasExtra
+
+	keys := make([]string, 0, len(obj))
+	for k := range obj {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		child := at + "." + k
+		if p, ok := props[k]; ok {
+			if err := d.validate(p, obj[k], child); err != nil {
+				return err
+			}
+			continue
+		}
+		switch {
+		case hasExtra:
+			if err := d.validate(extra, obj[k], child); err != nil {
+				return err
+			}
+		case declared:
+			return fmt.Errorf("%s: undocumented property", child)
+		}
+	}
+	return nil
+}
+
+func schemaTypes(v any) []string {
+	switch t := v.(type) {
+	case string:
+		return []string{t}
+	case []any:
+		out := make([]string, 0, len(t))
+		for _, x := range t {
+			if s, ok := x.(string); ok {
+				out = append(out, s)
+			}
+		}
+		return out
+	}
+	return nil
+}
+
+func jsonType(v any) string {
+	switch n := v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	case json.Number:
+		if f, err := n.Float64(); err == nil && f == math.Trunc(f) && !strings.ContainsAny(n.String(), ".eE") {
+			return "integer"
+		}
+		return "number"
+	case float64:
+		if n == math.Trunc(n) {
+			return "integer"
+		}
+		return "number"
+	}
+	return fmt.Sprintf("%T", v)
+}
+
+func typeAllowed(types []string, t string) bool {
+	for _, want := range types {
+		if want == t || (want == "number" && t == "integer") {
+			return true
+		}
+	}
+	return false
+}
+
+func inEnum(enum []any, value any) bool {
+	for _, e := range enum {
+		if e == nil && value == nil {
+			return true
+		}
+		if e == nil || value == nil {
+			continue
+		}
+		if fmt.Sprint(e) == fmt.Sprint(value) {
+			return true
+		}
+	}
+	return false
+}
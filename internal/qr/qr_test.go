@@ -0,0 +1,218 @@
+package qr
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// The worked example from the ISO/IEC 18004 tutorial literature: "HELLO
+// WORLD" at 1-M in alphanumeric mode.
+func TestRSRemainder_KnownVector(t *testing.T) {
+	data := []byte{32, 91, 11, 120, 209, 114, 220, 77, 67, 64, 236, 17, 236, 17, 236, 17}
+	want := []byte{196, 35, 39, 119, 235, 215, 231, 226, 93, 23}
+	if got := rsRemainder(data, rsDivisor(10)); !bytes.Equal(got, want) {
+		t.Errorf("ECC = %v, want %v", got, want)
+	}
+}
+
+func TestFormatBits(t *testing.T) {
+	want := []int{
+		0b101010000010010, 0b101000100100101, 0b101111001111100, 0b101101101001011,
+		0b100010111111001, 0b100000011001110, 0b100111110010111, 0b100101010100000,
+	}
+	for mask, w := range want {
+		if got := formatBits(mask); got != w {
+			t.Errorf("formatBits(%d) = %015b, want %015b", mask, got, w)
+		}
+	}
+}
+
+func TestVersionBits(t *testing.T) {
+	if got := versionBits(7); got != 0x07C94 {
+		t.Errorf("versionBits(7) = %#x, want 0x07c94", got)
+	}
+}
+
+func TestAlignmentPositions(t *testing.T) {
+	tests := map[int][]int{
+		1:  nil,
+		2:  {6, 18},
+		7:  {6, 22, 38},
+		14: {6, 26, 46, 66},
+		32: {6, 34, 60, 86, 112, 138},
+	}
+	for version, want := range tests {
+		if got := alignmentPositions(version); !slices.Equal(got, want) {
+			t.Errorf("alignmentPositions(%d) = %v, want %v", version, got, want)
+		}
+	}
+}
+
+// Byte-mode capacities at level M from the standard's capacity table.
+func TestCapacity(t *testing.T) {
+	want := map[int]int{1: 14, 2: 26, 3: 42, 4: 62, 5: 84, 7: 122, 10: 213, 40: 2331}
+	for version, capacity := range want {
+		if got := (numDataCodewords(version)*8 - 4 - countBits(version)) / 8; got != capacity {
+			t.Errorf("version %d holds %d bytes, want %d", version, got, capacity)
+		}
+	}
+}
+
+func TestEncode_RoundTrip(t *testing.T) {
+	for _, text := range []string{
+		"go/wiki",
+		"https://go.example.com/wiki",
+		"https://go.example.com/" + strings.Repeat("long-slug-", 12),
+		strings.Repeat("x", 400),
+	} {
+		c, err := Encode(text)
+		if err != nil {
+			t.Fatalf("Encode(%d bytes): %v", len(text), err)
+		}
+		if got := decode(t, c); got != text {
+			t.Errorf("decode(Encode(%q)) = %q", text, got)
+		}
+	}
+	if _, err := Encode(strings.Repeat("x", 2332)); err != ErrTooLong {
+		t.Errorf("oversized text: err = %v, want ErrTooLong", err)
+	}
+}
+
+func TestSVG(t *testing.T) {
+	c, err := Encode("go/wiki")
+	if err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+	svg := c.SVG()
+	if !strings.HasPrefix(svg, `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 29 29"`) {
+		t.Errorf("SVG header = %.80q", svg)
+	}
+	// The top-left finder's corner module sits just inside the quiet zone.
+	if !strings.Contains(svg, "M4,4h1v1h-1z") {
+		t.Error("SVG missing the finder pattern's corner module")
+	}
+}
+
+// decode reads c back the way a scanner would once the grid is sampled:
+// format information, unmasking, zigzag read-out, de-interleaving, an ECC
+// syndrome check, and byte-mode parsing.
+func decode(t *testing.T, c *Code) string {
+	t.Helper()
+	var format int
+	for i := 14; i >= 9; i-- {
+		format = format<<1 | bit(c.Module(14-i, 8))
+	}
+	format = format<<1 | bit(c.Module(7, 8))
+	format = format<<1 | bit(c.Module(8, 8))
+	format = format<<1 | bit(c.Module(8, 7))
+	for i := 5; i >= 0; i-- {
+		format = format<<1 | bit(c.Module(8, i))
+	}
+	mask := -1
+	for m := range 8 {
+		if formatBits(m) == format {
+			mask = m
+		}
+	}
+	if mask < 0 {
+		t.Fatalf("format bits %015b name no level M mask", format)
+	}
+
+	// Rebuild the function-module map for the version, then unmask a copy.
+	ref := newCode(c.Version)
+	ref.drawFunctionPatterns()
+	for y := range c.Size {
+		copy(ref.modules[y], c.modules[y])
+	}
+	ref.applyMask(mask)
+
+	raw := make([]byte, numRawDataModules(c.Version)/8)
+	i := 0
+	for right := c.Size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+		for vert := range c.Size {
+			for j := range 2 {
+				x, y := right-j, vert
+				if (right+1)&2 == 0 {
+					y = c.Size - 1 - vert
+				}
+				if !ref.isFunction[y][x] && i < len(raw)*8 {
+					raw[i>>3] |= byte(bit(ref.modules[y][x])) << (7 - i&7)
+					i++
+				}
+			}
+		}
+	}
+
+	blocks, blockECC := numBlocks[c.Version], eccPerBlock[c.Version]
+	numShort := blocks - len(raw)%blocks
+	shortLen := len(raw) / blocks
+	all := make([][]byte, blocks)
+	k := 0
+	for col := 0; col <= shortLen; col++ {
+		for b := range blocks {
+			if col == shortLen-blockECC && b < numShort {
+				continue // short blocks have no codeword here
+			}
+			all[b] = append(all[b], raw[k])
+			k++
+		}
+	}
+	var data []byte
+	for b, block := range all {
+		if !syndromesZero(block, blockECC) {
+			t.Fatalf("block %d fails its ECC check", b)
+		}
+		data = append(data, block[:len(block)-blockECC]...)
+	}
+
+	var bits []int
+	for _, b := range data {
+		for j := 7; j >= 0; j-- {
+			bits = append(bits, int(b>>j)&1)
+		}
+	}
+	read := func(n int) int {
+		v := 0
+		for range n {
+			v, bits = v<<1|bits[0], bits[1:]
+		}
+		return v
+	}
+	if mode := read(4); mode != 0x4 {
+		t.Fatalf("mode = %#x, want byte mode", mode)
+	}
+	out := make([]byte, read(countBits(c.Version)))
+	for j := range out {
+		out[j] = byte(read(8))
+	}
+	return string(out)
+}
+
+// syndromesZero evaluates block, highest-order coefficient first, at α^0
+// through α^(ecc-1); every result is zero for an intact codeword.
+func syndromesZero(block []byte, ecc int) bool {
+	alpha := byte(1)
+	for range ecc {
+		var s byte
+		for _, b := range block {
+			s = gfMul(s, alpha) ^ b
+		}
+		if s != 0 {
+			return false
+		}
+		alpha = gfMul(alpha, 2)
+	}
+	return true
+}
+
+func bit(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,72 @@
+// Governing: SPEC-0001 REQ "Encrypted Secrets"
+// Package secrets encrypts values the server stores but must not keep in
+// plaintext, such as OIDC refresh tokens and SMTP or LLM credentials.
+package secrets
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+)
+
+var (
+	// ErrNoKey is returned by New when no encryption key is configured.
+	ErrNoKey = errors.New("no encryption key configured")
+	// ErrInvalid is returned by Open when a value cannot be decrypted,
+	// because it is malformed, was tampered with, or was sealed with
+	// another key.
+	ErrInvalid = errors.New("sealed value invalid")
+)
+
+// Box seals and opens values with AES-256-GCM. It is safe for concurrent use.
+type Box struct {
+	aead cipher.AEAD
+}
+
+// New returns a Box whose AES-256 key is derived from key via SHA-256, so
+// any passphrase works. Changing key makes previously sealed values
+// unreadable.
+func New(key string) (*Box, error) {
+	if key == "" {
+		return nil, ErrNoKey
+	}
+	sum := sha256.Sum256([]byte(key))
+	block, err := aes.NewCipher(sum[:])
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &Box{aead: aead}, nil
+}
+
+// Seal encrypts plaintext and returns base64(nonce || ciphertext). Each call
+// uses a fresh random nonce, so sealing the same value twice gives different
+// results.
+func (b *Box) Seal(plaintext string) (string, error) {
+	nonce := make([]byte, b.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	out := b.aead.Seal(nonce, nonce, []byte(plaintext), nil)
+	return base64.RawStdEncoding.EncodeToString(out), nil
+}
+
+// Open reverses Seal.
+func (b *Box) Open(sealed string) (string, error) {
+	raw, err := base64.RawStdEncoding.DecodeString(sealed)
+	if err != nil || len(raw) < b.aead.NonceSize() {
+		return "", ErrInvalid
+	}
+	nonce, ct := raw[:b.aead.NonceSize()], raw[b.aead.NonceSize():]
+	pt, err := b.aead.Open(nil, nonce, ct, nil)
+	if err != nil {
+		return "", ErrInvalid
+	}
+	return string(pt), nil
+}
@@ -0,0 +1,38 @@
+package secrets
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestBox(t *testing.T) {
+	if _, err := New(""); !errors.Is(err, ErrNoKey) {
+		t.Fatalf("New(\"\") error = %v, want ErrNoKey", err)
+	}
+	b, err := New("test-key")
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	sealed, err := b.Seal("hunter2")
+	if err != nil {
+		t.Fatalf("Seal: %v", err)
+	}
+	if again, _ := b.Seal("hunter2"); again == sealed {
+		t.Error("sealing the same value twice gave the same result")
+	}
+	if got, err := b.Open(sealed); err != nil || got != "hunter2" {
+		t.Errorf("Open = %q, %v; want %q", got, err, "hunter2")
+	}
+
+	other, _ := New("other-key")
+	if _, err := other.Open(sealed); !errors.Is(err, ErrInvalid) {
+		t.Errorf("Open with another key: error = %v, want ErrInvalid", err)
+	}
+	tampered := []byte(sealed)
+	tampered[len(tampered)-1] ^= 1
+	for _, v := range []string{string(tampered), "not base64!", ""} {
+		if _, err := b.Open(v); !errors.Is(err, ErrInvalid) {
+			t.Errorf("Open(%q): error = %v, want ErrInvalid", v, err)
+		}
+	}
+}
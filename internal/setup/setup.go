@@ -0,0 +1,168 @@
+// Package setup implements the first-run setup shared by the /setup wizard
+// and `joe-links init`: choosing the admin, checking the identity provider,
+// adding a keyword, and creating demo links. Each step is idempotent, so
+// either path can be re-run or mixed with the other.
+// Governing: SPEC-0001 REQ "First-Run Setup"
+package setup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/mail"
+	"regexp"
+	"strings"
+	"time"
+
+	gooidc "github.com/coreos/go-oidc/v3/oidc"
+	"github.com/joestump/joe-links/internal/config"
+	"github.com/joestump/joe-links/internal/store"
+)
+
+var (
+	// ErrInvalidEmail is returned by SetAdminEmail for a malformed address.
+	ErrInvalidEmail = errors.New("admin email is not a valid address")
+	// ErrInvalidKeyword is returned by AddKeyword for a malformed keyword.
+	ErrInvalidKeyword = errors.New("keyword must be lowercase letters, digits, and hyphens, starting with a letter")
+	// ErrKeywordTemplate is returned by AddKeyword when the URL template has
+	// no {slug} placeholder.
+	ErrKeywordTemplate = errors.New("keyword URL template must contain {slug}")
+)
+
+// keywordRE matches the keywords the admin keywords screen accepts.
+var keywordRE = regexp.MustCompile(`^[a-z][a-z0-9\-]*$`)
+
+// DemoLink is a link created by the "demo links" step.
+type DemoLink struct {
+	Slug, URL, Title, Description string
+}
+
+// DemoLinks show a static link, a path variable, and a query variable.
+var DemoLinks = []DemoLink{
+	{"joe-links", "https://github.com/joestump/joe-links", "Joe Links", "Source code and documentation for this server."},
+	{"wiki", "https://en.wikipedia.org/wiki/$topic", "Wikipedia", "Try wiki/Go_(programming_language) — $topic fills in the article."},
+	{"search", "https://duckduckgo.com/?q=$q:q", "Search", "Try search?q=go+links — query variables fill in from ?q=."},
+}
+
+// Service runs the setup steps against the database.
+type Service struct {
+	settings *store.SettingsStore
+	users    *store.UserStore
+	links    *store.LinkStore
+	keywords *store.KeywordStore
+}
+
+// New creates a new Service.
+func New(ss *store.SettingsStore, us *store.UserStore, ls *store.LinkStore, ks *store.KeywordStore) *Service {
+	return &Service{settings: ss, users: us, links: ls, keywords: ks}
+}
+
+// Pending reports whether setup has not finished yet. Databases that had
+// users before the wizard existed are marked finished by their migration.
+func (s *Service) Pending(ctx context.Context) (bool, error) {
+	_, err := s.settings.Get(ctx, store.SettingSetupCompleted)
+	if errors.Is(err, store.ErrNotFound) {
+		return true, nil
+	}
+	return false, err
+}
+
+// Complete marks setup as finished, after which /setup is no longer served.
+func (s *Service) Complete(ctx context.Context) error {
+	return s.settings.Set(ctx, store.SettingSetupCompleted, time.Now().UTC().Format(time.RFC3339))
+}
+
+// AdminEmail returns the admin email chosen during setup, or "" if none was.
+func (s *Service) AdminEmail(ctx context.Context) string {
+	email, err := s.settings.Get(ctx, store.SettingAdminEmail)
+	if err != nil {
+		return ""
+	}
+	return email
+}
+
+// SetAdminEmail chooses the account that becomes admin when it signs in.
+func (s *Service) SetAdminEmail(ctx context.Context, email string) error {
+	email = strings.TrimSpace(email)
+	if addr, err := mail.ParseAddress(email); err != nil || addr.Address != email {
+		return ErrInvalidEmail
+	}
+	return s.settings.Set(ctx, store.SettingAdminEmail, email)
+}
+
+// HasAdmin reports whether any user has the admin role.
+func (s *Service) HasAdmin(ctx context.Context) (bool, error) {
+	users, err := s.users.ListAll(ctx)
+	if err != nil {
+		return false, err
+	}
+	for _, u := range users {
+		if u.IsAdmin() {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// AddKeyword creates a keyword unless one with that name already exists,
+// reporting whether it was created.
+func (s *Service) AddKeyword(ctx context.Context, keyword, urlTemplate, description string) (bool, error) {
+	keyword = strings.TrimSpace(keyword)
+	urlTemplate = strings.TrimSpace(urlTemplate)
+	if !keywordRE.MatchString(keyword) {
+		return false, ErrInvalidKeyword
+	}
+	if !strings.Contains(urlTemplate, "{slug}") {
+		return false, ErrKeywordTemplate
+	}
+	if existing, _ := s.keywords.GetByKeyword(ctx, keyword); existing != nil {
+		return false, nil
+	}
+	if _, err := s.keywords.Create(ctx, keyword, urlTemplate, strings.TrimSpace(description)); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// AddDemoLinks creates the DemoLinks owned by ownerID, skipping slugs that
+// are already taken, and returns how many it created.
+func (s *Service) AddDemoLinks(ctx context.Context, ownerID string) (int, error) {
+	n := 0
+	for _, d := range DemoLinks {
+		if _, err := s.links.GetBySlug(ctx, d.Slug); err == nil {
+			continue
+		}
+		if _, err := s.links.Create(ctx, d.Slug, d.URL, ownerID, d.Title, d.Description, "public"); err != nil {
+			return n, fmt.Errorf("demo link %s: %w", d.Slug, err)
+		}
+		n++
+	}
+	return n, nil
+}
+
+// CheckIdentityProvider fetches the configured provider's OIDC discovery
+// document or SAML metadata, returning an error describing what failed.
+func CheckIdentityProvider(ctx context.Context, cfg *config.Config) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	if cfg.AuthProvider == "saml" {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, cfg.SAML.IDPMetadataURL, nil)
+		if err != nil {
+			return err
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return fmt.Errorf("SAML metadata %s: %w", cfg.SAML.IDPMetadataURL, err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("SAML metadata %s: %s", cfg.SAML.IDPMetadataURL, resp.Status)
+		}
+		return nil
+	}
+	if _, err := gooidc.NewProvider(ctx, cfg.OIDC.Issuer); err != nil {
+		return fmt.Errorf("OIDC discovery for %s: %w", cfg.OIDC.Issuer, err)
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package setup_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/joestump/joe-links/internal/setup"
+	"github.com/joestump/joe-links/internal/store"
+	"github.com/joestump/joe-links/internal/testutil"
+)
+
+func newService(t *testing.T) (*setup.Service, *store.UserStore, *store.LinkStore) {
+	t.Helper()
+	db := testutil.NewTestDB(t)
+	us := store.NewUserStore(db)
+	ls := store.NewLinkStore(db, store.NewOwnershipStore(db), store.NewTagStore(db))
+	return setup.New(store.NewSettingsStore(db), us, ls, store.NewKeywordStore(db)), us, ls
+}
+
+func TestPendingUntilComplete(t *testing.T) {
+	svc, _, _ := newService(t)
+	ctx := context.Background()
+
+	if pending, err := svc.Pending(ctx); err != nil || !pending {
+		t.Fatalf("Pending on an empty database = %v, %v; want true", pending, err)
+	}
+	if err := svc.Complete(ctx); err != nil {
+		t.Fatalf("Complete: %v", err)
+	}
+	if pending, err := svc.Pending(ctx); err != nil || pending {
+		t.Errorf("Pending after Complete = %v, %v; want false", pending, err)
+	}
+}
+
+func TestSetAdminEmail(t *testing.T) {
+	svc, _, _ := newService(t)
+	ctx := context.Background()
+
+	if got := svc.AdminEmail(ctx); got != "" {
+		t.Errorf("AdminEmail before setup = %q, want empty", got)
+	}
+	for _, bad := range []string{"", "admin", "Admin <admin@example.com>"} {
+		if err := svc.SetAdminEmail(ctx, bad); !errors.Is(err, setup.ErrInvalidEmail) {
+			t.Errorf("SetAdminEmail(%q) = %v, want ErrInvalidEmail", bad, err)
+		}
+	}
+	if err := svc.SetAdminEmail(ctx, "first@example.com"); err != nil {
+		t.Fatalf("SetAdminEmail: %v", err)
+	}
+	if err := svc.SetAdminEmail(ctx, " admin@example.com "); err != nil {
+		t.Fatalf("SetAdminEmail: %v", err)
+	}
+	if got := svc.AdminEmail(ctx); got != "admin@example.com" {
+		t.Errorf("AdminEmail = %q, want admin@example.com", got)
+	}
+}
+
+func TestAddKeywordAndDemoLinks(t *testing.T) {
+	svc, us, ls := newService(t)
+	ctx := context.Background()
+
+	if _, err := svc.AddKeyword(ctx, "Jira", "https://jira.example.com/browse/{slug}", ""); !errors.Is(err, setup.ErrInvalidKeyword) {
+		t.Errorf("AddKeyword(Jira) = %v, want ErrInvalidKeyword", err)
+	}
+	if _, err := svc.AddKeyword(ctx, "jira", "https://jira.example.com/browse/", ""); !errors.Is(err, setup.ErrKeywordTemplate) {
+		t.Errorf("AddKeyword without {slug} = %v, want ErrKeywordTemplate", err)
+	}
+	for i, want := range []bool{true, false} {
+		created, err := svc.AddKeyword(ctx, "jira", "https://jira.example.com/browse/{slug}", "Jira")
+		if err != nil || created != want {
+			t.Errorf("AddKeyword #%d = %v, %v; want %v", i+1, created, err, want)
+		}
+	}
+
+	admin, err := us.Upsert(ctx, "test", "sub1", "admin@example.com", "Admin", "admin")
+	if err != nil {
+		t.Fatalf("seed user: %v", err)
+	}
+	if n, err := svc.AddDemoLinks(ctx, admin.ID); err != nil || n != len(setup.DemoLinks) {
+		t.Fatalf("AddDemoLinks = %d, %v; want %d", n, err, len(setup.DemoLinks))
+	}
+	if n, err := svc.AddDemoLinks(ctx, admin.ID); err != nil || n != 0 {
+		t.Errorf("AddDemoLinks again = %d, %v; want 0", n, err)
+	}
+	for _, d := range setup.DemoLinks {
+		if _, err := ls.GetBySlug(ctx, d.Slug); err != nil {
+			t.Errorf("demo link %s: %v", d.Slug, err)
+		}
+	}
+	if hasAdmin, err := svc.HasAdmin(ctx); err != nil || !hasAdmin {
+		t.Errorf("HasAdmin = %v, %v; want true", hasAdmin, err)
+	}
+}
@@ -0,0 +1,54 @@
+package shareurl_test
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/joestump/joe-links/internal/shareurl"
+)
+
+func TestSignVerify(t *testing.T) {
+	s := shareurl.NewSigner([]byte("test key"))
+	now := time.Now()
+	q, expires, err := s.Sign("link-1", time.Hour, now)
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+	if want := now.Add(time.Hour).Truncate(time.Second); !expires.Equal(want) {
+		t.Errorf("expires = %v, want %v", expires, want)
+	}
+
+	extended := url.Values{shareurl.ExpParam: {"99999999999"}, shareurl.SigParam: {q.Get(shareurl.SigParam)}}
+	cases := []struct {
+		name   string
+		signer *shareurl.Signer
+		linkID string
+		query  url.Values
+		now    time.Time
+		want   bool
+	}{
+		{"valid", s, "link-1", q, now, true},
+		{"expired", s, "link-1", q, now.Add(2 * time.Hour), false},
+		{"other link", s, "link-2", q, now, false},
+		{"other key", shareurl.NewSigner([]byte("other key")), "link-1", q, now, false},
+		{"extended expiry", s, "link-1", extended, now, false},
+		{"unsigned", s, "link-1", url.Values{}, now, false},
+		{"nil signer", nil, "link-1", q, now, false},
+	}
+	for _, tt := range cases {
+		if got := tt.signer.Verify(tt.linkID, tt.query, tt.now); got != tt.want {
+			t.Errorf("%s: Verify = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestSignRejectsInvalidTTL(t *testing.T) {
+	s := shareurl.NewSigner([]byte("test key"))
+	for _, ttl := range []time.Duration{0, -time.Minute, shareurl.MaxTTL + time.Second} {
+		if _, _, err := s.Sign("link-1", ttl, time.Now()); !errors.Is(err, shareurl.ErrInvalidTTL) {
+			t.Errorf("Sign(%v) error = %v, want ErrInvalidTTL", ttl, err)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+// Governing: SPEC-0016 REQ "Status Page"
+// Package status summarizes server health for the public status page and
+// GET /api/v1/status: database reachability and latency, click queue depth,
+// and how recently each background job last succeeded.
+package status
+
+import (
+	"context"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/joestump/joe-links/internal/build"
+	"github.com/joestump/joe-links/internal/metrics"
+)
+
+// Overall and per-component states, from best to worst.
+const (
+	OK       = "ok"
+	Degraded = "degraded"
+	Down     = "down"
+)
+
+// staleAfter is how many intervals a job may miss before it is reported stale.
+const staleAfter = 3
+
+// pingTimeout bounds the database check so a hung database reports down
+// instead of hanging the status page.
+const pingTimeout = 2 * time.Second
+
+// queueHighWater is the fraction of click queue capacity at which the queue
+// is reported degraded: near it, new clicks start being dropped.
+const queueHighWater = 0.9
+
+// Job is a background job that reports its successful runs with
+// metrics.MarkJobSuccess and is expected to run every Interval.
+type Job struct {
+	Name     string
+	Interval time.Duration
+}
+
+// Checker produces status reports.
+type Checker struct {
+	db      *sqlx.DB
+	jobs    []Job
+	started time.Time
+	now     func() time.Time
+}
+
+// NewChecker creates a Checker for db and the background jobs running in
+// this process. Jobs count as fresh from the time it is created until their
+// first run is overdue.
+func NewChecker(db *sqlx.DB, jobs ...Job) *Checker {
+	return &Checker{db: db, jobs: jobs, started: time.Now(), now: time.Now}
+}
+
+// Report is a point-in-time server status summary.
+type Report struct {
+	Status    string
+	Version   string
+	CheckedAt time.Time
+	Uptime    time.Duration
+	Database  DatabaseStatus
+	Queue     QueueStatus
+	Jobs      []JobStatus
+}
+
+// DatabaseStatus reports whether the database answered a ping, and how fast.
+type DatabaseStatus struct {
+	Status  string
+	Latency time.Duration
+}
+
+// QueueStatus reports the in-memory click queue's fill level.
+type QueueStatus struct {
+	Status   string
+	Depth    int
+	Capacity int
+}
+
+// JobStatus reports when a background job last succeeded. LastSuccess is
+// zero when it has not succeeded since the process started.
+type JobStatus struct {
+	Name        string
+	Status      string
+	Interval    time.Duration
+	LastSuccess time.Time
+}
+
+// Check builds a report. The overall status is Down when the database is
+// unreachable, Degraded when the click queue is nearly full or a job is
+// stale, and OK otherwise. Errors are not included: the report is public.
+func (c *Checker) Check(ctx context.Context) Report {
+	now := c.now()
+	r := Report{
+		Status:    OK,
+		Version:   build.Version,
+		CheckedAt: now.UTC(),
+		Uptime:    now.Sub(c.started).Truncate(time.Second),
+	}
+
+	r.Database.Status = OK
+	pingCtx, cancel := context.WithTimeout(ctx, pingTimeout)
+	start := time.Now()
+	if err := c.db.PingContext(pingCtx); err != nil {
+		r.Database.Status = Down
+	}
+	r.Database.Latency = time.Since(start)
+	cancel()
+
+	r.Queue.Status = OK
+	r.Queue.Depth, r.Queue.Capacity = metrics.ClickQueue()
+	if r.Queue.Capacity > 0 && float64(r.Queue.Depth) >= queueHighWater*float64(r.Queue.Capacity) {
+		r.Queue.Status = Degraded
+	}
+
+	for _, j := range c.jobs {
+		js := JobStatus{Name: j.Name, Status: OK, Interval: j.Interval}
+		last := c.started
+		if t, ok := metrics.JobLastSuccessAt(j.Name); ok {
+			js.LastSuccess = t.UTC()
+			last = t
+		}
+		if now.Sub(last) > staleAfter*j.Interval {
+			js.Status = Degraded
+		}
+		r.Jobs = append(r.Jobs, js)
+	}
+
+	switch {
+	case r.Database.Status == Down:
+		r.Status = Down
+	case r.Queue.Status != OK:
+		r.Status = Degraded
+	default:
+		for _, js := range r.Jobs {
+			if js.Status != OK {
+				r.Status = Degraded
+			}
+		}
+	}
+	return r
+}
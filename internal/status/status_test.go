@@ -0,0 +1,49 @@
+package status
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/joestump/joe-links/internal/metrics"
+	"github.com/joestump/joe-links/internal/testutil"
+)
+
+func TestCheck(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	c := NewChecker(db, Job{Name: "test_fresh", Interval: time.Minute}, Job{Name: "test_stale", Interval: time.Minute})
+
+	r := c.Check(context.Background())
+	if r.Status != OK || r.Database.Status != OK {
+		t.Fatalf("fresh checker status = %q, database %q; want ok", r.Status, r.Database.Status)
+	}
+	for _, j := range r.Jobs {
+		if j.Status != OK || !j.LastSuccess.IsZero() {
+			t.Errorf("job %s before its first run = %+v; want ok with no last success", j.Name, j)
+		}
+	}
+
+	// An hour after startup, only the job that reported a run is fresh.
+	metrics.MarkJobSuccess("test_fresh")
+	c.started = time.Now().Add(-time.Hour)
+	r = c.Check(context.Background())
+	if r.Status != Degraded {
+		t.Errorf("status with a stale job = %q; want degraded", r.Status)
+	}
+	got := map[string]string{}
+	for _, j := range r.Jobs {
+		got[j.Name] = j.Status
+	}
+	if got["test_fresh"] != OK || got["test_stale"] != Degraded {
+		t.Errorf("job statuses = %v; want test_fresh ok, test_stale degraded", got)
+	}
+}
+
+func TestCheck_DatabaseDown(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	_ = db.Close()
+	r := NewChecker(db).Check(context.Background())
+	if r.Status != Down || r.Database.Status != Down {
+		t.Errorf("status with a closed database = %q, database %q; want down", r.Status, r.Database.Status)
+	}
+}
@@ -0,0 +1,179 @@
+// Governing: SPEC-0005 REQ "Personal Data Export", REQ "Self-Service Account Deletion"
+package store
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Link dispositions for DeleteAccount.
+const (
+	AccountLinksDelete   = "delete"   // delete the links the user is primary owner of
+	AccountLinksTransfer = "transfer" // make another user their primary owner
+)
+
+// ErrLastAdmin is returned when the only admin tries to delete their account.
+var ErrLastAdmin = errors.New("cannot delete the last admin")
+
+// UserShare is a share the user received or granted, with the link's slug.
+type UserShare struct {
+	LinkID    string    `db:"link_id"`
+	Slug      string    `db:"slug"`
+	UserID    string    `db:"user_id"`
+	SharedBy  string    `db:"shared_by"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+// ListSharesInvolving returns the shares userID received or granted, oldest
+// first.
+func (s *UserStore) ListSharesInvolving(ctx context.Context, userID string) ([]UserShare, error) {
+	var shares []UserShare
+	err := s.db.SelectContext(ctx, &shares, s.q(`
+		SELECT ls.link_id, l.slug, ls.user_id, ls.shared_by, ls.created_at
+		FROM link_shares ls
+		JOIN links l ON l.id = ls.link_id
+		WHERE ls.user_id = ? OR ls.shared_by = ?
+		ORDER BY ls.created_at ASC
+	`), userID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return shares, nil
+}
+
+// UserClick is a click attributed to a signed-in user.
+type UserClick struct {
+	LinkID    string    `db:"link_id"`
+	Slug      string    `db:"slug"`
+	ClickedAt time.Time `db:"clicked_at"`
+	Referrer  string    `db:"referrer"`
+	UserAgent string    `db:"user_agent"`
+	Country   string    `db:"country"`
+	Source    string    `db:"source"`
+}
+
+// ListClicksByUser returns every click attributed to userID, oldest first,
+// including bots and excluded referrers.
+func (s *ClickStore) ListClicksByUser(ctx context.Context, userID string) ([]UserClick, error) {
+	var clicks []UserClick
+	err := s.db.SelectContext(ctx, &clicks, s.q(`
+		SELECT c.link_id, l.slug, c.clicked_at,
+		       COALESCE(c.referrer, '') AS referrer,
+		       COALESCE(c.user_agent, '') AS user_agent,
+		       c.country, c.source
+		FROM link_clicks c
+		JOIN links l ON l.id = c.link_id
+		WHERE c.user_id = ?
+		ORDER BY c.clicked_at ASC
+	`), userID)
+	if err != nil {
+		return nil, err
+	}
+	return clicks, nil
+}
+
+// DeleteAccount deletes userID at their own request. The links they are
+// primary owner of are deleted (AccountLinksDelete) or handed to transferTo
+// (AccountLinksTransfer). Their tokens, usage, saved searches, preferences,
+// co-ownerships, and received shares are removed, and their clicks become
+// anonymous. Shares they granted on surviving links are re-attributed to each
+// link's primary owner. The statements do not rely on foreign key cascades, so
+// personal data is erased on every driver. Returns ErrLastAdmin if userID is
+// the only admin.
+func (s *UserStore) DeleteAccount(ctx context.Context, userID, linkAction, transferTo string) error {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var role string
+	if err := tx.GetContext(ctx, &role, tx.Rebind(`SELECT role FROM users WHERE id = ?`), userID); err != nil {
+		return err
+	}
+	if role == "admin" {
+		var admins int
+		if err := tx.GetContext(ctx, &admins, `SELECT COUNT(*) FROM users WHERE role = 'admin'`); err != nil {
+			return err
+		}
+		if admins <= 1 {
+			return ErrLastAdmin
+		}
+	}
+
+	var primary []string
+	if err := tx.SelectContext(ctx, &primary, tx.Rebind(`SELECT link_id FROM link_owners WHERE user_id = ? AND is_primary = 1`), userID); err != nil {
+		return err
+	}
+	switch linkAction {
+	case AccountLinksTransfer:
+		// The recipient may already co-own some of the links.
+		_, err = tx.ExecContext(ctx, tx.Rebind(`
+			DELETE FROM link_owners WHERE user_id = ? AND is_primary = 0 AND link_id IN (
+				SELECT link_id FROM (SELECT link_id FROM link_owners WHERE user_id = ? AND is_primary = 1) AS p
+			)`), transferTo, userID)
+		if err != nil {
+			return err
+		}
+		_, err = tx.ExecContext(ctx, tx.Rebind(`UPDATE link_owners SET user_id = ? WHERE user_id = ? AND is_primary = 1`), transferTo, userID)
+		if err != nil {
+			return err
+		}
+	default:
+		for _, id := range primary {
+			for _, stmt := range []string{
+				`DELETE FROM link_shares WHERE link_id = ?`,
+				`DELETE FROM link_team_shares WHERE link_id = ?`,
+				`DELETE FROM link_team_owners WHERE link_id = ?`,
+				`DELETE FROM link_owners WHERE link_id = ?`,
+				`DELETE FROM link_tags WHERE link_id = ?`,
+				`DELETE FROM link_aliases WHERE link_id = ?`,
+				`DELETE FROM links WHERE id = ?`,
+			} {
+				if _, err := tx.ExecContext(ctx, tx.Rebind(stmt), id); err != nil {
+					return err
+				}
+			}
+		}
+		// Governing: SPEC-0002 REQ "Full-Text Link Search"
+		if err := reindexLinks(ctx, tx, primary...); err != nil {
+			return err
+		}
+	}
+
+	for _, stmt := range []string{
+		`DELETE FROM link_owners WHERE user_id = ?`,
+		`DELETE FROM link_shares WHERE user_id = ?`,
+		`DELETE FROM team_members WHERE user_id = ?`,
+		`DELETE FROM api_tokens WHERE user_id = ?`,
+		`DELETE FROM api_usage_daily WHERE user_id = ?`,
+		`DELETE FROM saved_searches WHERE user_id = ?`,
+		`DELETE FROM user_preferences WHERE user_id = ?`,
+		`UPDATE link_clicks SET user_id = NULL WHERE user_id = ?`,
+	} {
+		if _, err := tx.ExecContext(ctx, tx.Rebind(stmt), userID); err != nil {
+			return err
+		}
+	}
+	_, err = tx.ExecContext(ctx, tx.Rebind(`
+		UPDATE link_shares SET shared_by = COALESCE(
+			(SELECT lo.user_id FROM link_owners lo WHERE lo.link_id = link_shares.link_id AND lo.is_primary = 1),
+			user_id
+		) WHERE shared_by = ?`), userID)
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, tx.Rebind(`
+		UPDATE link_team_shares SET shared_by = COALESCE(
+			(SELECT lo.user_id FROM link_owners lo WHERE lo.link_id = link_team_shares.link_id AND lo.is_primary = 1),
+			shared_by
+		) WHERE shared_by = ?`), userID)
+	if err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, tx.Rebind(`DELETE FROM users WHERE id = ?`), userID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
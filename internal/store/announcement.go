@@ -0,0 +1,67 @@
+// Governing: SPEC-0004 REQ "Announcement Banner"
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// SettingAnnouncement holds the current Announcement, JSON-encoded.
+// Governing: SPEC-0004 REQ "Announcement Banner"
+const SettingAnnouncement = "announcement"
+
+// Announcement levels, which pick the banner's color.
+const (
+	AnnouncementInfo    = "info"
+	AnnouncementWarning = "warning"
+	AnnouncementError   = "error"
+)
+
+// Announcement is a banner admins publish across dashboard and public pages.
+type Announcement struct {
+	// ID changes whenever the message does, so a new message is shown again
+	// to users who dismissed the previous one.
+	ID       string     `json:"id"`
+	Message  string     `json:"message"`
+	Level    string     `json:"level"`
+	StartsAt *time.Time `json:"starts_at,omitempty"` // nil shows it immediately
+	EndsAt   *time.Time `json:"ends_at,omitempty"`   // nil shows it until cleared
+}
+
+// ActiveAt reports whether the announcement is shown at t.
+func (a *Announcement) ActiveAt(t time.Time) bool {
+	if a.StartsAt != nil && t.Before(*a.StartsAt) {
+		return false
+	}
+	return a.EndsAt == nil || t.Before(*a.EndsAt)
+}
+
+// Announcement returns the current announcement, or nil when none is set.
+func (s *SettingsStore) Announcement(ctx context.Context) (*Announcement, error) {
+	raw, err := s.Get(ctx, SettingAnnouncement)
+	if errors.Is(err, ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var a Announcement
+	if err := json.Unmarshal([]byte(raw), &a); err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+// SetAnnouncement replaces the current announcement; nil clears it.
+func (s *SettingsStore) SetAnnouncement(ctx context.Context, a *Announcement) error {
+	if a == nil {
+		return s.Delete(ctx, SettingAnnouncement)
+	}
+	raw, err := json.Marshal(a)
+	if err != nil {
+		return err
+	}
+	return s.Set(ctx, SettingAnnouncement, string(raw))
+}
@@ -0,0 +1,63 @@
+// Governing: SPEC-0003 REQ "Instance Branding"
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+)
+
+// SettingBranding holds the instance's Branding, JSON-encoded.
+// Governing: SPEC-0003 REQ "Instance Branding"
+const SettingBranding = "branding"
+
+// DefaultInstanceName is shown wherever the instance name appears until an
+// admin sets one.
+const DefaultInstanceName = "Joe Links"
+
+// Branding is the admin-chosen appearance of the instance. The zero value
+// is the stock look.
+type Branding struct {
+	InstanceName string       `json:"instance_name,omitempty"`
+	PrimaryColor string       `json:"primary_color,omitempty"` // "#rrggbb", or "" for the theme's own
+	Logo         []byte       `json:"logo,omitempty"`
+	LogoType     string       `json:"logo_type,omitempty"` // media type of Logo
+	FooterLinks  []FooterLink `json:"footer_links,omitempty"`
+}
+
+// FooterLink is a link an admin adds to the footer of every page.
+type FooterLink struct {
+	Label string `json:"label"`
+	URL   string `json:"url"`
+}
+
+// Name returns the instance name, or DefaultInstanceName when unset.
+func (b Branding) Name() string {
+	if b.InstanceName == "" {
+		return DefaultInstanceName
+	}
+	return b.InstanceName
+}
+
+// Branding returns the stored branding, or the zero Branding when none is set.
+func (s *SettingsStore) Branding(ctx context.Context) (Branding, error) {
+	var b Branding
+	raw, err := s.Get(ctx, SettingBranding)
+	if errors.Is(err, ErrNotFound) {
+		return b, nil
+	}
+	if err != nil {
+		return b, err
+	}
+	err = json.Unmarshal([]byte(raw), &b)
+	return b, err
+}
+
+// SetBranding replaces the stored branding.
+func (s *SettingsStore) SetBranding(ctx context.Context, b Branding) error {
+	raw, err := json.Marshal(b)
+	if err != nil {
+		return err
+	}
+	return s.Set(ctx, SettingBranding, string(raw))
+}
@@ -0,0 +1,184 @@
+// Governing: SPEC-0016 REQ "Durable Click Spool", ADR-0016
+package store
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"sync"
+)
+
+// ClickSpool is an append-only file of click events waiting to be written to
+// the database. Events are appended one JSON object per line and fsynced, so
+// queued clicks survive a restart or a database outage. Drain moves the spool
+// aside before inserting, so appends never wait on the database.
+type ClickSpool struct {
+	path string
+
+	mu sync.Mutex
+	f  *os.File
+}
+
+// OpenClickSpool opens (or creates) the spool file at path. Events left by a
+// previous process are kept and delivered by the next Drain.
+func OpenClickSpool(path string) (*ClickSpool, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("open click spool: %w", err)
+	}
+	return &ClickSpool{path: path, f: f}, nil
+}
+
+// drainPath is where Drain moves the spool while its events are inserted.
+func (s *ClickSpool) drainPath() string { return s.path + ".drain" }
+
+// Append durably writes events to the spool.
+func (s *ClickSpool) Append(events ...ClickEvent) error {
+	var buf []byte
+	for _, e := range events {
+		line, err := json.Marshal(e)
+		if err != nil {
+			return err
+		}
+		buf = append(append(buf, line...), '\n')
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := s.f.Write(buf); err != nil {
+		return fmt.Errorf("append click spool: %w", err)
+	}
+	return s.f.Sync()
+}
+
+// Drain passes the spooled events to record, oldest first, in batches of up
+// to ClickBatchSize, and returns how many were written. record reports how
+// many events of the batch it handled. When it lists events in an
+// *UnwrittenClicksError, or handled none of the batch, those events stay
+// spooled, with everything after them, for the next Drain, and the error is
+// returned. Other events of a batch with an error were rejected individually
+// and would fail again, so they are dropped and the error only logged. Lines
+// that cannot be decoded (e.g. a write torn by a crash) are logged and skipped.
+func (s *ClickSpool) Drain(ctx context.Context, record func(context.Context, []ClickEvent) (int, error)) (int, error) {
+	if err := s.rotate(); err != nil {
+		return 0, err
+	}
+	data, err := os.ReadFile(s.drainPath())
+	if errors.Is(err, fs.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("read click spool: %w", err)
+	}
+
+	total := 0
+	var batch []ClickEvent
+	offset := 0
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		n, err := record(ctx, batch)
+		total += n
+		var unwritten *UnwrittenClicksError
+		switch {
+		case errors.As(err, &unwritten):
+			return s.keep(unwritten.Events, data[min(offset, len(data)):], err)
+		case err != nil && n == 0:
+			return s.keep(batch, data[min(offset, len(data)):], err)
+		case err != nil:
+			log.Printf("click spool: dropped %d rejected event(s): %v", len(batch)-n, err)
+		}
+		batch = batch[:0]
+		return nil
+	}
+
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	sc.Buffer(make([]byte, 0, 4096), 1<<20)
+	for sc.Scan() {
+		line := sc.Bytes()
+		offset += len(line) + 1
+		var e ClickEvent
+		if err := json.Unmarshal(line, &e); err != nil {
+			log.Printf("click spool: skipping malformed entry: %v", err)
+			continue
+		}
+		batch = append(batch, e)
+		if len(batch) == ClickBatchSize {
+			if err := flush(); err != nil {
+				return total, err
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		return total, err
+	}
+	if err := os.Remove(s.drainPath()); err != nil {
+		return total, fmt.Errorf("remove drained click spool: %w", err)
+	}
+	return total, nil
+}
+
+// rotate moves a non-empty spool to drainPath and starts a fresh one, unless
+// a previous drain left events there, which must be delivered first.
+func (s *ClickSpool) rotate() error {
+	if _, err := os.Stat(s.drainPath()); err == nil {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	info, err := s.f.Stat()
+	if err != nil {
+		return fmt.Errorf("stat click spool: %w", err)
+	}
+	if info.Size() == 0 {
+		return nil
+	}
+	if err := s.f.Close(); err != nil {
+		return fmt.Errorf("close click spool: %w", err)
+	}
+	if err := os.Rename(s.path, s.drainPath()); err != nil {
+		return fmt.Errorf("rotate click spool: %w", err)
+	}
+	f, err := os.OpenFile(s.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return fmt.Errorf("reopen click spool: %w", err)
+	}
+	s.f = f
+	return nil
+}
+
+// keep rewrites drainPath to hold only the undelivered events followed by
+// rest, the spool lines not yet read, and returns cause annotated for the
+// caller.
+func (s *ClickSpool) keep(events []ClickEvent, rest []byte, cause error) error {
+	var buf []byte
+	for _, e := range events {
+		line, err := json.Marshal(e)
+		if err != nil {
+			return fmt.Errorf("record spooled click: %w (and saving remainder: %v)", cause, err)
+		}
+		buf = append(append(buf, line...), '\n')
+	}
+	buf = append(buf, rest...)
+	tmp := s.drainPath() + ".tmp"
+	if err := os.WriteFile(tmp, buf, 0o600); err != nil {
+		return fmt.Errorf("record spooled click: %w (and saving remainder: %v)", cause, err)
+	}
+	if err := os.Rename(tmp, s.drainPath()); err != nil {
+		return fmt.Errorf("record spooled click: %w (and saving remainder: %v)", cause, err)
+	}
+	return fmt.Errorf("record spooled click: %w", cause)
+}
+
+// Close closes the spool file. Undrained events remain on disk.
+func (s *ClickSpool) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.f.Close()
+}
@@ -0,0 +1,202 @@
+// Governing: SPEC-0016 REQ "Durable Click Spool"
+package store_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/joestump/joe-links/internal/store"
+	"github.com/joestump/joe-links/internal/testutil"
+)
+
+func TestClickSpool_SurvivesReopen(t *testing.T) {
+	cs, _, _, _, linkID := newClickTestEnv(t)
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "clicks.spool")
+
+	spool, err := store.OpenClickSpool(path)
+	if err != nil {
+		t.Fatalf("OpenClickSpool: %v", err)
+	}
+	clickedAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	if err := spool.Append(
+		store.ClickEvent{LinkID: linkID, IPHash: "a", ClickedAt: clickedAt},
+		store.ClickEvent{LinkID: linkID, IPHash: "b", ClickedAt: clickedAt},
+	); err != nil {
+		t.Fatalf("Append: %v", err)
+	}
+	if err := spool.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	// A new process picks up what the previous one left behind.
+	spool, err = store.OpenClickSpool(path)
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	defer spool.Close()
+	n, err := spool.Drain(ctx, cs.RecordClicks)
+	if err != nil {
+		t.Fatalf("Drain: %v", err)
+	}
+	if n != 2 {
+		t.Errorf("drained %d events, want 2", n)
+	}
+
+	recent, err := cs.ListRecentClicks(ctx, linkID, 10)
+	if err != nil {
+		t.Fatalf("ListRecentClicks: %v", err)
+	}
+	if len(recent) != 2 {
+		t.Fatalf("recorded %d clicks, want 2", len(recent))
+	}
+	if !recent[0].ClickedAt.Equal(clickedAt) {
+		t.Errorf("clicked_at = %v, want original time %v", recent[0].ClickedAt, clickedAt)
+	}
+
+	// Nothing is delivered twice.
+	if n, _ := spool.Drain(ctx, cs.RecordClicks); n != 0 {
+		t.Errorf("second drain delivered %d events, want 0", n)
+	}
+}
+
+func TestClickSpool_KeepsEventsOnFailure(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "clicks.spool")
+	spool, err := store.OpenClickSpool(path)
+	if err != nil {
+		t.Fatalf("OpenClickSpool: %v", err)
+	}
+	defer spool.Close()
+	ctx := context.Background()
+
+	for _, id := range []string{"1", "2", "3"} {
+		if err := spool.Append(store.ClickEvent{LinkID: id}); err != nil {
+			t.Fatalf("Append: %v", err)
+		}
+	}
+
+	// The database is down: nothing is written and everything stays spooled.
+	errDown := errors.New("database down")
+	n, err := spool.Drain(ctx, func(context.Context, []store.ClickEvent) (int, error) {
+		return 0, errDown
+	})
+	if !errors.Is(err, errDown) || n != 0 {
+		t.Fatalf("Drain = %d, %v; want 0, %v", n, err, errDown)
+	}
+
+	// Events appended meanwhile queue behind the undelivered ones.
+	if err := spool.Append(store.ClickEvent{LinkID: "4"}); err != nil {
+		t.Fatalf("Append: %v", err)
+	}
+	var got []string
+	record := func(_ context.Context, batch []store.ClickEvent) (int, error) {
+		for _, e := range batch {
+			got = append(got, e.LinkID)
+		}
+		return len(batch), nil
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := spool.Drain(ctx, record); err != nil {
+			t.Fatalf("Drain: %v", err)
+		}
+	}
+	if want := []string{"1", "2", "3", "4"}; strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("delivered %v, want %v", got, want)
+	}
+}
+
+func TestClickSpool_PartialBatchConsumed(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "clicks.spool")
+	spool, err := store.OpenClickSpool(path)
+	if err != nil {
+		t.Fatalf("OpenClickSpool: %v", err)
+	}
+	defer spool.Close()
+	ctx := context.Background()
+	if err := spool.Append(store.ClickEvent{LinkID: "ok"}, store.ClickEvent{LinkID: "deleted"}); err != nil {
+		t.Fatalf("Append: %v", err)
+	}
+
+	// One event is rejected on its own; retrying it would fail forever.
+	n, err := spool.Drain(ctx, func(context.Context, []store.ClickEvent) (int, error) {
+		return 1, errors.New("foreign key violation")
+	})
+	if err != nil || n != 1 {
+		t.Fatalf("Drain = %d, %v; want 1, nil", n, err)
+	}
+	n, _ = spool.Drain(ctx, func(_ context.Context, batch []store.ClickEvent) (int, error) {
+		return len(batch), nil
+	})
+	if n != 0 {
+		t.Errorf("rejected events were redelivered (%d)", n)
+	}
+}
+
+func TestClickSpool_KeepsUnwrittenEventsWhenDatabaseFailsMidBatch(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	cs := store.NewClickStore(db)
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "clicks.spool")
+	spool, err := store.OpenClickSpool(path)
+	if err != nil {
+		t.Fatalf("OpenClickSpool: %v", err)
+	}
+	defer spool.Close()
+	for _, id := range []string{"1", "2", "3"} {
+		if err := spool.Append(store.ClickEvent{LinkID: id}); err != nil {
+			t.Fatalf("Append: %v", err)
+		}
+	}
+
+	// The database goes away once the first click is in: the batch insert
+	// fails, and so does every row after the first.
+	if _, err := db.Exec(`CREATE TRIGGER clicks_down BEFORE INSERT ON link_clicks
+		WHEN (SELECT COUNT(*) FROM link_clicks) >= 1
+		BEGIN SELECT RAISE(ABORT, 'database is unavailable'); END`); err != nil {
+		t.Fatal(err)
+	}
+	n, err := spool.Drain(ctx, cs.RecordClicks)
+	var unwritten *store.UnwrittenClicksError
+	if n != 1 || !errors.As(err, &unwritten) || len(unwritten.Events) != 2 {
+		t.Fatalf("Drain = %d, %v; want 1 written and 2 unwritten", n, err)
+	}
+
+	// Once the database is back, the two unwritten clicks are delivered.
+	if _, err := db.Exec(`DROP TRIGGER clicks_down`); err != nil {
+		t.Fatal(err)
+	}
+	if n, err := spool.Drain(ctx, cs.RecordClicks); n != 2 || err != nil {
+		t.Fatalf("second Drain = %d, %v; want 2, nil", n, err)
+	}
+	var links []string
+	if err := db.Select(&links, `SELECT link_id FROM link_clicks ORDER BY link_id`); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(links, ",") != "1,2,3" {
+		t.Errorf("recorded clicks for %v, want 1,2,3", links)
+	}
+}
+
+func TestClickSpool_SkipsTornWrite(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "clicks.spool")
+	if err := os.WriteFile(path, []byte(`{"LinkID":"1"}`+"\n"+`{"LinkID":`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	spool, err := store.OpenClickSpool(path)
+	if err != nil {
+		t.Fatalf("OpenClickSpool: %v", err)
+	}
+	defer spool.Close()
+
+	n, err := spool.Drain(context.Background(), func(_ context.Context, batch []store.ClickEvent) (int, error) {
+		return len(batch), nil
+	})
+	if err != nil || n != 1 {
+		t.Errorf("Drain = %d, %v; want 1, nil", n, err)
+	}
+}
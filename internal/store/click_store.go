@@ -5,10 +5,13 @@ import (
 	"context"
 	"crypto/sha256"
 	"fmt"
+	"strings"
 	"time"
 
-	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
+	"github.com/joestump/joe-links/internal/events"
+	"github.com/joestump/joe-links/internal/ids"
+	"github.com/joestump/joe-links/internal/metrics"
 )
 
 // ClickEvent represents a single click to be recorded.
@@ -18,6 +21,32 @@ type ClickEvent struct {
 	IPHash    string // caller computes this
 	UserAgent string
 	Referrer  string
+	ClickedAt time.Time // zero = time of insert; set when the event may be spooled
+	Bot       bool      // flagged by the bot filter; Governing: SPEC-0016 REQ "Bot Filtering"
+	// IP is the raw client IP, used only to resolve Country before the event
+	// is queued or spooled; it is never stored.
+	// Governing: SPEC-0016 REQ "GeoIP Country Breakdown"
+	IP      string `json:"-"`
+	Country string // ISO 3166-1 alpha-2 code; empty = unknown
+	Source  string // verified campaign source; empty = none. Governing: SPEC-0016 REQ "Campaign Sources"
+	// KeywordID is set instead of LinkID for a keyword redirect, which is
+	// only counted per day. Governing: SPEC-0016 REQ "Keyword Analytics"
+	KeywordID string `json:",omitempty"`
+}
+
+// CountryCount is the number of clicks from one country; Country is "" for
+// clicks whose country is unknown.
+type CountryCount struct {
+	Country string `db:"country"`
+	Count   int64  `db:"count"`
+}
+
+// SourceCount is the number of clicks from one campaign source; Source is ""
+// for clicks that carried none.
+// Governing: SPEC-0016 REQ "Campaign Sources"
+type SourceCount struct {
+	Source string `db:"source"`
+	Count  int64  `db:"count"`
 }
 
 // ClickStats holds aggregate click counts for a link.
@@ -33,11 +62,23 @@ type RecentClick struct {
 	Referrer    string    `db:"referrer"`
 	UserID      string    `db:"user_id"`
 	DisplayName string    `db:"display_name"`
+	Bot         bool      `db:"bot"`
+	Country     string    `db:"country"`
+	Source      string    `db:"source"`
 }
 
 // ClickStore is the sqlx-backed store for click tracking operations.
 type ClickStore struct {
 	db *sqlx.DB
+	// includeExcluded makes stats queries count clicks from excluded
+	// referrers. Governing: SPEC-0016 REQ "Referrer Exclusion"
+	includeExcluded bool
+	// includeBots makes stats queries count clicks flagged as bots.
+	// Governing: SPEC-0016 REQ "Bot Filtering"
+	includeBots bool
+	// events receives a click event per link clicked, so live dashboards
+	// can refresh their counts. Governing: SPEC-0004 REQ "Live Dashboard Updates"
+	events *events.Broker
 }
 
 // NewClickStore creates a new ClickStore.
@@ -45,14 +86,148 @@ func NewClickStore(db *sqlx.DB) *ClickStore {
 	return &ClickStore{db: db}
 }
 
+// SetEvents publishes recorded clicks to b.
+// Governing: SPEC-0004 REQ "Live Dashboard Updates"
+func (s *ClickStore) SetEvents(b *events.Broker) { s.events = b }
+
+// publishClicks reports one click event per distinct link in batch.
+func (s *ClickStore) publishClicks(batch []ClickEvent) {
+	seen := make(map[string]bool, len(batch))
+	for _, e := range batch {
+		if !seen[e.LinkID] {
+			seen[e.LinkID] = true
+			s.events.Publish(events.Event{Kind: events.Click, LinkID: e.LinkID})
+		}
+	}
+}
+
 // q rebinds ? placeholders to the driver's native format.
 func (s *ClickStore) q(query string) string { return s.db.Rebind(query) }
 
-// RecordClick inserts a click event row.
-// Governing: SPEC-0016 REQ "Click Recording", ADR-0016
+// RecordClick inserts a click event row, unless its referrer is excluded.
+// Governing: SPEC-0016 REQ "Click Recording", REQ "Referrer Exclusion", ADR-0016
 func (s *ClickStore) RecordClick(ctx context.Context, e ClickEvent) error {
-	id := uuid.New().String()
+	kept, excluded, err := s.dropExcluded(ctx, []ClickEvent{e})
+	if err != nil {
+		return err
+	}
+	metrics.ClicksExcludedTotal.Add(float64(excluded))
+	if len(kept) == 0 {
+		return nil
+	}
+	if e.KeywordID != "" {
+		return s.addKeywordClicks(ctx, kept) // Governing: SPEC-0016 REQ "Keyword Analytics"
+	}
+	return s.insertClick(ctx, e)
+}
+
+// insertClick inserts a click event row.
+func (s *ClickStore) insertClick(ctx context.Context, e ClickEvent) error {
+	defer metrics.ObserveDBQuery("click_record", time.Now())
+	_, err := s.db.ExecContext(ctx, s.q(`
+		INSERT INTO link_clicks (id, link_id, user_id, ip_hash, user_agent, referrer, referrer_host, clicked_at, bot, country, source)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`), clickRow(e)...)
+	if err == nil {
+		s.publishClicks([]ClickEvent{e})
+	}
+	return err
+}
+
+// ClickBatchSize is the largest number of click events written by one
+// RecordClicks call from the click writer.
+const ClickBatchSize = 100
+
+// UnwrittenClicksError is returned by RecordClicks when some events were not
+// written for a reason that may pass, such as the database going away.
+// Events lists them, so the caller can retry exactly those.
+type UnwrittenClicksError struct {
+	Events []ClickEvent
+	Err    error
+}
+
+func (e *UnwrittenClicksError) Error() string {
+	return fmt.Sprintf("%d click event(s) not written: %v", len(e.Events), e.Err)
+}
+
+func (e *UnwrittenClicksError) Unwrap() error { return e.Err }
+
+// RecordClicks inserts events with a single multi-row INSERT. If the batch
+// fails (e.g. one event references a link deleted meanwhile), it falls back
+// to inserting the events one by one so a single bad row cannot lose the
+// rest. Events from excluded referrers are dropped first. It returns the
+// number of events handled, written or dropped, and the first error, if any.
+// Events that were neither written nor rejected by a constraint are listed
+// in an *UnwrittenClicksError.
+// Governing: SPEC-0016 REQ "Batched Click Inserts", REQ "Referrer Exclusion", ADR-0016
+func (s *ClickStore) RecordClicks(ctx context.Context, events []ClickEvent) (int, error) {
+	if len(events) == 0 {
+		return 0, nil
+	}
+	events, excluded, err := s.dropExcluded(ctx, events)
+	if err != nil {
+		return 0, &UnwrittenClicksError{Events: events, Err: err}
+	}
+	metrics.ClicksExcludedTotal.Add(float64(excluded))
+	// Governing: SPEC-0016 REQ "Keyword Analytics"
+	events, keywordHits := splitKeywordClicks(events)
+	if len(keywordHits) > 0 {
+		if err := s.addKeywordClicks(ctx, keywordHits); err != nil {
+			return excluded, &UnwrittenClicksError{Events: append(keywordHits, events...), Err: err}
+		}
+		excluded += len(keywordHits)
+	}
+	if len(events) == 0 {
+		return excluded, nil
+	}
+	metrics.ClickBatchSize.Observe(float64(len(events)))
+	start := time.Now()
+	args := make([]any, 0, 11*len(events))
+	rows := make([]string, len(events))
+	for i, e := range events {
+		args = append(args, clickRow(e)...)
+		rows[i] = "(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)"
+	}
+	_, err = s.db.ExecContext(ctx, s.q(`
+		INSERT INTO link_clicks (id, link_id, user_id, ip_hash, user_agent, referrer, referrer_host, clicked_at, bot, country, source)
+		VALUES `+strings.Join(rows, ", ")), args...)
+	metrics.ObserveDBQuery("click_record_batch", start)
+	if err == nil {
+		s.publishClicks(events)
+		return excluded + len(events), nil
+	}
+
+	var firstErr, retryErr error
+	var unwritten []ClickEvent
+	n := excluded
+	for _, e := range events {
+		err := s.insertClick(ctx, e)
+		if err == nil {
+			n++
+			continue
+		}
+		if firstErr == nil {
+			firstErr = err
+		}
+		if !isRowRejectedError(err) {
+			if retryErr == nil {
+				retryErr = err
+			}
+			unwritten = append(unwritten, e)
+		}
+	}
+	if len(unwritten) > 0 {
+		return n, &UnwrittenClicksError{Events: unwritten, Err: retryErr}
+	}
+	return n, firstErr
+}
+
+// clickRow returns the link_clicks column values for e, in insert order.
+func clickRow(e ClickEvent) []any {
 	now := time.Now().UTC()
+	if !e.ClickedAt.IsZero() {
+		now = e.ClickedAt.UTC()
+	}
 
 	// Truncate user_agent to 512 chars, referrer to 2048.
 	ua := e.UserAgent
@@ -68,102 +243,217 @@ func (s *ClickStore) RecordClick(ctx context.Context, e ClickEvent) error {
 	if e.UserID != "" {
 		userID = e.UserID
 	}
-
-	_, err := s.db.ExecContext(ctx, s.q(`
-		INSERT INTO link_clicks (id, link_id, user_id, ip_hash, user_agent, referrer, clicked_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?)
-	`), id, e.LinkID, userID, e.IPHash, ua, ref, now)
-	return err
+	host := referrerHost(e.Referrer)
+	if len(host) > 255 {
+		host = host[:255]
+	}
+	bot := 0
+	if e.Bot {
+		bot = 1
+	}
+	country := e.Country
+	if len(country) > 2 {
+		country = country[:2]
+	}
+	source := e.Source
+	if len(source) > 32 {
+		source = source[:32]
+	}
+	return []any{ids.New(), e.LinkID, userID, e.IPHash, ua, ref, host, now, bot, strings.ToUpper(country), source}
 }
 
-// GetClickStats returns total, 7d, and 30d click counts for a link.
-// Governing: SPEC-0016 REQ "Click Data Schema", ADR-0016
+// GetClickStats returns total, 7d, and 30d click counts for a link, leaving
+// out excluded referrers and bots unless the store includes them.
+// Governing: SPEC-0016 REQ "Click Data Schema", REQ "Referrer Exclusion", REQ "Bot Filtering", ADR-0016
 func (s *ClickStore) GetClickStats(ctx context.Context, linkID string) (ClickStats, error) {
 	var stats ClickStats
 	now := time.Now().UTC()
 	since7d := now.AddDate(0, 0, -7)
 	since30d := now.AddDate(0, 0, -30)
 
-	err := s.db.GetContext(ctx, &stats.Total,
-		s.q(`SELECT COUNT(*) FROM link_clicks WHERE link_id = ?`), linkID)
+	exclude, excludeArgs, err := s.exclusionClause(ctx)
 	if err != nil {
 		return stats, err
 	}
+	exclude += s.botClause()
+	count := func(dest *int64, since time.Time) error {
+		query := `SELECT COUNT(*) FROM link_clicks c WHERE c.link_id = ?`
+		args := []any{linkID}
+		if !since.IsZero() {
+			query += ` AND c.clicked_at >= ?`
+			args = append(args, since)
+		}
+		return s.db.GetContext(ctx, dest, s.q(query+exclude), append(args, excludeArgs...)...)
+	}
 
-	err = s.db.GetContext(ctx, &stats.Last7d,
-		s.q(`SELECT COUNT(*) FROM link_clicks WHERE link_id = ? AND clicked_at >= ?`), linkID, since7d)
-	if err != nil {
+	if err := count(&stats.Total, time.Time{}); err != nil {
 		return stats, err
 	}
-
-	err = s.db.GetContext(ctx, &stats.Last30d,
-		s.q(`SELECT COUNT(*) FROM link_clicks WHERE link_id = ? AND clicked_at >= ?`), linkID, since30d)
-	if err != nil {
+	if err := count(&stats.Last7d, since7d); err != nil {
+		return stats, err
+	}
+	if err := count(&stats.Last30d, since30d); err != nil {
 		return stats, err
 	}
-
 	return stats, nil
 }
 
 // ListRecentClicks returns the most recent N clicks for a link, joining users for display_name.
 // Governing: SPEC-0016 REQ "Click Data Schema", ADR-0016
 func (s *ClickStore) ListRecentClicks(ctx context.Context, linkID string, limit int) ([]RecentClick, error) {
+	return s.ListRecentClicksBefore(ctx, linkID, time.Time{}, limit)
+}
+
+// ListRecentClicksBefore returns clicks for a link strictly before the given time, newest first.
+// If before is zero, returns from the most recent. Excluded referrers and bots
+// are left out unless the store includes them.
+// Governing: SPEC-0016 REQ "REST API Clicks Endpoint", REQ "Referrer Exclusion", REQ "Bot Filtering", ADR-0016
+func (s *ClickStore) ListRecentClicksBefore(ctx context.Context, linkID string, before time.Time, limit int) ([]RecentClick, error) {
+	exclude, excludeArgs, err := s.exclusionClause(ctx)
+	if err != nil {
+		return nil, err
+	}
+	exclude += s.botClause()
+	where := `c.link_id = ?`
+	args := []any{linkID}
+	if !before.IsZero() {
+		where += ` AND c.clicked_at < ?`
+		args = append(args, before)
+	}
+	args = append(append(args, excludeArgs...), limit)
+
 	var clicks []RecentClick
-	err := s.db.SelectContext(ctx, &clicks, s.q(`
+	err = s.db.SelectContext(ctx, &clicks, s.q(`
 		SELECT c.clicked_at,
 		       COALESCE(c.referrer, '') AS referrer,
 		       COALESCE(c.user_id, '') AS user_id,
-		       COALESCE(u.display_name, '') AS display_name
+		       COALESCE(u.display_name, '') AS display_name,
+		       c.bot,
+		       c.country,
+		       c.source
 		FROM link_clicks c
 		LEFT JOIN users u ON u.id = c.user_id
-		WHERE c.link_id = ?
+		WHERE `+where+exclude+`
 		ORDER BY c.clicked_at DESC
 		LIMIT ?
-	`), linkID, limit)
+	`), args...)
 	if err != nil {
 		return nil, err
 	}
 	return clicks, nil
 }
 
-// ListRecentClicksBefore returns clicks for a link strictly before the given time, newest first.
-// If before is zero, returns from the most recent.
-// Governing: SPEC-0016 REQ "REST API Clicks Endpoint", ADR-0016
-func (s *ClickStore) ListRecentClicksBefore(ctx context.Context, linkID string, before time.Time, limit int) ([]RecentClick, error) {
-	var clicks []RecentClick
-	if before.IsZero() {
-		err := s.db.SelectContext(ctx, &clicks, s.q(`
-			SELECT c.clicked_at,
-			       COALESCE(c.referrer, '') AS referrer,
-			       COALESCE(c.user_id, '') AS user_id,
-			       COALESCE(u.display_name, '') AS display_name
-			FROM link_clicks c
-			LEFT JOIN users u ON u.id = c.user_id
-			WHERE c.link_id = ?
-			ORDER BY c.clicked_at DESC
-			LIMIT ?
-		`), linkID, limit)
-		if err != nil {
-			return nil, err
-		}
-		return clicks, nil
+// DailyClicks returns a link's click counts for each of the last days UTC
+// days, oldest first and ending with today, leaving out excluded referrers
+// and bots unless the store includes them. Days are bucketed with plain
+// range comparisons so the query runs unchanged on every driver.
+// Governing: SPEC-0016 REQ "Link Detail Stats Widget"
+func (s *ClickStore) DailyClicks(ctx context.Context, linkID string, days int) ([]int64, error) {
+	if days <= 0 {
+		return nil, nil
 	}
+	exclude, excludeArgs, err := s.exclusionClause(ctx)
+	if err != nil {
+		return nil, err
+	}
+	exclude += s.botClause()
 
-	err := s.db.SelectContext(ctx, &clicks, s.q(`
-		SELECT c.clicked_at,
-		       COALESCE(c.referrer, '') AS referrer,
-		       COALESCE(c.user_id, '') AS user_id,
-		       COALESCE(u.display_name, '') AS display_name
+	start := time.Now().UTC().Truncate(24 * time.Hour).AddDate(0, 0, -(days - 1))
+	cols := make([]string, days)
+	args := make([]any, 0, 2*days+2+len(excludeArgs))
+	for i := range cols {
+		day := start.AddDate(0, 0, i)
+		cols[i] = fmt.Sprintf(`COALESCE(SUM(CASE WHEN c.clicked_at >= ? AND c.clicked_at < ? THEN 1 ELSE 0 END), 0) AS d%d`, i)
+		args = append(args, day, day.AddDate(0, 0, 1))
+	}
+	args = append(append(args, linkID, start), excludeArgs...)
+
+	counts := make([]int64, days)
+	dest := make([]any, days)
+	for i := range counts {
+		dest[i] = &counts[i]
+	}
+	err = s.db.QueryRowxContext(ctx, s.q(`
+		SELECT `+strings.Join(cols, ", ")+`
 		FROM link_clicks c
-		LEFT JOIN users u ON u.id = c.user_id
-		WHERE c.link_id = ? AND c.clicked_at < ?
-		ORDER BY c.clicked_at DESC
+		WHERE c.link_id = ? AND c.clicked_at >= ?`+exclude), args...).Scan(dest...)
+	if err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+// TopCountries returns a link's click counts per country, most clicks first,
+// leaving out excluded referrers and bots unless the store includes them.
+// Clicks of unknown country are grouped under "".
+// Governing: SPEC-0016 REQ "GeoIP Country Breakdown"
+func (s *ClickStore) TopCountries(ctx context.Context, linkID string, limit int) ([]CountryCount, error) {
+	exclude, excludeArgs, err := s.exclusionClause(ctx)
+	if err != nil {
+		return nil, err
+	}
+	exclude += s.botClause()
+	args := append(append([]any{linkID}, excludeArgs...), limit)
+
+	var counts []CountryCount
+	err = s.db.SelectContext(ctx, &counts, s.q(`
+		SELECT c.country, COUNT(*) AS count
+		FROM link_clicks c
+		WHERE c.link_id = ?`+exclude+`
+		GROUP BY c.country
+		ORDER BY count DESC, c.country ASC
 		LIMIT ?
-	`), linkID, before, limit)
+	`), args...)
 	if err != nil {
 		return nil, err
 	}
-	return clicks, nil
+	return counts, nil
+}
+
+// TopSources returns a link's click counts per campaign source, most clicks
+// first, leaving out excluded referrers and bots unless the store includes
+// them. Clicks without a source are grouped under "".
+// Governing: SPEC-0016 REQ "Campaign Sources"
+func (s *ClickStore) TopSources(ctx context.Context, linkID string, limit int) ([]SourceCount, error) {
+	exclude, excludeArgs, err := s.exclusionClause(ctx)
+	if err != nil {
+		return nil, err
+	}
+	exclude += s.botClause()
+	args := append(append([]any{linkID}, excludeArgs...), limit)
+
+	var counts []SourceCount
+	err = s.db.SelectContext(ctx, &counts, s.q(`
+		SELECT c.source, COUNT(*) AS count
+		FROM link_clicks c
+		WHERE c.link_id = ?`+exclude+`
+		GROUP BY c.source
+		ORDER BY count DESC, c.source ASC
+		LIMIT ?
+	`), args...)
+	if err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+// botClause returns a predicate, prefixed " AND ", hiding clicks (aliased c)
+// flagged as bots, or "" when the store includes them.
+// Governing: SPEC-0016 REQ "Bot Filtering"
+func (s *ClickStore) botClause() string {
+	if s.includeBots {
+		return ""
+	}
+	return ` AND c.bot = 0`
+}
+
+// IncludingBots returns a view of the store whose stats queries count clicks
+// flagged as bots.
+// Governing: SPEC-0016 REQ "Bot Filtering"
+func (s *ClickStore) IncludingBots() *ClickStore {
+	c := *s
+	c.includeBots = true
+	return &c
 }
 
 // HashIP computes SHA-256(ip + ":" + YYYYMMDD_UTC) for the current day.
@@ -494,3 +494,175 @@ func TestHashIP_DifferentIPs(t *testing.T) {
 		t.Errorf("different IPs produced same hash: %q", h1)
 	}
 }
+
+// Governing: SPEC-0016 REQ "Batched Click Inserts"
+func TestRecordClicks_Batch(t *testing.T) {
+	cs, _, _, userID, linkID := newClickTestEnv(t)
+	ctx := context.Background()
+
+	events := make([]store.ClickEvent, 0, 5)
+	for i := 0; i < 5; i++ {
+		events = append(events, store.ClickEvent{LinkID: linkID, UserID: userID, IPHash: "h"})
+	}
+	n, err := cs.RecordClicks(ctx, events)
+	if err != nil || n != 5 {
+		t.Fatalf("RecordClicks = %d, %v; want 5, nil", n, err)
+	}
+	stats, err := cs.GetClickStats(ctx, linkID)
+	if err != nil {
+		t.Fatalf("GetClickStats: %v", err)
+	}
+	if stats.Total != 5 {
+		t.Errorf("total = %d, want 5", stats.Total)
+	}
+}
+
+// Governing: SPEC-0016 REQ "Bot Filtering"
+func TestClickStats_ExcludeBots(t *testing.T) {
+	cs, _, _, _, linkID := newClickTestEnv(t)
+	ctx := context.Background()
+
+	now := time.Now().UTC()
+	if _, err := cs.RecordClicks(ctx, []store.ClickEvent{
+		{LinkID: linkID, UserAgent: "Firefox", ClickedAt: now.Add(-2 * time.Minute)},
+		{LinkID: linkID, UserAgent: "Googlebot/2.1", ClickedAt: now.Add(-time.Minute), Bot: true},
+	}); err != nil {
+		t.Fatalf("RecordClicks: %v", err)
+	}
+	if err := cs.RecordClick(ctx, store.ClickEvent{LinkID: linkID, UserAgent: "curl/8", Bot: true}); err != nil {
+		t.Fatalf("RecordClick: %v", err)
+	}
+
+	stats, err := cs.GetClickStats(ctx, linkID)
+	if err != nil {
+		t.Fatalf("GetClickStats: %v", err)
+	}
+	if stats.Total != 1 || stats.Last7d != 1 {
+		t.Errorf("stats without bots = %+v, want 1 click", stats)
+	}
+	recent, err := cs.ListRecentClicks(ctx, linkID, 10)
+	if err != nil {
+		t.Fatalf("ListRecentClicks: %v", err)
+	}
+	if len(recent) != 1 || recent[0].Bot {
+		t.Errorf("recent without bots = %+v, want the one human click", recent)
+	}
+
+	all := cs.IncludingBots()
+	stats, err = all.GetClickStats(ctx, linkID)
+	if err != nil {
+		t.Fatalf("GetClickStats: %v", err)
+	}
+	if stats.Total != 3 {
+		t.Errorf("total including bots = %d, want 3", stats.Total)
+	}
+	recent, err = all.ListRecentClicks(ctx, linkID, 10)
+	if err != nil {
+		t.Fatalf("ListRecentClicks: %v", err)
+	}
+	if len(recent) != 3 || !recent[0].Bot || recent[2].Bot {
+		t.Errorf("recent including bots = %+v, want bot flags on the two newest", recent)
+	}
+}
+
+// Governing: SPEC-0016 REQ "GeoIP Country Breakdown"
+func TestTopCountries(t *testing.T) {
+	cs, _, _, _, linkID := newClickTestEnv(t)
+	ctx := context.Background()
+
+	if _, err := cs.RecordClicks(ctx, []store.ClickEvent{
+		{LinkID: linkID, Country: "de"},
+		{LinkID: linkID, Country: "DE"},
+		{LinkID: linkID, Country: "US"},
+		{LinkID: linkID},
+		{LinkID: linkID, Country: "FR", Bot: true},
+	}); err != nil {
+		t.Fatalf("RecordClicks: %v", err)
+	}
+
+	counts, err := cs.TopCountries(ctx, linkID, 10)
+	if err != nil {
+		t.Fatalf("TopCountries: %v", err)
+	}
+	want := []store.CountryCount{{Country: "DE", Count: 2}, {Country: "", Count: 1}, {Country: "US", Count: 1}}
+	if len(counts) != len(want) {
+		t.Fatalf("TopCountries = %+v, want %+v", counts, want)
+	}
+	for i := range want {
+		if counts[i] != want[i] {
+			t.Errorf("TopCountries[%d] = %+v, want %+v", i, counts[i], want[i])
+		}
+	}
+
+	recent, err := cs.ListRecentClicks(ctx, linkID, 10)
+	if err != nil {
+		t.Fatalf("ListRecentClicks: %v", err)
+	}
+	for _, c := range recent {
+		if c.Country != "" && c.Country != "DE" && c.Country != "US" {
+			t.Errorf("recent click country = %q", c.Country)
+		}
+	}
+}
+
+// Governing: SPEC-0016 REQ "Campaign Sources"
+func TestTopSources(t *testing.T) {
+	cs, _, _, _, linkID := newClickTestEnv(t)
+	ctx := context.Background()
+
+	if _, err := cs.RecordClicks(ctx, []store.ClickEvent{
+		{LinkID: linkID, Source: "email"},
+		{LinkID: linkID, Source: "email"},
+		{LinkID: linkID, Source: "slack"},
+		{LinkID: linkID},
+		{LinkID: linkID, Source: "slack", Bot: true},
+	}); err != nil {
+		t.Fatalf("RecordClicks: %v", err)
+	}
+
+	counts, err := cs.TopSources(ctx, linkID, 10)
+	if err != nil {
+		t.Fatalf("TopSources: %v", err)
+	}
+	want := []store.SourceCount{{Source: "email", Count: 2}, {Source: "", Count: 1}, {Source: "slack", Count: 1}}
+	if len(counts) != len(want) {
+		t.Fatalf("TopSources = %+v, want %+v", counts, want)
+	}
+	for i := range want {
+		if counts[i] != want[i] {
+			t.Errorf("TopSources[%d] = %+v, want %+v", i, counts[i], want[i])
+		}
+	}
+}
+
+// Governing: SPEC-0016 REQ "Link Detail Stats Widget"
+func TestDailyClicks(t *testing.T) {
+	cs, _, _, _, linkID := newClickTestEnv(t)
+	ctx := context.Background()
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+
+	if _, err := cs.RecordClicks(ctx, []store.ClickEvent{
+		{LinkID: linkID, ClickedAt: today.Add(time.Hour)},
+		{LinkID: linkID, ClickedAt: today.Add(2 * time.Hour)},
+		{LinkID: linkID, ClickedAt: today.AddDate(0, 0, -2).Add(time.Hour)},
+		{LinkID: linkID, ClickedAt: today.AddDate(0, 0, -2).Add(time.Hour), Bot: true},
+		{LinkID: linkID, ClickedAt: today.AddDate(0, 0, -10)},
+	}); err != nil {
+		t.Fatalf("RecordClicks: %v", err)
+	}
+
+	counts, err := cs.DailyClicks(ctx, linkID, 3)
+	if err != nil {
+		t.Fatalf("DailyClicks: %v", err)
+	}
+	want := []int64{1, 0, 2}
+	if len(counts) != len(want) {
+		t.Fatalf("DailyClicks = %v, want %v", counts, want)
+	}
+	for i := range want {
+		if counts[i] != want[i] {
+			t.Errorf("DailyClicks = %v, want %v", counts, want)
+			break
+		}
+	}
+}
@@ -0,0 +1,147 @@
+// Governing: SPEC-0002 REQ "Destination Domain Rules"
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Domain rule actions.
+const (
+	DomainAllow = "allow"
+	DomainDeny  = "deny"
+)
+
+var (
+	// ErrDomainNotAllowed is returned when a link's destination breaks a
+	// domain rule. The wrapped message names the rule.
+	ErrDomainNotAllowed = errors.New("destination domain not allowed")
+	// ErrDomainRuleExists is returned when a domain already has a rule.
+	ErrDomainRuleExists = errors.New("domain already has a rule")
+	// ErrInvalidDomainAction is returned for an action other than allow or deny.
+	ErrInvalidDomainAction = errors.New("action must be allow or deny")
+)
+
+// DomainRule allows or denies link destinations on a domain and all of its
+// subdomains.
+type DomainRule struct {
+	Domain    string    `db:"domain"`
+	Action    string    `db:"action"`
+	Note      string    `db:"note"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+// DomainRuleStore is the sqlx-backed store for admin-managed domain rules.
+type DomainRuleStore struct {
+	db *sqlx.DB
+}
+
+// NewDomainRuleStore creates a new DomainRuleStore.
+func NewDomainRuleStore(db *sqlx.DB) *DomainRuleStore {
+	return &DomainRuleStore{db: db}
+}
+
+// q rebinds ? placeholders to the driver's native format.
+func (s *DomainRuleStore) q(query string) string { return s.db.Rebind(query) }
+
+// List returns the domain rules in domain order.
+func (s *DomainRuleStore) List(ctx context.Context) ([]*DomainRule, error) {
+	return listDomainRules(ctx, s.db)
+}
+
+// Add creates a rule for domain, given as a bare host or a URL on it. Links
+// already saved are not affected; only later creates and URL changes are
+// checked. Returns ErrInvalidDomain, ErrInvalidDomainAction, or
+// ErrDomainRuleExists.
+func (s *DomainRuleStore) Add(ctx context.Context, domain, action, note string) (*DomainRule, error) {
+	domain, err := NormalizeReferrerDomain(domain)
+	if err != nil {
+		return nil, err
+	}
+	if action != DomainAllow && action != DomainDeny {
+		return nil, ErrInvalidDomainAction
+	}
+	dr := &DomainRule{Domain: domain, Action: action, Note: strings.TrimSpace(note), CreatedAt: time.Now().UTC()}
+	_, err = s.db.ExecContext(ctx, s.q(`
+		INSERT INTO domain_rules (domain, action, note, created_at) VALUES (?, ?, ?, ?)
+	`), dr.Domain, dr.Action, dr.Note, dr.CreatedAt)
+	if err != nil {
+		if isUniqueConstraintError(err) {
+			return nil, ErrDomainRuleExists
+		}
+		return nil, err
+	}
+	return dr, nil
+}
+
+// Remove deletes the rule for domain. Returns ErrNotFound if there is none.
+func (s *DomainRuleStore) Remove(ctx context.Context, domain string) error {
+	res, err := s.db.ExecContext(ctx, s.q(`DELETE FROM domain_rules WHERE domain = ?`), domain)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// Check reports whether the domain rules allow target as a link destination,
+// returning an error wrapping ErrDomainNotAllowed that names the rule if not.
+func (s *DomainRuleStore) Check(ctx context.Context, target string) error {
+	return checkDestination(ctx, s.db, target)
+}
+
+func listDomainRules(ctx context.Context, q sqlx.QueryerContext) ([]*DomainRule, error) {
+	var rules []*DomainRule
+	if err := sqlx.SelectContext(ctx, q, &rules, `SELECT * FROM domain_rules ORDER BY domain ASC`); err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+// checkDestination applies the domain rules, read through q (which may be a
+// transaction), to target's host. The rule for the longest matching domain
+// decides; with no match, target is refused only if some allow rule exists.
+// Targets without a host, such as relative paths, are not checked.
+func checkDestination(ctx context.Context, q sqlx.QueryerContext, target string) error {
+	u, err := url.Parse(target)
+	if err != nil {
+		return nil
+	}
+	host := strings.TrimSuffix(strings.ToLower(u.Hostname()), ".")
+	if host == "" {
+		return nil
+	}
+	rules, err := listDomainRules(ctx, q)
+	if err != nil || len(rules) == 0 {
+		return err
+	}
+	var best *DomainRule
+	allowlist := false
+	for _, r := range rules {
+		if r.Action == DomainAllow {
+			allowlist = true
+		}
+		if matchesDomain(host, r.Domain) && (best == nil || len(r.Domain) > len(best.Domain)) {
+			best = r
+		}
+	}
+	switch {
+	case best != nil && best.Action == DomainDeny:
+		msg := fmt.Sprintf("%s is blocked by the deny rule for %s", host, best.Domain)
+		if best.Note != "" {
+			msg += " (" + best.Note + ")"
+		}
+		return fmt.Errorf("%w: %s", ErrDomainNotAllowed, msg)
+	case best == nil && allowlist:
+		return fmt.Errorf("%w: %s is not on the domain allowlist", ErrDomainNotAllowed, host)
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+// Governing: SPEC-0002 REQ "Destination Domain Rules"
+package store_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/joestump/joe-links/internal/store"
+	"github.com/joestump/joe-links/internal/testutil"
+)
+
+func TestDomainRules_Check(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	ds := store.NewDomainRuleStore(db)
+	ctx := context.Background()
+
+	if err := ds.Check(ctx, "https://anything.example"); err != nil {
+		t.Fatalf("no rules: %v", err)
+	}
+	for _, r := range []struct{ domain, action string }{
+		{"example.com", store.DomainAllow},
+		{"files.example.com", store.DomainDeny},
+		{"docs.files.example.com", store.DomainAllow},
+	} {
+		if _, err := ds.Add(ctx, r.domain, r.action, ""); err != nil {
+			t.Fatalf("Add(%s): %v", r.domain, err)
+		}
+	}
+
+	cases := []struct {
+		url     string
+		allowed bool
+	}{
+		{"https://example.com/a", true},
+		{"https://wiki.example.com", true},
+		{"https://files.example.com/x", false},
+		{"https://a.files.example.com", false},
+		{"https://docs.files.example.com", true},
+		{"https://other.org", false},      // allowlist in effect
+		{"https://notexample.com", false}, // suffix match is by label
+		{"/relative/path", true},          // no host to check
+	}
+	for _, c := range cases {
+		err := ds.Check(ctx, c.url)
+		if c.allowed && err != nil {
+			t.Errorf("Check(%s) = %v, want allowed", c.url, err)
+		}
+		if !c.allowed && !errors.Is(err, store.ErrDomainNotAllowed) {
+			t.Errorf("Check(%s) = %v, want ErrDomainNotAllowed", c.url, err)
+		}
+	}
+}
+
+func TestDomainRules_LinkStore(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	ls := store.NewLinkStore(db, store.NewOwnershipStore(db), store.NewTagStore(db))
+	ds := store.NewDomainRuleStore(db)
+	ctx := context.Background()
+
+	u, err := store.NewUserStore(db).Upsert(ctx, "test", "sub1", "test@example.com", "Test User", "")
+	if err != nil {
+		t.Fatalf("seed user: %v", err)
+	}
+	link, err := ls.Create(ctx, "drive", "https://drive.example.com/f", u.ID, "", "", "")
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if _, err := ds.Add(ctx, "drive.example.com", store.DomainDeny, "use the wiki"); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	if _, err := ls.Create(ctx, "drive2", "https://drive.example.com/g", u.ID, "", "", ""); !errors.Is(err, store.ErrDomainNotAllowed) {
+		t.Errorf("Create to denied domain = %v, want ErrDomainNotAllowed", err)
+	}
+	// Editing a link whose existing destination is now denied is still
+	// allowed as long as the URL itself does not change.
+	if _, err := ls.Update(ctx, link.ID, link.URL, "Drive", "", "public"); err != nil {
+		t.Errorf("Update without URL change: %v", err)
+	}
+	if _, err := ls.Update(ctx, link.ID, "https://drive.example.com/h", "Drive", "", "public"); !errors.Is(err, store.ErrDomainNotAllowed) {
+		t.Errorf("Update to denied domain = %v, want ErrDomainNotAllowed", err)
+	}
+	if _, err := ls.Update(ctx, link.ID, "https://wiki.example.com", "Drive", "", "public"); err != nil {
+		t.Errorf("Update to other domain: %v", err)
+	}
+}
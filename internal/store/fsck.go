@@ -0,0 +1,317 @@
+// Governing: SPEC-0001 REQ "Data Consistency Check"
+package store
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// FsckCheck is the outcome of one consistency check.
+type FsckCheck struct {
+	Name        string
+	Description string
+	Problems    []string // one human-readable line per offending row
+	Repaired    int
+}
+
+// Unrepaired returns how many problems remain after the run.
+func (c *FsckCheck) Unrepaired() int { return len(c.Problems) - c.Repaired }
+
+// FsckReport collects the results of every check.
+type FsckReport struct {
+	Checks []*FsckCheck
+}
+
+// Problems returns the total number of problems found.
+func (r *FsckReport) Problems() int {
+	n := 0
+	for _, c := range r.Checks {
+		n += len(c.Problems)
+	}
+	return n
+}
+
+// Unrepaired returns the number of problems left unrepaired.
+func (r *FsckReport) Unrepaired() int {
+	n := 0
+	for _, c := range r.Checks {
+		n += c.Unrepaired()
+	}
+	return n
+}
+
+// Fsck scans the database for rows that violate invariants the application
+// relies on but the schema cannot always enforce (SQLite runs without foreign
+// key enforcement, and older databases may predate unique indexes).
+type Fsck struct {
+	db *sqlx.DB
+}
+
+// NewFsck creates a new Fsck.
+func NewFsck(db *sqlx.DB) *Fsck {
+	return &Fsck{db: db}
+}
+
+// fsckStep is one named check. run records problems on the FsckCheck and,
+// when repair is set, fixes them and counts the repairs. Orphan checks run
+// first so later checks see a clean join graph.
+type fsckStep struct {
+	name        string
+	description string
+	run         func(ctx context.Context, tx *sqlx.Tx, c *FsckCheck, repair bool) error
+}
+
+var fsckSteps = []fsckStep{
+	{"orphaned_link_owners", "link_owners rows whose link or user no longer exists", fsckOrphanedOwners},
+	{"orphaned_link_tags", "link_tags rows whose link or tag no longer exists", fsckOrphanedTags},
+	{"orphaned_link_shares", "link_shares rows whose link or user no longer exists", fsckOrphanedShares},
+	{"links_without_primary_owner", "links with no primary owner in link_owners", fsckPrimaryOwners},
+	{"duplicate_tag_slugs", "tags sharing the same slug", fsckDuplicateTags},
+	{"invalid_visibility", "links whose visibility is not public, private, or secure", fsckVisibility},
+	{"search_index", "links missing from the full-text search index, or index rows for deleted links", fsckSearchIndex},
+}
+
+// Run executes every check inside a single transaction. When repair is true
+// the fixes are committed; otherwise the transaction is rolled back so the
+// database is never modified.
+func (f *Fsck) Run(ctx context.Context, repair bool) (*FsckReport, error) {
+	tx, err := f.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	report := &FsckReport{}
+	for _, step := range fsckSteps {
+		c := &FsckCheck{Name: step.name, Description: step.description}
+		if err := step.run(ctx, tx, c, repair); err != nil {
+			return nil, fmt.Errorf("fsck %s: %w", step.name, err)
+		}
+		report.Checks = append(report.Checks, c)
+	}
+
+	if repair {
+		if err := tx.Commit(); err != nil {
+			return nil, err
+		}
+	}
+	return report, nil
+}
+
+func fsckOrphanedOwners(ctx context.Context, tx *sqlx.Tx, c *FsckCheck, repair bool) error {
+	const where = `
+		NOT EXISTS (SELECT 1 FROM links WHERE links.id = link_owners.link_id)
+		OR NOT EXISTS (SELECT 1 FROM users WHERE users.id = link_owners.user_id)`
+	return fsckOrphans(ctx, tx, c, repair, "link_owners", "link_id, user_id", where)
+}
+
+func fsckOrphanedTags(ctx context.Context, tx *sqlx.Tx, c *FsckCheck, repair bool) error {
+	const where = `
+		NOT EXISTS (SELECT 1 FROM links WHERE links.id = link_tags.link_id)
+		OR NOT EXISTS (SELECT 1 FROM tags WHERE tags.id = link_tags.tag_id)`
+	return fsckOrphans(ctx, tx, c, repair, "link_tags", "link_id, tag_id", where)
+}
+
+func fsckOrphanedShares(ctx context.Context, tx *sqlx.Tx, c *FsckCheck, repair bool) error {
+	const where = `
+		NOT EXISTS (SELECT 1 FROM links WHERE links.id = link_shares.link_id)
+		OR NOT EXISTS (SELECT 1 FROM users WHERE users.id = link_shares.user_id)`
+	return fsckOrphans(ctx, tx, c, repair, "link_shares", "link_id, user_id", where)
+}
+
+// fsckOrphans reports join-table rows matching where and deletes them when repairing.
+// cols must name exactly two columns.
+func fsckOrphans(ctx context.Context, tx *sqlx.Tx, c *FsckCheck, repair bool, table, cols, where string) error {
+	rows, err := tx.QueryxContext(ctx, `SELECT `+cols+` FROM `+table+` WHERE `+where)
+	if err != nil {
+		return err
+	}
+	for rows.Next() {
+		var a, b string
+		if err := rows.Scan(&a, &b); err != nil {
+			_ = rows.Close()
+			return err
+		}
+		c.Problems = append(c.Problems, fmt.Sprintf("%s(%s) = (%s, %s)", table, cols, a, b))
+	}
+	if err := rows.Close(); err != nil {
+		return err
+	}
+	if !repair || len(c.Problems) == 0 {
+		return nil
+	}
+	res, err := tx.ExecContext(ctx, `DELETE FROM `+table+` WHERE `+where)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	c.Repaired = int(n)
+	return err
+}
+
+// fsckPrimaryOwners promotes a remaining co-owner to primary. Links with no
+// owners at all cannot be repaired automatically and are left for an admin.
+func fsckPrimaryOwners(ctx context.Context, tx *sqlx.Tx, c *FsckCheck, repair bool) error {
+	var links []struct {
+		ID   string `db:"id"`
+		Slug string `db:"slug"`
+	}
+	err := tx.SelectContext(ctx, &links, `
+		SELECT id, slug FROM links
+		WHERE NOT EXISTS (
+			SELECT 1 FROM link_owners
+			WHERE link_owners.link_id = links.id AND link_owners.is_primary = 1
+		)
+		ORDER BY slug`)
+	if err != nil {
+		return err
+	}
+	for _, l := range links {
+		var owners []string
+		if err := tx.SelectContext(ctx, &owners, tx.Rebind(`
+			SELECT user_id FROM link_owners WHERE link_id = ? ORDER BY user_id
+		`), l.ID); err != nil {
+			return err
+		}
+		if len(owners) == 0 {
+			c.Problems = append(c.Problems, fmt.Sprintf("link %q (%s) has no owners; assign one from /admin/links", l.Slug, l.ID))
+			continue
+		}
+		c.Problems = append(c.Problems, fmt.Sprintf("link %q (%s) has no primary owner; candidate %s", l.Slug, l.ID, owners[0]))
+		if !repair {
+			continue
+		}
+		if _, err := tx.ExecContext(ctx, tx.Rebind(`
+			UPDATE link_owners SET is_primary = 1 WHERE link_id = ? AND user_id = ?
+		`), l.ID, owners[0]); err != nil {
+			return err
+		}
+		c.Repaired++
+	}
+	return nil
+}
+
+// fsckDuplicateTags merges tags that share a slug into the oldest one,
+// re-pointing link_tags rows and dropping the duplicates.
+func fsckDuplicateTags(ctx context.Context, tx *sqlx.Tx, c *FsckCheck, repair bool) error {
+	var slugs []string
+	if err := tx.SelectContext(ctx, &slugs, `
+		SELECT slug FROM tags GROUP BY slug HAVING COUNT(*) > 1 ORDER BY slug
+	`); err != nil {
+		return err
+	}
+	for _, slug := range slugs {
+		var ids []string
+		if err := tx.SelectContext(ctx, &ids, tx.Rebind(`
+			SELECT id FROM tags WHERE slug = ? ORDER BY created_at, id
+		`), slug); err != nil {
+			return err
+		}
+		keeper, dups := ids[0], ids[1:]
+		for _, dup := range dups {
+			c.Problems = append(c.Problems, fmt.Sprintf("tag %s duplicates slug %q of tag %s", dup, slug, keeper))
+		}
+		if !repair {
+			continue
+		}
+
+		var kept []string
+		if err := tx.SelectContext(ctx, &kept, tx.Rebind(`SELECT link_id FROM link_tags WHERE tag_id = ?`), keeper); err != nil {
+			return err
+		}
+		tagged := make(map[string]bool, len(kept))
+		for _, id := range kept {
+			tagged[id] = true
+		}
+		for _, dup := range dups {
+			var linkIDs []string
+			if err := tx.SelectContext(ctx, &linkIDs, tx.Rebind(`SELECT link_id FROM link_tags WHERE tag_id = ?`), dup); err != nil {
+				return err
+			}
+			for _, linkID := range linkIDs {
+				query := `UPDATE link_tags SET tag_id = ? WHERE link_id = ? AND tag_id = ?`
+				args := []any{keeper, linkID, dup}
+				if tagged[linkID] {
+					query = `DELETE FROM link_tags WHERE link_id = ? AND tag_id = ?`
+					args = []any{linkID, dup}
+				}
+				if _, err := tx.ExecContext(ctx, tx.Rebind(query), args...); err != nil {
+					return err
+				}
+				tagged[linkID] = true
+			}
+			if _, err := tx.ExecContext(ctx, tx.Rebind(`DELETE FROM tags WHERE id = ?`), dup); err != nil {
+				return err
+			}
+			if err := reindexLinks(ctx, tx, linkIDs...); err != nil {
+				return err
+			}
+			c.Repaired++
+		}
+	}
+	return nil
+}
+
+// fsckVisibility resets unknown visibility values to secure, the most
+// restrictive setting that still lets owners and share recipients resolve the link.
+func fsckVisibility(ctx context.Context, tx *sqlx.Tx, c *FsckCheck, repair bool) error {
+	var links []struct {
+		ID         string `db:"id"`
+		Slug       string `db:"slug"`
+		Visibility string `db:"visibility"`
+	}
+	const where = `visibility NOT IN ('public', 'private', 'secure')`
+	if err := tx.SelectContext(ctx, &links, `SELECT id, slug, visibility FROM links WHERE `+where+` ORDER BY slug`); err != nil {
+		return err
+	}
+	for _, l := range links {
+		c.Problems = append(c.Problems, fmt.Sprintf("link %q (%s) has visibility %q", l.Slug, l.ID, l.Visibility))
+	}
+	if !repair || len(links) == 0 {
+		return nil
+	}
+	res, err := tx.ExecContext(ctx, `UPDATE links SET visibility = 'secure' WHERE `+where)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	c.Repaired = int(n)
+	return err
+}
+
+// fsckSearchIndex indexes links the search index is missing and drops index
+// rows left behind by links deleted outside the store.
+// Governing: SPEC-0002 REQ "Full-Text Link Search"
+func fsckSearchIndex(ctx context.Context, tx *sqlx.Tx, c *FsckCheck, repair bool) error {
+	var missing, stale []string
+	if err := tx.SelectContext(ctx, &missing, `
+		SELECT id FROM links
+		WHERE NOT EXISTS (SELECT 1 FROM link_search WHERE link_search.link_id = links.id)
+		ORDER BY slug
+	`); err != nil {
+		return err
+	}
+	if err := tx.SelectContext(ctx, &stale, `
+		SELECT link_id FROM link_search
+		WHERE NOT EXISTS (SELECT 1 FROM links WHERE links.id = link_search.link_id)
+	`); err != nil {
+		return err
+	}
+	for _, id := range missing {
+		c.Problems = append(c.Problems, fmt.Sprintf("link %s is not in the search index", id))
+	}
+	for _, id := range stale {
+		c.Problems = append(c.Problems, fmt.Sprintf("search index has a row for deleted link %s", id))
+	}
+	if !repair || len(c.Problems) == 0 {
+		return nil
+	}
+	// reindexLinks drops the row for a missing link and inserts one for a present link.
+	if err := reindexLinks(ctx, tx, append(missing, stale...)...); err != nil {
+		return err
+	}
+	c.Repaired = len(c.Problems)
+	return nil
+}
@@ -0,0 +1,130 @@
+// Governing: SPEC-0001 REQ "Data Consistency Check"
+package store_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/joestump/joe-links/internal/store"
+	"github.com/joestump/joe-links/internal/testutil"
+)
+
+// seedFsckProblems creates one instance of every problem fsck detects.
+// SQLite test connections do not enforce foreign keys, mirroring production.
+func seedFsckProblems(t *testing.T, db *sqlx.DB) (ls *store.LinkStore, ownerID string) {
+	t.Helper()
+	ctx := context.Background()
+	owns := store.NewOwnershipStore(db)
+	tags := store.NewTagStore(db)
+	ls = store.NewLinkStore(db, owns, tags)
+	us := store.NewUserStore(db)
+
+	u, err := us.Upsert(ctx, "test", "fsck-sub", "fsck@example.com", "Fsck", "")
+	if err != nil {
+		t.Fatalf("seed user: %v", err)
+	}
+	co, err := us.Upsert(ctx, "test", "fsck-co", "fsck-co@example.com", "Co", "")
+	if err != nil {
+		t.Fatalf("seed co-owner: %v", err)
+	}
+	link, err := ls.Create(ctx, "fsck-link", "https://example.com", u.ID, "", "", "")
+	if err != nil {
+		t.Fatalf("seed link: %v", err)
+	}
+	if err := owns.AddOwner(link.ID, co.ID); err != nil {
+		t.Fatalf("add co-owner: %v", err)
+	}
+
+	stmts := []string{
+		// Orphans.
+		`INSERT INTO link_owners (link_id, user_id, is_primary) VALUES ('missing-link', '` + u.ID + `', 0)`,
+		`INSERT INTO link_tags (link_id, tag_id) VALUES ('` + link.ID + `', 'missing-tag')`,
+		`INSERT INTO link_shares (link_id, user_id, shared_by) VALUES ('` + link.ID + `', 'missing-user', '` + u.ID + `')`,
+		// Demote the primary owner; the co-owner is the repair candidate.
+		`DELETE FROM link_owners WHERE link_id = '` + link.ID + `' AND user_id = '` + u.ID + `'`,
+		// Duplicate tag slugs (older databases may lack the unique index).
+		`DROP INDEX idx_tags_slug`,
+		`INSERT INTO tags (id, name, slug, created_at) VALUES ('tag-a', 'Ops', 'ops', '2024-01-01 00:00:00')`,
+		`INSERT INTO tags (id, name, slug, created_at) VALUES ('tag-b', 'ops', 'ops', '2024-02-01 00:00:00')`,
+		`INSERT INTO link_tags (link_id, tag_id) VALUES ('` + link.ID + `', 'tag-b')`,
+		// Invalid visibility.
+		`UPDATE links SET visibility = 'hidden' WHERE id = '` + link.ID + `'`,
+		// Link missing from the search index.
+		`DELETE FROM link_search WHERE link_id = '` + link.ID + `'`,
+	}
+	for _, q := range stmts {
+		if _, err := db.Exec(q); err != nil {
+			t.Fatalf("%s: %v", q, err)
+		}
+	}
+	return ls, co.ID
+}
+
+func TestFsck_ReportOnlyDoesNotModify(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	seedFsckProblems(t, db)
+	ctx := context.Background()
+
+	report, err := store.NewFsck(db).Run(ctx, false)
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	for _, c := range report.Checks {
+		if len(c.Problems) != 1 {
+			t.Errorf("%s: %d problems, want 1: %v", c.Name, len(c.Problems), c.Problems)
+		}
+		if c.Repaired != 0 {
+			t.Errorf("%s: repaired %d without --repair", c.Name, c.Repaired)
+		}
+	}
+
+	again, err := store.NewFsck(db).Run(ctx, false)
+	if err != nil {
+		t.Fatalf("second Run: %v", err)
+	}
+	if again.Problems() != report.Problems() {
+		t.Errorf("problems changed from %d to %d after report-only run", report.Problems(), again.Problems())
+	}
+}
+
+func TestFsck_Repair(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	ls, coID := seedFsckProblems(t, db)
+	ctx := context.Background()
+
+	report, err := store.NewFsck(db).Run(ctx, true)
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if report.Unrepaired() != 0 {
+		t.Fatalf("unrepaired = %d, want 0", report.Unrepaired())
+	}
+
+	after, err := store.NewFsck(db).Run(ctx, false)
+	if err != nil {
+		t.Fatalf("verify Run: %v", err)
+	}
+	if after.Problems() != 0 {
+		for _, c := range after.Checks {
+			t.Logf("%s: %v", c.Name, c.Problems)
+		}
+		t.Fatalf("problems after repair = %d, want 0", after.Problems())
+	}
+
+	link, err := ls.GetBySlug(ctx, "fsck-link")
+	if err != nil {
+		t.Fatalf("GetBySlug: %v", err)
+	}
+	if link.Visibility != "secure" {
+		t.Errorf("visibility = %q, want secure", link.Visibility)
+	}
+	var primary string
+	if err := db.Get(&primary, `SELECT user_id FROM link_owners WHERE link_id = ? AND is_primary = 1`, link.ID); err != nil || primary != coID {
+		t.Errorf("primary owner = %q (err %v), want co-owner %q", primary, err, coID)
+	}
+	var tagID string
+	if err := db.Get(&tagID, `SELECT tag_id FROM link_tags WHERE link_id = ?`, link.ID); err != nil || tagID != "tag-a" {
+		t.Errorf("link tag = %q (err %v), want merged into tag-a", tagID, err)
+	}
+}
@@ -0,0 +1,106 @@
+// Governing: SPEC-0001 REQ "Link Health Checks"
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// LinkHealth is the latest health check result for a link's target URL.
+type LinkHealth struct {
+	LinkID     string    `db:"link_id"`
+	StatusCode int       `db:"status_code"` // 0 when the request failed
+	LatencyMS  int64     `db:"latency_ms"`
+	Error      string    `db:"error"` // transport error, empty on a response
+	CheckedAt  time.Time `db:"checked_at"`
+}
+
+// Broken reports whether the check failed or the target answered 4xx/5xx.
+func (h *LinkHealth) Broken() bool {
+	return h.Error != "" || h.StatusCode >= 400
+}
+
+// HealthStore persists link health check results.
+type HealthStore struct {
+	db *sqlx.DB
+}
+
+// NewHealthStore creates a new HealthStore.
+func NewHealthStore(db *sqlx.DB) *HealthStore {
+	return &HealthStore{db: db}
+}
+
+// q rebinds ? placeholders to the driver's native format.
+func (s *HealthStore) q(query string) string { return s.db.Rebind(query) }
+
+// Record stores h as the latest result for its link, replacing any previous one.
+func (s *HealthStore) Record(ctx context.Context, h LinkHealth) error {
+	checkedAt := h.CheckedAt.UTC()
+	if h.CheckedAt.IsZero() {
+		checkedAt = time.Now().UTC()
+	}
+	res, err := s.db.ExecContext(ctx, s.q(`
+		UPDATE link_health SET status_code = ?, latency_ms = ?, error = ?, checked_at = ?
+		WHERE link_id = ?
+	`), h.StatusCode, h.LatencyMS, h.Error, checkedAt, h.LinkID)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n > 0 {
+		return nil
+	}
+	_, err = s.db.ExecContext(ctx, s.q(`
+		INSERT INTO link_health (link_id, status_code, latency_ms, error, checked_at)
+		VALUES (?, ?, ?, ?, ?)
+	`), h.LinkID, h.StatusCode, h.LatencyMS, h.Error, checkedAt)
+	return err
+}
+
+// Get returns the latest result for linkID, or ErrNotFound if it was never checked.
+func (s *HealthStore) Get(ctx context.Context, linkID string) (*LinkHealth, error) {
+	var h LinkHealth
+	err := s.db.GetContext(ctx, &h, s.q(`SELECT * FROM link_health WHERE link_id = ?`), linkID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &h, nil
+}
+
+// ListBroken returns the latest results that count as broken, most recently checked first.
+func (s *HealthStore) ListBroken(ctx context.Context) ([]*LinkHealth, error) {
+	var rows []*LinkHealth
+	err := s.db.SelectContext(ctx, &rows, `
+		SELECT * FROM link_health
+		WHERE status_code >= 400 OR error <> ''
+		ORDER BY checked_at DESC
+	`)
+	return rows, err
+}
+
+// Attach sets Health on every link in links that has been checked.
+func (s *HealthStore) Attach(ctx context.Context, links []*Link) error {
+	if len(links) == 0 {
+		return nil
+	}
+	byID := make(map[string]*Link, len(links))
+	for _, l := range links {
+		byID[l.ID] = l
+	}
+	var rows []*LinkHealth
+	if err := s.db.SelectContext(ctx, &rows, `SELECT * FROM link_health`); err != nil {
+		return err
+	}
+	for _, h := range rows {
+		if l, ok := byID[h.LinkID]; ok {
+			l.Health = h
+		}
+	}
+	return nil
+}
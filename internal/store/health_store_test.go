@@ -0,0 +1,70 @@
+// Governing: SPEC-0001 REQ "Link Health Checks"
+package store_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/joestump/joe-links/internal/store"
+	"github.com/joestump/joe-links/internal/testutil"
+)
+
+func TestHealthStore_RecordReplacesAndAttaches(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	owns := store.NewOwnershipStore(db)
+	ls := store.NewLinkStore(db, owns, store.NewTagStore(db))
+	hs := store.NewHealthStore(db)
+	ctx := context.Background()
+
+	u, err := store.NewUserStore(db).Upsert(ctx, "test", "sub1", "health@example.com", "Health", "")
+	if err != nil {
+		t.Fatalf("seed user: %v", err)
+	}
+	ok, _ := ls.Create(ctx, "healthy", "https://example.com", u.ID, "", "", "")
+	bad, _ := ls.Create(ctx, "broken", "https://example.com/gone", u.ID, "", "", "")
+	unchecked, _ := ls.Create(ctx, "unchecked", "https://example.com/new", u.ID, "", "", "")
+
+	if _, err := hs.Get(ctx, ok.ID); !errors.Is(err, store.ErrNotFound) {
+		t.Fatalf("Get before check: err = %v, want ErrNotFound", err)
+	}
+	if err := hs.Record(ctx, store.LinkHealth{LinkID: ok.ID, Error: "timeout"}); err != nil {
+		t.Fatalf("Record: %v", err)
+	}
+	if err := hs.Record(ctx, store.LinkHealth{LinkID: ok.ID, StatusCode: 200, LatencyMS: 12}); err != nil {
+		t.Fatalf("Record replace: %v", err)
+	}
+	if err := hs.Record(ctx, store.LinkHealth{LinkID: bad.ID, StatusCode: 500}); err != nil {
+		t.Fatalf("Record: %v", err)
+	}
+
+	h, err := hs.Get(ctx, ok.ID)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if h.StatusCode != 200 || h.Error != "" || h.Broken() {
+		t.Errorf("expected replaced healthy result, got %+v", h)
+	}
+
+	broken, err := hs.ListBroken(ctx)
+	if err != nil {
+		t.Fatalf("ListBroken: %v", err)
+	}
+	if len(broken) != 1 || broken[0].LinkID != bad.ID {
+		t.Errorf("ListBroken = %+v, want only %s", broken, bad.ID)
+	}
+
+	links := []*store.Link{ok, bad, unchecked}
+	if err := hs.Attach(ctx, links); err != nil {
+		t.Fatalf("Attach: %v", err)
+	}
+	if ok.Health == nil || ok.Health.Broken() {
+		t.Errorf("healthy link: Health = %+v", ok.Health)
+	}
+	if bad.Health == nil || !bad.Health.Broken() {
+		t.Errorf("broken link: Health = %+v", bad.Health)
+	}
+	if unchecked.Health != nil {
+		t.Errorf("unchecked link: Health = %+v, want nil", unchecked.Health)
+	}
+}
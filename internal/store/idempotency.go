@@ -0,0 +1,70 @@
+// Governing: SPEC-0005 REQ "Idempotent Link Creation"
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// ErrIdempotencyKeyReused is returned when an idempotency key is sent again
+// with a different request body.
+var ErrIdempotencyKeyReused = errors.New("idempotency key was already used for a different request")
+
+// IdempotencyKeyTTL is how long an idempotency key is remembered.
+const IdempotencyKeyTTL = 24 * time.Hour
+
+// IdempotencyStore remembers which link each client-supplied idempotency key
+// created, so a retried create returns the original link.
+type IdempotencyStore struct {
+	db *sqlx.DB
+}
+
+// NewIdempotencyStore creates a new IdempotencyStore.
+func NewIdempotencyStore(db *sqlx.DB) *IdempotencyStore {
+	return &IdempotencyStore{db: db}
+}
+
+// q rebinds ? placeholders to the driver's native format ($1,$2,... for PostgreSQL).
+func (s *IdempotencyStore) q(query string) string { return s.db.Rebind(query) }
+
+// Lookup returns the ID of the link userID created with key, or "" if the key
+// is unknown or expired. Returns ErrIdempotencyKeyReused when the key was used
+// with a request whose hash differs from requestHash.
+func (s *IdempotencyStore) Lookup(ctx context.Context, userID, key, requestHash string) (string, error) {
+	var row struct {
+		RequestHash string `db:"request_hash"`
+		LinkID      string `db:"link_id"`
+	}
+	err := s.db.GetContext(ctx, &row, s.q(`
+		SELECT request_hash, link_id FROM idempotency_keys
+		WHERE user_id = ? AND idempotency_key = ? AND created_at > ?
+	`), userID, key, time.Now().UTC().Add(-IdempotencyKeyTTL))
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	if row.RequestHash != requestHash {
+		return "", ErrIdempotencyKeyReused
+	}
+	return row.LinkID, nil
+}
+
+// Save records that userID created linkID with key, replacing an expired use
+// of the key, and forgets keys older than IdempotencyKeyTTL.
+func (s *IdempotencyStore) Save(ctx context.Context, userID, key, requestHash, linkID string) error {
+	now := time.Now().UTC()
+	if _, err := s.db.ExecContext(ctx, s.q(`DELETE FROM idempotency_keys WHERE created_at <= ? OR (user_id = ? AND idempotency_key = ?)`),
+		now.Add(-IdempotencyKeyTTL), userID, key); err != nil {
+		return err
+	}
+	_, err := s.db.ExecContext(ctx, s.q(`
+		INSERT INTO idempotency_keys (user_id, idempotency_key, request_hash, link_id, created_at) VALUES (?, ?, ?, ?, ?)
+	`), userID, key, requestHash, linkID, now)
+	return err
+}
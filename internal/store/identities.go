@@ -0,0 +1,315 @@
+// Governing: SPEC-0001 REQ "Account Linking"
+package store
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base32"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/joestump/joe-links/internal/ids"
+)
+
+var (
+	// ErrIdentityLinked is returned when linking a sign-in that already
+	// belongs to another account.
+	ErrIdentityLinked = errors.New("identity is linked to another account")
+
+	// ErrLastIdentity is returned when unlinking a user's only sign-in.
+	ErrLastIdentity = errors.New("cannot unlink the only sign-in of an account")
+)
+
+// LinkCodeTTL is how long an account link code stays valid.
+const LinkCodeTTL = 15 * time.Minute
+
+// Identity is one (provider, subject) pair a user can sign in with.
+type Identity struct {
+	ID        string    `db:"id"`
+	UserID    string    `db:"user_id"`
+	Provider  string    `db:"provider"`
+	Subject   string    `db:"subject"`
+	Email     string    `db:"email"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+// GetByIdentity returns the user the (provider, subject) sign-in belongs to,
+// or ErrNotFound.
+func (s *UserStore) GetByIdentity(ctx context.Context, provider, subject string) (*User, error) {
+	u, err := identityUser(ctx, s.db, provider, subject)
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && !inTenant(ctx, u.TenantID)) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+// identityUser looks up the owner of a sign-in regardless of tenant. It
+// returns sql.ErrNoRows when the sign-in is unknown.
+func identityUser(ctx context.Context, q sqlx.ExtContext, provider, subject string) (*User, error) {
+	var u User
+	err := sqlx.GetContext(ctx, q, &u, q.Rebind(`
+		SELECT u.* FROM users u JOIN user_identities i ON i.user_id = u.id
+		WHERE i.provider = ? AND i.subject = ?
+	`), provider, subject)
+	if err != nil {
+		return nil, err
+	}
+	return &u, nil
+}
+
+// Identities returns the sign-ins linked to userID, oldest first.
+func (s *UserStore) Identities(ctx context.Context, userID string) ([]*Identity, error) {
+	var out []*Identity
+	err := s.db.SelectContext(ctx, &out, s.q(`
+		SELECT * FROM user_identities WHERE user_id = ? ORDER BY created_at ASC
+	`), userID)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// LinkIdentity lets userID sign in with (provider, subject). Linking a
+// sign-in the user already has is a no-op; one that belongs to another
+// account returns ErrIdentityLinked.
+func (s *UserStore) LinkIdentity(ctx context.Context, userID, provider, subject, email string) error {
+	return linkIdentity(ctx, s.db, userID, provider, subject, email)
+}
+
+func linkIdentity(ctx context.Context, e sqlx.ExtContext, userID, provider, subject, email string) error {
+	owner, err := identityUser(ctx, e, provider, subject)
+	switch {
+	case err == nil && owner.ID == userID:
+		return nil
+	case err == nil:
+		return ErrIdentityLinked
+	case !errors.Is(err, sql.ErrNoRows):
+		return err
+	}
+	// An account created before identities were recorded still answers to
+	// its own provider and subject.
+	var count int
+	err = sqlx.GetContext(ctx, e, &count, e.Rebind(`
+		SELECT COUNT(*) FROM users WHERE provider = ? AND subject = ? AND id != ?
+	`), provider, subject, userID)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return ErrIdentityLinked
+	}
+	return insertIdentity(ctx, e, userID, provider, subject, email)
+}
+
+// ClaimIdentity links (provider, subject) to userID like LinkIdentity, except
+// that a sign-in which is the only one of another account brings that
+// account along: its links are reassigned to userID and it is deleted, as
+// when an admin deletes a user. A sign-in of an account with several
+// sign-ins, or of another tenant, returns ErrIdentityLinked.
+func (s *UserStore) ClaimIdentity(ctx context.Context, userID, provider, subject, email string) error {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err := claimIdentity(ctx, tx, userID, provider, subject, email); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// JoinByLinkCode redeems code, which userID must have created, and claims
+// (provider, subject) for userID as ClaimIdentity does. Both happen in one
+// transaction, so when the claim fails the code stays live and neither
+// account changes. It returns ErrNotFound if the code is unknown, used,
+// expired, or another account's.
+func (s *UserStore) JoinByLinkCode(ctx context.Context, code, userID, provider, subject, email string) error {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	owner, err := redeemLinkCode(ctx, tx, code)
+	if err != nil {
+		return err
+	}
+	if owner != userID {
+		return ErrNotFound
+	}
+	if err := claimIdentity(ctx, tx, userID, provider, subject, email); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func claimIdentity(ctx context.Context, tx *sqlx.Tx, userID, provider, subject, email string) error {
+	owner, err := identityUser(ctx, tx, provider, subject)
+	if errors.Is(err, sql.ErrNoRows) {
+		return linkIdentity(ctx, tx, userID, provider, subject, email)
+	}
+	if err != nil {
+		return err
+	}
+	if owner.ID == userID {
+		return nil
+	}
+	var target User
+	err = tx.GetContext(ctx, &target, tx.Rebind(`SELECT * FROM users WHERE id = ?`), userID)
+	if err == nil && !inTenant(ctx, target.TenantID) {
+		err = sql.ErrNoRows
+	}
+	if err != nil {
+		return err
+	}
+	if target.TenantID != owner.TenantID {
+		return ErrIdentityLinked
+	}
+	var others int
+	err = tx.GetContext(ctx, &others, tx.Rebind(`SELECT COUNT(*) FROM user_identities WHERE user_id = ?`), owner.ID)
+	if err != nil {
+		return err
+	}
+	if others != 1 {
+		return ErrIdentityLinked
+	}
+	// Deleting the account frees its sign-in.
+	if err := deleteUserWithLinks(ctx, tx, owner.ID, userID, "reassign"); err != nil {
+		return err
+	}
+	return insertIdentity(ctx, tx, userID, provider, subject, email)
+}
+
+func insertIdentity(ctx context.Context, e sqlx.ExtContext, userID, provider, subject, email string) error {
+	_, err := e.ExecContext(ctx, e.Rebind(`
+		INSERT INTO user_identities (id, user_id, provider, subject, email, created_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`), ids.New(), userID, provider, subject, email, time.Now().UTC())
+	return err
+}
+
+// UnlinkIdentity removes one of userID's sign-ins. It returns ErrNotFound if
+// the user has no such sign-in and ErrLastIdentity if it is their only one.
+// When the account's original sign-in goes, the oldest remaining one takes
+// its place on the users row.
+func (s *UserStore) UnlinkIdentity(ctx context.Context, userID, id string) error {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var all []*Identity
+	err = tx.SelectContext(ctx, &all, tx.Rebind(`
+		SELECT * FROM user_identities WHERE user_id = ? ORDER BY created_at ASC
+	`), userID)
+	if err != nil {
+		return err
+	}
+	var target *Identity
+	var rest []*Identity
+	for _, i := range all {
+		if i.ID == id {
+			target = i
+		} else {
+			rest = append(rest, i)
+		}
+	}
+	if target == nil {
+		return ErrNotFound
+	}
+	if len(rest) == 0 {
+		return ErrLastIdentity
+	}
+
+	if _, err := tx.ExecContext(ctx, tx.Rebind(`DELETE FROM user_identities WHERE id = ?`), id); err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, tx.Rebind(`
+		UPDATE users SET provider = ?, subject = ?, updated_at = ?
+		WHERE id = ? AND provider = ? AND subject = ?
+	`), rest[0].Provider, rest[0].Subject, time.Now().UTC(), userID, target.Provider, target.Subject)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// linkCodeBytes is the randomness in a link code: 128 bits, so codes cannot
+// be guessed within their lifetime.
+const linkCodeBytes = 16
+
+// CreateLinkCode returns a one-time code another account of the same user
+// can redeem to join userID. It expires after LinkCodeTTL.
+func (s *UserStore) CreateLinkCode(ctx context.Context, userID string) (string, error) {
+	raw := make([]byte, linkCodeBytes)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	code := base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(raw)
+	_, err := s.db.ExecContext(ctx, s.q(`
+		INSERT INTO account_link_codes (code_hash, user_id, expires_at) VALUES (?, ?, ?)
+	`), hashLinkCode(code), userID, time.Now().UTC().Add(LinkCodeTTL))
+	if err != nil {
+		return "", err
+	}
+	return code, nil
+}
+
+// LinkCodeUser returns the user that created code without consuming it, or
+// ErrNotFound if the code is unknown, used, or expired.
+func (s *UserStore) LinkCodeUser(ctx context.Context, code string) (string, error) {
+	return linkCodeUser(ctx, s.db, code)
+}
+
+func linkCodeUser(ctx context.Context, q sqlx.ExtContext, code string) (string, error) {
+	var c struct {
+		UserID    string    `db:"user_id"`
+		ExpiresAt time.Time `db:"expires_at"`
+	}
+	err := sqlx.GetContext(ctx, q, &c, q.Rebind(`SELECT user_id, expires_at FROM account_link_codes WHERE code_hash = ?`), hashLinkCode(code))
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && !time.Now().UTC().Before(c.ExpiresAt)) {
+		return "", ErrNotFound
+	}
+	if err != nil {
+		return "", err
+	}
+	return c.UserID, nil
+}
+
+// RedeemLinkCode consumes code and returns the user that created it, or
+// ErrNotFound if the code is unknown, used, or expired.
+func (s *UserStore) RedeemLinkCode(ctx context.Context, code string) (string, error) {
+	return redeemLinkCode(ctx, s.db, code)
+}
+
+func redeemLinkCode(ctx context.Context, e sqlx.ExtContext, code string) (string, error) {
+	userID, err := linkCodeUser(ctx, e, code)
+	if err != nil {
+		return "", err
+	}
+	res, err := e.ExecContext(ctx, e.Rebind(`DELETE FROM account_link_codes WHERE code_hash = ?`), hashLinkCode(code))
+	if err != nil {
+		return "", err
+	}
+	// Only the request that deleted the row may use it.
+	if n, _ := res.RowsAffected(); n == 0 {
+		return "", ErrNotFound
+	}
+	return userID, nil
+}
+
+// hashLinkCode normalises a code as typed (case, spaces, dashes) and hashes it.
+func hashLinkCode(code string) string {
+	code = strings.ToUpper(strings.NewReplacer(" ", "", "-", "").Replace(code))
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(code)))
+}
@@ -0,0 +1,177 @@
+package store_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/joestump/joe-links/internal/store"
+	"github.com/joestump/joe-links/internal/testutil"
+)
+
+// Governing: SPEC-0001 REQ "Account Linking"
+func TestUserStore_AccountLinking(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	ctx := context.Background()
+	us := store.NewUserStore(db)
+
+	alice, err := us.Upsert(ctx, "https://okta", "a1", "alice@example.com", "Alice", "user")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ids, _ := us.Identities(ctx, alice.ID); len(ids) != 1 || ids[0].Subject != "a1" {
+		t.Fatalf("identities after first login = %+v", ids)
+	}
+
+	// A linked sign-in resolves to the same account, but only records its
+	// own email: the profile and role follow the primary sign-in.
+	if err := us.LinkIdentity(ctx, alice.ID, "https://google", "g1", "alice@gmail.com"); err != nil {
+		t.Fatal(err)
+	}
+	u, err := us.Upsert(ctx, "https://google", "g1", "alice@personal.example", "Alice G", "admin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u.ID != alice.ID {
+		t.Errorf("linked sign-in gave %s, want %s", u.ID, alice.ID)
+	}
+	if u.Email != "alice@example.com" || u.DisplayName != "Alice" || u.DisplayNameSlug != alice.DisplayNameSlug || u.Role != "user" {
+		t.Errorf("linked sign-in changed the account to %q %q %q %q", u.Email, u.DisplayName, u.DisplayNameSlug, u.Role)
+	}
+	ids, _ := us.Identities(ctx, alice.ID)
+	for _, i := range ids {
+		if i.Subject == "g1" && i.Email != "alice@personal.example" {
+			t.Errorf("linked sign-in email = %q, want alice@personal.example", i.Email)
+		}
+	}
+
+	// A sign-in of another account cannot be linked directly.
+	bob, err := us.Upsert(ctx, "https://okta", "b1", "bob@example.com", "Bob", "user")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := us.LinkIdentity(ctx, alice.ID, "https://okta", "b1", ""); !errors.Is(err, store.ErrIdentityLinked) {
+		t.Errorf("link other account's sign-in: err = %v, want ErrIdentityLinked", err)
+	}
+
+	// Link codes work once.
+	code, err := us.CreateLinkCode(ctx, alice.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := us.RedeemLinkCode(ctx, code); err != nil || got != alice.ID {
+		t.Fatalf("redeem = %q, %v", got, err)
+	}
+	if _, err := us.RedeemLinkCode(ctx, code); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("second redeem: err = %v, want ErrNotFound", err)
+	}
+
+	// Claiming a duplicate account's only sign-in merges it in.
+	owns := store.NewOwnershipStore(db)
+	ls := store.NewLinkStore(db, owns, store.NewTagStore(db))
+	if _, err := ls.Create(ctx, "bobs-link", "https://example.com", bob.ID, "", "", "public"); err != nil {
+		t.Fatal(err)
+	}
+	if err := us.ClaimIdentity(ctx, alice.ID, "https://okta", "b1", "bob@example.com"); err != nil {
+		t.Fatalf("claim: %v", err)
+	}
+	if _, err := us.GetByID(ctx, bob.ID); err == nil {
+		t.Error("duplicate account not deleted")
+	}
+	if links, _ := ls.ListByOwner(ctx, alice.ID); len(links) != 1 {
+		t.Errorf("alice owns %d links after merge, want 1", len(links))
+	}
+	if u, err := us.GetByIdentity(ctx, "https://okta", "b1"); err != nil || u.ID != alice.ID {
+		t.Errorf("claimed sign-in resolves to %v, %v", u, err)
+	}
+
+	// Unlinking the original sign-in hands its place to the next one, and
+	// the removed sign-in becomes a new account on its next login.
+	ids, _ = us.Identities(ctx, alice.ID)
+	if len(ids) != 3 {
+		t.Fatalf("alice has %d sign-ins, want 3", len(ids))
+	}
+	if err := us.UnlinkIdentity(ctx, alice.ID, ids[0].ID); err != nil {
+		t.Fatal(err)
+	}
+	if u, _ := us.GetByID(ctx, alice.ID); u.Subject != "g1" {
+		t.Errorf("users.subject = %q, want g1", u.Subject)
+	}
+	if u, err := us.Upsert(ctx, "https://okta", "a1", "alice@example.com", "Alice", "user"); err != nil || u.ID == alice.ID {
+		t.Errorf("unlinked sign-in still signs in to alice: %v", err)
+	}
+	ids, _ = us.Identities(ctx, alice.ID)
+	if err := us.UnlinkIdentity(ctx, alice.ID, ids[0].ID); err != nil {
+		t.Fatal(err)
+	}
+	ids, _ = us.Identities(ctx, alice.ID)
+	if err := us.UnlinkIdentity(ctx, alice.ID, ids[0].ID); !errors.Is(err, store.ErrLastIdentity) {
+		t.Errorf("unlink last: err = %v, want ErrLastIdentity", err)
+	}
+}
+
+// Governing: SPEC-0001 REQ "Account Linking"
+func TestUserStore_JoinByLinkCodeIsAtomic(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	ctx := context.Background()
+	us := store.NewUserStore(db)
+	owns := store.NewOwnershipStore(db)
+	ls := store.NewLinkStore(db, owns, store.NewTagStore(db))
+
+	alice, err := us.Upsert(ctx, "https://okta", "a1", "alice@example.com", "Alice", "user")
+	if err != nil {
+		t.Fatal(err)
+	}
+	bob, err := us.Upsert(ctx, "https://google", "b1", "alice@gmail.com", "Alice G", "user")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ls.Create(ctx, "bobs-link", "https://example.com", bob.ID, "", "", "public"); err != nil {
+		t.Fatal(err)
+	}
+	code, err := us.CreateLinkCode(ctx, alice.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A code is only redeemed by the account that created it.
+	if err := us.JoinByLinkCode(ctx, code, bob.ID, "https://google", "b1", ""); !errors.Is(err, store.ErrNotFound) {
+		t.Fatalf("join with another account's code: err = %v, want ErrNotFound", err)
+	}
+
+	// A failure after the duplicate account is deleted undoes the whole join.
+	if _, err := db.Exec(`CREATE TRIGGER identities_down BEFORE INSERT ON user_identities BEGIN SELECT RAISE(ABORT, 'database is unavailable'); END`); err != nil {
+		t.Fatal(err)
+	}
+	if err := us.JoinByLinkCode(ctx, code, alice.ID, "https://google", "b1", "alice@gmail.com"); err == nil {
+		t.Fatal("join succeeded with identity inserts failing")
+	}
+	if _, err := us.GetByID(ctx, bob.ID); err != nil {
+		t.Errorf("duplicate account deleted by a failed join: %v", err)
+	}
+	if links, _ := ls.ListByOwner(ctx, bob.ID); len(links) != 1 {
+		t.Errorf("duplicate account owns %d links after a failed join, want 1", len(links))
+	}
+	if u, err := us.GetByIdentity(ctx, "https://google", "b1"); err != nil || u.ID != bob.ID {
+		t.Errorf("sign-in resolves to %v, %v after a failed join", u, err)
+	}
+	if got, err := us.LinkCodeUser(ctx, code); err != nil || got != alice.ID {
+		t.Errorf("code used up by a failed join: %q, %v", got, err)
+	}
+
+	if _, err := db.Exec(`DROP TRIGGER identities_down`); err != nil {
+		t.Fatal(err)
+	}
+	if err := us.JoinByLinkCode(ctx, code, alice.ID, "https://google", "b1", "alice@gmail.com"); err != nil {
+		t.Fatalf("join: %v", err)
+	}
+	if u, err := us.GetByIdentity(ctx, "https://google", "b1"); err != nil || u.ID != alice.ID {
+		t.Errorf("joined sign-in resolves to %v, %v", u, err)
+	}
+	if links, _ := ls.ListByOwner(ctx, alice.ID); len(links) != 1 {
+		t.Errorf("alice owns %d links after the join, want 1", len(links))
+	}
+	if _, err := us.LinkCodeUser(ctx, code); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("code still live after the join: %v", err)
+	}
+}
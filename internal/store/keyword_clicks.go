@@ -0,0 +1,106 @@
+// Governing: SPEC-0016 REQ "Keyword Analytics"
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// KeywordUsageWindow is how far back the admin keywords screen counts hits.
+const KeywordUsageWindow = 30 * 24 * time.Hour
+
+// splitKeywordClicks separates keyword hits from link clicks.
+func splitKeywordClicks(events []ClickEvent) (links, keywords []ClickEvent) {
+	for _, e := range events {
+		if e.KeywordID != "" {
+			keywords = append(keywords, e)
+		} else {
+			links = append(links, e)
+		}
+	}
+	return links, keywords
+}
+
+// addKeywordClicks adds keyword hits to their daily counts. Bot hits are not
+// counted. Counts are written one bucket at a time, like API usage, so a
+// bucket created concurrently by another instance is retried as an update.
+func (s *ClickStore) addKeywordClicks(ctx context.Context, events []ClickEvent) error {
+	type bucket struct{ keywordID, day string }
+	counts := make(map[bucket]int64)
+	var order []bucket
+	for _, e := range events {
+		if e.Bot {
+			continue
+		}
+		at := e.ClickedAt
+		if at.IsZero() {
+			at = time.Now()
+		}
+		b := bucket{e.KeywordID, at.UTC().Format(UsageDayFormat)}
+		if _, ok := counts[b]; !ok {
+			order = append(order, b)
+		}
+		counts[b]++
+	}
+	update := s.q(`UPDATE keyword_clicks SET clicks = clicks + ? WHERE keyword_id = ? AND day = ?`)
+	for _, b := range order {
+		res, err := s.db.ExecContext(ctx, update, counts[b], b.keywordID, b.day)
+		if err != nil {
+			return err
+		}
+		if n, _ := res.RowsAffected(); n > 0 {
+			continue
+		}
+		_, err = s.db.ExecContext(ctx, s.q(`
+			INSERT INTO keyword_clicks (keyword_id, day, clicks) VALUES (?, ?, ?)
+		`), b.keywordID, b.day, counts[b])
+		if isUniqueConstraintError(err) {
+			_, err = s.db.ExecContext(ctx, update, counts[b], b.keywordID, b.day)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AttachUsage sets Clicks to each keyword's hits on or after since and
+// LastUsed to the last day it was hit at all.
+func (s *KeywordStore) AttachUsage(ctx context.Context, keywords []*Keyword, since time.Time) error {
+	if len(keywords) == 0 {
+		return nil
+	}
+	byID := make(map[string]*Keyword, len(keywords))
+	ids := make([]string, 0, len(keywords))
+	for _, k := range keywords {
+		byID[k.ID] = k
+		ids = append(ids, k.ID)
+	}
+	query, args, err := sqlx.In(`
+		SELECT keyword_id,
+		       COALESCE(SUM(CASE WHEN day >= ? THEN clicks ELSE 0 END), 0) AS clicks,
+		       MAX(day) AS last_day
+		FROM keyword_clicks
+		WHERE keyword_id IN (?)
+		GROUP BY keyword_id
+	`, since.UTC().Format(UsageDayFormat), ids)
+	if err != nil {
+		return err
+	}
+	var rows []struct {
+		KeywordID string `db:"keyword_id"`
+		Clicks    int64  `db:"clicks"`
+		LastDay   string `db:"last_day"`
+	}
+	if err := s.db.SelectContext(ctx, &rows, s.q(query), args...); err != nil {
+		return err
+	}
+	for _, r := range rows {
+		if k, ok := byID[r.KeywordID]; ok {
+			k.Clicks, k.LastUsed = r.Clicks, r.LastDay
+		}
+	}
+	return nil
+}
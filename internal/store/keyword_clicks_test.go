@@ -0,0 +1,80 @@
+// Governing: SPEC-0016 REQ "Keyword Analytics"
+package store_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/joestump/joe-links/internal/store"
+	"github.com/joestump/joe-links/internal/testutil"
+)
+
+func TestKeywordClicks(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	cs := store.NewClickStore(db)
+	ks := store.NewKeywordStore(db)
+	ctx := context.Background()
+
+	jira, err := ks.Create(ctx, "jira", "https://jira.example.com/browse/{slug}", "")
+	if err != nil {
+		t.Fatalf("Create jira: %v", err)
+	}
+	if _, err := ks.Create(ctx, "gh", "https://github.com/{slug}", ""); err != nil {
+		t.Fatalf("Create gh: %v", err)
+	}
+
+	now := time.Now().UTC()
+	old := now.AddDate(0, 0, -40)
+	n, err := cs.RecordClicks(ctx, []store.ClickEvent{
+		{KeywordID: jira.ID, ClickedAt: now},
+		{KeywordID: jira.ID, ClickedAt: now},
+		{KeywordID: jira.ID, ClickedAt: now, Bot: true},
+		{KeywordID: jira.ID, ClickedAt: old},
+	})
+	if err != nil || n != 4 {
+		t.Fatalf("RecordClicks = %d, %v; want 4, nil", n, err)
+	}
+	if err := cs.RecordClick(ctx, store.ClickEvent{KeywordID: jira.ID, ClickedAt: now}); err != nil {
+		t.Fatalf("RecordClick: %v", err)
+	}
+
+	keywords, err := ks.List(ctx)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if err := ks.AttachUsage(ctx, keywords, now.Add(-store.KeywordUsageWindow)); err != nil {
+		t.Fatalf("AttachUsage: %v", err)
+	}
+	for _, k := range keywords {
+		switch k.Keyword {
+		case "jira":
+			if k.Clicks != 3 || k.LastUsed != now.Format(store.UsageDayFormat) {
+				t.Errorf("jira usage = %d, %q; want 3 hits last used today", k.Clicks, k.LastUsed)
+			}
+		case "gh":
+			if k.Clicks != 0 || k.LastUsed != "" {
+				t.Errorf("gh usage = %d, %q; want none", k.Clicks, k.LastUsed)
+			}
+		}
+	}
+
+	var linkClicks int
+	if err := db.Get(&linkClicks, `SELECT COUNT(*) FROM link_clicks`); err != nil {
+		t.Fatalf("count link_clicks: %v", err)
+	}
+	if linkClicks != 0 {
+		t.Errorf("link_clicks has %d rows, want keyword hits kept out of it", linkClicks)
+	}
+
+	if err := ks.Delete(ctx, jira.ID); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	var left int
+	if err := db.Get(&left, `SELECT COUNT(*) FROM keyword_clicks`); err != nil {
+		t.Fatalf("count keyword_clicks: %v", err)
+	}
+	if left != 0 {
+		t.Errorf("keyword_clicks has %d rows after delete, want 0", left)
+	}
+}
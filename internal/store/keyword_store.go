@@ -6,8 +6,9 @@ import (
 	"database/sql"
 	"time"
 
-	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
+	"github.com/joestump/joe-links/internal/ids"
+	"github.com/joestump/joe-links/internal/metrics"
 )
 
 // Keyword represents a row in the keywords table.
@@ -17,6 +18,21 @@ type Keyword struct {
 	URLTemplate string    `db:"url_template"`
 	Description string    `db:"description"`
 	CreatedAt   time.Time `db:"created_at"`
+
+	// TenantID is the tenant the keyword belongs to; empty for the default tenant.
+	// Governing: SPEC-0001 REQ "Multi-Tenancy"
+	TenantID string `db:"tenant_id"`
+
+	// Secure keywords only redirect signed-in users.
+	// Governing: SPEC-0008 REQ "Secure Keywords"
+	Secure bool `db:"secure"`
+
+	// Clicks is the number of non-bot hits since the window passed to
+	// AttachUsage, and LastUsed the last day (YYYY-MM-DD) it was hit; both
+	// are zero when not loaded.
+	// Governing: SPEC-0016 REQ "Keyword Analytics"
+	Clicks   int64  `db:"-"`
+	LastUsed string `db:"-"`
 }
 
 // KeywordStore is the sqlx-backed store for keyword operations.
@@ -32,10 +48,11 @@ func NewKeywordStore(db *sqlx.DB) *KeywordStore {
 // q rebinds ? placeholders to the driver's native format ($1,$2,... for PostgreSQL).
 func (s *KeywordStore) q(query string) string { return s.db.Rebind(query) }
 
-// List returns all keywords ordered by keyword name.
+// List returns the keywords of the tenant ctx is scoped to, ordered by keyword name.
 func (s *KeywordStore) List(ctx context.Context) ([]*Keyword, error) {
 	var keywords []*Keyword
-	err := s.db.SelectContext(ctx, &keywords, `SELECT * FROM keywords ORDER BY keyword ASC`)
+	scope, args := tenantScope(ctx, "tenant_id")
+	err := s.db.SelectContext(ctx, &keywords, s.q(`SELECT * FROM keywords WHERE 1 = 1`+scope+` ORDER BY keyword ASC`), args...)
 	if err != nil {
 		return nil, err
 	}
@@ -46,7 +63,7 @@ func (s *KeywordStore) List(ctx context.Context) ([]*Keyword, error) {
 func (s *KeywordStore) GetByID(ctx context.Context, id string) (*Keyword, error) {
 	var k Keyword
 	err := s.db.GetContext(ctx, &k, s.q(`SELECT * FROM keywords WHERE id = ?`), id)
-	if err == sql.ErrNoRows {
+	if err == sql.ErrNoRows || (err == nil && !inTenant(ctx, k.TenantID)) {
 		return nil, ErrNotFound
 	}
 	if err != nil {
@@ -57,8 +74,10 @@ func (s *KeywordStore) GetByID(ctx context.Context, id string) (*Keyword, error)
 
 // GetByKeyword returns the keyword matching the given keyword string, or ErrNotFound.
 func (s *KeywordStore) GetByKeyword(ctx context.Context, keyword string) (*Keyword, error) {
+	defer metrics.ObserveDBQuery("keyword_get_by_keyword", time.Now())
 	var k Keyword
-	err := s.db.GetContext(ctx, &k, s.q(`SELECT * FROM keywords WHERE keyword = ?`), keyword)
+	scope, args := tenantScope(ctx, "tenant_id")
+	err := s.db.GetContext(ctx, &k, s.q(`SELECT * FROM keywords WHERE keyword = ?`+scope), append([]interface{}{keyword}, args...)...)
 	if err == sql.ErrNoRows {
 		return nil, ErrNotFound
 	}
@@ -70,22 +89,23 @@ func (s *KeywordStore) GetByKeyword(ctx context.Context, keyword string) (*Keywo
 
 // Create inserts a new keyword and returns it.
 func (s *KeywordStore) Create(ctx context.Context, keyword, urlTemplate, description string) (*Keyword, error) {
-	id := uuid.New().String()
+	id := ids.New()
 	now := time.Now().UTC()
+	tenantID, _ := TenantFromContext(ctx) // Governing: SPEC-0001 REQ "Multi-Tenancy"
 	_, err := s.db.ExecContext(ctx, s.q(`
-		INSERT INTO keywords (id, keyword, url_template, description, created_at) VALUES (?, ?, ?, ?, ?)
-	`), id, keyword, urlTemplate, description, now)
+		INSERT INTO keywords (id, keyword, url_template, description, tenant_id, created_at) VALUES (?, ?, ?, ?, ?, ?)
+	`), id, keyword, urlTemplate, description, tenantID, now)
 	if err != nil {
 		return nil, err
 	}
-	return &Keyword{ID: id, Keyword: keyword, URLTemplate: urlTemplate, Description: description, CreatedAt: now}, nil
+	return &Keyword{ID: id, Keyword: keyword, URLTemplate: urlTemplate, Description: description, CreatedAt: now, TenantID: tenantID}, nil
 }
 
 // Update updates an existing keyword and returns it.
 func (s *KeywordStore) Update(ctx context.Context, id, keyword, urlTemplate, description string) (*Keyword, error) {
-	result, err := s.db.ExecContext(ctx, s.q(`
-		UPDATE keywords SET keyword = ?, url_template = ?, description = ? WHERE id = ?
-	`), keyword, urlTemplate, description, id)
+	scope, args := tenantScope(ctx, "tenant_id")
+	result, err := s.db.ExecContext(ctx, s.q(`UPDATE keywords SET keyword = ?, url_template = ?, description = ? WHERE id = ?`+scope),
+		append([]interface{}{keyword, urlTemplate, description, id}, args...)...)
 	if err != nil {
 		return nil, err
 	}
@@ -105,9 +125,34 @@ func (s *KeywordStore) Update(ctx context.Context, id, keyword, urlTemplate, des
 	return &k, nil
 }
 
+// SetSecure sets whether the keyword only redirects signed-in users.
+// Returns ErrNotFound if no such keyword exists.
+// Governing: SPEC-0008 REQ "Secure Keywords"
+func (s *KeywordStore) SetSecure(ctx context.Context, id string, secure bool) error {
+	flag := 0
+	if secure {
+		flag = 1
+	}
+	scope, args := tenantScope(ctx, "tenant_id")
+	result, err := s.db.ExecContext(ctx, s.q(`UPDATE keywords SET secure = ? WHERE id = ?`+scope),
+		append([]interface{}{flag, id}, args...)...)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
 // Delete removes a keyword by ID.
 func (s *KeywordStore) Delete(ctx context.Context, id string) error {
-	result, err := s.db.ExecContext(ctx, s.q(`DELETE FROM keywords WHERE id = ?`), id)
+	scope, args := tenantScope(ctx, "tenant_id")
+	result, err := s.db.ExecContext(ctx, s.q(`DELETE FROM keywords WHERE id = ?`+scope), append([]interface{}{id}, args...)...)
 	if err != nil {
 		return err
 	}
@@ -118,5 +163,8 @@ func (s *KeywordStore) Delete(ctx context.Context, id string) error {
 	if rows == 0 {
 		return ErrNotFound
 	}
-	return nil
+	// SQLite does not enforce the cascade unless foreign keys are switched on.
+	// Governing: SPEC-0016 REQ "Keyword Analytics"
+	_, err = s.db.ExecContext(ctx, s.q(`DELETE FROM keyword_clicks WHERE keyword_id = ?`), id)
+	return err
 }
@@ -0,0 +1,119 @@
+// Governing: SPEC-0002 REQ "Link Aliases"
+package store
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Alias is an additional slug that resolves to a link.
+type Alias struct {
+	Slug      string    `db:"slug"`
+	LinkID    string    `db:"link_id"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+// SlugInUse reports whether slug is taken by a link of the tenant ctx is
+// scoped to or by an alias. Alias slugs are unique across all tenants.
+func (s *LinkStore) SlugInUse(ctx context.Context, slug string) (bool, error) {
+	var count int
+	scope, args := tenantScope(ctx, "tenant_id")
+	err := s.db.GetContext(ctx, &count, s.q(`
+		SELECT (SELECT COUNT(*) FROM links WHERE slug = ?`+scope+`) + (SELECT COUNT(*) FROM link_aliases WHERE slug = ?)
+	`), append(append([]interface{}{slug}, args...), slug)...)
+	return count > 0, err
+}
+
+// AddAlias makes linkID reachable under slug as well. Returns ErrSlugReserved
+// if slug is reserved and ErrSlugTaken if it is already a link slug or an alias.
+func (s *LinkStore) AddAlias(ctx context.Context, linkID, slug string) (*Alias, error) {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	// Governing: SPEC-0002 REQ "Reserved Slugs"
+	if reserved, err := slugReserved(ctx, tx, slug); err != nil {
+		return nil, err
+	} else if reserved {
+		return nil, fmt.Errorf("%w: %q", ErrSlugReserved, slug)
+	}
+	var count int
+	scope, args := tenantScope(ctx, "tenant_id")
+	if err := tx.GetContext(ctx, &count, tx.Rebind(`SELECT COUNT(*) FROM links WHERE slug = ?`+scope), append([]interface{}{slug}, args...)...); err != nil {
+		return nil, err
+	}
+	if count > 0 {
+		return nil, ErrSlugTaken
+	}
+	a := &Alias{Slug: slug, LinkID: linkID, CreatedAt: time.Now().UTC()}
+	_, err = tx.ExecContext(ctx, tx.Rebind(`
+		INSERT INTO link_aliases (slug, link_id, created_at) VALUES (?, ?, ?)
+	`), a.Slug, a.LinkID, a.CreatedAt)
+	if err != nil {
+		if isUniqueConstraintError(err) {
+			return nil, ErrSlugTaken
+		}
+		return nil, err
+	}
+	s.noteSlug(a.Slug) // Governing: SPEC-0009 REQ "Slug Filter Fast Path"
+	return a, tx.Commit()
+}
+
+// ListAliases returns linkID's aliases in slug order.
+func (s *LinkStore) ListAliases(ctx context.Context, linkID string) ([]*Alias, error) {
+	aliases := []*Alias{}
+	err := s.db.SelectContext(ctx, &aliases, s.q(`
+		SELECT * FROM link_aliases WHERE link_id = ? ORDER BY slug ASC
+	`), linkID)
+	return aliases, err
+}
+
+// RemoveAlias deletes linkID's alias slug, or returns ErrNotFound if linkID
+// has no such alias.
+func (s *LinkStore) RemoveAlias(ctx context.Context, linkID, slug string) error {
+	res, err := s.db.ExecContext(ctx, s.q(`
+		DELETE FROM link_aliases WHERE link_id = ? AND slug = ?
+	`), linkID, slug)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// ListKnownSlugs returns every link slug and alias slug userID can know
+// exists, sorted. Secure links are left out unless userID owns them or they
+// are shared with userID; admins (all true) see every slug. It backs the
+// browser extension's slug Bloom filter.
+// Governing: SPEC-0008 REQ "Offline Slug Lookup", SPEC-0010 REQ "Secure Link Resolution"
+func (s *LinkStore) ListKnownSlugs(ctx context.Context, userID string, all bool) ([]string, error) {
+	known := `(l.visibility <> 'secure'
+		OR EXISTS (SELECT 1 FROM link_owners lo WHERE lo.link_id = l.id AND lo.user_id = ?)
+		OR EXISTS (SELECT 1 FROM link_shares ls WHERE ls.link_id = l.id AND ls.user_id = ?))`
+	args := []interface{}{userID, userID, userID, userID}
+	if all {
+		known, args = "1 = 1", nil
+	}
+	// Governing: SPEC-0001 REQ "Multi-Tenancy"
+	if tenantID, ok := TenantFromContext(ctx); ok {
+		known += ` AND l.tenant_id = ?`
+		if all {
+			args = []interface{}{tenantID, tenantID}
+		} else {
+			args = []interface{}{userID, userID, tenantID, userID, userID, tenantID}
+		}
+	}
+	var slugs []string
+	err := s.db.SelectContext(ctx, &slugs, s.q(`
+		SELECT l.slug FROM links l WHERE `+known+`
+		UNION ALL
+		SELECT a.slug FROM link_aliases a JOIN links l ON l.id = a.link_id WHERE `+known+`
+		ORDER BY 1
+	`), args...)
+	return slugs, err
+}
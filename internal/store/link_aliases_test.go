@@ -0,0 +1,61 @@
+// Governing: SPEC-0002 REQ "Link Aliases"
+package store_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/joestump/joe-links/internal/store"
+)
+
+func TestLinkAliases(t *testing.T) {
+	ls, _, _, userID := newTestEnv(t)
+	ctx := context.Background()
+
+	vacation, err := ls.Create(ctx, "vacation", "https://hr.example.com/pto", userID, "", "", "")
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if _, err := ls.Create(ctx, "payroll", "https://hr.example.com/pay", userID, "", "", ""); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	if _, err := ls.AddAlias(ctx, vacation.ID, "pto"); err != nil {
+		t.Fatalf("AddAlias: %v", err)
+	}
+	if _, err := ls.AddAlias(ctx, vacation.ID, "payroll"); !errors.Is(err, store.ErrSlugTaken) {
+		t.Errorf("alias of existing link slug: err = %v, want ErrSlugTaken", err)
+	}
+	if _, err := ls.AddAlias(ctx, vacation.ID, "pto"); !errors.Is(err, store.ErrSlugTaken) {
+		t.Errorf("duplicate alias: err = %v, want ErrSlugTaken", err)
+	}
+	if _, err := ls.Create(ctx, "pto", "https://example.com", userID, "", "", ""); !errors.Is(err, store.ErrSlugTaken) {
+		t.Errorf("link slug colliding with alias: err = %v, want ErrSlugTaken", err)
+	}
+	if inUse, err := ls.SlugInUse(ctx, "pto"); err != nil || !inUse {
+		t.Errorf("SlugInUse(pto) = %v, %v", inUse, err)
+	}
+
+	link, matched, err := ls.GetByPathPrefix(ctx, "pto/2026")
+	if err != nil {
+		t.Fatalf("GetByPathPrefix: %v", err)
+	}
+	if link.ID != vacation.ID || matched != "pto" {
+		t.Errorf("GetByPathPrefix = %s via %q, want %s via pto", link.Slug, matched, vacation.Slug)
+	}
+
+	aliases, err := ls.ListAliases(ctx, vacation.ID)
+	if err != nil || len(aliases) != 1 || aliases[0].Slug != "pto" {
+		t.Fatalf("ListAliases = %+v, %v", aliases, err)
+	}
+	if err := ls.RemoveAlias(ctx, vacation.ID, "pto"); err != nil {
+		t.Fatalf("RemoveAlias: %v", err)
+	}
+	if err := ls.RemoveAlias(ctx, vacation.ID, "pto"); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("second RemoveAlias: err = %v, want ErrNotFound", err)
+	}
+	if _, _, err := ls.GetByPathPrefix(ctx, "pto"); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("removed alias still resolves: err = %v", err)
+	}
+}
@@ -0,0 +1,504 @@
+// Governing: SPEC-0011 REQ "Link Lifecycle Policies"
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/joestump/joe-links/internal/ids"
+)
+
+// Policy rules. Each is checked against the links a policy's scope selects.
+const (
+	PolicyRequireTitle       = "require_title"
+	PolicyRequireDescription = "require_description"
+	PolicyRequireTeam        = "require_team"
+	PolicyMaxAge             = "max_age" // links older than Days days
+)
+
+// Policy enforcement levels. Warn records a violation for the link's owners to
+// fix. Block refuses to save a link that violates a content rule, and has the
+// nightly sweep delete links past a max_age limit.
+const (
+	PolicyWarn  = "warn"
+	PolicyBlock = "block"
+)
+
+// PolicyRules lists the valid rules in display order.
+var PolicyRules = []string{PolicyRequireTitle, PolicyRequireDescription, PolicyRequireTeam, PolicyMaxAge}
+
+var (
+	// ErrPolicyNameRequired is returned when a policy is created without a name.
+	ErrPolicyNameRequired = errors.New("policy name is required")
+	// ErrPolicyNameTaken is returned when another policy already has the name.
+	ErrPolicyNameTaken = errors.New("policy name already in use")
+	// ErrInvalidPolicy is returned for an unknown rule or enforcement level,
+	// or a max_age policy without a positive number of days.
+	ErrInvalidPolicy = errors.New("invalid policy")
+	// ErrPolicyViolation is returned by CheckLink when a link breaks a policy
+	// enforced with PolicyBlock.
+	ErrPolicyViolation = errors.New("link violates policy")
+)
+
+// LinkPolicy is an admin-defined rule for the links its scope selects.
+// Scope is a link search filter (owner:, tag:, team:, visibility:); an empty
+// scope covers every link.
+type LinkPolicy struct {
+	ID          string    `db:"id"`
+	Name        string    `db:"name"`
+	Scope       string    `db:"scope"`
+	Rule        string    `db:"rule"`
+	Days        int       `db:"days"`
+	Enforcement string    `db:"enforcement"`
+	CreatedAt   time.Time `db:"created_at"`
+}
+
+// Summary describes the policy's rule, e.g. "Expire after 30 days".
+func (p *LinkPolicy) Summary() string {
+	switch p.Rule {
+	case PolicyRequireTitle:
+		return "Require a title"
+	case PolicyRequireDescription:
+		return "Require a description"
+	case PolicyRequireTeam:
+		return "Require an owning team"
+	case PolicyMaxAge:
+		if p.Enforcement == PolicyBlock {
+			return fmt.Sprintf("Expire after %d days", p.Days)
+		}
+		return fmt.Sprintf("Flag after %d days", p.Days)
+	}
+	return p.Rule
+}
+
+// PolicyViolation is a policy a link currently breaks. PolicyName, Enforcement,
+// and Slug are filled in when violations are listed.
+type PolicyViolation struct {
+	PolicyID    string    `db:"policy_id"`
+	LinkID      string    `db:"link_id"`
+	Message     string    `db:"message"`
+	DetectedAt  time.Time `db:"detected_at"`
+	PolicyName  string    `db:"policy_name"`
+	Enforcement string    `db:"enforcement"`
+	Slug        string    `db:"slug"`
+}
+
+// PolicySubject is a link as policies see it, before or after it is saved.
+type PolicySubject struct {
+	LinkID      string // empty for a link not yet created
+	Title       string
+	Description string
+	Visibility  string
+	TeamID      string
+	Tags        []string  // tag names or slugs
+	OwnerIDs    []string  // user IDs
+	CreatedAt   time.Time // zero for a link not yet created
+}
+
+// PolicySweep summarizes one run of Sweep.
+type PolicySweep struct {
+	Checked    int // links evaluated
+	Violations int // violations recorded
+	Expired    int // links deleted by max_age policies enforced with block
+}
+
+// PolicyStore is the sqlx-backed store for link policies and their violations.
+type PolicyStore struct {
+	db    *sqlx.DB
+	links *LinkStore
+}
+
+// NewPolicyStore creates a new PolicyStore. links resolves owner: scopes and
+// deletes expired links.
+func NewPolicyStore(db *sqlx.DB, links *LinkStore) *PolicyStore {
+	return &PolicyStore{db: db, links: links}
+}
+
+// q rebinds ? placeholders to the driver's native format ($1,$2,... for PostgreSQL).
+func (s *PolicyStore) q(query string) string { return s.db.Rebind(query) }
+
+// List returns every policy ordered by name.
+func (s *PolicyStore) List(ctx context.Context) ([]*LinkPolicy, error) {
+	var policies []*LinkPolicy
+	if err := s.db.SelectContext(ctx, &policies, `SELECT * FROM link_policies ORDER BY name ASC`); err != nil {
+		return nil, err
+	}
+	return policies, nil
+}
+
+// Create adds a policy. Returns ErrPolicyNameRequired, ErrInvalidPolicy,
+// ErrInvalidFilter for a scope that cannot be parsed or uses free text, or
+// ErrPolicyNameTaken. It does not re-check existing links; the next sweep does.
+func (s *PolicyStore) Create(ctx context.Context, name, scope, rule string, days int, enforcement string) (*LinkPolicy, error) {
+	name, scope = strings.TrimSpace(name), strings.TrimSpace(scope)
+	if name == "" {
+		return nil, ErrPolicyNameRequired
+	}
+	switch rule {
+	case PolicyRequireTitle, PolicyRequireDescription, PolicyRequireTeam:
+		days = 0
+	case PolicyMaxAge:
+		if days <= 0 {
+			return nil, fmt.Errorf("%w: max_age needs a positive number of days", ErrInvalidPolicy)
+		}
+	default:
+		return nil, fmt.Errorf("%w: unknown rule %q", ErrInvalidPolicy, rule)
+	}
+	if enforcement == "" {
+		enforcement = PolicyWarn
+	}
+	if enforcement != PolicyWarn && enforcement != PolicyBlock {
+		return nil, fmt.Errorf("%w: enforcement must be warn or block", ErrInvalidPolicy)
+	}
+	f, err := ParseLinkFilter(scope)
+	if err != nil {
+		return nil, err
+	}
+	if f.Text != "" {
+		return nil, fmt.Errorf("%w: policy scopes use owner:, tag:, team:, and visibility: filters only", ErrInvalidFilter)
+	}
+
+	p := &LinkPolicy{
+		ID:          ids.New(),
+		Name:        name,
+		Scope:       scope,
+		Rule:        rule,
+		Days:        days,
+		Enforcement: enforcement,
+		CreatedAt:   time.Now().UTC(),
+	}
+	_, err = s.db.ExecContext(ctx, s.q(`
+		INSERT INTO link_policies (id, name, scope, rule, days, enforcement, created_at) VALUES (?, ?, ?, ?, ?, ?, ?)
+	`), p.ID, p.Name, p.Scope, p.Rule, p.Days, p.Enforcement, p.CreatedAt)
+	if err != nil {
+		if isUniqueConstraintError(err) {
+			return nil, ErrPolicyNameTaken
+		}
+		return nil, err
+	}
+	return p, nil
+}
+
+// Delete removes a policy and its recorded violations. Returns ErrNotFound if
+// there is no such policy.
+func (s *PolicyStore) Delete(ctx context.Context, id string) error {
+	res, err := s.db.ExecContext(ctx, s.q(`DELETE FROM link_policies WHERE id = ?`), id)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// CheckLink evaluates subj against every policy before it is saved. Content
+// rules enforced with block fail with an error wrapping ErrPolicyViolation;
+// every other violation is returned for RecordViolations. max_age rules only
+// warn here: expiry is left to Sweep.
+func (s *PolicyStore) CheckLink(ctx context.Context, subj PolicySubject) ([]*PolicyViolation, error) {
+	policies, err := s.compile(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var warnings []*PolicyViolation
+	for _, v := range evaluatePolicies(policies, subj, time.Now()) {
+		if v.Enforcement == PolicyBlock && v.rule != PolicyMaxAge {
+			return nil, fmt.Errorf("%w %q: %s", ErrPolicyViolation, v.PolicyName, v.Message)
+		}
+		warnings = append(warnings, &v.PolicyViolation)
+	}
+	return warnings, nil
+}
+
+// RecordViolations replaces linkID's recorded violations with vs.
+func (s *PolicyStore) RecordViolations(ctx context.Context, linkID string, vs []*PolicyViolation) error {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.ExecContext(ctx, tx.Rebind(`DELETE FROM link_policy_violations WHERE link_id = ?`), linkID); err != nil {
+		return err
+	}
+	now := time.Now().UTC()
+	for _, v := range vs {
+		if _, err := tx.ExecContext(ctx, tx.Rebind(`
+			INSERT INTO link_policy_violations (policy_id, link_id, message, detected_at) VALUES (?, ?, ?, ?)
+		`), v.PolicyID, linkID, v.Message, now); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// ListViolationsByOwner returns the recorded violations on links userID owns,
+// ordered by slug.
+func (s *PolicyStore) ListViolationsByOwner(ctx context.Context, userID string) ([]*PolicyViolation, error) {
+	return s.listViolations(ctx, `EXISTS (SELECT 1 FROM link_owners lo WHERE lo.link_id = v.link_id AND lo.user_id = ?)`, userID)
+}
+
+// ListViolationsByLink returns the recorded violations on linkID.
+func (s *PolicyStore) ListViolationsByLink(ctx context.Context, linkID string) ([]*PolicyViolation, error) {
+	return s.listViolations(ctx, `v.link_id = ?`, linkID)
+}
+
+func (s *PolicyStore) listViolations(ctx context.Context, where string, args ...interface{}) ([]*PolicyViolation, error) {
+	var vs []*PolicyViolation
+	err := s.db.SelectContext(ctx, &vs, s.q(`
+		SELECT v.policy_id, v.link_id, v.message, v.detected_at,
+		       p.name AS policy_name, p.enforcement, l.slug
+		FROM link_policy_violations v
+		INNER JOIN link_policies p ON p.id = v.policy_id
+		INNER JOIN links l ON l.id = v.link_id
+		WHERE `+where+`
+		ORDER BY l.slug ASC, p.name ASC
+	`), args...)
+	if err != nil {
+		return nil, err
+	}
+	return vs, nil
+}
+
+// Sweep evaluates every link against every policy as of now, replacing all
+// recorded violations, and deletes links past the limit of a max_age policy
+// enforced with block.
+func (s *PolicyStore) Sweep(ctx context.Context, now time.Time) (PolicySweep, error) {
+	var result PolicySweep
+	policies, err := s.compile(ctx)
+	if err != nil {
+		return result, err
+	}
+	subjects, err := s.subjects(ctx)
+	if err != nil {
+		return result, err
+	}
+
+	var found []*PolicyViolation
+	for _, subj := range subjects {
+		result.Checked++
+		vs := evaluatePolicies(policies, subj, now)
+		expired := false
+		for _, v := range vs {
+			if v.rule == PolicyMaxAge && v.Enforcement == PolicyBlock {
+				expired = true
+			}
+		}
+		if expired {
+			if err := s.links.Delete(ctx, subj.LinkID); err != nil {
+				return result, err
+			}
+			result.Expired++
+			continue
+		}
+		for _, v := range vs {
+			found = append(found, &v.PolicyViolation)
+		}
+	}
+
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return result, err
+	}
+	defer func() { _ = tx.Rollback() }()
+	if _, err := tx.ExecContext(ctx, `DELETE FROM link_policy_violations`); err != nil {
+		return result, err
+	}
+	for _, v := range found {
+		if _, err := tx.ExecContext(ctx, tx.Rebind(`
+			INSERT INTO link_policy_violations (policy_id, link_id, message, detected_at) VALUES (?, ?, ?, ?)
+		`), v.PolicyID, v.LinkID, v.Message, now.UTC()); err != nil {
+			return result, err
+		}
+	}
+	result.Violations = len(found)
+	return result, tx.Commit()
+}
+
+// subjects loads every link with its tag slugs and owners.
+func (s *PolicyStore) subjects(ctx context.Context) ([]PolicySubject, error) {
+	var links []*Link
+	if err := s.db.SelectContext(ctx, &links, `SELECT * FROM links ORDER BY slug ASC`); err != nil {
+		return nil, err
+	}
+	var pairs []struct {
+		LinkID string `db:"link_id"`
+		Value  string `db:"value"`
+	}
+	if err := s.db.SelectContext(ctx, &pairs, `
+		SELECT lt.link_id, t.slug AS value FROM link_tags lt INNER JOIN tags t ON t.id = lt.tag_id
+	`); err != nil {
+		return nil, err
+	}
+	tags := map[string][]string{}
+	for _, p := range pairs {
+		tags[p.LinkID] = append(tags[p.LinkID], p.Value)
+	}
+	pairs = nil
+	if err := s.db.SelectContext(ctx, &pairs, `SELECT link_id, user_id AS value FROM link_owners`); err != nil {
+		return nil, err
+	}
+	owners := map[string][]string{}
+	for _, p := range pairs {
+		owners[p.LinkID] = append(owners[p.LinkID], p.Value)
+	}
+
+	subjects := make([]PolicySubject, len(links))
+	for i, l := range links {
+		subjects[i] = PolicySubject{
+			LinkID:      l.ID,
+			Title:       l.Title,
+			Description: l.Description,
+			Visibility:  l.Visibility,
+			TeamID:      l.TeamID,
+			Tags:        tags[l.ID],
+			OwnerIDs:    owners[l.ID],
+			CreatedAt:   l.CreatedAt,
+		}
+	}
+	return subjects, nil
+}
+
+// compiledPolicy is a policy with its scope resolved: owner: and team:
+// values become the sets of user and team IDs they name.
+type compiledPolicy struct {
+	*LinkPolicy
+	filter  LinkFilter
+	owners  [][]string // per owner: filter, the matching user IDs
+	teamIDs [][]string // per team: filter, the matching team IDs
+}
+
+// compile loads every policy and resolves its scope.
+func (s *PolicyStore) compile(ctx context.Context) ([]*compiledPolicy, error) {
+	policies, err := s.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	compiled := make([]*compiledPolicy, 0, len(policies))
+	for _, p := range policies {
+		f, err := ParseLinkFilter(p.Scope)
+		if err != nil {
+			return nil, fmt.Errorf("policy %q: %w", p.Name, err)
+		}
+		c := &compiledPolicy{LinkPolicy: p, filter: f}
+		for _, owner := range f.Owners {
+			ids, err := s.links.ownerIDs(ctx, owner)
+			if err != nil {
+				return nil, err
+			}
+			c.owners = append(c.owners, ids)
+		}
+		for _, team := range f.Teams {
+			var ids []string
+			if err := s.db.SelectContext(ctx, &ids, s.q(`SELECT id FROM teams WHERE slug = ?`), team); err != nil && err != sql.ErrNoRows {
+				return nil, err
+			}
+			c.teamIDs = append(c.teamIDs, ids)
+		}
+		compiled = append(compiled, c)
+	}
+	return compiled, nil
+}
+
+// matches reports whether subj falls within the policy's scope.
+func (c *compiledPolicy) matches(subj PolicySubject) bool {
+	if c.filter.Visibility != "" && c.filter.Visibility != subj.Visibility {
+		return false
+	}
+	for _, tag := range c.filter.Tags {
+		found := false
+		for _, t := range subj.Tags {
+			if DeriveTagSlug(t) == tag {
+				found = true
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	for _, ids := range c.teamIDs {
+		if subj.TeamID == "" || !containsString(ids, subj.TeamID) {
+			return false
+		}
+	}
+	for _, ids := range c.owners {
+		found := false
+		for _, id := range subj.OwnerIDs {
+			if containsString(ids, id) {
+				found = true
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// evaluatedViolation is a violation along with the rule that produced it.
+type evaluatedViolation struct {
+	PolicyViolation
+	rule string
+}
+
+// evaluatePolicies returns the policies subj violates as of now.
+func evaluatePolicies(policies []*compiledPolicy, subj PolicySubject, now time.Time) []evaluatedViolation {
+	var vs []evaluatedViolation
+	for _, p := range policies {
+		if !p.matches(subj) {
+			continue
+		}
+		var msg string
+		switch p.Rule {
+		case PolicyRequireTitle:
+			if strings.TrimSpace(subj.Title) == "" {
+				msg = "a title is required"
+			}
+		case PolicyRequireDescription:
+			if strings.TrimSpace(subj.Description) == "" {
+				msg = "a description is required"
+			}
+		case PolicyRequireTeam:
+			if subj.TeamID == "" {
+				msg = "an owning team is required"
+			}
+		case PolicyMaxAge:
+			if !subj.CreatedAt.IsZero() && now.Sub(subj.CreatedAt) > time.Duration(p.Days)*24*time.Hour {
+				msg = fmt.Sprintf("older than %d days", p.Days)
+			}
+		}
+		if msg == "" {
+			continue
+		}
+		vs = append(vs, evaluatedViolation{
+			PolicyViolation: PolicyViolation{
+				PolicyID:    p.ID,
+				LinkID:      subj.LinkID,
+				Message:     msg,
+				PolicyName:  p.Name,
+				Enforcement: p.Enforcement,
+			},
+			rule: p.Rule,
+		})
+	}
+	return vs
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
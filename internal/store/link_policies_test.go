@@ -0,0 +1,102 @@
+// Governing: SPEC-0011 REQ "Link Lifecycle Policies"
+package store_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/joestump/joe-links/internal/store"
+	"github.com/joestump/joe-links/internal/testutil"
+)
+
+func TestLinkPolicies(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	owns := store.NewOwnershipStore(db)
+	ls := store.NewLinkStore(db, owns, store.NewTagStore(db))
+	ps := store.NewPolicyStore(db, ls)
+	ctx := context.Background()
+
+	u, err := store.NewUserStore(db).Upsert(ctx, "test", "sub1", "alice@example.com", "Alice", "")
+	if err != nil {
+		t.Fatalf("seed user: %v", err)
+	}
+
+	if _, err := ps.Create(ctx, "Temp links expire", "tag:temp", store.PolicyMaxAge, 30, store.PolicyBlock); err != nil {
+		t.Fatalf("Create(max_age): %v", err)
+	}
+	if _, err := ps.Create(ctx, "Secure links are documented", "visibility:secure", store.PolicyRequireDescription, 0, store.PolicyBlock); err != nil {
+		t.Fatalf("Create(require_description): %v", err)
+	}
+	if _, err := ps.Create(ctx, "Alice titles links", "owner:alice", store.PolicyRequireTitle, 0, store.PolicyWarn); err != nil {
+		t.Fatalf("Create(require_title): %v", err)
+	}
+
+	if _, err := ps.Create(ctx, "Alice titles links", "", store.PolicyRequireTitle, 0, ""); !errors.Is(err, store.ErrPolicyNameTaken) {
+		t.Errorf("duplicate name: err = %v, want ErrPolicyNameTaken", err)
+	}
+	if _, err := ps.Create(ctx, "No age", "", store.PolicyMaxAge, 0, ""); !errors.Is(err, store.ErrInvalidPolicy) {
+		t.Errorf("max_age without days: err = %v, want ErrInvalidPolicy", err)
+	}
+	if _, err := ps.Create(ctx, "Free text", "runbook", store.PolicyRequireTitle, 0, ""); !errors.Is(err, store.ErrInvalidFilter) {
+		t.Errorf("free text scope: err = %v, want ErrInvalidFilter", err)
+	}
+
+	// A secure link without a description is refused outright.
+	_, err = ps.CheckLink(ctx, store.PolicySubject{Title: "Vault", Visibility: "secure", OwnerIDs: []string{u.ID}})
+	if !errors.Is(err, store.ErrPolicyViolation) {
+		t.Errorf("secure without description: err = %v, want ErrPolicyViolation", err)
+	}
+
+	// An untitled link of Alice's is allowed, with a warning.
+	warnings, err := ps.CheckLink(ctx, store.PolicySubject{Visibility: "public", OwnerIDs: []string{u.ID}})
+	if err != nil {
+		t.Fatalf("CheckLink: %v", err)
+	}
+	if len(warnings) != 1 || warnings[0].Message != "a title is required" {
+		t.Fatalf("warnings = %+v, want one missing-title warning", warnings)
+	}
+
+	untitled, err := ls.Create(ctx, "untitled", "https://example.com", u.ID, "", "", "public")
+	if err != nil {
+		t.Fatalf("Create(untitled): %v", err)
+	}
+	temp, err := ls.Create(ctx, "launch", "https://example.com/launch", u.ID, "Launch", "", "public")
+	if err != nil {
+		t.Fatalf("Create(launch): %v", err)
+	}
+	if err := ls.SetTags(ctx, temp.ID, []string{"temp"}); err != nil {
+		t.Fatalf("SetTags: %v", err)
+	}
+	if _, err := db.Exec(db.Rebind(`UPDATE links SET created_at = ? WHERE id = ?`), time.Now().Add(-31*24*time.Hour).UTC(), temp.ID); err != nil {
+		t.Fatalf("backdate: %v", err)
+	}
+
+	sweep, err := ps.Sweep(ctx, time.Now())
+	if err != nil {
+		t.Fatalf("Sweep: %v", err)
+	}
+	if sweep.Checked != 2 || sweep.Expired != 1 || sweep.Violations != 1 {
+		t.Errorf("Sweep = %+v, want 2 checked, 1 expired, 1 violation", sweep)
+	}
+	if _, err := ls.GetByID(ctx, temp.ID); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("expired link: GetByID err = %v, want ErrNotFound", err)
+	}
+
+	vs, err := ps.ListViolationsByOwner(ctx, u.ID)
+	if err != nil {
+		t.Fatalf("ListViolationsByOwner: %v", err)
+	}
+	if len(vs) != 1 || vs[0].LinkID != untitled.ID || vs[0].Slug != "untitled" || vs[0].PolicyName != "Alice titles links" {
+		t.Fatalf("violations = %+v, want the untitled link", vs)
+	}
+
+	// Fixing the link and recording its (now empty) violations clears it.
+	if err := ps.RecordViolations(ctx, untitled.ID, nil); err != nil {
+		t.Fatalf("RecordViolations: %v", err)
+	}
+	if vs, _ := ps.ListViolationsByLink(ctx, untitled.ID); len(vs) != 0 {
+		t.Errorf("after RecordViolations(nil): %d violations, want 0", len(vs))
+	}
+}
@@ -0,0 +1,97 @@
+package store_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/joestump/joe-links/internal/store"
+	"github.com/joestump/joe-links/internal/testutil"
+)
+
+// Governing: SPEC-0004 REQ "Sortable Link Lists"
+func TestLinkStore_SortByClicks(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	ls := store.NewLinkStore(db, store.NewOwnershipStore(db), store.NewTagStore(db))
+	cs := store.NewClickStore(db)
+	ctx := context.Background()
+	u, err := store.NewUserStore(db).Upsert(ctx, "test", "sub1", "test@example.com", "Test User", "")
+	if err != nil {
+		t.Fatalf("seed user: %v", err)
+	}
+
+	clicks := map[string]int{"alpha": 1, "bravo": 3, "charlie": 0}
+	var events []store.ClickEvent
+	for _, slug := range []string{"alpha", "bravo", "charlie"} {
+		l, err := ls.Create(ctx, slug, "https://example.com/"+slug, u.ID, "", "", "public")
+		if err != nil {
+			t.Fatalf("Create %s: %v", slug, err)
+		}
+		for range clicks[slug] {
+			events = append(events, store.ClickEvent{LinkID: l.ID, ClickedAt: time.Now()})
+		}
+		events = append(events, store.ClickEvent{LinkID: l.ID, ClickedAt: time.Now(), Bot: true})
+	}
+	if _, err := cs.RecordClicks(ctx, events); err != nil {
+		t.Fatalf("RecordClicks: %v", err)
+	}
+
+	links, err := ls.ListByOwner(ctx, u.ID)
+	if err != nil {
+		t.Fatalf("ListByOwner: %v", err)
+	}
+	if err := ls.AttachClickCounts(ctx, links); err != nil {
+		t.Fatalf("AttachClickCounts: %v", err)
+	}
+	slices.SortStableFunc(links, func(a, b *store.Link) int {
+		return store.CompareLinks(a, b, store.LinkSortClicks, true)
+	})
+	var got []string
+	for _, l := range links {
+		got = append(got, l.Slug)
+		if want := int64(clicks[l.Slug]); l.ClickCount != want {
+			t.Errorf("%s ClickCount = %d, want %d", l.Slug, l.ClickCount, want)
+		}
+	}
+	if want := []string{"bravo", "alpha", "charlie"}; !slices.Equal(got, want) {
+		t.Errorf("sorted by clicks = %v, want %v", got, want)
+	}
+
+	admin, err := ls.ListAllAdmin(ctx, "")
+	if err != nil {
+		t.Fatalf("ListAllAdmin: %v", err)
+	}
+	for _, l := range admin {
+		if want := int64(clicks[l.Slug]); l.ClickCount != want {
+			t.Errorf("admin %s ClickCount = %d, want %d", l.Slug, l.ClickCount, want)
+		}
+	}
+}
+
+// Governing: SPEC-0004 REQ "Sortable Link Lists"
+func TestPreferenceStore(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	prefs := store.NewPreferenceStore(db)
+	ctx := context.Background()
+	u, err := store.NewUserStore(db).Upsert(ctx, "test", "sub1", "test@example.com", "Test User", "")
+	if err != nil {
+		t.Fatalf("seed user: %v", err)
+	}
+
+	if _, err := prefs.Get(ctx, u.ID, store.PrefDashboardSort); !errors.Is(err, store.ErrNotFound) {
+		t.Fatalf("Get unset = %v, want ErrNotFound", err)
+	}
+	for _, v := range []string{"clicks:desc", "slug:asc"} {
+		if err := prefs.Set(ctx, u.ID, store.PrefDashboardSort, v); err != nil {
+			t.Fatalf("Set %q: %v", v, err)
+		}
+		if got, err := prefs.Get(ctx, u.ID, store.PrefDashboardSort); err != nil || got != v {
+			t.Errorf("Get = %q, %v; want %q", got, err, v)
+		}
+	}
+	if _, err := prefs.Get(ctx, u.ID, store.PrefAdminLinksSort); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("Get other preference = %v, want ErrNotFound", err)
+	}
+}
@@ -4,12 +4,16 @@ package store
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
 
-	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
+	"github.com/joestump/joe-links/internal/events"
+	"github.com/joestump/joe-links/internal/ids"
+	"github.com/joestump/joe-links/internal/metrics"
 )
 
 // Link represents a row in the links table.
@@ -22,6 +26,89 @@ type Link struct {
 	Visibility  string    `db:"visibility"` // Governing: SPEC-0010 REQ "Visibility Column on Links Table"
 	CreatedAt   time.Time `db:"created_at"`
 	UpdatedAt   time.Time `db:"updated_at"`
+
+	// VariableConstraints is a JSON object mapping variable names to regular
+	// expressions their segments must fully match; empty when unconstrained.
+	// Governing: SPEC-0009 REQ "Variable Constraints"
+	VariableConstraints string `db:"variable_constraints"`
+
+	// RedirectType is the HTTP status the resolver redirects with: 301 or 308
+	// for permanent targets, 302 (the default) or 307 for ones that change.
+	// Governing: SPEC-0002 REQ "Redirect Type"
+	RedirectType int `db:"redirect_type"`
+
+	// UTMParams is a JSON object of utm_* parameters the resolver appends to
+	// the target URL; empty when the link only uses the system defaults.
+	// Governing: SPEC-0002 REQ "UTM Parameters"
+	UTMParams string `db:"utm_params"`
+
+	// PassQuery forwards the incoming request's query string to the target.
+	// Governing: SPEC-0009 REQ "Query-String Passthrough"
+	PassQuery bool `db:"pass_query"`
+
+	// TeamID is the owning team's ID, or empty when the link has none.
+	// Governing: SPEC-0002 REQ "Team Ownership"
+	TeamID string `db:"team_id"`
+
+	// PendingReview is set while a public link awaits admin approval; it
+	// resolves but is left out of public listings.
+	// Governing: SPEC-0011 REQ "Public Link Moderation"
+	PendingReview bool `db:"pending_review"`
+
+	// CreatedBy is the ID of the user who created the link. It is set once
+	// and kept when the primary owner changes or the creator is deleted.
+	// Governing: SPEC-0002 REQ "Link Creator Attribution"
+	CreatedBy string `db:"created_by"`
+
+	// TenantID is the tenant the link belongs to; empty for the default tenant.
+	// Governing: SPEC-0001 REQ "Multi-Tenancy"
+	TenantID string `db:"tenant_id"`
+
+	// ClickCount is the number of non-bot clicks on the link, joined by the
+	// admin list query or attached by AttachClickCounts; zero when not loaded.
+	// Governing: SPEC-0004 REQ "Sortable Link Lists"
+	ClickCount int64 `db:"click_count"`
+
+	// Health is the latest health check result, attached by HealthStore.Attach
+	// for views that flag broken links; nil when not loaded or never checked.
+	// Governing: SPEC-0001 REQ "Link Health Checks"
+	Health *LinkHealth `db:"-"`
+}
+
+// RedirectStatus returns the status code to redirect with, defaulting to 302
+// Found for rows that predate the redirect_type column.
+// Governing: SPEC-0002 REQ "Redirect Type"
+func (l *Link) RedirectStatus() int {
+	if l.RedirectType == 0 {
+		return DefaultRedirectType
+	}
+	return l.RedirectType
+}
+
+// Constraints decodes VariableConstraints. Malformed values decode as no constraints.
+// Governing: SPEC-0009 REQ "Variable Constraints"
+func (l *Link) Constraints() map[string]string {
+	if l.VariableConstraints == "" {
+		return nil
+	}
+	var c map[string]string
+	if err := json.Unmarshal([]byte(l.VariableConstraints), &c); err != nil {
+		return nil
+	}
+	return c
+}
+
+// UTM decodes UTMParams. Malformed values decode as no parameters.
+// Governing: SPEC-0002 REQ "UTM Parameters"
+func (l *Link) UTM() map[string]string {
+	if l.UTMParams == "" {
+		return nil
+	}
+	var p map[string]string
+	if err := json.Unmarshal([]byte(l.UTMParams), &p); err != nil {
+		return nil
+	}
+	return p
 }
 
 // ShareRecord represents a row in the link_shares table.
@@ -39,12 +126,42 @@ type LinkStore struct {
 	db    *sqlx.DB
 	owns  *OwnershipStore
 	tags  *TagStore
+
+	// moderate holds newly public links for admin review.
+	// Governing: SPEC-0011 REQ "Public Link Moderation"
+	moderate bool
+
+	// events receives link changes for live dashboards; nil disables them.
+	// Governing: SPEC-0004 REQ "Live Dashboard Updates"
+	events *events.Broker
+
+	// replica serves read-heavy lookups when set; see SetReplica.
+	replica *sqlx.DB
+
+	// slugs lets GetByPathPrefix skip the database for slugs no link has;
+	// nil until EnableSlugFilter. Governing: SPEC-0009 REQ "Slug Filter Fast Path"
+	slugs *slugFilter
 }
 
 func NewLinkStore(db *sqlx.DB, owns *OwnershipStore, tags *TagStore) *LinkStore {
 	return &LinkStore{db: db, owns: owns, tags: tags}
 }
 
+// SetModeration turns on the public link moderation queue: links created as
+// public, or changed to public, are marked pending review until an admin
+// approves them.
+// Governing: SPEC-0011 REQ "Public Link Moderation"
+func (s *LinkStore) SetModeration(enabled bool) { s.moderate = enabled }
+
+// SetEvents publishes link creates, updates, and deletes to b.
+// Governing: SPEC-0004 REQ "Live Dashboard Updates"
+func (s *LinkStore) SetEvents(b *events.Broker) { s.events = b }
+
+// publish reports a change to linkID to live dashboards.
+func (s *LinkStore) publish(kind, linkID string) {
+	s.events.Publish(events.Event{Kind: kind, LinkID: linkID})
+}
+
 // q rebinds ? placeholders to the driver's native format ($1,$2,... for PostgreSQL).
 func (s *LinkStore) q(query string) string { return s.db.Rebind(query) }
 
@@ -67,12 +184,15 @@ func (s *LinkStore) aggAll(col string) string {
 }
 
 // Create inserts a new link and registers ownerID as the primary owner.
+// Returns ErrSlugReserved if slug is reserved, ErrSlugTaken if it is
+// already used by a link or alias, and ErrDomainNotAllowed if a domain rule
+// refuses url.
 // Governing: SPEC-0010 REQ "Visibility Selector in Link Forms"
 func (s *LinkStore) Create(ctx context.Context, slug, url, ownerID, title, description, visibility string) (*Link, error) {
 	if visibility == "" {
 		visibility = "public"
 	}
-	id := uuid.New().String()
+	id := ids.New()
 	now := time.Now().UTC()
 
 	tx, err := s.db.BeginTxx(ctx, nil)
@@ -81,10 +201,37 @@ func (s *LinkStore) Create(ctx context.Context, slug, url, ownerID, title, descr
 	}
 	defer func() { _ = tx.Rollback() }()
 
+	// Governing: SPEC-0002 REQ "Reserved Slugs"
+	if reserved, err := slugReserved(ctx, tx, slug); err != nil {
+		return nil, err
+	} else if reserved {
+		return nil, fmt.Errorf("%w: %q", ErrSlugReserved, slug)
+	}
+
+	// Governing: SPEC-0002 REQ "Destination Domain Rules"
+	if err := checkDestination(ctx, tx, url); err != nil {
+		return nil, err
+	}
+
+	// Governing: SPEC-0002 REQ "Link Aliases" — slugs are unique across links and aliases
+	var aliased int
+	if err := tx.GetContext(ctx, &aliased, tx.Rebind(`SELECT COUNT(*) FROM link_aliases WHERE slug = ?`), slug); err != nil {
+		return nil, err
+	}
+	if aliased > 0 {
+		return nil, ErrSlugTaken
+	}
+
+	// Governing: SPEC-0011 REQ "Public Link Moderation"
+	pending := 0
+	if s.moderate && visibility == "public" {
+		pending = 1
+	}
+	tenantID, _ := TenantFromContext(ctx) // Governing: SPEC-0001 REQ "Multi-Tenancy"
 	_, err = tx.ExecContext(ctx, tx.Rebind(`
-		INSERT INTO links (id, slug, url, title, description, visibility, created_at, updated_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
-	`), id, slug, url, title, description, visibility, now, now)
+		INSERT INTO links (id, slug, url, title, description, visibility, pending_review, created_by, tenant_id, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`), id, slug, url, title, description, visibility, pending, ownerID, tenantID, now, now)
 	if err != nil {
 		if isUniqueConstraintError(err) {
 			return nil, ErrSlugTaken
@@ -99,9 +246,16 @@ func (s *LinkStore) Create(ctx context.Context, slug, url, ownerID, title, descr
 		return nil, err
 	}
 
+	// Governing: SPEC-0002 REQ "Full-Text Link Search"
+	if err := reindexLinks(ctx, tx, id); err != nil {
+		return nil, err
+	}
+
+	s.noteSlug(slug) // Governing: SPEC-0009 REQ "Slug Filter Fast Path"
 	if err := tx.Commit(); err != nil {
 		return nil, err
 	}
+	s.publish(events.LinkCreated, id)
 
 	return s.GetByID(ctx, id)
 }
@@ -109,8 +263,10 @@ func (s *LinkStore) Create(ctx context.Context, slug, url, ownerID, title, descr
 // GetBySlug returns the link matching slug, or ErrNotFound.
 // Governing: SPEC-0002 REQ "Link Store Interface" — WHEN GetBySlug called with missing slug THEN returns sentinel ErrNotFound
 func (s *LinkStore) GetBySlug(ctx context.Context, slug string) (*Link, error) {
+	defer metrics.ObserveDBQuery("link_get_by_slug", time.Now())
 	var l Link
-	err := s.db.GetContext(ctx, &l, s.q(`SELECT * FROM links WHERE slug = ?`), slug)
+	scope, scopeArgs := tenantScope(ctx, "tenant_id")
+	err := s.readGet(ctx, &l, `SELECT * FROM links WHERE slug = ?`+scope, append([]interface{}{slug}, scopeArgs...)...)
 	if err == sql.ErrNoRows {
 		return nil, ErrNotFound
 	}
@@ -120,11 +276,75 @@ func (s *LinkStore) GetBySlug(ctx context.Context, slug string) (*Link, error) {
 	return &l, nil
 }
 
-// GetByID returns the link matching id, or ErrNotFound.
+// MaxPrefixDepth bounds the number of leading path segments GetByPathPrefix
+// considers, so a deep path cannot inflate the candidate list without limit.
+const MaxPrefixDepth = 32
+
+// GetByPathPrefix returns the link whose slug or alias equals path or,
+// failing that, the longest "/"-delimited prefix of path, together with the
+// slug that matched, or ErrNotFound. For equally long candidates the primary
+// slug is checked before aliases. All candidates are fetched in one indexed
+// query instead of one query per prefix. Prefixes deeper than MaxPrefixDepth
+// segments are not considered.
+// Governing: SPEC-0009 REQ "Multi-Segment Path Resolution", ADR-0013
+// Governing: SPEC-0002 REQ "Link Aliases"
+func (s *LinkStore) GetByPathPrefix(ctx context.Context, path string) (_ *Link, _ string, err error) {
+	defer metrics.ObserveDBQuery("link_get_by_path_prefix", time.Now())
+	candidates := []string{path}
+	segments := strings.Split(path, "/")
+	for i := min(len(segments)-1, MaxPrefixDepth); i >= 1; i-- {
+		candidates = append(candidates, strings.Join(segments[:i], "/"))
+	}
+	// Governing: SPEC-0009 REQ "Slug Filter Fast Path"
+	if s.slugs != nil {
+		if !s.slugs.mayExist(candidates) {
+			metrics.SlugFilterChecksTotal.WithLabelValues("negative").Inc()
+			return nil, "", ErrNotFound
+		}
+		defer func() {
+			if errors.Is(err, ErrNotFound) {
+				metrics.SlugFilterChecksTotal.WithLabelValues("false_positive").Inc()
+			} else if err == nil {
+				metrics.SlugFilterChecksTotal.WithLabelValues("positive").Inc()
+			}
+		}()
+	}
+	// Governing: SPEC-0001 REQ "Multi-Tenancy" — only the request tenant's links match
+	scope, scopeArgs := tenantScope(ctx, "l.tenant_id")
+	query, args, err := sqlx.In(`
+		SELECT l.*, m.slug AS matched_slug FROM (
+			SELECT id AS link_id, slug, 0 AS is_alias FROM links WHERE slug IN (?)
+			UNION ALL
+			SELECT link_id, slug, 1 AS is_alias FROM link_aliases WHERE slug IN (?)
+		) m
+		JOIN links l ON l.id = m.link_id
+		WHERE 1 = 1`+scope+`
+		ORDER BY LENGTH(m.slug) DESC, m.is_alias ASC
+		LIMIT 1
+	`, append([]interface{}{candidates, candidates}, scopeArgs...)...)
+	if err != nil {
+		return nil, "", err
+	}
+	var row struct {
+		Link
+		MatchedSlug string `db:"matched_slug"`
+	}
+	err = s.readGet(ctx, &row, query, args...)
+	if err == sql.ErrNoRows {
+		return nil, "", ErrNotFound
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	return &row.Link, row.MatchedSlug, nil
+}
+
+// GetByID returns the link matching id, or ErrNotFound. Links of another
+// tenant than the one ctx is scoped to are not found.
 func (s *LinkStore) GetByID(ctx context.Context, id string) (*Link, error) {
 	var l Link
 	err := s.db.GetContext(ctx, &l, s.q(`SELECT * FROM links WHERE id = ?`), id)
-	if err == sql.ErrNoRows {
+	if err == sql.ErrNoRows || (err == nil && !inTenant(ctx, l.TenantID)) {
 		return nil, ErrNotFound
 	}
 	if err != nil {
@@ -149,56 +369,45 @@ func (s *LinkStore) ListByOwner(ctx context.Context, ownerID string) ([]*Link, e
 	return links, nil
 }
 
-// ListAll returns all links ordered by slug.
+// ListAll returns all links of the tenant ctx is scoped to, ordered by slug.
 func (s *LinkStore) ListAll(ctx context.Context) ([]*Link, error) {
 	var links []*Link
-	err := s.db.SelectContext(ctx, &links, `SELECT * FROM links ORDER BY slug ASC`)
+	scope, args := tenantScope(ctx, "tenant_id")
+	err := s.db.SelectContext(ctx, &links, s.q(`SELECT * FROM links WHERE 1 = 1`+scope+` ORDER BY slug ASC`), args...)
 	if err != nil {
 		return nil, err
 	}
 	return links, nil
 }
 
-// SearchByOwner returns links owned by userID whose slug, url, or description
-// contain the search term (case-insensitive LIKE). Returns all owner links if q is empty.
+// SearchByOwner returns links owned by userID matching q: free text matches
+// every word as a prefix of a word in the slug, title, description, URL, or
+// tags, best matches first, and key:value filters are applied as described
+// by ParseLinkFilter. Returns ErrInvalidFilter for a malformed filter.
 // Governing: SPEC-0004 REQ "User Dashboard" — HTMX debounced search
+// Governing: SPEC-0002 REQ "Full-Text Link Search", REQ "Structured Search Filters"
 func (s *LinkStore) SearchByOwner(ctx context.Context, ownerID, q string) ([]*Link, error) {
-	if q == "" {
-		return s.ListByOwner(ctx, ownerID)
-	}
-	var links []*Link
-	pattern := "%" + q + "%"
-	err := s.db.SelectContext(ctx, &links, s.q(`
-		SELECT l.* FROM links l
-		INNER JOIN link_owners lo ON lo.link_id = l.id
-		WHERE lo.user_id = ?
-		  AND (l.slug LIKE ? OR l.url LIKE ? OR l.description LIKE ?)
-		ORDER BY l.slug ASC
-	`), ownerID, pattern, pattern, pattern)
-	if err != nil {
-		return nil, err
-	}
-	return links, nil
+	return s.searchLinks(ctx, q, `EXISTS (SELECT 1 FROM link_owners so WHERE so.link_id = l.id AND so.user_id = ?)`, ownerID)
 }
 
-// SearchAll returns all links whose slug, url, or description contain the
-// search term (case-insensitive LIKE). Returns all links if q is empty.
+// SearchByOwnerOrShared is SearchByOwner widened to links shared with userID
+// and links owned by or shared with one of userID's teams.
+// Governing: SPEC-0002 REQ "Structured Search Filters"
+// Governing: SPEC-0010 REQ "Team Shares and Ownership"
+func (s *LinkStore) SearchByOwnerOrShared(ctx context.Context, userID, q string) ([]*Link, error) {
+	return s.searchLinks(ctx, q, `(
+		EXISTS (SELECT 1 FROM link_owners so WHERE so.link_id = l.id AND so.user_id = ?)
+		OR EXISTS (SELECT 1 FROM link_shares ss WHERE ss.link_id = l.id AND ss.user_id = ?)
+		OR `+teamOwnedScope+`
+		OR `+teamSharedScope+`
+	)`, userID, userID, userID, userID)
+}
+
+// SearchAll is SearchByOwner across every link (admin view).
 // Governing: SPEC-0004 REQ "User Dashboard" — HTMX debounced search (admin view)
+// Governing: SPEC-0002 REQ "Full-Text Link Search", REQ "Structured Search Filters"
 func (s *LinkStore) SearchAll(ctx context.Context, q string) ([]*Link, error) {
-	if q == "" {
-		return s.ListAll(ctx)
-	}
-	var links []*Link
-	pattern := "%" + q + "%"
-	err := s.db.SelectContext(ctx, &links, s.q(`
-		SELECT * FROM links
-		WHERE slug LIKE ? OR url LIKE ? OR description LIKE ?
-		ORDER BY slug ASC
-	`), pattern, pattern, pattern)
-	if err != nil {
-		return nil, err
-	}
-	return links, nil
+	return s.searchLinks(ctx, q, "")
 }
 
 // ListByOwnerAndTag returns links owned by userID that have the given tag slug.
@@ -220,29 +429,157 @@ func (s *LinkStore) ListByOwnerAndTag(ctx context.Context, ownerID, tagSlug stri
 }
 
 // Update modifies an existing link's url, title, description, and visibility.
+// Returns ErrDomainNotAllowed if url changes to a destination a domain rule
+// refuses.
 // Governing: SPEC-0001 REQ "Short Link Management" — slug is immutable after creation.
 // Governing: SPEC-0010 REQ "Visibility Selector in Link Forms"
 func (s *LinkStore) Update(ctx context.Context, id, url, title, description, visibility string) (*Link, error) {
 	now := time.Now().UTC()
-	_, err := s.db.ExecContext(ctx, s.q(`
-		UPDATE links SET url = ?, title = ?, description = ?, visibility = ?, updated_at = ? WHERE id = ?
-	`), url, title, description, visibility, now, id)
+	tx, err := s.db.BeginTxx(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
+	defer func() { _ = tx.Rollback() }()
+
+	// Governing: SPEC-0002 REQ "Destination Domain Rules" — only a changed
+	// destination is checked, so links saved before a rule keep saving.
+	var current string
+	if err := tx.GetContext(ctx, &current, tx.Rebind(`SELECT url FROM links WHERE id = ?`), id); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	if url != current {
+		if err := checkDestination(ctx, tx, url); err != nil {
+			return nil, err
+		}
+	}
+
+	// Governing: SPEC-0011 REQ "Public Link Moderation" — a link made public
+	// goes back to the queue; the CASE sees the visibility before this update.
+	review := 0
+	if s.moderate && visibility == "public" {
+		review = 1
+	}
+	_, err = tx.ExecContext(ctx, tx.Rebind(`
+		UPDATE links SET url = ?, title = ?, description = ?, visibility = ?, updated_at = ?,
+			pending_review = CASE WHEN ? = 1 AND visibility <> 'public' THEN 1 ELSE pending_review END
+		WHERE id = ?
+	`), url, title, description, visibility, now, review, id)
+	if err != nil {
+		return nil, err
+	}
+	// Governing: SPEC-0002 REQ "Full-Text Link Search"
+	if err := reindexLinks(ctx, tx, id); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	s.publish(events.LinkUpdated, id)
 	return s.GetByID(ctx, id)
 }
 
+// SetRedirectType sets the status code the link redirects with. Callers
+// validate it first with ValidateRedirectType.
+// Governing: SPEC-0002 REQ "Redirect Type"
+func (s *LinkStore) SetRedirectType(ctx context.Context, id string, code int) error {
+	now := time.Now().UTC()
+	_, err := s.db.ExecContext(ctx, s.q(`UPDATE links SET redirect_type = ?, updated_at = ? WHERE id = ?`),
+		code, now, id)
+	return err
+}
+
+// SetUTMParams replaces the link's UTM parameters. Callers validate them
+// first with ValidateUTMParams.
+// Governing: SPEC-0002 REQ "UTM Parameters"
+func (s *LinkStore) SetUTMParams(ctx context.Context, id string, params map[string]string) error {
+	encoded := ""
+	if len(params) > 0 {
+		b, err := json.Marshal(params)
+		if err != nil {
+			return err
+		}
+		encoded = string(b)
+	}
+	now := time.Now().UTC()
+	_, err := s.db.ExecContext(ctx, s.q(`UPDATE links SET utm_params = ?, updated_at = ? WHERE id = ?`),
+		encoded, now, id)
+	return err
+}
+
+// SetPassQuery turns query-string passthrough on or off for a link.
+// Governing: SPEC-0009 REQ "Query-String Passthrough"
+func (s *LinkStore) SetPassQuery(ctx context.Context, id string, pass bool) error {
+	v := 0
+	if pass {
+		v = 1
+	}
+	now := time.Now().UTC()
+	_, err := s.db.ExecContext(ctx, s.q(`UPDATE links SET pass_query = ?, updated_at = ? WHERE id = ?`),
+		v, now, id)
+	return err
+}
+
+// SetTeam sets the link's owning team; an empty teamID clears it.
+// Governing: SPEC-0002 REQ "Team Ownership"
+func (s *LinkStore) SetTeam(ctx context.Context, id, teamID string) error {
+	now := time.Now().UTC()
+	_, err := s.db.ExecContext(ctx, s.q(`UPDATE links SET team_id = ?, updated_at = ? WHERE id = ?`),
+		teamID, now, id)
+	return err
+}
+
+// GetTeam returns the team with the given ID, or nil when teamID is empty.
+// Governing: SPEC-0002 REQ "Team Ownership"
+func (s *LinkStore) GetTeam(ctx context.Context, teamID string) (*Team, error) {
+	if teamID == "" {
+		return nil, nil
+	}
+	var t Team
+	err := s.db.GetContext(ctx, &t, s.q(`SELECT * FROM teams WHERE id = ?`), teamID)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
 // UpdateVisibility sets the visibility field on a link.
 // Governing: SPEC-0010 REQ "Visibility Column on Links Table", REQ "Admin Visibility Override"
 func (s *LinkStore) UpdateVisibility(ctx context.Context, id, visibility string) error {
 	now := time.Now().UTC()
 	_, err := s.db.ExecContext(ctx, s.q(`UPDATE links SET visibility = ?, updated_at = ? WHERE id = ?`),
 		visibility, now, id)
+	if err == nil {
+		s.publish(events.LinkUpdated, id)
+	}
+	return err
+}
+
+// SetVariableConstraints replaces the link's per-variable regex constraints.
+// Callers validate them first with ValidateVariableConstraints.
+// Governing: SPEC-0009 REQ "Variable Constraints"
+func (s *LinkStore) SetVariableConstraints(ctx context.Context, id string, constraints map[string]string) error {
+	encoded := ""
+	if len(constraints) > 0 {
+		b, err := json.Marshal(constraints)
+		if err != nil {
+			return err
+		}
+		encoded = string(b)
+	}
+	now := time.Now().UTC()
+	_, err := s.db.ExecContext(ctx, s.q(`UPDATE links SET variable_constraints = ?, updated_at = ? WHERE id = ?`),
+		encoded, now, id)
 	return err
 }
 
-// ListByOwnerOrShared returns links where userID is an owner or has a share record.
+// ListByOwnerOrShared returns links where userID is an owner or has a share
+// record, directly or through one of their teams.
 // Governing: SPEC-0010 REQ "REST API Visibility Field"
 func (s *LinkStore) ListByOwnerOrShared(ctx context.Context, userID string) ([]*Link, error) {
 	var links []*Link
@@ -251,8 +588,9 @@ func (s *LinkStore) ListByOwnerOrShared(ctx context.Context, userID string) ([]*
 		LEFT JOIN link_owners lo ON lo.link_id = l.id AND lo.user_id = ?
 		LEFT JOIN link_shares ls ON ls.link_id = l.id AND ls.user_id = ?
 		WHERE lo.user_id IS NOT NULL OR ls.user_id IS NOT NULL
+		   OR `+teamOwnedScope+` OR `+teamSharedScope+`
 		ORDER BY l.slug ASC
-	`), userID, userID)
+	`), userID, userID, userID, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -265,9 +603,10 @@ func (s *LinkStore) ListByOwnerOrShared(ctx context.Context, userID string) ([]*
 func (s *LinkStore) ListByURL(ctx context.Context, url, userID string, isAdmin bool) ([]*Link, error) {
 	var links []*Link
 	if isAdmin {
+		scope, args := tenantScope(ctx, "tenant_id")
 		err := s.db.SelectContext(ctx, &links, s.q(`
-			SELECT * FROM links WHERE url = ? ORDER BY created_at DESC
-		`), url)
+			SELECT * FROM links WHERE url = ?`+scope+` ORDER BY created_at DESC
+		`), append([]interface{}{url}, args...)...)
 		return links, err
 	}
 	err := s.db.SelectContext(ctx, &links, s.q(`
@@ -280,10 +619,27 @@ func (s *LinkStore) ListByURL(ctx context.Context, url, userID string, isAdmin b
 	return links, err
 }
 
-// Delete removes a link by ID. CASCADE deletes handle link_owners and link_tags.
+// Delete removes a link by ID. CASCADE deletes handle link_owners and link_tags;
+// the search index row is removed explicitly.
 func (s *LinkStore) Delete(ctx context.Context, id string) error {
-	_, err := s.db.ExecContext(ctx, s.q(`DELETE FROM links WHERE id = ?`), id)
-	return err
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.ExecContext(ctx, tx.Rebind(`DELETE FROM links WHERE id = ?`), id); err != nil {
+		return err
+	}
+	// Governing: SPEC-0002 REQ "Full-Text Link Search"
+	if err := reindexLinks(ctx, tx, id); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	s.publish(events.LinkDeleted, id)
+	return nil
 }
 
 // AddOwner adds userID as a co-owner of linkID.
@@ -329,7 +685,15 @@ func (s *LinkStore) SetTags(ctx context.Context, linkID string, tagNames []strin
 		}
 	}
 
-	return tx.Commit()
+	// Governing: SPEC-0002 REQ "Full-Text Link Search"
+	if err := reindexLinks(ctx, tx, linkID); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	s.publish(events.LinkUpdated, linkID)
+	return nil
 }
 
 // ListTags returns all tags associated with a link.
@@ -347,6 +711,27 @@ func (s *LinkStore) ListTags(ctx context.Context, linkID string) ([]*Tag, error)
 	return tags, nil
 }
 
+// ListPublicTagsByOwner returns the tags used on userID's public links (those
+// where the user is the primary owner), most used first.
+// Governing: SPEC-0012 REQ "Profile Statistics"
+func (s *LinkStore) ListPublicTagsByOwner(ctx context.Context, userID string) ([]*TagWithCount, error) {
+	var tags []*TagWithCount
+	err := s.db.SelectContext(ctx, &tags, s.q(`
+		SELECT t.id, t.name, t.slug, t.created_at, COUNT(DISTINCT l.id) AS link_count
+		FROM tags t
+		INNER JOIN link_tags lt ON lt.tag_id = t.id
+		INNER JOIN links l ON l.id = lt.link_id AND l.visibility = 'public' AND l.pending_review = 0
+		INNER JOIN link_owners lo ON lo.link_id = l.id AND lo.is_primary = 1
+		WHERE lo.user_id = ?
+		GROUP BY t.id, t.name, t.slug, t.created_at
+		ORDER BY link_count DESC, t.name ASC
+	`), userID)
+	if err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
 // AdminLink is a Link augmented with owner display names and tag names for admin views.
 // Governing: SPEC-0011 REQ "Admin Links Screen", ADR-0005
 type AdminLink struct {
@@ -355,6 +740,16 @@ type AdminLink struct {
 	Tags      string `db:"tags"`       // comma-separated tag names
 	OwnerSlug string `db:"owner_slug"` // primary owner's display_name_slug (populated in public views)
 	IsOwner   bool   `db:"is_owner"`   // true if the querying user is an owner (populated in public views)
+
+	// Owning team, shown instead of the primary owner when set (populated in public views).
+	// Governing: SPEC-0002 REQ "Team Ownership"
+	TeamName    string `db:"team_name"`
+	TeamContact string `db:"team_contact"`
+
+	// CreatorName is the display name of the user in CreatedBy; empty when
+	// the creator no longer exists (populated in admin views).
+	// Governing: SPEC-0002 REQ "Link Creator Attribution"
+	CreatorName string `db:"creator_name"`
 }
 
 // TagList returns tag names as a slice for template iteration.
@@ -365,36 +760,86 @@ func (a *AdminLink) TagList() []string {
 	return strings.Split(a.Tags, ",")
 }
 
-// ListAllAdmin returns all links with owner display names and tags joined, ordered by slug.
-// Supports optional search query filtering by slug, URL, title, or owner display name.
+// ListAllAdmin returns all links with owner display names, tags, and click
+// counts joined, ordered by slug. An optional search query is matched as in SearchAll,
+// including key:value filters; free-text results are ranked best first.
 // Governing: SPEC-0011 REQ "Admin Links Screen"
+// Governing: SPEC-0002 REQ "Structured Search Filters"
 func (s *LinkStore) ListAllAdmin(ctx context.Context, q string) ([]*AdminLink, error) {
+	query, args, err := s.adminLinksQuery(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	var links []*AdminLink
+	err = s.db.SelectContext(ctx, &links, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return links, nil
+}
+
+// EachAdmin calls fn for every link ListAllAdmin would return, in the same
+// order, reading rows one at a time so large exports need not fit in memory.
+// Iteration stops at the first error fn returns.
+// Governing: SPEC-0011 REQ "Admin Links CSV Export"
+func (s *LinkStore) EachAdmin(ctx context.Context, q string, fn func(*AdminLink) error) error {
+	query, args, err := s.adminLinksQuery(ctx, q)
+	if err != nil {
+		return err
+	}
+	rows, err := s.db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var l AdminLink
+		if err := rows.StructScan(&l); err != nil {
+			return err
+		}
+		if err := fn(&l); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// adminLinksQuery builds the rebound ListAllAdmin query and its arguments.
+func (s *LinkStore) adminLinksQuery(ctx context.Context, q string) (string, []interface{}, error) {
+	f, err := ParseLinkFilter(q)
+	if err != nil {
+		return "", nil, err
+	}
+	c, err := s.filterClause(ctx, f)
+	if err != nil {
+		return "", nil, err
+	}
+	orderBy := `l.slug ASC`
+	if c.ranked() {
+		orderBy = `MAX(fts.score) DESC, l.slug ASC`
+	}
 	query := fmt.Sprintf(`
 		SELECT l.*,
 			%s AS owners,
-			%s AS tags
-		FROM links l
+			%s AS tags,
+			COALESCE(MAX(cu.display_name), '') AS creator_name,
+			COALESCE(MAX(cc.n), 0) AS click_count
+		FROM links l`+c.join+`
 		LEFT JOIN link_owners lo ON lo.link_id = l.id
 		LEFT JOIN users u ON u.id = lo.user_id
+		LEFT JOIN users cu ON cu.id = l.created_by
+		LEFT JOIN (
+			SELECT link_id, COUNT(*) AS n FROM link_clicks WHERE bot = 0 GROUP BY link_id
+		) cc ON cc.link_id = l.id
 		LEFT JOIN link_tags lt ON lt.link_id = l.id
-		LEFT JOIN tags t ON t.id = lt.tag_id`,
+		LEFT JOIN tags t ON t.id = lt.tag_id
+		WHERE 1 = 1`+c.where+`
+		GROUP BY l.id ORDER BY `+orderBy,
 		s.aggDistinct("u.display_name"),
 		s.aggDistinct("t.name"),
 	)
-	var args []interface{}
-	if q != "" {
-		pattern := "%" + q + "%"
-		query += ` WHERE l.slug LIKE ? OR l.url LIKE ? OR l.title LIKE ? OR u.display_name LIKE ?`
-		args = append(args, pattern, pattern, pattern, pattern)
-	}
-	query += ` GROUP BY l.id ORDER BY l.slug ASC`
-
-	var links []*AdminLink
-	err := s.db.SelectContext(ctx, &links, s.q(query), args...)
-	if err != nil {
-		return nil, err
-	}
-	return links, nil
+	args := append(append([]interface{}{}, c.joinArgs...), c.whereArgs...)
+	return s.q(query), args, nil
 }
 
 // GetAdminLink returns a single link with owner display names and tags joined.
@@ -404,10 +849,16 @@ func (s *LinkStore) GetAdminLink(ctx context.Context, id string) (*AdminLink, er
 	err := s.db.GetContext(ctx, &link, s.q(fmt.Sprintf(`
 		SELECT l.*,
 			%s AS owners,
-			%s AS tags
+			%s AS tags,
+			COALESCE(MAX(cu.display_name), '') AS creator_name,
+			COALESCE(MAX(cc.n), 0) AS click_count
 		FROM links l
 		LEFT JOIN link_owners lo ON lo.link_id = l.id
 		LEFT JOIN users u ON u.id = lo.user_id
+		LEFT JOIN users cu ON cu.id = l.created_by
+		LEFT JOIN (
+			SELECT link_id, COUNT(*) AS n FROM link_clicks WHERE bot = 0 GROUP BY link_id
+		) cc ON cc.link_id = l.id
 		LEFT JOIN link_tags lt ON lt.link_id = l.id
 		LEFT JOIN tags t ON t.id = lt.tag_id
 		WHERE l.id = ?
@@ -427,13 +878,14 @@ func (s *LinkStore) GetAdminLink(ctx context.Context, id string) (*AdminLink, er
 // ListByTag returns all links that have the given tag slug.
 func (s *LinkStore) ListByTag(ctx context.Context, tagSlug string) ([]*Link, error) {
 	var links []*Link
+	scope, args := tenantScope(ctx, "l.tenant_id")
 	err := s.db.SelectContext(ctx, &links, s.q(`
 		SELECT l.* FROM links l
 		INNER JOIN link_tags lt ON lt.link_id = l.id
 		INNER JOIN tags t ON t.id = lt.tag_id
-		WHERE t.slug = ?
+		WHERE t.slug = ?`+scope+`
 		ORDER BY l.slug ASC
-	`), tagSlug)
+	`), append([]interface{}{tagSlug}, args...)...)
 	if err != nil {
 		return nil, err
 	}
@@ -479,25 +931,31 @@ func (p *PublicLink) DisplayTitle() string {
 	return p.URL
 }
 
-// ListPublic returns paginated public links, optionally filtered by search query.
-// Returns the matching links and total count for pagination.
-// Governing: SPEC-0012 REQ "Public Link Browser (GET /links)", REQ "Public Link Search"
-// ListPublic returns paginated public links as AdminLink rows, optionally filtered by query.
+// ListPublic returns paginated public links as AdminLink rows, optionally
+// filtered by a full-text query; matches are ranked best first, otherwise
+// links are newest first. Returns the links and total count for pagination.
 // currentUserID is used to set IsOwner; pass "" for unauthenticated callers.
 // Governing: SPEC-0012 REQ "Public Link Browser (GET /links)", REQ "Public Link Search"
+// Governing: SPEC-0002 REQ "Full-Text Link Search"
 func (s *LinkStore) ListPublic(ctx context.Context, currentUserID, q string, page, perPage int) ([]*AdminLink, int, error) {
-	baseWhere := `WHERE l.visibility = 'public'`
-	var args []interface{}
-	if q != "" {
-		pattern := "%" + q + "%"
-		baseWhere += ` AND (l.slug LIKE ? OR l.url LIKE ? OR l.title LIKE ? OR l.description LIKE ?)`
-		args = append(args, pattern, pattern, pattern, pattern)
+	from := `FROM links l `
+	// Governing: SPEC-0011 REQ "Public Link Moderation" — unapproved links stay unlisted
+	baseWhere := `WHERE l.visibility = 'public' AND l.pending_review = 0`
+	orderBy := `l.created_at DESC`
+	source, args, ok := searchSource(s.db.DriverName(), q)
+	if ok {
+		from += `INNER JOIN ` + source + ` fts ON fts.link_id = l.id `
+		orderBy = `MAX(fts.score) DESC, l.created_at DESC`
 	}
+	// Governing: SPEC-0001 REQ "Multi-Tenancy"
+	scope, scopeArgs := tenantScope(ctx, "l.tenant_id")
+	baseWhere += scope
+	args = append(args, scopeArgs...)
 
 	// Count total matching rows.
-	countQuery := `SELECT COUNT(DISTINCT l.id) FROM links l ` + baseWhere
+	countQuery := `SELECT COUNT(DISTINCT l.id) ` + from + baseWhere
 	var total int
-	if err := s.db.GetContext(ctx, &total, s.q(countQuery), args...); err != nil {
+	if err := s.readGet(ctx, &total, countQuery, args...); err != nil {
 		return nil, 0, err
 	}
 
@@ -513,15 +971,18 @@ func (s *LinkStore) ListPublic(ctx context.Context, currentUserID, q string, pag
 		       %s AS tags,
 		       CASE WHEN EXISTS(
 		           SELECT 1 FROM link_owners lo2 WHERE lo2.link_id = l.id AND lo2.user_id = ?
-		       ) THEN 1 ELSE 0 END AS is_owner
-		FROM links l
+		       ) THEN 1 ELSE 0 END AS is_owner,
+		       COALESCE(MAX(tm.name), '') AS team_name,
+		       COALESCE(MAX(tm.contact), '') AS team_contact
+		`+from+`
 		LEFT JOIN link_owners lo ON lo.link_id = l.id AND lo.is_primary = 1
 		LEFT JOIN users u ON u.id = lo.user_id
+		LEFT JOIN teams tm ON tm.id = l.team_id
 		LEFT JOIN link_tags lt ON lt.link_id = l.id
 		LEFT JOIN tags t ON t.id = lt.tag_id
 		`+baseWhere+`
 		GROUP BY l.id
-		ORDER BY l.created_at DESC
+		ORDER BY `+orderBy+`
 		LIMIT ? OFFSET ?`,
 		s.aggDistinct("t.name"),
 	)
@@ -529,31 +990,52 @@ func (s *LinkStore) ListPublic(ctx context.Context, currentUserID, q string, pag
 	fetchArgs = append(fetchArgs, perPage, offset)
 
 	var links []*AdminLink
-	if err := s.db.SelectContext(ctx, &links, s.q(query), fetchArgs...); err != nil {
+	if err := s.readSelect(ctx, &links, query, fetchArgs...); err != nil {
 		return nil, 0, err
 	}
 	return links, total, nil
 }
 
-// ListSharedWithUser returns links shared with the given user via link_shares.
+// ListSharedWithUser returns links shared with the given user via link_shares,
+// plus links owned by or shared with one of the user's teams.
+// Governing: SPEC-0010 REQ "Team Shares and Ownership"
 // Governing: SPEC-0010 REQ "Dashboard Visibility Filtering"
 func (s *LinkStore) ListSharedWithUser(ctx context.Context, userID string) ([]*Link, error) {
 	var links []*Link
 	err := s.db.SelectContext(ctx, &links, s.q(`
 		SELECT l.* FROM links l
-		INNER JOIN link_shares ls ON ls.link_id = l.id
-		WHERE ls.user_id = ?
+		WHERE EXISTS (SELECT 1 FROM link_shares ls WHERE ls.link_id = l.id AND ls.user_id = ?)
+		   OR `+teamOwnedScope+` OR `+teamSharedScope+`
 		ORDER BY l.slug ASC
-	`), userID)
+	`), userID, userID, userID)
 	if err != nil {
 		return nil, err
 	}
 	return links, nil
 }
 
-// HasShare checks if user has a link_shares record.
-// Governing: SPEC-0010 REQ "Link Shares Table"
+// HasShare checks if user has a link_shares record or belongs to a team the
+// link is shared with.
+// Governing: SPEC-0010 REQ "Link Shares Table", REQ "Team Shares and Ownership"
 func (s *LinkStore) HasShare(ctx context.Context, linkID, userID string) (bool, error) {
+	defer metrics.ObserveDBQuery("link_has_share", time.Now())
+	var count int
+	err := s.db.GetContext(ctx, &count, s.q(`
+		SELECT (SELECT COUNT(*) FROM link_shares WHERE link_id = ? AND user_id = ?)
+		     + (SELECT COUNT(*) FROM link_team_shares lts
+		        INNER JOIN team_members tm ON tm.team_id = lts.team_id
+		        WHERE lts.link_id = ? AND tm.user_id = ?)
+	`), linkID, userID, linkID, userID)
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// HasDirectShare checks if user has a link_shares record of their own,
+// ignoring team shares.
+// Governing: SPEC-0010 REQ "Link Shares Table"
+func (s *LinkStore) HasDirectShare(ctx context.Context, linkID, userID string) (bool, error) {
 	var count int
 	err := s.db.GetContext(ctx, &count,
 		s.q(`SELECT COUNT(*) FROM link_shares WHERE link_id = ? AND user_id = ?`), linkID, userID)
@@ -595,18 +1077,18 @@ func (p *PublicLink) Excerpt(maxLen int) string {
 func (s *LinkStore) ListPublicByOwner(ctx context.Context, userID string, page, perPage int) ([]PublicLink, int, error) {
 	// Count total matching links
 	var total int
-	err := s.db.GetContext(ctx, &total, s.q(`
+	err := s.readGet(ctx, &total, `
 		SELECT COUNT(DISTINCT l.id) FROM links l
 		JOIN link_owners lo ON lo.link_id = l.id AND lo.is_primary = 1
-		WHERE l.visibility = 'public' AND lo.user_id = ?
-	`), userID)
+		WHERE l.visibility = 'public' AND l.pending_review = 0 AND lo.user_id = ?
+	`, userID)
 	if err != nil {
 		return nil, 0, err
 	}
 
 	offset := (page - 1) * perPage
 	var links []PublicLink
-	err = s.db.SelectContext(ctx, &links, s.q(fmt.Sprintf(`
+	err = s.readSelect(ctx, &links, fmt.Sprintf(`
 		SELECT l.id, l.slug, l.url, l.title, l.description, l.visibility, l.created_at,
 		       MAX(u.display_name) AS owner_display_name,
 		       MAX(u.display_name_slug) AS owner_display_name_slug,
@@ -616,12 +1098,12 @@ func (s *LinkStore) ListPublicByOwner(ctx context.Context, userID string, page,
 		JOIN users u ON u.id = lo.user_id
 		LEFT JOIN link_tags lt ON lt.link_id = l.id
 		LEFT JOIN tags t ON t.id = lt.tag_id
-		WHERE l.visibility = 'public'
+		WHERE l.visibility = 'public' AND l.pending_review = 0
 		  AND lo.user_id = ?
 		GROUP BY l.id
 		ORDER BY l.created_at DESC
 		LIMIT ? OFFSET ?
-	`, s.aggAll("t.name"))), userID, perPage, offset)
+	`, s.aggAll("t.name")), userID, perPage, offset)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -629,6 +1111,38 @@ func (s *LinkStore) ListPublicByOwner(ctx context.Context, userID string, page,
 	return links, total, nil
 }
 
+// ListRecentPublicByTag returns the newest public links carrying tagSlug,
+// with owner and tag info, up to limit rows.
+// Governing: SPEC-0012 REQ "Tag Feeds"
+func (s *LinkStore) ListRecentPublicByTag(ctx context.Context, tagSlug string, limit int) ([]PublicLink, error) {
+	var links []PublicLink
+	scope, scopeArgs := tenantScope(ctx, "l.tenant_id")
+	err := s.db.SelectContext(ctx, &links, s.q(fmt.Sprintf(`
+		SELECT l.id, l.slug, l.url, l.title, l.description, l.visibility, l.created_at,
+		       COALESCE(MAX(u.display_name), '') AS owner_display_name,
+		       COALESCE(MAX(u.display_name_slug), '') AS owner_display_name_slug,
+		       %s AS tag_list
+		FROM links l
+		LEFT JOIN link_owners lo ON lo.link_id = l.id AND lo.is_primary = 1
+		LEFT JOIN users u ON u.id = lo.user_id
+		LEFT JOIN link_tags lt ON lt.link_id = l.id
+		LEFT JOIN tags t ON t.id = lt.tag_id
+		WHERE l.visibility = 'public' AND l.pending_review = 0
+		  AND EXISTS (
+		      SELECT 1 FROM link_tags flt
+		      JOIN tags ft ON ft.id = flt.tag_id
+		      WHERE flt.link_id = l.id AND ft.slug = ?
+		  )`+scope+`
+		GROUP BY l.id
+		ORDER BY l.created_at DESC
+		LIMIT ?
+	`, s.aggDistinct("t.name"))), append(append([]interface{}{tagSlug}, scopeArgs...), limit)...)
+	if err != nil {
+		return nil, err
+	}
+	return links, nil
+}
+
 // CountAll returns the total number of links.
 // Governing: SPEC-0016 REQ "Prometheus Metrics Endpoint", ADR-0016
 func (s *LinkStore) CountAll(ctx context.Context) (int64, error) {
@@ -3,6 +3,7 @@ package store_test
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
 
 	"github.com/joestump/joe-links/internal/store"
@@ -47,6 +48,45 @@ func TestLinkStore_Create(t *testing.T) {
 	}
 }
 
+// Governing: SPEC-0002 REQ "Link Creator Attribution"
+func TestLinkStore_CreatedBySurvivesTransfer(t *testing.T) {
+	ls, _, us, creatorID := newTestEnv(t)
+	ctx := context.Background()
+	heir, err := us.Upsert(ctx, "test", "sub2", "heir@example.com", "Heir", "")
+	if err != nil {
+		t.Fatalf("seed user: %v", err)
+	}
+
+	link, err := ls.Create(ctx, "history", "https://example.com", creatorID, "", "", "")
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if link.CreatedBy != creatorID {
+		t.Errorf("CreatedBy = %q, want %q", link.CreatedBy, creatorID)
+	}
+	admin, err := ls.GetAdminLink(ctx, link.ID)
+	if err != nil {
+		t.Fatalf("GetAdminLink: %v", err)
+	}
+	if admin.CreatorName != "Test User" {
+		t.Errorf("CreatorName = %q, want %q", admin.CreatorName, "Test User")
+	}
+
+	if err := us.DeleteAccount(ctx, creatorID, store.AccountLinksTransfer, heir.ID); err != nil {
+		t.Fatalf("DeleteAccount: %v", err)
+	}
+	admin, err = ls.GetAdminLink(ctx, link.ID)
+	if err != nil {
+		t.Fatalf("GetAdminLink after transfer: %v", err)
+	}
+	if admin.CreatedBy != creatorID {
+		t.Errorf("CreatedBy after transfer = %q, want %q", admin.CreatedBy, creatorID)
+	}
+	if admin.Owners != "Heir" {
+		t.Errorf("Owners after transfer = %q, want %q", admin.Owners, "Heir")
+	}
+}
+
 func TestLinkStore_GetBySlug(t *testing.T) {
 	ls, _, _, userID := newTestEnv(t)
 	ctx := context.Background()
@@ -252,3 +292,92 @@ func TestLinkStore_ListByTag(t *testing.T) {
 		t.Errorf("slug = %q, want %q", links[0].Slug, "tag-filter")
 	}
 }
+
+func TestLinkStore_GetByPathPrefix(t *testing.T) {
+	ls, _, _, userID := newTestEnv(t)
+	ctx := context.Background()
+
+	for _, slug := range []string{"gh", "gh/joestump", "docs"} {
+		if _, err := ls.Create(ctx, slug, "https://example.com/"+slug, userID, "", "", ""); err != nil {
+			t.Fatalf("Create %q: %v", slug, err)
+		}
+	}
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"gh", "gh"},                             // exact
+		{"gh/joestump", "gh/joestump"},           // exact beats shorter prefix
+		{"gh/joestump/joe-links", "gh/joestump"}, // longest prefix
+		{"gh/other/repo", "gh"},
+		{"ghost/repo", ""},      // prefixes are segment-aligned
+		{"nothing/here/at", ""}, // no candidate exists
+	}
+	for _, tt := range tests {
+		link, _, err := ls.GetByPathPrefix(ctx, tt.path)
+		if tt.want == "" {
+			if !errors.Is(err, store.ErrNotFound) {
+				t.Errorf("%q: err = %v, want ErrNotFound", tt.path, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q: %v", tt.path, err)
+			continue
+		}
+		if link.Slug != tt.want {
+			t.Errorf("%q: slug = %q, want %q", tt.path, link.Slug, tt.want)
+		}
+	}
+}
+
+// newPrefixBenchStore seeds a handful of links plus a one-segment slug that
+// deep paths fall back to, so every lookup walks the whole prefix chain.
+func newPrefixBenchStore(b *testing.B) *store.LinkStore {
+	b.Helper()
+	db := testutil.NewTestDB(b)
+	owns := store.NewOwnershipStore(db)
+	ls := store.NewLinkStore(db, owns, store.NewTagStore(db))
+	ctx := context.Background()
+	u, err := store.NewUserStore(db).Upsert(ctx, "test", "sub1", "bench@example.com", "Bench", "")
+	if err != nil {
+		b.Fatalf("seed user: %v", err)
+	}
+	for _, slug := range []string{"gh", "docs", "jira", "wiki", "dash"} {
+		if _, err := ls.Create(ctx, slug, "https://example.com/$rest*", u.ID, "", "", ""); err != nil {
+			b.Fatalf("seed link: %v", err)
+		}
+	}
+	return ls
+}
+
+const deepBenchPath = "gh/a/b/c/d/e/f/g/h/i/j/k"
+
+// BenchmarkPrefixLookup_PerPrefix is the former resolver strategy: one
+// GetBySlug query per candidate prefix, longest first.
+func BenchmarkPrefixLookup_PerPrefix(b *testing.B) {
+	ls := newPrefixBenchStore(b)
+	ctx := context.Background()
+	segments := strings.Split(deepBenchPath, "/")
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for n := len(segments); n >= 1; n-- {
+			if _, err := ls.GetBySlug(ctx, strings.Join(segments[:n], "/")); err == nil {
+				break
+			}
+		}
+	}
+}
+
+// BenchmarkPrefixLookup_SingleQuery fetches all candidate prefixes at once.
+func BenchmarkPrefixLookup_SingleQuery(b *testing.B) {
+	ls := newPrefixBenchStore(b)
+	ctx := context.Background()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, _, err := ls.GetByPathPrefix(ctx, deepBenchPath); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+// Governing: SPEC-0011 REQ "Public Link Moderation"
+package store
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// ListPendingReview returns the public links awaiting admin approval, oldest
+// first, with their owners and tags.
+func (s *LinkStore) ListPendingReview(ctx context.Context) ([]*AdminLink, error) {
+	var links []*AdminLink
+	scope, args := tenantScope(ctx, "l.tenant_id")
+	err := s.db.SelectContext(ctx, &links, s.q(fmt.Sprintf(`
+		SELECT l.*,
+		       %s AS owners,
+		       %s AS tags
+		FROM links l
+		LEFT JOIN link_owners lo ON lo.link_id = l.id
+		LEFT JOIN users u ON u.id = lo.user_id
+		LEFT JOIN link_tags lt ON lt.link_id = l.id
+		LEFT JOIN tags t ON t.id = lt.tag_id
+		WHERE l.pending_review = 1`+scope+`
+		GROUP BY l.id
+		ORDER BY l.created_at ASC, l.slug ASC
+	`, s.aggDistinct("u.display_name"), s.aggDistinct("t.name"))), args...)
+	if err != nil {
+		return nil, err
+	}
+	return links, nil
+}
+
+// CountPendingReview returns how many links await admin approval.
+func (s *LinkStore) CountPendingReview(ctx context.Context) (int, error) {
+	var n int
+	scope, args := tenantScope(ctx, "tenant_id")
+	err := s.db.GetContext(ctx, &n, s.q(`SELECT COUNT(*) FROM links WHERE pending_review = 1`+scope), args...)
+	return n, err
+}
+
+// ApproveLink clears a link's pending review flag so it is listed publicly.
+// Returns ErrNotFound if the link is not awaiting review.
+func (s *LinkStore) ApproveLink(ctx context.Context, id string) error {
+	return s.review(ctx, `UPDATE links SET pending_review = 0, updated_at = ? WHERE id = ? AND pending_review = 1`, id)
+}
+
+// RejectLink clears a link's pending review flag and makes it private, so it
+// keeps resolving for its owners but is never listed. Returns ErrNotFound if
+// the link is not awaiting review.
+func (s *LinkStore) RejectLink(ctx context.Context, id string) error {
+	return s.review(ctx, `UPDATE links SET pending_review = 0, visibility = 'private', updated_at = ? WHERE id = ? AND pending_review = 1`, id)
+}
+
+func (s *LinkStore) review(ctx context.Context, query, id string) error {
+	res, err := s.db.ExecContext(ctx, s.q(query), time.Now().UTC(), id)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+// Governing: SPEC-0011 REQ "Public Link Moderation"
+package store_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/joestump/joe-links/internal/store"
+)
+
+func TestModeration(t *testing.T) {
+	ls, _, _, ownerID := newTestEnv(t)
+	ls.SetModeration(true)
+	ctx := context.Background()
+
+	public, err := ls.Create(ctx, "handbook", "https://example.com/handbook", ownerID, "Handbook", "", "public")
+	if err != nil {
+		t.Fatalf("Create(public): %v", err)
+	}
+	private, err := ls.Create(ctx, "notes", "https://example.com/notes", ownerID, "", "", "private")
+	if err != nil {
+		t.Fatalf("Create(private): %v", err)
+	}
+	if !public.PendingReview || private.PendingReview {
+		t.Fatalf("PendingReview = %v/%v, want public pending and private not", public.PendingReview, private.PendingReview)
+	}
+
+	listed, total, err := ls.ListPublic(ctx, "", "", 1, 10)
+	if err != nil || total != 0 || len(listed) != 0 {
+		t.Fatalf("ListPublic = %d links (err %v), want pending link hidden", total, err)
+	}
+
+	// Making a private link public sends it to the queue too.
+	if _, err := ls.Update(ctx, private.ID, private.URL, "", "", "public"); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	pending, err := ls.ListPendingReview(ctx)
+	if err != nil {
+		t.Fatalf("ListPendingReview: %v", err)
+	}
+	if len(pending) != 2 || pending[0].Slug != "handbook" {
+		t.Fatalf("pending = %d links, want handbook then notes", len(pending))
+	}
+
+	if err := ls.ApproveLink(ctx, public.ID); err != nil {
+		t.Fatalf("ApproveLink: %v", err)
+	}
+	if err := ls.ApproveLink(ctx, public.ID); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("approving twice: err = %v, want ErrNotFound", err)
+	}
+	if err := ls.RejectLink(ctx, private.ID); err != nil {
+		t.Fatalf("RejectLink: %v", err)
+	}
+	rejected, _ := ls.GetByID(ctx, private.ID)
+	if rejected.Visibility != "private" || rejected.PendingReview {
+		t.Errorf("rejected link = %s pending %v, want private and reviewed", rejected.Visibility, rejected.PendingReview)
+	}
+
+	listed, total, _ = ls.ListPublic(ctx, "", "", 1, 10)
+	if total != 1 || listed[0].Slug != "handbook" {
+		t.Errorf("ListPublic after review = %d links, want the approved handbook", total)
+	}
+	// Editing an approved public link does not re-queue it.
+	if _, err := ls.Update(ctx, public.ID, public.URL, "Handbook v2", "", "public"); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if n, _ := ls.CountPendingReview(ctx); n != 0 {
+		t.Errorf("CountPendingReview = %d after editing an approved link, want 0", n)
+	}
+}
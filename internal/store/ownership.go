@@ -5,8 +5,10 @@ import (
 	"database/sql"
 	"errors"
 	"strings"
+	"time"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/joestump/joe-links/internal/metrics"
 )
 
 var (
@@ -73,13 +75,18 @@ func (s *OwnershipStore) RemoveOwner(linkID, userID string) error {
 	return err
 }
 
-// IsOwner returns true if userID is in link_owners for linkID.
+// IsOwner returns true if userID is in link_owners for linkID, or belongs to
+// a team that owns it.
+// Governing: SPEC-0010 REQ "Team Shares and Ownership"
 func (s *OwnershipStore) IsOwner(linkID, userID string) (bool, error) {
+	defer metrics.ObserveDBQuery("owner_is_owner", time.Now())
 	var count int
-	err := s.db.QueryRow(
-		s.q(`SELECT COUNT(*) FROM link_owners WHERE link_id = ? AND user_id = ?`),
-		linkID, userID,
-	).Scan(&count)
+	err := s.db.QueryRow(s.q(`
+		SELECT (SELECT COUNT(*) FROM link_owners WHERE link_id = ? AND user_id = ?)
+		     + (SELECT COUNT(*) FROM link_team_owners lto
+		        INNER JOIN team_members tm ON tm.team_id = lto.team_id
+		        WHERE lto.link_id = ? AND tm.user_id = ?)
+	`), linkID, userID, linkID, userID).Scan(&count)
 	return count > 0, err
 }
 
@@ -119,3 +126,18 @@ func isUniqueConstraintError(err error) bool {
 		strings.Contains(msg, "duplicate key") || // PostgreSQL
 		strings.Contains(msg, "duplicate entry") // MySQL
 }
+
+// isRowRejectedError checks whether err rejects the row itself, through a
+// unique, foreign key, or not-null constraint, so retrying it cannot succeed.
+// Works across SQLite, PostgreSQL, and MySQL.
+func isRowRejectedError(err error) bool {
+	if err == nil {
+		return false
+	}
+	msg := strings.ToLower(err.Error())
+	return isUniqueConstraintError(err) ||
+		strings.Contains(msg, "foreign key") || // all three
+		strings.Contains(msg, "not null constraint") || // SQLite
+		strings.Contains(msg, "not-null constraint") || // PostgreSQL
+		strings.Contains(msg, "cannot be null") // MySQL
+}
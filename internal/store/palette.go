@@ -0,0 +1,84 @@
+// Governing: SPEC-0004 REQ "Command Palette"
+package store
+
+import (
+	"context"
+	"strings"
+)
+
+// PaletteLimit is the most links each command palette section returns.
+const PaletteLimit = 8
+
+// paletteSlugQuery normalizes palette input to a slug fragment: lowercase,
+// with any go/ prefix dropped. ok is false when the input cannot be part of
+// a slug, so it holds no LIKE wildcards.
+func paletteSlugQuery(q string) (string, bool) {
+	q = strings.TrimPrefix(strings.ToLower(strings.TrimSpace(q)), "go/")
+	if q == "" {
+		return "", false
+	}
+	for _, r := range q {
+		if !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '-') {
+			return "", false
+		}
+	}
+	return q, true
+}
+
+// MatchSlugsByOwner returns up to limit links owned by ownerID whose slug
+// contains q, slugs starting with q first and shorter slugs before longer.
+// It is a cheap LIKE lookup for type-ahead, unlike SearchByOwner.
+func (s *LinkStore) MatchSlugsByOwner(ctx context.Context, ownerID, q string, limit int) ([]*Link, error) {
+	return s.matchSlugs(ctx, q, limit, `EXISTS (SELECT 1 FROM link_owners lo WHERE lo.link_id = l.id AND lo.user_id = ?)`, ownerID)
+}
+
+// MatchSlugsAll is MatchSlugsByOwner across every link (admin view).
+func (s *LinkStore) MatchSlugsAll(ctx context.Context, q string, limit int) ([]*Link, error) {
+	return s.matchSlugs(ctx, q, limit, "")
+}
+
+// matchSlugs runs the slug lookup, restricted by scope (a predicate on l)
+// when it is not empty.
+func (s *LinkStore) matchSlugs(ctx context.Context, q string, limit int, scope string, args ...interface{}) ([]*Link, error) {
+	q, ok := paletteSlugQuery(q)
+	if !ok {
+		return nil, nil
+	}
+	where := `l.slug LIKE ?`
+	if scope != "" {
+		where += ` AND ` + scope
+	}
+	tenant, tenantArgs := tenantScope(ctx, "l.tenant_id")
+	where += tenant
+	args = append([]interface{}{"%" + q + "%"}, args...)
+	args = append(append(args, tenantArgs...), q+"%", limit)
+
+	var links []*Link
+	err := s.db.SelectContext(ctx, &links, s.q(`
+		SELECT l.* FROM links l
+		WHERE `+where+`
+		ORDER BY CASE WHEN l.slug LIKE ? THEN 0 ELSE 1 END, LENGTH(l.slug), l.slug
+		LIMIT ?
+	`), args...)
+	if err != nil {
+		return nil, err
+	}
+	return links, nil
+}
+
+// ListRecentByOwner returns up to limit of ownerID's links, most recently
+// created or edited first.
+func (s *LinkStore) ListRecentByOwner(ctx context.Context, ownerID string, limit int) ([]*Link, error) {
+	var links []*Link
+	err := s.db.SelectContext(ctx, &links, s.q(`
+		SELECT l.* FROM links l
+		INNER JOIN link_owners lo ON lo.link_id = l.id
+		WHERE lo.user_id = ?
+		ORDER BY l.updated_at DESC, l.slug ASC
+		LIMIT ?
+	`), ownerID, limit)
+	if err != nil {
+		return nil, err
+	}
+	return links, nil
+}
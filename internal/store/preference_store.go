@@ -0,0 +1,81 @@
+// Governing: SPEC-0004 REQ "Sortable Link Lists"
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Preference names.
+const (
+	// PrefDashboardSort is the dashboard link list's sort, as "key:dir".
+	PrefDashboardSort = "dashboard.sort"
+	// PrefAdminLinksSort is the admin links screen's sort, as "key:dir".
+	PrefAdminLinksSort = "admin.links.sort"
+	// PrefLanguage is the user's UI locale (e.g. "de"); unset follows the
+	// browser's Accept-Language.
+	// Governing: SPEC-0004 REQ "Internationalization"
+	PrefLanguage = "language"
+	// PrefTheme is the user's color theme, "joe-light" or "joe-dark"; unset
+	// follows the theme cookie, then the OS preference.
+	// Governing: SPEC-0003 REQ "Theme Persistence via Cookie"
+	PrefTheme = "theme"
+)
+
+// PreferenceStore reads and writes per-user UI preferences.
+type PreferenceStore struct {
+	db *sqlx.DB
+}
+
+// NewPreferenceStore creates a new PreferenceStore.
+func NewPreferenceStore(db *sqlx.DB) *PreferenceStore {
+	return &PreferenceStore{db: db}
+}
+
+// q rebinds ? placeholders to the driver's native format.
+func (s *PreferenceStore) q(query string) string { return s.db.Rebind(query) }
+
+// Get returns userID's value for the named preference, or ErrNotFound if they
+// have not set it.
+func (s *PreferenceStore) Get(ctx context.Context, userID, name string) (string, error) {
+	var value string
+	err := s.db.GetContext(ctx, &value, s.q(`SELECT value FROM user_preferences WHERE user_id = ? AND name = ?`), userID, name)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", ErrNotFound
+	}
+	return value, err
+}
+
+// All returns every preference userID has set, keyed by name.
+func (s *PreferenceStore) All(ctx context.Context, userID string) (map[string]string, error) {
+	var rows []struct {
+		Name  string `db:"name"`
+		Value string `db:"value"`
+	}
+	if err := s.db.SelectContext(ctx, &rows, s.q(`SELECT name, value FROM user_preferences WHERE user_id = ?`), userID); err != nil {
+		return nil, err
+	}
+	prefs := make(map[string]string, len(rows))
+	for _, row := range rows {
+		prefs[row.Name] = row.Value
+	}
+	return prefs, nil
+}
+
+// Set stores value as userID's named preference, replacing any previous value.
+func (s *PreferenceStore) Set(ctx context.Context, userID, name, value string) error {
+	now := time.Now().UTC()
+	res, err := s.db.ExecContext(ctx, s.q(`UPDATE user_preferences SET value = ?, updated_at = ? WHERE user_id = ? AND name = ?`), value, now, userID, name)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n > 0 {
+		return nil
+	}
+	_, err = s.db.ExecContext(ctx, s.q(`INSERT INTO user_preferences (user_id, name, value, updated_at) VALUES (?, ?, ?, ?)`), userID, name, value, now)
+	return err
+}
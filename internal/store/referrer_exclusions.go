@@ -0,0 +1,169 @@
+// Governing: SPEC-0016 REQ "Referrer Exclusion"
+package store
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrInvalidDomain is returned when an excluded referrer is not a host name.
+	ErrInvalidDomain = errors.New("invalid domain")
+	// ErrReferrerExcluded is returned when a domain is already excluded.
+	ErrReferrerExcluded = errors.New("referrer already excluded")
+)
+
+// domainRe matches a lowercase host name such as go.example.com or localhost.
+var domainRe = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]*[a-z0-9])?(\.[a-z0-9]([a-z0-9-]*[a-z0-9])?)*$`)
+
+// ExcludedReferrer is a referrer domain whose clicks do not count in stats:
+// the links dashboard itself, uptime checkers, and similar. It matches the
+// domain and all of its subdomains.
+type ExcludedReferrer struct {
+	Domain    string    `db:"domain"`
+	Reason    string    `db:"reason"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+// referrerHost returns the lowercase host of a referrer URL, or "" when the
+// referrer is empty or not an absolute URL.
+func referrerHost(ref string) string {
+	u, err := url.Parse(ref)
+	if err != nil {
+		return ""
+	}
+	return strings.ToLower(u.Hostname())
+}
+
+// NormalizeReferrerDomain turns admin input into the domain stored in
+// excluded_referrers, accepting a bare host (status.example.com) or a URL
+// (https://status.example.com/ping). Returns ErrInvalidDomain otherwise.
+func NormalizeReferrerDomain(s string) (string, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if strings.Contains(s, "://") {
+		s = referrerHost(s)
+	}
+	s = strings.TrimSuffix(s, ".")
+	if !domainRe.MatchString(s) {
+		return "", ErrInvalidDomain
+	}
+	return s, nil
+}
+
+// matchesDomain reports whether host is domain or one of its subdomains.
+func matchesDomain(host, domain string) bool {
+	return host == domain || strings.HasSuffix(host, "."+domain)
+}
+
+// ListExcludedReferrers returns the excluded referrer domains in domain order.
+func (s *ClickStore) ListExcludedReferrers(ctx context.Context) ([]*ExcludedReferrer, error) {
+	var list []*ExcludedReferrer
+	if err := s.db.SelectContext(ctx, &list, `SELECT * FROM excluded_referrers ORDER BY domain ASC`); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// ExcludeReferrer stops clicks referred by domain (or its subdomains) from
+// being recorded, and hides clicks already recorded from stats. Returns
+// ErrInvalidDomain for a malformed domain and ErrReferrerExcluded if it is
+// already excluded.
+func (s *ClickStore) ExcludeReferrer(ctx context.Context, domain, reason string) (*ExcludedReferrer, error) {
+	domain, err := NormalizeReferrerDomain(domain)
+	if err != nil {
+		return nil, err
+	}
+	er := &ExcludedReferrer{Domain: domain, Reason: reason, CreatedAt: time.Now().UTC()}
+	_, err = s.db.ExecContext(ctx, s.q(`
+		INSERT INTO excluded_referrers (domain, reason, created_at) VALUES (?, ?, ?)
+	`), er.Domain, er.Reason, er.CreatedAt)
+	if err != nil {
+		if isUniqueConstraintError(err) {
+			return nil, ErrReferrerExcluded
+		}
+		return nil, err
+	}
+	return er, nil
+}
+
+// RemoveExcludedReferrer counts domain's clicks again, including those
+// recorded before it was excluded. Clicks dropped while it was excluded are
+// not recovered. Returns ErrNotFound if domain is not excluded.
+func (s *ClickStore) RemoveExcludedReferrer(ctx context.Context, domain string) error {
+	res, err := s.db.ExecContext(ctx, s.q(`DELETE FROM excluded_referrers WHERE domain = ?`), domain)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// excludedDomains returns the excluded referrer domains.
+func (s *ClickStore) excludedDomains(ctx context.Context) ([]string, error) {
+	var domains []string
+	err := s.db.SelectContext(ctx, &domains, `SELECT domain FROM excluded_referrers`)
+	return domains, err
+}
+
+// dropExcluded returns the events whose referrer is not excluded, and how
+// many were dropped.
+func (s *ClickStore) dropExcluded(ctx context.Context, events []ClickEvent) ([]ClickEvent, int, error) {
+	domains, err := s.excludedDomains(ctx)
+	if err != nil || len(domains) == 0 {
+		return events, 0, err
+	}
+	kept := make([]ClickEvent, 0, len(events))
+	for _, e := range events {
+		if !excludedHost(referrerHost(e.Referrer), domains) {
+			kept = append(kept, e)
+		}
+	}
+	return kept, len(events) - len(kept), nil
+}
+
+// excludedHost reports whether host matches any of domains.
+func excludedHost(host string, domains []string) bool {
+	if host == "" {
+		return false
+	}
+	for _, d := range domains {
+		if matchesDomain(host, d) {
+			return true
+		}
+	}
+	return false
+}
+
+// exclusionClause returns a predicate, prefixed " AND ", hiding clicks (aliased
+// c) from excluded referrers, or "" when the store includes them or nothing is
+// excluded. Domains are validated host names, so they hold no LIKE wildcards.
+func (s *ClickStore) exclusionClause(ctx context.Context) (string, []any, error) {
+	if s.includeExcluded {
+		return "", nil, nil
+	}
+	domains, err := s.excludedDomains(ctx)
+	if err != nil || len(domains) == 0 {
+		return "", nil, err
+	}
+	var clause strings.Builder
+	args := make([]any, 0, 2*len(domains))
+	for _, d := range domains {
+		clause.WriteString(` AND c.referrer_host <> ? AND c.referrer_host NOT LIKE ?`)
+		args = append(args, d, "%."+d)
+	}
+	return clause.String(), args, nil
+}
+
+// IncludingExcluded returns a view of the store whose stats queries count
+// clicks from excluded referrers recorded before they were excluded.
+func (s *ClickStore) IncludingExcluded() *ClickStore {
+	c := *s
+	c.includeExcluded = true
+	return &c
+}
@@ -0,0 +1,98 @@
+// Governing: SPEC-0016 REQ "Referrer Exclusion"
+package store_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/joestump/joe-links/internal/store"
+)
+
+func TestNormalizeReferrerDomain(t *testing.T) {
+	for in, want := range map[string]string{
+		"Status.Example.com":              "status.example.com",
+		"https://go.example.com:8443/foo": "go.example.com",
+		"localhost.":                      "localhost",
+	} {
+		if got, err := store.NormalizeReferrerDomain(in); err != nil || got != want {
+			t.Errorf("NormalizeReferrerDomain(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"", "under_score.com", "%.example.com", "https://"} {
+		if _, err := store.NormalizeReferrerDomain(in); !errors.Is(err, store.ErrInvalidDomain) {
+			t.Errorf("NormalizeReferrerDomain(%q): err = %v, want ErrInvalidDomain", in, err)
+		}
+	}
+}
+
+func TestReferrerExclusion(t *testing.T) {
+	cs, _, _, _, linkID := newClickTestEnv(t)
+	ctx := context.Background()
+
+	click := func(ref string) store.ClickEvent {
+		return store.ClickEvent{LinkID: linkID, IPHash: "h", Referrer: ref}
+	}
+	// Recorded before the exclusion exists; hidden from stats retroactively.
+	if _, err := cs.RecordClicks(ctx, []store.ClickEvent{
+		click("https://uptime.example.com/check"),
+		click("https://wiki.example.org/page"),
+	}); err != nil {
+		t.Fatalf("RecordClicks: %v", err)
+	}
+
+	if _, err := cs.ExcludeReferrer(ctx, "example.com", "uptime checks"); err != nil {
+		t.Fatalf("ExcludeReferrer: %v", err)
+	}
+	if _, err := cs.ExcludeReferrer(ctx, "https://example.com/", ""); !errors.Is(err, store.ErrReferrerExcluded) {
+		t.Errorf("duplicate: err = %v, want ErrReferrerExcluded", err)
+	}
+
+	// Subdomains are dropped in the pipeline; lookalike hosts are not.
+	n, err := cs.RecordClicks(ctx, []store.ClickEvent{
+		click("https://status.example.com/"),
+		click("https://notexample.com/"),
+		click(""),
+	})
+	if err != nil || n != 3 {
+		t.Fatalf("RecordClicks = %d, %v; want 3 handled", n, err)
+	}
+	if err := cs.RecordClick(ctx, click("https://example.com/")); err != nil {
+		t.Fatalf("RecordClick: %v", err)
+	}
+
+	stats, err := cs.GetClickStats(ctx, linkID)
+	if err != nil {
+		t.Fatalf("GetClickStats: %v", err)
+	}
+	if stats.Total != 3 {
+		t.Errorf("filtered total = %d, want 3 (wiki, notexample, direct)", stats.Total)
+	}
+	recent, err := cs.ListRecentClicks(ctx, linkID, 10)
+	if err != nil {
+		t.Fatalf("ListRecentClicks: %v", err)
+	}
+	for _, c := range recent {
+		if c.Referrer == "https://uptime.example.com/check" {
+			t.Error("recent clicks include an excluded referrer")
+		}
+	}
+
+	all, err := cs.IncludingExcluded().GetClickStats(ctx, linkID)
+	if err != nil {
+		t.Fatalf("GetClickStats (all): %v", err)
+	}
+	if all.Total != 4 {
+		t.Errorf("unfiltered total = %d, want 4 (dropped clicks are never stored)", all.Total)
+	}
+
+	if err := cs.RemoveExcludedReferrer(ctx, "example.com"); err != nil {
+		t.Fatalf("RemoveExcludedReferrer: %v", err)
+	}
+	if err := cs.RemoveExcludedReferrer(ctx, "example.com"); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("remove again: err = %v, want ErrNotFound", err)
+	}
+	if stats, _ := cs.GetClickStats(ctx, linkID); stats.Total != 4 {
+		t.Errorf("total after removal = %d, want 4", stats.Total)
+	}
+}
@@ -0,0 +1,45 @@
+// Governing: SPEC-0001 REQ "Database Pool and Read Replica"
+package store
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/joestump/joe-links/internal/metrics"
+)
+
+// SetReplica routes the resolver's slug lookups and the public listings to a
+// read replica. Reads fall back to the primary when the replica fails, and
+// single-row lookups also when the replica has no row, since a link created
+// moments ago may not have replicated yet.
+func (s *LinkStore) SetReplica(replica *sqlx.DB) { s.replica = replica }
+
+// readGet runs a single-row query on the replica, falling back to the
+// primary as SetReplica describes.
+func (s *LinkStore) readGet(ctx context.Context, dest any, query string, args ...any) error {
+	if s.replica != nil {
+		err := s.replica.GetContext(ctx, dest, s.replica.Rebind(query), args...)
+		if err == nil || ctx.Err() != nil {
+			return err
+		}
+		metrics.ReplicaFallbacksTotal.Inc()
+	}
+	return s.db.GetContext(ctx, dest, s.q(query), args...)
+}
+
+// readSelect runs a multi-row query on the replica, falling back to the
+// primary if the replica fails.
+func (s *LinkStore) readSelect(ctx context.Context, dest any, query string, args ...any) error {
+	if s.replica != nil {
+		err := s.replica.SelectContext(ctx, dest, s.replica.Rebind(query), args...)
+		if err == nil || ctx.Err() != nil {
+			return err
+		}
+		metrics.ReplicaFallbacksTotal.Inc()
+		// Drop any rows scanned before the replica failed.
+		v := reflect.ValueOf(dest).Elem()
+		v.Set(reflect.Zero(v.Type()))
+	}
+	return s.db.SelectContext(ctx, dest, s.q(query), args...)
+}
@@ -0,0 +1,65 @@
+// Governing: SPEC-0001 REQ "Database Pool and Read Replica"
+package store_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/joestump/joe-links/internal/store"
+	"github.com/joestump/joe-links/internal/testutil"
+)
+
+func TestLinkStoreReplica(t *testing.T) {
+	primary := testutil.NewTestDB(t)
+	// NewTestDB names databases after the test, so a subtest gets its own.
+	t.Run("replica", func(t *testing.T) {
+		testReplica(t, primary, testutil.NewTestDB(t))
+	})
+}
+
+func testReplica(t *testing.T, primary, replica *sqlx.DB) {
+	ctx := context.Background()
+
+	seed := func(db *sqlx.DB, url string) *store.LinkStore {
+		t.Helper()
+		ls := store.NewLinkStore(db, store.NewOwnershipStore(db), store.NewTagStore(db))
+		owner, err := store.NewUserStore(db).Upsert(ctx, "test", "sub1", "owner@example.com", "Owner", "")
+		if err != nil {
+			t.Fatalf("seed user: %v", err)
+		}
+		if _, err := ls.Create(ctx, "wiki", url, owner.ID, "", "", "public"); err != nil {
+			t.Fatalf("seed link: %v", err)
+		}
+		return ls
+	}
+	ls := seed(primary, "https://wiki.example.com/primary")
+	seed(replica, "https://wiki.example.com/replica")
+	ls.SetReplica(replica)
+
+	// Reads are served by the replica when it has the row...
+	if l, err := ls.GetBySlug(ctx, "wiki"); err != nil || l.URL != "https://wiki.example.com/replica" {
+		t.Errorf("GetBySlug = %v, %v; want the replica's row", l, err)
+	}
+	if links, total, err := ls.ListPublic(ctx, "", "", 1, 10); err != nil || total != 1 || links[0].URL != "https://wiki.example.com/replica" {
+		t.Errorf("ListPublic = %d links, %v; want the replica's row", total, err)
+	}
+
+	// ...fall back to the primary for rows that have not replicated yet...
+	owner, _ := store.NewUserStore(primary).Upsert(ctx, "test", "sub1", "owner@example.com", "Owner", "")
+	if _, err := ls.Create(ctx, "fresh", "https://fresh.example.com", owner.ID, "", "", "public"); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if l, _, err := ls.GetByPathPrefix(ctx, "fresh/page"); err != nil || l.Slug != "fresh" {
+		t.Errorf("GetByPathPrefix = %v, %v; want fresh from the primary", l, err)
+	}
+	if _, err := ls.GetBySlug(ctx, "missing"); err != store.ErrNotFound {
+		t.Errorf("GetBySlug(missing) err = %v, want ErrNotFound", err)
+	}
+
+	// ...and when the replica is down.
+	_ = replica.Close()
+	if links, total, err := ls.ListPublic(ctx, "", "", 1, 10); err != nil || total != 2 || len(links) != 2 {
+		t.Errorf("ListPublic with replica down = %d links, %v; want both primary rows", total, err)
+	}
+}
@@ -0,0 +1,141 @@
+// Governing: SPEC-0002 REQ "Reserved Slugs"
+package store
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// ReservedSlug is a slug that no link or alias may use. Built-in entries
+// shadow application routes and cannot be removed; the rest are managed by
+// admins in the reserved_slugs table.
+type ReservedSlug struct {
+	Slug      string    `db:"slug"`
+	Reason    string    `db:"reason"`
+	CreatedAt time.Time `db:"created_at"`
+	BuiltIn   bool      `db:"-"`
+}
+
+// ReservedSlugStore is the sqlx-backed store for admin-managed reserved slugs.
+type ReservedSlugStore struct {
+	db *sqlx.DB
+}
+
+// NewReservedSlugStore creates a new ReservedSlugStore.
+func NewReservedSlugStore(db *sqlx.DB) *ReservedSlugStore {
+	return &ReservedSlugStore{db: db}
+}
+
+// q rebinds ? placeholders to the driver's native format ($1,$2,... for PostgreSQL).
+func (s *ReservedSlugStore) q(query string) string { return s.db.Rebind(query) }
+
+// BuiltInReservedSlugs returns the slugs reserved by application routes, sorted.
+func BuiltInReservedSlugs() []string {
+	slugs := make([]string, 0, len(reservedSlugs))
+	for slug := range reservedSlugs {
+		slugs = append(slugs, slug)
+	}
+	sort.Strings(slugs)
+	return slugs
+}
+
+// List returns the built-in reserved slugs followed by the admin-managed ones,
+// each group in slug order.
+func (s *ReservedSlugStore) List(ctx context.Context) ([]*ReservedSlug, error) {
+	var custom []*ReservedSlug
+	if err := s.db.SelectContext(ctx, &custom, `SELECT * FROM reserved_slugs ORDER BY slug ASC`); err != nil {
+		return nil, err
+	}
+	list := make([]*ReservedSlug, 0, len(reservedSlugs)+len(custom))
+	for _, slug := range BuiltInReservedSlugs() {
+		list = append(list, &ReservedSlug{Slug: slug, Reason: "Application route", BuiltIn: true})
+	}
+	return append(list, custom...), nil
+}
+
+// Add reserves slug. Links and aliases already using it keep working; only new
+// links and aliases are refused. Returns ErrSlugInvalid for a malformed slug
+// and ErrSlugTaken if slug is already reserved.
+func (s *ReservedSlugStore) Add(ctx context.Context, slug, reason string) (*ReservedSlug, error) {
+	if !slugRe.MatchString(slug) {
+		return nil, ErrSlugInvalid
+	}
+	if reservedSlugs[slug] {
+		return nil, ErrSlugTaken
+	}
+	rs := &ReservedSlug{Slug: slug, Reason: reason, CreatedAt: time.Now().UTC()}
+	_, err := s.db.ExecContext(ctx, s.q(`
+		INSERT INTO reserved_slugs (slug, reason, created_at) VALUES (?, ?, ?)
+	`), rs.Slug, rs.Reason, rs.CreatedAt)
+	if err != nil {
+		if isUniqueConstraintError(err) {
+			return nil, ErrSlugTaken
+		}
+		return nil, err
+	}
+	return rs, nil
+}
+
+// Remove releases an admin-managed reserved slug, or returns ErrNotFound if it
+// is not in the table. Built-in slugs are never in the table.
+func (s *ReservedSlugStore) Remove(ctx context.Context, slug string) error {
+	res, err := s.db.ExecContext(ctx, s.q(`DELETE FROM reserved_slugs WHERE slug = ?`), slug)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// Shadowed returns, sorted, the link and alias slugs equal to a built-in
+// reserved slug. They were created before a release reserved the slug for
+// an application route, which now wins, so they no longer resolve and must
+// be renamed. Admin-managed reservations never shadow existing links.
+func (s *ReservedSlugStore) Shadowed(ctx context.Context) ([]string, error) {
+	builtIn := BuiltInReservedSlugs()
+	query, args, err := sqlx.In(`
+		SELECT slug FROM links WHERE slug IN (?)
+		UNION
+		SELECT slug FROM link_aliases WHERE slug IN (?)
+		ORDER BY slug
+	`, builtIn, builtIn)
+	if err != nil {
+		return nil, err
+	}
+	var slugs []string
+	if err := s.db.SelectContext(ctx, &slugs, s.q(query), args...); err != nil {
+		return nil, err
+	}
+	return slugs, nil
+}
+
+// IsReserved reports whether slug is a built-in or admin-managed reserved slug.
+func (s *ReservedSlugStore) IsReserved(ctx context.Context, slug string) (bool, error) {
+	return slugReserved(ctx, s.db, slug)
+}
+
+// queryer is satisfied by both *sqlx.DB and *sqlx.Tx.
+type queryer interface {
+	GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+	Rebind(query string) string
+}
+
+// slugReserved checks slug against the built-in list and the reserved_slugs
+// table using q, which may be a transaction.
+func slugReserved(ctx context.Context, q queryer, slug string) (bool, error) {
+	if reservedSlugs[slug] {
+		return true, nil
+	}
+	var one int
+	err := q.GetContext(ctx, &one, q.Rebind(`SELECT 1 FROM reserved_slugs WHERE slug = ?`), slug)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	return err == nil, err
+}
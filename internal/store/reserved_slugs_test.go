@@ -0,0 +1,124 @@
+// Governing: SPEC-0002 REQ "Reserved Slugs"
+package store_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/joestump/joe-links/internal/store"
+	"github.com/joestump/joe-links/internal/testutil"
+)
+
+func TestReservedSlugs(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	owns := store.NewOwnershipStore(db)
+	ls := store.NewLinkStore(db, owns, store.NewTagStore(db))
+	rs := store.NewReservedSlugStore(db)
+	ctx := context.Background()
+
+	u, err := store.NewUserStore(db).Upsert(ctx, "test", "sub1", "test@example.com", "Test User", "")
+	if err != nil {
+		t.Fatalf("seed user: %v", err)
+	}
+	existing, err := ls.Create(ctx, "legal", "https://legal.example.com", u.ID, "", "", "")
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	if _, err := rs.Add(ctx, "hr", "Owned by the HR team"); err != nil {
+		t.Fatalf("Add(hr): %v", err)
+	}
+	if _, err := rs.Add(ctx, "legal", ""); err != nil {
+		t.Fatalf("Add(legal): %v", err)
+	}
+	if _, err := rs.Add(ctx, "hr", ""); !errors.Is(err, store.ErrSlugTaken) {
+		t.Errorf("duplicate: err = %v, want ErrSlugTaken", err)
+	}
+	if _, err := rs.Add(ctx, "admin", ""); !errors.Is(err, store.ErrSlugTaken) {
+		t.Errorf("built-in: err = %v, want ErrSlugTaken", err)
+	}
+	if _, err := rs.Add(ctx, "Not Valid", ""); !errors.Is(err, store.ErrSlugInvalid) {
+		t.Errorf("invalid: err = %v, want ErrSlugInvalid", err)
+	}
+
+	if _, err := ls.Create(ctx, "hr", "https://example.com", u.ID, "", "", ""); !errors.Is(err, store.ErrSlugReserved) {
+		t.Errorf("Create(hr): err = %v, want ErrSlugReserved", err)
+	}
+	if _, err := ls.AddAlias(ctx, existing.ID, "hr"); !errors.Is(err, store.ErrSlugReserved) {
+		t.Errorf("AddAlias(hr): err = %v, want ErrSlugReserved", err)
+	}
+	if _, err := ls.GetBySlug(ctx, "legal"); err != nil {
+		t.Errorf("existing link under a newly reserved slug: %v", err)
+	}
+
+	list, err := rs.List(ctx)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	builtIn := len(store.BuiltInReservedSlugs())
+	if len(list) != builtIn+2 {
+		t.Fatalf("List returned %d entries, want %d", len(list), builtIn+2)
+	}
+	if !list[0].BuiltIn || list[builtIn].Slug != "hr" || list[builtIn].BuiltIn || list[builtIn].Reason != "Owned by the HR team" {
+		t.Errorf("unexpected list order or fields: %+v, %+v", list[0], list[builtIn])
+	}
+
+	if err := rs.Remove(ctx, "hr"); err != nil {
+		t.Fatalf("Remove: %v", err)
+	}
+	if err := rs.Remove(ctx, "hr"); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("second Remove: err = %v, want ErrNotFound", err)
+	}
+	if err := rs.Remove(ctx, "admin"); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("Remove(built-in): err = %v, want ErrNotFound", err)
+	}
+	if reserved, err := rs.IsReserved(ctx, "hr"); err != nil || reserved {
+		t.Errorf("IsReserved(hr) after Remove = %v, %v", reserved, err)
+	}
+	if _, err := ls.Create(ctx, "hr", "https://example.com", u.ID, "", "", ""); err != nil {
+		t.Errorf("Create(hr) after Remove: %v", err)
+	}
+}
+
+func TestReservedSlugs_Shadowed(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	owns := store.NewOwnershipStore(db)
+	ls := store.NewLinkStore(db, owns, store.NewTagStore(db))
+	rs := store.NewReservedSlugStore(db)
+	ctx := context.Background()
+
+	u, err := store.NewUserStore(db).Upsert(ctx, "test", "sub1", "test@example.com", "Test User", "")
+	if err != nil {
+		t.Fatalf("seed user: %v", err)
+	}
+	link, err := ls.Create(ctx, "legal", "https://legal.example.com", u.ID, "", "", "")
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if _, err := rs.Add(ctx, "legal", ""); err != nil {
+		t.Fatalf("Add(legal): %v", err)
+	}
+	if slugs, err := rs.Shadowed(ctx); err != nil || len(slugs) != 0 {
+		t.Fatalf("Shadowed = %v, %v; want none", slugs, err)
+	}
+
+	// Links and aliases that predate a built-in reservation, as after an upgrade.
+	now := time.Now()
+	if _, err := db.Exec(`INSERT INTO links (id, slug, url, title, description, visibility, created_by, created_at, updated_at)
+		VALUES ('old', 'status', 'https://example.com', '', '', 'public', ?, ?, ?)`, u.ID, now, now); err != nil {
+		t.Fatalf("insert link: %v", err)
+	}
+	if _, err := db.Exec(`INSERT INTO link_aliases (slug, link_id, created_at) VALUES ('branding', ?, ?)`, link.ID, now); err != nil {
+		t.Fatalf("insert alias: %v", err)
+	}
+	slugs, err := rs.Shadowed(ctx)
+	if err != nil {
+		t.Fatalf("Shadowed: %v", err)
+	}
+	if strings.Join(slugs, ",") != "branding,status" {
+		t.Errorf("Shadowed = %v, want [branding status]", slugs)
+	}
+}
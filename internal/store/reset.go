@@ -0,0 +1,63 @@
+// Governing: SPEC-0001 REQ "Demo Mode"
+package store
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// dataTables lists every table holding application data, children before
+// the tables they reference. Sessions and the migration version table are
+// not listed: sessions of deleted users are dropped at their next request.
+var dataTables = []string{
+	"link_clicks",
+	"link_health",
+	"link_policy_violations",
+	"idempotency_keys",
+	"link_shares",
+	"link_team_shares",
+	"link_team_owners",
+	"link_tags",
+	"link_owners",
+	"link_aliases",
+	"link_search",
+	"links",
+	"tags",
+	"team_members",
+	"teams",
+	"keyword_clicks",
+	"keywords",
+	"api_usage_daily",
+	"api_tokens",
+	"saved_searches",
+	"user_preferences",
+	"link_policies",
+	"reserved_slugs",
+	"excluded_referrers",
+	"domain_rules",
+	"tenants",
+	"settings",
+	"account_link_codes",
+	"webauthn_credentials",
+	"user_identities",
+	"user_sessions",
+	"users",
+}
+
+// DeleteAllData empties every application table in a single transaction,
+// returning the database to its freshly migrated state. Used to reset demo
+// instances.
+func DeleteAllData(ctx context.Context, db *sqlx.DB) error {
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+	for _, table := range dataTables {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM `+table); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,52 @@
+// Governing: SPEC-0001 REQ "Demo Mode"
+package store_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/joestump/joe-links/internal/store"
+	"github.com/joestump/joe-links/internal/testutil"
+)
+
+func TestDeleteAllData(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	ctx := context.Background()
+	us := store.NewUserStore(db)
+	ls := store.NewLinkStore(db, store.NewOwnershipStore(db), store.NewTagStore(db))
+
+	u, err := us.Upsert(ctx, "test", "sub1", "test@example.com", "Test User", "")
+	if err != nil {
+		t.Fatalf("seed user: %v", err)
+	}
+	link, err := ls.Create(ctx, "wiki", "https://wiki.example.com", u.ID, "Wiki", "", "public")
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if err := ls.SetTags(ctx, link.ID, []string{"docs"}); err != nil {
+		t.Fatalf("SetTags: %v", err)
+	}
+
+	if err := store.DeleteAllData(ctx, db); err != nil {
+		t.Fatalf("DeleteAllData: %v", err)
+	}
+
+	// No table may keep rows except sessions and goose's version table.
+	var tables []string
+	if err := db.SelectContext(ctx, &tables, `SELECT name FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%'`); err != nil {
+		t.Fatalf("list tables: %v", err)
+	}
+	for _, table := range tables {
+		if table == "sessions" || table == "goose_db_version" || strings.HasPrefix(table, "link_search_") {
+			continue
+		}
+		var n int
+		if err := db.GetContext(ctx, &n, `SELECT COUNT(*) FROM `+table); err != nil {
+			t.Fatalf("count %s: %v", table, err)
+		}
+		if n != 0 {
+			t.Errorf("%s has %d rows after DeleteAllData", table, n)
+		}
+	}
+}
@@ -0,0 +1,132 @@
+// Governing: SPEC-0004 REQ "Saved Searches"
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/joestump/joe-links/internal/ids"
+)
+
+var (
+	// ErrSavedSearchNameRequired is returned when a search is saved without a name.
+	ErrSavedSearchNameRequired = errors.New("saved search name is required")
+	// ErrSavedSearchEmpty is returned when a search is saved with neither a
+	// query nor a tag, which would just repeat the unfiltered dashboard.
+	ErrSavedSearchEmpty = errors.New("saved search needs a query or a tag")
+	// ErrSavedSearchNameTaken is returned when the user already has a saved
+	// search with the same name.
+	ErrSavedSearchNameTaken = errors.New("saved search name already in use")
+)
+
+// SavedSearch is a named dashboard view: a link search query plus an optional
+// tag filter, private to the user who saved it.
+type SavedSearch struct {
+	ID        string    `db:"id"`
+	UserID    string    `db:"user_id"`
+	Name      string    `db:"name"`
+	Query     string    `db:"query"`
+	Tag       string    `db:"tag"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+// FilterQuery returns the search query with the tag folded in as a tag:
+// filter, in the syntax ParseLinkFilter accepts.
+func (s *SavedSearch) FilterQuery() string {
+	switch {
+	case s.Tag == "":
+		return s.Query
+	case s.Query == "":
+		return "tag:" + s.Tag
+	default:
+		return s.Query + " tag:" + s.Tag
+	}
+}
+
+// SavedSearchStore is the sqlx-backed store for saved searches.
+type SavedSearchStore struct {
+	db *sqlx.DB
+}
+
+// NewSavedSearchStore creates a new SavedSearchStore.
+func NewSavedSearchStore(db *sqlx.DB) *SavedSearchStore {
+	return &SavedSearchStore{db: db}
+}
+
+// q rebinds ? placeholders to the driver's native format ($1,$2,... for PostgreSQL).
+func (s *SavedSearchStore) q(query string) string { return s.db.Rebind(query) }
+
+// ListByUser returns the user's saved searches ordered by name.
+func (s *SavedSearchStore) ListByUser(ctx context.Context, userID string) ([]*SavedSearch, error) {
+	var searches []*SavedSearch
+	err := s.db.SelectContext(ctx, &searches, s.q(`
+		SELECT * FROM saved_searches WHERE user_id = ? ORDER BY name ASC
+	`), userID)
+	if err != nil {
+		return nil, err
+	}
+	return searches, nil
+}
+
+// Get returns the user's saved search with the given ID, or ErrNotFound.
+// Another user's saved search is reported as not found.
+func (s *SavedSearchStore) Get(ctx context.Context, userID, id string) (*SavedSearch, error) {
+	var ss SavedSearch
+	err := s.db.GetContext(ctx, &ss, s.q(`SELECT * FROM saved_searches WHERE id = ? AND user_id = ?`), id, userID)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &ss, nil
+}
+
+// Create saves a search for the user. The tag is stored as a tag slug.
+// Returns ErrSavedSearchNameRequired, ErrSavedSearchEmpty, ErrInvalidFilter
+// for a query that cannot be parsed, or ErrSavedSearchNameTaken.
+func (s *SavedSearchStore) Create(ctx context.Context, userID, name, query, tag string) (*SavedSearch, error) {
+	if name == "" {
+		return nil, ErrSavedSearchNameRequired
+	}
+	if tag != "" {
+		tag = DeriveTagSlug(tag)
+	}
+	if query == "" && tag == "" {
+		return nil, ErrSavedSearchEmpty
+	}
+	if _, err := ParseLinkFilter(query); err != nil {
+		return nil, err
+	}
+	ss := &SavedSearch{ID: ids.New(), UserID: userID, Name: name, Query: query, Tag: tag, CreatedAt: time.Now().UTC()}
+	_, err := s.db.ExecContext(ctx, s.q(`
+		INSERT INTO saved_searches (id, user_id, name, query, tag, created_at) VALUES (?, ?, ?, ?, ?, ?)
+	`), ss.ID, ss.UserID, ss.Name, ss.Query, ss.Tag, ss.CreatedAt)
+	if err != nil {
+		if isUniqueConstraintError(err) {
+			return nil, ErrSavedSearchNameTaken
+		}
+		return nil, err
+	}
+	return ss, nil
+}
+
+// Delete removes the user's saved search with the given ID. Returns
+// ErrNotFound if the user has no such saved search.
+func (s *SavedSearchStore) Delete(ctx context.Context, userID, id string) error {
+	res, err := s.db.ExecContext(ctx, s.q(`DELETE FROM saved_searches WHERE id = ? AND user_id = ?`), id, userID)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
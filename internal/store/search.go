@@ -0,0 +1,104 @@
+// Governing: SPEC-0002 REQ "Full-Text Link Search"
+package store
+
+import (
+	"context"
+	"strings"
+	"unicode"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// searchTokens splits q into lowercase runs of letters and digits. Punctuation
+// never reaches the full-text engines, so user input cannot produce a
+// malformed MATCH or tsquery expression.
+func searchTokens(q string) []string {
+	return strings.FieldsFunc(strings.ToLower(q), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// searchSource returns a derived table of (link_id, score) for links matching
+// every token of q as a word prefix, with higher scores for better matches.
+// Slug and title matches outrank tags, which outrank description and URL.
+// ok is false when q has no searchable tokens.
+func searchSource(driver, q string) (source string, args []interface{}, ok bool) {
+	tokens := searchTokens(q)
+	if len(tokens) == 0 {
+		return "", nil, false
+	}
+	switch driver {
+	case "postgres":
+		for i, t := range tokens {
+			tokens[i] = t + ":*"
+		}
+		return `(SELECT link_id, ts_rank(document, query) AS score
+			FROM link_search, to_tsquery('simple', ?) query
+			WHERE document @@ query)`, []interface{}{strings.Join(tokens, " & ")}, true
+	case "mysql":
+		for i, t := range tokens {
+			tokens[i] = "+" + t + "*"
+		}
+		expr := strings.Join(tokens, " ")
+		return `(SELECT link_id, MATCH(slug, title, description, url, tags) AGAINST (? IN BOOLEAN MODE) AS score
+			FROM link_search
+			WHERE MATCH(slug, title, description, url, tags) AGAINST (? IN BOOLEAN MODE))`, []interface{}{expr, expr}, true
+	default: // sqlite
+		for i, t := range tokens {
+			tokens[i] = `"` + t + `"*`
+		}
+		// bm25 ranks lower-is-better; negate it so every dialect sorts DESC.
+		// Weights follow the column order: link_id, slug, title, description, url, tags.
+		// LIMIT -1 stops SQLite flattening the subquery into an aggregate
+		// outer query, where bm25 cannot be evaluated.
+		return `(SELECT link_id, -bm25(link_search, 0.0, 10.0, 8.0, 2.0, 1.0, 4.0) AS score
+			FROM link_search
+			WHERE link_search MATCH ?
+			LIMIT -1)`, []interface{}{strings.Join(tokens, " ")}, true
+	}
+}
+
+// reindexLinks rebuilds the search index rows for linkIDs from the current
+// link and tag rows. IDs of deleted links are simply dropped from the index.
+// Run it in the same transaction as the write it follows.
+func reindexLinks(ctx context.Context, ext sqlx.ExtContext, linkIDs ...string) error {
+	var insert string
+	switch ext.DriverName() {
+	case "postgres":
+		insert = `INSERT INTO link_search (link_id, document)
+			SELECT l.id,
+			       setweight(to_tsvector('simple', l.slug), 'A') ||
+			       setweight(to_tsvector('simple', l.title), 'A') ||
+			       setweight(to_tsvector('simple', COALESCE((SELECT STRING_AGG(t.name, ' ') FROM link_tags lt JOIN tags t ON t.id = lt.tag_id WHERE lt.link_id = l.id), '')), 'B') ||
+			       setweight(to_tsvector('simple', l.description), 'C') ||
+			       setweight(to_tsvector('simple', l.url), 'D')
+			FROM links l WHERE l.id = ?`
+	case "mysql":
+		insert = `INSERT INTO link_search (link_id, slug, title, description, url, tags)
+			SELECT l.id, l.slug, l.title, l.description, l.url,
+			       COALESCE((SELECT GROUP_CONCAT(t.name SEPARATOR ' ') FROM link_tags lt JOIN tags t ON t.id = lt.tag_id WHERE lt.link_id = l.id), '')
+			FROM links l WHERE l.id = ?`
+	default: // sqlite
+		insert = `INSERT INTO link_search (link_id, slug, title, description, url, tags)
+			SELECT l.id, l.slug, l.title, l.description, l.url,
+			       COALESCE((SELECT GROUP_CONCAT(t.name, ' ') FROM link_tags lt JOIN tags t ON t.id = lt.tag_id WHERE lt.link_id = l.id), '')
+			FROM links l WHERE l.id = ?`
+	}
+	for _, id := range linkIDs {
+		if _, err := ext.ExecContext(ctx, ext.Rebind(`DELETE FROM link_search WHERE link_id = ?`), id); err != nil {
+			return err
+		}
+		if _, err := ext.ExecContext(ctx, ext.Rebind(insert), id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// taggedLinkIDs returns the IDs of links carrying tagID, for reindexing after
+// the tag changes.
+func taggedLinkIDs(ctx context.Context, ext sqlx.ExtContext, tagID string) ([]string, error) {
+	var ids []string
+	err := sqlx.SelectContext(ctx, ext, &ids, ext.Rebind(`SELECT link_id FROM link_tags WHERE tag_id = ?`), tagID)
+	return ids, err
+}
@@ -0,0 +1,203 @@
+// Governing: SPEC-0002 REQ "Structured Search Filters"
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidFilter is returned when a search query uses a filter with an
+// invalid value, or one this server cannot evaluate.
+var ErrInvalidFilter = errors.New("invalid search filter")
+
+// LinkFilter is a parsed link search: free text plus structured filters.
+// Every filter must match; repeating a filter requires each value to match
+// (tag:go tag:infra finds links carrying both tags).
+type LinkFilter struct {
+	Text       string   // free text, matched with full-text search
+	Owners     []string // owner: display name slug, username, or email of any owner
+	Tags       []string // tag: tag slug
+	Teams      []string // team: owning team slug
+	Visibility string   // visibility: public, private, or secure
+}
+
+// IsZero reports whether the filter matches every link.
+func (f LinkFilter) IsZero() bool {
+	return len(searchTokens(f.Text)) == 0 && len(f.Owners) == 0 && len(f.Tags) == 0 &&
+		len(f.Teams) == 0 && f.Visibility == ""
+}
+
+// ParseLinkFilter splits q into free text and key:value filters. Values may
+// be double-quoted to include spaces (owner:"Ada Lovelace"). Words whose key
+// is not a known filter stay in the free text, so pasted URLs such as
+// https://example.com search normally. Custom field filters (field.name:value)
+// are rejected with ErrInvalidFilter because links carry no custom fields.
+func ParseLinkFilter(q string) (LinkFilter, error) {
+	var f LinkFilter
+	var text []string
+	for _, word := range splitQuery(q) {
+		key, value, ok := strings.Cut(word, ":")
+		if !ok || value == "" {
+			text = append(text, word)
+			continue
+		}
+		value = strings.Trim(value, `"`)
+		switch key = strings.ToLower(key); {
+		case key == "owner":
+			f.Owners = append(f.Owners, strings.ToLower(strings.TrimPrefix(value, "@")))
+		case key == "tag":
+			f.Tags = append(f.Tags, DeriveTagSlug(value))
+		case key == "team":
+			f.Teams = append(f.Teams, strings.ToLower(value))
+		case key == "visibility":
+			v := strings.ToLower(value)
+			if v != "public" && v != "private" && v != "secure" {
+				return LinkFilter{}, fmt.Errorf("%w: visibility must be public, private, or secure", ErrInvalidFilter)
+			}
+			f.Visibility = v
+		case strings.HasPrefix(key, "field."):
+			return LinkFilter{}, fmt.Errorf("%w: %q filters on a custom field, but links have no custom fields", ErrInvalidFilter, key)
+		default:
+			text = append(text, word)
+		}
+	}
+	f.Text = strings.Join(text, " ")
+	return f, nil
+}
+
+// splitQuery splits q on whitespace, keeping double-quoted runs together.
+func splitQuery(q string) []string {
+	var words []string
+	var cur strings.Builder
+	quoted := false
+	for _, r := range q {
+		switch {
+		case r == '"':
+			quoted = !quoted
+			cur.WriteRune(r)
+		case !quoted && (r == ' ' || r == '\t' || r == '\n'):
+			if cur.Len() > 0 {
+				words = append(words, cur.String())
+				cur.Reset()
+			}
+		default:
+			cur.WriteRune(r)
+		}
+	}
+	if cur.Len() > 0 {
+		words = append(words, cur.String())
+	}
+	return words
+}
+
+// filterClause is a LinkFilter rendered as SQL against links aliased l.
+type filterClause struct {
+	join      string // INNER JOIN on the full-text matches, aliased fts; "" without free text
+	joinArgs  []interface{}
+	where     string // predicates joined with AND, each prefixed " AND "
+	whereArgs []interface{}
+}
+
+// ranked reports whether results can be ordered by fts.score.
+func (c *filterClause) ranked() bool { return c.join != "" }
+
+// filterClause renders f as SQL. Owner values are resolved to user IDs first,
+// matching a display name slug, a full email address, or an email local part.
+func (s *LinkStore) filterClause(ctx context.Context, f LinkFilter) (*filterClause, error) {
+	c := &filterClause{}
+	if source, args, ok := searchSource(s.db.DriverName(), f.Text); ok {
+		c.join = ` INNER JOIN ` + source + ` fts ON fts.link_id = l.id`
+		c.joinArgs = args
+	}
+	and := func(pred string, args ...interface{}) {
+		c.where += ` AND ` + pred
+		c.whereArgs = append(c.whereArgs, args...)
+	}
+	for _, owner := range f.Owners {
+		ids, err := s.ownerIDs(ctx, owner)
+		if err != nil {
+			return nil, err
+		}
+		if len(ids) == 0 {
+			and(`1 = 0`)
+			continue
+		}
+		placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(ids)), ", ")
+		args := make([]interface{}, len(ids))
+		for i, id := range ids {
+			args[i] = id
+		}
+		and(`EXISTS (SELECT 1 FROM link_owners fo WHERE fo.link_id = l.id AND fo.user_id IN (`+placeholders+`))`, args...)
+	}
+	for _, tag := range f.Tags {
+		and(`EXISTS (SELECT 1 FROM link_tags flt INNER JOIN tags ft ON ft.id = flt.tag_id WHERE flt.link_id = l.id AND ft.slug = ?)`, tag)
+	}
+	for _, team := range f.Teams {
+		and(`l.team_id IN (SELECT id FROM teams WHERE slug = ?)`, team)
+	}
+	if f.Visibility != "" {
+		and(`l.visibility = ?`, f.Visibility)
+	}
+	// Governing: SPEC-0001 REQ "Multi-Tenancy"
+	if tenantID, ok := TenantFromContext(ctx); ok {
+		and(`l.tenant_id = ?`, tenantID)
+	}
+	return c, nil
+}
+
+// ownerIDs returns the IDs of users an owner: filter value names.
+func (s *LinkStore) ownerIDs(ctx context.Context, owner string) ([]string, error) {
+	var users []struct {
+		ID    string `db:"id"`
+		Email string `db:"email"`
+		Slug  string `db:"display_name_slug"`
+	}
+	err := s.db.SelectContext(ctx, &users, s.q(`
+		SELECT id, email, display_name_slug FROM users
+		WHERE display_name_slug = ? OR LOWER(email) = ? OR LOWER(email) LIKE ?
+	`), owner, owner, owner+"@%")
+	if err != nil {
+		return nil, err
+	}
+	// LIKE treats _ and % in the value as wildcards, so confirm the match.
+	var ids []string
+	for _, u := range users {
+		local, _, _ := strings.Cut(u.Email, "@")
+		if u.Slug == owner || strings.EqualFold(u.Email, owner) || strings.EqualFold(local, owner) {
+			ids = append(ids, u.ID)
+		}
+	}
+	return ids, nil
+}
+
+// searchLinks returns links matching q within scope, a predicate on l that
+// restricts which links the caller may see ("" for every link). Results are
+// ranked when q has free text, otherwise ordered by slug.
+func (s *LinkStore) searchLinks(ctx context.Context, q, scope string, scopeArgs ...interface{}) ([]*Link, error) {
+	f, err := ParseLinkFilter(q)
+	if err != nil {
+		return nil, err
+	}
+	c, err := s.filterClause(ctx, f)
+	if err != nil {
+		return nil, err
+	}
+	if scope == "" {
+		scope = `1 = 1`
+	}
+	orderBy := `l.slug ASC`
+	if c.ranked() {
+		orderBy = `fts.score DESC, l.slug ASC`
+	}
+	args := append(append(append([]interface{}{}, c.joinArgs...), scopeArgs...), c.whereArgs...)
+	var links []*Link
+	err = s.db.SelectContext(ctx, &links, s.q(`SELECT l.* FROM links l`+c.join+`
+		WHERE `+scope+c.where+`
+		ORDER BY `+orderBy), args...)
+	if err != nil {
+		return nil, err
+	}
+	return links, nil
+}
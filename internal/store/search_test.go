@@ -0,0 +1,191 @@
+// Governing: SPEC-0002 REQ "Full-Text Link Search", REQ "Structured Search Filters"
+package store_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/joestump/joe-links/internal/store"
+	"github.com/joestump/joe-links/internal/testutil"
+)
+
+func slugsOf(links []*store.Link) []string {
+	out := make([]string, len(links))
+	for i, l := range links {
+		out[i] = l.Slug
+	}
+	return out
+}
+
+func TestLinkStore_FullTextSearch(t *testing.T) {
+	ls, tags, _, userID := newTestEnv(t)
+	ctx := context.Background()
+
+	for _, l := range []struct{ slug, url, title, desc string }{
+		{"handbook", "https://docs.example.com/handbook", "Employee Handbook", "Policies and benefits"},
+		{"benefits", "https://hr.example.com/benefits", "Benefits portal", "Enroll in health plans"},
+		{"grafana", "https://grafana.example.com", "Dashboards", "Metrics for the platform team"},
+	} {
+		if _, err := ls.Create(ctx, l.slug, l.url, userID, l.title, l.desc, "public"); err != nil {
+			t.Fatalf("Create %s: %v", l.slug, err)
+		}
+	}
+	grafana, _ := ls.GetBySlug(ctx, "grafana")
+	if err := ls.SetTags(ctx, grafana.ID, []string{"Observability"}); err != nil {
+		t.Fatalf("SetTags: %v", err)
+	}
+
+	search := func(q string) []string {
+		t.Helper()
+		links, err := ls.SearchAll(ctx, q)
+		if err != nil {
+			t.Fatalf("SearchAll(%q): %v", q, err)
+		}
+		return slugsOf(links)
+	}
+	assert := func(q string, want ...string) {
+		t.Helper()
+		got := search(q)
+		if len(got) != len(want) {
+			t.Errorf("search %q = %v, want %v", q, got, want)
+			return
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Errorf("search %q = %v, want %v", q, got, want)
+				return
+			}
+		}
+	}
+
+	// A slug/title match outranks a description match.
+	assert("benefits", "benefits", "handbook")
+	// Prefix matching for search-as-you-type; every word must match.
+	assert("bene", "benefits", "handbook")
+	assert("bene health", "benefits")
+	// Tags are searchable and follow renames.
+	assert("observ", "grafana")
+	if _, err := tags.Rename(ctx, "observability", "Monitoring"); err != nil {
+		t.Fatalf("Rename: %v", err)
+	}
+	assert("observ")
+	assert("monitor", "grafana")
+	// Updates and deletes keep the index current.
+	if _, err := ls.Update(ctx, grafana.ID, grafana.URL, "Metrics", "", "public"); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	assert("dashboards")
+	if err := ls.Delete(ctx, grafana.ID); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	assert("monitor")
+	// Operators and quotes are treated as word separators, not syntax.
+	assert(`"hand"* -employ`, "handbook")
+	if got := search("!!!"); len(got) != 2 {
+		t.Errorf("punctuation-only query = %v, want every link", got)
+	}
+
+	owned, err := ls.SearchByOwner(ctx, userID, "hand")
+	if err != nil || len(owned) != 1 || owned[0].Slug != "handbook" {
+		t.Errorf("SearchByOwner = %v (err %v), want [handbook]", slugsOf(owned), err)
+	}
+	public, total, err := ls.ListPublic(ctx, "", "benefit", 1, 10)
+	if err != nil || total != 2 || public[0].Slug != "benefits" {
+		t.Errorf("ListPublic = %d links, total %d (err %v), want benefits first of 2", len(public), total, err)
+	}
+}
+
+// Governing: SPEC-0002 REQ "Structured Search Filters"
+func TestParseLinkFilter(t *testing.T) {
+	f, err := store.ParseLinkFilter(`runbook owner:@Alice tag:"On Call" team:SRE visibility:Private https://example.com`)
+	if err != nil {
+		t.Fatalf("ParseLinkFilter: %v", err)
+	}
+	if f.Text != "runbook https://example.com" {
+		t.Errorf("Text = %q", f.Text)
+	}
+	if len(f.Owners) != 1 || f.Owners[0] != "alice" || len(f.Tags) != 1 || f.Tags[0] != "on-call" ||
+		len(f.Teams) != 1 || f.Teams[0] != "sre" || f.Visibility != "private" {
+		t.Errorf("filter = %+v", f)
+	}
+
+	for _, q := range []string{"visibility:hidden", "field.costcenter:123"} {
+		if _, err := store.ParseLinkFilter(q); !errors.Is(err, store.ErrInvalidFilter) {
+			t.Errorf("ParseLinkFilter(%q) err = %v, want ErrInvalidFilter", q, err)
+		}
+	}
+}
+
+// Governing: SPEC-0002 REQ "Structured Search Filters"
+func TestLinkStore_SearchFilters(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	owns := store.NewOwnershipStore(db)
+	ls := store.NewLinkStore(db, owns, store.NewTagStore(db))
+	us := store.NewUserStore(db)
+	teams := store.NewTeamStore(db)
+	ctx := context.Background()
+
+	alice, err := us.Upsert(ctx, "test", "a", "alice@example.com", "Alice Smith", "")
+	if err != nil {
+		t.Fatalf("seed alice: %v", err)
+	}
+	bob, err := us.Upsert(ctx, "test", "b", "bob@example.com", "Bob", "")
+	if err != nil {
+		t.Fatalf("seed bob: %v", err)
+	}
+	sre, err := teams.Create(ctx, "sre", "SRE", "")
+	if err != nil {
+		t.Fatalf("seed team: %v", err)
+	}
+	seed := func(slug, owner, visibility string, tags ...string) *store.Link {
+		l, err := ls.Create(ctx, slug, "https://example.com/"+slug, owner, "", "", visibility)
+		if err != nil {
+			t.Fatalf("Create %s: %v", slug, err)
+		}
+		if err := ls.SetTags(ctx, l.ID, tags); err != nil {
+			t.Fatalf("SetTags: %v", err)
+		}
+		return l
+	}
+	oncall := seed("oncall", alice.ID, "public", "infra", "pager")
+	seed("deploys", alice.ID, "private", "infra")
+	seed("lunch", bob.ID, "public", "food")
+	if err := ls.SetTeam(ctx, oncall.ID, sre.ID); err != nil {
+		t.Fatalf("SetTeam: %v", err)
+	}
+
+	tests := []struct {
+		q    string
+		want []string
+	}{
+		{"owner:alice", []string{"deploys", "oncall"}},
+		{"owner:alice-smith", []string{"deploys", "oncall"}},
+		{"owner:bob@example.com", []string{"lunch"}},
+		{"owner:nobody", nil},
+		{"tag:infra", []string{"deploys", "oncall"}},
+		{"tag:infra tag:pager", []string{"oncall"}},
+		{"tag:infra visibility:private", []string{"deploys"}},
+		{"team:sre", []string{"oncall"}},
+		{"owner:alice lunch", nil},
+		{"oncall tag:infra", []string{"oncall"}},
+	}
+	for _, tt := range tests {
+		links, err := ls.SearchAll(ctx, tt.q)
+		if err != nil {
+			t.Fatalf("SearchAll(%q): %v", tt.q, err)
+		}
+		if got := slugsOf(links); strings.Join(got, ",") != strings.Join(tt.want, ",") {
+			t.Errorf("SearchAll(%q) = %v, want %v", tt.q, got, tt.want)
+		}
+	}
+
+	admin, err := ls.ListAllAdmin(ctx, "tag:infra owner:alice")
+	if err != nil || len(admin) != 2 {
+		t.Errorf("ListAllAdmin = %d links (err %v), want 2", len(admin), err)
+	}
+	if _, err := ls.SearchByOwner(ctx, bob.ID, "field.costcenter:1"); !errors.Is(err, store.ErrInvalidFilter) {
+		t.Errorf("custom field filter err = %v, want ErrInvalidFilter", err)
+	}
+}
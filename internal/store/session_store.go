@@ -0,0 +1,100 @@
+// Governing: SPEC-0001 REQ "Session Management"
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/joestump/joe-links/internal/ids"
+)
+
+// SessionTouchInterval is how stale a session's last-seen time may get before
+// Touch writes it again, so busy sessions do not write on every request.
+const SessionTouchInterval = time.Minute
+
+// UserSession is one signed-in browser session. Token is the session
+// manager's key for it and must never be shown; ID names it in URLs.
+type UserSession struct {
+	ID         string    `db:"id"`
+	Token      string    `db:"token"`
+	UserID     string    `db:"user_id"`
+	IPHash     string    `db:"ip_hash"`
+	UserAgent  string    `db:"user_agent"`
+	CreatedAt  time.Time `db:"created_at"`
+	LastSeenAt time.Time `db:"last_seen_at"`
+}
+
+// SessionStore records users' signed-in sessions so they can be listed and
+// revoked. The session data itself lives in the session manager's store.
+type SessionStore struct {
+	db *sqlx.DB
+}
+
+// NewSessionStore creates a new SessionStore.
+func NewSessionStore(db *sqlx.DB) *SessionStore {
+	return &SessionStore{db: db}
+}
+
+// q rebinds ? placeholders to the driver's native format.
+func (s *SessionStore) q(query string) string { return s.db.Rebind(query) }
+
+// Touch records that token, signed in as userID, was used at now from a
+// client with the given IP hash and user agent. The first call for a token
+// records the session; later ones update it at most once per
+// SessionTouchInterval.
+func (s *SessionStore) Touch(ctx context.Context, token, userID, ipHash, userAgent string, now time.Time) error {
+	var cur UserSession
+	err := s.db.GetContext(ctx, &cur, s.q(`SELECT * FROM user_sessions WHERE token = ?`), token)
+	if errors.Is(err, sql.ErrNoRows) {
+		_, err = s.db.ExecContext(ctx, s.q(`
+			INSERT INTO user_sessions (id, token, user_id, ip_hash, user_agent, created_at, last_seen_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?)
+		`), ids.New(), token, userID, ipHash, userAgent, now, now)
+		return err
+	}
+	if err != nil {
+		return err
+	}
+	if cur.UserID == userID && now.Sub(cur.LastSeenAt) < SessionTouchInterval {
+		return nil
+	}
+	_, err = s.db.ExecContext(ctx, s.q(`
+		UPDATE user_sessions SET user_id = ?, ip_hash = ?, user_agent = ?, last_seen_at = ? WHERE token = ?
+	`), userID, ipHash, userAgent, now, token)
+	return err
+}
+
+// ListByUser returns userID's recorded sessions, most recently used first.
+func (s *SessionStore) ListByUser(ctx context.Context, userID string) ([]*UserSession, error) {
+	var sessions []*UserSession
+	err := s.db.SelectContext(ctx, &sessions, s.q(`
+		SELECT * FROM user_sessions WHERE user_id = ? ORDER BY last_seen_at DESC
+	`), userID)
+	if err != nil {
+		return nil, err
+	}
+	return sessions, nil
+}
+
+// Get returns userID's session with the given ID, or ErrNotFound. Another
+// user's session is reported as not found.
+func (s *SessionStore) Get(ctx context.Context, userID, id string) (*UserSession, error) {
+	var us UserSession
+	err := s.db.GetContext(ctx, &us, s.q(`SELECT * FROM user_sessions WHERE id = ? AND user_id = ?`), id, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &us, nil
+}
+
+// Delete forgets the session with the given token.
+func (s *SessionStore) Delete(ctx context.Context, token string) error {
+	_, err := s.db.ExecContext(ctx, s.q(`DELETE FROM user_sessions WHERE token = ?`), token)
+	return err
+}
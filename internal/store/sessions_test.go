@@ -0,0 +1,66 @@
+package store_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/joestump/joe-links/internal/store"
+	"github.com/joestump/joe-links/internal/testutil"
+)
+
+// Governing: SPEC-0001 REQ "Session Management"
+func TestSessionStore_Touch(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	ctx := context.Background()
+	users := store.NewUserStore(db)
+	alice, err := users.Upsert(ctx, "test", "a", "alice@example.com", "Alice", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	bob, err := users.Upsert(ctx, "test", "b", "bob@example.com", "Bob", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ss := store.NewSessionStore(db)
+	start := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	if err := ss.Touch(ctx, "tok1", alice.ID, "h1", "Firefox", start); err != nil {
+		t.Fatal(err)
+	}
+	// Within the interval nothing is written.
+	if err := ss.Touch(ctx, "tok1", alice.ID, "h2", "Chrome", start.Add(30*time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	list, err := ss.ListByUser(ctx, alice.ID)
+	if err != nil || len(list) != 1 {
+		t.Fatalf("list = %v, %v", list, err)
+	}
+	if s := list[0]; s.UserAgent != "Firefox" || !s.LastSeenAt.Equal(start) {
+		t.Errorf("touched within interval: %+v", s)
+	}
+
+	later := start.Add(2 * time.Minute)
+	if err := ss.Touch(ctx, "tok1", alice.ID, "h2", "Chrome", later); err != nil {
+		t.Fatal(err)
+	}
+	s, err := ss.Get(ctx, alice.ID, list[0].ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.UserAgent != "Chrome" || s.IPHash != "h2" || !s.LastSeenAt.Equal(later) || !s.CreatedAt.Equal(start) {
+		t.Errorf("after interval: %+v", s)
+	}
+
+	if _, err := ss.Get(ctx, bob.ID, s.ID); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("other user's session: err = %v, want ErrNotFound", err)
+	}
+
+	if err := ss.Delete(ctx, "tok1"); err != nil {
+		t.Fatal(err)
+	}
+	if list, _ := ss.ListByUser(ctx, alice.ID); len(list) != 0 {
+		t.Errorf("after delete: %d sessions", len(list))
+	}
+}
@@ -0,0 +1,124 @@
+// Governing: SPEC-0001 REQ "First-Run Setup"
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/joestump/joe-links/internal/secrets"
+)
+
+// Setting names.
+const (
+	// SettingSetupCompleted is present once the first-run setup has finished.
+	SettingSetupCompleted = "setup.completed"
+	// SettingAdminEmail is the admin email chosen during setup; it grants the
+	// admin role at sign-in when JOE_ADMIN_EMAIL is unset.
+	SettingAdminEmail = "setup.admin_email"
+	// SettingCampaignKey is the hex HMAC key that signs campaign source tags.
+	// Governing: SPEC-0016 REQ "Campaign Sources"
+	SettingCampaignKey = "campaign.key"
+	// SettingShareURLKey is the hex HMAC key that signs share URLs for
+	// secure links.
+	// Governing: SPEC-0010 REQ "Signed Share URLs"
+	SettingShareURLKey = "share_url.key"
+	// SettingVisibilityLenient is present on instances that predate strict
+	// visibility, which then stays off unless JOE_VISIBILITY_STRICT is set.
+	// Governing: SPEC-0010 REQ "Strict Visibility"
+	SettingVisibilityLenient = "visibility.lenient"
+	// SettingSMTPPassword is the SMTP password used when JOE_MAIL_PASSWORD
+	// is unset. It is stored encrypted; see SetSecret.
+	// Governing: SPEC-0001 REQ "Encrypted Secrets"
+	SettingSMTPPassword = "mail.smtp_password"
+	// SettingLLMAPIKey is the LLM provider API key used when JOE_LLM_API_KEY
+	// is unset. It is stored encrypted; see SetSecret.
+	// Governing: SPEC-0001 REQ "Encrypted Secrets"
+	SettingLLMAPIKey = "llm.api_key"
+)
+
+// SecretSettings lists the settings that hold credentials. They are only
+// written through SetSecret, so the settings table never holds them in
+// plaintext.
+var SecretSettings = []string{SettingSMTPPassword, SettingLLMAPIKey}
+
+// SettingsStore reads and writes instance-wide settings.
+type SettingsStore struct {
+	db      *sqlx.DB
+	secrets *secrets.Box
+}
+
+// NewSettingsStore creates a new SettingsStore.
+func NewSettingsStore(db *sqlx.DB) *SettingsStore {
+	return &SettingsStore{db: db}
+}
+
+// WithSecrets sets the box GetSecret and SetSecret encrypt with. Without
+// one they return secrets.ErrNoKey.
+func (s *SettingsStore) WithSecrets(b *secrets.Box) *SettingsStore {
+	s.secrets = b
+	return s
+}
+
+// q rebinds ? placeholders to the driver's native format.
+func (s *SettingsStore) q(query string) string { return s.db.Rebind(query) }
+
+// Get returns the value of the named setting, or ErrNotFound if it is unset.
+func (s *SettingsStore) Get(ctx context.Context, name string) (string, error) {
+	var value string
+	err := s.db.GetContext(ctx, &value, s.q(`SELECT value FROM settings WHERE name = ?`), name)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", ErrNotFound
+	}
+	return value, err
+}
+
+// Set stores value under name, replacing any previous value.
+func (s *SettingsStore) Set(ctx context.Context, name, value string) error {
+	now := time.Now().UTC()
+	res, err := s.db.ExecContext(ctx, s.q(`UPDATE settings SET value = ?, updated_at = ? WHERE name = ?`), value, now, name)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n > 0 {
+		return nil
+	}
+	_, err = s.db.ExecContext(ctx, s.q(`INSERT INTO settings (name, value, updated_at) VALUES (?, ?, ?)`), name, value, now)
+	return err
+}
+
+// Delete removes the named setting. Deleting an unset setting is not an error.
+func (s *SettingsStore) Delete(ctx context.Context, name string) error {
+	_, err := s.db.ExecContext(ctx, s.q(`DELETE FROM settings WHERE name = ?`), name)
+	return err
+}
+
+// GetSecret returns the decrypted value of a setting written by SetSecret,
+// or ErrNotFound if it is unset. It returns secrets.ErrInvalid when the value
+// was sealed with a different key.
+// Governing: SPEC-0001 REQ "Encrypted Secrets"
+func (s *SettingsStore) GetSecret(ctx context.Context, name string) (string, error) {
+	sealed, err := s.Get(ctx, name)
+	if err != nil {
+		return "", err
+	}
+	if s.secrets == nil {
+		return "", secrets.ErrNoKey
+	}
+	return s.secrets.Open(sealed)
+}
+
+// SetSecret encrypts value and stores it under name.
+// Governing: SPEC-0001 REQ "Encrypted Secrets"
+func (s *SettingsStore) SetSecret(ctx context.Context, name, value string) error {
+	if s.secrets == nil {
+		return secrets.ErrNoKey
+	}
+	sealed, err := s.secrets.Seal(value)
+	if err != nil {
+		return err
+	}
+	return s.Set(ctx, name, sealed)
+}
@@ -0,0 +1,46 @@
+// Governing: SPEC-0001 REQ "Encrypted Secrets"
+package store_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/joestump/joe-links/internal/secrets"
+	"github.com/joestump/joe-links/internal/store"
+	"github.com/joestump/joe-links/internal/testutil"
+)
+
+func TestSettingsStore_Secrets(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	ctx := context.Background()
+
+	plain := store.NewSettingsStore(db)
+	if err := plain.SetSecret(ctx, store.SettingSMTPPassword, "hunter2"); !errors.Is(err, secrets.ErrNoKey) {
+		t.Fatalf("SetSecret without a key: error = %v, want ErrNoKey", err)
+	}
+
+	box, _ := secrets.New("test-key")
+	ss := store.NewSettingsStore(db).WithSecrets(box)
+	if _, err := ss.GetSecret(ctx, store.SettingSMTPPassword); !errors.Is(err, store.ErrNotFound) {
+		t.Fatalf("GetSecret unset: error = %v, want ErrNotFound", err)
+	}
+	if err := ss.SetSecret(ctx, store.SettingSMTPPassword, "hunter2"); err != nil {
+		t.Fatalf("SetSecret: %v", err)
+	}
+	if got, err := ss.GetSecret(ctx, store.SettingSMTPPassword); err != nil || got != "hunter2" {
+		t.Errorf("GetSecret = %q, %v; want %q", got, err, "hunter2")
+	}
+	if stored, _ := ss.Get(ctx, store.SettingSMTPPassword); stored == "hunter2" {
+		t.Error("secret stored in plaintext")
+	}
+
+	otherBox, _ := secrets.New("other-key")
+	other := store.NewSettingsStore(db).WithSecrets(otherBox)
+	if _, err := other.GetSecret(ctx, store.SettingSMTPPassword); !errors.Is(err, secrets.ErrInvalid) {
+		t.Errorf("GetSecret with another key: error = %v, want ErrInvalid", err)
+	}
+	if _, err := plain.GetSecret(ctx, store.SettingSMTPPassword); !errors.Is(err, secrets.ErrNoKey) {
+		t.Errorf("GetSecret without a key: error = %v, want ErrNoKey", err)
+	}
+}
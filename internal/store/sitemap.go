@@ -0,0 +1,29 @@
+// Governing: SPEC-0012 REQ "Sitemap and Robots"
+package store
+
+import "context"
+
+// PublicProfile is a user with at least one listed public link.
+type PublicProfile struct {
+	Slug  string `db:"display_name_slug"`
+	Links int    `db:"links"`
+}
+
+// ListPublicProfiles returns every user whose profile page lists public links,
+// with the number of links it lists, ordered by slug. Only primary owners
+// count, matching ListPublicByOwner.
+func (s *LinkStore) ListPublicProfiles(ctx context.Context) ([]PublicProfile, error) {
+	var profiles []PublicProfile
+	scope, args := tenantScope(ctx, "l.tenant_id")
+	err := s.db.SelectContext(ctx, &profiles, s.q(`
+		SELECT u.display_name_slug, COUNT(DISTINCT l.id) AS links
+		FROM links l
+		JOIN link_owners lo ON lo.link_id = l.id AND lo.is_primary = 1
+		JOIN users u ON u.id = lo.user_id
+		WHERE l.visibility = 'public' AND l.pending_review = 0
+		  AND u.display_name_slug <> ''`+scope+`
+		GROUP BY u.display_name_slug
+		ORDER BY u.display_name_slug
+	`), args...)
+	return profiles, err
+}
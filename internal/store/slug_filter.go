@@ -0,0 +1,101 @@
+// Governing: SPEC-0009 REQ "Slug Filter Fast Path"
+package store
+
+import (
+	"context"
+	"sync"
+
+	"github.com/joestump/joe-links/internal/bloom"
+)
+
+// slugFilterHeadroom is how many slugs beyond twice the current count a
+// rebuilt filter is sized for, so links created before the next rebuild
+// barely raise its false positive rate.
+const slugFilterHeadroom = 1024
+
+// slugFilter is an in-process Bloom filter of every link slug and alias. A
+// negative answer means no link can match, so the lookup skips the
+// database. Deletes are not removed from it; they only cost a query until
+// the next rebuild.
+type slugFilter struct {
+	mu     sync.RWMutex
+	filter *bloom.Filter
+	// pending collects slugs added while a rebuild reads the database, so
+	// the rebuilt filter does not lose them; nil when no rebuild runs.
+	pending []string
+}
+
+// mayExist reports whether any of slugs may belong to a link.
+func (f *slugFilter) mayExist(slugs []string) bool {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	for _, slug := range slugs {
+		if f.filter.Test(slug) {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *slugFilter) add(slug string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.filter.Add(slug)
+	if f.pending != nil {
+		f.pending = append(f.pending, slug)
+	}
+}
+
+// rebuild replaces the filter with one built from the slugs list returns.
+func (f *slugFilter) rebuild(list func() ([]string, error)) error {
+	f.mu.Lock()
+	f.pending = []string{}
+	f.mu.Unlock()
+
+	slugs, err := list()
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	pending := f.pending
+	f.pending = nil
+	if err != nil {
+		return err
+	}
+	rebuilt := bloom.New(2*len(slugs) + slugFilterHeadroom)
+	for _, slug := range append(slugs, pending...) {
+		rebuilt.Add(slug)
+	}
+	f.filter = rebuilt
+	return nil
+}
+
+// EnableSlugFilter builds the in-process slug filter that lets lookups of
+// slugs no link or alias has return ErrNotFound without a query. Call
+// RefreshSlugFilter periodically to drop deleted slugs and to pick up links
+// created by other instances sharing the database.
+func (s *LinkStore) EnableSlugFilter(ctx context.Context) error {
+	f := &slugFilter{}
+	if err := f.rebuild(func() ([]string, error) { return s.ListKnownSlugs(ctx, "", true) }); err != nil {
+		return err
+	}
+	s.slugs = f
+	return nil
+}
+
+// RefreshSlugFilter rebuilds the slug filter from the database. It is a no-op
+// unless EnableSlugFilter was called.
+func (s *LinkStore) RefreshSlugFilter(ctx context.Context) error {
+	if s.slugs == nil {
+		return nil
+	}
+	return s.slugs.rebuild(func() ([]string, error) { return s.ListKnownSlugs(ctx, "", true) })
+}
+
+// noteSlug adds slug to the slug filter, if enabled. Writers call it before
+// committing, so a lookup can never miss a link that is visible in the
+// database; a rolled-back write only leaves a harmless false positive.
+func (s *LinkStore) noteSlug(slug string) {
+	if s.slugs != nil {
+		s.slugs.add(slug)
+	}
+}
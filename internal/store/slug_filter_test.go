@@ -0,0 +1,72 @@
+// Governing: SPEC-0009 REQ "Slug Filter Fast Path"
+package store_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/joestump/joe-links/internal/store"
+	"github.com/joestump/joe-links/internal/testutil"
+)
+
+func TestSlugFilter(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	ls := store.NewLinkStore(db, store.NewOwnershipStore(db), store.NewTagStore(db))
+	ctx := context.Background()
+
+	owner, err := store.NewUserStore(db).Upsert(ctx, "test", "sub1", "test@example.com", "Test User", "")
+	if err != nil {
+		t.Fatalf("seed user: %v", err)
+	}
+	if _, err := ls.Create(ctx, "wiki", "https://wiki.example.com", owner.ID, "", "", "public"); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if err := ls.EnableSlugFilter(ctx); err != nil {
+		t.Fatalf("EnableSlugFilter: %v", err)
+	}
+
+	found := func(path string) bool {
+		t.Helper()
+		_, _, err := ls.GetByPathPrefix(ctx, path)
+		if err != nil && !errors.Is(err, store.ErrNotFound) {
+			t.Fatalf("GetByPathPrefix(%q): %v", path, err)
+		}
+		return err == nil
+	}
+
+	if !found("wiki/page") {
+		t.Error("wiki/page: expected the link loaded when the filter was built")
+	}
+	if found("nope") {
+		t.Error("nope: expected ErrNotFound")
+	}
+
+	// Writes through the store update the filter at once.
+	docs, err := ls.Create(ctx, "docs", "https://docs.example.com", owner.ID, "", "", "public")
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if _, err := ls.AddAlias(ctx, docs.ID, "manual"); err != nil {
+		t.Fatalf("AddAlias: %v", err)
+	}
+	if !found("docs") || !found("manual") {
+		t.Error("expected a link and alias created after the filter was built")
+	}
+
+	// Rows written by another instance appear after a refresh.
+	if _, err := db.Exec(`INSERT INTO links (id, slug, url, title, description, visibility, created_by, created_at, updated_at)
+		VALUES ('other', 'elsewhere', 'https://example.com', '', '', 'public', ?, ?, ?)`, owner.ID, time.Now(), time.Now()); err != nil {
+		t.Fatalf("insert: %v", err)
+	}
+	if found("elsewhere") {
+		t.Error("elsewhere: expected the filter to hide it until a refresh")
+	}
+	if err := ls.RefreshSlugFilter(ctx); err != nil {
+		t.Fatalf("RefreshSlugFilter: %v", err)
+	}
+	if !found("elsewhere") {
+		t.Error("elsewhere: expected the link after a refresh")
+	}
+}
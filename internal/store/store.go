@@ -48,5 +48,6 @@ type KeywordStoreIface interface {
 	GetByKeyword(ctx context.Context, keyword string) (*Keyword, error)
 	Create(ctx context.Context, keyword, urlTemplate, description string) (*Keyword, error)
 	Update(ctx context.Context, id, keyword, urlTemplate, description string) (*Keyword, error)
+	SetSecure(ctx context.Context, id string, secure bool) error
 	Delete(ctx context.Context, id string) error
 }
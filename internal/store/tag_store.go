@@ -4,16 +4,28 @@ package store
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"regexp"
 	"strings"
 	"time"
 
-	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
+	"github.com/joestump/joe-links/internal/ids"
 )
 
 var tagSlugStripRe = regexp.MustCompile(`[^a-z0-9-]`)
 
+var (
+	// ErrInvalidTagName is returned when a tag name has no letters or digits
+	// to derive a slug from.
+	// Governing: SPEC-0004 REQ "Tag Administration"
+	ErrInvalidTagName = errors.New("tag name must contain a letter or digit")
+
+	// ErrMergeIntoSelf is returned when a tag is merged into itself.
+	// Governing: SPEC-0004 REQ "Tag Administration"
+	ErrMergeIntoSelf = errors.New("cannot merge a tag into itself")
+)
+
 // Tag represents a row in the tags table.
 type Tag struct {
 	ID        string    `db:"id"`
@@ -64,7 +76,7 @@ func (s *TagStore) Upsert(ctx context.Context, name string) (*Tag, error) {
 		return nil, err
 	}
 
-	id := uuid.New().String()
+	id := ids.New()
 	now := time.Now().UTC()
 	_, err = s.db.ExecContext(ctx, s.q(`
 		INSERT INTO tags (id, name, slug, created_at) VALUES (?, ?, ?, ?)
@@ -97,7 +109,7 @@ func (s *TagStore) upsertTx(ctx context.Context, tx *sqlx.Tx, name string) (*Tag
 		return nil, err
 	}
 
-	id := uuid.New().String()
+	id := ids.New()
 	now := time.Now().UTC()
 	_, err = tx.ExecContext(ctx, tx.Rebind(`
 		INSERT INTO tags (id, name, slug, created_at) VALUES (?, ?, ?, ?)
@@ -177,3 +189,181 @@ func (s *TagStore) ListAll(ctx context.Context) ([]*Tag, error) {
 	}
 	return tags, nil
 }
+
+// ListAllWithCounts returns every tag, including unused ones, annotated with
+// its link count and ordered by name.
+// Governing: SPEC-0004 REQ "Tag Administration"
+func (s *TagStore) ListAllWithCounts(ctx context.Context) ([]*TagWithCount, error) {
+	var tags []*TagWithCount
+	err := s.db.SelectContext(ctx, &tags, `
+		SELECT t.*, COUNT(lt.link_id) as link_count
+		FROM tags t
+		LEFT JOIN link_tags lt ON lt.tag_id = t.id
+		GROUP BY t.id
+		ORDER BY t.name ASC
+	`)
+	if err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+// GetWithCount returns the tag with slug and its link count, or ErrNotFound.
+// Governing: SPEC-0004 REQ "Tag Administration"
+func (s *TagStore) GetWithCount(ctx context.Context, slug string) (*TagWithCount, error) {
+	var t TagWithCount
+	err := s.db.GetContext(ctx, &t, s.q(`
+		SELECT t.*, COUNT(lt.link_id) as link_count
+		FROM tags t
+		LEFT JOIN link_tags lt ON lt.tag_id = t.id
+		WHERE t.slug = ?
+		GROUP BY t.id
+	`), slug)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// Rename changes the name of the tag with slug, and its slug to match the new
+// name. Returns ErrNotFound if no such tag exists, ErrInvalidTagName if name
+// yields an empty slug, and ErrSlugTaken if another tag already has the new
+// slug (merge into it instead).
+// Governing: SPEC-0004 REQ "Tag Administration"
+func (s *TagStore) Rename(ctx context.Context, slug, name string) (*Tag, error) {
+	name = strings.TrimSpace(name)
+	newSlug := DeriveTagSlug(name)
+	if newSlug == "" {
+		return nil, ErrInvalidTagName
+	}
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var t Tag
+	err = tx.GetContext(ctx, &t, tx.Rebind(`SELECT * FROM tags WHERE slug = ?`), slug)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	_, err = tx.ExecContext(ctx, tx.Rebind(`UPDATE tags SET name = ?, slug = ? WHERE id = ?`), name, newSlug, t.ID)
+	if err != nil {
+		if isUniqueConstraintError(err) {
+			return nil, ErrSlugTaken
+		}
+		return nil, err
+	}
+	// Governing: SPEC-0002 REQ "Full-Text Link Search" — tag names are indexed
+	linkIDs, err := taggedLinkIDs(ctx, tx, t.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := reindexLinks(ctx, tx, linkIDs...); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	t.Name, t.Slug = name, newSlug
+	return &t, nil
+}
+
+// Merge moves every link tagged fromSlug onto intoSlug and deletes fromSlug,
+// in one transaction. Links that already carry both keep a single intoSlug
+// tag. Returns the surviving tag, ErrNotFound if either tag is missing, or
+// ErrMergeIntoSelf if the slugs are equal.
+// Governing: SPEC-0004 REQ "Tag Administration"
+func (s *TagStore) Merge(ctx context.Context, fromSlug, intoSlug string) (*Tag, error) {
+	if fromSlug == intoSlug {
+		return nil, ErrMergeIntoSelf
+	}
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var from, into Tag
+	for _, lookup := range []struct {
+		dst  *Tag
+		slug string
+	}{{&from, fromSlug}, {&into, intoSlug}} {
+		err := tx.GetContext(ctx, lookup.dst, tx.Rebind(`SELECT * FROM tags WHERE slug = ?`), lookup.slug)
+		if err == sql.ErrNoRows {
+			return nil, ErrNotFound
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, tx.Rebind(`
+		INSERT INTO link_tags (link_id, tag_id)
+		SELECT link_id, ? FROM link_tags
+		WHERE tag_id = ? AND link_id NOT IN (SELECT link_id FROM link_tags WHERE tag_id = ?)
+	`), into.ID, from.ID, into.ID); err != nil {
+		return nil, err
+	}
+	if err := deleteTagTx(ctx, tx, from.ID); err != nil {
+		return nil, err
+	}
+	linkIDs, err := taggedLinkIDs(ctx, tx, into.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := reindexLinks(ctx, tx, linkIDs...); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return &into, nil
+}
+
+// Delete removes the tag with slug from every link and deletes it, in one
+// transaction. Returns ErrNotFound if no such tag exists.
+// Governing: SPEC-0004 REQ "Tag Administration"
+func (s *TagStore) Delete(ctx context.Context, slug string) error {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var id string
+	err = tx.GetContext(ctx, &id, tx.Rebind(`SELECT id FROM tags WHERE slug = ?`), slug)
+	if err == sql.ErrNoRows {
+		return ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+	linkIDs, err := taggedLinkIDs(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+	if err := deleteTagTx(ctx, tx, id); err != nil {
+		return err
+	}
+	if err := reindexLinks(ctx, tx, linkIDs...); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// deleteTagTx deletes a tag and its link_tags rows explicitly, so the result
+// does not depend on the driver enforcing ON DELETE CASCADE.
+func deleteTagTx(ctx context.Context, tx *sqlx.Tx, id string) error {
+	if _, err := tx.ExecContext(ctx, tx.Rebind(`DELETE FROM link_tags WHERE tag_id = ?`), id); err != nil {
+		return err
+	}
+	_, err := tx.ExecContext(ctx, tx.Rebind(`DELETE FROM tags WHERE id = ?`), id)
+	return err
+}
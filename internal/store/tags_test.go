@@ -152,3 +152,89 @@ func TestTagStore_ListWithCounts(t *testing.T) {
 		t.Errorf("count = %d, want 1", tags[0].Count)
 	}
 }
+
+// Governing: SPEC-0004 REQ "Tag Administration"
+func TestTagStore_RenameMergeDelete(t *testing.T) {
+	ts, ls, us := newTagTestEnv(t)
+	ctx := context.Background()
+
+	u, err := us.Upsert(ctx, "test", "sub1", "test@example.com", "Test", "")
+	if err != nil {
+		t.Fatalf("seed user: %v", err)
+	}
+	a, err := ls.Create(ctx, "a", "https://a.example.com", u.ID, "", "", "")
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	b, err := ls.Create(ctx, "b", "https://b.example.com", u.ID, "", "", "")
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if err := ls.SetTags(ctx, a.ID, []string{"kubernetes", "kuberentes"}); err != nil {
+		t.Fatalf("SetTags: %v", err)
+	}
+	if err := ls.SetTags(ctx, b.ID, []string{"kuberentes", "infra"}); err != nil {
+		t.Fatalf("SetTags: %v", err)
+	}
+	tagSlugs := func(linkID string) []string {
+		tags, err := ls.ListTags(ctx, linkID)
+		if err != nil {
+			t.Fatalf("ListTags: %v", err)
+		}
+		var slugs []string
+		for _, tag := range tags {
+			slugs = append(slugs, tag.Slug)
+		}
+		return slugs
+	}
+
+	// Rename onto an existing slug is refused; renaming elsewhere moves the slug.
+	if _, err := ts.Rename(ctx, "infra", "Kubernetes"); !errors.Is(err, store.ErrSlugTaken) {
+		t.Errorf("Rename onto existing: err = %v, want ErrSlugTaken", err)
+	}
+	if _, err := ts.Rename(ctx, "infra", "!!"); !errors.Is(err, store.ErrInvalidTagName) {
+		t.Errorf("Rename to symbols: err = %v, want ErrInvalidTagName", err)
+	}
+	renamed, err := ts.Rename(ctx, "infra", "Infrastructure")
+	if err != nil || renamed.Slug != "infrastructure" {
+		t.Fatalf("Rename = %+v, %v", renamed, err)
+	}
+
+	// Merge keeps one kubernetes tag on a, which had both.
+	if _, err := ts.Merge(ctx, "kuberentes", "kuberentes"); !errors.Is(err, store.ErrMergeIntoSelf) {
+		t.Errorf("Merge into self: err = %v", err)
+	}
+	if _, err := ts.Merge(ctx, "kuberentes", "missing"); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("Merge into missing: err = %v", err)
+	}
+	if _, err := ts.Merge(ctx, "kuberentes", "kubernetes"); err != nil {
+		t.Fatalf("Merge: %v", err)
+	}
+	if got := tagSlugs(a.ID); len(got) != 1 || got[0] != "kubernetes" {
+		t.Errorf("a tags = %v, want [kubernetes]", got)
+	}
+	if got := tagSlugs(b.ID); len(got) != 2 {
+		t.Errorf("b tags = %v, want infrastructure and kubernetes", got)
+	}
+	if _, err := ts.GetBySlug(ctx, "kuberentes"); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("merged tag still exists: err = %v", err)
+	}
+
+	// Delete removes the tag from every link; unused tags still list.
+	if err := ts.Delete(ctx, "kubernetes"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if got := tagSlugs(b.ID); len(got) != 1 || got[0] != "infrastructure" {
+		t.Errorf("b tags after delete = %v", got)
+	}
+	if err := ts.Delete(ctx, "kubernetes"); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("second Delete: err = %v, want ErrNotFound", err)
+	}
+	if _, err := ts.Upsert(ctx, "unused"); err != nil {
+		t.Fatalf("Upsert: %v", err)
+	}
+	all, err := ts.ListAllWithCounts(ctx)
+	if err != nil || len(all) != 2 || all[0].Slug != "infrastructure" || all[0].Count != 1 || all[1].Count != 0 {
+		t.Errorf("ListAllWithCounts = %v, %v", all, err)
+	}
+}
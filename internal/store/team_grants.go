@@ -0,0 +1,97 @@
+// Governing: SPEC-0010 REQ "Team Shares and Ownership"
+package store
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrAlreadyShared is returned by AddTeamShare when the link is already
+// shared with the team.
+var ErrAlreadyShared = errors.New("link is already shared with this team")
+
+// teamOwnedScope and teamSharedScope match links (aliased l) owned by or
+// shared with a team the user bound to their one ? belongs to.
+const (
+	teamOwnedScope = `EXISTS (SELECT 1 FROM link_team_owners lto
+		INNER JOIN team_members tmo ON tmo.team_id = lto.team_id
+		WHERE lto.link_id = l.id AND tmo.user_id = ?)`
+	teamSharedScope = `EXISTS (SELECT 1 FROM link_team_shares lts
+		INNER JOIN team_members tms ON tms.team_id = lts.team_id
+		WHERE lts.link_id = l.id AND tms.user_id = ?)`
+)
+
+// AddTeamOwner makes every member of teamID a co-owner of linkID.
+// Returns ErrAlreadyOwner if the team already owns the link.
+func (s *OwnershipStore) AddTeamOwner(linkID, teamID string) error {
+	_, err := s.db.Exec(s.q(`
+		INSERT INTO link_team_owners (link_id, team_id, created_at) VALUES (?, ?, ?)
+	`), linkID, teamID, time.Now().UTC())
+	if isUniqueConstraintError(err) {
+		return ErrAlreadyOwner
+	}
+	return err
+}
+
+// RemoveTeamOwner revokes teamID's co-ownership of linkID. Returns
+// ErrNotOwner if the team did not own the link.
+func (s *OwnershipStore) RemoveTeamOwner(linkID, teamID string) error {
+	res, err := s.db.Exec(s.q(`DELETE FROM link_team_owners WHERE link_id = ? AND team_id = ?`), linkID, teamID)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrNotOwner
+	}
+	return nil
+}
+
+// ListTeamOwners returns the teams that co-own linkID, ordered by name.
+func (s *OwnershipStore) ListTeamOwners(linkID string) ([]*Team, error) {
+	var teams []*Team
+	err := s.db.Select(&teams, s.q(`
+		SELECT t.* FROM teams t
+		INNER JOIN link_team_owners lto ON lto.team_id = t.id
+		WHERE lto.link_id = ?
+		ORDER BY t.name ASC
+	`), linkID)
+	return teams, err
+}
+
+// AddTeamShare grants every member of teamID access to the secure link
+// linkID. Returns ErrAlreadyShared if the link is already shared with the team.
+func (s *LinkStore) AddTeamShare(ctx context.Context, linkID, teamID, sharedBy string) error {
+	_, err := s.db.ExecContext(ctx, s.q(`
+		INSERT INTO link_team_shares (link_id, team_id, shared_by, created_at) VALUES (?, ?, ?, ?)
+	`), linkID, teamID, sharedBy, time.Now().UTC())
+	if isUniqueConstraintError(err) {
+		return ErrAlreadyShared
+	}
+	return err
+}
+
+// RemoveTeamShare revokes a team share. Returns ErrNotFound if the link was
+// not shared with the team.
+func (s *LinkStore) RemoveTeamShare(ctx context.Context, linkID, teamID string) error {
+	res, err := s.db.ExecContext(ctx, s.q(`DELETE FROM link_team_shares WHERE link_id = ? AND team_id = ?`), linkID, teamID)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// ListTeamShares returns the teams linkID is shared with, ordered by name.
+func (s *LinkStore) ListTeamShares(ctx context.Context, linkID string) ([]*Team, error) {
+	var teams []*Team
+	err := s.db.SelectContext(ctx, &teams, s.q(`
+		SELECT t.* FROM teams t
+		INNER JOIN link_team_shares lts ON lts.team_id = t.id
+		WHERE lts.link_id = ?
+		ORDER BY t.name ASC
+	`), linkID)
+	return teams, err
+}
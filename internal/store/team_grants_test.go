@@ -0,0 +1,95 @@
+// Governing: SPEC-0010 REQ "Team Shares and Ownership"
+package store_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/joestump/joe-links/internal/store"
+	"github.com/joestump/joe-links/internal/testutil"
+)
+
+func TestTeamGrants(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	owns := store.NewOwnershipStore(db)
+	ls := store.NewLinkStore(db, owns, store.NewTagStore(db))
+	ts := store.NewTeamStore(db)
+	us := store.NewUserStore(db)
+	ctx := context.Background()
+
+	owner, err := us.Upsert(ctx, "test", "sub1", "owner@example.com", "Owner", "")
+	if err != nil {
+		t.Fatalf("seed user: %v", err)
+	}
+	member, err := us.Upsert(ctx, "test", "sub2", "member@example.com", "Member", "")
+	if err != nil {
+		t.Fatalf("seed user: %v", err)
+	}
+	owned, err := ls.Create(ctx, "oncall", "https://oncall.example.com", owner.ID, "", "", "public")
+	if err != nil {
+		t.Fatalf("Create link: %v", err)
+	}
+	secret, err := ls.Create(ctx, "payroll", "https://hr.example.com/payroll", owner.ID, "", "", "secure")
+	if err != nil {
+		t.Fatalf("Create link: %v", err)
+	}
+	team, err := ts.Create(ctx, "sre", "SRE", "")
+	if err != nil {
+		t.Fatalf("Create team: %v", err)
+	}
+
+	if err := owns.AddTeamOwner(owned.ID, team.ID); err != nil {
+		t.Fatalf("AddTeamOwner: %v", err)
+	}
+	if err := owns.AddTeamOwner(owned.ID, team.ID); !errors.Is(err, store.ErrAlreadyOwner) {
+		t.Errorf("duplicate AddTeamOwner: err = %v, want ErrAlreadyOwner", err)
+	}
+	if err := ls.AddTeamShare(ctx, secret.ID, team.ID, owner.ID); err != nil {
+		t.Fatalf("AddTeamShare: %v", err)
+	}
+	if err := ls.AddTeamShare(ctx, secret.ID, team.ID, owner.ID); !errors.Is(err, store.ErrAlreadyShared) {
+		t.Errorf("duplicate AddTeamShare: err = %v, want ErrAlreadyShared", err)
+	}
+
+	check := func(when string, wantOwner, wantShare bool, wantShared int) {
+		t.Helper()
+		if ok, err := owns.IsOwner(owned.ID, member.ID); err != nil || ok != wantOwner {
+			t.Errorf("%s: IsOwner = %v, %v; want %v", when, ok, err, wantOwner)
+		}
+		if ok, err := ls.HasShare(ctx, secret.ID, member.ID); err != nil || ok != wantShare {
+			t.Errorf("%s: HasShare = %v, %v; want %v", when, ok, err, wantShare)
+		}
+		if ok, _ := ls.HasDirectShare(ctx, secret.ID, member.ID); ok {
+			t.Errorf("%s: HasDirectShare = true for a team share", when)
+		}
+		if links, err := ls.ListSharedWithUser(ctx, member.ID); err != nil || len(links) != wantShared {
+			t.Errorf("%s: ListSharedWithUser = %d links, %v; want %d", when, len(links), err, wantShared)
+		}
+	}
+	check("before joining", false, false, 0)
+
+	if err := ts.AddMember(ctx, team.ID, member.ID); err != nil {
+		t.Fatalf("AddMember: %v", err)
+	}
+	if err := ts.AddMember(ctx, team.ID, member.ID); !errors.Is(err, store.ErrAlreadyMember) {
+		t.Errorf("duplicate AddMember: err = %v, want ErrAlreadyMember", err)
+	}
+	check("as a member", true, true, 2)
+	if links, err := ls.SearchByOwnerOrShared(ctx, member.ID, "payroll"); err != nil || len(links) != 1 {
+		t.Errorf("SearchByOwnerOrShared = %d links, %v; want 1", len(links), err)
+	}
+
+	if err := ts.RemoveMember(ctx, team.ID, member.ID); err != nil {
+		t.Fatalf("RemoveMember: %v", err)
+	}
+	check("after leaving", false, false, 0)
+
+	if err := ts.AddMember(ctx, team.ID, member.ID); err != nil {
+		t.Fatalf("AddMember: %v", err)
+	}
+	if err := ts.Delete(ctx, team.Slug); err != nil {
+		t.Fatalf("Delete team: %v", err)
+	}
+	check("after the team is deleted", false, false, 0)
+}
@@ -0,0 +1,181 @@
+// Governing: SPEC-0002 REQ "Team Ownership"
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/joestump/joe-links/internal/ids"
+)
+
+// ErrTeamNameRequired is returned when a team is created without a name.
+var ErrTeamNameRequired = errors.New("team name is required")
+
+// Team is a group that can own links. Contact tells readers how to reach the
+// team about a link (an email address, chat channel, or URL).
+type Team struct {
+	ID        string    `db:"id"`
+	Slug      string    `db:"slug"`
+	Name      string    `db:"name"`
+	Contact   string    `db:"contact"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+// TeamStore is the sqlx-backed store for admin-managed teams.
+type TeamStore struct {
+	db *sqlx.DB
+}
+
+// NewTeamStore creates a new TeamStore.
+func NewTeamStore(db *sqlx.DB) *TeamStore {
+	return &TeamStore{db: db}
+}
+
+// q rebinds ? placeholders to the driver's native format ($1,$2,... for PostgreSQL).
+func (s *TeamStore) q(query string) string { return s.db.Rebind(query) }
+
+// List returns all teams ordered by name.
+func (s *TeamStore) List(ctx context.Context) ([]*Team, error) {
+	var teams []*Team
+	if err := s.db.SelectContext(ctx, &teams, `SELECT * FROM teams ORDER BY name ASC`); err != nil {
+		return nil, err
+	}
+	return teams, nil
+}
+
+// GetBySlug returns the team with the given slug, or ErrNotFound.
+func (s *TeamStore) GetBySlug(ctx context.Context, slug string) (*Team, error) {
+	var t Team
+	err := s.db.GetContext(ctx, &t, s.q(`SELECT * FROM teams WHERE slug = ?`), slug)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// Create adds a team. Returns ErrSlugInvalid for a malformed slug,
+// ErrTeamNameRequired for an empty name, and ErrSlugTaken if another team
+// already uses slug. Team slugs live apart from link slugs and may repeat them.
+func (s *TeamStore) Create(ctx context.Context, slug, name, contact string) (*Team, error) {
+	if !slugRe.MatchString(slug) {
+		return nil, ErrSlugInvalid
+	}
+	if name == "" {
+		return nil, ErrTeamNameRequired
+	}
+	t := &Team{ID: ids.New(), Slug: slug, Name: name, Contact: contact, CreatedAt: time.Now().UTC()}
+	_, err := s.db.ExecContext(ctx, s.q(`
+		INSERT INTO teams (id, slug, name, contact, created_at) VALUES (?, ?, ?, ?, ?)
+	`), t.ID, t.Slug, t.Name, t.Contact, t.CreatedAt)
+	if err != nil {
+		if isUniqueConstraintError(err) {
+			return nil, ErrSlugTaken
+		}
+		return nil, err
+	}
+	return t, nil
+}
+
+// Delete removes the team with the given slug and clears it from the links it
+// owned, which fall back to showing their primary owner. Returns ErrNotFound
+// if no such team exists.
+func (s *TeamStore) Delete(ctx context.Context, slug string) error {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var id string
+	err = tx.GetContext(ctx, &id, tx.Rebind(`SELECT id FROM teams WHERE slug = ?`), slug)
+	if err == sql.ErrNoRows {
+		return ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, tx.Rebind(`UPDATE links SET team_id = '' WHERE team_id = ?`), id); err != nil {
+		return err
+	}
+	// Governing: SPEC-0010 REQ "Team Shares and Ownership" — members lose every grant made to the team
+	for _, stmt := range []string{
+		`DELETE FROM link_team_shares WHERE team_id = ?`,
+		`DELETE FROM link_team_owners WHERE team_id = ?`,
+		`DELETE FROM team_members WHERE team_id = ?`,
+	} {
+		if _, err := tx.ExecContext(ctx, tx.Rebind(stmt), id); err != nil {
+			return err
+		}
+	}
+	if _, err := tx.ExecContext(ctx, tx.Rebind(`DELETE FROM teams WHERE id = ?`), id); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// ErrAlreadyMember is returned by AddMember when the user is already in the team.
+var ErrAlreadyMember = errors.New("user is already a member of this team")
+
+// TeamMember is a user in a team.
+type TeamMember struct {
+	User
+	TeamID string `db:"team_id"`
+}
+
+// AddMember adds userID to teamID. Returns ErrAlreadyMember if present.
+// Governing: SPEC-0010 REQ "Team Shares and Ownership"
+func (s *TeamStore) AddMember(ctx context.Context, teamID, userID string) error {
+	_, err := s.db.ExecContext(ctx, s.q(`
+		INSERT INTO team_members (team_id, user_id, created_at) VALUES (?, ?, ?)
+	`), teamID, userID, time.Now().UTC())
+	if isUniqueConstraintError(err) {
+		return ErrAlreadyMember
+	}
+	return err
+}
+
+// RemoveMember removes userID from teamID. Returns ErrNotFound if the user
+// was not a member.
+// Governing: SPEC-0010 REQ "Team Shares and Ownership"
+func (s *TeamStore) RemoveMember(ctx context.Context, teamID, userID string) error {
+	res, err := s.db.ExecContext(ctx, s.q(`DELETE FROM team_members WHERE team_id = ? AND user_id = ?`), teamID, userID)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// ListMembers returns the members of every team, ordered by team and then
+// display name.
+// Governing: SPEC-0010 REQ "Team Shares and Ownership"
+func (s *TeamStore) ListMembers(ctx context.Context) ([]*TeamMember, error) {
+	var members []*TeamMember
+	err := s.db.SelectContext(ctx, &members, `
+		SELECT u.*, tm.team_id FROM team_members tm
+		INNER JOIN users u ON u.id = tm.user_id
+		ORDER BY tm.team_id, u.display_name ASC
+	`)
+	return members, err
+}
+
+// ListTeamMembers returns the members of teamID ordered by display name.
+// Governing: SPEC-0010 REQ "Team Shares and Ownership"
+func (s *TeamStore) ListTeamMembers(ctx context.Context, teamID string) ([]*User, error) {
+	var users []*User
+	err := s.db.SelectContext(ctx, &users, s.q(`
+		SELECT u.* FROM team_members tm
+		INNER JOIN users u ON u.id = tm.user_id
+		WHERE tm.team_id = ?
+		ORDER BY u.display_name ASC
+	`), teamID)
+	return users, err
+}
@@ -0,0 +1,68 @@
+// Governing: SPEC-0002 REQ "Team Ownership"
+package store_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/joestump/joe-links/internal/store"
+	"github.com/joestump/joe-links/internal/testutil"
+)
+
+func TestTeams(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	owns := store.NewOwnershipStore(db)
+	ls := store.NewLinkStore(db, owns, store.NewTagStore(db))
+	ts := store.NewTeamStore(db)
+	ctx := context.Background()
+
+	u, err := store.NewUserStore(db).Upsert(ctx, "test", "sub1", "test@example.com", "Test User", "")
+	if err != nil {
+		t.Fatalf("seed user: %v", err)
+	}
+	link, err := ls.Create(ctx, "runbooks", "https://runbooks.example.com", u.ID, "", "", "public")
+	if err != nil {
+		t.Fatalf("Create link: %v", err)
+	}
+
+	team, err := ts.Create(ctx, "sre", "SRE", "#sre-oncall")
+	if err != nil {
+		t.Fatalf("Create team: %v", err)
+	}
+	if _, err := ts.Create(ctx, "sre", "Other", ""); !errors.Is(err, store.ErrSlugTaken) {
+		t.Errorf("duplicate: err = %v, want ErrSlugTaken", err)
+	}
+	if _, err := ts.Create(ctx, "Bad Slug", "Bad", ""); !errors.Is(err, store.ErrSlugInvalid) {
+		t.Errorf("invalid slug: err = %v, want ErrSlugInvalid", err)
+	}
+	if _, err := ts.Create(ctx, "noname", "", ""); !errors.Is(err, store.ErrTeamNameRequired) {
+		t.Errorf("empty name: err = %v, want ErrTeamNameRequired", err)
+	}
+
+	if err := ls.SetTeam(ctx, link.ID, team.ID); err != nil {
+		t.Fatalf("SetTeam: %v", err)
+	}
+	public, _, err := ls.ListPublic(ctx, "", "", 1, 10)
+	if err != nil {
+		t.Fatalf("ListPublic: %v", err)
+	}
+	if len(public) != 1 || public[0].TeamName != "SRE" || public[0].TeamContact != "#sre-oncall" {
+		t.Errorf("ListPublic team = %+v", public)
+	}
+
+	// Deleting the team clears it from the link.
+	if err := ts.Delete(ctx, "sre"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if err := ts.Delete(ctx, "sre"); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("second Delete: err = %v, want ErrNotFound", err)
+	}
+	got, err := ls.GetByID(ctx, link.ID)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if got.TeamID != "" {
+		t.Errorf("TeamID after team delete = %q, want empty", got.TeamID)
+	}
+}
@@ -0,0 +1,132 @@
+// Governing: SPEC-0011 REQ "Instance Telemetry"
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// TelemetryDays is how many days of redirects and API requests InstanceStats
+// covers.
+const TelemetryDays = 30
+
+// InstanceStats is an instance-wide aggregate of counts. It holds no slugs,
+// URLs, names, or emails, so it can be shared as-is.
+type InstanceStats struct {
+	Links          int64        `json:"links"`
+	PublicLinks    int64        `json:"public_links"`
+	PrivateLinks   int64        `json:"private_links"`
+	SecureLinks    int64        `json:"secure_links"`
+	Users          int64        `json:"users"`
+	Admins         int64        `json:"admins"`
+	Redirects      int64        `json:"redirects_30d"`
+	APIRequests    int64        `json:"api_requests_30d"`
+	RedirectsByDay []DayCount   `json:"redirects_by_day"`
+	Features       FeatureUsage `json:"features"`
+}
+
+// DayCount is a count for one UTC calendar day (UsageDayFormat).
+type DayCount struct {
+	Day   string `json:"day"`
+	Count int64  `json:"count"`
+}
+
+// FeatureUsage counts how much each optional feature is used.
+type FeatureUsage struct {
+	Aliases         int64 `json:"aliases"`
+	TemplatedLinks  int64 `json:"templated_links"`
+	CustomRedirects int64 `json:"custom_redirect_links"`
+	UTMLinks        int64 `json:"utm_links"`
+	PassQueryLinks  int64 `json:"pass_query_links"`
+	TeamLinks       int64 `json:"team_links"`
+	SharedLinks     int64 `json:"shared_links"`
+	Tags            int64 `json:"tags"`
+	Teams           int64 `json:"teams"`
+	Keywords        int64 `json:"keywords"`
+	ActiveAPITokens int64 `json:"active_api_tokens"`
+	SavedSearches   int64 `json:"saved_searches"`
+	LinkPolicies    int64 `json:"link_policies"`
+	ReservedSlugs   int64 `json:"reserved_slugs"`
+}
+
+// TelemetryStore aggregates InstanceStats for the admin telemetry page.
+type TelemetryStore struct {
+	db *sqlx.DB
+}
+
+// NewTelemetryStore creates a new TelemetryStore.
+func NewTelemetryStore(db *sqlx.DB) *TelemetryStore {
+	return &TelemetryStore{db: db}
+}
+
+// q rebinds ? placeholders to the driver's native format.
+func (s *TelemetryStore) q(query string) string { return s.db.Rebind(query) }
+
+// Collect aggregates the instance's stats as of now. RedirectsByDay has one
+// entry per day of the TelemetryDays ending today, oldest first, including
+// days without redirects.
+func (s *TelemetryStore) Collect(ctx context.Context, now time.Time) (*InstanceStats, error) {
+	now = now.UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	since := today.AddDate(0, 0, -(TelemetryDays - 1))
+
+	st := &InstanceStats{}
+	f := &st.Features
+	counts := []struct {
+		dest  *int64
+		query string
+		args  []any
+	}{
+		{&st.Links, `SELECT COUNT(*) FROM links`, nil},
+		{&st.PublicLinks, `SELECT COUNT(*) FROM links WHERE visibility = 'public'`, nil},
+		{&st.PrivateLinks, `SELECT COUNT(*) FROM links WHERE visibility = 'private'`, nil},
+		{&st.SecureLinks, `SELECT COUNT(*) FROM links WHERE visibility = 'secure'`, nil},
+		{&st.Users, `SELECT COUNT(*) FROM users`, nil},
+		{&st.Admins, `SELECT COUNT(*) FROM users WHERE role = 'admin'`, nil},
+		{&st.APIRequests, `SELECT COALESCE(SUM(request_count), 0) FROM api_usage_daily WHERE day >= ?`, []any{since.Format(UsageDayFormat)}},
+		{&f.Aliases, `SELECT COUNT(*) FROM link_aliases`, nil},
+		{&f.TemplatedLinks, `SELECT COUNT(*) FROM links WHERE url LIKE '%$%'`, nil},
+		{&f.CustomRedirects, `SELECT COUNT(*) FROM links WHERE redirect_type <> 302`, nil},
+		{&f.UTMLinks, `SELECT COUNT(*) FROM links WHERE utm_params <> ''`, nil},
+		{&f.PassQueryLinks, `SELECT COUNT(*) FROM links WHERE pass_query = 1`, nil},
+		{&f.TeamLinks, `SELECT COUNT(*) FROM links WHERE team_id <> ''`, nil},
+		{&f.SharedLinks, `SELECT COUNT(DISTINCT link_id) FROM link_shares`, nil},
+		{&f.Tags, `SELECT COUNT(*) FROM tags`, nil},
+		{&f.Teams, `SELECT COUNT(*) FROM teams`, nil},
+		{&f.Keywords, `SELECT COUNT(*) FROM keywords`, nil},
+		{&f.ActiveAPITokens, `SELECT COUNT(*) FROM api_tokens WHERE revoked_at IS NULL AND (expires_at IS NULL OR expires_at > ?)`, []any{now}},
+		{&f.SavedSearches, `SELECT COUNT(*) FROM saved_searches`, nil},
+		{&f.LinkPolicies, `SELECT COUNT(*) FROM link_policies`, nil},
+		{&f.ReservedSlugs, `SELECT COUNT(*) FROM reserved_slugs`, nil},
+	}
+	for _, c := range counts {
+		if err := s.db.GetContext(ctx, c.dest, s.q(c.query), c.args...); err != nil {
+			return nil, err
+		}
+	}
+
+	byDay := make(map[string]int64, TelemetryDays)
+	rows, err := s.db.QueryxContext(ctx, s.q(`SELECT clicked_at FROM link_clicks WHERE clicked_at >= ?`), since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var at time.Time
+		if err := rows.Scan(&at); err != nil {
+			return nil, err
+		}
+		byDay[at.UTC().Format(UsageDayFormat)]++
+		st.Redirects++
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	for d := since; !d.After(today); d = d.AddDate(0, 0, 1) {
+		day := d.Format(UsageDayFormat)
+		st.RedirectsByDay = append(st.RedirectsByDay, DayCount{Day: day, Count: byDay[day]})
+	}
+	return st, nil
+}
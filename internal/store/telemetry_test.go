@@ -0,0 +1,63 @@
+// Governing: SPEC-0011 REQ "Instance Telemetry"
+package store_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/joestump/joe-links/internal/store"
+	"github.com/joestump/joe-links/internal/testutil"
+)
+
+func TestTelemetryCollect(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	owns := store.NewOwnershipStore(db)
+	ls := store.NewLinkStore(db, owns, store.NewTagStore(db))
+	us := store.NewUserStore(db)
+	cs := store.NewClickStore(db)
+	ctx := context.Background()
+
+	u, err := us.Upsert(ctx, "test", "sub1", "test@example.com", "Test User", "")
+	if err != nil {
+		t.Fatalf("seed user: %v", err)
+	}
+	wiki, err := ls.Create(ctx, "wiki", "https://wiki.example.com", u.ID, "", "", "public")
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if _, err := ls.Create(ctx, "jira", "https://jira.example.com/browse/$ticket", u.ID, "", "", "secure"); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if _, err := ls.AddAlias(ctx, wiki.ID, "docs"); err != nil {
+		t.Fatalf("AddAlias: %v", err)
+	}
+
+	now := time.Date(2026, 3, 10, 15, 0, 0, 0, time.UTC)
+	for _, at := range []time.Time{now.Add(-time.Hour), now.Add(-2 * time.Hour), now.AddDate(0, 0, -1), now.AddDate(0, 0, -45)} {
+		if err := cs.RecordClick(ctx, store.ClickEvent{LinkID: wiki.ID, IPHash: "h", ClickedAt: at}); err != nil {
+			t.Fatalf("RecordClick: %v", err)
+		}
+	}
+
+	st, err := store.NewTelemetryStore(db).Collect(ctx, now)
+	if err != nil {
+		t.Fatalf("Collect: %v", err)
+	}
+	if st.Links != 2 || st.PublicLinks != 1 || st.SecureLinks != 1 || st.Users != 1 {
+		t.Errorf("links/public/secure/users = %d/%d/%d/%d, want 2/1/1/1", st.Links, st.PublicLinks, st.SecureLinks, st.Users)
+	}
+	if st.Features.Aliases != 1 || st.Features.TemplatedLinks != 1 {
+		t.Errorf("aliases/templated = %d/%d, want 1/1", st.Features.Aliases, st.Features.TemplatedLinks)
+	}
+	if st.Redirects != 3 {
+		t.Errorf("Redirects = %d, want 3 (the 45-day-old click is outside the window)", st.Redirects)
+	}
+	if len(st.RedirectsByDay) != store.TelemetryDays {
+		t.Fatalf("len(RedirectsByDay) = %d, want %d", len(st.RedirectsByDay), store.TelemetryDays)
+	}
+	last, prev := st.RedirectsByDay[store.TelemetryDays-1], st.RedirectsByDay[store.TelemetryDays-2]
+	if last.Day != "2026-03-10" || last.Count != 2 || prev.Count != 1 {
+		t.Errorf("last two days = %+v, %+v; want 2026-03-10 with 2 and the day before with 1", prev, last)
+	}
+}
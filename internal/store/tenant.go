@@ -0,0 +1,200 @@
+// Governing: SPEC-0001 REQ "Multi-Tenancy"
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/joestump/joe-links/internal/ids"
+)
+
+var (
+	// ErrTenantHostnameRequired is returned when a tenant is created without a hostname.
+	ErrTenantHostnameRequired = errors.New("tenant hostname is required")
+
+	// ErrTenantHostnameTaken is returned when another tenant already serves the hostname.
+	ErrTenantHostnameTaken = errors.New("tenant hostname is already in use")
+
+	// ErrTenantInUse is returned when deleting a tenant that still has links or users.
+	ErrTenantInUse = errors.New("tenant still has links or users")
+
+	// ErrWrongTenant is returned when a user signs in on the host of a tenant
+	// other than the one their account belongs to.
+	ErrWrongTenant = errors.New("account belongs to another tenant")
+)
+
+// tenantCacheTTL bounds how long a hostname lookup is served from memory, and
+// so how long a newly added tenant takes to be recognised.
+const tenantCacheTTL = 30 * time.Second
+
+// Tenant is an independent go-links namespace served on its own hostname.
+// Requests for hostnames without a tenant are served by the default tenant,
+// whose ID is the empty string and which has no row.
+type Tenant struct {
+	ID        string    `db:"id"`
+	Hostname  string    `db:"hostname"`
+	Name      string    `db:"name"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+type tenantKey struct{}
+
+// WithTenant returns a copy of ctx scoped to the tenant with the given ID.
+// Store methods called with the returned context only see and create rows
+// belonging to that tenant; without it they act on every tenant, as
+// background jobs and single-tenant deployments do.
+func WithTenant(ctx context.Context, tenantID string) context.Context {
+	return context.WithValue(ctx, tenantKey{}, tenantID)
+}
+
+// TenantFromContext returns the tenant ctx is scoped to, if any.
+func TenantFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(tenantKey{}).(string)
+	return id, ok
+}
+
+// inTenant reports whether a row belonging to tenantID is visible to ctx.
+func inTenant(ctx context.Context, tenantID string) bool {
+	id, ok := TenantFromContext(ctx)
+	return !ok || id == tenantID
+}
+
+// tenantScope returns an " AND col = ?" predicate restricting col to the
+// tenant ctx is scoped to, with its argument, or nothing when it is unscoped.
+func tenantScope(ctx context.Context, col string) (string, []interface{}) {
+	id, ok := TenantFromContext(ctx)
+	if !ok {
+		return "", nil
+	}
+	return ` AND ` + col + ` = ?`, []interface{}{id}
+}
+
+// TenantStore is the sqlx-backed store for tenants.
+type TenantStore struct {
+	db *sqlx.DB
+
+	mu       sync.Mutex
+	hosts    map[string]string // hostname -> tenant ID
+	loadedAt time.Time
+}
+
+// NewTenantStore creates a new TenantStore.
+func NewTenantStore(db *sqlx.DB) *TenantStore {
+	return &TenantStore{db: db}
+}
+
+// q rebinds ? placeholders to the driver's native format ($1,$2,... for PostgreSQL).
+func (s *TenantStore) q(query string) string { return s.db.Rebind(query) }
+
+// NormalizeHostname lowercases host and strips any port and trailing dot.
+func NormalizeHostname(host string) string {
+	host = strings.ToLower(strings.TrimSpace(host))
+	if i := strings.LastIndex(host, ":"); i >= 0 && !strings.Contains(host[i:], "]") {
+		host = host[:i]
+	}
+	host = strings.Trim(host, "[]")
+	return strings.TrimSuffix(host, ".")
+}
+
+// List returns all tenants ordered by hostname.
+func (s *TenantStore) List(ctx context.Context) ([]*Tenant, error) {
+	var tenants []*Tenant
+	if err := s.db.SelectContext(ctx, &tenants, `SELECT * FROM tenants ORDER BY hostname ASC`); err != nil {
+		return nil, err
+	}
+	return tenants, nil
+}
+
+// GetByID returns the tenant with the given ID, or ErrNotFound.
+func (s *TenantStore) GetByID(ctx context.Context, id string) (*Tenant, error) {
+	var t Tenant
+	err := s.db.GetContext(ctx, &t, s.q(`SELECT * FROM tenants WHERE id = ?`), id)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// Create adds a tenant served on hostname. Returns ErrTenantHostnameRequired
+// for an empty hostname and ErrTenantHostnameTaken if another tenant already
+// serves it. name defaults to the hostname.
+func (s *TenantStore) Create(ctx context.Context, hostname, name string) (*Tenant, error) {
+	hostname = NormalizeHostname(hostname)
+	if hostname == "" {
+		return nil, ErrTenantHostnameRequired
+	}
+	if name == "" {
+		name = hostname
+	}
+	t := &Tenant{ID: ids.New(), Hostname: hostname, Name: name, CreatedAt: time.Now().UTC()}
+	_, err := s.db.ExecContext(ctx, s.q(`
+		INSERT INTO tenants (id, hostname, name, created_at) VALUES (?, ?, ?, ?)
+	`), t.ID, t.Hostname, t.Name, t.CreatedAt)
+	if err != nil {
+		if isUniqueConstraintError(err) {
+			return nil, ErrTenantHostnameTaken
+		}
+		return nil, err
+	}
+	s.invalidate()
+	return t, nil
+}
+
+// Delete removes a tenant. Returns ErrNotFound if it does not exist and
+// ErrTenantInUse while any link or user still belongs to it.
+func (s *TenantStore) Delete(ctx context.Context, id string) error {
+	var used int
+	err := s.db.GetContext(ctx, &used, s.q(`
+		SELECT (SELECT COUNT(*) FROM links WHERE tenant_id = ?) + (SELECT COUNT(*) FROM users WHERE tenant_id = ?)
+	`), id, id)
+	if err != nil {
+		return err
+	}
+	if used > 0 {
+		return ErrTenantInUse
+	}
+	res, err := s.db.ExecContext(ctx, s.q(`DELETE FROM tenants WHERE id = ?`), id)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrNotFound
+	}
+	s.invalidate()
+	return nil
+}
+
+// Resolve returns the ID of the tenant serving host, or "" for the default
+// tenant. Hostnames are read from memory and reloaded every tenantCacheTTL,
+// so resolution costs no query on the request path.
+func (s *TenantStore) Resolve(ctx context.Context, host string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.hosts == nil || time.Since(s.loadedAt) > tenantCacheTTL {
+		var rows []Tenant
+		if err := s.db.SelectContext(ctx, &rows, `SELECT * FROM tenants`); err != nil {
+			return "", err
+		}
+		s.hosts = make(map[string]string, len(rows))
+		for _, t := range rows {
+			s.hosts[t.Hostname] = t.ID
+		}
+		s.loadedAt = time.Now()
+	}
+	return s.hosts[NormalizeHostname(host)], nil
+}
+
+// invalidate drops the cached hostnames so the next Resolve reloads them.
+func (s *TenantStore) invalidate() {
+	s.mu.Lock()
+	s.hosts = nil
+	s.mu.Unlock()
+}
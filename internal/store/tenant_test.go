@@ -0,0 +1,137 @@
+// Governing: SPEC-0001 REQ "Multi-Tenancy"
+package store_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/joestump/joe-links/internal/store"
+	"github.com/joestump/joe-links/internal/testutil"
+)
+
+func TestNormalizeHostname(t *testing.T) {
+	for in, want := range map[string]string{
+		"go.example.com":      "go.example.com",
+		"GO.Example.com:8080": "go.example.com",
+		"go.example.com.":     "go.example.com",
+		"[::1]:8080":          "::1",
+		" go.acme.example ":   "go.acme.example",
+	} {
+		if got := store.NormalizeHostname(in); got != want {
+			t.Errorf("NormalizeHostname(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestTenants(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	owns := store.NewOwnershipStore(db)
+	ls := store.NewLinkStore(db, owns, store.NewTagStore(db))
+	us := store.NewUserStore(db)
+	ks := store.NewKeywordStore(db)
+	ts := store.NewTenantStore(db)
+	ctx := context.Background()
+
+	acme, err := ts.Create(ctx, "GO.Acme.Example:443", "")
+	if err != nil {
+		t.Fatalf("Create tenant: %v", err)
+	}
+	if acme.Hostname != "go.acme.example" || acme.Name != "go.acme.example" {
+		t.Errorf("tenant = %+v, want normalized hostname as name", acme)
+	}
+	if _, err := ts.Create(ctx, "go.acme.example", "Again"); !errors.Is(err, store.ErrTenantHostnameTaken) {
+		t.Errorf("duplicate hostname: err = %v, want ErrTenantHostnameTaken", err)
+	}
+	if _, err := ts.Create(ctx, " ", ""); !errors.Is(err, store.ErrTenantHostnameRequired) {
+		t.Errorf("empty hostname: err = %v, want ErrTenantHostnameRequired", err)
+	}
+	if id, err := ts.Resolve(ctx, "go.acme.example:8080"); err != nil || id != acme.ID {
+		t.Errorf("Resolve(acme) = %q, %v; want %q", id, err, acme.ID)
+	}
+	if id, err := ts.Resolve(ctx, "go.example.com"); err != nil || id != "" {
+		t.Errorf("Resolve(unknown) = %q, %v; want default tenant", id, err)
+	}
+
+	def := store.WithTenant(ctx, "")
+	other := store.WithTenant(ctx, acme.ID)
+
+	// Users belong to the tenant they first signed in on.
+	alice, err := us.Upsert(def, "test", "alice", "alice@example.com", "Alice", "")
+	if err != nil {
+		t.Fatalf("Upsert alice: %v", err)
+	}
+	bob, err := us.Upsert(other, "test", "bob", "bob@acme.example", "Bob", "admin")
+	if err != nil {
+		t.Fatalf("Upsert bob: %v", err)
+	}
+	if bob.TenantID != acme.ID {
+		t.Errorf("bob.TenantID = %q, want %q", bob.TenantID, acme.ID)
+	}
+	if _, err := us.Upsert(other, "test", "alice", "alice@example.com", "Alice", ""); !errors.Is(err, store.ErrWrongTenant) {
+		t.Errorf("sign-in on another tenant: err = %v, want ErrWrongTenant", err)
+	}
+	if _, err := us.GetByID(other, alice.ID); err == nil {
+		t.Error("GetByID found a user of another tenant")
+	}
+	if _, err := us.GetByEmail(other, "alice@example.com"); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("GetByEmail across tenants: err = %v, want ErrNotFound", err)
+	}
+	if users, _ := us.ListAll(other); len(users) != 1 || users[0].ID != bob.ID {
+		t.Errorf("ListAll(acme) = %d users, want only bob", len(users))
+	}
+
+	// The same slug and keyword can exist once per tenant.
+	mine, err := ls.Create(def, "wiki", "https://wiki.example.com", alice.ID, "", "", "public")
+	if err != nil {
+		t.Fatalf("Create default link: %v", err)
+	}
+	theirs, err := ls.Create(other, "wiki", "https://wiki.acme.example", bob.ID, "", "", "public")
+	if err != nil {
+		t.Fatalf("Create acme link: %v", err)
+	}
+	if _, err := ls.Create(other, "wiki", "https://dup.acme.example", bob.ID, "", "", "public"); !errors.Is(err, store.ErrSlugTaken) {
+		t.Errorf("duplicate slug in tenant: err = %v, want ErrSlugTaken", err)
+	}
+	for _, tc := range []struct {
+		ctx  context.Context
+		want string
+	}{{def, mine.ID}, {other, theirs.ID}} {
+		if l, err := ls.GetBySlug(tc.ctx, "wiki"); err != nil || l.ID != tc.want {
+			t.Errorf("GetBySlug = %v, %v; want %s", l, err, tc.want)
+		}
+		if l, _, err := ls.GetByPathPrefix(tc.ctx, "wiki/page"); err != nil || l.ID != tc.want {
+			t.Errorf("GetByPathPrefix = %v, %v; want %s", l, err, tc.want)
+		}
+		if links, _, err := ls.ListPublic(tc.ctx, "", "", 1, 10); err != nil || len(links) != 1 || links[0].ID != tc.want {
+			t.Errorf("ListPublic = %d links, %v; want only %s", len(links), err, tc.want)
+		}
+	}
+	if _, err := ls.GetByID(other, mine.ID); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("GetByID across tenants: err = %v, want ErrNotFound", err)
+	}
+	if links, _ := ls.SearchAll(other, "wiki"); len(links) != 1 || links[0].ID != theirs.ID {
+		t.Errorf("SearchAll(acme) = %d links, want only the acme link", len(links))
+	}
+	// Background work runs unscoped and sees every tenant.
+	if links, _ := ls.ListAll(ctx); len(links) != 2 {
+		t.Errorf("unscoped ListAll = %d links, want 2", len(links))
+	}
+
+	if _, err := ks.Create(def, "jira", "https://jira.example.com/browse/{slug}", ""); err != nil {
+		t.Fatalf("Create default keyword: %v", err)
+	}
+	if _, err := ks.Create(other, "jira", "https://acme.atlassian.net/browse/{slug}", ""); err != nil {
+		t.Fatalf("Create acme keyword: %v", err)
+	}
+	if k, err := ks.GetByKeyword(other, "jira"); err != nil || k.TenantID != acme.ID {
+		t.Errorf("GetByKeyword(acme) = %+v, %v; want the acme keyword", k, err)
+	}
+
+	if err := ts.Delete(ctx, acme.ID); !errors.Is(err, store.ErrTenantInUse) {
+		t.Errorf("Delete in-use tenant: err = %v, want ErrTenantInUse", err)
+	}
+	if err := ts.Delete(ctx, "missing"); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("Delete missing: err = %v, want ErrNotFound", err)
+	}
+}
@@ -0,0 +1,128 @@
+// Governing: SPEC-0006 REQ "API Usage Tracking", ADR-0009
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/joestump/joe-links/internal/ids"
+)
+
+// UsageDayFormat is the layout of api_usage_daily.day (UTC calendar day).
+const UsageDayFormat = "2006-01-02"
+
+// UsageDelta is a request count to add to one (token, endpoint, day) bucket.
+type UsageDelta struct {
+	TokenID  string
+	UserID   string
+	Endpoint string // "METHOD /route/pattern"
+	Day      string // UsageDayFormat
+	Count    int64
+}
+
+// UsageRow is one daily rollup bucket joined with its token name.
+type UsageRow struct {
+	TokenID   string `db:"token_id"`
+	TokenName string `db:"token_name"`
+	Endpoint  string `db:"endpoint"`
+	Day       string `db:"day"`
+	Count     int64  `db:"request_count"`
+}
+
+// TokenUsageSummary totals a token's requests over a window, for the admin view.
+type TokenUsageSummary struct {
+	TokenID     string `db:"token_id"`
+	TokenName   string `db:"token_name"`
+	UserID      string `db:"user_id"`
+	UserEmail   string `db:"user_email"`
+	DisplayName string `db:"display_name"`
+	Endpoints   int    `db:"endpoints"`
+	Total       int64  `db:"total"`
+	LastDay     string `db:"last_day"`
+}
+
+// UsageStore persists per-token, per-endpoint daily API request counts.
+type UsageStore struct {
+	db *sqlx.DB
+}
+
+// NewUsageStore creates a new UsageStore.
+func NewUsageStore(db *sqlx.DB) *UsageStore {
+	return &UsageStore{db: db}
+}
+
+// q rebinds ? placeholders to the driver's native format.
+func (s *UsageStore) q(query string) string { return s.db.Rebind(query) }
+
+// Add applies request count deltas to their daily buckets. Each bucket is
+// incremented in place, or created on first use. Buckets are written one at a
+// time (not in a transaction) so a concurrent insert by another instance can
+// be retried as an update on PostgreSQL, which aborts failed transactions.
+func (s *UsageStore) Add(ctx context.Context, deltas []UsageDelta) error {
+	for _, d := range deltas {
+		if err := s.add(ctx, d); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *UsageStore) add(ctx context.Context, d UsageDelta) error {
+	update := s.q(`
+		UPDATE api_usage_daily SET request_count = request_count + ?
+		WHERE token_id = ? AND endpoint = ? AND day = ?
+	`)
+	res, err := s.db.ExecContext(ctx, update, d.Count, d.TokenID, d.Endpoint, d.Day)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n > 0 {
+		return nil
+	}
+	_, err = s.db.ExecContext(ctx, s.q(`
+		INSERT INTO api_usage_daily (id, token_id, user_id, endpoint, day, request_count)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`), ids.New(), d.TokenID, d.UserID, d.Endpoint, d.Day, d.Count)
+	if isUniqueConstraintError(err) {
+		// Another instance created the bucket concurrently; add to it instead.
+		_, err = s.db.ExecContext(ctx, update, d.Count, d.TokenID, d.Endpoint, d.Day)
+	}
+	return err
+}
+
+// ListByUser returns a user's daily usage buckets on or after since, newest day first.
+func (s *UsageStore) ListByUser(ctx context.Context, userID string, since time.Time) ([]*UsageRow, error) {
+	var rows []*UsageRow
+	err := s.db.SelectContext(ctx, &rows, s.q(`
+		SELECT u.token_id, COALESCE(t.name, '') AS token_name, u.endpoint, u.day, u.request_count
+		FROM api_usage_daily u
+		LEFT JOIN api_tokens t ON t.id = u.token_id
+		WHERE u.user_id = ? AND u.day >= ?
+		ORDER BY u.day DESC, u.request_count DESC, u.endpoint
+	`), userID, since.UTC().Format(UsageDayFormat))
+	return rows, err
+}
+
+// ListTopTokens returns per-token totals on or after since, busiest first.
+func (s *UsageStore) ListTopTokens(ctx context.Context, since time.Time, limit int) ([]*TokenUsageSummary, error) {
+	var rows []*TokenUsageSummary
+	err := s.db.SelectContext(ctx, &rows, s.q(`
+		SELECT u.token_id,
+		       COALESCE(t.name, '') AS token_name,
+		       u.user_id,
+		       COALESCE(us.email, '') AS user_email,
+		       COALESCE(us.display_name, '') AS display_name,
+		       COUNT(DISTINCT u.endpoint) AS endpoints,
+		       SUM(u.request_count) AS total,
+		       MAX(u.day) AS last_day
+		FROM api_usage_daily u
+		LEFT JOIN api_tokens t ON t.id = u.token_id
+		LEFT JOIN users us ON us.id = u.user_id
+		WHERE u.day >= ?
+		GROUP BY u.token_id, t.name, u.user_id, us.email, us.display_name
+		ORDER BY total DESC
+		LIMIT ?
+	`), since.UTC().Format(UsageDayFormat), limit)
+	return rows, err
+}
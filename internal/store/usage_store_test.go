@@ -0,0 +1,55 @@
+// Governing: SPEC-0006 REQ "API Usage Tracking"
+package store_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/joestump/joe-links/internal/auth"
+	"github.com/joestump/joe-links/internal/store"
+	"github.com/joestump/joe-links/internal/testutil"
+)
+
+func TestUsageStore_AddAccumulates(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	us := store.NewUserStore(db)
+	ts := auth.NewSQLTokenStore(db)
+	usage := store.NewUsageStore(db)
+	ctx := context.Background()
+
+	u, err := us.Upsert(ctx, "test", "usage-sub", "usage-store@example.com", "Usage", "")
+	if err != nil {
+		t.Fatalf("seed user: %v", err)
+	}
+	tok, err := ts.Create(ctx, u.ID, "ci", "hash-usage", nil, nil)
+	if err != nil {
+		t.Fatalf("seed token: %v", err)
+	}
+
+	today := time.Now().UTC()
+	delta := store.UsageDelta{TokenID: tok.ID, UserID: u.ID, Endpoint: "GET /api/v1/links", Day: today.Format(store.UsageDayFormat), Count: 2}
+	if err := usage.Add(ctx, []store.UsageDelta{delta}); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	delta.Count = 5
+	if err := usage.Add(ctx, []store.UsageDelta{delta}); err != nil {
+		t.Fatalf("Add again: %v", err)
+	}
+
+	rows, err := usage.ListByUser(ctx, u.ID, today)
+	if err != nil {
+		t.Fatalf("ListByUser: %v", err)
+	}
+	if len(rows) != 1 || rows[0].Count != 7 || rows[0].TokenName != "ci" {
+		t.Fatalf("rows = %+v, want one bucket with 7 requests for token ci", rows)
+	}
+
+	top, err := usage.ListTopTokens(ctx, today, 10)
+	if err != nil {
+		t.Fatalf("ListTopTokens: %v", err)
+	}
+	if len(top) != 1 || top[0].Total != 7 || top[0].UserEmail != "usage-store@example.com" {
+		t.Errorf("top = %+v, want one token with 7 requests", top)
+	}
+}
@@ -9,8 +9,9 @@ import (
 	"strings"
 	"time"
 
-	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
+	"github.com/joestump/joe-links/internal/ids"
+	"github.com/joestump/joe-links/internal/metrics"
 )
 
 type User struct {
@@ -23,6 +24,16 @@ type User struct {
 	Role            string    `db:"role"`
 	CreatedAt       time.Time `db:"created_at"`
 	UpdatedAt       time.Time `db:"updated_at"`
+
+	// TenantID is the tenant the account was created on; empty for the
+	// default tenant. A user only signs in on their own tenant's hostname.
+	// Governing: SPEC-0001 REQ "Multi-Tenancy"
+	TenantID string `db:"tenant_id"`
+
+	// EmailNotifications is false when the user opted out of co-owner and
+	// share notification emails.
+	// Governing: SPEC-0001 REQ "Email Notifications"
+	EmailNotifications bool `db:"email_notifications"`
 }
 
 func (u *User) IsAdmin() bool {
@@ -62,7 +73,7 @@ func (s *UserStore) q(query string) string { return s.db.Rebind(query) }
 func (s *UserStore) GetByDisplayNameSlug(ctx context.Context, slug string) (*User, error) {
 	var u User
 	err := s.db.GetContext(ctx, &u, s.q(`SELECT * FROM users WHERE display_name_slug = ?`), slug)
-	if err == sql.ErrNoRows {
+	if err == sql.ErrNoRows || (err == nil && !inTenant(ctx, u.TenantID)) {
 		return nil, ErrNotFound
 	}
 	if err != nil {
@@ -71,6 +82,45 @@ func (s *UserStore) GetByDisplayNameSlug(ctx context.Context, slug string) (*Use
 	return &u, nil
 }
 
+// GetByUsername returns the user whose email local part (the text before the
+// @) equals username, ignoring case, or ErrNotFound. When several users share
+// a local part across email domains, the earliest account wins.
+// Governing: SPEC-0012 REQ "Profile Username Alias"
+func (s *UserStore) GetByUsername(ctx context.Context, username string) (*User, error) {
+	if username == "" || strings.Contains(username, "@") {
+		return nil, ErrNotFound
+	}
+	var candidates []*User
+	err := s.db.SelectContext(ctx, &candidates,
+		s.q(`SELECT * FROM users WHERE LOWER(email) LIKE ? ORDER BY created_at ASC`),
+		strings.ToLower(username)+"@%")
+	if err != nil {
+		return nil, err
+	}
+	// LIKE treats _ and % in the username as wildcards, so confirm the match.
+	for _, u := range candidates {
+		if local, _, _ := strings.Cut(u.Email, "@"); strings.EqualFold(local, username) && inTenant(ctx, u.TenantID) {
+			return u, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+// GetByProfileRef resolves a profile URL segment: "@name" is looked up as a
+// username and then as a display_name_slug; anything else is a display_name_slug.
+// Governing: SPEC-0012 REQ "Profile Username Alias"
+func (s *UserStore) GetByProfileRef(ctx context.Context, ref string) (*User, error) {
+	name, isAlias := strings.CutPrefix(ref, "@")
+	if !isAlias {
+		return s.GetByDisplayNameSlug(ctx, ref)
+	}
+	u, err := s.GetByUsername(ctx, name)
+	if err != ErrNotFound {
+		return u, err
+	}
+	return s.GetByDisplayNameSlug(ctx, strings.ToLower(name))
+}
+
 // resolveUniqueSlug derives a slug from displayName and appends a numeric suffix if needed.
 // Governing: SPEC-0012 REQ "Display Name Slug Derivation and Lookup"
 func (s *UserStore) resolveUniqueSlug(ctx context.Context, displayName, excludeUserID string) (string, error) {
@@ -99,17 +149,28 @@ func (s *UserStore) resolveUniqueSlug(ctx context.Context, displayName, excludeU
 // role is applied on INSERT (new user). For existing users the role column is
 // intentionally not updated here — callers promote via UpdateRole after Upsert
 // so that manual role changes made through the admin UI are preserved across logins.
+// New users join the tenant ctx is scoped to; an existing user signing in
+// under another tenant gets ErrWrongTenant and is left unchanged.
+// A sign-in linked to an existing account returns that account unchanged,
+// recording only the sign-in's email.
 // Governing: SPEC-0012 REQ "Display Name Slug Derivation and Lookup", ADR-0002
+// Governing: SPEC-0001 REQ "Multi-Tenancy", REQ "Account Linking"
 func (s *UserStore) Upsert(ctx context.Context, provider, subject, email, displayName, role string) (*User, error) {
-	id := uuid.New().String()
+	id := ids.New()
 	now := time.Now().UTC()
 
 	// Look up existing user to get their ID for slug uniqueness check.
 	var existingID string
-	var existing User
-	err := s.db.GetContext(ctx, &existing, s.q(`SELECT * FROM users WHERE provider = ? AND subject = ?`), provider, subject)
+	existing, err := identityUser(ctx, s.db, provider, subject)
+	if err == sql.ErrNoRows {
+		existing = &User{}
+		err = s.db.GetContext(ctx, existing, s.q(`SELECT * FROM users WHERE provider = ? AND subject = ?`), provider, subject)
+	}
 	switch {
 	case err == nil:
+		if !inTenant(ctx, existing.TenantID) {
+			return nil, ErrWrongTenant
+		}
 		existingID = existing.ID
 	case err == sql.ErrNoRows:
 		// New user — existingID stays empty.
@@ -117,6 +178,22 @@ func (s *UserStore) Upsert(ctx context.Context, provider, subject, email, displa
 		return nil, fmt.Errorf("lookup existing user: %w", err)
 	}
 
+	// A linked sign-in only refreshes its own email. The account's profile
+	// and role follow its primary sign-in, so another identity provider's
+	// claims cannot rename or promote it.
+	// Governing: SPEC-0001 REQ "Account Linking"
+	if existingID != "" && (existing.Provider != provider || existing.Subject != subject) {
+		_, err = s.db.ExecContext(ctx, s.q(`
+			UPDATE user_identities SET email = ? WHERE provider = ? AND subject = ?
+		`), email, provider, subject)
+		if err != nil {
+			return nil, err
+		}
+		return s.GetByID(ctx, existingID)
+	}
+
+	tenantID, _ := TenantFromContext(ctx)
+
 	// Derive a unique display_name_slug for this user.
 	slug, err := s.resolveUniqueSlug(ctx, displayName, existingID)
 	if err != nil {
@@ -134,23 +211,23 @@ func (s *UserStore) Upsert(ctx context.Context, provider, subject, email, displa
 			`), email, displayName, slug, role, now, provider, subject)
 		} else {
 			_, err = s.db.ExecContext(ctx, s.q(`
-				INSERT INTO users (id, provider, subject, email, display_name, display_name_slug, role, created_at, updated_at)
-				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
-			`), id, provider, subject, email, displayName, slug, role, now, now)
+				INSERT INTO users (id, provider, subject, email, display_name, display_name_slug, role, tenant_id, created_at, updated_at)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			`), id, provider, subject, email, displayName, slug, role, tenantID, now, now)
 		}
 	} else {
 		// SQLite and PostgreSQL: atomic upsert.
 		// Role is included in the UPDATE so admin assignment via email/group is enforced on every login.
 		_, err = s.db.ExecContext(ctx, s.q(`
-			INSERT INTO users (id, provider, subject, email, display_name, display_name_slug, role, created_at, updated_at)
-			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+			INSERT INTO users (id, provider, subject, email, display_name, display_name_slug, role, tenant_id, created_at, updated_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 			ON CONFLICT (provider, subject) DO UPDATE SET
 				email = excluded.email,
 				display_name = excluded.display_name,
 				display_name_slug = excluded.display_name_slug,
 				role = excluded.role,
 				updated_at = excluded.updated_at
-		`), id, provider, subject, email, displayName, slug, role, now, now)
+		`), id, provider, subject, email, displayName, slug, role, tenantID, now, now)
 	}
 	if err != nil {
 		return nil, err
@@ -161,13 +238,18 @@ func (s *UserStore) Upsert(ctx context.Context, provider, subject, email, displa
 	if err != nil {
 		return nil, err
 	}
+	// Governing: SPEC-0001 REQ "Account Linking"
+	if err := s.LinkIdentity(ctx, u.ID, provider, subject, email); err != nil {
+		return nil, fmt.Errorf("record identity: %w", err)
+	}
 	return &u, nil
 }
 
 // GetByEmail returns the user matching email, or ErrNotFound.
 func (s *UserStore) GetByEmail(ctx context.Context, email string) (*User, error) {
 	var u User
-	err := s.db.GetContext(ctx, &u, s.q(`SELECT * FROM users WHERE email = ?`), email)
+	scope, args := tenantScope(ctx, "tenant_id")
+	err := s.db.GetContext(ctx, &u, s.q(`SELECT * FROM users WHERE email = ?`+scope), append([]interface{}{email}, args...)...)
 	if err == sql.ErrNoRows {
 		return nil, ErrNotFound
 	}
@@ -177,20 +259,41 @@ func (s *UserStore) GetByEmail(ctx context.Context, email string) (*User, error)
 	return &u, nil
 }
 
+// GetByID returns the user with the given ID. A user of another tenant than
+// the one ctx is scoped to is reported as sql.ErrNoRows, so sessions and API
+// tokens only authenticate on their own tenant's hostname.
+// Governing: SPEC-0001 REQ "Multi-Tenancy"
 func (s *UserStore) GetByID(ctx context.Context, id string) (*User, error) {
+	defer metrics.ObserveDBQuery("user_get_by_id", time.Now())
 	var u User
 	err := s.db.GetContext(ctx, &u, s.q(`SELECT * FROM users WHERE id = ?`), id)
+	if err == nil && !inTenant(ctx, u.TenantID) {
+		err = sql.ErrNoRows
+	}
 	if err != nil {
 		return nil, err
 	}
 	return &u, nil
 }
 
-// ListAll returns all users ordered by display name.
+// SetEmailNotifications opts the user in to or out of notification emails.
+// Governing: SPEC-0001 REQ "Email Notifications"
+func (s *UserStore) SetEmailNotifications(ctx context.Context, id string, enabled bool) error {
+	v := 0
+	if enabled {
+		v = 1
+	}
+	_, err := s.db.ExecContext(ctx, s.q(`UPDATE users SET email_notifications = ?, updated_at = ? WHERE id = ?`),
+		v, time.Now().UTC(), id)
+	return err
+}
+
+// ListAll returns the users of the tenant ctx is scoped to, ordered by display name.
 // Governing: SPEC-0004 REQ "Admin Dashboard"
 func (s *UserStore) ListAll(ctx context.Context) ([]*User, error) {
 	var users []*User
-	err := s.db.SelectContext(ctx, &users, `SELECT * FROM users ORDER BY display_name ASC`)
+	scope, args := tenantScope(ctx, "tenant_id")
+	err := s.db.SelectContext(ctx, &users, s.q(`SELECT * FROM users WHERE 1 = 1`+scope+` ORDER BY display_name ASC`), args...)
 	if err != nil {
 		return nil, err
 	}
@@ -229,6 +332,15 @@ func (s *UserStore) DeleteUserWithLinks(ctx context.Context, targetID, adminID,
 	}
 	defer func() { _ = tx.Rollback() }()
 
+	if err := deleteUserWithLinks(ctx, tx, targetID, adminID, linkAction); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// deleteUserWithLinks is DeleteUserWithLinks within the caller's transaction.
+func deleteUserWithLinks(ctx context.Context, tx *sqlx.Tx, targetID, adminID, linkAction string) error {
+	var err error
 	switch linkAction {
 	case "reassign":
 		// Transfer primary ownership to admin
@@ -239,6 +351,15 @@ func (s *UserStore) DeleteUserWithLinks(ctx context.Context, targetID, adminID,
 			return err
 		}
 	case "delete":
+		// Governing: SPEC-0002 REQ "Full-Text Link Search" — drop index rows first
+		_, err = tx.ExecContext(ctx, tx.Rebind(`
+			DELETE FROM link_search WHERE link_id IN (
+				SELECT link_id FROM link_owners
+				WHERE user_id = ? AND is_primary = 1
+			)`), targetID)
+		if err != nil {
+			return err
+		}
 		// Delete links where target is sole primary owner
 		_, err = tx.ExecContext(ctx, tx.Rebind(`
 			DELETE FROM links WHERE id IN (
@@ -258,13 +379,17 @@ func (s *UserStore) DeleteUserWithLinks(ctx context.Context, targetID, adminID,
 		return err
 	}
 
-	// Delete the user. CASCADE handles api_tokens and sessions.
-	_, err = tx.ExecContext(ctx, tx.Rebind(`DELETE FROM users WHERE id = ?`), targetID)
+	// Free the user's sign-ins explicitly: SQLite does not enforce the
+	// cascade, and a leftover row would block linking the sign-in again.
+	// Governing: SPEC-0001 REQ "Account Linking"
+	_, err = tx.ExecContext(ctx, tx.Rebind(`DELETE FROM user_identities WHERE user_id = ?`), targetID)
 	if err != nil {
 		return err
 	}
 
-	return tx.Commit()
+	// Delete the user. CASCADE handles api_tokens and sessions.
+	_, err = tx.ExecContext(ctx, tx.Rebind(`DELETE FROM users WHERE id = ?`), targetID)
+	return err
 }
 
 // CountAll returns the total number of users.
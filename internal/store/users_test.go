@@ -79,6 +79,36 @@ func TestGetByDisplayNameSlug(t *testing.T) {
 	}
 }
 
+func TestGetByProfileRef(t *testing.T) {
+	us := newUserStore(t)
+	ctx := context.Background()
+
+	alice, err := us.Upsert(ctx, "test", "sub1", "Alice@example.com", "Alice Smith", "")
+	if err != nil {
+		t.Fatalf("upsert: %v", err)
+	}
+	// a_ice would match alice if LIKE wildcards were not re-checked.
+	if _, err := us.Upsert(ctx, "test", "sub2", "a_ice@example.com", "Someone Else", ""); err != nil {
+		t.Fatalf("upsert: %v", err)
+	}
+
+	for _, ref := range []string{"alice-smith", "@alice", "@ALICE", "@alice-smith"} {
+		u, err := us.GetByProfileRef(ctx, ref)
+		if err != nil {
+			t.Errorf("GetByProfileRef(%q): %v", ref, err)
+			continue
+		}
+		if u.ID != alice.ID {
+			t.Errorf("GetByProfileRef(%q) = %s, want alice", ref, u.DisplayNameSlug)
+		}
+	}
+	for _, ref := range []string{"@al_ce", "@bob", "alice", "@"} {
+		if _, err := us.GetByProfileRef(ctx, ref); err != store.ErrNotFound {
+			t.Errorf("GetByProfileRef(%q) err = %v, want ErrNotFound", ref, err)
+		}
+	}
+}
+
 func TestResolveUniqueSlug_Duplicates(t *testing.T) {
 	us := newUserStore(t)
 	ctx := context.Background()
@@ -5,6 +5,9 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
+	"slices"
+	"sort"
+	"strings"
 )
 
 var (
@@ -21,25 +24,67 @@ var (
 	// Governing: SPEC-0009 REQ "Variable Placeholder Syntax", ADR-0013
 	ErrDuplicateVariable = errors.New("duplicate variable name in URL template")
 
+	// ErrRestVariableNotLast is returned when a $name* rest-capture placeholder is
+	// followed by another placeholder.
+	// Governing: SPEC-0009 REQ "Rest-Capture Placeholder", ADR-0013
+	ErrRestVariableNotLast = errors.New("rest-capture variable ($name*) must be the last variable in the URL template")
+
+	// ErrRequiredVariableAfterDefault is returned when a path placeholder
+	// without a default follows one written $name:default. Segments bind
+	// positionally, so only trailing variables can be left out.
+	// Governing: SPEC-0009 REQ "Variable Defaults", ADR-0013
+	ErrRequiredVariableAfterDefault = errors.New("variables with a default ($name:default) must come after every variable without one")
+
+	// ErrInvalidQueryVariable is returned when a $q: placeholder has no
+	// parameter name.
+	// Governing: SPEC-0009 REQ "Query-String Placeholder", ADR-0013
+	ErrInvalidQueryVariable = errors.New("query variable must be written $q:param with a parameter name of letters, digits, '_', '.', or '-'")
+
+	// ErrUnknownConstraintVariable is returned when a constraint names a
+	// variable that is not a path placeholder in the URL template.
+	// Governing: SPEC-0009 REQ "Variable Constraints", ADR-0013
+	ErrUnknownConstraintVariable = errors.New("constraint names a variable that is not in the URL template")
+
+	// ErrInvalidConstraint is returned when a constraint is empty, too long, or
+	// not a valid regular expression.
+	// Governing: SPEC-0009 REQ "Variable Constraints", ADR-0013
+	ErrInvalidConstraint = errors.New("invalid variable constraint")
+
 	// ErrInvalidVisibility is returned when a visibility value is not one of public, private, secure.
 	// Governing: SPEC-0010 REQ "Visibility Column on Links Table"
 	ErrInvalidVisibility = errors.New("visibility must be one of: public, private, secure")
 
+	// ErrInvalidRedirectType is returned when a redirect type is not one of 301, 302, 307, 308.
+	// Governing: SPEC-0002 REQ "Redirect Type"
+	ErrInvalidRedirectType = errors.New("redirect_type must be one of: 301, 302, 307, 308")
+
+	// ErrInvalidUTMParam is returned when a UTM parameter is not one of the
+	// five standard utm_* names or its value is empty or too long.
+	// Governing: SPEC-0002 REQ "UTM Parameters"
+	ErrInvalidUTMParam = errors.New("invalid UTM parameter")
+
 	slugRe = regexp.MustCompile(`^[a-z0-9]([a-z0-9\-]*[a-z0-9])?$`)
 
-	// VarPlaceholderRe matches $varname placeholders in URL templates.
-	// Governing: SPEC-0009 REQ "Variable Placeholder Syntax", ADR-0013
-	VarPlaceholderRe = regexp.MustCompile(`\$[a-z][a-z0-9_]*`)
+	// VarPlaceholderRe matches $varname placeholders in URL templates, including
+	// the $varname* rest-capture form, $varname:default optional placeholders,
+	// and $q:param query-string placeholders.
+	// Governing: SPEC-0009 REQ "Variable Placeholder Syntax", REQ "Rest-Capture Placeholder", REQ "Query-String Placeholder", REQ "Variable Defaults", ADR-0013
+	VarPlaceholderRe = regexp.MustCompile(`\$q:[A-Za-z0-9_.\-]+|\$[a-z][a-z0-9_]*(?:\*|:[A-Za-z0-9_.~\-]+)?`)
 
 	reservedSlugs = map[string]bool{
-		"auth":      true,
-		"static":    true,
-		"dashboard": true,
-		"admin":     true,
-		"api":       true, // Governing: SPEC-0005 REQ "API Router Mounting" — shadows /api/v1/* routes
-		"u":         true,
-		"links":     true, // Governing: SPEC-0012 REQ "Public Link Browser Route Priority"
-		"metrics":   true, // Governing: SPEC-0016 REQ "Prometheus Metrics Endpoint", ADR-0016
+		"auth":         true,
+		"static":       true,
+		"dashboard":    true,
+		"admin":        true,
+		"api":          true, // Governing: SPEC-0005 REQ "API Router Mounting" — shadows /api/v1/* routes
+		"u":            true,
+		"links":        true, // Governing: SPEC-0012 REQ "Public Link Browser Route Priority"
+		"metrics":      true, // Governing: SPEC-0016 REQ "Prometheus Metrics Endpoint", ADR-0016
+		"status":       true, // Governing: SPEC-0016 REQ "Status Page"
+		"s":            true, // Governing: SPEC-0010 REQ "Signed Share URLs"
+		"branding":     true, // Governing: SPEC-0003 REQ "Instance Branding" — serves /branding/logo
+		"announcement": true, // Governing: SPEC-0004 REQ "Announcement Banner" — POST /announcement/dismiss
+		"setup":        true, // Governing: SPEC-0001 REQ "First-Run Setup"
 	}
 )
 
@@ -56,24 +101,139 @@ func ValidateSlugFormat(slug string) error {
 	return nil
 }
 
-// ValidateURLVariables checks that any $varname placeholders in url are unique.
+// SplitPlaceholder breaks a path placeholder such as "$env:prod" or "$path*"
+// into its variable name (without $, * or default) and its default value.
+// hasDefault is false when the placeholder has no default.
+// Governing: SPEC-0009 REQ "Variable Defaults", ADR-0013
+func SplitPlaceholder(p string) (name, def string, hasDefault bool) {
+	name, def, hasDefault = strings.Cut(strings.TrimPrefix(p, "$"), ":")
+	return strings.TrimSuffix(name, "*"), def, hasDefault
+}
+
+// ValidateURLVariables checks that any $varname placeholders in url are unique
+// ($name, $name* and $name:default count as the same variable), that a $name*
+// rest-capture placeholder, if present, is the last path variable, that
+// variables with a default follow every variable without one, and that every
+// $q: placeholder names a query parameter. A $q:param may appear more than once.
 // Returns nil if the URL contains no variables or all variable names are distinct.
-// Governing: SPEC-0009 REQ "Variable Placeholder Syntax", ADR-0013
+// Governing: SPEC-0009 REQ "Variable Placeholder Syntax", REQ "Rest-Capture Placeholder", REQ "Query-String Placeholder", REQ "Variable Defaults", ADR-0013
 func ValidateURLVariables(url string) error {
-	vars := VarPlaceholderRe.FindAllString(url, -1)
-	if len(vars) <= 1 {
-		return nil
+	var vars []string
+	queryVars := 0
+	for _, v := range VarPlaceholderRe.FindAllString(url, -1) {
+		if strings.HasPrefix(v, "$q:") {
+			queryVars++
+			continue
+		}
+		vars = append(vars, v)
+	}
+	if strings.Count(url, "$q:") != queryVars {
+		return ErrInvalidQueryVariable
 	}
 	seen := make(map[string]bool, len(vars))
-	for _, v := range vars {
-		if seen[v] {
-			return fmt.Errorf("%w: %s", ErrDuplicateVariable, v)
+	optional := ""
+	for i, v := range vars {
+		name, _, hasDefault := SplitPlaceholder(v)
+		if seen[name] {
+			return fmt.Errorf("%w: $%s", ErrDuplicateVariable, name)
+		}
+		seen[name] = true
+		if strings.HasSuffix(v, "*") && i != len(vars)-1 {
+			return fmt.Errorf("%w: %s", ErrRestVariableNotLast, v)
+		}
+		if hasDefault {
+			optional = v
+		} else if optional != "" {
+			return fmt.Errorf("%w: %s follows %s", ErrRequiredVariableAfterDefault, v, optional)
+		}
+	}
+	return nil
+}
+
+// MaxConstraintLength bounds the length of a single variable constraint pattern.
+const MaxConstraintLength = 200
+
+// CompileConstraint compiles a variable constraint anchored so that it must
+// match the whole substituted value, not just part of it.
+// Governing: SPEC-0009 REQ "Variable Constraints", ADR-0013
+func CompileConstraint(pattern string) (*regexp.Regexp, error) {
+	return regexp.Compile(`^(?:` + pattern + `)$`)
+}
+
+// ValidateVariableConstraints checks that every constraint names a path
+// variable in url (without the leading $, trailing * or default) and is a
+// valid, non-empty regular expression of at most MaxConstraintLength bytes
+// that the variable's default, if any, satisfies.
+// Governing: SPEC-0009 REQ "Variable Constraints", REQ "Variable Defaults", ADR-0013
+func ValidateVariableConstraints(url string, constraints map[string]string) error {
+	if len(constraints) == 0 {
+		return nil
+	}
+	vars := make(map[string]bool)
+	defaults := make(map[string]string)
+	for _, v := range VarPlaceholderRe.FindAllString(url, -1) {
+		if !strings.HasPrefix(v, "$q:") {
+			name, def, hasDefault := SplitPlaceholder(v)
+			vars[name] = true
+			if hasDefault {
+				defaults[name] = def
+			}
+		}
+	}
+	for name, pattern := range constraints {
+		if !vars[name] {
+			return fmt.Errorf("%w: $%s", ErrUnknownConstraintVariable, name)
+		}
+		if pattern == "" || len(pattern) > MaxConstraintLength {
+			return fmt.Errorf("%w for $%s: must be 1 to %d characters", ErrInvalidConstraint, name, MaxConstraintLength)
+		}
+		re, err := CompileConstraint(pattern)
+		if err != nil {
+			return fmt.Errorf("%w for $%s: %v", ErrInvalidConstraint, name, err)
+		}
+		if def, ok := defaults[name]; ok && !re.MatchString(def) {
+			return fmt.Errorf("%w for $%s: default %q does not match", ErrInvalidConstraint, name, def)
 		}
-		seen[v] = true
 	}
 	return nil
 }
 
+// ParseVariableConstraints parses the form representation of constraints:
+// one "name=pattern" per line, where name may be written with or without the
+// leading $. Blank lines are ignored.
+// Governing: SPEC-0009 REQ "Variable Constraints", ADR-0013
+func ParseVariableConstraints(text string) (map[string]string, error) {
+	out := make(map[string]string)
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		name, pattern, ok := strings.Cut(line, "=")
+		name = strings.TrimPrefix(strings.TrimSpace(name), "$")
+		if !ok || name == "" {
+			return nil, fmt.Errorf("%w: %q must be written name=pattern", ErrInvalidConstraint, line)
+		}
+		out[name] = strings.TrimSpace(pattern)
+	}
+	return out, nil
+}
+
+// FormatVariableConstraints renders constraints in the form representation
+// accepted by ParseVariableConstraints, sorted by name.
+func FormatVariableConstraints(constraints map[string]string) string {
+	names := make([]string, 0, len(constraints))
+	for name := range constraints {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	lines := make([]string, len(names))
+	for i, name := range names {
+		lines[i] = name + "=" + constraints[name]
+	}
+	return strings.Join(lines, "\n")
+}
+
 // ValidateVisibility checks that v is one of the allowed visibility values.
 // Governing: SPEC-0010 REQ "Visibility Column on Links Table"
 func ValidateVisibility(v string) error {
@@ -84,3 +244,64 @@ func ValidateVisibility(v string) error {
 		return ErrInvalidVisibility
 	}
 }
+
+// DefaultRedirectType is the redirect status for links that do not choose one.
+const DefaultRedirectType = 302
+
+// ValidateRedirectType checks that code is a redirect status a link may use.
+// Governing: SPEC-0002 REQ "Redirect Type"
+func ValidateRedirectType(code int) error {
+	switch code {
+	case 301, 302, 307, 308:
+		return nil
+	default:
+		return ErrInvalidRedirectType
+	}
+}
+
+// MaxUTMValueLength is the longest value a UTM parameter may have.
+const MaxUTMValueLength = 200
+
+// UTMParamNames lists the campaign parameters a link or the system defaults may set.
+// Governing: SPEC-0002 REQ "UTM Parameters"
+var UTMParamNames = []string{"utm_source", "utm_medium", "utm_campaign", "utm_term", "utm_content"}
+
+// ValidateUTMParams checks that every key is a standard UTM parameter name and
+// every value is 1 to MaxUTMValueLength characters.
+// Governing: SPEC-0002 REQ "UTM Parameters"
+func ValidateUTMParams(params map[string]string) error {
+	for name, value := range params {
+		if !slices.Contains(UTMParamNames, name) {
+			return fmt.Errorf("%w: %q is not one of %s", ErrInvalidUTMParam, name, strings.Join(UTMParamNames, ", "))
+		}
+		if value == "" || len(value) > MaxUTMValueLength {
+			return fmt.Errorf("%w: %s must be 1 to %d characters", ErrInvalidUTMParam, name, MaxUTMValueLength)
+		}
+	}
+	return nil
+}
+
+// ParseUTMParams parses the form representation of UTM parameters: one
+// "name=value" per line. Blank lines are ignored.
+// Governing: SPEC-0002 REQ "UTM Parameters"
+func ParseUTMParams(text string) (map[string]string, error) {
+	out := make(map[string]string)
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("%w: %q must be written name=value", ErrInvalidUTMParam, line)
+		}
+		out[strings.TrimSpace(name)] = strings.TrimSpace(value)
+	}
+	return out, ValidateUTMParams(out)
+}
+
+// FormatUTMParams renders params in the form representation accepted by
+// ParseUTMParams, sorted by name.
+func FormatUTMParams(params map[string]string) string {
+	return FormatVariableConstraints(params)
+}
@@ -42,6 +42,7 @@ func TestValidateSlugFormat(t *testing.T) {
 		{name: "reserved dashboard", slug: "dashboard", wantErr: ErrSlugReserved},
 		{name: "reserved admin", slug: "admin", wantErr: ErrSlugReserved},
 		{name: "reserved links", slug: "links", wantErr: ErrSlugReserved}, // Governing: SPEC-0012 REQ "Public Link Browser Route Priority"
+		{name: "reserved setup", slug: "setup", wantErr: ErrSlugReserved}, // Governing: SPEC-0001 REQ "First-Run Setup"
 
 		// Not reserved (substrings of reserved words are fine)
 		{name: "auth-settings not reserved", slug: "auth-settings", wantErr: nil},
@@ -94,6 +95,30 @@ func TestValidateURLVariables(t *testing.T) {
 		{name: "duplicate variable", url: "https://example.com/$foo/$foo", wantErr: ErrDuplicateVariable},
 		{name: "duplicate among three", url: "https://example.com/$foo/$bar/$foo", wantErr: ErrDuplicateVariable},
 		{name: "duplicate in query", url: "https://example.com/?a=$x&b=$x", wantErr: ErrDuplicateVariable},
+
+		// Rest-capture placeholders
+		// Governing: SPEC-0009 REQ "Rest-Capture Placeholder"
+		{name: "trailing rest capture", url: "https://github.com/$org/$repo/$rest*", wantErr: nil},
+		{name: "rest capture only", url: "https://example.com/$path*", wantErr: nil},
+		{name: "rest capture not last", url: "https://example.com/$rest*/$id", wantErr: ErrRestVariableNotLast},
+		{name: "rest duplicates plain name", url: "https://example.com/$rest/$rest*", wantErr: ErrDuplicateVariable},
+
+		// Query-string placeholders
+		// Governing: SPEC-0009 REQ "Query-String Placeholder"
+		{name: "query placeholder", url: "https://example.com/search?q=$q:term", wantErr: nil},
+		{name: "query placeholder repeated", url: "https://example.com/?a=$q:term&b=$q:term", wantErr: nil},
+		{name: "query placeholder after rest", url: "https://example.com/$rest*?ref=$q:ref", wantErr: nil},
+		{name: "query and path variable q", url: "https://example.com/$q?x=$q:x", wantErr: nil},
+		{name: "query placeholder without name", url: "https://example.com/?q=$q:", wantErr: ErrInvalidQueryVariable},
+		{name: "query placeholder bad name", url: "https://example.com/?q=$q:&x", wantErr: ErrInvalidQueryVariable},
+
+		// Variable defaults
+		// Governing: SPEC-0009 REQ "Variable Defaults"
+		{name: "default", url: "https://dash.example.com/$env:prod", wantErr: nil},
+		{name: "required then default", url: "https://example.com/$org/$repo:main", wantErr: nil},
+		{name: "default then required", url: "https://example.com/$env:prod/$id", wantErr: ErrRequiredVariableAfterDefault},
+		{name: "default then rest", url: "https://example.com/$env:prod/$path*", wantErr: ErrRequiredVariableAfterDefault},
+		{name: "default duplicates plain name", url: "https://example.com/$env/$env:prod", wantErr: ErrDuplicateVariable},
 	}
 
 	for _, tt := range tests {
@@ -115,3 +140,89 @@ func TestValidateURLVariables(t *testing.T) {
 		})
 	}
 }
+
+// Governing: SPEC-0009 REQ "Variable Constraints"
+func TestValidateVariableConstraints(t *testing.T) {
+	tests := []struct {
+		name        string
+		url         string
+		constraints map[string]string
+		wantErr     error
+	}{
+		{name: "none", url: "https://example.com/$id", constraints: nil, wantErr: nil},
+		{name: "valid", url: "https://example.com/$id", constraints: map[string]string{"id": "[0-9]+"}, wantErr: nil},
+		{name: "rest capture", url: "https://example.com/$path*", constraints: map[string]string{"path": "[a-z/]+"}, wantErr: nil},
+		{name: "unknown variable", url: "https://example.com/$id", constraints: map[string]string{"ticket": "[0-9]+"}, wantErr: ErrUnknownConstraintVariable},
+		{name: "query variable", url: "https://example.com/?q=$q:term", constraints: map[string]string{"q:term": ".+"}, wantErr: ErrUnknownConstraintVariable},
+		{name: "bad regex", url: "https://example.com/$id", constraints: map[string]string{"id": "[0-9"}, wantErr: ErrInvalidConstraint},
+		{name: "empty pattern", url: "https://example.com/$id", constraints: map[string]string{"id": ""}, wantErr: ErrInvalidConstraint},
+		{name: "default matches", url: "https://example.com/$env:prod", constraints: map[string]string{"env": "prod|staging"}, wantErr: nil},
+		{name: "default mismatch", url: "https://example.com/$env:dev", constraints: map[string]string{"env": "prod|staging"}, wantErr: ErrInvalidConstraint},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateVariableConstraints(tt.url, tt.constraints)
+			if tt.wantErr == nil && err != nil {
+				t.Errorf("got %v, want nil", err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("got %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseVariableConstraints_RoundTrip(t *testing.T) {
+	c, err := ParseVariableConstraints("$ticket = [A-Z]+-[0-9]+\n\nid=[0-9]+\n")
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if c["ticket"] != "[A-Z]+-[0-9]+" || c["id"] != "[0-9]+" || len(c) != 2 {
+		t.Fatalf("unexpected constraints: %v", c)
+	}
+	if got := FormatVariableConstraints(c); got != "id=[0-9]+\nticket=[A-Z]+-[0-9]+" {
+		t.Errorf("format = %q", got)
+	}
+	if _, err := ParseVariableConstraints("no equals sign"); !errors.Is(err, ErrInvalidConstraint) {
+		t.Errorf("expected ErrInvalidConstraint, got %v", err)
+	}
+}
+
+func TestCompileConstraint_Anchored(t *testing.T) {
+	re, err := CompileConstraint("[0-9]+|abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for value, want := range map[string]bool{"123": true, "abc": true, "12x": false, "xabc": false} {
+		if got := re.MatchString(value); got != want {
+			t.Errorf("MatchString(%q) = %v, want %v", value, got, want)
+		}
+	}
+}
+
+// Governing: SPEC-0002 REQ "Redirect Type"
+func TestValidateRedirectType(t *testing.T) {
+	for code, valid := range map[int]bool{301: true, 302: true, 307: true, 308: true, 0: false, 200: false, 303: false} {
+		if err := ValidateRedirectType(code); (err == nil) != valid {
+			t.Errorf("ValidateRedirectType(%d) = %v", code, err)
+		}
+	}
+}
+
+func TestParseUTMParams(t *testing.T) {
+	got, err := ParseUTMParams("utm_source = golinks\n\nutm_campaign=q3 launch\n")
+	if err != nil {
+		t.Fatalf("ParseUTMParams: %v", err)
+	}
+	if got["utm_source"] != "golinks" || got["utm_campaign"] != "q3 launch" || len(got) != 2 {
+		t.Errorf("ParseUTMParams = %v", got)
+	}
+	if FormatUTMParams(got) != "utm_campaign=q3 launch\nutm_source=golinks" {
+		t.Errorf("FormatUTMParams = %q", FormatUTMParams(got))
+	}
+	for _, text := range []string{"utm_source", "ref=golinks", "utm_medium="} {
+		if _, err := ParseUTMParams(text); !errors.Is(err, ErrInvalidUTMParam) {
+			t.Errorf("ParseUTMParams(%q) err = %v, want ErrInvalidUTMParam", text, err)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+// Governing: SPEC-0001 REQ "WebAuthn Step-Up"
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/joestump/joe-links/internal/ids"
+)
+
+// WebAuthnCredential is a passkey registered by a user.
+type WebAuthnCredential struct {
+	ID           string       `db:"id"`
+	UserID       string       `db:"user_id"`
+	CredentialID string       `db:"credential_id"` // base64url, as the browser reports it
+	PublicKey    string       `db:"public_key"`    // base64url DER SubjectPublicKeyInfo
+	Alg          int64        `db:"alg"`           // COSE algorithm identifier
+	SignCount    int64        `db:"sign_count"`
+	Name         string       `db:"name"`
+	CreatedAt    time.Time    `db:"created_at"`
+	LastUsedAt   sql.NullTime `db:"last_used_at"`
+}
+
+// WebAuthnStore manages users' passkeys.
+type WebAuthnStore struct {
+	db *sqlx.DB
+}
+
+// NewWebAuthnStore creates a new WebAuthnStore.
+func NewWebAuthnStore(db *sqlx.DB) *WebAuthnStore {
+	return &WebAuthnStore{db: db}
+}
+
+// q rebinds ? placeholders to the driver's native format.
+func (s *WebAuthnStore) q(query string) string { return s.db.Rebind(query) }
+
+// Create records a newly registered passkey.
+func (s *WebAuthnStore) Create(ctx context.Context, c *WebAuthnCredential) error {
+	c.ID = ids.New()
+	c.CreatedAt = time.Now().UTC()
+	_, err := s.db.ExecContext(ctx, s.q(`
+		INSERT INTO webauthn_credentials (id, user_id, credential_id, public_key, alg, sign_count, name, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`), c.ID, c.UserID, c.CredentialID, c.PublicKey, c.Alg, c.SignCount, c.Name, c.CreatedAt)
+	return err
+}
+
+// ListByUser returns userID's passkeys, oldest first.
+func (s *WebAuthnStore) ListByUser(ctx context.Context, userID string) ([]*WebAuthnCredential, error) {
+	var out []*WebAuthnCredential
+	err := s.db.SelectContext(ctx, &out, s.q(`
+		SELECT * FROM webauthn_credentials WHERE user_id = ? ORDER BY created_at ASC
+	`), userID)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CountByUser returns how many passkeys userID has registered.
+func (s *WebAuthnStore) CountByUser(ctx context.Context, userID string) (int, error) {
+	var n int
+	err := s.db.GetContext(ctx, &n, s.q(`SELECT COUNT(*) FROM webauthn_credentials WHERE user_id = ?`), userID)
+	return n, err
+}
+
+// GetByCredentialID returns userID's passkey with the given credential ID,
+// or ErrNotFound.
+func (s *WebAuthnStore) GetByCredentialID(ctx context.Context, userID, credentialID string) (*WebAuthnCredential, error) {
+	var c WebAuthnCredential
+	err := s.db.GetContext(ctx, &c, s.q(`
+		SELECT * FROM webauthn_credentials WHERE user_id = ? AND credential_id = ?
+	`), userID, credentialID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// MarkUsed records a successful assertion and the authenticator's new
+// signature counter.
+func (s *WebAuthnStore) MarkUsed(ctx context.Context, id string, signCount int64) error {
+	_, err := s.db.ExecContext(ctx, s.q(`
+		UPDATE webauthn_credentials SET sign_count = ?, last_used_at = ? WHERE id = ?
+	`), signCount, time.Now().UTC(), id)
+	return err
+}
+
+// Delete removes userID's passkey with the given ID, or returns ErrNotFound.
+func (s *WebAuthnStore) Delete(ctx context.Context, userID, id string) error {
+	res, err := s.db.ExecContext(ctx, s.q(`DELETE FROM webauthn_credentials WHERE id = ? AND user_id = ?`), id, userID)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+// Package telemetry builds the instance statistics report shown on the admin
+// telemetry page and, only when an operator opts in, sends it upstream.
+// Governing: SPEC-0011 REQ "Instance Telemetry"
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"runtime"
+	"time"
+
+	"github.com/joestump/joe-links/internal/build"
+	"github.com/joestump/joe-links/internal/metrics"
+	"github.com/joestump/joe-links/internal/store"
+)
+
+// Interval is how often a Reporter sends the report.
+const Interval = 7 * 24 * time.Hour
+
+// Report is the payload the admin page previews and a Reporter sends. It is
+// made of aggregate counts only; see store.InstanceStats.
+type Report struct {
+	Version     string               `json:"version"`
+	GoVersion   string               `json:"go_version"`
+	Database    string               `json:"database"`
+	GeneratedAt time.Time            `json:"generated_at"`
+	Stats       *store.InstanceStats `json:"stats"`
+}
+
+// Build collects the instance's stats and wraps them in a Report. driver is
+// the database driver name (sqlite3, mysql, postgres).
+func Build(ctx context.Context, ts *store.TelemetryStore, driver string, now time.Time) (*Report, error) {
+	stats, err := ts.Collect(ctx, now)
+	if err != nil {
+		return nil, err
+	}
+	return &Report{
+		Version:     build.Version,
+		GoVersion:   runtime.Version(),
+		Database:    driver,
+		GeneratedAt: now.UTC().Truncate(time.Second),
+		Stats:       stats,
+	}, nil
+}
+
+// Reporter sends the Report to an upstream endpoint every Interval.
+type Reporter struct {
+	stats    *store.TelemetryStore
+	driver   string
+	endpoint string
+	client   *http.Client
+}
+
+// NewReporter creates a Reporter that posts reports to endpoint.
+func NewReporter(ts *store.TelemetryStore, driver, endpoint string) *Reporter {
+	return &Reporter{
+		stats:    ts,
+		driver:   driver,
+		endpoint: endpoint,
+		client:   &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// Run sends a report immediately and then every Interval until ctx is
+// cancelled.
+func (r *Reporter) Run(ctx context.Context) {
+	ticker := time.NewTicker(Interval)
+	defer ticker.Stop()
+	for {
+		if err := r.Send(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("telemetry: %v", err)
+		} else if err == nil {
+			metrics.MarkJobSuccess(metrics.JobTelemetry)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Send builds a report and posts it as JSON. Any 2xx response is success.
+func (r *Reporter) Send(ctx context.Context) error {
+	report, err := Build(ctx, r.stats, r.driver, time.Now())
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(report)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "joe-links-telemetry/"+build.Version)
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("upstream returned %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package telemetry
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/joestump/joe-links/internal/store"
+	"github.com/joestump/joe-links/internal/testutil"
+)
+
+func TestReporterSend(t *testing.T) {
+	var got Report
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("request = %s %s, want POST application/json", r.Method, r.Header.Get("Content-Type"))
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("decode: %v", err)
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer srv.Close()
+
+	ts := store.NewTelemetryStore(testutil.NewTestDB(t))
+	if err := NewReporter(ts, "sqlite3", srv.URL).Send(context.Background()); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if got.Database != "sqlite3" || got.Stats == nil || len(got.Stats.RedirectsByDay) != store.TelemetryDays {
+		t.Errorf("report = %+v, want sqlite3 stats", got)
+	}
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer failing.Close()
+	if err := NewReporter(ts, "sqlite3", failing.URL).Send(context.Background()); err == nil {
+		t.Error("Send to a failing endpoint returned nil")
+	}
+}
@@ -12,7 +12,7 @@ import (
 )
 
 // NewTestDB opens an in-memory SQLite DB and runs all goose migrations.
-func NewTestDB(t *testing.T) *sqlx.DB {
+func NewTestDB(t testing.TB) *sqlx.DB {
 	t.Helper()
 
 	// Use a file URI with shared cache so all pool connections share the
@@ -0,0 +1,99 @@
+// Governing: SPEC-0001 REQ "WebAuthn Step-Up"
+// Passkey registration and confirmation. The server speaks base64url for
+// every binary field; these helpers convert to and from ArrayBuffers.
+(function () {
+  function toBuf(s) {
+    s = s.replace(/-/g, "+").replace(/_/g, "/");
+    var bin = atob(s + "===".slice((s.length + 3) % 4));
+    var out = new Uint8Array(bin.length);
+    for (var i = 0; i < bin.length; i++) out[i] = bin.charCodeAt(i);
+    return out.buffer;
+  }
+  function toB64(buf) {
+    var bytes = new Uint8Array(buf), bin = "";
+    for (var i = 0; i < bytes.length; i++) bin += String.fromCharCode(bytes[i]);
+    return btoa(bin).replace(/\+/g, "-").replace(/\//g, "_").replace(/=+$/, "");
+  }
+  function post(url, body) {
+    return fetch(url, {
+      method: "POST",
+      credentials: "same-origin",
+      headers: {
+        "Content-Type": "application/json",
+        "X-CSRF-Token": (document.querySelector('meta[name="csrf-token"]') || {}).content || "",
+      },
+      body: body ? JSON.stringify(body) : null,
+    }).then(function (res) {
+      if (res.redirected) {
+        location.href = res.url;
+        return new Promise(function () {});
+      }
+      return res.json().then(function (data) {
+        if (!res.ok) throw new Error(data.error || res.statusText);
+        return data;
+      });
+    });
+  }
+  function descriptors(list) {
+    return (list || []).map(function (c) { return { type: c.type, id: toBuf(c.id) }; });
+  }
+  function report(el, err) {
+    if (el) {
+      el.textContent = err.message || String(err);
+      el.hidden = false;
+    }
+  }
+
+  window.passkeyRegister = function (form) {
+    var error = form.querySelector("[data-passkey-error]");
+    post("/dashboard/settings/passkeys/register/begin").then(function (data) {
+      var pk = data.publicKey;
+      pk.challenge = toBuf(pk.challenge);
+      pk.user.id = toBuf(pk.user.id);
+      pk.excludeCredentials = descriptors(pk.excludeCredentials);
+      return navigator.credentials.create({ publicKey: pk });
+    }).then(function (cred) {
+      var r = cred.response;
+      if (!r.getPublicKey || !r.getAuthenticatorData) {
+        throw new Error("This browser cannot register passkeys here.");
+      }
+      return post("/dashboard/settings/passkeys/register/finish", {
+        name: form.elements.name.value,
+        credential: {
+          id: cred.id,
+          response: {
+            clientDataJSON: toB64(r.clientDataJSON),
+            authenticatorData: toB64(r.getAuthenticatorData()),
+            publicKey: toB64(r.getPublicKey()),
+            publicKeyAlgorithm: r.getPublicKeyAlgorithm(),
+          },
+        },
+      });
+    }).then(function () {
+      location.reload();
+    }).catch(function (err) { report(error, err); });
+    return false;
+  };
+
+  window.passkeyConfirm = function (button) {
+    var error = document.querySelector("[data-passkey-error]");
+    post("/dashboard/step-up/begin").then(function (data) {
+      var pk = data.publicKey;
+      pk.challenge = toBuf(pk.challenge);
+      pk.allowCredentials = descriptors(pk.allowCredentials);
+      return navigator.credentials.get({ publicKey: pk });
+    }).then(function (cred) {
+      var r = cred.response;
+      return post("/dashboard/step-up/finish", {
+        id: cred.id,
+        response: {
+          clientDataJSON: toB64(r.clientDataJSON),
+          authenticatorData: toB64(r.authenticatorData),
+          signature: toB64(r.signature),
+        },
+      });
+    }).then(function () {
+      location.href = button.dataset.redirect;
+    }).catch(function (err) { report(error, err); });
+  };
+})();
@@ -0,0 +1,99 @@
+// Service worker for the installable dashboard.
+// Governing: SPEC-0004 REQ "Progressive Web App"
+//
+// Registered with scope /dashboard, so go-link redirects never wake it.
+// Static assets are served from cache and refreshed in the background; the
+// dashboard page is fetched from the network and falls back to its last
+// copy, with the recent link list, when the network is slow or gone.
+"use strict";
+
+var VERSION = "v1";
+var STATIC_CACHE = "joe-links-static-" + VERSION;
+var PAGE_CACHE = "joe-links-pages-" + VERSION;
+var SHELL = ["/static/css/app.css", "/static/js/htmx.min.js", "/static/icons/icon.svg"];
+var NETWORK_TIMEOUT_MS = 3000;
+
+self.addEventListener("install", function (event) {
+  event.waitUntil(
+    caches.open(STATIC_CACHE).then(function (cache) { return cache.addAll(SHELL); })
+      .then(function () { return self.skipWaiting(); })
+  );
+});
+
+self.addEventListener("activate", function (event) {
+  event.waitUntil(
+    caches.keys().then(function (names) {
+      return Promise.all(names.filter(function (name) {
+        return name.indexOf("joe-links-") === 0 && name !== STATIC_CACHE && name !== PAGE_CACHE;
+      }).map(function (name) { return caches.delete(name); }));
+    }).then(function () { return self.clients.claim(); })
+  );
+});
+
+self.addEventListener("fetch", function (event) {
+  var req = event.request;
+  var url = new URL(req.url);
+  if (url.origin !== self.location.origin) {
+    return;
+  }
+  // Signing out must not leave the last user's links on the device. Sign-outs
+  // from pages outside the scope rely on the Clear-Site-Data response header.
+  if (req.method === "POST" && url.pathname === "/auth/logout") {
+    event.waitUntil(caches.delete(PAGE_CACHE));
+    return;
+  }
+  if (req.method !== "GET") {
+    return;
+  }
+  if (url.pathname.indexOf("/static/") === 0) {
+    event.respondWith(staleWhileRevalidate(event, req));
+    return;
+  }
+  // Only full page loads of the dashboard itself; HTMX partials vary by
+  // request header and are always fetched.
+  if (req.mode === "navigate" && url.pathname === "/dashboard" && url.search === "") {
+    event.respondWith(networkFirst(req));
+  }
+});
+
+// cacheable reports whether res is a complete same-origin page or asset,
+// not an error or the login redirect of an expired session.
+function cacheable(res) {
+  return res && res.ok && !res.redirected && res.type === "basic";
+}
+
+function staleWhileRevalidate(event, req) {
+  return caches.open(STATIC_CACHE).then(function (cache) {
+    return cache.match(req).then(function (cached) {
+      var fresh = fetch(req).then(function (res) {
+        if (cacheable(res)) {
+          return cache.put(req, res.clone()).then(function () { return res; });
+        }
+        return res;
+      });
+      if (cached) {
+        event.waitUntil(fresh.catch(function () {}));
+        return cached;
+      }
+      return fresh;
+    });
+  });
+}
+
+function networkFirst(req) {
+  return caches.open(PAGE_CACHE).then(function (cache) {
+    var fresh = fetch(req).then(function (res) {
+      if (cacheable(res)) {
+        cache.put(req, res.clone());
+      }
+      return res;
+    });
+    var timeout = new Promise(function (resolve) {
+      setTimeout(resolve, NETWORK_TIMEOUT_MS);
+    });
+    var fallback = function () {
+      return cache.match(req).then(function (cached) { return cached || fresh; });
+    };
+    return Promise.race([fresh, timeout.then(fallback)]).catch(fallback);
+  });
+}